@@ -24,6 +24,116 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Defines how query values are matched against label paths.
+type RecordQueryMatchMode int32
+
+const (
+	// Matches the queried label and its descendants, e.g. "AI" matches
+	// "/skills/AI" and "/skills/AI/ML". Every matching query scores one.
+	RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_UNSPECIFIED RecordQueryMatchMode = 0
+	// Matches the queried label, its descendants and its ancestors, e.g. "AI/ML"
+	// also matches "/skills/AI". Exact matches score two, descendant and ancestor
+	// matches score one, so that records with the queried label rank first.
+	// Locator queries only match exactly.
+	RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL RecordQueryMatchMode = 1
+)
+
+// Enum value maps for RecordQueryMatchMode.
+var (
+	RecordQueryMatchMode_name = map[int32]string{
+		0: "RECORD_QUERY_MATCH_MODE_UNSPECIFIED",
+		1: "RECORD_QUERY_MATCH_MODE_HIERARCHICAL",
+	}
+	RecordQueryMatchMode_value = map[string]int32{
+		"RECORD_QUERY_MATCH_MODE_UNSPECIFIED":  0,
+		"RECORD_QUERY_MATCH_MODE_HIERARCHICAL": 1,
+	}
+)
+
+func (x RecordQueryMatchMode) Enum() *RecordQueryMatchMode {
+	p := new(RecordQueryMatchMode)
+	*p = x
+	return p
+}
+
+func (x RecordQueryMatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordQueryMatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_record_query_proto_enumTypes[0].Descriptor()
+}
+
+func (RecordQueryMatchMode) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_record_query_proto_enumTypes[0]
+}
+
+func (x RecordQueryMatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordQueryMatchMode.Descriptor instead.
+func (RecordQueryMatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_record_query_proto_rawDescGZIP(), []int{0}
+}
+
+// Defines a list of supported boolean query operators.
+type RecordQueryOperator int32
+
+const (
+	// Unspecified operator, rejected.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED RecordQueryOperator = 0
+	// Matches if all queries match.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_AND RecordQueryOperator = 1
+	// Matches if at least one query matches.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_OR RecordQueryOperator = 2
+	// Matches if none of the queries match.
+	RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT RecordQueryOperator = 3
+)
+
+// Enum value maps for RecordQueryOperator.
+var (
+	RecordQueryOperator_name = map[int32]string{
+		0: "RECORD_QUERY_OPERATOR_UNSPECIFIED",
+		1: "RECORD_QUERY_OPERATOR_AND",
+		2: "RECORD_QUERY_OPERATOR_OR",
+		3: "RECORD_QUERY_OPERATOR_NOT",
+	}
+	RecordQueryOperator_value = map[string]int32{
+		"RECORD_QUERY_OPERATOR_UNSPECIFIED": 0,
+		"RECORD_QUERY_OPERATOR_AND":         1,
+		"RECORD_QUERY_OPERATOR_OR":          2,
+		"RECORD_QUERY_OPERATOR_NOT":         3,
+	}
+)
+
+func (x RecordQueryOperator) Enum() *RecordQueryOperator {
+	p := new(RecordQueryOperator)
+	*p = x
+	return p
+}
+
+func (x RecordQueryOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RecordQueryOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_record_query_proto_enumTypes[1].Descriptor()
+}
+
+func (RecordQueryOperator) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_record_query_proto_enumTypes[1]
+}
+
+func (x RecordQueryOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RecordQueryOperator.Descriptor instead.
+func (RecordQueryOperator) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_record_query_proto_rawDescGZIP(), []int{1}
+}
+
 // Defines a list of supported record query types.
 type RecordQueryType int32
 
@@ -38,6 +148,9 @@ const (
 	RecordQueryType_RECORD_QUERY_TYPE_DOMAIN RecordQueryType = 3
 	// Query for a module name.
 	RecordQueryType_RECORD_QUERY_TYPE_MODULE RecordQueryType = 4
+	// Query for a label of any namespace, including custom namespaces.
+	// The value is the label without the leading slash, e.g. "teams/platform".
+	RecordQueryType_RECORD_QUERY_TYPE_LABEL RecordQueryType = 5
 )
 
 // Enum value maps for RecordQueryType.
@@ -48,6 +161,7 @@ var (
 		2: "RECORD_QUERY_TYPE_LOCATOR",
 		3: "RECORD_QUERY_TYPE_DOMAIN",
 		4: "RECORD_QUERY_TYPE_MODULE",
+		5: "RECORD_QUERY_TYPE_LABEL",
 	}
 	RecordQueryType_value = map[string]int32{
 		"RECORD_QUERY_TYPE_UNSPECIFIED": 0,
@@ -55,6 +169,7 @@ var (
 		"RECORD_QUERY_TYPE_LOCATOR":     2,
 		"RECORD_QUERY_TYPE_DOMAIN":      3,
 		"RECORD_QUERY_TYPE_MODULE":      4,
+		"RECORD_QUERY_TYPE_LABEL":       5,
 	}
 )
 
@@ -69,11 +184,11 @@ func (x RecordQueryType) String() string {
 }
 
 func (RecordQueryType) Descriptor() protoreflect.EnumDescriptor {
-	return file_agntcy_dir_routing_v1_record_query_proto_enumTypes[0].Descriptor()
+	return file_agntcy_dir_routing_v1_record_query_proto_enumTypes[2].Descriptor()
 }
 
 func (RecordQueryType) Type() protoreflect.EnumType {
-	return &file_agntcy_dir_routing_v1_record_query_proto_enumTypes[0]
+	return &file_agntcy_dir_routing_v1_record_query_proto_enumTypes[2]
 }
 
 func (x RecordQueryType) Number() protoreflect.EnumNumber {
@@ -82,7 +197,7 @@ func (x RecordQueryType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RecordQueryType.Descriptor instead.
 func (RecordQueryType) EnumDescriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_record_query_proto_rawDescGZIP(), []int{0}
+	return file_agntcy_dir_routing_v1_record_query_proto_rawDescGZIP(), []int{2}
 }
 
 // A query to match the record against during discovery.
@@ -92,12 +207,38 @@ func (RecordQueryType) EnumDescriptor() ([]byte, []int) {
 //	{ type: RECORD_QUERY_TYPE_LOCATOR, value: "helm-chart" }
 //	{ type: RECORD_QUERY_TYPE_DOMAIN, value: "research" }
 //	{ type: RECORD_QUERY_TYPE_MODULE, value: "runtime/language" }
+//	{ type: RECORD_QUERY_TYPE_LABEL, value: "teams/platform" }
+//
+// Queries can be combined into boolean expressions with a group, e.g.
+// "skill=AI AND domain=research AND NOT module=legacy":
+//
+//	{ group: { operator: RECORD_QUERY_OPERATOR_AND, queries: [
+//	  { type: RECORD_QUERY_TYPE_SKILL, value: "AI" },
+//	  { type: RECORD_QUERY_TYPE_DOMAIN, value: "research" },
+//	  { group: { operator: RECORD_QUERY_OPERATOR_NOT, queries: [
+//	    { type: RECORD_QUERY_TYPE_MODULE, value: "legacy" }
+//	  ] } }
+//	] } }
+//
+// Hierarchical queries also match the ancestors of the queried label and
+// score exact matches higher than partial ones:
+//
+//	{ type: RECORD_QUERY_TYPE_SKILL, value: "AI/ML", match_mode: RECORD_QUERY_MATCH_MODE_HIERARCHICAL }
 type RecordQuery struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The type of the query to match against.
+	// Ignored if group is set.
 	Type RecordQueryType `protobuf:"varint,1,opt,name=type,proto3,enum=agntcy.dir.routing.v1.RecordQueryType" json:"type,omitempty"`
 	// The query value to match against.
-	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Ignored if group is set.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Boolean combination of sub-queries.
+	// If set, the query matches according to the group operator
+	// and counts as a single query towards the match score.
+	Group *RecordQueryGroup `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	// How the value is matched against the label paths of records.
+	// Ignored if group is set.
+	MatchMode     RecordQueryMatchMode `protobuf:"varint,4,opt,name=match_mode,json=matchMode,proto3,enum=agntcy.dir.routing.v1.RecordQueryMatchMode" json:"match_mode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -146,6 +287,76 @@ func (x *RecordQuery) GetValue() string {
 	return ""
 }
 
+func (x *RecordQuery) GetGroup() *RecordQueryGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *RecordQuery) GetMatchMode() RecordQueryMatchMode {
+	if x != nil {
+		return x.MatchMode
+	}
+	return RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_UNSPECIFIED
+}
+
+// A boolean combination of queries.
+type RecordQueryGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The operator applied to the queries.
+	Operator RecordQueryOperator `protobuf:"varint,1,opt,name=operator,proto3,enum=agntcy.dir.routing.v1.RecordQueryOperator" json:"operator,omitempty"`
+	// The queries to combine. Must not be empty.
+	// Groups can be nested up to 8 levels deep.
+	Queries       []*RecordQuery `protobuf:"bytes,2,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordQueryGroup) Reset() {
+	*x = RecordQueryGroup{}
+	mi := &file_agntcy_dir_routing_v1_record_query_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordQueryGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordQueryGroup) ProtoMessage() {}
+
+func (x *RecordQueryGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_record_query_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordQueryGroup.ProtoReflect.Descriptor instead.
+func (*RecordQueryGroup) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_record_query_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RecordQueryGroup) GetOperator() RecordQueryOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED
+}
+
+func (x *RecordQueryGroup) GetQueries() []*RecordQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
 var File_agntcy_dir_routing_v1_record_query_proto protoreflect.FileDescriptor
 
 var file_agntcy_dir_routing_v1_record_query_proto_rawDesc = string([]byte{
@@ -153,37 +364,73 @@ var file_agntcy_dir_routing_v1_record_query_proto_rawDesc = string([]byte{
 	0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x71,
 	0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x61, 0x67, 0x6e, 0x74,
 	0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76,
-	0x31, 0x22, 0x5f, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x12, 0x3a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26,
-	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74,
-	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x2a, 0xac, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
-	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x43,
-	0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53,
-	0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
-	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41,
-	0x54, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f,
-	0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x4d, 0x41, 0x49,
-	0x4e, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
-	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10,
-	0x04, 0x42, 0xca, 0x01, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
-	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x42,
-	0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72,
-	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x52, 0xaa,
-	0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x75,
-	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79,
-	0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0xe2,
-	0x02, 0x21, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75,
-	0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0xea, 0x02, 0x18, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69,
-	0x72, 0x3a, 0x3a, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x31, 0x22, 0xea, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x3a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x26, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x4a, 0x0a, 0x0a, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x09, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x98,
+	0x01, 0x0a, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x46, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x3c, 0x0a, 0x07, 0x71,
+	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x2a, 0x69, 0x0a, 0x14, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x27, 0x0a, 0x23, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52,
+	0x59, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x28, 0x0a, 0x24, 0x52, 0x45,
+	0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x4d, 0x41, 0x54, 0x43, 0x48,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x48, 0x49, 0x45, 0x52, 0x41, 0x52, 0x43, 0x48, 0x49, 0x43,
+	0x41, 0x4c, 0x10, 0x01, 0x2a, 0x98, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x25, 0x0a, 0x21,
+	0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x45,
+	0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
+	0x45, 0x52, 0x59, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x41, 0x4e, 0x44,
+	0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45,
+	0x52, 0x59, 0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x4f, 0x52, 0x10, 0x02,
+	0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59,
+	0x5f, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x10, 0x03, 0x2a,
+	0xc9, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x1d, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
+	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44,
+	0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4b, 0x49, 0x4c,
+	0x4c, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55,
+	0x45, 0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x4f, 0x52,
+	0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45,
+	0x52, 0x59, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x4f, 0x4d, 0x41, 0x49, 0x4e, 0x10, 0x03,
+	0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10, 0x04, 0x12, 0x1b,
+	0x0a, 0x17, 0x52, 0x45, 0x43, 0x4f, 0x52, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x05, 0x42, 0xca, 0x01, 0x0a, 0x19,
+	0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x52, 0xaa, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74,
+	0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x56,
+	0x31, 0xca, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x21, 0x41, 0x67, 0x6e, 0x74,
+	0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56,
+	0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x18,
+	0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x52, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -198,19 +445,26 @@ func file_agntcy_dir_routing_v1_record_query_proto_rawDescGZIP() []byte {
 	return file_agntcy_dir_routing_v1_record_query_proto_rawDescData
 }
 
-var file_agntcy_dir_routing_v1_record_query_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_agntcy_dir_routing_v1_record_query_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_agntcy_dir_routing_v1_record_query_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_agntcy_dir_routing_v1_record_query_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_agntcy_dir_routing_v1_record_query_proto_goTypes = []any{
-	(RecordQueryType)(0), // 0: agntcy.dir.routing.v1.RecordQueryType
-	(*RecordQuery)(nil),  // 1: agntcy.dir.routing.v1.RecordQuery
+	(RecordQueryMatchMode)(0), // 0: agntcy.dir.routing.v1.RecordQueryMatchMode
+	(RecordQueryOperator)(0),  // 1: agntcy.dir.routing.v1.RecordQueryOperator
+	(RecordQueryType)(0),      // 2: agntcy.dir.routing.v1.RecordQueryType
+	(*RecordQuery)(nil),       // 3: agntcy.dir.routing.v1.RecordQuery
+	(*RecordQueryGroup)(nil),  // 4: agntcy.dir.routing.v1.RecordQueryGroup
 }
 var file_agntcy_dir_routing_v1_record_query_proto_depIdxs = []int32{
-	0, // 0: agntcy.dir.routing.v1.RecordQuery.type:type_name -> agntcy.dir.routing.v1.RecordQueryType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: agntcy.dir.routing.v1.RecordQuery.type:type_name -> agntcy.dir.routing.v1.RecordQueryType
+	4, // 1: agntcy.dir.routing.v1.RecordQuery.group:type_name -> agntcy.dir.routing.v1.RecordQueryGroup
+	0, // 2: agntcy.dir.routing.v1.RecordQuery.match_mode:type_name -> agntcy.dir.routing.v1.RecordQueryMatchMode
+	1, // 3: agntcy.dir.routing.v1.RecordQueryGroup.operator:type_name -> agntcy.dir.routing.v1.RecordQueryOperator
+	3, // 4: agntcy.dir.routing.v1.RecordQueryGroup.queries:type_name -> agntcy.dir.routing.v1.RecordQuery
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_agntcy_dir_routing_v1_record_query_proto_init() }
@@ -223,8 +477,8 @@ func file_agntcy_dir_routing_v1_record_query_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_routing_v1_record_query_proto_rawDesc), len(file_agntcy_dir_routing_v1_record_query_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   1,
+			NumEnums:      3,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,2837 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        (unknown)
+// source: agntcy/dir/routing/v1/routing_admin_service.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetRoutingTableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoutingTableRequest) Reset() {
+	*x = GetRoutingTableRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoutingTableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingTableRequest) ProtoMessage() {}
+
+func (x *GetRoutingTableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingTableRequest.ProtoReflect.Descriptor instead.
+func (*GetRoutingTableRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{0}
+}
+
+type GetRoutingTableResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the DHT serves records to other peers (server mode) or only queries them (client mode).
+	ServerMode bool `protobuf:"varint,1,opt,name=server_mode,json=serverMode,proto3" json:"server_mode,omitempty"`
+	// Peers of the routing table, ordered by bucket.
+	Peers         []*RoutingTablePeer `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoutingTableResponse) Reset() {
+	*x = GetRoutingTableResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoutingTableResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingTableResponse) ProtoMessage() {}
+
+func (x *GetRoutingTableResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingTableResponse.ProtoReflect.Descriptor instead.
+func (*GetRoutingTableResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRoutingTableResponse) GetServerMode() bool {
+	if x != nil {
+		return x.ServerMode
+	}
+	return false
+}
+
+func (x *GetRoutingTableResponse) GetPeers() []*RoutingTablePeer {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// RoutingTablePeer is a peer of the DHT routing table.
+type RoutingTablePeer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Bucket of the peer, the length of the common prefix of its DHT ID with this peer's.
+	Bucket uint32 `protobuf:"varint,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// Known multiaddrs of the peer.
+	Addrs []string `protobuf:"bytes,3,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	// Whether the peer is currently connected.
+	Connected bool `protobuf:"varint,4,opt,name=connected,proto3" json:"connected,omitempty"`
+	// When the peer was added to the routing table.
+	AddedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	// When the peer last answered a query usefully, unset if it never did.
+	LastUsefulAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_useful_at,json=lastUsefulAt,proto3" json:"last_useful_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoutingTablePeer) Reset() {
+	*x = RoutingTablePeer{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutingTablePeer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutingTablePeer) ProtoMessage() {}
+
+func (x *RoutingTablePeer) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutingTablePeer.ProtoReflect.Descriptor instead.
+func (*RoutingTablePeer) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RoutingTablePeer) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *RoutingTablePeer) GetBucket() uint32 {
+	if x != nil {
+		return x.Bucket
+	}
+	return 0
+}
+
+func (x *RoutingTablePeer) GetAddrs() []string {
+	if x != nil {
+		return x.Addrs
+	}
+	return nil
+}
+
+func (x *RoutingTablePeer) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *RoutingTablePeer) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+func (x *RoutingTablePeer) GetLastUsefulAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsefulAt
+	}
+	return nil
+}
+
+type GetGossipSubStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGossipSubStateRequest) Reset() {
+	*x = GetGossipSubStateRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGossipSubStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGossipSubStateRequest) ProtoMessage() {}
+
+func (x *GetGossipSubStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGossipSubStateRequest.ProtoReflect.Descriptor instead.
+func (*GetGossipSubStateRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{3}
+}
+
+type GetGossipSubStateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether GossipSub label announcements are enabled.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Joined topics, ordered by name.
+	Topics        []*GossipSubTopic `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGossipSubStateResponse) Reset() {
+	*x = GetGossipSubStateResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGossipSubStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGossipSubStateResponse) ProtoMessage() {}
+
+func (x *GetGossipSubStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGossipSubStateResponse.ProtoReflect.Descriptor instead.
+func (*GetGossipSubStateResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetGossipSubStateResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetGossipSubStateResponse) GetTopics() []*GossipSubTopic {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+// GossipSubTopic is the state of a joined GossipSub topic.
+type GossipSubTopic struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the topic.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Whether messages of the topic are received, as selected by the subscription policy.
+	Subscribed bool `protobuf:"varint,2,opt,name=subscribed,proto3" json:"subscribed,omitempty"`
+	// IDs of the peers known to be subscribed to the topic.
+	Peers []string `protobuf:"bytes,3,rep,name=peers,proto3" json:"peers,omitempty"`
+	// IDs of the peers in the mesh of the topic, to which messages are forwarded.
+	MeshPeers     []string `protobuf:"bytes,4,rep,name=mesh_peers,json=meshPeers,proto3" json:"mesh_peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GossipSubTopic) Reset() {
+	*x = GossipSubTopic{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipSubTopic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipSubTopic) ProtoMessage() {}
+
+func (x *GossipSubTopic) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipSubTopic.ProtoReflect.Descriptor instead.
+func (*GossipSubTopic) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GossipSubTopic) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *GossipSubTopic) GetSubscribed() bool {
+	if x != nil {
+		return x.Subscribed
+	}
+	return false
+}
+
+func (x *GossipSubTopic) GetPeers() []string {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *GossipSubTopic) GetMeshPeers() []string {
+	if x != nil {
+		return x.MeshPeers
+	}
+	return nil
+}
+
+type GetGossipSubDiagnosticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGossipSubDiagnosticsRequest) Reset() {
+	*x = GetGossipSubDiagnosticsRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGossipSubDiagnosticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGossipSubDiagnosticsRequest) ProtoMessage() {}
+
+func (x *GetGossipSubDiagnosticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGossipSubDiagnosticsRequest.ProtoReflect.Descriptor instead.
+func (*GetGossipSubDiagnosticsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{6}
+}
+
+type GetGossipSubDiagnosticsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether GossipSub label announcements are enabled.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Message statistics of the joined topics since startup, ordered by name.
+	Topics []*GossipSubTopicDiagnostics `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	// Whether GossipSub peer scoring is enabled. Peer scores are only reported if it is.
+	PeerScoring bool `protobuf:"varint,3,opt,name=peer_scoring,json=peerScoring,proto3" json:"peer_scoring,omitempty"`
+	// GossipSub scores of the connected peers, lowest first.
+	PeerScores []*GossipSubPeerScore `protobuf:"bytes,4,rep,name=peer_scores,json=peerScores,proto3" json:"peer_scores,omitempty"`
+	// Propagation latency of the announcements of this peer, measured from the
+	// acknowledgements of the receiving peers. Unset unless propagation acks are enabled.
+	Propagation   *GossipSubPropagation `protobuf:"bytes,5,opt,name=propagation,proto3" json:"propagation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGossipSubDiagnosticsResponse) Reset() {
+	*x = GetGossipSubDiagnosticsResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGossipSubDiagnosticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGossipSubDiagnosticsResponse) ProtoMessage() {}
+
+func (x *GetGossipSubDiagnosticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGossipSubDiagnosticsResponse.ProtoReflect.Descriptor instead.
+func (*GetGossipSubDiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetGossipSubDiagnosticsResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetGossipSubDiagnosticsResponse) GetTopics() []*GossipSubTopicDiagnostics {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *GetGossipSubDiagnosticsResponse) GetPeerScoring() bool {
+	if x != nil {
+		return x.PeerScoring
+	}
+	return false
+}
+
+func (x *GetGossipSubDiagnosticsResponse) GetPeerScores() []*GossipSubPeerScore {
+	if x != nil {
+		return x.PeerScores
+	}
+	return nil
+}
+
+func (x *GetGossipSubDiagnosticsResponse) GetPropagation() *GossipSubPropagation {
+	if x != nil {
+		return x.Propagation
+	}
+	return nil
+}
+
+// GossipSubTopicDiagnostics are the message statistics of a joined GossipSub topic.
+type GossipSubTopicDiagnostics struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the topic.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Number of peers in the mesh of the topic.
+	MeshDegree uint32 `protobuf:"varint,2,opt,name=mesh_degree,json=meshDegree,proto3" json:"mesh_degree,omitempty"`
+	// Messages received for the first time.
+	DeliveredMessages uint64 `protobuf:"varint,3,opt,name=delivered_messages,json=deliveredMessages,proto3" json:"delivered_messages,omitempty"`
+	// Messages received again from another peer, dropped by GossipSub.
+	DuplicateMessages uint64 `protobuf:"varint,4,opt,name=duplicate_messages,json=duplicateMessages,proto3" json:"duplicate_messages,omitempty"`
+	// Messages rejected by GossipSub or failing the validation of announcements.
+	InvalidMessages uint64 `protobuf:"varint,5,opt,name=invalid_messages,json=invalidMessages,proto3" json:"invalid_messages,omitempty"`
+	// Share of received messages that were duplicates, from 0 to 1.
+	DuplicateRate float64 `protobuf:"fixed64,6,opt,name=duplicate_rate,json=duplicateRate,proto3" json:"duplicate_rate,omitempty"`
+	// Share of received messages that were invalid, from 0 to 1.
+	InvalidRate   float64 `protobuf:"fixed64,7,opt,name=invalid_rate,json=invalidRate,proto3" json:"invalid_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GossipSubTopicDiagnostics) Reset() {
+	*x = GossipSubTopicDiagnostics{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipSubTopicDiagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipSubTopicDiagnostics) ProtoMessage() {}
+
+func (x *GossipSubTopicDiagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipSubTopicDiagnostics.ProtoReflect.Descriptor instead.
+func (*GossipSubTopicDiagnostics) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GossipSubTopicDiagnostics) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *GossipSubTopicDiagnostics) GetMeshDegree() uint32 {
+	if x != nil {
+		return x.MeshDegree
+	}
+	return 0
+}
+
+func (x *GossipSubTopicDiagnostics) GetDeliveredMessages() uint64 {
+	if x != nil {
+		return x.DeliveredMessages
+	}
+	return 0
+}
+
+func (x *GossipSubTopicDiagnostics) GetDuplicateMessages() uint64 {
+	if x != nil {
+		return x.DuplicateMessages
+	}
+	return 0
+}
+
+func (x *GossipSubTopicDiagnostics) GetInvalidMessages() uint64 {
+	if x != nil {
+		return x.InvalidMessages
+	}
+	return 0
+}
+
+func (x *GossipSubTopicDiagnostics) GetDuplicateRate() float64 {
+	if x != nil {
+		return x.DuplicateRate
+	}
+	return 0
+}
+
+func (x *GossipSubTopicDiagnostics) GetInvalidRate() float64 {
+	if x != nil {
+		return x.InvalidRate
+	}
+	return 0
+}
+
+// GossipSubPeerScore is the GossipSub score of a peer.
+type GossipSubPeerScore struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Score of the peer. Peers below the gossip threshold are excluded from gossip.
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Application-specific component of the score, derived from the peer's reputation.
+	AppSpecificScore float64 `protobuf:"fixed64,3,opt,name=app_specific_score,json=appSpecificScore,proto3" json:"app_specific_score,omitempty"`
+	// Penalty of the peer for misbehaving in the GossipSub protocol.
+	BehaviourPenalty float64 `protobuf:"fixed64,4,opt,name=behaviour_penalty,json=behaviourPenalty,proto3" json:"behaviour_penalty,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GossipSubPeerScore) Reset() {
+	*x = GossipSubPeerScore{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipSubPeerScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipSubPeerScore) ProtoMessage() {}
+
+func (x *GossipSubPeerScore) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipSubPeerScore.ProtoReflect.Descriptor instead.
+func (*GossipSubPeerScore) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GossipSubPeerScore) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *GossipSubPeerScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GossipSubPeerScore) GetAppSpecificScore() float64 {
+	if x != nil {
+		return x.AppSpecificScore
+	}
+	return 0
+}
+
+func (x *GossipSubPeerScore) GetBehaviourPenalty() float64 {
+	if x != nil {
+		return x.BehaviourPenalty
+	}
+	return 0
+}
+
+// GossipSubPropagation is the propagation latency of recent announcements of this peer,
+// the time between publishing an announcement and its receipt by another peer.
+type GossipSubPropagation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Acknowledgements received since startup.
+	Acks uint64 `protobuf:"varint,1,opt,name=acks,proto3" json:"acks,omitempty"`
+	// Median propagation latency of recent acknowledgements.
+	MedianLatency *durationpb.Duration `protobuf:"bytes,2,opt,name=median_latency,json=medianLatency,proto3" json:"median_latency,omitempty"`
+	// 90th percentile of the propagation latency of recent acknowledgements.
+	P90Latency *durationpb.Duration `protobuf:"bytes,3,opt,name=p90_latency,json=p90Latency,proto3" json:"p90_latency,omitempty"`
+	// Maximum propagation latency of recent acknowledgements.
+	MaxLatency    *durationpb.Duration `protobuf:"bytes,4,opt,name=max_latency,json=maxLatency,proto3" json:"max_latency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GossipSubPropagation) Reset() {
+	*x = GossipSubPropagation{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GossipSubPropagation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GossipSubPropagation) ProtoMessage() {}
+
+func (x *GossipSubPropagation) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GossipSubPropagation.ProtoReflect.Descriptor instead.
+func (*GossipSubPropagation) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GossipSubPropagation) GetAcks() uint64 {
+	if x != nil {
+		return x.Acks
+	}
+	return 0
+}
+
+func (x *GossipSubPropagation) GetMedianLatency() *durationpb.Duration {
+	if x != nil {
+		return x.MedianLatency
+	}
+	return nil
+}
+
+func (x *GossipSubPropagation) GetP90Latency() *durationpb.Duration {
+	if x != nil {
+		return x.P90Latency
+	}
+	return nil
+}
+
+func (x *GossipSubPropagation) GetMaxLatency() *durationpb.Duration {
+	if x != nil {
+		return x.MaxLatency
+	}
+	return nil
+}
+
+type GetLabelCacheStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelCacheStatsRequest) Reset() {
+	*x = GetLabelCacheStatsRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelCacheStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelCacheStatsRequest) ProtoMessage() {}
+
+func (x *GetLabelCacheStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelCacheStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetLabelCacheStatsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{11}
+}
+
+type GetLabelCacheStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Statistics per label namespace, ordered by namespace.
+	Namespaces []*NamespaceCacheStats `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// Number of labels of remote records kept before records are evicted, 0 if unbounded.
+	MaxCachedLabels int64 `protobuf:"varint,2,opt,name=max_cached_labels,json=maxCachedLabels,proto3" json:"max_cached_labels,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetLabelCacheStatsResponse) Reset() {
+	*x = GetLabelCacheStatsResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelCacheStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelCacheStatsResponse) ProtoMessage() {}
+
+func (x *GetLabelCacheStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelCacheStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetLabelCacheStatsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetLabelCacheStatsResponse) GetNamespaces() []*NamespaceCacheStats {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *GetLabelCacheStatsResponse) GetMaxCachedLabels() int64 {
+	if x != nil {
+		return x.MaxCachedLabels
+	}
+	return 0
+}
+
+// NamespaceCacheStats are the label cache statistics of a label namespace.
+type NamespaceCacheStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the namespace, e.g. "skills".
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Number of labels of local records.
+	LocalLabels int64 `protobuf:"varint,2,opt,name=local_labels,json=localLabels,proto3" json:"local_labels,omitempty"`
+	// Number of labels of remote records.
+	RemoteLabels int64 `protobuf:"varint,3,opt,name=remote_labels,json=remoteLabels,proto3" json:"remote_labels,omitempty"`
+	// Number of distinct label values.
+	DistinctLabels int64 `protobuf:"varint,4,opt,name=distinct_labels,json=distinctLabels,proto3" json:"distinct_labels,omitempty"`
+	// Number of distinct remote records with labels in the namespace.
+	RemoteRecords int64 `protobuf:"varint,5,opt,name=remote_records,json=remoteRecords,proto3" json:"remote_records,omitempty"`
+	// Number of distinct remote peers providing records with labels in the namespace.
+	RemotePeers   int64 `protobuf:"varint,6,opt,name=remote_peers,json=remotePeers,proto3" json:"remote_peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceCacheStats) Reset() {
+	*x = NamespaceCacheStats{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceCacheStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceCacheStats) ProtoMessage() {}
+
+func (x *NamespaceCacheStats) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceCacheStats.ProtoReflect.Descriptor instead.
+func (*NamespaceCacheStats) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *NamespaceCacheStats) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *NamespaceCacheStats) GetLocalLabels() int64 {
+	if x != nil {
+		return x.LocalLabels
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetRemoteLabels() int64 {
+	if x != nil {
+		return x.RemoteLabels
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetDistinctLabels() int64 {
+	if x != nil {
+		return x.DistinctLabels
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetRemoteRecords() int64 {
+	if x != nil {
+		return x.RemoteRecords
+	}
+	return 0
+}
+
+func (x *NamespaceCacheStats) GetRemotePeers() int64 {
+	if x != nil {
+		return x.RemotePeers
+	}
+	return 0
+}
+
+type GetQueueStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueStateRequest) Reset() {
+	*x = GetQueueStateRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueStateRequest) ProtoMessage() {}
+
+func (x *GetQueueStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueStateRequest.ProtoReflect.Descriptor instead.
+func (*GetQueueStateRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{14}
+}
+
+type GetQueueStateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of pending notifications of remote announcements, waiting to be processed
+	// or retried.
+	NotifyDepth uint32 `protobuf:"varint,1,opt,name=notify_depth,json=notifyDepth,proto3" json:"notify_depth,omitempty"`
+	// Capacity of the notification queue. Announcements are dropped while it is full.
+	NotifyCapacity uint32 `protobuf:"varint,2,opt,name=notify_capacity,json=notifyCapacity,proto3" json:"notify_capacity,omitempty"`
+	// Number of published records waiting for peers to be announced to.
+	PendingAnnouncements uint32 `protobuf:"varint,3,opt,name=pending_announcements,json=pendingAnnouncements,proto3" json:"pending_announcements,omitempty"`
+	// Number of notifications moved to the dead-letter bucket after their pulls repeatedly failed.
+	NotifyDeadLetters uint32 `protobuf:"varint,4,opt,name=notify_dead_letters,json=notifyDeadLetters,proto3" json:"notify_dead_letters,omitempty"`
+	// Number of notifications being processed by the pull workers.
+	ActivePulls uint32 `protobuf:"varint,5,opt,name=active_pulls,json=activePulls,proto3" json:"active_pulls,omitempty"`
+	// Maximum number of notifications processed concurrently.
+	PullConcurrency uint32 `protobuf:"varint,6,opt,name=pull_concurrency,json=pullConcurrency,proto3" json:"pull_concurrency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetQueueStateResponse) Reset() {
+	*x = GetQueueStateResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueStateResponse) ProtoMessage() {}
+
+func (x *GetQueueStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueStateResponse.ProtoReflect.Descriptor instead.
+func (*GetQueueStateResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetQueueStateResponse) GetNotifyDepth() uint32 {
+	if x != nil {
+		return x.NotifyDepth
+	}
+	return 0
+}
+
+func (x *GetQueueStateResponse) GetNotifyCapacity() uint32 {
+	if x != nil {
+		return x.NotifyCapacity
+	}
+	return 0
+}
+
+func (x *GetQueueStateResponse) GetPendingAnnouncements() uint32 {
+	if x != nil {
+		return x.PendingAnnouncements
+	}
+	return 0
+}
+
+func (x *GetQueueStateResponse) GetNotifyDeadLetters() uint32 {
+	if x != nil {
+		return x.NotifyDeadLetters
+	}
+	return 0
+}
+
+func (x *GetQueueStateResponse) GetActivePulls() uint32 {
+	if x != nil {
+		return x.ActivePulls
+	}
+	return 0
+}
+
+func (x *GetQueueStateResponse) GetPullConcurrency() uint32 {
+	if x != nil {
+		return x.PullConcurrency
+	}
+	return 0
+}
+
+type GetTaskStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatusRequest) Reset() {
+	*x = GetTaskStatusRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatusRequest) ProtoMessage() {}
+
+func (x *GetTaskStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskStatusRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{16}
+}
+
+type GetTaskStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Background tasks, ordered by name.
+	Tasks         []*TaskStatus `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskStatusResponse) Reset() {
+	*x = GetTaskStatusResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskStatusResponse) ProtoMessage() {}
+
+func (x *GetTaskStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskStatusResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTaskStatusResponse) GetTasks() []*TaskStatus {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// TaskStatus is the status of a periodic background task.
+type TaskStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the task, e.g. "cleanup" or "republish/default".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Interval between runs.
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// When the last run completed, including runs before a restart. Unset if it never ran.
+	LastRun *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_run,json=lastRun,proto3" json:"last_run,omitempty"`
+	// Duration of the last run since this peer started, unset if it did not run yet.
+	LastDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=last_duration,json=lastDuration,proto3" json:"last_duration,omitempty"`
+	// When the next run is scheduled, unset while running.
+	NextRun *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=next_run,json=nextRun,proto3" json:"next_run,omitempty"`
+	// Whether the task is currently running.
+	Running       bool `protobuf:"varint,6,opt,name=running,proto3" json:"running,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskStatus) Reset() {
+	*x = TaskStatus{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStatus) ProtoMessage() {}
+
+func (x *TaskStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStatus.ProtoReflect.Descriptor instead.
+func (*TaskStatus) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TaskStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaskStatus) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *TaskStatus) GetLastRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRun
+	}
+	return nil
+}
+
+func (x *TaskStatus) GetLastDuration() *durationpb.Duration {
+	if x != nil {
+		return x.LastDuration
+	}
+	return nil
+}
+
+func (x *TaskStatus) GetNextRun() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRun
+	}
+	return nil
+}
+
+func (x *TaskStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+type StartBackfillRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peers the records are pulled from at most. Defaults to 100.
+	MaxPeers uint32 `protobuf:"varint,1,opt,name=max_peers,json=maxPeers,proto3" json:"max_peers,omitempty"`
+	// Records pulled at most. Defaults to 10000.
+	MaxRecords uint32 `protobuf:"varint,2,opt,name=max_records,json=maxRecords,proto3" json:"max_records,omitempty"`
+	// Records pulled per second at most. Defaults to 5.
+	PullsPerSecond uint32 `protobuf:"varint,3,opt,name=pulls_per_second,json=pullsPerSecond,proto3" json:"pulls_per_second,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StartBackfillRequest) Reset() {
+	*x = StartBackfillRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBackfillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBackfillRequest) ProtoMessage() {}
+
+func (x *StartBackfillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBackfillRequest.ProtoReflect.Descriptor instead.
+func (*StartBackfillRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StartBackfillRequest) GetMaxPeers() uint32 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *StartBackfillRequest) GetMaxRecords() uint32 {
+	if x != nil {
+		return x.MaxRecords
+	}
+	return 0
+}
+
+func (x *StartBackfillRequest) GetPullsPerSecond() uint32 {
+	if x != nil {
+		return x.PullsPerSecond
+	}
+	return 0
+}
+
+type StartBackfillResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the started backfill.
+	Status        *BackfillStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartBackfillResponse) Reset() {
+	*x = StartBackfillResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartBackfillResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartBackfillResponse) ProtoMessage() {}
+
+func (x *StartBackfillResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartBackfillResponse.ProtoReflect.Descriptor instead.
+func (*StartBackfillResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StartBackfillResponse) GetStatus() *BackfillStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type GetBackfillStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackfillStatusRequest) Reset() {
+	*x = GetBackfillStatusRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackfillStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackfillStatusRequest) ProtoMessage() {}
+
+func (x *GetBackfillStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackfillStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBackfillStatusRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{21}
+}
+
+type GetBackfillStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Status of the running or the last backfill, unset if no backfill ran since this peer started.
+	Status        *BackfillStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBackfillStatusResponse) Reset() {
+	*x = GetBackfillStatusResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBackfillStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBackfillStatusResponse) ProtoMessage() {}
+
+func (x *GetBackfillStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackfillStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBackfillStatusResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetBackfillStatusResponse) GetStatus() *BackfillStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// BackfillStatus is the progress of a backfill of the remote label cache.
+type BackfillStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the backfill is running.
+	Running bool `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	// When the backfill started.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// When the backfill finished, unset while running.
+	FinishedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	// Peers of the directory network found by crawling the DHT.
+	PeersCrawled uint32 `protobuf:"varint,4,opt,name=peers_crawled,json=peersCrawled,proto3" json:"peers_crawled,omitempty"`
+	// Peers that listed the records they provide.
+	Providers uint32 `protobuf:"varint,5,opt,name=providers,proto3" json:"providers,omitempty"`
+	// Records listed by the providers.
+	RecordsFound uint32 `protobuf:"varint,6,opt,name=records_found,json=recordsFound,proto3" json:"records_found,omitempty"`
+	// Records pulled and cached.
+	RecordsPulled uint32 `protobuf:"varint,7,opt,name=records_pulled,json=recordsPulled,proto3" json:"records_pulled,omitempty"`
+	// Records skipped as their labels were cached already.
+	RecordsSkipped uint32 `protobuf:"varint,8,opt,name=records_skipped,json=recordsSkipped,proto3" json:"records_skipped,omitempty"`
+	// Records that could not be pulled.
+	RecordsFailed uint32 `protobuf:"varint,9,opt,name=records_failed,json=recordsFailed,proto3" json:"records_failed,omitempty"`
+	// Error the backfill failed with, empty if it did not fail.
+	Error         string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackfillStatus) Reset() {
+	*x = BackfillStatus{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackfillStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillStatus) ProtoMessage() {}
+
+func (x *BackfillStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillStatus.ProtoReflect.Descriptor instead.
+func (*BackfillStatus) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BackfillStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *BackfillStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *BackfillStatus) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *BackfillStatus) GetPeersCrawled() uint32 {
+	if x != nil {
+		return x.PeersCrawled
+	}
+	return 0
+}
+
+func (x *BackfillStatus) GetProviders() uint32 {
+	if x != nil {
+		return x.Providers
+	}
+	return 0
+}
+
+func (x *BackfillStatus) GetRecordsFound() uint32 {
+	if x != nil {
+		return x.RecordsFound
+	}
+	return 0
+}
+
+func (x *BackfillStatus) GetRecordsPulled() uint32 {
+	if x != nil {
+		return x.RecordsPulled
+	}
+	return 0
+}
+
+func (x *BackfillStatus) GetRecordsSkipped() uint32 {
+	if x != nil {
+		return x.RecordsSkipped
+	}
+	return 0
+}
+
+func (x *BackfillStatus) GetRecordsFailed() uint32 {
+	if x != nil {
+		return x.RecordsFailed
+	}
+	return 0
+}
+
+func (x *BackfillStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetBandwidthUsageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peers returned at most, those served the most bytes first. Zero returns all peers.
+	Limit         uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBandwidthUsageRequest) Reset() {
+	*x = GetBandwidthUsageRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBandwidthUsageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBandwidthUsageRequest) ProtoMessage() {}
+
+func (x *GetBandwidthUsageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBandwidthUsageRequest.ProtoReflect.Descriptor instead.
+func (*GetBandwidthUsageRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBandwidthUsageRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetBandwidthUsageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bytes each peer may be served per hour, zero if unlimited.
+	QuotaBytesPerHour uint64 `protobuf:"varint,1,opt,name=quota_bytes_per_hour,json=quotaBytesPerHour,proto3" json:"quota_bytes_per_hour,omitempty"`
+	// Peers served within the last 24 hours, by bytes served, most first.
+	Peers         []*PeerBandwidthUsage `protobuf:"bytes,2,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBandwidthUsageResponse) Reset() {
+	*x = GetBandwidthUsageResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBandwidthUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBandwidthUsageResponse) ProtoMessage() {}
+
+func (x *GetBandwidthUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBandwidthUsageResponse.ProtoReflect.Descriptor instead.
+func (*GetBandwidthUsageResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBandwidthUsageResponse) GetQuotaBytesPerHour() uint64 {
+	if x != nil {
+		return x.QuotaBytesPerHour
+	}
+	return 0
+}
+
+func (x *GetBandwidthUsageResponse) GetPeers() []*PeerBandwidthUsage {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// PeerBandwidthUsage is the record content served to a peer within the last 24 hours.
+type PeerBandwidthUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Bytes of record content served.
+	Bytes uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Pulls served.
+	Pulls uint64 `protobuf:"varint,3,opt,name=pulls,proto3" json:"pulls,omitempty"`
+	// Pulls refused as the peer exceeded its quota.
+	RefusedPulls uint64 `protobuf:"varint,4,opt,name=refused_pulls,json=refusedPulls,proto3" json:"refused_pulls,omitempty"`
+	// Bytes served within the current hour, counted against the quota.
+	CurrentHourBytes uint64 `protobuf:"varint,5,opt,name=current_hour_bytes,json=currentHourBytes,proto3" json:"current_hour_bytes,omitempty"`
+	// Usage of the hours the peer pulled in, oldest first.
+	Hours         []*HourlyBandwidthUsage `protobuf:"bytes,6,rep,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerBandwidthUsage) Reset() {
+	*x = PeerBandwidthUsage{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerBandwidthUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerBandwidthUsage) ProtoMessage() {}
+
+func (x *PeerBandwidthUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerBandwidthUsage.ProtoReflect.Descriptor instead.
+func (*PeerBandwidthUsage) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PeerBandwidthUsage) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerBandwidthUsage) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *PeerBandwidthUsage) GetPulls() uint64 {
+	if x != nil {
+		return x.Pulls
+	}
+	return 0
+}
+
+func (x *PeerBandwidthUsage) GetRefusedPulls() uint64 {
+	if x != nil {
+		return x.RefusedPulls
+	}
+	return 0
+}
+
+func (x *PeerBandwidthUsage) GetCurrentHourBytes() uint64 {
+	if x != nil {
+		return x.CurrentHourBytes
+	}
+	return 0
+}
+
+func (x *PeerBandwidthUsage) GetHours() []*HourlyBandwidthUsage {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+// HourlyBandwidthUsage is the record content served to a peer within an hour.
+type HourlyBandwidthUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the hour.
+	Hour *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=hour,proto3" json:"hour,omitempty"`
+	// Bytes of record content served.
+	Bytes uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Pulls served.
+	Pulls uint64 `protobuf:"varint,3,opt,name=pulls,proto3" json:"pulls,omitempty"`
+	// Pulls refused as the peer exceeded its quota.
+	RefusedPulls  uint64 `protobuf:"varint,4,opt,name=refused_pulls,json=refusedPulls,proto3" json:"refused_pulls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HourlyBandwidthUsage) Reset() {
+	*x = HourlyBandwidthUsage{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyBandwidthUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyBandwidthUsage) ProtoMessage() {}
+
+func (x *HourlyBandwidthUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyBandwidthUsage.ProtoReflect.Descriptor instead.
+func (*HourlyBandwidthUsage) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *HourlyBandwidthUsage) GetHour() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Hour
+	}
+	return nil
+}
+
+func (x *HourlyBandwidthUsage) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *HourlyBandwidthUsage) GetPulls() uint64 {
+	if x != nil {
+		return x.Pulls
+	}
+	return 0
+}
+
+func (x *HourlyBandwidthUsage) GetRefusedPulls() uint64 {
+	if x != nil {
+		return x.RefusedPulls
+	}
+	return 0
+}
+
+type SnapshotDatastoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotDatastoreRequest) Reset() {
+	*x = SnapshotDatastoreRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotDatastoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotDatastoreRequest) ProtoMessage() {}
+
+func (x *SnapshotDatastoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotDatastoreRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotDatastoreRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{28}
+}
+
+type RestoreDatastoreResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer whose datastore was snapshotted.
+	SnapshotPeerId string `protobuf:"bytes,1,opt,name=snapshot_peer_id,json=snapshotPeerId,proto3" json:"snapshot_peer_id,omitempty"`
+	// When the snapshot was taken.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Entries written to the datastore.
+	EntriesRestored uint64 `protobuf:"varint,3,opt,name=entries_restored,json=entriesRestored,proto3" json:"entries_restored,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestoreDatastoreResponse) Reset() {
+	*x = RestoreDatastoreResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreDatastoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDatastoreResponse) ProtoMessage() {}
+
+func (x *RestoreDatastoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDatastoreResponse.ProtoReflect.Descriptor instead.
+func (*RestoreDatastoreResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RestoreDatastoreResponse) GetSnapshotPeerId() string {
+	if x != nil {
+		return x.SnapshotPeerId
+	}
+	return ""
+}
+
+func (x *RestoreDatastoreResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RestoreDatastoreResponse) GetEntriesRestored() uint64 {
+	if x != nil {
+		return x.EntriesRestored
+	}
+	return 0
+}
+
+type PromoteFollowerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Promote even if the primary still answers, e.g. when it is known to be cut off from the network.
+	Force         bool `protobuf:"varint,1,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteFollowerRequest) Reset() {
+	*x = PromoteFollowerRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteFollowerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteFollowerRequest) ProtoMessage() {}
+
+func (x *PromoteFollowerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteFollowerRequest.ProtoReflect.Descriptor instead.
+func (*PromoteFollowerRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PromoteFollowerRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type PromoteFollowerResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the routing datastore of the primary was last mirrored. Unset if it never was.
+	LastSyncedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=last_synced_at,json=lastSyncedAt,proto3" json:"last_synced_at,omitempty"`
+	// Entries of the last mirrored datastore.
+	EntriesSynced uint64 `protobuf:"varint,2,opt,name=entries_synced,json=entriesSynced,proto3" json:"entries_synced,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteFollowerResponse) Reset() {
+	*x = PromoteFollowerResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteFollowerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteFollowerResponse) ProtoMessage() {}
+
+func (x *PromoteFollowerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteFollowerResponse.ProtoReflect.Descriptor instead.
+func (*PromoteFollowerResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *PromoteFollowerResponse) GetLastSyncedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSyncedAt
+	}
+	return nil
+}
+
+func (x *PromoteFollowerResponse) GetEntriesSynced() uint64 {
+	if x != nil {
+		return x.EntriesSynced
+	}
+	return 0
+}
+
+type ListPinnedRecordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedRecordsRequest) Reset() {
+	*x = ListPinnedRecordsRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedRecordsRequest) ProtoMessage() {}
+
+func (x *ListPinnedRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{32}
+}
+
+type ListPinnedRecordsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pinned records, ordered by CID.
+	Records []*PinnedRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// Garbage collection of unpinned local records.
+	Gc            *RecordGCStatus `protobuf:"bytes,2,opt,name=gc,proto3" json:"gc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedRecordsResponse) Reset() {
+	*x = ListPinnedRecordsResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedRecordsResponse) ProtoMessage() {}
+
+func (x *ListPinnedRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListPinnedRecordsResponse) GetRecords() []*PinnedRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListPinnedRecordsResponse) GetGc() *RecordGCStatus {
+	if x != nil {
+		return x.Gc
+	}
+	return nil
+}
+
+type PinnedRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the pinned record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// When the record was pinned.
+	PinnedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	// Whether the record is published by this peer.
+	Local bool `protobuf:"varint,3,opt,name=local,proto3" json:"local,omitempty"`
+	// Whether the remote record was mirrored into the local store.
+	Mirrored bool `protobuf:"varint,4,opt,name=mirrored,proto3" json:"mirrored,omitempty"`
+	// When the local record was last reprovided to the network.
+	// Not set for remote records and local records not reprovided since they were published.
+	ReprovidedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=reprovided_at,json=reprovidedAt,proto3" json:"reprovided_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedRecord) Reset() {
+	*x = PinnedRecord{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedRecord) ProtoMessage() {}
+
+func (x *PinnedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedRecord.ProtoReflect.Descriptor instead.
+func (*PinnedRecord) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PinnedRecord) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *PinnedRecord) GetPinnedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return nil
+}
+
+func (x *PinnedRecord) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+func (x *PinnedRecord) GetMirrored() bool {
+	if x != nil {
+		return x.Mirrored
+	}
+	return false
+}
+
+func (x *PinnedRecord) GetReprovidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReprovidedAt
+	}
+	return nil
+}
+
+type RecordGCStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether unpinned local records are garbage collected under disk pressure.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Fraction of the filesystem in use at which unpinned local records are collected.
+	Threshold float64 `protobuf:"fixed64,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Fraction of the filesystem in use at the last check.
+	DiskUsage float64 `protobuf:"fixed64,3,opt,name=disk_usage,json=diskUsage,proto3" json:"disk_usage,omitempty"`
+	// When disk usage was last checked, not set before the first check.
+	CheckedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// Number of local records collected since startup.
+	Collected     uint64 `protobuf:"varint,5,opt,name=collected,proto3" json:"collected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordGCStatus) Reset() {
+	*x = RecordGCStatus{}
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordGCStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordGCStatus) ProtoMessage() {}
+
+func (x *RecordGCStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordGCStatus.ProtoReflect.Descriptor instead.
+func (*RecordGCStatus) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecordGCStatus) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RecordGCStatus) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *RecordGCStatus) GetDiskUsage() float64 {
+	if x != nil {
+		return x.DiskUsage
+	}
+	return 0
+}
+
+func (x *RecordGCStatus) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *RecordGCStatus) GetCollected() uint64 {
+	if x != nil {
+		return x.Collected
+	}
+	return 0
+}
+
+var File_agntcy_dir_routing_v1_routing_admin_service_proto protoreflect.FileDescriptor
+
+var file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDesc = string([]byte{
+	0x0a, 0x31, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f,
+	0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x15, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x1a, 0x2b, 0x61, 0x67, 0x6e, 0x74,
+	0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76,
+	0x31, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x18, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x79, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x3d,
+	0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x22, 0xf0, 0x01,
+	0x0a, 0x10, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65,
+	0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x61, 0x64, 0x64, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x61, 0x64, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x40,
+	0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x66, 0x75, 0x6c, 0x5f, 0x61, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65, 0x66, 0x75, 0x6c, 0x41, 0x74,
+	0x22, 0x1a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x74, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x73, 0x73,
+	0x69, 0x70, 0x53, 0x75, 0x62, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x73, 0x22, 0x7b, 0x0a, 0x0e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x65, 0x73, 0x68, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x65, 0x73, 0x68, 0x50, 0x65, 0x65, 0x72, 0x73, 0x22,
+	0x20, 0x0a, 0x1e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x44,
+	0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0xc3, 0x02, 0x0a, 0x1f, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53,
+	0x75, 0x62, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12,
+	0x48, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x30, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75,
+	0x62, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63,
+	0x73, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x65, 0x65,
+	0x72, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x70, 0x65, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x4a, 0x0a, 0x0b,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70,
+	0x53, 0x75, 0x62, 0x50, 0x65, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x0a, 0x70, 0x65,
+	0x65, 0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x4d, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x70,
+	0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x50,
+	0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x70,
+	0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa5, 0x02, 0x0a, 0x19, 0x47, 0x6f, 0x73, 0x73,
+	0x69, 0x70, 0x53, 0x75, 0x62, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f,
+	0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x6d,
+	0x65, 0x73, 0x68, 0x5f, 0x64, 0x65, 0x67, 0x72, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x6d, 0x65, 0x73, 0x68, 0x44, 0x65, 0x67, 0x72, 0x65, 0x65, 0x12, 0x2d, 0x0a, 0x12,
+	0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x64,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x64,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0b, 0x69, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x52, 0x61, 0x74, 0x65, 0x22,
+	0x9e, 0x01, 0x0a, 0x12, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x50, 0x65, 0x65,
+	0x72, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x70, 0x70, 0x5f, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x66, 0x69, 0x63, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x10, 0x61, 0x70, 0x70, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x53, 0x63,
+	0x6f, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72,
+	0x5f, 0x70, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
+	0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x75, 0x72, 0x50, 0x65, 0x6e, 0x61, 0x6c, 0x74, 0x79,
+	0x22, 0xe4, 0x01, 0x0a, 0x14, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x50, 0x72,
+	0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x63, 0x6b,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x63, 0x6b, 0x73, 0x12, 0x40, 0x0a,
+	0x0e, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x6e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x3a, 0x0a, 0x0b, 0x70, 0x39, 0x30, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0a, 0x70, 0x39, 0x30, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x3a, 0x0a, 0x0b, 0x6d,
+	0x61, 0x78, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6d, 0x61, 0x78,
+	0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x1b, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x94, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0xee, 0x01, 0x0a, 0x13,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x69, 0x73,
+	0x74, 0x69, 0x6e, 0x63, 0x74, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x64, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x50, 0x65, 0x65, 0x72, 0x73, 0x22, 0x16, 0x0a, 0x14,
+	0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x96, 0x02, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21,
+	0x0a, 0x0c, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x44, 0x65, 0x70, 0x74,
+	0x68, 0x12, 0x27, 0x0a, 0x0f, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6e, 0x6f, 0x74, 0x69,
+	0x66, 0x79, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x15, 0x70, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x5f, 0x6c,
+	0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x6e, 0x6f,
+	0x74, 0x69, 0x66, 0x79, 0x44, 0x65, 0x61, 0x64, 0x4c, 0x65, 0x74, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x75, 0x6c,
+	0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x70, 0x75,
+	0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x16, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x50, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
+	0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x22, 0x9f, 0x02, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x12, 0x35, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x07, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x12, 0x3e, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x72, 0x75, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x7e, 0x0a, 0x14, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x28, 0x0a, 0x10, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x70, 0x75, 0x6c, 0x6c, 0x73,
+	0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0x56, 0x0a, 0x15, 0x53, 0x74, 0x61,
+	0x72, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x66,
+	0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x22, 0x1a, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5a, 0x0a,
+	0x19, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x97, 0x03, 0x0a, 0x0e, 0x42, 0x61,
+	0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x72, 0x61, 0x77, 0x6c, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x73, 0x43, 0x72, 0x61, 0x77,
+	0x6c, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x50, 0x75, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x27, 0x0a,
+	0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x53,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x22, 0x30, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6e,
+	0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x14, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x5f, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x11, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72,
+	0x48, 0x6f, 0x75, 0x72, 0x12, 0x3f, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05,
+	0x70, 0x65, 0x65, 0x72, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x12, 0x50, 0x65, 0x65, 0x72, 0x42, 0x61,
+	0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x75, 0x6c, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x70, 0x75, 0x6c, 0x6c,
+	0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x75, 0x6c,
+	0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x73, 0x65,
+	0x64, 0x50, 0x75, 0x6c, 0x6c, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x10, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x05, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x6f, 0x75, 0x72,
+	0x6c, 0x79, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x05, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x22, 0x97, 0x01, 0x0a, 0x14, 0x48, 0x6f, 0x75, 0x72,
+	0x6c, 0x79, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x2e, 0x0a, 0x04, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x68, 0x6f, 0x75, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x66, 0x75, 0x73, 0x65, 0x64, 0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x75, 0x73, 0x65, 0x64, 0x50, 0x75, 0x6c, 0x6c,
+	0x73, 0x22, 0x1a, 0x0a, 0x18, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xaa, 0x01,
+	0x0a, 0x18, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x50, 0x65,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x29, 0x0a, 0x10, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x6e, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x22, 0x2e, 0x0a, 0x16, 0x50, 0x72,
+	0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x82, 0x01, 0x0a, 0x17, 0x50,
+	0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x79, 0x6e, 0x63, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74,
+	0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x41, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x22,
+	0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x91, 0x01, 0x0a, 0x19,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x02, 0x67, 0x63, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x47, 0x43, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x02, 0x67, 0x63, 0x22,
+	0xcc, 0x01, 0x0a, 0x0c, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63,
+	0x69, 0x64, 0x12, 0x37, 0x0a, 0x09, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x3f, 0x0a,
+	0x0d, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x0c, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x41, 0x74, 0x22, 0xc0,
+	0x01, 0x0a, 0x0e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x47, 0x43, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x73,
+	0x6b, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x64,
+	0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x32, 0x86, 0x0c, 0x0a, 0x13, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x41, 0x64, 0x6d,
+	0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x70, 0x0a, 0x0f, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x2d, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x2f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73,
+	0x69, 0x70, 0x53, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x30, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73,
+	0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x88, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x53, 0x75, 0x62, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12,
+	0x35, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x53, 0x75, 0x62, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x36, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x53, 0x75, 0x62, 0x44, 0x69, 0x61, 0x67, 0x6e,
+	0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x79,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x30, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x0d, 0x47, 0x65, 0x74,
+	0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2b, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x6a, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69,
+	0x6c, 0x6c, 0x12, 0x2b, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x42, 0x61, 0x63,
+	0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x2f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61,
+	0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42,
+	0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x76, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6e, 0x64,
+	0x77, 0x69, 0x64, 0x74, 0x68, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2f, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x70, 0x0a,
+	0x11, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x2f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12,
+	0x6f, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x12, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x2f, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01,
+	0x12, 0x70, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x72, 0x12, 0x2d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d,
+	0x6f, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6d, 0x6f,
+	0x74, 0x65, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x76, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x2f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xd2, 0x01, 0x0a, 0x19, 0x63,
+	0x6f, 0x6d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x42, 0x18, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x52,
+	0xaa, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x44, 0x69, 0x72, 0x2e, 0x52, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31,
+	0xe2, 0x02, 0x21, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x18, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44,
+	0x69, 0x72, 0x3a, 0x3a, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescOnce sync.Once
+	file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescData []byte
+)
+
+func file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescGZIP() []byte {
+	file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescOnce.Do(func() {
+		file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDesc), len(file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDesc)))
+	})
+	return file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDescData
+}
+
+var file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_agntcy_dir_routing_v1_routing_admin_service_proto_goTypes = []any{
+	(*GetRoutingTableRequest)(nil),          // 0: agntcy.dir.routing.v1.GetRoutingTableRequest
+	(*GetRoutingTableResponse)(nil),         // 1: agntcy.dir.routing.v1.GetRoutingTableResponse
+	(*RoutingTablePeer)(nil),                // 2: agntcy.dir.routing.v1.RoutingTablePeer
+	(*GetGossipSubStateRequest)(nil),        // 3: agntcy.dir.routing.v1.GetGossipSubStateRequest
+	(*GetGossipSubStateResponse)(nil),       // 4: agntcy.dir.routing.v1.GetGossipSubStateResponse
+	(*GossipSubTopic)(nil),                  // 5: agntcy.dir.routing.v1.GossipSubTopic
+	(*GetGossipSubDiagnosticsRequest)(nil),  // 6: agntcy.dir.routing.v1.GetGossipSubDiagnosticsRequest
+	(*GetGossipSubDiagnosticsResponse)(nil), // 7: agntcy.dir.routing.v1.GetGossipSubDiagnosticsResponse
+	(*GossipSubTopicDiagnostics)(nil),       // 8: agntcy.dir.routing.v1.GossipSubTopicDiagnostics
+	(*GossipSubPeerScore)(nil),              // 9: agntcy.dir.routing.v1.GossipSubPeerScore
+	(*GossipSubPropagation)(nil),            // 10: agntcy.dir.routing.v1.GossipSubPropagation
+	(*GetLabelCacheStatsRequest)(nil),       // 11: agntcy.dir.routing.v1.GetLabelCacheStatsRequest
+	(*GetLabelCacheStatsResponse)(nil),      // 12: agntcy.dir.routing.v1.GetLabelCacheStatsResponse
+	(*NamespaceCacheStats)(nil),             // 13: agntcy.dir.routing.v1.NamespaceCacheStats
+	(*GetQueueStateRequest)(nil),            // 14: agntcy.dir.routing.v1.GetQueueStateRequest
+	(*GetQueueStateResponse)(nil),           // 15: agntcy.dir.routing.v1.GetQueueStateResponse
+	(*GetTaskStatusRequest)(nil),            // 16: agntcy.dir.routing.v1.GetTaskStatusRequest
+	(*GetTaskStatusResponse)(nil),           // 17: agntcy.dir.routing.v1.GetTaskStatusResponse
+	(*TaskStatus)(nil),                      // 18: agntcy.dir.routing.v1.TaskStatus
+	(*StartBackfillRequest)(nil),            // 19: agntcy.dir.routing.v1.StartBackfillRequest
+	(*StartBackfillResponse)(nil),           // 20: agntcy.dir.routing.v1.StartBackfillResponse
+	(*GetBackfillStatusRequest)(nil),        // 21: agntcy.dir.routing.v1.GetBackfillStatusRequest
+	(*GetBackfillStatusResponse)(nil),       // 22: agntcy.dir.routing.v1.GetBackfillStatusResponse
+	(*BackfillStatus)(nil),                  // 23: agntcy.dir.routing.v1.BackfillStatus
+	(*GetBandwidthUsageRequest)(nil),        // 24: agntcy.dir.routing.v1.GetBandwidthUsageRequest
+	(*GetBandwidthUsageResponse)(nil),       // 25: agntcy.dir.routing.v1.GetBandwidthUsageResponse
+	(*PeerBandwidthUsage)(nil),              // 26: agntcy.dir.routing.v1.PeerBandwidthUsage
+	(*HourlyBandwidthUsage)(nil),            // 27: agntcy.dir.routing.v1.HourlyBandwidthUsage
+	(*SnapshotDatastoreRequest)(nil),        // 28: agntcy.dir.routing.v1.SnapshotDatastoreRequest
+	(*RestoreDatastoreResponse)(nil),        // 29: agntcy.dir.routing.v1.RestoreDatastoreResponse
+	(*PromoteFollowerRequest)(nil),          // 30: agntcy.dir.routing.v1.PromoteFollowerRequest
+	(*PromoteFollowerResponse)(nil),         // 31: agntcy.dir.routing.v1.PromoteFollowerResponse
+	(*ListPinnedRecordsRequest)(nil),        // 32: agntcy.dir.routing.v1.ListPinnedRecordsRequest
+	(*ListPinnedRecordsResponse)(nil),       // 33: agntcy.dir.routing.v1.ListPinnedRecordsResponse
+	(*PinnedRecord)(nil),                    // 34: agntcy.dir.routing.v1.PinnedRecord
+	(*RecordGCStatus)(nil),                  // 35: agntcy.dir.routing.v1.RecordGCStatus
+	(*timestamppb.Timestamp)(nil),           // 36: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),             // 37: google.protobuf.Duration
+	(*StateArchiveChunk)(nil),               // 38: agntcy.dir.routing.v1.StateArchiveChunk
+}
+var file_agntcy_dir_routing_v1_routing_admin_service_proto_depIdxs = []int32{
+	2,  // 0: agntcy.dir.routing.v1.GetRoutingTableResponse.peers:type_name -> agntcy.dir.routing.v1.RoutingTablePeer
+	36, // 1: agntcy.dir.routing.v1.RoutingTablePeer.added_at:type_name -> google.protobuf.Timestamp
+	36, // 2: agntcy.dir.routing.v1.RoutingTablePeer.last_useful_at:type_name -> google.protobuf.Timestamp
+	5,  // 3: agntcy.dir.routing.v1.GetGossipSubStateResponse.topics:type_name -> agntcy.dir.routing.v1.GossipSubTopic
+	8,  // 4: agntcy.dir.routing.v1.GetGossipSubDiagnosticsResponse.topics:type_name -> agntcy.dir.routing.v1.GossipSubTopicDiagnostics
+	9,  // 5: agntcy.dir.routing.v1.GetGossipSubDiagnosticsResponse.peer_scores:type_name -> agntcy.dir.routing.v1.GossipSubPeerScore
+	10, // 6: agntcy.dir.routing.v1.GetGossipSubDiagnosticsResponse.propagation:type_name -> agntcy.dir.routing.v1.GossipSubPropagation
+	37, // 7: agntcy.dir.routing.v1.GossipSubPropagation.median_latency:type_name -> google.protobuf.Duration
+	37, // 8: agntcy.dir.routing.v1.GossipSubPropagation.p90_latency:type_name -> google.protobuf.Duration
+	37, // 9: agntcy.dir.routing.v1.GossipSubPropagation.max_latency:type_name -> google.protobuf.Duration
+	13, // 10: agntcy.dir.routing.v1.GetLabelCacheStatsResponse.namespaces:type_name -> agntcy.dir.routing.v1.NamespaceCacheStats
+	18, // 11: agntcy.dir.routing.v1.GetTaskStatusResponse.tasks:type_name -> agntcy.dir.routing.v1.TaskStatus
+	37, // 12: agntcy.dir.routing.v1.TaskStatus.interval:type_name -> google.protobuf.Duration
+	36, // 13: agntcy.dir.routing.v1.TaskStatus.last_run:type_name -> google.protobuf.Timestamp
+	37, // 14: agntcy.dir.routing.v1.TaskStatus.last_duration:type_name -> google.protobuf.Duration
+	36, // 15: agntcy.dir.routing.v1.TaskStatus.next_run:type_name -> google.protobuf.Timestamp
+	23, // 16: agntcy.dir.routing.v1.StartBackfillResponse.status:type_name -> agntcy.dir.routing.v1.BackfillStatus
+	23, // 17: agntcy.dir.routing.v1.GetBackfillStatusResponse.status:type_name -> agntcy.dir.routing.v1.BackfillStatus
+	36, // 18: agntcy.dir.routing.v1.BackfillStatus.started_at:type_name -> google.protobuf.Timestamp
+	36, // 19: agntcy.dir.routing.v1.BackfillStatus.finished_at:type_name -> google.protobuf.Timestamp
+	26, // 20: agntcy.dir.routing.v1.GetBandwidthUsageResponse.peers:type_name -> agntcy.dir.routing.v1.PeerBandwidthUsage
+	27, // 21: agntcy.dir.routing.v1.PeerBandwidthUsage.hours:type_name -> agntcy.dir.routing.v1.HourlyBandwidthUsage
+	36, // 22: agntcy.dir.routing.v1.HourlyBandwidthUsage.hour:type_name -> google.protobuf.Timestamp
+	36, // 23: agntcy.dir.routing.v1.RestoreDatastoreResponse.created_at:type_name -> google.protobuf.Timestamp
+	36, // 24: agntcy.dir.routing.v1.PromoteFollowerResponse.last_synced_at:type_name -> google.protobuf.Timestamp
+	34, // 25: agntcy.dir.routing.v1.ListPinnedRecordsResponse.records:type_name -> agntcy.dir.routing.v1.PinnedRecord
+	35, // 26: agntcy.dir.routing.v1.ListPinnedRecordsResponse.gc:type_name -> agntcy.dir.routing.v1.RecordGCStatus
+	36, // 27: agntcy.dir.routing.v1.PinnedRecord.pinned_at:type_name -> google.protobuf.Timestamp
+	36, // 28: agntcy.dir.routing.v1.PinnedRecord.reprovided_at:type_name -> google.protobuf.Timestamp
+	36, // 29: agntcy.dir.routing.v1.RecordGCStatus.checked_at:type_name -> google.protobuf.Timestamp
+	0,  // 30: agntcy.dir.routing.v1.RoutingAdminService.GetRoutingTable:input_type -> agntcy.dir.routing.v1.GetRoutingTableRequest
+	3,  // 31: agntcy.dir.routing.v1.RoutingAdminService.GetGossipSubState:input_type -> agntcy.dir.routing.v1.GetGossipSubStateRequest
+	6,  // 32: agntcy.dir.routing.v1.RoutingAdminService.GetGossipSubDiagnostics:input_type -> agntcy.dir.routing.v1.GetGossipSubDiagnosticsRequest
+	11, // 33: agntcy.dir.routing.v1.RoutingAdminService.GetLabelCacheStats:input_type -> agntcy.dir.routing.v1.GetLabelCacheStatsRequest
+	14, // 34: agntcy.dir.routing.v1.RoutingAdminService.GetQueueState:input_type -> agntcy.dir.routing.v1.GetQueueStateRequest
+	16, // 35: agntcy.dir.routing.v1.RoutingAdminService.GetTaskStatus:input_type -> agntcy.dir.routing.v1.GetTaskStatusRequest
+	19, // 36: agntcy.dir.routing.v1.RoutingAdminService.StartBackfill:input_type -> agntcy.dir.routing.v1.StartBackfillRequest
+	21, // 37: agntcy.dir.routing.v1.RoutingAdminService.GetBackfillStatus:input_type -> agntcy.dir.routing.v1.GetBackfillStatusRequest
+	24, // 38: agntcy.dir.routing.v1.RoutingAdminService.GetBandwidthUsage:input_type -> agntcy.dir.routing.v1.GetBandwidthUsageRequest
+	28, // 39: agntcy.dir.routing.v1.RoutingAdminService.SnapshotDatastore:input_type -> agntcy.dir.routing.v1.SnapshotDatastoreRequest
+	38, // 40: agntcy.dir.routing.v1.RoutingAdminService.RestoreDatastore:input_type -> agntcy.dir.routing.v1.StateArchiveChunk
+	30, // 41: agntcy.dir.routing.v1.RoutingAdminService.PromoteFollower:input_type -> agntcy.dir.routing.v1.PromoteFollowerRequest
+	32, // 42: agntcy.dir.routing.v1.RoutingAdminService.ListPinnedRecords:input_type -> agntcy.dir.routing.v1.ListPinnedRecordsRequest
+	1,  // 43: agntcy.dir.routing.v1.RoutingAdminService.GetRoutingTable:output_type -> agntcy.dir.routing.v1.GetRoutingTableResponse
+	4,  // 44: agntcy.dir.routing.v1.RoutingAdminService.GetGossipSubState:output_type -> agntcy.dir.routing.v1.GetGossipSubStateResponse
+	7,  // 45: agntcy.dir.routing.v1.RoutingAdminService.GetGossipSubDiagnostics:output_type -> agntcy.dir.routing.v1.GetGossipSubDiagnosticsResponse
+	12, // 46: agntcy.dir.routing.v1.RoutingAdminService.GetLabelCacheStats:output_type -> agntcy.dir.routing.v1.GetLabelCacheStatsResponse
+	15, // 47: agntcy.dir.routing.v1.RoutingAdminService.GetQueueState:output_type -> agntcy.dir.routing.v1.GetQueueStateResponse
+	17, // 48: agntcy.dir.routing.v1.RoutingAdminService.GetTaskStatus:output_type -> agntcy.dir.routing.v1.GetTaskStatusResponse
+	20, // 49: agntcy.dir.routing.v1.RoutingAdminService.StartBackfill:output_type -> agntcy.dir.routing.v1.StartBackfillResponse
+	22, // 50: agntcy.dir.routing.v1.RoutingAdminService.GetBackfillStatus:output_type -> agntcy.dir.routing.v1.GetBackfillStatusResponse
+	25, // 51: agntcy.dir.routing.v1.RoutingAdminService.GetBandwidthUsage:output_type -> agntcy.dir.routing.v1.GetBandwidthUsageResponse
+	38, // 52: agntcy.dir.routing.v1.RoutingAdminService.SnapshotDatastore:output_type -> agntcy.dir.routing.v1.StateArchiveChunk
+	29, // 53: agntcy.dir.routing.v1.RoutingAdminService.RestoreDatastore:output_type -> agntcy.dir.routing.v1.RestoreDatastoreResponse
+	31, // 54: agntcy.dir.routing.v1.RoutingAdminService.PromoteFollower:output_type -> agntcy.dir.routing.v1.PromoteFollowerResponse
+	33, // 55: agntcy.dir.routing.v1.RoutingAdminService.ListPinnedRecords:output_type -> agntcy.dir.routing.v1.ListPinnedRecordsResponse
+	43, // [43:56] is the sub-list for method output_type
+	30, // [30:43] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
+}
+
+func init() { file_agntcy_dir_routing_v1_routing_admin_service_proto_init() }
+func file_agntcy_dir_routing_v1_routing_admin_service_proto_init() {
+	if File_agntcy_dir_routing_v1_routing_admin_service_proto != nil {
+		return
+	}
+	file_agntcy_dir_routing_v1_routing_service_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDesc), len(file_agntcy_dir_routing_v1_routing_admin_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   36,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_agntcy_dir_routing_v1_routing_admin_service_proto_goTypes,
+		DependencyIndexes: file_agntcy_dir_routing_v1_routing_admin_service_proto_depIdxs,
+		MessageInfos:      file_agntcy_dir_routing_v1_routing_admin_service_proto_msgTypes,
+	}.Build()
+	File_agntcy_dir_routing_v1_routing_admin_service_proto = out.File
+	file_agntcy_dir_routing_v1_routing_admin_service_proto_goTypes = nil
+	file_agntcy_dir_routing_v1_routing_admin_service_proto_depIdxs = nil
+}
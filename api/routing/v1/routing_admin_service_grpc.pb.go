@@ -0,0 +1,714 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: agntcy/dir/routing/v1/routing_admin_service.proto
+
+package v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	RoutingAdminService_GetRoutingTable_FullMethodName         = "/agntcy.dir.routing.v1.RoutingAdminService/GetRoutingTable"
+	RoutingAdminService_GetGossipSubState_FullMethodName       = "/agntcy.dir.routing.v1.RoutingAdminService/GetGossipSubState"
+	RoutingAdminService_GetGossipSubDiagnostics_FullMethodName = "/agntcy.dir.routing.v1.RoutingAdminService/GetGossipSubDiagnostics"
+	RoutingAdminService_GetLabelCacheStats_FullMethodName      = "/agntcy.dir.routing.v1.RoutingAdminService/GetLabelCacheStats"
+	RoutingAdminService_GetQueueState_FullMethodName           = "/agntcy.dir.routing.v1.RoutingAdminService/GetQueueState"
+	RoutingAdminService_GetTaskStatus_FullMethodName           = "/agntcy.dir.routing.v1.RoutingAdminService/GetTaskStatus"
+	RoutingAdminService_StartBackfill_FullMethodName           = "/agntcy.dir.routing.v1.RoutingAdminService/StartBackfill"
+	RoutingAdminService_GetBackfillStatus_FullMethodName       = "/agntcy.dir.routing.v1.RoutingAdminService/GetBackfillStatus"
+	RoutingAdminService_GetBandwidthUsage_FullMethodName       = "/agntcy.dir.routing.v1.RoutingAdminService/GetBandwidthUsage"
+	RoutingAdminService_SnapshotDatastore_FullMethodName       = "/agntcy.dir.routing.v1.RoutingAdminService/SnapshotDatastore"
+	RoutingAdminService_RestoreDatastore_FullMethodName        = "/agntcy.dir.routing.v1.RoutingAdminService/RestoreDatastore"
+	RoutingAdminService_PromoteFollower_FullMethodName         = "/agntcy.dir.routing.v1.RoutingAdminService/PromoteFollower"
+	RoutingAdminService_ListPinnedRecords_FullMethodName       = "/agntcy.dir.routing.v1.RoutingAdminService/ListPinnedRecords"
+)
+
+// RoutingAdminServiceClient is the client API for RoutingAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RoutingAdminService exposes the internal state of the routing layer of a peer,
+// for debugging multi-node deployments without attaching a debugger.
+//
+// All operations are local-only and, except for StartBackfill and RestoreDatastore,
+// read-only. They are meant for operators and may expose peer IDs and addresses of
+// the network.
+type RoutingAdminServiceClient interface {
+	// GetRoutingTable dumps the DHT routing table of this peer.
+	GetRoutingTable(ctx context.Context, in *GetRoutingTableRequest, opts ...grpc.CallOption) (*GetRoutingTableResponse, error)
+	// GetGossipSubState returns the peers and the mesh of each joined GossipSub topic.
+	GetGossipSubState(ctx context.Context, in *GetGossipSubStateRequest, opts ...grpc.CallOption) (*GetGossipSubStateResponse, error)
+	// GetGossipSubDiagnostics returns the mesh degree and the message rates of each joined
+	// GossipSub topic, the GossipSub scores of peers and the measured propagation latency
+	// of the label announcements of this peer.
+	GetGossipSubDiagnostics(ctx context.Context, in *GetGossipSubDiagnosticsRequest, opts ...grpc.CallOption) (*GetGossipSubDiagnosticsResponse, error)
+	// GetLabelCacheStats returns statistics of the label cache per label namespace.
+	// The label cache is scanned, so this may be slow for large caches.
+	GetLabelCacheStats(ctx context.Context, in *GetLabelCacheStatsRequest, opts ...grpc.CallOption) (*GetLabelCacheStatsResponse, error)
+	// GetQueueState returns the depth of the internal queues of routing.
+	GetQueueState(ctx context.Context, in *GetQueueStateRequest, opts ...grpc.CallOption) (*GetQueueStateResponse, error)
+	// GetTaskStatus returns the schedule and the last runs of the background tasks of routing.
+	GetTaskStatus(ctx context.Context, in *GetTaskStatusRequest, opts ...grpc.CallOption) (*GetTaskStatusResponse, error)
+	// StartBackfill starts rebuilding the remote label cache in the background, e.g. after
+	// the datastore was lost. The DHT is crawled for peers of the directory network, and the
+	// records they provide are pulled from them at a limited rate and their labels cached.
+	// Fails with FailedPrecondition while a backfill is running or without remote routing.
+	StartBackfill(ctx context.Context, in *StartBackfillRequest, opts ...grpc.CallOption) (*StartBackfillResponse, error)
+	// GetBackfillStatus returns the progress of the running or the last backfill.
+	GetBackfillStatus(ctx context.Context, in *GetBackfillStatusRequest, opts ...grpc.CallOption) (*GetBackfillStatusResponse, error)
+	// GetBandwidthUsage returns the record content served by Pull to each peer
+	// per hour, within the last 24 hours, against the hourly quota of each peer.
+	GetBandwidthUsage(ctx context.Context, in *GetBandwidthUsageRequest, opts ...grpc.CallOption) (*GetBandwidthUsageResponse, error)
+	// SnapshotDatastore streams a point-in-time archive of the routing datastore of this
+	// peer, holding the label cache, the peer address book, the DHT provider records and
+	// the other routing state, in chunks.
+	SnapshotDatastore(ctx context.Context, in *SnapshotDatastoreRequest, opts ...grpc.CallOption) (RoutingAdminService_SnapshotDatastoreClient, error)
+	// RestoreDatastore writes the entries of a datastore archive streamed in chunks into
+	// the routing datastore of this peer, e.g. the archive of the peer it replaces.
+	// Entries overwrite the existing values of their keys; other keys are kept.
+	RestoreDatastore(ctx context.Context, opts ...grpc.CallOption) (RoutingAdminService_RestoreDatastoreClient, error)
+	// PromoteFollower promotes this peer from a follower mirroring the routing datastore of
+	// its primary, see routing.follower, to the peer announcing the records of their shared
+	// identity. The peer stops mirroring, joins the network and starts announcing. Promotion
+	// is refused while the primary still answers, unless forced, so that the primary and its
+	// follower never announce records at the same time.
+	PromoteFollower(ctx context.Context, in *PromoteFollowerRequest, opts ...grpc.CallOption) (*PromoteFollowerResponse, error)
+	// ListPinnedRecords lists the pinned records with when local records were last
+	// reprovided, and the state of the garbage collection of unpinned local records
+	// under disk pressure, see routing.record_gc.
+	ListPinnedRecords(ctx context.Context, in *ListPinnedRecordsRequest, opts ...grpc.CallOption) (*ListPinnedRecordsResponse, error)
+}
+
+type routingAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoutingAdminServiceClient(cc grpc.ClientConnInterface) RoutingAdminServiceClient {
+	return &routingAdminServiceClient{cc}
+}
+
+func (c *routingAdminServiceClient) GetRoutingTable(ctx context.Context, in *GetRoutingTableRequest, opts ...grpc.CallOption) (*GetRoutingTableResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoutingTableResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetRoutingTable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetGossipSubState(ctx context.Context, in *GetGossipSubStateRequest, opts ...grpc.CallOption) (*GetGossipSubStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGossipSubStateResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetGossipSubState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetGossipSubDiagnostics(ctx context.Context, in *GetGossipSubDiagnosticsRequest, opts ...grpc.CallOption) (*GetGossipSubDiagnosticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGossipSubDiagnosticsResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetGossipSubDiagnostics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetLabelCacheStats(ctx context.Context, in *GetLabelCacheStatsRequest, opts ...grpc.CallOption) (*GetLabelCacheStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLabelCacheStatsResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetLabelCacheStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetQueueState(ctx context.Context, in *GetQueueStateRequest, opts ...grpc.CallOption) (*GetQueueStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQueueStateResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetQueueState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetTaskStatus(ctx context.Context, in *GetTaskStatusRequest, opts ...grpc.CallOption) (*GetTaskStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaskStatusResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetTaskStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) StartBackfill(ctx context.Context, in *StartBackfillRequest, opts ...grpc.CallOption) (*StartBackfillResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartBackfillResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_StartBackfill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetBackfillStatus(ctx context.Context, in *GetBackfillStatusRequest, opts ...grpc.CallOption) (*GetBackfillStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackfillStatusResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetBackfillStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) GetBandwidthUsage(ctx context.Context, in *GetBandwidthUsageRequest, opts ...grpc.CallOption) (*GetBandwidthUsageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBandwidthUsageResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_GetBandwidthUsage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) SnapshotDatastore(ctx context.Context, in *SnapshotDatastoreRequest, opts ...grpc.CallOption) (RoutingAdminService_SnapshotDatastoreClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingAdminService_ServiceDesc.Streams[0], RoutingAdminService_SnapshotDatastore_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingAdminServiceSnapshotDatastoreClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RoutingAdminService_SnapshotDatastoreClient interface {
+	Recv() (*StateArchiveChunk, error)
+	grpc.ClientStream
+}
+
+type routingAdminServiceSnapshotDatastoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingAdminServiceSnapshotDatastoreClient) Recv() (*StateArchiveChunk, error) {
+	m := new(StateArchiveChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routingAdminServiceClient) RestoreDatastore(ctx context.Context, opts ...grpc.CallOption) (RoutingAdminService_RestoreDatastoreClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingAdminService_ServiceDesc.Streams[1], RoutingAdminService_RestoreDatastore_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingAdminServiceRestoreDatastoreClient{ClientStream: stream}
+	return x, nil
+}
+
+type RoutingAdminService_RestoreDatastoreClient interface {
+	Send(*StateArchiveChunk) error
+	CloseAndRecv() (*RestoreDatastoreResponse, error)
+	grpc.ClientStream
+}
+
+type routingAdminServiceRestoreDatastoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingAdminServiceRestoreDatastoreClient) Send(m *StateArchiveChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *routingAdminServiceRestoreDatastoreClient) CloseAndRecv() (*RestoreDatastoreResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RestoreDatastoreResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routingAdminServiceClient) PromoteFollower(ctx context.Context, in *PromoteFollowerRequest, opts ...grpc.CallOption) (*PromoteFollowerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoteFollowerResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_PromoteFollower_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingAdminServiceClient) ListPinnedRecords(ctx context.Context, in *ListPinnedRecordsRequest, opts ...grpc.CallOption) (*ListPinnedRecordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPinnedRecordsResponse)
+	err := c.cc.Invoke(ctx, RoutingAdminService_ListPinnedRecords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoutingAdminServiceServer is the server API for RoutingAdminService service.
+// All implementations should embed UnimplementedRoutingAdminServiceServer
+// for forward compatibility.
+//
+// RoutingAdminService exposes the internal state of the routing layer of a peer,
+// for debugging multi-node deployments without attaching a debugger.
+//
+// All operations are local-only and, except for StartBackfill and RestoreDatastore,
+// read-only. They are meant for operators and may expose peer IDs and addresses of
+// the network.
+type RoutingAdminServiceServer interface {
+	// GetRoutingTable dumps the DHT routing table of this peer.
+	GetRoutingTable(context.Context, *GetRoutingTableRequest) (*GetRoutingTableResponse, error)
+	// GetGossipSubState returns the peers and the mesh of each joined GossipSub topic.
+	GetGossipSubState(context.Context, *GetGossipSubStateRequest) (*GetGossipSubStateResponse, error)
+	// GetGossipSubDiagnostics returns the mesh degree and the message rates of each joined
+	// GossipSub topic, the GossipSub scores of peers and the measured propagation latency
+	// of the label announcements of this peer.
+	GetGossipSubDiagnostics(context.Context, *GetGossipSubDiagnosticsRequest) (*GetGossipSubDiagnosticsResponse, error)
+	// GetLabelCacheStats returns statistics of the label cache per label namespace.
+	// The label cache is scanned, so this may be slow for large caches.
+	GetLabelCacheStats(context.Context, *GetLabelCacheStatsRequest) (*GetLabelCacheStatsResponse, error)
+	// GetQueueState returns the depth of the internal queues of routing.
+	GetQueueState(context.Context, *GetQueueStateRequest) (*GetQueueStateResponse, error)
+	// GetTaskStatus returns the schedule and the last runs of the background tasks of routing.
+	GetTaskStatus(context.Context, *GetTaskStatusRequest) (*GetTaskStatusResponse, error)
+	// StartBackfill starts rebuilding the remote label cache in the background, e.g. after
+	// the datastore was lost. The DHT is crawled for peers of the directory network, and the
+	// records they provide are pulled from them at a limited rate and their labels cached.
+	// Fails with FailedPrecondition while a backfill is running or without remote routing.
+	StartBackfill(context.Context, *StartBackfillRequest) (*StartBackfillResponse, error)
+	// GetBackfillStatus returns the progress of the running or the last backfill.
+	GetBackfillStatus(context.Context, *GetBackfillStatusRequest) (*GetBackfillStatusResponse, error)
+	// GetBandwidthUsage returns the record content served by Pull to each peer
+	// per hour, within the last 24 hours, against the hourly quota of each peer.
+	GetBandwidthUsage(context.Context, *GetBandwidthUsageRequest) (*GetBandwidthUsageResponse, error)
+	// SnapshotDatastore streams a point-in-time archive of the routing datastore of this
+	// peer, holding the label cache, the peer address book, the DHT provider records and
+	// the other routing state, in chunks.
+	SnapshotDatastore(*SnapshotDatastoreRequest, RoutingAdminService_SnapshotDatastoreServer) error
+	// RestoreDatastore writes the entries of a datastore archive streamed in chunks into
+	// the routing datastore of this peer, e.g. the archive of the peer it replaces.
+	// Entries overwrite the existing values of their keys; other keys are kept.
+	RestoreDatastore(RoutingAdminService_RestoreDatastoreServer) error
+	// PromoteFollower promotes this peer from a follower mirroring the routing datastore of
+	// its primary, see routing.follower, to the peer announcing the records of their shared
+	// identity. The peer stops mirroring, joins the network and starts announcing. Promotion
+	// is refused while the primary still answers, unless forced, so that the primary and its
+	// follower never announce records at the same time.
+	PromoteFollower(context.Context, *PromoteFollowerRequest) (*PromoteFollowerResponse, error)
+	// ListPinnedRecords lists the pinned records with when local records were last
+	// reprovided, and the state of the garbage collection of unpinned local records
+	// under disk pressure, see routing.record_gc.
+	ListPinnedRecords(context.Context, *ListPinnedRecordsRequest) (*ListPinnedRecordsResponse, error)
+}
+
+// UnimplementedRoutingAdminServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRoutingAdminServiceServer struct{}
+
+func (UnimplementedRoutingAdminServiceServer) GetRoutingTable(context.Context, *GetRoutingTableRequest) (*GetRoutingTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoutingTable not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetGossipSubState(context.Context, *GetGossipSubStateRequest) (*GetGossipSubStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGossipSubState not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetGossipSubDiagnostics(context.Context, *GetGossipSubDiagnosticsRequest) (*GetGossipSubDiagnosticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGossipSubDiagnostics not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetLabelCacheStats(context.Context, *GetLabelCacheStatsRequest) (*GetLabelCacheStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLabelCacheStats not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetQueueState(context.Context, *GetQueueStateRequest) (*GetQueueStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueState not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetTaskStatus(context.Context, *GetTaskStatusRequest) (*GetTaskStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskStatus not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) StartBackfill(context.Context, *StartBackfillRequest) (*StartBackfillResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartBackfill not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetBackfillStatus(context.Context, *GetBackfillStatusRequest) (*GetBackfillStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackfillStatus not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) GetBandwidthUsage(context.Context, *GetBandwidthUsageRequest) (*GetBandwidthUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBandwidthUsage not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) SnapshotDatastore(*SnapshotDatastoreRequest, RoutingAdminService_SnapshotDatastoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method SnapshotDatastore not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) RestoreDatastore(RoutingAdminService_RestoreDatastoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method RestoreDatastore not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) PromoteFollower(context.Context, *PromoteFollowerRequest) (*PromoteFollowerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteFollower not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) ListPinnedRecords(context.Context, *ListPinnedRecordsRequest) (*ListPinnedRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPinnedRecords not implemented")
+}
+func (UnimplementedRoutingAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRoutingAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoutingAdminServiceServer will
+// result in compilation errors.
+type UnsafeRoutingAdminServiceServer interface {
+	mustEmbedUnimplementedRoutingAdminServiceServer()
+}
+
+func RegisterRoutingAdminServiceServer(s grpc.ServiceRegistrar, srv RoutingAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRoutingAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RoutingAdminService_ServiceDesc, srv)
+}
+
+func _RoutingAdminService_GetRoutingTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoutingTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetRoutingTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetRoutingTable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetRoutingTable(ctx, req.(*GetRoutingTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetGossipSubState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGossipSubStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetGossipSubState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetGossipSubState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetGossipSubState(ctx, req.(*GetGossipSubStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetGossipSubDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGossipSubDiagnosticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetGossipSubDiagnostics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetGossipSubDiagnostics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetGossipSubDiagnostics(ctx, req.(*GetGossipSubDiagnosticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetLabelCacheStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLabelCacheStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetLabelCacheStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetLabelCacheStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetLabelCacheStats(ctx, req.(*GetLabelCacheStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetQueueState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueueStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetQueueState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetQueueState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetQueueState(ctx, req.(*GetQueueStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetTaskStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetTaskStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetTaskStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetTaskStatus(ctx, req.(*GetTaskStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_StartBackfill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartBackfillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).StartBackfill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_StartBackfill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).StartBackfill(ctx, req.(*StartBackfillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetBackfillStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackfillStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetBackfillStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetBackfillStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetBackfillStatus(ctx, req.(*GetBackfillStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_GetBandwidthUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBandwidthUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).GetBandwidthUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_GetBandwidthUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).GetBandwidthUsage(ctx, req.(*GetBandwidthUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_SnapshotDatastore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SnapshotDatastoreRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoutingAdminServiceServer).SnapshotDatastore(m, &routingAdminServiceSnapshotDatastoreServer{ServerStream: stream})
+}
+
+type RoutingAdminService_SnapshotDatastoreServer interface {
+	Send(*StateArchiveChunk) error
+	grpc.ServerStream
+}
+
+type routingAdminServiceSnapshotDatastoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingAdminServiceSnapshotDatastoreServer) Send(m *StateArchiveChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RoutingAdminService_RestoreDatastore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RoutingAdminServiceServer).RestoreDatastore(&routingAdminServiceRestoreDatastoreServer{ServerStream: stream})
+}
+
+type RoutingAdminService_RestoreDatastoreServer interface {
+	SendAndClose(*RestoreDatastoreResponse) error
+	Recv() (*StateArchiveChunk, error)
+	grpc.ServerStream
+}
+
+type routingAdminServiceRestoreDatastoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingAdminServiceRestoreDatastoreServer) SendAndClose(m *RestoreDatastoreResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *routingAdminServiceRestoreDatastoreServer) Recv() (*StateArchiveChunk, error) {
+	m := new(StateArchiveChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RoutingAdminService_PromoteFollower_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteFollowerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).PromoteFollower(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_PromoteFollower_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).PromoteFollower(ctx, req.(*PromoteFollowerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingAdminService_ListPinnedRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPinnedRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingAdminServiceServer).ListPinnedRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingAdminService_ListPinnedRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingAdminServiceServer).ListPinnedRecords(ctx, req.(*ListPinnedRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RoutingAdminService_ServiceDesc is the grpc.ServiceDesc for RoutingAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoutingAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "agntcy.dir.routing.v1.RoutingAdminService",
+	HandlerType: (*RoutingAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRoutingTable",
+			Handler:    _RoutingAdminService_GetRoutingTable_Handler,
+		},
+		{
+			MethodName: "GetGossipSubState",
+			Handler:    _RoutingAdminService_GetGossipSubState_Handler,
+		},
+		{
+			MethodName: "GetGossipSubDiagnostics",
+			Handler:    _RoutingAdminService_GetGossipSubDiagnostics_Handler,
+		},
+		{
+			MethodName: "GetLabelCacheStats",
+			Handler:    _RoutingAdminService_GetLabelCacheStats_Handler,
+		},
+		{
+			MethodName: "GetQueueState",
+			Handler:    _RoutingAdminService_GetQueueState_Handler,
+		},
+		{
+			MethodName: "GetTaskStatus",
+			Handler:    _RoutingAdminService_GetTaskStatus_Handler,
+		},
+		{
+			MethodName: "StartBackfill",
+			Handler:    _RoutingAdminService_StartBackfill_Handler,
+		},
+		{
+			MethodName: "GetBackfillStatus",
+			Handler:    _RoutingAdminService_GetBackfillStatus_Handler,
+		},
+		{
+			MethodName: "GetBandwidthUsage",
+			Handler:    _RoutingAdminService_GetBandwidthUsage_Handler,
+		},
+		{
+			MethodName: "PromoteFollower",
+			Handler:    _RoutingAdminService_PromoteFollower_Handler,
+		},
+		{
+			MethodName: "ListPinnedRecords",
+			Handler:    _RoutingAdminService_ListPinnedRecords_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SnapshotDatastore",
+			Handler:       _RoutingAdminService_SnapshotDatastore_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RestoreDatastore",
+			Handler:       _RoutingAdminService_RestoreDatastore_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "agntcy/dir/routing/v1/routing_admin_service.proto",
+}
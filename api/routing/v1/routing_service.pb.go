@@ -14,7 +14,9 @@ import (
 	v11 "github.com/agntcy/dir/api/search/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -27,13 +29,424 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// PublishStage is a stage of publishing a record. Stages are reported in order,
+// and stages that do not apply to a record are skipped, e.g. GossipSub stages
+// of low-priority records.
+type PublishStage int32
+
+const (
+	// Unspecified stage.
+	PublishStage_PUBLISH_STAGE_UNSPECIFIED PublishStage = 0
+	// The record was stored in the local routing index.
+	PublishStage_PUBLISH_STAGE_STORED PublishStage = 1
+	// This peer has no peers in its routing table yet. The record is announced
+	// in the background once it has, and no further network stages are reported.
+	PublishStage_PUBLISH_STAGE_QUEUED PublishStage = 2
+	// The record was provided to the DHT. Records of private tenants are only
+	// announced via GossipSub and skip providing.
+	PublishStage_PUBLISH_STAGE_DHT_PROVIDED PublishStage = 3
+	// The record's labels were published via GossipSub.
+	PublishStage_PUBLISH_STAGE_GOSSIPSUB_PUBLISHED PublishStage = 4
+	// GossipSub topic peers were asked whether they cached the record's labels,
+	// until one did or the propagation check timed out.
+	// No peers resolving the record means propagation could not be confirmed.
+	PublishStage_PUBLISH_STAGE_LABELS_PROPAGATED PublishStage = 5
+	// The record was published.
+	PublishStage_PUBLISH_STAGE_COMPLETED PublishStage = 6
+	// Publishing the record failed.
+	PublishStage_PUBLISH_STAGE_FAILED PublishStage = 7
+)
+
+// Enum value maps for PublishStage.
+var (
+	PublishStage_name = map[int32]string{
+		0: "PUBLISH_STAGE_UNSPECIFIED",
+		1: "PUBLISH_STAGE_STORED",
+		2: "PUBLISH_STAGE_QUEUED",
+		3: "PUBLISH_STAGE_DHT_PROVIDED",
+		4: "PUBLISH_STAGE_GOSSIPSUB_PUBLISHED",
+		5: "PUBLISH_STAGE_LABELS_PROPAGATED",
+		6: "PUBLISH_STAGE_COMPLETED",
+		7: "PUBLISH_STAGE_FAILED",
+	}
+	PublishStage_value = map[string]int32{
+		"PUBLISH_STAGE_UNSPECIFIED":         0,
+		"PUBLISH_STAGE_STORED":              1,
+		"PUBLISH_STAGE_QUEUED":              2,
+		"PUBLISH_STAGE_DHT_PROVIDED":        3,
+		"PUBLISH_STAGE_GOSSIPSUB_PUBLISHED": 4,
+		"PUBLISH_STAGE_LABELS_PROPAGATED":   5,
+		"PUBLISH_STAGE_COMPLETED":           6,
+		"PUBLISH_STAGE_FAILED":              7,
+	}
+)
+
+func (x PublishStage) Enum() *PublishStage {
+	p := new(PublishStage)
+	*p = x
+	return p
+}
+
+func (x PublishStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PublishStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[0].Descriptor()
+}
+
+func (PublishStage) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[0]
+}
+
+func (x PublishStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PublishStage.Descriptor instead.
+func (PublishStage) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{0}
+}
+
+// AnnouncementPriority controls how eagerly records are announced to the network,
+// trading discovery latency against network cost.
+type AnnouncementPriority int32
+
+const (
+	// Unspecified priority, treated as normal.
+	AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED AnnouncementPriority = 0
+	// Announced immediately via DHT and GossipSub, and republished more often.
+	AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH AnnouncementPriority = 1
+	// Announced via DHT and GossipSub, and republished at the regular interval.
+	AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL AnnouncementPriority = 2
+	// Announced via DHT only, without GossipSub label announcements.
+	AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW AnnouncementPriority = 3
+)
+
+// Enum value maps for AnnouncementPriority.
+var (
+	AnnouncementPriority_name = map[int32]string{
+		0: "ANNOUNCEMENT_PRIORITY_UNSPECIFIED",
+		1: "ANNOUNCEMENT_PRIORITY_HIGH",
+		2: "ANNOUNCEMENT_PRIORITY_NORMAL",
+		3: "ANNOUNCEMENT_PRIORITY_LOW",
+	}
+	AnnouncementPriority_value = map[string]int32{
+		"ANNOUNCEMENT_PRIORITY_UNSPECIFIED": 0,
+		"ANNOUNCEMENT_PRIORITY_HIGH":        1,
+		"ANNOUNCEMENT_PRIORITY_NORMAL":      2,
+		"ANNOUNCEMENT_PRIORITY_LOW":         3,
+	}
+)
+
+func (x AnnouncementPriority) Enum() *AnnouncementPriority {
+	p := new(AnnouncementPriority)
+	*p = x
+	return p
+}
+
+func (x AnnouncementPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnnouncementPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[1].Descriptor()
+}
+
+func (AnnouncementPriority) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[1]
+}
+
+func (x AnnouncementPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnnouncementPriority.Descriptor instead.
+func (AnnouncementPriority) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{1}
+}
+
+// SearchMode lets callers choose between fast and complete search results.
+type SearchMode int32
+
+const (
+	// Unspecified mode, the default search.
+	SearchMode_SEARCH_MODE_UNSPECIFIED SearchMode = 0
+	// Returns quickly from an in-memory index of the label cache.
+	// Does not wait for cache warming and never queries peers, even if live is set.
+	SearchMode_SEARCH_MODE_FAST SearchMode = 1
+	// Resolves the labels of every record from the datastore, waits for cache
+	// warming and always queries connected peers as in a live search.
+	SearchMode_SEARCH_MODE_THOROUGH SearchMode = 2
+)
+
+// Enum value maps for SearchMode.
+var (
+	SearchMode_name = map[int32]string{
+		0: "SEARCH_MODE_UNSPECIFIED",
+		1: "SEARCH_MODE_FAST",
+		2: "SEARCH_MODE_THOROUGH",
+	}
+	SearchMode_value = map[string]int32{
+		"SEARCH_MODE_UNSPECIFIED": 0,
+		"SEARCH_MODE_FAST":        1,
+		"SEARCH_MODE_THOROUGH":    2,
+	}
+)
+
+func (x SearchMode) Enum() *SearchMode {
+	p := new(SearchMode)
+	*p = x
+	return p
+}
+
+func (x SearchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[2].Descriptor()
+}
+
+func (SearchMode) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[2]
+}
+
+func (x SearchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchMode.Descriptor instead.
+func (SearchMode) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{2}
+}
+
+// ScoringStrategy selects how the relevance of search results is computed.
+type ScoringStrategy int32
+
+const (
+	// Unspecified strategy, the default strategy configured on the server (count unless configured).
+	ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED ScoringStrategy = 0
+	// Relevance is the number of matched queries, i.e. the match score.
+	ScoringStrategy_SCORING_STRATEGY_COUNT ScoringStrategy = 1
+	// Matched queries are weighted by label namespace as configured on the server,
+	// e.g. a matched skill may count more than a matched locator.
+	ScoringStrategy_SCORING_STRATEGY_WEIGHTED_NAMESPACES ScoringStrategy = 2
+	// The number of matched queries decays with the time since the record was last
+	// announced, halving every freshness half-life configured on the server.
+	ScoringStrategy_SCORING_STRATEGY_FRESHNESS ScoringStrategy = 3
+	// The number of matched queries is scaled by the reputation of the providing peer in [0, 1].
+	ScoringStrategy_SCORING_STRATEGY_REPUTATION ScoringStrategy = 4
+	// Weighted combination of ranking factors, each normalized to [0, 1]: the fraction
+	// of queries matched, the freshness of the record's last announcement, the
+	// reputation of the providing peer and the number of peers providing the record.
+	// Factors are weighted by SearchRequest.ranking_weights, or by the weights configured
+	// on the server. The relevance is normalized to [0, 1].
+	ScoringStrategy_SCORING_STRATEGY_RANKED ScoringStrategy = 5
+)
+
+// Enum value maps for ScoringStrategy.
+var (
+	ScoringStrategy_name = map[int32]string{
+		0: "SCORING_STRATEGY_UNSPECIFIED",
+		1: "SCORING_STRATEGY_COUNT",
+		2: "SCORING_STRATEGY_WEIGHTED_NAMESPACES",
+		3: "SCORING_STRATEGY_FRESHNESS",
+		4: "SCORING_STRATEGY_REPUTATION",
+		5: "SCORING_STRATEGY_RANKED",
+	}
+	ScoringStrategy_value = map[string]int32{
+		"SCORING_STRATEGY_UNSPECIFIED":         0,
+		"SCORING_STRATEGY_COUNT":               1,
+		"SCORING_STRATEGY_WEIGHTED_NAMESPACES": 2,
+		"SCORING_STRATEGY_FRESHNESS":           3,
+		"SCORING_STRATEGY_REPUTATION":          4,
+		"SCORING_STRATEGY_RANKED":              5,
+	}
+)
+
+func (x ScoringStrategy) Enum() *ScoringStrategy {
+	p := new(ScoringStrategy)
+	*p = x
+	return p
+}
+
+func (x ScoringStrategy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ScoringStrategy) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[3].Descriptor()
+}
+
+func (ScoringStrategy) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[3]
+}
+
+func (x ScoringStrategy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ScoringStrategy.Descriptor instead.
+func (ScoringStrategy) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{3}
+}
+
+// DiscoveryProfile bundles the routing behavior of a peer for its role in the network.
+type DiscoveryProfile int32
+
+const (
+	// Unspecified profile.
+	DiscoveryProfile_DISCOVERY_PROFILE_UNSPECIFIED DiscoveryProfile = 0
+	// Full node: serves the DHT, processes GossipSub announcements, caches
+	// remote labels up to the configured limit and republishes its records.
+	DiscoveryProfile_DISCOVERY_PROFILE_FULL DiscoveryProfile = 1
+	// Edge node: uses the DHT as a client only, processes GossipSub announcements,
+	// caches a bounded number of remote labels and republishes its records.
+	DiscoveryProfile_DISCOVERY_PROFILE_EDGE DiscoveryProfile = 2
+	// Client-only node: uses the DHT as a client only, ignores GossipSub
+	// announcements, caches few remote labels and does not republish its records.
+	DiscoveryProfile_DISCOVERY_PROFILE_CLIENT DiscoveryProfile = 3
+)
+
+// Enum value maps for DiscoveryProfile.
+var (
+	DiscoveryProfile_name = map[int32]string{
+		0: "DISCOVERY_PROFILE_UNSPECIFIED",
+		1: "DISCOVERY_PROFILE_FULL",
+		2: "DISCOVERY_PROFILE_EDGE",
+		3: "DISCOVERY_PROFILE_CLIENT",
+	}
+	DiscoveryProfile_value = map[string]int32{
+		"DISCOVERY_PROFILE_UNSPECIFIED": 0,
+		"DISCOVERY_PROFILE_FULL":        1,
+		"DISCOVERY_PROFILE_EDGE":        2,
+		"DISCOVERY_PROFILE_CLIENT":      3,
+	}
+)
+
+func (x DiscoveryProfile) Enum() *DiscoveryProfile {
+	p := new(DiscoveryProfile)
+	*p = x
+	return p
+}
+
+func (x DiscoveryProfile) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiscoveryProfile) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[4].Descriptor()
+}
+
+func (DiscoveryProfile) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[4]
+}
+
+func (x DiscoveryProfile) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiscoveryProfile.Descriptor instead.
+func (DiscoveryProfile) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{4}
+}
+
+// RetrievalMethod is a way for clients to pull a record from the peer that provides it.
+type RetrievalMethod int32
+
+const (
+	// Unspecified method, records are returned regardless of how they can be retrieved.
+	RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED RetrievalMethod = 0
+	// Legacy pull of the whole record over the libp2p routing RPC protocol.
+	RetrievalMethod_RETRIEVAL_METHOD_RPC_PULL RetrievalMethod = 1
+	// Streaming pull over the peer's Directory API (StoreService.Pull).
+	// Requires the peer to advertise a Directory API address.
+	RetrievalMethod_RETRIEVAL_METHOD_STREAMING RetrievalMethod = 2
+)
+
+// Enum value maps for RetrievalMethod.
+var (
+	RetrievalMethod_name = map[int32]string{
+		0: "RETRIEVAL_METHOD_UNSPECIFIED",
+		1: "RETRIEVAL_METHOD_RPC_PULL",
+		2: "RETRIEVAL_METHOD_STREAMING",
+	}
+	RetrievalMethod_value = map[string]int32{
+		"RETRIEVAL_METHOD_UNSPECIFIED": 0,
+		"RETRIEVAL_METHOD_RPC_PULL":    1,
+		"RETRIEVAL_METHOD_STREAMING":   2,
+	}
+)
+
+func (x RetrievalMethod) Enum() *RetrievalMethod {
+	p := new(RetrievalMethod)
+	*p = x
+	return p
+}
+
+func (x RetrievalMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RetrievalMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[5].Descriptor()
+}
+
+func (RetrievalMethod) Type() protoreflect.EnumType {
+	return &file_agntcy_dir_routing_v1_routing_service_proto_enumTypes[5]
+}
+
+func (x RetrievalMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RetrievalMethod.Descriptor instead.
+func (RetrievalMethod) EnumDescriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{5}
+}
+
 type PublishRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Request:
 	//
 	//	*PublishRequest_RecordRefs
 	//	*PublishRequest_Queries
-	Request       isPublishRequest_Request `protobuf_oneof:"request"`
+	Request isPublishRequest_Request `protobuf_oneof:"request"`
+	// Announcement priority of the published records.
+	// If not set, records are published with normal priority.
+	Priority AnnouncementPriority `protobuf:"varint,4,opt,name=priority,proto3,enum=agntcy.dir.routing.v1.AnnouncementPriority" json:"priority,omitempty"`
+	// Time to live of the published records, at least one minute.
+	// Once it elapses, the records are no longer announced and remote peers
+	// drop them from their caches and search results.
+	// If not set, records are announced until they are unpublished.
+	Ttl *durationpb.Duration `protobuf:"bytes,5,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// CID of the previous version of the published record.
+	// Announced together with the record, so that searches can skip versions
+	// superseded by the same peer and peers expire them sooner.
+	// Only valid when publishing a single record by reference.
+	Supersedes string `protobuf:"bytes,6,opt,name=supersedes,proto3" json:"supersedes,omitempty"`
+	// If set, the records and their labels are announced, but their content is only
+	// served to the peers allowed to pull access-gated records, so that they can be
+	// discovered without exposing their content.
+	// Republishing a record without it keeps the record access-gated.
+	AccessGated bool `protobuf:"varint,7,opt,name=access_gated,json=accessGated,proto3" json:"access_gated,omitempty"`
+	// Tenant to publish the records for. Their labels are stored and announced under
+	// /tenants/<tenant_id>/, so that they are only returned to searches of the tenant,
+	// or of any shared tenant if the tenant is public. The tenant must be configured
+	// on the publishing and discovering peers.
+	// Republishing a record without it keeps the record's tenant.
+	TenantId string `protobuf:"bytes,8,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// did:key identifier of the publisher to publish the records as. The publisher's
+	// key must be configured on this peer, which signs the records' announcements with
+	// it, so that several publishers can share a peer. If not set, the records are
+	// published as this peer. Republishing a record without it keeps the record's publisher.
+	Publisher string `protobuf:"bytes,9,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	// Label policies overriding the publish policy configured on the peer
+	// (routing.publish_policy) for their namespaces. Labels withheld by the policies are
+	// neither stored nor announced. At most one policy per namespace.
+	// Republishing a record without them keeps the record's policies.
+	LabelPolicies []*LabelPolicy `protobuf:"bytes,10,rep,name=label_policies,json=labelPolicies,proto3" json:"label_policies,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -93,6 +506,55 @@ func (x *PublishRequest) GetQueries() *RecordQueries {
 	return nil
 }
 
+func (x *PublishRequest) GetPriority() AnnouncementPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED
+}
+
+func (x *PublishRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *PublishRequest) GetSupersedes() string {
+	if x != nil {
+		return x.Supersedes
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetAccessGated() bool {
+	if x != nil {
+		return x.AccessGated
+	}
+	return false
+}
+
+func (x *PublishRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *PublishRequest) GetLabelPolicies() []*LabelPolicy {
+	if x != nil {
+		return x.LabelPolicies
+	}
+	return nil
+}
+
 type isPublishRequest_Request interface {
 	isPublishRequest_Request()
 }
@@ -111,32 +573,2782 @@ func (*PublishRequest_RecordRefs) isPublishRequest_Request() {}
 
 func (*PublishRequest_Queries) isPublishRequest_Request() {}
 
-type UnpublishRequest struct {
+// LabelPolicy selects the labels of a namespace that are published for a record.
+// Patterns are matched against label values without the namespace prefix, e.g.
+// "internal/*" for "/modules/internal/auth", and also match the descendants of
+// the labels they match.
+type LabelPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Label namespace the policy applies to, e.g. "modules".
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Patterns of the labels that are published.
+	// If empty, all labels that are not excluded are published.
+	Include []string `protobuf:"bytes,2,rep,name=include,proto3" json:"include,omitempty"`
+	// Patterns of the labels that are withheld, taking precedence over include.
+	Exclude       []string `protobuf:"bytes,3,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelPolicy) Reset() {
+	*x = LabelPolicy{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelPolicy) ProtoMessage() {}
+
+func (x *LabelPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelPolicy.ProtoReflect.Descriptor instead.
+func (*LabelPolicy) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LabelPolicy) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LabelPolicy) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *LabelPolicy) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+// PublishProgress reports that a published record reached a publish stage.
+type PublishProgress struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the published record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Stage the record reached.
+	Stage PublishStage `protobuf:"varint,2,opt,name=stage,proto3,enum=agntcy.dir.routing.v1.PublishStage" json:"stage,omitempty"`
+	// When the record reached the stage.
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// Number of GossipSub topic peers the record's labels were published to.
+	// Only set for PUBLISH_STAGE_GOSSIPSUB_PUBLISHED.
+	TopicPeers uint32 `protobuf:"varint,4,opt,name=topic_peers,json=topicPeers,proto3" json:"topic_peers,omitempty"`
+	// Number of GossipSub topic peers asked whether they cached the record's labels.
+	// Only set for PUBLISH_STAGE_LABELS_PROPAGATED.
+	PeersChecked uint32 `protobuf:"varint,5,opt,name=peers_checked,json=peersChecked,proto3" json:"peers_checked,omitempty"`
+	// Number of asked peers that cached labels of the record from this peer.
+	// Only set for PUBLISH_STAGE_LABELS_PROPAGATED.
+	PeersResolved uint32 `protobuf:"varint,6,opt,name=peers_resolved,json=peersResolved,proto3" json:"peers_resolved,omitempty"`
+	// Why publishing the record failed. Only set for PUBLISH_STAGE_FAILED.
+	Error string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	// Validation rules the record violated. Only set for PUBLISH_STAGE_FAILED
+	// of records that failed validation before publishing.
+	Violations    []*RecordViolation `protobuf:"bytes,8,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishProgress) Reset() {
+	*x = PublishProgress{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishProgress) ProtoMessage() {}
+
+func (x *PublishProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishProgress.ProtoReflect.Descriptor instead.
+func (*PublishProgress) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PublishProgress) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *PublishProgress) GetStage() PublishStage {
+	if x != nil {
+		return x.Stage
+	}
+	return PublishStage_PUBLISH_STAGE_UNSPECIFIED
+}
+
+func (x *PublishProgress) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *PublishProgress) GetTopicPeers() uint32 {
+	if x != nil {
+		return x.TopicPeers
+	}
+	return 0
+}
+
+func (x *PublishProgress) GetPeersChecked() uint32 {
+	if x != nil {
+		return x.PeersChecked
+	}
+	return 0
+}
+
+func (x *PublishProgress) GetPeersResolved() uint32 {
+	if x != nil {
+		return x.PeersResolved
+	}
+	return 0
+}
+
+func (x *PublishProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PublishProgress) GetViolations() []*RecordViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// RecordValidationFailure lists the validation rules a record violated.
+// It is attached as an error detail to INVALID_ARGUMENT errors of Publish.
+type RecordValidationFailure struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Violated validation rules.
+	Violations    []*RecordViolation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordValidationFailure) Reset() {
+	*x = RecordValidationFailure{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordValidationFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordValidationFailure) ProtoMessage() {}
+
+func (x *RecordValidationFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordValidationFailure.ProtoReflect.Descriptor instead.
+func (*RecordValidationFailure) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RecordValidationFailure) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *RecordValidationFailure) GetViolations() []*RecordViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// RecordViolation is a violation of a record validation rule.
+type RecordViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Validation rule, e.g. "schema", "taxonomy", "locators" or "locator_reachability".
+	Rule string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	// Path of the violating field of the record, e.g. "skills[0].name".
+	// Empty if the violation concerns the whole record.
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// Description of the violation.
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViolation) Reset() {
+	*x = RecordViolation{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViolation) ProtoMessage() {}
+
+func (x *RecordViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViolation.ProtoReflect.Descriptor instead.
+func (*RecordViolation) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RecordViolation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RecordViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *RecordViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RankingWeights are the weights of the ranking factors of SCORING_STRATEGY_RANKED.
+// Unset weights default to the weights configured on the server.
+// Weights must not be negative, and at least one weight must be positive.
+type RankingWeights struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Weight of the fraction of queries matched.
+	Match *float64 `protobuf:"fixed64,1,opt,name=match,proto3,oneof" json:"match,omitempty"`
+	// Weight of the freshness of the record's last announcement.
+	Freshness *float64 `protobuf:"fixed64,2,opt,name=freshness,proto3,oneof" json:"freshness,omitempty"`
+	// Weight of the reputation of the providing peer.
+	Reputation *float64 `protobuf:"fixed64,3,opt,name=reputation,proto3,oneof" json:"reputation,omitempty"`
+	// Weight of the number of peers providing the record.
+	Providers     *float64 `protobuf:"fixed64,4,opt,name=providers,proto3,oneof" json:"providers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RankingWeights) Reset() {
+	*x = RankingWeights{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RankingWeights) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RankingWeights) ProtoMessage() {}
+
+func (x *RankingWeights) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RankingWeights.ProtoReflect.Descriptor instead.
+func (*RankingWeights) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RankingWeights) GetMatch() float64 {
+	if x != nil && x.Match != nil {
+		return *x.Match
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetFreshness() float64 {
+	if x != nil && x.Freshness != nil {
+		return *x.Freshness
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetReputation() float64 {
+	if x != nil && x.Reputation != nil {
+		return *x.Reputation
+	}
+	return 0
+}
+
+func (x *RankingWeights) GetProviders() float64 {
+	if x != nil && x.Providers != nil {
+		return *x.Providers
+	}
+	return 0
+}
+
+type UnpublishRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*UnpublishRequest_RecordRefs
+	//	*UnpublishRequest_Queries
+	Request       isUnpublishRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishRequest) Reset() {
+	*x = UnpublishRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishRequest) ProtoMessage() {}
+
+func (x *UnpublishRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UnpublishRequest) GetRequest() isUnpublishRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *UnpublishRequest) GetRecordRefs() *RecordRefs {
+	if x != nil {
+		if x, ok := x.Request.(*UnpublishRequest_RecordRefs); ok {
+			return x.RecordRefs
+		}
+	}
+	return nil
+}
+
+func (x *UnpublishRequest) GetQueries() *RecordQueries {
+	if x != nil {
+		if x, ok := x.Request.(*UnpublishRequest_Queries); ok {
+			return x.Queries
+		}
+	}
+	return nil
+}
+
+type isUnpublishRequest_Request interface {
+	isUnpublishRequest_Request()
+}
+
+type UnpublishRequest_RecordRefs struct {
+	// References to the records to be unpublished.
+	RecordRefs *RecordRefs `protobuf:"bytes,1,opt,name=record_refs,json=recordRefs,proto3,oneof"`
+}
+
+type UnpublishRequest_Queries struct {
+	// Queries to match against the records to be unpublished.
+	Queries *RecordQueries `protobuf:"bytes,2,opt,name=queries,proto3,oneof"`
+}
+
+func (*UnpublishRequest_RecordRefs) isUnpublishRequest_Request() {}
+
+func (*UnpublishRequest_Queries) isUnpublishRequest_Request() {}
+
+type RecordRefs struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Refs          []*v1.RecordRef        `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordRefs) Reset() {
+	*x = RecordRefs{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordRefs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordRefs) ProtoMessage() {}
+
+func (x *RecordRefs) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordRefs.ProtoReflect.Descriptor instead.
+func (*RecordRefs) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RecordRefs) GetRefs() []*v1.RecordRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type RecordQueries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*v11.RecordQuery     `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordQueries) Reset() {
+	*x = RecordQueries{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordQueries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordQueries) ProtoMessage() {}
+
+func (x *RecordQueries) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordQueries.ProtoReflect.Descriptor instead.
+func (*RecordQueries) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RecordQueries) GetQueries() []*v11.RecordQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of queries to match against the records.
+	Queries []*RecordQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	// Minimal target query match score.
+	// For example, if min_match_score=2, it will return records that match
+	// at least two of the queries.
+	// Exact matches of hierarchical queries score two (see RecordQueryMatchMode).
+	// If not set, it will return records that match at least one query.
+	MinMatchScore *uint32 `protobuf:"varint,2,opt,name=min_match_score,json=minMatchScore,proto3,oneof" json:"min_match_score,omitempty"`
+	// Limit the number of results returned.
+	// If not set, it will return all discovered records.
+	// Note that this is a soft limit, as the search may return more results
+	// than the limit if there are multiple peers providing the same record.
+	Limit *uint32 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// Opaque continuation token returned in SearchResponse.next_page_token.
+	// If set, the search resumes right after the result that returned the token
+	// instead of re-scanning all label namespaces from the start.
+	// The token is only valid for the same queries and min_match_score.
+	PageToken *string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"`
+	// If set, the search also queries connected peers directly instead of only
+	// the local cache of their labels, so records they published recently are found.
+	// Live results are merged with cached results and returned after them.
+	// Only the first page of a search queries peers, live results carry no page token.
+	Live bool `protobuf:"varint,5,opt,name=live,proto3" json:"live,omitempty"`
+	// Preferred trade-off between latency and recall.
+	// If not set, the cache is searched as usual and live is honoured.
+	SearchMode SearchMode `protobuf:"varint,6,opt,name=search_mode,json=searchMode,proto3,enum=agntcy.dir.routing.v1.SearchMode" json:"search_mode,omitempty"`
+	// Retrieval method the caller will use to pull the returned records.
+	// If set, records of peers known not to support it are skipped, based on the
+	// protocols peers advertised when they were identified. Peers that were never
+	// identified are not skipped for RETRIEVAL_METHOD_RPC_PULL.
+	RequiredRetrievalMethod RetrievalMethod `protobuf:"varint,7,opt,name=required_retrieval_method,json=requiredRetrievalMethod,proto3,enum=agntcy.dir.routing.v1.RetrievalMethod" json:"required_retrieval_method,omitempty"`
+	// Strategy used to compute the relevance of the returned records.
+	// It does not change which records are returned: min_match_score always
+	// applies to the number of matched queries.
+	ScoringStrategy ScoringStrategy `protobuf:"varint,8,opt,name=scoring_strategy,json=scoringStrategy,proto3,enum=agntcy.dir.routing.v1.ScoringStrategy" json:"scoring_strategy,omitempty"`
+	// DIRQL expression compiled into a query and added to queries, e.g.
+	// skill:"AI/ML" AND (domain:research OR module:tensorflow).
+	// The expression counts as a single query towards min_match_score.
+	// Invalid expressions are rejected with InvalidArgument.
+	Query string `protobuf:"bytes,9,opt,name=query,proto3" json:"query,omitempty"`
+	// If set, records superseded by a newer version announced by the same peer
+	// are not returned, so that only the latest version of each record is found.
+	// Live results are not filtered.
+	LatestOnly bool `protobuf:"varint,10,opt,name=latest_only,json=latestOnly,proto3" json:"latest_only,omitempty"`
+	// Weights of the ranking factors of SCORING_STRATEGY_RANKED for this search.
+	// Ignored by other scoring strategies.
+	RankingWeights *RankingWeights `protobuf:"bytes,11,opt,name=ranking_weights,json=rankingWeights,proto3" json:"ranking_weights,omitempty"`
+	// Locality zones of the caller, e.g. its region. Records of peers that
+	// advertise one of the zones are returned before those of other peers, so
+	// that nearby providers are preferred. Peers advertise their zone when they
+	// are identified and in their DHT provider records.
+	Zones []string `protobuf:"bytes,12,rep,name=zones,proto3" json:"zones,omitempty"`
+	// If set, only records of peers in one of the zones are returned.
+	ZonesOnly bool `protobuf:"varint,13,opt,name=zones_only,json=zonesOnly,proto3" json:"zones_only,omitempty"`
+	// Tenant to search the records of. Searches of a tenant only return records
+	// published for it, unless the tenant is public. Searches without a tenant and
+	// of public tenants return untenanted records and those of public tenants.
+	TenantId string `protobuf:"bytes,14,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// did:key identifier of a publisher. If set, only records announced with a valid
+	// signature of the publisher are returned, regardless of the peer hosting them.
+	// Live results carry no publisher signatures and are not returned.
+	Publisher     string `protobuf:"bytes,15,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchRequest) GetQueries() []*RecordQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetMinMatchScore() uint32 {
+	if x != nil && x.MinMatchScore != nil {
+		return *x.MinMatchScore
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLive() bool {
+	if x != nil {
+		return x.Live
+	}
+	return false
+}
+
+func (x *SearchRequest) GetSearchMode() SearchMode {
+	if x != nil {
+		return x.SearchMode
+	}
+	return SearchMode_SEARCH_MODE_UNSPECIFIED
+}
+
+func (x *SearchRequest) GetRequiredRetrievalMethod() RetrievalMethod {
+	if x != nil {
+		return x.RequiredRetrievalMethod
+	}
+	return RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED
+}
+
+func (x *SearchRequest) GetScoringStrategy() ScoringStrategy {
+	if x != nil {
+		return x.ScoringStrategy
+	}
+	return ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLatestOnly() bool {
+	if x != nil {
+		return x.LatestOnly
+	}
+	return false
+}
+
+func (x *SearchRequest) GetRankingWeights() *RankingWeights {
+	if x != nil {
+		return x.RankingWeights
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetZones() []string {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetZonesOnly() bool {
+	if x != nil {
+		return x.ZonesOnly
+	}
+	return false
+}
+
+func (x *SearchRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+type SearchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The record that matches the search query.
+	RecordRef *v1.RecordRef `protobuf:"bytes,1,opt,name=record_ref,json=recordRef,proto3" json:"record_ref,omitempty"`
+	// The peer that provided the record.
+	Peer *Peer `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
+	// The queries that were matched.
+	MatchQueries []*RecordQuery `protobuf:"bytes,3,rep,name=match_queries,json=matchQueries,proto3" json:"match_queries,omitempty"`
+	// The score of the search match, the number of matched queries,
+	// counting exact matches of hierarchical queries twice.
+	MatchScore uint32 `protobuf:"varint,4,opt,name=match_score,json=matchScore,proto3" json:"match_score,omitempty"`
+	// Opaque continuation token that resumes the search after this result.
+	// Pass it as SearchRequest.page_token to fetch the next page.
+	NextPageToken string `protobuf:"bytes,5,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Relevance of the record computed by the scoring strategy of the request.
+	// Higher is more relevant; results are not ordered by relevance.
+	// Normalized to [0, 1] by SCORING_STRATEGY_RANKED.
+	Relevance float64 `protobuf:"fixed64,6,opt,name=relevance,proto3" json:"relevance,omitempty"`
+	// All remote peers known to provide the record.
+	// The record is matched against the labels announced by any of them.
+	ProviderSet *ProviderSet `protobuf:"bytes,7,opt,name=provider_set,json=providerSet,proto3" json:"provider_set,omitempty"`
+	// Whether the peer announced the record as access-gated, i.e. it only serves
+	// the record's content to peers it authorized.
+	AccessGated bool `protobuf:"varint,8,opt,name=access_gated,json=accessGated,proto3" json:"access_gated,omitempty"`
+	// Digest of the record's canonical content, e.g. "sha256:<hex>", derived from its CID.
+	// Content pulled from the peer must match it.
+	ContentDigest string `protobuf:"bytes,9,opt,name=content_digest,json=contentDigest,proto3" json:"content_digest,omitempty"`
+	// Size in bytes of the record's canonical content, as announced by the peer
+	// or measured when its content was pulled. 0 if unknown.
+	// Content pulled from the peer must match it if set.
+	ContentSize uint64 `protobuf:"varint,10,opt,name=content_size,json=contentSize,proto3" json:"content_size,omitempty"`
+	// Name of the record, as announced by the peer. Empty if unknown.
+	Name string `protobuf:"bytes,11,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the record, as announced by the peer. Empty if unknown.
+	Version string `protobuf:"bytes,12,opt,name=version,proto3" json:"version,omitempty"`
+	// Description of the record truncated to 256 bytes, as announced by the peer.
+	// Empty if unknown. Lets search results be rendered without pulling the record.
+	Description string `protobuf:"bytes,13,opt,name=description,proto3" json:"description,omitempty"`
+	// did:key identifier of the publisher that signed the record's announcement,
+	// empty if the record was published as the peer itself.
+	Publisher     string `protobuf:"bytes,14,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchResponse) GetRecordRef() *v1.RecordRef {
+	if x != nil {
+		return x.RecordRef
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetPeer() *Peer {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetMatchQueries() []*RecordQuery {
+	if x != nil {
+		return x.MatchQueries
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetMatchScore() uint32 {
+	if x != nil {
+		return x.MatchScore
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetRelevance() float64 {
+	if x != nil {
+		return x.Relevance
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetProviderSet() *ProviderSet {
+	if x != nil {
+		return x.ProviderSet
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetAccessGated() bool {
+	if x != nil {
+		return x.AccessGated
+	}
+	return false
+}
+
+func (x *SearchResponse) GetContentDigest() string {
+	if x != nil {
+		return x.ContentDigest
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetContentSize() uint64 {
+	if x != nil {
+		return x.ContentSize
+	}
+	return 0
+}
+
+func (x *SearchResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SearchResponse) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+// ProviderSet aggregates the remote peers that announced the same record.
+type ProviderSet struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Number of remote peers providing the record.
+	ProviderCount uint32 `protobuf:"varint,2,opt,name=provider_count,json=providerCount,proto3" json:"provider_count,omitempty"`
+	// When the record was first announced by any of the providers.
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// When the record was last refreshed by any of the providers.
+	LastSeen *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	// Union of the labels announced by the providers, sorted.
+	Labels        []string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProviderSet) Reset() {
+	*x = ProviderSet{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProviderSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderSet) ProtoMessage() {}
+
+func (x *ProviderSet) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderSet.ProtoReflect.Descriptor instead.
+func (*ProviderSet) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProviderSet) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *ProviderSet) GetProviderCount() uint32 {
+	if x != nil {
+		return x.ProviderCount
+	}
+	return 0
+}
+
+func (x *ProviderSet) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *ProviderSet) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *ProviderSet) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of queries to match against the records.
+	// If set, all queries must match for the record to be returned.
+	Queries []*RecordQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	// Limit the number of results returned.
+	// If not set, it will return all records that this peer is providing.
+	Limit *uint32 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// DIRQL expression compiled into a query and added to queries, e.g.
+	// skill:"AI/ML" AND NOT module:legacy.
+	// Invalid expressions are rejected with InvalidArgument.
+	Query         string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListRequest) GetQueries() []*RecordQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *ListRequest) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The record that matches the list queries.
+	RecordRef *v1.RecordRef `protobuf:"bytes,1,opt,name=record_ref,json=recordRef,proto3" json:"record_ref,omitempty"`
+	// Labels associated with this record (skills, domains, modules)
+	// Derived from the record content for CLI display purposes
+	Labels []string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
+	// When the record was first published by this peer.
+	// Unset for records published before publish times were recorded.
+	PublishedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	// When the record was last announced to the network, by publishing or republishing.
+	// Unset if the record was not announced since this peer started.
+	LastAnnouncedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_announced_at,json=lastAnnouncedAt,proto3" json:"last_announced_at,omitempty"`
+	// Last check of whether other peers resolve the record via the DHT and GossipSub.
+	// Unset if the record was not checked since this peer started.
+	AnnouncementCheck *AnnouncementCheck `protobuf:"bytes,5,opt,name=announcement_check,json=announcementCheck,proto3" json:"announcement_check,omitempty"`
+	// Tenant the record was published for, empty if untenanted.
+	TenantId      string `protobuf:"bytes,6,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListResponse) GetRecordRef() *v1.RecordRef {
+	if x != nil {
+		return x.RecordRef
+	}
+	return nil
+}
+
+func (x *ListResponse) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListResponse) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *ListResponse) GetLastAnnouncedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAnnouncedAt
+	}
+	return nil
+}
+
+func (x *ListResponse) GetAnnouncementCheck() *AnnouncementCheck {
+	if x != nil {
+		return x.AnnouncementCheck
+	}
+	return nil
+}
+
+func (x *ListResponse) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+type EstimateResultsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Approximate number of matching records.
+	EstimatedCount uint64 `protobuf:"varint,1,opt,name=estimated_count,json=estimatedCount,proto3" json:"estimated_count,omitempty"`
+	// Approximate number of records in the label cache visible to the caller's tenant.
+	// Zero if the caller may not discover every label namespace.
+	TotalCount    uint64 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EstimateResultsResponse) Reset() {
+	*x = EstimateResultsResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EstimateResultsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EstimateResultsResponse) ProtoMessage() {}
+
+func (x *EstimateResultsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EstimateResultsResponse.ProtoReflect.Descriptor instead.
+func (*EstimateResultsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EstimateResultsResponse) GetEstimatedCount() uint64 {
+	if x != nil {
+		return x.EstimatedCount
+	}
+	return 0
+}
+
+func (x *EstimateResultsResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of peers per leaderboard.
+	// If not set, 10 peers are returned.
+	Limit         *uint32 `protobuf:"varint,1,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsRequest) ProtoMessage() {}
+
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetStatsRequest) GetLimit() uint32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type GetStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peers with the most labels in the local cache of remote labels.
+	// Value is the number of cached labels.
+	TopLabelCounts []*PeerStat `protobuf:"bytes,1,rep,name=top_label_counts,json=topLabelCounts,proto3" json:"top_label_counts,omitempty"`
+	// Peers with the highest announcement rate.
+	// Value is the number of announcements per hour, averaged over the last hour.
+	TopAnnouncementRates []*PeerStat `protobuf:"bytes,2,rep,name=top_announcement_rates,json=topAnnouncementRates,proto3" json:"top_announcement_rates,omitempty"`
+	// Peers with the most failed record pulls.
+	// Value is the number of failed pulls.
+	TopPullFailures []*PeerStat `protobuf:"bytes,3,rep,name=top_pull_failures,json=topPullFailures,proto3" json:"top_pull_failures,omitempty"`
+	// Local records that the last announcement verification found unresolvable
+	// by other peers, ordered by CID.
+	UnresolvableRecords []*AnnouncementCheck `protobuf:"bytes,4,rep,name=unresolvable_records,json=unresolvableRecords,proto3" json:"unresolvable_records,omitempty"`
+	// Runtime state of this peer, persisted across restarts.
+	RuntimeState *RuntimeState `protobuf:"bytes,5,opt,name=runtime_state,json=runtimeState,proto3" json:"runtime_state,omitempty"`
+	// Peers whose clocks are furthest off the local clock, as estimated from the
+	// timestamps of their GossipSub announcements.
+	// Value is the absolute clock skew in seconds, averaged over recent announcements.
+	TopClockSkews []*PeerStat `protobuf:"bytes,6,rep,name=top_clock_skews,json=topClockSkews,proto3" json:"top_clock_skews,omitempty"`
+	// Remote records with the most providers, ordered by provider count.
+	// Only records announced by more than one peer are included.
+	TopProviderSets []*ProviderSet `protobuf:"bytes,7,rep,name=top_provider_sets,json=topProviderSets,proto3" json:"top_provider_sets,omitempty"`
+	// Discovery profile of this peer.
+	Profile       DiscoveryProfile `protobuf:"varint,8,opt,name=profile,proto3,enum=agntcy.dir.routing.v1.DiscoveryProfile" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatsResponse) ProtoMessage() {}
+
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetStatsResponse) GetTopLabelCounts() []*PeerStat {
+	if x != nil {
+		return x.TopLabelCounts
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetTopAnnouncementRates() []*PeerStat {
+	if x != nil {
+		return x.TopAnnouncementRates
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetTopPullFailures() []*PeerStat {
+	if x != nil {
+		return x.TopPullFailures
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetUnresolvableRecords() []*AnnouncementCheck {
+	if x != nil {
+		return x.UnresolvableRecords
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetRuntimeState() *RuntimeState {
+	if x != nil {
+		return x.RuntimeState
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetTopClockSkews() []*PeerStat {
+	if x != nil {
+		return x.TopClockSkews
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetTopProviderSets() []*ProviderSet {
+	if x != nil {
+		return x.TopProviderSets
+	}
+	return nil
+}
+
+func (x *GetStatsResponse) GetProfile() DiscoveryProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return DiscoveryProfile_DISCOVERY_PROFILE_UNSPECIFIED
+}
+
+// RuntimeState is lightweight runtime state of a peer that is persisted across
+// restarts, so that background tasks are scheduled from when they last ran.
+type RuntimeState struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// When the routing subsystem started.
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// When the previous run stopped.
+	// Unset on first start or if the previous run did not stop gracefully.
+	PreviousStoppedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=previous_stopped_at,json=previousStoppedAt,proto3" json:"previous_stopped_at,omitempty"`
+	// When background tasks last completed, by task name, e.g. "cleanup",
+	// "expired_record_cleanup", or "republish/<cycle>" with the cycle "default",
+	// "highPriority" or the namespace of a republish strategy.
+	LastTaskRuns map[string]*timestamppb.Timestamp `protobuf:"bytes,3,rep,name=last_task_runs,json=lastTaskRuns,proto3" json:"last_task_runs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Number of successful DHT announcements of local records, across restarts.
+	AnnouncementsPublished uint64 `protobuf:"varint,4,opt,name=announcements_published,json=announcementsPublished,proto3" json:"announcements_published,omitempty"`
+	// Number of announcements received from remote peers via DHT and GossipSub, across restarts.
+	AnnouncementsReceived uint64 `protobuf:"varint,5,opt,name=announcements_received,json=announcementsReceived,proto3" json:"announcements_received,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *RuntimeState) Reset() {
+	*x = RuntimeState{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeState) ProtoMessage() {}
+
+func (x *RuntimeState) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeState.ProtoReflect.Descriptor instead.
+func (*RuntimeState) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RuntimeState) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *RuntimeState) GetPreviousStoppedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousStoppedAt
+	}
+	return nil
+}
+
+func (x *RuntimeState) GetLastTaskRuns() map[string]*timestamppb.Timestamp {
+	if x != nil {
+		return x.LastTaskRuns
+	}
+	return nil
+}
+
+func (x *RuntimeState) GetAnnouncementsPublished() uint64 {
+	if x != nil {
+		return x.AnnouncementsPublished
+	}
+	return 0
+}
+
+func (x *RuntimeState) GetAnnouncementsReceived() uint64 {
+	if x != nil {
+		return x.AnnouncementsReceived
+	}
+	return 0
+}
+
+// AnnouncementCheck is the result of verifying that a published record
+// is resolvable by other peers of the network.
+// A transport fails if some of its peers were checked and none resolved the record.
+type AnnouncementCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the local record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Number of peers closest to the CID in the DHT that were asked for its providers.
+	DhtPeersChecked uint32 `protobuf:"varint,2,opt,name=dht_peers_checked,json=dhtPeersChecked,proto3" json:"dht_peers_checked,omitempty"`
+	// Number of checked DHT peers holding a provider record of this peer.
+	DhtPeersResolved uint32 `protobuf:"varint,3,opt,name=dht_peers_resolved,json=dhtPeersResolved,proto3" json:"dht_peers_resolved,omitempty"`
+	// Number of GossipSub topic peers that were asked for the record's labels.
+	GossipPeersChecked uint32 `protobuf:"varint,4,opt,name=gossip_peers_checked,json=gossipPeersChecked,proto3" json:"gossip_peers_checked,omitempty"`
+	// Number of checked GossipSub peers that cached labels of the record from this peer.
+	GossipPeersResolved uint32 `protobuf:"varint,5,opt,name=gossip_peers_resolved,json=gossipPeersResolved,proto3" json:"gossip_peers_resolved,omitempty"`
+	// When the record was checked.
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnnouncementCheck) Reset() {
+	*x = AnnouncementCheck{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnnouncementCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnnouncementCheck) ProtoMessage() {}
+
+func (x *AnnouncementCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnnouncementCheck.ProtoReflect.Descriptor instead.
+func (*AnnouncementCheck) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AnnouncementCheck) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *AnnouncementCheck) GetDhtPeersChecked() uint32 {
+	if x != nil {
+		return x.DhtPeersChecked
+	}
+	return 0
+}
+
+func (x *AnnouncementCheck) GetDhtPeersResolved() uint32 {
+	if x != nil {
+		return x.DhtPeersResolved
+	}
+	return 0
+}
+
+func (x *AnnouncementCheck) GetGossipPeersChecked() uint32 {
+	if x != nil {
+		return x.GossipPeersChecked
+	}
+	return 0
+}
+
+func (x *AnnouncementCheck) GetGossipPeersResolved() uint32 {
+	if x != nil {
+		return x.GossipPeersResolved
+	}
+	return 0
+}
+
+func (x *AnnouncementCheck) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+type PinRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// References to the records to pin.
+	// The records must be published by this peer or have labels in the local cache of remote labels.
+	Refs []*v1.RecordRef `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	// Also pull the remote records from one of their providers into the local store.
+	// Ignored for local records.
+	MirrorContent bool `protobuf:"varint,2,opt,name=mirror_content,json=mirrorContent,proto3" json:"mirror_content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinRequest) Reset() {
+	*x = PinRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinRequest) ProtoMessage() {}
+
+func (x *PinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinRequest.ProtoReflect.Descriptor instead.
+func (*PinRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PinRequest) GetRefs() []*v1.RecordRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+func (x *PinRequest) GetMirrorContent() bool {
+	if x != nil {
+		return x.MirrorContent
+	}
+	return false
+}
+
+type UnpinRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// References to the records to unpin.
+	Refs          []*v1.RecordRef `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinRequest) Reset() {
+	*x = UnpinRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinRequest) ProtoMessage() {}
+
+func (x *UnpinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinRequest.ProtoReflect.Descriptor instead.
+func (*UnpinRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UnpinRequest) GetRefs() []*v1.RecordRef {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
+type ListPinsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinsRequest) Reset() {
+	*x = ListPinsRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinsRequest) ProtoMessage() {}
+
+func (x *ListPinsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinsRequest.ProtoReflect.Descriptor instead.
+func (*ListPinsRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{21}
+}
+
+type ListPinsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the pinned record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// When the record was pinned.
+	PinnedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	// Whether the record was mirrored into the local store.
+	Mirrored bool `protobuf:"varint,3,opt,name=mirrored,proto3" json:"mirrored,omitempty"`
+	// Whether the record is published by this peer.
+	Local         bool `protobuf:"varint,4,opt,name=local,proto3" json:"local,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinsResponse) Reset() {
+	*x = ListPinsResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinsResponse) ProtoMessage() {}
+
+func (x *ListPinsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinsResponse.ProtoReflect.Descriptor instead.
+func (*ListPinsResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPinsResponse) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *ListPinsResponse) GetPinnedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return nil
+}
+
+func (x *ListPinsResponse) GetMirrored() bool {
+	if x != nil {
+		return x.Mirrored
+	}
+	return false
+}
+
+func (x *ListPinsResponse) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+type VerifyCacheRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of cached remote records to verify.
+	// If not set, 100 records are verified. At most 1000 records are verified.
+	SampleSize *uint32 `protobuf:"varint,1,opt,name=sample_size,json=sampleSize,proto3,oneof" json:"sample_size,omitempty"`
+	// Evict the cached labels of records that their providers no longer store.
+	Evict         bool `protobuf:"varint,2,opt,name=evict,proto3" json:"evict,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCacheRequest) Reset() {
+	*x = VerifyCacheRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCacheRequest) ProtoMessage() {}
+
+func (x *VerifyCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCacheRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCacheRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifyCacheRequest) GetSampleSize() uint32 {
+	if x != nil && x.SampleSize != nil {
+		return *x.SampleSize
+	}
+	return 0
+}
+
+func (x *VerifyCacheRequest) GetEvict() bool {
+	if x != nil {
+		return x.Evict
+	}
+	return false
+}
+
+type VerifyCacheResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of remote records in the label cache.
+	CachedRecords uint32 `protobuf:"varint,1,opt,name=cached_records,json=cachedRecords,proto3" json:"cached_records,omitempty"`
+	// Number of sampled records whose providers answered.
+	Verified uint32 `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+	// Number of verified records that their providers still store.
+	Present uint32 `protobuf:"varint,3,opt,name=present,proto3" json:"present,omitempty"`
+	// Number of verified records that their providers no longer store.
+	Missing uint32 `protobuf:"varint,4,opt,name=missing,proto3" json:"missing,omitempty"`
+	// Number of sampled records whose providers could not be reached.
+	Unreachable uint32 `protobuf:"varint,5,opt,name=unreachable,proto3" json:"unreachable,omitempty"`
+	// Number of cached labels evicted. Always zero unless evict is set.
+	EvictedLabels uint32 `protobuf:"varint,6,opt,name=evicted_labels,json=evictedLabels,proto3" json:"evicted_labels,omitempty"`
+	// Records that their providers no longer store, ordered by CID.
+	MissingRecords []*CachedRecord `protobuf:"bytes,7,rep,name=missing_records,json=missingRecords,proto3" json:"missing_records,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VerifyCacheResponse) Reset() {
+	*x = VerifyCacheResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCacheResponse) ProtoMessage() {}
+
+func (x *VerifyCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCacheResponse.ProtoReflect.Descriptor instead.
+func (*VerifyCacheResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VerifyCacheResponse) GetCachedRecords() uint32 {
+	if x != nil {
+		return x.CachedRecords
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetVerified() uint32 {
+	if x != nil {
+		return x.Verified
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetPresent() uint32 {
+	if x != nil {
+		return x.Present
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetMissing() uint32 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetUnreachable() uint32 {
+	if x != nil {
+		return x.Unreachable
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetEvictedLabels() uint32 {
+	if x != nil {
+		return x.EvictedLabels
+	}
+	return 0
+}
+
+func (x *VerifyCacheResponse) GetMissingRecords() []*CachedRecord {
+	if x != nil {
+		return x.MissingRecords
+	}
+	return nil
+}
+
+// CachedRecord is a remote record in the local cache of remote labels.
+type CachedRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// ID of the peer that announced the record.
+	PeerId        string `protobuf:"bytes,2,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CachedRecord) Reset() {
+	*x = CachedRecord{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CachedRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedRecord) ProtoMessage() {}
+
+func (x *CachedRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedRecord.ProtoReflect.Descriptor instead.
+func (*CachedRecord) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CachedRecord) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *CachedRecord) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+type SetProfileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Discovery profile to switch to.
+	Profile       DiscoveryProfile `protobuf:"varint,1,opt,name=profile,proto3,enum=agntcy.dir.routing.v1.DiscoveryProfile" json:"profile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProfileRequest) Reset() {
+	*x = SetProfileRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProfileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProfileRequest) ProtoMessage() {}
+
+func (x *SetProfileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProfileRequest.ProtoReflect.Descriptor instead.
+func (*SetProfileRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *SetProfileRequest) GetProfile() DiscoveryProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return DiscoveryProfile_DISCOVERY_PROFILE_UNSPECIFIED
+}
+
+type SetProfileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Discovery profile of the peer before the switch.
+	PreviousProfile DiscoveryProfile `protobuf:"varint,1,opt,name=previous_profile,json=previousProfile,proto3,enum=agntcy.dir.routing.v1.DiscoveryProfile" json:"previous_profile,omitempty"`
+	// Whether the DHT mode of the new profile differs from the running DHT mode.
+	// The DHT mode only changes after a restart with the profile configured.
+	RestartRequired bool `protobuf:"varint,2,opt,name=restart_required,json=restartRequired,proto3" json:"restart_required,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SetProfileResponse) Reset() {
+	*x = SetProfileResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProfileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProfileResponse) ProtoMessage() {}
+
+func (x *SetProfileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProfileResponse.ProtoReflect.Descriptor instead.
+func (*SetProfileResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetProfileResponse) GetPreviousProfile() DiscoveryProfile {
+	if x != nil {
+		return x.PreviousProfile
+	}
+	return DiscoveryProfile_DISCOVERY_PROFILE_UNSPECIFIED
+}
+
+func (x *SetProfileResponse) GetRestartRequired() bool {
+	if x != nil {
+		return x.RestartRequired
+	}
+	return false
+}
+
+type GetHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only return points starting at or after this time.
+	// If not set, all retained points are returned.
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	// Only return points starting before this time.
+	// If not set, points up to now are returned.
+	Until         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryRequest) Reset() {
+	*x = GetHistoryRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryRequest) ProtoMessage() {}
+
+func (x *GetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetHistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetHistoryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type GetHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Retained points, ordered by start time. Hours without samples have no point.
+	Points []*HistoryPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	// Time covered by each point.
+	Resolution *durationpb.Duration `protobuf:"bytes,2,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	// How long points are retained.
+	Retention     *durationpb.Duration `protobuf:"bytes,3,opt,name=retention,proto3" json:"retention,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetHistoryResponse) GetPoints() []*HistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetResolution() *durationpb.Duration {
+	if x != nil {
+		return x.Resolution
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetRetention() *durationpb.Duration {
+	if x != nil {
+		return x.Retention
+	}
+	return nil
+}
+
+// HistoryPoint is a downsampled point of the discovery metrics of a peer.
+// Counters are totals over the point; gauges are averages of the samples taken.
+type HistoryPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Start of the time covered by the point.
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// Number of samples taken during the point.
+	Samples uint32 `protobuf:"varint,2,opt,name=samples,proto3" json:"samples,omitempty"`
+	// Number of successful DHT announcements of local records.
+	AnnouncementsPublished uint64 `protobuf:"varint,3,opt,name=announcements_published,json=announcementsPublished,proto3" json:"announcements_published,omitempty"`
+	// Number of announcements received from remote peers.
+	AnnouncementsReceived uint64 `protobuf:"varint,4,opt,name=announcements_received,json=announcementsReceived,proto3" json:"announcements_received,omitempty"`
+	// Number of searches served.
+	Searches uint64 `protobuf:"varint,5,opt,name=searches,proto3" json:"searches,omitempty"`
+	// Average number of labels in the local cache of remote labels.
+	CachedLabels float64 `protobuf:"fixed64,6,opt,name=cached_labels,json=cachedLabels,proto3" json:"cached_labels,omitempty"`
+	// Average number of remote records in the local cache of remote labels.
+	CachedRecords float64 `protobuf:"fixed64,7,opt,name=cached_records,json=cachedRecords,proto3" json:"cached_records,omitempty"`
+	// Average number of connected peers.
+	ConnectedPeers float64 `protobuf:"fixed64,8,opt,name=connected_peers,json=connectedPeers,proto3" json:"connected_peers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *HistoryPoint) Reset() {
+	*x = HistoryPoint{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryPoint) ProtoMessage() {}
+
+func (x *HistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryPoint.ProtoReflect.Descriptor instead.
+func (*HistoryPoint) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *HistoryPoint) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *HistoryPoint) GetSamples() uint32 {
+	if x != nil {
+		return x.Samples
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetAnnouncementsPublished() uint64 {
+	if x != nil {
+		return x.AnnouncementsPublished
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetAnnouncementsReceived() uint64 {
+	if x != nil {
+		return x.AnnouncementsReceived
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetSearches() uint64 {
+	if x != nil {
+		return x.Searches
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetCachedLabels() float64 {
+	if x != nil {
+		return x.CachedLabels
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetCachedRecords() float64 {
+	if x != nil {
+		return x.CachedRecords
+	}
+	return 0
+}
+
+func (x *HistoryPoint) GetConnectedPeers() float64 {
+	if x != nil {
+		return x.ConnectedPeers
+	}
+	return 0
+}
+
+type GrantAccessRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer authorized to pull.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// CID of the only record the token authorizes.
+	// If not set, the token authorizes all access-gated records of this peer.
+	Cid string `protobuf:"bytes,2,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Validity of the token.
+	// If not set, the token is valid for 30 days.
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantAccessRequest) Reset() {
+	*x = GrantAccessRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantAccessRequest) ProtoMessage() {}
+
+func (x *GrantAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantAccessRequest.ProtoReflect.Descriptor instead.
+func (*GrantAccessRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GrantAccessRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) GetCid() string {
+	if x != nil {
+		return x.Cid
+	}
+	return ""
+}
+
+func (x *GrantAccessRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type GrantAccessResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Encoded access token.
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Time when the token expires.
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantAccessResponse) Reset() {
+	*x = GrantAccessResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantAccessResponse) ProtoMessage() {}
+
+func (x *GrantAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantAccessResponse.ProtoReflect.Descriptor instead.
+func (*GrantAccessResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GrantAccessResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GrantAccessResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// PeerStat is a single entry of a peer leaderboard.
+type PeerStat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// Value of the statistic for the peer.
+	Value         float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStat) Reset() {
+	*x = PeerStat{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStat) ProtoMessage() {}
+
+func (x *PeerStat) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStat.ProtoReflect.Descriptor instead.
+func (*PeerStat) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PeerStat) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+func (x *PeerStat) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ExportStateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether to include the content of the exported records held by the local store.
+	// Access-gated records of this peer are never included.
+	IncludeRecords bool `protobuf:"varint,1,opt,name=include_records,json=includeRecords,proto3" json:"include_records,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExportStateRequest) Reset() {
+	*x = ExportStateRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStateRequest) ProtoMessage() {}
+
+func (x *ExportStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStateRequest.ProtoReflect.Descriptor instead.
+func (*ExportStateRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ExportStateRequest) GetIncludeRecords() bool {
+	if x != nil {
+		return x.IncludeRecords
+	}
+	return false
+}
+
+// StateArchiveChunk is a chunk of a state archive, in order.
+type StateArchiveChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateArchiveChunk) Reset() {
+	*x = StateArchiveChunk{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateArchiveChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateArchiveChunk) ProtoMessage() {}
+
+func (x *StateArchiveChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateArchiveChunk.ProtoReflect.Descriptor instead.
+func (*StateArchiveChunk) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *StateArchiveChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportStateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the peer that exported the archive.
+	ExportedBy string `protobuf:"bytes,1,opt,name=exported_by,json=exportedBy,proto3" json:"exported_by,omitempty"`
+	// Time when the archive was exported.
+	ExportedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	// Number of label entries imported into the label cache.
+	LabelsImported uint32 `protobuf:"varint,3,opt,name=labels_imported,json=labelsImported,proto3" json:"labels_imported,omitempty"`
+	// Number of label entries skipped: already cached, stale, expired, revoked,
+	// or published by this peer.
+	LabelsSkipped uint32 `protobuf:"varint,4,opt,name=labels_skipped,json=labelsSkipped,proto3" json:"labels_skipped,omitempty"`
+	// Number of records stored in the local store.
+	RecordsImported uint32 `protobuf:"varint,5,opt,name=records_imported,json=recordsImported,proto3" json:"records_imported,omitempty"`
+	// Number of records skipped as they were already stored.
+	RecordsSkipped uint32 `protobuf:"varint,6,opt,name=records_skipped,json=recordsSkipped,proto3" json:"records_skipped,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ImportStateResponse) Reset() {
+	*x = ImportStateResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStateResponse) ProtoMessage() {}
+
+func (x *ImportStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStateResponse.ProtoReflect.Descriptor instead.
+func (*ImportStateResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ImportStateResponse) GetExportedBy() string {
+	if x != nil {
+		return x.ExportedBy
+	}
+	return ""
+}
+
+func (x *ImportStateResponse) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+func (x *ImportStateResponse) GetLabelsImported() uint32 {
+	if x != nil {
+		return x.LabelsImported
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetLabelsSkipped() uint32 {
+	if x != nil {
+		return x.LabelsSkipped
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetRecordsImported() uint32 {
+	if x != nil {
+		return x.RecordsImported
+	}
+	return 0
+}
+
+func (x *ImportStateResponse) GetRecordsSkipped() uint32 {
+	if x != nil {
+		return x.RecordsSkipped
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of queries to match against the records, as in SearchRequest.
+	Queries []*RecordQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	// Minimal target query match score, as in SearchRequest.
+	// If not set, records matching at least one query are pushed.
+	MinMatchScore *uint32 `protobuf:"varint,2,opt,name=min_match_score,json=minMatchScore,proto3,oneof" json:"min_match_score,omitempty"`
+	// DIRQL expression compiled into a query and added to queries, as in SearchRequest.
+	Query string `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	// Tenant to subscribe to the records of, as in SearchRequest.
+	TenantId string `protobuf:"bytes,4,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// did:key identifier of a publisher. If set, only records announced with a
+	// valid signature of the publisher are pushed.
+	Publisher     string `protobuf:"bytes,5,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubscribeRequest) GetQueries() []*RecordQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetMinMatchScore() uint32 {
+	if x != nil && x.MinMatchScore != nil {
+		return *x.MinMatchScore
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+type GetTaxonomyRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Request:
-	//
-	//	*UnpublishRequest_RecordRefs
-	//	*UnpublishRequest_Queries
-	Request       isUnpublishRequest_Request `protobuf_oneof:"request"`
+	// Label namespaces to return the values of, e.g. "skills".
+	// If empty, all namespaces of the taxonomy are returned.
+	Namespaces []string `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	// Only return values starting with this prefix, e.g. "natural_language_processing/".
+	// Values are matched case-insensitively.
+	Prefix        string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnpublishRequest) Reset() {
-	*x = UnpublishRequest{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[1]
+func (x *GetTaxonomyRequest) Reset() {
+	*x = GetTaxonomyRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnpublishRequest) String() string {
+func (x *GetTaxonomyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnpublishRequest) ProtoMessage() {}
+func (*GetTaxonomyRequest) ProtoMessage() {}
 
-func (x *UnpublishRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[1]
+func (x *GetTaxonomyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,76 +3359,50 @@ func (x *UnpublishRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnpublishRequest.ProtoReflect.Descriptor instead.
-func (*UnpublishRequest) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *UnpublishRequest) GetRequest() isUnpublishRequest_Request {
-	if x != nil {
-		return x.Request
-	}
-	return nil
+// Deprecated: Use GetTaxonomyRequest.ProtoReflect.Descriptor instead.
+func (*GetTaxonomyRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *UnpublishRequest) GetRecordRefs() *RecordRefs {
+func (x *GetTaxonomyRequest) GetNamespaces() []string {
 	if x != nil {
-		if x, ok := x.Request.(*UnpublishRequest_RecordRefs); ok {
-			return x.RecordRefs
-		}
+		return x.Namespaces
 	}
 	return nil
 }
 
-func (x *UnpublishRequest) GetQueries() *RecordQueries {
+func (x *GetTaxonomyRequest) GetPrefix() string {
 	if x != nil {
-		if x, ok := x.Request.(*UnpublishRequest_Queries); ok {
-			return x.Queries
-		}
+		return x.Prefix
 	}
-	return nil
-}
-
-type isUnpublishRequest_Request interface {
-	isUnpublishRequest_Request()
-}
-
-type UnpublishRequest_RecordRefs struct {
-	// References to the records to be unpublished.
-	RecordRefs *RecordRefs `protobuf:"bytes,1,opt,name=record_refs,json=recordRefs,proto3,oneof"`
+	return ""
 }
 
-type UnpublishRequest_Queries struct {
-	// Queries to match against the records to be unpublished.
-	Queries *RecordQueries `protobuf:"bytes,2,opt,name=queries,proto3,oneof"`
-}
-
-func (*UnpublishRequest_RecordRefs) isUnpublishRequest_Request() {}
-
-func (*UnpublishRequest_Queries) isUnpublishRequest_Request() {}
-
-type RecordRefs struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Refs          []*v1.RecordRef        `protobuf:"bytes,1,rep,name=refs,proto3" json:"refs,omitempty"`
+type GetTaxonomyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version of the taxonomy, e.g. the OASF version its ontologies were taken from.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Requested namespaces of the taxonomy, ordered by name.
+	Namespaces    []*TaxonomyNamespace `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordRefs) Reset() {
-	*x = RecordRefs{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[2]
+func (x *GetTaxonomyResponse) Reset() {
+	*x = GetTaxonomyResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordRefs) String() string {
+func (x *GetTaxonomyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordRefs) ProtoMessage() {}
+func (*GetTaxonomyResponse) ProtoMessage() {}
 
-func (x *RecordRefs) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[2]
+func (x *GetTaxonomyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,40 +3413,52 @@ func (x *RecordRefs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordRefs.ProtoReflect.Descriptor instead.
-func (*RecordRefs) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetTaxonomyResponse.ProtoReflect.Descriptor instead.
+func (*GetTaxonomyResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *RecordRefs) GetRefs() []*v1.RecordRef {
+func (x *GetTaxonomyResponse) GetVersion() string {
 	if x != nil {
-		return x.Refs
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetTaxonomyResponse) GetNamespaces() []*TaxonomyNamespace {
+	if x != nil {
+		return x.Namespaces
 	}
 	return nil
 }
 
-type RecordQueries struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Queries       []*v11.RecordQuery     `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+// TaxonomyNamespace holds the canonical values of a label namespace.
+type TaxonomyNamespace struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the namespace, e.g. "skills".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Canonical label values of the namespace, without the namespace prefix, in order.
+	// Labels are also valid if their value is a parent of a value, e.g. a skill category.
+	Values        []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordQueries) Reset() {
-	*x = RecordQueries{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[3]
+func (x *TaxonomyNamespace) Reset() {
+	*x = TaxonomyNamespace{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordQueries) String() string {
+func (x *TaxonomyNamespace) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordQueries) ProtoMessage() {}
+func (*TaxonomyNamespace) ProtoMessage() {}
 
-func (x *RecordQueries) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[3]
+func (x *TaxonomyNamespace) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -271,51 +3469,48 @@ func (x *RecordQueries) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordQueries.ProtoReflect.Descriptor instead.
-func (*RecordQueries) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use TaxonomyNamespace.ProtoReflect.Descriptor instead.
+func (*TaxonomyNamespace) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *RecordQueries) GetQueries() []*v11.RecordQuery {
+func (x *TaxonomyNamespace) GetName() string {
 	if x != nil {
-		return x.Queries
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaxonomyNamespace) GetValues() []string {
+	if x != nil {
+		return x.Values
 	}
 	return nil
 }
 
-type SearchRequest struct {
+type GetProvenanceRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of queries to match against the records.
-	Queries []*RecordQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
-	// Minimal target query match score.
-	// For example, if min_match_score=2, it will return records that match
-	// at least two of the queries.
-	// If not set, it will return records that match at least one query.
-	MinMatchScore *uint32 `protobuf:"varint,2,opt,name=min_match_score,json=minMatchScore,proto3,oneof" json:"min_match_score,omitempty"`
-	// Limit the number of results returned.
-	// If not set, it will return all discovered records.
-	// Note that this is a soft limit, as the search may return more results
-	// than the limit if there are multiple peers providing the same record.
-	Limit         *uint32 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// CID of the record.
+	Cid           string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchRequest) Reset() {
-	*x = SearchRequest{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[4]
+func (x *GetProvenanceRequest) Reset() {
+	*x = GetProvenanceRequest{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchRequest) String() string {
+func (x *GetProvenanceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchRequest) ProtoMessage() {}
+func (*GetProvenanceRequest) ProtoMessage() {}
 
-func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[4]
+func (x *GetProvenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,61 +3521,49 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
-func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetProvenanceRequest.ProtoReflect.Descriptor instead.
+func (*GetProvenanceRequest) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *SearchRequest) GetQueries() []*RecordQuery {
+func (x *GetProvenanceRequest) GetCid() string {
 	if x != nil {
-		return x.Queries
-	}
-	return nil
-}
-
-func (x *SearchRequest) GetMinMatchScore() uint32 {
-	if x != nil && x.MinMatchScore != nil {
-		return *x.MinMatchScore
+		return x.Cid
 	}
-	return 0
-}
-
-func (x *SearchRequest) GetLimit() uint32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
-	}
-	return 0
+	return ""
 }
 
-type SearchResponse struct {
+type GetProvenanceResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The record that matches the search query.
-	RecordRef *v1.RecordRef `protobuf:"bytes,1,opt,name=record_ref,json=recordRef,proto3" json:"record_ref,omitempty"`
-	// The peer that provided the record.
-	Peer *Peer `protobuf:"bytes,2,opt,name=peer,proto3" json:"peer,omitempty"`
-	// The queries that were matched.
-	MatchQueries []*RecordQuery `protobuf:"bytes,3,rep,name=match_queries,json=matchQueries,proto3" json:"match_queries,omitempty"`
-	// The score of the search match.
-	MatchScore    uint32 `protobuf:"varint,4,opt,name=match_score,json=matchScore,proto3" json:"match_score,omitempty"`
+	// CID of the record.
+	Cid string `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Earliest logged announcement of the record.
+	// It is kept when older announcements are pruned from the log.
+	FirstAnnouncement *ProvenanceEntry `protobuf:"bytes,2,opt,name=first_announcement,json=firstAnnouncement,proto3" json:"first_announcement,omitempty"`
+	// IDs of the peers currently providing the record, sorted,
+	// including this peer if it publishes the record.
+	Providers []string `protobuf:"bytes,3,rep,name=providers,proto3" json:"providers,omitempty"`
+	// Logged announcements of the record, ordered by the time they were received.
+	Announcements []*ProvenanceEntry `protobuf:"bytes,4,rep,name=announcements,proto3" json:"announcements,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchResponse) Reset() {
-	*x = SearchResponse{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[5]
+func (x *GetProvenanceResponse) Reset() {
+	*x = GetProvenanceResponse{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchResponse) String() string {
+func (x *GetProvenanceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResponse) ProtoMessage() {}
+func (*GetProvenanceResponse) ProtoMessage() {}
 
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[5]
+func (x *GetProvenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -391,66 +3574,77 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GetProvenanceResponse.ProtoReflect.Descriptor instead.
+func (*GetProvenanceResponse) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *SearchResponse) GetRecordRef() *v1.RecordRef {
+func (x *GetProvenanceResponse) GetCid() string {
 	if x != nil {
-		return x.RecordRef
+		return x.Cid
 	}
-	return nil
+	return ""
 }
 
-func (x *SearchResponse) GetPeer() *Peer {
+func (x *GetProvenanceResponse) GetFirstAnnouncement() *ProvenanceEntry {
 	if x != nil {
-		return x.Peer
+		return x.FirstAnnouncement
 	}
 	return nil
 }
 
-func (x *SearchResponse) GetMatchQueries() []*RecordQuery {
+func (x *GetProvenanceResponse) GetProviders() []string {
 	if x != nil {
-		return x.MatchQueries
+		return x.Providers
 	}
 	return nil
 }
 
-func (x *SearchResponse) GetMatchScore() uint32 {
+func (x *GetProvenanceResponse) GetAnnouncements() []*ProvenanceEntry {
 	if x != nil {
-		return x.MatchScore
+		return x.Announcements
 	}
-	return 0
+	return nil
 }
 
-type ListRequest struct {
+// ProvenanceEntry is an announcement of a record received by a peer.
+type ProvenanceEntry struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of queries to match against the records.
-	// If set, all queries must match for the record to be returned.
-	Queries []*RecordQuery `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
-	// Limit the number of results returned.
-	// If not set, it will return all records that this peer is providing.
-	Limit         *uint32 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	// ID of the peer that announced the record.
+	PeerId string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	// How the announcement was received: "local" for records published by this peer,
+	// "gossipsub", "dht" (pulled after a DHT provider notification) or "label_sync".
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// When the announcement was received, in the local clock.
+	ReceivedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	// When the record was announced, as reported by the announcing peer.
+	AnnouncedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=announced_at,json=announcedAt,proto3" json:"announced_at,omitempty"`
+	// did:key identifier of the publisher that signed the announcement,
+	// empty if the record was published as the peer itself.
+	Publisher string `protobuf:"bytes,5,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	// Whether the announcement was signed with the identity key of the announcing peer.
+	Signed bool `protobuf:"varint,6,opt,name=signed,proto3" json:"signed,omitempty"`
+	// CID of the previous version of the record, empty if it does not supersede another one.
+	Supersedes    string `protobuf:"bytes,7,opt,name=supersedes,proto3" json:"supersedes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRequest) Reset() {
-	*x = ListRequest{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[6]
+func (x *ProvenanceEntry) Reset() {
+	*x = ProvenanceEntry{}
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRequest) String() string {
+func (x *ProvenanceEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRequest) ProtoMessage() {}
+func (*ProvenanceEntry) ProtoMessage() {}
 
-func (x *ListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[6]
+func (x *ProvenanceEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -461,78 +3655,58 @@ func (x *ListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
-func (*ListRequest) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ProvenanceEntry.ProtoReflect.Descriptor instead.
+func (*ProvenanceEntry) Descriptor() ([]byte, []int) {
+	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListRequest) GetQueries() []*RecordQuery {
+func (x *ProvenanceEntry) GetPeerId() string {
 	if x != nil {
-		return x.Queries
+		return x.PeerId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListRequest) GetLimit() uint32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
+func (x *ProvenanceEntry) GetSource() string {
+	if x != nil {
+		return x.Source
 	}
-	return 0
-}
-
-type ListResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// The record that matches the list queries.
-	RecordRef *v1.RecordRef `protobuf:"bytes,1,opt,name=record_ref,json=recordRef,proto3" json:"record_ref,omitempty"`
-	// Labels associated with this record (skills, domains, modules)
-	// Derived from the record content for CLI display purposes
-	Labels        []string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListResponse) Reset() {
-	*x = ListResponse{}
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[7]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *ListResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ProvenanceEntry) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
 }
 
-func (*ListResponse) ProtoMessage() {}
-
-func (x *ListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[7]
+func (x *ProvenanceEntry) GetAnnouncedAt() *timestamppb.Timestamp {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.AnnouncedAt
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
-func (*ListResponse) Descriptor() ([]byte, []int) {
-	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP(), []int{7}
+func (x *ProvenanceEntry) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
 }
 
-func (x *ListResponse) GetRecordRef() *v1.RecordRef {
+func (x *ProvenanceEntry) GetSigned() bool {
 	if x != nil {
-		return x.RecordRef
+		return x.Signed
 	}
-	return nil
+	return false
 }
 
-func (x *ListResponse) GetLabels() []string {
+func (x *ProvenanceEntry) GetSupersedes() string {
 	if x != nil {
-		return x.Labels
+		return x.Supersedes
 	}
-	return nil
+	return ""
 }
 
 var File_agntcy_dir_routing_v1_routing_service_proto protoreflect.FileDescriptor
@@ -551,50 +3725,163 @@ var file_agntcy_dir_routing_v1_routing_service_proto_rawDesc = string([]byte{
 	0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x27, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69, 0x72, 0x2f, 0x73, 0x65,
 	0x61, 0x72, 0x63, 0x68, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x71,
-	0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
+	0x75, 0x65, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74,
-	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa3, 0x01, 0x0a, 0x0e, 0x50, 0x75, 0x62, 0x6c,
-	0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0b, 0x72, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
-	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65,
-	0x66, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x73,
-	0x12, 0x40, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72,
-	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69,
-	0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa5, 0x01,
-	0x0a, 0x10, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x44, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
-	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x73, 0x12, 0x40, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72,
-	0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74,
-	0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x48,
-	0x00, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
-	0x65, 0x66, 0x73, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66,
-	0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x4c, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
-	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65,
-	0x72, 0x69, 0x65, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
-	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65,
-	0x72, 0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63,
-	0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52,
-	0x0d, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x88, 0x01,
-	0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10,
-	0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
-	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xe9, 0x01, 0x0a, 0x0e, 0x53,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe2, 0x03, 0x0a, 0x0e, 0x50, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0b, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x66, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66,
+	0x73, 0x12, 0x40, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2b, 0x0a, 0x03,
+	0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x70,
+	0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x75, 0x70, 0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x5f, 0x67, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x47, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x69,
+	0x65, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5f, 0x0a,
+	0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x22, 0xd9,
+	0x02, 0x0a, 0x0f, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x63, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x73, 0x68, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12,
+	0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x70, 0x65, 0x65, 0x72, 0x73, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x72,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70,
+	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x46, 0x0a, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x73, 0x0a, 0x17, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x46, 0x0a, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0x55, 0x0a, 0x0f, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0e, 0x52, 0x61, 0x6e, 0x6b, 0x69,
+	0x6e, 0x67, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x19, 0x0a, 0x05, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x05, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x6e, 0x65, 0x73, 0x73, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x75, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52, 0x0a, 0x72,
+	0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48,
+	0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x88, 0x01, 0x01, 0x42,
+	0x08, 0x0a, 0x06, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x72, 0x65, 0x70, 0x75,
+	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x73, 0x22, 0xa5, 0x01, 0x0a, 0x10, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x0b, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66,
+	0x73, 0x48, 0x00, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x73, 0x12,
+	0x40, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51,
+	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x0a,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x73, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x65,
+	0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x4c, 0x0a,
+	0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3b,
+	0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x22, 0xec, 0x05, 0x0a, 0x0d,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x0f, 0x6d,
+	0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x4d, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x88, 0x01, 0x01, 0x12, 0x22, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x76, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x62, 0x0a, 0x19, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x76, 0x61, 0x6c, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x26, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x76, 0x61, 0x6c, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x17, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x51, 0x0a, 0x10, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72,
+	0x61, 0x74, 0x65, 0x67, 0x79, 0x52, 0x0f, 0x73, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x4e, 0x0a,
+	0x0f, 0x72, 0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x0e, 0x72,
+	0x61, 0x6e, 0x6b, 0x69, 0x6e, 0x67, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x7a, 0x6f,
+	0x6e, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x7a, 0x6f, 0x6e, 0x65, 0x73, 0x4f, 0x6e,
+	0x6c, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x42, 0x12, 0x0a,
+	0x10, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x0d, 0x0a, 0x0b, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xd1, 0x04, 0x0a, 0x0e, 0x53,
 	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
 	0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63,
@@ -609,55 +3896,570 @@ var file_agntcy_dir_routing_v1_routing_service_proto_rawDesc = string([]byte{
 	0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x51, 0x75,
 	0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73,
 	0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x61, 0x74, 0x63,
-	0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x70, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
-	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72,
-	0x69, 0x65, 0x73, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0d, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x42, 0x08,
-	0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x64, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61,
-	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x52, 0x09, 0x72, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x32, 0xd4,
-	0x02, 0x0a, 0x0e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x48, 0x0a, 0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x25, 0x2e, 0x61,
+	0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x09, 0x72, 0x65, 0x6c, 0x65, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x45, 0x0a, 0x0c,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x67, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x47, 0x61, 0x74, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x22, 0xd2,
+	0x01, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x53, 0x65,
+	0x65, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0xe1, 0x02, 0x0a,
+	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x72, 0x65, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66,
+	0x52, 0x09, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x46, 0x0a, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x64, 0x41, 0x74, 0x12, 0x57, 0x0a, 0x12, 0x61, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x52, 0x11, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64,
+	0x22, 0x63, 0x0a, 0x17, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x65,
+	0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x36, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x84, 0x05,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x74, 0x6f, 0x70, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x0e, 0x74,
+	0x6f, 0x70, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x55, 0x0a,
+	0x16, 0x74, 0x6f, 0x70, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x52, 0x14,
+	0x74, 0x6f, 0x70, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x61, 0x74, 0x65, 0x73, 0x12, 0x4b, 0x0a, 0x11, 0x74, 0x6f, 0x70, 0x5f, 0x70, 0x75, 0x6c, 0x6c,
+	0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x52, 0x0f, 0x74, 0x6f, 0x70, 0x50, 0x75, 0x6c, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x73, 0x12, 0x5b, 0x0a, 0x14, 0x75, 0x6e, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65,
+	0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x13, 0x75, 0x6e, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x48,
+	0x0a, 0x0d, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x0f, 0x74, 0x6f, 0x70, 0x5f,
+	0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x52, 0x0d, 0x74, 0x6f, 0x70, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77,
+	0x73, 0x12, 0x4e, 0x0a, 0x11, 0x74, 0x6f, 0x70, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65,
+	0x72, 0x5f, 0x73, 0x65, 0x74, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74,
+	0x52, 0x0f, 0x74, 0x6f, 0x70, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x65, 0x74,
+	0x73, 0x12, 0x41, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0xbf, 0x03, 0x0a, 0x0c, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x4a, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x74, 0x6f,
+	0x70, 0x70, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x53, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x41, 0x74, 0x12, 0x5b, 0x0a, 0x0e,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x54, 0x61,
+	0x73, 0x6b, 0x52, 0x75, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x75, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x17, 0x61, 0x6e, 0x6e,
+	0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x12, 0x35, 0x0a, 0x16, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65,
+	0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x15, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x1a, 0x5b, 0x0a, 0x11, 0x4c, 0x61, 0x73,
+	0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x75, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa0, 0x02, 0x0a, 0x11, 0x41, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x2a,
+	0x0a, 0x11, 0x64, 0x68, 0x74, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x68, 0x74, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x64, 0x68,
+	0x74, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x64, 0x68, 0x74, 0x50, 0x65, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x67, 0x6f, 0x73, 0x73,
+	0x69, 0x70, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x67, 0x6f,
+	0x73, 0x73, 0x69, 0x70, 0x5f, 0x70, 0x65, 0x65, 0x72, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x67, 0x6f, 0x73, 0x73, 0x69,
+	0x70, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x12, 0x39,
+	0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x22, 0x66, 0x0a, 0x0a, 0x50, 0x69, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x66, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x69,
+	0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0d, 0x6d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x22, 0x41, 0x0a, 0x0c, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x31, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x66, 0x52, 0x04,
+	0x72, 0x65, 0x66, 0x73, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x37,
+	0x0a, 0x09, 0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x70,
+	0x69, 0x6e, 0x6e, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f,
+	0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6d, 0x69, 0x72, 0x72, 0x6f,
+	0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x22, 0x60, 0x0a, 0x12, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x24, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x69, 0x63, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x76, 0x69, 0x63, 0x74, 0x42, 0x0e, 0x0a, 0x0c, 0x5f,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0xa3, 0x02, 0x0a, 0x13,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e,
+	0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x75, 0x6e, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e,
+	0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x12, 0x4c, 0x0a, 0x0f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x5f, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61,
 	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
-	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4c, 0x0a, 0x09, 0x55,
-	0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x52, 0x0e, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x22, 0x39, 0x0a, 0x0c, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x63, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x22, 0x56, 0x0a, 0x11,
+	0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x41, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0x93, 0x01, 0x0a, 0x12, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x10, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0f,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12,
+	0x29, 0x0a, 0x10, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0x77, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x30, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x12, 0x30, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x75, 0x6e,
+	0x74, 0x69, 0x6c, 0x22, 0xc5, 0x01, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdb, 0x02, 0x0a, 0x0c,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x17, 0x61, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65,
+	0x64, 0x12, 0x35, 0x0a, 0x16, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x15, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x65,
+	0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x50, 0x65, 0x65, 0x72, 0x73, 0x22, 0x6c, 0x0a, 0x12, 0x47, 0x72, 0x61,
+	0x6e, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x74,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x66, 0x0a, 0x13, 0x47, 0x72, 0x61, 0x6e, 0x74,
+	0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22,
+	0x39, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70,
+	0x65, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3d, 0x0a, 0x12, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x27, 0x0a, 0x11, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x97, 0x02, 0x0a, 0x13, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x3b, 0x0a, 0x0b, 0x65,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x5f, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x12, 0x25, 0x0a, 0x0e, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x5f, 0x73, 0x6b, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x5f, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x5f, 0x73,
+	0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x22, 0xe2, 0x01, 0x0a,
+	0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x3c, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x2b, 0x0a, 0x0f, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f,
+	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x72, 0x42, 0x12, 0x0a,
+	0x10, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x22, 0x4c, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22,
+	0x79, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x48, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x78,
+	0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x52, 0x0a,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x22, 0x3f, 0x0a, 0x11, 0x54, 0x61,
+	0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x28, 0x0a, 0x14, 0x47,
+	0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x63, 0x69, 0x64, 0x22, 0xec, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x10, 0x0a, 0x03, 0x63, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x69,
+	0x64, 0x12, 0x55, 0x0a, 0x12, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x66, 0x69, 0x72, 0x73, 0x74, 0x41, 0x6e, 0x6e, 0x6f,
+	0x75, 0x6e, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x4c, 0x0a, 0x0d, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0x94, 0x02, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e,
+	0x63, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68,
+	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x75, 0x70, 0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x75, 0x70, 0x65, 0x72, 0x73, 0x65, 0x64, 0x65, 0x73, 0x2a, 0x84, 0x02, 0x0a, 0x0c,
+	0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x19,
+	0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x50,
+	0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x54, 0x4f,
+	0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48,
+	0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x1e, 0x0a, 0x1a, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45,
+	0x5f, 0x44, 0x48, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x44, 0x10, 0x03, 0x12,
+	0x25, 0x0a, 0x21, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45,
+	0x5f, 0x47, 0x4f, 0x53, 0x53, 0x49, 0x50, 0x53, 0x55, 0x42, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49,
+	0x53, 0x48, 0x45, 0x44, 0x10, 0x04, 0x12, 0x23, 0x0a, 0x1f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x53,
+	0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x53, 0x5f, 0x50,
+	0x52, 0x4f, 0x50, 0x41, 0x47, 0x41, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x1b, 0x0a, 0x17, 0x50,
+	0x55, 0x42, 0x4c, 0x49, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x43, 0x4f, 0x4d,
+	0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x55, 0x42, 0x4c,
+	0x49, 0x53, 0x48, 0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x07, 0x2a, 0x9e, 0x01, 0x0a, 0x14, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x25, 0x0a, 0x21, 0x41,
+	0x4e, 0x4e, 0x4f, 0x55, 0x4e, 0x43, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50, 0x52, 0x49, 0x4f,
+	0x52, 0x49, 0x54, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x4e, 0x4e, 0x4f, 0x55, 0x4e, 0x43, 0x45, 0x4d, 0x45,
+	0x4e, 0x54, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x48, 0x49, 0x47, 0x48,
+	0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x4e, 0x4e, 0x4f, 0x55, 0x4e, 0x43, 0x45, 0x4d, 0x45,
+	0x4e, 0x54, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x4f, 0x52, 0x4d,
+	0x41, 0x4c, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x41, 0x4e, 0x4e, 0x4f, 0x55, 0x4e, 0x43, 0x45,
+	0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x4f,
+	0x57, 0x10, 0x03, 0x2a, 0x59, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14,
+	0x0a, 0x10, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x46, 0x41,
+	0x53, 0x54, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x5f, 0x54, 0x48, 0x4f, 0x52, 0x4f, 0x55, 0x47, 0x48, 0x10, 0x02, 0x2a, 0xd7,
+	0x01, 0x0a, 0x0f, 0x53, 0x63, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x67, 0x79, 0x12, 0x20, 0x0a, 0x1c, 0x53, 0x43, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54,
+	0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x43, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x5f,
+	0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x01,
+	0x12, 0x28, 0x0a, 0x24, 0x53, 0x43, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x52, 0x41,
+	0x54, 0x45, 0x47, 0x59, 0x5f, 0x57, 0x45, 0x49, 0x47, 0x48, 0x54, 0x45, 0x44, 0x5f, 0x4e, 0x41,
+	0x4d, 0x45, 0x53, 0x50, 0x41, 0x43, 0x45, 0x53, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x43,
+	0x4f, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x46,
+	0x52, 0x45, 0x53, 0x48, 0x4e, 0x45, 0x53, 0x53, 0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x43,
+	0x4f, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f, 0x52,
+	0x45, 0x50, 0x55, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x1b, 0x0a, 0x17, 0x53,
+	0x43, 0x4f, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x54, 0x52, 0x41, 0x54, 0x45, 0x47, 0x59, 0x5f,
+	0x52, 0x41, 0x4e, 0x4b, 0x45, 0x44, 0x10, 0x05, 0x2a, 0x8b, 0x01, 0x0a, 0x10, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x21, 0x0a,
+	0x1d, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49,
+	0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1a, 0x0a, 0x16, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x50, 0x52,
+	0x4f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16,
+	0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c,
+	0x45, 0x5f, 0x45, 0x44, 0x47, 0x45, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x49, 0x53, 0x43,
+	0x4f, 0x56, 0x45, 0x52, 0x59, 0x5f, 0x50, 0x52, 0x4f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x43, 0x4c,
+	0x49, 0x45, 0x4e, 0x54, 0x10, 0x03, 0x2a, 0x72, 0x0a, 0x0f, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65,
+	0x76, 0x61, 0x6c, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x1c, 0x52, 0x45, 0x54,
+	0x52, 0x49, 0x45, 0x56, 0x41, 0x4c, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x52,
+	0x45, 0x54, 0x52, 0x49, 0x45, 0x56, 0x41, 0x4c, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f,
+	0x52, 0x50, 0x43, 0x5f, 0x50, 0x55, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x52, 0x45,
+	0x54, 0x52, 0x49, 0x45, 0x56, 0x41, 0x4c, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x53,
+	0x54, 0x52, 0x45, 0x41, 0x4d, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x32, 0xf9, 0x0d, 0x0a, 0x0e, 0x52,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a,
+	0x07, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
 	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
-	0x2e, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x57, 0x0a, 0x06, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x12, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
-	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x67, 0x6e, 0x74,
+	0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x66, 0x0a, 0x13, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x73, 0x68, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12,
+	0x4c, 0x0a, 0x09, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x12, 0x27, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x57, 0x0a,
+	0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x67, 0x0a, 0x0f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x61, 0x67, 0x6e, 0x74,
 	0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x30, 0x01, 0x12, 0x51, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x22, 0x2e, 0x61, 0x67, 0x6e,
+	0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2e, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x51, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x22, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x12, 0x5b, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26,
+	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x40, 0x0a, 0x03, 0x50, 0x69, 0x6e, 0x12, 0x21, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
+	0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x44, 0x0a, 0x05, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x12, 0x23, 0x2e, 0x61, 0x67, 0x6e,
+	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x6e, 0x70, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x5d, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x69, 0x6e, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x69, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x64, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64,
+	0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2a, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0a,
+	0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x28, 0x2e, 0x61, 0x67, 0x6e,
 	0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x76, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69,
+	0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x61, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x28, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x64, 0x0a, 0x0b, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x41,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x72, 0x61, 0x6e, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x28, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x65,
+	0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x28, 0x2e,
+	0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x2a, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x5d, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x12, 0x27, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x64, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x61, 0x78, 0x6f, 0x6e,
+	0x6f, 0x6d, 0x79, 0x12, 0x29, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72,
+	0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54,
+	0x61, 0x78, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
 	0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74,
-	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0xcd, 0x01, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x67,
+	0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x78, 0x6f, 0x6e, 0x6f,
+	0x6d, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6a, 0x0a, 0x0d, 0x47, 0x65,
+	0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x2b, 0x2e, 0x61, 0x67,
 	0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
-	0x2e, 0x76, 0x31, 0x42, 0x13, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64, 0x69,
-	0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31,
-	0xa2, 0x02, 0x03, 0x41, 0x44, 0x52, 0xaa, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e,
-	0x44, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x31, 0xca, 0x02,
-	0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75, 0x74,
-	0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x21, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c,
-	0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0x5c, 0x47,
-	0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x18, 0x41, 0x67, 0x6e,
-	0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e,
-	0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x61, 0x67, 0x6e, 0x74, 0x63,
+	0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xcd, 0x01, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e, 0x61,
+	0x67, 0x6e, 0x74, 0x63, 0x79, 0x2e, 0x64, 0x69, 0x72, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x76, 0x31, 0x42, 0x13, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x2f, 0x64,
+	0x69, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x76,
+	0x31, 0xa2, 0x02, 0x03, 0x41, 0x44, 0x52, 0xaa, 0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x2e, 0x44, 0x69, 0x72, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x56, 0x31, 0xca,
+	0x02, 0x15, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79, 0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75,
+	0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x21, 0x41, 0x67, 0x6e, 0x74, 0x63, 0x79,
+	0x5c, 0x44, 0x69, 0x72, 0x5c, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x5c, 0x56, 0x31, 0x5c,
+	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x18, 0x41, 0x67,
+	0x6e, 0x74, 0x63, 0x79, 0x3a, 0x3a, 0x44, 0x69, 0x72, 0x3a, 0x3a, 0x52, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 })
 
 var (
@@ -672,48 +4474,175 @@ func file_agntcy_dir_routing_v1_routing_service_proto_rawDescGZIP() []byte {
 	return file_agntcy_dir_routing_v1_routing_service_proto_rawDescData
 }
 
-var file_agntcy_dir_routing_v1_routing_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_agntcy_dir_routing_v1_routing_service_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_agntcy_dir_routing_v1_routing_service_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_agntcy_dir_routing_v1_routing_service_proto_goTypes = []any{
-	(*PublishRequest)(nil),   // 0: agntcy.dir.routing.v1.PublishRequest
-	(*UnpublishRequest)(nil), // 1: agntcy.dir.routing.v1.UnpublishRequest
-	(*RecordRefs)(nil),       // 2: agntcy.dir.routing.v1.RecordRefs
-	(*RecordQueries)(nil),    // 3: agntcy.dir.routing.v1.RecordQueries
-	(*SearchRequest)(nil),    // 4: agntcy.dir.routing.v1.SearchRequest
-	(*SearchResponse)(nil),   // 5: agntcy.dir.routing.v1.SearchResponse
-	(*ListRequest)(nil),      // 6: agntcy.dir.routing.v1.ListRequest
-	(*ListResponse)(nil),     // 7: agntcy.dir.routing.v1.ListResponse
-	(*v1.RecordRef)(nil),     // 8: agntcy.dir.core.v1.RecordRef
-	(*v11.RecordQuery)(nil),  // 9: agntcy.dir.search.v1.RecordQuery
-	(*RecordQuery)(nil),      // 10: agntcy.dir.routing.v1.RecordQuery
-	(*Peer)(nil),             // 11: agntcy.dir.routing.v1.Peer
-	(*emptypb.Empty)(nil),    // 12: google.protobuf.Empty
+	(PublishStage)(0),               // 0: agntcy.dir.routing.v1.PublishStage
+	(AnnouncementPriority)(0),       // 1: agntcy.dir.routing.v1.AnnouncementPriority
+	(SearchMode)(0),                 // 2: agntcy.dir.routing.v1.SearchMode
+	(ScoringStrategy)(0),            // 3: agntcy.dir.routing.v1.ScoringStrategy
+	(DiscoveryProfile)(0),           // 4: agntcy.dir.routing.v1.DiscoveryProfile
+	(RetrievalMethod)(0),            // 5: agntcy.dir.routing.v1.RetrievalMethod
+	(*PublishRequest)(nil),          // 6: agntcy.dir.routing.v1.PublishRequest
+	(*LabelPolicy)(nil),             // 7: agntcy.dir.routing.v1.LabelPolicy
+	(*PublishProgress)(nil),         // 8: agntcy.dir.routing.v1.PublishProgress
+	(*RecordValidationFailure)(nil), // 9: agntcy.dir.routing.v1.RecordValidationFailure
+	(*RecordViolation)(nil),         // 10: agntcy.dir.routing.v1.RecordViolation
+	(*RankingWeights)(nil),          // 11: agntcy.dir.routing.v1.RankingWeights
+	(*UnpublishRequest)(nil),        // 12: agntcy.dir.routing.v1.UnpublishRequest
+	(*RecordRefs)(nil),              // 13: agntcy.dir.routing.v1.RecordRefs
+	(*RecordQueries)(nil),           // 14: agntcy.dir.routing.v1.RecordQueries
+	(*SearchRequest)(nil),           // 15: agntcy.dir.routing.v1.SearchRequest
+	(*SearchResponse)(nil),          // 16: agntcy.dir.routing.v1.SearchResponse
+	(*ProviderSet)(nil),             // 17: agntcy.dir.routing.v1.ProviderSet
+	(*ListRequest)(nil),             // 18: agntcy.dir.routing.v1.ListRequest
+	(*ListResponse)(nil),            // 19: agntcy.dir.routing.v1.ListResponse
+	(*EstimateResultsResponse)(nil), // 20: agntcy.dir.routing.v1.EstimateResultsResponse
+	(*GetStatsRequest)(nil),         // 21: agntcy.dir.routing.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),        // 22: agntcy.dir.routing.v1.GetStatsResponse
+	(*RuntimeState)(nil),            // 23: agntcy.dir.routing.v1.RuntimeState
+	(*AnnouncementCheck)(nil),       // 24: agntcy.dir.routing.v1.AnnouncementCheck
+	(*PinRequest)(nil),              // 25: agntcy.dir.routing.v1.PinRequest
+	(*UnpinRequest)(nil),            // 26: agntcy.dir.routing.v1.UnpinRequest
+	(*ListPinsRequest)(nil),         // 27: agntcy.dir.routing.v1.ListPinsRequest
+	(*ListPinsResponse)(nil),        // 28: agntcy.dir.routing.v1.ListPinsResponse
+	(*VerifyCacheRequest)(nil),      // 29: agntcy.dir.routing.v1.VerifyCacheRequest
+	(*VerifyCacheResponse)(nil),     // 30: agntcy.dir.routing.v1.VerifyCacheResponse
+	(*CachedRecord)(nil),            // 31: agntcy.dir.routing.v1.CachedRecord
+	(*SetProfileRequest)(nil),       // 32: agntcy.dir.routing.v1.SetProfileRequest
+	(*SetProfileResponse)(nil),      // 33: agntcy.dir.routing.v1.SetProfileResponse
+	(*GetHistoryRequest)(nil),       // 34: agntcy.dir.routing.v1.GetHistoryRequest
+	(*GetHistoryResponse)(nil),      // 35: agntcy.dir.routing.v1.GetHistoryResponse
+	(*HistoryPoint)(nil),            // 36: agntcy.dir.routing.v1.HistoryPoint
+	(*GrantAccessRequest)(nil),      // 37: agntcy.dir.routing.v1.GrantAccessRequest
+	(*GrantAccessResponse)(nil),     // 38: agntcy.dir.routing.v1.GrantAccessResponse
+	(*PeerStat)(nil),                // 39: agntcy.dir.routing.v1.PeerStat
+	(*ExportStateRequest)(nil),      // 40: agntcy.dir.routing.v1.ExportStateRequest
+	(*StateArchiveChunk)(nil),       // 41: agntcy.dir.routing.v1.StateArchiveChunk
+	(*ImportStateResponse)(nil),     // 42: agntcy.dir.routing.v1.ImportStateResponse
+	(*SubscribeRequest)(nil),        // 43: agntcy.dir.routing.v1.SubscribeRequest
+	(*GetTaxonomyRequest)(nil),      // 44: agntcy.dir.routing.v1.GetTaxonomyRequest
+	(*GetTaxonomyResponse)(nil),     // 45: agntcy.dir.routing.v1.GetTaxonomyResponse
+	(*TaxonomyNamespace)(nil),       // 46: agntcy.dir.routing.v1.TaxonomyNamespace
+	(*GetProvenanceRequest)(nil),    // 47: agntcy.dir.routing.v1.GetProvenanceRequest
+	(*GetProvenanceResponse)(nil),   // 48: agntcy.dir.routing.v1.GetProvenanceResponse
+	(*ProvenanceEntry)(nil),         // 49: agntcy.dir.routing.v1.ProvenanceEntry
+	nil,                             // 50: agntcy.dir.routing.v1.RuntimeState.LastTaskRunsEntry
+	(*durationpb.Duration)(nil),     // 51: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),   // 52: google.protobuf.Timestamp
+	(*v1.RecordRef)(nil),            // 53: agntcy.dir.core.v1.RecordRef
+	(*v11.RecordQuery)(nil),         // 54: agntcy.dir.search.v1.RecordQuery
+	(*RecordQuery)(nil),             // 55: agntcy.dir.routing.v1.RecordQuery
+	(*Peer)(nil),                    // 56: agntcy.dir.routing.v1.Peer
+	(*emptypb.Empty)(nil),           // 57: google.protobuf.Empty
 }
 var file_agntcy_dir_routing_v1_routing_service_proto_depIdxs = []int32{
-	2,  // 0: agntcy.dir.routing.v1.PublishRequest.record_refs:type_name -> agntcy.dir.routing.v1.RecordRefs
-	3,  // 1: agntcy.dir.routing.v1.PublishRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQueries
-	2,  // 2: agntcy.dir.routing.v1.UnpublishRequest.record_refs:type_name -> agntcy.dir.routing.v1.RecordRefs
-	3,  // 3: agntcy.dir.routing.v1.UnpublishRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQueries
-	8,  // 4: agntcy.dir.routing.v1.RecordRefs.refs:type_name -> agntcy.dir.core.v1.RecordRef
-	9,  // 5: agntcy.dir.routing.v1.RecordQueries.queries:type_name -> agntcy.dir.search.v1.RecordQuery
-	10, // 6: agntcy.dir.routing.v1.SearchRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQuery
-	8,  // 7: agntcy.dir.routing.v1.SearchResponse.record_ref:type_name -> agntcy.dir.core.v1.RecordRef
-	11, // 8: agntcy.dir.routing.v1.SearchResponse.peer:type_name -> agntcy.dir.routing.v1.Peer
-	10, // 9: agntcy.dir.routing.v1.SearchResponse.match_queries:type_name -> agntcy.dir.routing.v1.RecordQuery
-	10, // 10: agntcy.dir.routing.v1.ListRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQuery
-	8,  // 11: agntcy.dir.routing.v1.ListResponse.record_ref:type_name -> agntcy.dir.core.v1.RecordRef
-	0,  // 12: agntcy.dir.routing.v1.RoutingService.Publish:input_type -> agntcy.dir.routing.v1.PublishRequest
-	1,  // 13: agntcy.dir.routing.v1.RoutingService.Unpublish:input_type -> agntcy.dir.routing.v1.UnpublishRequest
-	4,  // 14: agntcy.dir.routing.v1.RoutingService.Search:input_type -> agntcy.dir.routing.v1.SearchRequest
-	6,  // 15: agntcy.dir.routing.v1.RoutingService.List:input_type -> agntcy.dir.routing.v1.ListRequest
-	12, // 16: agntcy.dir.routing.v1.RoutingService.Publish:output_type -> google.protobuf.Empty
-	12, // 17: agntcy.dir.routing.v1.RoutingService.Unpublish:output_type -> google.protobuf.Empty
-	5,  // 18: agntcy.dir.routing.v1.RoutingService.Search:output_type -> agntcy.dir.routing.v1.SearchResponse
-	7,  // 19: agntcy.dir.routing.v1.RoutingService.List:output_type -> agntcy.dir.routing.v1.ListResponse
-	16, // [16:20] is the sub-list for method output_type
-	12, // [12:16] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	13, // 0: agntcy.dir.routing.v1.PublishRequest.record_refs:type_name -> agntcy.dir.routing.v1.RecordRefs
+	14, // 1: agntcy.dir.routing.v1.PublishRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQueries
+	1,  // 2: agntcy.dir.routing.v1.PublishRequest.priority:type_name -> agntcy.dir.routing.v1.AnnouncementPriority
+	51, // 3: agntcy.dir.routing.v1.PublishRequest.ttl:type_name -> google.protobuf.Duration
+	7,  // 4: agntcy.dir.routing.v1.PublishRequest.label_policies:type_name -> agntcy.dir.routing.v1.LabelPolicy
+	0,  // 5: agntcy.dir.routing.v1.PublishProgress.stage:type_name -> agntcy.dir.routing.v1.PublishStage
+	52, // 6: agntcy.dir.routing.v1.PublishProgress.time:type_name -> google.protobuf.Timestamp
+	10, // 7: agntcy.dir.routing.v1.PublishProgress.violations:type_name -> agntcy.dir.routing.v1.RecordViolation
+	10, // 8: agntcy.dir.routing.v1.RecordValidationFailure.violations:type_name -> agntcy.dir.routing.v1.RecordViolation
+	13, // 9: agntcy.dir.routing.v1.UnpublishRequest.record_refs:type_name -> agntcy.dir.routing.v1.RecordRefs
+	14, // 10: agntcy.dir.routing.v1.UnpublishRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQueries
+	53, // 11: agntcy.dir.routing.v1.RecordRefs.refs:type_name -> agntcy.dir.core.v1.RecordRef
+	54, // 12: agntcy.dir.routing.v1.RecordQueries.queries:type_name -> agntcy.dir.search.v1.RecordQuery
+	55, // 13: agntcy.dir.routing.v1.SearchRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQuery
+	2,  // 14: agntcy.dir.routing.v1.SearchRequest.search_mode:type_name -> agntcy.dir.routing.v1.SearchMode
+	5,  // 15: agntcy.dir.routing.v1.SearchRequest.required_retrieval_method:type_name -> agntcy.dir.routing.v1.RetrievalMethod
+	3,  // 16: agntcy.dir.routing.v1.SearchRequest.scoring_strategy:type_name -> agntcy.dir.routing.v1.ScoringStrategy
+	11, // 17: agntcy.dir.routing.v1.SearchRequest.ranking_weights:type_name -> agntcy.dir.routing.v1.RankingWeights
+	53, // 18: agntcy.dir.routing.v1.SearchResponse.record_ref:type_name -> agntcy.dir.core.v1.RecordRef
+	56, // 19: agntcy.dir.routing.v1.SearchResponse.peer:type_name -> agntcy.dir.routing.v1.Peer
+	55, // 20: agntcy.dir.routing.v1.SearchResponse.match_queries:type_name -> agntcy.dir.routing.v1.RecordQuery
+	17, // 21: agntcy.dir.routing.v1.SearchResponse.provider_set:type_name -> agntcy.dir.routing.v1.ProviderSet
+	52, // 22: agntcy.dir.routing.v1.ProviderSet.first_seen:type_name -> google.protobuf.Timestamp
+	52, // 23: agntcy.dir.routing.v1.ProviderSet.last_seen:type_name -> google.protobuf.Timestamp
+	55, // 24: agntcy.dir.routing.v1.ListRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQuery
+	53, // 25: agntcy.dir.routing.v1.ListResponse.record_ref:type_name -> agntcy.dir.core.v1.RecordRef
+	52, // 26: agntcy.dir.routing.v1.ListResponse.published_at:type_name -> google.protobuf.Timestamp
+	52, // 27: agntcy.dir.routing.v1.ListResponse.last_announced_at:type_name -> google.protobuf.Timestamp
+	24, // 28: agntcy.dir.routing.v1.ListResponse.announcement_check:type_name -> agntcy.dir.routing.v1.AnnouncementCheck
+	39, // 29: agntcy.dir.routing.v1.GetStatsResponse.top_label_counts:type_name -> agntcy.dir.routing.v1.PeerStat
+	39, // 30: agntcy.dir.routing.v1.GetStatsResponse.top_announcement_rates:type_name -> agntcy.dir.routing.v1.PeerStat
+	39, // 31: agntcy.dir.routing.v1.GetStatsResponse.top_pull_failures:type_name -> agntcy.dir.routing.v1.PeerStat
+	24, // 32: agntcy.dir.routing.v1.GetStatsResponse.unresolvable_records:type_name -> agntcy.dir.routing.v1.AnnouncementCheck
+	23, // 33: agntcy.dir.routing.v1.GetStatsResponse.runtime_state:type_name -> agntcy.dir.routing.v1.RuntimeState
+	39, // 34: agntcy.dir.routing.v1.GetStatsResponse.top_clock_skews:type_name -> agntcy.dir.routing.v1.PeerStat
+	17, // 35: agntcy.dir.routing.v1.GetStatsResponse.top_provider_sets:type_name -> agntcy.dir.routing.v1.ProviderSet
+	4,  // 36: agntcy.dir.routing.v1.GetStatsResponse.profile:type_name -> agntcy.dir.routing.v1.DiscoveryProfile
+	52, // 37: agntcy.dir.routing.v1.RuntimeState.started_at:type_name -> google.protobuf.Timestamp
+	52, // 38: agntcy.dir.routing.v1.RuntimeState.previous_stopped_at:type_name -> google.protobuf.Timestamp
+	50, // 39: agntcy.dir.routing.v1.RuntimeState.last_task_runs:type_name -> agntcy.dir.routing.v1.RuntimeState.LastTaskRunsEntry
+	52, // 40: agntcy.dir.routing.v1.AnnouncementCheck.checked_at:type_name -> google.protobuf.Timestamp
+	53, // 41: agntcy.dir.routing.v1.PinRequest.refs:type_name -> agntcy.dir.core.v1.RecordRef
+	53, // 42: agntcy.dir.routing.v1.UnpinRequest.refs:type_name -> agntcy.dir.core.v1.RecordRef
+	52, // 43: agntcy.dir.routing.v1.ListPinsResponse.pinned_at:type_name -> google.protobuf.Timestamp
+	31, // 44: agntcy.dir.routing.v1.VerifyCacheResponse.missing_records:type_name -> agntcy.dir.routing.v1.CachedRecord
+	4,  // 45: agntcy.dir.routing.v1.SetProfileRequest.profile:type_name -> agntcy.dir.routing.v1.DiscoveryProfile
+	4,  // 46: agntcy.dir.routing.v1.SetProfileResponse.previous_profile:type_name -> agntcy.dir.routing.v1.DiscoveryProfile
+	52, // 47: agntcy.dir.routing.v1.GetHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	52, // 48: agntcy.dir.routing.v1.GetHistoryRequest.until:type_name -> google.protobuf.Timestamp
+	36, // 49: agntcy.dir.routing.v1.GetHistoryResponse.points:type_name -> agntcy.dir.routing.v1.HistoryPoint
+	51, // 50: agntcy.dir.routing.v1.GetHistoryResponse.resolution:type_name -> google.protobuf.Duration
+	51, // 51: agntcy.dir.routing.v1.GetHistoryResponse.retention:type_name -> google.protobuf.Duration
+	52, // 52: agntcy.dir.routing.v1.HistoryPoint.start:type_name -> google.protobuf.Timestamp
+	51, // 53: agntcy.dir.routing.v1.GrantAccessRequest.ttl:type_name -> google.protobuf.Duration
+	52, // 54: agntcy.dir.routing.v1.GrantAccessResponse.expires_at:type_name -> google.protobuf.Timestamp
+	52, // 55: agntcy.dir.routing.v1.ImportStateResponse.exported_at:type_name -> google.protobuf.Timestamp
+	55, // 56: agntcy.dir.routing.v1.SubscribeRequest.queries:type_name -> agntcy.dir.routing.v1.RecordQuery
+	46, // 57: agntcy.dir.routing.v1.GetTaxonomyResponse.namespaces:type_name -> agntcy.dir.routing.v1.TaxonomyNamespace
+	49, // 58: agntcy.dir.routing.v1.GetProvenanceResponse.first_announcement:type_name -> agntcy.dir.routing.v1.ProvenanceEntry
+	49, // 59: agntcy.dir.routing.v1.GetProvenanceResponse.announcements:type_name -> agntcy.dir.routing.v1.ProvenanceEntry
+	52, // 60: agntcy.dir.routing.v1.ProvenanceEntry.received_at:type_name -> google.protobuf.Timestamp
+	52, // 61: agntcy.dir.routing.v1.ProvenanceEntry.announced_at:type_name -> google.protobuf.Timestamp
+	52, // 62: agntcy.dir.routing.v1.RuntimeState.LastTaskRunsEntry.value:type_name -> google.protobuf.Timestamp
+	6,  // 63: agntcy.dir.routing.v1.RoutingService.Publish:input_type -> agntcy.dir.routing.v1.PublishRequest
+	6,  // 64: agntcy.dir.routing.v1.RoutingService.PublishWithProgress:input_type -> agntcy.dir.routing.v1.PublishRequest
+	12, // 65: agntcy.dir.routing.v1.RoutingService.Unpublish:input_type -> agntcy.dir.routing.v1.UnpublishRequest
+	15, // 66: agntcy.dir.routing.v1.RoutingService.Search:input_type -> agntcy.dir.routing.v1.SearchRequest
+	15, // 67: agntcy.dir.routing.v1.RoutingService.EstimateResults:input_type -> agntcy.dir.routing.v1.SearchRequest
+	18, // 68: agntcy.dir.routing.v1.RoutingService.List:input_type -> agntcy.dir.routing.v1.ListRequest
+	21, // 69: agntcy.dir.routing.v1.RoutingService.GetStats:input_type -> agntcy.dir.routing.v1.GetStatsRequest
+	25, // 70: agntcy.dir.routing.v1.RoutingService.Pin:input_type -> agntcy.dir.routing.v1.PinRequest
+	26, // 71: agntcy.dir.routing.v1.RoutingService.Unpin:input_type -> agntcy.dir.routing.v1.UnpinRequest
+	27, // 72: agntcy.dir.routing.v1.RoutingService.ListPins:input_type -> agntcy.dir.routing.v1.ListPinsRequest
+	29, // 73: agntcy.dir.routing.v1.RoutingService.VerifyCache:input_type -> agntcy.dir.routing.v1.VerifyCacheRequest
+	32, // 74: agntcy.dir.routing.v1.RoutingService.SetProfile:input_type -> agntcy.dir.routing.v1.SetProfileRequest
+	34, // 75: agntcy.dir.routing.v1.RoutingService.GetHistory:input_type -> agntcy.dir.routing.v1.GetHistoryRequest
+	37, // 76: agntcy.dir.routing.v1.RoutingService.GrantAccess:input_type -> agntcy.dir.routing.v1.GrantAccessRequest
+	40, // 77: agntcy.dir.routing.v1.RoutingService.ExportState:input_type -> agntcy.dir.routing.v1.ExportStateRequest
+	41, // 78: agntcy.dir.routing.v1.RoutingService.ImportState:input_type -> agntcy.dir.routing.v1.StateArchiveChunk
+	43, // 79: agntcy.dir.routing.v1.RoutingService.Subscribe:input_type -> agntcy.dir.routing.v1.SubscribeRequest
+	44, // 80: agntcy.dir.routing.v1.RoutingService.GetTaxonomy:input_type -> agntcy.dir.routing.v1.GetTaxonomyRequest
+	47, // 81: agntcy.dir.routing.v1.RoutingService.GetProvenance:input_type -> agntcy.dir.routing.v1.GetProvenanceRequest
+	57, // 82: agntcy.dir.routing.v1.RoutingService.Publish:output_type -> google.protobuf.Empty
+	8,  // 83: agntcy.dir.routing.v1.RoutingService.PublishWithProgress:output_type -> agntcy.dir.routing.v1.PublishProgress
+	57, // 84: agntcy.dir.routing.v1.RoutingService.Unpublish:output_type -> google.protobuf.Empty
+	16, // 85: agntcy.dir.routing.v1.RoutingService.Search:output_type -> agntcy.dir.routing.v1.SearchResponse
+	20, // 86: agntcy.dir.routing.v1.RoutingService.EstimateResults:output_type -> agntcy.dir.routing.v1.EstimateResultsResponse
+	19, // 87: agntcy.dir.routing.v1.RoutingService.List:output_type -> agntcy.dir.routing.v1.ListResponse
+	22, // 88: agntcy.dir.routing.v1.RoutingService.GetStats:output_type -> agntcy.dir.routing.v1.GetStatsResponse
+	57, // 89: agntcy.dir.routing.v1.RoutingService.Pin:output_type -> google.protobuf.Empty
+	57, // 90: agntcy.dir.routing.v1.RoutingService.Unpin:output_type -> google.protobuf.Empty
+	28, // 91: agntcy.dir.routing.v1.RoutingService.ListPins:output_type -> agntcy.dir.routing.v1.ListPinsResponse
+	30, // 92: agntcy.dir.routing.v1.RoutingService.VerifyCache:output_type -> agntcy.dir.routing.v1.VerifyCacheResponse
+	33, // 93: agntcy.dir.routing.v1.RoutingService.SetProfile:output_type -> agntcy.dir.routing.v1.SetProfileResponse
+	35, // 94: agntcy.dir.routing.v1.RoutingService.GetHistory:output_type -> agntcy.dir.routing.v1.GetHistoryResponse
+	38, // 95: agntcy.dir.routing.v1.RoutingService.GrantAccess:output_type -> agntcy.dir.routing.v1.GrantAccessResponse
+	41, // 96: agntcy.dir.routing.v1.RoutingService.ExportState:output_type -> agntcy.dir.routing.v1.StateArchiveChunk
+	42, // 97: agntcy.dir.routing.v1.RoutingService.ImportState:output_type -> agntcy.dir.routing.v1.ImportStateResponse
+	16, // 98: agntcy.dir.routing.v1.RoutingService.Subscribe:output_type -> agntcy.dir.routing.v1.SearchResponse
+	45, // 99: agntcy.dir.routing.v1.RoutingService.GetTaxonomy:output_type -> agntcy.dir.routing.v1.GetTaxonomyResponse
+	48, // 100: agntcy.dir.routing.v1.RoutingService.GetProvenance:output_type -> agntcy.dir.routing.v1.GetProvenanceResponse
+	82, // [82:101] is the sub-list for method output_type
+	63, // [63:82] is the sub-list for method input_type
+	63, // [63:63] is the sub-list for extension type_name
+	63, // [63:63] is the sub-list for extension extendee
+	0,  // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_agntcy_dir_routing_v1_routing_service_proto_init() }
@@ -727,24 +4656,29 @@ func file_agntcy_dir_routing_v1_routing_service_proto_init() {
 		(*PublishRequest_RecordRefs)(nil),
 		(*PublishRequest_Queries)(nil),
 	}
-	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[1].OneofWrappers = []any{
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[6].OneofWrappers = []any{
 		(*UnpublishRequest_RecordRefs)(nil),
 		(*UnpublishRequest_Queries)(nil),
 	}
-	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[4].OneofWrappers = []any{}
-	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[9].OneofWrappers = []any{}
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[12].OneofWrappers = []any{}
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[23].OneofWrappers = []any{}
+	file_agntcy_dir_routing_v1_routing_service_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_agntcy_dir_routing_v1_routing_service_proto_rawDesc), len(file_agntcy_dir_routing_v1_routing_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      6,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_agntcy_dir_routing_v1_routing_service_proto_goTypes,
 		DependencyIndexes: file_agntcy_dir_routing_v1_routing_service_proto_depIdxs,
+		EnumInfos:         file_agntcy_dir_routing_v1_routing_service_proto_enumTypes,
 		MessageInfos:      file_agntcy_dir_routing_v1_routing_service_proto_msgTypes,
 	}.Build()
 	File_agntcy_dir_routing_v1_routing_service_proto = out.File
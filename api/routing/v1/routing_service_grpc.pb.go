@@ -23,10 +23,25 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	RoutingService_Publish_FullMethodName   = "/agntcy.dir.routing.v1.RoutingService/Publish"
-	RoutingService_Unpublish_FullMethodName = "/agntcy.dir.routing.v1.RoutingService/Unpublish"
-	RoutingService_Search_FullMethodName    = "/agntcy.dir.routing.v1.RoutingService/Search"
-	RoutingService_List_FullMethodName      = "/agntcy.dir.routing.v1.RoutingService/List"
+	RoutingService_Publish_FullMethodName             = "/agntcy.dir.routing.v1.RoutingService/Publish"
+	RoutingService_PublishWithProgress_FullMethodName = "/agntcy.dir.routing.v1.RoutingService/PublishWithProgress"
+	RoutingService_Unpublish_FullMethodName           = "/agntcy.dir.routing.v1.RoutingService/Unpublish"
+	RoutingService_Search_FullMethodName              = "/agntcy.dir.routing.v1.RoutingService/Search"
+	RoutingService_EstimateResults_FullMethodName     = "/agntcy.dir.routing.v1.RoutingService/EstimateResults"
+	RoutingService_List_FullMethodName                = "/agntcy.dir.routing.v1.RoutingService/List"
+	RoutingService_GetStats_FullMethodName            = "/agntcy.dir.routing.v1.RoutingService/GetStats"
+	RoutingService_Pin_FullMethodName                 = "/agntcy.dir.routing.v1.RoutingService/Pin"
+	RoutingService_Unpin_FullMethodName               = "/agntcy.dir.routing.v1.RoutingService/Unpin"
+	RoutingService_ListPins_FullMethodName            = "/agntcy.dir.routing.v1.RoutingService/ListPins"
+	RoutingService_VerifyCache_FullMethodName         = "/agntcy.dir.routing.v1.RoutingService/VerifyCache"
+	RoutingService_SetProfile_FullMethodName          = "/agntcy.dir.routing.v1.RoutingService/SetProfile"
+	RoutingService_GetHistory_FullMethodName          = "/agntcy.dir.routing.v1.RoutingService/GetHistory"
+	RoutingService_GrantAccess_FullMethodName         = "/agntcy.dir.routing.v1.RoutingService/GrantAccess"
+	RoutingService_ExportState_FullMethodName         = "/agntcy.dir.routing.v1.RoutingService/ExportState"
+	RoutingService_ImportState_FullMethodName         = "/agntcy.dir.routing.v1.RoutingService/ImportState"
+	RoutingService_Subscribe_FullMethodName           = "/agntcy.dir.routing.v1.RoutingService/Subscribe"
+	RoutingService_GetTaxonomy_FullMethodName         = "/agntcy.dir.routing.v1.RoutingService/GetTaxonomy"
+	RoutingService_GetProvenance_FullMethodName       = "/agntcy.dir.routing.v1.RoutingService/GetProvenance"
 )
 
 // RoutingServiceClient is the client API for RoutingService service.
@@ -46,7 +61,17 @@ type RoutingServiceClient interface {
 	//
 	// Items need to be periodically republished (eg. 24h) to the network
 	// to avoid stale data. Republication should be done in the background.
+	//
+	// If record validation is enabled on the peer, records referenced by CID are
+	// validated before the publish is accepted. Records violating a validation rule
+	// fail the call with INVALID_ARGUMENT and a RecordValidationFailure detail per record.
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Publish records like Publish, but synchronously instead of in the background,
+	// streaming the progress of each record through the publish stages as they complete:
+	// stored locally, provided to the DHT, published via GossipSub and its labels
+	// propagated to GossipSub peers. Each record ends with a completed or failed event,
+	// after which the stream ends.
+	PublishWithProgress(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (RoutingService_PublishWithProgressClient, error)
 	// Stop serving this record to the network. If other peers try
 	// to retrieve this record, the peer will refuse the request.
 	Unpublish(ctx context.Context, in *UnpublishRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -59,10 +84,90 @@ type RoutingServiceClient interface {
 	// Results from the search can be used as an input
 	// to Pull operation to retrieve the records.
 	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (RoutingService_SearchClient, error)
+	// Estimate the number of records a Search with the same request would return,
+	// without streaming them. The estimate is computed from cardinality sketches of
+	// the cached labels, so it is cheap but approximate, and ignores limit,
+	// page_token and search_mode.
+	// This operation does not interact with the network.
+	EstimateResults(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*EstimateResultsResponse, error)
 	// List all records that this peer is currently providing
-	// that match the given parameters.
+	// that match the given parameters, with their labels and announcement status.
 	// This operation does not interact with the network.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (RoutingService_ListClient, error)
+	// Get routing statistics about remote peers, such as the peers
+	// with the most cached labels, announcements, or failed pulls,
+	// and the local records that were found to be unresolvable by other peers.
+	// This operation does not interact with the network.
+	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
+	// Pin remote records found by Search, so that their cached labels are never
+	// cleaned up as stale or expired nor evicted, and they remain searchable while
+	// this peer is disconnected from the network. Optionally mirrors the records
+	// into the local store, so that they also remain pullable.
+	// Revocations by the publishing peers still purge the cached labels.
+	//
+	// Pinned local records are reprovided on every republish cycle, even while the
+	// discovery profile disables republishing, and are neither expired by their TTL
+	// nor garbage collected under disk pressure, see routing.record_gc.
+	Pin(ctx context.Context, in *PinRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Unpin records, subjecting their cached labels to cleanup again and local
+	// records to expiry and garbage collection. Mirrored records are kept in the local store.
+	Unpin(ctx context.Context, in *UnpinRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// List the pinned records.
+	// This operation does not interact with the network.
+	ListPins(ctx context.Context, in *ListPinsRequest, opts ...grpc.CallOption) (RoutingService_ListPinsClient, error)
+	// Verify a random sample of the local cache of remote labels by asking the
+	// providers of the sampled records whether they still store them.
+	// Optionally evicts the labels of records that their providers no longer store.
+	// Pinned records are verified but never evicted.
+	VerifyCache(ctx context.Context, in *VerifyCacheRequest, opts ...grpc.CallOption) (*VerifyCacheResponse, error)
+	// Switch the discovery profile of this peer at runtime, until it is restarted.
+	// GossipSub processing, the label cache limit and republishing follow the new
+	// profile immediately; its DHT mode only takes effect after a restart with the
+	// profile configured.
+	SetProfile(ctx context.Context, in *SetProfileRequest, opts ...grpc.CallOption) (*SetProfileResponse, error)
+	// Get the retained history of discovery metrics of this peer, downsampled to
+	// hourly points. Fails with FailedPrecondition unless history retention is
+	// enabled on the server. This operation does not interact with the network.
+	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// Issue an access token authorizing a remote peer to pull the access-gated
+	// records of this peer, or a single one of them, until the token expires.
+	// The token is signed with this peer's identity key and handed to the reader,
+	// who configures it in its gated access tokens.
+	// This operation does not interact with the network.
+	GrantAccess(ctx context.Context, in *GrantAccessRequest, opts ...grpc.CallOption) (*GrantAccessResponse, error)
+	// Export the label cache of this peer, and optionally the records of its local
+	// store, as a portable archive streamed in chunks, so that air-gapped peers can
+	// exchange directory state offline. The archive is imported with ImportState.
+	// This operation does not interact with the network.
+	ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (RoutingService_ExportStateClient, error)
+	// Import an archive produced by ExportState on another peer, streamed in chunks.
+	// Labels are cached as announced by the peers that published them, like when
+	// warming the label cache from a seed peer. Records are stored in the local store
+	// once their content is verified against their CIDs.
+	// This operation does not interact with the network.
+	ImportState(ctx context.Context, opts ...grpc.CallOption) (RoutingService_ImportStateClient, error)
+	// Subscribe to remote records matching queries. A SearchResponse is pushed
+	// whenever labels of a remote record matching the queries are newly cached,
+	// whether they arrive via GossipSub, DHT provider notifications or label sync,
+	// until the stream is cancelled. Records cached before subscribing are returned
+	// by Search, not by the subscription.
+	// Fails with ResourceExhausted if the server holds its maximum number of
+	// subscriptions, and ends with ResourceExhausted if the subscriber does not keep
+	// up with the pushed records, so that it can search for the records it missed.
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RoutingService_SubscribeClient, error)
+	// Get the label taxonomy of this peer: the canonical values of the label
+	// namespaces it normalizes labels of, so that clients can enumerate valid labels.
+	// Labels of records and announcements are normalized to these values.
+	// Fails with FailedPrecondition unless a taxonomy is configured on the server.
+	// This operation does not interact with the network.
+	GetTaxonomy(ctx context.Context, in *GetTaxonomyRequest, opts ...grpc.CallOption) (*GetTaxonomyResponse, error)
+	// Get the provenance of a record as observed by this peer: which peer first
+	// announced it, which peers currently provide it, and the announcements received
+	// with their publishers and signatures, from the provenance log of this peer.
+	// Fails with FailedPrecondition unless provenance logging is enabled on the server,
+	// and with NotFound if no announcement of the record was logged.
+	// This operation does not interact with the network.
+	GetProvenance(ctx context.Context, in *GetProvenanceRequest, opts ...grpc.CallOption) (*GetProvenanceResponse, error)
 }
 
 type routingServiceClient struct {
@@ -83,6 +188,39 @@ func (c *routingServiceClient) Publish(ctx context.Context, in *PublishRequest,
 	return out, nil
 }
 
+func (c *routingServiceClient) PublishWithProgress(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (RoutingService_PublishWithProgressClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[0], RoutingService_PublishWithProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingServicePublishWithProgressClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RoutingService_PublishWithProgressClient interface {
+	Recv() (*PublishProgress, error)
+	grpc.ClientStream
+}
+
+type routingServicePublishWithProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingServicePublishWithProgressClient) Recv() (*PublishProgress, error) {
+	m := new(PublishProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *routingServiceClient) Unpublish(ctx context.Context, in *UnpublishRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -95,7 +233,7 @@ func (c *routingServiceClient) Unpublish(ctx context.Context, in *UnpublishReque
 
 func (c *routingServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (RoutingService_SearchClient, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[0], RoutingService_Search_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[1], RoutingService_Search_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,9 +264,19 @@ func (x *routingServiceSearchClient) Recv() (*SearchResponse, error) {
 	return m, nil
 }
 
+func (c *routingServiceClient) EstimateResults(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*EstimateResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EstimateResultsResponse)
+	err := c.cc.Invoke(ctx, RoutingService_EstimateResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *routingServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (RoutingService_ListClient, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[1], RoutingService_List_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[2], RoutingService_List_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -159,6 +307,230 @@ func (x *routingServiceListClient) Recv() (*ListResponse, error) {
 	return m, nil
 }
 
+func (c *routingServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatsResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) Pin(ctx context.Context, in *PinRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoutingService_Pin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) Unpin(ctx context.Context, in *UnpinRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoutingService_Unpin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) ListPins(ctx context.Context, in *ListPinsRequest, opts ...grpc.CallOption) (RoutingService_ListPinsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[3], RoutingService_ListPins_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingServiceListPinsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RoutingService_ListPinsClient interface {
+	Recv() (*ListPinsResponse, error)
+	grpc.ClientStream
+}
+
+type routingServiceListPinsClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingServiceListPinsClient) Recv() (*ListPinsResponse, error) {
+	m := new(ListPinsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routingServiceClient) VerifyCache(ctx context.Context, in *VerifyCacheRequest, opts ...grpc.CallOption) (*VerifyCacheResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyCacheResponse)
+	err := c.cc.Invoke(ctx, RoutingService_VerifyCache_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) SetProfile(ctx context.Context, in *SetProfileRequest, opts ...grpc.CallOption) (*SetProfileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetProfileResponse)
+	err := c.cc.Invoke(ctx, RoutingService_SetProfile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GetHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) GrantAccess(ctx context.Context, in *GrantAccessRequest, opts ...grpc.CallOption) (*GrantAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantAccessResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GrantAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) ExportState(ctx context.Context, in *ExportStateRequest, opts ...grpc.CallOption) (RoutingService_ExportStateClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[4], RoutingService_ExportState_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingServiceExportStateClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RoutingService_ExportStateClient interface {
+	Recv() (*StateArchiveChunk, error)
+	grpc.ClientStream
+}
+
+type routingServiceExportStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingServiceExportStateClient) Recv() (*StateArchiveChunk, error) {
+	m := new(StateArchiveChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routingServiceClient) ImportState(ctx context.Context, opts ...grpc.CallOption) (RoutingService_ImportStateClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[5], RoutingService_ImportState_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingServiceImportStateClient{ClientStream: stream}
+	return x, nil
+}
+
+type RoutingService_ImportStateClient interface {
+	Send(*StateArchiveChunk) error
+	CloseAndRecv() (*ImportStateResponse, error)
+	grpc.ClientStream
+}
+
+type routingServiceImportStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingServiceImportStateClient) Send(m *StateArchiveChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *routingServiceImportStateClient) CloseAndRecv() (*ImportStateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routingServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RoutingService_SubscribeClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[6], RoutingService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routingServiceSubscribeClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RoutingService_SubscribeClient interface {
+	Recv() (*SearchResponse, error)
+	grpc.ClientStream
+}
+
+type routingServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *routingServiceSubscribeClient) Recv() (*SearchResponse, error) {
+	m := new(SearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routingServiceClient) GetTaxonomy(ctx context.Context, in *GetTaxonomyRequest, opts ...grpc.CallOption) (*GetTaxonomyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTaxonomyResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GetTaxonomy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) GetProvenance(ctx context.Context, in *GetProvenanceRequest, opts ...grpc.CallOption) (*GetProvenanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProvenanceResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GetProvenance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoutingServiceServer is the server API for RoutingService service.
 // All implementations should embed UnimplementedRoutingServiceServer
 // for forward compatibility.
@@ -176,7 +548,17 @@ type RoutingServiceServer interface {
 	//
 	// Items need to be periodically republished (eg. 24h) to the network
 	// to avoid stale data. Republication should be done in the background.
+	//
+	// If record validation is enabled on the peer, records referenced by CID are
+	// validated before the publish is accepted. Records violating a validation rule
+	// fail the call with INVALID_ARGUMENT and a RecordValidationFailure detail per record.
 	Publish(context.Context, *PublishRequest) (*emptypb.Empty, error)
+	// Publish records like Publish, but synchronously instead of in the background,
+	// streaming the progress of each record through the publish stages as they complete:
+	// stored locally, provided to the DHT, published via GossipSub and its labels
+	// propagated to GossipSub peers. Each record ends with a completed or failed event,
+	// after which the stream ends.
+	PublishWithProgress(*PublishRequest, RoutingService_PublishWithProgressServer) error
 	// Stop serving this record to the network. If other peers try
 	// to retrieve this record, the peer will refuse the request.
 	Unpublish(context.Context, *UnpublishRequest) (*emptypb.Empty, error)
@@ -189,10 +571,90 @@ type RoutingServiceServer interface {
 	// Results from the search can be used as an input
 	// to Pull operation to retrieve the records.
 	Search(*SearchRequest, RoutingService_SearchServer) error
+	// Estimate the number of records a Search with the same request would return,
+	// without streaming them. The estimate is computed from cardinality sketches of
+	// the cached labels, so it is cheap but approximate, and ignores limit,
+	// page_token and search_mode.
+	// This operation does not interact with the network.
+	EstimateResults(context.Context, *SearchRequest) (*EstimateResultsResponse, error)
 	// List all records that this peer is currently providing
-	// that match the given parameters.
+	// that match the given parameters, with their labels and announcement status.
 	// This operation does not interact with the network.
 	List(*ListRequest, RoutingService_ListServer) error
+	// Get routing statistics about remote peers, such as the peers
+	// with the most cached labels, announcements, or failed pulls,
+	// and the local records that were found to be unresolvable by other peers.
+	// This operation does not interact with the network.
+	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
+	// Pin remote records found by Search, so that their cached labels are never
+	// cleaned up as stale or expired nor evicted, and they remain searchable while
+	// this peer is disconnected from the network. Optionally mirrors the records
+	// into the local store, so that they also remain pullable.
+	// Revocations by the publishing peers still purge the cached labels.
+	//
+	// Pinned local records are reprovided on every republish cycle, even while the
+	// discovery profile disables republishing, and are neither expired by their TTL
+	// nor garbage collected under disk pressure, see routing.record_gc.
+	Pin(context.Context, *PinRequest) (*emptypb.Empty, error)
+	// Unpin records, subjecting their cached labels to cleanup again and local
+	// records to expiry and garbage collection. Mirrored records are kept in the local store.
+	Unpin(context.Context, *UnpinRequest) (*emptypb.Empty, error)
+	// List the pinned records.
+	// This operation does not interact with the network.
+	ListPins(*ListPinsRequest, RoutingService_ListPinsServer) error
+	// Verify a random sample of the local cache of remote labels by asking the
+	// providers of the sampled records whether they still store them.
+	// Optionally evicts the labels of records that their providers no longer store.
+	// Pinned records are verified but never evicted.
+	VerifyCache(context.Context, *VerifyCacheRequest) (*VerifyCacheResponse, error)
+	// Switch the discovery profile of this peer at runtime, until it is restarted.
+	// GossipSub processing, the label cache limit and republishing follow the new
+	// profile immediately; its DHT mode only takes effect after a restart with the
+	// profile configured.
+	SetProfile(context.Context, *SetProfileRequest) (*SetProfileResponse, error)
+	// Get the retained history of discovery metrics of this peer, downsampled to
+	// hourly points. Fails with FailedPrecondition unless history retention is
+	// enabled on the server. This operation does not interact with the network.
+	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
+	// Issue an access token authorizing a remote peer to pull the access-gated
+	// records of this peer, or a single one of them, until the token expires.
+	// The token is signed with this peer's identity key and handed to the reader,
+	// who configures it in its gated access tokens.
+	// This operation does not interact with the network.
+	GrantAccess(context.Context, *GrantAccessRequest) (*GrantAccessResponse, error)
+	// Export the label cache of this peer, and optionally the records of its local
+	// store, as a portable archive streamed in chunks, so that air-gapped peers can
+	// exchange directory state offline. The archive is imported with ImportState.
+	// This operation does not interact with the network.
+	ExportState(*ExportStateRequest, RoutingService_ExportStateServer) error
+	// Import an archive produced by ExportState on another peer, streamed in chunks.
+	// Labels are cached as announced by the peers that published them, like when
+	// warming the label cache from a seed peer. Records are stored in the local store
+	// once their content is verified against their CIDs.
+	// This operation does not interact with the network.
+	ImportState(RoutingService_ImportStateServer) error
+	// Subscribe to remote records matching queries. A SearchResponse is pushed
+	// whenever labels of a remote record matching the queries are newly cached,
+	// whether they arrive via GossipSub, DHT provider notifications or label sync,
+	// until the stream is cancelled. Records cached before subscribing are returned
+	// by Search, not by the subscription.
+	// Fails with ResourceExhausted if the server holds its maximum number of
+	// subscriptions, and ends with ResourceExhausted if the subscriber does not keep
+	// up with the pushed records, so that it can search for the records it missed.
+	Subscribe(*SubscribeRequest, RoutingService_SubscribeServer) error
+	// Get the label taxonomy of this peer: the canonical values of the label
+	// namespaces it normalizes labels of, so that clients can enumerate valid labels.
+	// Labels of records and announcements are normalized to these values.
+	// Fails with FailedPrecondition unless a taxonomy is configured on the server.
+	// This operation does not interact with the network.
+	GetTaxonomy(context.Context, *GetTaxonomyRequest) (*GetTaxonomyResponse, error)
+	// Get the provenance of a record as observed by this peer: which peer first
+	// announced it, which peers currently provide it, and the announcements received
+	// with their publishers and signatures, from the provenance log of this peer.
+	// Fails with FailedPrecondition unless provenance logging is enabled on the server,
+	// and with NotFound if no announcement of the record was logged.
+	// This operation does not interact with the network.
+	GetProvenance(context.Context, *GetProvenanceRequest) (*GetProvenanceResponse, error)
 }
 
 // UnimplementedRoutingServiceServer should be embedded to have
@@ -205,15 +667,60 @@ type UnimplementedRoutingServiceServer struct{}
 func (UnimplementedRoutingServiceServer) Publish(context.Context, *PublishRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
 }
+func (UnimplementedRoutingServiceServer) PublishWithProgress(*PublishRequest, RoutingService_PublishWithProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method PublishWithProgress not implemented")
+}
 func (UnimplementedRoutingServiceServer) Unpublish(context.Context, *UnpublishRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Unpublish not implemented")
 }
 func (UnimplementedRoutingServiceServer) Search(*SearchRequest, RoutingService_SearchServer) error {
 	return status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedRoutingServiceServer) EstimateResults(context.Context, *SearchRequest) (*EstimateResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateResults not implemented")
+}
 func (UnimplementedRoutingServiceServer) List(*ListRequest, RoutingService_ListServer) error {
 	return status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedRoutingServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedRoutingServiceServer) Pin(context.Context, *PinRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pin not implemented")
+}
+func (UnimplementedRoutingServiceServer) Unpin(context.Context, *UnpinRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unpin not implemented")
+}
+func (UnimplementedRoutingServiceServer) ListPins(*ListPinsRequest, RoutingService_ListPinsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListPins not implemented")
+}
+func (UnimplementedRoutingServiceServer) VerifyCache(context.Context, *VerifyCacheRequest) (*VerifyCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyCache not implemented")
+}
+func (UnimplementedRoutingServiceServer) SetProfile(context.Context, *SetProfileRequest) (*SetProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProfile not implemented")
+}
+func (UnimplementedRoutingServiceServer) GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHistory not implemented")
+}
+func (UnimplementedRoutingServiceServer) GrantAccess(context.Context, *GrantAccessRequest) (*GrantAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantAccess not implemented")
+}
+func (UnimplementedRoutingServiceServer) ExportState(*ExportStateRequest, RoutingService_ExportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportState not implemented")
+}
+func (UnimplementedRoutingServiceServer) ImportState(RoutingService_ImportStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportState not implemented")
+}
+func (UnimplementedRoutingServiceServer) Subscribe(*SubscribeRequest, RoutingService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedRoutingServiceServer) GetTaxonomy(context.Context, *GetTaxonomyRequest) (*GetTaxonomyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaxonomy not implemented")
+}
+func (UnimplementedRoutingServiceServer) GetProvenance(context.Context, *GetProvenanceRequest) (*GetProvenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProvenance not implemented")
+}
 func (UnimplementedRoutingServiceServer) testEmbeddedByValue() {}
 
 // UnsafeRoutingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -252,6 +759,27 @@ func _RoutingService_Publish_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoutingService_PublishWithProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PublishRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoutingServiceServer).PublishWithProgress(m, &routingServicePublishWithProgressServer{ServerStream: stream})
+}
+
+type RoutingService_PublishWithProgressServer interface {
+	Send(*PublishProgress) error
+	grpc.ServerStream
+}
+
+type routingServicePublishWithProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingServicePublishWithProgressServer) Send(m *PublishProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _RoutingService_Unpublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UnpublishRequest)
 	if err := dec(in); err != nil {
@@ -291,6 +819,24 @@ func (x *routingServiceSearchServer) Send(m *SearchResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RoutingService_EstimateResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).EstimateResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_EstimateResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).EstimateResults(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RoutingService_List_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ListRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -312,6 +858,257 @@ func (x *routingServiceListServer) Send(m *ListResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RoutingService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GetStats(ctx, req.(*GetStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_Pin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).Pin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_Pin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).Pin(ctx, req.(*PinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_Unpin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).Unpin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_Unpin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).Unpin(ctx, req.(*UnpinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_ListPins_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListPinsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoutingServiceServer).ListPins(m, &routingServiceListPinsServer{ServerStream: stream})
+}
+
+type RoutingService_ListPinsServer interface {
+	Send(*ListPinsResponse) error
+	grpc.ServerStream
+}
+
+type routingServiceListPinsServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingServiceListPinsServer) Send(m *ListPinsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RoutingService_VerifyCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).VerifyCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_VerifyCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).VerifyCache(ctx, req.(*VerifyCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_SetProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).SetProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_SetProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).SetProfile(ctx, req.(*SetProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_GetHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GetHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GetHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GetHistory(ctx, req.(*GetHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_GrantAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GrantAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GrantAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GrantAccess(ctx, req.(*GrantAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_ExportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoutingServiceServer).ExportState(m, &routingServiceExportStateServer{ServerStream: stream})
+}
+
+type RoutingService_ExportStateServer interface {
+	Send(*StateArchiveChunk) error
+	grpc.ServerStream
+}
+
+type routingServiceExportStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingServiceExportStateServer) Send(m *StateArchiveChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RoutingService_ImportState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RoutingServiceServer).ImportState(&routingServiceImportStateServer{ServerStream: stream})
+}
+
+type RoutingService_ImportStateServer interface {
+	SendAndClose(*ImportStateResponse) error
+	Recv() (*StateArchiveChunk, error)
+	grpc.ServerStream
+}
+
+type routingServiceImportStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingServiceImportStateServer) SendAndClose(m *ImportStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *routingServiceImportStateServer) Recv() (*StateArchiveChunk, error) {
+	m := new(StateArchiveChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _RoutingService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoutingServiceServer).Subscribe(m, &routingServiceSubscribeServer{ServerStream: stream})
+}
+
+type RoutingService_SubscribeServer interface {
+	Send(*SearchResponse) error
+	grpc.ServerStream
+}
+
+type routingServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *routingServiceSubscribeServer) Send(m *SearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RoutingService_GetTaxonomy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaxonomyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GetTaxonomy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GetTaxonomy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GetTaxonomy(ctx, req.(*GetTaxonomyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_GetProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProvenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GetProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GetProvenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GetProvenance(ctx, req.(*GetProvenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoutingService_ServiceDesc is the grpc.ServiceDesc for RoutingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -327,8 +1124,53 @@ var RoutingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Unpublish",
 			Handler:    _RoutingService_Unpublish_Handler,
 		},
+		{
+			MethodName: "EstimateResults",
+			Handler:    _RoutingService_EstimateResults_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _RoutingService_GetStats_Handler,
+		},
+		{
+			MethodName: "Pin",
+			Handler:    _RoutingService_Pin_Handler,
+		},
+		{
+			MethodName: "Unpin",
+			Handler:    _RoutingService_Unpin_Handler,
+		},
+		{
+			MethodName: "VerifyCache",
+			Handler:    _RoutingService_VerifyCache_Handler,
+		},
+		{
+			MethodName: "SetProfile",
+			Handler:    _RoutingService_SetProfile_Handler,
+		},
+		{
+			MethodName: "GetHistory",
+			Handler:    _RoutingService_GetHistory_Handler,
+		},
+		{
+			MethodName: "GrantAccess",
+			Handler:    _RoutingService_GrantAccess_Handler,
+		},
+		{
+			MethodName: "GetTaxonomy",
+			Handler:    _RoutingService_GetTaxonomy_Handler,
+		},
+		{
+			MethodName: "GetProvenance",
+			Handler:    _RoutingService_GetProvenance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PublishWithProgress",
+			Handler:       _RoutingService_PublishWithProgress_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Search",
 			Handler:       _RoutingService_Search_Handler,
@@ -339,6 +1181,26 @@ var RoutingService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _RoutingService_List_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListPins",
+			Handler:       _RoutingService_ListPins_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportState",
+			Handler:       _RoutingService_ExportState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportState",
+			Handler:       _RoutingService_ImportState_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _RoutingService_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "agntcy/dir/routing/v1/routing_service.proto",
 }
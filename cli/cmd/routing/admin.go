@@ -0,0 +1,373 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/agntcy/dir/client"
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Inspect the internal routing state of the peer",
+	Long: `Inspect the internal routing state of the peer, for debugging multi-node
+deployments. All operations are local-only and, except for backfill, restore and promote, read-only.
+
+- table: DHT routing table, with the bucket and connectedness of each peer
+- gossipsub: peers and mesh of each joined GossipSub topic
+- gossipsub-diagnostics: mesh degree and duplicate/invalid message rates of each
+  GossipSub topic, peer scores and announcement propagation latency (measured with
+  routing.gossipsub.propagation_acks)
+- cache: label cache statistics per label namespace
+- queues: depth of the announcement notification queue, dead letters, busy pull workers
+  and pending announcements
+- tasks: schedule and last runs of the cleanup and republish tasks
+- backfill: rebuild the remote label cache from a crawl of the DHT, e.g. after
+  the datastore was lost, and show its progress
+- bandwidth: record content served to each peer by pulls per hour, within the
+  last 24 hours, to spot peers exceeding or abusing the pull quota
+- snapshot: save a point-in-time archive of the routing datastore (label cache, peer
+  addresses, DHT provider records) to a file
+- restore: write a datastore archive into the routing datastore, e.g. on the server
+  replacing the snapshotted one during a blue/green upgrade
+- promote: promote a follower mirroring the datastore of its primary (routing.follower)
+  to announce the records of their shared identity, once the primary is down
+- pins: pinned records with when local records were last reprovided, and the disk
+  usage and collected records of the garbage collection of local records (routing.record_gc)
+
+Usage examples:
+
+1. Check whether the peer has DHT peers:
+   dirctl routing admin table
+
+2. Check the GossipSub mesh of each topic and its health:
+   dirctl routing admin gossipsub --output json
+   dirctl routing admin gossipsub-diagnostics
+
+3. Rebuild the remote label cache and follow its progress:
+   dirctl routing admin backfill --pulls-per-second 10
+   dirctl routing admin backfill --status
+
+4. List the 10 peers pulling the most record content:
+   dirctl routing admin bandwidth --limit 10
+
+5. Move the discovery state of a peer to its replacement:
+   dirctl --server-addr blue:8888 routing admin snapshot datastore.tar
+   dirctl --server-addr green:8888 routing admin restore datastore.tar
+
+6. Fail over to the follower of a primary that went down:
+   dirctl --server-addr follower:8888 routing admin promote
+
+7. Check that pinned local records are reprovided:
+   dirctl routing admin pins --output json
+`,
+}
+
+var adminTableCmd = &cobra.Command{
+	Use:   "table",
+	Short: "Dump the DHT routing table",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetRoutingTable(cmd.Context(), &routingv1.GetRoutingTableRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get routing table: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "routing table", "DHT routing table", resp)
+	},
+}
+
+var adminGossipSubCmd = &cobra.Command{
+	Use:   "gossipsub",
+	Short: "Show the peers and mesh of each GossipSub topic",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetGossipSubState(cmd.Context(), &routingv1.GetGossipSubStateRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get gossipsub state: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "gossipsub state", "GossipSub state", resp)
+	},
+}
+
+var adminGossipSubDiagnosticsCmd = &cobra.Command{
+	Use:   "gossipsub-diagnostics",
+	Short: "Show the message rates, peer scores and propagation latency of GossipSub",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetGossipSubDiagnostics(cmd.Context(), &routingv1.GetGossipSubDiagnosticsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get gossipsub diagnostics: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "gossipsub diagnostics", "GossipSub diagnostics", resp)
+	},
+}
+
+var adminCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Show label cache statistics per label namespace",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetLabelCacheStats(cmd.Context(), &routingv1.GetLabelCacheStatsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get label cache stats: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "label cache stats", "Label cache statistics", resp)
+	},
+}
+
+var adminQueuesCmd = &cobra.Command{
+	Use:   "queues",
+	Short: "Show the depth of the internal routing queues",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetQueueState(cmd.Context(), &routingv1.GetQueueStateRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get queue state: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "queue state", "Routing queues", resp)
+	},
+}
+
+var adminTasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "Show the status of the cleanup and republish tasks",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetTaskStatus(cmd.Context(), &routingv1.GetTaskStatusRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get task status: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "task status", "Routing tasks", resp)
+	},
+}
+
+var adminBackfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Rebuild the remote label cache from a crawl of the DHT",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		if adminBackfillOpts.Status {
+			resp, err := c.GetBackfillStatus(cmd.Context(), &routingv1.GetBackfillStatusRequest{})
+			if err != nil {
+				return fmt.Errorf("failed to get backfill status: %w", err)
+			}
+
+			return presenter.PrintMessage(cmd, "backfill status", "Backfill", resp)
+		}
+
+		resp, err := c.StartBackfill(cmd.Context(), &routingv1.StartBackfillRequest{
+			MaxPeers:       adminBackfillOpts.MaxPeers,
+			MaxRecords:     adminBackfillOpts.MaxRecords,
+			PullsPerSecond: adminBackfillOpts.PullsPerSecond,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start backfill: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "backfill status", "Started backfill", resp)
+	},
+}
+
+var adminBandwidthCmd = &cobra.Command{
+	Use:   "bandwidth",
+	Short: "Show the record content served to each peer by pulls",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.GetBandwidthUsage(cmd.Context(), &routingv1.GetBandwidthUsageRequest{
+			Limit: adminBandwidthOpts.Limit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get bandwidth usage: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "bandwidth usage", "Pull bandwidth", resp)
+	},
+}
+
+var adminSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <file>",
+	Short: "Save a point-in-time archive of the routing datastore",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		path := args[0]
+
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create archive file: %w", err)
+		}
+
+		err = c.SnapshotDatastore(cmd.Context(), file)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write archive file: %w", closeErr)
+		}
+
+		if err != nil {
+			_ = os.Remove(path)
+
+			return fmt.Errorf("failed to snapshot datastore: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "archive", "Datastore snapshot saved to", path)
+	},
+}
+
+var adminRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "Restore a datastore archive into the routing datastore",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to open archive file: %w", err)
+		}
+		defer file.Close()
+
+		resp, err := c.RestoreDatastore(cmd.Context(), file)
+		if err != nil {
+			return fmt.Errorf("failed to restore datastore: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "restore", "Datastore restored", resp)
+	},
+}
+
+var adminPromoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promote a follower to announce the records of its primary",
+	Long: `Promote a follower to announce the records of its primary. The follower stops
+mirroring the routing datastore of the primary, joins the network and starts
+announcing the records of their shared identity.
+
+Promotion is refused while the primary still answers, so that the primary and its
+follower never announce records at the same time. Use --force to promote anyway,
+e.g. when the primary is known to be cut off from the network.
+`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.PromoteFollower(cmd.Context(), &routingv1.PromoteFollowerRequest{Force: adminPromoteOpts.Force})
+		if err != nil {
+			return fmt.Errorf("failed to promote follower: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "promote", "Follower promoted", resp)
+	},
+}
+
+var adminPinsCmd = &cobra.Command{
+	Use:   "pins",
+	Short: "List pinned records and the state of record garbage collection",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		c, err := adminClient(cmd)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.ListPinnedRecords(cmd.Context(), &routingv1.ListPinnedRecordsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to list pinned records: %w", err)
+		}
+
+		return presenter.PrintMessage(cmd, "pinned records", "Pinned records", resp)
+	},
+}
+
+var adminPromoteOpts struct {
+	Force bool
+}
+
+var adminBandwidthOpts struct {
+	Limit uint32
+}
+
+var adminBackfillOpts struct {
+	Status         bool
+	MaxPeers       uint32
+	MaxRecords     uint32
+	PullsPerSecond uint32
+}
+
+func init() {
+	adminBackfillCmd.Flags().BoolVar(&adminBackfillOpts.Status, "status", false, "Show the progress of the running or the last backfill instead of starting one")
+	adminBackfillCmd.Flags().Uint32Var(&adminBackfillOpts.MaxPeers, "max-peers", 0, "Peers to pull records from at most (default 100)")
+	adminBackfillCmd.Flags().Uint32Var(&adminBackfillOpts.MaxRecords, "max-records", 0, "Records to pull at most (default 10000)")
+	adminBackfillCmd.Flags().Uint32Var(&adminBackfillOpts.PullsPerSecond, "pulls-per-second", 0, "Records to pull per second at most (default 5)")
+	adminBandwidthCmd.Flags().Uint32Var(&adminBandwidthOpts.Limit, "limit", 0, "Peers to show at most, those served the most bytes first (default all)")
+	adminPromoteCmd.Flags().BoolVar(&adminPromoteOpts.Force, "force", false, "Promote even if the primary still answers")
+
+	for _, cmd := range []*cobra.Command{adminTableCmd, adminGossipSubCmd, adminGossipSubDiagnosticsCmd, adminCacheCmd, adminQueuesCmd, adminTasksCmd, adminBackfillCmd, adminBandwidthCmd, adminSnapshotCmd, adminRestoreCmd, adminPromoteCmd, adminPinsCmd} {
+		adminCmd.AddCommand(cmd)
+		presenter.AddOutputFlags(cmd)
+	}
+}
+
+// adminClient returns the client of the command.
+func adminClient(cmd *cobra.Command) (*client.Client, error) {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return nil, errors.New("failed to get client from context")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,82 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "cache-stats",
+	Short: "Show label cache statistics per label namespace",
+	Long: `Show statistics of the label cache of the peer per label namespace: the labels
+of local and remote records, distinct label values, and the remote records and
+peers they were announced by.
+
+Usage examples:
+
+1. Show the label cache statistics:
+   dirctl routing cache-stats
+
+2. Show the label cache statistics in JSON format:
+   dirctl routing cache-stats --json
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runCacheStatsCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runCacheStatsCommand(cmd)
+	},
+}
+
+func runCacheStatsCommand(cmd *cobra.Command) error {
+	c, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.GetLabelCacheStats(cmd.Context(), &routingv1.GetLabelCacheStatsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get label cache stats: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "label cache stats", "Label cache statistics", resp)
+	}
+
+	if len(resp.GetNamespaces()) == 0 {
+		presenter.Println(cmd, "The label cache is empty")
+
+		return nil
+	}
+
+	var local, remote int64
+
+	table := newTable(cmd)
+	_, _ = fmt.Fprintln(table, "NAMESPACE\tLOCAL LABELS\tREMOTE LABELS\tDISTINCT LABELS\tREMOTE RECORDS\tREMOTE PEERS")
+
+	for _, ns := range resp.GetNamespaces() {
+		local += ns.GetLocalLabels()
+		remote += ns.GetRemoteLabels()
+
+		_, _ = fmt.Fprintf(table, "%s\t%d\t%d\t%d\t%d\t%d\n",
+			ns.GetNamespace(), ns.GetLocalLabels(), ns.GetRemoteLabels(), ns.GetDistinctLabels(), ns.GetRemoteRecords(), ns.GetRemotePeers())
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("failed to print label cache stats: %w", err)
+	}
+
+	presenter.Printf(cmd, "\n%d local and %d remote label(s)", local, remote)
+
+	if resp.GetMaxCachedLabels() > 0 {
+		presenter.Printf(cmd, ", remote records are evicted above %d label(s)", resp.GetMaxCachedLabels())
+	}
+
+	presenter.Printf(cmd, "\n")
+
+	return nil
+}
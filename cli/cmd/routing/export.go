@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Export the label cache of this peer, and optionally its records, to an archive",
+	Long: `Export the label cache of this peer, and optionally the records of its local
+store, to a state archive file.
+
+The archive can be carried to a peer without network access to this one, e.g. an
+air-gapped peer, and imported there with "dirctl routing import". Labels of tenant
+records and the content of access-gated records are never exported.
+
+Usage examples:
+
+1. Export the label cache:
+   dirctl routing export state.tar
+
+2. Export the label cache and the records of the local store:
+   dirctl routing export state.tar --records
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExportCommand(cmd, args[0])
+	},
+}
+
+// Export command options.
+var exportOpts struct {
+	Records bool
+}
+
+func init() {
+	exportCmd.Flags().BoolVar(&exportOpts.Records, "records", false, "Include the records of the local store")
+}
+
+func runExportCommand(cmd *cobra.Command, path string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+
+	err = c.ExportState(cmd.Context(), &routingv1.ExportStateRequest{IncludeRecords: exportOpts.Records}, file)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write archive file: %w", closeErr)
+	}
+
+	if err != nil {
+		_ = os.Remove(path)
+
+		return fmt.Errorf("failed to export state: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "archive", "State exported to", path)
+}
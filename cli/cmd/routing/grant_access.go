@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+var grantAccessCmd = &cobra.Command{
+	Use:   "grant-access <peer-id>",
+	Short: "Issue an access token for the access-gated records of this peer",
+	Long: `Issue an access token authorizing a remote peer to pull the records that this
+peer published as access-gated.
+
+Other peers only learn the labels of access-gated records. The token is signed
+with the identity key of this peer and authorizes only the given peer, optionally
+only for a single record, until it expires. Hand it to the operator of the remote
+peer, who adds it to the routing.gated_access_tokens setting of their peer.
+
+Usage examples:
+
+1. Authorize a peer to pull all access-gated records for 30 days:
+   dirctl routing grant-access 12D3KooW...
+
+2. Authorize a peer to pull a single record for a day:
+   dirctl routing grant-access 12D3KooW... --cid <cid> --ttl 24h
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGrantAccessCommand(cmd, args[0])
+	},
+}
+
+// Grant access command options.
+var grantAccessOpts struct {
+	CID string
+	TTL time.Duration
+}
+
+func init() {
+	grantAccessCmd.Flags().StringVar(&grantAccessOpts.CID, "cid", "", "Only authorize pulling this record (default all access-gated records)")
+	grantAccessCmd.Flags().DurationVar(&grantAccessOpts.TTL, "ttl", 0, "Validity of the token (default 30 days)")
+}
+
+func runGrantAccessCommand(cmd *cobra.Command, peerID string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	req := &routingv1.GrantAccessRequest{PeerId: peerID, Cid: grantAccessOpts.CID}
+	if grantAccessOpts.TTL > 0 {
+		req.Ttl = durationpb.New(grantAccessOpts.TTL)
+	}
+
+	resp, err := c.GrantAccess(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("failed to grant access: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "access token", "Access token issued", resp)
+}
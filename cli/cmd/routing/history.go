@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the retained discovery history of the peer",
+	Long: `Show the discovery metrics retained by the peer, downsampled to hourly points.
+
+Each point reports the announcements published and received and the searches
+served during the hour, and the average number of cached labels, cached remote
+records and connected peers. History is only retained by peers that enable it
+(routing.history.enabled), for the configured retention (14 days by default).
+
+Usage examples:
+
+1. Show the history of the last week:
+   dirctl routing history
+
+2. Compare with the week before:
+   dirctl routing history --since 336h --until 168h
+
+3. Export the full retained history as JSON:
+   dirctl routing history --since 0 --output json
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runHistoryCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runHistoryCommand(cmd)
+	},
+}
+
+// History command options.
+var historyOpts struct {
+	Since time.Duration
+	Until time.Duration
+}
+
+func init() {
+	historyCmd.Flags().DurationVar(&historyOpts.Since, "since", 7*24*time.Hour, "Show points starting within this duration before now (0 for all retained points)")
+	historyCmd.Flags().DurationVar(&historyOpts.Until, "until", 0, "Show points starting before this duration before now (0 for up to now)")
+}
+
+func runHistoryCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	if historyOpts.Since > 0 && historyOpts.Until >= historyOpts.Since {
+		return fmt.Errorf("--until (%s) must be shorter than --since (%s)", historyOpts.Until, historyOpts.Since)
+	}
+
+	now := time.Now()
+	req := &routingv1.GetHistoryRequest{}
+
+	if historyOpts.Since > 0 {
+		req.Since = timestamppb.New(now.Add(-historyOpts.Since))
+	}
+
+	if historyOpts.Until > 0 {
+		req.Until = timestamppb.New(now.Add(-historyOpts.Until))
+	}
+
+	resp, err := c.GetRoutingHistory(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("failed to get discovery history: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "discovery history", "Discovery history", resp)
+}
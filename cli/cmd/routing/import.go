@@ -0,0 +1,56 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a state archive exported by another peer",
+	Long: `Import a state archive exported by another peer with "dirctl routing export".
+
+Labels are imported into the label cache like labels received from a seed peer:
+labels older than the maximum label age, labels of the local peer and labels of
+revoked records are skipped. Records are verified against their CIDs and stored
+in the local store, unless it already holds them.
+
+Usage examples:
+
+1. Import a state archive:
+   dirctl routing import state.tar
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImportCommand(cmd, args[0])
+	},
+}
+
+func runImportCommand(cmd *cobra.Command, path string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open archive file: %w", err)
+	}
+	defer file.Close()
+
+	resp, err := c.ImportState(cmd.Context(), file)
+	if err != nil {
+		return fmt.Errorf("failed to import state: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "import", "State imported", resp)
+}
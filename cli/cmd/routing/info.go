@@ -7,7 +7,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
@@ -28,12 +31,16 @@ Key Features:
 - Label distribution: Frequency of each label across records
 - Local-only: Shows statistics for local routing data only
 - Fast: Uses local storage index for efficient counting
+- Peer leaderboards: Remote peers with the most cached labels, announcements, or failed pulls
 
 Usage examples:
 
 1. Show local routing statistics:
    dirctl routing info
 
+2. Include leaderboards of remote peers:
+   dirctl routing info --peers
+
 Note: For network-wide statistics, use 'dirctl routing search' with broad queries.
 `,
 	//nolint:gocritic // Lambda required due to signature mismatch - runInfoCommand doesn't use args
@@ -42,7 +49,13 @@ Note: For network-wide statistics, use 'dirctl routing search' with broad querie
 	},
 }
 
+var infoOpts struct {
+	Peers bool
+}
+
 func init() {
+	infoCmd.Flags().BoolVar(&infoOpts.Peers, "peers", false, "Include leaderboards of remote peers")
+
 	// Add output format flags
 	presenter.AddOutputFlags(infoCmd)
 }
@@ -68,6 +81,13 @@ func runInfoCommand(cmd *cobra.Command) error {
 	// Collect statistics
 	stats := collectRoutingStatistics(resultCh)
 
+	if infoOpts.Peers {
+		stats.peers, err = c.GetRoutingStats(cmd.Context(), &routingv1.GetStatsRequest{})
+		if err != nil {
+			return fmt.Errorf("failed to get peer statistics: %w", err)
+		}
+	}
+
 	// Output in the appropriate format
 	if outputOpts.Format == presenter.FormatJSON {
 		return outputJSONStatistics(cmd, stats)
@@ -76,6 +96,7 @@ func runInfoCommand(cmd *cobra.Command) error {
 	// Default human-readable format
 	presenter.Printf(cmd, "Local Routing Summary:\n\n")
 	displayRoutingStatistics(cmd, stats)
+	displayPeerStatistics(cmd, stats.peers)
 
 	return nil
 }
@@ -89,6 +110,19 @@ func outputJSONStatistics(cmd *cobra.Command, stats *routingStatistics) error {
 		"otherLabels":  stats.otherLabels,
 	}
 
+	if stats.peers != nil {
+		result["peers"] = map[string]interface{}{
+			"topLabelCounts":       stats.peers.GetTopLabelCounts(),
+			"topAnnouncementRates": stats.peers.GetTopAnnouncementRates(),
+			"topPullFailures":      stats.peers.GetTopPullFailures(),
+			"topClockSkews":        stats.peers.GetTopClockSkews(),
+			"topProviderSets":      stats.peers.GetTopProviderSets(),
+		}
+		result["unresolvableRecords"] = stats.peers.GetUnresolvableRecords()
+		result["runtimeState"] = stats.peers.GetRuntimeState()
+		result["profile"] = stats.peers.GetProfile().String()
+	}
+
 	output, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal JSON: %w", err)
@@ -105,6 +139,7 @@ type routingStatistics struct {
 	skillCounts   map[string]int
 	locatorCounts map[string]int
 	otherLabels   map[string]int
+	peers         *routingv1.GetStatsResponse // nil unless requested
 }
 
 // collectRoutingStatistics processes routing results and collects statistics.
@@ -217,3 +252,93 @@ func displayHelpfulTips(cmd *cobra.Command) {
 	presenter.Printf(cmd, "  - Use 'dirctl routing list --skill <skill>' to filter by skill\n")
 	presenter.Printf(cmd, "  - Use 'dirctl routing search --skill <skill>' to find remote records\n")
 }
+
+// displayPeerStatistics shows the leaderboards of remote peers.
+func displayPeerStatistics(cmd *cobra.Command, peers *routingv1.GetStatsResponse) {
+	if peers == nil {
+		return
+	}
+
+	if profile := peers.GetProfile(); profile != routingv1.DiscoveryProfile_DISCOVERY_PROFILE_UNSPECIFIED {
+		presenter.Printf(cmd, "\n🧭 Discovery Profile: %s\n", strings.ToLower(strings.TrimPrefix(profile.String(), "DISCOVERY_PROFILE_")))
+	}
+
+	presenter.Printf(cmd, "\n🌐 Remote Peers:\n")
+	displayPeerLeaderboard(cmd, "Most cached labels", "%.0f label(s)", peers.GetTopLabelCounts())
+	displayPeerLeaderboard(cmd, "Highest announcement rate", "%.1f announcement(s)/hour", peers.GetTopAnnouncementRates())
+	displayPeerLeaderboard(cmd, "Most failed pulls", "%.0f failure(s)", peers.GetTopPullFailures())
+	displayPeerLeaderboard(cmd, "Largest clock skew", "%.1f second(s)", peers.GetTopClockSkews())
+	displayProviderSets(cmd, peers.GetTopProviderSets())
+	displayUnresolvableRecords(cmd, peers.GetUnresolvableRecords())
+	displayRuntimeState(cmd, peers.GetRuntimeState())
+}
+
+// displayRuntimeState shows the runtime state persisted across restarts.
+func displayRuntimeState(cmd *cobra.Command, state *routingv1.RuntimeState) {
+	if state == nil {
+		return
+	}
+
+	presenter.Printf(cmd, "\n⏱️  Runtime State:\n")
+	presenter.Printf(cmd, "  Started: %s\n", state.GetStartedAt().AsTime().Format(time.RFC3339))
+
+	if state.GetPreviousStoppedAt() != nil {
+		presenter.Printf(cmd, "  Previous stop: %s\n", state.GetPreviousStoppedAt().AsTime().Format(time.RFC3339))
+	} else {
+		presenter.Printf(cmd, "  Previous stop: (unclean or first start)\n")
+	}
+
+	presenter.Printf(cmd, "  Announcements: %d published, %d received\n",
+		state.GetAnnouncementsPublished(), state.GetAnnouncementsReceived())
+
+	tasks := slices.Sorted(maps.Keys(state.GetLastTaskRuns()))
+	for _, task := range tasks {
+		presenter.Printf(cmd, "  Last %s: %s\n", task, state.GetLastTaskRuns()[task].AsTime().Format(time.RFC3339))
+	}
+}
+
+// displayProviderSets shows the remote records announced by the most peers.
+func displayProviderSets(cmd *cobra.Command, sets []*routingv1.ProviderSet) {
+	if len(sets) == 0 {
+		return
+	}
+
+	presenter.Printf(cmd, "\n👥 Most Provided Records:\n")
+
+	for _, set := range sets {
+		presenter.Printf(cmd, "  - %s: %d provider(s), %d label(s) (first seen %s, last seen %s)\n",
+			set.GetCid(), set.GetProviderCount(), len(set.GetLabels()),
+			set.GetFirstSeen().AsTime().Format(time.RFC3339),
+			set.GetLastSeen().AsTime().Format(time.RFC3339))
+	}
+}
+
+// displayUnresolvableRecords shows the local records that other peers failed to resolve.
+func displayUnresolvableRecords(cmd *cobra.Command, checks []*routingv1.AnnouncementCheck) {
+	if len(checks) == 0 {
+		return
+	}
+
+	presenter.Printf(cmd, "\n⚠️  Unresolvable Records:\n")
+
+	for _, check := range checks {
+		presenter.Printf(cmd, "  - %s: DHT %d/%d peer(s), GossipSub %d/%d peer(s) (checked %s)\n",
+			check.GetCid(),
+			check.GetDhtPeersResolved(), check.GetDhtPeersChecked(),
+			check.GetGossipPeersResolved(), check.GetGossipPeersChecked(),
+			check.GetCheckedAt().AsTime().Format(time.RFC3339))
+	}
+}
+
+// displayPeerLeaderboard shows a single peer leaderboard.
+func displayPeerLeaderboard(cmd *cobra.Command, title, valueFormat string, entries []*routingv1.PeerStat) {
+	presenter.Printf(cmd, "  %s:\n", title)
+
+	if len(entries) == 0 {
+		presenter.Printf(cmd, "    (none)\n")
+	}
+
+	for i, entry := range entries {
+		presenter.Printf(cmd, "    %d. %s: "+valueFormat+"\n", i+1, entry.GetPeerId(), entry.GetValue())
+	}
+}
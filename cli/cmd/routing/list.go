@@ -7,6 +7,7 @@ package routing
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
@@ -28,6 +29,8 @@ Key Features:
 - Fast: Uses local storage index, no network access
 - Filtering: Supports skill and locator queries with AND logic
 - Efficient: Extracts labels from storage keys, no content parsing
+- Status: Shows when each record was published and last announced, and whether
+  other peers resolved it at the last announcement check
 
 Usage examples:
 
@@ -43,6 +46,9 @@ Usage examples:
 4. List specific record by CID:
    dirctl routing list --cid <cid>
 
+5. List records matching a DIRQL expression:
+   dirctl routing list --query 'skill:AI AND NOT module:legacy'
+
 Note: For network-wide discovery, use 'dirctl routing search' instead.
 `,
 	//nolint:gocritic // Lambda required due to signature mismatch - runListCommand doesn't use args
@@ -58,6 +64,7 @@ var listOpts struct {
 	Locators []string
 	Domains  []string
 	Modules  []string
+	Query    string
 	Limit    uint32
 }
 
@@ -68,6 +75,7 @@ func init() {
 	listCmd.Flags().StringArrayVar(&listOpts.Locators, "locator", nil, "Filter by locator type (can be repeated)")
 	listCmd.Flags().StringArrayVar(&listOpts.Domains, "domain", nil, "Filter by domain (can be repeated)")
 	listCmd.Flags().StringArrayVar(&listOpts.Modules, "module", nil, "Filter by module (can be repeated)")
+	listCmd.Flags().StringVar(&listOpts.Query, "query", "", "Filter by a DIRQL expression (e.g., --query 'skill:AI OR domain:research')")
 	listCmd.Flags().Uint32Var(&listOpts.Limit, "limit", 0, "Maximum number of results (0 = no limit)")
 
 	// Add examples in flag help
@@ -131,6 +139,7 @@ func runListCommand(cmd *cobra.Command) error {
 	// Build list request
 	req := &routingv1.ListRequest{
 		Queries: queries,
+		Query:   listOpts.Query,
 	}
 
 	// Add optional limit
@@ -150,7 +159,7 @@ func runListCommand(cmd *cobra.Command) error {
 		results = append(results, result)
 	}
 
-	return presenter.PrintMessage(cmd, "local records", "Local records found", results)
+	return printListResults(cmd, results)
 }
 
 // listByCID lists a specific record by CID.
@@ -174,5 +183,32 @@ func listByCID(cmd *cobra.Command, c *client.Client, cid string) error {
 		}
 	}
 
-	return presenter.PrintMessage(cmd, "local records", "Local records found", results)
+	return printListResults(cmd, results)
+}
+
+// printListResults prints local records as a table, or in the requested output format.
+func printListResults(cmd *cobra.Command, results []interface{}) error {
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman || len(results) == 0 {
+		return presenter.PrintMessage(cmd, "local records", "Local records found", results)
+	}
+
+	table := newTable(cmd)
+	_, _ = fmt.Fprintln(table, "CID\tLABELS")
+
+	for _, result := range results {
+		record, ok := result.(*routingv1.ListResponse)
+		if !ok {
+			continue
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%s\n", record.GetRecordRef().GetCid(), orDash(strings.Join(record.GetLabels(), ", ")))
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("failed to print local records: %w", err)
+	}
+
+	presenter.Printf(cmd, "\nLocal records found: %d\n", len(results))
+
+	return nil
 }
@@ -0,0 +1,108 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var peersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "List the peers of the DHT routing table",
+	Long: `List the peers of the DHT routing table of the peer, with their bucket,
+connectedness and known addresses.
+
+Usage examples:
+
+1. List the peers of the routing table:
+   dirctl routing peers
+
+2. Only list connected peers:
+   dirctl routing peers --connected
+
+3. List the peers in JSON format:
+   dirctl routing peers --json
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runPeersCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runPeersCommand(cmd)
+	},
+}
+
+var peersOpts struct {
+	Connected bool
+}
+
+func init() {
+	peersCmd.Flags().BoolVar(&peersOpts.Connected, "connected", false, "Only list peers that are currently connected")
+}
+
+func runPeersCommand(cmd *cobra.Command) error {
+	c, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.GetRoutingTable(cmd.Context(), &routingv1.GetRoutingTableRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to get routing table: %w", err)
+	}
+
+	peers := make([]*routingv1.RoutingTablePeer, 0, len(resp.GetPeers()))
+
+	for _, peer := range resp.GetPeers() {
+		if peersOpts.Connected && !peer.GetConnected() {
+			continue
+		}
+
+		peers = append(peers, peer)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		results := make([]interface{}, len(peers))
+		for i, peer := range peers {
+			results[i] = peer
+		}
+
+		return presenter.PrintMessage(cmd, "peers", "Peers", results)
+	}
+
+	if len(peers) == 0 {
+		presenter.Println(cmd, "No peers found")
+
+		return nil
+	}
+
+	table := newTable(cmd)
+	_, _ = fmt.Fprintln(table, "PEER ID\tBUCKET\tCONNECTED\tLAST USEFUL\tADDRESSES")
+
+	for _, peer := range peers {
+		lastUseful := "-"
+		if peer.GetLastUsefulAt() != nil {
+			lastUseful = peer.GetLastUsefulAt().AsTime().Format(time.RFC3339)
+		}
+
+		_, _ = fmt.Fprintf(table, "%s\t%d\t%t\t%s\t%s\n",
+			peer.GetPeerId(), peer.GetBucket(), peer.GetConnected(), lastUseful, orDash(strings.Join(peer.GetAddrs(), ", ")))
+	}
+
+	if err := table.Flush(); err != nil {
+		return fmt.Errorf("failed to print peers: %w", err)
+	}
+
+	mode := "client"
+	if resp.GetServerMode() {
+		mode = "server"
+	}
+
+	presenter.Printf(cmd, "\n%d peer(s), DHT in %s mode\n", len(peers), mode)
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <cid>...",
+	Short: "Pin records to keep them available offline or announced",
+	Long: `Pin remote records found by search to keep them available while this peer
+is disconnected from the network, e.g. on edge nodes, or local records to keep
+them announced.
+
+Cached labels of pinned remote records are never cleaned up as stale or expired nor
+evicted from the label cache, so the records remain searchable. With --mirror,
+the records are also pulled from one of their providers into the local store,
+so they remain pullable.
+
+Pinned local records are reprovided on every republish cycle, even while the
+discovery profile disables republishing, and are neither removed when their TTL
+elapses nor garbage collected under disk pressure (routing.record_gc).
+
+Usage examples:
+
+1. Keep remote records searchable offline:
+   dirctl routing pin <cid> <cid>
+
+2. Also keep their content pullable offline:
+   dirctl routing pin <cid> --mirror
+
+3. Keep a local record announced and out of garbage collection:
+   dirctl routing pin <local-cid>
+
+Note: Revocations by the publishing peers still remove pinned records from search.
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPinCommand(cmd, args)
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <cid>...",
+	Short: "Unpin records",
+	Long: `Unpin records, so that their cached labels are cleaned up again once stale or expired,
+and local records are removed again once their TTL elapses or under disk pressure.
+
+Mirrored records are kept in the local store. Use 'dirctl delete' to remove them.
+
+Usage examples:
+
+1. Unpin a record:
+   dirctl routing unpin <cid>
+`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runUnpinCommand(cmd, args)
+	},
+}
+
+var pinsCmd = &cobra.Command{
+	Use:   "pins",
+	Short: "List pinned records",
+	Long: `List the pinned records of this peer.
+
+This operation does not interact with the network.
+
+Usage examples:
+
+1. List pinned records:
+   dirctl routing pins
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runPinsCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runPinsCommand(cmd)
+	},
+}
+
+// Pin command options.
+var pinOpts struct {
+	Mirror bool
+}
+
+func init() {
+	pinCmd.Flags().BoolVar(&pinOpts.Mirror, "mirror", false, "Also pull the records into the local store")
+}
+
+func recordRefs(cids []string) []*corev1.RecordRef {
+	refs := make([]*corev1.RecordRef, 0, len(cids))
+	for _, cid := range cids {
+		refs = append(refs, &corev1.RecordRef{Cid: cid})
+	}
+
+	return refs
+}
+
+func runPinCommand(cmd *cobra.Command, cids []string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	if err := c.Pin(cmd.Context(), &routingv1.PinRequest{
+		Refs:          recordRefs(cids),
+		MirrorContent: pinOpts.Mirror,
+	}); err != nil {
+		return fmt.Errorf("failed to pin: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"cids":     cids,
+		"status":   "pinned",
+		"mirrored": pinOpts.Mirror,
+	}
+
+	return presenter.PrintMessage(cmd, "Pin", "Successfully pinned records", result)
+}
+
+func runUnpinCommand(cmd *cobra.Command, cids []string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	if err := c.Unpin(cmd.Context(), &routingv1.UnpinRequest{Refs: recordRefs(cids)}); err != nil {
+		return fmt.Errorf("failed to unpin: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"cids":   cids,
+		"status": "unpinned",
+	}
+
+	return presenter.PrintMessage(cmd, "Unpin", "Successfully unpinned records", result)
+}
+
+func runPinsCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	pinCh, err := c.ListPins(cmd.Context(), &routingv1.ListPinsRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list pins: %w", err)
+	}
+
+	results := make([]interface{}, 0)
+	for pin := range pinCh {
+		results = append(results, pin)
+	}
+
+	return presenter.PrintMessage(cmd, "pinned records", "Pinned records", results)
+}
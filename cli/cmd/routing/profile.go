@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile <full|edge|client>",
+	Short: "Switch the discovery profile of the peer",
+	Long: `Switch the discovery profile of the peer at runtime, until it is restarted.
+
+Profiles bundle the routing behavior of a peer for its role in the network:
+
+- full: serves the DHT, processes GossipSub announcements, caches remote labels
+  up to the configured limit and republishes its records
+- edge: uses the DHT as a client only, processes GossipSub announcements,
+  caches a bounded number of remote labels and republishes its records
+- client: uses the DHT as a client only, ignores GossipSub announcements,
+  caches few remote labels and does not republish its records
+
+The DHT mode of a profile only takes effect after a restart with the profile
+configured (routing.profile). The current profile is shown by
+'dirctl routing info --peers'.
+
+Usage examples:
+
+1. Run a peer as a lightweight client:
+   dirctl routing profile client
+
+2. Switch back to a full node:
+   dirctl routing profile full
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProfileCommand(cmd, args[0])
+	},
+}
+
+// parseDiscoveryProfile converts a discovery profile argument to the API enum.
+func parseDiscoveryProfile(profile string) (routingv1.DiscoveryProfile, error) {
+	switch strings.ToLower(profile) {
+	case "full":
+		return routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL, nil
+	case "edge":
+		return routingv1.DiscoveryProfile_DISCOVERY_PROFILE_EDGE, nil
+	case "client":
+		return routingv1.DiscoveryProfile_DISCOVERY_PROFILE_CLIENT, nil
+	default:
+		return routingv1.DiscoveryProfile_DISCOVERY_PROFILE_UNSPECIFIED, fmt.Errorf("invalid discovery profile %q, must be one of: full, edge, client", profile)
+	}
+}
+
+func runProfileCommand(cmd *cobra.Command, name string) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	profile, err := parseDiscoveryProfile(name)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.SetProfile(cmd.Context(), &routingv1.SetProfileRequest{Profile: profile})
+	if err != nil {
+		return fmt.Errorf("failed to set discovery profile: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "discovery profile", "Discovery profile switched", resp)
+}
@@ -0,0 +1,78 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var provenanceCmd = &cobra.Command{
+	Use:   "provenance <cid>",
+	Short: "Show which peers announced a record and when",
+	Long: `Show the provenance of a record as observed by the peer: which peer first
+announced it, which peers currently provide it, and the announcements the peer
+received, with their publishers and signatures.
+
+Announcements are only logged while routing.provenance is enabled on the peer.
+
+Usage examples:
+
+1. Show the provenance of a record:
+   dirctl routing provenance <cid>
+
+2. Show the provenance in JSON format:
+   dirctl routing provenance <cid> --json
+`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProvenanceCommand(cmd, args[0])
+	},
+}
+
+func runProvenanceCommand(cmd *cobra.Command, cid string) error {
+	c, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.GetProvenance(cmd.Context(), &routingv1.GetProvenanceRequest{Cid: cid})
+	if err != nil {
+		return fmt.Errorf("failed to get provenance: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "provenance", "Record provenance", resp)
+	}
+
+	first := resp.GetFirstAnnouncement()
+	presenter.Printf(cmd, "First announced by %s at %s\n", first.GetPeerId(), first.GetAnnouncedAt().AsTime().Format(time.RFC3339))
+	presenter.Printf(cmd, "Providers (%d):\n", len(resp.GetProviders()))
+
+	for _, peerID := range resp.GetProviders() {
+		presenter.Printf(cmd, "  %s\n", peerID)
+	}
+
+	presenter.Printf(cmd, "Announcements (%d):\n", len(resp.GetAnnouncements()))
+
+	for _, entry := range resp.GetAnnouncements() {
+		presenter.Printf(cmd, "  %s  %-10s  %s", entry.GetReceivedAt().AsTime().Format(time.RFC3339), entry.GetSource(), entry.GetPeerId())
+
+		if entry.GetSigned() {
+			presenter.Printf(cmd, "  signed")
+		}
+
+		if entry.GetPublisher() != "" {
+			presenter.Printf(cmd, "  publisher=%s", entry.GetPublisher())
+		}
+
+		presenter.Printf(cmd, "\n")
+	}
+
+	return nil
+}
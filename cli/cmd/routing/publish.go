@@ -8,12 +8,14 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
 	ctxUtils "github.com/agntcy/dir/cli/util/context"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 var publishCmd = &cobra.Command{
@@ -37,6 +39,33 @@ Usage examples:
 1. Publish a record to the network:
    dirctl routing publish <cid>
 
+2. Publish a record with high priority (faster discovery, more frequent republishing):
+   dirctl routing publish <cid> --priority high
+
+3. Publish a record with low priority (DHT only, no GossipSub label announcements):
+   dirctl routing publish <cid> --priority low
+
+4. Publish a record that stops being discoverable after 24 hours:
+   dirctl routing publish <cid> --ttl 24h
+
+5. Publish a new version of a record, superseding the previous one:
+   dirctl routing publish <cid> --supersedes <previous-cid>
+
+6. Announce a record and its labels without serving its content to unauthorized peers:
+   dirctl routing publish <cid> --access-gated
+
+7. Publish a record for a tenant configured on the network:
+   dirctl routing publish <cid> --tenant acme
+
+8. Publish a record and wait until its labels propagated, printing each publish stage:
+   dirctl routing publish <cid> --wait
+
+9. Publish a record as a publisher whose key is configured on the peer:
+   dirctl routing publish <cid> --publisher did:key:z6Mk...
+
+10. Publish a record without announcing its internal module labels:
+   dirctl routing publish <cid> --exclude-labels modules=internal/*
+
 Note: The record must already be pushed to storage before publishing.
 `,
 	Args: cobra.ExactArgs(1),
@@ -45,7 +74,93 @@ Note: The record must already be pushed to storage before publishing.
 	},
 }
 
+var publishOpts struct {
+	Priority    string
+	TTL         time.Duration
+	Supersedes  string
+	AccessGated bool
+	Tenant      string
+	Publisher   string
+	Include     []string
+	Exclude     []string
+	Wait        bool
+}
+
+func init() {
+	publishCmd.Flags().StringVar(&publishOpts.Priority, "priority", "normal", "Announcement priority (high, normal, low)")
+	publishCmd.Flags().DurationVar(&publishOpts.TTL, "ttl", 0, "Time after which the record is no longer announced (at least 1m, 0 never expires)")
+	publishCmd.Flags().StringVar(&publishOpts.Supersedes, "supersedes", "", "CID of the previous version of the record")
+	publishCmd.Flags().BoolVar(&publishOpts.AccessGated, "access-gated", false, "Only serve the record's content to peers allowed to pull access-gated records")
+	publishCmd.Flags().BoolVar(&publishOpts.Wait, "wait", false, "Publish synchronously, printing the progress of the record through the publish stages")
+	publishCmd.Flags().StringVar(&publishOpts.Tenant, "tenant", "", "Tenant to publish the record for; only searches of the tenant find it unless the tenant is public")
+	publishCmd.Flags().StringVar(&publishOpts.Publisher, "publisher", "", "did:key of the publisher to publish the record as; its key must be configured on the peer")
+	publishCmd.Flags().StringArrayVar(&publishOpts.Include, "include-labels", nil, "Only publish the labels of a namespace matching a pattern, as <namespace>=<pattern>; overrides the namespace's configured policy")
+	publishCmd.Flags().StringArrayVar(&publishOpts.Exclude, "exclude-labels", nil, "Withhold the labels of a namespace matching a pattern, as <namespace>=<pattern>; overrides the namespace's configured policy")
+}
+
+// parseLabelPolicies groups the include and exclude patterns of the label policy flags by namespace.
+func parseLabelPolicies(include, exclude []string) ([]*routingv1.LabelPolicy, error) {
+	var policies []*routingv1.LabelPolicy
+
+	policy := func(namespace string) *routingv1.LabelPolicy {
+		for _, p := range policies {
+			if p.GetNamespace() == namespace {
+				return p
+			}
+		}
+
+		p := &routingv1.LabelPolicy{Namespace: namespace}
+		policies = append(policies, p)
+
+		return p
+	}
+
+	for _, flag := range []struct {
+		values  []string
+		include bool
+	}{{include, true}, {exclude, false}} {
+		for _, value := range flag.values {
+			namespace, pattern, ok := strings.Cut(value, "=")
+			if !ok || namespace == "" || pattern == "" {
+				return nil, fmt.Errorf("invalid label pattern %q, must be <namespace>=<pattern>", value)
+			}
+
+			if p := policy(namespace); flag.include {
+				p.Include = append(p.Include, pattern)
+			} else {
+				p.Exclude = append(p.Exclude, pattern)
+			}
+		}
+	}
+
+	return policies, nil
+}
+
+// parsePriority converts a priority flag value to the API enum.
+func parsePriority(priority string) (routingv1.AnnouncementPriority, error) {
+	switch strings.ToLower(priority) {
+	case "high":
+		return routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH, nil
+	case "normal", "":
+		return routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL, nil
+	case "low":
+		return routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW, nil
+	default:
+		return routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED, fmt.Errorf("invalid priority %q, must be one of: high, normal, low", priority)
+	}
+}
+
 func runPublishCommand(cmd *cobra.Command, cid string) error {
+	priority, err := parsePriority(publishOpts.Priority)
+	if err != nil {
+		return err
+	}
+
+	labelPolicies, err := parseLabelPolicies(publishOpts.Include, publishOpts.Exclude)
+	if err != nil {
+		return err
+	}
+
 	// Get the client from the context
 	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
 	if !ok {
@@ -58,19 +173,36 @@ func runPublishCommand(cmd *cobra.Command, cid string) error {
 	}
 
 	// Lookup metadata to verify record exists
-	_, err := c.Lookup(cmd.Context(), recordRef)
+	_, err = c.Lookup(cmd.Context(), recordRef)
 	if err != nil {
 		return fmt.Errorf("failed to lookup: %w", err)
 	}
 
-	// Start publishing using the same RecordRef
-	if err := c.Publish(cmd.Context(), &routingv1.PublishRequest{
+	req := &routingv1.PublishRequest{
 		Request: &routingv1.PublishRequest_RecordRefs{
 			RecordRefs: &routingv1.RecordRefs{
 				Refs: []*corev1.RecordRef{recordRef},
 			},
 		},
-	}); err != nil {
+		Priority:    priority,
+		Supersedes:  publishOpts.Supersedes,
+		AccessGated: publishOpts.AccessGated,
+		TenantId:    publishOpts.Tenant,
+		Publisher:   publishOpts.Publisher,
+
+		LabelPolicies: labelPolicies,
+	}
+
+	if publishOpts.TTL > 0 {
+		req.Ttl = durationpb.New(publishOpts.TTL)
+	}
+
+	if publishOpts.Wait {
+		return publishWithProgress(cmd, req)
+	}
+
+	// Start publishing using the same RecordRef
+	if err := c.Publish(cmd.Context(), req); err != nil {
 		if strings.Contains(err.Error(), "failed to announce object") {
 			return errors.New("failed to announce object, it will be retried in the background on the API server")
 		}
@@ -87,3 +219,78 @@ func runPublishCommand(cmd *cobra.Command, cid string) error {
 
 	return presenter.PrintMessage(cmd, "Publish", "Successfully submitted publication request", result)
 }
+
+// publishWithProgress publishes a record synchronously, printing each publish stage it reaches.
+func publishWithProgress(cmd *cobra.Command, req *routingv1.PublishRequest) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	human := presenter.GetOutputOptions(cmd).Format == presenter.FormatHuman
+
+	var (
+		events []interface{}
+		failed error
+	)
+
+	err := c.PublishWithProgress(cmd.Context(), req, func(event *routingv1.PublishProgress) {
+		events = append(events, event)
+
+		if event.GetStage() == routingv1.PublishStage_PUBLISH_STAGE_FAILED {
+			failed = errors.New(event.GetError())
+		}
+
+		if human {
+			presenter.Printf(cmd, "%s %s %s\n", event.GetTime().AsTime().Format(time.TimeOnly), event.GetCid(), describePublishStage(event))
+
+			for _, violation := range event.GetViolations() {
+				presenter.Printf(cmd, "  - %s\n", describeViolation(violation))
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish: %w", err)
+	}
+
+	if failed != nil {
+		return fmt.Errorf("failed to publish: %w", failed)
+	}
+
+	if human {
+		return nil
+	}
+
+	return presenter.PrintMessage(cmd, "Publish", "Publish progress", events)
+}
+
+// describeViolation describes a violated validation rule, e.g. "skills[0].name: ... (taxonomy)".
+func describeViolation(violation *routingv1.RecordViolation) string {
+	if violation.GetField() == "" {
+		return fmt.Sprintf("%s (%s)", violation.GetMessage(), violation.GetRule())
+	}
+
+	return fmt.Sprintf("%s: %s (%s)", violation.GetField(), violation.GetMessage(), violation.GetRule())
+}
+
+// describePublishStage describes the publish stage a record reached.
+func describePublishStage(event *routingv1.PublishProgress) string {
+	switch event.GetStage() {
+	case routingv1.PublishStage_PUBLISH_STAGE_STORED:
+		return "stored locally"
+	case routingv1.PublishStage_PUBLISH_STAGE_QUEUED:
+		return "queued until the peer has network connectivity"
+	case routingv1.PublishStage_PUBLISH_STAGE_DHT_PROVIDED:
+		return "provided to the DHT"
+	case routingv1.PublishStage_PUBLISH_STAGE_GOSSIPSUB_PUBLISHED:
+		return fmt.Sprintf("published via GossipSub to %d topic peers", event.GetTopicPeers())
+	case routingv1.PublishStage_PUBLISH_STAGE_LABELS_PROPAGATED:
+		return fmt.Sprintf("labels cached by %d of %d checked peers", event.GetPeersResolved(), event.GetPeersChecked())
+	case routingv1.PublishStage_PUBLISH_STAGE_COMPLETED:
+		return "published"
+	case routingv1.PublishStage_PUBLISH_STAGE_FAILED:
+		return "failed: " + event.GetError()
+	default:
+		return event.GetStage().String()
+	}
+}
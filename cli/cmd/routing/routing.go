@@ -19,7 +19,19 @@ This command group provides access to all routing-specific operations:
 - unpublish: Remove records from network discovery
 - list: Query local records with filtering
 - search: Discover remote records from other peers
+- subscribe: Watch for new remote records matching search criteria
 - info: Show routing statistics and summary information
+- peers: List the peers of the DHT routing table
+- cache-stats: Show label cache statistics per label namespace
+- taxonomy: List the valid labels of the label taxonomy of the peer
+- pin, unpin, pins: Keep remote records available while disconnected from the network
+- verify-cache: Verify cached remote records against their providers
+- profile: Switch the discovery profile of the peer
+- history: Show the retained discovery history of the peer
+- provenance: Show which peers announced a record and when
+- grant-access: Authorize a peer to pull the access-gated records of the peer
+- export, import: Carry the label cache and records to peers without network access
+- admin: Inspect the internal routing state of the peer
 
 Examples:
 
@@ -35,6 +47,12 @@ Examples:
 4. Unpublish a record from the network:
    dirctl routing unpublish <cid>
 
+5. Watch for new remote records as they are discovered:
+   dirctl routing subscribe --skill "AI"
+
+6. List the connected peers of the routing table:
+   dirctl routing peers --connected
+
 This follows clear service separation - all routing API operations are grouped together.
 `,
 }
@@ -45,9 +63,34 @@ func init() {
 	Command.AddCommand(unpublishCmd)
 	Command.AddCommand(listCmd)
 	Command.AddCommand(searchCmd)
+	Command.AddCommand(subscribeCmd)
 	Command.AddCommand(infoCmd)
+	Command.AddCommand(peersCmd)
+	Command.AddCommand(cacheStatsCmd)
+	Command.AddCommand(taxonomyCmd)
+	Command.AddCommand(pinCmd)
+	Command.AddCommand(unpinCmd)
+	Command.AddCommand(pinsCmd)
+	Command.AddCommand(verifyCacheCmd)
+	Command.AddCommand(profileCmd)
+	Command.AddCommand(historyCmd)
+	Command.AddCommand(provenanceCmd)
+	Command.AddCommand(grantAccessCmd)
+	Command.AddCommand(exportCmd)
+	Command.AddCommand(importCmd)
+	Command.AddCommand(adminCmd)
 
 	// Add output format flags to routing subcommands
 	presenter.AddOutputFlags(publishCmd)
 	presenter.AddOutputFlags(unpublishCmd)
+	presenter.AddOutputFlags(pinCmd)
+	presenter.AddOutputFlags(unpinCmd)
+	presenter.AddOutputFlags(pinsCmd)
+	presenter.AddOutputFlags(verifyCacheCmd)
+	presenter.AddOutputFlags(profileCmd)
+	presenter.AddOutputFlags(historyCmd)
+	presenter.AddOutputFlags(provenanceCmd)
+	presenter.AddOutputFlags(peersCmd)
+	presenter.AddOutputFlags(cacheStatsCmd)
+	presenter.AddOutputFlags(taxonomyCmd)
 }
@@ -7,6 +7,8 @@ package routing
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/cli/presenter"
@@ -25,8 +27,19 @@ It uses cached network announcements and filters out local records.
 Key Features:
 - Remote-only: Only returns records from other peers
 - OR logic: Records returned if they match ≥ minScore queries
+- Boolean logic: Require all criteria (--all) and exclude records (--exclude-*)
 - Match scoring: Shows how well records match your criteria
+- Hierarchical matching: Also match parents of the searched labels, ranking exact matches first (--hierarchical)
 - Peer information: Shows which peer provides each record
+- Live mode: Also query connected peers for records not yet in the cache (--live)
+- Search mode: Prefer fast or thorough results (--mode fast|thorough)
+- Retrieval: Skip peers that cannot serve records the way you pull them (--require-retrieval rpc-pull|streaming)
+- Scoring: Pick how result relevance is computed (--scoring count|weighted-namespaces|freshness|reputation|ranked)
+- Versions: Only return the latest version of records that were republished as new versions (--latest-only)
+- Zones: Prefer or only return records of peers in given locality zones (--zone, --zones-only)
+- Tenants: Search the records of a tenant configured on the network (--tenant)
+- Publishers: Only return records signed by a publisher, whichever peer hosts them (--publisher)
+- Estimation: Estimate the number of matching records without fetching them (--estimate)
 
 Usage examples:
 
@@ -39,6 +52,53 @@ Usage examples:
 3. Search with result limiting:
    dirctl routing search --skill "web-development" --limit 5
 
+4. Search a custom label namespace configured on the network:
+   dirctl routing search --label "teams/platform"
+
+5. Search with boolean logic (skill=AI AND domain=research AND NOT module=legacy):
+   dirctl routing search --skill "AI" --domain "research" --exclude-module "legacy" --all
+
+6. Resume a previous search from the next_page_token of its last result:
+   dirctl routing search --skill "web-development" --limit 5 --page-token <token>
+
+7. Also query connected peers for recently published records:
+   dirctl routing search --skill "AI" --live
+
+8. Return quickly from the in-memory index, or trade latency for recall:
+   dirctl routing search --skill "AI" --mode fast
+   dirctl routing search --skill "AI" --mode thorough
+
+9. Estimate how many records match before running a broad search:
+   dirctl routing search --skill "AI" --estimate
+
+10. Only return records of peers that serve streaming pulls over their Directory API:
+   dirctl routing search --skill "AI" --require-retrieval streaming
+
+11. Rank recently announced records higher when comparing result relevance:
+   dirctl routing search --skill "AI" --scoring freshness
+
+12. Search with a DIRQL expression:
+   dirctl routing search --query 'skill:"AI/ML" AND (domain:research OR module:tensorflow)'
+
+13. Skip records superseded by a newer version of the same peer:
+   dirctl routing search --skill "AI" --latest-only
+
+14. Rank results by a normalized combination of match, freshness, reputation and provider count:
+   dirctl routing search --skill "AI" --skill "ML" --scoring ranked --ranking-weight match=2 --ranking-weight providers=1
+
+15. Prefer records of peers in your zone, or only return those:
+   dirctl routing search --skill "AI" --zone eu-west-1
+   dirctl routing search --skill "AI" --zone eu-west-1 --zones-only
+
+16. Search the records of a tenant:
+   dirctl routing search --skill "AI" --tenant acme
+
+17. Search the records of a publisher:
+   dirctl routing search --skill "AI" --publisher did:key:z6Mk...
+
+18. Also match records labelled with a parent skill, exact matches scoring higher:
+   dirctl routing search --skill "AI/ML" --hierarchical
+
 `,
 	//nolint:gocritic // Lambda required due to signature mismatch - runSearchCommand doesn't use args
 	RunE: func(cmd *cobra.Command, _ []string) error {
@@ -48,13 +108,35 @@ Usage examples:
 
 // Search command options.
 var searchOpts struct {
-	Skills   []string
-	Locators []string
-	Domains  []string
-	Modules  []string
-	Limit    uint32
-	MinScore uint32
-	JSON     bool
+	Skills       []string
+	Locators     []string
+	Domains      []string
+	Modules      []string
+	Labels       []string
+	Query        string
+	All          bool
+	Hierarchical bool
+	Limit        uint32
+	MinScore     uint32
+	PageToken    string
+	Live         bool
+	Latest       bool
+	Mode         string
+	Retrieval    string
+	Zones        []string
+	ZonesOnly    bool
+	Tenant       string
+	Publisher    string
+	Scoring      string
+	Weights      map[string]string
+	Estimate     bool
+	JSON         bool
+
+	ExcludeSkills   []string
+	ExcludeLocators []string
+	ExcludeDomains  []string
+	ExcludeModules  []string
+	ExcludeLabels   []string
 }
 
 const (
@@ -69,8 +151,29 @@ func init() {
 	searchCmd.Flags().StringArrayVar(&searchOpts.Locators, "locator", nil, "Search for records with specific locator type (can be repeated)")
 	searchCmd.Flags().StringArrayVar(&searchOpts.Domains, "domain", nil, "Search for records with specific domain (can be repeated)")
 	searchCmd.Flags().StringArrayVar(&searchOpts.Modules, "module", nil, "Search for records with specific module (can be repeated)")
+	searchCmd.Flags().StringArrayVar(&searchOpts.Labels, "label", nil, "Search for records with a label of any namespace (can be repeated)")
+	searchCmd.Flags().StringVar(&searchOpts.Query, "query", "", "DIRQL expression, counting as a single query (e.g., --query 'skill:AI AND NOT module:legacy')")
+	searchCmd.Flags().BoolVar(&searchOpts.All, "all", false, "Only return records matching all search criteria instead of at least --min-score of them")
+	searchCmd.Flags().BoolVar(&searchOpts.Hierarchical, "hierarchical", false, "Also match the parents of the searched labels; exact matches score 2, parent and child matches 1")
+	searchCmd.Flags().StringArrayVar(&searchOpts.ExcludeSkills, "exclude-skill", nil, "Exclude records with specific skill (can be repeated)")
+	searchCmd.Flags().StringArrayVar(&searchOpts.ExcludeLocators, "exclude-locator", nil, "Exclude records with specific locator type (can be repeated)")
+	searchCmd.Flags().StringArrayVar(&searchOpts.ExcludeDomains, "exclude-domain", nil, "Exclude records with specific domain (can be repeated)")
+	searchCmd.Flags().StringArrayVar(&searchOpts.ExcludeModules, "exclude-module", nil, "Exclude records with specific module (can be repeated)")
+	searchCmd.Flags().StringArrayVar(&searchOpts.ExcludeLabels, "exclude-label", nil, "Exclude records with a label of any namespace (can be repeated)")
 	searchCmd.Flags().Uint32Var(&searchOpts.Limit, "limit", defaultSearchLimit, "Maximum number of results to return")
 	searchCmd.Flags().Uint32Var(&searchOpts.MinScore, "min-score", defaultMinScore, "Minimum match score (number of queries that must match)")
+	searchCmd.Flags().StringVar(&searchOpts.PageToken, "page-token", "", "Continuation token to resume a previous search after its last result")
+	searchCmd.Flags().BoolVar(&searchOpts.Live, "live", false, "Also query connected peers directly, not just cached labels")
+	searchCmd.Flags().BoolVar(&searchOpts.Latest, "latest-only", false, "Skip records superseded by a newer version announced by the same peer")
+	searchCmd.Flags().StringVar(&searchOpts.Mode, "mode", "", "Search mode (fast, thorough); defaults to a regular cache search")
+	searchCmd.Flags().StringVar(&searchOpts.Retrieval, "require-retrieval", "", "Retrieval method peers must support (rpc-pull, streaming)")
+	searchCmd.Flags().StringArrayVar(&searchOpts.Zones, "zone", nil, "Prefer records of peers in this locality zone (can be repeated)")
+	searchCmd.Flags().BoolVar(&searchOpts.ZonesOnly, "zones-only", false, "Only return records of peers in the --zone zones")
+	searchCmd.Flags().StringVar(&searchOpts.Tenant, "tenant", "", "Tenant to search as; private tenants only find their own records")
+	searchCmd.Flags().StringVar(&searchOpts.Publisher, "publisher", "", "Only return records signed by this publisher (did:key); skips live results")
+	searchCmd.Flags().StringVar(&searchOpts.Scoring, "scoring", "", "Relevance scoring strategy (count, weighted-namespaces, freshness, reputation); defaults to the server's")
+	searchCmd.Flags().StringToStringVar(&searchOpts.Weights, "ranking-weight", nil, "Weight of a ranking factor of --scoring ranked (match, freshness, reputation, providers), e.g. match=2 (can be repeated)")
+	searchCmd.Flags().BoolVar(&searchOpts.Estimate, "estimate", false, "Only estimate the number of matching records")
 	searchCmd.Flags().BoolVar(&searchOpts.JSON, "json", false, "Output results in JSON format")
 
 	// Add examples in flag help
@@ -78,53 +181,109 @@ func init() {
 	searchCmd.Flags().Lookup("locator").Usage = "Search for records with specific locator type (e.g., --locator 'docker-image')"
 	searchCmd.Flags().Lookup("domain").Usage = "Search for records with specific domain (e.g., --domain 'research' --domain 'analytics')"
 	searchCmd.Flags().Lookup("module").Usage = "Search for records with specific module (e.g., --module 'runtime/language' --module 'runtime/framework')"
+	searchCmd.Flags().Lookup("label").Usage = "Search for records with a label of any namespace, including custom ones (e.g., --label 'teams/platform')"
 }
 
-func runSearchCommand(cmd *cobra.Command) error {
-	// Get the client from the context
-	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
-	if !ok {
-		return errors.New("failed to get client from context")
+// parseSearchMode converts a search mode flag value to the API enum.
+func parseSearchMode(mode string) (routingv1.SearchMode, error) {
+	switch strings.ToLower(mode) {
+	case "":
+		return routingv1.SearchMode_SEARCH_MODE_UNSPECIFIED, nil
+	case "fast":
+		return routingv1.SearchMode_SEARCH_MODE_FAST, nil
+	case "thorough":
+		return routingv1.SearchMode_SEARCH_MODE_THOROUGH, nil
+	default:
+		return routingv1.SearchMode_SEARCH_MODE_UNSPECIFIED, fmt.Errorf("invalid search mode %q, must be one of: fast, thorough", mode)
+	}
+}
+
+// parseRetrievalMethod converts a retrieval method flag value to the API enum.
+func parseRetrievalMethod(method string) (routingv1.RetrievalMethod, error) {
+	switch strings.ToLower(method) {
+	case "":
+		return routingv1.RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED, nil
+	case "rpc-pull":
+		return routingv1.RetrievalMethod_RETRIEVAL_METHOD_RPC_PULL, nil
+	case "streaming":
+		return routingv1.RetrievalMethod_RETRIEVAL_METHOD_STREAMING, nil
+	default:
+		return routingv1.RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED, fmt.Errorf("invalid retrieval method %q, must be one of: rpc-pull, streaming", method)
 	}
+}
 
-	// Build queries from flags
-	queries := make([]*routingv1.RecordQuery, 0, len(searchOpts.Skills)+len(searchOpts.Locators)+len(searchOpts.Domains)+len(searchOpts.Modules))
+// parseScoringStrategy converts a scoring strategy flag value to the API enum.
+func parseScoringStrategy(strategy string) (routingv1.ScoringStrategy, error) {
+	switch strings.ToLower(strategy) {
+	case "":
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED, nil
+	case "count":
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_COUNT, nil
+	case "weighted-namespaces":
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_WEIGHTED_NAMESPACES, nil
+	case "freshness":
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_FRESHNESS, nil
+	case "reputation":
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_REPUTATION, nil
+	case "ranked":
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_RANKED, nil
+	default:
+		return routingv1.ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED, fmt.Errorf("invalid scoring strategy %q, must be one of: count, weighted-namespaces, freshness, reputation, ranked", strategy)
+	}
+}
 
-	// Add skill queries
-	for _, skill := range searchOpts.Skills {
-		queries = append(queries, &routingv1.RecordQuery{
-			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
-			Value: skill,
-		})
+// parseRankingWeights converts ranking weight flag values to the API message.
+// Returns nil if no weights are set, so that the server's weights are used.
+func parseRankingWeights(weights map[string]string) (*routingv1.RankingWeights, error) {
+	if len(weights) == 0 {
+		return nil, nil //nolint:nilnil
 	}
 
-	// Add locator queries
-	for _, locator := range searchOpts.Locators {
-		queries = append(queries, &routingv1.RecordQuery{
-			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR,
-			Value: locator,
-		})
+	result := &routingv1.RankingWeights{}
+
+	for factor, value := range weights {
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ranking weight %q of factor %q: %w", value, factor, err)
+		}
+
+		switch strings.ToLower(factor) {
+		case "match":
+			result.Match = &weight
+		case "freshness":
+			result.Freshness = &weight
+		case "reputation":
+			result.Reputation = &weight
+		case "providers":
+			result.Providers = &weight
+		default:
+			return nil, fmt.Errorf("invalid ranking factor %q, must be one of: match, freshness, reputation, providers", factor)
+		}
 	}
 
-	// Add domain queries
-	for _, domain := range searchOpts.Domains {
-		queries = append(queries, &routingv1.RecordQuery{
-			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
-			Value: domain,
-		})
+	return result, nil
+}
+
+func runSearchCommand(cmd *cobra.Command) error {
+	// Get the client from the context
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
 	}
 
-	// Add module queries
-	for _, module := range searchOpts.Modules {
-		queries = append(queries, &routingv1.RecordQuery{
-			Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE,
-			Value: module,
-		})
+	// Build queries from flags
+	queries := buildQueries(searchOpts.Skills, searchOpts.Locators, searchOpts.Domains, searchOpts.Modules, searchOpts.Labels)
+	excluded := buildQueries(searchOpts.ExcludeSkills, searchOpts.ExcludeLocators, searchOpts.ExcludeDomains, searchOpts.ExcludeModules, searchOpts.ExcludeLabels)
+
+	if searchOpts.Hierarchical {
+		for _, query := range queries {
+			query.MatchMode = routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL
+		}
 	}
 
 	// Validate that we have at least some criteria
-	if len(queries) == 0 {
-		presenter.Printf(cmd, "No search criteria specified. Use --skill, --locator, --domain, or --module flags.\n")
+	if len(queries) == 0 && len(excluded) == 0 && searchOpts.Query == "" {
+		presenter.Printf(cmd, "No search criteria specified. Use --skill, --locator, --domain, --module, --label, or --query flags.\n")
 		presenter.Printf(cmd, "Examples:\n")
 		presenter.Printf(cmd, "  dirctl routing search --skill 'AI' --locator 'docker-image'\n")
 		presenter.Printf(cmd, "  dirctl routing search --domain 'research' --module 'runtime/language'\n")
@@ -132,11 +291,55 @@ func runSearchCommand(cmd *cobra.Command) error {
 		return nil
 	}
 
+	// Combine the criteria into a single boolean query if requested
+	if searchOpts.All || len(excluded) > 0 {
+		if cmd.Flags().Changed("min-score") && searchOpts.MinScore > defaultMinScore {
+			return errors.New("--min-score cannot be combined with --all or --exclude-* flags")
+		}
+
+		queries = []*routingv1.RecordQuery{booleanQuery(queries, excluded, searchOpts.All)}
+	}
+
 	// Build search request
 	req := &routingv1.SearchRequest{
-		Queries: queries,
+		Queries:    queries,
+		Query:      searchOpts.Query,
+		Live:       searchOpts.Live,
+		LatestOnly: searchOpts.Latest,
+		Zones:      searchOpts.Zones,
+		ZonesOnly:  searchOpts.ZonesOnly,
+		TenantId:   searchOpts.Tenant,
+		Publisher:  searchOpts.Publisher,
 	}
 
+	mode, err := parseSearchMode(searchOpts.Mode)
+	if err != nil {
+		return err
+	}
+
+	req.SearchMode = mode
+
+	retrieval, err := parseRetrievalMethod(searchOpts.Retrieval)
+	if err != nil {
+		return err
+	}
+
+	req.RequiredRetrievalMethod = retrieval
+
+	scoring, err := parseScoringStrategy(searchOpts.Scoring)
+	if err != nil {
+		return err
+	}
+
+	req.ScoringStrategy = scoring
+
+	weights, err := parseRankingWeights(searchOpts.Weights)
+	if err != nil {
+		return err
+	}
+
+	req.RankingWeights = weights
+
 	// Add optional parameters
 	if searchOpts.Limit > 0 {
 		req.Limit = &searchOpts.Limit
@@ -146,17 +349,166 @@ func runSearchCommand(cmd *cobra.Command) error {
 		req.MinMatchScore = &searchOpts.MinScore
 	}
 
+	if searchOpts.PageToken != "" {
+		req.PageToken = &searchOpts.PageToken
+	}
+
+	if searchOpts.Estimate {
+		return runEstimate(cmd, req)
+	}
+
 	// Execute search
 	resultCh, err := c.SearchRouting(cmd.Context(), req)
 	if err != nil {
 		return fmt.Errorf("failed to search routing: %w", err)
 	}
 
-	// Collect results
-	results := make([]interface{}, 0, searchOpts.Limit)
+	if searchOpts.JSON {
+		// Collect results
+		results := make([]interface{}, 0, searchOpts.Limit)
+		for result := range resultCh {
+			results = append(results, result)
+		}
+
+		return presenter.PrintMessage(cmd, "remote records", "Remote records found", results)
+	}
+
+	// Print results as they are received, as searches of remote peers can take a while
+	var (
+		count     uint32
+		pageToken string
+	)
+
 	for result := range resultCh {
-		results = append(results, result)
+		count++
+		pageToken = result.GetNextPageToken()
+
+		printSearchResult(cmd, result)
 	}
 
-	return presenter.PrintMessage(cmd, "remote records", "Remote records found", results)
+	if count == 0 {
+		presenter.Println(cmd, "No remote records found")
+
+		return nil
+	}
+
+	presenter.Printf(cmd, "\nRemote records found: %d\n", count)
+
+	if pageToken != "" && count == searchOpts.Limit {
+		presenter.Printf(cmd, "More results may be available: --page-token %s\n", pageToken)
+	}
+
+	return nil
+}
+
+// printSearchResult prints a search result as soon as it is received.
+func printSearchResult(cmd *cobra.Command, result *routingv1.SearchResponse) {
+	matches := make([]string, 0, len(result.GetMatchQueries()))
+	for _, query := range result.GetMatchQueries() {
+		matches = append(matches, describeQuery(query))
+	}
+
+	presenter.Printf(cmd, "%s %s from %s (score %d, relevance %.2f)\n",
+		result.GetRecordRef().GetCid(), orDash(result.GetName()), result.GetPeer().GetId(), result.GetMatchScore(), result.GetRelevance())
+
+	if len(matches) > 0 {
+		presenter.Printf(cmd, "  matched: %s\n", strings.Join(matches, ", "))
+	}
+}
+
+// describeQuery describes a matched query, e.g. "skill=AI" or "(skill=AI AND NOT module=legacy)".
+func describeQuery(query *routingv1.RecordQuery) string {
+	if group := query.GetGroup(); group != nil {
+		queries := make([]string, len(group.GetQueries()))
+		for i, q := range group.GetQueries() {
+			queries[i] = describeQuery(q)
+		}
+
+		operator := strings.TrimPrefix(group.GetOperator().String(), "RECORD_QUERY_OPERATOR_")
+		if group.GetOperator() == routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT {
+			return "NOT " + strings.Join(queries, " AND NOT ")
+		}
+
+		return "(" + strings.Join(queries, " "+operator+" ") + ")"
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(query.GetType().String(), "RECORD_QUERY_TYPE_"))
+
+	return name + "=" + query.GetValue()
+}
+
+// runEstimate prints the estimated number of records matching the search request.
+func runEstimate(cmd *cobra.Command, req *routingv1.SearchRequest) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	resp, err := c.EstimateRoutingResults(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("failed to estimate results: %w", err)
+	}
+
+	if searchOpts.JSON {
+		return presenter.PrintMessage(cmd, "result estimate", "Result estimate", resp)
+	}
+
+	presenter.Printf(cmd, "About %d matching records (of %d cached)\n", resp.GetEstimatedCount(), resp.GetTotalCount())
+
+	return nil
+}
+
+// buildQueries converts search criteria into record queries.
+func buildQueries(skills, locators, domains, modules, labels []string) []*routingv1.RecordQuery {
+	queries := make([]*routingv1.RecordQuery, 0, len(skills)+len(locators)+len(domains)+len(modules)+len(labels))
+
+	for _, criteria := range []struct {
+		queryType routingv1.RecordQueryType
+		values    []string
+	}{
+		{routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, skills},
+		{routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, locators},
+		{routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, domains},
+		{routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, modules},
+		{routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, labels},
+	} {
+		for _, value := range criteria.values {
+			queries = append(queries, &routingv1.RecordQuery{
+				Type:  criteria.queryType,
+				Value: value,
+			})
+		}
+	}
+
+	return queries
+}
+
+// booleanQuery combines the search criteria into a single query group.
+// Records must match all of the queries if all is set, or any of them otherwise,
+// and none of the excluded queries.
+func booleanQuery(queries, excluded []*routingv1.RecordQuery, all bool) *routingv1.RecordQuery {
+	var criteria []*routingv1.RecordQuery
+
+	switch {
+	case len(queries) == 0:
+	case all:
+		criteria = append(criteria, queries...)
+	default:
+		criteria = append(criteria, queryGroup(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, queries))
+	}
+
+	if len(excluded) > 0 {
+		criteria = append(criteria, queryGroup(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, excluded))
+	}
+
+	return queryGroup(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, criteria)
+}
+
+func queryGroup(operator routingv1.RecordQueryOperator, queries []*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{
+		Group: &routingv1.RecordQueryGroup{
+			Operator: operator,
+			Queries:  queries,
+		},
+	}
 }
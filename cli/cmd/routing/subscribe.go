@@ -0,0 +1,169 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var subscribeCmd = &cobra.Command{
+	Use:   "subscribe",
+	Short: "Watch for new remote records matching search criteria",
+	Long: `Watch for new remote records matching search criteria.
+
+Instead of polling search, this command subscribes to the peer and prints each remote
+record matching the criteria as soon as the peer discovers it via GossipSub or DHT.
+Records discovered before the subscription are not printed; use search to find them.
+
+The subscription runs until interrupted, or until the peer ends it because its
+subscription limit is reached or the output does not keep up with new records.
+
+Usage examples:
+
+1. Watch for new records with a skill:
+   dirctl routing subscribe --skill "AI"
+
+2. Watch for records matching several criteria:
+   dirctl routing subscribe --skill "AI" --domain "research" --min-score 2
+
+3. Watch with a DIRQL expression, printing one JSON result per line:
+   dirctl routing subscribe --query 'skill:"AI/ML" AND NOT module:legacy' --json
+
+4. Watch for new records of a publisher in a tenant:
+   dirctl routing subscribe --skill "AI" --tenant acme --publisher did:key:z6Mk...
+
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runSubscribeCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runSubscribeCommand(cmd)
+	},
+}
+
+// Subscribe command options.
+var subscribeOpts struct {
+	Skills    []string
+	Locators  []string
+	Domains   []string
+	Modules   []string
+	Labels    []string
+	Query     string
+	All       bool
+	MinScore  uint32
+	Tenant    string
+	Publisher string
+	JSON      bool
+
+	ExcludeSkills   []string
+	ExcludeLocators []string
+	ExcludeDomains  []string
+	ExcludeModules  []string
+	ExcludeLabels   []string
+}
+
+func init() {
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.Skills, "skill", nil, "Watch for records with specific skill (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.Locators, "locator", nil, "Watch for records with specific locator type (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.Domains, "domain", nil, "Watch for records with specific domain (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.Modules, "module", nil, "Watch for records with specific module (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.Labels, "label", nil, "Watch for records with a label of any namespace (can be repeated)")
+	subscribeCmd.Flags().StringVar(&subscribeOpts.Query, "query", "", "DIRQL expression, counting as a single query")
+	subscribeCmd.Flags().BoolVar(&subscribeOpts.All, "all", false, "Only print records matching all criteria instead of at least --min-score of them")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.ExcludeSkills, "exclude-skill", nil, "Exclude records with specific skill (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.ExcludeLocators, "exclude-locator", nil, "Exclude records with specific locator type (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.ExcludeDomains, "exclude-domain", nil, "Exclude records with specific domain (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.ExcludeModules, "exclude-module", nil, "Exclude records with specific module (can be repeated)")
+	subscribeCmd.Flags().StringArrayVar(&subscribeOpts.ExcludeLabels, "exclude-label", nil, "Exclude records with a label of any namespace (can be repeated)")
+	subscribeCmd.Flags().Uint32Var(&subscribeOpts.MinScore, "min-score", defaultMinScore, "Minimum match score (number of queries that must match)")
+	subscribeCmd.Flags().StringVar(&subscribeOpts.Tenant, "tenant", "", "Tenant to subscribe as; private tenants only see their own records")
+	subscribeCmd.Flags().StringVar(&subscribeOpts.Publisher, "publisher", "", "Only print records signed by this publisher (did:key)")
+	subscribeCmd.Flags().BoolVar(&subscribeOpts.JSON, "json", false, "Output one result per line in JSON format")
+}
+
+func runSubscribeCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	queries := buildQueries(subscribeOpts.Skills, subscribeOpts.Locators, subscribeOpts.Domains, subscribeOpts.Modules, subscribeOpts.Labels)
+	excluded := buildQueries(subscribeOpts.ExcludeSkills, subscribeOpts.ExcludeLocators, subscribeOpts.ExcludeDomains, subscribeOpts.ExcludeModules, subscribeOpts.ExcludeLabels)
+
+	if len(queries) == 0 && len(excluded) == 0 && subscribeOpts.Query == "" {
+		return errors.New("no subscription criteria specified, use --skill, --locator, --domain, --module, --label, or --query flags")
+	}
+
+	// Combine the criteria into a single boolean query if requested
+	if subscribeOpts.All || len(excluded) > 0 {
+		if cmd.Flags().Changed("min-score") && subscribeOpts.MinScore > defaultMinScore {
+			return errors.New("--min-score cannot be combined with --all or --exclude-* flags")
+		}
+
+		queries = []*routingv1.RecordQuery{booleanQuery(queries, excluded, subscribeOpts.All)}
+	}
+
+	req := &routingv1.SubscribeRequest{
+		Queries:   queries,
+		Query:     subscribeOpts.Query,
+		TenantId:  subscribeOpts.Tenant,
+		Publisher: subscribeOpts.Publisher,
+	}
+
+	if subscribeOpts.MinScore > 0 {
+		req.MinMatchScore = &subscribeOpts.MinScore
+	}
+
+	if !subscribeOpts.JSON {
+		presenter.Printf(cmd, "Watching for new matching records, press Ctrl+C to stop\n")
+	}
+
+	var printErr error
+
+	err := c.Subscribe(cmd.Context(), req, func(result *routingv1.SearchResponse) {
+		if printErr == nil {
+			printErr = printSubscriptionResult(cmd, result)
+		}
+	})
+
+	// Interrupting the command ends the subscription
+	if cmd.Context().Err() != nil {
+		return printErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("subscription ended: %w", err)
+	}
+
+	return printErr
+}
+
+// printSubscriptionResult prints a result as soon as it is received.
+func printSubscriptionResult(cmd *cobra.Command, result *routingv1.SearchResponse) error {
+	if subscribeOpts.JSON {
+		output, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+
+		presenter.Println(cmd, string(output))
+
+		return nil
+	}
+
+	name := result.GetName()
+	if name == "" {
+		name = "-"
+	}
+
+	presenter.Printf(cmd, "%s %s from %s (score %d)\n", result.GetRecordRef().GetCid(), name, result.GetPeer().GetId(), result.GetMatchScore())
+
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// newTable returns a writer aligning tab-separated columns of the command output.
+// Rows are only written once the table is flushed.
+func newTable(cmd *cobra.Command) *tabwriter.Writer {
+	return tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0) //nolint:mnd
+}
+
+// orDash returns the value, or "-" if it is empty, so that table columns stay aligned.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
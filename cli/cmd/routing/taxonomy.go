@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	"github.com/spf13/cobra"
+)
+
+var taxonomyCmd = &cobra.Command{
+	Use:   "taxonomy",
+	Short: "List the valid labels of the label taxonomy of the peer",
+	Long: `List the values of the label taxonomy configured on the peer. Labels of the
+namespaces it covers are normalized to these values when records are published
+and when announcements of other peers are received, e.g. /skills/AI to /skills/ai.
+
+Usage examples:
+
+1. List all values of the taxonomy:
+   dirctl routing taxonomy
+
+2. List the skills starting with a prefix:
+   dirctl routing taxonomy --namespace skills --prefix natural_language
+
+3. List the taxonomy in JSON format:
+   dirctl routing taxonomy --json
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runTaxonomyCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runTaxonomyCommand(cmd)
+	},
+}
+
+var taxonomyOpts struct {
+	Namespaces []string
+	Prefix     string
+}
+
+func init() {
+	taxonomyCmd.Flags().StringArrayVar(&taxonomyOpts.Namespaces, "namespace", nil, "Only list the values of the namespace (can be repeated)")
+	taxonomyCmd.Flags().StringVar(&taxonomyOpts.Prefix, "prefix", "", "Only list the values starting with the prefix")
+}
+
+func runTaxonomyCommand(cmd *cobra.Command) error {
+	c, err := adminClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.GetTaxonomy(cmd.Context(), &routingv1.GetTaxonomyRequest{
+		Namespaces: taxonomyOpts.Namespaces,
+		Prefix:     taxonomyOpts.Prefix,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get taxonomy: %w", err)
+	}
+
+	if presenter.GetOutputOptions(cmd).Format != presenter.FormatHuman {
+		return presenter.PrintMessage(cmd, "taxonomy", "Label taxonomy", resp)
+	}
+
+	var values int
+
+	for _, ns := range resp.GetNamespaces() {
+		for _, value := range ns.GetValues() {
+			presenter.Printf(cmd, "/%s/%s\n", ns.GetName(), value)
+		}
+
+		values += len(ns.GetValues())
+	}
+
+	presenter.Printf(cmd, "\n%d label(s) of taxonomy version %s\n", values, resp.GetVersion())
+
+	return nil
+}
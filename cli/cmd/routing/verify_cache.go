@@ -0,0 +1,71 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//nolint:wrapcheck
+package routing
+
+import (
+	"errors"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/cli/presenter"
+	ctxUtils "github.com/agntcy/dir/cli/util/context"
+	"github.com/spf13/cobra"
+)
+
+var verifyCacheCmd = &cobra.Command{
+	Use:   "verify-cache",
+	Short: "Verify cached remote records against their providers",
+	Long: `Verify a random sample of the cached remote records by asking their providers
+whether they still store them.
+
+Records that providers deleted without unpublishing them remain in the label
+cache until their labels go stale, so searches keep returning records that can
+no longer be pulled. This command reports how many of the sampled records are
+still available, and with --evict removes the labels of unavailable records.
+Pinned records are never evicted.
+
+Usage examples:
+
+1. Report cache rot on a sample of 100 records:
+   dirctl routing verify-cache
+
+2. Verify a larger sample and evict unavailable records:
+   dirctl routing verify-cache --sample 1000 --evict
+`,
+	//nolint:gocritic // Lambda required due to signature mismatch - runVerifyCacheCommand doesn't use args
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return runVerifyCacheCommand(cmd)
+	},
+}
+
+// Verify cache command options.
+var verifyCacheOpts struct {
+	Sample uint32
+	Evict  bool
+}
+
+func init() {
+	verifyCacheCmd.Flags().Uint32Var(&verifyCacheOpts.Sample, "sample", 0, "Number of cached records to verify (default 100, max 1000)")
+	verifyCacheCmd.Flags().BoolVar(&verifyCacheOpts.Evict, "evict", false, "Evict the labels of records that their providers no longer store")
+}
+
+func runVerifyCacheCommand(cmd *cobra.Command) error {
+	c, ok := ctxUtils.GetClientFromContext(cmd.Context())
+	if !ok {
+		return errors.New("failed to get client from context")
+	}
+
+	req := &routingv1.VerifyCacheRequest{Evict: verifyCacheOpts.Evict}
+	if verifyCacheOpts.Sample > 0 {
+		req.SampleSize = &verifyCacheOpts.Sample
+	}
+
+	resp, err := c.VerifyCache(cmd.Context(), req)
+	if err != nil {
+		return fmt.Errorf("failed to verify cache: %w", err)
+	}
+
+	return presenter.PrintMessage(cmd, "cache verification", "Label cache verification", resp)
+}
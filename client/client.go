@@ -18,6 +18,7 @@ import (
 type Client struct {
 	storev1.StoreServiceClient
 	routingv1.RoutingServiceClient
+	routingv1.RoutingAdminServiceClient
 	searchv1.SearchServiceClient
 	storev1.SyncServiceClient
 	signv1.SignServiceClient
@@ -45,13 +46,14 @@ func New(opts ...Option) (*Client, error) {
 	}
 
 	return &Client{
-		StoreServiceClient:   storev1.NewStoreServiceClient(client),
-		RoutingServiceClient: routingv1.NewRoutingServiceClient(client),
-		SearchServiceClient:  searchv1.NewSearchServiceClient(client),
-		SyncServiceClient:    storev1.NewSyncServiceClient(client),
-		SignServiceClient:    signv1.NewSignServiceClient(client),
-		config:               options.config,
-		authClient:           options.authClient,
+		StoreServiceClient:        storev1.NewStoreServiceClient(client),
+		RoutingServiceClient:      routingv1.NewRoutingServiceClient(client),
+		RoutingAdminServiceClient: routingv1.NewRoutingAdminServiceClient(client),
+		SearchServiceClient:       searchv1.NewSearchServiceClient(client),
+		SyncServiceClient:         storev1.NewSyncServiceClient(client),
+		SignServiceClient:         signv1.NewSignServiceClient(client),
+		config:                    options.config,
+		authClient:                options.authClient,
 	}, nil
 }
 
@@ -24,6 +24,28 @@ func (c *Client) Publish(ctx context.Context, req *routingv1.PublishRequest) err
 	return nil
 }
 
+// PublishWithProgress publishes records synchronously, calling progress with each
+// progress event of the published records until all of them completed or failed.
+func (c *Client) PublishWithProgress(ctx context.Context, req *routingv1.PublishRequest, progress func(*routingv1.PublishProgress)) error {
+	stream, err := c.RoutingServiceClient.PublishWithProgress(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create publish stream: %w", err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to publish object: %w", err)
+		}
+
+		progress(event)
+	}
+}
+
 func (c *Client) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error) {
 	stream, err := c.RoutingServiceClient.List(ctx, req)
 	if err != nil {
@@ -86,6 +108,28 @@ func (c *Client) SearchRouting(ctx context.Context, req *routingv1.SearchRequest
 	return resCh, nil
 }
 
+// Subscribe calls result with each remote record matching the subscription as the server
+// discovers it, until the context is cancelled or the server ends the subscription.
+func (c *Client) Subscribe(ctx context.Context, req *routingv1.SubscribeRequest, result func(*routingv1.SearchResponse)) error {
+	stream, err := c.RoutingServiceClient.Subscribe(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to create subscription stream: %w", err)
+	}
+
+	for {
+		obj, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to receive subscription result: %w", err)
+		}
+
+		result(obj)
+	}
+}
+
 func (c *Client) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest) error {
 	_, err := c.RoutingServiceClient.Unpublish(ctx, req)
 	if err != nil {
@@ -94,3 +138,183 @@ func (c *Client) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest)
 
 	return nil
 }
+
+func (c *Client) EstimateRoutingResults(ctx context.Context, req *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error) {
+	resp, err := c.RoutingServiceClient.EstimateResults(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate routing results: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetRoutingStats(ctx context.Context, req *routingv1.GetStatsRequest) (*routingv1.GetStatsResponse, error) {
+	resp, err := c.RoutingServiceClient.GetStats(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing stats: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) Pin(ctx context.Context, req *routingv1.PinRequest) error {
+	_, err := c.RoutingServiceClient.Pin(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to pin records: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) Unpin(ctx context.Context, req *routingv1.UnpinRequest) error {
+	_, err := c.RoutingServiceClient.Unpin(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to unpin records: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) ListPins(ctx context.Context, req *routingv1.ListPinsRequest) (<-chan *routingv1.ListPinsResponse, error) {
+	stream, err := c.RoutingServiceClient.ListPins(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list pins stream: %w", err)
+	}
+
+	resCh := make(chan *routingv1.ListPinsResponse, 100) //nolint:mnd
+
+	go func() {
+		defer close(resCh)
+
+		for {
+			obj, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				logger.Error("error receiving pin", "error", err)
+
+				return
+			}
+
+			resCh <- obj
+		}
+	}()
+
+	return resCh, nil
+}
+
+func (c *Client) VerifyCache(ctx context.Context, req *routingv1.VerifyCacheRequest) (*routingv1.VerifyCacheResponse, error) {
+	resp, err := c.RoutingServiceClient.VerifyCache(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify label cache: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) SetProfile(ctx context.Context, req *routingv1.SetProfileRequest) (*routingv1.SetProfileResponse, error) {
+	resp, err := c.RoutingServiceClient.SetProfile(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set discovery profile: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetRoutingHistory(ctx context.Context, req *routingv1.GetHistoryRequest) (*routingv1.GetHistoryResponse, error) {
+	resp, err := c.RoutingServiceClient.GetHistory(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discovery history: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GrantAccess(ctx context.Context, req *routingv1.GrantAccessRequest) (*routingv1.GrantAccessResponse, error) {
+	resp, err := c.RoutingServiceClient.GrantAccess(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to grant access: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetTaxonomy(ctx context.Context, req *routingv1.GetTaxonomyRequest) (*routingv1.GetTaxonomyResponse, error) {
+	resp, err := c.RoutingServiceClient.GetTaxonomy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get taxonomy: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetProvenance(ctx context.Context, req *routingv1.GetProvenanceRequest) (*routingv1.GetProvenanceResponse, error) {
+	resp, err := c.RoutingServiceClient.GetProvenance(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provenance: %w", err)
+	}
+
+	return resp, nil
+}
+
+// stateArchiveChunkSize is the size of the chunks state archives are streamed in.
+const stateArchiveChunkSize = 1024 * 1024 // 1MB
+
+// ExportState writes the state archive of the peer to w.
+func (c *Client) ExportState(ctx context.Context, req *routingv1.ExportStateRequest, w io.Writer) error {
+	stream, err := c.RoutingServiceClient.ExportState(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to export state: %w", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to receive state archive: %w", err)
+		}
+
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return fmt.Errorf("failed to write state archive: %w", err)
+		}
+	}
+}
+
+// ImportState imports the state archive read from r into the peer.
+func (c *Client) ImportState(ctx context.Context, r io.Reader) (*routingv1.ImportStateResponse, error) {
+	stream, err := c.RoutingServiceClient.ImportState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import state: %w", err)
+	}
+
+	buf := make([]byte, stateArchiveChunkSize)
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&routingv1.StateArchiveChunk{Data: buf[:n]}); err != nil {
+				return nil, fmt.Errorf("failed to send state archive: %w", err)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read state archive: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to import state: %w", err)
+	}
+
+	return resp, nil
+}
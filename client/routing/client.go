@@ -0,0 +1,243 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package routing provides a client of the routing service of one or more directory
+// servers, with typed helpers to build searches and publishes.
+//
+// Calls are retried with exponential backoff when they fail with a transient error
+// (the server is unavailable, overloaded or timed out), failing over to the next server:
+//
+//	c, err := routing.New([]string{"dir-1:8888", "dir-2:8888"})
+//	if err != nil {
+//	    return err
+//	}
+//	defer c.Close()
+//
+//	if err := c.Publish(ctx, routing.PublishRecords("baeareib...")); err != nil {
+//	    return err
+//	}
+//
+//	results := c.Search(ctx, routing.SearchQueries(routing.Skill("AI"), routing.Domain("research")))
+//	defer results.Close()
+//
+//	for results.Next() {
+//	    fmt.Println(results.Result().GetRecordRef().GetCid())
+//	}
+//
+//	if err := results.Err(); err != nil {
+//	    return err
+//	}
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var logger = logging.Logger("client/routing")
+
+// Client calls the routing service of a set of directory servers, preferring the
+// server that last answered and failing over to the others when it fails.
+type Client struct {
+	opts      *options
+	endpoints []*endpoint
+
+	mu      sync.Mutex
+	current int // Index of the server that last answered
+}
+
+// endpoint is a connection to a directory server.
+type endpoint struct {
+	address string
+	conn    *grpc.ClientConn
+	client  routingv1.RoutingServiceClient
+
+	failedAt time.Time // Last failure, zero if the last call succeeded
+}
+
+// New connects to the routing service of the servers, in order of preference.
+// Connections are established lazily, on the first call to each server.
+func New(servers []string, opts ...Option) (*Client, error) {
+	if len(servers) == 0 {
+		return nil, errors.New("at least one server is required")
+	}
+
+	options := defaultOptions()
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, fmt.Errorf("failed to load options: %w", err)
+		}
+	}
+
+	dialOpts := options.dialOpts
+	if len(dialOpts) == 0 {
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	c := &Client{opts: options}
+
+	for _, address := range servers {
+		conn, err := grpc.NewClient(address, dialOpts...)
+		if err != nil {
+			_ = c.Close()
+
+			return nil, fmt.Errorf("failed to create gRPC client for %s: %w", address, err)
+		}
+
+		c.endpoints = append(c.endpoints, &endpoint{
+			address: address,
+			conn:    conn,
+			client:  routingv1.NewRoutingServiceClient(conn),
+		})
+	}
+
+	return c, nil
+}
+
+// Close closes the connections to all servers.
+func (c *Client) Close() error {
+	var errs []error
+
+	for _, e := range c.endpoints {
+		if err := e.conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close connection to %s: %w", e.address, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Servers returns the addresses of the servers, starting with the one calls go to next.
+func (c *Client) Servers() []string {
+	order := c.order()
+
+	addresses := make([]string, len(order))
+	for i, e := range order {
+		addresses[i] = e.address
+	}
+
+	return addresses
+}
+
+// order returns the servers in the order they are tried: the server that last answered,
+// the servers that did not fail recently in configuration order, then the others.
+func (c *Client) order() []*endpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	healthy := make([]*endpoint, 0, len(c.endpoints))
+
+	var failed []*endpoint
+
+	for i := range c.endpoints {
+		e := c.endpoints[(c.current+i)%len(c.endpoints)]
+		if !e.failedAt.IsZero() && now.Sub(e.failedAt) < c.opts.failoverCooldown {
+			failed = append(failed, e)
+		} else {
+			healthy = append(healthy, e)
+		}
+	}
+
+	return append(healthy, failed...)
+}
+
+// succeeded makes the server the preferred one.
+func (c *Client) succeeded(e *endpoint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e.failedAt = time.Time{}
+
+	for i, candidate := range c.endpoints {
+		if candidate == e {
+			c.current = i
+		}
+	}
+}
+
+// failed moves the server behind the others until the failover cooldown elapsed.
+func (c *Client) failed(e *endpoint, err error) {
+	c.mu.Lock()
+	e.failedAt = time.Now()
+	c.mu.Unlock()
+
+	logger.Warn("Directory server failed, failing over", "server", e.address, "error", err)
+}
+
+// call calls a server, retrying transient failures on the next server with backoff.
+func (c *Client) call(ctx context.Context, fn func(routingv1.RoutingServiceClient) error) error {
+	var err error
+
+	for attempt := range c.opts.maxAttempts {
+		if attempt > 0 {
+			if waitErr := c.wait(ctx, attempt); waitErr != nil {
+				return fmt.Errorf("%w (last error: %w)", waitErr, err)
+			}
+		}
+
+		e := c.order()[0]
+
+		err = fn(e.client)
+		if err == nil {
+			c.succeeded(e)
+
+			return nil
+		}
+
+		if !retryable(ctx, err) {
+			return err
+		}
+
+		c.failed(e, err)
+	}
+
+	return fmt.Errorf("failed after %d attempts: %w", c.opts.maxAttempts, err)
+}
+
+// wait waits before a retry: an exponentially growing backoff with jitter.
+func (c *Client) wait(ctx context.Context, attempt int) error {
+	backoff := c.opts.initialBackoff << (attempt - 1)
+	if backoff > c.opts.maxBackoff || backoff <= 0 {
+		backoff = c.opts.maxBackoff
+	}
+
+	// Jitter spreads the retries of clients that failed at the same time
+	backoff = backoff/2 + time.Duration(rand.Int64N(int64(backoff/2)+1)) //nolint:gosec,mnd // Jitter does not need a secure source
+
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryable reports whether a call failed with a transient error another attempt may not
+// fail with. Errors of cancelled calls are not transient.
+func retryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
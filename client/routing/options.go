@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultMaxAttempts is the number of attempts of a call before its error is returned.
+	DefaultMaxAttempts = 5
+
+	// DefaultInitialBackoff is the wait before the first retry of a call.
+	DefaultInitialBackoff = 200 * time.Millisecond
+
+	// DefaultMaxBackoff bounds the exponentially growing wait between retries.
+	DefaultMaxBackoff = 5 * time.Second
+
+	// DefaultFailoverCooldown is how long a server that failed is only tried
+	// after the servers that did not fail.
+	DefaultFailoverCooldown = 30 * time.Second
+)
+
+type Option func(*options) error
+
+type options struct {
+	dialOpts         []grpc.DialOption
+	maxAttempts      int
+	initialBackoff   time.Duration
+	maxBackoff       time.Duration
+	failoverCooldown time.Duration
+}
+
+func defaultOptions() *options {
+	return &options{
+		maxAttempts:      DefaultMaxAttempts,
+		initialBackoff:   DefaultInitialBackoff,
+		maxBackoff:       DefaultMaxBackoff,
+		failoverCooldown: DefaultFailoverCooldown,
+	}
+}
+
+// WithDialOptions sets the gRPC dial options of the connections to the servers,
+// e.g. their transport credentials. Connections are insecure if none are set.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) error {
+		o.dialOpts = append(o.dialOpts, opts...)
+
+		return nil
+	}
+}
+
+// WithRetry sets the number of attempts of calls and the exponential backoff between them.
+// Retries go to the next server, so that a failed server is failed over.
+func WithRetry(maxAttempts int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(o *options) error {
+		if maxAttempts < 1 {
+			return errors.New("at least one attempt is required")
+		}
+
+		if initialBackoff < 0 || maxBackoff < initialBackoff {
+			return errors.New("backoff must not be negative and the maximum backoff must not be less than the initial one")
+		}
+
+		o.maxAttempts = maxAttempts
+		o.initialBackoff = initialBackoff
+		o.maxBackoff = maxBackoff
+
+		return nil
+	}
+}
+
+// WithFailoverCooldown sets how long a server that failed is only tried after the others.
+func WithFailoverCooldown(cooldown time.Duration) Option {
+	return func(o *options) error {
+		if cooldown < 0 {
+			return errors.New("failover cooldown must not be negative")
+		}
+
+		o.failoverCooldown = cooldown
+
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// Skill matches records with the skill or a skill below it, e.g. "AI" matches "AI/ML".
+func Skill(skill string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: skill}
+}
+
+// Domain matches records with the domain or a domain below it.
+func Domain(domain string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: domain}
+}
+
+// Module matches records with the module or a module below it.
+func Module(module string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, Value: module}
+}
+
+// Locator matches records with the locator type, e.g. "docker-image".
+func Locator(locator string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, Value: locator}
+}
+
+// Label matches records with the label of any namespace, including custom ones,
+// or a label below it, e.g. "teams/platform".
+func Label(label string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: label}
+}
+
+// And matches records matching all of the queries. It counts as a single query towards the match score.
+func And(queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, queries)
+}
+
+// Or matches records matching any of the queries. It counts as a single query towards the match score.
+func Or(queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, queries)
+}
+
+// Not matches records matching none of the queries. It counts as a single query towards the match score.
+func Not(queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, queries)
+}
+
+func group(operator routingv1.RecordQueryOperator, queries []*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{Operator: operator, Queries: queries}}
+}
+
+// SearchQueries returns a search for records matching any of the queries.
+// Further settings, e.g. the minimum match score or the limit, are set on the request.
+func SearchQueries(queries ...*routingv1.RecordQuery) *routingv1.SearchRequest {
+	return &routingv1.SearchRequest{Queries: queries}
+}
+
+// PublishRecords returns a publish of the records with the CIDs.
+// Further settings, e.g. the priority or the TTL, are set on the request.
+func PublishRecords(cids ...string) *routingv1.PublishRequest {
+	refs := make([]*corev1.RecordRef, len(cids))
+	for i, cid := range cids {
+		refs[i] = &corev1.RecordRef{Cid: cid}
+	}
+
+	return &routingv1.PublishRequest{
+		Request: &routingv1.PublishRequest_RecordRefs{
+			RecordRefs: &routingv1.RecordRefs{Refs: refs},
+		},
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Publish publishes records, retrying transient failures.
+// Publishing a record again is harmless, so failed attempts that reached a server are retried too.
+func (c *Client) Publish(ctx context.Context, req *routingv1.PublishRequest) error {
+	err := c.call(ctx, func(client routingv1.RoutingServiceClient) error {
+		_, err := client.Publish(ctx, req)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish object: %w", err)
+	}
+
+	return nil
+}
+
+// Unpublish unpublishes records, retrying transient failures.
+func (c *Client) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest) error {
+	err := c.call(ctx, func(client routingv1.RoutingServiceClient) error {
+		_, err := client.Unpublish(ctx, req)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unpublish object: %w", err)
+	}
+
+	return nil
+}
+
+// EstimateResults estimates how many records a search would return, retrying transient failures.
+func (c *Client) EstimateResults(ctx context.Context, req *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error) {
+	var resp *routingv1.EstimateResultsResponse
+
+	err := c.call(ctx, func(client routingv1.RoutingServiceClient) error {
+		var err error
+
+		resp, err = client.EstimateResults(ctx, req)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate routing results: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Search starts a search, returning an iterator over its results.
+//
+// If the search stream fails with a transient error, the search is resumed after the
+// last result on the same server, or restarted on the next server. Each record is
+// returned once, also when servers return it again after a restart.
+func (c *Client) Search(ctx context.Context, req *routingv1.SearchRequest) *SearchIterator {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &SearchIterator{
+		client: c,
+		ctx:    ctx,
+		cancel: cancel,
+		req:    req,
+		seen:   make(map[string]bool),
+	}
+}
+
+// SearchIterator iterates over the results of a search:
+//
+//	for it.Next() {
+//	    result := it.Result()
+//	}
+//
+//	if err := it.Err(); err != nil {
+//	    ...
+//	}
+type SearchIterator struct {
+	client *Client
+	ctx    context.Context //nolint:containedctx // The iterator spans the calls of a search
+	cancel context.CancelFunc
+	req    *routingv1.SearchRequest
+
+	stream   routingv1.RoutingService_SearchClient
+	endpoint *endpoint // Server of the stream
+	attempt  int       // Failed attempts, reset by each received result
+
+	lastEndpoint *endpoint // Server that issued lastToken
+	lastToken    string    // Page token of the last result
+
+	seen   map[string]bool // CIDs of the returned records
+	result *routingv1.SearchResponse
+	err    error
+	done   bool
+}
+
+// Next advances to the next result, returning false when the search completed or failed.
+func (it *SearchIterator) Next() bool {
+	for !it.done {
+		if it.stream == nil {
+			it.open()
+
+			continue
+		}
+
+		result, err := it.stream.Recv()
+		if errors.Is(err, io.EOF) {
+			it.client.succeeded(it.endpoint)
+			it.finish(nil)
+
+			return false
+		}
+
+		if err != nil {
+			it.fail(err)
+
+			continue
+		}
+
+		it.attempt = 0
+		it.lastEndpoint, it.lastToken = it.endpoint, result.GetNextPageToken()
+
+		cid := result.GetRecordRef().GetCid()
+		if it.seen[cid] {
+			continue
+		}
+
+		it.seen[cid] = true
+		it.result = result
+
+		// Restarted searches return results that count towards the limit again
+		if limit := it.req.GetLimit(); limit > 0 && len(it.seen) >= int(limit) {
+			it.finish(nil)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Result returns the current result.
+func (it *SearchIterator) Result() *routingv1.SearchResponse {
+	return it.result
+}
+
+// Err returns the error that failed the search, nil if it completed.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// Close stops the search. It must be called if the iteration is stopped before Next returns false.
+func (it *SearchIterator) Close() {
+	it.finish(nil)
+}
+
+// open opens the search stream on the next server, waiting before retries.
+func (it *SearchIterator) open() {
+	if it.attempt > 0 {
+		if err := it.client.wait(it.ctx, it.attempt); err != nil {
+			it.finish(fmt.Errorf("failed to search: %w", err))
+
+			return
+		}
+	}
+
+	it.endpoint = it.client.order()[0]
+
+	// Resume after the last result on the server that returned it
+	req := it.req
+	if it.lastToken != "" && it.endpoint == it.lastEndpoint {
+		req = proto.CloneOf(it.req)
+		req.PageToken = &it.lastToken
+	}
+
+	stream, err := it.endpoint.client.Search(it.ctx, req)
+	if err != nil {
+		it.fail(err)
+
+		return
+	}
+
+	it.stream = stream
+}
+
+// fail fails over to the next server if the error is transient and attempts are left.
+func (it *SearchIterator) fail(err error) {
+	it.stream = nil
+	it.attempt++
+
+	if !retryable(it.ctx, err) {
+		it.finish(fmt.Errorf("failed to search: %w", err))
+
+		return
+	}
+
+	it.client.failed(it.endpoint, err)
+
+	if it.attempt >= it.client.opts.maxAttempts {
+		it.finish(fmt.Errorf("failed to search after %d attempts: %w", it.attempt, err))
+	}
+}
+
+func (it *SearchIterator) finish(err error) {
+	if it.done {
+		return
+	}
+
+	it.done = true
+	it.err = err
+	it.stream = nil
+	it.cancel()
+}
@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+func (c *Client) GetRoutingTable(ctx context.Context, req *routingv1.GetRoutingTableRequest) (*routingv1.GetRoutingTableResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetRoutingTable(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get routing table: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetGossipSubState(ctx context.Context, req *routingv1.GetGossipSubStateRequest) (*routingv1.GetGossipSubStateResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetGossipSubState(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gossipsub state: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetGossipSubDiagnostics(ctx context.Context, req *routingv1.GetGossipSubDiagnosticsRequest) (*routingv1.GetGossipSubDiagnosticsResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetGossipSubDiagnostics(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gossipsub diagnostics: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetLabelCacheStats(ctx context.Context, req *routingv1.GetLabelCacheStatsRequest) (*routingv1.GetLabelCacheStatsResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetLabelCacheStats(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get label cache stats: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetQueueState(ctx context.Context, req *routingv1.GetQueueStateRequest) (*routingv1.GetQueueStateResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetQueueState(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue state: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetTaskStatus(ctx context.Context, req *routingv1.GetTaskStatusRequest) (*routingv1.GetTaskStatusResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetTaskStatus(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task status: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) StartBackfill(ctx context.Context, req *routingv1.StartBackfillRequest) (*routingv1.StartBackfillResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.StartBackfill(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start backfill: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetBackfillStatus(ctx context.Context, req *routingv1.GetBackfillStatusRequest) (*routingv1.GetBackfillStatusResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetBackfillStatus(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backfill status: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) GetBandwidthUsage(ctx context.Context, req *routingv1.GetBandwidthUsageRequest) (*routingv1.GetBandwidthUsageResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.GetBandwidthUsage(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bandwidth usage: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SnapshotDatastore writes the datastore archive of the peer to w.
+func (c *Client) SnapshotDatastore(ctx context.Context, w io.Writer) error {
+	stream, err := c.RoutingAdminServiceClient.SnapshotDatastore(ctx, &routingv1.SnapshotDatastoreRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to snapshot datastore: %w", err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to receive datastore archive: %w", err)
+		}
+
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return fmt.Errorf("failed to write datastore archive: %w", err)
+		}
+	}
+}
+
+// RestoreDatastore restores the datastore archive read from r into the peer.
+func (c *Client) RestoreDatastore(ctx context.Context, r io.Reader) (*routingv1.RestoreDatastoreResponse, error) {
+	stream, err := c.RoutingAdminServiceClient.RestoreDatastore(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore datastore: %w", err)
+	}
+
+	buf := make([]byte, stateArchiveChunkSize)
+
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&routingv1.StateArchiveChunk{Data: buf[:n]}); err != nil {
+				return nil, fmt.Errorf("failed to send datastore archive: %w", err)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read datastore archive: %w", err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore datastore: %w", err)
+	}
+
+	return resp, nil
+}
+
+// PromoteFollower promotes the follower peer to announce the records of its primary.
+func (c *Client) PromoteFollower(ctx context.Context, req *routingv1.PromoteFollowerRequest) (*routingv1.PromoteFollowerResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.PromoteFollower(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote follower: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListPinnedRecords lists the pinned records of the peer and the state of its record garbage collection.
+func (c *Client) ListPinnedRecords(ctx context.Context, req *routingv1.ListPinnedRecordsRequest) (*routingv1.ListPinnedRecordsResponse, error) {
+	resp, err := c.RoutingAdminServiceClient.ListPinnedRecords(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned records: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,54 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package authn
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/agntcy/dir/server/authn/config"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// TLSConfig returns the TLS configuration for HTTP servers, matching the gRPC server credentials.
+// The server presents its X.509-SVID, and requires one from clients in X.509 mode.
+func (s *Service) TLSConfig() *tls.Config {
+	if s.mode == config.AuthModeX509 {
+		return tlsconfig.MTLSServerConfig(s.x509Src, s.bundleSrc, tlsconfig.AuthorizeAny())
+	}
+
+	return tlsconfig.TLSServerConfig(s.x509Src)
+}
+
+// AuthenticateHTTP authenticates an HTTP request served with TLSConfig and returns
+// its context carrying the SPIFFE ID of the caller, as the gRPC interceptors do.
+// JWT-SVIDs are read from the Authorization header and X.509-SVIDs from the TLS connection.
+func (s *Service) AuthenticateHTTP(r *http.Request) (context.Context, error) {
+	switch s.mode {
+	case config.AuthModeJWT:
+		ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs("authorization", r.Header.Get("Authorization")))
+
+		return NewJWTInterceptor(s.jwtSource, s.audiences)(ctx)
+
+	case config.AuthModeX509:
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "not authenticated via X.509") //nolint:wrapcheck
+		}
+
+		sid, err := x509svid.IDFromCert(r.TLS.PeerCertificates[0])
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid X.509-SVID: %v", err) //nolint:wrapcheck
+		}
+
+		return context.WithValue(r.Context(), SpiffeIDContextKey, sid), nil
+
+	default:
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported auth mode: %s", s.mode) //nolint:wrapcheck
+	}
+}
@@ -6,11 +6,14 @@ package authz
 import (
 	_ "embed"
 	"fmt"
+	"slices"
 
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
 	"github.com/agntcy/dir/server/authz/config"
 	"github.com/casbin/casbin/v2"
 	"github.com/casbin/casbin/v2/model"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 // Defines the Casbin authorization model
@@ -25,10 +28,13 @@ var allowedExternalAPIMethods = []string{
 	storev1.StoreService_PullReferrer_FullMethodName,              // store: pull referrer
 	storev1.StoreService_Lookup_FullMethodName,                    // store: lookup
 	storev1.SyncService_RequestRegistryCredentials_FullMethodName, // sync: negotiate
+	healthpb.Health_Check_FullMethodName,                          // health: check
+	healthpb.Health_Watch_FullMethodName,                          // health: watch
 }
 
 type Authorizer struct {
 	enforcer *casbin.Enforcer
+	tenants  map[string]string
 }
 
 // New creates a new Casbin-based Authorizer.
@@ -50,7 +56,7 @@ func NewAuthorizer(cfg config.Config) (*Authorizer, error) {
 		return nil, fmt.Errorf("failed to add policies: %w", err)
 	}
 
-	return &Authorizer{enforcer: enforcer}, nil
+	return &Authorizer{enforcer: enforcer, tenants: cfg.Tenants}, nil
 }
 
 // Authorize checks if the user in trust domain can perform a given API method.
@@ -60,9 +66,33 @@ func (a *Authorizer) Authorize(trustDomain, apiMethod string) (bool, error) {
 	return a.enforcer.Enforce(trustDomain, apiMethod)
 }
 
+// AuthorizeLabelNamespace checks if the user in trust domain can discover labels of a given namespace.
+//
+//nolint:wrapcheck
+func (a *Authorizer) AuthorizeLabelNamespace(trustDomain, namespace string) (bool, error) {
+	return a.enforcer.Enforce(trustDomain, labelNamespaceObject(namespace))
+}
+
+// Tenant returns the routing tenant of the user with a SPIFFE ID in a trust domain,
+// empty if the user has no tenant. Tenants of SPIFFE IDs take precedence over those of trust domains.
+func (a *Authorizer) Tenant(spiffeID, trustDomain string) string {
+	if tenant, ok := a.tenants[spiffeID]; ok {
+		return tenant
+	}
+
+	return a.tenants[trustDomain]
+}
+
+// labelNamespaceObject returns the policy object for discovering labels of a namespace.
+// The prefix distinguishes label namespaces from API methods.
+func labelNamespaceObject(namespace string) string {
+	return "label:" + namespace
+}
+
 // getPolicies returns a list of authorization in the following form:
-//   - All API methods are allowed for users within our trust domain
+//   - All API methods and label namespaces are allowed for users within our trust domain
 //   - Only specific API methods are allowed for users outside of the trust domain
+//   - Search and the configured label namespaces are allowed for trust domains with label policies
 func getPolicies(cfg config.Config) [][]string {
 	policies := [][]string{}
 
@@ -74,5 +104,21 @@ func getPolicies(cfg config.Config) [][]string {
 		policies = append(policies, []string{"*", method})
 	}
 
+	// Allow search over specific label namespaces for other trust domains
+	for trustDomain, namespaces := range cfg.LabelNamespaces {
+		policies = append(policies, []string{trustDomain, routingv1.RoutingService_Search_FullMethodName})
+
+		seen := []string{}
+
+		for _, namespace := range namespaces {
+			if slices.Contains(seen, namespace) {
+				continue
+			}
+
+			seen = append(seen, namespace)
+			policies = append(policies, []string{trustDomain, labelNamespaceObject(namespace)})
+		}
+	}
+
 	return policies
 }
@@ -4,11 +4,16 @@
 package authz
 
 import (
+	"context"
 	"testing"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	storev1 "github.com/agntcy/dir/api/store/v1"
+	"github.com/agntcy/dir/server/authn"
 	"github.com/agntcy/dir/server/authz/config"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestAuthorizer(t *testing.T) {
@@ -48,3 +53,116 @@ func TestAuthorizer(t *testing.T) {
 		}
 	}
 }
+
+func TestAuthorizer_LabelNamespaces(t *testing.T) {
+	authz, err := NewAuthorizer(config.Config{
+		TrustDomain: "dir.com",
+		LabelNamespaces: map[string][]string{
+			"partner.com": {"skills", "domains", "skills"},
+			"*":           {"locators"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create Casbin authorizer: %v", err)
+	}
+
+	methods := []struct {
+		trustDomain string
+		apiMethod   string
+		allow       bool
+	}{
+		// trust domains with label policies may search
+		{"partner.com", routingv1.RoutingService_Search_FullMethodName, true},
+		{"other.com", routingv1.RoutingService_Search_FullMethodName, true},
+		{"partner.com", routingv1.RoutingService_Publish_FullMethodName, false},
+	}
+
+	for _, tt := range methods {
+		allowed, err := authz.Authorize(tt.trustDomain, tt.apiMethod)
+		if err != nil {
+			t.Errorf("Authorize() error: %v", err)
+		}
+
+		if allowed != tt.allow {
+			t.Errorf("Authorize(%q, %q) = %v, want %v", tt.trustDomain, tt.apiMethod, allowed, tt.allow)
+		}
+	}
+
+	namespaces := []struct {
+		trustDomain string
+		namespace   string
+		allow       bool
+	}{
+		// dir.com: all namespaces allowed
+		{"dir.com", "skills", true},
+		{"dir.com", "modules", true},
+
+		// partner.com: configured namespaces plus the wildcard ones
+		{"partner.com", "skills", true},
+		{"partner.com", "domains", true},
+		{"partner.com", "locators", true},
+		{"partner.com", "modules", false},
+
+		// anyone else: wildcard namespaces only
+		{"other.com", "locators", true},
+		{"other.com", "skills", false},
+	}
+
+	for _, tt := range namespaces {
+		allowed, err := authz.AuthorizeLabelNamespace(tt.trustDomain, tt.namespace)
+		if err != nil {
+			t.Errorf("AuthorizeLabelNamespace() error: %v", err)
+		}
+
+		if allowed != tt.allow {
+			t.Errorf("AuthorizeLabelNamespace(%q, %q) = %v, want %v", tt.trustDomain, tt.namespace, allowed, tt.allow)
+		}
+	}
+}
+
+func TestService_AuthorizeTenant(t *testing.T) {
+	service, err := New(t.Context(), config.Config{
+		Enabled:     true,
+		TrustDomain: "dir.com",
+		Tenants: map[string]string{
+			"acme.com":                   "acme",
+			"spiffe://dir.com/ci/globex": "globex",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create authorization service: %v", err)
+	}
+
+	tests := []struct {
+		spiffeID string
+		tenant   string
+		want     string
+		code     codes.Code
+	}{
+		// tenants are derived from the caller's trust domain or SPIFFE ID
+		{"spiffe://acme.com/client", "", "acme", codes.OK},
+		{"spiffe://acme.com/client", "acme", "acme", codes.OK},
+		{"spiffe://dir.com/ci/globex", "", "globex", codes.OK},
+
+		// callers may not name another tenant
+		{"spiffe://acme.com/client", "globex", "", codes.PermissionDenied},
+		{"spiffe://dir.com/client", "acme", "", codes.PermissionDenied},
+		{"spiffe://other.com/client", "acme", "", codes.PermissionDenied},
+
+		// callers without a tenant are untenanted
+		{"spiffe://other.com/client", "", "", codes.OK},
+	}
+
+	for _, tt := range tests {
+		ctx := context.WithValue(t.Context(), authn.SpiffeIDContextKey, spiffeid.RequireFromString(tt.spiffeID))
+
+		tenant, err := service.AuthorizeTenant(ctx, tt.tenant)
+		if status.Code(err) != tt.code || tenant != tt.want {
+			t.Errorf("AuthorizeTenant(%q, %q) = %q, %v, want %q, %v", tt.spiffeID, tt.tenant, tenant, err, tt.want, tt.code)
+		}
+	}
+
+	if _, err := service.AuthorizeTenant(t.Context(), ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("AuthorizeTenant() without SPIFFE ID = %v, want %v", err, codes.Unauthenticated)
+	}
+}
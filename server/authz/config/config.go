@@ -3,7 +3,10 @@
 
 package config
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Config contains configuration for authorization (AuthZ) services.
 // Authorization is separate from authentication (AuthN) - it receives
@@ -15,6 +18,18 @@ type Config struct {
 	// Trust domain for this Directory server
 	// Used to distinguish internal vs external requests
 	TrustDomain string `json:"trust_domain,omitempty" mapstructure:"trust_domain"`
+
+	// Label namespaces that users of other trust domains may discover through Search,
+	// keyed by trust domain ("*" matches any trust domain).
+	// Trust domains listed here are allowed to call Search, and search queries
+	// for namespaces they are not permitted are dropped.
+	// Users of our own trust domain may always discover all namespaces.
+	LabelNamespaces map[string][]string `json:"label_namespaces,omitempty" mapstructure:"label_namespaces"`
+
+	// Routing tenants of users, keyed by SPIFFE ID or by trust domain for all of its users.
+	// Users publish and search records of their tenant only, and requests for another
+	// tenant are rejected. Users without a tenant only publish and search untenanted records.
+	Tenants map[string]string `json:"tenants,omitempty" mapstructure:"tenants"`
 }
 
 func (c *Config) Validate() error {
@@ -26,5 +41,11 @@ func (c *Config) Validate() error {
 		return errors.New("trust domain is required for authorization")
 	}
 
+	for user, tenant := range c.Tenants {
+		if tenant == "" {
+			return fmt.Errorf("tenant of %q is empty", user)
+		}
+	}
+
 	return nil
 }
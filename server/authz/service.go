@@ -6,10 +6,15 @@ package authz
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/agntcy/dir/server/authn"
 	"github.com/agntcy/dir/server/authz/config"
+	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var logger = logging.Logger("authz")
@@ -28,6 +33,10 @@ func New(_ context.Context, cfg config.Config) (*Service, error) {
 		return nil, fmt.Errorf("invalid authz config: %w", err)
 	}
 
+	if err := validateLabelNamespaces(cfg.LabelNamespaces); err != nil {
+		return nil, fmt.Errorf("invalid authz config: %w", err)
+	}
+
 	// Create authorizer
 	authorizer, err := NewAuthorizer(cfg)
 	if err != nil {
@@ -49,6 +58,57 @@ func (s *Service) GetServerOptions() []grpc.ServerOption {
 	}
 }
 
+// Authorize checks if the authenticated caller can call the given gRPC method.
+// It lets non-gRPC frontends of the API apply the policies of the interceptors.
+func (s *Service) Authorize(ctx context.Context, apiMethod string) error {
+	return NewInterceptor(s.authorizer)(ctx, apiMethod)
+}
+
+// AuthorizeLabelNamespace checks if the authenticated caller can discover labels of a given namespace.
+// It expects the SPIFFE ID to already be in the context (set by the authn interceptor).
+func (s *Service) AuthorizeLabelNamespace(ctx context.Context, namespace string) (bool, error) {
+	sid, ok := authn.SpiffeIDFromContext(ctx)
+	if !ok {
+		return false, status.Error(codes.Unauthenticated, "not authenticated") //nolint:wrapcheck
+	}
+
+	return s.authorizer.AuthorizeLabelNamespace(sid.TrustDomain().String(), namespace)
+}
+
+// AuthorizeTenant returns the routing tenant of the authenticated caller, derived from its identity.
+// Requests for another tenant than the caller's are rejected; an empty tenant requests the caller's.
+// It expects the SPIFFE ID to already be in the context (set by the authn interceptor).
+//
+//nolint:wrapcheck
+func (s *Service) AuthorizeTenant(ctx context.Context, tenant string) (string, error) {
+	sid, ok := authn.SpiffeIDFromContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "not authenticated")
+	}
+
+	callerTenant := s.authorizer.Tenant(sid.String(), sid.TrustDomain().String())
+	if tenant != "" && tenant != callerTenant {
+		logger.Warn("Tenant denied", "tenant", tenant, "spiffe_id", sid.String())
+
+		return "", status.Errorf(codes.PermissionDenied, "not allowed to access tenant %q", tenant)
+	}
+
+	return callerTenant, nil
+}
+
+// validateLabelNamespaces checks that label policies only reference known label namespaces.
+func validateLabelNamespaces(policies map[string][]string) error {
+	for trustDomain, namespaces := range policies {
+		for _, namespace := range namespaces {
+			if !slices.Contains(types.AllLabelTypes(), types.LabelType(namespace)) {
+				return fmt.Errorf("unknown label namespace %q for trust domain %q", namespace, trustDomain)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Stop closes any resources used by the authorization service.
 func (s *Service) Stop() error {
 	// No resources to clean up in the current implementation
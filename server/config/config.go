@@ -18,6 +18,7 @@ import (
 	oci "github.com/agntcy/dir/server/store/oci/config"
 	sync "github.com/agntcy/dir/server/sync/config"
 	syncmonitor "github.com/agntcy/dir/server/sync/monitor/config"
+	tracing "github.com/agntcy/dir/server/tracing/config"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
@@ -35,6 +36,7 @@ const (
 
 	DefaultListenAddress      = "0.0.0.0:8888"
 	DefaultHealthCheckAddress = "0.0.0.0:8889"
+	DefaultMetricsAddress     = "0.0.0.0:9090"
 )
 
 var logger = logging.Logger("config")
@@ -44,6 +46,17 @@ type Config struct {
 	ListenAddress      string `json:"listen_address,omitempty"      mapstructure:"listen_address"`
 	HealthCheckAddress string `json:"healthcheck_address,omitempty" mapstructure:"healthcheck_address"`
 
+	// Address to serve Prometheus metrics on at /metrics.
+	// If empty, metrics are not served.
+	MetricsAddress string `json:"metrics_address,omitempty" mapstructure:"metrics_address"`
+
+	// Address to serve the HTTP/JSON gateway of the routing API on.
+	// If empty, the gateway is not served.
+	GatewayAddress string `json:"gateway_address,omitempty" mapstructure:"gateway_address"`
+
+	// Tracing configuration (OpenTelemetry)
+	Tracing tracing.Config `json:"tracing,omitempty" mapstructure:"tracing"`
+
 	// Authn configuration (JWT or X.509 authentication)
 	Authn authn.Config `json:"authn,omitempty" mapstructure:"authn"`
 
@@ -99,6 +112,27 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("healthcheck_address")
 	v.SetDefault("healthcheck_address", DefaultHealthCheckAddress)
 
+	_ = v.BindEnv("metrics_address")
+	v.SetDefault("metrics_address", DefaultMetricsAddress)
+
+	_ = v.BindEnv("gateway_address")
+	v.SetDefault("gateway_address", "")
+
+	//
+	// Tracing configuration
+	//
+	_ = v.BindEnv("tracing.endpoint")
+	v.SetDefault("tracing.endpoint", "")
+
+	_ = v.BindEnv("tracing.insecure")
+	v.SetDefault("tracing.insecure", "false")
+
+	_ = v.BindEnv("tracing.service_name")
+	v.SetDefault("tracing.service_name", tracing.DefaultServiceName)
+
+	_ = v.BindEnv("tracing.sample_ratio")
+	v.SetDefault("tracing.sample_ratio", tracing.DefaultSampleRatio)
+
 	//
 	// Authn configuration (authentication: JWT or X.509)
 	//
@@ -141,6 +175,9 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("store.oci.repository_name")
 	v.SetDefault("store.oci.repository_name", oci.DefaultRepositoryName)
 
+	_ = v.BindEnv("store.oci.encryption_key_path")
+	v.SetDefault("store.oci.encryption_key_path", "")
+
 	_ = v.BindEnv("store.oci.auth_config.insecure")
 	v.SetDefault("store.oci.auth_config.insecure", oci.DefaultAuthConfigInsecure)
 
@@ -158,23 +195,353 @@ func LoadConfig() (*Config, error) {
 	_ = v.BindEnv("routing.directory_api_address")
 	v.SetDefault("routing.directory_api_address", "")
 
+	_ = v.BindEnv("routing.zone")
+	v.SetDefault("routing.zone", "")
+
 	_ = v.BindEnv("routing.bootstrap_peers")
 	v.SetDefault("routing.bootstrap_peers", strings.Join(routing.DefaultBootstrapPeers, ","))
 
+	_ = v.BindEnv("routing.mdns.enabled")
+	v.SetDefault("routing.mdns.enabled", routing.DefaultMDNSEnabled)
+
+	_ = v.BindEnv("routing.mdns.service_name")
+	v.SetDefault("routing.mdns.service_name", routing.DefaultMDNSServiceName)
+
 	_ = v.BindEnv("routing.key_path")
 	v.SetDefault("routing.key_path", "")
 
+	_ = v.BindEnv("routing.previous_key_path")
+	v.SetDefault("routing.previous_key_path", "")
+
+	_ = v.BindEnv("routing.allowed_peers")
+	v.SetDefault("routing.allowed_peers", "")
+
+	_ = v.BindEnv("routing.denied_peers")
+	v.SetDefault("routing.denied_peers", "")
+
+	_ = v.BindEnv("routing.gated_access_peers")
+	v.SetDefault("routing.gated_access_peers", "")
+
+	_ = v.BindEnv("routing.gated_access_tokens")
+	v.SetDefault("routing.gated_access_tokens", "")
+
+	_ = v.BindEnv("routing.private_network_key_path")
+	v.SetDefault("routing.private_network_key_path", "")
+
+	_ = v.BindEnv("routing.nat.auto_nat_service")
+	v.SetDefault("routing.nat.auto_nat_service", routing.DefaultNATAutoNATService)
+
+	_ = v.BindEnv("routing.nat.port_mapping")
+	v.SetDefault("routing.nat.port_mapping", routing.DefaultNATPortMapping)
+
+	_ = v.BindEnv("routing.nat.hole_punching")
+	v.SetDefault("routing.nat.hole_punching", routing.DefaultNATHolePunching)
+
+	_ = v.BindEnv("routing.nat.relay_client")
+	v.SetDefault("routing.nat.relay_client", routing.DefaultNATRelayClient)
+
+	_ = v.BindEnv("routing.nat.static_relays")
+	v.SetDefault("routing.nat.static_relays", "")
+
+	_ = v.BindEnv("routing.nat.relay_service")
+	v.SetDefault("routing.nat.relay_service", routing.DefaultNATRelayService)
+
+	_ = v.BindEnv("routing.nat.reachability")
+	v.SetDefault("routing.nat.reachability", "")
+
+	_ = v.BindEnv("routing.dht.mode")
+	v.SetDefault("routing.dht.mode", "")
+
+	_ = v.BindEnv("routing.dht.bucket_size")
+	v.SetDefault("routing.dht.bucket_size", routing.DefaultDHTBucketSize)
+
+	_ = v.BindEnv("routing.dht.concurrency")
+	v.SetDefault("routing.dht.concurrency", routing.DefaultDHTConcurrency)
+
+	_ = v.BindEnv("routing.resource_limits.max_memory")
+	v.SetDefault("routing.resource_limits.max_memory", 0)
+
+	_ = v.BindEnv("routing.resource_limits.max_file_descriptors")
+	v.SetDefault("routing.resource_limits.max_file_descriptors", 0)
+
+	_ = v.BindEnv("routing.resource_limits.max_connections")
+	v.SetDefault("routing.resource_limits.max_connections", 0)
+
+	_ = v.BindEnv("routing.resource_limits.max_streams")
+	v.SetDefault("routing.resource_limits.max_streams", 0)
+
+	_ = v.BindEnv("routing.timeouts.provide")
+	v.SetDefault("routing.timeouts.provide", routing.DefaultTimeoutProvide)
+
+	_ = v.BindEnv("routing.timeouts.pull")
+	v.SetDefault("routing.timeouts.pull", routing.DefaultTimeoutPull)
+
+	_ = v.BindEnv("routing.timeouts.gossipsub_publish")
+	v.SetDefault("routing.timeouts.gossipsub_publish", routing.DefaultTimeoutGossipSubPublish)
+
+	_ = v.BindEnv("routing.timeouts.publish")
+	v.SetDefault("routing.timeouts.publish", routing.DefaultTimeoutPublish)
+
 	_ = v.BindEnv("routing.datastore_dir")
 	v.SetDefault("routing.datastore_dir", "")
 
+	_ = v.BindEnv("routing.datastore_max_buffered_writes")
+	v.SetDefault("routing.datastore_max_buffered_writes", routing.DefaultDatastoreMaxBufferedWrites)
+
+	_ = v.BindEnv("routing.publish_dedup_window")
+	v.SetDefault("routing.publish_dedup_window", routing.DefaultPublishDedupWindow)
+
+	_ = v.BindEnv("routing.pull_concurrency")
+	v.SetDefault("routing.pull_concurrency", routing.DefaultPullConcurrency)
+
+	_ = v.BindEnv("routing.pull_concurrency_per_peer")
+	v.SetDefault("routing.pull_concurrency_per_peer", routing.DefaultPullConcurrencyPerPeer)
+
+	_ = v.BindEnv("routing.max_cached_labels")
+	v.SetDefault("routing.max_cached_labels", routing.DefaultMaxCachedLabels)
+
+	_ = v.BindEnv("routing.seed_peer")
+	v.SetDefault("routing.seed_peer", "")
+
+	_ = v.BindEnv("routing.scoring.strategy")
+	v.SetDefault("routing.scoring.strategy", routing.DefaultScoringStrategy)
+
+	_ = v.BindEnv("routing.scoring.freshness_half_life")
+	v.SetDefault("routing.scoring.freshness_half_life", routing.DefaultScoringFreshnessHalfLife)
+
+	_ = v.BindEnv("routing.scoring.ranking_weights.match")
+	v.SetDefault("routing.scoring.ranking_weights.match", routing.DefaultRankingWeightMatch)
+
+	_ = v.BindEnv("routing.scoring.ranking_weights.freshness")
+	v.SetDefault("routing.scoring.ranking_weights.freshness", routing.DefaultRankingWeightFreshness)
+
+	_ = v.BindEnv("routing.scoring.ranking_weights.reputation")
+	v.SetDefault("routing.scoring.ranking_weights.reputation", routing.DefaultRankingWeightReputation)
+
+	_ = v.BindEnv("routing.scoring.ranking_weights.providers")
+	v.SetDefault("routing.scoring.ranking_weights.providers", routing.DefaultRankingWeightProviders)
+
+	_ = v.BindEnv("routing.peer_redaction")
+	v.SetDefault("routing.peer_redaction", routing.DefaultPeerRedaction)
+
+	_ = v.BindEnv("routing.profile")
+	v.SetDefault("routing.profile", routing.DefaultProfile)
+
+	_ = v.BindEnv("routing.readiness_min_peers")
+	v.SetDefault("routing.readiness_min_peers", routing.DefaultReadinessMinPeers)
+
+	_ = v.BindEnv("routing.max_subscriptions")
+	v.SetDefault("routing.max_subscriptions", routing.DefaultMaxSubscriptions)
+
 	//
 	// Routing GossipSub configuration
-	// Note: Only enable/disable is configurable. Protocol parameters (topic, message size)
+	// Note: Only enable/disable, the subscription, the signature policy and the published wire format are configurable. Protocol parameters (topic, message size)
 	// are hardcoded in server/routing/pubsub/constants.go for network compatibility.
 	//
 	_ = v.BindEnv("routing.gossipsub.enabled")
 	v.SetDefault("routing.gossipsub.enabled", routing.DefaultGossipSubEnabled)
 
+	_ = v.BindEnv("routing.gossipsub.require_signatures")
+	v.SetDefault("routing.gossipsub.require_signatures", routing.DefaultGossipSubRequireSignatures)
+
+	_ = v.BindEnv("routing.gossipsub.namespaces")
+	v.SetDefault("routing.gossipsub.namespaces", strings.Join(routing.DefaultGossipSubNamespaces, ","))
+
+	_ = v.BindEnv("routing.gossipsub.wire_format")
+	v.SetDefault("routing.gossipsub.wire_format", routing.DefaultGossipSubWireFormat)
+
+	_ = v.BindEnv("routing.gossipsub.batch_window")
+	v.SetDefault("routing.gossipsub.batch_window", routing.DefaultGossipSubBatchWindow)
+
+	_ = v.BindEnv("routing.gossipsub.propagation_acks")
+	v.SetDefault("routing.gossipsub.propagation_acks", routing.DefaultGossipSubPropagationAcks)
+
+	//
+	// Routing rate limit configuration
+	//
+	_ = v.BindEnv("routing.rate_limit.announcement_rate")
+	v.SetDefault("routing.rate_limit.announcement_rate", routing.DefaultRateLimitAnnouncementRate)
+
+	_ = v.BindEnv("routing.rate_limit.announcement_burst")
+	v.SetDefault("routing.rate_limit.announcement_burst", routing.DefaultRateLimitAnnouncementBurst)
+
+	_ = v.BindEnv("routing.rate_limit.request_rate")
+	v.SetDefault("routing.rate_limit.request_rate", routing.DefaultRateLimitRequestRate)
+
+	_ = v.BindEnv("routing.rate_limit.request_burst")
+	v.SetDefault("routing.rate_limit.request_burst", routing.DefaultRateLimitRequestBurst)
+
+	_ = v.BindEnv("routing.rate_limit.ban_threshold")
+	v.SetDefault("routing.rate_limit.ban_threshold", routing.DefaultRateLimitBanThreshold)
+
+	_ = v.BindEnv("routing.rate_limit.ban_duration")
+	v.SetDefault("routing.rate_limit.ban_duration", routing.DefaultRateLimitBanDuration)
+
+	_ = v.BindEnv("routing.rate_limit.pull_bytes_per_hour")
+	v.SetDefault("routing.rate_limit.pull_bytes_per_hour", routing.DefaultRateLimitPullBytesPerHour)
+
+	//
+	// Routing events configuration
+	//
+	_ = v.BindEnv("routing.events.kafka.rest_proxy_url")
+	v.SetDefault("routing.events.kafka.rest_proxy_url", "")
+
+	_ = v.BindEnv("routing.events.kafka.topic")
+	v.SetDefault("routing.events.kafka.topic", routing.DefaultEventsKafkaTopic)
+
+	_ = v.BindEnv("routing.events.nats.url")
+	v.SetDefault("routing.events.nats.url", "")
+
+	_ = v.BindEnv("routing.events.nats.subject_prefix")
+	v.SetDefault("routing.events.nats.subject_prefix", routing.DefaultEventsNATSSubjectPrefix)
+
+	//
+	// Announcement sinks configuration
+	//
+	_ = v.BindEnv("routing.announcement_sinks.nats.url")
+	v.SetDefault("routing.announcement_sinks.nats.url", "")
+
+	_ = v.BindEnv("routing.announcement_sinks.nats.subject_prefix")
+	v.SetDefault("routing.announcement_sinks.nats.subject_prefix", routing.DefaultAnnouncementSinksNATSSubjectPrefix)
+
+	_ = v.BindEnv("routing.announcement_sinks.mqtt.broker_url")
+	v.SetDefault("routing.announcement_sinks.mqtt.broker_url", "")
+
+	_ = v.BindEnv("routing.announcement_sinks.mqtt.topic_prefix")
+	v.SetDefault("routing.announcement_sinks.mqtt.topic_prefix", routing.DefaultAnnouncementSinksMQTTTopicPrefix)
+
+	_ = v.BindEnv("routing.announcement_sinks.mqtt.client_id")
+	v.SetDefault("routing.announcement_sinks.mqtt.client_id", "")
+
+	_ = v.BindEnv("routing.announcement_sinks.mqtt.qos")
+	v.SetDefault("routing.announcement_sinks.mqtt.qos", routing.DefaultAnnouncementSinksMQTTQoS)
+
+	_ = v.BindEnv("routing.announcement_sinks.mqtt.username")
+	v.SetDefault("routing.announcement_sinks.mqtt.username", "")
+
+	_ = v.BindEnv("routing.announcement_sinks.mqtt.password")
+	v.SetDefault("routing.announcement_sinks.mqtt.password", "")
+
+	//
+	// Routing history configuration
+	//
+	_ = v.BindEnv("routing.history.enabled")
+	v.SetDefault("routing.history.enabled", routing.DefaultHistoryEnabled)
+
+	_ = v.BindEnv("routing.history.retention")
+	v.SetDefault("routing.history.retention", routing.DefaultHistoryRetention)
+
+	//
+	// Routing provenance configuration
+	//
+	_ = v.BindEnv("routing.provenance.enabled")
+	v.SetDefault("routing.provenance.enabled", routing.DefaultProvenanceEnabled)
+
+	_ = v.BindEnv("routing.provenance.retention")
+	v.SetDefault("routing.provenance.retention", routing.DefaultProvenanceRetention)
+
+	//
+	// Routing liveness configuration
+	//
+	_ = v.BindEnv("routing.liveness.enabled")
+	v.SetDefault("routing.liveness.enabled", routing.DefaultLivenessEnabled)
+
+	_ = v.BindEnv("routing.liveness.interval")
+	v.SetDefault("routing.liveness.interval", routing.DefaultLivenessInterval)
+
+	_ = v.BindEnv("routing.liveness.stale_after")
+	v.SetDefault("routing.liveness.stale_after", routing.DefaultLivenessStaleAfter)
+
+	_ = v.BindEnv("routing.liveness.delete_after")
+	v.SetDefault("routing.liveness.delete_after", routing.DefaultLivenessDeleteAfter)
+
+	//
+	// Routing follower configuration
+	//
+	_ = v.BindEnv("routing.follower.enabled")
+	v.SetDefault("routing.follower.enabled", routing.DefaultFollowerEnabled)
+
+	_ = v.BindEnv("routing.follower.primary_address")
+
+	_ = v.BindEnv("routing.follower.sync_interval")
+	v.SetDefault("routing.follower.sync_interval", routing.DefaultFollowerSyncInterval)
+
+	//
+	// Routing IPNI configuration
+	//
+	_ = v.BindEnv("routing.ipni.enabled")
+	v.SetDefault("routing.ipni.enabled", routing.DefaultIPNIEnabled)
+
+	_ = v.BindEnv("routing.ipni.listen_address")
+	v.SetDefault("routing.ipni.listen_address", routing.DefaultIPNIListenAddress)
+
+	_ = v.BindEnv("routing.ipni.announce_address")
+
+	_ = v.BindEnv("routing.ipni.indexers")
+
+	//
+	// Routing search cache configuration
+	//
+	_ = v.BindEnv("routing.search_cache.enabled")
+	v.SetDefault("routing.search_cache.enabled", routing.DefaultSearchCacheEnabled)
+
+	_ = v.BindEnv("routing.search_cache.ttl")
+	v.SetDefault("routing.search_cache.ttl", routing.DefaultSearchCacheTTL)
+
+	_ = v.BindEnv("routing.search_cache.max_bytes")
+	v.SetDefault("routing.search_cache.max_bytes", routing.DefaultSearchCacheMaxBytes)
+
+	// Routing prefetch configuration
+	_ = v.BindEnv("routing.prefetch.enabled")
+	v.SetDefault("routing.prefetch.enabled", routing.DefaultPrefetchEnabled)
+
+	_ = v.BindEnv("routing.prefetch.min_score")
+	v.SetDefault("routing.prefetch.min_score", routing.DefaultPrefetchMinScore)
+
+	_ = v.BindEnv("routing.prefetch.quota_bytes")
+	v.SetDefault("routing.prefetch.quota_bytes", routing.DefaultPrefetchQuotaBytes)
+
+	// Routing pulled record retention configuration
+	_ = v.BindEnv("routing.retain_pulled.enabled")
+	v.SetDefault("routing.retain_pulled.enabled", routing.DefaultRetainPulledEnabled)
+
+	_ = v.BindEnv("routing.retain_pulled.budget_bytes")
+	v.SetDefault("routing.retain_pulled.budget_bytes", routing.DefaultRetainPulledBudgetBytes)
+
+	// Routing record garbage collection configuration
+	_ = v.BindEnv("routing.record_gc.path")
+	v.SetDefault("routing.record_gc.path", "")
+
+	_ = v.BindEnv("routing.record_gc.threshold")
+	v.SetDefault("routing.record_gc.threshold", routing.DefaultRecordGCThreshold)
+
+	_ = v.BindEnv("routing.record_gc.interval")
+	v.SetDefault("routing.record_gc.interval", routing.DefaultRecordGCInterval)
+
+	// Routing record validation configuration
+	_ = v.BindEnv("routing.record_validation.schema")
+	v.SetDefault("routing.record_validation.schema", routing.DefaultRecordValidationSchema)
+
+	_ = v.BindEnv("routing.record_validation.taxonomy")
+	v.SetDefault("routing.record_validation.taxonomy", routing.DefaultRecordValidationTaxonomy)
+
+	_ = v.BindEnv("routing.record_validation.skills_ontology")
+
+	_ = v.BindEnv("routing.record_validation.locators")
+	v.SetDefault("routing.record_validation.locators", routing.DefaultRecordValidationLocators)
+
+	_ = v.BindEnv("routing.record_validation.locator_reachability")
+	v.SetDefault("routing.record_validation.locator_reachability", routing.DefaultRecordValidationLocatorReachability)
+
+	_ = v.BindEnv("routing.record_validation.locator_reachability_timeout")
+	v.SetDefault("routing.record_validation.locator_reachability_timeout", routing.DefaultRecordValidationLocatorReachabilityTimeout)
+
+	// Routing label taxonomy configuration
+	_ = v.BindEnv("routing.taxonomy.path")
+
+	_ = v.BindEnv("routing.taxonomy.strict")
+	v.SetDefault("routing.taxonomy.strict", routing.DefaultTaxonomyStrict)
+
 	//
 	// Database configuration
 	//
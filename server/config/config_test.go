@@ -18,6 +18,7 @@ import (
 	oci "github.com/agntcy/dir/server/store/oci/config"
 	sync "github.com/agntcy/dir/server/sync/config"
 	monitor "github.com/agntcy/dir/server/sync/monitor/config"
+	tracing "github.com/agntcy/dir/server/tracing/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,38 +31,122 @@ func TestConfig(t *testing.T) {
 		{
 			Name: "Custom config",
 			EnvVars: map[string]string{
-				"DIRECTORY_SERVER_LISTEN_ADDRESS":                       "example.com:8889",
-				"DIRECTORY_SERVER_HEALTHCHECK_ADDRESS":                  "example.com:18888",
-				"DIRECTORY_SERVER_STORE_PROVIDER":                       "provider",
-				"DIRECTORY_SERVER_STORE_OCI_LOCAL_DIR":                  "local-dir",
-				"DIRECTORY_SERVER_STORE_OCI_REGISTRY_ADDRESS":           "example.com:5001",
-				"DIRECTORY_SERVER_STORE_OCI_REPOSITORY_NAME":            "test-dir",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_INSECURE":       "true",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_USERNAME":       "username",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_PASSWORD":       "password",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_ACCESS_TOKEN":   "access-token",
-				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_REFRESH_TOKEN":  "refresh-token",
-				"DIRECTORY_SERVER_ROUTING_LISTEN_ADDRESS":               "/ip4/1.1.1.1/tcp/1",
-				"DIRECTORY_SERVER_ROUTING_BOOTSTRAP_PEERS":              "/ip4/1.1.1.1/tcp/1,/ip4/1.1.1.1/tcp/2",
-				"DIRECTORY_SERVER_ROUTING_KEY_PATH":                     "/path/to/key",
-				"DIRECTORY_SERVER_DATABASE_DB_TYPE":                     "sqlite",
-				"DIRECTORY_SERVER_DATABASE_SQLITE_DB_PATH":              "sqlite.db",
-				"DIRECTORY_SERVER_SYNC_SCHEDULER_INTERVAL":              "1s",
-				"DIRECTORY_SERVER_SYNC_WORKER_COUNT":                    "1",
-				"DIRECTORY_SERVER_SYNC_REGISTRY_MONITOR_CHECK_INTERVAL": "10s",
-				"DIRECTORY_SERVER_SYNC_WORKER_TIMEOUT":                  "10s",
-				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_USERNAME":            "sync-user",
-				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_PASSWORD":            "sync-password",
-				"DIRECTORY_SERVER_AUTHZ_ENABLED":                        "true",
-				"DIRECTORY_SERVER_AUTHZ_SOCKET_PATH":                    "/test/agent.sock",
-				"DIRECTORY_SERVER_AUTHZ_TRUST_DOMAIN":                   "dir.com",
-				"DIRECTORY_SERVER_PUBLICATION_SCHEDULER_INTERVAL":       "10s",
-				"DIRECTORY_SERVER_PUBLICATION_WORKER_COUNT":             "1",
-				"DIRECTORY_SERVER_PUBLICATION_WORKER_TIMEOUT":           "10s",
+				"DIRECTORY_SERVER_LISTEN_ADDRESS":                          "example.com:8889",
+				"DIRECTORY_SERVER_HEALTHCHECK_ADDRESS":                     "example.com:18888",
+				"DIRECTORY_SERVER_METRICS_ADDRESS":                         "example.com:19090",
+				"DIRECTORY_SERVER_GATEWAY_ADDRESS":                         "example.com:18080",
+				"DIRECTORY_SERVER_TRACING_ENDPOINT":                        "otel-collector:4317",
+				"DIRECTORY_SERVER_TRACING_INSECURE":                        "true",
+				"DIRECTORY_SERVER_TRACING_SAMPLE_RATIO":                    "0.25",
+				"DIRECTORY_SERVER_STORE_PROVIDER":                          "provider",
+				"DIRECTORY_SERVER_STORE_OCI_LOCAL_DIR":                     "local-dir",
+				"DIRECTORY_SERVER_STORE_OCI_REGISTRY_ADDRESS":              "example.com:5001",
+				"DIRECTORY_SERVER_STORE_OCI_REPOSITORY_NAME":               "test-dir",
+				"DIRECTORY_SERVER_STORE_OCI_ENCRYPTION_KEY_PATH":           "/etc/dir/record.key",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_INSECURE":          "true",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_USERNAME":          "username",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_PASSWORD":          "password",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_ACCESS_TOKEN":      "access-token",
+				"DIRECTORY_SERVER_STORE_OCI_AUTH_CONFIG_REFRESH_TOKEN":     "refresh-token",
+				"DIRECTORY_SERVER_ROUTING_LISTEN_ADDRESS":                  "/ip4/1.1.1.1/tcp/1",
+				"DIRECTORY_SERVER_ROUTING_ZONE":                            "eu-west-1",
+				"DIRECTORY_SERVER_ROUTING_BOOTSTRAP_PEERS":                 "/ip4/1.1.1.1/tcp/1,/ip4/1.1.1.1/tcp/2",
+				"DIRECTORY_SERVER_ROUTING_MDNS_ENABLED":                    "false",
+				"DIRECTORY_SERVER_ROUTING_MDNS_SERVICE_NAME":               "dir-lab",
+				"DIRECTORY_SERVER_ROUTING_KEY_PATH":                        "/path/to/key",
+				"DIRECTORY_SERVER_ROUTING_PREVIOUS_KEY_PATH":               "/path/to/old.key",
+				"DIRECTORY_SERVER_ROUTING_ALLOWED_PEERS":                   "peer1,peer2",
+				"DIRECTORY_SERVER_ROUTING_DENIED_PEERS":                    "peer3",
+				"DIRECTORY_SERVER_ROUTING_GATED_ACCESS_PEERS":              "peer4",
+				"DIRECTORY_SERVER_ROUTING_GATED_ACCESS_TOKENS":             "token1",
+				"DIRECTORY_SERVER_ROUTING_PRIVATE_NETWORK_KEY_PATH":        "/path/to/swarm.key",
+				"DIRECTORY_SERVER_ROUTING_SEED_PEER":                       "/ip4/1.1.1.1/tcp/3/p2p/seed",
+				"DIRECTORY_SERVER_ROUTING_MAX_CACHED_LABELS":               "100000",
+				"DIRECTORY_SERVER_ROUTING_PULL_CONCURRENCY":                "16",
+				"DIRECTORY_SERVER_ROUTING_PULL_CONCURRENCY_PER_PEER":       "4",
+				"DIRECTORY_SERVER_ROUTING_DATASTORE_MAX_BUFFERED_WRITES":   "500",
+				"DIRECTORY_SERVER_ROUTING_SCORING_STRATEGY":                "freshness",
+				"DIRECTORY_SERVER_ROUTING_SCORING_RANKING_WEIGHTS_MATCH":   "0.8",
+				"DIRECTORY_SERVER_ROUTING_PEER_REDACTION":                  "hash",
+				"DIRECTORY_SERVER_ROUTING_PROFILE":                         "edge",
+				"DIRECTORY_SERVER_ROUTING_DHT_MODE":                        "auto",
+				"DIRECTORY_SERVER_ROUTING_DHT_CONCURRENCY":                 "3",
+				"DIRECTORY_SERVER_ROUTING_RESOURCE_LIMITS_MAX_CONNECTIONS": "400",
+				"DIRECTORY_SERVER_ROUTING_TIMEOUTS_PROVIDE":                "20s",
+				"DIRECTORY_SERVER_ROUTING_TIMEOUTS_PUBLISH":                "45s",
+				"DIRECTORY_SERVER_ROUTING_READINESS_MIN_PEERS":             "3",
+				"DIRECTORY_SERVER_ROUTING_MAX_SUBSCRIPTIONS":               "5",
+				"DIRECTORY_SERVER_ROUTING_GOSSIPSUB_NAMESPACES":            "skills,domains",
+				"DIRECTORY_SERVER_ROUTING_GOSSIPSUB_WIRE_FORMAT":           "protobuf",
+				"DIRECTORY_SERVER_ROUTING_GOSSIPSUB_BATCH_WINDOW":          "250ms",
+				"DIRECTORY_SERVER_ROUTING_GOSSIPSUB_PROPAGATION_ACKS":      "true",
+				"DIRECTORY_SERVER_ROUTING_RATE_LIMIT_REQUEST_RATE":         "5.5",
+				"DIRECTORY_SERVER_ROUTING_RATE_LIMIT_BAN_DURATION":         "1h",
+				"DIRECTORY_SERVER_ROUTING_EVENTS_KAFKA_REST_PROXY_URL":     "http://kafka-rest:8082",
+				"DIRECTORY_SERVER_ROUTING_EVENTS_NATS_URL":                 "nats://nats:4222",
+				"DIRECTORY_SERVER_ROUTING_EVENTS_NATS_SUBJECT_PREFIX":      "dir.events",
+				"DIRECTORY_SERVER_ROUTING_ANNOUNCEMENT_SINKS_NATS_URL":     "nats://nats:4222",
+				"DIRECTORY_SERVER_ROUTING_ANNOUNCEMENT_SINKS_MQTT_QOS":     "2",
+				"DIRECTORY_SERVER_ROUTING_HISTORY_ENABLED":                 "true",
+				"DIRECTORY_SERVER_ROUTING_HISTORY_RETENTION":               "168h",
+				"DIRECTORY_SERVER_ROUTING_PROVENANCE_ENABLED":              "true",
+				"DIRECTORY_SERVER_ROUTING_LIVENESS_ENABLED":                "true",
+				"DIRECTORY_SERVER_ROUTING_LIVENESS_STALE_AFTER":            "1h",
+				"DIRECTORY_SERVER_ROUTING_FOLLOWER_ENABLED":                "true",
+				"DIRECTORY_SERVER_ROUTING_FOLLOWER_PRIMARY_ADDRESS":        "dir-primary:8888",
+				"DIRECTORY_SERVER_ROUTING_IPNI_ENABLED":                    "true",
+				"DIRECTORY_SERVER_ROUTING_IPNI_ANNOUNCE_ADDRESS":           "/dns4/dir.example.com/tcp/3104/http",
+				"DIRECTORY_SERVER_ROUTING_IPNI_INDEXERS":                   "https://cid.contact",
+				"DIRECTORY_SERVER_ROUTING_SEARCH_CACHE_ENABLED":            "true",
+				"DIRECTORY_SERVER_ROUTING_SEARCH_CACHE_TTL":                "1m",
+				"DIRECTORY_SERVER_ROUTING_PREFETCH_ENABLED":                "true",
+				"DIRECTORY_SERVER_ROUTING_PREFETCH_MIN_SCORE":              "3",
+				"DIRECTORY_SERVER_ROUTING_PREFETCH_QUOTA_BYTES":            "1048576",
+				"DIRECTORY_SERVER_ROUTING_RETAIN_PULLED_ENABLED":           "true",
+				"DIRECTORY_SERVER_ROUTING_RETAIN_PULLED_BUDGET_BYTES":      "2097152",
+				"DIRECTORY_SERVER_ROUTING_RECORD_GC_PATH":                  "/var/lib/dir",
+				"DIRECTORY_SERVER_ROUTING_RECORD_GC_THRESHOLD":             "0.9",
+				"DIRECTORY_SERVER_DATABASE_DB_TYPE":                        "sqlite",
+				"DIRECTORY_SERVER_DATABASE_SQLITE_DB_PATH":                 "sqlite.db",
+				"DIRECTORY_SERVER_SYNC_SCHEDULER_INTERVAL":                 "1s",
+				"DIRECTORY_SERVER_SYNC_WORKER_COUNT":                       "1",
+				"DIRECTORY_SERVER_SYNC_REGISTRY_MONITOR_CHECK_INTERVAL":    "10s",
+				"DIRECTORY_SERVER_SYNC_WORKER_TIMEOUT":                     "10s",
+				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_USERNAME":               "sync-user",
+				"DIRECTORY_SERVER_SYNC_AUTH_CONFIG_PASSWORD":               "sync-password",
+				"DIRECTORY_SERVER_AUTHZ_ENABLED":                           "true",
+				"DIRECTORY_SERVER_AUTHZ_SOCKET_PATH":                       "/test/agent.sock",
+				"DIRECTORY_SERVER_AUTHZ_TRUST_DOMAIN":                      "dir.com",
+				"DIRECTORY_SERVER_PUBLICATION_SCHEDULER_INTERVAL":          "10s",
+				"DIRECTORY_SERVER_PUBLICATION_WORKER_COUNT":                "1",
+				"DIRECTORY_SERVER_PUBLICATION_WORKER_TIMEOUT":              "10s",
+
+				"DIRECTORY_SERVER_ROUTING_RECORD_VALIDATION_SCHEMA":                       "true",
+				"DIRECTORY_SERVER_ROUTING_RECORD_VALIDATION_TAXONOMY":                     "true",
+				"DIRECTORY_SERVER_ROUTING_RECORD_VALIDATION_SKILLS_ONTOLOGY":              "/etc/dir/skills.txt",
+				"DIRECTORY_SERVER_ROUTING_RECORD_VALIDATION_LOCATOR_REACHABILITY_TIMEOUT": "2s",
+
+				"DIRECTORY_SERVER_ROUTING_NAT_HOLE_PUNCHING": "false",
+				"DIRECTORY_SERVER_ROUTING_NAT_STATIC_RELAYS": "/ip4/1.1.1.1/tcp/4/p2p/relay",
+				"DIRECTORY_SERVER_ROUTING_NAT_RELAY_SERVICE": "true",
+				"DIRECTORY_SERVER_ROUTING_NAT_REACHABILITY":  "private",
+
+				"DIRECTORY_SERVER_ROUTING_TAXONOMY_PATH":   "/etc/dir/taxonomy.json",
+				"DIRECTORY_SERVER_ROUTING_TAXONOMY_STRICT": "true",
+
+				"DIRECTORY_SERVER_ROUTING_RATE_LIMIT_PULL_BYTES_PER_HOUR": "1073741824",
 			},
 			ExpectedConfig: &Config{
 				ListenAddress:      "example.com:8889",
 				HealthCheckAddress: "example.com:18888",
+				MetricsAddress:     "example.com:19090",
+				GatewayAddress:     "example.com:18080",
+				Tracing: tracing.Config{
+					Endpoint:    "otel-collector:4317",
+					Insecure:    true,
+					ServiceName: tracing.DefaultServiceName,
+					SampleRatio: 0.25,
+				},
 				Authn: authn.Config{
 					Enabled:   false,
 					Mode:      authn.AuthModeX509, // Default from config.go:109
@@ -70,9 +155,10 @@ func TestConfig(t *testing.T) {
 				Store: store.Config{
 					Provider: "provider",
 					OCI: oci.Config{
-						LocalDir:        "local-dir",
-						RegistryAddress: "example.com:5001",
-						RepositoryName:  "test-dir",
+						LocalDir:          "local-dir",
+						RegistryAddress:   "example.com:5001",
+						RepositoryName:    "test-dir",
+						EncryptionKeyPath: "/etc/dir/record.key",
 						AuthConfig: oci.AuthConfig{
 							Insecure:     true,
 							Username:     "username",
@@ -84,13 +170,152 @@ func TestConfig(t *testing.T) {
 				},
 				Routing: routing.Config{
 					ListenAddress: "/ip4/1.1.1.1/tcp/1",
+					Zone:          "eu-west-1",
 					BootstrapPeers: []string{
 						"/ip4/1.1.1.1/tcp/1",
 						"/ip4/1.1.1.1/tcp/2",
 					},
-					KeyPath: "/path/to/key",
+					MDNS: routing.MDNSConfig{
+						ServiceName: "dir-lab",
+					},
+					NAT: routing.NATConfig{
+						AutoNATService: routing.DefaultNATAutoNATService,
+						PortMapping:    routing.DefaultNATPortMapping,
+						RelayClient:    routing.DefaultNATRelayClient,
+						StaticRelays:   []string{"/ip4/1.1.1.1/tcp/4/p2p/relay"},
+						RelayService:   true,
+						Reachability:   "private",
+					},
+					DHT: routing.DHTConfig{
+						Mode:        "auto",
+						BucketSize:  routing.DefaultDHTBucketSize,
+						Concurrency: 3,
+					},
+					ResourceLimits: routing.ResourceLimitsConfig{
+						MaxConnections: 400,
+					},
+					Timeouts: routing.TimeoutsConfig{
+						Provide:          20 * time.Second,
+						Pull:             routing.DefaultTimeoutPull,
+						GossipSubPublish: routing.DefaultTimeoutGossipSubPublish,
+						Publish:          45 * time.Second,
+					},
+					KeyPath:                    "/path/to/key",
+					PreviousKeyPath:            "/path/to/old.key",
+					AllowedPeers:               []string{"peer1", "peer2"},
+					DeniedPeers:                []string{"peer3"},
+					GatedAccessPeers:           []string{"peer4"},
+					GatedAccessTokens:          []string{"token1"},
+					PrivateNetworkKeyPath:      "/path/to/swarm.key",
+					PublishDedupWindow:         routing.DefaultPublishDedupWindow,
+					MaxCachedLabels:            100000,
+					PullConcurrency:            16,
+					PullConcurrencyPerPeer:     4,
+					DatastoreMaxBufferedWrites: 500,
+					SeedPeer:                   "/ip4/1.1.1.1/tcp/3/p2p/seed",
+					Scoring: routing.ScoringConfig{
+						Strategy:          "freshness",
+						FreshnessHalfLife: routing.DefaultScoringFreshnessHalfLife,
+						RankingWeights: routing.RankingWeightsConfig{
+							Match:      0.8,
+							Freshness:  routing.DefaultRankingWeightFreshness,
+							Reputation: routing.DefaultRankingWeightReputation,
+							Providers:  routing.DefaultRankingWeightProviders,
+						},
+					},
+					PeerRedaction:     "hash",
+					Profile:           "edge",
+					ReadinessMinPeers: 3,
+					MaxSubscriptions:  5,
 					GossipSub: routing.GossipSubConfig{
-						Enabled: true, // Default value
+						Enabled:         true, // Default value
+						Namespaces:      []string{"skills", "domains"},
+						WireFormat:      "protobuf",
+						BatchWindow:     250 * time.Millisecond,
+						PropagationAcks: true,
+					},
+					RateLimit: routing.RateLimitConfig{
+						AnnouncementRate:  routing.DefaultRateLimitAnnouncementRate,
+						AnnouncementBurst: routing.DefaultRateLimitAnnouncementBurst,
+						RequestRate:       5.5,
+						RequestBurst:      routing.DefaultRateLimitRequestBurst,
+						BanThreshold:      routing.DefaultRateLimitBanThreshold,
+						BanDuration:       time.Hour,
+						PullBytesPerHour:  1 << 30,
+					},
+					Events: routing.EventsConfig{
+						Kafka: routing.KafkaEventsConfig{
+							RESTProxyURL: "http://kafka-rest:8082",
+							Topic:        routing.DefaultEventsKafkaTopic,
+						},
+						NATS: routing.NATSEventsConfig{
+							URL:           "nats://nats:4222",
+							SubjectPrefix: "dir.events",
+						},
+					},
+					AnnouncementSinks: routing.AnnouncementSinksConfig{
+						NATS: routing.NATSSinkConfig{
+							URL:           "nats://nats:4222",
+							SubjectPrefix: routing.DefaultAnnouncementSinksNATSSubjectPrefix,
+						},
+						MQTT: routing.MQTTSinkConfig{
+							TopicPrefix: routing.DefaultAnnouncementSinksMQTTTopicPrefix,
+							QoS:         2,
+						},
+					},
+					History: routing.HistoryConfig{
+						Enabled:   true,
+						Retention: 168 * time.Hour,
+					},
+					Provenance: routing.ProvenanceConfig{
+						Enabled:   true,
+						Retention: routing.DefaultProvenanceRetention,
+					},
+					Liveness: routing.LivenessConfig{
+						Enabled:     true,
+						Interval:    routing.DefaultLivenessInterval,
+						StaleAfter:  time.Hour,
+						DeleteAfter: routing.DefaultLivenessDeleteAfter,
+					},
+					Follower: routing.FollowerConfig{
+						Enabled:        true,
+						PrimaryAddress: "dir-primary:8888",
+						SyncInterval:   routing.DefaultFollowerSyncInterval,
+					},
+					IPNI: routing.IPNIConfig{
+						Enabled:         true,
+						ListenAddress:   routing.DefaultIPNIListenAddress,
+						AnnounceAddress: "/dns4/dir.example.com/tcp/3104/http",
+						Indexers:        []string{"https://cid.contact"},
+					},
+					SearchCache: routing.SearchCacheConfig{
+						Enabled:  true,
+						TTL:      time.Minute,
+						MaxBytes: routing.DefaultSearchCacheMaxBytes,
+					},
+					Prefetch: routing.PrefetchConfig{
+						Enabled:    true,
+						MinScore:   3,
+						QuotaBytes: 1048576,
+					},
+					RetainPulled: routing.RetainPulledConfig{
+						Enabled:     true,
+						BudgetBytes: 2097152,
+					},
+					RecordGC: routing.RecordGCConfig{
+						Path:      "/var/lib/dir",
+						Threshold: 0.9,
+						Interval:  routing.DefaultRecordGCInterval,
+					},
+					RecordValidation: routing.RecordValidationConfig{
+						Schema:                     true,
+						Taxonomy:                   true,
+						SkillsOntology:             "/etc/dir/skills.txt",
+						LocatorReachabilityTimeout: 2 * time.Second,
+					},
+					Taxonomy: routing.TaxonomyConfig{
+						Path:   "/etc/dir/taxonomy.json",
+						Strict: true,
 					},
 				},
 				Database: database.Config{
@@ -128,6 +353,11 @@ func TestConfig(t *testing.T) {
 			ExpectedConfig: &Config{
 				ListenAddress:      DefaultListenAddress,
 				HealthCheckAddress: DefaultHealthCheckAddress,
+				MetricsAddress:     DefaultMetricsAddress,
+				Tracing: tracing.Config{
+					ServiceName: tracing.DefaultServiceName,
+					SampleRatio: tracing.DefaultSampleRatio,
+				},
 				Authn: authn.Config{
 					Enabled:   false,
 					Mode:      authn.AuthModeX509, // Default from config.go:109
@@ -144,10 +374,136 @@ func TestConfig(t *testing.T) {
 					},
 				},
 				Routing: routing.Config{
-					ListenAddress:  routing.DefaultListenAddress,
-					BootstrapPeers: routing.DefaultBootstrapPeers,
+					ListenAddress:              routing.DefaultListenAddress,
+					BootstrapPeers:             routing.DefaultBootstrapPeers,
+					AllowedPeers:               []string{},
+					DeniedPeers:                []string{},
+					GatedAccessPeers:           []string{},
+					GatedAccessTokens:          []string{},
+					PublishDedupWindow:         routing.DefaultPublishDedupWindow,
+					MaxCachedLabels:            routing.DefaultMaxCachedLabels,
+					PullConcurrency:            routing.DefaultPullConcurrency,
+					PullConcurrencyPerPeer:     routing.DefaultPullConcurrencyPerPeer,
+					DatastoreMaxBufferedWrites: routing.DefaultDatastoreMaxBufferedWrites,
+					Scoring: routing.ScoringConfig{
+						Strategy:          routing.DefaultScoringStrategy,
+						FreshnessHalfLife: routing.DefaultScoringFreshnessHalfLife,
+						RankingWeights: routing.RankingWeightsConfig{
+							Match:      routing.DefaultRankingWeightMatch,
+							Freshness:  routing.DefaultRankingWeightFreshness,
+							Reputation: routing.DefaultRankingWeightReputation,
+							Providers:  routing.DefaultRankingWeightProviders,
+						},
+					},
+					PeerRedaction:     routing.DefaultPeerRedaction,
+					Profile:           routing.DefaultProfile,
+					ReadinessMinPeers: routing.DefaultReadinessMinPeers,
+					MaxSubscriptions:  routing.DefaultMaxSubscriptions,
 					GossipSub: routing.GossipSubConfig{
-						Enabled: routing.DefaultGossipSubEnabled,
+						Enabled:           routing.DefaultGossipSubEnabled,
+						RequireSignatures: routing.DefaultGossipSubRequireSignatures,
+						Namespaces:        routing.DefaultGossipSubNamespaces,
+						WireFormat:        routing.DefaultGossipSubWireFormat,
+						BatchWindow:       routing.DefaultGossipSubBatchWindow,
+						PropagationAcks:   routing.DefaultGossipSubPropagationAcks,
+					},
+					RateLimit: routing.RateLimitConfig{
+						AnnouncementRate:  routing.DefaultRateLimitAnnouncementRate,
+						AnnouncementBurst: routing.DefaultRateLimitAnnouncementBurst,
+						RequestRate:       routing.DefaultRateLimitRequestRate,
+						RequestBurst:      routing.DefaultRateLimitRequestBurst,
+						BanThreshold:      routing.DefaultRateLimitBanThreshold,
+						BanDuration:       routing.DefaultRateLimitBanDuration,
+						PullBytesPerHour:  routing.DefaultRateLimitPullBytesPerHour,
+					},
+					Events: routing.EventsConfig{
+						Kafka: routing.KafkaEventsConfig{
+							Topic: routing.DefaultEventsKafkaTopic,
+						},
+						NATS: routing.NATSEventsConfig{
+							SubjectPrefix: routing.DefaultEventsNATSSubjectPrefix,
+						},
+					},
+					AnnouncementSinks: routing.AnnouncementSinksConfig{
+						NATS: routing.NATSSinkConfig{
+							SubjectPrefix: routing.DefaultAnnouncementSinksNATSSubjectPrefix,
+						},
+						MQTT: routing.MQTTSinkConfig{
+							TopicPrefix: routing.DefaultAnnouncementSinksMQTTTopicPrefix,
+							QoS:         routing.DefaultAnnouncementSinksMQTTQoS,
+						},
+					},
+					MDNS: routing.MDNSConfig{
+						Enabled:     routing.DefaultMDNSEnabled,
+						ServiceName: routing.DefaultMDNSServiceName,
+					},
+					NAT: routing.NATConfig{
+						AutoNATService: routing.DefaultNATAutoNATService,
+						PortMapping:    routing.DefaultNATPortMapping,
+						HolePunching:   routing.DefaultNATHolePunching,
+						RelayClient:    routing.DefaultNATRelayClient,
+						StaticRelays:   []string{},
+						RelayService:   routing.DefaultNATRelayService,
+					},
+					DHT: routing.DHTConfig{
+						BucketSize:  routing.DefaultDHTBucketSize,
+						Concurrency: routing.DefaultDHTConcurrency,
+					},
+					Timeouts: routing.TimeoutsConfig{
+						Provide:          routing.DefaultTimeoutProvide,
+						Pull:             routing.DefaultTimeoutPull,
+						GossipSubPublish: routing.DefaultTimeoutGossipSubPublish,
+						Publish:          routing.DefaultTimeoutPublish,
+					},
+					History: routing.HistoryConfig{
+						Enabled:   routing.DefaultHistoryEnabled,
+						Retention: routing.DefaultHistoryRetention,
+					},
+					Provenance: routing.ProvenanceConfig{
+						Enabled:   routing.DefaultProvenanceEnabled,
+						Retention: routing.DefaultProvenanceRetention,
+					},
+					Liveness: routing.LivenessConfig{
+						Enabled:     routing.DefaultLivenessEnabled,
+						Interval:    routing.DefaultLivenessInterval,
+						StaleAfter:  routing.DefaultLivenessStaleAfter,
+						DeleteAfter: routing.DefaultLivenessDeleteAfter,
+					},
+					Follower: routing.FollowerConfig{
+						Enabled:      routing.DefaultFollowerEnabled,
+						SyncInterval: routing.DefaultFollowerSyncInterval,
+					},
+					IPNI: routing.IPNIConfig{
+						Enabled:       routing.DefaultIPNIEnabled,
+						ListenAddress: routing.DefaultIPNIListenAddress,
+					},
+					SearchCache: routing.SearchCacheConfig{
+						Enabled:  routing.DefaultSearchCacheEnabled,
+						TTL:      routing.DefaultSearchCacheTTL,
+						MaxBytes: routing.DefaultSearchCacheMaxBytes,
+					},
+					Prefetch: routing.PrefetchConfig{
+						Enabled:    routing.DefaultPrefetchEnabled,
+						MinScore:   routing.DefaultPrefetchMinScore,
+						QuotaBytes: routing.DefaultPrefetchQuotaBytes,
+					},
+					RetainPulled: routing.RetainPulledConfig{
+						Enabled:     routing.DefaultRetainPulledEnabled,
+						BudgetBytes: routing.DefaultRetainPulledBudgetBytes,
+					},
+					RecordGC: routing.RecordGCConfig{
+						Threshold: routing.DefaultRecordGCThreshold,
+						Interval:  routing.DefaultRecordGCInterval,
+					},
+					RecordValidation: routing.RecordValidationConfig{
+						Schema:                     routing.DefaultRecordValidationSchema,
+						Taxonomy:                   routing.DefaultRecordValidationTaxonomy,
+						Locators:                   routing.DefaultRecordValidationLocators,
+						LocatorReachability:        routing.DefaultRecordValidationLocatorReachability,
+						LocatorReachabilityTimeout: routing.DefaultRecordValidationLocatorReachabilityTimeout,
+					},
+					Taxonomy: routing.TaxonomyConfig{
+						Strict: routing.DefaultTaxonomyStrict,
 					},
 				},
 				Database: database.Config{
@@ -0,0 +1,82 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/authn"
+	"google.golang.org/protobuf/proto"
+)
+
+// Peer redaction modes of search results returned to unauthenticated callers.
+const (
+	PeerRedactionNone = "none"
+	PeerRedactionOmit = "omit"
+	PeerRedactionHash = "hash"
+)
+
+// peerRedactor redacts the providing peers of search results for unauthenticated callers,
+// so that public read-only endpoints do not enumerate the addresses of the network's nodes.
+type peerRedactor struct {
+	mode string
+	key  []byte // Key of the opaque peer identifiers, random per server process
+}
+
+func newPeerRedactor(mode string) (*peerRedactor, error) {
+	switch mode {
+	case "", PeerRedactionNone:
+		return nil, nil //nolint:nilnil
+	case PeerRedactionOmit:
+		return &peerRedactor{mode: mode}, nil
+	case PeerRedactionHash:
+		key := make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate peer redaction key: %w", err)
+		}
+
+		return &peerRedactor{mode: mode, key: key}, nil
+	default:
+		return nil, fmt.Errorf("invalid peer redaction %q: must be %s, %s or %s", mode, PeerRedactionNone, PeerRedactionOmit, PeerRedactionHash)
+	}
+}
+
+// redact returns the search result as returned to the caller.
+// Results are returned as is to callers authenticated via SPIFFE.
+// Page tokens are sealed by routing, so they do not reveal redacted peers either.
+func (r *peerRedactor) redact(ctx context.Context, item *routingv1.SearchResponse) *routingv1.SearchResponse {
+	if r == nil || item.GetPeer() == nil {
+		return item
+	}
+
+	if _, ok := authn.SpiffeIDFromContext(ctx); ok {
+		return item
+	}
+
+	item = proto.CloneOf(item)
+
+	switch r.mode {
+	case PeerRedactionOmit:
+		item.Peer = nil
+	case PeerRedactionHash:
+		// Opaque identifiers still tell results of the same peer apart,
+		// but cannot be resolved to peer IDs or addresses
+		item.Peer = &routingv1.Peer{Id: r.hash(item.GetPeer().GetId())}
+	}
+
+	return item
+}
+
+func (r *peerRedactor) hash(peerID string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(peerID))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRedactor(t *testing.T) {
+	item := &routingv1.SearchResponse{
+		RecordRef:  &corev1.RecordRef{Cid: "cid1"},
+		Peer:       &routingv1.Peer{Id: "peer1", Addrs: []string{"/ip4/10.0.0.1/tcp/8888"}},
+		MatchScore: 2,
+	}
+
+	authenticated := context.WithValue(t.Context(), authn.SpiffeIDContextKey, spiffeid.RequireFromString("spiffe://example.org/client"))
+
+	t.Run("none", func(t *testing.T) {
+		redactor, err := newPeerRedactor(PeerRedactionNone)
+		require.NoError(t, err)
+		assert.Same(t, item, redactor.redact(t.Context(), item))
+	})
+
+	t.Run("omit", func(t *testing.T) {
+		redactor, err := newPeerRedactor(PeerRedactionOmit)
+		require.NoError(t, err)
+
+		redacted := redactor.redact(t.Context(), item)
+		assert.Nil(t, redacted.GetPeer())
+		assert.Equal(t, "cid1", redacted.GetRecordRef().GetCid())
+		assert.Equal(t, uint32(2), redacted.GetMatchScore())
+		assert.NotNil(t, item.GetPeer(), "the result must not be modified")
+
+		assert.Same(t, item, redactor.redact(authenticated, item))
+	})
+
+	t.Run("hash", func(t *testing.T) {
+		redactor, err := newPeerRedactor(PeerRedactionHash)
+		require.NoError(t, err)
+
+		redacted := redactor.redact(t.Context(), item)
+		assert.NotEqual(t, "peer1", redacted.GetPeer().GetId())
+		assert.Empty(t, redacted.GetPeer().GetAddrs())
+		assert.Equal(t, redacted.GetPeer().GetId(), redactor.redact(t.Context(), item).GetPeer().GetId())
+
+		assert.Same(t, item, redactor.redact(authenticated, item))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newPeerRedactor("mask")
+		assert.Error(t, err)
+	})
+}
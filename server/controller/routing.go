@@ -4,39 +4,98 @@
 package controller
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/dirql"
+	"github.com/agntcy/dir/server/routing/routingerr"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var routingLogger = logging.Logger("controller/routing")
 
+// LabelNamespaceAuthorizer decides which label namespaces a caller may discover through Search.
+type LabelNamespaceAuthorizer interface {
+	AuthorizeLabelNamespace(ctx context.Context, namespace string) (bool, error)
+}
+
+// TenantAuthorizer derives the routing tenant of a caller from its authenticated identity.
+type TenantAuthorizer interface {
+	// AuthorizeTenant returns the tenant of the caller, or an error if the caller may not
+	// access the requested tenant. An empty requested tenant requests the caller's.
+	AuthorizeTenant(ctx context.Context, tenant string) (string, error)
+}
+
 type routingCtlr struct {
 	routingv1.UnimplementedRoutingServiceServer
 	routing     types.RoutingAPI
 	store       types.StoreAPI
 	publication types.PublicationAPI
+	authorizer  LabelNamespaceAuthorizer
+	tenants     TenantAuthorizer
+	redactor    *peerRedactor // nil if peers are not redacted
 }
 
-func NewRoutingController(routing types.RoutingAPI, store types.StoreAPI, publication types.PublicationAPI) routingv1.RoutingServiceServer {
+// NewRoutingController creates the routing service controller.
+// If authorizer is nil, search results are not filtered by label namespace.
+// If tenants is nil, the tenants of requests are not tied to callers.
+// The peers of search results are redacted for unauthenticated callers
+// according to peerRedaction (none, omit or hash).
+func NewRoutingController(routing types.RoutingAPI, store types.StoreAPI, publication types.PublicationAPI, authorizer LabelNamespaceAuthorizer, tenants TenantAuthorizer, peerRedaction string) (routingv1.RoutingServiceServer, error) {
+	redactor, err := newPeerRedactor(peerRedaction)
+	if err != nil {
+		return nil, err
+	}
+
 	return &routingCtlr{
 		routing:                           routing,
 		store:                             store,
 		publication:                       publication,
+		authorizer:                        authorizer,
+		tenants:                           tenants,
+		redactor:                          redactor,
 		UnimplementedRoutingServiceServer: routingv1.UnimplementedRoutingServiceServer{},
-	}
+	}, nil
 }
 
 func (c *routingCtlr) Publish(ctx context.Context, req *routingv1.PublishRequest) (*emptypb.Empty, error) {
 	routingLogger.Debug("Called routing controller's Publish method", "req", req)
 
+	if err := validatePublishRequest(req); err != nil {
+		return nil, err
+	}
+
+	req, err := c.authorizedPublish(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate records referenced by CID now, as the publication is processed in the background.
+	// The error carries the violations of each record as details and is returned as is.
+	if refs := req.GetRecordRefs().GetRefs(); len(refs) > 0 {
+		cids := make([]string, len(refs))
+		for i, ref := range refs {
+			cids[i] = ref.GetCid()
+		}
+
+		if err := c.routing.ValidateRecords(ctx, cids); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
 	// Create publication to be handled by the publication service
 	publicationID, err := c.publication.CreatePublication(ctx, req)
 	if err != nil {
@@ -50,14 +109,135 @@ func (c *routingCtlr) Publish(ctx context.Context, req *routingv1.PublishRequest
 	return &emptypb.Empty{}, nil
 }
 
+// PublishWithProgress publishes records synchronously, streaming the progress of each record.
+func (c *routingCtlr) PublishWithProgress(req *routingv1.PublishRequest, srv routingv1.RoutingService_PublishWithProgressServer) error {
+	routingLogger.Debug("Called routing controller's PublishWithProgress method", "req", req)
+
+	if err := validatePublishRequest(req); err != nil {
+		return err
+	}
+
+	req, err := c.authorizedPublish(srv.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	// Records are published concurrently, while the stream only supports one sender at a time
+	var (
+		mu      sync.Mutex
+		sendErr error
+	)
+
+	progress := func(event *routingv1.PublishProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if sendErr == nil {
+			sendErr = srv.Send(event)
+		}
+	}
+
+	if err := c.publication.Publish(srv.Context(), req, progress); err != nil {
+		return status.Errorf(codes.Internal, "failed to publish: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if sendErr != nil {
+		return status.Errorf(codes.Internal, "failed to send publish progress: %v", sendErr)
+	}
+
+	return nil
+}
+
+// authorizedPublish returns the publish request for the tenant of the caller.
+func (c *routingCtlr) authorizedPublish(ctx context.Context, req *routingv1.PublishRequest) (*routingv1.PublishRequest, error) {
+	tenant, err := c.authorizedTenant(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	req = proto.CloneOf(req)
+	req.TenantId = tenant
+
+	return req, nil
+}
+
+// authorizedTenant returns the tenant the caller publishes or searches records of,
+// so that callers cannot access the records of private tenants just by naming them.
+func (c *routingCtlr) authorizedTenant(ctx context.Context, tenant string) (string, error) {
+	if c.tenants == nil {
+		return tenant, nil
+	}
+
+	tenant, err := c.tenants.AuthorizeTenant(ctx, tenant)
+	if err != nil {
+		return "", routingerr.Wrap(err, "failed to authorize tenant")
+	}
+
+	return tenant, nil
+}
+
+// validatePublishRequest checks the TTL, label policies and superseded record of a publish request.
+func validatePublishRequest(req *routingv1.PublishRequest) error {
+	if ttl := req.GetTtl(); ttl != nil {
+		if err := ttl.CheckValid(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid ttl: %v", err)
+		}
+
+		if ttl.AsDuration() < types.MinRecordTTL {
+			return status.Errorf(codes.InvalidArgument, "ttl must be at least %s", types.MinRecordTTL)
+		}
+	}
+
+	// Namespaces are checked when the records are published, as custom namespaces depend on the routing config
+	if err := types.ValidateLabelPolicies(types.LabelPoliciesFromProto(req.GetLabelPolicies())); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid label policies: %v", err)
+	}
+
+	return validateSupersedes(req)
+}
+
+// validateSupersedes checks that a superseded CID is only set when publishing
+// a single record by reference, and that it refers to another record.
+func validateSupersedes(req *routingv1.PublishRequest) error {
+	supersedes := req.GetSupersedes()
+	if supersedes == "" {
+		return nil
+	}
+
+	if !corev1.IsValidCID(supersedes) {
+		return status.Errorf(codes.InvalidArgument, "invalid supersedes CID: %s", supersedes)
+	}
+
+	refs := req.GetRecordRefs().GetRefs()
+	if len(refs) != 1 {
+		return status.Error(codes.InvalidArgument, "supersedes requires publishing a single record by reference") //nolint:wrapcheck
+	}
+
+	if refs[0].GetCid() == supersedes {
+		return status.Error(codes.InvalidArgument, "a record cannot supersede itself") //nolint:wrapcheck
+	}
+
+	return nil
+}
+
 func (c *routingCtlr) List(req *routingv1.ListRequest, srv routingv1.RoutingService_ListServer) error {
 	routingLogger.Debug("Called routing controller's List method", "req", req)
 
-	itemChan, err := c.routing.List(srv.Context(), req)
+	queries, err := compileQuery(req.GetQueries(), req.GetQuery())
 	if err != nil {
-		st := status.Convert(err)
+		return err
+	}
 
-		return status.Errorf(st.Code(), "failed to list: %s", st.Message())
+	req = proto.CloneOf(req)
+	req.Queries = queries
+	req.Query = ""
+
+	itemChan, err := c.routing.List(srv.Context(), req)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to list")
 	}
 
 	// Stream ListResponse items directly to the client
@@ -73,16 +253,39 @@ func (c *routingCtlr) List(req *routingv1.ListRequest, srv routingv1.RoutingServ
 func (c *routingCtlr) Search(req *routingv1.SearchRequest, srv routingv1.RoutingService_SearchServer) error {
 	routingLogger.Debug("Called routing controller's Search method", "req", req)
 
-	itemChan, err := c.routing.Search(srv.Context(), req)
+	compiled, err := compileQuery(req.GetQueries(), req.GetQuery())
+	if err != nil {
+		return err
+	}
+
+	tenant, err := c.authorizedTenant(srv.Context(), req.GetTenantId())
+	if err != nil {
+		return err
+	}
+
+	// Only search label namespaces the caller is entitled to discover
+	queries, err := c.authorizedQueries(srv.Context(), compiled)
 	if err != nil {
-		st := status.Convert(err)
+		return err
+	}
 
-		return status.Errorf(st.Code(), "failed to search: %s", st.Message())
+	if len(queries) == 0 && len(compiled) > 0 {
+		return nil
 	}
 
-	// Stream SearchResponse items directly to the client
+	req = proto.CloneOf(req)
+	req.Queries = queries
+	req.Query = ""
+	req.TenantId = tenant
+
+	itemChan, err := c.routing.Search(srv.Context(), req)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to search")
+	}
+
+	// Stream SearchResponse items to the client, redacting peers for unauthenticated callers
 	for item := range itemChan {
-		if err := srv.Send(item); err != nil {
+		if err := srv.Send(c.redactor.redact(srv.Context(), item)); err != nil {
 			return status.Errorf(codes.Internal, "failed to send search response: %v", err)
 		}
 	}
@@ -90,6 +293,140 @@ func (c *routingCtlr) Search(req *routingv1.SearchRequest, srv routingv1.Routing
 	return nil
 }
 
+// Subscribe streams the remote records matching the queries as they are discovered.
+func (c *routingCtlr) Subscribe(req *routingv1.SubscribeRequest, srv routingv1.RoutingService_SubscribeServer) error {
+	routingLogger.Debug("Called routing controller's Subscribe method", "req", req)
+
+	compiled, err := compileQuery(req.GetQueries(), req.GetQuery())
+	if err != nil {
+		return err
+	}
+
+	tenant, err := c.authorizedTenant(srv.Context(), req.GetTenantId())
+	if err != nil {
+		return err
+	}
+
+	// Only push records of label namespaces the caller is entitled to discover
+	queries, err := c.authorizedQueries(srv.Context(), compiled)
+	if err != nil {
+		return err
+	}
+
+	if len(queries) == 0 && len(compiled) > 0 {
+		return nil
+	}
+
+	req = proto.CloneOf(req)
+	req.Queries = queries
+	req.Query = ""
+	req.TenantId = tenant
+
+	err = c.routing.Subscribe(srv.Context(), req, func(item *routingv1.SearchResponse) error {
+		if err := srv.Send(c.redactor.redact(srv.Context(), item)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send subscription response: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return routingerr.Wrap(err, "failed to subscribe")
+	}
+
+	return nil
+}
+
+// compileQuery compiles the DIRQL expression of a request, if any, and adds it to its queries.
+func compileQuery(queries []*routingv1.RecordQuery, expr string) ([]*routingv1.RecordQuery, error) {
+	if strings.TrimSpace(expr) == "" {
+		return queries, nil
+	}
+
+	query, err := dirql.Parse(expr)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error()) //nolint:wrapcheck
+	}
+
+	return append(slices.Clone(queries), query), nil
+}
+
+// authorizedQueries drops search queries for label namespaces the caller may not discover,
+// so that Search never reveals records the caller is not entitled to see.
+func (c *routingCtlr) authorizedQueries(ctx context.Context, queries []*routingv1.RecordQuery) ([]*routingv1.RecordQuery, error) {
+	if c.authorizer == nil {
+		return queries, nil
+	}
+
+	allowed := make([]*routingv1.RecordQuery, 0, len(queries))
+
+	for _, query := range queries {
+		// Authorize the canonical form, which expands namespace aliases the same way routing does
+		query = types.CanonicalQuery(query)
+
+		ok, err := c.queryAuthorized(ctx, query)
+		if err != nil {
+			return nil, routingerr.Wrap(err, "failed to authorize search")
+		}
+
+		if !ok {
+			continue
+		}
+
+		allowed = append(allowed, query)
+	}
+
+	return allowed, nil
+}
+
+// queryAuthorized reports whether the caller may discover the label namespaces searched by a query.
+// Query groups are only authorized if all of their sub-queries are, since dropping
+// a sub-query would change the meaning of the group.
+func (c *routingCtlr) queryAuthorized(ctx context.Context, query *routingv1.RecordQuery) (bool, error) {
+	if group := query.GetGroup(); group != nil {
+		for _, sub := range group.GetQueries() {
+			if ok, err := c.queryAuthorized(ctx, sub); err != nil || !ok {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	namespace := queryLabelType(query)
+
+	ok, err := c.authorizer.AuthorizeLabelNamespace(ctx, string(namespace))
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+
+	if !ok {
+		routingLogger.Debug("Dropping search query for unauthorized label namespace", "namespace", namespace, "value", query.GetValue())
+	}
+
+	return ok, nil
+}
+
+// queryLabelType returns the label namespace searched by a query.
+func queryLabelType(query *routingv1.RecordQuery) types.LabelType {
+	switch query.GetType() {
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
+		return types.LabelTypeSkill
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
+		return types.LabelTypeDomain
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		return types.LabelTypeModule
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+		return types.LabelTypeLocator
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL:
+		// Generic label queries are scoped to the first path segment, e.g. "teams/platform"
+		namespace, _, _ := strings.Cut(strings.TrimPrefix(query.GetValue(), "/"), "/")
+
+		return types.LabelType(namespace)
+	default:
+		return types.LabelTypeUnknown
+	}
+}
+
 func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishRequest) (*emptypb.Empty, error) {
 	routingLogger.Debug("Called routing controller's Unpublish method", "req", req)
 
@@ -103,9 +440,7 @@ func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishReq
 	for _, ref := range recordRefs.RecordRefs.GetRefs() {
 		record, err := c.getRecord(ctx, ref)
 		if err != nil {
-			st := status.Convert(err)
-
-			return nil, status.Errorf(st.Code(), "failed to get record: %s", st.Message())
+			return nil, routingerr.Wrap(err, "failed to get record")
 		}
 
 		// Wrap record with adapter for interface-based unpublishing
@@ -113,9 +448,7 @@ func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishReq
 
 		err = c.routing.Unpublish(ctx, adapter)
 		if err != nil {
-			st := status.Convert(err)
-
-			return nil, status.Errorf(st.Code(), "failed to unpublish: %s", st.Message())
+			return nil, routingerr.Wrap(err, "failed to unpublish")
 		}
 
 		routingLogger.Info("Successfully unpublished record", "cid", ref.GetCid())
@@ -124,6 +457,268 @@ func (c *routingCtlr) Unpublish(ctx context.Context, req *routingv1.UnpublishReq
 	return &emptypb.Empty{}, nil
 }
 
+func (c *routingCtlr) EstimateResults(ctx context.Context, req *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error) {
+	routingLogger.Debug("Called routing controller's EstimateResults method", "req", req)
+
+	compiled, err := compileQuery(req.GetQueries(), req.GetQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := c.authorizedTenant(ctx, req.GetTenantId())
+	if err != nil {
+		return nil, err
+	}
+
+	// Only count records in label namespaces the caller is entitled to discover
+	queries, err := c.authorizedQueries(ctx, compiled)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(queries) == 0 && len(compiled) > 0 {
+		return &routingv1.EstimateResultsResponse{}, nil
+	}
+
+	req = proto.CloneOf(req)
+	req.Queries = queries
+	req.Query = ""
+	req.TenantId = tenant
+
+	estimate, err := c.routing.EstimateResults(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to estimate results")
+	}
+
+	// The total counts records of all label namespaces of the tenant,
+	// so it is only reported to callers entitled to discover all of them
+	allowed, err := c.allLabelNamespacesAuthorized(ctx)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to authorize search")
+	}
+
+	if !allowed {
+		estimate = proto.CloneOf(estimate)
+		estimate.TotalCount = 0
+	}
+
+	return estimate, nil
+}
+
+// allLabelNamespacesAuthorized reports whether the caller may discover all label namespaces.
+func (c *routingCtlr) allLabelNamespacesAuthorized(ctx context.Context) (bool, error) {
+	if c.authorizer == nil {
+		return true, nil
+	}
+
+	for _, namespace := range types.AllLabelTypes() {
+		ok, err := c.authorizer.AuthorizeLabelNamespace(ctx, string(namespace))
+		if err != nil || !ok {
+			return false, err //nolint:wrapcheck
+		}
+	}
+
+	return true, nil
+}
+
+func (c *routingCtlr) GetStats(ctx context.Context, req *routingv1.GetStatsRequest) (*routingv1.GetStatsResponse, error) {
+	routingLogger.Debug("Called routing controller's GetStats method", "req", req)
+
+	stats, err := c.routing.GetStats(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get routing stats")
+	}
+
+	return stats, nil
+}
+
+func (c *routingCtlr) Pin(ctx context.Context, req *routingv1.PinRequest) (*emptypb.Empty, error) {
+	routingLogger.Debug("Called routing controller's Pin method", "req", req)
+
+	if err := c.routing.Pin(ctx, req); err != nil {
+		return nil, routingerr.Wrap(err, "failed to pin")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *routingCtlr) Unpin(ctx context.Context, req *routingv1.UnpinRequest) (*emptypb.Empty, error) {
+	routingLogger.Debug("Called routing controller's Unpin method", "req", req)
+
+	if err := c.routing.Unpin(ctx, req); err != nil {
+		return nil, routingerr.Wrap(err, "failed to unpin")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (c *routingCtlr) ListPins(req *routingv1.ListPinsRequest, srv routingv1.RoutingService_ListPinsServer) error {
+	routingLogger.Debug("Called routing controller's ListPins method", "req", req)
+
+	pins, err := c.routing.ListPins(srv.Context(), req)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to list pins")
+	}
+
+	for _, pin := range pins {
+		if err := srv.Send(pin); err != nil {
+			return status.Errorf(codes.Internal, "failed to send pin: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *routingCtlr) VerifyCache(ctx context.Context, req *routingv1.VerifyCacheRequest) (*routingv1.VerifyCacheResponse, error) {
+	routingLogger.Debug("Called routing controller's VerifyCache method", "req", req)
+
+	resp, err := c.routing.VerifyCache(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to verify cache")
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) SetProfile(ctx context.Context, req *routingv1.SetProfileRequest) (*routingv1.SetProfileResponse, error) {
+	routingLogger.Debug("Called routing controller's SetProfile method", "req", req)
+
+	resp, err := c.routing.SetProfile(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to set discovery profile")
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) GetHistory(ctx context.Context, req *routingv1.GetHistoryRequest) (*routingv1.GetHistoryResponse, error) {
+	routingLogger.Debug("Called routing controller's GetHistory method", "req", req)
+
+	resp, err := c.routing.GetHistory(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get discovery history")
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) GrantAccess(ctx context.Context, req *routingv1.GrantAccessRequest) (*routingv1.GrantAccessResponse, error) {
+	routingLogger.Debug("Called routing controller's GrantAccess method", "req", req)
+
+	resp, err := c.routing.GrantAccess(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to grant access")
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) GetTaxonomy(ctx context.Context, req *routingv1.GetTaxonomyRequest) (*routingv1.GetTaxonomyResponse, error) {
+	routingLogger.Debug("Called routing controller's GetTaxonomy method", "req", req)
+
+	resp, err := c.routing.GetTaxonomy(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get taxonomy")
+	}
+
+	return resp, nil
+}
+
+func (c *routingCtlr) GetProvenance(ctx context.Context, req *routingv1.GetProvenanceRequest) (*routingv1.GetProvenanceResponse, error) {
+	routingLogger.Debug("Called routing controller's GetProvenance method", "req", req)
+
+	resp, err := c.routing.GetProvenance(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get provenance")
+	}
+
+	return resp, nil
+}
+
+// stateArchiveChunkSize is the size of the chunks state archives are streamed in.
+const stateArchiveChunkSize = 1024 * 1024 // 1MB
+
+// ExportState streams the state archive of this peer in chunks.
+func (c *routingCtlr) ExportState(req *routingv1.ExportStateRequest, srv routingv1.RoutingService_ExportStateServer) error {
+	routingLogger.Debug("Called routing controller's ExportState method", "req", req)
+
+	chunks := bufio.NewWriterSize(&stateArchiveSender{srv: srv}, stateArchiveChunkSize)
+
+	if err := c.routing.ExportState(srv.Context(), req, chunks); err != nil {
+		return routingerr.Wrap(err, "failed to export state")
+	}
+
+	if err := chunks.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to send state archive: %v", err)
+	}
+
+	return nil
+}
+
+// ImportState imports a state archive streamed in chunks.
+func (c *routingCtlr) ImportState(srv routingv1.RoutingService_ImportStateServer) error {
+	routingLogger.Debug("Called routing controller's ImportState method")
+
+	resp, err := c.routing.ImportState(srv.Context(), &stateArchiveReceiver{srv: srv})
+	if err != nil {
+		return routingerr.Wrap(err, "failed to import state")
+	}
+
+	if err := srv.SendAndClose(resp); err != nil {
+		return status.Errorf(codes.Internal, "failed to send import result: %v", err)
+	}
+
+	return nil
+}
+
+// stateArchiveSender sends the bytes written to it as state archive chunks.
+// It also streams datastore archives of the routing admin service.
+type stateArchiveSender struct {
+	srv interface {
+		Send(*routingv1.StateArchiveChunk) error
+	}
+}
+
+func (s *stateArchiveSender) Write(data []byte) (int, error) {
+	for start := 0; start < len(data); start += stateArchiveChunkSize {
+		chunk := data[start:min(start+stateArchiveChunkSize, len(data))]
+
+		if err := s.srv.Send(&routingv1.StateArchiveChunk{Data: chunk}); err != nil {
+			return start, err //nolint:wrapcheck
+		}
+	}
+
+	return len(data), nil
+}
+
+// stateArchiveReceiver reads the state archive chunks received from the client.
+type stateArchiveReceiver struct {
+	srv interface {
+		Recv() (*routingv1.StateArchiveChunk, error)
+	}
+	pending []byte
+}
+
+func (r *stateArchiveReceiver) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		chunk, err := r.srv.Recv()
+		if errors.Is(err, io.EOF) {
+			return 0, io.EOF
+		}
+
+		if err != nil {
+			return 0, err //nolint:wrapcheck
+		}
+
+		r.pending = chunk.GetData()
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
 func (c *routingCtlr) getRecord(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record, error) {
 	routingLogger.Debug("Called routing controller's getRecord method", "ref", ref)
 
@@ -133,16 +728,12 @@ func (c *routingCtlr) getRecord(ctx context.Context, ref *corev1.RecordRef) (*co
 
 	_, err := c.store.Lookup(ctx, ref)
 	if err != nil {
-		st := status.Convert(err)
-
-		return nil, status.Errorf(st.Code(), "failed to lookup object: %s", st.Message())
+		return nil, routingerr.Wrap(err, "failed to lookup object")
 	}
 
 	record, err := c.store.Pull(ctx, ref)
 	if err != nil {
-		st := status.Convert(err)
-
-		return nil, status.Errorf(st.Code(), "failed to pull object: %s", st.Message())
+		return nil, routingerr.Wrap(err, "failed to pull object")
 	}
 
 	return record, nil
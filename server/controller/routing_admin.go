@@ -0,0 +1,187 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"bufio"
+	"context"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var routingAdminLogger = logging.Logger("controller/routing_admin")
+
+// routingAdminCtlr implements the RoutingAdminService gRPC interface.
+type routingAdminCtlr struct {
+	routingv1.UnimplementedRoutingAdminServiceServer
+	routing types.RoutingAdminAPI
+}
+
+// NewRoutingAdminController creates a new routing admin controller.
+func NewRoutingAdminController(routing types.RoutingAdminAPI) routingv1.RoutingAdminServiceServer {
+	return &routingAdminCtlr{
+		routing: routing,
+	}
+}
+
+func (c *routingAdminCtlr) GetRoutingTable(ctx context.Context, req *routingv1.GetRoutingTableRequest) (*routingv1.GetRoutingTableResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetRoutingTable method")
+
+	resp, err := c.routing.GetRoutingTable(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get routing table")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetGossipSubState(ctx context.Context, req *routingv1.GetGossipSubStateRequest) (*routingv1.GetGossipSubStateResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetGossipSubState method")
+
+	resp, err := c.routing.GetGossipSubState(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get gossipsub state")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetGossipSubDiagnostics(ctx context.Context, req *routingv1.GetGossipSubDiagnosticsRequest) (*routingv1.GetGossipSubDiagnosticsResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetGossipSubDiagnostics method")
+
+	resp, err := c.routing.GetGossipSubDiagnostics(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get gossipsub diagnostics")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetLabelCacheStats(ctx context.Context, req *routingv1.GetLabelCacheStatsRequest) (*routingv1.GetLabelCacheStatsResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetLabelCacheStats method")
+
+	resp, err := c.routing.GetLabelCacheStats(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get label cache stats")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetQueueState(ctx context.Context, req *routingv1.GetQueueStateRequest) (*routingv1.GetQueueStateResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetQueueState method")
+
+	resp, err := c.routing.GetQueueState(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get queue state")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetTaskStatus(ctx context.Context, req *routingv1.GetTaskStatusRequest) (*routingv1.GetTaskStatusResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetTaskStatus method")
+
+	resp, err := c.routing.GetTaskStatus(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get task status")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) StartBackfill(ctx context.Context, req *routingv1.StartBackfillRequest) (*routingv1.StartBackfillResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's StartBackfill method", "req", req)
+
+	resp, err := c.routing.StartBackfill(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to start backfill")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetBackfillStatus(ctx context.Context, req *routingv1.GetBackfillStatusRequest) (*routingv1.GetBackfillStatusResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetBackfillStatus method")
+
+	resp, err := c.routing.GetBackfillStatus(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get backfill status")
+	}
+
+	return resp, nil
+}
+
+func (c *routingAdminCtlr) GetBandwidthUsage(ctx context.Context, req *routingv1.GetBandwidthUsageRequest) (*routingv1.GetBandwidthUsageResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's GetBandwidthUsage method", "req", req)
+
+	resp, err := c.routing.GetBandwidthUsage(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to get bandwidth usage")
+	}
+
+	return resp, nil
+}
+
+// SnapshotDatastore streams the datastore archive of this peer in chunks.
+func (c *routingAdminCtlr) SnapshotDatastore(_ *routingv1.SnapshotDatastoreRequest, srv routingv1.RoutingAdminService_SnapshotDatastoreServer) error {
+	routingAdminLogger.Debug("Called routing admin controller's SnapshotDatastore method")
+
+	chunks := bufio.NewWriterSize(&stateArchiveSender{srv: srv}, stateArchiveChunkSize)
+
+	if err := c.routing.SnapshotDatastore(srv.Context(), chunks); err != nil {
+		return routingerr.Wrap(err, "failed to snapshot datastore")
+	}
+
+	if err := chunks.Flush(); err != nil {
+		return status.Errorf(codes.Internal, "failed to send datastore archive: %v", err)
+	}
+
+	return nil
+}
+
+// RestoreDatastore restores a datastore archive streamed in chunks.
+func (c *routingAdminCtlr) RestoreDatastore(srv routingv1.RoutingAdminService_RestoreDatastoreServer) error {
+	routingAdminLogger.Debug("Called routing admin controller's RestoreDatastore method")
+
+	resp, err := c.routing.RestoreDatastore(srv.Context(), &stateArchiveReceiver{srv: srv})
+	if err != nil {
+		return routingerr.Wrap(err, "failed to restore datastore")
+	}
+
+	if err := srv.SendAndClose(resp); err != nil {
+		return status.Errorf(codes.Internal, "failed to send restore result: %v", err)
+	}
+
+	return nil
+}
+
+// PromoteFollower promotes this follower to announce the records of its primary.
+func (c *routingAdminCtlr) PromoteFollower(ctx context.Context, req *routingv1.PromoteFollowerRequest) (*routingv1.PromoteFollowerResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's PromoteFollower method", "req", req)
+
+	resp, err := c.routing.PromoteFollower(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to promote follower")
+	}
+
+	return resp, nil
+}
+
+// ListPinnedRecords lists the pinned records and the state of the garbage collection of local records.
+func (c *routingAdminCtlr) ListPinnedRecords(ctx context.Context, req *routingv1.ListPinnedRecordsRequest) (*routingv1.ListPinnedRecordsResponse, error) {
+	routingAdminLogger.Debug("Called routing admin controller's ListPinnedRecords method")
+
+	resp, err := c.routing.ListPinnedRecords(ctx, req)
+	if err != nil {
+		return nil, routingerr.Wrap(err, "failed to list pinned records")
+	}
+
+	return resp, nil
+}
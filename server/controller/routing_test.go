@@ -0,0 +1,145 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/authn"
+	"github.com/agntcy/dir/server/authz"
+	authzconfig "github.com/agntcy/dir/server/authz/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateSupersedes(t *testing.T) {
+	const (
+		current  = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+		previous = "bafybeihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"
+	)
+
+	refs := func(cids ...string) *routingv1.PublishRequest_RecordRefs {
+		recordRefs := &routingv1.RecordRefs{}
+		for _, cid := range cids {
+			recordRefs.Refs = append(recordRefs.Refs, &corev1.RecordRef{Cid: cid})
+		}
+
+		return &routingv1.PublishRequest_RecordRefs{RecordRefs: recordRefs}
+	}
+
+	tests := []struct {
+		name    string
+		req     *routingv1.PublishRequest
+		wantErr bool
+	}{
+		{"not set", &routingv1.PublishRequest{Request: refs(current, previous)}, false},
+		{"single record", &routingv1.PublishRequest{Request: refs(current), Supersedes: previous}, false},
+		{"invalid CID", &routingv1.PublishRequest{Request: refs(current), Supersedes: "not-a-cid"}, true},
+		{"multiple records", &routingv1.PublishRequest{Request: refs(current, previous), Supersedes: previous}, true},
+		{"queries", &routingv1.PublishRequest{Request: &routingv1.PublishRequest_Queries{}, Supersedes: previous}, true},
+		{"itself", &routingv1.PublishRequest{Request: refs(current), Supersedes: current}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSupersedes(tt.req)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestRoutingController_Tenants(t *testing.T) {
+	authzService, err := authz.New(t.Context(), authzconfig.Config{
+		Enabled:     true,
+		TrustDomain: "dir.com",
+		Tenants:     map[string]string{"acme.com": "acme"},
+	})
+	require.NoError(t, err)
+
+	ctrl := &routingCtlr{tenants: authzService}
+
+	caller := func(spiffeID string) context.Context {
+		return context.WithValue(t.Context(), authn.SpiffeIDContextKey, spiffeid.RequireFromString(spiffeID))
+	}
+
+	// An unrelated caller cannot publish or search as another tenant by naming it
+	_, err = ctrl.Publish(caller("spiffe://other.com/client"), &routingv1.PublishRequest{TenantId: "acme"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = ctrl.EstimateResults(caller("spiffe://other.com/client"), &routingv1.SearchRequest{TenantId: "acme"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Callers of a tenant act as their tenant without naming it
+	req, err := ctrl.authorizedPublish(caller("spiffe://acme.com/client"), &routingv1.PublishRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", req.GetTenantId())
+}
+
+// namespaceAuthorizer allows discovering the given label namespaces only.
+type namespaceAuthorizer []string
+
+func (a namespaceAuthorizer) AuthorizeLabelNamespace(_ context.Context, namespace string) (bool, error) {
+	return slices.Contains(a, namespace), nil
+}
+
+// estimatingRouting returns a fixed estimate, counting the calls.
+type estimatingRouting struct {
+	types.RoutingAPI
+	calls int
+}
+
+func (r *estimatingRouting) EstimateResults(context.Context, *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error) {
+	r.calls++
+
+	return &routingv1.EstimateResultsResponse{EstimatedCount: 10, TotalCount: 100}, nil
+}
+
+func TestRoutingController_EstimateResults(t *testing.T) {
+	skills := &routingv1.SearchRequest{Queries: []*routingv1.RecordQuery{
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"},
+	}}
+
+	t.Run("all queries unauthorized", func(t *testing.T) {
+		routing := &estimatingRouting{}
+		ctrl := &routingCtlr{routing: routing, authorizer: namespaceAuthorizer{}}
+
+		estimate, err := ctrl.EstimateResults(t.Context(), skills)
+		require.NoError(t, err)
+		assert.Zero(t, estimate.GetEstimatedCount())
+		assert.Zero(t, estimate.GetTotalCount())
+		assert.Zero(t, routing.calls)
+	})
+
+	t.Run("some namespaces unauthorized", func(t *testing.T) {
+		ctrl := &routingCtlr{routing: &estimatingRouting{}, authorizer: namespaceAuthorizer{string(types.LabelTypeSkill)}}
+
+		// The total would count records of namespaces the caller may not discover
+		estimate, err := ctrl.EstimateResults(t.Context(), skills)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), estimate.GetEstimatedCount())
+		assert.Zero(t, estimate.GetTotalCount())
+	})
+
+	t.Run("no authorizer", func(t *testing.T) {
+		ctrl := &routingCtlr{routing: &estimatingRouting{}}
+
+		estimate, err := ctrl.EstimateResults(t.Context(), skills)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), estimate.GetTotalCount())
+	})
+}
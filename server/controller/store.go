@@ -24,15 +24,19 @@ var storeLogger = logging.Logger("controller/store")
 
 type storeCtrl struct {
 	storev1.UnimplementedStoreServiceServer
-	store types.StoreAPI
-	db    types.DatabaseAPI
+	store    types.StoreAPI
+	db       types.DatabaseAPI
+	onDelete []types.DeleteHook
 }
 
-func NewStoreController(store types.StoreAPI, db types.DatabaseAPI) storev1.StoreServiceServer {
+// NewStoreController creates a store controller. The delete hooks are notified
+// of each deleted record, e.g. to retract its announcements.
+func NewStoreController(store types.StoreAPI, db types.DatabaseAPI, onDelete ...types.DeleteHook) storev1.StoreServiceServer {
 	return &storeCtrl{
 		UnimplementedStoreServiceServer: storev1.UnimplementedStoreServiceServer{},
 		store:                           store,
 		db:                              db,
+		onDelete:                        onDelete,
 	}
 }
 
@@ -192,6 +196,13 @@ func (s storeCtrl) Delete(stream storev1.StoreService_DeleteServer) error {
 			storeLogger.Debug("Record removed from search index", "cid", recordRef.GetCid())
 		}
 
+		// Notify delete hooks (secondary operations - don't fail on errors)
+		for _, hook := range s.onDelete {
+			if err := hook(stream.Context(), recordRef.GetCid()); err != nil {
+				storeLogger.Error("Failed to run delete hook", "error", err, "cid", recordRef.GetCid())
+			}
+		}
+
 		storeLogger.Info("Record deleted successfully", "cid", recordRef.GetCid())
 	}
 }
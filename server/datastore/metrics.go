@@ -0,0 +1,316 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+var metricsLogger = logging.Logger("datastore/metrics")
+
+// SlowOperationThreshold is the latency above which a single datastore
+// operation is logged as slow. Network-backed volumes typically exceed this
+// long before routing operations start timing out.
+const SlowOperationThreshold = 100 * time.Millisecond
+
+// Datastore operation names used as metric labels.
+const (
+	OpGet         = "get"
+	OpHas         = "has"
+	OpGetSize     = "get_size"
+	OpPut         = "put"
+	OpDelete      = "delete"
+	OpQuery       = "query"
+	OpSync        = "sync"
+	OpBatchPut    = "batch_put"
+	OpBatchDelete = "batch_delete"
+	OpBatchCommit = "batch_commit"
+)
+
+// OperationStats contains the recorded metrics for one operation on one key prefix.
+type OperationStats struct {
+	Operation    string        `json:"operation"`
+	Prefix       string        `json:"prefix"`
+	Count        uint64        `json:"count"`
+	Errors       uint64        `json:"errors"`
+	TotalLatency time.Duration `json:"total_latency"`
+	MaxLatency   time.Duration `json:"max_latency"`
+}
+
+// AvgLatency returns the mean latency of the operation.
+func (s OperationStats) AvgLatency() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return s.TotalLatency / time.Duration(s.Count) //nolint:gosec
+}
+
+// ErrorRate returns the fraction of failed operations in the range [0, 1].
+func (s OperationStats) ErrorRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return float64(s.Errors) / float64(s.Count)
+}
+
+// Stats is a point-in-time snapshot of the datastore metrics.
+type Stats struct {
+	// Operations holds per-operation, per-prefix latency and error counters,
+	// sorted by prefix and operation name.
+	Operations []OperationStats `json:"operations"`
+
+	// KeyCounts holds the current number of keys stored under each tracked prefix.
+	KeyCounts map[string]int `json:"key_counts"`
+}
+
+type opKey struct {
+	op     string
+	prefix string
+}
+
+// MetricsDatastore wraps a datastore and records per-operation latency and
+// error counters grouped by top-level key prefix (e.g. "/skills", "/records").
+// Key-count gauges for the tracked prefixes are computed on demand by Stats.
+type MetricsDatastore struct {
+	types.Datastore
+
+	prefixes []string
+
+	mu  sync.Mutex
+	ops map[opKey]*OperationStats
+}
+
+// WrapWithMetrics wraps the datastore with the metrics middleware.
+// Prefixes lists the key prefixes for which key-count gauges are reported.
+func WrapWithMetrics(dstore types.Datastore, prefixes ...string) *MetricsDatastore {
+	return &MetricsDatastore{
+		Datastore: dstore,
+		prefixes:  prefixes,
+		ops:       make(map[opKey]*OperationStats),
+	}
+}
+
+func (m *MetricsDatastore) Get(ctx context.Context, key datastore.Key) ([]byte, error) {
+	start := time.Now()
+	value, err := m.Datastore.Get(ctx, key)
+	// Missing keys are an expected outcome, not a storage failure.
+	m.record(OpGet, key.String(), start, ignoreNotFound(err))
+
+	return value, err //nolint:wrapcheck
+}
+
+func (m *MetricsDatastore) Has(ctx context.Context, key datastore.Key) (bool, error) {
+	start := time.Now()
+	exists, err := m.Datastore.Has(ctx, key)
+	m.record(OpHas, key.String(), start, err)
+
+	return exists, err //nolint:wrapcheck
+}
+
+func (m *MetricsDatastore) GetSize(ctx context.Context, key datastore.Key) (int, error) {
+	start := time.Now()
+	size, err := m.Datastore.GetSize(ctx, key)
+	m.record(OpGetSize, key.String(), start, ignoreNotFound(err))
+
+	return size, err //nolint:wrapcheck
+}
+
+func (m *MetricsDatastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	start := time.Now()
+	err := m.Datastore.Put(ctx, key, value)
+	m.record(OpPut, key.String(), start, err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *MetricsDatastore) Delete(ctx context.Context, key datastore.Key) error {
+	start := time.Now()
+	err := m.Datastore.Delete(ctx, key)
+	m.record(OpDelete, key.String(), start, err)
+
+	return err //nolint:wrapcheck
+}
+
+// Query records the time needed to set up the query, not to drain its results.
+func (m *MetricsDatastore) Query(ctx context.Context, q query.Query) (query.Results, error) {
+	start := time.Now()
+	results, err := m.Datastore.Query(ctx, q)
+	m.record(OpQuery, q.Prefix, start, err)
+
+	return results, err //nolint:wrapcheck
+}
+
+func (m *MetricsDatastore) Sync(ctx context.Context, prefix datastore.Key) error {
+	start := time.Now()
+	err := m.Datastore.Sync(ctx, prefix)
+	m.record(OpSync, prefix.String(), start, err)
+
+	return err //nolint:wrapcheck
+}
+
+func (m *MetricsDatastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	batch, err := m.Datastore.Batch(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &metricsBatch{Batch: batch, metrics: m}, nil
+}
+
+// Stats returns a snapshot of the operation counters and the current key counts
+// for each tracked prefix. Key counting bypasses the operation counters.
+func (m *MetricsDatastore) Stats(ctx context.Context) (*Stats, error) {
+	stats := &Stats{
+		KeyCounts: make(map[string]int, len(m.prefixes)),
+	}
+
+	m.mu.Lock()
+
+	for _, op := range m.ops {
+		stats.Operations = append(stats.Operations, *op)
+	}
+
+	m.mu.Unlock()
+
+	sort.Slice(stats.Operations, func(i, j int) bool {
+		if stats.Operations[i].Prefix != stats.Operations[j].Prefix {
+			return stats.Operations[i].Prefix < stats.Operations[j].Prefix
+		}
+
+		return stats.Operations[i].Operation < stats.Operations[j].Operation
+	})
+
+	for _, prefix := range m.prefixes {
+		count, err := m.countKeys(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		stats.KeyCounts[prefix] = count
+	}
+
+	return stats, nil
+}
+
+// countKeys counts the keys stored under the given prefix using the underlying datastore.
+func (m *MetricsDatastore) countKeys(ctx context.Context, prefix string) (int, error) {
+	results, err := m.Datastore.Query(ctx, query.Query{Prefix: prefix, KeysOnly: true})
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+	defer results.Close()
+
+	count := 0
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return 0, result.Error
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// record updates the counters for a single operation.
+func (m *MetricsDatastore) record(op, key string, start time.Time, err error) {
+	latency := time.Since(start)
+	prefix := keyPrefix(key)
+
+	m.mu.Lock()
+
+	stats, ok := m.ops[opKey{op: op, prefix: prefix}]
+	if !ok {
+		stats = &OperationStats{Operation: op, Prefix: prefix}
+		m.ops[opKey{op: op, prefix: prefix}] = stats
+	}
+
+	stats.Count++
+	stats.TotalLatency += latency
+
+	if latency > stats.MaxLatency {
+		stats.MaxLatency = latency
+	}
+
+	if err != nil {
+		stats.Errors++
+	}
+
+	m.mu.Unlock()
+
+	if latency > SlowOperationThreshold {
+		metricsLogger.Warn("Slow datastore operation",
+			"operation", op,
+			"key", key,
+			"latency", latency,
+			"threshold", SlowOperationThreshold)
+	}
+}
+
+// metricsBatch records metrics for batched writes.
+type metricsBatch struct {
+	datastore.Batch
+
+	metrics *MetricsDatastore
+}
+
+func (b *metricsBatch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	start := time.Now()
+	err := b.Batch.Put(ctx, key, value)
+	b.metrics.record(OpBatchPut, key.String(), start, err)
+
+	return err //nolint:wrapcheck
+}
+
+func (b *metricsBatch) Delete(ctx context.Context, key datastore.Key) error {
+	start := time.Now()
+	err := b.Batch.Delete(ctx, key)
+	b.metrics.record(OpBatchDelete, key.String(), start, err)
+
+	return err //nolint:wrapcheck
+}
+
+func (b *metricsBatch) Commit(ctx context.Context) error {
+	start := time.Now()
+	err := b.Batch.Commit(ctx)
+	b.metrics.record(OpBatchCommit, "/", start, err)
+
+	return err //nolint:wrapcheck
+}
+
+// keyPrefix returns the top-level namespace of a key.
+// Example: "/skills/AI/ML/CID123/Peer1" → "/skills".
+func keyPrefix(key string) string {
+	trimmed := strings.TrimPrefix(key, "/")
+	if trimmed == "" {
+		return "/"
+	}
+
+	if idx := strings.Index(trimmed, "/"); idx >= 0 {
+		trimmed = trimmed[:idx]
+	}
+
+	return "/" + trimmed
+}
+
+func ignoreNotFound(err error) error {
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,49 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"testing"
+
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsDatastore(t *testing.T) {
+	ctx := t.Context()
+
+	base, err := New()
+	require.NoError(t, err)
+
+	dstore := WrapWithMetrics(base, "/skills/", "/records/")
+
+	require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/skills/AI/CID1/Peer1"), []byte("a")))
+	require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/skills/AI/CID2/Peer1"), []byte("b")))
+
+	batch, err := dstore.Batch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, batch.Put(ctx, ipfsdatastore.NewKey("/records/CID1"), nil))
+	require.NoError(t, batch.Commit(ctx))
+
+	// Missing keys must not be counted as errors
+	_, err = dstore.Get(ctx, ipfsdatastore.NewKey("/skills/missing"))
+	require.ErrorIs(t, err, ipfsdatastore.ErrNotFound)
+
+	stats, err := dstore.Stats(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"/skills/": 2, "/records/": 1}, stats.KeyCounts)
+
+	byOp := make(map[string]OperationStats)
+	for _, op := range stats.Operations {
+		byOp[op.Prefix+":"+op.Operation] = op
+	}
+
+	assert.Equal(t, uint64(2), byOp["/skills:"+OpPut].Count)
+	assert.Equal(t, uint64(1), byOp["/records:"+OpBatchPut].Count)
+	assert.Equal(t, uint64(1), byOp["/:"+OpBatchCommit].Count)
+	assert.Equal(t, uint64(1), byOp["/skills:"+OpGet].Count)
+	assert.Zero(t, byOp["/skills:"+OpGet].ErrorRate())
+}
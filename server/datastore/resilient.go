@@ -0,0 +1,469 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+var resilientLogger = logging.Logger("datastore/resilient")
+
+// RecoveryProbeInterval is how often an unavailable datastore is probed for recovery.
+const RecoveryProbeInterval = 5 * time.Second
+
+// ErrUnavailable is returned for writes while the datastore is unavailable
+// and the write buffer is full.
+var ErrUnavailable = errors.New("datastore unavailable")
+
+// bufferedWrite is the latest write of a key while the datastore is unavailable.
+type bufferedWrite struct {
+	value   []byte
+	deleted bool
+	seq     uint64 // Orders writes of the same key across flushes
+}
+
+// ResilientDatastore wraps a datastore and keeps accepting writes while it is
+// unavailable (e.g. disk full or backend down).
+//
+// When a write fails, the datastore enters degraded mode: writes are buffered
+// in memory up to a limit, reads of buffered keys are served from memory and
+// queries merge the buffered writes into the results of the datastore.
+// The datastore is probed in the background and the buffered writes are
+// flushed once it recovers, which leaves degraded mode.
+//
+// Buffered writes are only held in memory, so they are lost if the process
+// exits before the datastore recovers.
+type ResilientDatastore struct {
+	types.Datastore
+
+	maxBuffered int
+
+	mu       sync.RWMutex
+	degraded bool
+	since    time.Time
+	overflow bool // Whether writes were refused during the current degraded period
+	buffer   map[datastore.Key]bufferedWrite
+	seq      uint64
+
+	ctx    context.Context //nolint:containedctx // Lifecycle of the recovery probe
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// WrapWithWriteBuffer wraps the datastore with the degraded mode middleware.
+// MaxBuffered bounds the writes buffered while the datastore is unavailable;
+// zero refuses writes until it recovers.
+func WrapWithWriteBuffer(dstore types.Datastore, maxBuffered int) *ResilientDatastore {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ResilientDatastore{
+		Datastore:   dstore,
+		maxBuffered: maxBuffered,
+		buffer:      make(map[datastore.Key]bufferedWrite),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Unwrap returns the wrapped datastore.
+func (d *ResilientDatastore) Unwrap() types.Datastore {
+	return d.Datastore
+}
+
+// Degraded reports whether the datastore is unavailable and the number of buffered writes.
+func (d *ResilientDatastore) Degraded() (bool, int) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.degraded, len(d.buffer)
+}
+
+func (d *ResilientDatastore) Get(ctx context.Context, key datastore.Key) ([]byte, error) {
+	if write, ok := d.buffered(key); ok {
+		if write.deleted {
+			return nil, datastore.ErrNotFound
+		}
+
+		return slices.Clone(write.value), nil
+	}
+
+	return d.Datastore.Get(ctx, key) //nolint:wrapcheck
+}
+
+func (d *ResilientDatastore) Has(ctx context.Context, key datastore.Key) (bool, error) {
+	if write, ok := d.buffered(key); ok {
+		return !write.deleted, nil
+	}
+
+	return d.Datastore.Has(ctx, key) //nolint:wrapcheck
+}
+
+func (d *ResilientDatastore) GetSize(ctx context.Context, key datastore.Key) (int, error) {
+	if write, ok := d.buffered(key); ok {
+		if write.deleted {
+			return -1, datastore.ErrNotFound
+		}
+
+		return len(write.value), nil
+	}
+
+	return d.Datastore.GetSize(ctx, key) //nolint:wrapcheck
+}
+
+func (d *ResilientDatastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	return d.write(ctx, []datastore.Key{key}, []bufferedWrite{{value: slices.Clone(value)}})
+}
+
+func (d *ResilientDatastore) Delete(ctx context.Context, key datastore.Key) error {
+	return d.write(ctx, []datastore.Key{key}, []bufferedWrite{{deleted: true}})
+}
+
+// Sync succeeds while the datastore is unavailable, as buffered writes cannot be persisted.
+func (d *ResilientDatastore) Sync(ctx context.Context, prefix datastore.Key) error {
+	d.mu.RLock()
+	degraded := d.degraded
+	d.mu.RUnlock()
+
+	if degraded {
+		return nil
+	}
+
+	err := d.Datastore.Sync(ctx, prefix)
+	if err == nil || !isUnavailable(err) {
+		return err //nolint:wrapcheck
+	}
+
+	// Subsequent writes are held in memory until the datastore recovers
+	d.enterDegraded(err)
+
+	return nil
+}
+
+// Query merges the buffered writes into the results of the datastore.
+// The datastore is queried directly while no writes are buffered.
+func (d *ResilientDatastore) Query(ctx context.Context, q query.Query) (query.Results, error) {
+	d.mu.RLock()
+	overlay := make(map[string]bufferedWrite, len(d.buffer))
+
+	for key, write := range d.buffer {
+		overlay[key.String()] = write
+	}
+	d.mu.RUnlock()
+
+	if len(overlay) == 0 {
+		return d.Datastore.Query(ctx, q) //nolint:wrapcheck
+	}
+
+	// Filters, orders and pagination are applied to the merged entries
+	results, err := d.Datastore.Query(ctx, query.Query{Prefix: q.Prefix, KeysOnly: q.KeysOnly, ReturnsSizes: q.ReturnsSizes})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	stored, err := results.Rest()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	entries := make([]query.Entry, 0, len(stored)+len(overlay))
+
+	for _, entry := range stored {
+		if _, ok := overlay[entry.Key]; !ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	for key, write := range overlay {
+		if write.deleted {
+			continue
+		}
+
+		entry := query.Entry{Key: key, Size: len(write.value)}
+		if !q.KeysOnly {
+			entry.Value = slices.Clone(write.value)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// Keep the key order of the datastore for queries without explicit orders
+	slices.SortFunc(entries, func(a, b query.Entry) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return query.NaiveQueryApply(q, query.ResultsWithEntries(q, entries)), nil
+}
+
+func (d *ResilientDatastore) Batch(_ context.Context) (datastore.Batch, error) {
+	return &resilientBatch{dstore: d}, nil
+}
+
+// Close stops probing the datastore, flushes the buffered writes if possible and closes the datastore.
+func (d *ResilientDatastore) Close() error {
+	d.cancel()
+	d.wg.Wait()
+
+	if degraded, buffered := d.Degraded(); degraded && buffered > 0 {
+		if err := d.flush(context.Background()); err != nil {
+			resilientLogger.Error("Discarding writes buffered while the datastore is unavailable",
+				"writes", buffered,
+				"error", err)
+		}
+	}
+
+	return d.Datastore.Close() //nolint:wrapcheck
+}
+
+// buffered returns the buffered write of a key, if any.
+func (d *ResilientDatastore) buffered(key datastore.Key) (bufferedWrite, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	write, ok := d.buffer[key]
+
+	return write, ok
+}
+
+// write applies writes to the datastore, or buffers them if it is unavailable.
+// The writes are applied or buffered together.
+func (d *ResilientDatastore) write(ctx context.Context, keys []datastore.Key, writes []bufferedWrite) error {
+	d.mu.RLock()
+	degraded := d.degraded
+	d.mu.RUnlock()
+
+	if !degraded {
+		err := d.apply(ctx, keys, writes)
+		if err == nil || !isUnavailable(err) {
+			return err
+		}
+
+		d.enterDegraded(err)
+	}
+
+	return d.bufferWrites(keys, writes)
+}
+
+// apply writes to the datastore in a single batch.
+func (d *ResilientDatastore) apply(ctx context.Context, keys []datastore.Key, writes []bufferedWrite) error {
+	if len(keys) == 1 {
+		if writes[0].deleted {
+			return d.Datastore.Delete(ctx, keys[0]) //nolint:wrapcheck
+		}
+
+		return d.Datastore.Put(ctx, keys[0], writes[0].value) //nolint:wrapcheck
+	}
+
+	batch, err := d.Datastore.Batch(ctx)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for i, key := range keys {
+		if writes[i].deleted {
+			err = batch.Delete(ctx, key)
+		} else {
+			err = batch.Put(ctx, key, writes[i].value)
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return batch.Commit(ctx) //nolint:wrapcheck
+}
+
+// bufferWrites holds writes in memory until the datastore recovers.
+func (d *ResilientDatastore) bufferWrites(keys []datastore.Key, writes []bufferedWrite) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	added := make(map[datastore.Key]struct{})
+
+	for _, key := range keys {
+		if _, ok := d.buffer[key]; !ok {
+			added[key] = struct{}{}
+		}
+	}
+
+	if len(d.buffer)+len(added) > d.maxBuffered {
+		if !d.overflow {
+			d.overflow = true
+
+			resilientLogger.Error("Refusing writes while the datastore is unavailable, the write buffer is full",
+				"maxBuffered", d.maxBuffered,
+				"unavailableSince", d.since)
+		}
+
+		return fmt.Errorf("%w: write buffer of %d writes is full", ErrUnavailable, d.maxBuffered)
+	}
+
+	for i, key := range keys {
+		d.seq++
+		write := writes[i]
+		write.seq = d.seq
+		d.buffer[key] = write
+	}
+
+	return nil
+}
+
+// enterDegraded starts buffering writes and probing the datastore for recovery.
+func (d *ResilientDatastore) enterDegraded(cause error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.degraded || d.ctx.Err() != nil {
+		return
+	}
+
+	d.degraded = true
+	d.since = time.Now()
+	d.overflow = false
+
+	resilientLogger.Warn("Datastore unavailable, buffering writes until it recovers",
+		"error", cause,
+		"maxBuffered", d.maxBuffered)
+
+	d.wg.Add(1)
+
+	go d.probe()
+}
+
+// probe periodically flushes the buffered writes until the datastore recovers.
+func (d *ResilientDatastore) probe() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(RecoveryProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.flush(d.ctx); err != nil {
+				resilientLogger.Debug("Datastore still unavailable", "error", err)
+
+				continue
+			}
+
+			if d.recover() {
+				return
+			}
+		}
+	}
+}
+
+// flush applies the buffered writes to the datastore and removes them from the buffer,
+// unless they were overwritten in the meantime. With nothing buffered, the datastore
+// is probed by syncing it.
+func (d *ResilientDatastore) flush(ctx context.Context) error {
+	d.mu.RLock()
+	keys := make([]datastore.Key, 0, len(d.buffer))
+	writes := make([]bufferedWrite, 0, len(d.buffer))
+
+	for key, write := range d.buffer {
+		keys = append(keys, key)
+		writes = append(writes, write)
+	}
+	d.mu.RUnlock()
+
+	if len(keys) == 0 {
+		return d.Datastore.Sync(ctx, datastore.NewKey("/")) //nolint:wrapcheck
+	}
+
+	if err := d.apply(ctx, keys, writes); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, key := range keys {
+		if d.buffer[key].seq == writes[i].seq {
+			delete(d.buffer, key)
+		}
+	}
+
+	resilientLogger.Info("Flushed writes buffered while the datastore was unavailable", "writes", len(keys))
+
+	return nil
+}
+
+// recover leaves degraded mode if no writes were buffered since the last flush.
+func (d *ResilientDatastore) recover() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.buffer) > 0 {
+		return false
+	}
+
+	resilientLogger.Info("Datastore recovered", "unavailableFor", time.Since(d.since))
+
+	d.degraded = false
+	d.overflow = false
+
+	return true
+}
+
+// isUnavailable reports whether a datastore error indicates that the datastore is unavailable,
+// as opposed to an expected outcome or a cancelled operation.
+func isUnavailable(err error) bool {
+	return !errors.Is(err, datastore.ErrNotFound) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+// resilientBatch collects batched writes and applies or buffers them together on commit.
+type resilientBatch struct {
+	dstore *ResilientDatastore
+
+	mu     sync.Mutex
+	keys   []datastore.Key
+	writes []bufferedWrite
+}
+
+func (b *resilientBatch) Put(_ context.Context, key datastore.Key, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.keys = append(b.keys, key)
+	b.writes = append(b.writes, bufferedWrite{value: slices.Clone(value)})
+
+	return nil
+}
+
+func (b *resilientBatch) Delete(_ context.Context, key datastore.Key) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.keys = append(b.keys, key)
+	b.writes = append(b.writes, bufferedWrite{deleted: true})
+
+	return nil
+}
+
+func (b *resilientBatch) Commit(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.keys) == 0 {
+		return nil
+	}
+
+	return b.dstore.write(ctx, b.keys, b.writes)
+}
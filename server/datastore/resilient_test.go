@@ -0,0 +1,177 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDiskFull = errors.New("no space left on device")
+
+// unavailableDatastore fails all writes while unavailable is set.
+type unavailableDatastore struct {
+	types.Datastore
+
+	unavailable atomic.Bool
+}
+
+func (d *unavailableDatastore) Put(ctx context.Context, key ipfsdatastore.Key, value []byte) error {
+	if d.unavailable.Load() {
+		return errDiskFull
+	}
+
+	return d.Datastore.Put(ctx, key, value) //nolint:wrapcheck
+}
+
+func (d *unavailableDatastore) Delete(ctx context.Context, key ipfsdatastore.Key) error {
+	if d.unavailable.Load() {
+		return errDiskFull
+	}
+
+	return d.Datastore.Delete(ctx, key) //nolint:wrapcheck
+}
+
+func (d *unavailableDatastore) Sync(ctx context.Context, prefix ipfsdatastore.Key) error {
+	if d.unavailable.Load() {
+		return errDiskFull
+	}
+
+	return d.Datastore.Sync(ctx, prefix) //nolint:wrapcheck
+}
+
+func (d *unavailableDatastore) Batch(ctx context.Context) (ipfsdatastore.Batch, error) {
+	if d.unavailable.Load() {
+		return nil, errDiskFull
+	}
+
+	return d.Datastore.Batch(ctx) //nolint:wrapcheck
+}
+
+func newUnavailableDatastore(t *testing.T, maxBuffered int) (*ResilientDatastore, *unavailableDatastore) {
+	t.Helper()
+
+	base, err := New()
+	require.NoError(t, err)
+
+	backend := &unavailableDatastore{Datastore: base}
+	dstore := WrapWithWriteBuffer(backend, maxBuffered)
+
+	t.Cleanup(func() { _ = dstore.Close() })
+
+	return dstore, backend
+}
+
+func TestResilientDatastore_BuffersWhileUnavailable(t *testing.T) {
+	ctx := t.Context()
+	dstore, backend := newUnavailableDatastore(t, 10)
+
+	require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/skills/AI/CID1/Peer1"), []byte("a")))
+
+	backend.unavailable.Store(true)
+
+	require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/skills/AI/CID2/Peer1"), []byte("b")))
+	require.NoError(t, dstore.Delete(ctx, ipfsdatastore.NewKey("/skills/AI/CID1/Peer1")))
+	require.NoError(t, dstore.Sync(ctx, ipfsdatastore.NewKey("/")))
+
+	degraded, buffered := dstore.Degraded()
+	assert.True(t, degraded)
+	assert.Equal(t, 2, buffered)
+
+	// Reads are served from the buffered writes
+	value, err := dstore.Get(ctx, ipfsdatastore.NewKey("/skills/AI/CID2/Peer1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), value)
+
+	_, err = dstore.Get(ctx, ipfsdatastore.NewKey("/skills/AI/CID1/Peer1"))
+	require.ErrorIs(t, err, ipfsdatastore.ErrNotFound)
+
+	has, err := dstore.Has(ctx, ipfsdatastore.NewKey("/skills/AI/CID1/Peer1"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// Queries merge the buffered writes into the stored entries
+	results, err := dstore.Query(ctx, query.Query{Prefix: "/skills/"})
+	require.NoError(t, err)
+
+	entries, err := results.Rest()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/skills/AI/CID2/Peer1", entries[0].Key)
+	assert.Equal(t, []byte("b"), entries[0].Value)
+
+	// The buffered writes are flushed once the datastore recovers
+	backend.unavailable.Store(false)
+
+	require.NoError(t, dstore.flush(ctx))
+	assert.True(t, dstore.recover())
+
+	degraded, buffered = dstore.Degraded()
+	assert.False(t, degraded)
+	assert.Zero(t, buffered)
+
+	value, err = backend.Get(ctx, ipfsdatastore.NewKey("/skills/AI/CID2/Peer1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("b"), value)
+
+	has, err = backend.Has(ctx, ipfsdatastore.NewKey("/skills/AI/CID1/Peer1"))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestResilientDatastore_BufferFull(t *testing.T) {
+	ctx := t.Context()
+	dstore, backend := newUnavailableDatastore(t, 1)
+
+	backend.unavailable.Store(true)
+
+	require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/records/CID1"), []byte("a")))
+
+	err := dstore.Put(ctx, ipfsdatastore.NewKey("/records/CID2"), []byte("b"))
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	// Overwriting a buffered key does not take more space
+	require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey("/records/CID1"), []byte("c")))
+
+	value, err := dstore.Get(ctx, ipfsdatastore.NewKey("/records/CID1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("c"), value)
+}
+
+func TestResilientDatastore_Batch(t *testing.T) {
+	ctx := t.Context()
+	dstore, backend := newUnavailableDatastore(t, 10)
+
+	backend.unavailable.Store(true)
+
+	batch, err := dstore.Batch(ctx)
+	require.NoError(t, err)
+	require.NoError(t, batch.Put(ctx, ipfsdatastore.NewKey("/records/CID1"), []byte("a")))
+	require.NoError(t, batch.Put(ctx, ipfsdatastore.NewKey("/records/CID2"), []byte("b")))
+	require.NoError(t, batch.Commit(ctx))
+
+	_, buffered := dstore.Degraded()
+	assert.Equal(t, 2, buffered)
+
+	// Flushing fails while the datastore is still unavailable
+	require.ErrorIs(t, dstore.flush(ctx), errDiskFull)
+	assert.False(t, dstore.recover())
+
+	backend.unavailable.Store(false)
+
+	require.NoError(t, dstore.flush(ctx))
+	assert.True(t, dstore.recover())
+
+	has, err := backend.Has(ctx, ipfsdatastore.NewKey("/records/CID2"))
+	require.NoError(t, err)
+	assert.True(t, has)
+}
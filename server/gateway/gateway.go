@@ -0,0 +1,121 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gateway serves the routing API over HTTP/JSON for clients that cannot use gRPC.
+//
+// Requests and responses use the protobuf JSON mapping of the routingv1 messages
+// and are handled by the same controller as the gRPC service:
+//
+//	POST /v1/routing/search   SearchRequest  -> stream of SearchResponse
+//	POST /v1/routing/publish  PublishRequest -> empty object
+//
+// Search results are streamed as newline-delimited JSON, or as server-sent
+// events if the client accepts text/event-stream.
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/utils/logging"
+)
+
+const (
+	// SearchPath is the HTTP path of the routing Search method.
+	SearchPath = "/v1/routing/search"
+
+	// PublishPath is the HTTP path of the routing Publish method.
+	PublishPath = "/v1/routing/publish"
+)
+
+const readHeaderTimeout = 10 * time.Second
+
+var logger = logging.Logger("gateway")
+
+// Authenticator authenticates HTTP requests the way the gRPC interceptors authenticate calls.
+type Authenticator interface {
+	// TLSConfig returns the TLS configuration of the server.
+	TLSConfig() *tls.Config
+
+	// AuthenticateHTTP returns the request context carrying the identity of the caller.
+	AuthenticateHTTP(r *http.Request) (context.Context, error)
+}
+
+// Authorizer authorizes authenticated callers to call gRPC methods.
+type Authorizer interface {
+	Authorize(ctx context.Context, apiMethod string) error
+}
+
+// Server serves the routing API over HTTP.
+type Server struct {
+	server *http.Server
+}
+
+// NewServer creates a gateway server listening on the given address.
+// Requests are authenticated and authorized if authn and authz are set,
+// in which case the server also serves TLS.
+func NewServer(address string, routing routingv1.RoutingServiceServer, authn Authenticator, authz Authorizer) *Server {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           Handler(routing, authn, authz),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	if authn != nil {
+		server.TLSConfig = authn.TLSConfig()
+	}
+
+	return &Server{server: server}
+}
+
+// Handler returns the HTTP handler of the routing API.
+func Handler(routing routingv1.RoutingServiceServer, authn Authenticator, authz Authorizer) http.Handler {
+	h := &handler{
+		routing: routing,
+		authn:   authn,
+		authz:   authz,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+SearchPath, h.search)
+	mux.HandleFunc("POST "+PublishPath, h.publish)
+
+	return mux
+}
+
+// Start listens on the server address and serves requests in the background.
+func (s *Server) Start() error {
+	listen, err := net.Listen("tcp", s.server.Addr) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	if s.server.TLSConfig != nil {
+		listen = tls.NewListener(listen, s.server.TLSConfig)
+	}
+
+	go func() {
+		logger.Info("Gateway server starting", "address", s.server.Addr, "tls", s.server.TLSConfig != nil)
+
+		if err := s.server.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Gateway server failed", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the server.
+func (s *Server) Stop(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to stop gateway server: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeRouting struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	results   []*routingv1.SearchResponse
+	searchErr error
+	published *routingv1.PublishRequest
+}
+
+func (f *fakeRouting) Search(req *routingv1.SearchRequest, srv routingv1.RoutingService_SearchServer) error {
+	if len(req.GetQueries()) == 0 {
+		return status.Error(codes.InvalidArgument, "no queries")
+	}
+
+	for _, result := range f.results {
+		if err := srv.Send(result); err != nil {
+			return err
+		}
+	}
+
+	return f.searchErr
+}
+
+func (f *fakeRouting) Publish(_ context.Context, req *routingv1.PublishRequest) (*emptypb.Empty, error) {
+	f.published = req
+
+	return &emptypb.Empty{}, nil
+}
+
+type denyAll struct{}
+
+func (denyAll) Authorize(_ context.Context, apiMethod string) error {
+	return status.Error(codes.PermissionDenied, "not allowed to access "+apiMethod)
+}
+
+const searchBody = `{"queries":[{"type":"RECORD_QUERY_TYPE_SKILL","value":"AI"}]}`
+
+func serve(t *testing.T, h http.Handler, path, body, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, path, strings.NewReader(body))
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func searchResults() []*routingv1.SearchResponse {
+	return []*routingv1.SearchResponse{
+		{MatchScore: 1, MatchQueries: []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}}},
+		{MatchScore: 1},
+	}
+}
+
+func TestSearch_NDJSON(t *testing.T) {
+	h := Handler(&fakeRouting{results: searchResults()}, nil, nil)
+
+	rec := serve(t, h, SearchPath, searchBody, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, contentTypeNDJSON, rec.Header().Get("Content-Type"))
+
+	var count int
+
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		resp := &routingv1.SearchResponse{}
+		require.NoError(t, protojson.Unmarshal(scanner.Bytes(), resp))
+		assert.Equal(t, uint32(1), resp.GetMatchScore())
+
+		count++
+	}
+
+	assert.Equal(t, 2, count)
+}
+
+func TestSearch_SSE(t *testing.T) {
+	h := Handler(&fakeRouting{results: searchResults(), searchErr: status.Error(codes.Unavailable, "peer gone")}, nil, nil)
+
+	rec := serve(t, h, SearchPath, searchBody, contentTypeSSE)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, contentTypeSSE, rec.Header().Get("Content-Type"))
+
+	events := strings.Split(strings.TrimSpace(rec.Body.String()), "\n\n")
+	require.Len(t, events, 3)
+	assert.True(t, strings.HasPrefix(events[0], "data: {"))
+
+	// Errors after the first result are reported as the last event
+	assert.True(t, strings.HasPrefix(events[2], "event: error\ndata: {"))
+	assert.Contains(t, events[2], "peer gone")
+}
+
+func TestSearch_Errors(t *testing.T) {
+	h := Handler(&fakeRouting{}, nil, nil)
+
+	t.Run("invalid body", func(t *testing.T) {
+		rec := serve(t, h, SearchPath, `{"queries":`, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, contentTypeJSON, rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("controller error before results", func(t *testing.T) {
+		rec := serve(t, h, SearchPath, `{}`, "")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "no queries")
+	})
+
+	t.Run("empty results", func(t *testing.T) {
+		rec := serve(t, h, SearchPath, searchBody, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, SearchPath, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
+
+func TestPublish(t *testing.T) {
+	routing := &fakeRouting{}
+	h := Handler(routing, nil, nil)
+
+	rec := serve(t, h, PublishPath, `{"recordRefs":{"refs":[{"cid":"bafy-test"}]},"ttl":"3600s"}`, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{}`, rec.Body.String())
+
+	require.NotNil(t, routing.published)
+	assert.Equal(t, "bafy-test", routing.published.GetRecordRefs().GetRefs()[0].GetCid())
+	assert.Equal(t, int64(3600), routing.published.GetTtl().GetSeconds())
+}
+
+func TestAuthorization(t *testing.T) {
+	routing := &fakeRouting{results: searchResults()}
+	h := Handler(routing, nil, denyAll{})
+
+	rec := serve(t, h, SearchPath, searchBody, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), routingv1.RoutingService_Search_FullMethodName)
+
+	rec = serve(t, h, PublishPath, `{}`, "")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Nil(t, routing.published)
+}
@@ -0,0 +1,287 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxRequestSize limits the size of request bodies.
+const maxRequestSize = 1 << 20
+
+const (
+	contentTypeJSON   = "application/json"
+	contentTypeNDJSON = "application/x-ndjson"
+	contentTypeSSE    = "text/event-stream"
+)
+
+type handler struct {
+	routing routingv1.RoutingServiceServer
+	authn   Authenticator
+	authz   Authorizer
+}
+
+func (h *handler) search(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.authorize(r, routingv1.RoutingService_Search_FullMethodName)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	req := &routingv1.SearchRequest{}
+	if err := decodeRequest(w, r, req); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	stream := &searchStream{
+		ctx: ctx,
+		w:   w,
+		sse: strings.Contains(r.Header.Get("Accept"), contentTypeSSE),
+	}
+
+	if err := h.routing.Search(req, stream); err != nil {
+		// Errors can only be reported in the status before the first result
+		if !stream.started {
+			writeError(w, err)
+
+			return
+		}
+
+		logger.Warn("Search stream failed", "error", err)
+		stream.writeError(err)
+	}
+
+	// Send the headers of empty result streams
+	stream.start()
+}
+
+func (h *handler) publish(w http.ResponseWriter, r *http.Request) {
+	ctx, err := h.authorize(r, routingv1.RoutingService_Publish_FullMethodName)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	req := &routingv1.PublishRequest{}
+	if err := decodeRequest(w, r, req); err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	resp, err := h.routing.Publish(ctx, req)
+	if err != nil {
+		writeError(w, err)
+
+		return
+	}
+
+	writeMessage(w, http.StatusOK, resp)
+}
+
+// authorize authenticates the request and authorizes the caller to call the gRPC method it maps to.
+// It returns the context to call the controller with.
+func (h *handler) authorize(r *http.Request, apiMethod string) (context.Context, error) {
+	ctx := r.Context()
+
+	if h.authn != nil {
+		var err error
+
+		ctx, err = h.authn.AuthenticateHTTP(r)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	if h.authz != nil {
+		if err := h.authz.Authorize(ctx, apiMethod); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return ctx, nil
+}
+
+// decodeRequest reads the JSON body of the request into msg.
+func decodeRequest(w http.ResponseWriter, r *http.Request, msg proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request body: %v", err)
+	}
+
+	return nil
+}
+
+// searchStream adapts an HTTP response to the Search server stream of the controller.
+// Results are written as newline-delimited JSON, or as server-sent events.
+//
+//nolint:containedctx // The context of the stream is that of the request
+type searchStream struct {
+	ctx     context.Context
+	w       http.ResponseWriter
+	sse     bool
+	started bool
+}
+
+var _ routingv1.RoutingService_SearchServer = &searchStream{}
+
+func (s *searchStream) Send(resp *routingv1.SearchResponse) error {
+	data, err := protojson.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search response: %w", err)
+	}
+
+	return s.write("", data)
+}
+
+// writeError reports an error of a started stream as its last message.
+// Server-sent events carry it as an error event, newline-delimited JSON as an error object.
+func (s *searchStream) writeError(err error) {
+	data, marshalErr := protojson.Marshal(status.Convert(err).Proto())
+	if marshalErr != nil {
+		return
+	}
+
+	if s.sse {
+		_ = s.write("error", data)
+
+		return
+	}
+
+	_ = s.write("", []byte(`{"error":`+string(data)+`}`))
+}
+
+// start writes the response headers once.
+func (s *searchStream) start() {
+	if s.started {
+		return
+	}
+
+	s.started = true
+
+	if s.sse {
+		s.w.Header().Set("Content-Type", contentTypeSSE)
+		s.w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		s.w.Header().Set("Content-Type", contentTypeNDJSON)
+	}
+
+	s.w.WriteHeader(http.StatusOK)
+}
+
+// write writes a message and flushes it to the client.
+func (s *searchStream) write(event string, data []byte) error {
+	s.start()
+
+	var err error
+
+	switch {
+	case s.sse && event != "":
+		_, err = fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, data)
+	case s.sse:
+		_, err = fmt.Fprintf(s.w, "data: %s\n\n", data)
+	default:
+		_, err = fmt.Fprintf(s.w, "%s\n", data)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to write search response: %w", err)
+	}
+
+	if err := http.NewResponseController(s.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("failed to flush search response: %w", err)
+	}
+
+	return nil
+}
+
+func (s *searchStream) Context() context.Context { return s.ctx }
+
+func (s *searchStream) SetHeader(metadata.MD) error { return nil }
+
+func (s *searchStream) SendHeader(metadata.MD) error { return nil }
+
+func (s *searchStream) SetTrailer(metadata.MD) {}
+
+func (s *searchStream) SendMsg(m any) error {
+	resp, ok := m.(*routingv1.SearchResponse)
+	if !ok {
+		return status.Errorf(codes.Internal, "unexpected message type %T", m)
+	}
+
+	return s.Send(resp)
+}
+
+func (s *searchStream) RecvMsg(any) error { return io.EOF }
+
+// writeMessage writes a JSON response.
+func writeMessage(w http.ResponseWriter, code int, msg proto.Message) {
+	data, err := protojson.Marshal(msg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+// writeError writes an error as a JSON google.rpc.Status with the HTTP status matching its gRPC code.
+func writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+
+	writeMessage(w, httpStatus(st.Code()), st.Proto())
+}
+
+// httpStatus maps gRPC status codes to HTTP status codes.
+func httpStatus(code codes.Code) int {
+	switch code { //nolint:exhaustive
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 //nolint:mnd // Client Closed Request
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
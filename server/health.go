@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthReportInterval is how often the statuses of the gRPC health service are updated.
+const HealthReportInterval = 5 * time.Second
+
+// Subsystems reported by /healthz, /readyz and the gRPC health service.
+const (
+	subsystemDHT       = "dht"
+	subsystemGossipSub = "gossipsub"
+	subsystemDatastore = "datastore"
+)
+
+// healthReport is the per-subsystem readiness served by /healthz and /readyz.
+type healthReport struct {
+	Status     string          `json:"status"`
+	Subsystems map[string]bool `json:"subsystems"`
+	types.RoutingReadiness
+}
+
+func newHealthReport(readiness types.RoutingReadiness) healthReport {
+	report := healthReport{
+		Status: "ready",
+		Subsystems: map[string]bool{
+			subsystemDHT:       readiness.DHTReady(),
+			subsystemGossipSub: readiness.GossipSubReady(),
+			subsystemDatastore: readiness.DatastoreWritable,
+		},
+		RoutingReadiness: readiness,
+	}
+
+	if !readiness.Ready() {
+		report.Status = "degraded"
+	}
+
+	return report
+}
+
+// serveHealth reports the readiness of each subsystem. As a liveness check,
+// it responds with 200 while the server runs, regardless of the subsystems.
+func (s Server) serveHealth(w http.ResponseWriter, _ *http.Request) {
+	writeHealthReport(w, newHealthReport(s.routing.Readiness()), http.StatusOK)
+}
+
+// serveReadiness reports the readiness of each subsystem. It responds with 503
+// until the DHT bootstrapped with enough peers, a GossipSub mesh formed and
+// the routing datastore is writable, and with 200 afterwards.
+func (s Server) serveReadiness(w http.ResponseWriter, _ *http.Request) {
+	report := newHealthReport(s.routing.Readiness())
+
+	code := http.StatusOK
+	if !report.Ready() {
+		code = http.StatusServiceUnavailable
+	}
+
+	writeHealthReport(w, report, code)
+}
+
+func writeHealthReport(w http.ResponseWriter, report healthReport, code int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// startHealthReporting periodically updates the gRPC health service with the
+// readiness of each subsystem, and of the server as a whole (empty service name).
+func (s Server) startHealthReporting(ctx context.Context) {
+	s.reportHealth()
+
+	go func() {
+		ticker := time.NewTicker(HealthReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.healthDone:
+				return
+			case <-ticker.C:
+				s.reportHealth()
+			}
+		}
+	}()
+}
+
+func (s Server) reportHealth() {
+	report := newHealthReport(s.routing.Readiness())
+
+	s.healthServer.SetServingStatus("", servingStatus(report.Ready()))
+
+	for subsystem, ready := range report.Subsystems {
+		s.healthServer.SetServingStatus(subsystem, servingStatus(ready))
+	}
+}
+
+func servingStatus(ready bool) healthpb.HealthCheckResponse_ServingStatus {
+	if ready {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
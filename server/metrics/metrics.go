@@ -0,0 +1,30 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the Prometheus metrics of the directory server
+// and serves them on the /metrics endpoint.
+//
+// All metrics are registered in a dedicated Registry rather than the global
+// default registerer, so that only directory and runtime metrics are exposed.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Namespace is the common prefix of all directory server metrics.
+const Namespace = "dir"
+
+// Registry holds all metrics exposed by the server.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
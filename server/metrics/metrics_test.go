@@ -0,0 +1,41 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServesRoutingMetrics(t *testing.T) {
+	AnnouncementsPublished.WithLabelValues(TransportDHT, ResultSuccess).Inc()
+	PullFallbacks.WithLabelValues(ResultFailure).Inc()
+	RemoteLabels.Set(42)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, Path, nil)
+	rec := httptest.NewRecorder()
+
+	Handler().ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(body), `dir_routing_announcements_published_total{result="success",transport="dht"}`)
+	assert.Contains(t, string(body), `dir_routing_pull_fallbacks_total{result="failure"}`)
+	assert.Contains(t, string(body), "dir_routing_remote_labels 42")
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestResult(t *testing.T) {
+	assert.Equal(t, ResultSuccess, Result(nil))
+	assert.Equal(t, ResultFailure, Result(errors.New("boom")))
+}
@@ -0,0 +1,410 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const routingSubsystem = "routing"
+
+// Label values of the routing metrics.
+const (
+	TransportDHT       = "dht"
+	TransportGossipSub = "gossipsub"
+	TransportLabelSync = "label_sync"
+	TransportRPC       = "rpc"
+
+	ResultSuccess   = "success"
+	ResultFailure   = "failure"
+	ResultMismatch  = "mismatch"
+	ResultDropped   = "dropped"
+	ResultUnknown   = "unknown"
+	ResultSatisfied = "satisfied"
+	ResultPresent   = "present"
+	ResultMissing   = "missing"
+	ResultHit       = "hit"
+	ResultMiss      = "miss"
+	ResultNegative  = "negative_hit"
+	ResultLimited   = "limited"
+	ResultBanned    = "banned"
+	ResultQuota     = "quota"
+	ResultQueried   = "queried"
+	ResultSkipped   = "skipped"
+	ResultDelivered = "delivered"
+	ResultDuplicate = "duplicate"
+
+	RejectInvalid   = "invalid"
+	RejectNamespace = "namespace"
+	RejectSignature = "signature"
+	RejectRevoked   = "revoked"
+	RejectReplayed  = "replayed"
+	RejectStale     = "stale"
+	RejectExpired   = "expired"
+	RejectTenant    = "tenant"
+	RejectRetired   = "retired"
+
+	CleanupStaleLabel        = "stale_label"
+	CleanupOrphanedRecord    = "orphaned_record"
+	CleanupExpiredRevocation = "expired_revocation"
+	CleanupExpiredContinuity = "expired_continuity"
+	CleanupExpiredRecord     = "expired_record"
+	CleanupEvictedLabel      = "evicted_label"
+	CleanupUnavailableLabel  = "unavailable_label"
+	CleanupSupersededLabel   = "superseded_label"
+	CleanupUnreachableLabel  = "unreachable_label"
+	CleanupCollectedRecord   = "collected_record"
+	CleanupRemovedLabel      = "removed_label"
+
+	TaskRepublish = "republish"
+	TaskCleanup   = "cleanup"
+	TaskCompact   = "compact"
+	TaskReplicate = "replicate"
+	TaskRecordGC  = "record_gc"
+
+	OperationProvide          = "provide"
+	OperationPull             = "pull"
+	OperationGossipSubPublish = "gossipsub_publish"
+	OperationPublish          = "publish"
+)
+
+var (
+	// AnnouncementsPublished counts announcements of local records by transport and result.
+	AnnouncementsPublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announcements_published_total",
+		Help:      "Announcements of local records published to the network.",
+	}, []string{"transport", "result"})
+
+	// AnnouncementsReceived counts announcements received from remote peers by transport.
+	AnnouncementsReceived = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announcements_received_total",
+		Help:      "Announcements received from remote peers.",
+	}, []string{"transport"})
+
+	// AnnouncementsRejected counts received announcements that were dropped, by transport and reason.
+	AnnouncementsRejected = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announcements_rejected_total",
+		Help:      "Received announcements dropped without caching their labels.",
+	}, []string{"transport", "reason"})
+
+	// AnnouncementVerifications counts verifications of local record announcements by transport
+	// and result: success if a checked peer resolved the record, failure if none did, and
+	// unknown if no peer could be checked.
+	AnnouncementVerifications = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announcement_verifications_total",
+		Help:      "Verifications that local records are resolvable by other peers.",
+	}, []string{"transport", "result"})
+
+	// ReplicationChecks counts replication policy checks of remote records by result: satisfied
+	// if the record had enough providers, success if this peer replicated it, and failure otherwise.
+	ReplicationChecks = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "replication_checks_total",
+		Help:      "Replication policy checks of remote records.",
+	}, []string{"result"})
+
+	// Prefetches counts search results prefetched into the local store by result: success if the
+	// record was prefetched, present if it already was stored, dropped if the prefetch queue was
+	// full, limited if the record exceeds the prefetch quota, and failure otherwise.
+	Prefetches = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "prefetches_total",
+		Help:      "Search results prefetched into the local store.",
+	}, []string{"result"})
+
+	// PulledRecordsRetained counts records pulled by the DHT+Pull fallback and retained in the
+	// local store by result: success if the record was retained, present if it already was
+	// stored, limited if the record exceeds the retention budget, and failure otherwise.
+	PulledRecordsRetained = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "pulled_records_retained_total",
+		Help:      "Records pulled by the DHT+Pull fallback retained in the local store.",
+	}, []string{"result"})
+
+	// LiveSearchPeers counts the peers a live search fans out to by result: queried, or
+	// skipped if their label digest cannot contain enough of the queried labels.
+	LiveSearchPeers = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "live_search_peers_total",
+		Help:      "Peers selected for live searches by their label digests.",
+	}, []string{"result"})
+
+	// ProviderLookups counts DHT provider lookups by result: hit or negative_hit if cached
+	// providers or a cached lookup without providers were reused, and miss if the DHT was queried.
+	ProviderLookups = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "provider_lookups_total",
+		Help:      "DHT provider lookups of records.",
+	}, []string{"result"})
+
+	// SearchCacheLookups counts search result cache lookups of first search pages by result:
+	// hit if cached results were returned, and miss if the label cache was searched.
+	SearchCacheLookups = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "search_cache_lookups_total",
+		Help:      "Search result cache lookups.",
+	}, []string{"result"})
+
+	// EventsPublished counts routing events published to message queues by publisher and result.
+	EventsPublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "events_published_total",
+		Help:      "Routing events published to external message queues.",
+	}, []string{"publisher", "result"})
+
+	// AnnouncementSinkPublished counts announcements and retractions republished to
+	// announcement sinks by sink, kind (announced, retracted) and result.
+	AnnouncementSinkPublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announcement_sink_published_total",
+		Help:      "Announcements and retractions republished to external message buses.",
+	}, []string{"sink", "kind", "result"})
+
+	// PullFallbacks counts records pulled from remote peers because their labels
+	// were not received via GossipSub, by result.
+	PullFallbacks = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "pull_fallbacks_total",
+		Help:      "Records pulled from remote peers to discover their labels (DHT+Pull fallback).",
+	}, []string{"result"})
+
+	// NotificationsDeadLettered counts DHT provider notifications moved to the dead-letter
+	// bucket after their pulls repeatedly failed.
+	NotificationsDeadLettered = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "notifications_dead_lettered_total",
+		Help:      "DHT provider notifications dead-lettered after repeated pull failures.",
+	})
+
+	// IdentityRotations counts applied continuity records of peer identity rotations.
+	IdentityRotations = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "identity_rotations_total",
+		Help:      "Peer identity rotations whose cached labels and addresses were migrated.",
+	})
+
+	// AnnouncedLabelRemovals counts labels of republished local records announced as removed.
+	AnnouncedLabelRemovals = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announced_label_removals_total",
+		Help:      "Labels of republished local records announced as removed.",
+	})
+
+	// NotifyQueuePending is the number of DHT provider notifications waiting to be processed.
+	NotifyQueuePending = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "notify_queue_pending",
+		Help:      "DHT provider notifications waiting to be processed.",
+	})
+
+	// NotifyQueueDeadLetters is the number of dead-lettered DHT provider notifications.
+	NotifyQueueDeadLetters = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "notify_queue_dead_letters",
+		Help:      "DHT provider notifications in the dead-letter bucket.",
+	})
+
+	// LivenessProbes counts liveness probes of peers with cached labels by result:
+	// success if the peer was connected or could be dialed, failure otherwise.
+	LivenessProbes = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "liveness_probes_total",
+		Help:      "Liveness probes of peers with cached labels.",
+	}, []string{"result"})
+
+	// RecordGCDiskUsage is the fraction of the filesystem of the local store in use,
+	// as last checked by the garbage collection of local records.
+	RecordGCDiskUsage = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "record_gc_disk_usage_ratio",
+		Help:      "Fraction of the filesystem of the local store in use, as last checked by record garbage collection.",
+	})
+
+	// StalePeers is the number of peers whose cached labels are excluded from search results
+	// because they have been unreachable for longer than the configured period.
+	StalePeers = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "stale_peers",
+		Help:      "Peers with cached labels unreachable for longer than the stale period.",
+	})
+
+	// PullDuration observes the duration of fallback pulls.
+	PullDuration = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "pull_duration_seconds",
+		Help:      "Duration of record pulls from remote peers.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12), //nolint:mnd
+	})
+
+	// AnnouncementClockSkew observes the difference between the claimed and receive
+	// times of announcements, which is mostly the clock skew of their publishers.
+	AnnouncementClockSkew = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "announcement_clock_skew_seconds",
+		Help:      "Absolute difference between the claimed and receive times of GossipSub announcements.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8), //nolint:mnd
+	})
+
+	// RemoteLabels is the number of remote labels in the label cache.
+	RemoteLabels = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "remote_labels",
+		Help:      "Labels of remote records in the label cache.",
+	})
+
+	// DHTRoutingTableSize is the number of peers in the DHT routing table.
+	DHTRoutingTableSize = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "dht_routing_table_peers",
+		Help:      "Peers in the DHT routing table.",
+	})
+
+	// DatastoreDegraded is 1 while the routing datastore is unavailable and writes are buffered in memory.
+	DatastoreDegraded = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "datastore_degraded",
+		Help:      "Whether the routing datastore is unavailable (1) or not (0).",
+	})
+
+	// DatastoreBufferedWrites is the number of writes buffered while the routing datastore is unavailable.
+	DatastoreBufferedWrites = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "datastore_buffered_writes",
+		Help:      "Writes buffered in memory while the routing datastore is unavailable.",
+	})
+
+	// GossipSubTopicPeers is the number of peers subscribed to each GossipSub topic.
+	GossipSubTopicPeers = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "gossipsub_topic_peers",
+		Help:      "Peers subscribed to a joined GossipSub topic.",
+	}, []string{"topic"})
+
+	// GossipSubMeshPeers is the number of peers in the mesh of each GossipSub topic.
+	GossipSubMeshPeers = factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "gossipsub_mesh_peers",
+		Help:      "Peers in the mesh of a joined GossipSub topic.",
+	}, []string{"topic"})
+
+	// GossipSubMessages counts received GossipSub messages by topic and result: delivered if
+	// received for the first time, duplicate if received again, and invalid if rejected.
+	GossipSubMessages = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "gossipsub_messages_total",
+		Help:      "GossipSub messages received on joined topics.",
+	}, []string{"topic", "result"})
+
+	// GossipSubPropagationLatency observes the time between publishing a label announcement and
+	// its receipt by another peer, as acknowledged by that peer with propagation acks enabled.
+	GossipSubPropagationLatency = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "gossipsub_propagation_latency_seconds",
+		Help:      "Time between publishing GossipSub label announcements and their receipt by other peers.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12), //nolint:mnd
+	})
+
+	// CleanupRemoved counts entries removed by the cleanup tasks, by kind.
+	CleanupRemoved = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "cleanup_removed_total",
+		Help:      "Entries removed from the routing datastore by cleanup tasks.",
+	}, []string{"kind"})
+
+	// CacheVerifications counts cached remote records verified against their providers, by result.
+	CacheVerifications = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "cache_verifications_total",
+		Help:      "Cached remote records verified against their providers.",
+	}, []string{"result"})
+
+	// RateLimited counts inbound announcements and RPC requests refused by the per-peer
+	// rate limits, by transport and result: limited if the peer exceeded its limit,
+	// banned if the peer is temporarily banned, and quota if the peer exceeded its
+	// hourly pull bandwidth quota.
+	RateLimited = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "rate_limited_total",
+		Help:      "Inbound announcements and RPC requests refused by per-peer rate limits.",
+	}, []string{"transport", "result"})
+
+	// PullBytesServed counts the bytes of record content served to remote peers by Pull.
+	PullBytesServed = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "pull_bytes_served_total",
+		Help:      "Bytes of record content served to remote peers by Pull.",
+	})
+
+	// RecordsRepublished counts local records republished by the republish task, by result.
+	RecordsRepublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "records_republished_total",
+		Help:      "Local records republished to the network.",
+	}, []string{"result"})
+
+	// TaskDuration observes the duration of background routing tasks.
+	TaskDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "task_duration_seconds",
+		Help:      "Duration of background republish, cleanup, compaction, replication and record garbage collection runs.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 4, 8), //nolint:mnd
+	}, []string{"task"})
+
+	// OperationTimeouts counts remote operations aborted by their configured deadline by operation:
+	// provide, pull, gossipsub_publish, or publish if the overall publish budget was exhausted.
+	OperationTimeouts = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Subsystem: routingSubsystem,
+		Name:      "operation_timeouts_total",
+		Help:      "Remote operations aborted by their configured deadline.",
+	}, []string{"operation"})
+)
+
+// Result returns ResultSuccess for nil errors and ResultFailure otherwise.
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+
+	return ResultSuccess
+}
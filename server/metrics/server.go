@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the HTTP path metrics are served on.
+const Path = "/metrics"
+
+const readHeaderTimeout = 10 * time.Second
+
+var logger = logging.Logger("metrics")
+
+// Server serves the metrics of the Registry over HTTP.
+type Server struct {
+	server *http.Server
+}
+
+// NewServer creates a metrics server listening on the given address.
+func NewServer(address string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler())
+
+	return &Server{
+		server: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Handler returns the HTTP handler exposing the metrics of the Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Start listens on the server address and serves metrics in the background.
+func (s *Server) Start() error {
+	listen, err := net.Listen("tcp", s.server.Addr) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	go func() {
+		logger.Info("Metrics server starting", "address", s.server.Addr, "path", Path)
+
+		if err := s.server.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Metrics server failed", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the server.
+func (s *Server) Stop(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to stop metrics server: %w", err)
+	}
+
+	return nil
+}
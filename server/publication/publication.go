@@ -46,6 +46,24 @@ func (s *Service) CreatePublication(_ context.Context, req *routingv1.PublishReq
 	return s.db.CreatePublication(req) //nolint:wrapcheck
 }
 
+// Publish runs a publication synchronously instead of queuing it for the workers,
+// reporting the progress of each record through the publish stages.
+func (s *Service) Publish(ctx context.Context, req *routingv1.PublishRequest, progress func(*routingv1.PublishProgress)) error {
+	worker := &Worker{db: s.db, store: s.store, routing: s.routing}
+
+	cids, err := worker.getCIDsFromRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	opts := publishOptions(req)
+	opts.Progress = progress
+
+	worker.announceBatch(ctx, "", cids, opts)
+
+	return nil
+}
+
 // Start begins the publication service operations.
 func (s *Service) Start(ctx context.Context) error {
 	logger.Info("Starting publication service", "workers", s.config.WorkerCount, "interval", s.config.SchedulerInterval)
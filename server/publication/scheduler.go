@@ -5,6 +5,7 @@ package publication
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
@@ -65,6 +66,9 @@ func (s *Scheduler) processPendingPublications(ctx context.Context) {
 		return
 	}
 
+	// Dispatch high-priority publications first so they are announced immediately
+	sortByPriority(publications)
+
 	for _, publication := range publications {
 		select {
 		case <-ctx.Done():
@@ -87,3 +91,26 @@ func (s *Scheduler) processPendingPublications(ctx context.Context) {
 		}
 	}
 }
+
+// sortByPriority orders publications by announcement priority (high first),
+// keeping the original order for publications of the same priority.
+func sortByPriority(publications []types.PublicationObject) {
+	sort.SliceStable(publications, func(i, j int) bool {
+		return priorityRank(publications[i].GetRequest().GetPriority()) < priorityRank(publications[j].GetRequest().GetPriority())
+	})
+}
+
+// priorityRank returns the dispatch order of a priority (lower is dispatched first).
+func priorityRank(priority routingv1.AnnouncementPriority) int {
+	switch priority {
+	case routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH:
+		return 0
+	case routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW:
+		return 2 //nolint:mnd
+	case routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED,
+		routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL:
+		return 1
+	default:
+		return 1
+	}
+}
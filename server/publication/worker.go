@@ -15,6 +15,7 @@ import (
 	publypes "github.com/agntcy/dir/server/publication/types"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Worker processes publication requests from the work queue.
@@ -100,18 +101,8 @@ func (w *Worker) processPublication(ctx context.Context, workItem publypes.WorkI
 		return
 	}
 
-	// Announce each CID to the DHT
-	successCount := 0
-
-	for _, cid := range cids {
-		if err := w.announceToDHT(timeoutCtx, cid); err != nil {
-			logger.Error("Failed to announce CID to DHT", "publication_id", workItem.PublicationID, "cid", cid, "error", err)
-		} else {
-			successCount++
-
-			logger.Debug("Successfully announced CID to DHT", "publication_id", workItem.PublicationID, "cid", cid)
-		}
-	}
+	// Pull records from the store and announce them to the network in one batch
+	successCount := w.announceBatch(timeoutCtx, workItem.PublicationID, cids, publishOptions(request))
 
 	logger.Info("Publication processing completed", "worker_id", w.id, "publication_id", workItem.PublicationID,
 		"total_cids", len(cids), "successful_announcements", successCount)
@@ -124,6 +115,20 @@ func (w *Worker) processPublication(ctx context.Context, workItem publypes.WorkI
 	}
 }
 
+// publishOptions returns the options the records of a publication are published with.
+func publishOptions(request *routingv1.PublishRequest) types.PublishOptions {
+	return types.PublishOptions{
+		Priority:    request.GetPriority(),
+		TTL:         request.GetTtl().AsDuration(),
+		Supersedes:  request.GetSupersedes(),
+		AccessGated: request.GetAccessGated(),
+		Tenant:      request.GetTenantId(),
+		Publisher:   request.GetPublisher(),
+
+		LabelPolicies: types.LabelPoliciesFromProto(request.GetLabelPolicies()),
+	}
+}
+
 // getCIDsFromRequest extracts CIDs from the publication request based on its type.
 func (w *Worker) getCIDsFromRequest(_ context.Context, request *routingv1.PublishRequest) ([]string, error) {
 	switch req := request.GetRequest().(type) {
@@ -151,29 +156,55 @@ func (w *Worker) getCIDsFromRequest(_ context.Context, request *routingv1.Publis
 	}
 }
 
-// announceToDHT announces a single CID to the DHT.
-func (w *Worker) announceToDHT(ctx context.Context, cid string) error {
-	// Create a RecordRef for the CID
-	recordRef := &corev1.RecordRef{
-		Cid: cid,
+// announceBatch pulls the records for the given CIDs and publishes them to the
+// network in a single batch. Returns the number of successfully announced CIDs.
+func (w *Worker) announceBatch(ctx context.Context, publicationID string, cids []string, opts types.PublishOptions) int {
+	records := make([]types.Record, 0, len(cids))
+	recordCIDs := make([]string, 0, len(cids))
+
+	for _, cid := range cids {
+		// Pull the record from the store
+		record, err := w.store.Pull(ctx, &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			logger.Error("Failed to pull record from store", "publication_id", publicationID, "cid", cid, "error", err)
+
+			if opts.Progress != nil {
+				opts.Progress(&routingv1.PublishProgress{
+					Cid:   cid,
+					Stage: routingv1.PublishStage_PUBLISH_STAGE_FAILED,
+					Time:  timestamppb.Now(),
+					Error: fmt.Sprintf("failed to pull record from store: %v", err),
+				})
+			}
+
+			continue
+		}
+
+		// Wrap record with adapter for interface-based publishing
+		records = append(records, adapters.NewRecordAdapter(record))
+		recordCIDs = append(recordCIDs, cid)
 	}
 
-	// Pull the record from the store
-	record, err := w.store.Pull(ctx, recordRef)
-	if err != nil {
-		return fmt.Errorf("failed to pull record from store: %w", err)
+	if len(records) == 0 {
+		return 0
 	}
 
-	// Wrap record with adapter for interface-based publishing
-	adapter := adapters.NewRecordAdapter(record)
+	// Publish the records to the network
+	successCount := 0
 
-	// Publish the record to the network
-	err = w.routing.Publish(ctx, adapter)
-	if err != nil {
-		return fmt.Errorf("failed to publish record to network: %w", err)
+	for i, err := range w.routing.PublishBatch(ctx, records, opts) {
+		if err != nil {
+			logger.Error("Failed to announce CID to DHT", "publication_id", publicationID, "cid", recordCIDs[i], "error", err)
+
+			continue
+		}
+
+		successCount++
+
+		logger.Debug("Successfully announced CID to DHT", "publication_id", publicationID, "cid", recordCIDs[i])
 	}
 
-	return nil
+	return successCount
 }
 
 // markPublicationCompleted marks a publication as completed.
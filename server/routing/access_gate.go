@@ -0,0 +1,186 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/accesstoken"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recordAccessGated reports whether a local record is access-gated.
+// It is included in the record's GossipSub announcements.
+func (r *routeRemote) recordAccessGated(cid string) bool {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return false
+	}
+
+	return decodeLocalRecordMetadata(value).AccessGated
+}
+
+// newGatedAccessAuthorizer authorizes the given peers to pull access-gated records,
+// as well as peers sending an unexpired access token issued by self for the record.
+// Invalid peer IDs are logged and ignored.
+func newGatedAccessAuthorizer(peerIDs []string, self peer.ID) rpc.GatedAccessAuthorizer {
+	allowed := make(map[peer.ID]struct{}, len(peerIDs))
+
+	for _, peerIDStr := range peerIDs {
+		peerID, err := peer.Decode(peerIDStr)
+		if err != nil {
+			remoteLogger.Warn("Ignoring invalid gated access peer", "peer", peerIDStr, "error", err)
+
+			continue
+		}
+
+		allowed[peerID] = struct{}{}
+	}
+
+	return func(reader peer.ID, cid, encodedToken string) bool {
+		if _, ok := allowed[reader]; ok {
+			return true
+		}
+
+		if encodedToken == "" {
+			return false
+		}
+
+		token, err := accesstoken.Decode(encodedToken)
+		if err != nil {
+			remoteLogger.Debug("Rejecting invalid access token", "peer", reader, "error", err)
+
+			return false
+		}
+
+		return token.Authorizes(self, reader, cid, time.Now())
+	}
+}
+
+// GrantAccess issues an access token authorizing a remote peer to pull the access-gated
+// records of this peer, or only the requested one, until the token expires.
+func (r *routeRemote) GrantAccess(_ context.Context, req *routingv1.GrantAccessRequest) (*routingv1.GrantAccessResponse, error) {
+	reader, err := peer.Decode(req.GetPeerId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid peer ID %q: %v", req.GetPeerId(), err)
+	}
+
+	if req.GetCid() != "" {
+		if _, err := cid.Decode(req.GetCid()); err != nil {
+			return nil, routingerr.ErrCIDInvalid.Errorf("invalid CID %q: %w", req.GetCid(), err)
+		}
+	}
+
+	ttl := GrantAccessTTL
+	if req.GetTtl() != nil {
+		ttl = req.GetTtl().AsDuration()
+	}
+
+	if ttl <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid TTL %s, must be positive", ttl)
+	}
+
+	host := r.server.Host()
+
+	token := accesstoken.New(reader, req.GetCid(), ttl)
+	if err := token.Sign(host.Peerstore().PrivKey(host.ID())); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to sign access token: %v", err)
+	}
+
+	encoded, err := token.Encode()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode access token: %v", err)
+	}
+
+	remoteLogger.Info("Granted access to gated records", "peer", reader, "cid", req.GetCid(), "expiresAt", token.ExpiresAt)
+
+	return &routingv1.GrantAccessResponse{
+		Token:     encoded,
+		ExpiresAt: timestamppb.New(token.ExpiresAt),
+	}, nil
+}
+
+// newAccessTokenProvider sends the given access tokens with pulls from the peers that issued them.
+// Invalid tokens and tokens issued to other peers are logged and ignored.
+func newAccessTokenProvider(encodedTokens []string, self peer.ID) rpc.AccessTokenProvider {
+	type issuedToken struct {
+		token   *accesstoken.Token
+		encoded string
+	}
+
+	tokens := make(map[peer.ID][]issuedToken)
+
+	for i, encoded := range encodedTokens {
+		token, err := accesstoken.Decode(encoded)
+		if err != nil {
+			remoteLogger.Warn("Ignoring invalid gated access token", "index", i, "error", err)
+
+			continue
+		}
+
+		if token.Reader != self.String() {
+			remoteLogger.Warn("Ignoring gated access token issued to another peer", "index", i, "reader", token.Reader)
+
+			continue
+		}
+
+		issuer, err := token.IssuerID()
+		if err != nil {
+			continue
+		}
+
+		tokens[issuer] = append(tokens[issuer], issuedToken{token: token, encoded: encoded})
+	}
+
+	return func(issuer peer.ID, cid string) string {
+		now := time.Now()
+
+		for _, issued := range tokens[issuer] {
+			if issued.token.Authorizes(issuer, self, cid, now) {
+				return issued.encoded
+			}
+		}
+
+		return ""
+	}
+}
+
+// pulledRecordLabels returns the labels of a pulled record, or the labels announced for
+// an access-gated record that was not served. Announced labels beyond the GossipSub limit
+// are rejected like oversized announcements.
+func pulledRecordLabels(record *corev1.Record, metadata rpc.RecordMetadata) []types.Label {
+	if record != nil {
+		return types.GetLabelsFromRecord(adapters.NewRecordAdapter(record))
+	}
+
+	if len(metadata.Labels) > pubsub.MaxLabelsPerAnnouncement {
+		return nil
+	}
+
+	return remoteLabels(metadata.Labels)
+}
+
+// labelAccessGated reports whether a cached label entry belongs to an access-gated record.
+func labelAccessGated(value []byte) bool {
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return false
+	}
+
+	return metadata.AccessGated
+}
@@ -0,0 +1,104 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/accesstoken"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatedAccessAuthorizer(t *testing.T) {
+	newPeerID := func() peer.ID {
+		_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+
+		peerID, err := peer.IDFromPublicKey(pub)
+		require.NoError(t, err)
+
+		return peerID
+	}
+
+	allowed, other := newPeerID(), newPeerID()
+
+	authorize := newGatedAccessAuthorizer([]string{allowed.String(), "not-a-peer-id"}, "")
+	assert.True(t, authorize(allowed, testTokenCID, ""))
+	assert.False(t, authorize(other, testTokenCID, ""))
+
+	assert.False(t, newGatedAccessAuthorizer(nil, "")(allowed, testTokenCID, ""))
+}
+
+const testTokenCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+
+func TestGatedAccessAuthorizer_AccessToken(t *testing.T) {
+	newPeer := func() (crypto.PrivKey, peer.ID) {
+		key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+
+		peerID, err := peer.IDFromPrivateKey(key)
+		require.NoError(t, err)
+
+		return key, peerID
+	}
+
+	serverKey, server := newPeer()
+	_, reader := newPeer()
+	otherKey, other := newPeer()
+
+	token := accesstoken.New(reader, testTokenCID, time.Hour)
+	require.NoError(t, token.Sign(serverKey))
+
+	encoded, err := token.Encode()
+	require.NoError(t, err)
+
+	// Readers send the tokens issued by the serving peer
+	provide := newAccessTokenProvider([]string{encoded, "not-a-token"}, reader)
+	assert.Equal(t, encoded, provide(server, testTokenCID))
+	assert.Empty(t, provide(server, "bafkreiother"))
+	assert.Empty(t, provide(other, testTokenCID))
+
+	// Tokens issued to other peers are not sent
+	assert.Empty(t, newAccessTokenProvider([]string{encoded}, other)(server, testTokenCID))
+
+	// The serving peer authorizes the reader with the token
+	authorize := newGatedAccessAuthorizer(nil, server)
+	assert.True(t, authorize(reader, testTokenCID, encoded))
+	assert.False(t, authorize(other, testTokenCID, encoded))
+	assert.False(t, authorize(reader, "bafkreiother", encoded))
+	assert.False(t, authorize(reader, testTokenCID, "not-a-token"))
+
+	// Tokens issued by another peer are rejected
+	forged := accesstoken.New(reader, testTokenCID, time.Hour)
+	require.NoError(t, forged.Sign(otherKey))
+
+	forgedEncoded, err := forged.Encode()
+	require.NoError(t, err)
+	assert.False(t, authorize(reader, testTokenCID, forgedEncoded))
+}
+
+func TestPulledRecordLabels_AccessGated(t *testing.T) {
+	labels := pulledRecordLabels(nil, rpc.RecordMetadata{
+		AccessGated: true,
+		Labels:      []string{"/skills/AI", "/domains/research"},
+	})
+	assert.Equal(t, []types.Label{"/skills/AI", "/domains/research"}, labels)
+
+	// Oversized label sets are rejected like oversized announcements
+	tooMany := make([]string, pubsub.MaxLabelsPerAnnouncement+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("/skills/skill-%d", i)
+	}
+
+	assert.Empty(t, pulledRecordLabels(nil, rpc.RecordMetadata{AccessGated: true, Labels: tooMany}))
+}
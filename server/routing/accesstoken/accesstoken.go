@@ -0,0 +1,199 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package accesstoken implements capability tokens for pulling access-gated records.
+//
+// A token is issued by the peer serving access-gated records and signed with its
+// Ed25519 identity key. It authorizes a single reader peer to pull the peer's
+// access-gated records, optionally only one record, until it expires. Readers
+// send the token with their pull requests, so that the serving peer can authorize
+// them without configuring them in its peer allowlist.
+package accesstoken
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// SignatureDomain is prepended to the signed payload of access tokens.
+	// It prevents token signatures from being replayed as announcements or revocations.
+	SignatureDomain = "dir/access-tokens/v1/signature"
+
+	// MaxSize is the maximum size of a marshalled access token.
+	MaxSize = 2 * 1024 // 2KB
+)
+
+// Token authorizes a reader peer to pull access-gated records of the signing peer.
+//
+// Example wire format, before base64 encoding:
+//
+//	{
+//	  "reader": "12D3KooW...",
+//	  "cid": "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+//	  "expires_at": "2025-10-01T10:00:00Z",
+//	  "public_key": "CAESIB...",
+//	  "signature": "mE3s..."
+//	}
+type Token struct {
+	// Reader is the ID of the peer authorized to pull.
+	Reader string `json:"reader"`
+
+	// CID restricts the token to a single record, all access-gated records if empty.
+	CID string `json:"cid,omitempty"`
+
+	// ExpiresAt is when the token stops authorizing pulls.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// PublicKey is the marshalled libp2p public key of the issuing peer.
+	PublicKey []byte `json:"public_key"`
+
+	// Signature is the signature of SigningPayload() made with the
+	// issuing peer's identity key.
+	Signature []byte `json:"signature"`
+}
+
+// New creates an unsigned token authorizing the reader for the given duration.
+func New(reader peer.ID, recordCID string, ttl time.Duration) *Token {
+	return &Token{
+		Reader:    reader.String(),
+		CID:       recordCID,
+		ExpiresAt: time.Now().Add(ttl).UTC(),
+	}
+}
+
+// Validate checks that the token is well-formed.
+// The signature is checked separately by Verify.
+func (t *Token) Validate() error {
+	if _, err := peer.Decode(t.Reader); err != nil {
+		return fmt.Errorf("invalid reader %q: %w", t.Reader, err)
+	}
+
+	if t.CID != "" {
+		if _, err := cid.Decode(t.CID); err != nil {
+			return fmt.Errorf("invalid CID %q: %w", t.CID, err)
+		}
+	}
+
+	if t.ExpiresAt.IsZero() {
+		return errors.New("missing expiry")
+	}
+
+	return nil
+}
+
+// SigningPayload returns the canonical bytes covered by the token signature.
+//
+// Format: SignatureDomain \0 reader \0 CID \0 expiresAt(RFC3339Nano, UTC).
+func (t *Token) SigningPayload() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(SignatureDomain)
+	buf.WriteByte(0)
+	buf.WriteString(t.Reader)
+	buf.WriteByte(0)
+	buf.WriteString(t.CID)
+	buf.WriteByte(0)
+	buf.WriteString(t.ExpiresAt.UTC().Format(time.RFC3339Nano))
+
+	return buf.Bytes()
+}
+
+// Sign signs the token with the issuing peer's Ed25519 identity key.
+func (t *Token) Sign(key crypto.PrivKey) error {
+	publicKey, signature, err := signing.Sign(key, t.SigningPayload())
+	if err != nil {
+		return fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	t.PublicKey = publicKey
+	t.Signature = signature
+
+	return nil
+}
+
+// Verify checks the token signature against the embedded public key.
+func (t *Token) Verify() error {
+	return signing.Verify(t.PublicKey, t.Signature, t.SigningPayload()) //nolint:wrapcheck
+}
+
+// IssuerID returns the ID of the peer that signed the token.
+func (t *Token) IssuerID() (peer.ID, error) {
+	return signing.SignerID(t.PublicKey) //nolint:wrapcheck
+}
+
+// Authorizes reports whether the token, issued by issuer, authorizes reader to pull the record at now.
+func (t *Token) Authorizes(issuer, reader peer.ID, recordCID string, now time.Time) bool {
+	if t.Reader != reader.String() || (t.CID != "" && t.CID != recordCID) || !now.Before(t.ExpiresAt) {
+		return false
+	}
+
+	issuerID, err := t.IssuerID()
+
+	return err == nil && issuerID == issuer
+}
+
+// Marshal serializes the token to JSON.
+func (t *Token) Marshal() ([]byte, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal access token: %w", err)
+	}
+
+	if len(data) > MaxSize {
+		return nil, errors.New("access token exceeds maximum size")
+	}
+
+	return data, nil
+}
+
+// Unmarshal deserializes a token and checks that it is well-formed and validly signed.
+func Unmarshal(data []byte) (*Token, error) {
+	if len(data) > MaxSize {
+		return nil, errors.New("access token exceeds maximum size")
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal access token: %w", err)
+	}
+
+	if err := token.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid access token: %w", err)
+	}
+
+	if err := token.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid access token signature: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Encode returns the marshalled token as a URL-safe string, as handed to the reader.
+func (t *Token) Encode() (string, error) {
+	data, err := t.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode parses a token encoded by Encode.
+func Decode(encoded string) (*Token, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode access token: %w", err)
+	}
+
+	return Unmarshal(data)
+}
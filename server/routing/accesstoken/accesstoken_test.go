@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package accesstoken
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+
+func newTestPeer(t *testing.T) (crypto.PrivKey, peer.ID) {
+	t.Helper()
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	return key, id
+}
+
+func TestToken_EncodeAndAuthorize(t *testing.T) {
+	issuerKey, issuer := newTestPeer(t)
+	_, reader := newTestPeer(t)
+	_, other := newTestPeer(t)
+
+	token := New(reader, testCID, time.Hour)
+	require.NoError(t, token.Sign(issuerKey))
+
+	encoded, err := token.Encode()
+	require.NoError(t, err)
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.True(t, decoded.Authorizes(issuer, reader, testCID, now))
+
+	// Tokens only authorize the reader, for the record, at the issuing peer, until they expire
+	assert.False(t, decoded.Authorizes(issuer, other, testCID, now))
+	assert.False(t, decoded.Authorizes(issuer, reader, "bafkreiother", now))
+	assert.False(t, decoded.Authorizes(other, reader, testCID, now))
+	assert.False(t, decoded.Authorizes(issuer, reader, testCID, now.Add(2*time.Hour)))
+
+	// Tokens without a CID authorize all records
+	all := New(reader, "", time.Hour)
+	require.NoError(t, all.Sign(issuerKey))
+	assert.True(t, all.Authorizes(issuer, reader, "bafkreiother", now))
+}
+
+func TestToken_RejectsTampering(t *testing.T) {
+	issuerKey, _ := newTestPeer(t)
+	_, reader := newTestPeer(t)
+	_, other := newTestPeer(t)
+
+	token := New(reader, testCID, time.Hour)
+	require.NoError(t, token.Sign(issuerKey))
+
+	// Handing the token to another reader invalidates its signature
+	token.Reader = other.String()
+
+	data, err := token.Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(data)
+	assert.ErrorContains(t, err, "invalid access token signature")
+
+	_, err = Decode("not a token")
+	assert.Error(t, err)
+}
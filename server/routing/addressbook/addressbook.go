@@ -0,0 +1,422 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package addressbook maintains the multiaddrs of remote directory peers,
+// from which their Directory API addresses are resolved for search results.
+//
+// Addresses are learned from several sources that differ in how much they can
+// be trusted to be current, so every address carries the priority of the best
+// source it was seen from. Addresses are refreshed whenever they are seen again
+// and expire once they have not been seen within the TTL; a peer whose addresses
+// have all expired is removed from the book.
+//
+// Entries are persisted in the routing datastore under /peer_addrs/<peerID>.
+package addressbook
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/internal/p2p"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// Namespace is the datastore namespace of address book entries.
+	Namespace = "peer_addrs"
+
+	// MaxAddrsPerPeer bounds the number of addresses kept per peer.
+	// The addresses with the lowest priority and the oldest sightings are dropped first.
+	MaxAddrsPerPeer = 16
+)
+
+// Priority ranks the sources addresses are learned from.
+// Higher priorities are returned first.
+type Priority int
+
+const (
+	// PrioritySeedPeer is for addresses imported from the seed peer's cache.
+	PrioritySeedPeer Priority = iota
+
+	// PriorityPeerstore is for addresses found in the libp2p peerstore.
+	PriorityPeerstore
+
+	// PriorityAnnouncement is for addresses carried by DHT provider announcements.
+	PriorityAnnouncement
+
+	// PriorityIdentify is for addresses the peer advertised itself over an identified connection.
+	PriorityIdentify
+)
+
+// Address is a multiaddr of a peer with the priority of its best source.
+type Address struct {
+	Addr     string    `json:"addr"`
+	Priority Priority  `json:"priority"`
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Entry holds the addresses of a peer, ordered by priority and then recency.
+type Entry struct {
+	Addrs []Address `json:"addrs"`
+}
+
+// Multiaddrs returns the parsed addresses in order, skipping invalid ones.
+func (e *Entry) Multiaddrs() []ma.Multiaddr {
+	addrs := make([]ma.Multiaddr, 0, len(e.Addrs))
+
+	for _, addr := range e.Addrs {
+		maddr, err := ma.NewMultiaddr(addr.Addr)
+		if err != nil {
+			continue
+		}
+
+		addrs = append(addrs, maddr)
+	}
+
+	return addrs
+}
+
+// DirectoryAddresses returns the Directory API addresses (/dir/ components) in order.
+func (e *Entry) DirectoryAddresses() []string {
+	return DirectoryAddresses(e.Multiaddrs())
+}
+
+// DirectoryAddresses extracts the distinct Directory API addresses (/dir/ components)
+// of multiaddrs in order.
+func DirectoryAddresses(addrs []ma.Multiaddr) []string {
+	var dirAddrs []string
+
+	for _, maddr := range addrs {
+		value, err := maddr.ValueForProtocol(p2p.DirProtocolCode)
+		if err != nil || value == "" || slices.Contains(dirAddrs, value) {
+			continue
+		}
+
+		dirAddrs = append(dirAddrs, value)
+	}
+
+	return dirAddrs
+}
+
+// Zone returns the locality zone the peer advertised (/dir-zone/ component), empty if none.
+func (e *Entry) Zone() string {
+	return Zone(e.Multiaddrs())
+}
+
+// Zone extracts the first locality zone (/dir-zone/ component) of multiaddrs in order.
+func Zone(addrs []ma.Multiaddr) string {
+	for _, maddr := range addrs {
+		if value, err := maddr.ValueForProtocol(p2p.ZoneProtocolCode); err == nil && value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// merge adds the addresses seen at the given time with the given priority.
+func (e *Entry) merge(addrs []ma.Multiaddr, priority Priority, now time.Time) {
+	for _, maddr := range addrs {
+		addr := maddr.String()
+
+		idx := slices.IndexFunc(e.Addrs, func(a Address) bool { return a.Addr == addr })
+		if idx < 0 {
+			e.Addrs = append(e.Addrs, Address{Addr: addr, Priority: priority, LastSeen: now})
+
+			continue
+		}
+
+		e.Addrs[idx].Priority = max(e.Addrs[idx].Priority, priority)
+		e.Addrs[idx].LastSeen = now
+	}
+
+	e.sort()
+
+	if len(e.Addrs) > MaxAddrsPerPeer {
+		e.Addrs = e.Addrs[:MaxAddrsPerPeer]
+	}
+}
+
+// expire drops the addresses not seen since the cutoff.
+func (e *Entry) expire(cutoff time.Time) {
+	e.Addrs = slices.DeleteFunc(e.Addrs, func(a Address) bool { return a.LastSeen.Before(cutoff) })
+}
+
+func (e *Entry) sort() {
+	slices.SortStableFunc(e.Addrs, func(a, b Address) int {
+		if c := cmp.Compare(b.Priority, a.Priority); c != 0 {
+			return c
+		}
+
+		return b.LastSeen.Compare(a.LastSeen)
+	})
+}
+
+// Decode parses a stored entry. Entries written before the address book existed
+// hold a plain JSON list of multiaddrs; their addresses are considered seen at
+// the given time with PrioritySeedPeer, so that they expire unless refreshed.
+func Decode(data []byte, now time.Time) (*Entry, error) {
+	entry, _, err := decode(data, now)
+
+	return entry, err
+}
+
+// decode parses a stored entry and reports whether it was in the legacy format.
+func decode(data []byte, now time.Time) (*Entry, bool, error) {
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err == nil {
+		return &entry, false, nil
+	}
+
+	var legacy []string
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, false, fmt.Errorf("failed to decode peer addresses: %w", err)
+	}
+
+	addrs := make([]ma.Multiaddr, 0, len(legacy))
+
+	for _, addr := range legacy {
+		maddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			continue
+		}
+
+		addrs = append(addrs, maddr)
+	}
+
+	entry.merge(addrs, PrioritySeedPeer, now)
+
+	return &entry, true, nil
+}
+
+// Book is the peer address book. It is safe for concurrent use.
+type Book struct {
+	dstore datastore.Datastore
+	ttl    time.Duration
+	now    func() time.Time
+
+	// Invoked when the Directory API addresses of a peer change (optional)
+	onChange func(peerID string, dirAddrs []string)
+
+	mu sync.Mutex // Serializes read-modify-write updates of entries
+}
+
+// New creates an address book persisted in dstore, expiring addresses not seen within ttl.
+func New(dstore datastore.Datastore, ttl time.Duration) *Book {
+	return &Book{
+		dstore: dstore,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// OnChange registers a callback invoked when the Directory API addresses of a
+// peer change, with no addresses once the peer's entry expired.
+// The callback is invoked with the book locked and must not block.
+func (b *Book) OnChange(fn func(peerID string, dirAddrs []string)) {
+	b.onChange = fn
+}
+
+// Key returns the datastore key of a peer's entry.
+func Key(peerID string) datastore.Key {
+	return datastore.NewKey(Namespace + "/" + peerID)
+}
+
+// Add records addresses of a peer seen now from a source with the given priority.
+func (b *Book) Add(ctx context.Context, peerID string, addrs []ma.Multiaddr, priority Priority) error {
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, _, err := b.get(ctx, peerID)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		entry = &Entry{}
+	}
+
+	before := entry.DirectoryAddresses()
+
+	entry.merge(addrs, priority, b.now())
+
+	if err := b.put(ctx, peerID, entry); err != nil {
+		return err
+	}
+
+	b.notify(peerID, before, entry.DirectoryAddresses())
+
+	return nil
+}
+
+// Move merges the addresses of a peer into the entry of another peer and removes the
+// entry of the former, e.g. when a peer rotated its identity. Addresses keep their
+// priority and last sighting, so that they expire as before.
+func (b *Book) Move(ctx context.Context, fromPeerID, toPeerID string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	from, _, err := b.get(ctx, fromPeerID)
+	if err != nil || from == nil {
+		return err
+	}
+
+	to, _, err := b.get(ctx, toPeerID)
+	if err != nil {
+		return err
+	}
+
+	if to == nil {
+		to = &Entry{}
+	}
+
+	fromBefore, toBefore := from.DirectoryAddresses(), to.DirectoryAddresses()
+
+	for _, addr := range from.Addrs {
+		idx := slices.IndexFunc(to.Addrs, func(a Address) bool { return a.Addr == addr.Addr })
+		if idx < 0 {
+			to.Addrs = append(to.Addrs, addr)
+
+			continue
+		}
+
+		to.Addrs[idx].Priority = max(to.Addrs[idx].Priority, addr.Priority)
+		if addr.LastSeen.After(to.Addrs[idx].LastSeen) {
+			to.Addrs[idx].LastSeen = addr.LastSeen
+		}
+	}
+
+	to.sort()
+
+	if len(to.Addrs) > MaxAddrsPerPeer {
+		to.Addrs = to.Addrs[:MaxAddrsPerPeer]
+	}
+
+	if err := b.put(ctx, toPeerID, to); err != nil {
+		return err
+	}
+
+	if err := b.dstore.Delete(ctx, Key(fromPeerID)); err != nil {
+		return fmt.Errorf("failed to delete peer addresses: %w", err)
+	}
+
+	b.notify(fromPeerID, fromBefore, nil)
+	b.notify(toPeerID, toBefore, to.DirectoryAddresses())
+
+	return nil
+}
+
+// Get returns the entry of a peer, or nil if the peer is unknown.
+func (b *Book) Get(ctx context.Context, peerID string) (*Entry, error) {
+	entry, _, err := b.get(ctx, peerID)
+
+	return entry, err
+}
+
+// Has reports whether the book holds addresses of a peer.
+func (b *Book) Has(ctx context.Context, peerID string) bool {
+	exists, err := b.dstore.Has(ctx, Key(peerID))
+
+	return err == nil && exists
+}
+
+// Expire removes the addresses not seen within the TTL and the peers left without addresses.
+// Legacy entries are rewritten in the current format, starting their TTL.
+// Returns the number of removed peers.
+func (b *Book) Expire(ctx context.Context) (int, error) {
+	results, err := b.dstore.Query(ctx, query.Query{Prefix: "/" + Namespace + "/", KeysOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query peer addresses: %w", err)
+	}
+
+	var peerIDs []string
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		peerIDs = append(peerIDs, datastore.RawKey(result.Key).BaseNamespace())
+	}
+
+	results.Close()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	cutoff := now.Add(-b.ttl)
+	removed := 0
+
+	for _, peerID := range peerIDs {
+		entry, legacy, err := b.get(ctx, peerID)
+		if err != nil || entry == nil {
+			continue
+		}
+
+		before, dirAddrs := len(entry.Addrs), entry.DirectoryAddresses()
+		entry.expire(cutoff)
+
+		switch {
+		case len(entry.Addrs) == 0:
+			if err := b.dstore.Delete(ctx, Key(peerID)); err != nil {
+				return removed, fmt.Errorf("failed to delete peer addresses: %w", err)
+			}
+
+			removed++
+		case len(entry.Addrs) != before || legacy:
+			if err := b.put(ctx, peerID, entry); err != nil {
+				return removed, err
+			}
+		}
+
+		b.notify(peerID, dirAddrs, entry.DirectoryAddresses())
+	}
+
+	return removed, nil
+}
+
+// notify invokes the change callback if the Directory API addresses changed.
+func (b *Book) notify(peerID string, before, after []string) {
+	if b.onChange != nil && !slices.Equal(before, after) {
+		b.onChange(peerID, after)
+	}
+}
+
+func (b *Book) get(ctx context.Context, peerID string) (*Entry, bool, error) {
+	data, err := b.dstore.Get(ctx, Key(peerID))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get peer addresses: %w", err)
+	}
+
+	return decode(data, b.now())
+}
+
+func (b *Book) put(ctx context.Context, peerID string, entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode peer addresses: %w", err)
+	}
+
+	if err := b.dstore.Put(ctx, Key(peerID), data); err != nil {
+		return fmt.Errorf("failed to store peer addresses: %w", err)
+	}
+
+	return nil
+}
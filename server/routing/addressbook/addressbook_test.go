@@ -0,0 +1,217 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package addressbook
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBook(now *time.Time) *Book {
+	book := New(dssync.MutexWrap(datastore.NewMapDatastore()), time.Hour)
+	book.now = func() time.Time { return *now }
+
+	return book
+}
+
+func addrs(t *testing.T, strs ...string) []ma.Multiaddr {
+	t.Helper()
+
+	result := make([]ma.Multiaddr, 0, len(strs))
+
+	for _, s := range strs {
+		maddr, err := ma.NewMultiaddr(s)
+		require.NoError(t, err)
+
+		result = append(result, maddr)
+	}
+
+	return result
+}
+
+func TestBook_AddOrdersByPriorityAndRecency(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/ip4/10.0.0.1/tcp/1", "/ip4/10.0.0.2/tcp/1"), PrioritySeedPeer))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/ip4/10.0.0.3/tcp/1"), PriorityIdentify))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/ip4/10.0.0.2/tcp/1"), PrioritySeedPeer))
+
+	entry, err := book.Get(t.Context(), "peer1")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+
+	got := make([]string, 0, len(entry.Addrs))
+	for _, addr := range entry.Addrs {
+		got = append(got, addr.Addr)
+	}
+
+	assert.Equal(t, []string{"/ip4/10.0.0.3/tcp/1", "/ip4/10.0.0.2/tcp/1", "/ip4/10.0.0.1/tcp/1"}, got)
+
+	// A lower priority source never downgrades an address
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/ip4/10.0.0.3/tcp/1"), PrioritySeedPeer))
+
+	entry, err = book.Get(t.Context(), "peer1")
+	require.NoError(t, err)
+	assert.Equal(t, PriorityIdentify, entry.Addrs[0].Priority)
+}
+
+func TestBook_AddCapsAddresses(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	for i := range MaxAddrsPerPeer + 4 {
+		now = now.Add(time.Second)
+		require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, fmt.Sprintf("/ip4/10.0.0.%d/tcp/1", i)), PriorityPeerstore))
+	}
+
+	entry, err := book.Get(t.Context(), "peer1")
+	require.NoError(t, err)
+	assert.Len(t, entry.Addrs, MaxAddrsPerPeer)
+	assert.Equal(t, fmt.Sprintf("/ip4/10.0.0.%d/tcp/1", MaxAddrsPerPeer+3), entry.Addrs[0].Addr)
+}
+
+func TestBook_GetUnknownPeer(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	entry, err := book.Get(t.Context(), "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+	assert.False(t, book.Has(t.Context(), "unknown"))
+}
+
+func TestBook_Expire(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	require.NoError(t, book.Add(t.Context(), "stale", addrs(t, "/ip4/10.0.0.1/tcp/1"), PriorityIdentify))
+	require.NoError(t, book.Add(t.Context(), "partial", addrs(t, "/ip4/10.0.0.2/tcp/1"), PriorityIdentify))
+
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, book.Add(t.Context(), "partial", addrs(t, "/ip4/10.0.0.3/tcp/1"), PriorityPeerstore))
+
+	now = now.Add(45 * time.Minute)
+
+	removed, err := book.Expire(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	assert.False(t, book.Has(t.Context(), "stale"))
+
+	entry, err := book.Get(t.Context(), "partial")
+	require.NoError(t, err)
+	require.Len(t, entry.Addrs, 1)
+	assert.Equal(t, "/ip4/10.0.0.3/tcp/1", entry.Addrs[0].Addr)
+}
+
+func TestBook_Move(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	require.NoError(t, book.Add(t.Context(), "old", addrs(t, "/ip4/10.0.0.1/tcp/1", "/ip4/10.0.0.2/tcp/1"), PriorityIdentify))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, book.Add(t.Context(), "new", addrs(t, "/ip4/10.0.0.2/tcp/1"), PrioritySeedPeer))
+
+	require.NoError(t, book.Move(t.Context(), "old", "new"))
+	assert.False(t, book.Has(t.Context(), "old"))
+
+	entry, err := book.Get(t.Context(), "new")
+	require.NoError(t, err)
+	require.Len(t, entry.Addrs, 2)
+
+	// Merged addresses keep the best priority and the latest sighting
+	assert.Equal(t, "/ip4/10.0.0.2/tcp/1", entry.Addrs[0].Addr)
+	assert.Equal(t, PriorityIdentify, entry.Addrs[0].Priority)
+	assert.True(t, now.Equal(entry.Addrs[0].LastSeen))
+
+	// Moving an unknown peer is a no-op
+	require.NoError(t, book.Move(t.Context(), "unknown", "new"))
+}
+
+func TestBook_LegacyEntries(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	legacy := []byte(`["/ip4/10.0.0.1/tcp/1/dir/10.0.0.1:8888", "invalid"]`)
+	require.NoError(t, book.dstore.Put(t.Context(), Key("peer1"), legacy))
+
+	entry, err := book.Get(t.Context(), "peer1")
+	require.NoError(t, err)
+	require.Len(t, entry.Addrs, 1)
+	assert.Equal(t, PrioritySeedPeer, entry.Addrs[0].Priority)
+	assert.Equal(t, []string{"10.0.0.1:8888"}, entry.DirectoryAddresses())
+
+	// Expiring rewrites legacy entries, starting their TTL
+	removed, err := book.Expire(t.Context())
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+
+	now = now.Add(2 * time.Hour)
+
+	removed, err = book.Expire(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+}
+
+func TestBook_OnChange(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	var changes [][]string
+
+	book.OnChange(func(peerID string, dirAddrs []string) {
+		assert.Equal(t, "peer1", peerID)
+
+		changes = append(changes, dirAddrs)
+	})
+
+	dirAddr := "/ip4/10.0.0.1/tcp/1/dir/10.0.0.1:8888"
+
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, dirAddr), PriorityPeerstore))
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, dirAddr), PriorityIdentify))
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/ip4/10.0.0.2/tcp/1"), PriorityIdentify))
+
+	// Only changes of the Directory API addresses are reported
+	assert.Equal(t, [][]string{{"10.0.0.1:8888"}}, changes)
+
+	now = now.Add(2 * time.Hour)
+
+	_, err := book.Expire(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"10.0.0.1:8888"}, nil}, changes)
+}
+
+func TestBook_Zone(t *testing.T) {
+	now := time.Now()
+	book := newTestBook(&now)
+
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/ip4/10.0.0.1/tcp/1", "/dir-zone/us-east-1"), PrioritySeedPeer))
+
+	entry, err := book.Get(t.Context(), "peer1")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", entry.Zone())
+
+	// The zone the peer advertises itself takes precedence
+	now = now.Add(time.Minute)
+	require.NoError(t, book.Add(t.Context(), "peer1", addrs(t, "/dir-zone/eu-west-1"), PriorityIdentify))
+
+	entry, err = book.Get(t.Context(), "peer1")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", entry.Zone())
+
+	assert.Empty(t, Zone(addrs(t, "/ip4/10.0.0.1/tcp/1")))
+}
@@ -0,0 +1,261 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore/query"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	kbucket "github.com/libp2p/go-libp2p-kbucket"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetRoutingTable dumps the DHT routing table, ordered by bucket.
+func (r *routeRemote) GetRoutingTable(_ context.Context, _ *routingv1.GetRoutingTableRequest) (*routingv1.GetRoutingTableResponse, error) {
+	kdht := r.server.DHT()
+	h := r.server.Host()
+	localID := kbucket.ConvertPeerID(h.ID())
+
+	infos := kdht.RoutingTable().GetPeerInfos()
+	peers := make([]*routingv1.RoutingTablePeer, 0, len(infos))
+
+	for _, info := range infos {
+		tablePeer := &routingv1.RoutingTablePeer{
+			PeerId:    info.Id.String(),
+			Bucket:    uint32(kbucket.CommonPrefixLen(localID, kbucket.ConvertPeerID(info.Id))), //nolint:gosec // Bounded by the key length
+			Addrs:     multiaddrStrings(h.Peerstore().Addrs(info.Id)),
+			Connected: h.Network().Connectedness(info.Id) == network.Connected,
+			AddedAt:   timestamppb.New(info.AddedAt),
+		}
+
+		if !info.LastUsefulAt.IsZero() {
+			tablePeer.LastUsefulAt = timestamppb.New(info.LastUsefulAt)
+		}
+
+		peers = append(peers, tablePeer)
+	}
+
+	slices.SortFunc(peers, func(a, b *routingv1.RoutingTablePeer) int {
+		if a.GetBucket() != b.GetBucket() {
+			return int(a.GetBucket()) - int(b.GetBucket())
+		}
+
+		return strings.Compare(a.GetPeerId(), b.GetPeerId())
+	})
+
+	return &routingv1.GetRoutingTableResponse{
+		ServerMode: kdht.Mode() == dht.ModeServer,
+		Peers:      peers,
+	}, nil
+}
+
+// GetGossipSubState returns the peers and the mesh of each joined GossipSub topic.
+func (r *routeRemote) GetGossipSubState(_ context.Context, _ *routingv1.GetGossipSubStateRequest) (*routingv1.GetGossipSubStateResponse, error) {
+	if r.pubsubManager == nil {
+		return &routingv1.GetGossipSubStateResponse{}, nil
+	}
+
+	states := r.pubsubManager.TopicStates()
+	topics := make([]*routingv1.GossipSubTopic, 0, len(states))
+
+	for _, state := range states {
+		topics = append(topics, &routingv1.GossipSubTopic{
+			Topic:      state.Topic,
+			Subscribed: state.Subscribed,
+			Peers:      peerIDStrings(state.Peers),
+			MeshPeers:  peerIDStrings(state.MeshPeers),
+		})
+	}
+
+	return &routingv1.GetGossipSubStateResponse{
+		Enabled: true,
+		Topics:  topics,
+	}, nil
+}
+
+// GetGossipSubDiagnostics returns the message rates of each joined GossipSub topic,
+// the scores of connected peers and the propagation latency of announcements.
+func (r *routeRemote) GetGossipSubDiagnostics(_ context.Context, _ *routingv1.GetGossipSubDiagnosticsRequest) (*routingv1.GetGossipSubDiagnosticsResponse, error) {
+	if r.pubsubManager == nil {
+		return &routingv1.GetGossipSubDiagnosticsResponse{}, nil
+	}
+
+	diagnostics := r.pubsubManager.Diagnostics()
+
+	topics := make([]*routingv1.GossipSubTopicDiagnostics, 0, len(diagnostics.Topics))
+	for _, topic := range diagnostics.Topics {
+		topics = append(topics, gossipSubTopicDiagnostics(topic))
+	}
+
+	scores := make([]*routingv1.GossipSubPeerScore, 0, len(diagnostics.PeerScores))
+	for _, score := range diagnostics.PeerScores {
+		scores = append(scores, &routingv1.GossipSubPeerScore{
+			PeerId:           score.Peer.String(),
+			Score:            score.Score,
+			AppSpecificScore: score.AppSpecificScore,
+			BehaviourPenalty: score.BehaviourPenalty,
+		})
+	}
+
+	resp := &routingv1.GetGossipSubDiagnosticsResponse{
+		Enabled:     true,
+		Topics:      topics,
+		PeerScoring: diagnostics.PeerScoring,
+		PeerScores:  scores,
+	}
+
+	if propagation := diagnostics.Propagation; propagation != nil {
+		resp.Propagation = &routingv1.GossipSubPropagation{
+			Acks:          propagation.Acks,
+			MedianLatency: durationpb.New(propagation.Median),
+			P90Latency:    durationpb.New(propagation.P90),
+			MaxLatency:    durationpb.New(propagation.Max),
+		}
+	}
+
+	return resp, nil
+}
+
+// gossipSubTopicDiagnostics converts the diagnostics of a topic, with the rates of duplicate
+// and invalid messages among all received messages.
+func gossipSubTopicDiagnostics(topic pubsub.TopicDiagnostics) *routingv1.GossipSubTopicDiagnostics {
+	diagnostics := &routingv1.GossipSubTopicDiagnostics{
+		Topic:             topic.Topic,
+		MeshDegree:        uint32(topic.MeshDegree), //nolint:gosec // Mesh degrees are small
+		DeliveredMessages: topic.Delivered,
+		DuplicateMessages: topic.Duplicate,
+		InvalidMessages:   topic.Invalid,
+	}
+
+	if received := topic.Delivered + topic.Duplicate + topic.Invalid; received > 0 {
+		diagnostics.DuplicateRate = float64(topic.Duplicate) / float64(received)
+		diagnostics.InvalidRate = float64(topic.Invalid) / float64(received)
+	}
+
+	return diagnostics
+}
+
+// GetLabelCacheStats scans the label cache and returns its statistics per label namespace.
+func (r *routeRemote) GetLabelCacheStats(ctx context.Context, _ *routingv1.GetLabelCacheStatsRequest) (*routingv1.GetLabelCacheStatsResponse, error) {
+	localPeerID := r.server.Host().ID().String()
+
+	labelTypes := types.AllLabelTypes()
+	namespaces := make([]*routingv1.NamespaceCacheStats, 0, len(labelTypes))
+
+	for _, labelType := range labelTypes {
+		stats, err := r.namespaceCacheStats(ctx, labelType, localPeerID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to scan %s labels: %v", labelType, err)
+		}
+
+		namespaces = append(namespaces, stats)
+	}
+
+	slices.SortFunc(namespaces, func(a, b *routingv1.NamespaceCacheStats) int {
+		return strings.Compare(a.GetNamespace(), b.GetNamespace())
+	})
+
+	return &routingv1.GetLabelCacheStatsResponse{
+		Namespaces:      namespaces,
+		MaxCachedLabels: int64(r.cacheLimit()),
+	}, nil
+}
+
+// namespaceCacheStats counts the cached labels of a namespace from their keys.
+func (r *routeRemote) namespaceCacheStats(ctx context.Context, labelType types.LabelType, localPeerID string) (*routingv1.NamespaceCacheStats, error) {
+	results, err := r.dstore.Query(ctx, query.Query{Prefix: labelType.Prefix(), KeysOnly: true})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer results.Close()
+
+	stats := &routingv1.NamespaceCacheStats{Namespace: labelType.String()}
+	labels := make(map[types.Label]struct{})
+	records := make(map[string]struct{})
+	peers := make(map[string]struct{})
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+
+		label, cid, peerID, err := ParseEnhancedLabelKey(result.Key)
+		if err != nil {
+			continue
+		}
+
+		labels[label] = struct{}{}
+
+		if peerID == localPeerID {
+			stats.LocalLabels++
+
+			continue
+		}
+
+		stats.RemoteLabels++
+		records[cid] = struct{}{}
+		peers[peerID] = struct{}{}
+	}
+
+	stats.DistinctLabels = int64(len(labels))
+	stats.RemoteRecords = int64(len(records))
+	stats.RemotePeers = int64(len(peers))
+
+	return stats, nil
+}
+
+// GetQueueState returns the depth of the notification queue and the pending announcements.
+func (r *routeRemote) GetQueueState(_ context.Context, _ *routingv1.GetQueueStateRequest) (*routingv1.GetQueueStateResponse, error) {
+	pending, dead := r.notifyQueue.len()
+	active, concurrency := r.pulls.active()
+
+	return &routingv1.GetQueueStateResponse{
+		NotifyDepth:          uint32(pending),               //nolint:gosec // Bounded by NotificationQueueSize
+		NotifyCapacity:       uint32(NotificationQueueSize), //nolint:gosec // Constant
+		PendingAnnouncements: uint32(r.pending.len()),       //nolint:gosec // Bounded by MaxPendingAnnouncements
+		NotifyDeadLetters:    uint32(dead),                  //nolint:gosec // Bounded by the dead-letter retention
+		ActivePulls:          uint32(active),                //nolint:gosec // Bounded by the pull concurrency
+		PullConcurrency:      uint32(concurrency),           //nolint:gosec // Configured pull concurrency
+	}, nil
+}
+
+// GetTaskStatus returns the schedules and last runs of the cleanup and republish tasks.
+func (r *routeRemote) GetTaskStatus(_ context.Context, _ *routingv1.GetTaskStatusRequest) (*routingv1.GetTaskStatusResponse, error) {
+	var tasks []*routingv1.TaskStatus
+	if r.cleanupManager != nil {
+		tasks = r.cleanupManager.tasks.toProto(r.state)
+	}
+
+	return &routingv1.GetTaskStatusResponse{Tasks: tasks}, nil
+}
+
+func multiaddrStrings(addrs []ma.Multiaddr) []string {
+	result := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		result = append(result, addr.String())
+	}
+
+	return result
+}
+
+func peerIDStrings(peers []peer.ID) []string {
+	result := make([]string, 0, len(peers))
+	for _, p := range peers {
+		result = append(result, p.String())
+	}
+
+	return result
+}
@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceCacheStats(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	keys := []string{
+		BuildEnhancedLabelKey("/skills/AI", "cid-local", "local-peer"),
+		BuildEnhancedLabelKey("/skills/AI", "cid-1", "remote-peer-1"),
+		BuildEnhancedLabelKey("/skills/AI/ML", "cid-1", "remote-peer-1"),
+		BuildEnhancedLabelKey("/skills/AI", "cid-2", "remote-peer-2"),
+		BuildEnhancedLabelKey("/domains/research", "cid-3", "remote-peer-3"),
+	}
+	for _, key := range keys {
+		require.NoError(t, dstore.Put(ctx, datastore.NewKey(key), []byte("{}")))
+	}
+
+	r := &routeRemote{dstore: dstore}
+
+	stats, err := r.namespaceCacheStats(ctx, types.LabelTypeSkill, "local-peer")
+	require.NoError(t, err)
+	assert.Equal(t, "skills", stats.GetNamespace())
+	assert.Equal(t, int64(1), stats.GetLocalLabels())
+	assert.Equal(t, int64(3), stats.GetRemoteLabels())
+	assert.Equal(t, int64(2), stats.GetDistinctLabels())
+	assert.Equal(t, int64(2), stats.GetRemoteRecords())
+	assert.Equal(t, int64(2), stats.GetRemotePeers())
+
+	stats, err = r.namespaceCacheStats(ctx, types.LabelTypeModule, "local-peer")
+	require.NoError(t, err)
+	assert.Zero(t, stats.GetLocalLabels()+stats.GetRemoteLabels())
+}
+
+func TestGossipSubTopicDiagnostics(t *testing.T) {
+	diagnostics := gossipSubTopicDiagnostics(pubsub.TopicDiagnostics{
+		Topic:      "dir/labels.skills/v1",
+		MeshDegree: 6,
+		Delivered:  6,
+		Duplicate:  3,
+		Invalid:    1,
+	})
+	assert.Equal(t, uint32(6), diagnostics.GetMeshDegree())
+	assert.InDelta(t, 0.3, diagnostics.GetDuplicateRate(), 1e-9)
+	assert.InDelta(t, 0.1, diagnostics.GetInvalidRate(), 1e-9)
+
+	// Topics without messages have no rates
+	diagnostics = gossipSubTopicDiagnostics(pubsub.TopicDiagnostics{Topic: "dir/acks/v1"})
+	assert.Zero(t, diagnostics.GetDuplicateRate())
+	assert.Zero(t, diagnostics.GetInvalidRate())
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// AnnouncedLabelsNamespace is the datastore namespace of the label sets last announced for local records.
+const AnnouncedLabelsNamespace = "announced-labels"
+
+// announcedLabelsKey returns the datastore key of the labels last announced for a record: /announced-labels/<CID>.
+func announcedLabelsKey(cid string) datastore.Key {
+	return datastore.NewKey("/" + AnnouncedLabelsNamespace + "/" + cid)
+}
+
+// announcedLabels returns the labels last announced for a local record.
+func (r *routeRemote) announcedLabels(ctx context.Context, cid string) []string {
+	data, err := r.dstore.Get(ctx, announcedLabelsKey(cid))
+	if err != nil {
+		return nil
+	}
+
+	var labels []string
+	if err := json.Unmarshal(data, &labels); err != nil {
+		remoteLogger.Warn("Failed to parse announced labels", "cid", cid, "error", err)
+	}
+
+	return labels
+}
+
+// removedLabels returns the labels last announced for a local record that it no longer has,
+// so that remote peers delete them from their label cache instead of keeping them until the
+// record's labels go stale. Records announced for the first time have no removed labels.
+// The announced labels are only updated once announcements are published, see labelsAnnounced.
+func (r *routeRemote) removedLabels(ctx context.Context, cid string, labels []string) []string {
+	var removed []string
+
+	for _, label := range r.announcedLabels(ctx, cid) {
+		if !slices.Contains(labels, label) {
+			removed = append(removed, label)
+		}
+	}
+
+	if len(removed) > 0 {
+		remoteLogger.Info("Announcing removed labels of republished record", "cid", cid, "removed", removed)
+	}
+
+	return removed
+}
+
+// labelsAnnounced updates the labels last announced for a local record with a published
+// announcement: its removed labels are dropped and its labels added. Announcements cover
+// one namespace each, so the labels of other namespaces are kept.
+func (r *routeRemote) labelsAnnounced(ctx context.Context, event *pubsub.RecordPublishEvent) {
+	r.announcedLabelsMu.Lock()
+	defer r.announcedLabelsMu.Unlock()
+
+	var labels []string
+
+	for _, label := range r.announcedLabels(ctx, event.CID) {
+		if !slices.Contains(event.RemovedLabels, label) && !slices.Contains(event.Labels, label) {
+			labels = append(labels, label)
+		}
+	}
+
+	labels = append(labels, event.Labels...)
+
+	data, err := json.Marshal(labels)
+	if err != nil {
+		remoteLogger.Warn("Failed to marshal announced labels", "cid", event.CID, "error", err)
+
+		return
+	}
+
+	if err := r.dstore.Put(ctx, announcedLabelsKey(event.CID), data); err != nil {
+		remoteLogger.Warn("Failed to store announced labels", "cid", event.CID, "error", err)
+
+		return
+	}
+
+	metrics.AnnouncedLabelRemovals.Add(float64(len(event.RemovedLabels)))
+}
+
+// gossipRemovedLabels announces the labels removed from local records that are not announced
+// via GossipSub, i.e. low-priority records, so that peers that cached them from earlier
+// announcements delete them. Best-effort like GossipSub announcements.
+func (r *routeRemote) gossipRemovedLabels(ctx context.Context, records []types.Record) {
+	err := r.gossip(ctx, func(ctx context.Context) error { return r.pubsubManager.PublishRemovedLabels(ctx, records) })
+	if err != nil {
+		remoteLogger.Warn("Failed to publish removed labels via GossipSub", "records", len(records), "error", err)
+	}
+}
+
+// forgetAnnouncedLabels deletes the labels last announced for a record that is no longer published.
+func (r *routeRemote) forgetAnnouncedLabels(ctx context.Context, cid string) {
+	if err := r.dstore.Delete(ctx, announcedLabelsKey(cid)); err != nil {
+		remoteLogger.Debug("Failed to delete announced labels", "cid", cid, "error", err)
+	}
+}
+
+// announcementMutation returns the label cache mutation of an announcement of a remote peer:
+// its labels are stored with the given metadata and its removed labels deleted, both under
+// the namespace of the record's tenant. Labels are normalized by the label taxonomy, and
+// removed labels that are still announced after normalization are kept.
+func announcementMutation(event *pubsub.RecordPublishEvent, peerID string, metadata []byte) *cacheMutation {
+	labels := &cacheMutation{}
+
+	// Convert wire format ([]string) to storage format
+	announced := remoteLabels(event.Labels)
+	for _, label := range announced {
+		labels.put(tenantKey(event.Tenant, BuildEnhancedLabelKey(label, event.CID, peerID)), metadata)
+	}
+
+	for _, label := range remoteLabels(event.RemovedLabels) {
+		if slices.Contains(announced, label) {
+			continue
+		}
+
+		labels.delete(tenantKey(event.Tenant, BuildEnhancedLabelKey(label, event.CID, peerID)))
+	}
+
+	return labels
+}
@@ -0,0 +1,95 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemovedLabels(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{ctx: t.Context(), dstore: dstore}
+
+	// The first announcement has nothing to remove
+	assert.Empty(t, r.removedLabels(t.Context(), revokedTestCID, []string{"/skills/AI/ML", "/skills/AI/NLP", "/domains/research"}))
+	r.labelsAnnounced(t.Context(), &pubsub.RecordPublishEvent{CID: revokedTestCID, Labels: []string{"/skills/AI/ML", "/skills/AI/NLP"}})
+	r.labelsAnnounced(t.Context(), &pubsub.RecordPublishEvent{CID: revokedTestCID, Labels: []string{"/domains/research"}})
+
+	// Republishing with different labels announces the dropped ones
+	labels := []string{"/skills/AI/ML", "/modules/tensorflow"}
+	assert.Equal(t, []string{"/skills/AI/NLP", "/domains/research"}, r.removedLabels(t.Context(), revokedTestCID, labels))
+
+	// Removals are announced again until the announcement carrying them was published
+	assert.Equal(t, []string{"/skills/AI/NLP", "/domains/research"}, r.removedLabels(t.Context(), revokedTestCID, labels))
+
+	// Published announcements of one namespace keep the labels of the others
+	r.labelsAnnounced(t.Context(), &pubsub.RecordPublishEvent{CID: revokedTestCID, Labels: []string{"/skills/AI/ML"}, RemovedLabels: []string{"/skills/AI/NLP"}})
+	assert.Equal(t, []string{"/domains/research"}, r.removedLabels(t.Context(), revokedTestCID, labels))
+
+	r.labelsAnnounced(t.Context(), &pubsub.RecordPublishEvent{CID: revokedTestCID, RemovedLabels: []string{"/domains/research"}})
+	r.labelsAnnounced(t.Context(), &pubsub.RecordPublishEvent{CID: revokedTestCID, Labels: []string{"/modules/tensorflow"}})
+
+	// Unchanged labels have nothing to remove
+	assert.Empty(t, r.removedLabels(t.Context(), revokedTestCID, labels))
+
+	// Records published again after being unpublished start over
+	r.forgetAnnouncedLabels(t.Context(), revokedTestCID)
+	assert.Empty(t, r.removedLabels(t.Context(), revokedTestCID, []string{"/skills/AI/ML"}))
+}
+
+func TestAnnouncementMutation_DeletesRemovedLabels(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore:       dstore,
+		peerStats:    peerstats.New(),
+		cardinality:  cardinality.NewIndex(),
+		providerSets: providerset.NewIndex(),
+	}
+
+	putTestLabel(t, dstore, "/tenants/acme/skills/AI/NLP", revokedTestCID, "peer1", time.Now())
+	putTestLabel(t, dstore, "/tenants/acme/skills/AI/NLP", revokedTestCID, "peer2", time.Now())
+	r.peerStats.AddLabels("peer1", 1)
+
+	event := &pubsub.RecordPublishEvent{
+		CID:           revokedTestCID,
+		Labels:        []string{"/skills/AI/ML"},
+		RemovedLabels: []string{"/skills/AI/NLP", "/skills/AI/ML"},
+		Tenant:        "acme",
+	}
+
+	labels := announcementMutation(event, "peer1", []byte(`{}`))
+	require.Len(t, labels.Puts, 1)
+
+	// Labels that are announced again are not deleted
+	require.Equal(t, []string{"/tenants/acme/skills/AI/NLP/" + revokedTestCID + "/peer1"}, labels.Deletes)
+
+	require.NoError(t, r.cacheRemoteLabels(t.Context(), "peer1", labels, nil))
+
+	for key, cached := range map[string]bool{
+		"/tenants/acme/skills/AI/ML/" + revokedTestCID + "/peer1":  true,
+		"/tenants/acme/skills/AI/NLP/" + revokedTestCID + "/peer1": false,
+		// Labels announced by other peers are kept
+		"/tenants/acme/skills/AI/NLP/" + revokedTestCID + "/peer2": true,
+	} {
+		has, err := dstore.Has(t.Context(), ipfsdatastore.NewKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, cached, has, key)
+	}
+
+	// The deleted label no longer counts towards the peer's cached labels
+	assert.Equal(t, int64(1), r.peerStats.TotalLabels())
+}
@@ -0,0 +1,479 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/events"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// announcementCheck is the result of verifying that a local record is resolvable by other peers.
+type announcementCheck struct {
+	DHTPeersChecked     int
+	DHTPeersResolved    int
+	GossipPeersChecked  int
+	GossipPeersResolved int
+	CheckedAt           time.Time
+}
+
+// failedTransports returns the transports whose checked peers all failed to resolve the record.
+func (c announcementCheck) failedTransports() []string {
+	var failed []string
+
+	if c.DHTPeersChecked > 0 && c.DHTPeersResolved == 0 {
+		failed = append(failed, metrics.TransportDHT)
+	}
+
+	if c.GossipPeersChecked > 0 && c.GossipPeersResolved == 0 {
+		failed = append(failed, metrics.TransportGossipSub)
+	}
+
+	return failed
+}
+
+func (c announcementCheck) unresolvable() bool {
+	return len(c.failedTransports()) > 0
+}
+
+func (c announcementCheck) toProto(cid string) *routingv1.AnnouncementCheck {
+	return &routingv1.AnnouncementCheck{
+		Cid:                 cid,
+		DhtPeersChecked:     uint32(c.DHTPeersChecked),     //nolint:gosec // At most VerificationPeers
+		DhtPeersResolved:    uint32(c.DHTPeersResolved),    //nolint:gosec // At most VerificationPeers
+		GossipPeersChecked:  uint32(c.GossipPeersChecked),  //nolint:gosec // At most VerificationPeers
+		GossipPeersResolved: uint32(c.GossipPeersResolved), //nolint:gosec // At most VerificationPeers
+		CheckedAt:           timestamppb.New(c.CheckedAt),
+	}
+}
+
+// announcementChecks holds the last announcement check and the last announcement
+// of each local record. It is safe for concurrent use.
+type announcementChecks struct {
+	mu          sync.RWMutex
+	checks      map[string]announcementCheck
+	announcedAt map[string]time.Time
+}
+
+func newAnnouncementChecks() *announcementChecks {
+	return &announcementChecks{
+		checks:      make(map[string]announcementCheck),
+		announcedAt: make(map[string]time.Time),
+	}
+}
+
+// announced records that a record was announced to the network.
+func (a *announcementChecks) announced(cid string, at time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.announcedAt[cid] = at
+}
+
+// forget drops the announcement time of an unpublished record.
+func (a *announcementChecks) forget(cid string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.announcedAt, cid)
+}
+
+// status returns when a record was last announced and its last check, if any.
+func (a *announcementChecks) status(cid string) (time.Time, *routingv1.AnnouncementCheck) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	check, ok := a.checks[cid]
+	if !ok {
+		return a.announcedAt[cid], nil
+	}
+
+	return a.announcedAt[cid], check.toProto(cid)
+}
+
+// leastRecentlyChecked returns up to limit of the given CIDs, unchecked ones first.
+func (a *announcementChecks) leastRecentlyChecked(cids []string, limit int) []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	sorted := slices.Clone(cids)
+	slices.SortStableFunc(sorted, func(x, y string) int {
+		return a.checks[x].CheckedAt.Compare(a.checks[y].CheckedAt)
+	})
+
+	return sorted[:min(limit, len(sorted))]
+}
+
+// update stores new checks and forgets the checks of records that are no longer published.
+// It returns the CIDs of the records that became unresolvable.
+func (a *announcementChecks) update(checks map[string]announcementCheck, published map[string]bool) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var unresolvable []string
+
+	for cid, check := range checks {
+		if check.unresolvable() && !a.checks[cid].unresolvable() {
+			unresolvable = append(unresolvable, cid)
+		}
+
+		a.checks[cid] = check
+	}
+
+	for cid := range a.checks {
+		if _, ok := published[cid]; !ok {
+			delete(a.checks, cid)
+		}
+	}
+
+	slices.Sort(unresolvable)
+
+	return unresolvable
+}
+
+// unresolvable returns up to limit unresolvable records ordered by CID.
+func (a *announcementChecks) unresolvable(limit int) []*routingv1.AnnouncementCheck {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	var cids []string
+
+	for cid, check := range a.checks {
+		if check.unresolvable() {
+			cids = append(cids, cid)
+		}
+	}
+
+	slices.Sort(cids)
+
+	result := make([]*routingv1.AnnouncementCheck, 0, min(limit, len(cids)))
+	for _, cid := range cids[:min(limit, len(cids))] {
+		result = append(result, a.checks[cid].toProto(cid))
+	}
+
+	return result
+}
+
+// serveVerification reports how this peer sees the announcements of records by a remote peer:
+// whether the DHT provider store holds a provider record of the peer for each record,
+// and how many labels of each record were cached from the peer.
+func (r *routeRemote) serveVerification(ctx context.Context, announcer peer.ID, cids []string) ([]rpc.VerifyResult, error) {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := countAnnouncedLabels(entries, announcer.String())
+	results := make([]rpc.VerifyResult, 0, len(cids))
+
+	for _, cidStr := range cids {
+		result := rpc.VerifyResult{Cid: cidStr, Labels: labels[cidStr]}
+
+		if decodedCID, err := cid.Decode(cidStr); err == nil {
+			providers, err := r.server.DHT().ProviderStore().GetProviders(ctx, decodedCID.Hash())
+			if err == nil {
+				result.Provider = slices.ContainsFunc(providers, func(p peer.AddrInfo) bool { return p.ID == announcer })
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// countAnnouncedLabels counts the unexpired cached labels of each record announced by a peer.
+func countAnnouncedLabels(entries []NamespaceEntry, peerID string) map[string]int {
+	counts := make(map[string]int)
+	now := time.Now()
+
+	for _, entry := range entries {
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID != peerID || labelExpired(entry.Value, now) {
+			continue
+		}
+
+		counts[keyCID]++
+	}
+
+	return counts
+}
+
+// verifyAnnouncements checks that the least recently checked local records are resolvable
+// by other peers, from the perspective of this peer:
+//   - DHT: a fresh lookup of the peers closest to the CID, which are asked whether they hold
+//     a provider record of this peer
+//   - GossipSub: topic peers are asked whether they cached the record's labels from this peer
+//
+// Records that became unresolvable are reported as record.unresolvable events.
+func (r *routeRemote) verifyAnnouncements(ctx context.Context) {
+	published, err := r.publishedRecords(ctx)
+	if err != nil {
+		remoteLogger.Warn("Failed to list local records for announcement verification", "error", err)
+
+		return
+	}
+
+	cids := r.announcements.leastRecentlyChecked(slices.Sorted(maps.Keys(published)), MaxVerifiedRecords)
+	checks := make(map[string]announcementCheck, len(cids))
+	now := time.Now()
+
+	for _, cidStr := range cids {
+		checks[cidStr] = announcementCheck{CheckedAt: now}
+	}
+
+	r.verifyGossipAnnouncements(ctx, cids, published, checks)
+	r.verifyDHTAnnouncements(ctx, cids, checks)
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	for _, check := range checks {
+		metrics.AnnouncementVerifications.WithLabelValues(metrics.TransportDHT, verificationResult(check.DHTPeersChecked, check.DHTPeersResolved)).Inc()
+
+		if check.GossipPeersChecked > 0 {
+			metrics.AnnouncementVerifications.WithLabelValues(metrics.TransportGossipSub, verificationResult(check.GossipPeersChecked, check.GossipPeersResolved)).Inc()
+		}
+	}
+
+	localPeerID := r.server.Host().ID().String()
+
+	unresolvable := r.announcements.update(checks, published)
+	for _, cidStr := range unresolvable {
+		failed := checks[cidStr].failedTransports()
+
+		remoteLogger.Warn("Published record is not resolvable by other peers", "cid", cidStr, "transports", failed)
+
+		r.events.Emit(events.RecordUnresolvable(cidStr, localPeerID, strings.Join(failed, ",")))
+	}
+
+	remoteLogger.Debug("Verified announcements of local records", "checked", len(cids), "unresolvable", len(unresolvable))
+}
+
+// publishedRecords returns the CIDs of the unexpired or pinned local records, mapped to whether
+// they are announced via GossipSub (all but low priority records).
+func (r *routeRemote) publishedRecords(ctx context.Context) (map[string]bool, error) {
+	results, err := r.dstore.Query(ctx, query.Query{Prefix: "/records/"})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer results.Close()
+
+	published := make(map[string]bool)
+	now := time.Now()
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		metadata := decodeLocalRecordMetadata(result.Value)
+		if metadata.expired(now) && !r.pins.has(path.Base(result.Key)) {
+			continue
+		}
+
+		published[path.Base(result.Key)] = metadata.Priority != routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW
+	}
+
+	return published, nil
+}
+
+// verifyGossipAnnouncements asks up to VerificationPeers GossipSub topic peers
+// whether they cached the labels of the gossiped records.
+func (r *routeRemote) verifyGossipAnnouncements(ctx context.Context, cids []string, gossiped map[string]bool, checks map[string]announcementCheck) {
+	if r.pubsubManager == nil {
+		return
+	}
+
+	var batch []string
+
+	for _, cidStr := range cids {
+		if gossiped[cidStr] {
+			batch = append(batch, cidStr)
+		}
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	topicPeers := r.pubsubManager.GetTopicPeers()
+
+	for _, peerStr := range topicPeers[:min(VerificationPeers, len(topicPeers))] {
+		peerID, err := peer.Decode(peerStr)
+		if err != nil {
+			continue
+		}
+
+		for chunk := range slices.Chunk(batch, rpc.MaxVerifyCids) {
+			results, err := r.verifyWithPeer(ctx, peerID, chunk)
+			if err != nil {
+				continue
+			}
+
+			for _, cidStr := range chunk {
+				check := checks[cidStr]
+				check.GossipPeersChecked++
+
+				if results[cidStr].Labels > 0 {
+					check.GossipPeersResolved++
+				}
+
+				checks[cidStr] = check
+			}
+		}
+	}
+}
+
+// verifyDHTAnnouncements looks up the peers closest to each record in the DHT and asks up to
+// VerificationPeers of them whether they hold a provider record of this peer.
+func (r *routeRemote) verifyDHTAnnouncements(ctx context.Context, cids []string, checks map[string]announcementCheck) {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, VerificationConcurrency)
+	)
+
+	for _, cidStr := range cids {
+		decodedCID, err := cid.Decode(cidStr)
+		if err != nil {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+
+			return
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			checked, resolved := r.verifyDHTAnnouncement(ctx, decodedCID)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			check := checks[cidStr]
+			check.DHTPeersChecked = checked
+			check.DHTPeersResolved = resolved
+			checks[cidStr] = check
+		}()
+	}
+
+	wg.Wait()
+}
+
+// verifyDHTAnnouncement returns how many of the peers closest to the record were checked,
+// and how many of them hold a provider record of this peer.
+func (r *routeRemote) verifyDHTAnnouncement(ctx context.Context, decodedCID cid.Cid) (int, int) {
+	ctx, cancel := context.WithTimeout(ctx, AnnouncementVerificationTimeout)
+	defer cancel()
+
+	closest, err := r.server.DHT().GetClosestPeers(ctx, string(decodedCID.Hash()))
+	if err != nil {
+		remoteLogger.Debug("Failed to look up closest peers for announcement verification", "cid", decodedCID, "error", err)
+
+		return 0, 0
+	}
+
+	localPeerID := r.server.Host().ID()
+	cidStr := decodedCID.String()
+
+	var checked, resolved int
+
+	for _, peerID := range closest {
+		if checked >= VerificationPeers {
+			break
+		}
+
+		if peerID == localPeerID {
+			continue
+		}
+
+		results, err := r.verifyWithPeer(ctx, peerID, []string{cidStr})
+		if err != nil {
+			continue
+		}
+
+		checked++
+
+		if results[cidStr].Provider {
+			resolved++
+		}
+	}
+
+	return checked, resolved
+}
+
+// verifyWithPeer asks a peer how it sees the announcements of the given records, keyed by CID.
+func (r *routeRemote) verifyWithPeer(ctx context.Context, peerID peer.ID, cids []string) (map[string]rpc.VerifyResult, error) {
+	results, err := r.service.Verify(ctx, peerID, cids)
+	if err != nil {
+		// Peers running older versions do not serve Verify
+		remoteLogger.Debug("Failed to verify announcements with peer", "peer", peerID, "error", err)
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	byCID := make(map[string]rpc.VerifyResult, len(results))
+	for _, result := range results {
+		byCID[result.Cid] = result
+	}
+
+	return byCID, nil
+}
+
+func verificationResult(checked, resolved int) string {
+	switch {
+	case checked == 0:
+		return metrics.ResultUnknown
+	case resolved == 0:
+		return metrics.ResultFailure
+	default:
+		return metrics.ResultSuccess
+	}
+}
+
+// startAnnouncementVerification periodically verifies that local records are resolvable by other peers.
+func (r *routeRemote) startAnnouncementVerification() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(AnnouncementVerificationInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping announcement verification")
+
+				return
+			case <-ticker.C:
+				r.verifyAnnouncements(r.ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,89 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnouncementCheck_FailedTransports(t *testing.T) {
+	assert.Empty(t, announcementCheck{}.failedTransports(), "unchecked records are not unresolvable")
+	assert.Empty(t, announcementCheck{DHTPeersChecked: 3, DHTPeersResolved: 1}.failedTransports())
+	assert.Equal(t, []string{metrics.TransportDHT}, announcementCheck{DHTPeersChecked: 3}.failedTransports())
+	assert.Equal(t, []string{metrics.TransportDHT, metrics.TransportGossipSub},
+		announcementCheck{DHTPeersChecked: 1, GossipPeersChecked: 2}.failedTransports())
+}
+
+func TestAnnouncementChecks(t *testing.T) {
+	checks := newAnnouncementChecks()
+	now := time.Now()
+
+	published := map[string]bool{"cid1": true, "cid2": true, "cid3": false}
+
+	// Records become unresolvable once
+	unresolvable := checks.update(map[string]announcementCheck{
+		"cid1": {DHTPeersChecked: 3, CheckedAt: now},
+		"cid2": {DHTPeersChecked: 3, DHTPeersResolved: 2, CheckedAt: now},
+	}, published)
+	assert.Equal(t, []string{"cid1"}, unresolvable)
+
+	unresolvable = checks.update(map[string]announcementCheck{
+		"cid1": {DHTPeersChecked: 3, CheckedAt: now.Add(time.Hour)},
+	}, published)
+	assert.Empty(t, unresolvable)
+
+	// Unchecked records are verified first, then the least recently checked
+	assert.Equal(t, []string{"cid3", "cid2"}, checks.leastRecentlyChecked([]string{"cid1", "cid2", "cid3"}, 2))
+
+	stats := checks.unresolvable(10)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "cid1", stats[0].GetCid())
+	assert.Equal(t, uint32(3), stats[0].GetDhtPeersChecked())
+	assert.Zero(t, stats[0].GetDhtPeersResolved())
+
+	// Checks of records that are no longer published are forgotten
+	checks.update(nil, map[string]bool{"cid2": true})
+	assert.Empty(t, checks.unresolvable(10))
+}
+
+func TestAnnouncementChecks_Status(t *testing.T) {
+	checks := newAnnouncementChecks()
+	now := time.Now()
+
+	announcedAt, check := checks.status("cid1")
+	assert.True(t, announcedAt.IsZero())
+	assert.Nil(t, check)
+
+	checks.announced("cid1", now)
+	checks.update(map[string]announcementCheck{"cid1": {DHTPeersChecked: 3, DHTPeersResolved: 1, CheckedAt: now}}, map[string]bool{"cid1": true})
+
+	announcedAt, check = checks.status("cid1")
+	assert.True(t, announcedAt.Equal(now))
+	require.NotNil(t, check)
+	assert.Equal(t, uint32(1), check.GetDhtPeersResolved())
+
+	checks.forget("cid1")
+
+	announcedAt, _ = checks.status("cid1")
+	assert.True(t, announcedAt.IsZero())
+}
+
+func TestCountAnnouncedLabels(t *testing.T) {
+	expired := []byte(`{"expires_at":"2000-01-01T00:00:00Z"}`)
+
+	entries := []NamespaceEntry{
+		{Key: "/skills/AI/cid1/peer1", Value: []byte(`{}`)},
+		{Key: "/domains/research/cid1/peer1", Value: []byte(`{}`)},
+		{Key: "/skills/AI/cid1/peer2", Value: []byte(`{}`)},
+		{Key: "/skills/AI/cid2/peer1", Value: expired},
+		{Key: "/invalid", Value: []byte(`{}`)},
+	}
+
+	assert.Equal(t, map[string]int{"cid1": 2}, countAnnouncedLabels(entries, "peer1"))
+}
@@ -0,0 +1,292 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/libp2p/go-libp2p-kad-dht/crawler"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// backfillOptions bound a backfill of the remote label cache.
+type backfillOptions struct {
+	maxPeers       int
+	maxRecords     int
+	pullsPerSecond int
+}
+
+// newBackfillOptions returns the options of the request, with defaults for unset options.
+func newBackfillOptions(req *routingv1.StartBackfillRequest) backfillOptions {
+	opts := backfillOptions{
+		maxPeers:       int(req.GetMaxPeers()),
+		maxRecords:     int(req.GetMaxRecords()),
+		pullsPerSecond: int(req.GetPullsPerSecond()),
+	}
+
+	if opts.maxPeers == 0 {
+		opts.maxPeers = DefaultBackfillMaxPeers
+	}
+
+	if opts.maxRecords == 0 {
+		opts.maxRecords = DefaultBackfillMaxRecords
+	}
+
+	if opts.pullsPerSecond == 0 {
+		opts.pullsPerSecond = DefaultBackfillPullsPerSecond
+	}
+
+	return opts
+}
+
+// backfillJob tracks the progress of the running or the last backfill.
+// It is safe for concurrent use.
+type backfillJob struct {
+	mu     sync.Mutex
+	status *routingv1.BackfillStatus // Nil if no backfill ran since this peer started
+}
+
+// start marks a backfill as running, reporting false if one is running already.
+func (j *backfillJob) start(now time.Time) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.status.GetRunning() {
+		return false
+	}
+
+	j.status = &routingv1.BackfillStatus{Running: true, StartedAt: timestamppb.New(now)}
+
+	return true
+}
+
+// update applies fn to the status of the running backfill.
+func (j *backfillJob) update(fn func(*routingv1.BackfillStatus)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fn(j.status)
+}
+
+// finish marks the running backfill as finished, failed with err if it is not nil.
+func (j *backfillJob) finish(now time.Time, err error) {
+	j.update(func(s *routingv1.BackfillStatus) {
+		s.Running = false
+		s.FinishedAt = timestamppb.New(now)
+
+		if err != nil {
+			s.Error = err.Error()
+		}
+	})
+}
+
+// snapshot returns a copy of the status, nil if no backfill ran.
+func (j *backfillJob) snapshot() *routingv1.BackfillStatus {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.status == nil {
+		return nil
+	}
+
+	return proto.Clone(j.status).(*routingv1.BackfillStatus) //nolint:forcetypeassert
+}
+
+// StartBackfill starts rebuilding the remote label cache from the records provided by
+// the peers of the directory network found by crawling the DHT.
+func (r *routeRemote) StartBackfill(_ context.Context, req *routingv1.StartBackfillRequest) (*routingv1.StartBackfillResponse, error) {
+	if !r.backfill.start(time.Now()) {
+		return nil, status.Error(codes.FailedPrecondition, "a backfill is already running") //nolint:wrapcheck
+	}
+
+	opts := newBackfillOptions(req)
+
+	remoteLogger.Info("Started label cache backfill",
+		"maxPeers", opts.maxPeers,
+		"maxRecords", opts.maxRecords,
+		"pullsPerSecond", opts.pullsPerSecond)
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		err := r.runBackfill(r.ctx, opts)
+		r.backfill.finish(time.Now(), err)
+
+		result := r.backfill.snapshot()
+		remoteLogger.Info("Finished label cache backfill",
+			"peersCrawled", result.GetPeersCrawled(),
+			"providers", result.GetProviders(),
+			"recordsPulled", result.GetRecordsPulled(),
+			"recordsSkipped", result.GetRecordsSkipped(),
+			"recordsFailed", result.GetRecordsFailed(),
+			"error", err)
+	}()
+
+	return &routingv1.StartBackfillResponse{Status: r.backfill.snapshot()}, nil
+}
+
+// GetBackfillStatus returns the progress of the running or the last backfill.
+func (r *routeRemote) GetBackfillStatus(_ context.Context, _ *routingv1.GetBackfillStatusRequest) (*routingv1.GetBackfillStatusResponse, error) {
+	return &routingv1.GetBackfillStatusResponse{Status: r.backfill.snapshot()}, nil
+}
+
+// runBackfill crawls the DHT for peers of the directory network and pulls the records
+// they provide, until opts.maxRecords records were pulled or the context is done.
+// Provider records cannot be enumerated through the DHT, so each crawled peer is asked
+// for the records it provides via label sync.
+func (r *routeRemote) runBackfill(ctx context.Context, opts backfillOptions) error {
+	providers, err := r.crawlDirectoryPeers(ctx, opts.maxPeers)
+	if err != nil {
+		return err
+	}
+
+	pace := time.NewTicker(time.Second / time.Duration(opts.pullsPerSecond))
+	defer pace.Stop()
+
+	pulled := 0
+
+	for _, provider := range providers {
+		if pulled >= opts.maxRecords {
+			break
+		}
+
+		if !r.service.SupportsLabelSync(provider.ID) {
+			continue
+		}
+
+		pulled += r.backfillFrom(ctx, provider, opts.maxRecords-pulled, pace.C)
+
+		if err := ctx.Err(); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+
+	return nil
+}
+
+// crawlDirectoryPeers crawls the DHT from the routing table for up to maxPeers peers
+// speaking the DHT protocol of the directory network, except this peer.
+func (r *routeRemote) crawlDirectoryPeers(ctx context.Context, maxPeers int) ([]peer.AddrInfo, error) {
+	h := r.server.Host()
+
+	c, err := crawler.NewDefaultCrawler(h, crawler.WithProtocols([]protocol.ID{DHTProtocol}))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create DHT crawler: %v", err)
+	}
+
+	crawlCtx, cancel := context.WithTimeout(ctx, BackfillCrawlTimeout)
+	defer cancel()
+
+	var peers []peer.AddrInfo
+
+	seen := make(map[peer.ID]struct{})
+
+	add := func(p peer.ID) {
+		if _, ok := seen[p]; ok || p == h.ID() || len(peers) >= maxPeers {
+			return
+		}
+
+		seen[p] = struct{}{}
+		peers = append(peers, h.Peerstore().PeerInfo(p))
+		r.backfill.update(func(s *routingv1.BackfillStatus) { s.PeersCrawled++ })
+
+		if len(peers) >= maxPeers {
+			cancel()
+		}
+	}
+
+	// Peers of the routing table speak the DHT protocol of the directory network. They are
+	// added up front, as peers do not return the requesting peer, so that the peers of small
+	// networks without other peers to return are not lost.
+	var startingPeers []*peer.AddrInfo
+
+	for _, id := range r.server.DHT().RoutingTable().ListPeers() {
+		add(id)
+
+		info := h.Peerstore().PeerInfo(id)
+		startingPeers = append(startingPeers, &info)
+	}
+
+	if len(peers) < maxPeers {
+		// Results are handled sequentially by the crawler
+		c.Run(crawlCtx, startingPeers, func(p peer.ID, _ []*peer.AddrInfo) { add(p) }, nil)
+	}
+
+	return peers, nil
+}
+
+// backfillFrom pulls up to maxRecords records listed by the provider whose labels are not
+// cached yet, one per tick of pace. Returns the number of pulled records.
+func (r *routeRemote) backfillFrom(ctx context.Context, provider peer.AddrInfo, maxRecords int, pace <-chan time.Time) int {
+	providerID := provider.ID.String()
+	pulled := 0
+	cursor := ""
+
+	for page := 0; page < MaxLabelSyncPages && pulled < maxRecords; page++ {
+		resp, err := r.service.SyncLabels(ctx, provider.ID, &rpc.LabelSyncRequest{Cursor: cursor, Limit: LabelSyncPageSize})
+		if err != nil {
+			remoteLogger.Warn("Failed to list the records of a backfill provider", "peer", providerID, "error", err)
+
+			return pulled
+		}
+
+		if page == 0 {
+			r.backfill.update(func(s *routingv1.BackfillStatus) { s.Providers++ })
+		}
+
+		for _, record := range resp.Records {
+			if pulled >= maxRecords {
+				break
+			}
+
+			r.backfill.update(func(s *routingv1.BackfillStatus) { s.RecordsFound++ })
+
+			if r.hasRemoteRecordCached(ctx, record.Cid, providerID) || r.isRevoked(ctx, record.Cid, providerID) {
+				r.backfill.update(func(s *routingv1.BackfillStatus) { s.RecordsSkipped++ })
+
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return pulled
+			case <-pace:
+			}
+
+			err := r.handleCIDProviderNotification(ctx, &handlerSync{
+				Ref:  &corev1.RecordRef{Cid: record.Cid},
+				Peer: provider,
+			})
+			if err != nil {
+				r.backfill.update(func(s *routingv1.BackfillStatus) { s.RecordsFailed++ })
+
+				continue
+			}
+
+			pulled++
+
+			r.backfill.update(func(s *routingv1.BackfillStatus) { s.RecordsPulled++ })
+		}
+
+		if resp.NextCursor == "" {
+			break
+		}
+
+		cursor = resp.NextCursor
+	}
+
+	return pulled
+}
@@ -0,0 +1,94 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBackfillOptions(t *testing.T) {
+	assert.Equal(t, backfillOptions{
+		maxPeers:       DefaultBackfillMaxPeers,
+		maxRecords:     DefaultBackfillMaxRecords,
+		pullsPerSecond: DefaultBackfillPullsPerSecond,
+	}, newBackfillOptions(&routingv1.StartBackfillRequest{}))
+
+	assert.Equal(t, backfillOptions{maxPeers: 3, maxRecords: 20, pullsPerSecond: 1}, newBackfillOptions(&routingv1.StartBackfillRequest{
+		MaxPeers:       3,
+		MaxRecords:     20,
+		PullsPerSecond: 1,
+	}))
+}
+
+func TestBackfillJob(t *testing.T) {
+	var job backfillJob
+
+	assert.Nil(t, job.snapshot())
+
+	now := time.Now()
+	require.True(t, job.start(now))
+	assert.False(t, job.start(now), "only one backfill runs at a time")
+
+	job.update(func(s *routingv1.BackfillStatus) { s.RecordsPulled++ })
+	job.finish(now.Add(time.Minute), errors.New("crawl failed"))
+
+	status := job.snapshot()
+	assert.False(t, status.GetRunning())
+	assert.Equal(t, uint32(1), status.GetRecordsPulled())
+	assert.Equal(t, "crawl failed", status.GetError())
+	assert.Equal(t, now.Add(time.Minute).Unix(), status.GetFinishedAt().AsTime().Unix())
+
+	// Finished backfills can be restarted with a fresh status
+	require.True(t, job.start(now))
+	assert.Zero(t, job.snapshot().GetRecordsPulled())
+}
+
+func TestRunBackfill(t *testing.T) {
+	testRecord := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-backfill-agent",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+
+	firstNode := newTestServer(t, t.Context(), nil)
+	secondNode := newTestServer(t, t.Context(), firstNode.remote.server.P2pAddrs())
+
+	// wait for connection
+	time.Sleep(time.Second)
+	<-firstNode.remote.server.DHT().RefreshRoutingTable()
+	<-secondNode.remote.server.DHT().RefreshRoutingTable()
+
+	// The record is only published locally, so the first node does not learn about it
+	_, err := secondNode.remote.storeAPI.Push(t.Context(), testRecord)
+	require.NoError(t, err)
+	require.NoError(t, secondNode.local.Publish(t.Context(), adapters.NewRecordAdapter(testRecord)))
+
+	secondID := secondNode.remote.server.Host().ID().String()
+	require.False(t, firstNode.remote.hasRemoteRecordCached(t.Context(), testRecord.GetCid(), secondID))
+
+	require.True(t, firstNode.remote.backfill.start(time.Now()))
+	require.NoError(t, firstNode.remote.runBackfill(t.Context(), backfillOptions{maxPeers: 10, maxRecords: 10, pullsPerSecond: 100}))
+
+	assert.True(t, firstNode.remote.hasRemoteRecordCached(t.Context(), testRecord.GetCid(), secondID))
+
+	status := firstNode.remote.backfill.snapshot()
+	assert.Equal(t, uint32(1), status.GetPeersCrawled())
+	assert.Equal(t, uint32(1), status.GetProviders())
+	assert.Equal(t, uint32(1), status.GetRecordsPulled())
+
+	// Cached records are not pulled again
+	require.NoError(t, firstNode.remote.runBackfill(t.Context(), backfillOptions{maxPeers: 10, maxRecords: 10, pullsPerSecond: 100}))
+	assert.Equal(t, uint32(1), firstNode.remote.backfill.snapshot().GetRecordsSkipped())
+}
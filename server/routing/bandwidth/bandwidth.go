@@ -0,0 +1,203 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bandwidth meters the record content served to each remote peer by the
+// hour and enforces an hourly quota per peer, so that operators can spot and stop
+// peers abusing the free content distribution of the directory.
+//
+// Usage is kept in memory for the last RetainedHours hours. Quotas are soft: a
+// pull is refused once the peer was served its quota within the current hour,
+// so the pull crossing the quota is still served in full.
+package bandwidth
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// RetainedHours is the number of hours the usage of each peer is kept for.
+	RetainedHours = 24
+
+	// maxTrackedPeers is the number of peers above which peers without retained usage are forgotten.
+	maxTrackedPeers = 10000
+)
+
+// HourUsage is the usage of a peer within an hour.
+type HourUsage struct {
+	Hour         time.Time // Start of the hour
+	Bytes        uint64    // Bytes of record content served
+	Pulls        uint64    // Pulls served
+	RefusedPulls uint64    // Pulls refused as the quota was exceeded
+}
+
+// PeerUsage is the usage of a peer within the retained hours.
+type PeerUsage struct {
+	PeerID           string
+	Bytes            uint64
+	Pulls            uint64
+	RefusedPulls     uint64
+	CurrentHourBytes uint64
+	Hours            []HourUsage // Hours with usage, oldest first
+}
+
+// Meter meters the bytes served to each peer by the hour. It is safe for concurrent use.
+// A nil Meter meters nothing and allows all pulls.
+type Meter struct {
+	mu    sync.Mutex
+	quota uint64
+	peers map[string]*[RetainedHours]HourUsage // Ring of hours, indexed by hour
+}
+
+// New creates a meter refusing pulls of peers that were served quota bytes within the
+// current hour. A zero quota only meters usage.
+func New(quota uint64) *Meter {
+	return &Meter{
+		quota: quota,
+		peers: make(map[string]*[RetainedHours]HourUsage),
+	}
+}
+
+// Quota returns the bytes each peer may be served per hour, zero if unlimited.
+func (m *Meter) Quota() uint64 {
+	if m == nil {
+		return 0
+	}
+
+	return m.quota
+}
+
+// Allow reports whether the peer may pull at the given time, counting refused pulls.
+func (m *Meter) Allow(peerID string, now time.Time) bool {
+	if m == nil || m.quota == 0 {
+		return true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hour := m.hour(peerID, now)
+	if hour.Bytes < m.quota {
+		return true
+	}
+
+	hour.RefusedPulls++
+
+	return false
+}
+
+// Record records a pull of the peer served with the given number of bytes.
+func (m *Meter) Record(peerID string, bytes uint64, now time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hour := m.hour(peerID, now)
+	hour.Bytes += bytes
+	hour.Pulls++
+}
+
+// Usage returns the usage of the peers within the retained hours, by bytes served, most first.
+func (m *Meter) Usage(now time.Time) []PeerUsage {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current := now.Truncate(time.Hour)
+	usage := make([]PeerUsage, 0, len(m.peers))
+
+	for peerID, ring := range m.peers {
+		peer := PeerUsage{PeerID: peerID}
+
+		for _, hour := range retained(ring, current) {
+			peer.Bytes += hour.Bytes
+			peer.Pulls += hour.Pulls
+			peer.RefusedPulls += hour.RefusedPulls
+			peer.Hours = append(peer.Hours, hour)
+
+			if hour.Hour.Equal(current) {
+				peer.CurrentHourBytes = hour.Bytes
+			}
+		}
+
+		if len(peer.Hours) > 0 {
+			usage = append(usage, peer)
+		}
+	}
+
+	slices.SortFunc(usage, func(a, b PeerUsage) int {
+		switch {
+		case a.Bytes > b.Bytes:
+			return -1
+		case a.Bytes < b.Bytes:
+			return 1
+		case a.PeerID < b.PeerID:
+			return -1
+		default:
+			return 1
+		}
+	})
+
+	return usage
+}
+
+// hour returns the usage of the peer within the hour of now, resetting stale ring slots.
+func (m *Meter) hour(peerID string, now time.Time) *HourUsage {
+	current := now.Truncate(time.Hour)
+
+	ring, ok := m.peers[peerID]
+	if !ok {
+		if len(m.peers) >= maxTrackedPeers {
+			m.prune(current)
+		}
+
+		ring = &[RetainedHours]HourUsage{}
+		m.peers[peerID] = ring
+	}
+
+	hour := &ring[current.Unix()/int64(time.Hour/time.Second)%RetainedHours]
+	if !hour.Hour.Equal(current) {
+		*hour = HourUsage{Hour: current}
+	}
+
+	return hour
+}
+
+// prune forgets peers without usage within the retained hours.
+func (m *Meter) prune(current time.Time) {
+	for peerID, ring := range m.peers {
+		if len(retained(ring, current)) == 0 {
+			delete(m.peers, peerID)
+		}
+	}
+}
+
+// retained returns the hours of the ring with usage within the retained hours up to current, oldest first.
+func retained(ring *[RetainedHours]HourUsage, current time.Time) []HourUsage {
+	oldest := current.Add(-(RetainedHours - 1) * time.Hour)
+
+	var hours []HourUsage
+
+	for _, hour := range ring {
+		if hour.Hour.IsZero() || hour.Hour.Before(oldest) || hour.Hour.After(current) {
+			continue
+		}
+
+		if hour.Bytes > 0 || hour.Pulls > 0 || hour.RefusedPulls > 0 {
+			hours = append(hours, hour)
+		}
+	}
+
+	slices.SortFunc(hours, func(a, b HourUsage) int {
+		return a.Hour.Compare(b.Hour)
+	})
+
+	return hours
+}
@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package bandwidth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeter_Quota(t *testing.T) {
+	meter := New(100)
+	now := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	// The pull crossing the quota is served, later pulls of the hour are refused
+	assert.True(t, meter.Allow("peer1", now))
+	meter.Record("peer1", 80, now)
+	assert.True(t, meter.Allow("peer1", now))
+	meter.Record("peer1", 80, now)
+	assert.False(t, meter.Allow("peer1", now))
+
+	// Peers have separate quotas
+	assert.True(t, meter.Allow("peer2", now))
+
+	// The quota is reset every hour
+	assert.True(t, meter.Allow("peer1", now.Add(30*time.Minute)))
+}
+
+func TestMeter_Usage(t *testing.T) {
+	meter := New(100)
+	now := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	meter.Record("peer1", 50, now.Add(-2*time.Hour))
+	meter.Record("peer1", 150, now)
+	assert.False(t, meter.Allow("peer1", now))
+	meter.Record("peer2", 500, now)
+	meter.Record("peer3", 10, now.Add(-RetainedHours*time.Hour)) // No longer retained
+
+	usage := meter.Usage(now)
+	require.Len(t, usage, 2)
+
+	assert.Equal(t, "peer2", usage[0].PeerID)
+	assert.Equal(t, PeerUsage{
+		PeerID:           "peer1",
+		Bytes:            200,
+		Pulls:            2,
+		RefusedPulls:     1,
+		CurrentHourBytes: 150,
+		Hours: []HourUsage{
+			{Hour: time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC), Bytes: 50, Pulls: 1},
+			{Hour: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), Bytes: 150, Pulls: 1, RefusedPulls: 1},
+		},
+	}, usage[1])
+
+	// Slots of the ring are reused a day later
+	meter.Record("peer1", 5, now.Add(22*time.Hour))
+	usage = meter.Usage(now.Add(22 * time.Hour))
+	assert.Equal(t, uint64(155), usage[len(usage)-1].Bytes)
+}
+
+func TestMeter_Disabled(t *testing.T) {
+	var meter *Meter
+
+	meter.Record("peer1", 100, time.Now())
+	assert.True(t, meter.Allow("peer1", time.Now()))
+	assert.Nil(t, meter.Usage(time.Now()))
+	assert.Zero(t, meter.Quota())
+
+	// Without a quota usage is only metered
+	meter = New(0)
+	meter.Record("peer1", 1<<40, time.Now())
+	assert.True(t, meter.Allow("peer1", time.Now()))
+	assert.Len(t, meter.Usage(time.Now()), 1)
+}
@@ -0,0 +1,272 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cachedRecords groups the cached labels of remote records by CID/PeerID key.
+// Labels of the local peer are skipped.
+func cachedRecords(entries []NamespaceEntry, localPeerID string) []*cachedRecord {
+	records := make(map[string]*cachedRecord)
+
+	for _, entry := range entries {
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID == localPeerID {
+			continue
+		}
+
+		recordKey := keyCID + "/" + keyPeerID
+
+		record, ok := records[recordKey]
+		if !ok {
+			record = &cachedRecord{key: recordKey, cid: keyCID, peerID: keyPeerID}
+			records[recordKey] = record
+		}
+
+		record.labelKeys = append(record.labelKeys, entry.Key)
+	}
+
+	return slices.Collect(maps.Values(records))
+}
+
+// sampleRecords returns up to size records chosen uniformly at random.
+func sampleRecords(records []*cachedRecord, size int) []*cachedRecord {
+	if len(records) <= size {
+		return records
+	}
+
+	sample := make([]*cachedRecord, 0, size)
+	for _, i := range rand.Perm(len(records))[:size] {
+		sample = append(sample, records[i])
+	}
+
+	return sample
+}
+
+// recordsByProvider groups records by the peer that announced them.
+func recordsByProvider(records []*cachedRecord) map[string][]*cachedRecord {
+	providers := make(map[string][]*cachedRecord)
+	for _, record := range records {
+		providers[record.peerID] = append(providers[record.peerID], record)
+	}
+
+	return providers
+}
+
+// VerifyCache verifies a random sample of the cached remote records by asking their
+// providers whether they still store them, and optionally evicts the labels of records
+// that they no longer store. Pinned records are never evicted.
+func (r *routeRemote) VerifyCache(ctx context.Context, req *routingv1.VerifyCacheRequest) (*routingv1.VerifyCacheResponse, error) {
+	sampleSize := DefaultCacheVerificationSample
+	if req.SampleSize != nil {
+		sampleSize = int(req.GetSampleSize())
+	}
+
+	if sampleSize <= 0 || sampleSize > MaxCacheVerificationSample {
+		return nil, status.Errorf(codes.InvalidArgument, "sample size must be between 1 and %d", MaxCacheVerificationSample)
+	}
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query label cache: %v", err)
+	}
+
+	records := cachedRecords(entries, r.server.Host().ID().String())
+	sample := sampleRecords(records, sampleSize)
+
+	remoteLogger.Info("Verifying label cache against providers", "records", len(sample), "cachedRecords", len(records))
+
+	resp := &routingv1.VerifyCacheResponse{CachedRecords: uint32(len(records))} //nolint:gosec
+
+	var missing []*cachedRecord
+
+	for record, result := range r.checkProviders(ctx, sample) {
+		metrics.CacheVerifications.WithLabelValues(result).Inc()
+
+		switch result {
+		case metrics.ResultPresent:
+			resp.Verified++
+			resp.Present++
+		case metrics.ResultMissing:
+			resp.Verified++
+			resp.Missing++
+
+			missing = append(missing, record)
+		default:
+			resp.Unreachable++
+		}
+	}
+
+	slices.SortFunc(missing, func(a, b *cachedRecord) int {
+		return cmp.Or(cmp.Compare(a.cid, b.cid), cmp.Compare(a.peerID, b.peerID))
+	})
+
+	for _, record := range missing {
+		resp.MissingRecords = append(resp.MissingRecords, &routingv1.CachedRecord{Cid: record.cid, PeerId: record.peerID})
+	}
+
+	if req.GetEvict() {
+		evictedLabels, err := r.evictUnavailable(ctx, missing)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		resp.EvictedLabels = uint32(evictedLabels) //nolint:gosec
+	}
+
+	remoteLogger.Info("Verified label cache against providers",
+		"present", resp.GetPresent(), "missing", resp.GetMissing(),
+		"unreachable", resp.GetUnreachable(), "evictedLabels", resp.GetEvictedLabels())
+
+	return resp, nil
+}
+
+// checkProviders asks the providers of the records whether they still store them.
+// Returns the metrics result of each record: ResultPresent, ResultMissing, or
+// ResultUnknown if its provider could not be asked.
+func (r *routeRemote) checkProviders(ctx context.Context, records []*cachedRecord) map[*cachedRecord]string {
+	results := make(map[*cachedRecord]string, len(records))
+
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, CacheVerificationConcurrency)
+
+	var wg sync.WaitGroup
+
+	for peerID, peerRecords := range recordsByProvider(records) {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+
+			return results
+		}
+
+		wg.Add(1)
+
+		go func(peerID string, peerRecords []*cachedRecord) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			checkCtx, cancel := context.WithTimeout(ctx, CacheVerificationTimeout)
+			defer cancel()
+
+			has, err := r.askProvider(checkCtx, peerID, peerRecords)
+			if err != nil {
+				remoteLogger.Debug("Failed to verify cached records with provider", "peer", peerID, "error", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for i, record := range peerRecords {
+				switch {
+				case err != nil:
+					results[record] = metrics.ResultUnknown
+				case has[i]:
+					results[record] = metrics.ResultPresent
+				default:
+					results[record] = metrics.ResultMissing
+				}
+			}
+		}(peerID, peerRecords)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// askProvider asks a provider which of its records it still stores, in chunks of at most
+// rpc.MaxHasCids CIDs. Returns one result per record in order.
+func (r *routeRemote) askProvider(ctx context.Context, peerID string, records []*cachedRecord) ([]bool, error) {
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid peer ID: %w", err)
+	}
+
+	has := make([]bool, 0, len(records))
+
+	for chunk := range slices.Chunk(records, rpc.MaxHasCids) {
+		cids := make([]string, 0, len(chunk))
+		for _, record := range chunk {
+			cids = append(cids, record.cid)
+		}
+
+		chunkHas, err := r.service.Has(ctx, pid, cids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to ask provider: %w", err)
+		}
+
+		has = append(has, chunkHas...)
+	}
+
+	return has, nil
+}
+
+// evictUnavailable deletes the cached labels of records that their providers no longer store
+// in a single journaled mutation. Pinned records are kept. Returns the number of evicted labels.
+func (r *routeRemote) evictUnavailable(ctx context.Context, records []*cachedRecord) (int, error) {
+	return r.evictRecords(ctx, records, metrics.CleanupUnavailableLabel)
+}
+
+// evictRecords deletes the cached labels of records in a single journaled mutation, counting
+// them as removed for the given cleanup kind. Pinned records are kept. Returns the number of
+// evicted labels.
+func (r *routeRemote) evictRecords(ctx context.Context, records []*cachedRecord, kind string) (int, error) {
+	eviction := &cacheMutation{}
+	evicted := make([]*cachedRecord, 0, len(records))
+	evictedKeys := make([]string, 0, len(records))
+	evictedLabels := 0
+
+	for _, record := range records {
+		if r.pins.has(record.cid) {
+			continue
+		}
+
+		for _, key := range record.labelKeys {
+			eviction.delete(key)
+		}
+
+		evicted = append(evicted, record)
+		evictedKeys = append(evictedKeys, record.key)
+		evictedLabels += len(record.labelKeys)
+	}
+
+	if evictedLabels == 0 {
+		return 0, nil
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, eviction); err != nil {
+		return 0, fmt.Errorf("failed to evict %s records: %w", kind, err)
+	}
+
+	for _, record := range evicted {
+		r.peerStats.AddLabels(record.peerID, -len(record.labelKeys))
+	}
+
+	r.cacheUsage.forget(evictedKeys)
+
+	metrics.CleanupRemoved.WithLabelValues(kind).Add(float64(evictedLabels))
+
+	remoteLogger.Info("Evicted remote records from the label cache",
+		"kind", kind, "records", len(evicted), "labels", evictedLabels)
+
+	return evictedLabels, nil
+}
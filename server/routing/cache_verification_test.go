@@ -0,0 +1,53 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedRecords(t *testing.T) {
+	entries := []NamespaceEntry{
+		{Key: "/skills/AI/cid1/peer1", Value: []byte(`{}`)},
+		{Key: "/domains/research/cid1/peer1", Value: []byte(`{}`)},
+		{Key: "/skills/AI/cid1/peer2", Value: []byte(`{}`)},
+		{Key: "/skills/AI/cid2/local", Value: []byte(`{}`)}, // local record
+		{Key: "/invalid", Value: []byte(`{}`)},
+	}
+
+	records := cachedRecords(entries, "local")
+	slices.SortFunc(records, func(a, b *cachedRecord) int { return strings.Compare(a.key, b.key) })
+
+	require.Len(t, records, 2)
+	assert.Equal(t, &cachedRecord{
+		key:       "cid1/peer1",
+		cid:       "cid1",
+		peerID:    "peer1",
+		labelKeys: []string{"/skills/AI/cid1/peer1", "/domains/research/cid1/peer1"},
+	}, records[0])
+	assert.Equal(t, "cid1/peer2", records[1].key)
+
+	providers := recordsByProvider(records)
+	assert.Equal(t, []*cachedRecord{records[0]}, providers["peer1"])
+	assert.Equal(t, []*cachedRecord{records[1]}, providers["peer2"])
+}
+
+func TestSampleRecords(t *testing.T) {
+	records := []*cachedRecord{{key: "a"}, {key: "b"}, {key: "c"}, {key: "d"}}
+
+	assert.Equal(t, records, sampleRecords(records, 10))
+
+	sample := sampleRecords(records, 2)
+	require.Len(t, sample, 2)
+	assert.NotEqual(t, sample[0], sample[1])
+
+	for _, record := range sample {
+		assert.Contains(t, records, record)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// snapshotCursorHash binds snapshot cursors so they cannot be reused as search page tokens.
+const snapshotCursorHash = "snapshot"
+
+// serveLabelSnapshot serves a page of the label cache to a remote peer warming its cache.
+// Entries are returned in Search order, so the cursor is the last returned key.
+// Labels of tenant records are not served, as snapshot entries do not carry a tenant.
+func (r *routeRemote) serveLabelSnapshot(ctx context.Context, token string, limit int) (*rpc.SnapshotResponse, error) {
+	cursor, err := decodeSearchCursor(token, snapshotCursorHash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid snapshot cursor: %v", err)
+	}
+
+	entries, err := queryNamespacesFrom(ctx, r.dstore, "", cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &rpc.SnapshotResponse{
+		PeerAddrs: make(map[string][]byte),
+	}
+
+	if len(entries) > limit {
+		last := entries[limit-1]
+		resp.NextCursor = encodeSearchCursor(&searchCursor{
+			Namespace: namespaceIndexOf(last.Namespace),
+			Key:       last.Key,
+			QueryHash: snapshotCursorHash,
+		})
+		entries = entries[:limit]
+	}
+
+	for _, entry := range entries {
+		_, _, peerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil {
+			continue
+		}
+
+		resp.Entries = append(resp.Entries, rpc.SnapshotEntry{Key: entry.Key, Value: entry.Value})
+
+		if _, ok := resp.PeerAddrs[peerID]; ok {
+			continue
+		}
+
+		// Addresses are served as a plain list of multiaddrs, which peers
+		// without an address book can still import.
+		if addrs := r.peerMultiaddrs(ctx, peerID); len(addrs) > 0 {
+			if data, err := json.Marshal(addrs); err == nil {
+				resp.PeerAddrs[peerID] = data
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// peerMultiaddrs returns the multiaddrs of a peer from the address book, nil if unknown.
+func (r *routeRemote) peerMultiaddrs(ctx context.Context, peerID string) []string {
+	entry, err := r.addressBook.Get(ctx, peerID)
+	if err != nil || entry == nil {
+		return nil
+	}
+
+	addrs := make([]string, 0, len(entry.Multiaddrs()))
+	for _, addr := range entry.Multiaddrs() {
+		addrs = append(addrs, addr.String())
+	}
+
+	return addrs
+}
+
+// startCacheWarming warms the remote label cache from the seed peer in the background.
+// Search waits until warming completes, fails, or times out.
+func (r *routeRemote) startCacheWarming(seedPeer string) {
+	r.cacheWarmed = make(chan struct{})
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		defer close(r.cacheWarmed)
+
+		ctx, cancel := context.WithTimeout(r.ctx, CacheWarmTimeout)
+		defer cancel()
+
+		imported, err := r.warmCache(ctx, seedPeer)
+		if err != nil {
+			remoteLogger.Warn("Failed to warm label cache from seed peer",
+				"seedPeer", seedPeer,
+				"imported", imported,
+				"error", err)
+
+			return
+		}
+
+		remoteLogger.Info("Warmed label cache from seed peer", "seedPeer", seedPeer, "imported", imported)
+	}()
+}
+
+// waitForCacheWarming blocks until cache warming is done or the context is canceled.
+func (r *routeRemote) waitForCacheWarming(ctx context.Context) error {
+	if r.cacheWarmed == nil {
+		return nil
+	}
+
+	select {
+	case <-r.cacheWarmed:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for label cache warming: %w", ctx.Err())
+	}
+}
+
+// warmCache imports the seed peer's label cache page by page.
+// Warming only happens on first boot, i.e. when no remote labels are cached yet.
+// Returns the number of imported label entries.
+func (r *routeRemote) warmCache(ctx context.Context, seedPeer string) (int, error) {
+	localPeerID := r.server.Host().ID().String()
+
+	if r.hasCachedRemoteLabels(ctx, localPeerID) {
+		remoteLogger.Info("Remote label cache is not empty, skipping cache warming", "seedPeer", seedPeer)
+
+		return 0, nil
+	}
+
+	seed, err := peer.AddrInfoFromString(seedPeer)
+	if err != nil {
+		return 0, fmt.Errorf("invalid seed peer address: %w", err)
+	}
+
+	if seed.ID == r.server.Host().ID() {
+		return 0, errors.New("seed peer is the local peer")
+	}
+
+	if err := r.server.Host().Connect(ctx, *seed); err != nil {
+		return 0, fmt.Errorf("failed to connect to seed peer: %w", err)
+	}
+
+	imported := 0
+	cursor := ""
+
+	for imported < MaxCacheWarmEntries {
+		resp, err := r.service.Snapshot(ctx, seed.ID, &rpc.SnapshotRequest{Cursor: cursor, Limit: CacheWarmPageSize})
+		if err != nil {
+			return imported, fmt.Errorf("failed to fetch label cache snapshot: %w", err)
+		}
+
+		imported += r.importSnapshot(ctx, resp, localPeerID)
+
+		if resp.NextCursor == "" {
+			break
+		}
+
+		cursor = resp.NextCursor
+	}
+
+	return imported, nil
+}
+
+// importSnapshot stores the valid, fresh label entries of a snapshot page that are
+// not cached yet, together with the addresses of the peers they belong to.
+// Pages come from the seed peer or from imported state archives.
+// Entries of the local peer are skipped since local records are authoritative,
+// and entries of revoked and expired records are skipped.
+func (r *routeRemote) importSnapshot(ctx context.Context, resp *rpc.SnapshotResponse, localPeerID string) int {
+	// The labels of a page are stored as a single journaled mutation
+	labels := &cacheMutation{}
+	added := make(map[string]int)
+
+	for _, entry := range resp.Entries {
+		_, cid, peerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || peerID == localPeerID || r.isRevoked(ctx, cid, peerID) {
+			continue
+		}
+
+		var metadata types.LabelMetadata
+		if err := json.Unmarshal(entry.Value, &metadata); err != nil || metadata.Validate() != nil {
+			continue
+		}
+
+		if time.Since(metadata.LastSeen) > MaxLabelAge || metadata.IsExpired(time.Now()) {
+			continue
+		}
+
+		// The source peer's timestamps are bounded to the local clock. Publishers are only
+		// trusted from their signed announcements, which snapshots do not carry.
+		value := entry.Value
+
+		attributed := metadata.Publisher != ""
+		metadata.Publisher = ""
+
+		if clampToLocalClock(&metadata, time.Now()) || attributed {
+			if value, err = json.Marshal(&metadata); err != nil {
+				continue
+			}
+		}
+
+		key := datastore.NewKey(entry.Key)
+
+		if exists, err := r.dstore.Has(ctx, key); err != nil || exists {
+			continue
+		}
+
+		labels.put(entry.Key, value)
+		added[peerID]++
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, labels); err != nil {
+		remoteLogger.Warn("Failed to import labels", "error", err)
+
+		return 0
+	}
+
+	for peerID, count := range added {
+		r.peerStats.AddLabels(peerID, count)
+	}
+
+	for peerID, addrs := range resp.PeerAddrs {
+		if peerID == localPeerID {
+			continue
+		}
+
+		// Addresses known locally are fresher than the source peer's
+		if r.addressBook.Has(ctx, peerID) {
+			continue
+		}
+
+		entry, err := addressbook.Decode(addrs, time.Now())
+		if err != nil {
+			remoteLogger.Warn("Invalid imported peer addresses", "peer", peerID, "error", err)
+
+			continue
+		}
+
+		if err := r.addressBook.Add(ctx, peerID, entry.Multiaddrs(), addressbook.PrioritySeedPeer); err != nil {
+			remoteLogger.Warn("Failed to import peer addresses", "peer", peerID, "error", err)
+		}
+	}
+
+	return len(labels.Puts)
+}
+
+// hasCachedRemoteLabels reports whether any label of a remote peer is cached.
+func (r *routeRemote) hasCachedRemoteLabels(ctx context.Context, localPeerID string) bool {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		_, _, peerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err == nil && peerID != localPeerID {
+			return true
+		}
+	}
+
+	return false
+}
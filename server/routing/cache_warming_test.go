@@ -0,0 +1,131 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func putTestLabel(t *testing.T, dstore types.Datastore, label, cid, peerID string, lastSeen time.Time) {
+	t.Helper()
+
+	metadata, err := json.Marshal(&types.LabelMetadata{Timestamp: lastSeen, LastSeen: lastSeen})
+	require.NoError(t, err)
+
+	key := BuildEnhancedLabelKey(types.Label(label), cid, peerID)
+	require.NoError(t, dstore.Put(t.Context(), ipfsdatastore.NewKey(key), metadata))
+}
+
+func TestServeLabelSnapshot_Pagination(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	for i := range 5 {
+		putTestLabel(t, dstore, "/skills/AI", fmt.Sprintf("cid%d", i), "peer1", time.Now())
+	}
+
+	putTestLabel(t, dstore, "/domains/research", "cid0", "peer1", time.Now())
+	r := &routeRemote{dstore: dstore, addressBook: addressbook.New(dstore, PeerAddressTTL)}
+	require.NoError(t, r.addressBook.Add(t.Context(), "peer1", []ma.Multiaddr{ma.StringCast("/ip4/10.0.0.1/tcp/8999")}, addressbook.PriorityIdentify))
+
+	var (
+		keys   []string
+		cursor string
+		pages  int
+	)
+
+	for {
+		resp, err := r.serveLabelSnapshot(t.Context(), cursor, 2)
+		require.NoError(t, err)
+
+		pages++
+
+		for _, entry := range resp.Entries {
+			keys = append(keys, entry.Key)
+		}
+
+		assert.Contains(t, resp.PeerAddrs, "peer1")
+
+		if resp.NextCursor == "" {
+			break
+		}
+
+		cursor = resp.NextCursor
+	}
+
+	assert.Len(t, keys, 6)
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, keys, slices.Compact(slices.Clone(keys)), "snapshot pages must not overlap")
+}
+
+func TestServeLabelSnapshot_RejectsSearchToken(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{dstore: dstore}
+
+	token := encodeSearchCursor(&searchCursor{Key: "/skills/AI/cid/peer", QueryHash: "0123456789abcdef"})
+
+	_, err := r.serveLabelSnapshot(t.Context(), token, 10)
+	assert.Error(t, err)
+}
+
+func TestImportSnapshot(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{dstore: dstore, addressBook: addressbook.New(dstore, PeerAddressTTL)}
+
+	fresh, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+	require.NoError(t, err)
+
+	stale, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now().Add(-2 * MaxLabelAge), LastSeen: time.Now().Add(-2 * MaxLabelAge)})
+	require.NoError(t, err)
+
+	resp := &rpc.SnapshotResponse{
+		Entries: []rpc.SnapshotEntry{
+			{Key: "/skills/AI/cid1/peer1", Value: fresh},
+			{Key: "/skills/AI/cid2/peer1", Value: stale},             // too old
+			{Key: "/skills/AI/cid3/local", Value: fresh},             // local peer
+			{Key: "/skills/AI/cid4/peer2", Value: []byte("garbage")}, // invalid metadata
+			{Key: "not-a-label-key", Value: fresh},                   // invalid key
+		},
+		PeerAddrs: map[string][]byte{
+			"peer1": []byte(`["/ip4/10.0.0.1/tcp/8999"]`),
+			"local": []byte(`["/ip4/10.0.0.2/tcp/8999"]`),
+		},
+	}
+
+	imported := r.importSnapshot(t.Context(), resp, "local")
+	assert.Equal(t, 1, imported)
+
+	exists, err := dstore.Has(t.Context(), ipfsdatastore.NewKey("/skills/AI/cid1/peer1"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = dstore.Has(t.Context(), ipfsdatastore.NewKey("peer_addrs/peer1"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = dstore.Has(t.Context(), ipfsdatastore.NewKey("peer_addrs/local"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	assert.True(t, r.hasCachedRemoteLabels(t.Context(), "local"))
+
+	// Importing the same snapshot again does not overwrite cached entries
+	assert.Equal(t, 0, r.importSnapshot(t.Context(), resp, "local"))
+}
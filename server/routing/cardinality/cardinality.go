@@ -0,0 +1,210 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cardinality estimates how many records match routing queries
+// without scanning the label cache.
+//
+// An Index keeps one HyperLogLog sketch of record CIDs per cached label, and
+// one of all cached records. The records matching a simple query are the union
+// of the sketches of its matching labels, which HyperLogLog merges losslessly.
+// Boolean groups and minimum match scores above one cannot be derived from
+// unions alone; they are estimated assuming that queries match independently.
+package cardinality
+
+import (
+	"math"
+	"sync"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// Matcher reports whether a query without boolean group matches a label.
+type Matcher func(query *routingv1.RecordQuery, label string) bool
+
+// Visibility reports whether the records carrying a label are visible to a search.
+type Visibility func(label string) bool
+
+// Index holds the cardinality sketches of the label cache.
+// It is safe for concurrent use.
+type Index struct {
+	mu      sync.RWMutex
+	labels  map[string]*Sketch // Records per label
+	records *Sketch            // All records
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{
+		labels:  make(map[string]*Sketch),
+		records: NewSketch(),
+	}
+}
+
+// Add records that the record with the given CID carries the label.
+func (i *Index) Add(label, cid string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	sketch, ok := i.labels[label]
+	if !ok {
+		sketch = NewSketch()
+		i.labels[label] = sketch
+	}
+
+	sketch.Add(cid)
+	i.records.Add(cid)
+}
+
+// Replace replaces the sketches of the index with those of other, which must not be used afterwards.
+// Sketches never forget items, so the index is periodically rebuilt from
+// the label cache and replaced to drop removed labels.
+func (i *Index) Replace(other *Index) {
+	other.mu.RLock()
+	labels, records := other.labels, other.records
+	other.mu.RUnlock()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.labels = labels
+	i.records = records
+}
+
+// Labels returns the number of labels in the index.
+func (i *Index) Labels() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.labels)
+}
+
+// Estimate returns the estimated number of records matching at least minMatchScore
+// of the queries, and the estimated number of records in the index visible to the search,
+// i.e. carrying a visible label. A nil visibility makes all records visible.
+// Records match nothing without queries, as in Search.
+func (i *Index) Estimate(queries []*routingv1.RecordQuery, minMatchScore int, match Matcher, visible Visibility) (uint64, uint64) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	total := i.visibleRecords(visible)
+	if total == 0 || len(queries) == 0 || minMatchScore > len(queries) {
+		return 0, total
+	}
+
+	// Records matching any simple query are the union of their matching labels
+	if minMatchScore <= 1 && !hasGroups(queries) {
+		union := NewSketch()
+		for _, query := range queries {
+			union.Merge(i.matching(query, match))
+		}
+
+		return min(union.Estimate(), total), total
+	}
+
+	probabilities := make([]float64, len(queries))
+	for k, query := range queries {
+		probabilities[k] = i.probability(query, total, match)
+	}
+
+	estimate := math.Round(float64(total) * atLeast(probabilities, minMatchScore))
+
+	return min(uint64(estimate), total), total
+}
+
+// visibleRecords returns the estimated number of records carrying a visible label.
+func (i *Index) visibleRecords(visible Visibility) uint64 {
+	if visible == nil {
+		return i.records.Estimate()
+	}
+
+	union := NewSketch()
+
+	for label, sketch := range i.labels {
+		if visible(label) {
+			union.Merge(sketch)
+		}
+	}
+
+	return union.Estimate()
+}
+
+// matching returns the union of the sketches of the labels matching a simple query.
+func (i *Index) matching(query *routingv1.RecordQuery, match Matcher) *Sketch {
+	union := NewSketch()
+
+	for label, sketch := range i.labels {
+		if match(query, label) {
+			union.Merge(sketch)
+		}
+	}
+
+	return union
+}
+
+// probability returns the estimated fraction of records matching a query.
+func (i *Index) probability(query *routingv1.RecordQuery, total uint64, match Matcher) float64 {
+	group := query.GetGroup()
+	if group == nil {
+		return min(float64(i.matching(query, match).Estimate())/float64(total), 1)
+	}
+
+	children := make([]float64, len(group.GetQueries()))
+	for k, child := range group.GetQueries() {
+		children[k] = i.probability(child, total, match)
+	}
+
+	switch group.GetOperator() {
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND:
+		return product(children, func(p float64) float64 { return p })
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR:
+		return 1 - product(children, func(p float64) float64 { return 1 - p })
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT:
+		return product(children, func(p float64) float64 { return 1 - p })
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED:
+		return 0
+	default:
+		return 0
+	}
+}
+
+func hasGroups(queries []*routingv1.RecordQuery) bool {
+	for _, query := range queries {
+		if query.GetGroup() != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func product(values []float64, fn func(float64) float64) float64 {
+	result := 1.0
+	for _, v := range values {
+		result *= fn(v)
+	}
+
+	return result
+}
+
+// atLeast returns the probability that at least k of the independent
+// events with the given probabilities occur (Poisson binomial distribution).
+func atLeast(probabilities []float64, k int) float64 {
+	// exactly[j] is the probability that exactly j of the events seen so far occurred
+	exactly := make([]float64, len(probabilities)+1)
+	exactly[0] = 1
+
+	for n, p := range probabilities {
+		for j := n + 1; j > 0; j-- {
+			exactly[j] = exactly[j]*(1-p) + exactly[j-1]*p
+		}
+
+		exactly[0] *= 1 - p
+	}
+
+	var result float64
+	for j := max(k, 0); j < len(exactly); j++ {
+		result += exactly[j]
+	}
+
+	return min(result, 1)
+}
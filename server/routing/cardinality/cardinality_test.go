@@ -0,0 +1,176 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinality
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// matchPrefix matches labels starting with the query value.
+func matchPrefix(query *routingv1.RecordQuery, label string) bool {
+	return strings.HasPrefix(label, query.GetValue())
+}
+
+func labelQuery(value string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: value}
+}
+
+func groupQuery(operator routingv1.RecordQueryOperator, queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{Operator: operator, Queries: queries}}
+}
+
+func TestSketch_SparseIsExact(t *testing.T) {
+	sketch := NewSketch()
+
+	for i := range sparseLimit {
+		sketch.Add(fmt.Sprintf("cid%d", i))
+		sketch.Add(fmt.Sprintf("cid%d", i))
+	}
+
+	assert.Nil(t, sketch.regs)
+	assert.Equal(t, uint64(sparseLimit), sketch.Estimate())
+}
+
+func TestSketch_DenseEstimate(t *testing.T) {
+	for _, n := range []int{1000, 20000, 200000} {
+		sketch := NewSketch()
+		for i := range n {
+			sketch.Add(fmt.Sprintf("cid%d", i))
+		}
+
+		assert.NotNil(t, sketch.regs)
+		assert.InEpsilon(t, n, sketch.Estimate(), 0.05, "n=%d", n)
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	a, b := NewSketch(), NewSketch()
+
+	for i := range 3000 {
+		a.Add(fmt.Sprintf("cid%d", i))
+	}
+
+	for i := 2000; i < 2100; i++ {
+		b.Add(fmt.Sprintf("cid%d", i))
+	}
+
+	b.Add("other")
+
+	// Sparse into dense
+	a.Merge(b)
+	assert.InEpsilon(t, 3001, a.Estimate(), 0.05)
+
+	// Dense into sparse
+	c := NewSketch()
+	c.Merge(a)
+	assert.InEpsilon(t, 3001, c.Estimate(), 0.05)
+}
+
+func TestIndex_Estimate(t *testing.T) {
+	index := NewIndex()
+
+	// 1000 records with /skills/AI, every second of them with /domains/research,
+	// and 500 more records with /domains/research only
+	for i := range 1000 {
+		index.Add("/skills/AI", fmt.Sprintf("cid%d", i))
+
+		if i%2 == 0 {
+			index.Add("/domains/research", fmt.Sprintf("cid%d", i))
+		}
+	}
+
+	for i := 1000; i < 1500; i++ {
+		index.Add("/domains/research", fmt.Sprintf("cid%d", i))
+	}
+
+	ai := labelQuery("/skills/AI")
+	research := labelQuery("/domains/research")
+
+	t.Run("union", func(t *testing.T) {
+		estimated, total := index.Estimate([]*routingv1.RecordQuery{ai, research}, 1, matchPrefix, nil)
+		assert.InEpsilon(t, 1500, total, 0.05)
+		assert.InEpsilon(t, 1500, estimated, 0.05)
+	})
+
+	t.Run("single query", func(t *testing.T) {
+		estimated, _ := index.Estimate([]*routingv1.RecordQuery{research}, 1, matchPrefix, nil)
+		assert.InEpsilon(t, 1000, estimated, 0.05)
+	})
+
+	t.Run("min match score", func(t *testing.T) {
+		// Assuming independence: 1500 * 1000/1500 * 1000/1500
+		estimated, _ := index.Estimate([]*routingv1.RecordQuery{ai, research}, 2, matchPrefix, nil)
+		assert.InEpsilon(t, 667, estimated, 0.1)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		and := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, ai, research)
+		not := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, ai)
+
+		estimated, _ := index.Estimate([]*routingv1.RecordQuery{and}, 1, matchPrefix, nil)
+		assert.InEpsilon(t, 667, estimated, 0.1)
+
+		estimated, _ = index.Estimate([]*routingv1.RecordQuery{not}, 1, matchPrefix, nil)
+		assert.InEpsilon(t, 500, estimated, 0.1)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		estimated, _ := index.Estimate([]*routingv1.RecordQuery{labelQuery("/modules/none")}, 1, matchPrefix, nil)
+		assert.Zero(t, estimated)
+
+		estimated, _ = index.Estimate(nil, 1, matchPrefix, nil)
+		assert.Zero(t, estimated)
+
+		estimated, _ = index.Estimate([]*routingv1.RecordQuery{ai}, 2, matchPrefix, nil)
+		assert.Zero(t, estimated)
+	})
+}
+
+func TestIndex_EstimateVisibleRecords(t *testing.T) {
+	index := NewIndex()
+
+	for i := range 100 {
+		index.Add("/skills/AI", fmt.Sprintf("cid%d", i))
+		index.Add("/tenants/acme/skills/AI", fmt.Sprintf("acme%d", i))
+	}
+
+	shared := func(label string) bool { return !strings.HasPrefix(label, "/tenants/") }
+
+	// Records of labels that are not visible are not counted in the total
+	estimated, total := index.Estimate([]*routingv1.RecordQuery{labelQuery("/skills/AI")}, 1, matchPrefix, shared)
+	assert.Equal(t, uint64(100), total)
+	assert.Equal(t, uint64(100), estimated)
+
+	_, total = index.Estimate(nil, 1, matchPrefix, nil)
+	assert.Equal(t, uint64(200), total)
+}
+
+func TestIndex_Replace(t *testing.T) {
+	index := NewIndex()
+	index.Add("/skills/AI", "cid1")
+	index.Add("/skills/ML", "cid2")
+
+	rebuilt := NewIndex()
+	rebuilt.Add("/skills/AI", "cid1")
+	index.Replace(rebuilt)
+
+	assert.Equal(t, 1, index.Labels())
+
+	_, total := index.Estimate(nil, 1, matchPrefix, nil)
+	assert.Equal(t, uint64(1), total)
+}
+
+func TestAtLeast(t *testing.T) {
+	probabilities := []float64{0.5, 0.5}
+
+	assert.InDelta(t, 1, atLeast(probabilities, 0), 1e-9)
+	assert.InDelta(t, 0.75, atLeast(probabilities, 1), 1e-9)
+	assert.InDelta(t, 0.25, atLeast(probabilities, 2), 1e-9)
+	assert.InDelta(t, 0, atLeast(probabilities, 3), 1e-9)
+}
@@ -0,0 +1,142 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package cardinality
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/cespare/xxhash/v2"
+)
+
+const (
+	// Precision is the number of hash bits selecting a register.
+	// 2^12 registers give a standard error of about 1.6%.
+	Precision = 12
+
+	// registers is the number of registers of a dense sketch.
+	registers = 1 << Precision
+
+	// sparseLimit is the number of hashes kept by a sparse sketch before it
+	// switches to registers. Both representations then use the same memory.
+	sparseLimit = registers / 8
+)
+
+// Sketch is a HyperLogLog sketch estimating the number of distinct items added to it.
+//
+// Small sketches keep the hashes of their items and are exact. Once they hold
+// more than sparseLimit items they switch to HyperLogLog registers, so that the
+// many labels carried by few records stay cheap.
+// A Sketch is not safe for concurrent use.
+type Sketch struct {
+	sparse []uint64 // Sorted hashes, nil once dense
+	regs   []uint8  // HyperLogLog registers, nil while sparse
+}
+
+// NewSketch creates an empty sketch.
+func NewSketch() *Sketch {
+	return &Sketch{}
+}
+
+// Add adds an item to the sketch.
+func (s *Sketch) Add(item string) {
+	s.addHash(xxhash.Sum64String(item))
+}
+
+func (s *Sketch) addHash(hash uint64) {
+	if s.regs != nil {
+		s.setRegister(hash)
+
+		return
+	}
+
+	i, found := slices.BinarySearch(s.sparse, hash)
+	if found {
+		return
+	}
+
+	s.sparse = slices.Insert(s.sparse, i, hash)
+
+	if len(s.sparse) > sparseLimit {
+		s.toDense()
+	}
+}
+
+// setRegister records the hash in its register.
+func (s *Sketch) setRegister(hash uint64) {
+	index := hash >> (64 - Precision)
+	rank := uint8(bits.LeadingZeros64(hash<<Precision|1<<(Precision-1))) + 1 //nolint:gosec // At most 64-Precision+1
+
+	if rank > s.regs[index] {
+		s.regs[index] = rank
+	}
+}
+
+// toDense switches the sketch to registers.
+func (s *Sketch) toDense() {
+	s.regs = make([]uint8, registers)
+
+	for _, hash := range s.sparse {
+		s.setRegister(hash)
+	}
+
+	s.sparse = nil
+}
+
+// Merge adds the items of other to the sketch.
+func (s *Sketch) Merge(other *Sketch) {
+	if other == nil {
+		return
+	}
+
+	if other.regs == nil {
+		for _, hash := range other.sparse {
+			s.addHash(hash)
+		}
+
+		return
+	}
+
+	if s.regs == nil {
+		s.toDense()
+	}
+
+	for i, rank := range other.regs {
+		if rank > s.regs[i] {
+			s.regs[i] = rank
+		}
+	}
+}
+
+// Estimate returns the estimated number of distinct items added to the sketch.
+func (s *Sketch) Estimate() uint64 {
+	if s.regs == nil {
+		return uint64(len(s.sparse))
+	}
+
+	var (
+		sum   float64
+		zeros int
+	)
+
+	for _, rank := range s.regs {
+		sum += math.Ldexp(1, -int(rank))
+
+		if rank == 0 {
+			zeros++
+		}
+	}
+
+	m := float64(registers)
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+
+	// Linear counting is more accurate for small cardinalities
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
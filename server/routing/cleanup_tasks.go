@@ -8,12 +8,18 @@ import (
 	"encoding/json"
 	"fmt"
 	"path"
+	"slices"
 	"sync"
 	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/continuity"
 	"github.com/agntcy/dir/server/routing/internal/p2p"
+	"github.com/agntcy/dir/server/routing/peerstats"
 	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/revocation"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
@@ -53,41 +59,81 @@ type CleanupManager struct {
 	storeAPI    types.StoreAPI
 	server      *p2p.Server
 	publishFunc pubsub.PublishEventHandler // Publishing callback (captures routeRemote state)
+	peerStats   *peerstats.Tracker         // Per-peer label counts, updated on cleanup
+	pins        *pinSet                    // Pinned records, whose labels are never stale or expired and which are always republished
+	lineage     *lineageIndex              // Superseded records, whose labels are removed sooner
+	strategies  []republishStrategy        // Per-namespace republish intervals
+	state       *runtimeState              // Last task runs, which schedule the first runs after a restart
+	republish   func() bool                // Reports whether local records are republished
+	tasks       *taskStatuses              // Schedules of the background tasks, for introspection
+	pacer       *republishPacer            // Paces the records of republishing cycles
 }
 
 // NewCleanupManager creates a new cleanup manager with the required dependencies.
-// The publishFunc is injected from routeRemote.Publish to avoid circular dependencies
+// The publishFunc is injected from routeRemote.PublishWithPriority to avoid circular dependencies
 // while still providing access to DHT and GossipSub publishing logic.
 //
 // Parameters:
 //   - dstore: Datastore for label storage
 //   - storeAPI: Store API for record operations
 //   - server: P2P server for DHT operations
-//   - publishFunc: Callback for publishing (from routeRemote.Publish, see pubsub.PublishEventHandler)
+//   - publishFunc: Callback for publishing (from routeRemote.PublishWithPriority, see pubsub.PublishEventHandler)
+//   - peerStats: Per-peer statistics to update when remote labels are removed
+//   - pins: Pinned records whose remote labels are kept and whose local records are always republished
+//   - lineage: Superseded remote records whose labels are removed after SupersededLabelRetention
+//   - strategies: Per-namespace republish intervals overriding RepublishInterval
+//   - state: Persisted runtime state recording when tasks last ran
+//   - republish: Reports whether local records are republished, as set by the discovery profile
 func NewCleanupManager(
 	dstore types.Datastore,
 	storeAPI types.StoreAPI,
 	server *p2p.Server,
 	publishFunc pubsub.PublishEventHandler,
+	peerStats *peerstats.Tracker,
+	pins *pinSet,
+	lineage *lineageIndex,
+	strategies []republishStrategy,
+	state *runtimeState,
+	republish func() bool,
 ) *CleanupManager {
 	return &CleanupManager{
 		dstore:      dstore,
 		storeAPI:    storeAPI,
 		server:      server,
 		publishFunc: publishFunc,
+		peerStats:   peerStats,
+		pins:        pins,
+		lineage:     lineage,
+		strategies:  strategies,
+		state:       state,
+		republish:   republish,
+		tasks:       newTaskStatuses(),
+		pacer:       newRepublishPacer(),
 	}
 }
 
 // StartLabelRepublishTask starts a background task that periodically republishes local
 // CID provider announcements to keep content discoverable (provider records expire after ProviderRecordTTL).
+// High-priority records are additionally republished every HighPriorityRepublishInterval,
+// in a separate goroutine so that paced default cycles do not hold them back.
+// Records covered by a republish strategy are left to StartNamespaceRepublishTask.
+// After a restart, the first runs are scheduled from the last runs before the restart.
+// Intervals are jittered by RepublishJitter, see republishLocalProviders for pacing.
 // The wg parameter is used to track this goroutine in the parent's WaitGroup.
 func (c *CleanupManager) StartLabelRepublishTask(ctx context.Context, wg *sync.WaitGroup) {
-	ticker := time.NewTicker(RepublishInterval)
+	defaultTask := stateTaskRepublish("default")
+	timer := time.NewTimer(c.firstRepublishDelay(defaultTask, RepublishInterval))
 
-	cleanupLogger.Info("Started CID provider republishing task", "interval", RepublishInterval)
+	wg.Add(1)
+
+	go c.startHighPriorityRepublishTask(ctx, wg)
+
+	cleanupLogger.Info("Started CID provider republishing task",
+		"interval", RepublishInterval,
+		"highPriorityInterval", HighPriorityRepublishInterval)
 
 	defer func() {
-		ticker.Stop()
+		timer.Stop()
 		wg.Done()
 		cleanupLogger.Debug("CID provider republishing task stopped")
 	}()
@@ -98,22 +144,120 @@ func (c *CleanupManager) StartLabelRepublishTask(ctx context.Context, wg *sync.W
 			cleanupLogger.Info("CID provider republishing task stopping (context cancelled)")
 
 			return
-		case <-ticker.C:
-			c.republishLocalProviders(ctx)
+		case <-timer.C:
+			c.runTimed(defaultTask, metrics.TaskRepublish, func() {
+				assigned, err := c.assignRepublishStrategies(ctx)
+				if err != nil {
+					// Republish everything rather than risk letting provider records expire
+					cleanupLogger.Warn("Failed to assign republish strategies", "error", err)
+				}
+
+				c.republishLocalProviders(ctx, "default", RepublishInterval, func(cid string, _ routingv1.AnnouncementPriority) bool {
+					_, ok := assigned[cid]
+
+					return !ok
+				})
+			})
+
+			c.state.taskCompleted(ctx, defaultTask, time.Now())
+			timer.Reset(c.nextRepublishDelay(defaultTask, RepublishInterval))
+		}
+	}
+}
+
+// startHighPriorityRepublishTask republishes high-priority local records every HighPriorityRepublishInterval.
+func (c *CleanupManager) startHighPriorityRepublishTask(ctx context.Context, wg *sync.WaitGroup) {
+	task := stateTaskRepublish("highPriority")
+	timer := time.NewTimer(c.firstRepublishDelay(task, HighPriorityRepublishInterval))
+
+	defer func() {
+		timer.Stop()
+		wg.Done()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			c.runTimed(task, metrics.TaskRepublish, func() {
+				c.republishLocalProviders(ctx, "highPriority", HighPriorityRepublishInterval, func(_ string, priority routingv1.AnnouncementPriority) bool {
+					return priority == routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH
+				})
+			})
+
+			c.state.taskCompleted(ctx, task, time.Now())
+			timer.Reset(c.nextRepublishDelay(task, HighPriorityRepublishInterval))
 		}
 	}
 }
 
+// StartNamespaceRepublishTask starts a background task that republishes local records
+// with labels in the strategy's namespace at the strategy's interval.
+// Records with labels in several namespaces are republished by the strategy with the
+// shortest interval only.
+// The wg parameter is used to track this goroutine in the parent's WaitGroup.
+func (c *CleanupManager) StartNamespaceRepublishTask(ctx context.Context, wg *sync.WaitGroup, strategy republishStrategy) {
+	task := stateTaskRepublish(strategy.namespace.String())
+	timer := time.NewTimer(c.firstRepublishDelay(task, strategy.interval))
+
+	cleanupLogger.Info("Started namespace republishing task",
+		"namespace", strategy.namespace,
+		"interval", strategy.interval)
+
+	defer func() {
+		timer.Stop()
+		wg.Done()
+		cleanupLogger.Debug("Namespace republishing task stopped", "namespace", strategy.namespace)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cleanupLogger.Info("Namespace republishing task stopping (context cancelled)", "namespace", strategy.namespace)
+
+			return
+		case <-timer.C:
+			c.runTimed(task, metrics.TaskRepublish, func() {
+				assigned, err := c.assignRepublishStrategies(ctx)
+				if err != nil {
+					cleanupLogger.Error("Failed to assign republish strategies", "namespace", strategy.namespace, "error", err)
+
+					return
+				}
+
+				c.republishLocalProviders(ctx, strategy.namespace.String(), strategy.interval, func(cid string, _ routingv1.AnnouncementPriority) bool {
+					return assigned[cid] == strategy.namespace
+				})
+
+				c.state.taskCompleted(ctx, task, time.Now())
+			})
+
+			timer.Reset(c.nextRepublishDelay(task, strategy.interval))
+		}
+	}
+}
+
+// assignRepublishStrategies maps local record CIDs to the namespace of the
+// republish strategy responsible for them.
+func (c *CleanupManager) assignRepublishStrategies(ctx context.Context) (map[string]types.LabelType, error) {
+	if len(c.strategies) == 0 {
+		return nil, nil
+	}
+
+	return assignRepublishStrategies(ctx, c.dstore, c.server.Host().ID().String(), c.strategies)
+}
+
 // StartRemoteLabelCleanupTask starts a background task that periodically cleans up stale remote labels.
 // This is critical for the pull-based architecture to remove cached labels from offline or deleted remote content.
 // The wg parameter is used to track this goroutine in the parent's WaitGroup.
 func (c *CleanupManager) StartRemoteLabelCleanupTask(ctx context.Context, wg *sync.WaitGroup) {
-	ticker := time.NewTicker(CleanupInterval)
+	timer := time.NewTimer(c.firstRunDelay(stateTaskCleanup, CleanupInterval))
 
 	cleanupLogger.Info("Starting remote label cleanup task", "interval", CleanupInterval)
 
 	defer func() {
-		ticker.Stop()
+		timer.Stop()
 		wg.Done()
 		cleanupLogger.Debug("Remote label cleanup task stopped")
 	}()
@@ -124,67 +268,173 @@ func (c *CleanupManager) StartRemoteLabelCleanupTask(ctx context.Context, wg *sy
 			cleanupLogger.Info("Remote label cleanup task stopping (context cancelled)")
 
 			return
-		case <-ticker.C:
-			if err := c.cleanupStaleRemoteLabels(ctx); err != nil {
-				cleanupLogger.Error("Failed to cleanup stale remote labels", "error", err)
-			}
+		case <-timer.C:
+			c.runTimed(stateTaskCleanup, metrics.TaskCleanup, func() {
+				if err := c.cleanupStaleRemoteLabels(ctx); err != nil {
+					cleanupLogger.Error("Failed to cleanup stale remote labels", "error", err)
+				}
+
+				if err := c.cleanupExpiredRevocations(ctx); err != nil {
+					cleanupLogger.Error("Failed to cleanup expired revocations", "error", err)
+				}
+
+				if err := c.cleanupExpiredContinuity(ctx); err != nil {
+					cleanupLogger.Error("Failed to cleanup expired continuity records", "error", err)
+				}
+			})
+
+			c.state.taskCompleted(ctx, stateTaskCleanup, time.Now())
+			timer.Reset(CleanupInterval)
 		}
 	}
 }
 
+// StartExpiredRecordCleanupTask starts a background task that periodically removes local
+// records whose TTL has elapsed, together with their labels.
+// The wg parameter is used to track this goroutine in the parent's WaitGroup.
+func (c *CleanupManager) StartExpiredRecordCleanupTask(ctx context.Context, wg *sync.WaitGroup) {
+	timer := time.NewTimer(c.firstRunDelay(stateTaskExpiredRecordCleanup, ExpiredRecordCleanupInterval))
+
+	cleanupLogger.Info("Starting expired record cleanup task", "interval", ExpiredRecordCleanupInterval)
+
+	defer func() {
+		timer.Stop()
+		wg.Done()
+		cleanupLogger.Debug("Expired record cleanup task stopped")
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cleanupLogger.Info("Expired record cleanup task stopping (context cancelled)")
+
+			return
+		case <-timer.C:
+			c.runTimed(stateTaskExpiredRecordCleanup, metrics.TaskCleanup, func() {
+				if err := c.cleanupExpiredRecords(ctx); err != nil {
+					cleanupLogger.Error("Failed to cleanup expired records", "error", err)
+				}
+			})
+
+			c.state.taskCompleted(ctx, stateTaskExpiredRecordCleanup, time.Now())
+			timer.Reset(ExpiredRecordCleanupInterval)
+		}
+	}
+}
+
+// firstRunDelay returns how long to wait before the first run of a task after startup
+// (see runtimeState.nextRunDelay) and tracks the schedule of the task.
+func (c *CleanupManager) firstRunDelay(task string, interval time.Duration) time.Duration {
+	now := time.Now()
+	delay := c.state.nextRunDelay(task, interval, now)
+
+	c.tasks.scheduled(task, interval, delay, now)
+
+	return delay
+}
+
+// firstRepublishDelay is firstRunDelay jittered by RepublishJitter, but no sooner than TaskStartupDelay.
+func (c *CleanupManager) firstRepublishDelay(task string, interval time.Duration) time.Duration {
+	now := time.Now()
+	delay := max(jitterInterval(c.state.nextRunDelay(task, interval, now)), TaskStartupDelay)
+
+	c.tasks.scheduled(task, interval, delay, now)
+
+	return delay
+}
+
+// nextRepublishDelay returns the interval until the next run of a republish task,
+// jittered by RepublishJitter, and tracks the schedule of the task.
+func (c *CleanupManager) nextRepublishDelay(task string, interval time.Duration) time.Duration {
+	delay := jitterInterval(interval)
+
+	c.tasks.rescheduled(task, delay, time.Now())
+
+	return delay
+}
+
+// runTimed runs a background task, tracks it as running and records its duration under the metric task.
+func (c *CleanupManager) runTimed(task, metricTask string, fn func()) {
+	start := time.Now()
+	c.tasks.started(task, start)
+
+	fn()
+
+	c.tasks.finished(task, time.Now())
+	metrics.TaskDuration.WithLabelValues(metricTask).Observe(time.Since(start).Seconds())
+}
+
 // republishLocalProviders republishes all local CID provider announcements and labels
 // to ensure they remain discoverable. This maintains both DHT provider records and
 // GossipSub label announcements for optimal network propagation.
-func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
-	cleanupLogger.Info("Starting CID provider and label republishing cycle")
-
-	// Query all local records from the datastore
-	results, err := c.dstore.Query(ctx, query.Query{
-		Prefix: "/records/",
-	})
+// Each record is republished with its stored announcement priority.
+// Only records accepted by selectRecord are republished; cycle names the run in logs.
+// Records are republished in order of the estimated expiry of their provider records,
+// spread across RepublishSpread of the cycle interval and slowed down while the DHT is slow,
+// but never later than RepublishExpiryMargin before they expire.
+// While the discovery profile disables republishing, only pinned records are republished
+// and orphaned records are cleaned up.
+func (c *CleanupManager) republishLocalProviders(
+	ctx context.Context,
+	cycle string,
+	interval time.Duration,
+	selectRecord func(cid string, priority routingv1.AnnouncementPriority) bool,
+) {
+	republish := c.republish == nil || c.republish()
+
+	cleanupLogger.Info("Starting CID provider and label republishing cycle", "cycle", cycle, "republish", republish)
+
+	candidates, err := c.republishCandidates(ctx, selectRecord)
 	if err != nil {
 		cleanupLogger.Error("Failed to query local records for republishing", "error", err)
 
 		return
 	}
-	defer results.Close()
 
 	republishedCount := 0
 	labelRepublishedCount := 0
 	errorCount := 0
 
-	var orphanedCIDs []string
+	var (
+		orphanedCIDs []string
+		available    []republishCandidate
+	)
 
-	for result := range results.Next() {
-		if result.Error != nil {
-			cleanupLogger.Warn("Error reading local record for republishing", "error", result.Error)
+	for _, candidate := range candidates {
+		// Verify the record still exists in storage
+		_, err := c.storeAPI.Lookup(ctx, &corev1.RecordRef{Cid: candidate.cid})
+		if err != nil {
+			cleanupLogger.Warn("Record no longer exists in storage, marking as orphaned", "cid", candidate.cid, "error", err)
+			orphanedCIDs = append(orphanedCIDs, candidate.cid)
+			errorCount++
 
 			continue
 		}
 
-		// Extract CID from record key: /records/CID123 → CID123
-		cidStr := path.Base(result.Key)
-		if cidStr == "" {
-			continue
-		}
+		available = append(available, candidate)
+	}
 
-		// Verify the record still exists in storage
-		ref := &corev1.RecordRef{Cid: cidStr}
+	// The discovery profile may disable republishing, pinned records are still republished
+	// and orphaned records cleaned up
+	if !republish {
+		available = slices.DeleteFunc(available, func(candidate republishCandidate) bool {
+			return !c.pins.has(candidate.cid)
+		})
+	}
 
-		_, err := c.storeAPI.Lookup(ctx, ref)
-		if err != nil {
-			cleanupLogger.Warn("Record no longer exists in storage, marking as orphaned", "cid", cidStr, "error", err)
-			orphanedCIDs = append(orphanedCIDs, cidStr)
-			errorCount++
+	sortByExpiry(available)
+	step := c.pacer.step(interval, len(available))
 
-			continue
+	for _, candidate := range available {
+		if !c.pacer.wait(ctx, c.pacer.delay(step, candidate.expiresAt(), time.Now())) {
+			break
 		}
 
 		// Pull the record from storage for republishing
-		record, err := c.storeAPI.Pull(ctx, ref)
+		record, err := c.storeAPI.Pull(ctx, &corev1.RecordRef{Cid: candidate.cid})
 		if err != nil {
 			cleanupLogger.Warn("Failed to pull record for republishing",
-				"cid", cidStr,
+				"cid", candidate.cid,
 				"error", err)
 
 			errorCount++
@@ -197,9 +447,14 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 
 		// Use injected publishing function (handles both DHT and GossipSub)
 		// This reuses routeRemote.Publish logic without circular dependency
-		if err := c.publishFunc(ctx, adapter); err != nil {
+		start := time.Now()
+		err = c.publishFunc(ctx, adapter, candidate.metadata.Priority)
+		c.pacer.observe(time.Since(start))
+		metrics.RecordsRepublished.WithLabelValues(metrics.Result(err)).Inc()
+
+		if err != nil {
 			cleanupLogger.Warn("Failed to republish record to network",
-				"cid", cidStr,
+				"cid", candidate.cid,
 				"error", err)
 
 			errorCount++
@@ -207,7 +462,9 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 			continue
 		}
 
-		cleanupLogger.Debug("Successfully republished record to network", "cid", cidStr)
+		cleanupLogger.Debug("Successfully republished record to network", "cid", candidate.cid)
+
+		c.markAnnounced(ctx, candidate.cid, time.Now())
 
 		republishedCount++
 		labelRepublishedCount++ // Count label republishing (done inside publishFunc)
@@ -216,17 +473,98 @@ func (c *CleanupManager) republishLocalProviders(ctx context.Context) {
 	// Clean up orphaned local records and their labels
 	if len(orphanedCIDs) > 0 {
 		cleanedCount := c.cleanupOrphanedLocalLabels(ctx, orphanedCIDs)
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupOrphanedRecord).Add(float64(cleanedCount))
 		cleanupLogger.Info("Cleaned up orphaned local records", "count", cleanedCount)
 	}
 
 	cleanupLogger.Info("Completed republishing cycle",
+		"cycle", cycle,
 		"dhtRepublished", republishedCount,
 		"gossipSubRepublished", labelRepublishedCount,
 		"errors", errorCount,
-		"orphaned", len(orphanedCIDs))
+		"orphaned", len(orphanedCIDs),
+		"throttle", c.pacer.throttle())
 }
 
-// cleanupStaleRemoteLabels removes remote labels that haven't been seen recently.
+// republishCandidates returns the unexpired or pinned local records accepted by selectRecord.
+func (c *CleanupManager) republishCandidates(
+	ctx context.Context,
+	selectRecord func(cid string, priority routingv1.AnnouncementPriority) bool,
+) ([]republishCandidate, error) {
+	// Query all local records from the datastore
+	results, err := c.dstore.Query(ctx, query.Query{
+		Prefix: "/records/",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query local records: %w", err)
+	}
+	defer results.Close()
+
+	now := time.Now()
+
+	var candidates []republishCandidate
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			cleanupLogger.Warn("Error reading local record for republishing", "error", result.Error)
+
+			continue
+		}
+
+		// Extract CID from record key: /records/CID123 → CID123
+		cidStr := path.Base(result.Key)
+		if cidStr == "" {
+			continue
+		}
+
+		recordMetadata := decodeLocalRecordMetadata(result.Value)
+
+		// Expired records are no longer announced, StartExpiredRecordCleanupTask removes them unless pinned
+		if recordMetadata.expired(now) && !c.pins.has(cidStr) {
+			continue
+		}
+
+		if !selectRecord(cidStr, recordMetadata.Priority) {
+			continue
+		}
+
+		candidates = append(candidates, republishCandidate{cid: cidStr, metadata: recordMetadata})
+	}
+
+	return candidates, nil
+}
+
+// markAnnounced stores when a local record was last republished, from which the
+// expiry of its provider record is estimated by later cycles.
+func (c *CleanupManager) markAnnounced(ctx context.Context, cid string, at time.Time) {
+	key := datastore.NewKey("/records/" + cid)
+
+	value, err := c.dstore.Get(ctx, key)
+	if err != nil {
+		// The record may have been deleted while it was republished
+		return
+	}
+
+	metadata := decodeLocalRecordMetadata(value)
+	metadata.AnnouncedAt = at
+
+	encoded, err := encodeLocalRecordMetadata(metadata)
+	if err != nil {
+		cleanupLogger.Warn("Failed to encode local record metadata", "cid", cid, "error", err)
+
+		return
+	}
+
+	if err := c.dstore.Put(ctx, key, encoded); err != nil {
+		cleanupLogger.Warn("Failed to store republish time", "cid", cid, "error", err)
+	}
+}
+
+// cleanupStaleRemoteLabels removes remote labels that haven't been seen recently,
+// whose record's TTL has elapsed, or whose record was superseded by a newer version of
+// the same peer more than SupersededLabelRetention ago. Labels of pinned records are kept.
+//
+//nolint:cyclop
 func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 	localPeerID := c.server.Host().ID().String()
 
@@ -236,9 +574,9 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 	// We'll query each namespace separately and combine results
 	var allResults []query.Result
 
-	for _, namespace := range types.AllLabelTypes() {
+	for _, namespace := range labelKeyPrefixes() {
 		nsResults, err := c.dstore.Query(ctx, query.Query{
-			Prefix: namespace.Prefix(),
+			Prefix: namespace,
 			Filters: []query.Filter{
 				&remoteLabelFilter{
 					dstore:      c.dstore,
@@ -263,6 +601,9 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 
 	var staleKeys []datastore.Key
 
+	superseded := 0
+	now := time.Now()
+
 	// Check each remote label for staleness
 	for _, result := range allResults {
 		if result.Error != nil {
@@ -272,7 +613,7 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 		}
 
 		// Parse enhanced key to get peer information
-		_, _, keyPeerID, err := ParseEnhancedLabelKey(result.Key)
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(result.Key)
 		if err != nil {
 			cleanupLogger.Warn("Failed to parse enhanced label key, marking for deletion",
 				"key", result.Key, "error", err)
@@ -302,33 +643,52 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 			continue
 		}
 
-		// Check if label is stale using the IsStale method
-		if metadata.IsStale(MaxLabelAge) {
+		// Keep labels of pinned records, even while disconnected from the network
+		if c.pins.has(keyCID) {
+			continue
+		}
+
+		// Check if label is stale using the IsStale method, or its record's TTL has elapsed
+		if metadata.IsStale(MaxLabelAge) || metadata.IsExpired(now) {
 			cleanupLogger.Debug("Found stale remote label",
-				"key", result.Key, "age", metadata.Age(), "peer", keyPeerID)
+				"key", result.Key, "age", metadata.Age(), "expiresAt", metadata.ExpiresAt, "peer", keyPeerID)
+
+			staleKeys = append(staleKeys, datastore.NewKey(result.Key))
+
+			continue
+		}
+
+		// Superseded records are kept for a while for clients still resolving them
+		if since, ok := c.lineage.supersededSince(keyCID, keyPeerID); ok && now.Sub(since) > SupersededLabelRetention {
+			cleanupLogger.Debug("Found superseded remote label",
+				"key", result.Key, "supersededSince", since, "peer", keyPeerID)
 
 			staleKeys = append(staleKeys, datastore.NewKey(result.Key))
+			superseded++
 		}
 	}
 
 	// Delete stale labels in batch
 	if len(staleKeys) > 0 {
-		batch, err := c.dstore.Batch(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to create batch for cleanup: %w", err)
+		cleanup := &cacheMutation{}
+		for _, key := range staleKeys {
+			cleanup.delete(key.String())
+		}
+
+		if err := applyCacheMutation(ctx, c.dstore, cleanup); err != nil {
+			return fmt.Errorf("failed to apply stale label cleanup: %w", err)
 		}
 
 		for _, key := range staleKeys {
-			if err := batch.Delete(ctx, key); err != nil {
-				cleanupLogger.Warn("Failed to delete stale label", "key", key.String(), "error", err)
+			if _, _, keyPeerID, err := ParseEnhancedLabelKey(key.String()); err == nil {
+				c.peerStats.AddLabels(keyPeerID, -1)
 			}
 		}
 
-		if err := batch.Commit(ctx); err != nil {
-			return fmt.Errorf("failed to commit stale label cleanup: %w", err)
-		}
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupStaleLabel).Add(float64(len(staleKeys) - superseded))
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupSupersededLabel).Add(float64(superseded))
 
-		cleanupLogger.Info("Cleaned up stale remote labels", "count", len(staleKeys))
+		cleanupLogger.Info("Cleaned up stale remote labels", "count", len(staleKeys), "superseded", superseded)
 	} else {
 		cleanupLogger.Debug("No stale remote labels found")
 	}
@@ -336,6 +696,130 @@ func (c *CleanupManager) cleanupStaleRemoteLabels(ctx context.Context) error {
 	return nil
 }
 
+// cleanupExpiredRevocations removes received revocations older than RevocationTTL.
+func (c *CleanupManager) cleanupExpiredRevocations(ctx context.Context) error {
+	results, err := c.dstore.Query(ctx, query.Query{
+		Prefix: "/" + revocation.Namespace + "/",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query revocations: %w", err)
+	}
+	defer results.Close()
+
+	var expiredKeys []datastore.Key
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			cleanupLogger.Warn("Error reading revocation entry", "error", result.Error)
+
+			continue
+		}
+
+		rev, err := revocation.Unmarshal(result.Value)
+		if err != nil || time.Since(rev.Timestamp) > RevocationTTL {
+			expiredKeys = append(expiredKeys, datastore.NewKey(result.Key))
+		}
+	}
+
+	for _, key := range expiredKeys {
+		if err := c.dstore.Delete(ctx, key); err != nil {
+			cleanupLogger.Warn("Failed to delete expired revocation", "key", key.String(), "error", err)
+
+			continue
+		}
+
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupExpiredRevocation).Inc()
+	}
+
+	if len(expiredKeys) > 0 {
+		cleanupLogger.Info("Cleaned up expired revocations", "count", len(expiredKeys))
+	}
+
+	return nil
+}
+
+// cleanupExpiredContinuity removes continuity records of identity rotations older than ContinuityTTL.
+func (c *CleanupManager) cleanupExpiredContinuity(ctx context.Context) error {
+	results, err := c.dstore.Query(ctx, query.Query{
+		Prefix: "/" + continuity.Namespace + "/",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query continuity records: %w", err)
+	}
+	defer results.Close()
+
+	var expiredKeys []datastore.Key
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			cleanupLogger.Warn("Error reading continuity entry", "error", result.Error)
+
+			continue
+		}
+
+		record, err := continuity.Unmarshal(result.Value)
+		if err != nil || time.Since(record.Timestamp) > ContinuityTTL {
+			expiredKeys = append(expiredKeys, datastore.NewKey(result.Key))
+		}
+	}
+
+	for _, key := range expiredKeys {
+		if err := c.dstore.Delete(ctx, key); err != nil {
+			cleanupLogger.Warn("Failed to delete expired continuity record", "key", key.String(), "error", err)
+
+			continue
+		}
+
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupExpiredContinuity).Inc()
+	}
+
+	if len(expiredKeys) > 0 {
+		cleanupLogger.Info("Cleaned up expired continuity records", "count", len(expiredKeys))
+	}
+
+	return nil
+}
+
+// cleanupExpiredRecords removes local records whose TTL has elapsed, together with their labels.
+// Pinned records are kept.
+func (c *CleanupManager) cleanupExpiredRecords(ctx context.Context) error {
+	results, err := c.dstore.Query(ctx, query.Query{
+		Prefix: "/records/",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query local records: %w", err)
+	}
+
+	now := time.Now()
+
+	var expiredCIDs []string
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			cleanupLogger.Warn("Error reading local record entry", "error", result.Error)
+
+			continue
+		}
+
+		if decodeLocalRecordMetadata(result.Value).expired(now) && !c.pins.has(path.Base(result.Key)) {
+			expiredCIDs = append(expiredCIDs, path.Base(result.Key))
+		}
+	}
+
+	results.Close()
+
+	if len(expiredCIDs) == 0 {
+		return nil
+	}
+
+	cleanedCount := c.cleanupOrphanedLocalLabels(ctx, expiredCIDs)
+	metrics.CleanupRemoved.WithLabelValues(metrics.CleanupExpiredRecord).Add(float64(cleanedCount))
+
+	cleanupLogger.Info("Cleaned up expired local records", "count", cleanedCount)
+
+	return nil
+}
+
 // cleanupOrphanedLocalLabels removes local records and labels for CIDs that no longer exist in storage.
 func (c *CleanupManager) cleanupOrphanedLocalLabels(ctx context.Context, orphanedCIDs []string) int {
 	cleanedCount := 0
@@ -351,30 +835,19 @@ func (c *CleanupManager) cleanupOrphanedLocalLabels(ctx context.Context, orphane
 
 // cleanupLabelsForCID removes all local records and labels associated with a specific CID.
 func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bool {
-	batch, err := c.dstore.Batch(ctx)
-	if err != nil {
-		cleanupLogger.Error("Failed to create cleanup batch", "cid", cid, "error", err)
-
-		return false
-	}
-
-	keysDeleted := 0
+	// The record and its labels are removed together as a single journaled mutation
+	cleanup := &cacheMutation{}
 
 	// Remove the /records/ key
-	recordKey := datastore.NewKey("/records/" + cid)
-	if err := batch.Delete(ctx, recordKey); err != nil {
-		cleanupLogger.Warn("Failed to delete record key", "key", recordKey.String(), "error", err)
-	} else {
-		keysDeleted++
-	}
+	cleanup.delete("/records/" + cid)
 
 	// Find and remove all label keys for this CID across all namespaces
 	localPeerID := c.server.Host().ID().String()
 
-	for _, namespace := range types.AllLabelTypes() {
+	for _, namespace := range labelKeyPrefixes() {
 		// Query labels in this namespace that match our CID
 		labelResults, err := c.dstore.Query(ctx, query.Query{
-			Prefix: namespace.Prefix(),
+			Prefix: namespace,
 		})
 		if err != nil {
 			cleanupLogger.Warn("Failed to query labels for cleanup", "namespace", namespace, "cid", cid, "error", err)
@@ -391,9 +864,7 @@ func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bo
 				cleanupLogger.Warn("Failed to parse enhanced label key during cleanup, deleting",
 					"key", result.Key, "error", err)
 				// Delete malformed keys
-				if err := batch.Delete(ctx, datastore.NewKey(result.Key)); err == nil {
-					keysDeleted++
-				}
+				cleanup.delete(result.Key)
 
 				continue
 			}
@@ -401,25 +872,21 @@ func (c *CleanupManager) cleanupLabelsForCID(ctx context.Context, cid string) bo
 			// Check if this key matches our CID and is from local peer
 			if keyCID == cid && keyPeerID == localPeerID {
 				// Delete this local label
-				labelKey := datastore.NewKey(result.Key)
-				if err := batch.Delete(ctx, labelKey); err != nil {
-					cleanupLogger.Warn("Failed to delete label key", "key", labelKey.String(), "error", err)
-				} else {
-					keysDeleted++
+				cleanup.delete(result.Key)
 
-					cleanupLogger.Debug("Scheduled orphaned label for deletion", "key", result.Key)
-				}
+				cleanupLogger.Debug("Scheduled orphaned label for deletion", "key", result.Key)
 			}
 		}
 	}
 
-	// Commit the batch deletion
-	if err := batch.Commit(ctx); err != nil {
-		cleanupLogger.Error("Failed to commit orphaned label cleanup", "cid", cid, "error", err)
+	if err := applyCacheMutation(ctx, c.dstore, cleanup); err != nil {
+		cleanupLogger.Error("Failed to apply orphaned label cleanup", "cid", cid, "error", err)
 
 		return false
 	}
 
+	keysDeleted := len(cleanup.Deletes)
+
 	if keysDeleted > 0 {
 		cleanupLogger.Debug("Successfully cleaned up orphaned labels", "cid", cid, "keysDeleted", keysDeleted)
 	}
@@ -0,0 +1,70 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"time"
+
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+)
+
+// announcementMetadata returns the label metadata of an announcement received at receivedAt,
+// translated from the publisher's clock to the local clock, and the clock skew of the publisher,
+// positive if its clock is ahead. The skew is estimated as the difference between the claimed
+// and receive times, so it includes the network delay of the announcement.
+//
+// The timestamp is clamped to the accepted announcement window, so that it is never after
+// LastSeen, and the expiry is shifted by the skew, so that records of publishers with bad
+// clocks are neither evicted early nor retained past their TTL.
+func announcementMetadata(event *pubsub.RecordPublishEvent, receivedAt time.Time) (*types.LabelMetadata, time.Duration) {
+	skew := event.Timestamp.Sub(receivedAt)
+
+	metadata := &types.LabelMetadata{
+		Timestamp:     clampTime(event.Timestamp, receivedAt.Add(-pubsub.MaxAnnouncementAge), receivedAt),
+		LastSeen:      receivedAt,
+		Supersedes:    event.Supersedes,
+		AccessGated:   event.AccessGated,
+		Size:          event.Size,
+		Publisher:     event.Publisher,
+		RecordSummary: event.RecordSummary,
+	}
+
+	if !event.ExpiresAt.IsZero() {
+		metadata.ExpiresAt = event.ExpiresAt.Add(-skew)
+	}
+
+	return metadata, skew
+}
+
+// clampToLocalClock bounds timestamps of label metadata written by another peer's clock
+// to now, so that labels stamped in the future do not escape stale label cleanup.
+// Reports whether the metadata was changed.
+func clampToLocalClock(metadata *types.LabelMetadata, now time.Time) bool {
+	changed := false
+
+	if metadata.LastSeen.After(now) {
+		metadata.LastSeen = now
+		changed = true
+	}
+
+	if metadata.Timestamp.After(now) {
+		metadata.Timestamp = now
+		changed = true
+	}
+
+	return changed
+}
+
+// clampTime bounds t to [lower, upper].
+func clampTime(t, lower, upper time.Time) time.Time {
+	switch {
+	case t.Before(lower):
+		return lower
+	case t.After(upper):
+		return upper
+	default:
+		return t
+	}
+}
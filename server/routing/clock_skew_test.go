@@ -0,0 +1,56 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnouncementMetadata(t *testing.T) {
+	receivedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Publisher clock 3 minutes ahead: stamped in the future, expiry shifted back
+	metadata, skew := announcementMetadata(&pubsub.RecordPublishEvent{
+		Timestamp: receivedAt.Add(3 * time.Minute),
+		ExpiresAt: receivedAt.Add(time.Hour + 3*time.Minute),
+	}, receivedAt)
+
+	assert.Equal(t, 3*time.Minute, skew)
+	assert.Equal(t, receivedAt, metadata.Timestamp)
+	assert.Equal(t, receivedAt, metadata.LastSeen)
+	assert.Equal(t, receivedAt.Add(time.Hour), metadata.ExpiresAt)
+	assert.NoError(t, metadata.Validate())
+
+	// Publisher clock 10 minutes behind: expiry shifted forward
+	metadata, skew = announcementMetadata(&pubsub.RecordPublishEvent{
+		Timestamp: receivedAt.Add(-10 * time.Minute),
+		ExpiresAt: receivedAt.Add(50 * time.Minute),
+	}, receivedAt)
+
+	assert.Equal(t, -10*time.Minute, skew)
+	assert.Equal(t, receivedAt.Add(-10*time.Minute), metadata.Timestamp)
+	assert.Equal(t, receivedAt.Add(time.Hour), metadata.ExpiresAt)
+
+	// Records without TTL never expire, and timestamps are bounded by the announcement window
+	metadata, _ = announcementMetadata(&pubsub.RecordPublishEvent{Timestamp: receivedAt.Add(-time.Hour)}, receivedAt)
+	assert.True(t, metadata.ExpiresAt.IsZero())
+	assert.Equal(t, receivedAt.Add(-pubsub.MaxAnnouncementAge), metadata.Timestamp)
+}
+
+func TestClampToLocalClock(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	metadata := &types.LabelMetadata{Timestamp: now.Add(-time.Hour), LastSeen: now.Add(-time.Minute)}
+	assert.False(t, clampToLocalClock(metadata, now))
+
+	metadata = &types.LabelMetadata{Timestamp: now.Add(time.Hour), LastSeen: now.Add(2 * time.Hour)}
+	assert.True(t, clampToLocalClock(metadata, now))
+	assert.Equal(t, now, metadata.Timestamp)
+	assert.Equal(t, now, metadata.LastSeen)
+}
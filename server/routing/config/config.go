@@ -11,8 +11,138 @@ var (
 		// TODO: once we deploy our bootstrap nodes, we should update this
 	}
 
-	// GossipSub default (only enable/disable is configurable).
-	DefaultGossipSubEnabled = true
+	// mDNS discovery of peers on the local network is enabled by default.
+	DefaultMDNSEnabled     = true
+	DefaultMDNSServiceName = "agntcy-dir-local-discovery"
+
+	// NAT traversal defaults. Peers behind NATs are reachable via relays and hole punching,
+	// but do not relay for others.
+	DefaultNATAutoNATService = true
+	DefaultNATPortMapping    = true
+	DefaultNATHolePunching   = true
+	DefaultNATRelayClient    = true
+	DefaultNATRelayService   = false
+
+	// Default DHT settings, those of the libp2p Kademlia DHT.
+	DefaultDHTBucketSize  = 20
+	DefaultDHTConcurrency = 10
+
+	// GossipSub defaults.
+	DefaultGossipSubEnabled           = true
+	DefaultGossipSubRequireSignatures = false
+	DefaultGossipSubNamespaces        = []string{}
+	DefaultGossipSubWireFormat        = "json"
+	DefaultGossipSubBatchWindow       = time.Duration(0)
+	DefaultGossipSubPropagationAcks   = false
+
+	// Event publishing defaults.
+	DefaultEventsKafkaTopic        = "dir.routing.events"
+	DefaultEventsNATSSubjectPrefix = "dir.routing"
+
+	// Announcement sink defaults.
+	DefaultAnnouncementSinksNATSSubjectPrefix = "dir.announcements"
+	DefaultAnnouncementSinksMQTTTopicPrefix   = "dir/announcements"
+	DefaultAnnouncementSinksMQTTQoS           = 1
+
+	// Window within which repeated Publish calls for the same CID are coalesced.
+	DefaultPublishDedupWindow = 30 * time.Second
+
+	// Concurrency of pulls triggered by DHT provider notifications, overall and per announcing peer.
+	DefaultPullConcurrency        = 8
+	DefaultPullConcurrencyPerPeer = 2
+
+	// Maximum number of cached remote labels. Zero leaves the cache unbounded.
+	DefaultMaxCachedLabels = 0
+
+	// Maximum number of writes buffered in memory while the routing datastore is unavailable.
+	DefaultDatastoreMaxBufferedWrites = 10000
+
+	// Search result scoring defaults.
+	DefaultScoringStrategy          = "count"
+	DefaultScoringFreshnessHalfLife = 24 * time.Hour
+
+	// Ranking factor weights of the ranked scoring strategy.
+	DefaultRankingWeightMatch      = 0.5
+	DefaultRankingWeightFreshness  = 0.2
+	DefaultRankingWeightReputation = 0.2
+	DefaultRankingWeightProviders  = 0.1
+
+	// Deadlines of remote operations.
+	DefaultTimeoutProvide          = time.Minute
+	DefaultTimeoutPull             = 30 * time.Second
+	DefaultTimeoutGossipSubPublish = 10 * time.Second
+	DefaultTimeoutPublish          = 2 * time.Minute
+
+	// Peers of search results are returned to all callers by default.
+	DefaultPeerRedaction = "none"
+
+	// Nodes run as full nodes unless configured otherwise.
+	DefaultProfile = "full"
+
+	// Nodes are ready once connected to at least one peer.
+	DefaultReadinessMinPeers = 1
+
+	// Maximum number of concurrent search subscriptions.
+	DefaultMaxSubscriptions = 100
+
+	// Discovery history is not retained by default.
+	DefaultHistoryEnabled   = false
+	DefaultHistoryRetention = 14 * 24 * time.Hour
+
+	// Default provenance settings.
+	DefaultProvenanceEnabled   = false
+	DefaultProvenanceRetention = 30 * 24 * time.Hour
+
+	// Peers with cached labels are not probed for liveness by default.
+	DefaultLivenessEnabled     = false
+	DefaultLivenessInterval    = 5 * time.Minute
+	DefaultLivenessStaleAfter  = 30 * time.Minute
+	DefaultLivenessDeleteAfter = 24 * time.Hour
+
+	// Nodes run as primaries unless configured as followers.
+	DefaultFollowerEnabled      = false
+	DefaultFollowerSyncInterval = 30 * time.Second
+
+	// Records are not advertised to IPNI indexers by default.
+	DefaultIPNIEnabled       = false
+	DefaultIPNIListenAddress = "0.0.0.0:3104"
+
+	// Search results are not cached by default.
+	DefaultSearchCacheEnabled         = false
+	DefaultSearchCacheTTL             = 30 * time.Second
+	DefaultSearchCacheMaxBytes uint64 = 64 << 20
+
+	// Default prefetch settings.
+	DefaultPrefetchEnabled           = false
+	DefaultPrefetchMinScore   uint32 = 2
+	DefaultPrefetchQuotaBytes uint64 = 1 << 30
+
+	// Records pulled by the DHT+Pull fallback are not retained by default.
+	DefaultRetainPulledEnabled            = false
+	DefaultRetainPulledBudgetBytes uint64 = 1 << 30
+
+	// Local records are not garbage collected by default.
+	DefaultRecordGCThreshold = 0.0
+	DefaultRecordGCInterval  = 5 * time.Minute
+
+	// Records are not validated before publishing by default.
+	DefaultRecordValidationSchema                     = false
+	DefaultRecordValidationTaxonomy                   = false
+	DefaultRecordValidationLocators                   = false
+	DefaultRecordValidationLocatorReachability        = false
+	DefaultRecordValidationLocatorReachabilityTimeout = 5 * time.Second
+
+	// Labels outside the label taxonomy are kept by default.
+	DefaultTaxonomyStrict = false
+
+	// Per-peer rate limit defaults.
+	DefaultRateLimitAnnouncementRate         = 20.0
+	DefaultRateLimitAnnouncementBurst        = 200
+	DefaultRateLimitRequestRate              = 50.0
+	DefaultRateLimitRequestBurst             = 500
+	DefaultRateLimitBanThreshold             = 100
+	DefaultRateLimitBanDuration              = 10 * time.Minute
+	DefaultRateLimitPullBytesPerHour  uint64 = 0
 )
 
 type Config struct {
@@ -22,31 +152,657 @@ type Config struct {
 	// Address to use for sync operations
 	DirectoryAPIAddress string `json:"directory_api_address,omitempty" mapstructure:"directory_api_address"`
 
+	// Locality zone of this peer, e.g. its region. It is advertised with the peer's
+	// addresses, so that searches can prefer providers in the caller's zone.
+	Zone string `json:"zone,omitempty" mapstructure:"zone"`
+
 	// Peers to use for bootstrapping.
 	// We can choose between public and private peers.
 	BootstrapPeers []string `json:"bootstrap_peers,omitempty" mapstructure:"bootstrap_peers"`
 
+	// MDNS configures discovery of peers on the local network via mDNS, in addition
+	// to the bootstrap peers and the DHT rendezvous.
+	MDNS MDNSConfig `json:"mdns,omitempty" mapstructure:"mdns"`
+
 	// Path to asymmetric private key
 	KeyPath string `json:"key_path,omitempty" mapstructure:"key_path"`
 
+	// Path to the private key of the identity this peer rotated from, if it rotated its key.
+	// The peer announces a continuity record signed with both keys, so that other peers
+	// migrate the labels and addresses cached under the old peer ID to the new one.
+	PreviousKeyPath string `json:"previous_key_path,omitempty" mapstructure:"previous_key_path"`
+
+	// Peer IDs allowed to connect. If set, connections with any other peer are refused,
+	// except for bootstrap peers, which are implicitly allowed.
+	// If empty, all peers that are not denied can connect.
+	AllowedPeers []string `json:"allowed_peers,omitempty" mapstructure:"allowed_peers"`
+
+	// Peer IDs refused to connect, even if allowed or bootstrap peers.
+	DeniedPeers []string `json:"denied_peers,omitempty" mapstructure:"denied_peers"`
+
+	// Peer IDs allowed to pull records published as access-gated.
+	// Other peers only learn the labels of access-gated records.
+	GatedAccessPeers []string `json:"gated_access_peers,omitempty" mapstructure:"gated_access_peers"`
+
+	// Access tokens issued to this peer by other peers, sent when pulling their access-gated records.
+	// Tokens are issued with GrantAccess on the serving peer.
+	GatedAccessTokens []string `json:"gated_access_tokens,omitempty" mapstructure:"gated_access_tokens"`
+
+	// Path to the pre-shared key of a private network, in the swarm.key format.
+	// If set, only peers with the same key can connect and only TCP transports are used.
+	// If empty, the peer joins the public network.
+	PrivateNetworkKeyPath string `json:"private_network_key_path,omitempty" mapstructure:"private_network_key_path"`
+
+	// NAT configures NAT traversal, so that records of peers behind NATs can be pulled.
+	NAT NATConfig `json:"nat,omitempty" mapstructure:"nat"`
+
+	// DHT tunes the Kademlia DHT, e.g. lightweight edge nodes querying it as clients
+	// and hubs serving it to other peers.
+	DHT DHTConfig `json:"dht,omitempty" mapstructure:"dht"`
+
+	// ResourceLimits bounds the resources used by the libp2p host.
+	ResourceLimits ResourceLimitsConfig `json:"resource_limits,omitempty" mapstructure:"resource_limits"`
+
+	// Timeouts bounds the remote operations of publishing and pulling records.
+	Timeouts TimeoutsConfig `json:"timeouts,omitempty" mapstructure:"timeouts"`
+
 	// Path to the routing datastore.
 	// If empty, the routing data will be stored in memory.
 	// If not empty, this dir will be used to store the routing data on disk.
 	DatastoreDir string `json:"datastore_dir,omitempty" mapstructure:"datastore_dir"`
 
+	// Maximum number of writes buffered in memory while the routing datastore is unavailable
+	// (e.g. disk full). Buffered writes are flushed once it recovers. Writes beyond
+	// the limit fail, zero fails all writes until the datastore recovers.
+	DatastoreMaxBufferedWrites int `json:"datastore_max_buffered_writes,omitempty" mapstructure:"datastore_max_buffered_writes"`
+
 	// Refresh interval for DHT routing tables.
 	// If not set or zero, uses the default RefreshInterval constant.
 	// This is primarily used for testing with faster intervals.
 	RefreshInterval time.Duration `json:"refresh_interval,omitempty" mapstructure:"refresh_interval"`
 
+	// Window within which repeated Publish calls for the same CID are coalesced
+	// and return the prior result instead of re-announcing to the network.
+	// Publishes with another priority or label policies are always announced.
+	// Zero disables deduplication.
+	PublishDedupWindow time.Duration `json:"publish_dedup_window,omitempty" mapstructure:"publish_dedup_window"`
+
+	// Maximum number of DHT provider notifications processed concurrently, i.e. records
+	// pulled to discover their labels when GossipSub announcements did not arrive.
+	// If not set or zero, uses DefaultPullConcurrency.
+	PullConcurrency int `json:"pull_concurrency,omitempty" mapstructure:"pull_concurrency"`
+
+	// Maximum number of concurrent pulls from the same peer, so that a slow peer
+	// cannot occupy all pull workers.
+	// If not set or zero, uses DefaultPullConcurrencyPerPeer.
+	PullConcurrencyPerPeer int `json:"pull_concurrency_per_peer,omitempty" mapstructure:"pull_concurrency_per_peer"`
+
+	// Maximum number of remote labels kept in the label cache.
+	// When exceeded, the least frequently returned and least recently seen
+	// remote records are evicted with all their labels.
+	// Zero leaves the cache unbounded.
+	MaxCachedLabels int `json:"max_cached_labels,omitempty" mapstructure:"max_cached_labels"`
+
+	// Seed peer to warm the remote label cache from on first boot, as a multiaddr
+	// including the peer ID (e.g. /ip4/10.0.0.1/tcp/8999/p2p/12D3KooW...).
+	// Until warming completes or times out, Search waits for the cache.
+	// If empty, the cache is only populated by announcements.
+	SeedPeer string `json:"seed_peer,omitempty" mapstructure:"seed_peer"`
+
+	// Republish strategies overriding how often local records are republished
+	// based on the namespaces of their labels, e.g. hourly for locators.
+	// Records without labels in a configured namespace are republished every 36 hours.
+	RepublishStrategies []RepublishStrategyConfig `json:"republish_strategies,omitempty" mapstructure:"republish_strategies"`
+
+	// Replication policies keeping a minimum number of providers of the records
+	// whose labels they match, e.g. at least 3 providers of /domains/research records.
+	// Under-replicated records are pulled, stored and announced by this peer.
+	Replication []ReplicationPolicyConfig `json:"replication,omitempty" mapstructure:"replication"`
+
+	// Scoring configures how the relevance of search results is computed
+	Scoring ScoringConfig `json:"scoring,omitempty" mapstructure:"scoring"`
+
+	// PeerRedaction controls how the providing peers of search results are returned to
+	// unauthenticated callers: none (default), omit (only CIDs and scores are returned)
+	// or hash (peer IDs are replaced by opaque identifiers and addresses are dropped).
+	// Callers authenticated via SPIFFE always receive the peers.
+	PeerRedaction string `json:"peer_redaction,omitempty" mapstructure:"peer_redaction"`
+
+	// Profile is the discovery profile bundling the routing behavior of this node:
+	// full (default) serves the DHT, processes GossipSub announcements and republishes its records,
+	// edge uses the DHT as a client only and bounds the label cache, and client additionally
+	// ignores GossipSub announcements and does not republish its records.
+	// The profile can be switched at runtime via RoutingService.SetProfile.
+	Profile string `json:"profile,omitempty" mapstructure:"profile"`
+
+	// Minimum number of peers in the DHT routing table for the node to report ready on /readyz
+	// and the gRPC health service. Zero lets standalone nodes without peers report ready.
+	ReadinessMinPeers int `json:"readiness_min_peers,omitempty" mapstructure:"readiness_min_peers"`
+
+	// Maximum number of concurrent RoutingService.Subscribe streams of all callers.
+	// Further subscriptions fail with ResourceExhausted. Zero disables subscriptions.
+	MaxSubscriptions int `json:"max_subscriptions,omitempty" mapstructure:"max_subscriptions"`
+
 	// GossipSub configuration for label announcements
 	GossipSub GossipSubConfig `json:"gossipsub,omitempty" mapstructure:"gossipsub"`
+
+	// RateLimit configures the per-peer rate limits of inbound announcements and RPC requests
+	RateLimit RateLimitConfig `json:"rate_limit,omitempty" mapstructure:"rate_limit"`
+
+	// Events configures publishing of routing events to message queues
+	Events EventsConfig `json:"events,omitempty" mapstructure:"events"`
+
+	// AnnouncementSinks configures mirroring of record announcements to external message buses
+	AnnouncementSinks AnnouncementSinksConfig `json:"announcement_sinks,omitempty" mapstructure:"announcement_sinks"`
+
+	// History configures retention of downsampled discovery metrics in the datastore
+	History HistoryConfig `json:"history,omitempty" mapstructure:"history"`
+
+	// Provenance configures the log of the announcements of records queried via RoutingService.GetProvenance
+	Provenance ProvenanceConfig `json:"provenance,omitempty" mapstructure:"provenance"`
+
+	// SearchCache configures caching of search results.
+	SearchCache SearchCacheConfig `json:"search_cache,omitempty" mapstructure:"search_cache"`
+
+	// Prefetching of search results into the local store.
+	Prefetch PrefetchConfig `json:"prefetch,omitempty" mapstructure:"prefetch"`
+
+	// Retention of the records pulled by the DHT+Pull fallback in the local store.
+	RetainPulled RetainPulledConfig `json:"retain_pulled,omitempty" mapstructure:"retain_pulled"`
+
+	// RecordGC configures the garbage collection of unpinned local records under disk pressure.
+	RecordGC RecordGCConfig `json:"record_gc,omitempty" mapstructure:"record_gc"`
+
+	// Liveness probing of the peers with cached labels.
+	Liveness LivenessConfig `json:"liveness,omitempty" mapstructure:"liveness"`
+
+	// Follower configures this peer as a warm standby of a primary peer.
+	Follower FollowerConfig `json:"follower,omitempty" mapstructure:"follower"`
+
+	// IPNI configures advertisements of published records to InterPlanetary Network Indexers.
+	IPNI IPNIConfig `json:"ipni,omitempty" mapstructure:"ipni"`
+
+	// RecordValidation configures the rules records are validated with before they are published.
+	RecordValidation RecordValidationConfig `json:"record_validation,omitempty" mapstructure:"record_validation"`
+
+	// LabelIndex configures a relational index of the label cache, used to find the records
+	// matching combinations of search queries and to count them without scanning the label cache.
+	LabelIndex LabelIndexConfig `json:"label_index,omitempty" mapstructure:"label_index"`
+
+	// Custom label namespaces indexed in addition to the built-in
+	// skills, domains, modules and locators namespaces.
+	LabelNamespaces []LabelNamespaceConfig `json:"label_namespaces,omitempty" mapstructure:"label_namespaces"`
+
+	// Taxonomy configures the versioned ontology labels are normalized to.
+	Taxonomy TaxonomyConfig `json:"taxonomy,omitempty" mapstructure:"taxonomy"`
+
+	// Tenants whose records this peer publishes, caches and searches.
+	// Labels of tenant records are stored and announced under /tenants/<id>/, and
+	// announcements of tenants that are not configured are dropped.
+	Tenants []TenantConfig `json:"tenants,omitempty" mapstructure:"tenants"`
+
+	// Publishers whose records this peer publishes. Records published as a publisher
+	// are announced with its signature, so that searches can find the records of a
+	// publisher regardless of the peer hosting them.
+	Publishers []PublisherConfig `json:"publishers,omitempty" mapstructure:"publishers"`
+
+	// PublishPolicy selects the labels of local records that are published, per namespace,
+	// to withhold labels such as internal module names from announcements. Publish requests
+	// may override the policy of a namespace for their records.
+	// Default: empty (all labels are published)
+	PublishPolicy []LabelPolicyConfig `json:"publish_policy,omitempty" mapstructure:"publish_policy"`
+}
+
+// LabelPolicyConfig selects the labels of a namespace that are published for local records.
+// Patterns are matched against label values without the namespace prefix (path.Match syntax,
+// e.g. "internal/*"), and a pattern matching a label also matches its descendants.
+type LabelPolicyConfig struct {
+	// Namespace the policy applies to, a built-in or configured custom namespace.
+	Namespace string `json:"namespace,omitempty" mapstructure:"namespace"`
+
+	// Patterns of the labels that are published. Empty publishes all labels that are not excluded.
+	Include []string `json:"include,omitempty" mapstructure:"include"`
+
+	// Patterns of the labels that are withheld, taking precedence over Include.
+	Exclude []string `json:"exclude,omitempty" mapstructure:"exclude"`
+}
+
+// PublisherConfig configures a publisher sharing this peer.
+// Publishers are identified by the did:key identifier of their key.
+type PublisherConfig struct {
+	// Path to the PEM encoded Ed25519 private key of the publisher,
+	// e.g. generated with openssl genpkey -algorithm ed25519.
+	KeyPath string `json:"key_path,omitempty" mapstructure:"key_path"`
+}
+
+// TenantConfig configures a tenant sharing this peer.
+// All peers that should discover records of a tenant must configure it.
+type TenantConfig struct {
+	// ID of the tenant, 1-63 letters, digits, dots, dashes or underscores.
+	ID string `json:"id,omitempty" mapstructure:"id"`
+
+	// Public tenants share the records of untenanted and other public publishers:
+	// their records are returned by searches without a tenant, and their searches
+	// return shared records. Records of other tenants are only returned to searches
+	// of the same tenant.
+	Public bool `json:"public,omitempty" mapstructure:"public"`
+}
+
+// LabelNamespaceConfig configures a custom label namespace, e.g. an organization-specific taxonomy.
+// All peers that should discover labels of a custom namespace must configure it.
+type LabelNamespaceConfig struct {
+	// Name of the namespace. Labels are stored and searched under /<name>/.
+	// Must be lowercase alphanumeric with dashes and not collide with a built-in namespace.
+	Name string `json:"name,omitempty" mapstructure:"name"`
+
+	// Record annotation holding the comma-separated label values,
+	// e.g. "platform/search,platform/storage".
+	Annotation string `json:"annotation,omitempty" mapstructure:"annotation"`
+
+	// Regular expression that label values must fully match.
+	// If empty, any non-empty value is accepted.
+	Pattern string `json:"pattern,omitempty" mapstructure:"pattern"`
+}
+
+// MDNSConfig configures mDNS discovery of peers on the local network.
+// Nodes on the same LAN connect to each other automatically, without bootstrap peers.
+type MDNSConfig struct {
+	// Enabled controls whether peers on the local network are discovered via mDNS.
+	// Default: true
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// ServiceName is the mDNS service advertised and browsed for peers.
+	// Only nodes with the same service name discover each other, so separate
+	// directory networks on the same LAN should use different names.
+	// Default: agntcy-dir-local-discovery
+	ServiceName string `json:"service_name,omitempty" mapstructure:"service_name"`
+}
+
+// NATConfig configures how peers behind NATs reach each other. Peers learn whether
+// they are publicly reachable via AutoNAT. Unreachable peers reserve slots on circuit
+// relays and advertise the relayed addresses, and relayed connections are upgraded
+// to direct ones via hole punching where possible.
+type NATConfig struct {
+	// AutoNATService controls whether this peer helps other peers detect whether they are
+	// publicly reachable, by dialing them back. Detecting the own reachability is always enabled.
+	// Default: true
+	AutoNATService bool `json:"auto_nat_service,omitempty" mapstructure:"auto_nat_service"`
+
+	// PortMapping controls whether ports are opened on the router via UPnP or NAT-PMP.
+	// Default: true
+	PortMapping bool `json:"port_mapping,omitempty" mapstructure:"port_mapping"`
+
+	// HolePunching controls whether relayed connections are upgraded to direct ones (DCUtR).
+	// Default: true
+	HolePunching bool `json:"hole_punching,omitempty" mapstructure:"hole_punching"`
+
+	// RelayClient controls whether this peer reserves slots on circuit relays while it is not
+	// publicly reachable, and advertises the relayed addresses (AutoRelay).
+	// Default: true
+	RelayClient bool `json:"relay_client,omitempty" mapstructure:"relay_client"`
+
+	// StaticRelays are the multiaddrs (with /p2p/<peer-id>) of the relays to reserve slots on.
+	// If empty, relays are picked among the peers of the DHT routing table.
+	StaticRelays []string `json:"static_relays,omitempty" mapstructure:"static_relays"`
+
+	// RelayService controls whether this peer acts as a circuit relay v2 for other peers
+	// while it is publicly reachable. Relayed connections are limited in duration and data.
+	// Default: false
+	RelayService bool `json:"relay_service,omitempty" mapstructure:"relay_service"`
+
+	// Reachability overrides the detected reachability: "public" or "private".
+	// Peers known to be behind a NAT can set "private" to reserve relay slots immediately.
+	// Default: empty (detected via AutoNAT)
+	Reachability string `json:"reachability,omitempty" mapstructure:"reachability"`
+}
+
+// DHTConfig configures the Kademlia DHT of the peer.
+type DHTConfig struct {
+	// Mode is the DHT mode: "client" only queries the DHT, "server" also serves records
+	// and routing queries of other peers, and "auto" serves them while the peer is
+	// publicly reachable. The mode is applied at startup only.
+	// Default: empty (server for the full profile and for peers without bootstrap peers, client otherwise)
+	Mode string `json:"mode,omitempty" mapstructure:"mode"`
+
+	// BucketSize is the number of peers per routing table bucket (k), which is also
+	// the number of closest peers provider records are stored on.
+	// Default: 20
+	BucketSize int `json:"bucket_size,omitempty" mapstructure:"bucket_size"`
+
+	// Concurrency is the number of peers queried in parallel by DHT lookups (alpha).
+	// Default: 10
+	Concurrency int `json:"concurrency,omitempty" mapstructure:"concurrency"`
+}
+
+// ResourceLimitsConfig configures the system-wide limits of the libp2p resource manager.
+// Connections, streams and memory beyond the limits are refused. Unset limits are
+// scaled to the host: 1/8 of the system memory and half of the file descriptors.
+type ResourceLimitsConfig struct {
+	// MaxMemory is the memory in bytes reserved by connections and streams.
+	// Default: 0 (scaled)
+	MaxMemory int64 `json:"max_memory,omitempty" mapstructure:"max_memory"`
+
+	// MaxFileDescriptors is the number of file descriptors used by connections.
+	// Default: 0 (scaled)
+	MaxFileDescriptors int `json:"max_file_descriptors,omitempty" mapstructure:"max_file_descriptors"`
+
+	// MaxConnections is the number of open connections, inbound and outbound.
+	// It should be above the connection manager high water mark of 200 connections,
+	// which prunes connections before the limit is reached.
+	// Default: 0 (scaled)
+	MaxConnections int `json:"max_connections,omitempty" mapstructure:"max_connections"`
+
+	// MaxStreams is the number of open streams, inbound and outbound.
+	// Default: 0 (scaled)
+	MaxStreams int `json:"max_streams,omitempty" mapstructure:"max_streams"`
+}
+
+// TimeoutsConfig configures the deadlines of remote operations, so that a slow DHT or
+// unresponsive peers cannot stall callers indefinitely. Each deadline is applied on top
+// of the caller's context; zero leaves an operation bounded by the caller's context only.
+type TimeoutsConfig struct {
+	// Provide bounds announcing a record as provided in the DHT.
+	// Default: 1m
+	Provide time.Duration `json:"provide,omitempty" mapstructure:"provide"`
+
+	// Pull bounds pulling a record from a remote peer.
+	// Default: 30s
+	Pull time.Duration `json:"pull,omitempty" mapstructure:"pull"`
+
+	// GossipSubPublish bounds publishing the label announcements of records via GossipSub.
+	// Default: 10s
+	GossipSubPublish time.Duration `json:"gossipsub_publish,omitempty" mapstructure:"gossipsub_publish"`
+
+	// Publish is the overall budget of publishing a record or a batch of records, covering
+	// the DHT announcement, GossipSub and IPNI. Publishing fails once it is exhausted.
+	// Default: 2m
+	Publish time.Duration `json:"publish,omitempty" mapstructure:"publish"`
+}
+
+// RepublishStrategyConfig configures the republish cadence of local records
+// with labels in a namespace, as namespaces differ in volatility.
+type RepublishStrategyConfig struct {
+	// Label namespace, built-in (e.g. "locators") or custom.
+	Namespace string `json:"namespace,omitempty" mapstructure:"namespace"`
+
+	// Interval at which local records with labels in the namespace are republished.
+	// Must be between 1 minute and 36 hours.
+	Interval time.Duration `json:"interval,omitempty" mapstructure:"interval"`
+}
+
+// ReplicationPolicyConfig configures the minimum number of providers of records with a label.
+type ReplicationPolicyConfig struct {
+	// Label the policy applies to, including its namespace, e.g. "/domains/research".
+	// Records with the label or a label below it (e.g. "/domains/research/ml") match.
+	Label string `json:"label,omitempty" mapstructure:"label"`
+
+	// Minimum number of peers that should provide matching records, between 2 and 20.
+	MinProviders int `json:"min_providers,omitempty" mapstructure:"min_providers"`
+}
+
+// ScoringConfig configures the scoring strategies of search results.
+type ScoringConfig struct {
+	// Strategy of searches that do not select one:
+	// count (default), weighted_namespaces, freshness, reputation or ranked.
+	Strategy string `json:"strategy,omitempty" mapstructure:"strategy"`
+
+	// Weights of matched queries by label namespace for the weighted_namespaces
+	// strategy, e.g. {"skills": 2, "locators": 0.5}. Other namespaces weigh 1.
+	NamespaceWeights map[string]float64 `json:"namespace_weights,omitempty" mapstructure:"namespace_weights"`
+
+	// Time after which the relevance of a record is halved by the freshness strategy
+	// if it was not announced again.
+	FreshnessHalfLife time.Duration `json:"freshness_half_life,omitempty" mapstructure:"freshness_half_life"`
+
+	// Weights of the ranking factors combined by the ranked strategy,
+	// used for searches that do not provide their own weights.
+	RankingWeights RankingWeightsConfig `json:"ranking_weights,omitempty" mapstructure:"ranking_weights"`
+}
+
+// RankingWeightsConfig weighs the ranking factors of the ranked scoring strategy.
+// Each factor is normalized to [0, 1], so that the weights are comparable.
+type RankingWeightsConfig struct {
+	// Weight of the fraction of queries matched.
+	// Default: 0.5
+	Match float64 `json:"match,omitempty" mapstructure:"match"`
+
+	// Weight of the freshness of the record's last announcement, decaying with freshness_half_life.
+	// Default: 0.2
+	Freshness float64 `json:"freshness,omitempty" mapstructure:"freshness"`
+
+	// Weight of the reputation of the providing peer.
+	// Default: 0.2
+	Reputation float64 `json:"reputation,omitempty" mapstructure:"reputation"`
+
+	// Weight of the number of peers providing the record.
+	// Default: 0.1
+	Providers float64 `json:"providers,omitempty" mapstructure:"providers"`
+}
+
+// HistoryConfig configures retention of discovery metrics (announcements, label cache size,
+// connected peers and searches), downsampled to hourly points and queried via
+// RoutingService.GetHistory, for operators without external monitoring.
+type HistoryConfig struct {
+	// Enabled controls whether discovery metrics are retained.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Retention is how long points are kept before they are pruned, at least 1 hour.
+	// Default: 336h (14 days, for week-over-week comparisons)
+	Retention time.Duration `json:"retention,omitempty" mapstructure:"retention"`
+}
+
+// ProvenanceConfig configures the provenance log, an append-only log of the announcements of
+// records received by this peer, written with their labels. It records which peer first announced
+// a record, and when and how the record was announced since, with its publisher and signature.
+type ProvenanceConfig struct {
+	// Enabled controls whether announcements are logged.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Retention is how long announcements are kept before they are pruned, at least 1 hour.
+	// The first announcement of each record is kept regardless.
+	// Default: 720h (30 days)
+	Retention time.Duration `json:"retention,omitempty" mapstructure:"retention"`
+}
+
+// TaxonomyConfig configures the label taxonomy. Labels of the namespaces it covers are
+// normalized to their canonical values when records are published and when announcements
+// of other peers are received, e.g. "/skills/AI" to "/skills/ai".
+type TaxonomyConfig struct {
+	// Path is the path of the taxonomy, a JSON file of the form
+	// {"version": "1.0.0", "namespaces": {"skills": ["natural_language_processing/text_completion"]}}.
+	// Labels are not normalized if it is empty.
+	Path string `json:"path,omitempty" mapstructure:"path"`
+
+	// Strict controls whether labels of covered namespaces that are not part of the
+	// taxonomy are dropped, both from published records and from received announcements.
+	// Default: false
+	Strict bool `json:"strict,omitempty" mapstructure:"strict"`
+}
+
+// RecordValidationConfig configures the validation of records before they are published.
+// Each rule is enabled separately. Records violating an enabled rule are not published,
+// and the publisher is told about all violations of the record.
+type RecordValidationConfig struct {
+	// Schema controls whether records are validated against their OASF schema.
+	// Default: false
+	Schema bool `json:"schema,omitempty" mapstructure:"schema"`
+
+	// Taxonomy controls whether the skills of records are checked against the skills ontology.
+	// Default: false
+	Taxonomy bool `json:"taxonomy,omitempty" mapstructure:"taxonomy"`
+
+	// SkillsOntology is the path of the skills ontology: a file with one skill name
+	// (e.g. "natural_language_processing/text_completion") per line. Required by Taxonomy.
+	SkillsOntology string `json:"skills_ontology,omitempty" mapstructure:"skills_ontology"`
+
+	// Locators controls whether records are required to have locators with a type and a URL.
+	// Default: false
+	Locators bool `json:"locators,omitempty" mapstructure:"locators"`
+
+	// LocatorReachability controls whether the HTTP and HTTPS locators of records
+	// are requested to check that they respond. It slows down publishing.
+	// Default: false
+	LocatorReachability bool `json:"locator_reachability,omitempty" mapstructure:"locator_reachability"`
+
+	// LocatorReachabilityTimeout bounds each locator request.
+	// Default: 5s
+	LocatorReachabilityTimeout time.Duration `json:"locator_reachability_timeout,omitempty" mapstructure:"locator_reachability_timeout"`
+}
+
+// LabelIndexConfig configures the label index, a SQL table mirroring the enhanced label keys
+// of the label cache. The label cache remains the source of truth: the index is updated by
+// the same writes and rebuilt from the label cache when it falls behind.
+type LabelIndexConfig struct {
+	// Driver of the index database: sqlite or postgres. Empty disables the index.
+	// Default: ""
+	Driver string `json:"driver,omitempty" mapstructure:"driver"`
+
+	// DSN of the index database: a file path for sqlite,
+	// a connection string (e.g. "host=db user=dir dbname=labels") for postgres.
+	DSN string `json:"dsn,omitempty" mapstructure:"dsn"`
+}
+
+// SearchCacheConfig configures the search result cache: the results of first search pages are
+// cached by their normalized query set, and invalidated as labels they may match are added to or
+// removed from the label cache. Live searches and later pages are not cached.
+type SearchCacheConfig struct {
+	// Enabled controls whether search results are cached.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// TTL is how long search results are cached at most. It bounds how long changes that do not
+	// invalidate cached searches, e.g. peers becoming unreachable, take to be reflected.
+	// Default: 30s
+	TTL time.Duration `json:"ttl,omitempty" mapstructure:"ttl"`
+
+	// MaxBytes is the memory budget of the cached results; the least recently used searches
+	// are evicted to stay within it.
+	// Default: 67108864 (64 MiB)
+	MaxBytes uint64 `json:"max_bytes,omitempty" mapstructure:"max_bytes"`
+}
+
+// PrefetchConfig configures prefetching: the records of search results matching enough
+// queries are pulled from their providers into the local store in the background, so that
+// pulling them after searching is served locally instead of paying the latency twice.
+type PrefetchConfig struct {
+	// Enabled controls whether search results are prefetched.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// MinScore is the minimum match score of prefetched search results.
+	// Default: 2
+	MinScore uint32 `json:"min_score,omitempty" mapstructure:"min_score"`
+
+	// QuotaBytes bounds the total content size of the prefetched records in the local store.
+	// The least recently prefetched records are deleted to make room for new ones.
+	// Default: 1073741824 (1 GiB)
+	QuotaBytes uint64 `json:"quota_bytes,omitempty" mapstructure:"quota_bytes"`
+}
+
+// RetainPulledConfig configures the retention of records pulled by the DHT+Pull fallback:
+// instead of discarding their content once their labels are cached, they are kept in the
+// local store and the node announces itself as a secondary provider of them in the DHT.
+type RetainPulledConfig struct {
+	// Enabled controls whether pulled records are retained.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// BudgetBytes bounds the total content size of the retained records in the local store.
+	// The least recently used records, i.e. retained or served to peers, are deleted to make
+	// room for new ones.
+	// Default: 1073741824 (1 GiB)
+	BudgetBytes uint64 `json:"budget_bytes,omitempty" mapstructure:"budget_bytes"`
+}
+
+// RecordGCConfig configures the garbage collection of local records under disk pressure:
+// while the filesystem holding the local store is filled beyond the threshold, the least
+// recently published local records that are not pinned are deleted from the store and
+// their announcements retracted.
+type RecordGCConfig struct {
+	// Path of the filesystem whose usage is checked, e.g. the local store directory.
+	// Required when Threshold is set.
+	Path string `json:"path,omitempty" mapstructure:"path"`
+
+	// Threshold is the fraction of the filesystem in use at which local records are collected, e.g. 0.9.
+	// Default: 0 (disabled)
+	Threshold float64 `json:"threshold,omitempty" mapstructure:"threshold"`
+
+	// Interval is how often the filesystem usage is checked.
+	// Default: 5m
+	Interval time.Duration `json:"interval,omitempty" mapstructure:"interval"`
+}
+
+// LivenessConfig configures liveness probes of the remote peers with cached labels.
+// Labels of peers that stay unreachable are excluded from search results, and
+// eventually deleted, instead of lingering until their labels become stale.
+type LivenessConfig struct {
+	// Enabled controls whether peers with cached labels are probed.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// Interval is how often peers with cached labels are probed.
+	// Default: 5m
+	Interval time.Duration `json:"interval,omitempty" mapstructure:"interval"`
+
+	// StaleAfter is how long a peer must be unreachable before its labels are
+	// excluded from search results. Labels of pinned records are still returned.
+	// Default: 30m
+	StaleAfter time.Duration `json:"stale_after,omitempty" mapstructure:"stale_after"`
+
+	// DeleteAfter is how long a peer must be unreachable before its labels are
+	// deleted from the label cache, at least StaleAfter. Zero keeps the labels
+	// until they are cleaned up as stale. Labels of pinned records are kept.
+	// Default: 24h
+	DeleteAfter time.Duration `json:"delete_after,omitempty" mapstructure:"delete_after"`
+}
+
+// FollowerConfig configures follower mode: the peer is a warm standby of a primary peer sharing
+// its identity key. It mirrors the routing datastore of the primary, but neither connects to
+// the network nor announces records until it is promoted via RoutingAdminService.PromoteFollower,
+// so that the primary and its standby never announce records at the same time.
+type FollowerConfig struct {
+	// Enabled controls whether the peer starts as a follower. Requires key_path to be the
+	// identity key of the primary.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// PrimaryAddress is the gRPC address of the primary's API server, e.g. "dir-primary:8888".
+	PrimaryAddress string `json:"primary_address,omitempty" mapstructure:"primary_address"`
+
+	// SyncInterval is how often the routing datastore of the primary is mirrored.
+	// Default: 30s
+	SyncInterval time.Duration `json:"sync_interval,omitempty" mapstructure:"sync_interval"`
+}
+
+// IPNIConfig configures IPNI (InterPlanetary Network Indexer) advertisements. Published records
+// are advertised in a chain of signed advertisements with the record CID as context ID and
+// entry, and the record labels as metadata. The chain is served over HTTP and its head is
+// announced to the configured indexers, which fetch new advertisements from the publisher.
+type IPNIConfig struct {
+	// Enabled controls whether published records are advertised.
+	// Default: false
+	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// ListenAddress is the address the advertisement chain is served at.
+	// Default: 0.0.0.0:3104
+	ListenAddress string `json:"listen_address,omitempty" mapstructure:"listen_address"`
+
+	// AnnounceAddress is the HTTP multiaddr indexers fetch advertisements from,
+	// e.g. "/dns4/dir.example.com/tcp/3104/http". Required when indexers are set.
+	AnnounceAddress string `json:"announce_address,omitempty" mapstructure:"announce_address"`
+
+	// Indexers are the base URLs of the indexers new advertisements are announced to,
+	// e.g. "https://cid.contact". Without indexers, advertisements are only served.
+	Indexers []string `json:"indexers,omitempty" mapstructure:"indexers"`
 }
 
 // GossipSubConfig configures GossipSub-based label announcements.
 // Protocol parameters (topic name, message size limits) are NOT configurable
 // and are defined in server/routing/pubsub/constants.go to ensure network-wide
-// compatibility. Only the enable/disable flag is configurable.
+// compatibility. Only the enable/disable flag, the subscription policy and the
+// signature policy are configurable.
 //
 // Benefits when enabled:
 //   - Reaches ALL subscribed peers (not just k-closest in DHT)
@@ -62,4 +818,158 @@ type GossipSubConfig struct {
 	// Note: Protocol parameters (topic, message size) are hardcoded in
 	// server/routing/pubsub/constants.go for network compatibility.
 	Enabled bool `json:"enabled,omitempty" mapstructure:"enabled"`
+
+	// RequireSignatures controls whether unsigned label announcements are dropped.
+	// Announcements are always signed with the peer's Ed25519 identity key and
+	// invalid signatures are always rejected; this flag additionally rejects
+	// announcements without a signature (e.g. from older peers).
+	// Default: false (accept unsigned announcements during rollout)
+	RequireSignatures bool `json:"require_signatures,omitempty" mapstructure:"require_signatures"`
+
+	// Namespaces is the subscription policy: the label namespaces whose topics
+	// this node subscribes to (e.g. ["skills", "domains"]).
+	// Records are always published to all namespace topics regardless of this policy.
+	// Default: empty (subscribe to all namespaces)
+	Namespaces []string `json:"namespaces,omitempty" mapstructure:"namespaces"`
+
+	// WireFormat is the encoding of published label announcements: "json", "protobuf"
+	// or "zstd" (zstd-compressed protobuf batches). Announcements are accepted in all
+	// formats, but peers that predate a format drop its announcements, so switch once
+	// all peers are upgraded.
+	// Default: "json"
+	WireFormat string `json:"wire_format,omitempty" mapstructure:"wire_format"`
+
+	// BatchWindow is how long label announcements of individually published records
+	// are aggregated before they are published as one batch message per namespace.
+	// Combined with the "zstd" wire format, hundreds of announcements fit in a message.
+	// Zero publishes every announcement right away. At most one minute.
+	// Default: 0 (disabled)
+	BatchWindow time.Duration `json:"batch_window,omitempty" mapstructure:"batch_window"`
+
+	// PropagationAcks is a debug mode in which peers acknowledge received announcements
+	// to their originator on a dedicated topic, to measure how long announcements take to
+	// propagate. It adds a message per received announcement, so only enable it while
+	// debugging propagation. Latencies are only accurate between peers with synchronized clocks.
+	// Default: false
+	PropagationAcks bool `json:"propagation_acks,omitempty" mapstructure:"propagation_acks"`
+}
+
+// RateLimitConfig configures per-peer token bucket rate limits protecting this peer
+// from peers flooding announcements or hammering its RPC service.
+// Peers exceeding a limit too often are temporarily banned from both.
+type RateLimitConfig struct {
+	// GossipSub messages accepted per second from a single originating peer.
+	// Zero disables the announcement rate limit.
+	// Default: 20
+	AnnouncementRate float64 `json:"announcement_rate,omitempty" mapstructure:"announcement_rate"`
+
+	// Messages a single peer may send at once before AnnouncementRate applies.
+	// Default: 200
+	AnnouncementBurst int `json:"announcement_burst,omitempty" mapstructure:"announcement_burst"`
+
+	// RPC requests (e.g. Pull, Search) served per second to a single peer.
+	// Zero disables the request rate limit.
+	// Default: 50
+	RequestRate float64 `json:"request_rate,omitempty" mapstructure:"request_rate"`
+
+	// Requests a single peer may send at once before RequestRate applies.
+	// Default: 500
+	RequestBurst int `json:"request_burst,omitempty" mapstructure:"request_burst"`
+
+	// Rate limit violations within a minute after which a peer is banned.
+	// Zero disables bans.
+	// Default: 100
+	BanThreshold int `json:"ban_threshold,omitempty" mapstructure:"ban_threshold"`
+
+	// How long banned peers' announcements and requests are dropped.
+	// Default: 10m
+	BanDuration time.Duration `json:"ban_duration,omitempty" mapstructure:"ban_duration"`
+
+	// Bytes of record content served by Pull to a single peer per hour. Pulls of a peer
+	// are refused once it was served its quota within the current hour.
+	// Zero disables the quota; the bytes served are metered regardless.
+	// Default: 0
+	PullBytesPerHour uint64 `json:"pull_bytes_per_hour,omitempty" mapstructure:"pull_bytes_per_hour"`
+}
+
+// EventsConfig configures the publishing of routing events (record discovered,
+// record retracted, peer changed) to external message queues.
+// Each publisher is enabled by configuring its address; by default none is.
+type EventsConfig struct {
+	// Kafka publisher configuration
+	Kafka KafkaEventsConfig `json:"kafka,omitempty" mapstructure:"kafka"`
+
+	// NATS publisher configuration
+	NATS NATSEventsConfig `json:"nats,omitempty" mapstructure:"nats"`
+}
+
+// KafkaEventsConfig configures the Kafka event publisher.
+// Events are produced through a Kafka REST Proxy (v2 API) and keyed by CID
+// (or peer ID for peer events) to keep them ordered per partition.
+type KafkaEventsConfig struct {
+	// RESTProxyURL is the URL of the Kafka REST Proxy (e.g. "http://kafka-rest:8082").
+	// Empty disables the Kafka publisher.
+	RESTProxyURL string `json:"rest_proxy_url,omitempty" mapstructure:"rest_proxy_url"`
+
+	// Topic events are produced to.
+	// Default: "dir.routing.events"
+	Topic string `json:"topic,omitempty" mapstructure:"topic"`
+}
+
+// NATSEventsConfig configures the NATS event publisher.
+// Events are published to "<subject_prefix>.<event type>", e.g. "dir.routing.record.discovered".
+type NATSEventsConfig struct {
+	// URL of the NATS server (e.g. "nats://localhost:4222"). Empty disables the NATS publisher.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// SubjectPrefix of the event subjects.
+	// Default: "dir.routing"
+	SubjectPrefix string `json:"subject_prefix,omitempty" mapstructure:"subject_prefix"`
+}
+
+// AnnouncementSinksConfig configures the republishing of the label announcements published
+// and received via GossipSub, and the revocations retracting them, to external message buses.
+// Each sink is enabled by configuring its address; by default none is.
+type AnnouncementSinksConfig struct {
+	// NATS sink configuration
+	NATS NATSSinkConfig `json:"nats,omitempty" mapstructure:"nats"`
+
+	// MQTT sink configuration
+	MQTT MQTTSinkConfig `json:"mqtt,omitempty" mapstructure:"mqtt"`
+}
+
+// NATSSinkConfig configures the NATS announcement sink.
+// Messages are published to "<subject_prefix>.<announced|retracted>.<peer ID>".
+type NATSSinkConfig struct {
+	// URL of the NATS server (e.g. "nats://localhost:4222"). Empty disables the NATS sink.
+	URL string `json:"url,omitempty" mapstructure:"url"`
+
+	// SubjectPrefix of the announcement subjects.
+	// Default: "dir.announcements"
+	SubjectPrefix string `json:"subject_prefix,omitempty" mapstructure:"subject_prefix"`
+}
+
+// MQTTSinkConfig configures the MQTT announcement sink.
+// Messages are published to "<topic_prefix>/<announced|retracted>/<peer ID>".
+type MQTTSinkConfig struct {
+	// BrokerURL is the URL of the MQTT broker (e.g. "tcp://mosquitto:1883", "ssl://broker:8883").
+	// Empty disables the MQTT sink.
+	BrokerURL string `json:"broker_url,omitempty" mapstructure:"broker_url"`
+
+	// TopicPrefix of the announcement topics.
+	// Default: "dir/announcements"
+	TopicPrefix string `json:"topic_prefix,omitempty" mapstructure:"topic_prefix"`
+
+	// ClientID of the connection to the broker. Empty uses "dir-<peer ID>".
+	ClientID string `json:"client_id,omitempty" mapstructure:"client_id"`
+
+	// QoS of the published messages: 0 (at most once), 1 (at least once) or 2 (exactly once).
+	// Default: 1
+	QoS int `json:"qos,omitempty" mapstructure:"qos"`
+
+	// Username authenticating the connection, if set.
+	Username string `json:"username,omitempty" mapstructure:"username"`
+
+	// Password authenticating the connection, if set.
+	Password string `json:"password,omitempty" mapstructure:"password"`
 }
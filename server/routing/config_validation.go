@@ -0,0 +1,461 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/accesstoken"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/internal/p2p"
+	"github.com/agntcy/dir/server/routing/labelindex"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/taxonomy"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// mdnsServiceNamePattern matches mDNS service names, which must be valid DNS labels.
+var mdnsServiceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// ValidateConfig checks the routing configuration before any routing component is created,
+// so that misconfigurations fail at startup with the offending setting instead of deep
+// inside the p2p host or a background task. All problems are reported at once.
+// Custom label namespaces must be registered first, as other settings may refer to them.
+func ValidateConfig(cfg routingconfig.Config) error {
+	var errs []error
+
+	invalid := func(setting string, err error) {
+		errs = append(errs, fmt.Errorf("routing.%s: %w", setting, err))
+	}
+
+	if _, err := parseDiscoveryProfile(cfg.Profile); err != nil {
+		invalid("profile", err)
+	}
+
+	// Addresses
+	if cfg.ListenAddress != "" {
+		if _, err := ma.NewMultiaddr(cfg.ListenAddress); err != nil {
+			invalid("listen_address", fmt.Errorf("invalid multiaddr %q: %w", cfg.ListenAddress, err))
+		}
+	}
+
+	if cfg.DirectoryAPIAddress != "" {
+		if _, _, err := net.SplitHostPort(cfg.DirectoryAPIAddress); err != nil {
+			invalid("directory_api_address", fmt.Errorf("must be host:port: %w", err))
+		}
+	}
+
+	if cfg.Zone != "" {
+		if err := validateZone(cfg.Zone); err != nil {
+			invalid("zone", err)
+		}
+	}
+
+	for i, addr := range cfg.BootstrapPeers {
+		if _, err := peer.AddrInfoFromString(addr); err != nil {
+			invalid(fmt.Sprintf("bootstrap_peers[%d]", i), fmt.Errorf("must be a multiaddr ending in /p2p/<peer-id>: %w", err))
+		}
+	}
+
+	if cfg.SeedPeer != "" {
+		if _, err := peer.AddrInfoFromString(cfg.SeedPeer); err != nil {
+			invalid("seed_peer", fmt.Errorf("must be a multiaddr ending in /p2p/<peer-id>: %w", err))
+		}
+	}
+
+	if cfg.MDNS.Enabled && !mdnsServiceNamePattern.MatchString(cfg.MDNS.ServiceName) {
+		invalid("mdns.service_name", fmt.Errorf("invalid service name %q, must be 1-63 letters, digits or dashes, not starting or ending with a dash", cfg.MDNS.ServiceName))
+	}
+
+	for i, addr := range cfg.NAT.StaticRelays {
+		if _, err := peer.AddrInfoFromString(addr); err != nil {
+			invalid(fmt.Sprintf("nat.static_relays[%d]", i), fmt.Errorf("must be a multiaddr ending in /p2p/<peer-id>: %w", err))
+		}
+	}
+
+	switch cfg.NAT.Reachability {
+	case "", p2p.ReachabilityPublic, p2p.ReachabilityPrivate:
+	default:
+		invalid("nat.reachability", fmt.Errorf("invalid reachability %q, must be %q or %q", cfg.NAT.Reachability, p2p.ReachabilityPublic, p2p.ReachabilityPrivate))
+	}
+
+	// DHT and resource limits
+	switch cfg.DHT.Mode {
+	case "", p2p.DHTModeClient, p2p.DHTModeServer, p2p.DHTModeAuto:
+	default:
+		invalid("dht.mode", fmt.Errorf("invalid DHT mode %q, must be %q, %q or %q", cfg.DHT.Mode, p2p.DHTModeClient, p2p.DHTModeServer, p2p.DHTModeAuto))
+	}
+
+	for setting, value := range map[string]int64{
+		"dht.bucket_size":                      int64(cfg.DHT.BucketSize),
+		"dht.concurrency":                      int64(cfg.DHT.Concurrency),
+		"resource_limits.max_memory":           cfg.ResourceLimits.MaxMemory,
+		"resource_limits.max_file_descriptors": int64(cfg.ResourceLimits.MaxFileDescriptors),
+		"resource_limits.max_connections":      int64(cfg.ResourceLimits.MaxConnections),
+		"resource_limits.max_streams":          int64(cfg.ResourceLimits.MaxStreams),
+	} {
+		if value < 0 {
+			invalid(setting, fmt.Errorf("%d must not be negative", value))
+		}
+	}
+
+	for setting, value := range map[string]time.Duration{
+		"timeouts.provide":           cfg.Timeouts.Provide,
+		"timeouts.pull":              cfg.Timeouts.Pull,
+		"timeouts.gossipsub_publish": cfg.Timeouts.GossipSubPublish,
+		"timeouts.publish":           cfg.Timeouts.Publish,
+	} {
+		if value < 0 {
+			invalid(setting, fmt.Errorf("%v must not be negative", value))
+		}
+	}
+
+	// Peer lists
+	for setting, peerIDs := range map[string][]string{
+		"allowed_peers":      cfg.AllowedPeers,
+		"denied_peers":       cfg.DeniedPeers,
+		"gated_access_peers": cfg.GatedAccessPeers,
+	} {
+		for i, peerID := range peerIDs {
+			if _, err := peer.Decode(peerID); err != nil {
+				invalid(fmt.Sprintf("%s[%d]", setting, i), fmt.Errorf("invalid peer ID %q: %w", peerID, err))
+			}
+		}
+	}
+
+	for i, token := range cfg.GatedAccessTokens {
+		if _, err := accesstoken.Decode(token); err != nil {
+			invalid(fmt.Sprintf("gated_access_tokens[%d]", i), err)
+		}
+	}
+
+	// Key files
+	for setting, path := range map[string]string{
+		"key_path":                 cfg.KeyPath,
+		"previous_key_path":        cfg.PreviousKeyPath,
+		"private_network_key_path": cfg.PrivateNetworkKeyPath,
+	} {
+		if path == "" {
+			continue
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			invalid(setting, fmt.Errorf("key file is not readable: %w", err))
+		}
+	}
+
+	// Intervals
+	if cfg.RefreshInterval < 0 || cfg.RefreshInterval >= RecordTTL {
+		invalid("refresh_interval", fmt.Errorf("%s must be between 0 (default) and the DHT record TTL of %s", cfg.RefreshInterval, RecordTTL))
+	}
+
+	strategies, err := newRepublishStrategies(cfg.RepublishStrategies)
+	if err != nil {
+		invalid("republish_strategies", err)
+	}
+
+	// Republishes within the dedup window would be coalesced and never reach the network
+	shortestRepublish := RepublishInterval
+	for _, strategy := range strategies {
+		shortestRepublish = min(shortestRepublish, strategy.interval)
+	}
+
+	if cfg.PublishDedupWindow < 0 || cfg.PublishDedupWindow >= shortestRepublish {
+		invalid("publish_dedup_window", fmt.Errorf("%s must be between 0 (disabled) and the shortest republish interval of %s", cfg.PublishDedupWindow, shortestRepublish))
+	}
+
+	if cfg.MaxCachedLabels < 0 {
+		invalid("max_cached_labels", fmt.Errorf("%d must not be negative, 0 leaves the cache unbounded", cfg.MaxCachedLabels))
+	}
+
+	if cfg.PullConcurrency < 0 {
+		invalid("pull_concurrency", fmt.Errorf("%d must not be negative", cfg.PullConcurrency))
+	}
+
+	if cfg.PullConcurrencyPerPeer < 0 {
+		invalid("pull_concurrency_per_peer", fmt.Errorf("%d must not be negative", cfg.PullConcurrencyPerPeer))
+	}
+
+	if cfg.DatastoreMaxBufferedWrites < 0 {
+		invalid("datastore_max_buffered_writes", fmt.Errorf("%d must not be negative", cfg.DatastoreMaxBufferedWrites))
+	}
+
+	if cfg.ReadinessMinPeers < 0 {
+		invalid("readiness_min_peers", fmt.Errorf("%d must not be negative", cfg.ReadinessMinPeers))
+	}
+
+	if cfg.MaxSubscriptions < 0 {
+		invalid("max_subscriptions", fmt.Errorf("%d must not be negative", cfg.MaxSubscriptions))
+	}
+
+	if _, err := newReplicationPolicies(cfg.Replication); err != nil {
+		invalid("replication", err)
+	}
+
+	if _, err := newScoringStrategies(cfg.Scoring, nil); err != nil {
+		invalid("scoring", err)
+	}
+
+	if cfg.Scoring.FreshnessHalfLife < 0 {
+		invalid("scoring.freshness_half_life", fmt.Errorf("%s must not be negative", cfg.Scoring.FreshnessHalfLife))
+	}
+
+	if cfg.History.Enabled && cfg.History.Retention < HistoryResolution {
+		invalid("history.retention", fmt.Errorf("%s must be at least the history resolution of %s", cfg.History.Retention, HistoryResolution))
+	}
+
+	if cfg.Provenance.Enabled && cfg.Provenance.Retention < ProvenancePruneInterval {
+		invalid("provenance.retention", fmt.Errorf("%s must be at least the prune interval of %s", cfg.Provenance.Retention, ProvenancePruneInterval))
+	}
+
+	if cfg.Liveness.Enabled {
+		if cfg.Liveness.Interval <= 0 {
+			invalid("liveness.interval", fmt.Errorf("%s must be positive", cfg.Liveness.Interval))
+		}
+
+		if cfg.Liveness.StaleAfter < cfg.Liveness.Interval {
+			invalid("liveness.stale_after", fmt.Errorf("%s must be at least the probe interval of %s", cfg.Liveness.StaleAfter, cfg.Liveness.Interval))
+		}
+
+		if cfg.Liveness.DeleteAfter != 0 && cfg.Liveness.DeleteAfter < cfg.Liveness.StaleAfter {
+			invalid("liveness.delete_after", fmt.Errorf("%s must be 0 (disabled) or at least stale_after of %s", cfg.Liveness.DeleteAfter, cfg.Liveness.StaleAfter))
+		}
+	}
+
+	// A generated identity cannot succeed the previous one across restarts
+	if cfg.PreviousKeyPath != "" && cfg.KeyPath == "" {
+		invalid("key_path", errors.New("must be set when previous_key_path is set"))
+	}
+
+	if cfg.Follower.Enabled {
+		if cfg.Follower.PrimaryAddress == "" {
+			invalid("follower.primary_address", errors.New("must be set when follower mode is enabled"))
+		}
+
+		// Followers take over the identity of the primary, so it cannot be generated
+		if cfg.KeyPath == "" {
+			invalid("key_path", errors.New("must be the identity key of the primary when follower mode is enabled"))
+		}
+
+		if cfg.Follower.SyncInterval <= 0 {
+			invalid("follower.sync_interval", fmt.Errorf("%s must be positive", cfg.Follower.SyncInterval))
+		}
+	}
+
+	if cfg.IPNI.Enabled {
+		if cfg.IPNI.ListenAddress == "" {
+			invalid("ipni.listen_address", errors.New("must be set when IPNI advertisements are enabled"))
+		}
+
+		if cfg.IPNI.AnnounceAddress != "" {
+			if _, err := ma.NewMultiaddr(cfg.IPNI.AnnounceAddress); err != nil {
+				invalid("ipni.announce_address", err)
+			}
+		} else if len(cfg.IPNI.Indexers) > 0 {
+			invalid("ipni.announce_address", errors.New("must be set when indexers are set"))
+		}
+
+		for _, indexer := range cfg.IPNI.Indexers {
+			if u, err := url.Parse(indexer); err != nil || u.Scheme == "" || u.Host == "" {
+				invalid("ipni.indexers", fmt.Errorf("%q is not a URL", indexer))
+			}
+		}
+	}
+
+	if cfg.SearchCache.Enabled {
+		if cfg.SearchCache.TTL <= 0 {
+			invalid("search_cache.ttl", fmt.Errorf("%s must be positive", cfg.SearchCache.TTL))
+		}
+
+		if cfg.SearchCache.MaxBytes == 0 {
+			invalid("search_cache.max_bytes", errors.New("must be positive"))
+		}
+	}
+
+	if cfg.Prefetch.Enabled && cfg.Prefetch.MinScore == 0 {
+		invalid("prefetch.min_score", errors.New("must be at least 1"))
+	}
+
+	if cfg.Prefetch.Enabled && cfg.Prefetch.QuotaBytes == 0 {
+		invalid("prefetch.quota_bytes", errors.New("must be positive"))
+	}
+
+	if cfg.RetainPulled.Enabled && cfg.RetainPulled.BudgetBytes == 0 {
+		invalid("retain_pulled.budget_bytes", errors.New("must be positive"))
+	}
+
+	if cfg.RecordGC.Threshold < 0 || cfg.RecordGC.Threshold >= 1 {
+		invalid("record_gc.threshold", fmt.Errorf("%v must be 0 (disabled) or between 0 and 1", cfg.RecordGC.Threshold))
+	}
+
+	if cfg.RecordGC.Threshold > 0 {
+		if cfg.RecordGC.Path == "" {
+			invalid("record_gc.path", errors.New("must be set when garbage collection is enabled"))
+		}
+
+		if cfg.RecordGC.Interval <= 0 {
+			invalid("record_gc.interval", fmt.Errorf("%s must be positive", cfg.RecordGC.Interval))
+		}
+	}
+
+	switch cfg.LabelIndex.Driver {
+	case "":
+	case labelindex.DriverSQLite, labelindex.DriverPostgres:
+		if cfg.LabelIndex.DSN == "" {
+			invalid("label_index.dsn", errors.New("must be set"))
+		}
+	default:
+		invalid("label_index.driver", fmt.Errorf("invalid driver %q, must be %q or %q", cfg.LabelIndex.Driver, labelindex.DriverSQLite, labelindex.DriverPostgres))
+	}
+
+	if cfg.RecordValidation.Taxonomy && cfg.RecordValidation.SkillsOntology == "" {
+		invalid("record_validation.skills_ontology", errors.New("must be set when taxonomy validation is enabled"))
+	} else if _, err := newRecordValidation(cfg.RecordValidation); err != nil {
+		invalid("record_validation.skills_ontology", err)
+	}
+
+	if cfg.RecordValidation.LocatorReachability && cfg.RecordValidation.LocatorReachabilityTimeout <= 0 {
+		invalid("record_validation.locator_reachability_timeout", errors.New("must be positive"))
+	}
+
+	if cfg.Taxonomy.Path != "" {
+		if _, err := taxonomy.Load(cfg.Taxonomy.Path); err != nil {
+			invalid("taxonomy.path", err)
+		}
+	} else if cfg.Taxonomy.Strict {
+		invalid("taxonomy.path", errors.New("must be set when strict is enabled"))
+	}
+
+	if _, err := newTenants(cfg.Tenants); err != nil {
+		invalid("tenants", err)
+	}
+
+	if _, err := newPublishers(cfg.Publishers); err != nil {
+		invalid("publishers", err)
+	}
+
+	if _, err := newPublishPolicy(cfg.PublishPolicy, cfg.LabelNamespaces); err != nil {
+		invalid("publish_policy", err)
+	}
+
+	errs = append(errs, validateGossipSubConfig(cfg.GossipSub)...)
+	errs = append(errs, validateRateLimitConfig(cfg.RateLimit)...)
+	errs = append(errs, validateEventsConfig(cfg.Events)...)
+	errs = append(errs, validateAnnouncementSinksConfig(cfg.AnnouncementSinks)...)
+
+	return errors.Join(errs...)
+}
+
+// validateGossipSubConfig checks that the GossipSub settings are consistent.
+func validateGossipSubConfig(cfg routingconfig.GossipSubConfig) []error {
+	var errs []error
+
+	if _, err := pubsub.ParseNamespaces(cfg.Namespaces); err != nil {
+		errs = append(errs, fmt.Errorf("routing.gossipsub.namespaces: %w", err))
+	}
+
+	switch cfg.WireFormat {
+	case "", pubsub.WireFormatJSON, pubsub.WireFormatProtobuf, pubsub.WireFormatZstd:
+	default:
+		errs = append(errs, fmt.Errorf("routing.gossipsub.wire_format: invalid wire format %q, must be %q, %q or %q",
+			cfg.WireFormat, pubsub.WireFormatJSON, pubsub.WireFormatProtobuf, pubsub.WireFormatZstd))
+	}
+
+	if cfg.BatchWindow < 0 || cfg.BatchWindow > pubsub.MaxBatchWindow {
+		errs = append(errs, fmt.Errorf("routing.gossipsub.batch_window: %s must be between 0 (disabled) and %s", cfg.BatchWindow, pubsub.MaxBatchWindow))
+	}
+
+	// Settings of a disabled GossipSub are most likely meant to take effect
+	if !cfg.Enabled {
+		if cfg.RequireSignatures {
+			errs = append(errs, errors.New("routing.gossipsub.require_signatures: has no effect while gossipsub is disabled, enable gossipsub or unset it"))
+		}
+
+		if len(cfg.Namespaces) > 0 {
+			errs = append(errs, errors.New("routing.gossipsub.namespaces: has no effect while gossipsub is disabled, enable gossipsub or unset it"))
+		}
+
+		if cfg.BatchWindow > 0 {
+			errs = append(errs, errors.New("routing.gossipsub.batch_window: has no effect while gossipsub is disabled, enable gossipsub or unset it"))
+		}
+	}
+
+	return errs
+}
+
+// validateRateLimitConfig checks that the rate limits are not negative and bans have a duration.
+func validateRateLimitConfig(cfg routingconfig.RateLimitConfig) []error {
+	var errs []error
+
+	for setting, value := range map[string]float64{
+		"announcement_rate":  cfg.AnnouncementRate,
+		"announcement_burst": float64(cfg.AnnouncementBurst),
+		"request_rate":       cfg.RequestRate,
+		"request_burst":      float64(cfg.RequestBurst),
+		"ban_threshold":      float64(cfg.BanThreshold),
+	} {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("routing.rate_limit.%s: %v must not be negative", setting, value))
+		}
+	}
+
+	if cfg.BanThreshold > 0 && cfg.BanDuration <= 0 {
+		errs = append(errs, fmt.Errorf("routing.rate_limit.ban_duration: %s must be positive while ban_threshold is set, set ban_threshold to 0 to disable bans", cfg.BanDuration))
+	}
+
+	return errs
+}
+
+// validateEventsConfig checks the settings of the configured event publishers.
+func validateEventsConfig(cfg routingconfig.EventsConfig) []error {
+	var errs []error
+
+	if cfg.Kafka.RESTProxyURL != "" {
+		if u, err := url.Parse(cfg.Kafka.RESTProxyURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("routing.events.kafka.rest_proxy_url: invalid URL %q, must be http(s)://<host>", cfg.Kafka.RESTProxyURL))
+		}
+
+		if cfg.Kafka.Topic == "" {
+			errs = append(errs, errors.New("routing.events.kafka.topic: must be set while rest_proxy_url is set"))
+		}
+	}
+
+	if cfg.NATS.URL != "" && cfg.NATS.SubjectPrefix == "" {
+		errs = append(errs, errors.New("routing.events.nats.subject_prefix: must be set while url is set"))
+	}
+
+	return errs
+}
+
+// validateAnnouncementSinksConfig checks the settings of the configured announcement sinks.
+func validateAnnouncementSinksConfig(cfg routingconfig.AnnouncementSinksConfig) []error {
+	var errs []error
+
+	if cfg.NATS.URL != "" && cfg.NATS.SubjectPrefix == "" {
+		errs = append(errs, errors.New("routing.announcement_sinks.nats.subject_prefix: must be set while url is set"))
+	}
+
+	if cfg.MQTT.BrokerURL != "" {
+		if u, err := url.Parse(cfg.MQTT.BrokerURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("routing.announcement_sinks.mqtt.broker_url: invalid URL %q, must be <scheme>://<host>:<port>", cfg.MQTT.BrokerURL))
+		}
+
+		if cfg.MQTT.TopicPrefix == "" {
+			errs = append(errs, errors.New("routing.announcement_sinks.mqtt.topic_prefix: must be set while broker_url is set"))
+		}
+	}
+
+	if cfg.MQTT.QoS < 0 || cfg.MQTT.QoS > 2 {
+		errs = append(errs, fmt.Errorf("routing.announcement_sinks.mqtt.qos: %d must be 0, 1 or 2", cfg.MQTT.QoS))
+	}
+
+	return errs
+}
@@ -0,0 +1,399 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func validTestConfig() routingconfig.Config {
+	return routingconfig.Config{
+		ListenAddress:       routingconfig.DefaultListenAddress,
+		BootstrapPeers:      routingconfig.DefaultBootstrapPeers,
+		PublishDedupWindow:  routingconfig.DefaultPublishDedupWindow,
+		DirectoryAPIAddress: "localhost:8888",
+		MDNS: routingconfig.MDNSConfig{
+			Enabled:     routingconfig.DefaultMDNSEnabled,
+			ServiceName: routingconfig.DefaultMDNSServiceName,
+		},
+		GossipSub: routingconfig.GossipSubConfig{
+			Enabled: true,
+		},
+		RateLimit: routingconfig.RateLimitConfig{
+			AnnouncementRate: routingconfig.DefaultRateLimitAnnouncementRate,
+			BanThreshold:     routingconfig.DefaultRateLimitBanThreshold,
+			BanDuration:      routingconfig.DefaultRateLimitBanDuration,
+		},
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(validTestConfig()))
+	assert.NoError(t, ValidateConfig(routingconfig.Config{}))
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *routingconfig.Config)
+		wantErr string
+	}{
+		{
+			name:    "unknown discovery profile",
+			modify:  func(cfg *routingconfig.Config) { cfg.Profile = "light" },
+			wantErr: "routing.profile",
+		},
+		{
+			name:    "listen address is not a multiaddr",
+			modify:  func(cfg *routingconfig.Config) { cfg.ListenAddress = "0.0.0.0:8999" },
+			wantErr: "routing.listen_address",
+		},
+		{
+			name:    "directory api address without port",
+			modify:  func(cfg *routingconfig.Config) { cfg.DirectoryAPIAddress = "localhost" },
+			wantErr: "routing.directory_api_address",
+		},
+		{
+			name:    "invalid zone",
+			modify:  func(cfg *routingconfig.Config) { cfg.Zone = "eu/west" },
+			wantErr: "routing.zone",
+		},
+		{
+			name: "duplicate tenant",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Tenants = []routingconfig.TenantConfig{{ID: "acme"}, {ID: "acme", Public: true}}
+			},
+			wantErr: "routing.tenants",
+		},
+		{
+			name:    "bootstrap peer without peer ID",
+			modify:  func(cfg *routingconfig.Config) { cfg.BootstrapPeers = []string{"/ip4/1.2.3.4/tcp/8999"} },
+			wantErr: "routing.bootstrap_peers[0]",
+		},
+		{
+			name: "mdns service name with dots",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.MDNS.Enabled = true
+				cfg.MDNS.ServiceName = "dir.local"
+			},
+			wantErr: "routing.mdns.service_name",
+		},
+		{
+			name:    "static relay without peer ID",
+			modify:  func(cfg *routingconfig.Config) { cfg.NAT.StaticRelays = []string{"/ip4/1.2.3.4/tcp/8999"} },
+			wantErr: "routing.nat.static_relays[0]",
+		},
+		{
+			name:    "unknown reachability",
+			modify:  func(cfg *routingconfig.Config) { cfg.NAT.Reachability = "unknown" },
+			wantErr: "routing.nat.reachability",
+		},
+		{
+			name:    "unknown DHT mode",
+			modify:  func(cfg *routingconfig.Config) { cfg.DHT.Mode = "hub" },
+			wantErr: "routing.dht.mode",
+		},
+		{
+			name:    "negative connection limit",
+			modify:  func(cfg *routingconfig.Config) { cfg.ResourceLimits.MaxConnections = -1 },
+			wantErr: "routing.resource_limits.max_connections",
+		},
+		{
+			name:    "invalid denied peer",
+			modify:  func(cfg *routingconfig.Config) { cfg.DeniedPeers = []string{"not-a-peer-id"} },
+			wantErr: "routing.denied_peers[0]",
+		},
+		{
+			name:    "invalid gated access token",
+			modify:  func(cfg *routingconfig.Config) { cfg.GatedAccessTokens = []string{"not-a-token"} },
+			wantErr: "routing.gated_access_tokens[0]",
+		},
+		{
+			name:    "missing key file",
+			modify:  func(cfg *routingconfig.Config) { cfg.KeyPath = "/nonexistent/node.privkey" },
+			wantErr: "routing.key_path",
+		},
+		{
+			name:    "previous key without identity key",
+			modify:  func(cfg *routingconfig.Config) { cfg.PreviousKeyPath = "/nonexistent/old.privkey" },
+			wantErr: "routing.key_path",
+		},
+		{
+			name:    "refresh interval exceeds record TTL",
+			modify:  func(cfg *routingconfig.Config) { cfg.RefreshInterval = RecordTTL },
+			wantErr: "routing.refresh_interval",
+		},
+		{
+			name: "dedup window exceeds republish interval",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RepublishStrategies = []routingconfig.RepublishStrategyConfig{{Namespace: "skills", Interval: time.Minute}}
+				cfg.PublishDedupWindow = time.Hour
+			},
+			wantErr: "routing.publish_dedup_window",
+		},
+		{
+			name: "unknown republish namespace",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RepublishStrategies = []routingconfig.RepublishStrategyConfig{{Namespace: "unknown", Interval: time.Hour}}
+			},
+			wantErr: "routing.republish_strategies",
+		},
+		{
+			name:    "unknown scoring strategy",
+			modify:  func(cfg *routingconfig.Config) { cfg.Scoring.Strategy = "unknown" },
+			wantErr: "routing.scoring",
+		},
+		{
+			name: "signatures required while gossipsub is disabled",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.GossipSub.Enabled = false
+				cfg.GossipSub.RequireSignatures = true
+			},
+			wantErr: "routing.gossipsub.require_signatures",
+		},
+		{
+			name:    "unknown gossipsub namespace",
+			modify:  func(cfg *routingconfig.Config) { cfg.GossipSub.Namespaces = []string{"unknown"} },
+			wantErr: "routing.gossipsub.namespaces",
+		},
+		{
+			name:    "unknown gossipsub wire format",
+			modify:  func(cfg *routingconfig.Config) { cfg.GossipSub.WireFormat = "cbor" },
+			wantErr: "routing.gossipsub.wire_format",
+		},
+		{
+			name:    "gossipsub batch window too long",
+			modify:  func(cfg *routingconfig.Config) { cfg.GossipSub.BatchWindow = 2 * time.Minute },
+			wantErr: "routing.gossipsub.batch_window",
+		},
+		{
+			name: "record gc threshold out of range",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RecordGC = routingconfig.RecordGCConfig{Path: "/var/lib/dir", Threshold: 1.5, Interval: time.Minute}
+			},
+			wantErr: "routing.record_gc.threshold",
+		},
+		{
+			name: "record gc without path",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RecordGC = routingconfig.RecordGCConfig{Threshold: 0.9, Interval: time.Minute}
+			},
+			wantErr: "routing.record_gc.path",
+		},
+		{
+			name: "publish policy of unknown namespace",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.PublishPolicy = []routingconfig.LabelPolicyConfig{{Namespace: "unknown", Exclude: []string{"internal"}}}
+			},
+			wantErr: "routing.publish_policy",
+		},
+		{
+			name: "invalid publish policy pattern",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.PublishPolicy = []routingconfig.LabelPolicyConfig{{Namespace: "modules", Exclude: []string{"internal/["}}}
+			},
+			wantErr: "routing.publish_policy",
+		},
+		{
+			name:    "negative pull concurrency per peer",
+			modify:  func(cfg *routingconfig.Config) { cfg.PullConcurrencyPerPeer = -1 },
+			wantErr: "routing.pull_concurrency_per_peer",
+		},
+		{
+			name:    "negative datastore write buffer",
+			modify:  func(cfg *routingconfig.Config) { cfg.DatastoreMaxBufferedWrites = -1 },
+			wantErr: "routing.datastore_max_buffered_writes",
+		},
+		{
+			name:    "negative readiness min peers",
+			modify:  func(cfg *routingconfig.Config) { cfg.ReadinessMinPeers = -1 },
+			wantErr: "routing.readiness_min_peers",
+		},
+		{
+			name:    "negative max subscriptions",
+			modify:  func(cfg *routingconfig.Config) { cfg.MaxSubscriptions = -1 },
+			wantErr: "routing.max_subscriptions",
+		},
+		{
+			name:    "bans without duration",
+			modify:  func(cfg *routingconfig.Config) { cfg.RateLimit.BanDuration = 0 },
+			wantErr: "routing.rate_limit.ban_duration",
+		},
+		{
+			name:    "negative rate",
+			modify:  func(cfg *routingconfig.Config) { cfg.RateLimit.RequestRate = -1 },
+			wantErr: "routing.rate_limit.request_rate",
+		},
+		{
+			name:    "kafka without topic",
+			modify:  func(cfg *routingconfig.Config) { cfg.Events.Kafka.RESTProxyURL = "http://kafka-rest:8082" },
+			wantErr: "routing.events.kafka.topic",
+		},
+		{
+			name: "kafka proxy url without scheme",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Events.Kafka.RESTProxyURL = "kafka-rest:8082"
+				cfg.Events.Kafka.Topic = routingconfig.DefaultEventsKafkaTopic
+			},
+			wantErr: "routing.events.kafka.rest_proxy_url",
+		},
+		{
+			name: "mqtt sink broker url without scheme",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.AnnouncementSinks.MQTT.BrokerURL = "mosquitto:1883"
+				cfg.AnnouncementSinks.MQTT.TopicPrefix = routingconfig.DefaultAnnouncementSinksMQTTTopicPrefix
+			},
+			wantErr: "routing.announcement_sinks.mqtt.broker_url",
+		},
+		{
+			name:    "invalid mqtt sink qos",
+			modify:  func(cfg *routingconfig.Config) { cfg.AnnouncementSinks.MQTT.QoS = 3 },
+			wantErr: "routing.announcement_sinks.mqtt.qos",
+		},
+		{
+			name: "history retention shorter than resolution",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.History.Enabled = true
+				cfg.History.Retention = time.Minute
+			},
+			wantErr: "routing.history.retention",
+		},
+		{
+			name: "provenance retention shorter than prune interval",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Provenance = routingconfig.ProvenanceConfig{Enabled: true, Retention: time.Minute}
+			},
+			wantErr: "routing.provenance.retention",
+		},
+		{
+			name: "liveness stale period shorter than interval",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Liveness = routingconfig.LivenessConfig{Enabled: true, Interval: time.Hour, StaleAfter: time.Minute}
+			},
+			wantErr: "routing.liveness.stale_after",
+		},
+		{
+			name: "liveness delete period shorter than stale period",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Liveness = routingconfig.LivenessConfig{Enabled: true, Interval: time.Minute, StaleAfter: time.Hour, DeleteAfter: time.Minute}
+			},
+			wantErr: "routing.liveness.delete_after",
+		},
+		{
+			name: "follower without identity key",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Follower = routingconfig.FollowerConfig{Enabled: true, PrimaryAddress: "dir-primary:8888", SyncInterval: time.Minute}
+			},
+			wantErr: "routing.key_path",
+		},
+		{
+			name: "IPNI indexers without announce address",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.IPNI = routingconfig.IPNIConfig{Enabled: true, ListenAddress: "0.0.0.0:3104", Indexers: []string{"https://cid.contact"}}
+			},
+			wantErr: "routing.ipni.announce_address",
+		},
+		{
+			name: "search cache without TTL",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.SearchCache = routingconfig.SearchCacheConfig{Enabled: true, MaxBytes: 1 << 20}
+			},
+			wantErr: "routing.search_cache.ttl",
+		},
+		{
+			name: "prefetch without quota",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Prefetch.Enabled = true
+				cfg.Prefetch.MinScore = 1
+			},
+			wantErr: "routing.prefetch.quota_bytes",
+		},
+		{
+			name:    "negative pull timeout",
+			modify:  func(cfg *routingconfig.Config) { cfg.Timeouts.Pull = -time.Second },
+			wantErr: "routing.timeouts.pull",
+		},
+		{
+			name:    "pulled record retention without budget",
+			modify:  func(cfg *routingconfig.Config) { cfg.RetainPulled.Enabled = true },
+			wantErr: "routing.retain_pulled.budget_bytes",
+		},
+		{
+			name: "unknown label index driver",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.LabelIndex.Driver = "mysql"
+			},
+			wantErr: "routing.label_index.driver",
+		},
+		{
+			name: "label index without dsn",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.LabelIndex.Driver = "sqlite"
+			},
+			wantErr: "routing.label_index.dsn",
+		},
+		{
+			name: "taxonomy validation without ontology",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RecordValidation.Taxonomy = true
+			},
+			wantErr: "routing.record_validation.skills_ontology",
+		},
+		{
+			name: "taxonomy validation with missing ontology",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RecordValidation.Taxonomy = true
+				cfg.RecordValidation.SkillsOntology = "/nonexistent/skills.txt"
+			},
+			wantErr: "routing.record_validation.skills_ontology",
+		},
+		{
+			name: "locator reachability without timeout",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.RecordValidation.LocatorReachability = true
+			},
+			wantErr: "routing.record_validation.locator_reachability_timeout",
+		},
+		{
+			name: "missing taxonomy",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Taxonomy.Path = "/nonexistent/taxonomy.json"
+			},
+			wantErr: "routing.taxonomy.path",
+		},
+		{
+			name: "strict taxonomy without path",
+			modify: func(cfg *routingconfig.Config) {
+				cfg.Taxonomy.Strict = true
+			},
+			wantErr: "routing.taxonomy.path",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validTestConfig()
+			tt.modify(&cfg)
+
+			err := ValidateConfig(cfg)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_ReportsAllErrors(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.ListenAddress = "invalid"
+	cfg.MaxCachedLabels = -1
+
+	err := ValidateConfig(cfg)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "routing.listen_address")
+		assert.Contains(t, err.Error(), "routing.max_cached_labels")
+	}
+}
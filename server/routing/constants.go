@@ -16,13 +16,139 @@ const (
 	// Provider records typically expire after 24h, but we use a longer interval for robustness.
 	// This ensures our content remains discoverable by triggering pull-based label caching.
 	RepublishInterval = 36 * time.Hour
+	// HighPriorityRepublishInterval defines how often high-priority records are republished.
+	// Shorter than RepublishInterval to keep their labels fresh in remote caches.
+	HighPriorityRepublishInterval = 12 * time.Hour
+	// MinRepublishInterval bounds how often a republish strategy may republish
+	// records of a namespace, to keep the DHT and GossipSub load reasonable.
+	MinRepublishInterval = time.Minute
+	// RepublishJitter is the fraction by which republish intervals are randomly varied,
+	// so that peers started together do not republish at the same time.
+	RepublishJitter = 0.1
+	// RepublishSpread is the fraction of a republish interval across which the records of
+	// a cycle are republished, instead of all at once.
+	RepublishSpread = 0.2
+	// RepublishStepJitter is the fraction by which the time between republished records is randomly varied.
+	RepublishStepJitter = 0.5
+	// RepublishExpiryMargin is how long before its estimated DHT expiry a provider record
+	// is republished at the latest, however slow the DHT is.
+	RepublishExpiryMargin = time.Hour
+	// RepublishTargetLatency is the latency of republishing a record above which
+	// republishing slows down proportionally, up to RepublishMaxThrottle.
+	RepublishTargetLatency = 2 * time.Second
+	// RepublishMaxThrottle bounds how much republishing slows down while the DHT is slow.
+	RepublishMaxThrottle = 4.0
+	// RepublishLatencySmoothing is the weight of the latest republish latency in its moving average.
+	RepublishLatencySmoothing = 0.2
 	// CleanupInterval defines how often we clean up stale announcements.
 	// This should match DHTRecordTTL to stay consistent with DHT behavior and prevent
 	// our local cache from having stale entries that no longer exist in the DHT.
 	CleanupInterval = 48 * time.Hour
+	// ExpiredRecordCleanupInterval defines how often local records whose TTL has elapsed
+	// are removed. It matches types.MinRecordTTL.
+	ExpiredRecordCleanupInterval = time.Minute
+	// CardinalityRebuildInterval defines how often the cardinality index used by
+	// EstimateResults and the provider sets are rebuilt from the label cache to drop removed labels.
+	CardinalityRebuildInterval = 10 * time.Minute
+	// LabelCacheCompactionInterval defines how often buffered LastSeen refreshes of cached
+	// labels are written to the datastore and the label cache is trimmed to its maximum size.
+	// It is far shorter than MaxLabelAge, so refreshed labels are never cleaned up as stale.
+	LabelCacheCompactionInterval = time.Minute
+	// ReplicationInterval defines how often the provider counts of records matched
+	// by replication policies are checked.
+	ReplicationInterval = 30 * time.Minute
+	// ReplicationLookupTimeout bounds the DHT provider lookup and pull of a single record.
+	ReplicationLookupTimeout = 30 * time.Second
+	// PendingAnnouncementInterval defines how often records published while the routing table
+	// was empty are announced if it has peers now.
+	PendingAnnouncementInterval = 10 * time.Second
+	// NotifyQueueInterval defines how often queued DHT provider notifications are checked
+	// for due retries and expired dead letters.
+	NotifyQueueInterval = 10 * time.Second
+	// NotifyQueueBatchSize defines how many queued notifications are read from the datastore at once.
+	NotifyQueueBatchSize = 100
+	// NotifyRetryDelay defines the delay before the first retry of a failed notification.
+	// It doubles with every further attempt.
+	NotifyRetryDelay = 30 * time.Second
+	// NotifyDeadLetterRetention defines how long dead-lettered notifications are kept for inspection.
+	NotifyDeadLetterRetention = 7 * 24 * time.Hour
+	// GrantAccessTTL defines how long access tokens issued by GrantAccess are valid by default.
+	GrantAccessTTL = 30 * 24 * time.Hour
+	// ProviderLookupTTL defines how long the providers found by a DHT provider lookup are reused.
+	ProviderLookupTTL = time.Hour
+	// NegativeProviderLookupTTL defines how long a DHT provider lookup that found no providers
+	// is reused. It is shorter than ProviderLookupTTL, as new providers are expected to appear.
+	NegativeProviderLookupTTL = 5 * time.Minute
+	// AnnouncementVerificationInterval defines how often local records are verified
+	// to be resolvable by other peers.
+	AnnouncementVerificationInterval = time.Hour
+	// AnnouncementVerificationTimeout bounds the DHT lookup and peer checks of a single record.
+	AnnouncementVerificationTimeout = 30 * time.Second
+	// PrefetchTimeout bounds prefetching a single search result from its provider.
+	PrefetchTimeout = 30 * time.Second
+	// RetainedProvideTimeout bounds announcing this peer as a provider of a retained record.
+	RetainedProvideTimeout = time.Minute
+	// LabelPropagationCheckInterval defines how often GossipSub topic peers are asked whether
+	// they cached the labels of records published with progress.
+	LabelPropagationCheckInterval = time.Second
+	// LabelPropagationTimeout bounds how long the label propagation of records published
+	// with progress is checked before it is reported as unconfirmed.
+	LabelPropagationTimeout = 10 * time.Second
+	// TaskStartupDelay is the earliest that overdue background tasks run after startup,
+	// so that the DHT routing table is populated before records are republished.
+	TaskStartupDelay = time.Minute
 	// RefreshInterval defines how often DHT routing tables are refreshed.
 	// This is a shorter interval for maintaining network connectivity.
 	RefreshInterval = 30 * time.Second
+	// DatastoreMetricsReportInterval defines how often routing datastore metrics are logged.
+	DatastoreMetricsReportInterval = 5 * time.Minute
+	// StreamPoolReportInterval defines how often warm RPC stream pool stats are logged.
+	StreamPoolReportInterval = 5 * time.Minute
+	// MetricsReportInterval defines how often the label cache, DHT and GossipSub gauges are updated.
+	MetricsReportInterval = 15 * time.Second
+	// CacheWarmTimeout bounds how long the label cache is warmed from the seed peer.
+	// Search waits at most this long for warming to complete.
+	CacheWarmTimeout = time.Minute
+	// RevocationTTL defines how long received record revocations are honoured.
+	// It matches RecordTTL, after which the revoked records' DHT entries have expired.
+	RevocationTTL = RecordTTL
+	// RevocationLookupTimeout bounds the DHT lookup for a revocation before
+	// a record is pulled via the DHT+Pull fallback.
+	RevocationLookupTimeout = 2 * time.Second
+	// ContinuityTTL defines how long continuity records of identity rotations are honoured
+	// and announced. It matches RecordTTL, after which the labels cached under the old peer ID expired.
+	ContinuityTTL = RecordTTL
+	// ContinuityAnnounceInterval defines how often the continuity record of this peer's
+	// identity rotation is announced, reaching peers that missed earlier announcements.
+	ContinuityAnnounceInterval = 30 * time.Minute
+	// MaxContinuityChain bounds the successive rotations followed to find the current identity of a peer.
+	MaxContinuityChain = 8
+	// LiveSearchTimeout bounds the live search RPC to a single peer.
+	LiveSearchTimeout = 5 * time.Second
+	// LabelDigestInterval defines how often the label digest of the local peer is published.
+	LabelDigestInterval = 5 * time.Minute
+	// LabelDigestTTL defines how long a received label digest is used to select the
+	// peers of live searches. Afterwards, the peer is queried until a new digest arrives.
+	LabelDigestTTL = 3 * LabelDigestInterval
+	// CacheVerificationTimeout bounds the RPCs asking a single provider which of
+	// the sampled records it still stores.
+	CacheVerificationTimeout = 10 * time.Second
+	// LabelSyncInterval defines how often the labels published by connected peers are
+	// synced after the initial sync on bootstrap. Only changes since the last sync are fetched.
+	LabelSyncInterval = 30 * time.Minute
+	// LabelSyncTimeout bounds syncing the published labels of a single peer.
+	LabelSyncTimeout = time.Minute
+	// LabelSyncOverlap is how long before the requested time records updated are served
+	// again by label sync, so that records written while the previous sync ran are not missed.
+	LabelSyncOverlap = time.Minute
+	// BackfillCrawlTimeout bounds crawling the DHT for the peers a backfill pulls records from.
+	BackfillCrawlTimeout = 5 * time.Minute
+	// HistoryResolution is the time covered by each retained point of discovery history.
+	HistoryResolution = time.Hour
+	// HistorySampleInterval defines how often discovery metrics are sampled into the current history point.
+	HistorySampleInterval = 5 * time.Minute
+	// ProvenancePruneInterval defines how often announcements older than the retention are pruned from the provenance log.
+	ProvenancePruneInterval = time.Hour
 )
 
 // Protocol constants for libp2p DHT and discovery.
@@ -30,6 +156,9 @@ const (
 	// ProtocolPrefix is the prefix used for DHT protocol identification.
 	ProtocolPrefix = "dir"
 
+	// DHTProtocol is the DHT protocol spoken by peers of the directory network.
+	DHTProtocol = ProtocolPrefix + "/kad/1.0.0"
+
 	// ProtocolRendezvous is the rendezvous string used for peer discovery.
 	ProtocolRendezvous = "dir/connect"
 )
@@ -39,17 +168,166 @@ const (
 	// MaxHops defines the maximum number of hops allowed in distributed queries.
 	MaxHops = 20
 
-	// NotificationChannelSize defines the buffer size for announcement notifications.
-	NotificationChannelSize = 1000
+	// MaxQueryGroupDepth defines how deeply boolean query groups can be nested.
+	MaxQueryGroupDepth = 8
+
+	// NotificationQueueSize defines the maximum number of DHT provider notifications waiting
+	// to be processed. Notifications of further records are dropped while the queue is full.
+	NotificationQueueSize = 10000
+
+	// NotifyMaxAttempts defines how often the pull of a notified record is attempted
+	// before the notification is moved to the dead-letter bucket.
+	NotifyMaxAttempts = 5
 
 	// MaxLabelAge defines when remote label announcements are considered stale.
 	// Labels older than this will be cleaned up during periodic cleanup cycles.
 	MaxLabelAge = 72 * time.Hour
 
+	// SupersededLabelRetention is how long remote labels of a record are kept after the
+	// peer announcing it announced a newer version, instead of MaxLabelAge.
+	SupersededLabelRetention = time.Hour
+
+	// PeerAddressTTL defines when peer addresses that have not been seen again are expired
+	// from the address book. It matches MaxLabelAge so that the addresses of announcing
+	// peers outlive their cached labels.
+	PeerAddressTTL = MaxLabelAge
+
+	// PeerAddressRefreshInterval defines how often the addresses of connected peers
+	// are refreshed from the peerstore and stale addresses are expired.
+	PeerAddressRefreshInterval = 10 * time.Minute
+
+	// PeerCapabilitiesTTL defines how long the capabilities advertised by a peer are used.
+	// Peers are handshaken again on reconnect, so that upgrades are noticed sooner.
+	PeerCapabilitiesTTL = 6 * time.Hour
+
+	// PeerCapabilitiesRefreshInterval defines how often connected peers without current
+	// capabilities are handshaken, and expired capabilities are removed.
+	PeerCapabilitiesRefreshInterval = 10 * time.Minute
+
+	// PeerCapabilitiesTimeout bounds the capability handshake with a single peer.
+	PeerCapabilitiesTimeout = 10 * time.Second
+
+	// LivenessProbeTimeout bounds the dial of a single peer probed for liveness.
+	LivenessProbeTimeout = 10 * time.Second
+
+	// LivenessProbeConcurrency defines how many peers are probed for liveness at once.
+	LivenessProbeConcurrency = 8
+
+	// FollowerFenceTimeout bounds the call checking that the primary no longer answers
+	// before its follower is promoted.
+	FollowerFenceTimeout = 5 * time.Second
+
 	// DefaultMinMatchScore defines the minimum allowed match score for production safety.
 	// Per proto specification: "If not set, it will return records that match at least one query".
 	// Any value below this threshold is automatically corrected to this value.
 	DefaultMinMatchScore = 1
+
+	// ExactMatchScore is the score of a hierarchical query matching the queried label.
+	ExactMatchScore = 2
+
+	// PartialMatchScore is the score of a hierarchical query matching a descendant or
+	// an ancestor of the queried label.
+	PartialMatchScore = 1
+
+	// PublishBatchConcurrency defines how many DHT provide operations
+	// PublishBatch runs in parallel.
+	PublishBatchConcurrency = 8
+
+	// LiveSearchConcurrency defines how many peers a live search queries in parallel.
+	LiveSearchConcurrency = 8
+
+	// MaxLiveSearchPeers bounds the number of connected peers a live search fans out to.
+	MaxLiveSearchPeers = 64
+
+	// MaxReplicationProviders bounds the minimum number of providers a replication policy may require.
+	MaxReplicationProviders = 20
+
+	// MaxPendingAnnouncements bounds the number of records queued for announcement
+	// while the routing table is empty. Further records are announced by the next republish.
+	MaxPendingAnnouncements = 10000
+
+	// MaxProviderLookups bounds the number of cached DHT provider lookups.
+	MaxProviderLookups = 10000
+
+	// MaxReplicationChecks bounds the number of records checked per replication run.
+	// The least recently checked records are checked first.
+	MaxReplicationChecks = 100
+
+	// ReplicationConcurrency defines how many records are checked and replicated in parallel.
+	ReplicationConcurrency = 4
+
+	// SubscriptionBufferSize bounds the number of matching records waiting to be sent to a subscriber.
+	// Subscribers falling further behind are ended with ResourceExhausted.
+	SubscriptionBufferSize = 256
+
+	// PrefetchQueueSize bounds the number of search results waiting to be prefetched.
+	// Further results are not prefetched until the queue drains.
+	PrefetchQueueSize = 100
+
+	// MaxVerifiedRecords bounds the number of local records verified per announcement
+	// verification run. The least recently verified records are verified first.
+	MaxVerifiedRecords = 100
+
+	// VerificationPeers defines how many peers per transport are asked to resolve a record
+	// during announcement verification.
+	VerificationPeers = 3
+
+	// VerificationConcurrency defines how many records are verified via the DHT in parallel.
+	VerificationConcurrency = 8
+
+	// CacheWarmPageSize defines how many label entries are fetched per snapshot request.
+	CacheWarmPageSize = 500
+
+	// MaxCacheWarmEntries bounds the number of label entries imported from the seed peer.
+	MaxCacheWarmEntries = 100000
+
+	// StateArchivePageSize defines how many label entries are written per page of exported state archives.
+	StateArchivePageSize = 1000
+
+	// DatastoreSnapshotPageSize defines how many datastore entries are written per page of datastore snapshots.
+	DatastoreSnapshotPageSize = 1000
+
+	// LabelSyncPageSize defines how many records are fetched per label sync request.
+	LabelSyncPageSize = 500
+
+	// MaxLabelSyncPages bounds the pages fetched per label sync of a single peer.
+	// It stays within the per-peer rate limit of the serving peer.
+	MaxLabelSyncPages = 40
+
+	// MaxLabelSyncPeers bounds the number of connected peers whose labels are synced per run.
+	MaxLabelSyncPeers = 16
+
+	// DefaultBackfillMaxPeers defines how many peers a backfill pulls records from
+	// if the request does not specify it.
+	DefaultBackfillMaxPeers = 100
+
+	// DefaultBackfillMaxRecords defines how many records a backfill pulls
+	// if the request does not specify it.
+	DefaultBackfillMaxRecords = 10000
+
+	// DefaultBackfillPullsPerSecond defines how many records a backfill pulls per second
+	// if the request does not specify it.
+	DefaultBackfillPullsPerSecond = 5
+
+	// EdgeProfileMaxCachedLabels bounds the label cache of nodes running the edge discovery profile.
+	EdgeProfileMaxCachedLabels = 100000
+	// ClientProfileMaxCachedLabels bounds the label cache of nodes running the client discovery profile.
+	ClientProfileMaxCachedLabels = 10000
+
+	// DefaultCacheVerificationSample defines how many cached remote records are verified
+	// against their providers if the request does not specify a sample size.
+	DefaultCacheVerificationSample = 100
+
+	// MaxCacheVerificationSample bounds the number of cached remote records verified per request.
+	MaxCacheVerificationSample = 1000
+
+	// CacheVerificationConcurrency defines how many providers are asked in parallel
+	// during cache verification.
+	CacheVerificationConcurrency = 8
+
+	// RecordGCBatchSize bounds the number of local records garbage collected per disk usage check,
+	// so that a filesystem filled by something else does not empty the local store at once.
+	RecordGCBatchSize = 100
 )
 
 const ResultChannelBufferSize = 100
@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// canonicalRecord is implemented by records that can serialize their canonical content.
+type canonicalRecord interface {
+	Marshal() ([]byte, error)
+}
+
+// recordContentSize returns the size of the canonical content of a record,
+// or zero if the record does not expose its content.
+func recordContentSize(record types.Record) uint64 {
+	canonical, ok := record.(canonicalRecord)
+	if !ok {
+		return 0
+	}
+
+	data, err := canonical.Marshal()
+	if err != nil {
+		localLogger.Warn("Failed to marshal record to get its size", "cid", record.GetCid(), "error", err)
+
+		return 0
+	}
+
+	return uint64(len(data))
+}
+
+// recordSize returns the content size of a local record, zero if unknown.
+// It is included in the record's GossipSub announcements.
+func (r *routeRemote) recordSize(cid string) uint64 {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return 0
+	}
+
+	return decodeLocalRecordMetadata(value).Size
+}
+
+// contentDigest returns the digest of the canonical content of a record, derived from its CID,
+// or an empty string for invalid CIDs.
+func contentDigest(cid string) string {
+	digest, err := corev1.ConvertCIDToDigest(cid)
+	if err != nil {
+		return ""
+	}
+
+	return digest.String()
+}
+
+// labelContentSize returns the content size of the record of a cached label entry, zero if unknown.
+func labelContentSize(value []byte) uint64 {
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return 0
+	}
+
+	return metadata.Size
+}
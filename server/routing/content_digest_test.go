@@ -0,0 +1,42 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentDigest(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{Name: "digest-agent", SchemaVersion: "v0.3.1"})
+
+	canonicalBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	digest, err := corev1.CalculateDigest(canonicalBytes)
+	require.NoError(t, err)
+
+	// The digest derived from the CID matches the digest of the canonical content
+	assert.Equal(t, digest.String(), contentDigest(record.GetCid()))
+	assert.Empty(t, contentDigest("not-a-cid"))
+
+	assert.Equal(t, uint64(len(canonicalBytes)), recordContentSize(adapters.NewRecordAdapter(record)))
+}
+
+func TestLabelContentSize(t *testing.T) {
+	value, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now(), Size: 512})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(512), labelContentSize(value))
+	assert.Zero(t, labelContentSize([]byte("{}")))
+	assert.Zero(t, labelContentSize([]byte("invalid")))
+}
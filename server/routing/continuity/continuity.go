@@ -0,0 +1,229 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package continuity implements signed continuity records of peer identity rotations.
+//
+// When a peer rotates its libp2p identity key, the labels it announced stay cached by
+// other peers under its old peer ID until they expire. A continuity record links the old
+// and the new peer ID and is signed with both identity keys: the old key proves that the
+// retiring identity hands over its announcements, the new key that the successor accepts
+// them. Receivers migrate the cached labels and addresses of the old peer ID to the new one.
+// Records are distributed via GossipSub and stored in the DHT under Key(oldPeerID).
+package continuity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// Namespace is the DHT namespace of continuity records.
+	Namespace = "continuity"
+
+	// SignatureDomain is prepended to the signed payload of continuity records.
+	// It prevents continuity signatures from being replayed as label announcements,
+	// revocations or in other libp2p protocols that use the same identity keys.
+	SignatureDomain = "dir/continuity/v1/signature"
+
+	// MaxSize is the maximum size of a marshalled continuity record.
+	MaxSize = 4 * 1024 // 4KB
+)
+
+// Record links a retired peer identity to its successor.
+//
+// Example wire format:
+//
+//	{
+//	  "old_peer_id": "12D3KooWD3bfmNbuuuVCYwkjnFt3ukm3qaB3hDED3peHHXawvRAi",
+//	  "new_peer_id": "12D3KooWRBy97UB99e3J6hiPesre1MZeuNQvfan4gBziswrRJsNK",
+//	  "timestamp": "2025-10-01T10:00:00Z",
+//	  "old_public_key": "CAESIB...",
+//	  "old_signature": "mE3s...",
+//	  "new_public_key": "CAESIC...",
+//	  "new_signature": "u9Qa..."
+//	}
+type Record struct {
+	// OldPeerID is the retired peer identity.
+	OldPeerID string `json:"old_peer_id"`
+
+	// NewPeerID is the peer identity succeeding it.
+	NewPeerID string `json:"new_peer_id"`
+
+	// Timestamp is when the rotation was signed. The most recent record of an old peer ID wins.
+	Timestamp time.Time `json:"timestamp"`
+
+	// OldPublicKey is the marshalled libp2p public key of the old peer ID.
+	OldPublicKey []byte `json:"old_public_key"`
+
+	// OldSignature is the signature of SigningPayload() made with the old identity key.
+	OldSignature []byte `json:"old_signature"`
+
+	// NewPublicKey is the marshalled libp2p public key of the new peer ID.
+	NewPublicKey []byte `json:"new_public_key"`
+
+	// NewSignature is the signature of SigningPayload() made with the new identity key.
+	NewSignature []byte `json:"new_signature"`
+}
+
+// New creates a continuity record of the rotation from the old to the new identity key
+// issued now, signed with both keys.
+func New(oldKey, newKey crypto.PrivKey) (*Record, error) {
+	if oldKey == nil || newKey == nil {
+		return nil, errors.New("identity key is nil")
+	}
+
+	oldPeerID, err := peer.IDFromPrivateKey(oldKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive old peer ID: %w", err)
+	}
+
+	newPeerID, err := peer.IDFromPrivateKey(newKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive new peer ID: %w", err)
+	}
+
+	record := &Record{
+		OldPeerID: oldPeerID.String(),
+		NewPeerID: newPeerID.String(),
+		Timestamp: time.Now(),
+	}
+
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := record.Sign(oldKey, newKey); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// Key returns the DHT key of the continuity record of a retired peer.
+// Format: /continuity/<old_peer_id>.
+func Key(oldPeerID string) string {
+	return "/" + Namespace + "/" + oldPeerID
+}
+
+// Validate checks that the record is well-formed.
+// The signatures are checked separately by Verify.
+func (r *Record) Validate() error {
+	if r.OldPeerID == "" || r.NewPeerID == "" {
+		return errors.New("missing peer ID")
+	}
+
+	if r.OldPeerID == r.NewPeerID {
+		return errors.New("old and new peer ID are the same")
+	}
+
+	if r.Timestamp.IsZero() {
+		return errors.New("missing timestamp")
+	}
+
+	return nil
+}
+
+// SigningPayload returns the canonical bytes covered by both signatures.
+//
+// Format: SignatureDomain \0 old peer ID \0 new peer ID \0 timestamp(RFC3339Nano, UTC).
+func (r *Record) SigningPayload() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(SignatureDomain)
+	buf.WriteByte(0)
+	buf.WriteString(r.OldPeerID)
+	buf.WriteByte(0)
+	buf.WriteString(r.NewPeerID)
+	buf.WriteByte(0)
+	buf.WriteString(r.Timestamp.UTC().Format(time.RFC3339Nano))
+
+	return buf.Bytes()
+}
+
+// Sign signs the record with the Ed25519 identity keys of the old and the new peer ID.
+func (r *Record) Sign(oldKey, newKey crypto.PrivKey) error {
+	var err error
+
+	r.OldPublicKey, r.OldSignature, err = signing.Sign(oldKey, r.SigningPayload())
+	if err != nil {
+		return fmt.Errorf("failed to sign continuity record with old key: %w", err)
+	}
+
+	r.NewPublicKey, r.NewSignature, err = signing.Sign(newKey, r.SigningPayload())
+	if err != nil {
+		return fmt.Errorf("failed to sign continuity record with new key: %w", err)
+	}
+
+	return nil
+}
+
+// Verify checks both signatures and that they were made with the keys of the linked peer IDs.
+func (r *Record) Verify() error {
+	for _, identity := range []struct {
+		name      string
+		peerID    string
+		publicKey []byte
+		signature []byte
+	}{
+		{"old", r.OldPeerID, r.OldPublicKey, r.OldSignature},
+		{"new", r.NewPeerID, r.NewPublicKey, r.NewSignature},
+	} {
+		if err := signing.Verify(identity.publicKey, identity.signature, r.SigningPayload()); err != nil {
+			return fmt.Errorf("invalid %s signature: %w", identity.name, err)
+		}
+
+		signerID, err := signing.SignerID(identity.publicKey)
+		if err != nil {
+			return fmt.Errorf("invalid %s public key: %w", identity.name, err)
+		}
+
+		if signerID.String() != identity.peerID {
+			return fmt.Errorf("%s signature made by %s instead of %s", identity.name, signerID, identity.peerID)
+		}
+	}
+
+	return nil
+}
+
+// Marshal serializes the record to JSON.
+func (r *Record) Marshal() ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal continuity record: %w", err)
+	}
+
+	if len(data) > MaxSize {
+		return nil, errors.New("continuity record exceeds maximum size")
+	}
+
+	return data, nil
+}
+
+// Unmarshal deserializes a record and checks that it is well-formed and validly signed.
+func Unmarshal(data []byte) (*Record, error) {
+	if len(data) > MaxSize {
+		return nil, errors.New("continuity record exceeds maximum size")
+	}
+
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal continuity record: %w", err)
+	}
+
+	if err := record.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid continuity record: %w", err)
+	}
+
+	if err := record.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid continuity record signature: %w", err)
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,90 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package continuity
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKey(t *testing.T) (crypto.PrivKey, string) {
+	t.Helper()
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	return key, id.String()
+}
+
+func TestRecord_NewAndUnmarshal(t *testing.T) {
+	oldKey, oldPeerID := newTestKey(t)
+	newKey, newPeerID := newTestKey(t)
+
+	record, err := New(oldKey, newKey)
+	require.NoError(t, err)
+
+	data, err := record.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, oldPeerID, decoded.OldPeerID)
+	assert.Equal(t, newPeerID, decoded.NewPeerID)
+	assert.True(t, record.Timestamp.Equal(decoded.Timestamp))
+}
+
+func TestRecord_New_RejectsSameKey(t *testing.T) {
+	key, _ := newTestKey(t)
+
+	_, err := New(key, key)
+	assert.Error(t, err)
+}
+
+func TestRecord_UnmarshalRejectsInvalid(t *testing.T) {
+	oldKey, _ := newTestKey(t)
+	newKey, _ := newTestKey(t)
+	otherKey, otherPeerID := newTestKey(t)
+
+	for name, tamper := range map[string]func(r *Record){
+		// A third peer claims to succeed the old identity
+		"new peer replaced": func(r *Record) { r.NewPeerID = otherPeerID },
+		// The successor signed without the consent of the old identity
+		"old signature missing": func(r *Record) { r.OldSignature = nil },
+		"timestamp changed":     func(r *Record) { r.Timestamp = r.Timestamp.Add(time.Hour) },
+		// Signed with a key that does not match the old peer ID
+		"old key replaced": func(r *Record) {
+			var err error
+
+			r.OldPublicKey, r.OldSignature, err = signing.Sign(otherKey, r.SigningPayload())
+			require.NoError(t, err)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			record, err := New(oldKey, newKey)
+			require.NoError(t, err)
+
+			tamper(record)
+
+			data, err := record.Marshal()
+			require.NoError(t, err)
+
+			_, err = Unmarshal(data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "/continuity/12D3KooWD3bfmNbuuuVCYwkjnFt3ukm3qaB3hDED3peHHXawvRAi", Key("12D3KooWD3bfmNbuuuVCYwkjnFt3ukm3qaB3hDED3peHHXawvRAi"))
+}
@@ -0,0 +1,85 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"time"
+
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/types"
+)
+
+// datastoreMetricsPrefixes returns the key prefixes reported by the datastore key-count gauges.
+// This covers all label namespaces and tenant labels plus the routing bookkeeping keys.
+func datastoreMetricsPrefixes() []string {
+	return append([]string{"/records/", "/" + addressbook.Namespace + "/", "/providers/"}, labelKeyPrefixes()...)
+}
+
+// unwrapDatastore returns the first datastore of type T in a chain of datastore middlewares.
+func unwrapDatastore[T types.Datastore](dstore types.Datastore) (T, bool) {
+	for {
+		if target, ok := dstore.(T); ok {
+			return target, true
+		}
+
+		wrapper, ok := dstore.(interface{ Unwrap() types.Datastore })
+		if !ok {
+			var zero T
+
+			return zero, false
+		}
+
+		dstore = wrapper.Unwrap()
+	}
+}
+
+// startDatastoreMetricsReporting starts a background goroutine that periodically
+// logs the routing datastore metrics. This makes slow storage (e.g. network volumes)
+// diagnosable as the cause of slow routing operations.
+func (r *routeRemote) startDatastoreMetricsReporting(metrics *datastore.MetricsDatastore) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(DatastoreMetricsReportInterval)
+		defer ticker.Stop()
+
+		remoteLogger.Info("Started datastore metrics reporting", "interval", DatastoreMetricsReportInterval)
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping datastore metrics reporting")
+
+				return
+			case <-ticker.C:
+				r.reportDatastoreMetrics(metrics)
+			}
+		}
+	}()
+}
+
+// reportDatastoreMetrics logs a single snapshot of the datastore metrics.
+func (r *routeRemote) reportDatastoreMetrics(metrics *datastore.MetricsDatastore) {
+	stats, err := metrics.Stats(r.ctx)
+	if err != nil {
+		remoteLogger.Warn("Failed to collect datastore metrics", "error", err)
+
+		return
+	}
+
+	for _, op := range stats.Operations {
+		remoteLogger.Info("Datastore operation metrics",
+			"operation", op.Operation,
+			"prefix", op.Prefix,
+			"count", op.Count,
+			"errorRate", op.ErrorRate(),
+			"avgLatency", op.AvgLatency(),
+			"maxLatency", op.MaxLatency)
+	}
+
+	remoteLogger.Info("Datastore key counts", "keyCounts", stats.KeyCounts)
+}
@@ -0,0 +1,147 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/dsarchive"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SnapshotDatastore writes all entries of the routing datastore as a datastore archive.
+// Entries are read by a single query, which Badger serves from a read transaction, so
+// that the archive reflects the datastore at a single point in time.
+func (r *routeRemote) SnapshotDatastore(ctx context.Context, w io.Writer) error {
+	results, err := r.dstore.Query(ctx, query.Query{})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to query datastore: %v", err)
+	}
+	defer results.Close()
+
+	archive, err := dsarchive.NewWriter(w, dsarchive.Manifest{
+		PeerID:    r.server.Host().ID().String(),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to start datastore archive: %v", err)
+	}
+
+	page := make([]dsarchive.Entry, 0, DatastoreSnapshotPageSize)
+	total := 0
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return status.Errorf(codes.Internal, "failed to read datastore: %v", result.Error)
+		}
+
+		page = append(page, dsarchive.Entry{Key: result.Key, Value: result.Value})
+		if len(page) < DatastoreSnapshotPageSize {
+			continue
+		}
+
+		if err := archive.WritePage(page); err != nil {
+			return status.Errorf(codes.Internal, "failed to write datastore archive: %v", err)
+		}
+
+		total += len(page)
+		page = page[:0]
+	}
+
+	if len(page) > 0 {
+		if err := archive.WritePage(page); err != nil {
+			return status.Errorf(codes.Internal, "failed to write datastore archive: %v", err)
+		}
+
+		total += len(page)
+	}
+
+	if err := archive.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to write datastore archive: %v", err)
+	}
+
+	remoteLogger.Info("Snapshotted routing datastore", "entries", total)
+
+	return nil
+}
+
+// RestoreDatastore writes the entries of a datastore archive into the routing datastore,
+// one batch per page, and reloads the in-memory state derived from it. Entries overwrite
+// the existing values of their keys; keys missing from the archive are kept.
+func (r *routeRemote) RestoreDatastore(ctx context.Context, rd io.Reader) (*routingv1.RestoreDatastoreResponse, error) {
+	archive, err := dsarchive.NewReader(rd)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	manifest := archive.Manifest()
+	resp := &routingv1.RestoreDatastoreResponse{
+		SnapshotPeerId: manifest.PeerID,
+		CreatedAt:      timestamppb.New(manifest.CreatedAt),
+	}
+
+	// Local records of the snapshotted peer are cached remote records of other peers
+	if localPeerID := r.server.Host().ID().String(); manifest.PeerID != localPeerID {
+		remoteLogger.Warn("Restoring the datastore of another peer", "snapshotPeer", manifest.PeerID, "localPeer", localPeerID)
+	}
+
+	for {
+		entries, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return resp, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+
+		if err := r.restoreEntries(ctx, entries); err != nil {
+			return resp, err
+		}
+
+		resp.EntriesRestored += uint64(len(entries))
+	}
+
+	// Pins, label indexes and provider sets are derived from the datastore
+	if err := r.loadPins(ctx); err != nil {
+		remoteLogger.Warn("Failed to reload pins after restoring the datastore", "error", err)
+	}
+
+	r.rebuildLabelIndexes(ctx)
+
+	remoteLogger.Info("Restored routing datastore",
+		"snapshotPeer", resp.GetSnapshotPeerId(),
+		"createdAt", manifest.CreatedAt,
+		"entries", resp.GetEntriesRestored())
+
+	return resp, nil
+}
+
+// restoreEntries writes a page of archived entries in a single batch.
+func (r *routeRemote) restoreEntries(ctx context.Context, entries []dsarchive.Entry) error {
+	batch, err := r.dstore.Batch(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to create batch: %v", err)
+	}
+
+	for _, entry := range entries {
+		if err := batch.Put(ctx, datastore.NewKey(entry.Key), entry.Value); err != nil {
+			return status.Errorf(codes.Internal, "failed to restore %s: %v", entry.Key, err)
+		}
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return status.Errorf(codes.Internal, "failed to restore datastore entries: %v", err)
+	}
+
+	return nil
+}
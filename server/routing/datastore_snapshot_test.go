@@ -0,0 +1,62 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDatastoreSnapshotRestore(t *testing.T) {
+	blue := newTestServer(t, t.Context(), nil)
+	green := newTestServer(t, t.Context(), nil)
+
+	labelMetadata, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+	require.NoError(t, err)
+
+	pinMetadata, err := json.Marshal(&pin{PinnedAt: time.Now()})
+	require.NoError(t, err)
+
+	entries := map[string][]byte{
+		"/skills/AI/cid1/peer1": labelMetadata,
+		"/pins/cid1":            pinMetadata,
+		"/providers/binary":     {0x00, 0xff},
+	}
+
+	for key, value := range entries {
+		require.NoError(t, blue.remote.dstore.Put(t.Context(), ipfsdatastore.NewKey(key), value))
+	}
+
+	var archive bytes.Buffer
+
+	require.NoError(t, blue.SnapshotDatastore(t.Context(), &archive))
+
+	resp, err := green.RestoreDatastore(t.Context(), bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, blue.remote.server.Host().ID().String(), resp.GetSnapshotPeerId())
+	assert.GreaterOrEqual(t, resp.GetEntriesRestored(), uint64(len(entries)))
+
+	for key, value := range entries {
+		restored, err := green.remote.dstore.Get(t.Context(), ipfsdatastore.NewKey(key))
+		require.NoError(t, err, key)
+		assert.Equal(t, value, restored, key)
+	}
+
+	// State derived from the datastore is reloaded
+	assert.True(t, green.remote.pins.has("cid1"))
+	assert.Equal(t, 1, green.remote.providerSets.Records())
+
+	// Archives that are not datastore archives are rejected
+	_, err = green.RestoreDatastore(t.Context(), bytes.NewReader([]byte("not an archive")))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,363 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dirql parses DIRQL, the directory query language, into record queries.
+//
+// A DIRQL expression combines field:value terms with AND, OR, NOT and parentheses:
+//
+//	skill:"AI/ML" AND (domain:research OR module:tensorflow) AND NOT locator:helm-chart
+//
+// Fields are skill, locator, domain, module and label, the latter matching labels of any
+// namespace, e.g. label:teams/platform. Values containing whitespace, parentheses or quotes
+// must be double-quoted; backslashes escape quotes and backslashes in quoted values.
+// Keywords and fields are case-insensitive. NOT binds tighter than AND, which binds
+// tighter than OR.
+package dirql
+
+import (
+	"fmt"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// MaxLength bounds the length of an expression in bytes.
+const MaxLength = 4096
+
+// fieldTypes maps the fields of terms to their query types.
+var fieldTypes = map[string]routingv1.RecordQueryType{
+	"skill":   routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+	"locator": routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR,
+	"domain":  routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
+	"module":  routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE,
+	"label":   routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL,
+}
+
+// SyntaxError reports an invalid expression.
+type SyntaxError struct {
+	Pos int // Byte offset of the error in the expression
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid query at position %d: %s", e.Pos, e.Msg)
+}
+
+// Parse compiles an expression into a single record query.
+// A single term compiles into a plain query, anything else into a query group.
+// Nested groups with the same operator are flattened.
+func Parse(expr string) (*routingv1.RecordQuery, error) {
+	if len(expr) > MaxLength {
+		return nil, &SyntaxError{Pos: MaxLength, Msg: fmt.Sprintf("query is longer than %d bytes", MaxLength)}
+	}
+
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	query, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %s", tok)}
+	}
+
+	return query, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+	tokenTerm
+)
+
+type token struct {
+	kind  tokenKind
+	pos   int
+	field string // Lowercased field of terms
+	value string // Unquoted value of terms
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	case tokenLParen:
+		return `"("`
+	case tokenRParen:
+		return `")"`
+	case tokenTerm:
+		return fmt.Sprintf("term %s:%q", t.field, t.value)
+	default:
+		return "unknown token"
+	}
+}
+
+// tokenize splits an expression into keywords, parentheses and field:value terms.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(expr); {
+		switch c := expr[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, pos: i})
+			i++
+		default:
+			tok, next, err := scanWord(expr, i)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, tok)
+			i = next
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+// scanWord scans a keyword or a field:value term starting at start.
+// Returns the token and the offset right after it.
+func scanWord(expr string, start int) (token, int, error) {
+	end := start
+	for end < len(expr) && !isDelimiter(expr[end]) && expr[end] != ':' {
+		end++
+	}
+
+	word := expr[start:end]
+
+	if end == len(expr) || expr[end] != ':' {
+		switch strings.ToUpper(word) {
+		case "AND":
+			return token{kind: tokenAnd, pos: start}, end, nil
+		case "OR":
+			return token{kind: tokenOr, pos: start}, end, nil
+		case "NOT":
+			return token{kind: tokenNot, pos: start}, end, nil
+		}
+
+		if word == "" {
+			return token{}, 0, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", expr[start])}
+		}
+
+		return token{}, 0, &SyntaxError{Pos: start, Msg: fmt.Sprintf("expected field:value, got %q", word)}
+	}
+
+	field := strings.ToLower(word)
+	if _, ok := fieldTypes[field]; !ok {
+		return token{}, 0, &SyntaxError{
+			Pos: start,
+			Msg: fmt.Sprintf("unknown field %q, must be one of: skill, locator, domain, module, label", word),
+		}
+	}
+
+	value, next, err := scanValue(expr, end+1)
+	if err != nil {
+		return token{}, 0, err
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return token{}, 0, &SyntaxError{Pos: end + 1, Msg: fmt.Sprintf("empty value for field %q", field)}
+	}
+
+	return token{kind: tokenTerm, pos: start, field: field, value: value}, next, nil
+}
+
+// scanValue scans a bare or double-quoted value starting at start.
+// Returns the unquoted value and the offset right after it.
+func scanValue(expr string, start int) (string, int, error) {
+	if start == len(expr) || expr[start] != '"' {
+		end := start
+		for end < len(expr) && !isDelimiter(expr[end]) {
+			end++
+		}
+
+		return expr[start:end], end, nil
+	}
+
+	var value strings.Builder
+
+	for i := start + 1; i < len(expr); i++ {
+		switch expr[i] {
+		case '"':
+			return value.String(), i + 1, nil
+		case '\\':
+			if i+1 == len(expr) || (expr[i+1] != '"' && expr[i+1] != '\\') {
+				return "", 0, &SyntaxError{Pos: i, Msg: `only \" and \\ can be escaped`}
+			}
+
+			i++
+			value.WriteByte(expr[i])
+		default:
+			value.WriteByte(expr[i])
+		}
+	}
+
+	return "", 0, &SyntaxError{Pos: start, Msg: "unterminated quoted value"}
+}
+
+func isDelimiter(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '(' || c == ')' || c == '"'
+}
+
+// parser is a recursive descent parser over the tokens of an expression.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+// parseOr parses: and ("OR" and)*.
+func (p *parser) parseOr() (*routingv1.RecordQuery, error) {
+	return p.parseBinary(tokenOr, routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, p.parseAnd)
+}
+
+// parseAnd parses: unary ("AND" unary)*.
+func (p *parser) parseAnd() (*routingv1.RecordQuery, error) {
+	return p.parseBinary(tokenAnd, routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, p.parseUnary)
+}
+
+// parseBinary parses operands separated by the keyword into a group of the operator.
+// Operands that are groups of the same operator are flattened into the group.
+func (p *parser) parseBinary(
+	keyword tokenKind,
+	operator routingv1.RecordQueryOperator,
+	operand func() (*routingv1.RecordQuery, error),
+) (*routingv1.RecordQuery, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != keyword {
+		return first, nil
+	}
+
+	group := &routingv1.RecordQueryGroup{Operator: operator}
+	appendOperand(group, first)
+
+	for p.peek().kind == keyword {
+		p.next()
+
+		query, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		appendOperand(group, query)
+	}
+
+	return &routingv1.RecordQuery{Group: group}, nil
+}
+
+func appendOperand(group *routingv1.RecordQueryGroup, query *routingv1.RecordQuery) {
+	if query.GetGroup().GetOperator() == group.GetOperator() {
+		group.Queries = append(group.Queries, query.GetGroup().GetQueries()...)
+
+		return
+	}
+
+	group.Queries = append(group.Queries, query)
+}
+
+// parseUnary parses: "NOT" unary | primary.
+// NOT of an OR group negates its sub-queries directly, as a NOT group
+// matches if none of its sub-queries match.
+func (p *parser) parseUnary() (*routingv1.RecordQuery, error) {
+	if p.peek().kind != tokenNot {
+		return p.parsePrimary()
+	}
+
+	p.next()
+
+	query, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	group := query.GetGroup()
+
+	switch group.GetOperator() {
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR:
+		return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{
+			Operator: routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT,
+			Queries:  group.GetQueries(),
+		}}, nil
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT:
+		// NOT NOT x is x, and NOT of a NOT group matches if any of its sub-queries match
+		if len(group.GetQueries()) == 1 {
+			return group.GetQueries()[0], nil
+		}
+
+		return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{
+			Operator: routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR,
+			Queries:  group.GetQueries(),
+		}}, nil
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED,
+		routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND:
+	}
+
+	return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{
+		Operator: routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT,
+		Queries:  []*routingv1.RecordQuery{query},
+	}}, nil
+}
+
+// parsePrimary parses: "(" expression ")" | term.
+func (p *parser) parsePrimary() (*routingv1.RecordQuery, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokenTerm:
+		return &routingv1.RecordQuery{Type: fieldTypes[tok.field], Value: tok.value}, nil
+	case tokenLParen:
+		query, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &SyntaxError{Pos: closing.pos, Msg: fmt.Sprintf(`expected ")", got %s`, closing)}
+		}
+
+		return query, nil
+	case tokenEOF, tokenAnd, tokenOr, tokenNot, tokenRParen:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected field:value or \"(\", got %s", tok)}
+	default:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unexpected %s", tok)}
+	}
+}
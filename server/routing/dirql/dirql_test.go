@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package dirql
+
+import (
+	"strings"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func term(queryType routingv1.RecordQueryType, value string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: queryType, Value: value}
+}
+
+func group(operator routingv1.RecordQueryOperator, queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{Operator: operator, Queries: queries}}
+}
+
+func TestParse(t *testing.T) {
+	var (
+		and = routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND
+		or  = routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR
+		not = routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT
+
+		skillAIML   = term(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI/ML")
+		research    = term(routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, "research")
+		tensorflow  = term(routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, "tensorflow")
+		helm        = term(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, "helm-chart")
+		teamsPlatfm = term(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "teams/platform")
+	)
+
+	tests := []struct {
+		expr     string
+		expected *routingv1.RecordQuery
+	}{
+		{`skill:"AI/ML"`, skillAIML},
+		{`skill:AI/ML`, skillAIML},
+		{`label:teams/platform`, teamsPlatfm},
+		{
+			`skill:"AI/ML" AND (domain:research OR module:tensorflow)`,
+			group(and, skillAIML, group(or, research, tensorflow)),
+		},
+		// AND binds tighter than OR, NOT tighter than AND
+		{
+			`skill:AI/ML or domain:research and not module:tensorflow`,
+			group(or, skillAIML, group(and, research, group(not, tensorflow))),
+		},
+		// Groups of the same operator are flattened
+		{
+			`skill:AI/ML AND (domain:research AND module:tensorflow) AND locator:helm-chart`,
+			group(and, skillAIML, research, tensorflow, helm),
+		},
+		// NOT of an OR group negates its sub-queries, NOT NOT cancels out
+		{`NOT (domain:research OR module:tensorflow)`, group(not, research, tensorflow)},
+		{`NOT NOT domain:research`, research},
+		{`NOT NOT (domain:research OR module:tensorflow)`, group(or, research, tensorflow)},
+		// Quoted values may contain delimiters and escapes
+		{
+			`Skill:"Natural Language (NLP) \"v2\" \\"`,
+			term(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, `Natural Language (NLP) "v2" \`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			query, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tt.expected, query), "got %v", query)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{``, 0},
+		{`AI`, 0},
+		{`skil:AI`, 0},
+		{`skill:`, 6},
+		{`skill:""`, 6},
+		{`skill:"AI`, 6},
+		{`skill:"A\I"`, 8},
+		{`skill:AI AND`, 12},
+		{`skill:AI domain:research`, 9},
+		{`(skill:AI`, 9},
+		{`skill:AI)`, 8},
+		{`NOT`, 3},
+		{strings.Repeat("x", MaxLength+1), MaxLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.pos, syntaxErr.Pos, syntaxErr.Error())
+		})
+	}
+}
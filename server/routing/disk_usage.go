@@ -0,0 +1,27 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux || darwin
+
+package routing
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// diskUsage returns the fraction of the filesystem holding path that is in use,
+// counting space reserved for the superuser as free like df does.
+func diskUsage(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("failed to stat filesystem of %s: %w", path, err)
+	}
+
+	used := stat.Blocks - stat.Bfree
+	if used+stat.Bavail == 0 {
+		return 0, nil
+	}
+
+	return float64(used) / float64(used+stat.Bavail), nil
+}
@@ -0,0 +1,13 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux && !darwin
+
+package routing
+
+import "errors"
+
+// diskUsage is not supported on this platform, so local records are never garbage collected.
+func diskUsage(string) (float64, error) {
+	return 0, errors.New("disk usage is not supported on this platform")
+}
@@ -0,0 +1,234 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dsarchive implements point-in-time archives of the routing datastore, used to
+// move the discovery state of a peer to another server, e.g. during blue/green upgrades.
+//
+// Unlike state archives, which carry the label cache in a portable form between
+// unrelated peers, datastore archives hold the raw keys and values of the datastore,
+// including the label cache, the peer address book and the DHT provider records.
+//
+// An archive is a tar file holding, in order:
+//
+//	manifest.json         format version, snapshotted peer and snapshot time
+//	entries/000001.json   pages of datastore keys and their values
+//
+// Unknown files are skipped, so that later versions can add content.
+package dsarchive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// Version is the format version of archives written by this package.
+	Version = 1
+
+	// ManifestFile is the name of the manifest, the first file of an archive.
+	ManifestFile = "manifest.json"
+
+	// EntriesDir holds the entry pages of an archive.
+	EntriesDir = "entries/"
+
+	// MaxManifestSize is the maximum size of a manifest.
+	MaxManifestSize = 64 * 1024 // 64KB
+
+	// MaxPageSize is the maximum size of an entry page.
+	MaxPageSize = 64 * 1024 * 1024 // 64MB
+)
+
+// ErrInvalidArchive is returned when reading a malformed archive.
+var ErrInvalidArchive = errors.New("invalid datastore archive")
+
+// Manifest describes an archive.
+//
+// Example:
+//
+//	{
+//	  "version": 1,
+//	  "peer_id": "12D3KooW...",
+//	  "created_at": "2025-10-01T10:00:00Z"
+//	}
+type Manifest struct {
+	// Version is the format version of the archive.
+	Version int `json:"version"`
+
+	// PeerID is the ID of the peer whose datastore was snapshotted.
+	PeerID string `json:"peer_id"`
+
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Entry is a datastore key and its value.
+type Entry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// Writer writes an archive.
+type Writer struct {
+	tw    *tar.Writer
+	pages int
+	now   time.Time
+}
+
+// NewWriter starts an archive with its manifest, stamping its files with the snapshot time.
+func NewWriter(w io.Writer, manifest Manifest) (*Writer, error) {
+	manifest.Version = Version
+
+	writer := &Writer{tw: tar.NewWriter(w), now: manifest.CreatedAt}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := writer.writeFile(ManifestFile, data); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// WritePage adds a page of entries.
+func (w *Writer) WritePage(entries []Entry) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal entry page: %w", err)
+	}
+
+	if len(data) > MaxPageSize {
+		return fmt.Errorf("entry page exceeds %d bytes", MaxPageSize)
+	}
+
+	w.pages++
+
+	return w.writeFile(fmt.Sprintf("%s%06d.json", EntriesDir, w.pages), data)
+}
+
+// Close completes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return fmt.Errorf("failed to complete archive: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Writer) writeFile(name string, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644, //nolint:mnd
+		Size:     int64(len(data)),
+		ModTime:  w.now,
+	}
+
+	if err := w.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	if _, err := w.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Reader reads an archive.
+type Reader struct {
+	tr       *tar.Reader
+	manifest Manifest
+}
+
+// NewReader starts reading an archive, reading and checking its manifest.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{tr: tar.NewReader(r)}
+
+	header, err := reader.tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read manifest: %w", ErrInvalidArchive, err)
+	}
+
+	if header.Name != ManifestFile {
+		return nil, fmt.Errorf("%w: first file is %q, not the manifest", ErrInvalidArchive, header.Name)
+	}
+
+	data, err := reader.readFile(header, MaxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &reader.manifest); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal manifest: %w", ErrInvalidArchive, err)
+	}
+
+	if reader.manifest.Version < 1 || reader.manifest.Version > Version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, reader.manifest.Version)
+	}
+
+	return reader, nil
+}
+
+// Manifest returns the manifest of the archive.
+func (r *Reader) Manifest() Manifest {
+	return r.manifest
+}
+
+// Next returns the next page of entries of the archive, or io.EOF at its end.
+// Entries without a key are rejected.
+func (r *Reader) Next() ([]Entry, error) {
+	for {
+		header, err := r.tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+
+		if header.Typeflag != tar.TypeReg || !strings.HasPrefix(header.Name, EntriesDir) {
+			continue
+		}
+
+		data, err := r.readFile(header, MaxPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		var entries []Entry
+		if err := json.Unmarshal(data, &entries); err != nil {
+			return nil, fmt.Errorf("%w: failed to unmarshal %s: %w", ErrInvalidArchive, header.Name, err)
+		}
+
+		for _, entry := range entries {
+			if entry.Key == "" {
+				return nil, fmt.Errorf("%w: %s has an entry without key", ErrInvalidArchive, header.Name)
+			}
+		}
+
+		return entries, nil
+	}
+}
+
+// readFile reads the content of the current file, which must not exceed maxSize.
+func (r *Reader) readFile(header *tar.Header, maxSize int64) ([]byte, error) {
+	if header.Size > maxSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidArchive, header.Name, maxSize)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.tr, maxSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read %s: %w", ErrInvalidArchive, header.Name, err)
+	}
+
+	return data, nil
+}
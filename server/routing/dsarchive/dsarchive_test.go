@@ -0,0 +1,111 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package dsarchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+	first := []Entry{
+		{Key: "/skills/AI/cid1/peer1", Value: []byte(`{"timestamp":"2025-10-01T09:00:00Z"}`)},
+		{Key: "/providers/abc", Value: []byte{0x00, 0xff, 0x10}},
+	}
+	second := []Entry{{Key: "/peer-addrs/peer1", Value: []byte(`["/ip4/10.0.0.1/tcp/4001"]`)}}
+
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, Manifest{PeerID: "peer1", CreatedAt: createdAt})
+	require.NoError(t, err)
+	require.NoError(t, writer.WritePage(first))
+	require.NoError(t, writer.WritePage(second))
+	require.NoError(t, writer.Close())
+
+	reader, err := NewReader(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, Manifest{Version: Version, PeerID: "peer1", CreatedAt: createdAt}, reader.Manifest())
+
+	entries, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, first, entries)
+
+	entries, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, second, entries)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReader_RejectsInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []file
+	}{
+		{name: "missing manifest", files: []file{{EntriesDir + "000001.json", `[]`}}},
+		{name: "invalid manifest", files: []file{{ManifestFile, `{`}}},
+		{name: "unsupported version", files: []file{{ManifestFile, `{"version":2}`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(tarFiles(t, tt.files...))
+			assert.ErrorIs(t, err, ErrInvalidArchive)
+		})
+	}
+}
+
+func TestReader_SkipsUnknownFilesAndRejectsInvalidPages(t *testing.T) {
+	reader, err := NewReader(tarFiles(t,
+		file{ManifestFile, `{"version":1}`},
+		file{"extensions/unknown", "ignored"},
+		file{EntriesDir + "000001.json", `[{"key":"/a","value":"AQ=="}]`},
+		file{EntriesDir + "000002.json", `[{"value":"AQ=="}]`},
+	))
+	require.NoError(t, err)
+
+	entries, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{{Key: "/a", Value: []byte{0x01}}}, entries)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, ErrInvalidArchive)
+	assert.ErrorContains(t, err, "without key")
+}
+
+type file struct {
+	name    string
+	content string
+}
+
+func tarFiles(t *testing.T, files ...file) io.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     f.name,
+			Mode:     0o644,
+			Size:     int64(len(f.content)),
+		}))
+
+		_, err := tw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return &buf
+}
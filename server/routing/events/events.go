@@ -0,0 +1,309 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events publishes normalized routing events to external message queues,
+// so that data platforms can build derived catalogs without polling the Directory.
+//
+// Events are emitted when the routing subsystem discovers a record, when a record
+// is retracted, when a local record is found to be unresolvable by other peers,
+// and when the addresses of a peer change. Every event is delivered
+// to all configured publishers (Kafka, NATS). Events sharing a key (the CID for
+// record events, the peer ID for peer events) are published in the order they were
+// emitted; events with different keys may be published concurrently.
+//
+// Publishing never blocks routing: when a queue is full, events are dropped and
+// counted by the dir_routing_events_published_total metric.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/google/uuid"
+)
+
+var logger = logging.Logger("routing/events")
+
+// SchemaVersion is the version of the event schema.
+// It is incremented on incompatible changes to Event.
+const SchemaVersion = "v1"
+
+const (
+	// Shards is the number of queues events are distributed to by key.
+	// Each queue is drained by one goroutine, preserving the order per key.
+	Shards = 8
+
+	// QueueSize is the buffer size of each queue.
+	QueueSize = 1000
+
+	// PublishTimeout bounds the delivery of a single event to a publisher.
+	PublishTimeout = 10 * time.Second
+)
+
+// Type is the type of a routing event.
+type Type string
+
+const (
+	// TypeRecordDiscovered is emitted when labels of a record announced by a peer are cached
+	// for the first time. A record announced on several label namespace topics may be
+	// reported by one event per namespace; consumers merge the labels.
+	TypeRecordDiscovered Type = "record.discovered"
+
+	// TypeRecordRetracted is emitted when a record is no longer announced by a peer,
+	// because it was unpublished locally or revoked by its remote publisher.
+	TypeRecordRetracted Type = "record.retracted"
+
+	// TypeRecordUnresolvable is emitted when the announcement verification finds that a
+	// record published by this peer became unresolvable by other peers. The reason lists
+	// the failing transports ("dht", "gossipsub").
+	TypeRecordUnresolvable Type = "record.unresolvable"
+
+	// TypePeerChanged is emitted when the Directory API addresses of a peer change.
+	// An event without addresses reports that the peer's addresses expired.
+	TypePeerChanged Type = "peer.changed"
+)
+
+// Event is a normalized routing event.
+//
+// Example wire format:
+//
+//	{
+//	  "schema_version": "v1",
+//	  "id": "0e3c2b4e-8f4b-4a4e-9d43-3f0c1b7c9a2e",
+//	  "type": "record.discovered",
+//	  "time": "2025-10-01T10:00:00Z",
+//	  "cid": "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+//	  "peer_id": "12D3KooWD3bfmNbuuuVCYwkjnFt3ukm3qaB3hDED3peHHXawvRAi",
+//	  "labels": ["/skills/AI/ML", "/domains/research"]
+//	}
+type Event struct {
+	// SchemaVersion is the version of the event schema (SchemaVersion).
+	SchemaVersion string `json:"schema_version"`
+
+	// ID uniquely identifies the event, for deduplication by consumers.
+	ID string `json:"id"`
+
+	// Type is the type of the event.
+	Type Type `json:"type"`
+
+	// Time is when the event occurred.
+	Time time.Time `json:"time"`
+
+	// CID is the record the event is about. Empty for peer events.
+	CID string `json:"cid,omitempty"`
+
+	// PeerID is the peer announcing the record, or the peer whose addresses changed.
+	PeerID string `json:"peer_id"`
+
+	// Labels are the discovered labels of the record (record.discovered).
+	Labels []string `json:"labels,omitempty"`
+
+	// Reason explains a retraction (record.retracted) or lists the failing transports (record.unresolvable).
+	Reason string `json:"reason,omitempty"`
+
+	// Addrs are the Directory API addresses of the peer (peer.changed).
+	Addrs []string `json:"addrs,omitempty"`
+}
+
+// RecordDiscovered creates a record.discovered event.
+func RecordDiscovered(cid, peerID string, labels []string) *Event {
+	return newEvent(TypeRecordDiscovered, cid, peerID, func(e *Event) { e.Labels = labels })
+}
+
+// RecordRetracted creates a record.retracted event.
+func RecordRetracted(cid, peerID, reason string) *Event {
+	return newEvent(TypeRecordRetracted, cid, peerID, func(e *Event) { e.Reason = reason })
+}
+
+// RecordUnresolvable creates a record.unresolvable event.
+func RecordUnresolvable(cid, peerID, reason string) *Event {
+	return newEvent(TypeRecordUnresolvable, cid, peerID, func(e *Event) { e.Reason = reason })
+}
+
+// PeerChanged creates a peer.changed event.
+func PeerChanged(peerID string, addrs []string) *Event {
+	return newEvent(TypePeerChanged, "", peerID, func(e *Event) { e.Addrs = addrs })
+}
+
+func newEvent(typ Type, cid, peerID string, set func(*Event)) *Event {
+	event := &Event{
+		SchemaVersion: SchemaVersion,
+		ID:            uuid.NewString(),
+		Type:          typ,
+		Time:          time.Now().UTC(),
+		CID:           cid,
+		PeerID:        peerID,
+	}
+
+	set(event)
+
+	return event
+}
+
+// Key returns the ordering key of the event: the CID for record events
+// and the peer ID for peer events.
+func (e *Event) Key() string {
+	if e.CID != "" {
+		return e.CID
+	}
+
+	return e.PeerID
+}
+
+// Marshal serializes the event to JSON.
+func (e *Event) Marshal() ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return data, nil
+}
+
+// Publisher delivers events to a message queue.
+// Publish is called sequentially for events sharing a key.
+type Publisher interface {
+	// Name identifies the publisher in logs and metrics.
+	Name() string
+
+	// Publish delivers a single event.
+	Publish(ctx context.Context, event *Event) error
+
+	// Close flushes pending events and releases the connection.
+	Close() error
+}
+
+// Emitter distributes events to publishers in the background.
+// A nil Emitter discards all events, so callers need not check whether
+// publishing is enabled. It is safe for concurrent use.
+type Emitter struct {
+	publishers []Publisher
+	queues     []chan *Event
+	wg         sync.WaitGroup
+
+	mu     sync.RWMutex // Guards closed against concurrent Emit and Close
+	closed bool
+}
+
+// NewEmitter starts an emitter delivering events to the given publishers.
+// Returns nil if there are no publishers.
+func NewEmitter(publishers ...Publisher) *Emitter {
+	if len(publishers) == 0 {
+		return nil
+	}
+
+	e := &Emitter{
+		publishers: publishers,
+		queues:     make([]chan *Event, Shards),
+	}
+
+	for i := range e.queues {
+		e.queues[i] = make(chan *Event, QueueSize)
+
+		e.wg.Add(1)
+
+		go e.run(e.queues[i])
+	}
+
+	return e
+}
+
+// Emit queues an event for publishing. It never blocks: the event is
+// dropped if its queue is full or the emitter is closed.
+func (e *Emitter) Emit(event *Event) {
+	if e == nil || event == nil {
+		return
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		return
+	}
+
+	select {
+	case e.queues[shardOf(event.Key())] <- event:
+	default:
+		for _, p := range e.publishers {
+			metrics.EventsPublished.WithLabelValues(p.Name(), metrics.ResultDropped).Inc()
+		}
+
+		logger.Warn("Event queue full, dropping event", "type", event.Type, "key", event.Key())
+	}
+}
+
+// Close publishes the queued events and closes the publishers.
+func (e *Emitter) Close() error {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+
+		return nil
+	}
+
+	e.closed = true
+
+	for _, queue := range e.queues {
+		close(queue)
+	}
+	e.mu.Unlock()
+
+	e.wg.Wait()
+
+	var errs []error
+
+	for _, p := range e.publishers {
+		if err := p.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s publisher: %w", p.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// run publishes the events of a queue in order.
+func (e *Emitter) run(queue <-chan *Event) {
+	defer e.wg.Done()
+
+	for event := range queue {
+		for _, p := range e.publishers {
+			e.publish(p, event)
+		}
+	}
+}
+
+func (e *Emitter) publish(p Publisher, event *Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), PublishTimeout)
+	defer cancel()
+
+	err := p.Publish(ctx, event)
+	metrics.EventsPublished.WithLabelValues(p.Name(), metrics.Result(err)).Inc()
+
+	if err != nil {
+		logger.Warn("Failed to publish event",
+			"publisher", p.Name(),
+			"type", event.Type,
+			"key", event.Key(),
+			"error", err)
+	}
+}
+
+// shardOf maps an ordering key to its queue.
+func shardOf(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+
+	return int(h.Sum32() % Shards)
+}
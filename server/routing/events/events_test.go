@@ -0,0 +1,150 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []*Event
+	closed bool
+}
+
+func (p *recordingPublisher) Name() string { return "recording" }
+
+func (p *recordingPublisher) Publish(_ context.Context, event *Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	p.closed = true
+
+	return nil
+}
+
+func TestEvent_Key(t *testing.T) {
+	assert.Equal(t, "cid1", RecordDiscovered("cid1", "peer1", []string{"/skills/AI"}).Key())
+	assert.Equal(t, "cid1", RecordRetracted("cid1", "peer1", "unpublished").Key())
+	assert.Equal(t, "peer1", PeerChanged("peer1", nil).Key())
+}
+
+func TestEvent_Marshal(t *testing.T) {
+	event := RecordRetracted("cid1", "peer1", "unpublished")
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	assert.Equal(t, SchemaVersion, fields["schema_version"])
+	assert.Equal(t, "record.retracted", fields["type"])
+	assert.Equal(t, "cid1", fields["cid"])
+	assert.Equal(t, "peer1", fields["peer_id"])
+	assert.Equal(t, "unpublished", fields["reason"])
+	assert.NotEmpty(t, fields["id"])
+	assert.NotContains(t, fields, "labels")
+}
+
+func TestEmitter_NilIsNoop(t *testing.T) {
+	emitter := NewEmitter()
+	assert.Nil(t, emitter)
+
+	emitter.Emit(PeerChanged("peer1", nil))
+	assert.NoError(t, emitter.Close())
+}
+
+func TestEmitter_PreservesOrderPerKey(t *testing.T) {
+	publisher := &recordingPublisher{}
+	emitter := NewEmitter(publisher)
+
+	const perKey = 100
+
+	for i := range perKey {
+		for _, cid := range []string{"cid1", "cid2", "cid3"} {
+			emitter.Emit(RecordRetracted(cid, "peer1", fmt.Sprint(i)))
+		}
+	}
+
+	require.NoError(t, emitter.Close())
+	assert.True(t, publisher.closed)
+	require.Len(t, publisher.events, 3*perKey)
+
+	next := map[string]int{}
+	for _, event := range publisher.events {
+		assert.Equal(t, fmt.Sprint(next[event.CID]), event.Reason, "events of %s out of order", event.CID)
+		next[event.CID]++
+	}
+
+	// Events emitted after closing are dropped
+	emitter.Emit(PeerChanged("peer1", nil))
+	assert.Len(t, publisher.events, 3*perKey)
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	var received kafkaProduceRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/dir.routing.events", r.URL.Path)
+		assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(body, &received))
+
+		if received.Records[0].Key == "failing" {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"Kafka error"}]}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`))
+	}))
+	defer server.Close()
+
+	publisher, err := NewKafkaPublisher(server.URL+"/", "dir.routing.events")
+	require.NoError(t, err)
+
+	defer publisher.Close()
+
+	event := RecordDiscovered("cid1", "peer1", []string{"/skills/AI"})
+	require.NoError(t, publisher.Publish(t.Context(), event))
+
+	require.Len(t, received.Records, 1)
+	assert.Equal(t, "cid1", received.Records[0].Key)
+
+	var value Event
+	require.NoError(t, json.Unmarshal(received.Records[0].Value, &value))
+	assert.Equal(t, event.ID, value.ID)
+	assert.Equal(t, []string{"/skills/AI"}, value.Labels)
+
+	// Per-record errors are reported
+	assert.Error(t, publisher.Publish(t.Context(), RecordDiscovered("failing", "peer1", nil)))
+}
+
+func TestNewKafkaPublisher_RequiresTopic(t *testing.T) {
+	_, err := NewKafkaPublisher("http://localhost:8082", "")
+	assert.Error(t, err)
+}
+
+func TestSubject(t *testing.T) {
+	assert.Equal(t, "dir.routing.peer.changed", Subject("dir.routing", TypePeerChanged))
+}
@@ -0,0 +1,129 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAccept      = "application/vnd.kafka.v2+json"
+)
+
+// kafkaPublisher produces events to a Kafka topic through a Kafka REST Proxy
+// (v2 API, as served by the Confluent REST Proxy and the Redpanda HTTP Proxy).
+// Records are keyed by Event.Key, so that all events of a record land on the
+// same partition and are consumed in order.
+type kafkaPublisher struct {
+	client   *http.Client
+	endpoint string
+}
+
+// kafkaProduceRequest is the body of a REST Proxy produce request.
+type kafkaProduceRequest struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// kafkaProduceResponse is the body of a REST Proxy produce response.
+type kafkaProduceResponse struct {
+	Offsets []struct {
+		ErrorCode *int   `json:"error_code"`
+		Error     string `json:"error"`
+	} `json:"offsets"`
+}
+
+// NewKafkaPublisher creates a publisher producing to the topic through the REST Proxy at proxyURL.
+func NewKafkaPublisher(proxyURL, topic string) (Publisher, error) {
+	if proxyURL == "" {
+		return nil, errors.New("no kafka rest proxy url configured")
+	}
+
+	if topic == "" {
+		return nil, errors.New("no kafka topic configured")
+	}
+
+	base, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kafka rest proxy url: %w", err)
+	}
+
+	return &kafkaPublisher{
+		client:   &http.Client{},
+		endpoint: strings.TrimSuffix(base.String(), "/") + "/topics/" + url.PathEscape(topic),
+	}, nil
+}
+
+func (p *kafkaPublisher) Name() string {
+	return "kafka"
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, event *Event) error {
+	data, err := event.Marshal()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(&kafkaProduceRequest{
+		Records: []kafkaRecord{{Key: event.Key(), Value: data}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal produce request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create produce request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", kafkaContentType)
+	req.Header.Set("Accept", kafkaAccept)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to produce event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read produce response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to produce event: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	var produced kafkaProduceResponse
+	if err := json.Unmarshal(respBody, &produced); err != nil {
+		return fmt.Errorf("failed to decode produce response: %w", err)
+	}
+
+	for _, offset := range produced.Offsets {
+		if offset.ErrorCode != nil {
+			return fmt.Errorf("failed to produce event: %s (error code %d)", offset.Error, *offset.ErrorCode)
+		}
+	}
+
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	p.client.CloseIdleConnections()
+
+	return nil
+}
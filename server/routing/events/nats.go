@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsPublisher publishes events to NATS subjects <prefix>.<type>,
+// e.g. "dir.routing.record.discovered". NATS preserves the order of
+// messages published over a single connection.
+type natsPublisher struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+// NewNATSPublisher creates a publisher connected to the NATS server at url.
+// The connection is re-established in the background if it is lost.
+func NewNATSPublisher(url, subjectPrefix string) (Publisher, error) {
+	if url == "" {
+		return nil, errors.New("no nats url configured")
+	}
+
+	if subjectPrefix == "" {
+		return nil, errors.New("no nats subject prefix configured")
+	}
+
+	conn, err := nats.Connect(url,
+		nats.Name("dir-routing-events"),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	return &natsPublisher{conn: conn, prefix: subjectPrefix}, nil
+}
+
+// Subject returns the subject an event type is published to.
+func Subject(prefix string, typ Type) string {
+	return prefix + "." + string(typ)
+}
+
+func (p *natsPublisher) Name() string {
+	return "nats"
+}
+
+func (p *natsPublisher) Publish(_ context.Context, event *Event) error {
+	data, err := event.Marshal()
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(Subject(p.prefix, event.Type))
+	msg.Data = data
+	msg.Header.Set("Dir-Event-Key", event.Key())
+	msg.Header.Set("Dir-Schema-Version", event.SchemaVersion)
+	msg.Header.Set(nats.MsgIdHdr, event.ID)
+
+	if err := p.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+func (p *natsPublisher) Close() error {
+	if err := p.conn.Drain(); err != nil {
+		return fmt.Errorf("failed to drain nats connection: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/dsarchive"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// follower mirrors the routing datastore of the primary while this peer is its warm standby.
+// The primary and its follower share the identity key, so that the follower takes over the
+// records announced by the primary once promoted. Until then, the follower neither joins the
+// network nor announces records. A nil follower is a primary.
+type follower struct {
+	primaryAddress string
+	interval       time.Duration
+	client         routingv1.RoutingAdminServiceClient
+	conn           io.Closer
+	promoted       atomic.Bool
+
+	// Held while mirroring, so that promotion waits for a running mirror to complete
+	mu            sync.Mutex
+	lastSyncedAt  time.Time
+	entriesSynced uint64
+}
+
+func newFollower(cfg routingconfig.FollowerConfig) (*follower, error) {
+	conn, err := grpc.NewClient(cfg.PrimaryAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client of primary %s: %w", cfg.PrimaryAddress, err)
+	}
+
+	return &follower{
+		primaryAddress: cfg.PrimaryAddress,
+		interval:       cfg.SyncInterval,
+		client:         routingv1.NewRoutingAdminServiceClient(conn),
+		conn:           conn,
+	}, nil
+}
+
+// following reports whether the peer is a follower that was not promoted yet.
+func (f *follower) following() bool {
+	return f != nil && !f.promoted.Load()
+}
+
+// synced returns when the datastore of the primary was last mirrored and its number of entries,
+// waiting for a running mirror to complete.
+func (f *follower) synced() (time.Time, uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.lastSyncedAt, f.entriesSynced
+}
+
+// primaryAnswers reports whether the primary answers an RPC call, regardless of its result.
+func (f *follower) primaryAnswers(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, FollowerFenceTimeout)
+	defer cancel()
+
+	_, err := f.client.GetRoutingTable(ctx, &routingv1.GetRoutingTableRequest{})
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return false
+	default:
+		return true
+	}
+}
+
+// errFollowing is returned for requests announcing records while the peer is a follower.
+func errFollowing() error {
+	return status.Error(codes.FailedPrecondition, "records are announced by the primary while this peer is a follower, promote it to announce records") //nolint:wrapcheck
+}
+
+// startFollowing starts a background goroutine that mirrors the routing datastore
+// of the primary until the peer is promoted.
+func (r *routeRemote) startFollowing() {
+	remoteLogger.Info("Following primary, records are not announced until promoted",
+		"primary", r.follower.primaryAddress,
+		"interval", r.follower.interval)
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		defer r.follower.conn.Close()
+
+		ticker := time.NewTicker(r.follower.interval)
+		defer ticker.Stop()
+
+		for r.follower.following() {
+			if err := r.mirrorPrimary(r.ctx); err != nil {
+				remoteLogger.Warn("Failed to mirror the datastore of the primary", "primary", r.follower.primaryAddress, "error", err)
+			}
+
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping mirroring of the primary")
+
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// mirrorPrimary mirrors the routing datastore of the primary from a snapshot streamed by it.
+func (r *routeRemote) mirrorPrimary(ctx context.Context) error {
+	r.follower.mu.Lock()
+	defer r.follower.mu.Unlock()
+
+	// The peer may have been promoted while waiting for the lock
+	if !r.follower.following() {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := r.follower.client.SnapshotDatastore(ctx, &routingv1.SnapshotDatastoreRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to snapshot datastore: %w", err)
+	}
+
+	entries, err := r.mirrorDatastore(ctx, &snapshotChunkReader{stream: stream})
+	if err != nil {
+		return err
+	}
+
+	r.follower.lastSyncedAt = time.Now()
+	r.follower.entriesSynced = entries
+
+	return nil
+}
+
+// mirrorDatastore replaces the routing datastore with the entries of a datastore archive of the
+// primary: entries are written like on RestoreDatastore, and keys missing from the archive are
+// deleted. Returns the number of mirrored entries.
+func (r *routeRemote) mirrorDatastore(ctx context.Context, rd io.Reader) (uint64, error) {
+	archive, err := dsarchive.NewReader(rd)
+	if err != nil {
+		return 0, fmt.Errorf("invalid datastore archive: %w", err)
+	}
+
+	// Records are announced by the identity of the primary, which is only taken over if shared
+	if localPeerID := r.server.Host().ID().String(); archive.Manifest().PeerID != localPeerID {
+		return 0, fmt.Errorf("primary %s does not share the identity %s of this peer, set routing.key_path to its key", archive.Manifest().PeerID, localPeerID)
+	}
+
+	mirrored := make(map[string]struct{})
+
+	for {
+		entries, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("invalid datastore archive: %w", err)
+		}
+
+		if err := r.restoreEntries(ctx, entries); err != nil {
+			return 0, err
+		}
+
+		for _, entry := range entries {
+			mirrored[entry.Key] = struct{}{}
+		}
+	}
+
+	deleted, err := r.deleteUnmirrored(ctx, mirrored)
+	if err != nil {
+		return 0, err
+	}
+
+	// Pins, label indexes and provider sets are derived from the datastore
+	if err := r.loadPins(ctx); err != nil {
+		remoteLogger.Warn("Failed to reload pins after mirroring the datastore", "error", err)
+	}
+
+	r.rebuildLabelIndexes(ctx)
+
+	remoteLogger.Debug("Mirrored the datastore of the primary",
+		"createdAt", archive.Manifest().CreatedAt,
+		"entries", len(mirrored),
+		"deleted", deleted)
+
+	return uint64(len(mirrored)), nil
+}
+
+// deleteUnmirrored deletes the keys of the routing datastore missing from the mirrored keys.
+func (r *routeRemote) deleteUnmirrored(ctx context.Context, mirrored map[string]struct{}) (int, error) {
+	results, err := r.dstore.Query(ctx, query.Query{KeysOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query datastore: %w", err)
+	}
+
+	var stale []datastore.Key
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			results.Close()
+
+			return 0, fmt.Errorf("failed to read datastore: %w", result.Error)
+		}
+
+		if _, ok := mirrored[result.Key]; !ok {
+			stale = append(stale, datastore.NewKey(result.Key))
+		}
+	}
+
+	results.Close()
+
+	if len(stale) == 0 {
+		return 0, nil
+	}
+
+	batch, err := r.dstore.Batch(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	for _, key := range stale {
+		if err := batch.Delete(ctx, key); err != nil {
+			return 0, fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to delete datastore entries: %w", err)
+	}
+
+	return len(stale), nil
+}
+
+// PromoteFollower promotes the follower to announce the records of the identity it shares with
+// the primary. Unless forced, promotion is refused while the primary answers, so that records are
+// never announced by both.
+func (r *routeRemote) PromoteFollower(ctx context.Context, req *routingv1.PromoteFollowerRequest) (*routingv1.PromoteFollowerResponse, error) {
+	if r.follower == nil {
+		return nil, status.Error(codes.FailedPrecondition, "this peer is not a follower, enable routing.follower to run it as one") //nolint:wrapcheck
+	}
+
+	if !r.follower.following() {
+		return nil, status.Error(codes.FailedPrecondition, "this peer was already promoted") //nolint:wrapcheck
+	}
+
+	if !req.GetForce() {
+		// A primary that was never reached may only be unreachable from this peer
+		if lastSyncedAt, _ := r.follower.synced(); lastSyncedAt.IsZero() {
+			return nil, status.Errorf(codes.FailedPrecondition, "primary %s was never mirrored, check that it is reachable or promote with force", r.follower.primaryAddress)
+		}
+
+		if r.follower.primaryAnswers(ctx) {
+			return nil, status.Errorf(codes.FailedPrecondition, "primary %s still answers, stop it or promote with force", r.follower.primaryAddress)
+		}
+	}
+
+	if !r.follower.promoted.CompareAndSwap(false, true) {
+		return nil, status.Error(codes.FailedPrecondition, "this peer was already promoted") //nolint:wrapcheck
+	}
+
+	// Wait for a running mirror, so that it does not overwrite what is announced from now on
+	lastSyncedAt, entries := r.follower.synced()
+
+	resp := &routingv1.PromoteFollowerResponse{EntriesSynced: entries}
+	if !lastSyncedAt.IsZero() {
+		resp.LastSyncedAt = timestamppb.New(lastSyncedAt)
+	}
+
+	r.server.Join()
+	r.startAnnouncing()
+
+	remoteLogger.Info("Promoted follower, announcing records",
+		"primary", r.follower.primaryAddress,
+		"forced", req.GetForce(),
+		"lastSyncedAt", lastSyncedAt,
+		"entries", entries)
+
+	return resp, nil
+}
+
+// snapshotChunkReader reads the datastore archive chunks streamed by the primary.
+type snapshotChunkReader struct {
+	stream  grpc.ServerStreamingClient[routingv1.StateArchiveChunk]
+	pending []byte
+}
+
+func (r *snapshotChunkReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err //nolint:wrapcheck
+		}
+
+		r.pending = chunk.GetData()
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMirrorDatastore(t *testing.T) {
+	primary := newTestServer(t, t.Context(), nil)
+	other := newTestServer(t, t.Context(), nil)
+
+	labelMetadata, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+	require.NoError(t, err)
+
+	pinMetadata, err := json.Marshal(&pin{PinnedAt: time.Now()})
+	require.NoError(t, err)
+
+	require.NoError(t, primary.remote.dstore.Put(t.Context(), ipfsdatastore.NewKey("/skills/AI/cid1/peer1"), labelMetadata))
+	require.NoError(t, primary.remote.dstore.Put(t.Context(), ipfsdatastore.NewKey("/pins/cid1"), pinMetadata))
+
+	var archive bytes.Buffer
+
+	require.NoError(t, primary.SnapshotDatastore(t.Context(), &archive))
+
+	// Archives of peers with another identity are not mirrored
+	_, err = other.remote.mirrorDatastore(t.Context(), bytes.NewReader(archive.Bytes()))
+	require.ErrorContains(t, err, "does not share the identity")
+
+	// The mirror replaces the datastore: entries are restored and others are deleted
+	require.NoError(t, primary.remote.dstore.Delete(t.Context(), ipfsdatastore.NewKey("/pins/cid1")))
+	require.NoError(t, primary.remote.dstore.Put(t.Context(), ipfsdatastore.NewKey("/skills/AI/cid2/peer2"), labelMetadata))
+
+	entries, err := primary.remote.mirrorDatastore(t.Context(), bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, entries, uint64(2))
+
+	has, err := primary.remote.dstore.Has(t.Context(), ipfsdatastore.NewKey("/skills/AI/cid2/peer2"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	has, err = primary.remote.dstore.Has(t.Context(), ipfsdatastore.NewKey("/pins/cid1"))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// State derived from the datastore is reloaded
+	assert.True(t, primary.remote.pins.has("cid1"))
+	assert.Equal(t, 1, primary.remote.providerSets.Records())
+}
+
+func TestPromoteFollower(t *testing.T) {
+	r := newTestServer(t, t.Context(), nil)
+
+	_, err := r.PromoteFollower(t.Context(), &routingv1.PromoteFollowerRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Nothing listens on the primary address, so the primary does not answer
+	f, err := newFollower(routingconfig.FollowerConfig{PrimaryAddress: "127.0.0.1:1", SyncInterval: time.Minute})
+	require.NoError(t, err)
+
+	defer f.conn.Close()
+
+	r.remote.follower = f
+
+	// Records are announced by the primary while following
+	record := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{Name: "agent-1", SchemaVersion: "v0.3.1"}))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(r.Publish(t.Context(), record)))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(r.Unpublish(t.Context(), record)))
+
+	// Followers that never mirrored the primary are only promoted with force
+	_, err = r.PromoteFollower(t.Context(), &routingv1.PromoteFollowerRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	syncedAt := time.Now()
+	f.lastSyncedAt = syncedAt
+	f.entriesSynced = 42
+
+	resp, err := r.PromoteFollower(t.Context(), &routingv1.PromoteFollowerRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), resp.GetEntriesSynced())
+	assert.True(t, resp.GetLastSyncedAt().AsTime().Equal(syncedAt))
+	assert.False(t, r.following())
+
+	_, err = r.PromoteFollower(t.Context(), &routingv1.PromoteFollowerRequest{Force: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -22,8 +22,8 @@ var (
 
 type handler struct {
 	*providers.ProviderManager
-	hostID   string
-	notifyCh chan<- *handlerSync
+	hostID string
+	queue  *notifyQueue
 }
 
 type handlerSync struct {
@@ -73,7 +73,7 @@ func (h *handler) handleAnnounce(ctx context.Context, key []byte, prov peer.Addr
 }
 
 // handleCIDProviderAnnouncement handles CID provider announcements (existing logic).
-func (h *handler) handleCIDProviderAnnouncement(_ context.Context, key []byte, prov peer.AddrInfo) error {
+func (h *handler) handleCIDProviderAnnouncement(ctx context.Context, key []byte, prov peer.AddrInfo) error {
 	// get ref cid from request
 	// if this fails, it may mean that it's not DIR-constructed CID
 	cast, err := mh.Cast(key)
@@ -97,10 +97,9 @@ func (h *handler) handleCIDProviderAnnouncement(_ context.Context, key []byte, p
 
 	handlerLogger.Info("CID provider announcement event", "ref", ref, "provider", prov, "host", h.hostID)
 
-	// notify the channel
-	h.notifyCh <- &handlerSync{
-		Ref:  ref,
-		Peer: prov,
+	// persist the notification until the record is pulled
+	if err := h.queue.enqueue(ctx, &handlerSync{Ref: ref, Peer: prov}); err != nil {
+		return fmt.Errorf("failed to queue provider notification: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,320 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// HistoryNamespace is the datastore namespace of retained discovery history.
+const HistoryNamespace = "history"
+
+// historyKey returns the datastore key of the history point starting at start: /history/<unix seconds>.
+// Seconds are zero-padded, so that keys sort by time.
+func historyKey(start time.Time) datastore.Key {
+	return datastore.NewKey(fmt.Sprintf("/%s/%020d", HistoryNamespace, start.Unix()))
+}
+
+// historyPoint is a downsampled point of discovery metrics stored in the datastore.
+// Counters are totals over the point, gauges are averages of its samples.
+type historyPoint struct {
+	Start                  time.Time `json:"start"`
+	Samples                uint32    `json:"samples"`
+	AnnouncementsPublished uint64    `json:"announcements_published,omitempty"`
+	AnnouncementsReceived  uint64    `json:"announcements_received,omitempty"`
+	Searches               uint64    `json:"searches,omitempty"`
+	CachedLabels           float64   `json:"cached_labels,omitempty"`
+	CachedRecords          float64   `json:"cached_records,omitempty"`
+	ConnectedPeers         float64   `json:"connected_peers,omitempty"`
+}
+
+// historySample is a sample of the discovery metrics.
+// Announcement counters are totals since the node first started.
+type historySample struct {
+	announcementsPublished uint64
+	announcementsReceived  uint64
+	cachedLabels           int64
+	cachedRecords          int
+	connectedPeers         int
+}
+
+// historyRecorder downsamples discovery metrics into hourly points retained in the datastore,
+// so that operators without external monitoring can compare discovery over weeks.
+// It is safe for concurrent use. A nil recorder records nothing.
+type historyRecorder struct {
+	mu        sync.Mutex
+	dstore    types.Datastore
+	retention time.Duration
+	current   historyPoint
+	previous  historySample // Last sample, the baseline of counter deltas
+	sampled   bool          // Whether previous holds a sample
+	searches  atomic.Uint64 // Searches since the last sample
+}
+
+// newHistoryRecorder creates a history recorder. If this run started within the hour of the
+// last stored point, samples are added to that point instead of overwriting it.
+func newHistoryRecorder(ctx context.Context, dstore types.Datastore, retention time.Duration, now time.Time) *historyRecorder {
+	h := &historyRecorder{
+		dstore:    dstore,
+		retention: retention,
+		current:   historyPoint{Start: now.Truncate(HistoryResolution)},
+	}
+
+	value, err := dstore.Get(ctx, historyKey(h.current.Start))
+
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+	case err != nil:
+		remoteLogger.Warn("Failed to get current history point", "error", err)
+	default:
+		if err := json.Unmarshal(value, &h.current); err != nil {
+			remoteLogger.Warn("Failed to parse current history point, starting from zero", "error", err)
+
+			h.current = historyPoint{Start: now.Truncate(HistoryResolution)}
+		}
+	}
+
+	return h
+}
+
+// searched counts a search served.
+func (h *historyRecorder) searched() {
+	if h == nil {
+		return
+	}
+
+	h.searches.Add(1)
+}
+
+// record adds a sample to the point covering now, starting a new point and pruning points
+// older than the retention when the hour has passed. Counter deltas are counted from the
+// previous sample; the first sample of a run only sets the baseline of counters.
+func (h *historyRecorder) record(ctx context.Context, sample historySample, now time.Time) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+
+	started := false
+	if start := now.Truncate(HistoryResolution); !start.Equal(h.current.Start) {
+		h.current = historyPoint{Start: start}
+		started = true
+	}
+
+	point := &h.current
+
+	if h.sampled {
+		point.AnnouncementsPublished += counterDelta(sample.announcementsPublished, h.previous.announcementsPublished)
+		point.AnnouncementsReceived += counterDelta(sample.announcementsReceived, h.previous.announcementsReceived)
+	}
+
+	point.Searches += h.searches.Swap(0)
+
+	// Gauges are averaged incrementally over the samples of the point
+	point.Samples++
+	n := float64(point.Samples)
+	point.CachedLabels += (float64(sample.cachedLabels) - point.CachedLabels) / n
+	point.CachedRecords += (float64(sample.cachedRecords) - point.CachedRecords) / n
+	point.ConnectedPeers += (float64(sample.connectedPeers) - point.ConnectedPeers) / n
+
+	h.previous = sample
+	h.sampled = true
+
+	value, err := json.Marshal(point)
+	key := historyKey(point.Start)
+
+	h.mu.Unlock()
+
+	if err != nil {
+		remoteLogger.Warn("Failed to marshal history point", "error", err)
+	} else if err := h.dstore.Put(ctx, key, value); err != nil {
+		remoteLogger.Warn("Failed to store history point", "error", err)
+	}
+
+	if started {
+		h.prune(ctx, now)
+	}
+}
+
+// counterDelta returns the increase of a counter, zero if it was reset.
+func counterDelta(current, previous uint64) uint64 {
+	if current < previous {
+		return 0
+	}
+
+	return current - previous
+}
+
+// prune deletes the points that started before the retention.
+func (h *historyRecorder) prune(ctx context.Context, now time.Time) {
+	points, err := h.query(ctx, time.Time{}, now.Add(-h.retention))
+	if err != nil {
+		remoteLogger.Warn("Failed to query history points to prune", "error", err)
+
+		return
+	}
+
+	for _, point := range points {
+		if err := h.dstore.Delete(ctx, historyKey(point.Start)); err != nil {
+			remoteLogger.Warn("Failed to delete history point", "start", point.Start, "error", err)
+		}
+	}
+
+	if len(points) > 0 {
+		remoteLogger.Debug("Pruned history points", "count", len(points))
+	}
+}
+
+// query returns the stored points starting within [since, until), ordered by start.
+// Zero times leave the range open.
+func (h *historyRecorder) query(ctx context.Context, since, until time.Time) ([]historyPoint, error) {
+	results, err := h.dstore.Query(ctx, query.Query{
+		Prefix: "/" + HistoryNamespace + "/",
+		Orders: []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer results.Close()
+
+	var points []historyPoint
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to read history point: %w", result.Error)
+		}
+
+		// Filter by the key first, to avoid parsing points outside the range
+		seconds, err := strconv.ParseInt(strings.TrimPrefix(result.Key, "/"+HistoryNamespace+"/"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		start := time.Unix(seconds, 0)
+		if (!since.IsZero() && start.Before(since)) || (!until.IsZero() && !start.Before(until)) {
+			continue
+		}
+
+		var point historyPoint
+		if err := json.Unmarshal(result.Value, &point); err != nil {
+			remoteLogger.Warn("Skipping invalid history point", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		points = append(points, point)
+	}
+
+	return points, nil
+}
+
+// toProto returns the point for the history RPC.
+func (p historyPoint) toProto() *routingv1.HistoryPoint {
+	return &routingv1.HistoryPoint{
+		Start:                  timestamppb.New(p.Start),
+		Samples:                p.Samples,
+		AnnouncementsPublished: p.AnnouncementsPublished,
+		AnnouncementsReceived:  p.AnnouncementsReceived,
+		Searches:               p.Searches,
+		CachedLabels:           p.CachedLabels,
+		CachedRecords:          p.CachedRecords,
+		ConnectedPeers:         p.ConnectedPeers,
+	}
+}
+
+// startHistoryRecording starts a background goroutine that periodically samples
+// the discovery metrics into the retained history.
+func (r *routeRemote) startHistoryRecording() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(HistorySampleInterval)
+		defer ticker.Stop()
+
+		remoteLogger.Info("Started discovery history recording", "interval", HistorySampleInterval, "retention", r.history.retention)
+
+		r.history.record(r.ctx, r.historySample(), time.Now())
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping discovery history recording")
+
+				return
+			case <-ticker.C:
+				r.history.record(r.ctx, r.historySample(), time.Now())
+			}
+		}
+	}()
+}
+
+// historySample samples the current discovery metrics.
+func (r *routeRemote) historySample() historySample {
+	published, received := r.state.announcements()
+
+	return historySample{
+		announcementsPublished: published,
+		announcementsReceived:  received,
+		cachedLabels:           r.peerStats.TotalLabels(),
+		cachedRecords:          r.providerSets.Records(),
+		connectedPeers:         len(r.server.Host().Network().Peers()),
+	}
+}
+
+// GetHistory returns the retained discovery history points within the requested range.
+func (r *routeRemote) GetHistory(ctx context.Context, req *routingv1.GetHistoryRequest) (*routingv1.GetHistoryResponse, error) {
+	if r.history == nil {
+		return nil, status.Error(codes.FailedPrecondition, "discovery history is not retained, enable routing.history to retain it") //nolint:wrapcheck
+	}
+
+	var since, until time.Time
+	if req.GetSince() != nil {
+		since = req.GetSince().AsTime()
+	}
+
+	if req.GetUntil() != nil {
+		until = req.GetUntil().AsTime()
+	}
+
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return nil, status.Error(codes.InvalidArgument, "since must be before until") //nolint:wrapcheck
+	}
+
+	points, err := r.history.query(ctx, since, until)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	resp := &routingv1.GetHistoryResponse{
+		Points:     make([]*routingv1.HistoryPoint, 0, len(points)),
+		Resolution: durationpb.New(HistoryResolution),
+		Retention:  durationpb.New(r.history.retention),
+	}
+
+	for _, point := range points {
+		resp.Points = append(resp.Points, point.toProto())
+	}
+
+	return resp, nil
+}
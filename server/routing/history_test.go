@@ -0,0 +1,115 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestHistoryRecorder_Downsampling(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	ctx := t.Context()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	history := newHistoryRecorder(ctx, dstore, 24*time.Hour, start)
+
+	// The first sample only sets the baseline of counters
+	history.searched()
+	history.record(ctx, historySample{announcementsPublished: 10, announcementsReceived: 100, cachedLabels: 100, connectedPeers: 2}, start)
+	history.searched()
+	history.searched()
+	history.record(ctx, historySample{announcementsPublished: 12, announcementsReceived: 150, cachedLabels: 300, connectedPeers: 4}, start.Add(30*time.Minute))
+
+	// Samples of the next hour start a new point
+	history.record(ctx, historySample{announcementsPublished: 15, announcementsReceived: 150, cachedLabels: 500, connectedPeers: 5}, start.Add(time.Hour))
+
+	points, err := history.query(ctx, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+
+	assert.True(t, start.Equal(points[0].Start))
+	assert.Equal(t, uint32(2), points[0].Samples)
+	assert.Equal(t, uint64(2), points[0].AnnouncementsPublished)
+	assert.Equal(t, uint64(50), points[0].AnnouncementsReceived)
+	assert.Equal(t, uint64(3), points[0].Searches)
+	assert.InDelta(t, 200, points[0].CachedLabels, 0.001)
+	assert.InDelta(t, 3, points[0].ConnectedPeers, 0.001)
+
+	assert.True(t, start.Add(time.Hour).Equal(points[1].Start))
+	assert.Equal(t, uint64(3), points[1].AnnouncementsPublished)
+	assert.Equal(t, uint64(0), points[1].AnnouncementsReceived)
+
+	// A restart within the hour continues the stored point
+	history = newHistoryRecorder(ctx, dstore, 24*time.Hour, start.Add(90*time.Minute))
+	history.record(ctx, historySample{cachedLabels: 700}, start.Add(90*time.Minute))
+
+	points, err = history.query(ctx, start.Add(time.Hour), time.Time{})
+	require.NoError(t, err)
+	require.Len(t, points, 1)
+	assert.Equal(t, uint32(2), points[0].Samples)
+	assert.Equal(t, uint64(3), points[0].AnnouncementsPublished)
+	assert.InDelta(t, 600, points[0].CachedLabels, 0.001)
+}
+
+func TestHistoryRecorder_Pruning(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	ctx := t.Context()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	history := newHistoryRecorder(ctx, dstore, 3*time.Hour, start)
+	for hour := range 5 {
+		history.record(ctx, historySample{}, start.Add(time.Duration(hour)*time.Hour))
+	}
+
+	// Points that started before the retention are pruned when a new point starts
+	points, err := history.query(ctx, time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, points, 4)
+	assert.True(t, start.Add(time.Hour).Equal(points[0].Start))
+
+	// The range is half-open
+	points, err = history.query(ctx, start.Add(2*time.Hour), start.Add(4*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.True(t, start.Add(2*time.Hour).Equal(points[0].Start))
+	assert.True(t, start.Add(3*time.Hour).Equal(points[1].Start))
+}
+
+func TestGetHistory(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	ctx := t.Context()
+	r := &routeRemote{dstore: dstore}
+
+	// History is not retained unless enabled
+	_, err := r.GetHistory(ctx, &routingv1.GetHistoryRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	now := time.Now()
+	r.history = newHistoryRecorder(ctx, dstore, 24*time.Hour, now)
+	r.history.record(ctx, historySample{cachedRecords: 7}, now)
+
+	resp, err := r.GetHistory(ctx, &routingv1.GetHistoryRequest{Since: timestamppb.New(now.Add(-2 * time.Hour))})
+	require.NoError(t, err)
+	require.Len(t, resp.GetPoints(), 1)
+	assert.InDelta(t, 7, resp.GetPoints()[0].GetCachedRecords(), 0.001)
+	assert.Equal(t, HistoryResolution, resp.GetResolution().AsDuration())
+	assert.Equal(t, 24*time.Hour, resp.GetRetention().AsDuration())
+
+	_, err = r.GetHistory(ctx, &routingv1.GetHistoryRequest{Since: timestamppb.New(now), Until: timestamppb.New(now.Add(-time.Hour))})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,220 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/continuity"
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// newContinuityRecord creates the continuity record of the rotation from the
+// previous identity key at previousKeyPath to the current identity key.
+func newContinuityRecord(previousKeyPath string, key crypto.PrivKey) (*continuity.Record, error) {
+	previousKey, err := signing.LoadKey(previousKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load previous identity key: %w", err)
+	}
+
+	record, err := continuity.New(previousKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create continuity record: %w", err)
+	}
+
+	return record, nil
+}
+
+// startIdentityContinuity migrates the local state of the previous identity of this peer
+// and announces its rotation until the continuity record expires, so that remote peers
+// move the labels and addresses cached under the previous peer ID to the current one.
+func (r *routeRemote) startIdentityContinuity(record *continuity.Record) {
+	r.applyContinuity(r.ctx, record)
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(ContinuityAnnounceInterval)
+		defer ticker.Stop()
+
+		bootstrapped := r.server.Bootstrapped()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping identity continuity announcements")
+
+				return
+			case <-bootstrapped:
+				bootstrapped = nil
+
+				r.announceContinuity(r.ctx, record)
+			case <-ticker.C:
+				if time.Since(record.Timestamp) > ContinuityTTL {
+					remoteLogger.Info("Identity continuity record expired, stopping announcements", "oldPeer", record.OldPeerID)
+
+					return
+				}
+
+				r.announceContinuity(r.ctx, record)
+			}
+		}
+	}()
+}
+
+// announceContinuity publishes the continuity record via GossipSub (if enabled)
+// and stores it in the DHT for peers that look up the retired peer ID.
+func (r *routeRemote) announceContinuity(ctx context.Context, record *continuity.Record) {
+	data, err := record.Marshal()
+	if err != nil {
+		remoteLogger.Warn("Failed to marshal continuity record", "error", err)
+
+		return
+	}
+
+	if err := r.server.DHT().PutValue(ctx, continuity.Key(record.OldPeerID), data); err != nil {
+		remoteLogger.Warn("Failed to store continuity record in DHT", "oldPeer", record.OldPeerID, "error", err)
+	}
+
+	if r.pubsubManager != nil {
+		if err := r.pubsubManager.PublishContinuity(ctx, record); err != nil {
+			remoteLogger.Warn("Failed to publish continuity record", "oldPeer", record.OldPeerID, "error", err)
+		}
+	}
+}
+
+// handleContinuity applies a continuity record received via GossipSub.
+// The record is signed by both identities and originated by the new one.
+func (r *routeRemote) handleContinuity(ctx context.Context, record *continuity.Record) {
+	r.applyContinuity(ctx, record)
+}
+
+// applyContinuity stores a verified continuity record and migrates the labels and
+// addresses cached under the old peer ID to the current identity of the peer.
+// Expired records and records older than the stored one are ignored.
+func (r *routeRemote) applyContinuity(ctx context.Context, record *continuity.Record) {
+	if time.Since(record.Timestamp) > ContinuityTTL {
+		return
+	}
+
+	// Keep the most recent record only
+	if existing, ok := r.getContinuity(ctx, record.OldPeerID); ok && !record.Timestamp.After(existing.Timestamp) {
+		return
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		remoteLogger.Warn("Failed to marshal continuity record", "oldPeer", record.OldPeerID, "error", err)
+
+		return
+	}
+
+	if err := r.dstore.Put(ctx, datastore.NewKey(continuity.Key(record.OldPeerID)), data); err != nil {
+		remoteLogger.Warn("Failed to store continuity record", "oldPeer", record.OldPeerID, "error", err)
+
+		return
+	}
+
+	// The new identity may have been rotated again before this record arrived
+	newPeerID := r.currentIdentity(ctx, record.NewPeerID)
+
+	migrated := r.migratePeerLabels(ctx, record.OldPeerID, newPeerID)
+
+	if err := r.addressBook.Move(ctx, record.OldPeerID, newPeerID); err != nil {
+		remoteLogger.Warn("Failed to move peer addresses", "oldPeer", record.OldPeerID, "newPeer", newPeerID, "error", err)
+	}
+
+	metrics.IdentityRotations.Inc()
+
+	remoteLogger.Info("Applied peer identity rotation",
+		"oldPeer", record.OldPeerID,
+		"newPeer", newPeerID,
+		"migratedLabels", migrated)
+}
+
+// getContinuity returns the continuity record of a retired peer ID, if one is honoured.
+// Expired records are removed.
+func (r *routeRemote) getContinuity(ctx context.Context, peerID string) (*continuity.Record, bool) {
+	key := datastore.NewKey(continuity.Key(peerID))
+
+	data, err := r.dstore.Get(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+
+	record, err := continuity.Unmarshal(data)
+	if err != nil || time.Since(record.Timestamp) > ContinuityTTL {
+		_ = r.dstore.Delete(ctx, key)
+
+		return nil, false
+	}
+
+	return record, true
+}
+
+// isRetiredIdentity reports whether a peer ID was rotated to another identity.
+// Announcements of retired identities are rejected, since their signing key may be compromised.
+func (r *routeRemote) isRetiredIdentity(ctx context.Context, peerID string) bool {
+	_, retired := r.getContinuity(ctx, peerID)
+
+	return retired
+}
+
+// currentIdentity follows the successors of a peer ID up to MaxContinuityChain rotations.
+func (r *routeRemote) currentIdentity(ctx context.Context, peerID string) string {
+	for range MaxContinuityChain {
+		record, ok := r.getContinuity(ctx, peerID)
+		if !ok {
+			break
+		}
+
+		peerID = record.NewPeerID
+	}
+
+	return peerID
+}
+
+// migratePeerLabels moves all labels cached under the old peer ID to the new one,
+// keeping their metadata. Returns the number of migrated labels.
+func (r *routeRemote) migratePeerLabels(ctx context.Context, oldPeerID, newPeerID string) int {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		remoteLogger.Error("Failed to get namespace entries for identity rotation", "error", err)
+
+		return 0
+	}
+
+	migration := &cacheMutation{}
+
+	for _, entry := range entries {
+		_, _, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID != oldPeerID {
+			continue
+		}
+
+		// Replace the peer ID suffix only, keeping tenant prefixes of the key
+		migration.put(strings.TrimSuffix(entry.Key, oldPeerID)+newPeerID, entry.Value)
+		migration.delete(entry.Key)
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, migration); err != nil {
+		remoteLogger.Warn("Failed to migrate labels of rotated peer", "oldPeer", oldPeerID, "newPeer", newPeerID, "error", err)
+
+		return 0
+	}
+
+	migrated := len(migration.Deletes)
+	r.peerStats.AddLabels(oldPeerID, -migrated)
+	r.peerStats.AddLabels(newPeerID, migrated)
+
+	return migrated
+}
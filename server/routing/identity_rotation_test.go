@@ -0,0 +1,104 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/routing/continuity"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestContinuity(t *testing.T, oldKey, newKey crypto.PrivKey, timestamp time.Time) *continuity.Record {
+	t.Helper()
+
+	record, err := continuity.New(oldKey, newKey)
+	require.NoError(t, err)
+
+	record.Timestamp = timestamp
+	require.NoError(t, record.Sign(oldKey, newKey))
+
+	return record
+}
+
+func TestApplyContinuity_MigratesLabelsAndAddresses(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	oldKey, oldPeer := newTestPublisher(t)
+	newKey, newPeer := newTestPublisher(t)
+
+	putTestLabel(t, dstore, "/skills/AI", revokedTestCID, oldPeer, time.Now())
+	putTestLabel(t, dstore, "/tenants/acme/skills/AI", revokedTestCID, oldPeer, time.Now())
+	putTestLabel(t, dstore, "/skills/AI", revokedTestCID, "other-peer", time.Now())
+
+	book := addressbook.New(dstore, PeerAddressTTL)
+	require.NoError(t, book.Add(t.Context(), oldPeer, []ma.Multiaddr{ma.StringCast("/ip4/10.0.0.1/tcp/4001")}, addressbook.PriorityIdentify))
+
+	r := &routeRemote{dstore: dstore, addressBook: book}
+	r.applyContinuity(t.Context(), newTestContinuity(t, oldKey, newKey, time.Now()))
+
+	assert.False(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, oldPeer))
+	assert.True(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, newPeer))
+	assert.True(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, "other-peer"))
+
+	// Tenant labels keep their tenant prefix
+	has, err := dstore.Has(t.Context(), ipfsdatastore.NewKey("/tenants/acme/skills/AI/"+revokedTestCID+"/"+newPeer))
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	assert.False(t, book.Has(t.Context(), oldPeer))
+	assert.True(t, book.Has(t.Context(), newPeer))
+
+	// Announcements of the retired identity are rejected from now on
+	assert.True(t, r.isRetiredIdentity(t.Context(), oldPeer))
+	assert.False(t, r.isRetiredIdentity(t.Context(), newPeer))
+}
+
+func TestApplyContinuity_FollowsRotationChain(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	firstKey, firstPeer := newTestPublisher(t)
+	secondKey, _ := newTestPublisher(t)
+	thirdKey, thirdPeer := newTestPublisher(t)
+
+	putTestLabel(t, dstore, "/skills/AI", revokedTestCID, firstPeer, time.Now())
+
+	r := &routeRemote{dstore: dstore, addressBook: addressbook.New(dstore, PeerAddressTTL)}
+
+	// The second rotation arrives before the first one
+	r.applyContinuity(t.Context(), newTestContinuity(t, secondKey, thirdKey, time.Now()))
+	r.applyContinuity(t.Context(), newTestContinuity(t, firstKey, secondKey, time.Now().Add(-time.Hour)))
+
+	assert.True(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, thirdPeer))
+}
+
+func TestApplyContinuity_IgnoresStaleAndExpired(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	oldKey, oldPeer := newTestPublisher(t)
+	newKey, _ := newTestPublisher(t)
+	otherKey, otherPeer := newTestPublisher(t)
+
+	r := &routeRemote{dstore: dstore, addressBook: addressbook.New(dstore, PeerAddressTTL)}
+
+	r.applyContinuity(t.Context(), newTestContinuity(t, oldKey, otherKey, time.Now().Add(-2*ContinuityTTL)))
+	assert.False(t, r.isRetiredIdentity(t.Context(), oldPeer))
+
+	r.applyContinuity(t.Context(), newTestContinuity(t, oldKey, newKey, time.Now()))
+
+	// An older record linking the same identity to another successor is ignored
+	putTestLabel(t, dstore, "/skills/AI", revokedTestCID, oldPeer, time.Now())
+	r.applyContinuity(t.Context(), newTestContinuity(t, oldKey, otherKey, time.Now().Add(-time.Hour)))
+
+	assert.False(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, otherPeer))
+}
@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package didkey encodes Ed25519 public keys as did:key identifiers, which
+// identify publishers independently of the peers announcing their records.
+//
+// Format: "did:key:z" followed by the base58btc encoding of the ed25519-pub
+// multicodec prefix (0xed 0x01) and the 32 byte public key.
+package didkey
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/multiformats/go-multibase"
+)
+
+// Prefix is the prefix of did:key identifiers.
+const Prefix = "did:key:"
+
+// ed25519Codec is the multicodec prefix of Ed25519 public keys.
+var ed25519Codec = []byte{0xed, 0x01}
+
+// Encode returns the did:key identifier of an Ed25519 public key.
+func Encode(key crypto.PubKey) (string, error) {
+	if key == nil || key.Type() != crypto.Ed25519 {
+		return "", errors.New("only Ed25519 keys can be encoded as did:key")
+	}
+
+	raw, err := key.Raw()
+	if err != nil {
+		return "", fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	encoded, err := multibase.Encode(multibase.Base58BTC, append(bytes.Clone(ed25519Codec), raw...))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode public key: %w", err)
+	}
+
+	return Prefix + encoded, nil
+}
+
+// Decode returns the Ed25519 public key of a did:key identifier.
+func Decode(did string) (crypto.PubKey, error) {
+	encoded, ok := strings.CutPrefix(did, Prefix)
+	if !ok {
+		return nil, fmt.Errorf("invalid DID %q, expected a %s identifier", did, Prefix)
+	}
+
+	encoding, data, err := multibase.Decode(encoded)
+	if err != nil || encoding != multibase.Base58BTC {
+		return nil, fmt.Errorf("invalid DID %q, expected a base58btc encoded key", did)
+	}
+
+	raw, ok := bytes.CutPrefix(data, ed25519Codec)
+	if !ok {
+		return nil, fmt.Errorf("invalid DID %q, only Ed25519 keys are supported", did)
+	}
+
+	key, err := crypto.UnmarshalEd25519PublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DID %q: %w", did, err)
+	}
+
+	return key, nil
+}
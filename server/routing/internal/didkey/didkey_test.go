@@ -0,0 +1,46 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package didkey
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	_, key, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	did, err := Encode(key)
+	require.NoError(t, err)
+	assert.Regexp(t, `^did:key:z6Mk`, did)
+
+	decoded, err := Decode(did)
+	require.NoError(t, err)
+	assert.True(t, key.Equals(decoded))
+}
+
+func TestEncode_RejectsOtherKeyTypes(t *testing.T) {
+	_, key, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = Encode(key)
+	assert.Error(t, err)
+}
+
+func TestDecode_RejectsInvalidIdentifiers(t *testing.T) {
+	for _, did := range []string{
+		"",
+		"did:web:example.com",
+		"did:key:6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK",   // missing multibase prefix
+		"did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme", // secp256k1 key
+	} {
+		_, err := Decode(did)
+		assert.Error(t, err, did)
+	}
+}
@@ -39,6 +39,5 @@ const (
 // to protect them from Connection Manager pruning as mesh topology changes.
 const MeshPeerTaggingInterval = 30 * time.Second
 
-// mDNS service name for local network peer discovery.
-// This is used to identify DIR peers on the same LAN.
-const MDNSServiceName = "agntcy-dir-local-discovery"
+// MDNSConnectTimeout bounds connecting to a peer discovered via mDNS.
+const MDNSConnectTimeout = 10 * time.Second
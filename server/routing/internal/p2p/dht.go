@@ -5,6 +5,7 @@ package p2p
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 
@@ -14,9 +15,30 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// DHT modes of the host.
+const (
+	DHTModeClient = "client"
+	DHTModeServer = "server"
+	DHTModeAuto   = "auto"
+)
+
+// parseDHTMode returns the DHT mode of the given name.
+func parseDHTMode(mode string) (dht.ModeOpt, error) {
+	switch mode {
+	case DHTModeClient:
+		return dht.ModeClient, nil
+	case DHTModeServer:
+		return dht.ModeServer, nil
+	case DHTModeAuto:
+		return dht.ModeAuto, nil
+	default:
+		return 0, fmt.Errorf("invalid DHT mode %q, must be %q, %q or %q", mode, DHTModeClient, DHTModeServer, DHTModeAuto)
+	}
+}
+
 // newDHT creates a DHT to be served over libp2p host.
-// DHT will serve as a bootstrap peer if no bootstrap peers provided.
-func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo, refreshPeriod time.Duration, options ...dht.Option) (*dht.IpfsDHT, error) {
+// DHT will serve as a bootstrap peer if no bootstrap peers provided, unless mode is set.
+func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo, refreshPeriod time.Duration, mode *dht.ModeOpt, options ...dht.Option) (*dht.IpfsDHT, error) {
 	// If no bootstrap nodes provided, we are the bootstrap node.
 	if len(bootstrapPeers) == 0 {
 		options = append(options, dht.Mode(dht.ModeServer))
@@ -24,6 +46,11 @@ func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo,
 		options = append(options, dht.BootstrapPeers(bootstrapPeers...))
 	}
 
+	// An explicit mode overrides the previous ones, as options apply in order
+	if mode != nil {
+		options = append(options, dht.Mode(*mode))
+	}
+
 	// Set refresh period
 	if refreshPeriod > 0 {
 		options = append(options, dht.RoutingTableRefreshPeriod(refreshPeriod))
@@ -40,6 +67,12 @@ func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo,
 		return nil, err //nolint:wrapcheck
 	}
 
+	return kdht, nil
+}
+
+// connectBootstrapPeers connects to the bootstrap peers and protects them from pruning.
+// Unreachable bootstrap peers are logged and skipped.
+func connectBootstrapPeers(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo) {
 	// Sync with bootstrap nodes
 	var wg sync.WaitGroup
 	for _, p := range bootstrapPeers {
@@ -79,6 +112,4 @@ func newDHT(ctx context.Context, host host.Host, bootstrapPeers []peer.AddrInfo,
 			}
 		}
 	}
-
-	return kdht, nil
 }
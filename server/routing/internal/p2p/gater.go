@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package p2p
+
+import (
+	"sync/atomic"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// peerGater restricts which peers can connect to the host.
+// Denied peers are always rejected. If an allowlist is set, only allowed peers
+// are accepted. Peers are checked before dialing and once an inbound connection
+// is secured, i.e. as soon as the remote peer ID is known.
+// In standby, all peers are rejected.
+type peerGater struct {
+	allowed map[peer.ID]bool // Empty allows all peers that are not denied
+	denied  map[peer.ID]bool
+	standby atomic.Bool
+}
+
+var _ connmgr.ConnectionGater = (*peerGater)(nil)
+
+// newPeerGater creates a gater for the allowed and denied peers.
+// Bootstrap peers are implicitly allowed unless denied, so that an allowlisted
+// network can still be joined.
+func newPeerGater(allowed, denied []peer.ID, bootstrapPeers []peer.AddrInfo) *peerGater {
+	gater := &peerGater{
+		allowed: make(map[peer.ID]bool, len(allowed)),
+		denied:  make(map[peer.ID]bool, len(denied)),
+	}
+
+	for _, id := range denied {
+		gater.denied[id] = true
+	}
+
+	for _, id := range allowed {
+		gater.allowed[id] = true
+	}
+
+	if len(allowed) > 0 {
+		for _, bootstrapPeer := range bootstrapPeers {
+			gater.allowed[bootstrapPeer.ID] = true
+		}
+	}
+
+	return gater
+}
+
+// enabled reports whether the gater restricts any peer.
+func (g *peerGater) enabled() bool {
+	return len(g.allowed) > 0 || len(g.denied) > 0 || g.standby.Load()
+}
+
+// allows reports whether the peer may connect.
+func (g *peerGater) allows(id peer.ID) bool {
+	if g.standby.Load() || g.denied[id] {
+		return false
+	}
+
+	return len(g.allowed) == 0 || g.allowed[id]
+}
+
+func (g *peerGater) InterceptPeerDial(id peer.ID) bool {
+	allowed := g.allows(id)
+	if !allowed {
+		logger.Debug("Refusing to dial gated peer", "peer", id)
+	}
+
+	return allowed
+}
+
+func (g *peerGater) InterceptAddrDial(peer.ID, ma.Multiaddr) bool {
+	return true
+}
+
+// InterceptAccept allows all inbound connections outside of standby, the remote peer ID is not known yet.
+func (g *peerGater) InterceptAccept(network.ConnMultiaddrs) bool {
+	return !g.standby.Load()
+}
+
+func (g *peerGater) InterceptSecured(dir network.Direction, id peer.ID, addrs network.ConnMultiaddrs) bool {
+	allowed := g.allows(id)
+	if !allowed {
+		logger.Debug("Rejecting connection of gated peer",
+			"peer", id, "direction", dir, "addr", addrs.RemoteMultiaddr())
+	}
+
+	return allowed
+}
+
+func (g *peerGater) InterceptUpgraded(network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
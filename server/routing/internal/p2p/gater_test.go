@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package p2p
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerGater(t *testing.T) {
+	allowed, denied, bootstrap, other := randomPeerID(t), randomPeerID(t), randomPeerID(t), randomPeerID(t)
+
+	// Without lists, all peers can connect
+	gater := newPeerGater(nil, nil, []peer.AddrInfo{{ID: bootstrap}})
+	assert.False(t, gater.enabled())
+	assert.True(t, gater.allows(other))
+
+	// Denied peers are refused, others can connect
+	gater = newPeerGater(nil, []peer.ID{denied}, nil)
+	assert.True(t, gater.enabled())
+	assert.False(t, gater.InterceptPeerDial(denied))
+	assert.False(t, gater.InterceptSecured(network.DirInbound, denied, connAddrs{}))
+	assert.True(t, gater.InterceptPeerDial(other))
+
+	// Only allowed and bootstrap peers can connect, unless denied
+	gater = newPeerGater([]peer.ID{allowed, denied}, []peer.ID{denied}, []peer.AddrInfo{{ID: bootstrap}})
+	assert.True(t, gater.allows(allowed))
+	assert.True(t, gater.allows(bootstrap))
+	assert.False(t, gater.allows(denied))
+	assert.False(t, gater.allows(other))
+
+	// In standby, no peer can connect until the gater is released
+	gater = newPeerGater(nil, []peer.ID{denied}, nil)
+	gater.standby.Store(true)
+	assert.False(t, gater.InterceptPeerDial(other))
+	assert.False(t, gater.InterceptAccept(connAddrs{}))
+
+	gater.standby.Store(false)
+	assert.True(t, gater.InterceptPeerDial(other))
+	assert.True(t, gater.InterceptAccept(connAddrs{}))
+	assert.False(t, gater.InterceptPeerDial(denied))
+}
+
+func TestWithPeerLists(t *testing.T) {
+	id := randomPeerID(t)
+
+	opts := &options{}
+	require.NoError(t, WithAllowedPeers([]string{id.String()})(opts))
+	require.NoError(t, WithDeniedPeers(nil)(opts))
+	assert.Equal(t, []peer.ID{id}, opts.AllowedPeers)
+	assert.Empty(t, opts.DeniedPeers)
+
+	require.Error(t, WithDeniedPeers([]string{"not-a-peer-id"})(opts))
+}
+
+func randomPeerID(t *testing.T) peer.ID {
+	t.Helper()
+
+	id, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	return id
+}
+
+type connAddrs struct{}
+
+func (connAddrs) LocalMultiaddr() ma.Multiaddr  { return ma.StringCast("/ip4/127.0.0.1/tcp/8999") }
+func (connAddrs) RemoteMultiaddr() ma.Multiaddr { return ma.StringCast("/ip4/127.0.0.2/tcp/8999") }
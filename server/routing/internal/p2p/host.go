@@ -9,24 +9,35 @@ import (
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	connmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	libp2ptls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
 	ma "github.com/multiformats/go-multiaddr"
 )
 
 const (
 	DirProtocol     = "dir"
 	DirProtocolCode = 65535
+
+	// ZoneProtocol advertises the locality zone of a peer (e.g. /dir-zone/eu-west-1)
+	// alongside its addresses, so that it is learned via identify and DHT provider records.
+	ZoneProtocol     = "dir-zone"
+	ZoneProtocolCode = 65534
 )
 
-// Add dir protocol to the host.
-//
-//nolint:mnd
+// Add dir and zone protocols to the host.
 func init() {
+	addTextProtocol(DirProtocol, DirProtocolCode)
+	addTextProtocol(ZoneProtocol, ZoneProtocolCode)
+}
+
+// addTextProtocol registers a multiaddr protocol with a plain text value.
+func addTextProtocol(name string, code int) {
 	err := ma.AddProtocol(ma.Protocol{
-		Name:  DirProtocol,
-		Code:  DirProtocolCode,
-		VCode: ma.CodeToVarint(DirProtocolCode),
+		Name:  name,
+		Code:  code,
+		VCode: ma.CodeToVarint(code),
 		Size:  ma.LengthPrefixedVarSize,
 		Transcoder: ma.NewTranscoderFromFunctions(
 			// String to bytes encoder
@@ -42,12 +53,16 @@ func init() {
 		),
 	})
 	if err != nil {
-		panic(fmt.Errorf("failed to add dir protocol: %w", err))
+		panic(fmt.Errorf("failed to add %s protocol: %w", name, err))
 	}
 }
 
 // newHost creates a new host libp2p host.
-func newHost(listenAddr, dirAPIAddr string, key crypto.PrivKey) (host.Host, error) {
+// If gater is set, it restricts which peers can connect.
+// If psk is set, the host only connects to peers of the same private network.
+// If zone is set, it is advertised with the host addresses.
+// NAT traversal and resource limits are configured by hostOpts, see natOptions and resourceManagerOption.
+func newHost(listenAddr, dirAPIAddr, zone string, key crypto.PrivKey, gater *peerGater, psk pnet.PSK, hostOpts []libp2p.Option) (host.Host, error) {
 	// Create connection manager to limit and manage peer connections.
 	// This prevents resource exhaustion and enables smart peer pruning based on priority.
 	connMgr, err := connmgr.NewConnManager(
@@ -58,16 +73,35 @@ func newHost(listenAddr, dirAPIAddr string, key crypto.PrivKey) (host.Host, erro
 	if err != nil {
 		return nil, fmt.Errorf("failed to create p2p host connection manager: %w", err)
 	}
+
+	transports := libp2p.DefaultTransports
+
+	var extraOpts []libp2p.Option
+
+	// Only TCP supports private networks, QUIC, WebTransport and WebRTC do not
+	if len(psk) > 0 {
+		transports = libp2p.Transport(tcp.NewTCPTransport)
+		extraOpts = append(extraOpts, libp2p.PrivateNetwork(psk))
+	}
+
+	if gater != nil && gater.enabled() {
+		extraOpts = append(extraOpts, libp2p.ConnectionGater(gater))
+	}
+
+	extraOpts = append(extraOpts, hostOpts...)
+
 	// Create host
-	host, err := libp2p.New(
+	host, err := libp2p.New(append([]libp2p.Option{
 		// Add directory API address to the host address factory
 		libp2p.AddrsFactory(
 			func(addrs []ma.Multiaddr) []ma.Multiaddr {
 				// Only add the dir address if dirAPIAddr is not empty
 				if dirAPIAddr != "" {
-					dirAddr := ma.StringCast("/dir/" + dirAPIAddr)
+					addrs = append(addrs, ma.StringCast("/dir/"+dirAPIAddr))
+				}
 
-					return append(addrs, dirAddr)
+				if zone != "" {
+					addrs = append(addrs, ma.StringCast("/"+ZoneProtocol+"/"+zone))
 				}
 
 				return addrs
@@ -79,26 +113,14 @@ func newHost(listenAddr, dirAPIAddr string, key crypto.PrivKey) (host.Host, erro
 		libp2p.ListenAddrStrings(listenAddr),
 		// support TLS connections
 		libp2p.Security(libp2ptls.ID, libp2ptls.New),
-		// support any other default transports (TCP)
-		libp2p.DefaultTransports,
+		// support any other default transports (TCP), or only TCP in private networks
+		transports,
 		// support any other default multiplexer
 		libp2p.DefaultMuxers,
 		// Let's prevent our peer from having too many
 		// connections by attaching a connection manager.
 		libp2p.ConnectionManager(connMgr),
-		// Enable hole punching to upgrade relay connections to direct.
-		// When two NAT'd peers connect via relay, hole punching attempts to
-		// establish a direct connection through simultaneous dialing (DCUtR protocol).
-		// Success rate: ~70-80%. Falls back to relay if hole punching fails.
-		libp2p.EnableHolePunching(),
-		// Attempt to open ports using uPNP for NATed hosts.
-		libp2p.NATPortMap(),
-		// Enable AutoNAT service to help other peers detect if they are behind NAT.
-		// This is the server-side component that responds to NAT detection requests.
-		// Note: AutoNAT client (for detecting our own NAT status) runs automatically.
-		// This service is highly rate-limited and should not cause any performance issues.
-		libp2p.EnableNATService(),
-	)
+	}, extraOpts...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create p2p host: %w", err)
 	}
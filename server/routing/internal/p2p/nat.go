@@ -0,0 +1,183 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/libp2p/go-libp2p"
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Reachability overrides of the AutoNAT reachability detection.
+const (
+	ReachabilityPublic  = "public"
+	ReachabilityPrivate = "private"
+)
+
+// NATOptions configure how the host is reached from behind NATs, and helps other
+// peers behind NATs. See DefaultNATOptions for the options used unless WithNAT is set.
+type NATOptions struct {
+	// AutoNATService helps other peers detect whether they are publicly reachable.
+	AutoNATService bool
+
+	// PortMapping opens ports on the router via UPnP or NAT-PMP.
+	PortMapping bool
+
+	// HolePunching upgrades relayed connections to direct ones (DCUtR).
+	HolePunching bool
+
+	// RelayClient reserves slots on circuit relays while the host is not publicly
+	// reachable, and advertises the relayed addresses (AutoRelay).
+	RelayClient bool
+
+	// StaticRelays are the multiaddrs of the relays to reserve slots on, with the ID
+	// of the relay, e.g. /ip4/1.2.3.4/tcp/8999/p2p/12D3KooW...
+	// If empty, relays are picked among the peers of the DHT routing table.
+	StaticRelays []string
+
+	// RelayService runs a circuit relay v2 for other peers while the host is publicly reachable.
+	RelayService bool
+
+	// Reachability overrides the detected reachability, ReachabilityPublic or ReachabilityPrivate.
+	Reachability string
+}
+
+// DefaultNATOptions returns the NAT options of hosts without WithNAT: all NAT
+// traversal is enabled, with relays picked from the DHT, but the host does not
+// relay for other peers.
+func DefaultNATOptions() NATOptions {
+	return NATOptions{
+		AutoNATService: true,
+		PortMapping:    true,
+		HolePunching:   true,
+		RelayClient:    true,
+	}
+}
+
+// WithNAT configures NAT traversal of the host.
+func WithNAT(nat NATOptions) Option {
+	return func(opts *options) error {
+		switch nat.Reachability {
+		case "", ReachabilityPublic, ReachabilityPrivate:
+		default:
+			return fmt.Errorf("invalid reachability %q, must be %q or %q", nat.Reachability, ReachabilityPublic, ReachabilityPrivate)
+		}
+
+		staticRelays := make([]peer.AddrInfo, len(nat.StaticRelays))
+
+		for i, addr := range nat.StaticRelays {
+			relay, err := peer.AddrInfoFromString(addr)
+			if err != nil {
+				return fmt.Errorf("invalid static relay: %w", err)
+			}
+
+			staticRelays[i] = *relay
+		}
+
+		opts.NAT = &nat
+		opts.StaticRelays = staticRelays
+
+		return nil
+	}
+}
+
+// natOptions returns the host options of NAT traversal. Relay candidates are
+// taken from the static relays if set, and from the DHT of relays otherwise.
+func natOptions(nat NATOptions, staticRelays []peer.AddrInfo, relays *dhtRelaySource) []libp2p.Option {
+	var opts []libp2p.Option
+
+	if nat.AutoNATService {
+		// Help other peers detect whether they are behind a NAT by dialing them back.
+		// The AutoNAT client detecting our own reachability always runs.
+		// This service is highly rate-limited and should not cause any performance issues.
+		opts = append(opts, libp2p.EnableNATService())
+	}
+
+	if nat.PortMapping {
+		// Attempt to open ports using UPnP for NATed hosts
+		opts = append(opts, libp2p.NATPortMap())
+	}
+
+	if nat.HolePunching {
+		// When two NAT'd peers connect via relay, hole punching attempts to
+		// establish a direct connection through simultaneous dialing (DCUtR protocol).
+		// Falls back to the relayed connection if hole punching fails.
+		opts = append(opts, libp2p.EnableHolePunching())
+	}
+
+	if nat.RelayClient {
+		if len(staticRelays) > 0 {
+			opts = append(opts, libp2p.EnableAutoRelayWithStaticRelays(staticRelays))
+		} else {
+			opts = append(opts, libp2p.EnableAutoRelayWithPeerSource(relays.candidates))
+		}
+	}
+
+	if nat.RelayService {
+		opts = append(opts, libp2p.EnableRelayService())
+	}
+
+	switch nat.Reachability {
+	case ReachabilityPublic:
+		opts = append(opts, libp2p.ForceReachabilityPublic())
+	case ReachabilityPrivate:
+		opts = append(opts, libp2p.ForceReachabilityPrivate())
+	}
+
+	return opts
+}
+
+// dhtRelaySource provides the peers of the DHT routing table as relay candidates,
+// as they are likely public and well-connected. The DHT is created after the host,
+// so candidates are only provided once it is set.
+type dhtRelaySource struct {
+	dht atomic.Pointer[dht.IpfsDHT]
+}
+
+// setDHT sets the DHT whose routing table relay candidates are taken from.
+func (s *dhtRelaySource) setDHT(kdht *dht.IpfsDHT) {
+	s.dht.Store(kdht)
+}
+
+// candidates implements autorelay.PeerSource.
+func (s *dhtRelaySource) candidates(ctx context.Context, numPeers int) <-chan peer.AddrInfo {
+	peerChan := make(chan peer.AddrInfo)
+
+	go func() {
+		defer close(peerChan)
+
+		kdht := s.dht.Load()
+		if kdht == nil {
+			return
+		}
+
+		count := 0
+
+		for _, p := range kdht.RoutingTable().ListPeers() {
+			if count >= numPeers {
+				break
+			}
+
+			addrs := kdht.Host().Peerstore().Addrs(p)
+			if len(addrs) == 0 {
+				continue
+			}
+
+			select {
+			case peerChan <- peer.AddrInfo{ID: p, Addrs: addrs}:
+				count++
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		logger.Debug("Provided relay candidates from DHT", "requested", numPeers, "provided", count)
+	}()
+
+	return peerChan
+}
@@ -4,19 +4,18 @@
 package p2p
 
 import (
-	"crypto/ed25519"
 	"crypto/rand"
-	"errors"
 	"fmt"
 	"os"
 	"time"
 
+	"github.com/agntcy/dir/server/routing/internal/signing"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p-kad-dht/providers"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
-	"golang.org/x/crypto/ssh"
+	"github.com/libp2p/go-libp2p/core/pnet"
 )
 
 type APIRegistrer func(host.Host) error
@@ -25,12 +24,22 @@ type options struct {
 	Key                 crypto.PrivKey
 	ListenAddress       string
 	DirectoryAPIAddress string
+	Zone                string
 	BootstrapPeers      []peer.AddrInfo
 	RefreshInterval     time.Duration
 	Randevous           string
+	MDNSServiceName     string
 	APIRegistrer        APIRegistrer
 	ProviderStore       providers.ProviderStore
 	DHTCustomOpts       func(host.Host) ([]dht.Option, error)
+	DHTMode             *dht.ModeOpt // nil serves the DHT only without bootstrap peers
+	AllowedPeers        []peer.ID
+	DeniedPeers         []peer.ID
+	PrivateNetworkKey   pnet.PSK
+	NAT                 *NATOptions // nil uses DefaultNATOptions
+	StaticRelays        []peer.AddrInfo
+	ResourceLimits      *ResourceLimits // nil scales the limits to the system
+	Standby             bool
 }
 
 type Option func(*options) error
@@ -43,6 +52,17 @@ func WithRandevous(randevous string) Option {
 	}
 }
 
+// WithMDNS enables mDNS discovery of peers on the local network, advertising and
+// browsing the given service name. Peers only discover each other with the same
+// service name. If empty, mDNS discovery is disabled.
+func WithMDNS(serviceName string) Option {
+	return func(opts *options) error {
+		opts.MDNSServiceName = serviceName
+
+		return nil
+	}
+}
+
 func WithIdentityKey(key crypto.PrivKey) Option {
 	return func(opts *options) error {
 		opts.Key = key
@@ -58,32 +78,13 @@ func WithIdentityKeyPath(keyPath string) Option {
 			return nil
 		}
 
-		// Read data
-		keyBytes, err := os.ReadFile(keyPath)
+		key, err := signing.LoadKey(keyPath)
 		if err != nil {
-			return fmt.Errorf("failed to read key: %w", err)
-		}
-
-		// Parse the private key
-		key, err := ssh.ParseRawPrivateKey(keyBytes)
-		if err != nil {
-			return fmt.Errorf("failed to parse private key: %w", err)
-		}
-
-		// Try to convert to ED25519 private key
-		ed25519Key, ok := key.(ed25519.PrivateKey)
-		if !ok {
-			return errors.New("key is not an ED25519 private key")
-		}
-
-		// Generate random key
-		generatedKey, err := crypto.UnmarshalEd25519PrivateKey(ed25519Key)
-		if err != nil {
-			return fmt.Errorf("failed to unmarshal identity key: %w", err)
+			return err //nolint:wrapcheck
 		}
 
 		// set key
-		opts.Key = generatedKey
+		opts.Key = key
 
 		return nil
 	}
@@ -105,6 +106,15 @@ func WithDirectoryAPIAddress(addr string) Option {
 	}
 }
 
+// WithZone advertises the locality zone of the host with its addresses.
+func WithZone(zone string) Option {
+	return func(opts *options) error {
+		opts.Zone = zone
+
+		return nil
+	}
+}
+
 func WithBootstrapAddrs(addrs []string) Option {
 	return func(opts *options) error {
 		peerInfos := make([]peer.AddrInfo, len(addrs))
@@ -159,6 +169,106 @@ func WithCustomDHTOpts(dhtOptFactory func(host.Host) ([]dht.Option, error)) Opti
 	}
 }
 
+// WithDHTMode sets the DHT mode, DHTModeClient, DHTModeServer or DHTModeAuto.
+// It overrides the mode set by WithCustomDHTOpts, and hosts without bootstrap peers
+// serving the DHT. If empty, the mode is left unchanged.
+func WithDHTMode(mode string) Option {
+	return func(opts *options) error {
+		if mode == "" {
+			return nil
+		}
+
+		dhtMode, err := parseDHTMode(mode)
+		if err != nil {
+			return err
+		}
+
+		opts.DHTMode = &dhtMode
+
+		return nil
+	}
+}
+
+// WithStandby starts the host in standby: it listens, but neither connects to peers nor
+// accepts their connections until it joins the network, see Server.Join.
+func WithStandby(standby bool) Option {
+	return func(opts *options) error {
+		opts.Standby = standby
+
+		return nil
+	}
+}
+
+// WithAllowedPeers only allows connections with the given peers and the bootstrap peers.
+// If empty, all peers that are not denied are allowed.
+func WithAllowedPeers(peerIDs []string) Option {
+	return func(opts *options) error {
+		ids, err := decodePeerIDs(peerIDs)
+		if err != nil {
+			return fmt.Errorf("invalid allowed peer: %w", err)
+		}
+
+		opts.AllowedPeers = ids
+
+		return nil
+	}
+}
+
+// WithDeniedPeers refuses connections with the given peers.
+func WithDeniedPeers(peerIDs []string) Option {
+	return func(opts *options) error {
+		ids, err := decodePeerIDs(peerIDs)
+		if err != nil {
+			return fmt.Errorf("invalid denied peer: %w", err)
+		}
+
+		opts.DeniedPeers = ids
+
+		return nil
+	}
+}
+
+// WithPrivateNetworkKeyPath joins the private network of the pre-shared key at keyPath,
+// in the swarm.key format (/key/swarm/psk/1.0.0/). Only peers with the same key can connect.
+func WithPrivateNetworkKeyPath(keyPath string) Option {
+	return func(opts *options) error {
+		// If path is not set, skip
+		if keyPath == "" {
+			return nil
+		}
+
+		keyFile, err := os.Open(keyPath)
+		if err != nil {
+			return fmt.Errorf("failed to open private network key: %w", err)
+		}
+		defer keyFile.Close()
+
+		psk, err := pnet.DecodeV1PSK(keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to decode private network key: %w", err)
+		}
+
+		opts.PrivateNetworkKey = psk
+
+		return nil
+	}
+}
+
+func decodePeerIDs(peerIDs []string) ([]peer.ID, error) {
+	ids := make([]peer.ID, 0, len(peerIDs))
+
+	for _, peerID := range peerIDs {
+		id, err := peer.Decode(peerID)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", peerID, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 func withRandomIdentity() Option {
 	return func(opts *options) error {
 		// Do not generate random identity if we already have the key
@@ -0,0 +1,81 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package p2p
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	dht "github.com/libp2p/go-libp2p-kad-dht"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPrivateNetworkKeyPath(t *testing.T) {
+	opts := &options{}
+	require.NoError(t, WithPrivateNetworkKeyPath("")(opts))
+	assert.Nil(t, opts.PrivateNetworkKey)
+
+	keyPath := filepath.Join(t.TempDir(), "swarm.key")
+	key := "/key/swarm/psk/1.0.0/\n/base16/\na5c1d5b6ac7e1568a0deaf77b7d2252d34158a5d4e624354d9cea5a1c1e7e31b\n"
+	require.NoError(t, os.WriteFile(keyPath, []byte(key), 0o600))
+
+	require.NoError(t, WithPrivateNetworkKeyPath(keyPath)(opts))
+	assert.Len(t, opts.PrivateNetworkKey, 32)
+
+	invalidPath := filepath.Join(t.TempDir(), "invalid.key")
+	require.NoError(t, os.WriteFile(invalidPath, []byte("not a key"), 0o600))
+	require.Error(t, WithPrivateNetworkKeyPath(invalidPath)(opts))
+	require.Error(t, WithPrivateNetworkKeyPath(filepath.Join(t.TempDir(), "missing.key"))(opts))
+}
+
+func TestWithNAT(t *testing.T) {
+	opts := &options{}
+	require.NoError(t, WithNAT(NATOptions{
+		RelayClient:  true,
+		StaticRelays: []string{"/ip4/1.2.3.4/tcp/8999/p2p/12D3KooWQYhTNQdmr3ArTeUHRYzFg94BKyTkoWBDWez9kSCVe2Xo"},
+		Reachability: ReachabilityPrivate,
+	})(opts))
+	require.NotNil(t, opts.NAT)
+	assert.Equal(t, ReachabilityPrivate, opts.NAT.Reachability)
+	require.Len(t, opts.StaticRelays, 1)
+	assert.Equal(t, "12D3KooWQYhTNQdmr3ArTeUHRYzFg94BKyTkoWBDWez9kSCVe2Xo", opts.StaticRelays[0].ID.String())
+
+	require.Error(t, WithNAT(NATOptions{StaticRelays: []string{"/ip4/1.2.3.4/tcp/8999"}})(opts))
+	require.Error(t, WithNAT(NATOptions{Reachability: "unknown"})(opts))
+
+	// All NAT traversal is enabled by default, except for relaying for other peers
+	assert.Len(t, natOptions(DefaultNATOptions(), nil, &dhtRelaySource{}), 4)
+	assert.Empty(t, natOptions(NATOptions{}, nil, &dhtRelaySource{}))
+}
+
+func TestWithDHTMode(t *testing.T) {
+	opts := &options{}
+	require.NoError(t, WithDHTMode("")(opts))
+	assert.Nil(t, opts.DHTMode)
+
+	require.NoError(t, WithDHTMode(DHTModeClient)(opts))
+	require.NotNil(t, opts.DHTMode)
+	assert.Equal(t, dht.ModeClient, *opts.DHTMode)
+
+	require.Error(t, WithDHTMode("hub")(opts))
+}
+
+func TestWithResourceLimits(t *testing.T) {
+	opts := &options{}
+	require.NoError(t, WithResourceLimits(ResourceLimits{MaxConnections: 300, MaxMemory: 256 << 20})(opts))
+	require.NotNil(t, opts.ResourceLimits)
+
+	require.Error(t, WithResourceLimits(ResourceLimits{MaxStreams: -1})(opts))
+
+	// Configured limits override the system scope, others keep the scaled defaults
+	scaled := resourceLimitConfig(ResourceLimits{}).ToPartialLimitConfig().System
+	limited := resourceLimitConfig(*opts.ResourceLimits).ToPartialLimitConfig().System
+	assert.Equal(t, rcmgr.LimitVal(300), limited.Conns)
+	assert.Equal(t, rcmgr.LimitVal64(256<<20), limited.Memory)
+	assert.Equal(t, scaled.Streams, limited.Streams)
+	assert.Equal(t, scaled.FD, limited.FD)
+}
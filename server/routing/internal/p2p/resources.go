@@ -0,0 +1,71 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package p2p
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+)
+
+// ResourceLimits are the system-wide limits of the libp2p resource manager.
+// Zero limits keep the defaults, scaled to 1/8 of the system memory and half
+// of the file descriptors of the process.
+type ResourceLimits struct {
+	// MaxMemory is the memory in bytes reserved by connections and streams.
+	MaxMemory int64
+
+	// MaxFileDescriptors is the number of file descriptors used by connections.
+	MaxFileDescriptors int
+
+	// MaxConnections is the number of open connections, inbound and outbound.
+	MaxConnections int
+
+	// MaxStreams is the number of open streams, inbound and outbound.
+	MaxStreams int
+}
+
+// WithResourceLimits bounds the resources used by the host.
+func WithResourceLimits(limits ResourceLimits) Option {
+	return func(opts *options) error {
+		if limits.MaxMemory < 0 || limits.MaxFileDescriptors < 0 || limits.MaxConnections < 0 || limits.MaxStreams < 0 {
+			return errors.New("resource limits must not be negative")
+		}
+
+		opts.ResourceLimits = &limits
+
+		return nil
+	}
+}
+
+// resourceLimitConfig returns the limits of the resource manager: the default limits
+// scaled to the system, with the configured limits of the system scope.
+func resourceLimitConfig(limits ResourceLimits) rcmgr.ConcreteLimitConfig {
+	scaling := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&scaling)
+
+	// Zero limits are rcmgr.DefaultLimit, keeping the scaled ones
+	system := rcmgr.PartialLimitConfig{
+		System: rcmgr.ResourceLimits{
+			Memory:  rcmgr.LimitVal64(limits.MaxMemory),
+			FD:      rcmgr.LimitVal(limits.MaxFileDescriptors),
+			Conns:   rcmgr.LimitVal(limits.MaxConnections),
+			Streams: rcmgr.LimitVal(limits.MaxStreams),
+		},
+	}
+
+	return system.Build(scaling.AutoScale())
+}
+
+// resourceManagerOption returns the host option of a resource manager enforcing the limits.
+func resourceManagerOption(limits ResourceLimits) (libp2p.Option, error) {
+	mgr, err := rcmgr.NewResourceManager(rcmgr.NewFixedLimiter(resourceLimitConfig(limits)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create p2p host resource manager: %w", err)
+	}
+
+	return libp2p.ResourceManager(mgr), nil
+}
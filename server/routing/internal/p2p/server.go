@@ -6,24 +6,27 @@ package p2p
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/agntcy/dir/utils/logging"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
 	discovery "github.com/libp2p/go-libp2p/p2p/discovery/routing"
-	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
 )
 
 var logger = logging.Logger("p2p")
 
 type Server struct {
-	opts    *options
-	host    host.Host
-	dht     *dht.IpfsDHT
-	closeFn func()
+	opts         *options
+	host         host.Host
+	dht          *dht.IpfsDHT
+	bootstrapped <-chan struct{}
+	join         func()
+	closeFn      func()
 }
 
 // New constructs a new p2p server.
@@ -40,16 +43,19 @@ func New(ctx context.Context, opts ...Option) (*Server, error) {
 
 	// Start in the background.
 	// Wait for ready status message before returning.
+	// Bootstrap continues in the background, see Bootstrapped.
 	status := <-start(ctx, options)
 	if status.Err != nil {
 		return nil, fmt.Errorf("failed while starting services: %w", status.Err)
 	}
 
 	server := &Server{
-		opts:    options,
-		host:    status.Host,
-		dht:     status.DHT,
-		closeFn: status.Close,
+		opts:         options,
+		host:         status.Host,
+		dht:          status.DHT,
+		bootstrapped: status.Bootstrapped,
+		join:         status.Join,
+		closeFn:      status.Close,
 	}
 
 	logger.Debug("P2P server created", "host", server.host.ID(), "addresses", server.P2pAddrs())
@@ -95,6 +101,20 @@ func (s *Server) DHT() *dht.IpfsDHT {
 	return s.dht
 }
 
+// Bootstrapped is closed once the bootstrap peers were dialled and the DHT routing table
+// was refreshed for the first time. Until then, the routing table may be empty.
+// Bootstrap completes even if no bootstrap peer could be reached.
+func (s *Server) Bootstrapped() <-chan struct{} {
+	return s.bootstrapped
+}
+
+// Join releases a host started in standby, see WithStandby: it connects to the bootstrap peers,
+// advertises itself and accepts connections. Bootstrapped is closed once the host joined the
+// network. Join does nothing for hosts that are not in standby, or already joined.
+func (s *Server) Join() {
+	s.join()
+}
+
 func (s *Server) Key() crypto.PrivKey {
 	return s.host.Peerstore().PrivKey(s.host.ID())
 }
@@ -105,10 +125,12 @@ func (s *Server) Close() {
 }
 
 type status struct {
-	Err   error
-	Host  host.Host
-	DHT   *dht.IpfsDHT
-	Close func()
+	Err          error
+	Host         host.Host
+	DHT          *dht.IpfsDHT
+	Bootstrapped <-chan struct{}
+	Join         func()
+	Close        func()
 }
 
 // start starts all routing related services.
@@ -124,7 +146,30 @@ func start(ctx context.Context, opts *options) <-chan status {
 		defer cancel()
 
 		// Create host
-		host, err := newHost(opts.ListenAddress, opts.DirectoryAPIAddress, opts.Key)
+		gater := newPeerGater(opts.AllowedPeers, opts.DeniedPeers, opts.BootstrapPeers)
+		gater.standby.Store(opts.Standby)
+
+		nat := DefaultNATOptions()
+		if opts.NAT != nil {
+			nat = *opts.NAT
+		}
+
+		relays := &dhtRelaySource{}
+
+		hostOpts := natOptions(nat, opts.StaticRelays, relays)
+
+		if opts.ResourceLimits != nil {
+			rcmgrOpt, err := resourceManagerOption(*opts.ResourceLimits)
+			if err != nil {
+				statusCh <- status{Err: err}
+
+				return
+			}
+
+			hostOpts = append(hostOpts, rcmgrOpt)
+		}
+
+		host, err := newHost(opts.ListenAddress, opts.DirectoryAPIAddress, opts.Zone, opts.Key, gater, opts.PrivateNetworkKey, hostOpts)
 		if err != nil {
 			statusCh <- status{Err: err}
 
@@ -135,8 +180,13 @@ func start(ctx context.Context, opts *options) <-chan status {
 
 		logger.Debug("Host created", "id", host.ID(), "addresses", host.Addrs())
 
-		// Enable mDNS for local network peer discovery
-		setupMDNS(host)
+		// Enable mDNS for local network peer discovery.
+		// Hosts in standby do not announce themselves on the local network until they join.
+		if opts.MDNSServiceName != "" && !opts.Standby {
+			if mdnsService := setupMDNS(ctx, host, opts.MDNSServiceName); mdnsService != nil {
+				defer mdnsService.Close()
+			}
+		}
 
 		// Create DHT
 		var customDhtOpts []dht.Option
@@ -149,7 +199,7 @@ func start(ctx context.Context, opts *options) <-chan status {
 			}
 		}
 
-		kdht, err := newDHT(ctx, host, opts.BootstrapPeers, opts.RefreshInterval, customDhtOpts...)
+		kdht, err := newDHT(ctx, host, opts.BootstrapPeers, opts.RefreshInterval, opts.DHTMode, customDhtOpts...)
 		if err != nil {
 			statusCh <- status{Err: err}
 
@@ -157,12 +207,9 @@ func start(ctx context.Context, opts *options) <-chan status {
 		}
 		defer kdht.Close()
 
-		// Enable AutoRelay with DHT as peer source for finding relay candidates.
 		// AutoRelay makes NAT'd peers reachable by establishing relay circuits.
-		// The DHT routing table is queried to find potential relay peers.
-		if err := setupAutoRelay(host, kdht); err != nil {
-			logger.Warn("Failed to setup AutoRelay", "error", err)
-		}
+		// Unless static relays are configured, relay candidates are the peers of the DHT routing table.
+		relays.setDHT(kdht)
 
 		// Advertise to rendezvous for initial peer discovery.
 		// Peer discovery is now handled automatically by:
@@ -173,18 +220,8 @@ func start(ctx context.Context, opts *options) <-chan status {
 		//
 		// The custom discover() polling loop has been removed as it was redundant
 		// with DHT's built-in peer discovery and caused excessive polling (60/min).
-		if opts.Randevous != "" {
-			routingDiscovery := discovery.NewRoutingDiscovery(kdht)
-
-			_, err := routingDiscovery.Advertise(ctx, opts.Randevous)
-			if err != nil {
-				logger.Warn("Failed to advertise to rendezvous",
-					"rendezvous", opts.Randevous,
-					"error", err)
-			} else {
-				logger.Info("Advertised to rendezvous (discovery handled by DHT)",
-					"rendezvous", opts.Randevous)
-			}
+		if opts.Randevous != "" && !opts.Standby {
+			advertise(ctx, kdht, opts.Randevous)
 		}
 		// Register services. Only available on non-bootstrap nodes.
 		if opts.APIRegistrer != nil && len(opts.BootstrapPeers) > 0 {
@@ -199,19 +236,17 @@ func start(ctx context.Context, opts *options) <-chan status {
 		// Run until context expiry
 		logger.Debug("Host and DHT created, running routing services", "host", host.ID(), "addresses", host.Addrs())
 
-		for _, peer := range opts.BootstrapPeers {
-			for _, addr := range peer.Addrs {
-				host.Peerstore().AddAddr(peer.ID, addr, 0)
-			}
-		}
+		// At this point, the host serves requests.
+		// Notify listener that we are ready, before the network is reachable.
+		bootstrapped := make(chan struct{})
+		joined := make(chan struct{})
+		join := sync.OnceFunc(func() { close(joined) })
 
-		<-kdht.RefreshRoutingTable()
-
-		// At this point, we are done.
-		// Notify listener that we are ready.
 		statusCh <- status{
-			Host: host,
-			DHT:  kdht,
+			Host:         host,
+			DHT:          kdht,
+			Bootstrapped: bootstrapped,
+			Join:         join,
 			Close: func() {
 				cancel()
 				host.Close()
@@ -219,76 +254,89 @@ func start(ctx context.Context, opts *options) <-chan status {
 			},
 		}
 
-		// Wait for context to close
-		<-ctx.Done()
-	}()
+		// Hosts in standby wait to be released before joining the network
+		if opts.Standby {
+			logger.Info("Host is in standby, waiting to join the network", "host", host.ID())
 
-	return statusCh
-}
+			select {
+			case <-joined:
+			case <-ctx.Done():
+				return
+			}
 
-// setupAutoRelay enables AutoRelay with DHT as the peer source for finding relay candidates.
-// AutoRelay provides guaranteed connectivity for NAT'd peers by establishing relay circuits.
-// The DHT routing table is used to discover potential relay peers (public nodes).
-func setupAutoRelay(h host.Host, kdht *dht.IpfsDHT) error {
-	// Create a peer source function that queries DHT for relay candidates
-	peerSource := func(ctx context.Context, numPeers int) <-chan peer.AddrInfo {
-		peerChan := make(chan peer.AddrInfo)
-
-		go func() {
-			defer close(peerChan)
-
-			// Get peers from DHT routing table
-			// These are likely good relay candidates (public, well-connected)
-			routingTable := kdht.RoutingTable()
-			peers := routingTable.ListPeers()
-
-			// Send up to numPeers candidates
-			count := 0
-			for _, p := range peers {
-				if count >= numPeers {
-					break
-				}
+			gater.standby.Store(false)
 
-				// Get peer's address info from peerstore
-				addrs := h.Peerstore().Addrs(p)
-				if len(addrs) > 0 {
-					select {
-					case peerChan <- peer.AddrInfo{ID: p, Addrs: addrs}:
-						count++
-					case <-ctx.Done():
-						return
-					}
+			if opts.MDNSServiceName != "" {
+				if mdnsService := setupMDNS(ctx, host, opts.MDNSServiceName); mdnsService != nil {
+					defer mdnsService.Close()
 				}
 			}
 
-			logger.Debug("Provided relay candidates from DHT",
-				"requested", numPeers,
-				"provided", count)
-		}()
+			if opts.Randevous != "" {
+				advertise(ctx, kdht, opts.Randevous)
+			}
 
-		return peerChan
-	}
+			logger.Info("Host joined the network", "host", host.ID())
+		}
 
-	// Enable AutoRelay with DHT-based peer source
-	_, err := autorelay.NewAutoRelay(h, autorelay.WithPeerSource(peerSource))
-	if err != nil {
-		return fmt.Errorf("failed to enable AutoRelay: %w", err)
-	}
+		// Join the network in the background, so that local functionality
+		// is available while bootstrap peers are slow or unreachable
+		connectBootstrapPeers(ctx, host, opts.BootstrapPeers)
 
-	logger.Info("AutoRelay enabled with DHT peer source")
+		for _, peer := range opts.BootstrapPeers {
+			for _, addr := range peer.Addrs {
+				host.Peerstore().AddAddr(peer.ID, addr, 0)
+			}
+		}
 
-	return nil
+		select {
+		case <-kdht.RefreshRoutingTable():
+			logger.Info("DHT bootstrap completed", "peers", kdht.RoutingTable().Size())
+		case <-ctx.Done():
+		}
+
+		close(bootstrapped)
+
+		// Wait for context to close
+		<-ctx.Done()
+	}()
+
+	return statusCh
+}
+
+// advertise advertises the host to the rendezvous namespace.
+func advertise(ctx context.Context, kdht *dht.IpfsDHT, rendezvous string) {
+	routingDiscovery := discovery.NewRoutingDiscovery(kdht)
+
+	_, err := routingDiscovery.Advertise(ctx, rendezvous)
+	if err != nil {
+		logger.Warn("Failed to advertise to rendezvous",
+			"rendezvous", rendezvous,
+			"error", err)
+	} else {
+		logger.Info("Advertised to rendezvous (discovery handled by DHT)",
+			"rendezvous", rendezvous)
+	}
 }
 
 // mdnsNotifee handles mDNS peer discovery events.
 type mdnsNotifee struct {
+	ctx  context.Context //nolint:containedctx // Bounds connections to discovered peers to the server's lifetime
 	host host.Host
 }
 
 // HandlePeerFound is called when mDNS discovers a peer on the local network.
 func (n *mdnsNotifee) HandlePeerFound(pi peer.AddrInfo) {
+	// Skip peers we are already connected to, mDNS announces peers periodically
+	if n.host.Network().Connectedness(pi.ID) == network.Connected {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(n.ctx, MDNSConnectTimeout)
+	defer cancel()
+
 	// Connect to discovered local peer
-	if err := n.host.Connect(context.Background(), pi); err != nil {
+	if err := n.host.Connect(ctx, pi); err != nil {
 		logger.Debug("Failed to connect to mDNS discovered peer",
 			"peer", pi.ID,
 			"error", err)
@@ -304,18 +352,21 @@ func (n *mdnsNotifee) HandlePeerFound(pi peer.AddrInfo) {
 // setupMDNS enables mDNS discovery for local network peers.
 // Peers on the same LAN will discover each other in < 1 second without bootstrap nodes.
 // This is useful for development, testing, and enterprise LAN deployments.
-func setupMDNS(h host.Host) {
-	notifee := &mdnsNotifee{host: h}
+// It returns nil if mDNS could not be started, e.g. without multicast-capable interfaces.
+func setupMDNS(ctx context.Context, h host.Host, serviceName string) mdns.Service {
+	notifee := &mdnsNotifee{ctx: ctx, host: h}
 
-	service := mdns.NewMdnsService(h, MDNSServiceName, notifee)
+	service := mdns.NewMdnsService(h, serviceName, notifee)
 	if err := service.Start(); err != nil {
 		logger.Warn("Failed to start mDNS discovery",
-			"service", MDNSServiceName,
+			"service", serviceName,
 			"error", err)
 
-		return
+		return nil
 	}
 
 	logger.Info("mDNS local discovery enabled",
-		"service", MDNSServiceName)
+		"service", serviceName)
+
+	return service
 }
@@ -0,0 +1,110 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package signing signs and verifies routing protocol messages with
+// the Ed25519 libp2p identity key of a peer.
+package signing
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	cryptopb "github.com/libp2p/go-libp2p/core/crypto/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/crypto/ssh"
+)
+
+// LoadKey reads a PEM encoded Ed25519 private key from a file,
+// e.g. one generated with openssl genpkey -algorithm ed25519.
+func LoadKey(path string) (crypto.PrivKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+
+	key, err := ssh.ParseRawPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	ed25519Key, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("key is not an ED25519 private key")
+	}
+
+	privKey, err := crypto.UnmarshalEd25519PrivateKey(ed25519Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key: %w", err)
+	}
+
+	return privKey, nil
+}
+
+// Sign signs the payload with the given Ed25519 identity key.
+// Returns the marshalled public key together with the signature.
+func Sign(key crypto.PrivKey, payload []byte) ([]byte, []byte, error) {
+	if key == nil {
+		return nil, nil, errors.New("signing key is nil")
+	}
+
+	if key.Type() != cryptopb.KeyType_Ed25519 {
+		return nil, nil, fmt.Errorf("unsupported signing key type %s, expected Ed25519", key.Type())
+	}
+
+	publicKey, err := crypto.MarshalPublicKey(key.GetPublic())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	signature, err := key.Sign(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign payload: %w", err)
+	}
+
+	return publicKey, signature, nil
+}
+
+// Verify checks the signature of the payload against the marshalled Ed25519 public key.
+func Verify(publicKey, signature, payload []byte) error {
+	if len(publicKey) == 0 || len(signature) == 0 {
+		return errors.New("payload is not signed")
+	}
+
+	key, err := crypto.UnmarshalPublicKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %w", err)
+	}
+
+	if key.Type() != cryptopb.KeyType_Ed25519 {
+		return fmt.Errorf("unsupported public key type %s, expected Ed25519", key.Type())
+	}
+
+	valid, err := key.Verify(payload, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	if !valid {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+// SignerID returns the peer ID derived from the marshalled public key.
+func SignerID(publicKey []byte) (peer.ID, error) {
+	key, err := crypto.UnmarshalPublicKey(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid public key: %w", err)
+	}
+
+	id, err := peer.IDFromPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive peer ID: %w", err)
+	}
+
+	return id, nil
+}
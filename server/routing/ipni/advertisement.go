@@ -0,0 +1,232 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ipni publishes records to InterPlanetary Network Indexer (IPNI) nodes.
+//
+// Indexers do not query providers; providers publish a chain of signed advertisements,
+// each linking to the previous one, and announce its head to indexers, which fetch the
+// advertisements they did not see yet over HTTP. An advertisement adds or removes the
+// multihashes of its entries under a context ID, with metadata describing how to retrieve
+// them. The advertisement, entry chunk and signed head formats are those of the IPNI
+// specification, encoded as DAG-JSON.
+package ipni
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime/codec/dagjson"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/fluent/qp"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/multiformats/go-multicodec"
+	"github.com/multiformats/go-multihash"
+	"github.com/multiformats/go-varint"
+)
+
+// NoEntries is the entries link of advertisements without entries, e.g. removals.
+var NoEntries = cid.NewCidV1(cid.Raw, mustIdentity(nil))
+
+// Advertisement adds or removes the entries of a context ID of a provider.
+type Advertisement struct {
+	PreviousID cid.Cid  // Previous advertisement of the chain, cid.Undef for the first one
+	Provider   string   // Peer ID of the provider
+	Addresses  []string // Multiaddrs the provider serves the entries at
+	Signature  []byte   // Signed envelope of the advertisement, see Sign
+	Entries    cid.Cid  // Head of the entry chunk chain, NoEntries if none
+	ContextID  []byte
+	Metadata   []byte // Varint protocol code followed by protocol specific data
+	IsRm       bool   // Whether the entries of the context ID are removed
+}
+
+// EntryChunk is a chunk of the multihashes of an advertisement.
+type EntryChunk struct {
+	Entries []multihash.Multihash
+	Next    cid.Cid // Next chunk of the chain, cid.Undef for the last one
+}
+
+// Encode returns the DAG-JSON encoding of the advertisement and its CID.
+func (ad *Advertisement) Encode() ([]byte, cid.Cid, error) {
+	node, err := qp.BuildMap(basicnode.Prototype.Any, -1, func(ma datamodel.MapAssembler) {
+		if ad.PreviousID.Defined() {
+			qp.MapEntry(ma, "PreviousID", qp.Link(cidlink.Link{Cid: ad.PreviousID}))
+		}
+
+		qp.MapEntry(ma, "Provider", qp.String(ad.Provider))
+		qp.MapEntry(ma, "Addresses", qp.List(int64(len(ad.Addresses)), func(la datamodel.ListAssembler) {
+			for _, addr := range ad.Addresses {
+				qp.ListEntry(la, qp.String(addr))
+			}
+		}))
+		qp.MapEntry(ma, "Signature", qp.Bytes(ad.Signature))
+		qp.MapEntry(ma, "Entries", qp.Link(cidlink.Link{Cid: ad.Entries}))
+		qp.MapEntry(ma, "ContextID", qp.Bytes(ad.ContextID))
+		qp.MapEntry(ma, "Metadata", qp.Bytes(ad.Metadata))
+		qp.MapEntry(ma, "IsRm", qp.Bool(ad.IsRm))
+	})
+	if err != nil {
+		return nil, cid.Undef, fmt.Errorf("failed to build advertisement: %w", err)
+	}
+
+	return encode(node)
+}
+
+// DecodeAdvertisement decodes a DAG-JSON encoded advertisement.
+func DecodeAdvertisement(data []byte) (*Advertisement, error) {
+	node, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ad := &Advertisement{}
+
+	if ad.PreviousID, err = optionalLink(node, "PreviousID"); err != nil {
+		return nil, err
+	}
+
+	if ad.Provider, err = field(node, "Provider").AsString(); err != nil {
+		return nil, fmt.Errorf("invalid provider: %w", err)
+	}
+
+	addrs := field(node, "Addresses").ListIterator()
+	for addrs != nil && !addrs.Done() {
+		_, addr, err := addrs.Next()
+		if err != nil {
+			return nil, fmt.Errorf("invalid addresses: %w", err)
+		}
+
+		value, err := addr.AsString()
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %w", err)
+		}
+
+		ad.Addresses = append(ad.Addresses, value)
+	}
+
+	if ad.Signature, err = field(node, "Signature").AsBytes(); err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+
+	if ad.Entries, err = link(field(node, "Entries")); err != nil {
+		return nil, fmt.Errorf("invalid entries: %w", err)
+	}
+
+	if ad.ContextID, err = field(node, "ContextID").AsBytes(); err != nil {
+		return nil, fmt.Errorf("invalid context ID: %w", err)
+	}
+
+	if ad.Metadata, err = field(node, "Metadata").AsBytes(); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %w", err)
+	}
+
+	if ad.IsRm, err = field(node, "IsRm").AsBool(); err != nil {
+		return nil, fmt.Errorf("invalid removal flag: %w", err)
+	}
+
+	return ad, nil
+}
+
+// Encode returns the DAG-JSON encoding of the entry chunk and its CID.
+func (c *EntryChunk) Encode() ([]byte, cid.Cid, error) {
+	node, err := qp.BuildMap(basicnode.Prototype.Any, -1, func(ma datamodel.MapAssembler) {
+		qp.MapEntry(ma, "Entries", qp.List(int64(len(c.Entries)), func(la datamodel.ListAssembler) {
+			for _, entry := range c.Entries {
+				qp.ListEntry(la, qp.Bytes(entry))
+			}
+		}))
+
+		if c.Next.Defined() {
+			qp.MapEntry(ma, "Next", qp.Link(cidlink.Link{Cid: c.Next}))
+		}
+	})
+	if err != nil {
+		return nil, cid.Undef, fmt.Errorf("failed to build entry chunk: %w", err)
+	}
+
+	return encode(node)
+}
+
+// Metadata returns advertisement metadata of the given protocol.
+func Metadata(protocol multicodec.Code, data []byte) []byte {
+	return append(varint.ToUvarint(uint64(protocol)), data...)
+}
+
+// encode encodes the node as DAG-JSON and returns its CID.
+func encode(node datamodel.Node) ([]byte, cid.Cid, error) {
+	var buf bytes.Buffer
+	if err := dagjson.Encode(node, &buf); err != nil {
+		return nil, cid.Undef, fmt.Errorf("failed to encode: %w", err)
+	}
+
+	hash, err := multihash.Sum(buf.Bytes(), multihash.SHA2_256, -1)
+	if err != nil {
+		return nil, cid.Undef, fmt.Errorf("failed to hash: %w", err)
+	}
+
+	return buf.Bytes(), cid.NewCidV1(uint64(multicodec.DagJson), hash), nil
+}
+
+// decode decodes a DAG-JSON encoded map.
+func decode(data []byte) (datamodel.Node, error) {
+	builder := basicnode.Prototype.Any.NewBuilder()
+	if err := dagjson.Decode(builder, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to decode: %w", err)
+	}
+
+	node := builder.Build()
+	if node.Kind() != datamodel.Kind_Map {
+		return nil, errors.New("failed to decode: not a map")
+	}
+
+	return node, nil
+}
+
+// field returns the value of a map field, or an absent node if it is missing.
+func field(node datamodel.Node, name string) datamodel.Node {
+	value, err := node.LookupByString(name)
+	if err != nil {
+		return datamodel.Absent
+	}
+
+	return value
+}
+
+func link(node datamodel.Node) (cid.Cid, error) {
+	value, err := node.AsLink()
+	if err != nil {
+		return cid.Undef, err //nolint:wrapcheck
+	}
+
+	cl, ok := value.(cidlink.Link)
+	if !ok {
+		return cid.Undef, errors.New("not a CID link")
+	}
+
+	return cl.Cid, nil
+}
+
+func optionalLink(node datamodel.Node, name string) (cid.Cid, error) {
+	value := field(node, name)
+	if value.IsAbsent() || value.IsNull() {
+		return cid.Undef, nil
+	}
+
+	c, err := link(value)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return c, nil
+}
+
+func mustIdentity(data []byte) multihash.Multihash {
+	hash, err := multihash.Sum(data, multihash.IDENTITY, -1)
+	if err != nil {
+		panic(err)
+	}
+
+	return hash
+}
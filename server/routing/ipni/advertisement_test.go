@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ipni
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvertisement(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	provider, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	entry, err := multihash.Sum([]byte("record"), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+
+	_, entries, err := (&EntryChunk{Entries: []multihash.Multihash{entry}}).Encode()
+	require.NoError(t, err)
+
+	ad := &Advertisement{
+		Provider:  provider.String(),
+		Addresses: []string{"/ip4/127.0.0.1/tcp/8999"},
+		Entries:   entries,
+		ContextID: []byte("context"),
+		Metadata:  Metadata(0x300000, []byte(`{}`)),
+	}
+	require.NoError(t, ad.Sign(key))
+
+	data, adCID, err := ad.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0x0129), adCID.Prefix().Codec) // DAG-JSON
+
+	// Advertisements without a previous advertisement omit the link
+	assert.NotContains(t, string(data), "PreviousID")
+
+	decoded, err := DecodeAdvertisement(data)
+	require.NoError(t, err)
+	assert.Equal(t, ad, decoded)
+
+	signer, err := decoded.Verify()
+	require.NoError(t, err)
+	assert.Equal(t, provider, signer)
+
+	// Changing a signed field invalidates the signature
+	decoded.IsRm = true
+	_, err = decoded.Verify()
+	require.Error(t, err)
+
+	// Linked advertisements round-trip their previous advertisement
+	next := &Advertisement{PreviousID: adCID, Provider: provider.String(), Entries: NoEntries, ContextID: []byte("context"), Metadata: []byte{}, IsRm: true}
+	require.NoError(t, next.Sign(key))
+
+	data, _, err = next.Encode()
+	require.NoError(t, err)
+
+	decoded, err = DecodeAdvertisement(data)
+	require.NoError(t, err)
+	assert.Equal(t, adCID, decoded.PreviousID)
+	assert.Equal(t, NoEntries, decoded.Entries)
+
+	_, err = DecodeAdvertisement([]byte(`[]`))
+	require.Error(t, err)
+}
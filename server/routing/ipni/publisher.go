@@ -0,0 +1,408 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ipni
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/utils/logging"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/multiformats/go-multihash"
+)
+
+const (
+	// Topic is the topic of the advertisement chain, that of the public indexers.
+	Topic = "/indexer/ingest/mainnet"
+
+	// Namespace is the datastore namespace of the advertisement chain.
+	Namespace = "ipni"
+
+	// MaxEntriesPerChunk is the maximum number of multihashes of an entry chunk.
+	MaxEntriesPerChunk = 16384
+
+	// AnnounceTimeout bounds the announcement of the chain head to a single indexer.
+	AnnounceTimeout = 10 * time.Second
+)
+
+var logger = logging.Logger("routing/ipni")
+
+var (
+	headKey = datastore.NewKey("/" + Namespace + "/head")
+
+	// ErrNotFound is returned for blocks that are not part of the advertisement chain.
+	ErrNotFound = errors.New("block not found")
+)
+
+// Options configures a publisher.
+type Options struct {
+	// Key is the identity key of the provider, which signs the advertisements.
+	Key crypto.PrivKey
+
+	// ProviderAddrs returns the multiaddrs the provider serves its records at.
+	ProviderAddrs func() []string
+
+	// PublisherAddr is the HTTP multiaddr indexers fetch the advertisements from,
+	// e.g. /dns4/dir.example.com/tcp/3104/http.
+	PublisherAddr ma.Multiaddr
+
+	// Indexers are the base URLs of the indexers the chain head is announced to.
+	Indexers []string
+}
+
+// Publisher publishes a chain of advertisements, stored in the datastore, and announces
+// its head to indexers. Advertisements are appended one at a time.
+type Publisher struct {
+	dstore datastore.Datastore
+	opts   Options
+	id     peer.ID
+	client *http.Client
+	mu     sync.Mutex // Serializes appending to the chain
+}
+
+// NewPublisher creates a publisher of the advertisement chain stored in dstore.
+func NewPublisher(dstore datastore.Datastore, opts Options) (*Publisher, error) {
+	id, err := peer.IDFromPrivateKey(opts.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid identity key: %w", err)
+	}
+
+	return &Publisher{
+		dstore: dstore,
+		opts:   opts,
+		id:     id,
+		client: &http.Client{Timeout: AnnounceTimeout},
+	}, nil
+}
+
+// Publish advertises the entries of the context ID with the metadata, replacing those
+// advertised before. Returns false if they were advertised already and nothing was published.
+func (p *Publisher) Publish(ctx context.Context, contextID []byte, entries []multihash.Multihash, metadata []byte) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	digest := contextDigest(entries, metadata)
+
+	previous, err := p.dstore.Get(ctx, contextKey(contextID))
+	if err != nil && !errors.Is(err, datastore.ErrNotFound) {
+		return false, fmt.Errorf("failed to read advertised context: %w", err)
+	}
+
+	if bytes.Equal(previous, digest) {
+		return false, nil
+	}
+
+	entriesLink, err := p.putEntries(ctx, entries)
+	if err != nil {
+		return false, err
+	}
+
+	ad := &Advertisement{Entries: entriesLink, ContextID: contextID, Metadata: metadata}
+	if err := p.append(ctx, ad); err != nil {
+		return false, err
+	}
+
+	if err := p.dstore.Put(ctx, contextKey(contextID), digest); err != nil {
+		return false, fmt.Errorf("failed to store advertised context: %w", err)
+	}
+
+	return true, nil
+}
+
+// Remove advertises the removal of the entries of the context ID.
+// Returns false if the context ID is not advertised.
+func (p *Publisher) Remove(ctx context.Context, contextID []byte) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	advertised, err := p.dstore.Has(ctx, contextKey(contextID))
+	if err != nil {
+		return false, fmt.Errorf("failed to read advertised context: %w", err)
+	}
+
+	if !advertised {
+		return false, nil
+	}
+
+	// Metadata is required, removals carry that of no protocol
+	ad := &Advertisement{Entries: NoEntries, ContextID: contextID, Metadata: []byte{}, IsRm: true}
+	if err := p.append(ctx, ad); err != nil {
+		return false, err
+	}
+
+	if err := p.dstore.Delete(ctx, contextKey(contextID)); err != nil {
+		return false, fmt.Errorf("failed to delete advertised context: %w", err)
+	}
+
+	return true, nil
+}
+
+// Head returns the CID of the last advertisement, cid.Undef if none was published.
+func (p *Publisher) Head(ctx context.Context) (cid.Cid, error) {
+	value, err := p.dstore.Get(ctx, headKey)
+	if errors.Is(err, datastore.ErrNotFound) {
+		return cid.Undef, nil
+	}
+
+	if err != nil {
+		return cid.Undef, fmt.Errorf("failed to read advertisement chain head: %w", err)
+	}
+
+	head, err := cid.Cast(value)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("invalid advertisement chain head: %w", err)
+	}
+
+	return head, nil
+}
+
+// Block returns an advertisement or entry chunk of the chain.
+func (p *Publisher) Block(ctx context.Context, c cid.Cid) ([]byte, error) {
+	data, err := p.dstore.Get(ctx, blockKey(c))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block %s: %w", c, err)
+	}
+
+	return data, nil
+}
+
+// Announce announces the chain head to the indexers, which fetch the advertisements they
+// did not see yet from the publisher address. Fails if no indexer accepted the announcement.
+func (p *Publisher) Announce(ctx context.Context) error {
+	if len(p.opts.Indexers) == 0 {
+		return nil
+	}
+
+	head, err := p.Head(ctx)
+	if err != nil || !head.Defined() {
+		return err
+	}
+
+	// Indexers learn the publisher from the /p2p component of its address
+	addr := p.opts.PublisherAddr.Encapsulate(ma.StringCast("/p2p/" + p.id.String()))
+
+	body, err := json.Marshal(announceMessage{Cid: head, Addrs: [][]byte{addr.Bytes()}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal announcement: %w", err)
+	}
+
+	var errs []error
+
+	for _, indexer := range p.opts.Indexers {
+		if err := p.announceTo(ctx, indexer, body); err != nil {
+			logger.Warn("Failed to announce advertisements to indexer", "indexer", indexer, "head", head, "error", err)
+
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) == len(p.opts.Indexers) {
+		return fmt.Errorf("no indexer accepted the announcement: %w", errors.Join(errs...))
+	}
+
+	logger.Debug("Announced advertisements to indexers", "head", head, "indexers", len(p.opts.Indexers)-len(errs))
+
+	return nil
+}
+
+// ServeHTTP serves the advertisement chain over HTTP, as the IPNI HTTP sync protocol:
+// GET /ipni/v1/ad/head returns the signed chain head, GET /ipni/v1/ad/<cid> a block.
+func (p *Publisher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	name, ok := strings.CutPrefix(r.URL.Path, HTTPPath)
+	if !ok || name == "" || strings.Contains(name, "/") {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	if name == "head" {
+		p.serveHead(w, r)
+
+		return
+	}
+
+	c, err := cid.Decode(name)
+	if err != nil {
+		http.Error(w, "invalid CID", http.StatusBadRequest)
+
+		return
+	}
+
+	data, err := p.Block(r.Context(), c)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	if err != nil {
+		logger.Warn("Failed to serve advertisement block", "cid", c, "error", err)
+		http.Error(w, "failed to read block", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+func (p *Publisher) serveHead(w http.ResponseWriter, r *http.Request) {
+	head, err := p.Head(r.Context())
+	if err != nil {
+		logger.Warn("Failed to serve advertisement chain head", "error", err)
+		http.Error(w, "failed to read head", http.StatusInternalServerError)
+
+		return
+	}
+
+	if !head.Defined() {
+		w.WriteHeader(http.StatusNoContent)
+
+		return
+	}
+
+	data, err := encodeSignedHead(head, Topic, p.opts.Key)
+	if err != nil {
+		logger.Warn("Failed to sign advertisement chain head", "error", err)
+		http.Error(w, "failed to sign head", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// append signs the advertisement, links it to the chain head and makes it the new head.
+func (p *Publisher) append(ctx context.Context, ad *Advertisement) error {
+	head, err := p.Head(ctx)
+	if err != nil {
+		return err
+	}
+
+	ad.PreviousID = head
+	ad.Provider = p.id.String()
+	ad.Addresses = p.opts.ProviderAddrs()
+
+	if err := ad.Sign(p.opts.Key); err != nil {
+		return err
+	}
+
+	data, adCID, err := ad.Encode()
+	if err != nil {
+		return err
+	}
+
+	if err := p.dstore.Put(ctx, blockKey(adCID), data); err != nil {
+		return fmt.Errorf("failed to store advertisement: %w", err)
+	}
+
+	if err := p.dstore.Put(ctx, headKey, adCID.Bytes()); err != nil {
+		return fmt.Errorf("failed to store advertisement chain head: %w", err)
+	}
+
+	logger.Debug("Published advertisement", "cid", adCID, "previous", head, "removal", ad.IsRm)
+
+	return nil
+}
+
+// putEntries stores the entries as a chain of chunks and returns the link to its first chunk.
+func (p *Publisher) putEntries(ctx context.Context, entries []multihash.Multihash) (cid.Cid, error) {
+	if len(entries) == 0 {
+		return NoEntries, nil
+	}
+
+	// Chunks are linked to the next one, so they are built from the last
+	next := cid.Undef
+
+	for end := len(entries); end > 0; end -= MaxEntriesPerChunk {
+		chunk := &EntryChunk{Entries: entries[max(0, end-MaxEntriesPerChunk):end], Next: next}
+
+		data, chunkCID, err := chunk.Encode()
+		if err != nil {
+			return cid.Undef, err
+		}
+
+		if err := p.dstore.Put(ctx, blockKey(chunkCID), data); err != nil {
+			return cid.Undef, fmt.Errorf("failed to store entry chunk: %w", err)
+		}
+
+		next = chunkCID
+	}
+
+	return next, nil
+}
+
+// announceTo announces the chain head to an indexer.
+func (p *Publisher) announceTo(ctx context.Context, indexer string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, strings.TrimSuffix(indexer, "/")+"/announce", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid indexer URL: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call indexer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("indexer responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// announceMessage announces the head of an advertisement chain and the addresses it is published at.
+type announceMessage struct {
+	Cid       cid.Cid
+	Addrs     [][]byte
+	ExtraData []byte `json:",omitempty"`
+}
+
+func blockKey(c cid.Cid) datastore.Key {
+	return datastore.NewKey("/" + Namespace + "/blocks/" + c.String())
+}
+
+func contextKey(contextID []byte) datastore.Key {
+	return datastore.NewKey("/" + Namespace + "/contexts/" + base64.RawURLEncoding.EncodeToString(contextID))
+}
+
+// contextDigest identifies the advertised entries and metadata of a context ID.
+func contextDigest(entries []multihash.Multihash, metadata []byte) []byte {
+	hash := sha256.New()
+
+	for _, entry := range entries {
+		hash.Write(entry)
+	}
+
+	hash.Write(metadata)
+
+	return hash.Sum(nil)
+}
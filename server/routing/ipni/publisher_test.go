@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ipni
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	var announced announceMessage
+
+	indexer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/announce", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&announced))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer indexer.Close()
+
+	publisher, err := NewPublisher(dssync.MutexWrap(datastore.NewMapDatastore()), Options{
+		Key:           key,
+		ProviderAddrs: func() []string { return []string{"/ip4/127.0.0.1/tcp/8999"} },
+		PublisherAddr: ma.StringCast("/ip4/127.0.0.1/tcp/3104/http"),
+		Indexers:      []string{indexer.URL},
+	})
+	require.NoError(t, err)
+
+	entry, err := multihash.Sum([]byte("record"), multihash.SHA2_256, -1)
+	require.NoError(t, err)
+
+	published, err := publisher.Publish(t.Context(), []byte("cid1"), []multihash.Multihash{entry}, []byte("labels"))
+	require.NoError(t, err)
+	assert.True(t, published)
+
+	first, err := publisher.Head(t.Context())
+	require.NoError(t, err)
+
+	// Unchanged entries and metadata are not advertised again
+	published, err = publisher.Publish(t.Context(), []byte("cid1"), []multihash.Multihash{entry}, []byte("labels"))
+	require.NoError(t, err)
+	assert.False(t, published)
+
+	removed, err := publisher.Remove(t.Context(), []byte("cid1"))
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = publisher.Remove(t.Context(), []byte("cid1"))
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	// The chain is served over HTTP, starting from the signed head
+	server := httptest.NewServer(publisher)
+	defer server.Close()
+
+	head := fetch(t, server.URL+HTTPPath+"head")
+	assert.Contains(t, string(head), `"sig"`)
+
+	node, err := decode(head)
+	require.NoError(t, err)
+
+	second, err := link(field(node, "head"))
+	require.NoError(t, err)
+
+	ad, err := DecodeAdvertisement(fetch(t, server.URL+HTTPPath+second.String()))
+	require.NoError(t, err)
+	assert.True(t, ad.IsRm)
+	assert.Equal(t, first, ad.PreviousID)
+
+	ad, err = DecodeAdvertisement(fetch(t, server.URL+HTTPPath+first.String()))
+	require.NoError(t, err)
+	assert.False(t, ad.IsRm)
+	assert.Equal(t, []byte("labels"), ad.Metadata)
+	assert.NotEmpty(t, fetch(t, server.URL+HTTPPath+ad.Entries.String()))
+
+	resp, err := http.Get(server.URL + HTTPPath + NoEntries.String()) //nolint:noctx
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// The head is announced with the publisher address
+	require.NoError(t, publisher.Announce(t.Context()))
+	assert.Equal(t, second, announced.Cid)
+	require.Len(t, announced.Addrs, 1)
+
+	addr, err := ma.NewMultiaddrBytes(announced.Addrs[0])
+	require.NoError(t, err)
+	assert.Contains(t, addr.String(), "/http/p2p/")
+}
+
+func fetch(t *testing.T, url string) []byte {
+	t.Helper()
+
+	resp, err := http.Get(url) //nolint:noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode, url)
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,64 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ipni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// HTTPPath is the HTTP path prefix the advertisement chain is served at.
+const HTTPPath = "/ipni/v1/ad/"
+
+const readHeaderTimeout = 10 * time.Second
+
+// Server serves the advertisement chain of a publisher to indexers over HTTP.
+type Server struct {
+	server *http.Server
+}
+
+// NewServer creates a server of the publisher's advertisement chain listening on the given address.
+func NewServer(address string, publisher *Publisher) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(HTTPPath, publisher)
+
+	return &Server{
+		server: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// Start listens on the server address and serves advertisements in the background.
+func (s *Server) Start() error {
+	listen, err := net.Listen("tcp", s.server.Addr) //nolint:noctx
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.server.Addr, err)
+	}
+
+	go func() {
+		logger.Info("IPNI advertisement server starting", "address", s.server.Addr, "path", HTTPPath)
+
+		if err := s.server.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("IPNI advertisement server failed", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Stop gracefully shuts down the server.
+func (s *Server) Stop(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to stop IPNI advertisement server: %w", err)
+	}
+
+	return nil
+}
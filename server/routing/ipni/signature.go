@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ipni
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/ipld/go-ipld-prime/datamodel"
+	"github.com/ipld/go-ipld-prime/fluent/qp"
+	cidlink "github.com/ipld/go-ipld-prime/linking/cid"
+	"github.com/ipld/go-ipld-prime/node/basicnode"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/record"
+	"github.com/multiformats/go-multihash"
+)
+
+const (
+	// adSignatureDomain and adSignatureCodec identify the signed envelopes of advertisements.
+	adSignatureDomain = "indexer"
+	adSignatureCodec  = "/indexer/ingest/adSignature"
+)
+
+// adSignatureRecord is the payload of the signed envelope of an advertisement.
+type adSignatureRecord struct {
+	payload []byte
+}
+
+var _ record.Record = (*adSignatureRecord)(nil)
+
+func (r *adSignatureRecord) Domain() string {
+	return adSignatureDomain
+}
+
+func (r *adSignatureRecord) Codec() []byte {
+	return []byte(adSignatureCodec)
+}
+
+func (r *adSignatureRecord) MarshalRecord() ([]byte, error) {
+	return r.payload, nil
+}
+
+func (r *adSignatureRecord) UnmarshalRecord(data []byte) error {
+	r.payload = data
+
+	return nil
+}
+
+// signaturePayload returns the signed payload of an advertisement: the multihash of its
+// previous ID, entries, provider, addresses, context ID, metadata and removal flag.
+func signaturePayload(ad *Advertisement) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.Write(ad.PreviousID.Bytes())
+	buf.Write(ad.Entries.Bytes())
+	buf.WriteString(ad.Provider)
+
+	for _, addr := range ad.Addresses {
+		buf.WriteString(addr)
+	}
+
+	buf.Write(ad.ContextID)
+	buf.Write(ad.Metadata)
+
+	if ad.IsRm {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+
+	hash, err := multihash.Sum(buf.Bytes(), multihash.SHA2_256, -1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash advertisement: %w", err)
+	}
+
+	return hash, nil
+}
+
+// Sign signs the advertisement with the key of its provider.
+func (ad *Advertisement) Sign(key crypto.PrivKey) error {
+	payload, err := signaturePayload(ad)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := record.Seal(&adSignatureRecord{payload: payload}, key)
+	if err != nil {
+		return fmt.Errorf("failed to sign advertisement: %w", err)
+	}
+
+	ad.Signature, err = envelope.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal advertisement signature: %w", err)
+	}
+
+	return nil
+}
+
+// Verify verifies the signature of the advertisement and returns the peer that signed it,
+// which is the provider unless the advertisement is published on behalf of it.
+func (ad *Advertisement) Verify() (peer.ID, error) {
+	signed := &adSignatureRecord{}
+
+	envelope, err := record.ConsumeTypedEnvelope(ad.Signature, signed)
+	if err != nil {
+		return "", fmt.Errorf("invalid advertisement signature: %w", err)
+	}
+
+	payload, err := signaturePayload(ad)
+	if err != nil {
+		return "", err
+	}
+
+	if !bytes.Equal(signed.payload, payload) {
+		return "", errors.New("advertisement signature does not match its content")
+	}
+
+	signer, err := peer.IDFromPublicKey(envelope.PublicKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid advertisement signer: %w", err)
+	}
+
+	return signer, nil
+}
+
+// encodeSignedHead returns the DAG-JSON encoding of the head of an advertisement chain,
+// signed with the key of the publisher. The signature covers the head CID and the topic.
+func encodeSignedHead(head cid.Cid, topic string, key crypto.PrivKey) ([]byte, error) {
+	sig, err := key.Sign(append(head.Bytes(), topic...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign head: %w", err)
+	}
+
+	pubKey, err := crypto.MarshalPublicKey(key.GetPublic())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	node, err := qp.BuildMap(basicnode.Prototype.Any, -1, func(ma datamodel.MapAssembler) {
+		qp.MapEntry(ma, "head", qp.Link(cidlink.Link{Cid: head}))
+
+		if topic != "" {
+			qp.MapEntry(ma, "topic", qp.String(topic))
+		}
+
+		qp.MapEntry(ma, "pubkey", qp.Bytes(pubKey))
+		qp.MapEntry(ma, "sig", qp.Bytes(sig))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build signed head: %w", err)
+	}
+
+	data, _, err := encode(node)
+
+	return data, err
+}
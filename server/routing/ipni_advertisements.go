@@ -0,0 +1,181 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/internal/p2p"
+	"github.com/agntcy/dir/server/routing/ipni"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/multiformats/go-multicodec"
+	"github.com/multiformats/go-multihash"
+)
+
+// IPNIMetadataProtocol identifies the metadata of directory record advertisements, the JSON
+// encoded labels of the record. It is a private-use multicodec, as there is no registered one.
+const IPNIMetadataProtocol = multicodec.Code(0x300000)
+
+// ipniAdvertiser advertises the published records to IPNI indexers: each record is advertised
+// with its CID as context ID and only entry, and its labels as metadata. New advertisements are
+// announced to the indexers in the background, coalescing the announcements of a publish batch.
+type ipniAdvertiser struct {
+	publisher *ipni.Publisher
+	server    *ipni.Server
+	pending   chan struct{} // Signals that the chain head changed since the last announcement
+}
+
+func newIPNIAdvertiser(cfg routingconfig.IPNIConfig, dstore datastore.Datastore, server *p2p.Server) (*ipniAdvertiser, error) {
+	var publisherAddr ma.Multiaddr
+
+	if cfg.AnnounceAddress != "" {
+		addr, err := ma.NewMultiaddr(cfg.AnnounceAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IPNI announce address: %w", err)
+		}
+
+		publisherAddr = addr
+	}
+
+	publisher, err := ipni.NewPublisher(dstore, ipni.Options{
+		Key: server.Key(),
+		ProviderAddrs: func() []string {
+			addrs := server.Host().Addrs()
+			strs := make([]string, len(addrs))
+
+			for i, addr := range addrs {
+				strs[i] = addr.String()
+			}
+
+			return strs
+		},
+		PublisherAddr: publisherAddr,
+		Indexers:      cfg.Indexers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IPNI publisher: %w", err)
+	}
+
+	ipniServer := ipni.NewServer(cfg.ListenAddress, publisher)
+	if err := ipniServer.Start(); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &ipniAdvertiser{
+		publisher: publisher,
+		server:    ipniServer,
+		pending:   make(chan struct{}, 1),
+	}, nil
+}
+
+// advertise advertises a published record with the given labels,
+// unless it was advertised with the same labels before.
+func (a *ipniAdvertiser) advertise(ctx context.Context, labels []types.Label, decodedCID cid.Cid) error {
+	if a == nil {
+		return nil
+	}
+
+	metadata, err := json.Marshal(labels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record labels: %w", err)
+	}
+
+	published, err := a.publisher.Publish(ctx, decodedCID.Bytes(), []multihash.Multihash{decodedCID.Hash()}, ipni.Metadata(IPNIMetadataProtocol, metadata))
+	if err != nil {
+		return fmt.Errorf("failed to publish IPNI advertisement: %w", err)
+	}
+
+	if published {
+		a.changed()
+	}
+
+	return nil
+}
+
+// remove advertises the removal of an unpublished record, if it was advertised.
+func (a *ipniAdvertiser) remove(ctx context.Context, cidStr string) error {
+	if a == nil {
+		return nil
+	}
+
+	decodedCID, err := cid.Decode(cidStr)
+	if err != nil {
+		return fmt.Errorf("invalid CID %q: %w", cidStr, err)
+	}
+
+	removed, err := a.publisher.Remove(ctx, decodedCID.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to publish IPNI removal advertisement: %w", err)
+	}
+
+	if removed {
+		a.changed()
+	}
+
+	return nil
+}
+
+// changed schedules the announcement of the chain head.
+func (a *ipniAdvertiser) changed() {
+	select {
+	case a.pending <- struct{}{}:
+	default:
+	}
+}
+
+// stop stops serving the advertisement chain.
+func (a *ipniAdvertiser) stop(ctx context.Context) error {
+	if a == nil {
+		return nil
+	}
+
+	return a.server.Stop(ctx) //nolint:wrapcheck
+}
+
+// startIPNIAnnouncing announces the chain head to the indexers whenever it changed.
+func (r *routeRemote) startIPNIAnnouncing() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping IPNI announcements")
+
+				return
+			case <-r.ipni.pending:
+				if err := r.ipni.publisher.Announce(r.ctx); err != nil {
+					remoteLogger.Warn("Failed to announce IPNI advertisements", "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// advertiseToIPNI advertises a newly announced record to IPNI indexers. Records of private
+// tenants are not advertised, as indexers are public. Best-effort like GossipSub announcements.
+func (r *routeRemote) advertiseToIPNI(ctx context.Context, record types.Record, decodedCID cid.Cid) {
+	if r.ipni == nil || !r.tenants.isShared(r.recordTenant(decodedCID.String())) {
+		return
+	}
+
+	labels, err := r.publishedLabels(ctx, decodedCID.String(), types.GetLabelsFromRecord(record))
+	if err != nil {
+		remoteLogger.Warn("Failed to advertise record to IPNI indexers", "cid", decodedCID.String(), "error", err)
+
+		return
+	}
+
+	if err := r.ipni.advertise(ctx, labels, decodedCID); err != nil {
+		remoteLogger.Warn("Failed to advertise record to IPNI indexers", "cid", decodedCID.String(), "error", err)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// JournalNamespace is the datastore namespace of the cache mutation journal.
+const JournalNamespace = "journal"
+
+// journalSeq orders journal entries written within the same nanosecond.
+var journalSeq atomic.Uint64
+
+// journalPut is a datastore write of a cache mutation.
+type journalPut struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// cacheMutation groups label cache writes and deletes that must be applied together,
+// e.g. all labels of a record, or a label and the label counts it affects.
+type cacheMutation struct {
+	Puts    []journalPut `json:"puts,omitempty"`
+	Deletes []string     `json:"deletes,omitempty"`
+}
+
+func (m *cacheMutation) put(key string, value []byte) {
+	m.Puts = append(m.Puts, journalPut{Key: key, Value: value})
+}
+
+func (m *cacheMutation) delete(key string) {
+	m.Deletes = append(m.Deletes, key)
+}
+
+func (m *cacheMutation) empty() bool {
+	return len(m.Puts) == 0 && len(m.Deletes) == 0
+}
+
+// applyCacheMutation applies a cache mutation with write-ahead journaling.
+//
+// The mutation is first written and synced to the journal, then applied in a
+// single batch, and finally removed from the journal. If the process crashes
+// before the journal entry is removed, replayJournal completes the mutation on
+// the next startup, so related keys never remain partially written.
+//
+// If applying the mutation fails, the journal entry is kept and the mutation
+// is completed on the next startup.
+func applyCacheMutation(ctx context.Context, dstore types.Datastore, m *cacheMutation) error {
+	if m.empty() {
+		return nil
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+
+	journalKey := datastore.NewKey(fmt.Sprintf("/%s/%020d-%010d", JournalNamespace, time.Now().UnixNano(), journalSeq.Add(1)))
+
+	if err := dstore.Put(ctx, journalKey, data); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+
+	if err := dstore.Sync(ctx, journalKey); err != nil {
+		return fmt.Errorf("failed to sync journal entry: %w", err)
+	}
+
+	if err := commitCacheMutation(ctx, dstore, m); err != nil {
+		return err
+	}
+
+	if err := dstore.Delete(ctx, journalKey); err != nil {
+		return fmt.Errorf("failed to remove journal entry: %w", err)
+	}
+
+	return nil
+}
+
+// commitCacheMutation applies the writes and deletes of a mutation in a batch.
+// Applying a mutation again has the same result, so journal entries can be replayed.
+func commitCacheMutation(ctx context.Context, dstore types.Datastore, m *cacheMutation) error {
+	batch, err := dstore.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	for _, p := range m.Puts {
+		if err := batch.Put(ctx, datastore.NewKey(p.Key), p.Value); err != nil {
+			return fmt.Errorf("failed to put %s: %w", p.Key, err)
+		}
+	}
+
+	for _, key := range m.Deletes {
+		if err := batch.Delete(ctx, datastore.NewKey(key)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
+
+// replayJournal completes the cache mutations that were interrupted by an
+// unclean shutdown, in the order they were started.
+// It must run on startup before the cache is read.
+// Returns the number of replayed mutations.
+func replayJournal(ctx context.Context, dstore types.Datastore) (int, error) {
+	results, err := dstore.Query(ctx, query.Query{
+		Prefix: "/" + JournalNamespace + "/",
+		Orders: []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query journal: %w", err)
+	}
+
+	entries, err := results.Rest()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	replayed := 0
+
+	for _, entry := range entries {
+		var m cacheMutation
+		if err := json.Unmarshal(entry.Value, &m); err != nil {
+			// A torn journal write means the mutation itself was never started
+			localLogger.Warn("Discarding invalid journal entry", "key", entry.Key, "error", err)
+		} else if err := commitCacheMutation(ctx, dstore, &m); err != nil {
+			return replayed, fmt.Errorf("failed to replay journal entry %s: %w", entry.Key, err)
+		} else {
+			replayed++
+		}
+
+		if err := dstore.Delete(ctx, datastore.NewKey(entry.Key)); err != nil {
+			return replayed, fmt.Errorf("failed to remove journal entry %s: %w", entry.Key, err)
+		}
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,105 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func journalEntries(t *testing.T, dstore datastore.Read) []query.Entry {
+	t.Helper()
+
+	results, err := dstore.Query(t.Context(), query.Query{Prefix: "/" + JournalNamespace + "/"})
+	require.NoError(t, err)
+
+	entries, err := results.Rest()
+	require.NoError(t, err)
+
+	return entries
+}
+
+func TestApplyCacheMutation(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	ctx := t.Context()
+	require.NoError(t, dstore.Put(ctx, datastore.NewKey("/skills/AI/cid1/peer1"), []byte(`{}`)))
+
+	m := &cacheMutation{}
+	m.put("/skills/AI/cid2/peer1", []byte(`{}`))
+	m.put("/domains/research/cid2/peer1", []byte(`{}`))
+	m.delete("/skills/AI/cid1/peer1")
+
+	require.NoError(t, applyCacheMutation(ctx, dstore, m))
+
+	for key, want := range map[string]bool{
+		"/skills/AI/cid2/peer1":        true,
+		"/domains/research/cid2/peer1": true,
+		"/skills/AI/cid1/peer1":        false,
+	} {
+		exists, err := dstore.Has(ctx, datastore.NewKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, want, exists, key)
+	}
+
+	assert.Empty(t, journalEntries(t, dstore), "journal entry should be removed once applied")
+
+	// Empty mutations are not journaled
+	require.NoError(t, applyCacheMutation(ctx, dstore, &cacheMutation{}))
+	assert.Empty(t, journalEntries(t, dstore))
+}
+
+func TestReplayJournal(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	ctx := t.Context()
+
+	// Simulate a crash after journaling two mutations but before applying them
+	require.NoError(t, dstore.Put(ctx, datastore.NewKey("/skills/AI/cid1/peer1"), []byte(`{}`)))
+
+	first := &cacheMutation{}
+	first.put("/skills/AI/cid2/peer1", []byte(`{"v":1}`))
+	first.delete("/skills/AI/cid1/peer1")
+
+	second := &cacheMutation{}
+	second.put("/skills/AI/cid2/peer1", []byte(`{"v":2}`))
+
+	for i, m := range []*cacheMutation{first, second} {
+		data, err := json.Marshal(m)
+		require.NoError(t, err)
+
+		key := datastore.NewKey("/" + JournalNamespace + "/" + string(rune('a'+i)))
+		require.NoError(t, dstore.Put(ctx, key, data))
+	}
+
+	// Torn journal entries are discarded
+	require.NoError(t, dstore.Put(ctx, datastore.NewKey("/"+JournalNamespace+"/c"), []byte(`{"puts":[`)))
+
+	replayed, err := replayJournal(ctx, dstore)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+
+	exists, err := dstore.Has(ctx, datastore.NewKey("/skills/AI/cid1/peer1"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	// Mutations are replayed in journal order
+	value, err := dstore.Get(ctx, datastore.NewKey("/skills/AI/cid2/peer1"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"v":2}`, string(value))
+
+	assert.Empty(t, journalEntries(t, dstore))
+
+	// Nothing is left to replay
+	replayed, err = replayJournal(ctx, dstore)
+	require.NoError(t, err)
+	assert.Zero(t, replayed)
+}
@@ -0,0 +1,288 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/types"
+)
+
+// labelCacheUsage tracks how cached remote records are used between compaction passes:
+// how often searches returned them, and the LastSeen refreshes not written yet.
+// Records are identified by their CID/PeerID key.
+type labelCacheUsage struct {
+	mu      sync.Mutex
+	hits    map[string]uint64
+	touched map[string]time.Time
+}
+
+func newLabelCacheUsage() *labelCacheUsage {
+	return &labelCacheUsage{
+		hits:    make(map[string]uint64),
+		touched: make(map[string]time.Time),
+	}
+}
+
+// recordHit counts a search returning the record.
+func (u *labelCacheUsage) recordHit(recordKey string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.hits[recordKey]++
+}
+
+// touch buffers a LastSeen refresh of all labels of the record.
+// Repeated refreshes are merged into a single write by the next compaction pass.
+func (u *labelCacheUsage) touch(recordKey string, at time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if at.After(u.touched[recordKey]) {
+		u.touched[recordKey] = at
+	}
+}
+
+// takeTouches returns and clears the buffered LastSeen refreshes.
+func (u *labelCacheUsage) takeTouches() map[string]time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	touched := u.touched
+	u.touched = make(map[string]time.Time)
+
+	return touched
+}
+
+// hitCount returns the number of searches that returned the record.
+func (u *labelCacheUsage) hitCount(recordKey string) uint64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.hits[recordKey]
+}
+
+// forget drops the hit counts of evicted records.
+func (u *labelCacheUsage) forget(recordKeys []string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, key := range recordKeys {
+		delete(u.hits, key)
+	}
+}
+
+// decay halves all hit counts, so that records popular in the past
+// do not stay in the cache forever.
+func (u *labelCacheUsage) decay() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for key, hits := range u.hits {
+		if hits <= 1 {
+			delete(u.hits, key)
+
+			continue
+		}
+
+		u.hits[key] = hits / 2 //nolint:mnd
+	}
+}
+
+// cachedRecord is a remote record in the label cache with the keys of all its labels.
+type cachedRecord struct {
+	key       string
+	cid       string
+	peerID    string
+	labelKeys []string
+	lastSeen  time.Time
+	hits      uint64
+	pinned    bool // Pinned records are never evicted
+}
+
+// selectEvictions returns the records to evict so that at most maxLabels of the total labels remain.
+// The least frequently returned records are evicted first, and of those the least recently seen.
+// Records are evicted with all their labels, so that they never match searches partially.
+// Pinned records are never evicted, even if the cache cannot fit within maxLabels.
+func selectEvictions(records []*cachedRecord, total, maxLabels int) []*cachedRecord {
+	if maxLabels <= 0 || total <= maxLabels {
+		return nil
+	}
+
+	slices.SortFunc(records, func(a, b *cachedRecord) int {
+		return cmp.Or(
+			cmp.Compare(a.hits, b.hits),
+			a.lastSeen.Compare(b.lastSeen),
+			cmp.Compare(a.key, b.key),
+		)
+	})
+
+	var evicted []*cachedRecord
+
+	for _, record := range records {
+		if total <= maxLabels {
+			break
+		}
+
+		if record.pinned {
+			continue
+		}
+
+		evicted = append(evicted, record)
+		total -= len(record.labelKeys)
+	}
+
+	return evicted
+}
+
+// compactLabelCache writes the buffered LastSeen refreshes of remote labels in a single
+// batch and evicts remote records while the cache holds more labels than the cache limit.
+func (r *routeRemote) compactLabelCache(ctx context.Context, localPeerID string) error {
+	touched := r.cacheUsage.takeTouches()
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		// Keep the refreshes for the next pass
+		for key, at := range touched {
+			r.cacheUsage.touch(key, at)
+		}
+
+		return fmt.Errorf("failed to query label cache: %w", err)
+	}
+
+	records := make(map[string]*cachedRecord)
+	refreshed := make(map[string][]byte)
+	total := 0
+
+	for _, entry := range entries {
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID == localPeerID {
+			continue
+		}
+
+		// Invalid metadata is removed by the stale label cleanup
+		var metadata types.LabelMetadata
+		if err := json.Unmarshal(entry.Value, &metadata); err != nil {
+			continue
+		}
+
+		recordKey := keyCID + "/" + keyPeerID
+
+		if at, ok := touched[recordKey]; ok && at.After(metadata.LastSeen) {
+			metadata.LastSeen = at
+
+			value, err := json.Marshal(metadata)
+			if err != nil {
+				return fmt.Errorf("failed to marshal label metadata: %w", err)
+			}
+
+			refreshed[entry.Key] = value
+		}
+
+		record, ok := records[recordKey]
+		if !ok {
+			record = &cachedRecord{
+				key:    recordKey,
+				cid:    keyCID,
+				peerID: keyPeerID,
+				hits:   r.cacheUsage.hitCount(recordKey),
+				pinned: r.pins.has(keyCID),
+			}
+			records[recordKey] = record
+		}
+
+		record.labelKeys = append(record.labelKeys, entry.Key)
+
+		if metadata.LastSeen.After(record.lastSeen) {
+			record.lastSeen = metadata.LastSeen
+		}
+
+		total++
+	}
+
+	evicted := selectEvictions(slices.Collect(maps.Values(records)), total, r.cacheLimit())
+
+	compaction := &cacheMutation{}
+	evictedKeys := make([]string, 0, len(evicted))
+	evictedLabels := 0
+
+	for _, record := range evicted {
+		for _, key := range record.labelKeys {
+			delete(refreshed, key)
+			compaction.delete(key)
+		}
+
+		evictedKeys = append(evictedKeys, record.key)
+		evictedLabels += len(record.labelKeys)
+	}
+
+	for key, value := range refreshed {
+		compaction.put(key, value)
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, compaction); err != nil {
+		return fmt.Errorf("failed to apply label cache compaction: %w", err)
+	}
+
+	for _, record := range evicted {
+		r.peerStats.AddLabels(record.peerID, -len(record.labelKeys))
+	}
+
+	r.cacheUsage.forget(evictedKeys)
+	r.cacheUsage.decay()
+
+	if evictedLabels > 0 {
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupEvictedLabel).Add(float64(evictedLabels))
+
+		remoteLogger.Info("Evicted remote records from the label cache",
+			"records", len(evicted), "labels", evictedLabels, "maxCachedLabels", r.cacheLimit())
+	}
+
+	remoteLogger.Debug("Compacted label cache",
+		"refreshedRecords", len(touched), "refreshedLabels", len(refreshed), "labels", total-evictedLabels)
+
+	return nil
+}
+
+// startLabelCacheCompaction starts the background task that periodically compacts the label cache.
+// Buffered LastSeen refreshes are written once more when the routing subsystem stops.
+func (r *routeRemote) startLabelCacheCompaction() {
+	localPeerID := r.server.Host().ID().String()
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(LabelCacheCompactionInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				if err := r.compactLabelCache(context.WithoutCancel(r.ctx), localPeerID); err != nil {
+					remoteLogger.Warn("Failed to compact label cache on shutdown", "error", err)
+				}
+
+				return
+			case <-ticker.C:
+				start := time.Now()
+
+				if err := r.compactLabelCache(r.ctx, localPeerID); err != nil {
+					remoteLogger.Warn("Failed to compact label cache", "error", err)
+				}
+
+				metrics.TaskDuration.WithLabelValues(metrics.TaskCompact).Observe(time.Since(start).Seconds())
+			}
+		}
+	}()
+}
@@ -0,0 +1,127 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelCacheUsage(t *testing.T) {
+	usage := newLabelCacheUsage()
+	now := time.Now()
+
+	// Refreshes are merged into the latest one
+	usage.touch("cid1/peer1", now)
+	usage.touch("cid1/peer1", now.Add(-time.Minute))
+	assert.Equal(t, map[string]time.Time{"cid1/peer1": now}, usage.takeTouches())
+	assert.Empty(t, usage.takeTouches())
+
+	// Hit counts decay until they are dropped
+	for range 4 {
+		usage.recordHit("cid1/peer1")
+	}
+
+	usage.recordHit("cid2/peer1")
+	usage.decay()
+	assert.Equal(t, uint64(2), usage.hitCount("cid1/peer1"))
+	assert.Zero(t, usage.hitCount("cid2/peer1"))
+
+	usage.forget([]string{"cid1/peer1"})
+	assert.Zero(t, usage.hitCount("cid1/peer1"))
+}
+
+func TestSelectEvictions(t *testing.T) {
+	now := time.Now()
+
+	newRecords := func() []*cachedRecord {
+		return []*cachedRecord{
+			{key: "popular", labelKeys: []string{"a", "b"}, lastSeen: now.Add(-time.Hour), hits: 5},
+			{key: "old", labelKeys: []string{"c", "d"}, lastSeen: now.Add(-time.Hour)},
+			{key: "recent", labelKeys: []string{"e"}, lastSeen: now},
+		}
+	}
+
+	assert.Empty(t, selectEvictions(newRecords(), 5, 0), "zero leaves the cache unbounded")
+	assert.Empty(t, selectEvictions(newRecords(), 5, 5))
+
+	// Least frequently returned first, then least recently seen
+	evicted := selectEvictions(newRecords(), 5, 3)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, "old", evicted[0].key)
+
+	evicted = selectEvictions(newRecords(), 5, 2)
+	require.Len(t, evicted, 2)
+	assert.Equal(t, "recent", evicted[1].key)
+
+	// Pinned records are never evicted
+	records := newRecords()
+	records[1].pinned = true
+
+	evicted = selectEvictions(records, 5, 4)
+	require.Len(t, evicted, 1)
+	assert.Equal(t, "recent", evicted[0].key)
+}
+
+func TestCompactLabelCache(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore:          dstore,
+		peerStats:       peerstats.New(),
+		cacheUsage:      newLabelCacheUsage(),
+		maxCachedLabels: 3,
+	}
+
+	seen := time.Now().Add(-time.Hour)
+
+	metadata, err := json.Marshal(&types.LabelMetadata{Timestamp: seen, LastSeen: seen})
+	require.NoError(t, err)
+
+	keys := []string{
+		"/skills/AI/cid1/peer1",
+		"/domains/research/cid1/peer1",
+		"/skills/AI/cid2/peer1",
+		"/skills/AI/cid3/peer2",
+		"/skills/AI/cid4/local",
+	}
+	for _, key := range keys {
+		require.NoError(t, dstore.Put(t.Context(), datastore.NewKey(key), metadata))
+	}
+
+	r.peerStats.AddLabels("peer1", 3)
+	r.peerStats.AddLabels("peer2", 1)
+
+	// cid1 was returned by a search and cid3 was reannounced, so cid2 is evicted
+	refreshed := time.Now()
+
+	r.cacheUsage.recordHit("cid1/peer1")
+	r.cacheUsage.touch("cid3/peer2", refreshed)
+
+	require.NoError(t, r.compactLabelCache(t.Context(), "local"))
+
+	for _, key := range keys {
+		exists, err := dstore.Has(t.Context(), datastore.NewKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, key != "/skills/AI/cid2/peer1", exists, key)
+	}
+
+	value, err := dstore.Get(t.Context(), datastore.NewKey("/skills/AI/cid3/peer2"))
+	require.NoError(t, err)
+
+	var updated types.LabelMetadata
+	require.NoError(t, json.Unmarshal(value, &updated))
+	assert.True(t, updated.LastSeen.Equal(refreshed))
+	assert.True(t, updated.Timestamp.Equal(seen))
+
+	assert.Equal(t, int64(3), r.peerStats.TotalLabels())
+}
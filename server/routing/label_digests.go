@@ -0,0 +1,252 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/labeldigest"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// labelDigests holds the most recent label digest received from each peer.
+type labelDigests struct {
+	mu      sync.Mutex
+	digests map[string]receivedDigest
+}
+
+type receivedDigest struct {
+	digest     *labeldigest.Digest
+	receivedAt time.Time
+}
+
+func newLabelDigests() *labelDigests {
+	return &labelDigests{digests: make(map[string]receivedDigest)}
+}
+
+// set stores the digest of a peer, unless a more recent one was received.
+func (d *labelDigests) set(peerID string, digest *labeldigest.Digest, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if existing, ok := d.digests[peerID]; ok && !digest.Timestamp.After(existing.digest.Timestamp) {
+		return
+	}
+
+	d.digests[peerID] = receivedDigest{digest: digest, receivedAt: now}
+}
+
+// get returns the digest of a peer received within LabelDigestTTL.
+// Expired digests are removed.
+func (d *labelDigests) get(peerID string, now time.Time) (*labeldigest.Digest, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	received, ok := d.digests[peerID]
+	if !ok {
+		return nil, false
+	}
+
+	if now.Sub(received.receivedAt) > LabelDigestTTL {
+		delete(d.digests, peerID)
+
+		return nil, false
+	}
+
+	return received.digest, true
+}
+
+// mayMatch reports whether a peer may hold records matching at least minMatchScore queries.
+// Peers without a current digest may hold any record.
+func (d *labelDigests) mayMatch(peerID string, queries []*routingv1.RecordQuery, minMatchScore uint32, now time.Time) bool {
+	digest, ok := d.get(peerID, now)
+	if !ok {
+		return true
+	}
+
+	var score uint32
+
+	for _, query := range queries {
+		if !digestMayMatchQuery(digest, query) {
+			continue
+		}
+
+		// Hierarchical queries may match the queried label exactly
+		if query.GetGroup() == nil && query.GetMatchMode() == routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL {
+			score += ExactMatchScore
+		} else {
+			score++
+		}
+	}
+
+	return score >= minMatchScore
+}
+
+// digestMayMatchQuery reports whether the labels of a digest may match a query,
+// following QueryMatchesLabels. As queries also match the children of the queried
+// label, digests contain the parents of all their labels. Hierarchical queries may
+// also match the ancestors of the queried label.
+func digestMayMatchQuery(digest *labeldigest.Digest, query *routingv1.RecordQuery) bool {
+	if query == nil {
+		return false
+	}
+
+	if group := query.GetGroup(); group != nil {
+		switch group.GetOperator() {
+		case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND:
+			for _, subQuery := range group.GetQueries() {
+				if !digestMayMatchQuery(digest, subQuery) {
+					return false
+				}
+			}
+
+			return true
+		case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR:
+			for _, subQuery := range group.GetQueries() {
+				if digestMayMatchQuery(digest, subQuery) {
+					return true
+				}
+			}
+
+			return false
+		default:
+			// The absence of labels cannot be told from a digest
+			return true
+		}
+	}
+
+	var target string
+
+	switch query.GetType() {
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
+		target = types.LabelTypeSkill.Prefix() + query.GetValue()
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+		target = types.LabelTypeLocator.Prefix() + query.GetValue()
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
+		target = types.LabelTypeDomain.Prefix() + query.GetValue()
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		target = types.LabelTypeModule.Prefix() + query.GetValue()
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL:
+		target = "/" + strings.Trim(query.GetValue(), "/")
+	default:
+		return true
+	}
+
+	target = strings.TrimSuffix(target, "/")
+	if digest.MayContain(target) {
+		return true
+	}
+
+	if query.GetMatchMode() != routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL ||
+		query.GetType() == routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR {
+		return false
+	}
+
+	// Ancestors below the namespace, e.g. /skills/AI for /skills/AI/ML
+	namespaceEnd := strings.Index(target[1:], "/") + 1
+
+	for i := strings.LastIndex(target, "/"); i > namespaceEnd; i = strings.LastIndex(target, "/") {
+		target = target[:i]
+
+		if digest.MayContain(target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// startLabelDigests publishes the label digest of the local peer once bootstrap
+// completes and every LabelDigestInterval afterwards.
+func (r *routeRemote) startLabelDigests() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(LabelDigestInterval)
+		defer ticker.Stop()
+
+		bootstrapped := r.server.Bootstrapped()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping label digests")
+
+				return
+			case <-bootstrapped:
+				bootstrapped = nil
+
+				r.publishLabelDigest(r.ctx)
+			case <-ticker.C:
+				r.publishLabelDigest(r.ctx)
+			}
+		}
+	}()
+}
+
+// publishLabelDigest publishes the digest of the labels served to live searches,
+// i.e. the labels of the unexpired untenanted local records.
+func (r *routeRemote) publishLabelDigest(ctx context.Context) {
+	digest, err := r.localLabelDigest(ctx)
+	if err != nil {
+		remoteLogger.Warn("Failed to build label digest", "error", err)
+
+		return
+	}
+
+	if err := r.pubsubManager.PublishLabelDigest(ctx, digest); err != nil {
+		remoteLogger.Warn("Failed to publish label digest", "error", err)
+	}
+}
+
+// localLabelDigest builds the digest of the labels served to live searches.
+func (r *routeRemote) localLabelDigest(ctx context.Context) (*labeldigest.Digest, error) {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, err
+	}
+
+	localPeerID := r.server.Host().ID().String()
+	now := time.Now()
+
+	var labels []string
+
+	for _, entry := range sharedEntries(entries) {
+		label, _, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID != localPeerID || labelExpired(entry.Value, now) {
+			continue
+		}
+
+		labels = append(labels, label.String())
+	}
+
+	return labeldigest.New(labels), nil
+}
+
+// handleLabelDigest stores a label digest received via GossipSub.
+func (r *routeRemote) handleLabelDigest(_ context.Context, publisher peer.ID, digest *labeldigest.Digest) {
+	r.labelDigests.set(publisher.String(), digest, time.Now())
+}
+
+// liveSearchPeerMayMatch reports whether a live search should query a peer,
+// counting the peers skipped because of their label digest.
+func (r *routeRemote) liveSearchPeerMayMatch(peerID string, queries []*routingv1.RecordQuery, minMatchScore uint32) bool {
+	if !r.labelDigests.mayMatch(peerID, queries, max(minMatchScore, DefaultMinMatchScore), time.Now()) {
+		metrics.LiveSearchPeers.WithLabelValues(metrics.ResultSkipped).Inc()
+
+		return false
+	}
+
+	metrics.LiveSearchPeers.WithLabelValues(metrics.ResultQueried).Inc()
+
+	return true
+}
@@ -0,0 +1,68 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/labeldigest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelDigests_MayMatch(t *testing.T) {
+	now := time.Now()
+	digests := newLabelDigests()
+	digests.set("peer1", labeldigest.New([]string{"/skills/AI/ML", "/domains/research"}), now)
+
+	skill := func(value string) *routingv1.RecordQuery {
+		return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: value}
+	}
+
+	domain := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+
+	// Queries match the digested labels and their parents
+	assert.True(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("AI")}, 1, now))
+	assert.True(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("AI/ML")}, 1, now))
+	assert.False(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("Vision")}, 1, now))
+
+	// Hierarchical queries also match the ancestors of the queried label and may match exactly
+	hierarchical := skill("AI/ML/NLP")
+	hierarchical.MatchMode = routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL
+
+	assert.False(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("AI/ML/NLP")}, 1, now))
+	assert.True(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{hierarchical}, ExactMatchScore, now))
+
+	// Enough queries must match for the minimum match score
+	assert.True(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("AI"), domain}, 2, now))
+	assert.False(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("AI"), skill("Vision")}, 2, now))
+
+	// The absence of labels cannot be told from a digest
+	not := &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{
+		Operator: routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT,
+		Queries:  []*routingv1.RecordQuery{skill("AI")},
+	}}
+	assert.True(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{not}, 1, now))
+
+	// Peers without a current digest are queried
+	assert.True(t, digests.mayMatch("peer2", []*routingv1.RecordQuery{skill("Vision")}, 1, now))
+	assert.True(t, digests.mayMatch("peer1", []*routingv1.RecordQuery{skill("Vision")}, 1, now.Add(LabelDigestTTL+time.Second)))
+}
+
+func TestLabelDigests_KeepsMostRecent(t *testing.T) {
+	now := time.Now()
+	digests := newLabelDigests()
+
+	recent := labeldigest.New([]string{"/skills/AI"})
+	older := labeldigest.New([]string{"/skills/Vision"})
+	older.Timestamp = recent.Timestamp.Add(-time.Minute)
+
+	digests.set("peer1", recent, now)
+	digests.set("peer1", older, now)
+
+	digest, ok := digests.get("peer1", now)
+	assert.True(t, ok)
+	assert.Same(t, recent, digest)
+}
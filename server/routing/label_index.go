@@ -0,0 +1,400 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/labelindex"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// labelIndexDatastore is a datastore middleware mirroring the enhanced label keys of
+// the label cache into a label index. All label cache writes, including journaled
+// cache mutations and their replay, go through it, so that the index follows the cache.
+//
+// The index is updated after the datastore writes succeed. If updating the index fails,
+// it is marked stale and not queried until it is rebuilt from the label cache.
+type labelIndexDatastore struct {
+	types.Datastore
+
+	index labelindex.Index
+
+	mu    sync.RWMutex // Held exclusively while rebuilding, so that no write is lost
+	stale atomic.Bool
+}
+
+// wrapWithLabelIndex wraps the datastore with the label index middleware.
+func wrapWithLabelIndex(dstore types.Datastore, index labelindex.Index) *labelIndexDatastore {
+	return &labelIndexDatastore{Datastore: dstore, index: index}
+}
+
+// Unwrap returns the wrapped datastore.
+func (d *labelIndexDatastore) Unwrap() types.Datastore {
+	return d.Datastore
+}
+
+func (d *labelIndexDatastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	entry, ok := labelIndexEntry(key.String())
+	if !ok {
+		return d.Datastore.Put(ctx, key, value) //nolint:wrapcheck
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if err := d.Datastore.Put(ctx, key, value); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	d.apply(ctx, []labelindex.Entry{entry}, nil)
+
+	return nil
+}
+
+func (d *labelIndexDatastore) Delete(ctx context.Context, key datastore.Key) error {
+	if _, ok := labelIndexEntry(key.String()); !ok {
+		return d.Datastore.Delete(ctx, key) //nolint:wrapcheck
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if err := d.Datastore.Delete(ctx, key); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	d.apply(ctx, nil, []string{key.String()})
+
+	return nil
+}
+
+func (d *labelIndexDatastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	batch, err := d.Datastore.Batch(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &labelIndexBatch{Batch: batch, dstore: d}, nil
+}
+
+// apply updates the index, marking it stale if that fails.
+func (d *labelIndexDatastore) apply(ctx context.Context, puts []labelindex.Entry, deletes []string) {
+	if err := d.index.Apply(ctx, puts, deletes); err != nil {
+		if !d.stale.Swap(true) {
+			remoteLogger.Warn("Label index fell behind the label cache, it is not used until rebuilt", "error", err)
+		}
+	}
+}
+
+// ready reports whether the index reflects the label cache.
+func (d *labelIndexDatastore) ready() bool {
+	return !d.stale.Load()
+}
+
+// rebuild replaces the index with the enhanced label keys of the label cache.
+// Label cache writes wait for the rebuild to complete.
+func (d *labelIndexDatastore) rebuild(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	entries, err := QueryAllNamespaces(ctx, d.Datastore)
+	if err != nil {
+		return err
+	}
+
+	indexEntries := make([]labelindex.Entry, 0, len(entries))
+
+	for _, entry := range entries {
+		if indexEntry, ok := labelIndexEntry(entry.Key); ok {
+			indexEntries = append(indexEntries, indexEntry)
+		}
+	}
+
+	if err := d.index.Reset(ctx, indexEntries); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	d.stale.Store(false)
+
+	remoteLogger.Info("Rebuilt label index", "labels", len(indexEntries))
+
+	return nil
+}
+
+// sync rebuilds the index if it is stale or has a different number of labels than the
+// label cache, e.g. after the cache was written without the index being configured.
+func (d *labelIndexDatastore) sync(ctx context.Context) error {
+	if d.ready() {
+		entries, err := QueryAllNamespaces(ctx, d.Datastore)
+		if err != nil {
+			return err
+		}
+
+		indexed, err := d.index.Len(ctx)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if indexed == int64(len(entries)) {
+			return nil
+		}
+	}
+
+	return d.rebuild(ctx)
+}
+
+// labelIndexBatch mirrors the label keys of a batch into the index once it is committed.
+// Only the last write of each key is mirrored, like the batch applies it.
+type labelIndexBatch struct {
+	datastore.Batch
+
+	dstore *labelIndexDatastore
+	keys   []string                     // Written label keys, in order of their first write
+	writes map[string]*labelindex.Entry // Last write of each label key, nil if deleted
+}
+
+func (b *labelIndexBatch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	if err := b.Batch.Put(ctx, key, value); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if entry, ok := labelIndexEntry(key.String()); ok {
+		b.write(key.String(), &entry)
+	}
+
+	return nil
+}
+
+func (b *labelIndexBatch) Delete(ctx context.Context, key datastore.Key) error {
+	if err := b.Batch.Delete(ctx, key); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if _, ok := labelIndexEntry(key.String()); ok {
+		b.write(key.String(), nil)
+	}
+
+	return nil
+}
+
+func (b *labelIndexBatch) write(key string, entry *labelindex.Entry) {
+	if b.writes == nil {
+		b.writes = make(map[string]*labelindex.Entry)
+	}
+
+	if _, ok := b.writes[key]; !ok {
+		b.keys = append(b.keys, key)
+	}
+
+	b.writes[key] = entry
+}
+
+func (b *labelIndexBatch) Commit(ctx context.Context) error {
+	b.dstore.mu.RLock()
+	defer b.dstore.mu.RUnlock()
+
+	if err := b.Batch.Commit(ctx); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var (
+		puts    []labelindex.Entry
+		deletes []string
+	)
+
+	for _, key := range b.keys {
+		if entry := b.writes[key]; entry != nil {
+			puts = append(puts, *entry)
+		} else {
+			deletes = append(deletes, key)
+		}
+	}
+
+	b.dstore.apply(ctx, puts, deletes)
+
+	return nil
+}
+
+// labelIndexEntry splits an enhanced label key into a label index entry.
+// Keys outside the label namespaces are not indexed.
+func labelIndexEntry(key string) (labelindex.Entry, bool) {
+	if !hasLabelKeyPrefix(key) {
+		return labelindex.Entry{}, false
+	}
+
+	label, cid, peerID, err := ParseEnhancedLabelKey(key)
+	if err != nil {
+		return labelindex.Entry{}, false
+	}
+
+	tenant, label := splitTenantLabel(label)
+
+	return labelindex.Entry{Key: key, Tenant: tenant, Label: label.String(), CID: cid, PeerID: peerID}, true
+}
+
+// hasLabelKeyPrefix reports whether a key is in a label namespace or the tenants namespace.
+func hasLabelKeyPrefix(key string) bool {
+	for _, prefix := range labelKeyPrefixes() {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// labelIndex returns the label index of the routing datastore, nil if it is disabled
+// or stale and should not be queried.
+func (r *routeRemote) labelIndex() *labelIndexDatastore {
+	dstore, ok := unwrapDatastore[*labelIndexDatastore](r.dstore)
+	if !ok || !dstore.ready() {
+		return nil
+	}
+
+	return dstore
+}
+
+// refreshLabelIndex rebuilds the label index if it fell behind the label cache.
+func (r *routeRemote) refreshLabelIndex(ctx context.Context) {
+	dstore, ok := unwrapDatastore[*labelIndexDatastore](r.dstore)
+	if !ok || dstore.ready() {
+		return
+	}
+
+	if err := dstore.rebuild(ctx); err != nil {
+		remoteLogger.Warn("Failed to rebuild label index", "error", err)
+	}
+}
+
+// labelIndexCandidates returns the CIDs of the remote records of the tenants in scope whose
+// labels may match at least minMatchScore of the queries, nil if the index cannot tell.
+// Candidates are selected from all labels of the records, including expired ones, so that
+// they are a superset of the records Search returns. Queries negating other queries cannot
+// select candidates from a superset of labels and are not supported.
+func (r *routeRemote) labelIndexCandidates(ctx context.Context, queries []*routingv1.RecordQuery, minMatchScore uint32, scope []string) map[string]bool {
+	index := r.labelIndex()
+	if index == nil || len(queries) == 0 || minMatchScore == 0 {
+		return nil
+	}
+
+	conditions, ok := queryConditions(queries)
+	if !ok {
+		return nil
+	}
+
+	for _, condition := range conditions {
+		if condition.HasNot() {
+			return nil
+		}
+	}
+
+	cids, err := index.index.Match(ctx, conditions, int(minMatchScore), labelindex.Filter{
+		Tenants:     scope,
+		ExcludePeer: r.server.Host().ID().String(),
+	})
+	if err != nil {
+		remoteLogger.Warn("Failed to query label index, scanning the label cache", "error", err)
+
+		return nil
+	}
+
+	candidates := make(map[string]bool, len(cids))
+	for _, cid := range cids {
+		candidates[cid] = true
+	}
+
+	return candidates
+}
+
+// countLabelIndexMatches counts the remote records of the tenants in scope matching at least
+// minMatchScore of the queries over their labels, returning false if the index cannot tell.
+func (r *routeRemote) countLabelIndexMatches(ctx context.Context, queries []*routingv1.RecordQuery, minMatchScore uint32, scope []string) (uint64, bool) {
+	index := r.labelIndex()
+	if index == nil || len(queries) == 0 {
+		return 0, false
+	}
+
+	conditions, ok := queryConditions(queries)
+	if !ok {
+		return 0, false
+	}
+
+	count, err := index.index.Count(ctx, conditions, int(minMatchScore), labelindex.Filter{
+		Tenants:     scope,
+		ExcludePeer: r.server.Host().ID().String(),
+	})
+	if err != nil {
+		remoteLogger.Warn("Failed to count label index matches", "error", err)
+
+		return 0, false
+	}
+
+	return uint64(count), true //nolint:gosec // Counts are not negative
+}
+
+// queryConditions converts search queries to label index conditions matching the same
+// labels as QueryMatchesLabels. Returns false if a query cannot be converted.
+func queryConditions(queries []*routingv1.RecordQuery) ([]labelindex.Condition, bool) {
+	conditions := make([]labelindex.Condition, 0, len(queries))
+
+	for _, query := range queries {
+		condition, ok := queryCondition(query)
+		if !ok {
+			return nil, false
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, true
+}
+
+func queryCondition(query *routingv1.RecordQuery) (labelindex.Condition, bool) {
+	if group := query.GetGroup(); group != nil {
+		children, ok := queryConditions(group.GetQueries())
+		if !ok || len(children) == 0 {
+			return labelindex.Condition{}, false
+		}
+
+		switch group.GetOperator() {
+		case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND:
+			return labelindex.And(children...), true
+		case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR:
+			return labelindex.Or(children...), true
+		case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT:
+			return labelindex.Not(children...), true
+		default:
+			return labelindex.Condition{}, false
+		}
+	}
+
+	// Hierarchical queries also match ancestors and score exact matches higher,
+	// which the index cannot count
+	if query.GetMatchMode() != routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_UNSPECIFIED {
+		return labelindex.Condition{}, false
+	}
+
+	switch query.GetType() {
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
+		return labelindex.Label(types.LabelTypeSkill.Prefix() + query.GetValue()), true
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
+		return labelindex.Label(types.LabelTypeDomain.Prefix() + query.GetValue()), true
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		return labelindex.Label(types.LabelTypeModule.Prefix() + query.GetValue()), true
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+		return labelindex.ExactLabel(types.LabelTypeLocator.Prefix() + query.GetValue()), true
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL:
+		return labelindex.Label("/" + strings.Trim(query.GetValue(), "/")), true
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED:
+		return labelindex.All(), true
+	default:
+		return labelindex.Condition{}, false
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"path/filepath"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/labelindex"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTestLabelIndex(t *testing.T) *labelIndexDatastore {
+	t.Helper()
+
+	dstore, cleanup := setupTestDatastore(t)
+	t.Cleanup(cleanup)
+
+	index, err := labelindex.Open(labelindex.DriverSQLite, filepath.Join(t.TempDir(), "labels.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = index.Close() })
+
+	return wrapWithLabelIndex(dstore, index)
+}
+
+func indexedCIDs(t *testing.T, d *labelIndexDatastore, condition labelindex.Condition, filter labelindex.Filter) []string {
+	t.Helper()
+
+	cids, err := d.index.Match(t.Context(), []labelindex.Condition{condition}, 1, filter)
+	require.NoError(t, err)
+
+	return cids
+}
+
+func TestLabelIndexDatastore_MirrorsLabelCache(t *testing.T) {
+	ctx := t.Context()
+	d := setupTestLabelIndex(t)
+
+	// Cache mutations are mirrored when committed, keys outside label namespaces are not
+	mutation := &cacheMutation{}
+	mutation.put("/skills/AI/ML/cid1/peer1", []byte(`{}`))
+	mutation.put("/domains/research/cid1/peer1", []byte(`{}`))
+	mutation.put(tenantKey("acme", "/skills/AI/cid2/peer1"), []byte(`{}`))
+	mutation.put("/records/cid1", []byte(`{}`))
+	require.NoError(t, applyCacheMutation(ctx, d, mutation))
+
+	count, err := d.index.Len(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+
+	assert.Equal(t, []string{"cid1"}, indexedCIDs(t, d, labelindex.Label("/skills/AI"), labelindex.Filter{Tenants: []string{""}}))
+	assert.Equal(t, []string{"cid2"}, indexedCIDs(t, d, labelindex.Label("/skills/AI"), labelindex.Filter{Tenants: []string{"acme"}}))
+
+	// Direct deletes are mirrored too
+	require.NoError(t, d.Delete(ctx, datastore.NewKey("/domains/research/cid1/peer1")))
+	assert.Empty(t, indexedCIDs(t, d, labelindex.Label("/domains"), labelindex.Filter{}))
+
+	// The last write of a key in a batch is mirrored
+	mutation = &cacheMutation{}
+	mutation.put("/domains/research/cid3/peer1", []byte(`{}`))
+	mutation.delete("/domains/research/cid3/peer1")
+	require.NoError(t, commitCacheMutation(ctx, d, mutation))
+	assert.Empty(t, indexedCIDs(t, d, labelindex.Label("/domains"), labelindex.Filter{}))
+}
+
+func TestLabelIndexDatastore_Sync(t *testing.T) {
+	ctx := t.Context()
+	d := setupTestLabelIndex(t)
+
+	// Labels cached before the index was configured are indexed on startup
+	require.NoError(t, d.Unwrap().Put(ctx, datastore.NewKey("/skills/AI/cid1/peer1"), []byte(`{}`)))
+	require.NoError(t, d.sync(ctx))
+	assert.Equal(t, []string{"cid1"}, indexedCIDs(t, d, labelindex.Label("/skills"), labelindex.Filter{}))
+
+	// A stale index is rebuilt even if its size matches the label cache
+	require.NoError(t, d.Unwrap().Delete(ctx, datastore.NewKey("/skills/AI/cid1/peer1")))
+	require.NoError(t, d.Unwrap().Put(ctx, datastore.NewKey("/skills/AI/cid2/peer1"), []byte(`{}`)))
+	d.stale.Store(true)
+
+	r := &routeRemote{dstore: d}
+	assert.Nil(t, r.labelIndex())
+
+	require.NoError(t, d.sync(ctx))
+	assert.Equal(t, []string{"cid2"}, indexedCIDs(t, d, labelindex.Label("/skills"), labelindex.Filter{}))
+	assert.NotNil(t, r.labelIndex())
+}
+
+func TestQueryConditions_MatchQueryMatchesLabels(t *testing.T) {
+	ctx := t.Context()
+	d := setupTestLabelIndex(t)
+
+	records := map[string][]string{
+		"cid1": {"/skills/AI/ML", "/domains/research"},
+		"cid2": {"/skills/AIOps", "/locators/docker-image"},
+		"cid3": {"/modules/runtime/language", "/locators/docker-image/v2"},
+	}
+
+	mutation := &cacheMutation{}
+
+	for cid, labels := range records {
+		for _, label := range labels {
+			mutation.put(BuildEnhancedLabelKey(types.Label(label), cid, "peer1"), []byte(`{}`))
+		}
+	}
+
+	require.NoError(t, applyCacheMutation(ctx, d, mutation))
+
+	skill := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}
+	locator := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, Value: "docker-image"}
+	module := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, Value: "runtime"}
+	label := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: "domains/research/"}
+	group := func(operator routingv1.RecordQueryOperator, queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+		return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{Operator: operator, Queries: queries}}
+	}
+
+	queries := []*routingv1.RecordQuery{
+		skill,
+		locator,
+		module,
+		label,
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED},
+		group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, skill, label),
+		group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, locator, module),
+		group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, skill),
+	}
+
+	for _, query := range queries {
+		conditions, ok := queryConditions([]*routingv1.RecordQuery{query})
+		require.True(t, ok)
+
+		cids, err := d.index.Match(ctx, conditions, 1, labelindex.Filter{})
+		require.NoError(t, err)
+
+		var want []string
+
+		for _, cid := range []string{"cid1", "cid2", "cid3"} {
+			var labels []types.Label
+			for _, l := range records[cid] {
+				labels = append(labels, types.Label(l))
+			}
+
+			if QueryMatchesLabels(query, labels) {
+				want = append(want, cid)
+			}
+		}
+
+		assert.Equal(t, want, cids, "query %v", query)
+	}
+
+	// Groups without operator match nothing in Search and are not converted
+	_, ok := queryConditions([]*routingv1.RecordQuery{group(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED, skill)})
+	assert.False(t, ok)
+}
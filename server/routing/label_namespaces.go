@@ -0,0 +1,72 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/agntcy/dir/server/types"
+)
+
+// reservedNamespaces are datastore and DHT key prefixes that cannot be used
+// as custom label namespaces.
+var reservedNamespaces = []string{"records", TenantsNamespace, revocation.Namespace, JournalNamespace, PinNamespace, StateNamespace, HistoryNamespace, ProvenanceNamespace}
+
+// registerLabelNamespaces registers the custom label namespaces from config
+// with the label namespace registry.
+func registerLabelNamespaces(cfgs []routingconfig.LabelNamespaceConfig) error {
+	for _, cfg := range cfgs {
+		namespace, err := newLabelNamespace(cfg)
+		if err != nil {
+			return err
+		}
+
+		if err := types.RegisterLabelNamespace(namespace); err != nil {
+			return fmt.Errorf("failed to register label namespace: %w", err)
+		}
+
+		remoteLogger.Info("Registered custom label namespace",
+			"namespace", cfg.Name,
+			"annotation", cfg.Annotation,
+			"pattern", cfg.Pattern)
+	}
+
+	return nil
+}
+
+// newLabelNamespace builds a label namespace from its config.
+// The pattern, if any, must match label values in full.
+func newLabelNamespace(cfg routingconfig.LabelNamespaceConfig) (types.LabelNamespace, error) {
+	if slices.Contains(reservedNamespaces, cfg.Name) {
+		return types.LabelNamespace{}, fmt.Errorf("label namespace %q is reserved", cfg.Name)
+	}
+
+	namespace := types.LabelNamespace{
+		Type:       types.LabelType(cfg.Name),
+		Annotation: cfg.Annotation,
+	}
+
+	if cfg.Pattern == "" {
+		return namespace, nil
+	}
+
+	pattern, err := regexp.Compile("^(?:" + cfg.Pattern + ")$")
+	if err != nil {
+		return types.LabelNamespace{}, fmt.Errorf("invalid pattern for label namespace %q: %w", cfg.Name, err)
+	}
+
+	namespace.ValidateValue = func(value string) error {
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("value %q does not match pattern %q", value, cfg.Pattern)
+		}
+
+		return nil
+	}
+
+	return namespace, nil
+}
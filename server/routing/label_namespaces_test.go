@@ -0,0 +1,38 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLabelNamespace(t *testing.T) {
+	namespace, err := newLabelNamespace(routingconfig.LabelNamespaceConfig{
+		Name:       "teams",
+		Annotation: "org.example/teams",
+		Pattern:    `[a-z]+(/[a-z]+)*`,
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, namespace.ValidateLabel("platform/search"))
+	assert.Error(t, namespace.ValidateLabel("Platform"))
+	assert.Error(t, namespace.ValidateLabel("platform/search!")) // pattern must match in full
+	assert.Error(t, namespace.ValidateLabel(""))
+
+	// Without a pattern any non-empty value is accepted
+	namespace, err = newLabelNamespace(routingconfig.LabelNamespaceConfig{Name: "teams", Annotation: "org.example/teams"})
+	require.NoError(t, err)
+	assert.NoError(t, namespace.ValidateLabel("Anything Goes"))
+
+	// Datastore and DHT prefixes are reserved
+	_, err = newLabelNamespace(routingconfig.LabelNamespaceConfig{Name: "revocations", Annotation: "org.example/revocations"})
+	assert.Error(t, err)
+
+	_, err = newLabelNamespace(routingconfig.LabelNamespaceConfig{Name: "teams", Annotation: "a", Pattern: "("})
+	assert.Error(t, err)
+}
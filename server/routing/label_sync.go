@@ -0,0 +1,303 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// serveLabelSync serves a page of the records published by this peer to a peer syncing its labels.
+// Records of tenants are not synced, as synced records do not carry a tenant.
+func (r *routeRemote) serveLabelSync(ctx context.Context, since time.Time, cursor string, limit int) (*rpc.LabelSyncResponse, error) {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, err
+	}
+
+	return labelSyncPage(sharedEntries(entries), r.server.Host().ID().String(), since, cursor, limit, time.Now()), nil
+}
+
+// labelSyncPage returns up to limit unexpired records of localPeerID after the cursor CID,
+// in CID order, that were updated after since. Records updated up to LabelSyncOverlap
+// before since are returned again. A zero since returns all records.
+func labelSyncPage(entries []NamespaceEntry, localPeerID string, since time.Time, cursor string, limit int, now time.Time) *rpc.LabelSyncResponse {
+	records := make(map[string]*rpc.LabelSyncRecord)
+
+	for _, entry := range entries {
+		label, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID != localPeerID || keyCID <= cursor {
+			continue
+		}
+
+		var metadata types.LabelMetadata
+		if err := json.Unmarshal(entry.Value, &metadata); err != nil || metadata.IsExpired(now) {
+			continue
+		}
+
+		record, ok := records[keyCID]
+		if !ok {
+			record = &rpc.LabelSyncRecord{Cid: keyCID}
+			records[keyCID] = record
+		}
+
+		// Announcements are bounded in size, so are synced records
+		if len(record.Labels) < pubsub.MaxLabelsPerAnnouncement {
+			record.Labels = append(record.Labels, label.String())
+		}
+
+		// The most recently published labels carry the current record metadata
+		if updatedAt := metadata.Timestamp.UnixNano(); updatedAt > record.UpdatedAt {
+			record.UpdatedAt = updatedAt
+			record.Supersedes = metadata.Supersedes
+			record.AccessGated = metadata.AccessGated
+			record.Size = metadata.Size
+			record.Summary = metadata.RecordSummary
+			record.ExpiresAt = 0
+
+			if !metadata.ExpiresAt.IsZero() {
+				record.ExpiresAt = metadata.ExpiresAt.Unix()
+			}
+		}
+	}
+
+	if !since.IsZero() {
+		threshold := since.Add(-LabelSyncOverlap).UnixNano()
+
+		for cid, record := range records {
+			if record.UpdatedAt <= threshold {
+				delete(records, cid)
+			}
+		}
+	}
+
+	cids := make([]string, 0, len(records))
+	for cid := range records {
+		cids = append(cids, cid)
+	}
+
+	sort.Strings(cids)
+
+	resp := &rpc.LabelSyncResponse{SyncedAt: now.UnixNano()}
+
+	if len(cids) > limit {
+		cids = cids[:limit]
+		resp.NextCursor = cids[limit-1]
+	}
+
+	for _, cid := range cids {
+		resp.Records = append(resp.Records, *records[cid])
+	}
+
+	return resp
+}
+
+// startLabelSync syncs the labels published by connected peers once bootstrap completes,
+// warming the label cache without waiting for announcements. Afterwards, the changes since
+// the last sync of each peer are synced every LabelSyncInterval.
+func (r *routeRemote) startLabelSync() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(LabelSyncInterval)
+		defer ticker.Stop()
+
+		// The serving peer's time of the last sync of each peer
+		var synced map[peer.ID]int64
+
+		bootstrapped := r.server.Bootstrapped()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping label sync")
+
+				return
+			case <-bootstrapped:
+				bootstrapped = nil
+
+				synced = r.syncLabels(synced)
+			case <-ticker.C:
+				synced = r.syncLabels(synced)
+			}
+		}
+	}()
+}
+
+// syncLabels syncs the labels published by the connected peers serving label sync.
+// It returns the time of the last sync of each of these peers, to only sync changes next time.
+func (r *routeRemote) syncLabels(synced map[peer.ID]int64) map[peer.ID]int64 {
+	next := make(map[peer.ID]int64)
+
+	for _, peerID := range r.labelSyncPeers() {
+		since, ok := synced[peerID]
+		if ok {
+			next[peerID] = since
+		}
+
+		ctx, cancel := context.WithTimeout(r.ctx, LabelSyncTimeout)
+		syncedAt, cached, err := r.syncLabelsFrom(ctx, peerID, since)
+
+		cancel()
+
+		if err != nil {
+			remoteLogger.Debug("Failed to sync labels from peer", "peer", peerID, "cached", cached, "error", err)
+
+			continue
+		}
+
+		next[peerID] = syncedAt
+
+		remoteLogger.Debug("Synced labels from peer", "peer", peerID, "full", since == 0, "cached", cached)
+	}
+
+	return next
+}
+
+// labelSyncPeers returns the connected peers serving label sync, excluding peers
+// with a low reputation, in a deterministic order.
+func (r *routeRemote) labelSyncPeers() []peer.ID {
+	localPeerID := r.server.Host().ID()
+
+	var peers []peer.ID
+
+	for _, peerID := range r.server.Host().Network().Peers() {
+		if peerID == localPeerID || r.reputation.IsExcluded(peerID.String()) || !r.service.SupportsLabelSync(peerID) {
+			continue
+		}
+
+		peers = append(peers, peerID)
+	}
+
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+
+	if len(peers) > MaxLabelSyncPeers {
+		peers = peers[:MaxLabelSyncPeers]
+	}
+
+	return peers
+}
+
+// syncLabelsFrom caches the labels of the records the peer published or updated since
+// the given time of the peer, page by page. A zero since syncs all its records.
+// Returns the time of the peer to sync changes from next time and the number of cached labels.
+func (r *routeRemote) syncLabelsFrom(ctx context.Context, peerID peer.ID, since int64) (int64, int, error) {
+	var syncedAt int64
+
+	cached := 0
+	cursor := ""
+
+	for range MaxLabelSyncPages {
+		resp, err := r.service.SyncLabels(ctx, peerID, &rpc.LabelSyncRequest{Since: since, Cursor: cursor, Limit: LabelSyncPageSize})
+		if err != nil {
+			return 0, cached, fmt.Errorf("failed to fetch published labels: %w", err)
+		}
+
+		// Changes made while the pages are fetched are synced next time
+		if syncedAt == 0 {
+			syncedAt = resp.SyncedAt
+		}
+
+		cached += r.importLabelSync(ctx, peerID.String(), resp.Records)
+
+		if resp.NextCursor == "" {
+			return syncedAt, cached, nil
+		}
+
+		if resp.NextCursor <= cursor {
+			return 0, cached, errors.New("label sync cursor did not advance")
+		}
+
+		cursor = resp.NextCursor
+	}
+
+	return 0, cached, fmt.Errorf("peer published more than %d pages of records", MaxLabelSyncPages)
+}
+
+// importLabelSync caches the labels of synced records like announcements of the peer.
+// Invalid records and records that are revoked or expired are skipped.
+// Returns the number of cached labels.
+func (r *routeRemote) importLabelSync(ctx context.Context, peerID string, records []rpc.LabelSyncRecord) int {
+	cached := 0
+
+	for _, record := range records {
+		metrics.AnnouncementsReceived.WithLabelValues(metrics.TransportLabelSync).Inc()
+
+		now := time.Now()
+		event := &pubsub.RecordPublishEvent{
+			CID:           record.Cid,
+			Labels:        record.Labels,
+			Timestamp:     time.Unix(0, record.UpdatedAt),
+			Supersedes:    record.Supersedes,
+			RecordSummary: record.Summary,
+		}
+
+		if record.UpdatedAt <= 0 || event.Validate() != nil {
+			metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportLabelSync, metrics.RejectInvalid).Inc()
+
+			continue
+		}
+
+		if r.isRevoked(ctx, record.Cid, peerID) {
+			metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportLabelSync, metrics.RejectRevoked).Inc()
+
+			continue
+		}
+
+		metadata := types.LabelMetadata{
+			Timestamp:     event.Timestamp,
+			LastSeen:      now,
+			Supersedes:    record.Supersedes,
+			AccessGated:   record.AccessGated,
+			Size:          record.Size,
+			RecordSummary: record.Summary,
+		}
+		if record.ExpiresAt > 0 {
+			metadata.ExpiresAt = time.Unix(record.ExpiresAt, 0).UTC()
+		}
+
+		if metadata.IsExpired(now) {
+			metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportLabelSync, metrics.RejectExpired).Inc()
+
+			continue
+		}
+
+		// The peer's timestamps are bounded to the local clock
+		clampToLocalClock(&metadata, now)
+
+		metadataBytes, err := json.Marshal(&metadata)
+		if err != nil {
+			continue
+		}
+
+		labels := &cacheMutation{}
+		for _, label := range remoteLabels(record.Labels) {
+			labels.put(BuildEnhancedLabelKey(label, record.Cid, peerID), metadataBytes)
+		}
+
+		announcement := newProvenanceEntry(record.Cid, peerID, metrics.TransportLabelSync, &metadata, false, now)
+
+		if err := r.cacheRemoteLabels(ctx, peerID, labels, announcement); err != nil {
+			remoteLogger.Warn("Failed to cache synced labels", "cid", record.Cid, "peer", peerID, "error", err)
+
+			continue
+		}
+
+		cached += len(labels.Puts)
+	}
+
+	return cached
+}
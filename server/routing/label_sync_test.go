@@ -0,0 +1,105 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func labelSyncEntry(t *testing.T, label, cid, peerID string, metadata types.LabelMetadata) NamespaceEntry {
+	t.Helper()
+
+	value, err := json.Marshal(&metadata)
+	require.NoError(t, err)
+
+	return NamespaceEntry{Key: BuildEnhancedLabelKey(types.Label(label), cid, peerID), Value: value}
+}
+
+func TestLabelSyncPage(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-time.Hour)
+
+	entries := []NamespaceEntry{
+		labelSyncEntry(t, "/skills/AI", "cid3", "local", types.LabelMetadata{Timestamp: old}),
+		labelSyncEntry(t, "/skills/AI", "cid1", "local", types.LabelMetadata{Timestamp: old}),
+		labelSyncEntry(t, "/domains/research", "cid1", "local", types.LabelMetadata{Timestamp: now, Supersedes: "cid0"}),
+		labelSyncEntry(t, "/skills/AI", "cid2", "local", types.LabelMetadata{Timestamp: old, AccessGated: true}),
+		labelSyncEntry(t, "/skills/AI", "cid4", "local", types.LabelMetadata{Timestamp: now, ExpiresAt: old}),
+		labelSyncEntry(t, "/skills/AI", "cid5", "remote", types.LabelMetadata{Timestamp: now}),
+	}
+
+	// Full sync pages through unexpired local records in CID order
+	page := labelSyncPage(entries, "local", time.Time{}, "", 2, now)
+	require.Len(t, page.Records, 2)
+	assert.Equal(t, "cid1", page.Records[0].Cid)
+	assert.ElementsMatch(t, []string{"/skills/AI", "/domains/research"}, page.Records[0].Labels)
+	assert.Equal(t, now.UnixNano(), page.Records[0].UpdatedAt)
+	assert.Equal(t, "cid0", page.Records[0].Supersedes)
+	assert.True(t, page.Records[1].AccessGated)
+	assert.Equal(t, "cid2", page.NextCursor)
+	assert.Equal(t, now.UnixNano(), page.SyncedAt)
+
+	page = labelSyncPage(entries, "local", time.Time{}, page.NextCursor, 2, now)
+	require.Len(t, page.Records, 1)
+	assert.Equal(t, "cid3", page.Records[0].Cid)
+	assert.Empty(t, page.NextCursor)
+
+	// Diffs only return records updated since the previous sync
+	page = labelSyncPage(entries, "local", now.Add(-time.Minute), "", 10, now)
+	require.Len(t, page.Records, 1)
+	assert.Equal(t, "cid1", page.Records[0].Cid)
+}
+
+func TestImportLabelSync(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore:       dstore,
+		peerStats:    peerstats.New(),
+		cardinality:  cardinality.NewIndex(),
+		providerSets: providerset.NewIndex(),
+		lineage:      newLineageIndex(),
+	}
+
+	updatedAt := time.Now().Add(-time.Minute)
+	expiresAt := time.Now().Add(time.Hour)
+
+	cached := r.importLabelSync(t.Context(), "peer1", []rpc.LabelSyncRecord{
+		{
+			Cid:        "cid2",
+			Labels:     []string{"/skills/AI", "/domains/research"},
+			UpdatedAt:  updatedAt.UnixNano(),
+			ExpiresAt:  expiresAt.Unix(),
+			Supersedes: "cid1",
+		},
+		{Cid: "cid3", UpdatedAt: updatedAt.UnixNano()},
+		{Cid: "cid4", Labels: []string{"/skills/AI"}},
+		{Cid: "cid5", Labels: []string{"/skills/AI"}, UpdatedAt: updatedAt.UnixNano(), ExpiresAt: updatedAt.Unix()},
+	})
+	assert.Equal(t, 2, cached)
+
+	value, err := dstore.Get(t.Context(), ipfsdatastore.NewKey(BuildEnhancedLabelKey("/skills/AI", "cid2", "peer1")))
+	require.NoError(t, err)
+
+	var metadata types.LabelMetadata
+	require.NoError(t, json.Unmarshal(value, &metadata))
+	assert.True(t, metadata.Timestamp.Equal(updatedAt))
+	assert.Equal(t, expiresAt.Unix(), metadata.ExpiresAt.Unix())
+	assert.Equal(t, "cid1", metadata.Supersedes)
+
+	assert.True(t, r.lineage.isSuperseded("cid1", "peer1"))
+	assert.Equal(t, int64(2), r.peerStats.TotalLabels())
+}
@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/taxonomy"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newLabelTaxonomy loads the label taxonomy from config and sets it as the label normalizer,
+// so that labels are normalized both when records are published and when announcements of
+// other peers are received. Without a configured taxonomy labels are not normalized.
+func newLabelTaxonomy(cfg routingconfig.TaxonomyConfig) (*taxonomy.Taxonomy, error) {
+	if cfg.Path == "" {
+		types.SetLabelNormalizer(nil)
+
+		return nil, nil //nolint:nilnil
+	}
+
+	labelTaxonomy, err := taxonomy.Load(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load label taxonomy: %w", err)
+	}
+
+	types.SetLabelNormalizer(labelTaxonomy.Normalizer(cfg.Strict))
+
+	localLogger.Info("Loaded label taxonomy",
+		"version", labelTaxonomy.Version(),
+		"namespaces", labelTaxonomy.Namespaces(),
+		"strict", cfg.Strict)
+
+	return labelTaxonomy, nil
+}
+
+// GetTaxonomy returns the values of the label taxonomy, optionally limited to
+// some namespaces and to values starting with a prefix.
+func (r *route) GetTaxonomy(_ context.Context, req *routingv1.GetTaxonomyRequest) (*routingv1.GetTaxonomyResponse, error) {
+	if r.taxonomy == nil {
+		return nil, status.Error(codes.FailedPrecondition, "no label taxonomy is configured") //nolint:wrapcheck
+	}
+
+	namespaces := r.taxonomy.Namespaces()
+
+	if len(req.GetNamespaces()) > 0 {
+		namespaces = nil
+
+		for _, name := range req.GetNamespaces() {
+			lt, ok := types.ParseLabelType(name)
+			if !ok || r.taxonomy.Values(lt) == nil {
+				return nil, status.Errorf(codes.InvalidArgument, "namespace %q is not covered by the label taxonomy", name) //nolint:wrapcheck
+			}
+
+			namespaces = append(namespaces, lt)
+		}
+	}
+
+	prefix := strings.ToLower(req.GetPrefix())
+
+	resp := &routingv1.GetTaxonomyResponse{Version: r.taxonomy.Version()}
+
+	for _, lt := range namespaces {
+		namespace := &routingv1.TaxonomyNamespace{Name: lt.String()}
+
+		for _, value := range r.taxonomy.Values(lt) {
+			if strings.HasPrefix(value, prefix) {
+				namespace.Values = append(namespace.Values, value)
+			}
+		}
+
+		resp.Namespaces = append(resp.Namespaces, namespace)
+	}
+
+	return resp, nil
+}
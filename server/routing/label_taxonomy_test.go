@@ -0,0 +1,61 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetTaxonomy(t *testing.T) {
+	t.Cleanup(func() { types.SetLabelNormalizer(nil) })
+
+	path := filepath.Join(t.TempDir(), "taxonomy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"version": "1.0.0",
+		"namespaces": {"skills": ["nlp/text_generation", "images"], "domains": ["finance"]}
+	}`), 0o600))
+
+	labelTaxonomy, err := newLabelTaxonomy(routingconfig.TaxonomyConfig{Path: path, Strict: true})
+	require.NoError(t, err)
+
+	// Remote labels are normalized and labels outside the taxonomy are dropped
+	assert.Equal(t, []types.Label{"/skills/nlp", "/modules/a"}, remoteLabels([]string{"/skills/NLP", "/skills/audio", "/modules/a", "/skills/nlp"}))
+
+	r := &route{taxonomy: labelTaxonomy}
+
+	resp, err := r.GetTaxonomy(t.Context(), &routingv1.GetTaxonomyRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", resp.GetVersion())
+	require.Len(t, resp.GetNamespaces(), 2)
+	assert.Equal(t, "skills", resp.GetNamespaces()[0].GetName())
+	assert.Equal(t, []string{"images", "nlp", "nlp/text_generation"}, resp.GetNamespaces()[0].GetValues())
+	assert.Equal(t, "domains", resp.GetNamespaces()[1].GetName())
+
+	resp, err = r.GetTaxonomy(t.Context(), &routingv1.GetTaxonomyRequest{Namespaces: []string{"skills"}, Prefix: "NLP/"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetNamespaces(), 1)
+	assert.Equal(t, []string{"nlp/text_generation"}, resp.GetNamespaces()[0].GetValues())
+
+	_, err = r.GetTaxonomy(t.Context(), &routingv1.GetTaxonomyRequest{Namespaces: []string{"modules"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Without a taxonomy labels are not normalized
+	labelTaxonomy, err = newLabelTaxonomy(routingconfig.TaxonomyConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, labelTaxonomy)
+	assert.Equal(t, []types.Label{"/skills/NLP"}, remoteLabels([]string{"/skills/NLP"}))
+
+	_, err = (&route{}).GetTaxonomy(t.Context(), &routingv1.GetTaxonomyRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
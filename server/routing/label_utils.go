@@ -19,6 +19,17 @@ func BuildEnhancedLabelKey(label types.Label, cid, peerID string) string {
 	return fmt.Sprintf("%s/%s/%s", label.String(), cid, peerID)
 }
 
+// remoteLabels converts the labels announced by another peer to labels, normalized
+// by the label taxonomy if one is configured.
+func remoteLabels(labelStrs []string) []types.Label {
+	labels := make([]types.Label, 0, len(labelStrs))
+	for _, label := range labelStrs {
+		labels = append(labels, types.Label(label))
+	}
+
+	return types.NormalizeLabels(labels)
+}
+
 // Example: "/skills/AI/ML/CID123/Peer1" → (Label("/skills/AI/ML"), "CID123", "Peer1", nil).
 func ParseEnhancedLabelKey(key string) (types.Label, string, string, error) {
 	labelStr, cid, peerID, err := parseEnhancedLabelKeyInternal(key)
@@ -0,0 +1,70 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labeldigest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+)
+
+// Filter is a Bloom filter of labels. It never reports a label it holds as absent,
+// and reports an absent label as present with about FalsePositiveRate.
+// Labels are hashed with SHA-256 so that filters built by different peers agree.
+type Filter struct {
+	bits   []byte
+	hashes uint
+}
+
+// NewFilter creates an empty filter sized for the given number of labels,
+// bounded by MinFilterSize and MaxFilterSize.
+func NewFilter(labels int) *Filter {
+	if labels <= 0 {
+		return &Filter{bits: make([]byte, MinFilterSize), hashes: 1}
+	}
+
+	// Optimal number of bits and hash functions for the false positive rate
+	size := int(math.Ceil(-float64(labels) * math.Log(FalsePositiveRate) / (math.Ln2 * math.Ln2) / 8)) //nolint:mnd
+	size = min(max(size, MinFilterSize), MaxFilterSize)
+
+	hashes := uint(math.Round(float64(size*8) / float64(labels) * math.Ln2)) //nolint:mnd
+	hashes = min(max(hashes, 1), MaxHashes)
+
+	return &Filter{bits: make([]byte, size), hashes: hashes}
+}
+
+// Add adds a label to the filter.
+func (f *Filter) Add(label string) {
+	for _, bit := range f.positions(label) {
+		f.bits[bit/8] |= 1 << (bit % 8) //nolint:mnd
+	}
+}
+
+// MayContain reports whether the label may have been added to the filter.
+func (f *Filter) MayContain(label string) bool {
+	for _, bit := range f.positions(label) {
+		if f.bits[bit/8]&(1<<(bit%8)) == 0 { //nolint:mnd
+			return false
+		}
+	}
+
+	return true
+}
+
+// positions returns the bits of a label, derived from two halves of its hash
+// with double hashing.
+func (f *Filter) positions(label string) []uint64 {
+	sum := sha256.Sum256([]byte(label))
+	h1 := binary.BigEndian.Uint64(sum[0:8])
+	h2 := binary.BigEndian.Uint64(sum[8:16]) | 1
+
+	size := uint64(len(f.bits)) * 8 //nolint:mnd
+	positions := make([]uint64, f.hashes)
+
+	for i := range positions {
+		positions[i] = (h1 + uint64(i)*h2) % size
+	}
+
+	return positions
+}
@@ -0,0 +1,155 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package labeldigest implements label digests: Bloom filters of the labels of
+// the records a peer serves to live searches.
+//
+// Peers periodically publish their digest via GossipSub, so that a live search
+// only fans out to the peers whose digest may contain the queried labels. As
+// queries match labels and their parent labels, every parent of a label is added
+// too, e.g. /skills/AI and /skills/AI/ML for /skills/AI/ML.
+package labeldigest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// FalsePositiveRate is the target rate of labels a filter wrongly reports as present.
+	FalsePositiveRate = 0.01
+
+	// MinFilterSize is the size of the filter of a peer without labels, in bytes.
+	MinFilterSize = 8
+
+	// MaxFilterSize bounds the filter size in bytes, so that digests fit GossipSub
+	// messages. Digests of larger label sets have a higher false positive rate.
+	MaxFilterSize = 6 * 1024 // 6KB
+
+	// MaxHashes bounds the number of hash functions of a filter.
+	MaxHashes = 16
+
+	// MaxSize is the maximum size of a marshalled digest.
+	MaxSize = 9 * 1024 // 9KB
+)
+
+// Digest summarizes the labels of a peer's records.
+//
+// Example wire format:
+//
+//	{
+//	  "filter": "AAAgAAQAAAA...",
+//	  "hashes": 7,
+//	  "labels": 42,
+//	  "timestamp": "2025-10-01T10:00:00Z"
+//	}
+type Digest struct {
+	// Filter holds the bits of the Bloom filter of the labels.
+	Filter []byte `json:"filter"`
+
+	// Hashes is the number of hash functions of the Bloom filter.
+	Hashes uint `json:"hashes"`
+
+	// Labels is the number of distinct labels added to the filter, including parents.
+	Labels int `json:"labels"`
+
+	// Timestamp is when the digest was built. Older digests of the same peer are ignored.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// New builds the digest of the given labels and their parents, issued now.
+func New(labels []string) *Digest {
+	expanded := make(map[string]struct{})
+
+	for _, label := range labels {
+		for _, parent := range parents(label) {
+			expanded[parent] = struct{}{}
+		}
+	}
+
+	filter := NewFilter(len(expanded))
+	for label := range expanded {
+		filter.Add(label)
+	}
+
+	return &Digest{
+		Filter:    filter.bits,
+		Hashes:    filter.hashes,
+		Labels:    len(expanded),
+		Timestamp: time.Now(),
+	}
+}
+
+// MayContain reports whether the digested labels may contain the label.
+func (d *Digest) MayContain(label string) bool {
+	return (&Filter{bits: d.Filter, hashes: d.Hashes}).MayContain(label)
+}
+
+// Validate checks that the digest is well-formed.
+func (d *Digest) Validate() error {
+	if len(d.Filter) < MinFilterSize || len(d.Filter) > MaxFilterSize {
+		return fmt.Errorf("filter size %d out of range [%d, %d]", len(d.Filter), MinFilterSize, MaxFilterSize)
+	}
+
+	if d.Hashes < 1 || d.Hashes > MaxHashes {
+		return fmt.Errorf("hashes %d out of range [1, %d]", d.Hashes, MaxHashes)
+	}
+
+	if d.Labels < 0 {
+		return errors.New("negative label count")
+	}
+
+	if d.Timestamp.IsZero() {
+		return errors.New("missing timestamp")
+	}
+
+	return nil
+}
+
+// Marshal serializes the digest to JSON.
+func (d *Digest) Marshal() ([]byte, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal label digest: %w", err)
+	}
+
+	if len(data) > MaxSize {
+		return nil, errors.New("label digest exceeds maximum size")
+	}
+
+	return data, nil
+}
+
+// Unmarshal deserializes a digest and checks that it is well-formed.
+func Unmarshal(data []byte) (*Digest, error) {
+	if len(data) > MaxSize {
+		return nil, errors.New("label digest exceeds maximum size")
+	}
+
+	var digest Digest
+	if err := json.Unmarshal(data, &digest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal label digest: %w", err)
+	}
+
+	if err := digest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid label digest: %w", err)
+	}
+
+	return &digest, nil
+}
+
+// parents returns the label and all its parent labels,
+// e.g. /skills, /skills/AI and /skills/AI/ML for /skills/AI/ML.
+func parents(label string) []string {
+	segments := strings.Split(strings.Trim(label, "/"), "/")
+	labels := make([]string, 0, len(segments))
+
+	for i := range segments {
+		labels = append(labels, "/"+strings.Join(segments[:i+1], "/"))
+	}
+
+	return labels
+}
@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labeldigest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest_ContainsLabelsAndParents(t *testing.T) {
+	digest := New([]string{"/skills/AI/ML", "/domains/research"})
+
+	for _, label := range []string{"/skills", "/skills/AI", "/skills/AI/ML", "/domains/research"} {
+		assert.True(t, digest.MayContain(label), label)
+	}
+
+	assert.False(t, digest.MayContain("/skills/AI/ML/deep"))
+	assert.False(t, digest.MayContain("/locators/docker-image"))
+	assert.Equal(t, 5, digest.Labels)
+}
+
+func TestDigest_FalsePositiveRate(t *testing.T) {
+	labels := make([]string, 1000)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("/label-%d", i)
+	}
+
+	digest := New(labels)
+
+	for _, label := range labels {
+		require.True(t, digest.MayContain(label))
+	}
+
+	falsePositives := 0
+
+	for i := range 10000 {
+		if digest.MayContain(fmt.Sprintf("/other-%d", i)) {
+			falsePositives++
+		}
+	}
+
+	assert.Less(t, falsePositives, 300)
+}
+
+func TestDigest_FilterSizeIsBounded(t *testing.T) {
+	assert.Len(t, New(nil).Filter, MinFilterSize)
+
+	labels := make([]string, 100000)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("/label-%d", i)
+	}
+
+	digest := New(labels)
+	assert.Len(t, digest.Filter, MaxFilterSize)
+
+	data, err := digest.Marshal()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(data), MaxSize)
+}
+
+func TestUnmarshal(t *testing.T) {
+	digest := New([]string{"/skills/AI"})
+
+	data, err := digest.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.True(t, decoded.MayContain("/skills/AI"))
+
+	invalid := *digest
+	invalid.Hashes = 0
+
+	data, err = invalid.Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(data)
+	assert.ErrorContains(t, err, "hashes")
+}
@@ -0,0 +1,387 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package labelindex implements a relational index of the label cache.
+//
+// The label cache is a flat key-value datastore of enhanced label keys
+// (/<namespace>/<label>/<cid>/<peer-id>), which can only be queried by key prefix.
+// The index mirrors these keys into a table with one row per key, so that records
+// whose labels satisfy AND/OR/NOT combinations of label queries, and their counts,
+// are found with a single SQL query instead of a scan of the label cache.
+//
+// The index is a mirror: the datastore remains the source of truth, and the index
+// can be rebuilt from it at any time with Reset.
+package labelindex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Supported database drivers.
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// batchSize is the number of rows inserted per statement.
+const batchSize = 500
+
+// Entry is an enhanced label key of the label cache, split into its parts.
+type Entry struct {
+	// Key is the enhanced label key, including the tenant's namespace of tenant labels.
+	Key string `gorm:"primaryKey"`
+
+	// Tenant is the tenant of the record, empty if untenanted.
+	Tenant string `gorm:"index:idx_label_index_label,priority:2"`
+
+	// Label is the label without the tenant's namespace, e.g. "/skills/AI/ML".
+	Label string `gorm:"index:idx_label_index_label,priority:1"`
+
+	// CID of the labeled record.
+	CID string `gorm:"column:cid;index"`
+
+	// PeerID of the peer providing the record.
+	PeerID string `gorm:"index"`
+}
+
+// TableName returns the name of the index table.
+func (Entry) TableName() string {
+	return "label_index_entries"
+}
+
+// Filter restricts the entries considered by a query.
+type Filter struct {
+	// Tenants whose entries are considered; "" selects untenanted records.
+	// All entries are considered if empty.
+	Tenants []string
+
+	// ExcludePeer excludes the entries of a peer, e.g. of the local peer.
+	ExcludePeer string
+}
+
+// Index is a relational index of enhanced label keys.
+type Index interface {
+	// Apply adds and removes entries in a single transaction.
+	// Adding an existing entry or removing a missing one is not an error.
+	Apply(ctx context.Context, puts []Entry, deletes []string) error
+
+	// Reset replaces all entries of the index.
+	Reset(ctx context.Context, entries []Entry) error
+
+	// Len returns the number of entries of the index.
+	Len(ctx context.Context) (int64, error)
+
+	// Match returns the CIDs of the records for which at least minMatch of the conditions
+	// hold over the labels of all their entries selected by the filter, in CID order.
+	Match(ctx context.Context, conditions []Condition, minMatch int, filter Filter) ([]string, error)
+
+	// Count returns the number of records Match returns.
+	Count(ctx context.Context, conditions []Condition, minMatch int, filter Filter) (int64, error)
+
+	// Close closes the connection to the database.
+	Close() error
+}
+
+// sqlIndex is an Index stored in a SQLite or Postgres database.
+type sqlIndex struct {
+	db *gorm.DB
+}
+
+// Open connects to the database of an index, creating its table if needed.
+// The DSN is a file path for SQLite and a connection string for Postgres.
+func Open(driver, dsn string) (Index, error) {
+	var dialector gorm.Dialector
+
+	switch driver {
+	case DriverSQLite:
+		dialector = sqlite.Open(dsn)
+	case DriverPostgres:
+		dialector = postgres.Open(dsn)
+	default:
+		return nil, fmt.Errorf("unsupported label index driver %q, must be %q or %q", driver, DriverSQLite, DriverPostgres)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: gormlogger.New(
+			log.New(os.Stdout, "\r\n", log.LstdFlags),
+			gormlogger.Config{
+				SlowThreshold: 200 * time.Millisecond, //nolint:mnd
+				LogLevel:      gormlogger.Warn,
+			},
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to label index database: %w", err)
+	}
+
+	if err := db.AutoMigrate(Entry{}); err != nil {
+		return nil, fmt.Errorf("failed to migrate label index schema: %w", err)
+	}
+
+	return &sqlIndex{db: db}, nil
+}
+
+func (i *sqlIndex) Apply(ctx context.Context, puts []Entry, deletes []string) error {
+	if len(puts) == 0 && len(deletes) == 0 {
+		return nil
+	}
+
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(deletes) > 0 {
+			for start := 0; start < len(deletes); start += batchSize {
+				if err := tx.Where("key IN ?", deletes[start:min(start+batchSize, len(deletes))]).Delete(&Entry{}).Error; err != nil {
+					return err //nolint:wrapcheck
+				}
+			}
+		}
+
+		if len(puts) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(puts, batchSize).Error; err != nil {
+				return err //nolint:wrapcheck
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update label index: %w", err)
+	}
+
+	return nil
+}
+
+func (i *sqlIndex) Reset(ctx context.Context, entries []Entry) error {
+	err := i.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&Entry{}).Error; err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(entries, batchSize).Error //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset label index: %w", err)
+	}
+
+	return nil
+}
+
+func (i *sqlIndex) Len(ctx context.Context) (int64, error) {
+	var count int64
+	if err := i.db.WithContext(ctx).Model(&Entry{}).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count label index entries: %w", err)
+	}
+
+	return count, nil
+}
+
+func (i *sqlIndex) Match(ctx context.Context, conditions []Condition, minMatch int, filter Filter) ([]string, error) {
+	matches, err := i.matches(ctx, conditions, minMatch, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var cids []string
+	if err := matches.Order("cid").Pluck("cid", &cids).Error; err != nil {
+		return nil, fmt.Errorf("failed to query label index: %w", err)
+	}
+
+	return cids, nil
+}
+
+func (i *sqlIndex) Count(ctx context.Context, conditions []Condition, minMatch int, filter Filter) (int64, error) {
+	matches, err := i.matches(ctx, conditions, minMatch, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err := i.db.WithContext(ctx).Table("(?) AS matches", matches).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count label index matches: %w", err)
+	}
+
+	return count, nil
+}
+
+// matches builds the query selecting the CIDs of the records matching the conditions.
+// Each condition is an aggregate over the labels of a record, so that the conditions
+// are evaluated over all labels of a record at once.
+func (i *sqlIndex) matches(ctx context.Context, conditions []Condition, minMatch int, filter Filter) (*gorm.DB, error) {
+	if len(conditions) == 0 {
+		return nil, errors.New("no conditions")
+	}
+
+	scores := make([]string, 0, len(conditions))
+
+	var args []any
+
+	for _, condition := range conditions {
+		expr, exprArgs, err := condition.aggregate()
+		if err != nil {
+			return nil, err
+		}
+
+		scores = append(scores, expr)
+		args = append(args, exprArgs...)
+	}
+
+	args = append(args, minMatch)
+
+	query := i.db.WithContext(ctx).Model(&Entry{}).Select("cid")
+
+	if len(filter.Tenants) > 0 {
+		query = query.Where("tenant IN ?", filter.Tenants)
+	}
+
+	if filter.ExcludePeer != "" {
+		query = query.Where("peer_id <> ?", filter.ExcludePeer)
+	}
+
+	return query.Group("cid").Having("("+strings.Join(scores, " + ")+") >= ?", args...), nil
+}
+
+// Close closes the connection to the database.
+func (i *sqlIndex) Close() error {
+	db, err := i.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get label index database: %w", err)
+	}
+
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("failed to close label index database: %w", err)
+	}
+
+	return nil
+}
+
+// Condition is a boolean expression over the labels of a record.
+// Conditions are built with Label, ExactLabel, All, And, Or and Not.
+type Condition struct {
+	op       conditionOp
+	label    string
+	children []Condition
+}
+
+type conditionOp int
+
+const (
+	opLabel conditionOp = iota
+	opExactLabel
+	opAll
+	opAnd
+	opOr
+	opNot
+)
+
+// Label holds if the record has the label or a label below it,
+// e.g. Label("/skills/AI") holds for "/skills/AI" and "/skills/AI/ML".
+func Label(label string) Condition {
+	return Condition{op: opLabel, label: label}
+}
+
+// ExactLabel holds if the record has the label.
+func ExactLabel(label string) Condition {
+	return Condition{op: opExactLabel, label: label}
+}
+
+// All holds for all records.
+func All() Condition {
+	return Condition{op: opAll}
+}
+
+// And holds if all of the conditions hold.
+func And(conditions ...Condition) Condition {
+	return Condition{op: opAnd, children: conditions}
+}
+
+// Or holds if any of the conditions holds.
+func Or(conditions ...Condition) Condition {
+	return Condition{op: opOr, children: conditions}
+}
+
+// Not holds if none of the conditions holds.
+func Not(conditions ...Condition) Condition {
+	return Condition{op: opNot, children: conditions}
+}
+
+// HasNot reports whether the condition negates another condition. Conditions without
+// negation hold for a record if they hold for a subset of its labels, so they can select
+// candidates from a superset of the labels used for the final matching.
+func (c Condition) HasNot() bool {
+	if c.op == opNot {
+		return true
+	}
+
+	for _, child := range c.children {
+		if child.HasNot() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// aggregate returns a SQL aggregate expression evaluating to 1 for records the condition
+// holds for and to 0 for the others, with its arguments.
+func (c Condition) aggregate() (string, []any, error) {
+	switch c.op {
+	case opLabel:
+		prefix := c.label + "/"
+
+		// substr counts characters in both SQLite and Postgres, and unlike LIKE
+		// it is case-sensitive in both and needs no escaping
+		return "MAX(CASE WHEN label = ? OR substr(label, 1, ?) = ? THEN 1 ELSE 0 END)",
+			[]any{c.label, utf8.RuneCountInString(prefix), prefix}, nil
+	case opExactLabel:
+		return "MAX(CASE WHEN label = ? THEN 1 ELSE 0 END)", []any{c.label}, nil
+	case opAll:
+		return "1", nil, nil
+	case opAnd, opOr, opNot:
+		if len(c.children) == 0 {
+			return "", nil, errors.New("empty condition group")
+		}
+
+		exprs := make([]string, 0, len(c.children))
+
+		var args []any
+
+		for _, child := range c.children {
+			expr, childArgs, err := child.aggregate()
+			if err != nil {
+				return "", nil, err
+			}
+
+			exprs = append(exprs, expr)
+			args = append(args, childArgs...)
+		}
+
+		sum := "(" + strings.Join(exprs, " + ") + ")"
+
+		switch c.op {
+		case opAnd:
+			return fmt.Sprintf("CASE WHEN %s = %d THEN 1 ELSE 0 END", sum, len(exprs)), args, nil
+		case opOr:
+			return fmt.Sprintf("CASE WHEN %s > 0 THEN 1 ELSE 0 END", sum), args, nil
+		default:
+			return fmt.Sprintf("CASE WHEN %s = 0 THEN 1 ELSE 0 END", sum), args, nil
+		}
+	default:
+		return "", nil, fmt.Errorf("unknown condition %d", c.op)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package labelindex
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestIndex(t *testing.T) Index {
+	t.Helper()
+
+	index, err := Open(DriverSQLite, filepath.Join(t.TempDir(), "labels.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = index.Close() })
+
+	return index
+}
+
+func entry(label, cid, peerID string) Entry {
+	return Entry{Key: label + "/" + cid + "/" + peerID, Label: label, CID: cid, PeerID: peerID}
+}
+
+func TestIndex_Match(t *testing.T) {
+	ctx := t.Context()
+	index := openTestIndex(t)
+
+	require.NoError(t, index.Apply(ctx, []Entry{
+		entry("/skills/AI/ML", "cid1", "peer1"),
+		entry("/domains/research", "cid1", "peer2"),
+		entry("/skills/AI/NLP", "cid2", "peer1"),
+		entry("/skills/AIOps", "cid3", "peer1"),
+		{Key: "/tenants/acme/skills/AI/cid4/peer1", Tenant: "acme", Label: "/skills/AI", CID: "cid4", PeerID: "peer1"},
+	}, nil))
+
+	tests := []struct {
+		name       string
+		conditions []Condition
+		minMatch   int
+		filter     Filter
+		want       []string
+	}{
+		{name: "prefix", conditions: []Condition{Label("/skills/AI")}, minMatch: 1, want: []string{"cid1", "cid2", "cid4"}},
+		{name: "exact", conditions: []Condition{ExactLabel("/skills/AI")}, minMatch: 1, want: []string{"cid4"}},
+		{name: "min match", conditions: []Condition{Label("/skills/AI"), Label("/domains/research")}, minMatch: 2, want: []string{"cid1"}},
+		{name: "and across peers", conditions: []Condition{And(Label("/skills/AI/ML"), Label("/domains/research"))}, minMatch: 1, want: []string{"cid1"}},
+		{name: "or", conditions: []Condition{Or(Label("/skills/AIOps"), Label("/domains"))}, minMatch: 1, want: []string{"cid1", "cid3"}},
+		{name: "not", conditions: []Condition{And(Label("/skills"), Not(Label("/domains/research")))}, minMatch: 1, want: []string{"cid2", "cid3", "cid4"}},
+		{name: "all", conditions: []Condition{All()}, minMatch: 1, want: []string{"cid1", "cid2", "cid3", "cid4"}},
+		{name: "tenants", conditions: []Condition{Label("/skills/AI")}, minMatch: 1, filter: Filter{Tenants: []string{"acme"}}, want: []string{"cid4"}},
+		{name: "excluded peer", conditions: []Condition{Or(Label("/skills"), Label("/domains"))}, minMatch: 1, filter: Filter{ExcludePeer: "peer1"}, want: []string{"cid1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cids, err := index.Match(ctx, tt.conditions, tt.minMatch, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cids)
+
+			count, err := index.Count(ctx, tt.conditions, tt.minMatch, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(tt.want)), count)
+		})
+	}
+}
+
+func TestIndex_ApplyAndReset(t *testing.T) {
+	ctx := t.Context()
+	index := openTestIndex(t)
+
+	first := entry("/skills/AI", "cid1", "peer1")
+	second := entry("/skills/AI", "cid2", "peer1")
+
+	// Applying is idempotent, so that replayed mutations keep the index consistent
+	require.NoError(t, index.Apply(ctx, []Entry{first, second}, nil))
+	require.NoError(t, index.Apply(ctx, []Entry{first}, []string{second.Key, "/skills/missing/cid9/peer1"}))
+
+	count, err := index.Len(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, index.Reset(ctx, []Entry{second}))
+
+	cids, err := index.Match(ctx, []Condition{Label("/skills")}, 1, Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cid2"}, cids)
+}
+
+func TestCondition_HasNot(t *testing.T) {
+	assert.False(t, And(Label("/a"), Or(ExactLabel("/b"), All())).HasNot())
+	assert.True(t, Or(Label("/a"), And(Not(Label("/b")))).HasNot())
+}
+
+func TestOpen_RejectsUnknownDriver(t *testing.T) {
+	_, err := Open("mysql", "dsn")
+	assert.ErrorContains(t, err, "unsupported label index driver")
+}
@@ -0,0 +1,253 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// serveLiveSearch searches the local records of this peer for a remote live search.
+// Records are matched against their local labels with the same OR logic as Search.
+// Records of tenants are not served, as the request does not carry a tenant.
+func (r *routeRemote) serveLiveSearch(ctx context.Context, queries []*routingv1.RecordQuery, minMatchScore uint32, limit int) ([]rpc.SearchResult, error) {
+	if minMatchScore < DefaultMinMatchScore {
+		minMatchScore = DefaultMinMatchScore
+	}
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return nil, err
+	}
+
+	return matchLocalRecords(sharedEntries(entries), r.server.Host().ID().String(), queries, minMatchScore, limit), nil
+}
+
+// matchLocalRecords returns up to limit unexpired records of localPeerID whose labels
+// match at least minMatchScore queries.
+func matchLocalRecords(entries []NamespaceEntry, localPeerID string, queries []*routingv1.RecordQuery, minMatchScore uint32, limit int) []rpc.SearchResult {
+	// Group local labels by record, keeping the first-seen record order
+	var cids []string
+
+	labelsByCID := make(map[string][]types.Label)
+	gated := make(map[string]bool)
+	sizes := make(map[string]uint64)
+	summaries := make(map[string]types.RecordSummary)
+	now := time.Now()
+
+	for _, entry := range entries {
+		label, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID != localPeerID || labelExpired(entry.Value, now) {
+			continue
+		}
+
+		if _, ok := labelsByCID[keyCID]; !ok {
+			cids = append(cids, keyCID)
+		}
+
+		labelsByCID[keyCID] = append(labelsByCID[keyCID], label)
+		gated[keyCID] = gated[keyCID] || labelAccessGated(entry.Value)
+		sizes[keyCID] = max(sizes[keyCID], labelContentSize(entry.Value))
+
+		if summary := labelRecordSummary(entry.Value); summary != (types.RecordSummary{}) {
+			summaries[keyCID] = summary
+		}
+	}
+
+	var results []rpc.SearchResult
+
+	for _, cid := range cids {
+		if len(results) >= limit {
+			break
+		}
+
+		labels := labelsByCID[cid]
+		if _, score := matchScoreForLabels(queries, labels); score < minMatchScore {
+			continue
+		}
+
+		labelStrs := make([]string, len(labels))
+		for i, label := range labels {
+			labelStrs[i] = label.String()
+		}
+
+		results = append(results, rpc.SearchResult{
+			Cid:         cid,
+			Labels:      labelStrs,
+			AccessGated: gated[cid],
+			Size:        sizes[cid],
+			Summary:     summaries[cid],
+		})
+	}
+
+	return results
+}
+
+// searchLivePeers fans out a live search to the connected peers and streams
+// the records that were not already returned from the label cache.
+// Peers are queried with bounded concurrency (LiveSearchConcurrency) and each
+// call is bounded by LiveSearchTimeout; failing peers are skipped.
+// processedCIDs holds the records already returned and is updated with the live results.
+// Peers that cannot serve the retrieval method required by the caller are not queried,
+// nor peers whose label digest cannot contain enough of the queried labels.
+func (r *routeRemote) searchLivePeers(
+	ctx context.Context,
+	queries []*routingv1.RecordQuery,
+	limit uint32,
+	minMatchScore uint32,
+	processedCIDs map[string]bool,
+	retrieval *retrievalFilter,
+	zones *zoneFilter,
+	scorer scoringStrategy,
+	outCh chan<- *routingv1.SearchResponse,
+) {
+	limitInt := int(limit)
+	if limitInt > 0 && len(processedCIDs) >= limitInt {
+		return
+	}
+
+	peers := r.livePeers()
+	if len(peers) == 0 {
+		return
+	}
+
+	remoteLogger.Debug("Starting live search fan-out", "peers", len(peers), "queries", len(queries))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Serialize merging of responses so limit and deduplication hold across peers
+	var mu sync.Mutex
+
+	sem := make(chan struct{}, LiveSearchConcurrency)
+
+	var wg sync.WaitGroup
+
+	for _, peerID := range peers {
+		// Do not query peers whose records could not be retrieved by the caller
+		if !retrieval.allows(ctx, peerID.String()) {
+			continue
+		}
+
+		// Do not query peers outside the zones the caller restricted the search to
+		if zones.excludes(ctx, peerID.String()) {
+			continue
+		}
+
+		// Do not query peers whose label digest rules out matching records
+		if !r.liveSearchPeerMayMatch(peerID.String(), queries, minMatchScore) {
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+
+		go func(peerID peer.ID) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			callCtx, callCancel := context.WithTimeout(ctx, LiveSearchTimeout)
+			defer callCancel()
+
+			results, err := r.service.Search(callCtx, peerID, queries, minMatchScore, limitInt)
+			if err != nil {
+				remoteLogger.Debug("Live search failed for peer", "peer", peerID, "error", err)
+
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, result := range results {
+				if limitInt > 0 && len(processedCIDs) >= limitInt {
+					cancel()
+
+					return
+				}
+
+				if result.Cid == "" || processedCIDs[result.Cid] {
+					continue
+				}
+
+				labels := remoteLabels(result.Labels)
+
+				// Re-score locally, the remote peer is not trusted to apply the threshold
+				matchQueries, score := matchScoreForLabels(queries, labels)
+				if score < minMatchScore {
+					continue
+				}
+
+				select {
+				case outCh <- &routingv1.SearchResponse{
+					RecordRef:    &corev1.RecordRef{Cid: result.Cid},
+					Peer:         r.createPeerInfo(ctx, peerID.String()),
+					MatchQueries: matchQueries,
+					MatchScore:   score,
+					Relevance: scorer.relevance(scoredResult{
+						matchQueries: matchQueries,
+						queries:      len(queries),
+						peerID:       peerID.String(),
+						providers:    r.providerCount(result.Cid),
+					}),
+					AccessGated:   result.AccessGated,
+					ContentDigest: contentDigest(result.Cid),
+					ContentSize:   result.Size,
+					Name:          result.Summary.Name,
+					Version:       result.Summary.Version,
+					Description:   result.Summary.Description,
+				}:
+				case <-ctx.Done():
+					return
+				}
+
+				processedCIDs[result.Cid] = true
+			}
+		}(peerID)
+	}
+
+	wg.Wait()
+
+	remoteLogger.Debug("Completed live search fan-out", "processed", len(processedCIDs))
+}
+
+// livePeers returns the connected peers to fan out a live search to,
+// excluding peers with a low reputation, in a deterministic order.
+func (r *routeRemote) livePeers() []peer.ID {
+	localPeerID := r.server.Host().ID()
+
+	var peers []peer.ID
+
+	for _, peerID := range r.server.Host().Network().Peers() {
+		if peerID == localPeerID || r.reputation.IsExcluded(peerID.String()) {
+			continue
+		}
+
+		peers = append(peers, peerID)
+	}
+
+	sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+
+	if len(peers) > MaxLiveSearchPeers {
+		peers = peers[:MaxLiveSearchPeers]
+	}
+
+	return peers
+}
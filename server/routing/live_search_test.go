@@ -0,0 +1,92 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchLocalRecords(t *testing.T) {
+	labels := []struct {
+		label  string
+		cid    string
+		peerID string
+	}{
+		{"/skills/AI", "cid-ai", testLocalPeerID},
+		{"/domains/research", "cid-ai", testLocalPeerID},
+		{"/skills/AI", "cid-remote", "remote-peer"},
+		{"/skills/ML", "cid-ml", testLocalPeerID},
+		{"/skills/AI", "cid-ai-2", testLocalPeerID},
+	}
+
+	entries := make([]NamespaceEntry, 0, len(labels))
+	for _, l := range labels {
+		entries = append(entries, NamespaceEntry{Key: BuildEnhancedLabelKey(types.Label(l.label), l.cid, l.peerID)})
+	}
+
+	aiQuery := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}
+	researchQuery := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+
+	t.Run("only local records matching the queries", func(t *testing.T) {
+		results := matchLocalRecords(entries, testLocalPeerID, []*routingv1.RecordQuery{aiQuery}, 1, 10)
+		assert.Equal(t, []rpc.SearchResult{
+			{Cid: "cid-ai", Labels: []string{"/skills/AI", "/domains/research"}},
+			{Cid: "cid-ai-2", Labels: []string{"/skills/AI"}},
+		}, results)
+	})
+
+	t.Run("min match score", func(t *testing.T) {
+		results := matchLocalRecords(entries, testLocalPeerID, []*routingv1.RecordQuery{aiQuery, researchQuery}, 2, 10)
+		assert.Len(t, results, 1)
+		assert.Equal(t, "cid-ai", results[0].Cid)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		results := matchLocalRecords(entries, testLocalPeerID, []*routingv1.RecordQuery{aiQuery}, 1, 1)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("expired records", func(t *testing.T) {
+		expired, err := json.Marshal(&types.LabelMetadata{
+			Timestamp: time.Now().Add(-time.Hour),
+			LastSeen:  time.Now().Add(-time.Hour),
+			ExpiresAt: time.Now().Add(-time.Minute),
+		})
+		require.NoError(t, err)
+
+		withExpired := append([]NamespaceEntry{}, entries...)
+		withExpired[len(withExpired)-1].Value = expired
+
+		results := matchLocalRecords(withExpired, testLocalPeerID, []*routingv1.RecordQuery{aiQuery}, 1, 10)
+		assert.Equal(t, []rpc.SearchResult{
+			{Cid: "cid-ai", Labels: []string{"/skills/AI", "/domains/research"}},
+		}, results)
+	})
+
+	t.Run("access-gated records", func(t *testing.T) {
+		gated, err := json.Marshal(&types.LabelMetadata{
+			Timestamp:   time.Now(),
+			LastSeen:    time.Now(),
+			AccessGated: true,
+		})
+		require.NoError(t, err)
+
+		withGated := append([]NamespaceEntry{}, entries...)
+		withGated[len(withGated)-1].Value = gated
+
+		results := matchLocalRecords(withGated, testLocalPeerID, []*routingv1.RecordQuery{aiQuery}, 1, 10)
+		assert.Equal(t, []rpc.SearchResult{
+			{Cid: "cid-ai", Labels: []string{"/skills/AI", "/domains/research"}},
+			{Cid: "cid-ai-2", Labels: []string{"/skills/AI"}, AccessGated: true},
+		}, results)
+	})
+}
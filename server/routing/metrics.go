@@ -80,16 +80,15 @@ func (m *Metrics) decrement(label types.Label) {
 // NOTE: labels() method removed as it's no longer used in the new List API
 // The new List API doesn't return peer statistics for empty requests
 
-func (m *Metrics) update(ctx context.Context, dstore types.Datastore) error {
+// record adds the write of the metrics to a cache mutation, so that the metrics
+// are stored together with the label changes they account for.
+func (m *Metrics) record(mutation *cacheMutation) error {
 	data, err := json.Marshal(m)
 	if err != nil {
 		return fmt.Errorf("failed to marshal metrics data: %w", err)
 	}
 
-	err = dstore.Put(ctx, datastore.NewKey("/metrics"), data)
-	if err != nil {
-		return fmt.Errorf("failed to update metrics data: %w", err)
-	}
+	mutation.put("/metrics", data)
 
 	return nil
 }
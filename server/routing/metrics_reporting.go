@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"time"
+
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/metrics"
+)
+
+// startMetricsReporting starts a background goroutine that periodically
+// updates the gauges of the label cache size, the DHT routing table size,
+// the notification queue, the routing datastore availability and the GossipSub topic peers.
+func (r *routeRemote) startMetricsReporting() {
+	r.reportMetrics()
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(MetricsReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping metrics reporting")
+
+				return
+			case <-ticker.C:
+				r.reportMetrics()
+			}
+		}
+	}()
+}
+
+// reportMetrics updates the routing gauges.
+func (r *routeRemote) reportMetrics() {
+	metrics.RemoteLabels.Set(float64(r.peerStats.TotalLabels()))
+	metrics.DHTRoutingTableSize.Set(float64(r.server.DHT().RoutingTable().Size()))
+
+	pending, dead := r.notifyQueue.len()
+	metrics.NotifyQueuePending.Set(float64(pending))
+	metrics.NotifyQueueDeadLetters.Set(float64(dead))
+
+	if resilient, ok := unwrapDatastore[*datastore.ResilientDatastore](r.dstore); ok {
+		degraded, buffered := resilient.Degraded()
+		if degraded {
+			metrics.DatastoreDegraded.Set(1)
+		} else {
+			metrics.DatastoreDegraded.Set(0)
+		}
+
+		metrics.DatastoreBufferedWrites.Set(float64(buffered))
+	}
+
+	if r.pubsubManager != nil {
+		r.pubsubManager.ReportMetrics()
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// NotifyQueueNamespace is the datastore namespace of queued DHT provider notifications.
+// Notifications waiting to be processed are stored under /notify/pending/<CID>/<PeerID>,
+// notifications whose pulls repeatedly failed under /notify/dead/<CID>/<PeerID>.
+const NotifyQueueNamespace = "notify"
+
+var (
+	notifyPendingPrefix = "/" + NotifyQueueNamespace + "/pending/"
+	notifyDeadPrefix    = "/" + NotifyQueueNamespace + "/dead/"
+)
+
+// errNotifyQueueFull is returned when a notification is dropped as NotificationQueueSize
+// notifications are already waiting to be processed.
+var errNotifyQueueFull = errors.New("notification queue is full")
+
+// queuedNotification is a DHT provider notification stored in the datastore, so that
+// it is processed at least once, including across restarts.
+type queuedNotification struct {
+	Peer        peer.AddrInfo `json:"peer"`
+	EnqueuedAt  time.Time     `json:"enqueued_at"`
+	Attempts    int           `json:"attempts,omitempty"`
+	NextAttempt time.Time     `json:"next_attempt,omitzero"` // Zero until the first attempt failed
+	LastError   string        `json:"last_error,omitempty"`
+	DeadAt      time.Time     `json:"dead_at,omitzero"` // Set once moved to the dead-letter bucket
+}
+
+// notifyQueueEntry is a queued notification with the record it announces.
+type notifyQueueEntry struct {
+	cid string
+	queuedNotification
+}
+
+// key identifies the notification by the record and the announcing peer.
+func (e notifyQueueEntry) key() string {
+	return e.cid + "/" + e.Peer.ID.String()
+}
+
+func (e notifyQueueEntry) sync() *handlerSync {
+	return &handlerSync{Ref: &corev1.RecordRef{Cid: e.cid}, Peer: e.Peer}
+}
+
+// notifyQueue is a datastore-backed queue of DHT provider notifications.
+// Notifications are removed only once processed; notifications whose processing fails
+// are retried with exponential backoff and moved to a dead-letter bucket after
+// NotifyMaxAttempts attempts, where they are kept for NotifyDeadLetterRetention.
+type notifyQueue struct {
+	dstore types.Datastore
+
+	// wake signals the consumer that notifications were enqueued
+	wake chan struct{}
+
+	// mu serializes updates of queued notifications and guards the counts
+	mu      sync.Mutex
+	pending int
+	dead    int
+}
+
+func newNotifyQueue(dstore types.Datastore) *notifyQueue {
+	return &notifyQueue{
+		dstore: dstore,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func notifyPendingKey(cid, peerID string) datastore.Key {
+	return datastore.NewKey(notifyPendingPrefix + cid + "/" + peerID)
+}
+
+func notifyDeadKey(cid, peerID string) datastore.Key {
+	return datastore.NewKey(notifyDeadPrefix + cid + "/" + peerID)
+}
+
+// load counts the notifications left in the datastore by a previous run.
+// Pending notifications are processed again, as they may not have been processed.
+func (q *notifyQueue) load(ctx context.Context) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pending, err := q.query(ctx, notifyPendingPrefix)
+	if err != nil {
+		return err
+	}
+
+	dead, err := q.query(ctx, notifyDeadPrefix)
+	if err != nil {
+		return err
+	}
+
+	q.pending = len(pending)
+	q.dead = len(dead)
+
+	if len(pending) > 0 {
+		q.signal()
+	}
+
+	return nil
+}
+
+// enqueue stores a notification until it is processed. A notification of a record
+// and peer that is already queued refreshes its addresses but keeps its retry state.
+func (q *notifyQueue) enqueue(ctx context.Context, notif *handlerSync) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := notifyPendingKey(notif.Ref.GetCid(), notif.Peer.ID.String())
+
+	entry := queuedNotification{Peer: notif.Peer, EnqueuedAt: time.Now()}
+	exists := false
+
+	value, err := q.dstore.Get(ctx, key)
+
+	switch {
+	case err == nil && json.Unmarshal(value, &entry) == nil:
+		entry.Peer = notif.Peer
+		exists = true
+	case err != nil && !errors.Is(err, datastore.ErrNotFound):
+		return fmt.Errorf("failed to get queued notification: %w", err)
+	}
+
+	if !exists && q.pending >= NotificationQueueSize {
+		return errNotifyQueueFull
+	}
+
+	if err := q.put(ctx, key, entry); err != nil {
+		return err
+	}
+
+	if !exists {
+		q.pending++
+	}
+
+	q.signal()
+
+	return nil
+}
+
+// due returns up to limit pending notifications whose next attempt is due, oldest first.
+// Notifications for which skip returns true are left out, skip may be nil.
+func (q *notifyQueue) due(ctx context.Context, now time.Time, limit int, skip func(notifyQueueEntry) bool) ([]notifyQueueEntry, error) {
+	entries, err := q.query(ctx, notifyPendingPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	entries = slices.DeleteFunc(entries, func(entry notifyQueueEntry) bool {
+		return entry.NextAttempt.After(now) || (skip != nil && skip(entry))
+	})
+
+	slices.SortFunc(entries, func(a, b notifyQueueEntry) int {
+		return a.EnqueuedAt.Compare(b.EnqueuedAt)
+	})
+
+	return entries[:min(limit, len(entries))], nil
+}
+
+// ack removes a processed notification.
+func (q *notifyQueue) ack(ctx context.Context, entry notifyQueueEntry) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := q.dstore.Delete(ctx, notifyPendingKey(entry.cid, entry.Peer.ID.String())); err != nil {
+		return fmt.Errorf("failed to remove queued notification: %w", err)
+	}
+
+	q.pending = max(q.pending-1, 0)
+
+	return nil
+}
+
+// retry schedules the next attempt of a notification whose processing failed,
+// or moves it to the dead-letter bucket once NotifyMaxAttempts attempts failed.
+// Returns whether the notification was dead-lettered.
+func (q *notifyQueue) retry(ctx context.Context, entry notifyQueueEntry, cause error, now time.Time) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entry.Attempts++
+	entry.LastError = cause.Error()
+
+	pendingKey := notifyPendingKey(entry.cid, entry.Peer.ID.String())
+
+	if entry.Attempts < NotifyMaxAttempts {
+		// Back off exponentially: NotifyRetryDelay, 2x, 4x, ...
+		entry.NextAttempt = now.Add(NotifyRetryDelay << (entry.Attempts - 1))
+
+		return false, q.put(ctx, pendingKey, entry.queuedNotification)
+	}
+
+	entry.DeadAt = now
+	deadKey := notifyDeadKey(entry.cid, entry.Peer.ID.String())
+
+	// A notification of the record and peer may have been dead-lettered before
+	replaced, err := q.dstore.Has(ctx, deadKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to check dead-lettered notification: %w", err)
+	}
+
+	value, err := json.Marshal(entry.queuedNotification)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal queued notification: %w", err)
+	}
+
+	batch, err := q.dstore.Batch(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	if err := batch.Put(ctx, deadKey, value); err != nil {
+		return false, fmt.Errorf("failed to dead-letter notification: %w", err)
+	}
+
+	if err := batch.Delete(ctx, pendingKey); err != nil {
+		return false, fmt.Errorf("failed to remove queued notification: %w", err)
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to dead-letter notification: %w", err)
+	}
+
+	q.pending = max(q.pending-1, 0)
+
+	if !replaced {
+		q.dead++
+	}
+
+	return true, nil
+}
+
+// pruneDeadLetters removes dead-lettered notifications older than NotifyDeadLetterRetention.
+func (q *notifyQueue) pruneDeadLetters(ctx context.Context, now time.Time) (int, error) {
+	entries, err := q.query(ctx, notifyDeadPrefix)
+	if err != nil {
+		return 0, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	pruned := 0
+
+	for _, entry := range entries {
+		if now.Sub(entry.DeadAt) < NotifyDeadLetterRetention {
+			continue
+		}
+
+		if err := q.dstore.Delete(ctx, notifyDeadKey(entry.cid, entry.Peer.ID.String())); err != nil {
+			return pruned, fmt.Errorf("failed to remove dead-lettered notification: %w", err)
+		}
+
+		pruned++
+	}
+
+	q.dead = len(entries) - pruned
+
+	return pruned, nil
+}
+
+// len returns the number of pending and dead-lettered notifications.
+func (q *notifyQueue) len() (int, int) {
+	if q == nil {
+		return 0, 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.pending, q.dead
+}
+
+// signal wakes up the consumer without blocking.
+func (q *notifyQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (q *notifyQueue) put(ctx context.Context, key datastore.Key, entry queuedNotification) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued notification: %w", err)
+	}
+
+	if err := q.dstore.Put(ctx, key, value); err != nil {
+		return fmt.Errorf("failed to store queued notification: %w", err)
+	}
+
+	return nil
+}
+
+// query returns the notifications stored under a prefix. Unparsable entries are skipped.
+func (q *notifyQueue) query(ctx context.Context, prefix string) ([]notifyQueueEntry, error) {
+	results, err := q.dstore.Query(ctx, query.Query{Prefix: prefix})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query queued notifications: %w", err)
+	}
+	defer results.Close()
+
+	var entries []notifyQueueEntry
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		var entry notifyQueueEntry
+		if err := json.Unmarshal(result.Value, &entry.queuedNotification); err != nil {
+			remoteLogger.Warn("Failed to parse queued notification", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		// Keys are <prefix><CID>/<PeerID>
+		entry.cid = datastore.NewKey(result.Key).Parent().BaseNamespace()
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// processNotifications dispatches the due queued notifications to the pull pool.
+// Notifications are only removed from the queue once processed, so they are processed
+// at least once. While all workers are busy, the remaining notifications wait in the queue.
+func (r *routeRemote) processNotifications(ctx context.Context) {
+	for ctx.Err() == nil {
+		entries, err := r.notifyQueue.due(ctx, time.Now(), NotifyQueueBatchSize, r.pulls.busy)
+		if err != nil {
+			remoteLogger.Error("Failed to read queued notifications", "error", err)
+
+			return
+		}
+
+		for _, entry := range entries {
+			if ctx.Err() != nil {
+				return
+			}
+
+			r.pulls.submit(ctx, entry, func(entry notifyQueueEntry) {
+				if err := r.processNotification(ctx, entry); err != nil {
+					remoteLogger.Error("Failed to update queued notification", "cid", entry.cid, "peer", entry.Peer.ID, "error", err)
+				}
+			}, r.notifyQueue.signal)
+		}
+
+		if len(entries) < NotifyQueueBatchSize {
+			return
+		}
+	}
+}
+
+// processNotification processes a queued notification and removes it from the queue,
+// or schedules its retry if it failed.
+func (r *routeRemote) processNotification(ctx context.Context, entry notifyQueueEntry) error {
+	handleErr := r.handleCIDProviderNotification(ctx, entry.sync())
+
+	// Notifications interrupted by shutdown are processed again after restart
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	if handleErr == nil {
+		return r.notifyQueue.ack(ctx, entry)
+	}
+
+	dead, err := r.notifyQueue.retry(ctx, entry, handleErr, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if dead {
+		metrics.NotificationsDeadLettered.Inc()
+
+		remoteLogger.Warn("Moved notification to the dead-letter bucket after repeated failures",
+			"cid", entry.cid,
+			"peer", entry.Peer.ID,
+			"attempts", NotifyMaxAttempts,
+			"error", handleErr)
+	}
+
+	return nil
+}
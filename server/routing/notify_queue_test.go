@@ -0,0 +1,127 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNotification(t *testing.T, cid string) *handlerSync {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	peerID, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	return &handlerSync{
+		Ref:  &corev1.RecordRef{Cid: cid},
+		Peer: peer.AddrInfo{ID: peerID, Addrs: []multiaddr.Multiaddr{multiaddr.StringCast("/ip4/10.0.0.1/tcp/8999")}},
+	}
+}
+
+func TestNotifyQueue_AtLeastOnce(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	queue := newNotifyQueue(dstore)
+	notif := newTestNotification(t, "bafyrecord1")
+
+	require.NoError(t, queue.enqueue(ctx, notif))
+	require.NoError(t, queue.enqueue(ctx, newTestNotification(t, "bafyrecord2")))
+
+	// Re-announcements of a queued record and peer are deduplicated
+	require.NoError(t, queue.enqueue(ctx, notif))
+
+	pending, _ := queue.len()
+	assert.Equal(t, 2, pending)
+
+	// Notifications survive a restart until they are acknowledged
+	restarted := newNotifyQueue(dstore)
+	require.NoError(t, restarted.load(ctx))
+
+	entries, err := restarted.due(ctx, time.Now(), NotifyQueueBatchSize, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "bafyrecord1", entries[0].cid)
+	assert.Equal(t, notif.Peer.ID, entries[0].Peer.ID)
+	assert.Equal(t, notif.Peer.Addrs, entries[0].Peer.Addrs)
+
+	require.NoError(t, restarted.ack(ctx, entries[0]))
+
+	entries, err = restarted.due(ctx, time.Now(), NotifyQueueBatchSize, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "bafyrecord2", entries[0].cid)
+}
+
+func TestNotifyQueue_DeadLetter(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	queue := newNotifyQueue(dstore)
+	require.NoError(t, queue.enqueue(ctx, newTestNotification(t, "bafyrecord1")))
+
+	now := time.Now()
+	pullErr := errors.New("peer unreachable")
+
+	for attempt := 1; attempt < NotifyMaxAttempts; attempt++ {
+		entries, err := queue.due(ctx, now, NotifyQueueBatchSize, nil)
+		require.NoError(t, err)
+		require.Len(t, entries, 1, "attempt %d", attempt)
+
+		dead, err := queue.retry(ctx, entries[0], pullErr, now)
+		require.NoError(t, err)
+		assert.False(t, dead)
+
+		// Failed notifications are retried after a growing delay
+		entries, err = queue.due(ctx, now, NotifyQueueBatchSize, nil)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		now = now.Add(NotifyRetryDelay << (attempt - 1))
+	}
+
+	entries, err := queue.due(ctx, now, NotifyQueueBatchSize, nil)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, NotifyMaxAttempts-1, entries[0].Attempts)
+	assert.Equal(t, pullErr.Error(), entries[0].LastError)
+
+	dead, err := queue.retry(ctx, entries[0], pullErr, now)
+	require.NoError(t, err)
+	assert.True(t, dead)
+
+	pending, deadLetters := queue.len()
+	assert.Zero(t, pending)
+	assert.Equal(t, 1, deadLetters)
+
+	// Dead letters are kept for inspection, then pruned
+	pruned, err := queue.pruneDeadLetters(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, pruned)
+
+	pruned, err = queue.pruneDeadLetters(ctx, now.Add(NotifyDeadLetterRetention))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	_, deadLetters = queue.len()
+	assert.Zero(t, deadLetters)
+}
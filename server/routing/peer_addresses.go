@@ -0,0 +1,186 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// createPeerInfo creates a Peer message from a PeerID string.
+// Addresses are the peer's Directory API addresses, best first.
+// The locality zone the peer advertised is set as the zone annotation,
+// and the capabilities from the capability handshake with the peer, if any, are included.
+func (r *routeRemote) createPeerInfo(ctx context.Context, peerID string) *routingv1.Peer {
+	info := &routingv1.Peer{
+		Id:           peerID,
+		Addrs:        r.getDirectoryAPIAddresses(ctx, peerID),
+		Capabilities: r.peerCapabilities.get(peerID, time.Now()),
+	}
+
+	if zone := r.peerZone(ctx, peerID); zone != "" {
+		info.Annotations = map[string]string{PeerAnnotationZone: zone}
+	}
+
+	return info
+}
+
+// getDirectoryAPIAddresses resolves the Directory API addresses of a peer from the address book.
+// Unknown peers are looked up in the live peerstore (e.g. peers discovered via mDNS or
+// DHT without addresses) and added to the address book.
+func (r *routeRemote) getDirectoryAPIAddresses(ctx context.Context, peerID string) []string {
+	entry, err := r.addressBook.Get(ctx, peerID)
+	if err != nil {
+		remoteLogger.Warn("Failed to get peer addresses", "peerID", peerID, "error", err)
+	}
+
+	if entry != nil {
+		if dirAddrs := entry.DirectoryAddresses(); len(dirAddrs) > 0 {
+			return dirAddrs
+		}
+	}
+
+	// Fallback: Try live peerstore
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		remoteLogger.Error("Failed to decode peer ID", "peerID", peerID, "error", err)
+
+		return nil
+	}
+
+	peerstoreAddrs := r.server.Host().Peerstore().Addrs(pid)
+
+	dirAddrs := addressbook.DirectoryAddresses(peerstoreAddrs)
+	if len(dirAddrs) == 0 {
+		remoteLogger.Warn("No Directory API address found for peer",
+			"peerID", peerID,
+			"note", "Peer might be discovered via mDNS or DHT without /dir/ configuration")
+
+		return nil
+	}
+
+	if err := r.addressBook.Add(ctx, peerID, peerstoreAddrs, addressbook.PriorityPeerstore); err != nil {
+		remoteLogger.Warn("Failed to store peerstore addresses", "peerID", peerID, "error", err)
+	}
+
+	return dirAddrs
+}
+
+// storePeerAddresses refreshes the addresses of a peer announcing a record.
+// Tries DHT notification addresses first, falls back to peerstore if empty.
+func (r *routeRemote) storePeerAddresses(ctx context.Context, peerIDStr string, peerID peer.ID, notifAddrs []ma.Multiaddr, cid string) {
+	peerAddrs, priority := notifAddrs, addressbook.PriorityAnnouncement
+	if len(peerAddrs) == 0 {
+		peerAddrs, priority = r.server.Host().Peerstore().Addrs(peerID), addressbook.PriorityPeerstore
+		remoteLogger.Debug("DHT notification had no addresses, using peerstore",
+			"peerID", peerIDStr,
+			"peerstoreAddrs", len(peerAddrs))
+	}
+
+	if len(peerAddrs) == 0 {
+		remoteLogger.Warn("No peer addresses available from DHT or peerstore",
+			"peerID", peerIDStr,
+			"cid", cid)
+
+		return
+	}
+
+	if err := r.addressBook.Add(ctx, peerIDStr, peerAddrs, priority); err != nil {
+		remoteLogger.Error("Failed to store peer addresses", "peerID", peerIDStr, "error", err)
+
+		return
+	}
+
+	remoteLogger.Debug("Refreshed peer addresses", "peerID", peerIDStr, "count", len(peerAddrs))
+}
+
+// startAddressBookMaintenance keeps the address book current in the background:
+// addresses are refreshed from identify events as peers connect, from the peerstore
+// of connected directory peers every PeerAddressRefreshInterval, and expired once
+// they have not been seen within PeerAddressTTL.
+func (r *routeRemote) startAddressBookMaintenance() {
+	sub, err := r.server.Host().EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		remoteLogger.Warn("Failed to subscribe to identify events, addresses are refreshed periodically only", "error", err)
+	}
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		var identified <-chan interface{}
+
+		if sub != nil {
+			defer sub.Close()
+
+			identified = sub.Out()
+		}
+
+		ticker := time.NewTicker(PeerAddressRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping address book maintenance")
+
+				return
+			case evt, ok := <-identified:
+				if !ok {
+					identified = nil
+
+					continue
+				}
+
+				if e, ok := evt.(event.EvtPeerIdentificationCompleted); ok {
+					r.refreshPeerAddresses(e.Peer, e.ListenAddrs, addressbook.PriorityIdentify)
+				}
+			case <-ticker.C:
+				r.maintainAddressBook()
+			}
+		}
+	}()
+}
+
+// refreshPeerAddresses refreshes the addresses of a directory peer.
+// Peers are only added if they advertise a Directory API address,
+// so that the book does not fill up with unrelated libp2p peers.
+func (r *routeRemote) refreshPeerAddresses(peerID peer.ID, addrs []ma.Multiaddr, priority addressbook.Priority) {
+	peerIDStr := peerID.String()
+
+	if peerID == r.server.Host().ID() || len(addrs) == 0 {
+		return
+	}
+
+	if len(addressbook.DirectoryAddresses(addrs)) == 0 && !r.addressBook.Has(r.ctx, peerIDStr) {
+		return
+	}
+
+	if err := r.addressBook.Add(r.ctx, peerIDStr, addrs, priority); err != nil {
+		remoteLogger.Warn("Failed to refresh peer addresses", "peerID", peerIDStr, "error", err)
+	}
+}
+
+// maintainAddressBook refreshes connected peers from the peerstore and expires stale entries.
+func (r *routeRemote) maintainAddressBook() {
+	for _, peerID := range r.server.Host().Network().Peers() {
+		r.refreshPeerAddresses(peerID, r.server.Host().Peerstore().Addrs(peerID), addressbook.PriorityPeerstore)
+	}
+
+	removed, err := r.addressBook.Expire(r.ctx)
+	if err != nil {
+		remoteLogger.Warn("Failed to expire peer addresses", "error", err)
+	}
+
+	if removed > 0 {
+		remoteLogger.Info("Expired peer addresses", "peers", removed)
+	}
+}
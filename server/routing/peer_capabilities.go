@@ -0,0 +1,233 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/api/version"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// API features advertised in the capability handshake.
+const (
+	// FeatureGossipSub is advertised by peers announcing labels via GossipSub.
+	FeatureGossipSub = "gossipsub"
+
+	// FeatureLabelSync is advertised by peers serving incremental label sync.
+	FeatureLabelSync = "label-sync"
+
+	// FeatureProtobufAnnouncements is advertised by peers decoding protobuf-encoded label announcements.
+	FeatureProtobufAnnouncements = "protobuf-announcements"
+
+	// FeatureLiveSearch is advertised by peers serving live searches of their local records.
+	FeatureLiveSearch = "live-search"
+)
+
+// peerCapabilities holds the capabilities advertised by peers in the capability handshake.
+// Peers that failed the handshake, e.g. because they predate it, are held without
+// capabilities, so that they are not handshaken again before PeerCapabilitiesTTL.
+type peerCapabilities struct {
+	mu       sync.Mutex
+	peers    map[string]receivedCapabilities
+	inFlight map[string]struct{}
+}
+
+type receivedCapabilities struct {
+	capabilities *routingv1.PeerCapabilities // Nil if the handshake failed
+	receivedAt   time.Time
+}
+
+func newPeerCapabilities() *peerCapabilities {
+	return &peerCapabilities{
+		peers:    make(map[string]receivedCapabilities),
+		inFlight: make(map[string]struct{}),
+	}
+}
+
+// get returns the capabilities of a peer received within PeerCapabilitiesTTL.
+// Nil if unknown or the peer failed the handshake.
+func (c *peerCapabilities) get(peerID string, now time.Time) *routingv1.PeerCapabilities {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	received, ok := c.peers[peerID]
+	if !ok || now.Sub(received.receivedAt) > PeerCapabilitiesTTL {
+		return nil
+	}
+
+	return received.capabilities
+}
+
+// current reports whether a handshake with the peer completed within PeerCapabilitiesTTL.
+func (c *peerCapabilities) current(peerID string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	received, ok := c.peers[peerID]
+
+	return ok && now.Sub(received.receivedAt) <= PeerCapabilitiesTTL
+}
+
+// begin marks a handshake with the peer as in flight.
+// Returns false if one already is.
+func (c *peerCapabilities) begin(peerID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.inFlight[peerID]; ok {
+		return false
+	}
+
+	c.inFlight[peerID] = struct{}{}
+
+	return true
+}
+
+// finish stores the outcome of a handshake with the peer, nil capabilities if it failed.
+func (c *peerCapabilities) finish(peerID string, capabilities *routingv1.PeerCapabilities, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.inFlight, peerID)
+	c.peers[peerID] = receivedCapabilities{capabilities: capabilities, receivedAt: now}
+}
+
+// expire removes the capabilities received more than PeerCapabilitiesTTL ago.
+func (c *peerCapabilities) expire(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for peerID, received := range c.peers {
+		if now.Sub(received.receivedAt) > PeerCapabilitiesTTL {
+			delete(c.peers, peerID)
+		}
+	}
+}
+
+// localCapabilities returns the capabilities advertised by this peer.
+func (r *routeRemote) localCapabilities() rpc.CapabilitiesResponse {
+	features := []string{FeatureLabelSync, FeatureProtobufAnnouncements, FeatureLiveSearch}
+	if r.pubsubManager != nil {
+		features = append([]string{FeatureGossipSub}, features...)
+	}
+
+	labelTypes := types.AllLabelTypes()
+	namespaces := make([]string, 0, len(labelTypes))
+
+	for _, labelType := range labelTypes {
+		namespaces = append(namespaces, labelType.String())
+	}
+
+	return rpc.CapabilitiesResponse{
+		Version:    version.Version,
+		Features:   features,
+		Namespaces: namespaces,
+	}
+}
+
+// startCapabilityHandshakes exchanges capabilities with directory peers once they are
+// identified, and every PeerCapabilitiesRefreshInterval with connected directory peers
+// whose capabilities are not current.
+func (r *routeRemote) startCapabilityHandshakes() {
+	sub, err := r.server.Host().EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		remoteLogger.Warn("Failed to subscribe to identify events, capabilities are refreshed periodically only", "error", err)
+	}
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		var identified <-chan interface{}
+
+		if sub != nil {
+			defer sub.Close()
+
+			identified = sub.Out()
+		}
+
+		ticker := time.NewTicker(PeerCapabilitiesRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping capability handshakes")
+
+				return
+			case evt, ok := <-identified:
+				if !ok {
+					identified = nil
+
+					continue
+				}
+
+				// Peers are handshaken again on reconnect, as they may have been upgraded
+				if e, ok := evt.(event.EvtPeerIdentificationCompleted); ok && slices.Contains(e.Protocols, rpc.Protocol) {
+					r.handshakeCapabilities(e.Peer)
+				}
+			case <-ticker.C:
+				r.refreshPeerCapabilities()
+			}
+		}
+	}()
+}
+
+// refreshPeerCapabilities handshakes the connected directory peers whose capabilities
+// are not current and removes expired capabilities.
+func (r *routeRemote) refreshPeerCapabilities() {
+	now := time.Now()
+	r.peerCapabilities.expire(now)
+
+	for _, peerID := range r.server.Host().Network().Peers() {
+		if r.peerCapabilities.current(peerID.String(), now) {
+			continue
+		}
+
+		if protocols, err := r.server.Host().Peerstore().SupportsProtocols(peerID, rpc.Protocol); err == nil && len(protocols) > 0 {
+			r.handshakeCapabilities(peerID)
+		}
+	}
+}
+
+// handshakeCapabilities fetches the capabilities of a peer in the background,
+// unless a handshake with it is already in flight.
+func (r *routeRemote) handshakeCapabilities(peerID peer.ID) {
+	if peerID == r.server.Host().ID() || !r.peerCapabilities.begin(peerID.String()) {
+		return
+	}
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ctx, cancel := context.WithTimeout(r.ctx, PeerCapabilitiesTimeout)
+		defer cancel()
+
+		resp, err := r.service.Capabilities(ctx, peerID)
+		if err != nil {
+			remoteLogger.Debug("Capability handshake failed", "peer", peerID, "error", err)
+			r.peerCapabilities.finish(peerID.String(), nil, time.Now())
+
+			return
+		}
+
+		remoteLogger.Debug("Received peer capabilities", "peer", peerID, "version", resp.Version, "features", resp.Features)
+		r.peerCapabilities.finish(peerID.String(), &routingv1.PeerCapabilities{
+			Version:    resp.Version,
+			Features:   resp.Features,
+			Namespaces: resp.Namespaces,
+		}, time.Now())
+	}()
+}
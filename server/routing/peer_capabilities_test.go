@@ -0,0 +1,51 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerCapabilities(t *testing.T) {
+	capabilities := newPeerCapabilities()
+	now := time.Now()
+	advertised := &routingv1.PeerCapabilities{Version: "v1.0.0", Features: []string{FeatureGossipSub}}
+
+	assert.Nil(t, capabilities.get("peer1", now))
+	assert.False(t, capabilities.current("peer1", now))
+
+	// Only one handshake per peer is in flight
+	assert.True(t, capabilities.begin("peer1"))
+	assert.False(t, capabilities.begin("peer1"))
+
+	capabilities.finish("peer1", advertised, now)
+	assert.Equal(t, advertised, capabilities.get("peer1", now))
+	assert.True(t, capabilities.begin("peer1"))
+
+	// Failed handshakes are current without capabilities
+	capabilities.finish("peer2", nil, now)
+	assert.Nil(t, capabilities.get("peer2", now))
+	assert.True(t, capabilities.current("peer2", now))
+
+	// Capabilities expire after PeerCapabilitiesTTL
+	later := now.Add(PeerCapabilitiesTTL + time.Second)
+	assert.Nil(t, capabilities.get("peer1", later))
+	assert.False(t, capabilities.current("peer2", later))
+
+	capabilities.expire(later)
+	assert.Empty(t, capabilities.peers)
+}
+
+func TestLocalCapabilities(t *testing.T) {
+	r := &routeRemote{}
+
+	capabilities := r.localCapabilities()
+	assert.NotContains(t, capabilities.Features, FeatureGossipSub)
+	assert.Contains(t, capabilities.Features, FeatureLabelSync)
+	assert.Subset(t, capabilities.Namespaces, []string{"skills", "domains", "modules", "locators"})
+}
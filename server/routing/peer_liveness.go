@@ -0,0 +1,289 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// peerLiveness tracks the peers with cached labels that failed liveness probes.
+// A peer is unreachable from its first failed probe until a probe succeeds or an
+// announcement of the peer is received. Once unreachable for longer than staleAfter,
+// its labels are excluded from search results, and once unreachable for longer than
+// deleteAfter, they are deleted.
+type peerLiveness struct {
+	staleAfter  time.Duration
+	deleteAfter time.Duration // Zero keeps the labels of unreachable peers
+
+	mu          sync.RWMutex
+	unreachable map[string]time.Time // When probes of the peer started failing
+}
+
+func newPeerLiveness(cfg routingconfig.LivenessConfig) *peerLiveness {
+	return &peerLiveness{
+		staleAfter:  cfg.StaleAfter,
+		deleteAfter: cfg.DeleteAfter,
+		unreachable: make(map[string]time.Time),
+	}
+}
+
+// markReachable records that the peer is alive.
+func (l *peerLiveness) markReachable(peerID string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.unreachable, peerID)
+}
+
+// markUnreachable records that a probe of the peer failed at the given time.
+// The peer stays unreachable since its first failed probe.
+func (l *peerLiveness) markUnreachable(peerID string, at time.Time) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.unreachable[peerID]; !ok {
+		l.unreachable[peerID] = at
+	}
+}
+
+// isStale reports whether the peer has been unreachable for longer than staleAfter.
+func (l *peerLiveness) isStale(peerID string, now time.Time) bool {
+	if l == nil {
+		return false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	since, ok := l.unreachable[peerID]
+
+	return ok && now.Sub(since) > l.staleAfter
+}
+
+// staleCount returns the number of peers unreachable for longer than staleAfter.
+func (l *peerLiveness) staleCount(now time.Time) int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	count := 0
+
+	for _, since := range l.unreachable {
+		if now.Sub(since) > l.staleAfter {
+			count++
+		}
+	}
+
+	return count
+}
+
+// expired returns the peers unreachable for longer than deleteAfter,
+// or none if the labels of unreachable peers are kept.
+func (l *peerLiveness) expired(now time.Time) map[string]bool {
+	if l == nil || l.deleteAfter <= 0 {
+		return nil
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	peers := make(map[string]bool)
+
+	for peerID, since := range l.unreachable {
+		if now.Sub(since) > l.deleteAfter {
+			peers[peerID] = true
+		}
+	}
+
+	return peers
+}
+
+// retain forgets the peers that no longer have cached labels.
+func (l *peerLiveness) retain(peerIDs []string) {
+	if l == nil {
+		return
+	}
+
+	keep := make(map[string]bool, len(peerIDs))
+	for _, peerID := range peerIDs {
+		keep[peerID] = true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for peerID := range l.unreachable {
+		if !keep[peerID] {
+			delete(l.unreachable, peerID)
+		}
+	}
+}
+
+// startLivenessProbing probes the peers with cached labels every interval.
+func (r *routeRemote) startLivenessProbing(interval time.Duration) {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping liveness probing")
+
+				return
+			case <-ticker.C:
+				r.probeLiveness(r.ctx)
+			}
+		}
+	}()
+}
+
+// probeLiveness probes all peers with cached labels, LivenessProbeConcurrency at a time,
+// and deletes the labels of peers unreachable for longer than the configured period.
+func (r *routeRemote) probeLiveness(ctx context.Context) {
+	// Followers refuse all connections until promoted, so every peer would seem unreachable
+	if r.follower.following() {
+		return
+	}
+
+	localPeerID := r.server.Host().ID().String()
+
+	peers := r.peerStats.PeersWithLabels()
+	r.liveness.retain(peers)
+
+	var (
+		wg          sync.WaitGroup
+		sem         = make(chan struct{}, LivenessProbeConcurrency)
+		unreachable int
+		mu          sync.Mutex
+	)
+
+	for _, peerID := range peers {
+		if peerID == localPeerID {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+
+			return
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			err := r.probePeer(ctx, peerID)
+			metrics.LivenessProbes.WithLabelValues(metrics.Result(err)).Inc()
+
+			if err != nil {
+				remoteLogger.Debug("Liveness probe failed", "peer", peerID, "error", err)
+				r.liveness.markUnreachable(peerID, time.Now())
+
+				mu.Lock()
+				unreachable++
+				mu.Unlock()
+
+				return
+			}
+
+			r.liveness.markReachable(peerID)
+		}()
+	}
+
+	wg.Wait()
+
+	now := time.Now()
+	stale := r.liveness.staleCount(now)
+	metrics.StalePeers.Set(float64(stale))
+
+	remoteLogger.Debug("Probed liveness of peers with cached labels",
+		"peers", len(peers), "unreachable", unreachable, "stale", stale)
+
+	if _, err := r.evictUnreachable(ctx, now); err != nil {
+		remoteLogger.Warn("Failed to evict labels of unreachable peers", "error", err)
+	}
+}
+
+// probePeer checks that a peer is connected, or dials it at its known addresses.
+func (r *routeRemote) probePeer(ctx context.Context, peerID string) error {
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return fmt.Errorf("invalid peer ID: %w", err)
+	}
+
+	host := r.server.Host()
+	if host.Network().Connectedness(pid) == network.Connected {
+		return nil
+	}
+
+	info := peer.AddrInfo{ID: pid}
+
+	// The peerstore may have dropped the addresses of peers disconnected for a while
+	if entry, err := r.addressBook.Get(ctx, peerID); err == nil && entry != nil {
+		info.Addrs = entry.Multiaddrs()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, LivenessProbeTimeout)
+	defer cancel()
+
+	if err := host.Connect(ctx, info); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	return nil
+}
+
+// evictUnreachable deletes the cached labels of peers unreachable for longer than the
+// configured period. Pinned records are kept. Returns the number of evicted labels.
+func (r *routeRemote) evictUnreachable(ctx context.Context, now time.Time) (int, error) {
+	peers := r.liveness.expired(now)
+	if len(peers) == 0 {
+		return 0, nil
+	}
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query cached labels: %w", err)
+	}
+
+	var records []*cachedRecord
+
+	for _, record := range cachedRecords(entries, r.server.Host().ID().String()) {
+		if peers[record.peerID] {
+			records = append(records, record)
+		}
+	}
+
+	return r.evictRecords(ctx, records, metrics.CleanupUnreachableLabel)
+}
@@ -0,0 +1,101 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerLiveness(t *testing.T) {
+	var nilLiveness *peerLiveness
+	assert.False(t, nilLiveness.isStale("peer1", time.Now()))
+	assert.Empty(t, nilLiveness.expired(time.Now()))
+
+	now := time.Now()
+	liveness := newPeerLiveness(routingconfig.LivenessConfig{StaleAfter: time.Minute, DeleteAfter: time.Hour})
+
+	liveness.markUnreachable("peer1", now)
+	liveness.markUnreachable("peer1", now.Add(30*time.Second)) // unreachable since the first failure
+	liveness.markUnreachable("peer2", now.Add(30*time.Minute))
+
+	assert.False(t, liveness.isStale("peer1", now.Add(time.Minute)))
+	assert.True(t, liveness.isStale("peer1", now.Add(2*time.Minute)))
+	assert.Equal(t, 2, liveness.staleCount(now.Add(time.Hour)))
+	assert.Equal(t, map[string]bool{"peer1": true}, liveness.expired(now.Add(time.Hour+time.Second)))
+
+	// Reachable peers and peers without cached labels are forgotten
+	liveness.markReachable("peer1")
+	assert.False(t, liveness.isStale("peer1", now.Add(time.Hour)))
+
+	liveness.retain([]string{"peer1"})
+	assert.False(t, liveness.isStale("peer2", now.Add(time.Hour)))
+
+	// Labels are kept without a delete period
+	kept := newPeerLiveness(routingconfig.LivenessConfig{StaleAfter: time.Minute})
+	kept.markUnreachable("peer1", now)
+	assert.Empty(t, kept.expired(now.Add(24*time.Hour)))
+}
+
+func TestProbeLiveness(t *testing.T) {
+	server := newTestServer(t, t.Context(), nil)
+	online := newTestServer(t, t.Context(), nil)
+
+	onlineHost := online.remote.server.Host()
+	require.NoError(t, server.remote.server.Host().Connect(t.Context(), peer.AddrInfo{ID: onlineHost.ID(), Addrs: onlineHost.Addrs()}))
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	offline, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	metadata, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+	require.NoError(t, err)
+
+	labels := map[string]string{
+		"/skills/AI/cid1/" + onlineHost.ID().String(): onlineHost.ID().String(),
+		"/skills/AI/cid2/" + offline.String():         offline.String(),
+		"/skills/AI/cid3/" + offline.String():         offline.String(),
+	}
+
+	for key, peerID := range labels {
+		require.NoError(t, server.remote.dstore.Put(t.Context(), ipfsdatastore.NewKey(key), metadata))
+		server.remote.peerStats.AddLabels(peerID, 1)
+	}
+
+	server.remote.pins.add("cid3")
+	server.remote.liveness = newPeerLiveness(routingconfig.LivenessConfig{StaleAfter: time.Minute, DeleteAfter: time.Hour})
+
+	server.remote.probeLiveness(t.Context())
+
+	now := time.Now()
+	assert.False(t, server.remote.liveness.isStale(onlineHost.ID().String(), now.Add(2*time.Minute)))
+	assert.True(t, server.remote.liveness.isStale(offline.String(), now.Add(2*time.Minute)))
+
+	// Labels of peers unreachable for longer than the delete period are evicted, unless pinned
+	evicted, err := server.remote.evictUnreachable(t.Context(), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, evicted)
+
+	for key, want := range map[string]bool{
+		"/skills/AI/cid1/" + onlineHost.ID().String(): true,
+		"/skills/AI/cid2/" + offline.String():         false,
+		"/skills/AI/cid3/" + offline.String():         true,
+	} {
+		has, err := server.remote.dstore.Has(t.Context(), ipfsdatastore.NewKey(key))
+		require.NoError(t, err)
+		assert.Equal(t, want, has, key)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// observeAnnouncement records the validity of a GossipSub announcement forwarded by the peer.
+func (r *routeRemote) observeAnnouncement(from peer.ID, valid bool) {
+	r.reputation.RecordAnnouncement(from.String(), valid)
+}
+
+// gossipSubPeerScore returns the application-specific GossipSub score of the peer,
+// derived from its reputation.
+func (r *routeRemote) gossipSubPeerScore(id peer.ID) float64 {
+	return r.reputation.GossipSubScore(id.String())
+}
@@ -0,0 +1,132 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/ipfs/go-datastore"
+)
+
+// DefaultStatsLimit is the number of peers per leaderboard if the request sets no limit.
+const DefaultStatsLimit = 10
+
+// GetStats returns leaderboards of the remote peers by cached label count,
+// announcement rate and failed pulls, the local records found to be unresolvable,
+// and the remote records with the most providers.
+func (r *routeRemote) GetStats(_ context.Context, req *routingv1.GetStatsRequest) (*routingv1.GetStatsResponse, error) {
+	limit := DefaultStatsLimit
+	if req.Limit != nil {
+		limit = int(req.GetLimit())
+	}
+
+	pullFailures := make(map[string]float64)
+	for peerID, stats := range r.reputation.Snapshot() {
+		pullFailures[peerID] = float64(stats.PullFailures)
+	}
+
+	return &routingv1.GetStatsResponse{
+		TopLabelCounts:       toPeerStats(r.peerStats.TopLabelCounts(limit)),
+		TopAnnouncementRates: toPeerStats(r.peerStats.TopAnnouncementRates(limit)),
+		TopPullFailures:      toPeerStats(peerstats.Leaderboard(pullFailures, limit)),
+		UnresolvableRecords:  r.announcements.unresolvable(limit),
+		RuntimeState:         r.state.toProto(),
+		TopClockSkews:        toPeerStats(r.peerStats.TopClockSkews(limit)),
+		TopProviderSets:      toProtoProviderSets(r.providerSets.Top(limit)),
+		Profile:              r.currentProfile().profile,
+	}, nil
+}
+
+func toPeerStats(entries []peerstats.Entry) []*routingv1.PeerStat {
+	result := make([]*routingv1.PeerStat, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, &routingv1.PeerStat{PeerId: entry.PeerID, Value: entry.Value})
+	}
+
+	return result
+}
+
+// cacheRemoteLabels stores the labels of a remote record as a single journaled
+// mutation and counts the labels that were not cached yet towards the peer's cached labels.
+// Cached labels deleted by the mutation, as removed from the record, are no longer counted.
+// Records with labels that were not cached yet are reported as discovered.
+// Unless nil, the announcement of the labels is logged in the provenance log by the same mutation.
+func (r *routeRemote) cacheRemoteLabels(ctx context.Context, peerID string, labels *cacheMutation, announcement *provenanceEntry) error {
+	var added []string
+
+	for _, p := range labels.Puts {
+		exists, err := r.dstore.Has(ctx, datastore.NewKey(p.Key))
+		if err != nil {
+			return fmt.Errorf("failed to check cached label: %w", err)
+		}
+
+		if !exists {
+			added = append(added, p.Key)
+		}
+	}
+
+	removed := 0
+
+	for _, key := range labels.Deletes {
+		exists, err := r.dstore.Has(ctx, datastore.NewKey(key))
+		if err != nil {
+			return fmt.Errorf("failed to check cached label: %w", err)
+		}
+
+		if exists {
+			removed++
+		}
+	}
+
+	mutation := labels
+	if announcement != nil && r.provenance != nil {
+		mutation = &cacheMutation{Puts: slices.Clone(labels.Puts), Deletes: labels.Deletes}
+		r.provenance.append(mutation, *announcement)
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, mutation); err != nil {
+		return fmt.Errorf("failed to store labels: %w", err)
+	}
+
+	r.peerStats.AddLabels(peerID, len(added)-removed)
+
+	if removed > 0 {
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupRemovedLabel).Add(float64(removed))
+	}
+	r.addToCardinalityIndex(added)
+	r.addToProviderSets(labels.Puts)
+	r.addToLineage(labels.Puts)
+	r.invalidateProviderLookups(added)
+	r.emitRecordsDiscovered(peerID, added)
+	r.notifySubscribers(ctx, peerID, labels, added)
+
+	return nil
+}
+
+// seedPeerStats counts the cached labels of each remote peer.
+// Called once on startup, the counts are maintained incrementally afterwards.
+func (r *routeRemote) seedPeerStats(ctx context.Context) {
+	localPeerID := r.server.Host().ID().String()
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		remoteLogger.Warn("Failed to count cached labels per peer", "error", err)
+
+		return
+	}
+
+	for _, entry := range entries {
+		_, _, peerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || peerID == localPeerID {
+			continue
+		}
+
+		r.peerStats.AddLabels(peerID, 1)
+	}
+}
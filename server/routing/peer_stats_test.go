@@ -0,0 +1,76 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/routing/reputation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore:        dstore,
+		peerStats:     peerstats.New(),
+		reputation:    reputation.New(),
+		cardinality:   cardinality.NewIndex(),
+		providerSets:  providerset.NewIndex(),
+		announcements: newAnnouncementChecks(),
+	}
+
+	cacheLabel := func(key, peerID string) {
+		labels := &cacheMutation{}
+		labels.put(key, []byte(`{}`))
+
+		require.NoError(t, r.cacheRemoteLabels(t.Context(), peerID, labels, nil))
+	}
+
+	// Re-caching the same label does not count twice
+	cacheLabel("/skills/AI/cid1/peer1", "peer1")
+	cacheLabel("/skills/AI/cid1/peer1", "peer1")
+	cacheLabel("/skills/AI/cid2/peer1", "peer1")
+	cacheLabel("/skills/AI/cid1/peer2", "peer2")
+
+	r.peerStats.RecordAnnouncement("peer2")
+	r.reputation.RecordPull("peer3", time.Second, errors.New("unreachable"))
+
+	stats, err := r.GetStats(t.Context(), &routingv1.GetStatsRequest{})
+	require.NoError(t, err)
+
+	require.Len(t, stats.GetTopLabelCounts(), 2)
+	assert.Equal(t, "peer1", stats.GetTopLabelCounts()[0].GetPeerId())
+	assert.InDelta(t, 2, stats.GetTopLabelCounts()[0].GetValue(), 1e-9)
+
+	require.Len(t, stats.GetTopAnnouncementRates(), 1)
+	assert.Equal(t, "peer2", stats.GetTopAnnouncementRates()[0].GetPeerId())
+
+	require.Len(t, stats.GetTopPullFailures(), 1)
+	assert.Equal(t, "peer3", stats.GetTopPullFailures()[0].GetPeerId())
+
+	// Only cid1 is provided by more than one peer
+	require.Len(t, stats.GetTopProviderSets(), 1)
+	assert.Equal(t, "cid1", stats.GetTopProviderSets()[0].GetCid())
+	assert.Equal(t, uint32(2), stats.GetTopProviderSets()[0].GetProviderCount())
+	assert.Equal(t, []string{"/skills/AI"}, stats.GetTopProviderSets()[0].GetLabels())
+
+	// Purging labels updates the counts
+	assert.Equal(t, 1, r.purgeRemoteRecordLabels(t.Context(), "cid1", "peer1"))
+
+	limit := uint32(1)
+	stats, err = r.GetStats(t.Context(), &routingv1.GetStatsRequest{Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, stats.GetTopLabelCounts(), 1)
+	assert.InDelta(t, 1, stats.GetTopLabelCounts()[0].GetValue(), 1e-9)
+}
@@ -0,0 +1,236 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package peerstats maintains per-peer routing statistics used to build
+// leaderboards of remote peers, helping operators spot imbalance or abuse.
+//
+// Statistics are updated incrementally as labels are cached, purged and
+// announced, so leaderboards never require a scan of the label cache.
+package peerstats
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// RateWindow is the averaging window of announcement rates.
+	// Older announcements decay exponentially with this time constant.
+	RateWindow = time.Hour
+
+	// minRate is the announcement rate below which peers without
+	// cached labels are dropped from the statistics.
+	minRate = 0.01
+
+	// skewWeight is the weight of a new sample in the moving average of clock skews.
+	skewWeight = 0.2
+)
+
+// Entry is a single entry of a peer leaderboard.
+type Entry struct {
+	PeerID string
+	Value  float64
+}
+
+// peerCounters holds the statistics of a single peer.
+type peerCounters struct {
+	labels int64
+
+	// Exponentially decayed announcement count and the time it was last decayed
+	announcements float64
+	decayedAt     time.Time
+
+	// Moving average of clock skews and whether any skew was recorded
+	clockSkew    time.Duration
+	hasClockSkew bool
+}
+
+// announcementRate returns the decayed announcement count at the given time, per hour.
+func (c *peerCounters) announcementRate(now time.Time) float64 {
+	return c.decayed(now) / RateWindow.Hours()
+}
+
+func (c *peerCounters) decayed(now time.Time) float64 {
+	if c.announcements == 0 {
+		return 0
+	}
+
+	return c.announcements * math.Exp(-float64(now.Sub(c.decayedAt))/float64(RateWindow))
+}
+
+// Tracker records per-peer statistics. It is safe for concurrent use.
+// A nil Tracker ignores all updates and reports empty leaderboards.
+type Tracker struct {
+	mu    sync.Mutex
+	peers map[string]*peerCounters
+	now   func() time.Time
+}
+
+// New creates an empty Tracker.
+func New() *Tracker {
+	return &Tracker{
+		peers: make(map[string]*peerCounters),
+		now:   time.Now,
+	}
+}
+
+// AddLabels adjusts the number of cached labels of the peer by delta.
+// Negative deltas record deleted labels.
+func (t *Tracker) AddLabels(peerID string, delta int) {
+	t.update(peerID, func(c *peerCounters, _ time.Time) {
+		c.labels = max(c.labels+int64(delta), 0)
+	})
+}
+
+// RecordAnnouncement records an announcement received from the peer.
+func (t *Tracker) RecordAnnouncement(peerID string) {
+	t.update(peerID, func(c *peerCounters, now time.Time) {
+		c.announcements = c.decayed(now) + 1
+		c.decayedAt = now
+	})
+}
+
+// RecordClockSkew records the clock skew of the peer observed in an announcement,
+// positive if the peer's clock is ahead of the local clock.
+// Skews are averaged so that network delays of single announcements are smoothed out.
+func (t *Tracker) RecordClockSkew(peerID string, skew time.Duration) {
+	t.update(peerID, func(c *peerCounters, _ time.Time) {
+		if !c.hasClockSkew {
+			c.clockSkew = skew
+			c.hasClockSkew = true
+
+			return
+		}
+
+		c.clockSkew += time.Duration(skewWeight * float64(skew-c.clockSkew))
+	})
+}
+
+func (t *Tracker) update(peerID string, fn func(*peerCounters, time.Time)) {
+	if t == nil || peerID == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c, ok := t.peers[peerID]
+	if !ok {
+		c = &peerCounters{}
+		t.peers[peerID] = c
+	}
+
+	fn(c, t.now())
+}
+
+// TotalLabels returns the number of cached labels across all peers.
+func (t *Tracker) TotalLabels() int64 {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var total int64
+	for _, c := range t.peers {
+		total += c.labels
+	}
+
+	return total
+}
+
+// PeersWithLabels returns the IDs of the peers with cached labels, in no particular order.
+func (t *Tracker) PeersWithLabels() []string {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	peers := make([]string, 0, len(t.peers))
+
+	for peerID, c := range t.peers {
+		if c.labels > 0 {
+			peers = append(peers, peerID)
+		}
+	}
+
+	return peers
+}
+
+// TopLabelCounts returns up to limit peers with the most cached labels.
+func (t *Tracker) TopLabelCounts(limit int) []Entry {
+	return t.top(limit, func(c *peerCounters, _ time.Time) float64 {
+		return float64(c.labels)
+	})
+}
+
+// TopAnnouncementRates returns up to limit peers with the highest
+// announcement rate, in announcements per hour.
+func (t *Tracker) TopAnnouncementRates(limit int) []Entry {
+	return t.top(limit, (*peerCounters).announcementRate)
+}
+
+// TopClockSkews returns up to limit peers with the largest absolute clock skew, in seconds.
+func (t *Tracker) TopClockSkews(limit int) []Entry {
+	return t.top(limit, func(c *peerCounters, _ time.Time) float64 {
+		return c.clockSkew.Abs().Seconds()
+	})
+}
+
+// top builds a leaderboard of the peers with a positive value.
+// Peers without cached labels and with a negligible announcement rate are dropped.
+func (t *Tracker) top(limit int, value func(*peerCounters, time.Time) float64) []Entry {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	values := make(map[string]float64, len(t.peers))
+
+	for peerID, c := range t.peers {
+		if c.labels == 0 && c.announcementRate(now) < minRate {
+			delete(t.peers, peerID)
+
+			continue
+		}
+
+		values[peerID] = value(c, now)
+	}
+
+	return Leaderboard(values, limit)
+}
+
+// Leaderboard returns up to limit entries with the highest positive values,
+// sorted by value in descending order and by peer ID for equal values.
+func Leaderboard(values map[string]float64, limit int) []Entry {
+	entries := make([]Entry, 0, len(values))
+
+	for peerID, value := range values {
+		if value > 0 {
+			entries = append(entries, Entry{PeerID: peerID, Value: value})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if c := cmp.Compare(b.Value, a.Value); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.PeerID, b.PeerID)
+	})
+
+	if limit >= 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries
+}
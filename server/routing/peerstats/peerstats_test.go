@@ -0,0 +1,93 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package peerstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker_TopLabelCounts(t *testing.T) {
+	tracker := New()
+
+	tracker.AddLabels("peer1", 5)
+	tracker.AddLabels("peer2", 10)
+	tracker.AddLabels("peer3", 3)
+	tracker.AddLabels("peer3", -5) // clamped at zero
+
+	assert.Equal(t, []Entry{
+		{PeerID: "peer2", Value: 10},
+		{PeerID: "peer1", Value: 5},
+	}, tracker.TopLabelCounts(10))
+
+	assert.Equal(t, []Entry{{PeerID: "peer2", Value: 10}}, tracker.TopLabelCounts(1))
+	assert.Equal(t, int64(15), tracker.TotalLabels())
+	assert.ElementsMatch(t, []string{"peer1", "peer2"}, tracker.PeersWithLabels())
+}
+
+func TestTracker_AnnouncementRateDecays(t *testing.T) {
+	tracker := New()
+
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	for range 10 {
+		tracker.RecordAnnouncement("chatty")
+	}
+
+	tracker.RecordAnnouncement("quiet")
+
+	top := tracker.TopAnnouncementRates(10)
+	assert.Len(t, top, 2)
+	assert.Equal(t, "chatty", top[0].PeerID)
+	assert.InDelta(t, 10, top[0].Value, 1e-9)
+
+	// After one window, the rate has decayed by a factor of e
+	now = now.Add(RateWindow)
+	top = tracker.TopAnnouncementRates(10)
+	assert.InDelta(t, 10/2.718281828, top[0].Value, 1e-6)
+
+	// Peers that went silent without cached labels are eventually dropped
+	now = now.Add(24 * RateWindow)
+	assert.Empty(t, tracker.TopAnnouncementRates(10))
+	assert.Empty(t, tracker.peers)
+}
+
+func TestTracker_TopClockSkews(t *testing.T) {
+	tracker := New()
+
+	tracker.RecordAnnouncement("behind")
+	tracker.RecordClockSkew("behind", -10*time.Second)
+	tracker.RecordAnnouncement("ahead")
+	tracker.RecordClockSkew("ahead", 5*time.Second)
+	tracker.RecordClockSkew("ahead", 10*time.Second) // averaged with the previous skew
+	tracker.RecordAnnouncement("synced")
+
+	assert.Equal(t, []Entry{
+		{PeerID: "behind", Value: 10},
+		{PeerID: "ahead", Value: 6},
+	}, tracker.TopClockSkews(10))
+}
+
+func TestLeaderboard(t *testing.T) {
+	entries := Leaderboard(map[string]float64{"b": 2, "a": 2, "c": 5, "zero": 0}, 10)
+
+	assert.Equal(t, []Entry{
+		{PeerID: "c", Value: 5},
+		{PeerID: "a", Value: 2},
+		{PeerID: "b", Value: 2},
+	}, entries)
+}
+
+func TestTracker_NilTrackerIsNoop(t *testing.T) {
+	var tracker *Tracker
+
+	tracker.AddLabels("peer1", 1)
+	tracker.RecordAnnouncement("peer1")
+
+	assert.Empty(t, tracker.TopLabelCounts(10))
+	assert.Empty(t, tracker.TopAnnouncementRates(10))
+}
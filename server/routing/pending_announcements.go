@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/types"
+)
+
+// pendingAnnouncements queues records published while the routing table is empty,
+// e.g. during bootstrap, so that they are announced once peers are reachable
+// instead of waiting for the next republish.
+type pendingAnnouncements struct {
+	mu      sync.Mutex
+	records map[string]pendingAnnouncement
+}
+
+type pendingAnnouncement struct {
+	record   types.Record
+	priority routingv1.AnnouncementPriority
+}
+
+func newPendingAnnouncements() *pendingAnnouncements {
+	return &pendingAnnouncements{records: make(map[string]pendingAnnouncement)}
+}
+
+// add queues the announcement of a record, replacing a queued announcement of the same record.
+// Returns false if the queue is full; the record is then announced by the next republish.
+func (p *pendingAnnouncements) add(record types.Record, priority routingv1.AnnouncementPriority) bool {
+	if p == nil {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.records[record.GetCid()]; !ok && len(p.records) >= MaxPendingAnnouncements {
+		return false
+	}
+
+	p.records[record.GetCid()] = pendingAnnouncement{record: record, priority: normalizePriority(priority)}
+
+	return true
+}
+
+// remove drops the queued announcement of a record, e.g. once it is unpublished.
+func (p *pendingAnnouncements) remove(cid string) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.records, cid)
+}
+
+// len returns the number of queued announcements.
+func (p *pendingAnnouncements) len() int {
+	if p == nil {
+		return 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.records)
+}
+
+// drain removes and returns the queued records, grouped by announcement priority.
+func (p *pendingAnnouncements) drain() map[routingv1.AnnouncementPriority][]types.Record {
+	p.mu.Lock()
+	records := p.records
+	p.records = make(map[string]pendingAnnouncement)
+	p.mu.Unlock()
+
+	byPriority := make(map[routingv1.AnnouncementPriority][]types.Record)
+	for _, pending := range records {
+		byPriority[pending.priority] = append(byPriority[pending.priority], pending.record)
+	}
+
+	return byPriority
+}
+
+// hasPeers reports whether the DHT routing table has peers to announce records to.
+func (r *routeRemote) hasPeers() bool {
+	return r.server != nil && r.server.DHT().RoutingTable().Size() > 0
+}
+
+// announcePending announces the queued records once the routing table has peers.
+// Failed announcements are logged; the records are announced again by the next republish.
+func (r *routeRemote) announcePending() {
+	if r.pending.len() == 0 || !r.hasPeers() {
+		return
+	}
+
+	for priority, records := range r.pending.drain() {
+		failed := 0
+
+		for _, err := range r.PublishBatch(r.ctx, records, priority, nil) {
+			if err != nil {
+				failed++
+			}
+		}
+
+		remoteLogger.Info("Announced records published before network connectivity",
+			"records", len(records), "failed", failed, "priority", priority)
+	}
+}
+
+// startPendingAnnouncements periodically announces records queued while the routing table was empty.
+func (r *routeRemote) startPendingAnnouncements() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(PendingAnnouncementInterval)
+		defer ticker.Stop()
+
+		// Announce as soon as bootstrap completes, then wait for peers if there were none
+		bootstrapped := r.server.Bootstrapped()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping pending announcements")
+
+				return
+			case <-bootstrapped:
+				bootstrapped = nil
+
+				r.announcePending()
+			case <-ticker.C:
+				r.announcePending()
+			}
+		}
+	}()
+}
+
+// readiness reports the network functionality available to remote routing.
+func (r *routeRemote) readiness() types.RoutingReadiness {
+	readiness := types.RoutingReadiness{PendingAnnouncements: r.pending.len()}
+
+	select {
+	case <-r.server.Bootstrapped():
+		readiness.Bootstrapped = true
+	default:
+	}
+
+	readiness.Peers = r.server.DHT().RoutingTable().Size()
+	readiness.MinPeers = r.readinessMinPeers
+
+	if r.pubsubManager != nil {
+		readiness.GossipSub = true
+		readiness.TopicPeers = len(r.pubsubManager.GetTopicPeers())
+	}
+
+	readiness.DatastoreWritable = true
+	if resilient, ok := unwrapDatastore[*datastore.ResilientDatastore](r.dstore); ok {
+		degraded, _ := resilient.Degraded()
+		readiness.DatastoreWritable = !degraded
+	}
+
+	return readiness
+}
@@ -0,0 +1,166 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPendingAnnouncements(t *testing.T) {
+	record1 := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{Name: "agent-1", SchemaVersion: "v0.3.1"}))
+	record2 := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{Name: "agent-2", SchemaVersion: "v0.3.1"}))
+	record3 := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{Name: "agent-3", SchemaVersion: "v0.3.1"}))
+
+	pending := newPendingAnnouncements()
+
+	assert.True(t, pending.add(record1, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED))
+	assert.True(t, pending.add(record2, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW))
+	assert.True(t, pending.add(record3, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH))
+
+	// Publishing again replaces the queued announcement
+	assert.True(t, pending.add(record2, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH))
+	assert.Equal(t, 3, pending.len())
+
+	// Unpublished records are not announced
+	pending.remove(record1.GetCid())
+
+	records := pending.drain()
+	assert.Equal(t, 0, pending.len())
+	assert.Empty(t, records[routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL])
+	require.Len(t, records[routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH], 2)
+	assert.ElementsMatch(t,
+		[]string{record2.GetCid(), record3.GetCid()},
+		[]string{records[routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH][0].GetCid(), records[routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH][1].GetCid()})
+}
+
+func TestPublish_QueuesWithoutPeers(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{Name: "agent-1", SchemaVersion: "v0.3.1"})
+
+	// A node without bootstrap peers has no peers to announce to
+	r := newTestServer(t, t.Context(), nil)
+	r.local.store = newMockStore()
+
+	_, err := r.local.store.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(record)))
+
+	readiness := r.Readiness()
+	assert.False(t, readiness.NetworkReady())
+	assert.Equal(t, 1, readiness.PendingAnnouncements)
+
+	// The record is still served locally
+	results, err := r.List(t.Context(), &routingv1.ListRequest{})
+	require.NoError(t, err)
+
+	listed := 0
+	for range results {
+		listed++
+	}
+
+	assert.Equal(t, 1, listed)
+
+	// Unpublished records are no longer announced
+	require.NoError(t, r.Unpublish(t.Context(), adapters.NewRecordAdapter(record)))
+	assert.Equal(t, 0, r.Readiness().PendingAnnouncements)
+}
+
+func TestUnpublish_ForgetsDeduplicatedPublish(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{Name: "agent-1", SchemaVersion: "v0.3.1"})
+
+	r := newTestServer(t, t.Context(), nil)
+	r.local.store = newMockStore()
+	r.remote.publishDedup = newPublishDeduplicator(time.Minute)
+
+	_, err := r.local.store.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	announce := func() bool {
+		deduplicated, err := r.remote.publishDedup.Do(t.Context(), record.GetCid(), "", func() error { return nil })
+		require.NoError(t, err)
+
+		return deduplicated
+	}
+
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(record)))
+	require.False(t, announce())
+	require.True(t, announce())
+
+	// Publishing again within the dedup window after unpublishing re-announces the record
+	require.NoError(t, r.Unpublish(t.Context(), adapters.NewRecordAdapter(record)))
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(record)))
+	assert.False(t, announce())
+}
+
+func TestRetract_DeletedRecord(t *testing.T) {
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "agent-1",
+		SchemaVersion: "v0.3.1",
+		Skills:        []*typesv1alpha0.Skill{{CategoryName: toPtr("category1"), ClassName: toPtr("class1")}},
+	})
+
+	r := newTestServer(t, t.Context(), nil)
+	r.local.store = newMockStore()
+
+	_, err := r.local.store.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Publish(t.Context(), adapters.NewRecordAdapter(record)))
+	require.NotEmpty(t, r.local.getRecordLabelsEfficiently(t.Context(), record.GetCid()))
+
+	// The record is deleted from the store, so its labels are taken from the label cache
+	require.NoError(t, r.local.store.Delete(t.Context(), &corev1.RecordRef{Cid: record.GetCid()}))
+	require.NoError(t, r.Retract(t.Context(), record.GetCid()))
+
+	assert.Empty(t, r.local.getRecordLabelsEfficiently(t.Context(), record.GetCid()))
+	assert.Equal(t, 0, r.Readiness().PendingAnnouncements)
+
+	results, err := r.List(t.Context(), &routingv1.ListRequest{})
+	require.NoError(t, err)
+
+	listed := 0
+	for range results {
+		listed++
+	}
+
+	assert.Equal(t, 0, listed)
+
+	// Records that are not published are ignored
+	require.NoError(t, r.Retract(t.Context(), record.GetCid()))
+}
+
+func TestRoutingReadiness(t *testing.T) {
+	assert.False(t, types.RoutingReadiness{Bootstrapped: true}.NetworkReady())
+	assert.False(t, types.RoutingReadiness{Peers: 1}.NetworkReady())
+	assert.True(t, types.RoutingReadiness{Bootstrapped: true, Peers: 1}.NetworkReady())
+
+	ready := types.RoutingReadiness{Bootstrapped: true, Peers: 2, MinPeers: 2, GossipSub: true, TopicPeers: 1, DatastoreWritable: true}
+	assert.True(t, ready.Ready())
+
+	belowMinPeers := ready
+	belowMinPeers.Peers = 1
+	assert.False(t, belowMinPeers.DHTReady())
+	assert.False(t, belowMinPeers.Ready())
+
+	noMesh := ready
+	noMesh.TopicPeers = 0
+	assert.False(t, noMesh.GossipSubReady())
+
+	// Standalone nodes require neither peers nor a mesh
+	standalone := types.RoutingReadiness{Bootstrapped: true, GossipSub: true, DatastoreWritable: true}
+	assert.True(t, standalone.Ready())
+
+	readOnly := ready
+	readOnly.DatastoreWritable = false
+	assert.False(t, readOnly.Ready())
+}
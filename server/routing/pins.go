@@ -0,0 +1,360 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PinNamespace is the datastore namespace of pinned records.
+const PinNamespace = "pins"
+
+// pinKey returns the datastore key of the pin of a record: /pins/<CID>.
+func pinKey(cid string) datastore.Key {
+	return datastore.NewKey("/" + PinNamespace + "/" + cid)
+}
+
+// pin is a pinned record. Cached labels of pinned remote records are exempt from
+// stale and expired label cleanup and from cache eviction. Pinned local records are
+// always republished and exempt from expiry and garbage collection.
+type pin struct {
+	PinnedAt time.Time `json:"pinned_at"`
+	Mirrored bool      `json:"mirrored,omitempty"` // Whether the record was pulled into the local store
+}
+
+// pinSet holds the CIDs of pinned records in memory, so that cleanup and search
+// can check pins without reading the datastore.
+type pinSet struct {
+	mu   sync.RWMutex
+	cids map[string]bool
+}
+
+func newPinSet() *pinSet {
+	return &pinSet{cids: make(map[string]bool)}
+}
+
+// has reports whether a record is pinned. A nil set has no pins.
+func (s *pinSet) has(cid string) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.cids[cid]
+}
+
+func (s *pinSet) add(cid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cids[cid] = true
+}
+
+func (s *pinSet) remove(cid string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.cids, cid)
+}
+
+// queryPins returns the pins stored in the datastore by CID.
+func (r *routeRemote) queryPins(ctx context.Context) (map[string]pin, error) {
+	results, err := r.dstore.Query(ctx, query.Query{Prefix: "/" + PinNamespace + "/"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pins: %w", err)
+	}
+	defer results.Close()
+
+	pins := make(map[string]pin)
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		var p pin
+		if err := json.Unmarshal(result.Value, &p); err != nil {
+			remoteLogger.Warn("Failed to parse pin", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		pins[strings.TrimPrefix(result.Key, "/"+PinNamespace+"/")] = p
+	}
+
+	return pins, nil
+}
+
+// loadPins loads the pins stored in the datastore into the in-memory pin set.
+func (r *routeRemote) loadPins(ctx context.Context) error {
+	pins, err := r.queryPins(ctx)
+	if err != nil {
+		return err
+	}
+
+	for pinnedCID := range pins {
+		r.pins.add(pinnedCID)
+	}
+
+	return nil
+}
+
+// Pin pins local records and remote records with cached labels, optionally mirroring the
+// content of remote records into the local store. Pinning a pinned record only mirrors it if requested.
+func (r *routeRemote) Pin(ctx context.Context, req *routingv1.PinRequest) error {
+	cids, err := refCIDs(req.GetRefs())
+	if err != nil {
+		return err
+	}
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to query label cache: %v", err)
+	}
+
+	providers := cachedProviders(entries, r.server.Host().ID().String(), cids)
+	local := make(map[string]bool, len(cids))
+
+	for _, pinCID := range cids {
+		local[pinCID], err = r.isLocalRecord(ctx, pinCID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "%v", err)
+		}
+
+		if !local[pinCID] && len(providers[pinCID]) == 0 {
+			return status.Errorf(codes.NotFound, "record %s is not published locally and has no cached remote labels", pinCID)
+		}
+	}
+
+	pins, err := r.queryPins(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%v", err)
+	}
+
+	for _, pinCID := range cids {
+		p, ok := pins[pinCID]
+		if !ok {
+			p = pin{PinnedAt: time.Now()}
+		}
+
+		// Local records are already in the local store
+		if req.GetMirrorContent() && !p.Mirrored && !local[pinCID] {
+			if err := r.mirrorRecord(ctx, pinCID, providers[pinCID]); err != nil {
+				return status.Errorf(codes.Unavailable, "failed to mirror record %s: %v", pinCID, err)
+			}
+
+			p.Mirrored = true
+		}
+
+		data, err := json.Marshal(p)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to marshal pin: %v", err)
+		}
+
+		if err := r.dstore.Put(ctx, pinKey(pinCID), data); err != nil {
+			return status.Errorf(codes.Internal, "failed to store pin of record %s: %v", pinCID, err)
+		}
+
+		r.pins.add(pinCID)
+
+		remoteLogger.Info("Pinned record", "cid", pinCID, "local", local[pinCID], "mirrored", p.Mirrored)
+	}
+
+	return nil
+}
+
+// Unpin unpins records. Unpinning a record that is not pinned does nothing.
+// Mirrored records are kept in the local store, and unpinned local records are
+// subject to expiry and garbage collection again.
+func (r *routeRemote) Unpin(ctx context.Context, req *routingv1.UnpinRequest) error {
+	cids, err := refCIDs(req.GetRefs())
+	if err != nil {
+		return err
+	}
+
+	for _, pinCID := range cids {
+		if err := r.dstore.Delete(ctx, pinKey(pinCID)); err != nil {
+			return status.Errorf(codes.Internal, "failed to delete pin of record %s: %v", pinCID, err)
+		}
+
+		r.pins.remove(pinCID)
+
+		remoteLogger.Info("Unpinned record", "cid", pinCID)
+	}
+
+	return nil
+}
+
+// ListPins returns the pinned records ordered by CID.
+func (r *routeRemote) ListPins(ctx context.Context, _ *routingv1.ListPinsRequest) ([]*routingv1.ListPinsResponse, error) {
+	pins, err := r.queryPins(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	resp := make([]*routingv1.ListPinsResponse, 0, len(pins))
+
+	for pinnedCID, p := range pins {
+		local, err := r.isLocalRecord(ctx, pinnedCID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%v", err)
+		}
+
+		resp = append(resp, &routingv1.ListPinsResponse{
+			Cid:      pinnedCID,
+			PinnedAt: timestamppb.New(p.PinnedAt),
+			Mirrored: p.Mirrored,
+			Local:    local,
+		})
+	}
+
+	slices.SortFunc(resp, func(a, b *routingv1.ListPinsResponse) int {
+		return strings.Compare(a.GetCid(), b.GetCid())
+	})
+
+	return resp, nil
+}
+
+// ListPinnedRecords returns the pinned records ordered by CID, with when local records were
+// last reprovided, and the state of the garbage collection of unpinned local records.
+func (r *routeRemote) ListPinnedRecords(ctx context.Context, _ *routingv1.ListPinnedRecordsRequest) (*routingv1.ListPinnedRecordsResponse, error) {
+	pins, err := r.queryPins(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	records := make([]*routingv1.PinnedRecord, 0, len(pins))
+
+	for pinnedCID, p := range pins {
+		record := &routingv1.PinnedRecord{
+			Cid:      pinnedCID,
+			PinnedAt: timestamppb.New(p.PinnedAt),
+			Mirrored: p.Mirrored,
+		}
+
+		value, err := r.dstore.Get(ctx, datastore.NewKey("/records/"+pinnedCID))
+
+		switch {
+		case errors.Is(err, datastore.ErrNotFound):
+		case err != nil:
+			return nil, status.Errorf(codes.Internal, "failed to get local record %s: %v", pinnedCID, err)
+		default:
+			record.Local = true
+
+			if announcedAt := decodeLocalRecordMetadata(value).AnnouncedAt; !announcedAt.IsZero() {
+				record.ReprovidedAt = timestamppb.New(announcedAt)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	slices.SortFunc(records, func(a, b *routingv1.PinnedRecord) int {
+		return strings.Compare(a.GetCid(), b.GetCid())
+	})
+
+	return &routingv1.ListPinnedRecordsResponse{Records: records, Gc: r.recordGC.status()}, nil
+}
+
+// isLocalRecord reports whether a record is published by this peer.
+func (r *routeRemote) isLocalRecord(ctx context.Context, cid string) (bool, error) {
+	local, err := r.dstore.Has(ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return false, fmt.Errorf("failed to check local record %s: %w", cid, err)
+	}
+
+	return local, nil
+}
+
+// cachedProviders returns the remote peers other than localPeerID whose labels of the records
+// are cached, by CID.
+func cachedProviders(entries []NamespaceEntry, localPeerID string, cids []string) map[string][]string {
+	providers := make(map[string][]string, len(cids))
+
+	for _, entry := range entries {
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyPeerID == localPeerID || !slices.Contains(cids, keyCID) {
+			continue
+		}
+
+		if !slices.Contains(providers[keyCID], keyPeerID) {
+			providers[keyCID] = append(providers[keyCID], keyPeerID)
+		}
+	}
+
+	return providers
+}
+
+// mirrorRecord pulls a record from the first of its providers that serves it
+// and stores it in the local store. Providers with a low reputation are skipped.
+func (r *routeRemote) mirrorRecord(ctx context.Context, cidStr string, providers []string) error {
+	var errs []error
+
+	for _, peerID := range providers {
+		if r.reputation.IsExcluded(peerID) {
+			continue
+		}
+
+		record, _, err := r.pullFromProvider(ctx, cidStr, peerID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("peer %s: %w", peerID, err))
+
+			continue
+		}
+
+		if _, err := r.storeAPI.Push(ctx, record); err != nil {
+			return fmt.Errorf("failed to store record: %w", err)
+		}
+
+		remoteLogger.Info("Mirrored pinned record", "cid", cidStr, "peer", peerID)
+
+		return nil
+	}
+
+	if len(errs) == 0 {
+		return errors.New("no provider with a sufficient reputation")
+	}
+
+	return errors.Join(errs...)
+}
+
+// refCIDs validates record references and returns their CIDs without duplicates.
+func refCIDs(refs []*corev1.RecordRef) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one record reference is required") //nolint:wrapcheck
+	}
+
+	cids := make([]string, 0, len(refs))
+
+	for _, ref := range refs {
+		if _, err := cid.Decode(ref.GetCid()); err != nil {
+			return nil, routingerr.ErrCIDInvalid.Errorf("invalid CID %q: %w", ref.GetCid(), err)
+		}
+
+		if !slices.Contains(cids, ref.GetCid()) {
+			cids = append(cids, ref.GetCid())
+		}
+	}
+
+	return cids, nil
+}
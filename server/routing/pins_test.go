@@ -0,0 +1,134 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	pinnedTestCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+	otherTestCID  = "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"
+)
+
+func TestPinSet(t *testing.T) {
+	var nilSet *pinSet
+	assert.False(t, nilSet.has(pinnedTestCID))
+
+	pins := newPinSet()
+	pins.add(pinnedTestCID)
+	assert.True(t, pins.has(pinnedTestCID))
+	assert.False(t, pins.has(otherTestCID))
+
+	pins.remove(pinnedTestCID)
+	assert.False(t, pins.has(pinnedTestCID))
+}
+
+func TestRefCIDs(t *testing.T) {
+	cids, err := refCIDs([]*corev1.RecordRef{{Cid: pinnedTestCID}, {Cid: otherTestCID}, {Cid: pinnedTestCID}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{pinnedTestCID, otherTestCID}, cids)
+
+	_, err = refCIDs(nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = refCIDs([]*corev1.RecordRef{{Cid: "invalid"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCachedProviders(t *testing.T) {
+	entries := []NamespaceEntry{
+		{Key: "/skills/AI/" + pinnedTestCID + "/peer1"},
+		{Key: "/domains/research/" + pinnedTestCID + "/peer1"},
+		{Key: "/skills/AI/" + pinnedTestCID + "/peer2"},
+		{Key: "/skills/AI/" + pinnedTestCID + "/local"},
+		{Key: "/skills/AI/" + otherTestCID + "/peer1"},
+	}
+
+	providers := cachedProviders(entries, "local", []string{pinnedTestCID})
+	assert.Equal(t, map[string][]string{pinnedTestCID: {"peer1", "peer2"}}, providers)
+}
+
+func TestUnpinAndListPins(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	pinnedAt := time.Now().Truncate(time.Second)
+
+	r := &routeRemote{dstore: dstore, pins: newPinSet()}
+	require.NoError(t, dstore.Put(t.Context(), pinKey(pinnedTestCID), []byte(`{"pinned_at":"`+pinnedAt.Format(time.RFC3339)+`","mirrored":true}`)))
+	require.NoError(t, dstore.Put(t.Context(), pinKey(otherTestCID), []byte(`{"pinned_at":"`+pinnedAt.Format(time.RFC3339)+`"}`)))
+
+	require.NoError(t, r.loadPins(t.Context()))
+	assert.True(t, r.pins.has(pinnedTestCID))
+	assert.True(t, r.pins.has(otherTestCID))
+
+	pins, err := r.ListPins(t.Context(), &routingv1.ListPinsRequest{})
+	require.NoError(t, err)
+	require.Len(t, pins, 2)
+	assert.Equal(t, otherTestCID, pins[0].GetCid())
+	assert.False(t, pins[0].GetMirrored())
+	assert.Equal(t, pinnedTestCID, pins[1].GetCid())
+	assert.True(t, pins[1].GetMirrored())
+	assert.True(t, pins[1].GetPinnedAt().AsTime().Equal(pinnedAt))
+
+	require.NoError(t, r.Unpin(t.Context(), &routingv1.UnpinRequest{Refs: []*corev1.RecordRef{{Cid: pinnedTestCID}}}))
+	assert.False(t, r.pins.has(pinnedTestCID))
+
+	pins, err = r.ListPins(t.Context(), &routingv1.ListPinsRequest{})
+	require.NoError(t, err)
+	require.Len(t, pins, 1)
+	assert.Equal(t, otherTestCID, pins[0].GetCid())
+
+	// Unpinning a record that is not pinned does nothing
+	require.NoError(t, r.Unpin(t.Context(), &routingv1.UnpinRequest{Refs: []*corev1.RecordRef{{Cid: pinnedTestCID}}}))
+}
+
+func TestListPinnedRecords(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	pinnedAt := time.Now().Truncate(time.Second)
+	announcedAt := pinnedAt.Add(time.Hour)
+
+	r := &routeRemote{dstore: dstore, pins: newPinSet()}
+	require.NoError(t, dstore.Put(t.Context(), pinKey(pinnedTestCID), []byte(`{"pinned_at":"`+pinnedAt.Format(time.RFC3339)+`"}`)))
+	require.NoError(t, dstore.Put(t.Context(), pinKey(otherTestCID), []byte(`{"pinned_at":"`+pinnedAt.Format(time.RFC3339)+`","mirrored":true}`)))
+
+	value, err := encodeLocalRecordMetadata(localRecordMetadata{AnnouncedAt: announcedAt})
+	require.NoError(t, err)
+	require.NoError(t, dstore.Put(t.Context(), datastore.NewKey("/records/"+pinnedTestCID), value))
+
+	pins, err := r.ListPins(t.Context(), &routingv1.ListPinsRequest{})
+	require.NoError(t, err)
+	require.Len(t, pins, 2)
+	assert.False(t, pins[0].GetLocal())
+	assert.True(t, pins[1].GetLocal())
+
+	resp, err := r.ListPinnedRecords(t.Context(), &routingv1.ListPinnedRecordsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRecords(), 2)
+
+	remote, local := resp.GetRecords()[0], resp.GetRecords()[1]
+	assert.Equal(t, otherTestCID, remote.GetCid())
+	assert.False(t, remote.GetLocal())
+	assert.True(t, remote.GetMirrored())
+	assert.Nil(t, remote.GetReprovidedAt())
+
+	assert.Equal(t, pinnedTestCID, local.GetCid())
+	assert.True(t, local.GetLocal())
+	assert.True(t, local.GetReprovidedAt().AsTime().Equal(announcedAt))
+
+	// Garbage collection is reported disabled unless configured
+	assert.False(t, resp.GetGc().GetEnabled())
+}
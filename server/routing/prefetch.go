@@ -0,0 +1,271 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// PrefetchNamespace is the datastore namespace of the records prefetched into the local store.
+const PrefetchNamespace = "prefetched"
+
+// prefetchKey returns the datastore key of a prefetched record: /prefetched/<CID>.
+func prefetchKey(cid string) datastore.Key {
+	return datastore.NewKey("/" + PrefetchNamespace + "/" + cid)
+}
+
+// prefetchedRecord is a record pulled into the local store because it was a search result.
+type prefetchedRecord struct {
+	Size         uint64    `json:"size"`
+	PrefetchedAt time.Time `json:"prefetched_at"`
+}
+
+// prefetchRequest is a search result waiting to be prefetched from the peer that provided it.
+type prefetchRequest struct {
+	cid    string
+	peerID string
+}
+
+// prefetcher queues search results matching enough queries for prefetching,
+// so that pulling them after searching is served from the local store.
+// Results are dropped if the queue is full, as prefetching is an optimization.
+type prefetcher struct {
+	minScore uint32
+	quota    uint64
+	queue    chan prefetchRequest
+
+	mu      sync.Mutex
+	pending map[string]bool // CIDs queued or being prefetched
+}
+
+func newPrefetcher(cfg routingconfig.PrefetchConfig) *prefetcher {
+	return &prefetcher{
+		minScore: cfg.MinScore,
+		quota:    cfg.QuotaBytes,
+		queue:    make(chan prefetchRequest, PrefetchQueueSize),
+		pending:  make(map[string]bool),
+	}
+}
+
+// offer queues a search result for prefetching if it matched enough queries.
+// Access-gated records and records larger than the quota are skipped.
+func (p *prefetcher) offer(resp *routingv1.SearchResponse) {
+	if resp.GetMatchScore() < p.minScore || resp.GetAccessGated() || resp.GetPeer().GetId() == "" {
+		return
+	}
+
+	if resp.GetContentSize() > p.quota {
+		metrics.Prefetches.WithLabelValues(metrics.ResultLimited).Inc()
+
+		return
+	}
+
+	cidStr := resp.GetRecordRef().GetCid()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pending[cidStr] {
+		return
+	}
+
+	select {
+	case p.queue <- prefetchRequest{cid: cidStr, peerID: resp.GetPeer().GetId()}:
+		p.pending[cidStr] = true
+	default:
+		metrics.Prefetches.WithLabelValues(metrics.ResultDropped).Inc()
+	}
+}
+
+// done marks a record as no longer being prefetched.
+func (p *prefetcher) done(cid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.pending, cid)
+}
+
+// prefetchResults forwards search results to out, offering each of them for prefetching.
+func (r *routeRemote) prefetchResults(ctx context.Context, results <-chan *routingv1.SearchResponse) <-chan *routingv1.SearchResponse {
+	out := make(chan *routingv1.SearchResponse)
+
+	go func() {
+		defer close(out)
+
+		for resp := range results {
+			r.prefetch.offer(resp)
+
+			select {
+			case out <- resp:
+			case <-ctx.Done():
+				// Drain the search, so that it is not blocked sending results
+				for range results { //nolint:revive // Intentionally empty
+				}
+
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// startPrefetching prefetches queued search results one at a time until routing stops.
+func (r *routeRemote) startPrefetching() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		remoteLogger.Info("Started prefetching search results", "minScore", r.prefetch.minScore, "quotaBytes", r.prefetch.quota)
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping prefetching of search results")
+
+				return
+			case req := <-r.prefetch.queue:
+				ctx, cancel := context.WithTimeout(r.ctx, PrefetchTimeout)
+				result, err := r.prefetchRecord(ctx, req)
+
+				cancel()
+				r.prefetch.done(req.cid)
+
+				metrics.Prefetches.WithLabelValues(result).Inc()
+
+				if err != nil {
+					remoteLogger.Debug("Failed to prefetch search result", "cid", req.cid, "peer", req.peerID, "error", err)
+				}
+			}
+		}
+	}()
+}
+
+// prefetchRecord pulls a record from its provider into the local store, making room for it
+// within the quota. Returns the metrics result of the prefetch.
+func (r *routeRemote) prefetchRecord(ctx context.Context, req prefetchRequest) (string, error) {
+	if _, err := r.storeAPI.Lookup(ctx, &corev1.RecordRef{Cid: req.cid}); err == nil {
+		return metrics.ResultPresent, nil
+	}
+
+	if r.reputation.IsExcluded(req.peerID) {
+		return metrics.ResultFailure, errors.New("provider has an insufficient reputation")
+	}
+
+	record, _, err := r.pullFromProvider(ctx, req.cid, req.peerID)
+	if err != nil {
+		return metrics.ResultFailure, err
+	}
+
+	size := recordContentSize(adapters.NewRecordAdapter(record))
+	if size > r.prefetch.quota {
+		return metrics.ResultLimited, fmt.Errorf("record of %d bytes exceeds the prefetch quota", size)
+	}
+
+	if err := r.evictPrefetched(ctx, r.prefetch.quota-size); err != nil {
+		return metrics.ResultFailure, err
+	}
+
+	if _, err := r.storeAPI.Push(ctx, record); err != nil {
+		return metrics.ResultFailure, fmt.Errorf("failed to store record: %w", err)
+	}
+
+	data, err := json.Marshal(prefetchedRecord{Size: size, PrefetchedAt: time.Now()})
+	if err != nil {
+		return metrics.ResultFailure, fmt.Errorf("failed to marshal prefetched record: %w", err)
+	}
+
+	if err := r.dstore.Put(ctx, prefetchKey(req.cid), data); err != nil {
+		return metrics.ResultFailure, fmt.Errorf("failed to store prefetched record: %w", err)
+	}
+
+	remoteLogger.Debug("Prefetched search result", "cid", req.cid, "peer", req.peerID, "size", size)
+
+	return metrics.ResultSuccess, nil
+}
+
+// evictPrefetched deletes the least recently prefetched records from the local store until
+// the prefetched records take at most limit bytes. Evicted records that were since published
+// or pinned are kept in the store, and only no longer count towards the quota.
+func (r *routeRemote) evictPrefetched(ctx context.Context, limit uint64) error {
+	results, err := r.dstore.Query(ctx, query.Query{Prefix: "/" + PrefetchNamespace + "/"})
+	if err != nil {
+		return fmt.Errorf("failed to query prefetched records: %w", err)
+	}
+
+	type prefetched struct {
+		cid string
+		prefetchedRecord
+	}
+
+	var (
+		records []prefetched
+		total   uint64
+	)
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		var record prefetchedRecord
+		if err := json.Unmarshal(result.Value, &record); err != nil {
+			remoteLogger.Warn("Failed to parse prefetched record", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		records = append(records, prefetched{cid: strings.TrimPrefix(result.Key, "/"+PrefetchNamespace+"/"), prefetchedRecord: record})
+		total += record.Size
+	}
+
+	results.Close()
+
+	slices.SortFunc(records, func(a, b prefetched) int {
+		return a.PrefetchedAt.Compare(b.PrefetchedAt)
+	})
+
+	for _, record := range records {
+		if total <= limit {
+			break
+		}
+
+		owned, err := r.dstore.Has(ctx, datastore.NewKey("/records/"+record.cid))
+		if err != nil {
+			return fmt.Errorf("failed to check local record: %w", err)
+		}
+
+		if !owned && !r.pins.has(record.cid) {
+			if err := r.storeAPI.Delete(ctx, &corev1.RecordRef{Cid: record.cid}); err != nil {
+				return fmt.Errorf("failed to delete prefetched record %s: %w", record.cid, err)
+			}
+		}
+
+		if err := r.dstore.Delete(ctx, prefetchKey(record.cid)); err != nil {
+			return fmt.Errorf("failed to delete prefetched record %s: %w", record.cid, err)
+		}
+
+		total -= record.Size
+
+		remoteLogger.Debug("Evicted prefetched record", "cid", record.cid, "kept", owned || r.pins.has(record.cid))
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/datastore"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSearchResponse(cid string, score uint32) *routingv1.SearchResponse {
+	return &routingv1.SearchResponse{
+		RecordRef:  &corev1.RecordRef{Cid: cid},
+		Peer:       &routingv1.Peer{Id: "peer1"},
+		MatchScore: score,
+	}
+}
+
+func TestPrefetcher_Offer(t *testing.T) {
+	p := newPrefetcher(routingconfig.PrefetchConfig{Enabled: true, MinScore: 2, QuotaBytes: 1024})
+
+	// Results below the minimum score, access-gated or larger than the quota are not prefetched
+	p.offer(newTestSearchResponse("cid-low", 1))
+
+	gated := newTestSearchResponse("cid-gated", 2)
+	gated.AccessGated = true
+	p.offer(gated)
+
+	large := newTestSearchResponse("cid-large", 2)
+	large.ContentSize = 2048
+	p.offer(large)
+
+	assert.Empty(t, p.queue)
+
+	// Records are queued once until they were prefetched
+	p.offer(newTestSearchResponse("cid-good", 3))
+	p.offer(newTestSearchResponse("cid-good", 3))
+	require.Len(t, p.queue, 1)
+
+	req := <-p.queue
+	assert.Equal(t, prefetchRequest{cid: "cid-good", peerID: "peer1"}, req)
+
+	p.done(req.cid)
+	p.offer(newTestSearchResponse("cid-good", 3))
+	assert.Len(t, p.queue, 1)
+}
+
+func TestEvictPrefetched_LeastRecentlyPrefetchedFirst(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	store := newMockStore()
+	r := &routeRemote{dstore: dstore, storeAPI: store, pins: newPinSet()}
+
+	prefetched := func(cid string, size uint64, at time.Time) {
+		store.data[cid] = &corev1.Record{}
+
+		data, err := json.Marshal(prefetchedRecord{Size: size, PrefetchedAt: at})
+		require.NoError(t, err)
+		require.NoError(t, dstore.Put(ctx, prefetchKey(cid), data))
+	}
+
+	now := time.Now()
+	prefetched("cid-oldest", 100, now.Add(-3*time.Hour))
+	prefetched("cid-pinned", 100, now.Add(-2*time.Hour))
+	prefetched("cid-newest", 100, now.Add(-time.Hour))
+	r.pins.add("cid-pinned")
+
+	// Nothing is evicted while the records fit
+	require.NoError(t, r.evictPrefetched(ctx, 300))
+	assert.Len(t, store.data, 3)
+
+	// Pinned records are kept in the store, but stop counting towards the quota
+	require.NoError(t, r.evictPrefetched(ctx, 100))
+	assert.NotContains(t, store.data, "cid-oldest")
+	assert.Contains(t, store.data, "cid-pinned")
+	assert.Contains(t, store.data, "cid-newest")
+
+	for _, cid := range []string{"cid-oldest", "cid-pinned"} {
+		exists, err := dstore.Has(ctx, prefetchKey(cid))
+		require.NoError(t, err)
+		assert.False(t, exists, cid)
+	}
+
+	exists, err := dstore.Has(ctx, ipfsdatastore.NewKey("/"+PrefetchNamespace+"/cid-newest"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -0,0 +1,75 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+)
+
+// localRecordMetadata is stored as the value of local "/records/CID" keys.
+// Records published before priorities existed have an empty value and
+// are treated as normal priority and never expire.
+type localRecordMetadata struct {
+	Priority    routingv1.AnnouncementPriority `json:"priority,omitempty"`
+	ExpiresAt   time.Time                      `json:"expires_at,omitzero"`
+	PublishedAt time.Time                      `json:"published_at,omitzero"`  // Zero for records published before publish times were stored
+	AnnouncedAt time.Time                      `json:"announced_at,omitzero"`  // Last republish, zero if never republished
+	Supersedes  string                         `json:"supersedes,omitempty"`   // CID of the previous version of the record
+	AccessGated bool                           `json:"access_gated,omitempty"` // Content only served to authorized peers
+	Tenant      string                         `json:"tenant,omitempty"`       // Tenant the record was published for, empty if untenanted
+	Size        uint64                         `json:"size,omitempty"`         // Size of the canonical content, zero for records published before sizes were stored
+	Publisher   string                         `json:"publisher,omitempty"`    // did:key of the publisher the record was published as, empty if this peer
+
+	// Label policies the record was published with, overriding the configured publish policy
+	LabelPolicies []types.LabelPolicy `json:"label_policies,omitempty"`
+
+	// Summary included in the record's announcements, empty for records published before summaries were stored
+	types.RecordSummary
+}
+
+// expired reports whether the record's TTL has elapsed at the given time.
+func (m localRecordMetadata) expired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
+// normalizePriority maps unspecified and unknown priorities to normal.
+func normalizePriority(priority routingv1.AnnouncementPriority) routingv1.AnnouncementPriority {
+	switch priority {
+	case routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH,
+		routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW:
+		return priority
+	case routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED,
+		routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL:
+		return routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL
+	default:
+		return routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL
+	}
+}
+
+// encodeLocalRecordMetadata serializes the value of a local record key.
+func encodeLocalRecordMetadata(metadata localRecordMetadata) ([]byte, error) {
+	metadata.Priority = normalizePriority(metadata.Priority)
+
+	return json.Marshal(metadata) //nolint:wrapcheck
+}
+
+// decodeLocalRecordMetadata parses the value of a local record key.
+// Empty or malformed values yield normal priority.
+func decodeLocalRecordMetadata(value []byte) localRecordMetadata {
+	var metadata localRecordMetadata
+
+	if len(value) > 0 {
+		if err := json.Unmarshal(value, &metadata); err != nil {
+			localLogger.Warn("Failed to decode local record metadata", "error", err)
+		}
+	}
+
+	metadata.Priority = normalizePriority(metadata.Priority)
+
+	return metadata
+}
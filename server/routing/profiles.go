@@ -0,0 +1,114 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// discoveryProfile bundles the routing behavior of a node for its role in the network.
+type discoveryProfile struct {
+	profile         routingv1.DiscoveryProfile
+	dhtServer       bool // Serve DHT records to other peers, applied at startup only
+	gossipSub       bool // Cache the labels of GossipSub announcements
+	maxCachedLabels int  // Remote labels kept before records are evicted (0 = the configured limit)
+	republish       bool // Republish local records before their provider records expire
+}
+
+var discoveryProfiles = map[routingv1.DiscoveryProfile]discoveryProfile{
+	routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL: {
+		profile:   routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL,
+		dhtServer: true,
+		gossipSub: true,
+		republish: true,
+	},
+	routingv1.DiscoveryProfile_DISCOVERY_PROFILE_EDGE: {
+		profile:         routingv1.DiscoveryProfile_DISCOVERY_PROFILE_EDGE,
+		gossipSub:       true,
+		maxCachedLabels: EdgeProfileMaxCachedLabels,
+		republish:       true,
+	},
+	routingv1.DiscoveryProfile_DISCOVERY_PROFILE_CLIENT: {
+		profile:         routingv1.DiscoveryProfile_DISCOVERY_PROFILE_CLIENT,
+		maxCachedLabels: ClientProfileMaxCachedLabels,
+	},
+}
+
+// parseDiscoveryProfile returns the discovery profile configured by name.
+// An empty name selects the full profile.
+func parseDiscoveryProfile(name string) (discoveryProfile, error) {
+	if name == "" {
+		return discoveryProfiles[routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL], nil
+	}
+
+	profile, ok := discoveryProfiles[routingv1.DiscoveryProfile(routingv1.DiscoveryProfile_value["DISCOVERY_PROFILE_"+strings.ToUpper(name)])]
+	if !ok {
+		return discoveryProfile{}, fmt.Errorf("unknown discovery profile %q, must be one of: full, edge, client", name)
+	}
+
+	return profile, nil
+}
+
+// cacheLimit returns the number of remote labels the profile keeps with the configured limit.
+// The lower of both limits applies; zero leaves the cache unbounded.
+func (p discoveryProfile) cacheLimit(configured int) int {
+	if p.maxCachedLabels > 0 && (configured <= 0 || p.maxCachedLabels < configured) {
+		return p.maxCachedLabels
+	}
+
+	return configured
+}
+
+// currentProfile returns the discovery profile the node currently runs with.
+// Nodes without a profile run as full nodes.
+func (r *routeRemote) currentProfile() discoveryProfile {
+	if profile := r.profile.Load(); profile != nil {
+		return *profile
+	}
+
+	return discoveryProfiles[routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL]
+}
+
+// cacheLimit returns the number of remote labels kept before records are evicted (0 = unbounded).
+func (r *routeRemote) cacheLimit() int {
+	return r.currentProfile().cacheLimit(r.maxCachedLabels)
+}
+
+// republishEnabled reports whether local records are republished by the current profile.
+func (r *routeRemote) republishEnabled() bool {
+	return r.currentProfile().republish
+}
+
+// SetProfile switches the discovery profile of the node until it is restarted.
+// The DHT mode is only applied at startup, so a restart is required to change it,
+// unless the DHT mode is configured regardless of the profile.
+func (r *routeRemote) SetProfile(_ context.Context, req *routingv1.SetProfileRequest) (*routingv1.SetProfileResponse, error) {
+	profile, ok := discoveryProfiles[req.GetProfile()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown discovery profile %s", req.GetProfile())
+	}
+
+	previous := discoveryProfiles[routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL]
+	if p := r.profile.Swap(&profile); p != nil {
+		previous = *p
+	}
+
+	remoteLogger.Info("Switched discovery profile",
+		"profile", profile.profile,
+		"previous", previous.profile,
+		"gossipSub", profile.gossipSub,
+		"maxCachedLabels", r.cacheLimit(),
+		"republish", profile.republish)
+
+	return &routingv1.SetProfileResponse{
+		PreviousProfile: previous.profile,
+		RestartRequired: r.dhtMode == "" && profile.dhtServer != r.dhtServer,
+	}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiscoveryProfile(t *testing.T) {
+	profile, err := parseDiscoveryProfile("")
+	require.NoError(t, err)
+	assert.Equal(t, routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL, profile.profile)
+	assert.True(t, profile.dhtServer)
+
+	profile, err = parseDiscoveryProfile("Edge")
+	require.NoError(t, err)
+	assert.Equal(t, routingv1.DiscoveryProfile_DISCOVERY_PROFILE_EDGE, profile.profile)
+	assert.False(t, profile.dhtServer)
+	assert.True(t, profile.gossipSub)
+
+	profile, err = parseDiscoveryProfile("client")
+	require.NoError(t, err)
+	assert.False(t, profile.gossipSub)
+	assert.False(t, profile.republish)
+
+	for _, name := range []string{"unknown", "unspecified"} {
+		_, err := parseDiscoveryProfile(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestDiscoveryProfile_CacheLimit(t *testing.T) {
+	full := discoveryProfiles[routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL]
+	assert.Equal(t, 0, full.cacheLimit(0))
+	assert.Equal(t, 500, full.cacheLimit(500))
+
+	// The lower of the profile's and the configured limit applies
+	client := discoveryProfiles[routingv1.DiscoveryProfile_DISCOVERY_PROFILE_CLIENT]
+	assert.Equal(t, ClientProfileMaxCachedLabels, client.cacheLimit(0))
+	assert.Equal(t, 500, client.cacheLimit(500))
+	assert.Equal(t, ClientProfileMaxCachedLabels, client.cacheLimit(ClientProfileMaxCachedLabels*2))
+}
+
+func TestSetProfile(t *testing.T) {
+	r := &routeRemote{maxCachedLabels: 0, dhtServer: true}
+
+	// Nodes without a profile run as full nodes
+	assert.True(t, r.republishEnabled())
+	assert.Equal(t, 0, r.cacheLimit())
+
+	resp, err := r.SetProfile(t.Context(), &routingv1.SetProfileRequest{Profile: routingv1.DiscoveryProfile_DISCOVERY_PROFILE_CLIENT})
+	require.NoError(t, err)
+	assert.Equal(t, routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL, resp.GetPreviousProfile())
+	assert.True(t, resp.GetRestartRequired())
+	assert.False(t, r.republishEnabled())
+	assert.Equal(t, ClientProfileMaxCachedLabels, r.cacheLimit())
+
+	// Switching back to the DHT mode the node runs with requires no restart
+	resp, err = r.SetProfile(t.Context(), &routingv1.SetProfileRequest{Profile: routingv1.DiscoveryProfile_DISCOVERY_PROFILE_FULL})
+	require.NoError(t, err)
+	assert.Equal(t, routingv1.DiscoveryProfile_DISCOVERY_PROFILE_CLIENT, resp.GetPreviousProfile())
+	assert.False(t, resp.GetRestartRequired())
+
+	// The configured DHT mode does not depend on the profile
+	r.dhtMode = "server"
+	resp, err = r.SetProfile(t.Context(), &routingv1.SetProfileRequest{Profile: routingv1.DiscoveryProfile_DISCOVERY_PROFILE_EDGE})
+	require.NoError(t, err)
+	assert.False(t, resp.GetRestartRequired())
+
+	_, err = r.SetProfile(t.Context(), &routingv1.SetProfileRequest{})
+	assert.Error(t, err)
+}
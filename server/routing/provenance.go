@@ -0,0 +1,278 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ProvenanceNamespace is the datastore namespace of the provenance log.
+const ProvenanceNamespace = "provenance"
+
+// ProvenanceSourceLocal is the provenance source of records published by this peer.
+// Announcements of remote peers are logged with the transport they were received over.
+const ProvenanceSourceLocal = "local"
+
+// provenanceKey returns the datastore key of an announcement of the record received at the given time:
+// /provenance/<cid>/<unix nanoseconds>-<peer ID>. Nanoseconds are zero-padded, so that the
+// announcements of a record sort by time.
+func provenanceKey(cid, peerID string, receivedAt time.Time) string {
+	return fmt.Sprintf("/%s/%s/%020d-%s", ProvenanceNamespace, cid, receivedAt.UnixNano(), peerID)
+}
+
+// provenanceEntry is an announcement of a record stored in the provenance log.
+type provenanceEntry struct {
+	CID         string    `json:"cid"`
+	PeerID      string    `json:"peer_id"`
+	Source      string    `json:"source"`
+	ReceivedAt  time.Time `json:"received_at"`
+	AnnouncedAt time.Time `json:"announced_at"`
+	Publisher   string    `json:"publisher,omitempty"`
+	Signed      bool      `json:"signed,omitempty"`
+	Supersedes  string    `json:"supersedes,omitempty"`
+}
+
+// newProvenanceEntry returns the provenance entry of an announcement with the given label metadata.
+func newProvenanceEntry(cid, peerID, source string, metadata *types.LabelMetadata, signed bool, receivedAt time.Time) *provenanceEntry {
+	return &provenanceEntry{
+		CID:         cid,
+		PeerID:      peerID,
+		Source:      source,
+		ReceivedAt:  receivedAt,
+		AnnouncedAt: metadata.Timestamp,
+		Publisher:   metadata.Publisher,
+		Signed:      signed,
+		Supersedes:  metadata.Supersedes,
+	}
+}
+
+// toProto returns the entry for the provenance RPC.
+func (e provenanceEntry) toProto() *routingv1.ProvenanceEntry {
+	return &routingv1.ProvenanceEntry{
+		PeerId:      e.PeerID,
+		Source:      e.Source,
+		ReceivedAt:  timestamppb.New(e.ReceivedAt),
+		AnnouncedAt: timestamppb.New(e.AnnouncedAt),
+		Publisher:   e.Publisher,
+		Signed:      e.Signed,
+		Supersedes:  e.Supersedes,
+	}
+}
+
+// provenanceLog is an append-only log of the announcements of records received by this peer,
+// stored alongside the label cache: entries are written by the cache mutations of the announced
+// labels, so that both are written together. Entries are never updated; those older than the
+// retention are pruned, except for the first announcement of each record.
+// A nil log logs nothing.
+type provenanceLog struct {
+	dstore    types.Datastore
+	retention time.Duration
+}
+
+func newProvenanceLog(dstore types.Datastore, retention time.Duration) *provenanceLog {
+	return &provenanceLog{dstore: dstore, retention: retention}
+}
+
+// append adds the announcement to the cache mutation.
+func (l *provenanceLog) append(m *cacheMutation, entry provenanceEntry) {
+	if l == nil {
+		return
+	}
+
+	value, err := json.Marshal(entry)
+	if err != nil {
+		remoteLogger.Warn("Failed to marshal provenance entry", "cid", entry.CID, "error", err)
+
+		return
+	}
+
+	m.put(provenanceKey(entry.CID, entry.PeerID, entry.ReceivedAt), value)
+}
+
+// entries returns the logged announcements of the record, ordered by the time they were received.
+func (l *provenanceLog) entries(ctx context.Context, cid string) ([]provenanceEntry, error) {
+	results, err := l.dstore.Query(ctx, query.Query{
+		Prefix: "/" + ProvenanceNamespace + "/" + cid + "/",
+		Orders: []query.Order{query.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query provenance log: %w", err)
+	}
+	defer results.Close()
+
+	var entries []provenanceEntry
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("failed to read provenance entry: %w", result.Error)
+		}
+
+		var entry provenanceEntry
+		if err := json.Unmarshal(result.Value, &entry); err != nil {
+			remoteLogger.Warn("Skipping invalid provenance entry", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// prune deletes the entries received before the retention, except for the first entry of each record.
+// Returns the number of deleted entries.
+func (l *provenanceLog) prune(ctx context.Context, now time.Time) (int, error) {
+	results, err := l.dstore.Query(ctx, query.Query{
+		Prefix:   "/" + ProvenanceNamespace + "/",
+		Orders:   []query.Order{query.OrderByKey{}},
+		KeysOnly: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query provenance log: %w", err)
+	}
+	defer results.Close()
+
+	cutoff := now.Add(-l.retention).UnixNano()
+	previousCID := ""
+	pruned := 0
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			return pruned, fmt.Errorf("failed to read provenance entry: %w", result.Error)
+		}
+
+		// Keys are /provenance/<cid>/<unix nanoseconds>-<peer ID>
+		cid, entry, ok := strings.Cut(strings.TrimPrefix(result.Key, "/"+ProvenanceNamespace+"/"), "/")
+		if !ok {
+			continue
+		}
+
+		// Keep the first announcement of each record
+		if cid != previousCID {
+			previousCID = cid
+
+			continue
+		}
+
+		nanos, _, _ := strings.Cut(entry, "-")
+
+		receivedAt, err := strconv.ParseInt(nanos, 10, 64)
+		if err != nil || receivedAt >= cutoff {
+			continue
+		}
+
+		if err := l.dstore.Delete(ctx, datastore.NewKey(result.Key)); err != nil {
+			return pruned, fmt.Errorf("failed to delete provenance entry: %w", err)
+		}
+
+		pruned++
+	}
+
+	return pruned, nil
+}
+
+// logReannouncement logs a DHT announcement of a record whose labels are already cached.
+// DHT provider records carry no announcement time, so it is the time it was received.
+func (r *routeRemote) logReannouncement(ctx context.Context, cid, peerID string) {
+	if r.provenance == nil {
+		return
+	}
+
+	now := time.Now()
+	entry := &cacheMutation{}
+	r.provenance.append(entry, provenanceEntry{CID: cid, PeerID: peerID, Source: metrics.TransportDHT, ReceivedAt: now, AnnouncedAt: now})
+
+	if err := commitCacheMutation(ctx, r.dstore, entry); err != nil {
+		remoteLogger.Warn("Failed to log reannouncement", "cid", cid, "peer", peerID, "error", err)
+	}
+}
+
+// startProvenancePruning starts a background goroutine that periodically prunes
+// the provenance log.
+func (r *routeRemote) startProvenancePruning() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(ProvenancePruneInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping provenance log pruning")
+
+				return
+			case <-ticker.C:
+				pruned, err := r.provenance.prune(r.ctx, time.Now())
+				if err != nil {
+					remoteLogger.Warn("Failed to prune provenance log", "error", err)
+				}
+
+				if pruned > 0 {
+					remoteLogger.Debug("Pruned provenance log", "entries", pruned)
+				}
+			}
+		}
+	}()
+}
+
+// GetProvenance returns the logged announcements and the current providers of a record.
+func (r *routeRemote) GetProvenance(ctx context.Context, req *routingv1.GetProvenanceRequest) (*routingv1.GetProvenanceResponse, error) {
+	if r.provenance == nil {
+		return nil, status.Error(codes.FailedPrecondition, "provenance is not logged, enable routing.provenance to log it") //nolint:wrapcheck
+	}
+
+	cid := req.GetCid()
+	if cid == "" || strings.Contains(cid, "/") {
+		return nil, routingerr.ErrCIDInvalid.Errorf("invalid CID %q", cid)
+	}
+
+	entries, err := r.provenance.entries(ctx, cid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, status.Errorf(codes.NotFound, "no announcement of record %s was logged", cid)
+	}
+
+	// Entries are ordered by key, i.e. by the time they were received
+	resp := &routingv1.GetProvenanceResponse{
+		Cid:               cid,
+		FirstAnnouncement: entries[0].toProto(),
+		Providers:         r.providerSets.Peers(cid),
+		Announcements:     make([]*routingv1.ProvenanceEntry, 0, len(entries)),
+	}
+
+	// Local records are not part of the provider sets of remote records
+	if published, err := r.dstore.Has(ctx, datastore.NewKey("/records/"+cid)); err == nil && published {
+		resp.Providers = append(resp.Providers, r.server.Host().ID().String())
+		slices.Sort(resp.Providers)
+	}
+
+	for _, entry := range entries {
+		resp.Announcements = append(resp.Announcements, entry.toProto())
+	}
+
+	return resp, nil
+}
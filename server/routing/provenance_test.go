@@ -0,0 +1,82 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetProvenance(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore:        dstore,
+		peerStats:     peerstats.New(),
+		cardinality:   cardinality.NewIndex(),
+		providerSets:  providerset.NewIndex(),
+		announcements: newAnnouncementChecks(),
+	}
+
+	_, err := r.GetProvenance(t.Context(), &routingv1.GetProvenanceRequest{Cid: "cid1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	r.provenance = newProvenanceLog(dstore, time.Hour)
+	now := time.Now()
+
+	announce := func(peerID, source string, receivedAt time.Time, signed bool) {
+		labels := &cacheMutation{}
+		labels.put("/skills/AI/cid1/"+peerID, []byte(`{}`))
+
+		metadata := &types.LabelMetadata{Timestamp: receivedAt.Add(-time.Second), Publisher: "did:key:z6Mkpublisher"}
+		require.NoError(t, r.cacheRemoteLabels(t.Context(), peerID, labels, newProvenanceEntry("cid1", peerID, source, metadata, signed, receivedAt)))
+	}
+
+	announce("peer1", metrics.TransportGossipSub, now.Add(-3*time.Hour), true)
+	announce("peer2", metrics.TransportDHT, now.Add(-2*time.Hour), false)
+	announce("peer1", metrics.TransportGossipSub, now, true)
+
+	// Announcements are logged alongside the labels, but are not cached labels themselves
+	assert.Equal(t, int64(2), r.peerStats.TotalLabels())
+
+	resp, err := r.GetProvenance(t.Context(), &routingv1.GetProvenanceRequest{Cid: "cid1"})
+	require.NoError(t, err)
+	assert.Equal(t, "peer1", resp.GetFirstAnnouncement().GetPeerId())
+	assert.Equal(t, []string{"peer1", "peer2"}, resp.GetProviders())
+	require.Len(t, resp.GetAnnouncements(), 3)
+	assert.Equal(t, "peer2", resp.GetAnnouncements()[1].GetPeerId())
+	assert.Equal(t, metrics.TransportDHT, resp.GetAnnouncements()[1].GetSource())
+	assert.True(t, resp.GetAnnouncements()[2].GetSigned())
+	assert.Equal(t, "did:key:z6Mkpublisher", resp.GetAnnouncements()[2].GetPublisher())
+	assert.True(t, resp.GetAnnouncements()[2].GetAnnouncedAt().AsTime().Before(resp.GetAnnouncements()[2].GetReceivedAt().AsTime()))
+
+	// Pruning keeps the first announcement of the record
+	pruned, err := r.provenance.prune(t.Context(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	resp, err = r.GetProvenance(t.Context(), &routingv1.GetProvenanceRequest{Cid: "cid1"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetAnnouncements(), 2)
+	assert.Equal(t, "peer1", resp.GetFirstAnnouncement().GetPeerId())
+	assert.Equal(t, "peer1", resp.GetAnnouncements()[1].GetPeerId())
+
+	_, err = r.GetProvenance(t.Context(), &routingv1.GetProvenanceRequest{Cid: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = r.GetProvenance(t.Context(), &routingv1.GetProvenanceRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// providerLookupCache caches the results of DHT provider lookups, so that repeated
+// lookups of the same record, in particular of records without providers, do not
+// query the DHT every time. Lookups that found no providers are cached for a
+// shorter time than lookups that found some.
+type providerLookupCache struct {
+	positiveTTL time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*providerLookup
+}
+
+type providerLookup struct {
+	providers []peer.ID
+	complete  bool // The lookup ended before reaching its limit, so all providers were found
+	expiresAt time.Time
+}
+
+func newProviderLookupCache(positiveTTL, negativeTTL time.Duration) *providerLookupCache {
+	return &providerLookupCache{
+		positiveTTL: positiveTTL,
+		negativeTTL: negativeTTL,
+		entries:     make(map[string]*providerLookup),
+	}
+}
+
+// get returns up to limit cached providers of the record, if a lookup that found
+// at least limit providers or all of them has not expired yet.
+func (c *providerLookupCache) get(cidStr string, limit int, now time.Time) ([]peer.ID, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[cidStr]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	if !entry.complete && len(entry.providers) < limit {
+		return nil, false
+	}
+
+	return entry.providers[:min(limit, len(entry.providers))], true
+}
+
+// put caches the providers found by a lookup. Incomplete lookups without providers,
+// e.g. cancelled ones, are not cached as they do not tell that the record has none.
+func (c *providerLookupCache) put(cidStr string, providers []peer.ID, complete bool, now time.Time) {
+	if c == nil || (len(providers) == 0 && !complete) {
+		return
+	}
+
+	ttl := c.positiveTTL
+	if len(providers) == 0 {
+		ttl = c.negativeTTL
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[cidStr]; !ok && len(c.entries) >= MaxProviderLookups {
+		c.pruneLocked(now)
+
+		if len(c.entries) >= MaxProviderLookups {
+			return
+		}
+	}
+
+	c.entries[cidStr] = &providerLookup{providers: providers, complete: complete, expiresAt: now.Add(ttl)}
+}
+
+// invalidate forgets the cached lookup of the record, e.g. once a new provider announced it.
+func (c *providerLookupCache) invalidate(cidStr string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, cidStr)
+}
+
+// pruneLocked removes expired lookups. Must be called with the lock held.
+func (c *providerLookupCache) pruneLocked(now time.Time) {
+	for cidStr, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, cidStr)
+		}
+	}
+}
+
+// invalidateProviderLookups forgets the cached lookups of records with newly cached
+// remote labels, as their providers announced them since.
+func (r *routeRemote) invalidateProviderLookups(keys []string) {
+	for _, key := range keys {
+		if _, cidStr, _, err := ParseEnhancedLabelKey(key); err == nil {
+			r.providerLookups.invalidate(cidStr)
+		}
+	}
+}
+
+// findProviders returns up to limit peers other than this one that provide the record
+// in the DHT, reusing the results of recent lookups of the record.
+func (r *routeRemote) findProviders(ctx context.Context, cidStr string, limit int) ([]peer.ID, error) {
+	decodedCID, err := cid.Decode(cidStr)
+	if err != nil {
+		return nil, routingerr.ErrCIDInvalid.Errorf("invalid CID %q: %w", cidStr, err)
+	}
+
+	if providers, ok := r.providerLookups.get(cidStr, limit, time.Now()); ok {
+		result := metrics.ResultHit
+		if len(providers) == 0 {
+			result = metrics.ResultNegative
+		}
+
+		metrics.ProviderLookups.WithLabelValues(result).Inc()
+
+		return providers, nil
+	}
+
+	metrics.ProviderLookups.WithLabelValues(metrics.ResultMiss).Inc()
+
+	// Stop the lookup when returning early
+	lookupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	localPeerID := r.server.Host().ID()
+	providers := make([]peer.ID, 0, limit)
+
+	// Ask for one more provider in case this peer is returned
+	for provider := range r.server.DHT().FindProvidersAsync(lookupCtx, decodedCID, limit+1) {
+		if provider.ID == localPeerID {
+			continue
+		}
+
+		providers = append(providers, provider.ID)
+		if len(providers) >= limit {
+			break
+		}
+	}
+
+	// The lookup found all providers if it ended on its own before reaching the limit
+	complete := len(providers) < limit && ctx.Err() == nil
+	r.providerLookups.put(cidStr, providers, complete, time.Now())
+
+	return providers, nil
+}
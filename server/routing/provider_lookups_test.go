@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderLookupCache(t *testing.T) {
+	now := time.Now()
+	cache := newProviderLookupCache(time.Hour, time.Minute)
+	providers := []peer.ID{"peer1", "peer2"}
+
+	// Complete lookups answer any limit
+	cache.put("cid1", providers, true, now)
+
+	cached, ok := cache.get("cid1", 5, now)
+	assert.True(t, ok)
+	assert.Equal(t, providers, cached)
+
+	cached, ok = cache.get("cid1", 1, now)
+	assert.True(t, ok)
+	assert.Equal(t, []peer.ID{"peer1"}, cached)
+
+	_, ok = cache.get("cid1", 1, now.Add(time.Hour))
+	assert.False(t, ok, "positive entries expire after their TTL")
+
+	// Lookups stopped at their limit only answer up to that limit
+	cache.put("cid2", providers, false, now)
+
+	_, ok = cache.get("cid2", 2, now)
+	assert.True(t, ok)
+
+	_, ok = cache.get("cid2", 3, now)
+	assert.False(t, ok)
+
+	// Lookups without providers are cached for the negative TTL, unless incomplete
+	cache.put("cid3", nil, true, now)
+
+	cached, ok = cache.get("cid3", 1, now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Empty(t, cached)
+
+	_, ok = cache.get("cid3", 1, now.Add(time.Minute))
+	assert.False(t, ok, "negative entries expire after their TTL")
+
+	cache.put("cid4", nil, false, now)
+
+	_, ok = cache.get("cid4", 1, now)
+	assert.False(t, ok)
+
+	// Announcements by new providers invalidate lookups
+	r := &routeRemote{providerLookups: cache}
+	r.invalidateProviderLookups([]string{"/skills/AI/cid3/peer3"})
+
+	_, ok = cache.get("cid3", 1, now)
+	assert.False(t, ok)
+}
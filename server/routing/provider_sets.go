@@ -0,0 +1,96 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"slices"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// addToProviderSets adds the providers of cached remote label keys to the provider sets.
+// Keys that are already cached refresh the times their providers were last seen.
+func (r *routeRemote) addToProviderSets(puts []journalPut) {
+	for _, p := range puts {
+		addProvider(r.providerSets, p.Key, p.Value)
+	}
+}
+
+// addProvider adds the provider of a cached remote label key to the provider sets.
+func addProvider(index *providerset.Index, key string, value []byte) {
+	label, cid, peerID, err := ParseEnhancedLabelKey(key)
+	if err != nil {
+		return
+	}
+
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return
+	}
+
+	index.Add(cid, peerID, label.String(), metadata.Timestamp, metadata.LastSeen)
+}
+
+// withProviderSetLabels adds the labels announced by the other providers of the record.
+// Records are content-addressed, so the labels of any provider describe the same record.
+func (r *routeRemote) withProviderSetLabels(cid string, labels []types.Label) []types.Label {
+	for _, label := range r.providerSets.Labels(cid) {
+		if !slices.Contains(labels, types.Label(label)) {
+			labels = append(labels, types.Label(label))
+		}
+	}
+
+	return labels
+}
+
+// providerSetOf returns the provider set of a cached remote record, or nil if unknown.
+func (r *routeRemote) providerSetOf(cid string) *routingv1.ProviderSet {
+	set, ok := r.providerSets.Get(cid)
+	if !ok {
+		return nil
+	}
+
+	return toProtoProviderSet(set)
+}
+
+// providerCount returns the number of remote peers known to provide the record, zero if unknown.
+func (r *routeRemote) providerCount(cid string) int {
+	set, ok := r.providerSets.Get(cid)
+	if !ok {
+		return 0
+	}
+
+	return set.Providers
+}
+
+func toProtoProviderSets(sets []providerset.Set) []*routingv1.ProviderSet {
+	result := make([]*routingv1.ProviderSet, 0, len(sets))
+	for _, set := range sets {
+		result = append(result, toProtoProviderSet(set))
+	}
+
+	return result
+}
+
+func toProtoProviderSet(set providerset.Set) *routingv1.ProviderSet {
+	result := &routingv1.ProviderSet{
+		Cid:           set.CID,
+		ProviderCount: uint32(set.Providers), //nolint:gosec // Bounded by the number of cached peers
+		Labels:        set.Labels,
+	}
+
+	if !set.FirstSeen.IsZero() {
+		result.FirstSeen = timestamppb.New(set.FirstSeen)
+	}
+
+	if !set.LastSeen.IsZero() {
+		result.LastSeen = timestamppb.New(set.LastSeen)
+	}
+
+	return result
+}
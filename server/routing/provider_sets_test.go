@@ -0,0 +1,38 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderSetLabels(t *testing.T) {
+	r := &routeRemote{providerSets: providerset.NewIndex()}
+
+	addProvider(r.providerSets, "/skills/AI/cid1/peer1", []byte(`{"timestamp":"2025-01-01T00:00:00Z","last_seen":"2025-01-02T00:00:00Z"}`))
+	addProvider(r.providerSets, "/domains/research/cid1/peer2", []byte(`{"timestamp":"2025-01-03T00:00:00Z","last_seen":"2025-01-03T00:00:00Z"}`))
+
+	// The record announced by peer1 matches the domain announced by peer2
+	labels := r.withProviderSetLabels("cid1", []types.Label{"/skills/AI"})
+	assert.ElementsMatch(t, []types.Label{"/skills/AI", "/domains/research"}, labels)
+
+	domainQuery := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+	_, score := matchScoreForLabels([]*routingv1.RecordQuery{domainQuery}, labels)
+	assert.Equal(t, uint32(1), score)
+
+	set := r.providerSetOf("cid1")
+	require.NotNil(t, set)
+	assert.Equal(t, uint32(2), set.GetProviderCount())
+	assert.Equal(t, "2025-01-01T00:00:00Z", set.GetFirstSeen().AsTime().Format("2006-01-02T15:04:05Z"))
+	assert.Equal(t, "2025-01-03T00:00:00Z", set.GetLastSeen().AsTime().Format("2006-01-02T15:04:05Z"))
+	assert.Equal(t, []string{"/domains/research", "/skills/AI"}, set.GetLabels())
+
+	assert.Nil(t, r.providerSetOf("unknown"))
+}
@@ -0,0 +1,226 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package providerset aggregates the remote peers providing each cached record.
+//
+// The label cache is keyed by record and peer, so records announced by several
+// peers are cached once per peer. An Index keeps a provider set per CID instead:
+// the providers with the times they were first and last seen, and the union of
+// their labels. As records are content-addressed, all providers serve the same
+// record, so the union holds labels that single providers may not have announced.
+package providerset
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Set is the aggregated provider set of a record.
+type Set struct {
+	CID       string
+	Providers int
+	FirstSeen time.Time // Earliest announcement of the record by any provider
+	LastSeen  time.Time // Latest refresh of the record by any provider
+	Labels    []string  // Sorted union of the labels of all providers
+}
+
+// seen holds the times a provider was first and last seen providing a record.
+type seen struct {
+	first time.Time
+	last  time.Time
+}
+
+type record struct {
+	providers map[string]*seen
+	labels    map[string]struct{}
+}
+
+// Index holds the provider sets of the cached remote records.
+// It is safe for concurrent use.
+type Index struct {
+	mu      sync.RWMutex
+	records map[string]*record
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{records: make(map[string]*record)}
+}
+
+// Add records that the peer provides the record with the given label,
+// announced at firstSeen and refreshed at lastSeen.
+func (i *Index) Add(cid, peerID, label string, firstSeen, lastSeen time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	r, ok := i.records[cid]
+	if !ok {
+		r = &record{providers: make(map[string]*seen), labels: make(map[string]struct{})}
+		i.records[cid] = r
+	}
+
+	r.labels[label] = struct{}{}
+
+	s, ok := r.providers[peerID]
+	if !ok {
+		r.providers[peerID] = &seen{first: firstSeen, last: lastSeen}
+
+		return
+	}
+
+	if firstSeen.Before(s.first) {
+		s.first = firstSeen
+	}
+
+	if lastSeen.After(s.last) {
+		s.last = lastSeen
+	}
+}
+
+// Touch records that a known provider of the record was seen at the given time.
+// Unknown records and providers are ignored, as their labels are not cached.
+func (i *Index) Touch(cid, peerID string, at time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if s, ok := i.records[cid].providerOf(peerID); ok && at.After(s.last) {
+		s.last = at
+	}
+}
+
+func (r *record) providerOf(peerID string) (*seen, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	s, ok := r.providers[peerID]
+
+	return s, ok
+}
+
+// Replace replaces the provider sets of the index with those of other, which must not be used afterwards.
+// Provider sets never shrink as labels are removed, so the index is periodically
+// rebuilt from the label cache and replaced to drop removed providers and labels.
+func (i *Index) Replace(other *Index) {
+	other.mu.RLock()
+	records := other.records
+	other.mu.RUnlock()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.records = records
+}
+
+// Get returns the provider set of the record.
+func (i *Index) Get(cid string) (Set, bool) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	r, ok := i.records[cid]
+	if !ok {
+		return Set{}, false
+	}
+
+	return r.set(cid), true
+}
+
+// Labels returns the union of the labels of all providers of the record.
+func (i *Index) Labels(cid string) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	r, ok := i.records[cid]
+	if !ok {
+		return nil
+	}
+
+	labels := make([]string, 0, len(r.labels))
+	for label := range r.labels {
+		labels = append(labels, label)
+	}
+
+	return labels
+}
+
+// Peers returns the IDs of the peers providing the record, sorted.
+func (i *Index) Peers(cid string) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	r, ok := i.records[cid]
+	if !ok {
+		return nil
+	}
+
+	peers := make([]string, 0, len(r.providers))
+	for peerID := range r.providers {
+		peers = append(peers, peerID)
+	}
+
+	slices.Sort(peers)
+
+	return peers
+}
+
+// Records returns the number of records in the index.
+func (i *Index) Records() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.records)
+}
+
+// Top returns up to limit provider sets of records with more than one provider,
+// sorted by the number of providers in descending order and by CID for equal counts.
+func (i *Index) Top(limit int) []Set {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	sets := make([]Set, 0)
+
+	for cid, r := range i.records {
+		if len(r.providers) > 1 {
+			sets = append(sets, r.set(cid))
+		}
+	}
+
+	slices.SortFunc(sets, func(a, b Set) int {
+		if c := cmp.Compare(b.Providers, a.Providers); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.CID, b.CID)
+	})
+
+	if limit >= 0 && len(sets) > limit {
+		sets = sets[:limit]
+	}
+
+	return sets
+}
+
+func (r *record) set(cid string) Set {
+	set := Set{CID: cid, Providers: len(r.providers)}
+
+	for _, s := range r.providers {
+		if set.FirstSeen.IsZero() || s.first.Before(set.FirstSeen) {
+			set.FirstSeen = s.first
+		}
+
+		if s.last.After(set.LastSeen) {
+			set.LastSeen = s.last
+		}
+	}
+
+	set.Labels = make([]string, 0, len(r.labels))
+	for label := range r.labels {
+		set.Labels = append(set.Labels, label)
+	}
+
+	slices.SortFunc(set.Labels, cmp.Compare[string])
+
+	return set
+}
@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package providerset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Aggregates(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	index := NewIndex()
+
+	index.Add("cid1", "peer1", "/skills/AI", base, base.Add(time.Minute))
+	index.Add("cid1", "peer1", "/domains/research", base.Add(-time.Minute), base)
+	index.Add("cid1", "peer2", "/skills/ML", base.Add(time.Hour), base.Add(2*time.Hour))
+	index.Add("cid2", "peer1", "/skills/AI", base, base)
+
+	set, ok := index.Get("cid1")
+	require.True(t, ok)
+	assert.Equal(t, 2, set.Providers)
+	assert.Equal(t, base.Add(-time.Minute), set.FirstSeen)
+	assert.Equal(t, base.Add(2*time.Hour), set.LastSeen)
+	assert.Equal(t, []string{"/domains/research", "/skills/AI", "/skills/ML"}, set.Labels)
+
+	assert.ElementsMatch(t, []string{"/skills/AI"}, index.Labels("cid2"))
+	assert.Nil(t, index.Labels("unknown"))
+	assert.Equal(t, []string{"peer1", "peer2"}, index.Peers("cid1"))
+	assert.Nil(t, index.Peers("unknown"))
+	assert.Equal(t, 2, index.Records())
+
+	_, ok = index.Get("unknown")
+	assert.False(t, ok)
+}
+
+func TestIndex_Touch(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	index := NewIndex()
+
+	index.Add("cid1", "peer1", "/skills/AI", base, base)
+
+	// Older refreshes and unknown records or providers are ignored
+	index.Touch("cid1", "peer1", base.Add(-time.Hour))
+	index.Touch("cid1", "peer2", base.Add(time.Hour))
+	index.Touch("unknown", "peer1", base.Add(time.Hour))
+
+	set, _ := index.Get("cid1")
+	assert.Equal(t, 1, set.Providers)
+	assert.Equal(t, base, set.LastSeen)
+
+	index.Touch("cid1", "peer1", base.Add(time.Minute))
+
+	set, _ = index.Get("cid1")
+	assert.Equal(t, base.Add(time.Minute), set.LastSeen)
+}
+
+func TestIndex_Top(t *testing.T) {
+	now := time.Now()
+	index := NewIndex()
+
+	for _, peerID := range []string{"peer1", "peer2", "peer3"} {
+		index.Add("cid3", peerID, "/skills/AI", now, now)
+	}
+
+	for _, peerID := range []string{"peer1", "peer2"} {
+		index.Add("cid2", peerID, "/skills/AI", now, now)
+		index.Add("cid1", peerID, "/skills/AI", now, now)
+	}
+
+	// Records with a single provider are not aggregated
+	index.Add("cid0", "peer1", "/skills/AI", now, now)
+
+	top := index.Top(-1)
+	require.Len(t, top, 3)
+	assert.Equal(t, "cid3", top[0].CID)
+	assert.Equal(t, 3, top[0].Providers)
+	assert.Equal(t, "cid1", top[1].CID)
+	assert.Equal(t, "cid2", top[2].CID)
+
+	assert.Len(t, index.Top(2), 2)
+	assert.Empty(t, index.Top(0))
+}
+
+func TestIndex_Replace(t *testing.T) {
+	now := time.Now()
+	index := NewIndex()
+	index.Add("cid1", "peer1", "/skills/AI", now, now)
+
+	rebuilt := NewIndex()
+	rebuilt.Add("cid2", "peer1", "/skills/AI", now, now)
+	index.Replace(rebuilt)
+
+	_, ok := index.Get("cid1")
+	assert.False(t, ok)
+
+	_, ok = index.Get("cid2")
+	assert.True(t, ok)
+}
@@ -0,0 +1,223 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-cid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PublishBatch announces many records to the network at once.
+//
+// Compared to calling Publish for each record:
+//   - DHT provide operations run with bounded concurrency (PublishBatchConcurrency)
+//     instead of one after another
+//   - GossipSub label announcements are coalesced into batched messages per namespace
+//   - Records published within the dedup window are skipped, as in Publish
+//
+// Priority handling:
+//   - High: every record is announced immediately and individually (see PublishWithPriority)
+//   - Normal: DHT announcements plus coalesced GossipSub batches
+//   - Low: DHT announcements only
+//
+// Returns one error per record in input order (nil on success).
+// GossipSub remains best-effort and does not cause per-record errors.
+// The network stages of each record are reported to progress, if set; records
+// coalesced with a recent announcement skip them.
+func (r *routeRemote) PublishBatch(ctx context.Context, records []types.Record, priority routingv1.AnnouncementPriority, progress publishProgress) []error {
+	priority = normalizePriority(priority)
+
+	ctx, span := tracer.Start(ctx, "routing.PublishBatch", trace.WithAttributes(
+		attribute.Int("records", len(records)),
+		attribute.String("priority", priority.String())))
+	defer span.End()
+
+	// The budget covers the whole batch, records not announced by then fail
+	ctx, cancel := withTimeout(ctx, r.timeouts.Publish)
+	defer cancel()
+
+	if priority == routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH {
+		return r.publishEach(ctx, records, priority, progress)
+	}
+
+	errs := make([]error, len(records))
+	announced := make([]bool, len(records))
+	decodedCIDs := make([]cid.Cid, len(records))
+
+	sem := make(chan struct{}, PublishBatchConcurrency)
+
+	var wg sync.WaitGroup
+
+	for i, record := range records {
+		decodedCID, err := parseRecordCID(record)
+		if err != nil {
+			errs[i] = err
+
+			continue
+		}
+
+		decodedCIDs[i] = decodedCID
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = status.Errorf(codes.Canceled, "batch publish canceled: %v", ctx.Err())
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int, decodedCID cid.Cid) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			variant, err := r.publishVariant(ctx, records[i], priority)
+			if err != nil {
+				errs[i] = err
+
+				return
+			}
+
+			deduplicated, err := r.publishDedup.Do(ctx, records[i].GetCid(), variant, func() error {
+				return publishBudgetError(ctx, r.provide(ctx, decodedCID, progress))
+			})
+
+			errs[i] = err
+			announced[i] = err == nil && !deduplicated
+		}(i, decodedCID)
+	}
+
+	wg.Wait()
+
+	// Coalesce label announcements of all newly announced records
+	var toGossip []types.Record
+
+	for i, record := range records {
+		if announced[i] {
+			toGossip = append(toGossip, record)
+			r.advertiseToIPNI(ctx, record, decodedCIDs[i])
+		}
+	}
+
+	gossip := r.pubsubManager != nil && priority != routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW
+	if gossip && len(toGossip) > 0 {
+		if err := r.gossip(ctx, func(ctx context.Context) error { return r.pubsubManager.PublishRecords(ctx, toGossip) }); err != nil {
+			// Log warning but don't fail - DHT announcements already succeeded
+			remoteLogger.Warn("Failed to publish record batch via GossipSub",
+				"records", len(toGossip),
+				"error", err,
+				"fallback", "DHT+Pull will handle discovery")
+		} else {
+			r.reportGossiped(ctx, toGossip, progress)
+		}
+	} else if r.pubsubManager != nil && len(toGossip) > 0 {
+		r.gossipRemovedLabels(ctx, toGossip)
+	}
+
+	remoteLogger.Info("Published record batch to network",
+		"records", len(records),
+		"announced", len(toGossip),
+		"priority", priority,
+		"gossipSub", gossip)
+
+	return errs
+}
+
+// publishEach publishes every record individually with bounded concurrency.
+func (r *routeRemote) publishEach(ctx context.Context, records []types.Record, priority routingv1.AnnouncementPriority, progress publishProgress) []error {
+	errs := make([]error, len(records))
+	sem := make(chan struct{}, PublishBatchConcurrency)
+
+	var wg sync.WaitGroup
+
+	for i, record := range records {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = status.Errorf(codes.Canceled, "batch publish canceled: %v", ctx.Err())
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int, record types.Record) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			errs[i] = r.publishWithPriority(ctx, record, priority, progress)
+		}(i, record)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// provide announces a CID to the DHT network and reports it as provided.
+// Records of private tenants are only announced via GossipSub, as DHT provider
+// records cannot carry the tenant.
+func (r *routeRemote) provide(ctx context.Context, decodedCID cid.Cid, progress publishProgress) error {
+	if tenant := r.recordTenant(decodedCID.String()); !r.tenants.isShared(tenant) {
+		remoteLogger.Debug("Skipping DHT announcement of private tenant record", "cid", decodedCID.String(), "tenant", tenant)
+
+		return nil
+	}
+
+	ctx, span := tracer.Start(ctx, "routing.Provide", trace.WithAttributes(attribute.String("cid", decodedCID.String())))
+
+	provideCtx, cancel := withTimeout(ctx, r.timeouts.Provide)
+	defer cancel()
+
+	err := r.server.DHT().Provide(provideCtx, decodedCID, true)
+	metrics.AnnouncementsPublished.WithLabelValues(metrics.TransportDHT, metrics.Result(err)).Inc()
+	endSpan(span, err)
+
+	if err != nil {
+		observeTimeout(provideCtx, metrics.OperationProvide)
+
+		return routingerr.ErrDHTUnavailable.Errorf("failed to announce CID to DHT: %w", err)
+	}
+
+	r.announcements.announced(decodedCID.String(), time.Now())
+	r.state.announcementPublished()
+
+	progress.report(decodedCID.String(), routingv1.PublishStage_PUBLISH_STAGE_DHT_PROVIDED)
+
+	return nil
+}
+
+// reportGossiped reports records as published via GossipSub and checks the propagation of their labels.
+func (r *routeRemote) reportGossiped(ctx context.Context, records []types.Record, progress publishProgress) {
+	if progress == nil {
+		return
+	}
+
+	topicPeers := uint32(len(r.pubsubManager.GetTopicPeers())) //nolint:gosec // Bounded by the GossipSub peers
+	cids := make([]string, len(records))
+
+	for i, record := range records {
+		cids[i] = record.GetCid()
+
+		progress.send(&routingv1.PublishProgress{
+			Cid:        cids[i],
+			Stage:      routingv1.PublishStage_PUBLISH_STAGE_GOSSIPSUB_PUBLISHED,
+			TopicPeers: topicPeers,
+		})
+	}
+
+	r.checkPropagation(ctx, cids, progress)
+}
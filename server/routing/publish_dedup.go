@@ -0,0 +1,171 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+)
+
+// publishDeduplicator coalesces repeated Publish calls for the same CID.
+// Concurrent calls share the result of the in-flight announcement, and calls
+// arriving within the dedup window after a successful announcement return
+// immediately without redoing the DHT Provide and GossipSub publish.
+// Failed announcements are not remembered so that retries go through.
+// Calls announcing a different variant of the record, e.g. with another priority
+// or publish policy, are never coalesced and replace the prior announcement.
+type publishDeduplicator struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*publishEntry
+
+	// Successful announcements in completion order, so that expired
+	// entries are pruned from the front without scanning all entries
+	expiries []publishExpiry
+}
+
+type publishEntry struct {
+	variant     string
+	done        chan struct{}
+	err         error
+	completedAt time.Time
+}
+
+type publishExpiry struct {
+	cid   string
+	entry *publishEntry
+}
+
+// newPublishDeduplicator creates a deduplicator with the given window.
+// A zero window disables deduplication.
+func newPublishDeduplicator(window time.Duration) *publishDeduplicator {
+	return &publishDeduplicator{
+		window:  window,
+		entries: make(map[string]*publishEntry),
+	}
+}
+
+// Do runs publishFn for the CID unless an announcement of the same variant for it is
+// in flight or succeeded within the dedup window, in which case the prior result is returned.
+// The boolean result reports whether the call was coalesced with a prior one.
+//
+// An in-flight announcement may fail only because its caller gave up, so callers
+// that waited for a failed announcement and whose own ctx is still live announce
+// again once, instead of returning the other caller's error.
+func (d *publishDeduplicator) Do(ctx context.Context, cid, variant string, publishFn func() error) (bool, error) {
+	if d == nil || d.window <= 0 {
+		return false, publishFn()
+	}
+
+	deduplicated, waitedForFailure, err := d.do(ctx, cid, variant, publishFn)
+	if waitedForFailure && ctx.Err() == nil {
+		deduplicated, _, err = d.do(ctx, cid, variant, publishFn)
+	}
+
+	return deduplicated, err
+}
+
+// do runs publishFn or waits for the in-flight or recent announcement of the CID.
+// The second result reports whether the call waited for an announcement that failed.
+func (d *publishDeduplicator) do(ctx context.Context, cid, variant string, publishFn func() error) (bool, bool, error) {
+	d.mu.Lock()
+	d.pruneLocked(time.Now())
+
+	if entry, ok := d.entries[cid]; ok && entry.variant == variant {
+		d.mu.Unlock()
+
+		select {
+		case <-entry.done:
+			return true, entry.err != nil, entry.err
+		case <-ctx.Done():
+			return true, false, fmt.Errorf("waiting for in-flight publish: %w", ctx.Err())
+		}
+	}
+
+	entry := &publishEntry{variant: variant, done: make(chan struct{})}
+	d.entries[cid] = entry
+	d.mu.Unlock()
+
+	err := publishFn()
+
+	d.mu.Lock()
+	entry.err = err
+	entry.completedAt = time.Now()
+
+	// Only remember successful announcements; failures must be retryable.
+	// The entry may have been forgotten, or replaced by a newer one, meanwhile.
+	if err != nil && d.entries[cid] == entry {
+		delete(d.entries, cid)
+	}
+
+	if err == nil {
+		d.expiries = append(d.expiries, publishExpiry{cid: cid, entry: entry})
+	}
+
+	d.mu.Unlock()
+	close(entry.done)
+
+	return false, false, err
+}
+
+// forget drops the announcement of a CID, so that the next publish of the
+// record is announced again. Called when the record's announcements are revoked.
+// Callers waiting for an in-flight announcement still receive its result.
+func (d *publishDeduplicator) forget(cid string) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	delete(d.entries, cid)
+	d.mu.Unlock()
+}
+
+// pruneLocked removes completed entries that are older than the window.
+// Entries complete in order, so only expired entries at the front are visited.
+// Must be called with the lock held.
+func (d *publishDeduplicator) pruneLocked(now time.Time) {
+	expired := 0
+
+	for _, expiry := range d.expiries {
+		if now.Sub(expiry.entry.completedAt) <= d.window {
+			break
+		}
+
+		// The entry may have been forgotten and replaced by a newer announcement
+		if d.entries[expiry.cid] == expiry.entry {
+			delete(d.entries, expiry.cid)
+		}
+
+		expired++
+	}
+
+	d.expiries = d.expiries[expired:]
+}
+
+// publishVariant identifies what an announcement of a record publishes: its priority
+// and the labels its publish policy lets through, so that republishing a record with
+// another priority or label policies is announced again within the dedup window.
+func (r *routeRemote) publishVariant(ctx context.Context, record types.Record, priority routingv1.AnnouncementPriority) (string, error) {
+	labels, err := r.publishedLabels(ctx, record.GetCid(), types.GetLabelsFromRecord(record))
+	if err != nil {
+		return "", err
+	}
+
+	variant := make([]string, 0, len(labels)+1)
+	variant = append(variant, priority.String())
+
+	for _, label := range labels {
+		variant = append(variant, label.String())
+	}
+
+	return strings.Join(variant, "\x00"), nil
+}
@@ -0,0 +1,242 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishDeduplicator_CoalescesWithinWindow(t *testing.T) {
+	d := newPublishDeduplicator(time.Minute)
+
+	var calls atomic.Int32
+
+	publishFn := func() error {
+		calls.Add(1)
+
+		return nil
+	}
+
+	deduplicated, err := d.Do(t.Context(), "cid1", "", publishFn)
+	assert.NoError(t, err)
+	assert.False(t, deduplicated)
+
+	deduplicated, err = d.Do(t.Context(), "cid1", "", publishFn)
+	assert.NoError(t, err)
+	assert.True(t, deduplicated)
+
+	// Different CID is announced independently
+	_, err = d.Do(t.Context(), "cid2", "", publishFn)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestPublishDeduplicator_ConcurrentCallsShareResult(t *testing.T) {
+	d := newPublishDeduplicator(time.Minute)
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+	publishFn := func() error {
+		calls.Add(1)
+		<-release
+
+		return nil
+	}
+
+	var wg sync.WaitGroup
+
+	for range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := d.Do(t.Context(), "cid1", "", publishFn)
+			assert.NoError(t, err)
+		}()
+	}
+
+	// Give the goroutines time to queue up behind the in-flight publish
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestPublishDeduplicator_FailuresAreRetried(t *testing.T) {
+	d := newPublishDeduplicator(time.Minute)
+
+	_, err := d.Do(t.Context(), "cid1", "", func() error { return errors.New("dht unavailable") })
+	assert.Error(t, err)
+
+	deduplicated, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, deduplicated)
+}
+
+func TestPublishDeduplicator_WaitersRetryCancelledPublish(t *testing.T) {
+	d := newPublishDeduplicator(time.Minute)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	// The first caller gives up while its announcement is in flight
+	go func() {
+		_, _ = d.Do(t.Context(), "cid1", "", func() error {
+			close(started)
+			<-release
+
+			return context.Canceled
+		})
+	}()
+
+	<-started
+
+	result := make(chan error, 1)
+
+	go func() {
+		_, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+		result <- err
+	}()
+
+	// Give the waiter time to queue up behind the in-flight publish
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	// The waiter's context is still live, so it announces the record itself
+	assert.NoError(t, <-result)
+
+	deduplicated, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+	assert.NoError(t, err)
+	assert.True(t, deduplicated)
+}
+
+func TestPublishDeduplicator_PrunesExpiredEntries(t *testing.T) {
+	d := newPublishDeduplicator(10 * time.Millisecond)
+
+	for i := range 100 {
+		_, err := d.Do(t.Context(), fmt.Sprintf("cid%d", i), "", func() error { return nil })
+		assert.NoError(t, err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, err := d.Do(t.Context(), "cid-new", "", func() error { return nil })
+	assert.NoError(t, err)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	assert.Len(t, d.entries, 1)
+	assert.Len(t, d.expiries, 1)
+}
+
+func TestPublishDeduplicator_ExpiresAfterWindow(t *testing.T) {
+	d := newPublishDeduplicator(10 * time.Millisecond)
+
+	_, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+	assert.NoError(t, err)
+
+	time.Sleep(20 * time.Millisecond)
+
+	deduplicated, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, deduplicated)
+}
+
+func TestPublishDeduplicator_Forget(t *testing.T) {
+	d := newPublishDeduplicator(time.Minute)
+
+	_, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+	assert.NoError(t, err)
+
+	// Revoked records are announced again on the next publish
+	d.forget("cid1")
+
+	deduplicated, err := d.Do(t.Context(), "cid1", "", func() error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, deduplicated)
+}
+
+func TestPublishDeduplicator_ZeroWindowDisables(t *testing.T) {
+	d := newPublishDeduplicator(0)
+
+	var calls atomic.Int32
+
+	for range 3 {
+		_, err := d.Do(t.Context(), "cid1", "", func() error {
+			calls.Add(1)
+
+			return nil
+		})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestPublishDeduplicator_NewVariant(t *testing.T) {
+	d := newPublishDeduplicator(time.Minute)
+
+	_, err := d.Do(t.Context(), "cid1", "normal", func() error { return nil })
+	assert.NoError(t, err)
+
+	// A republish of another variant within the window is announced again
+	deduplicated, err := d.Do(t.Context(), "cid1", "high", func() error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, deduplicated)
+
+	// and replaces the prior announcement
+	deduplicated, err = d.Do(t.Context(), "cid1", "high", func() error { return nil })
+	assert.NoError(t, err)
+	assert.True(t, deduplicated)
+
+	deduplicated, err = d.Do(t.Context(), "cid1", "normal", func() error { return nil })
+	assert.NoError(t, err)
+	assert.False(t, deduplicated)
+}
+
+func TestPublishVariant(t *testing.T) {
+	record := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{
+		Name:          "agent-1",
+		SchemaVersion: "v0.3.1",
+		Skills:        []*typesv1alpha0.Skill{{CategoryName: toPtr("category1"), ClassName: toPtr("class1")}},
+	}))
+
+	r := &routeRemote{}
+
+	variant := func(priority routingv1.AnnouncementPriority) string {
+		v, err := r.publishVariant(t.Context(), record, priority)
+		require.NoError(t, err)
+
+		return v
+	}
+
+	normal := variant(routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL)
+	assert.Equal(t, normal, variant(routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL))
+	assert.NotEqual(t, normal, variant(routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW))
+
+	// Label policies withholding labels change the variant
+	r.setPublishPolicy(func(context.Context, string, []types.Label) ([]types.Label, error) {
+		return nil, nil
+	})
+	assert.NotEqual(t, normal, variant(routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL))
+}
@@ -0,0 +1,96 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// newPublishPolicy validates the configured publish policy. Its namespaces must be built-in
+// or configured custom namespaces, which are not registered yet when the config is validated.
+func newPublishPolicy(cfgs []routingconfig.LabelPolicyConfig, namespaces []routingconfig.LabelNamespaceConfig) ([]types.LabelPolicy, error) {
+	policies := make([]types.LabelPolicy, 0, len(cfgs))
+
+	for _, cfg := range cfgs {
+		_, builtin := types.ParseLabelType(cfg.Namespace)
+		custom := slices.ContainsFunc(namespaces, func(namespace routingconfig.LabelNamespaceConfig) bool {
+			return namespace.Name == cfg.Namespace
+		})
+
+		if cfg.Namespace != "" && !builtin && !custom {
+			return nil, fmt.Errorf("unknown label namespace %q", cfg.Namespace)
+		}
+
+		policies = append(policies, types.LabelPolicy{
+			Namespace: cfg.Namespace,
+			Include:   cfg.Include,
+			Exclude:   cfg.Exclude,
+		})
+	}
+
+	if err := types.ValidateLabelPolicies(policies); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return policies, nil
+}
+
+// validateLabelPolicies checks the label policies of a publish request.
+func validateLabelPolicies(policies []types.LabelPolicy) error {
+	if err := types.ValidateLabelPolicies(policies); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for _, policy := range policies {
+		if _, ok := types.ParseLabelType(policy.Namespace); !ok {
+			return fmt.Errorf("unknown label namespace %q", policy.Namespace)
+		}
+	}
+
+	return nil
+}
+
+// publishedLabels withholds the labels of a local record that its publish policy excludes:
+// the configured policy, overridden by the label policies the record was published with.
+// Labels of records that are not published locally are returned unchanged.
+// It is applied wherever the labels of local records are announced, see routeRemote.publishedLabels.
+func (r *routeLocal) publishedLabels(ctx context.Context, cid string, labels []types.Label) ([]types.Label, error) {
+	value, err := r.dstore.Get(ctx, datastore.NewKey("/records/"+cid))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return labels, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get publish policy of record %s: %w", cid, err)
+	}
+
+	return types.FilterLabels(labels, types.MergeLabelPolicies(r.publishPolicy, decodeLocalRecordMetadata(value).LabelPolicies)), nil
+}
+
+// publishPolicyFunc withholds the labels of a local record that its publish policy excludes.
+type publishPolicyFunc func(ctx context.Context, cid string, labels []types.Label) ([]types.Label, error)
+
+// setPublishPolicy sets the publish policy applied by publishedLabels.
+func (r *routeRemote) setPublishPolicy(policy publishPolicyFunc) {
+	r.publishPolicy.Store(&policy)
+}
+
+// publishedLabels withholds the labels of a local record that its publish policy excludes
+// from its GossipSub announcements, IPNI advertisements and Pull responses.
+// Labels are returned unchanged until the publish policy is set.
+func (r *routeRemote) publishedLabels(ctx context.Context, cid string, labels []types.Label) ([]types.Label, error) {
+	policy := r.publishPolicy.Load()
+	if policy == nil {
+		return labels, nil
+	}
+
+	return (*policy)(ctx, cid, labels)
+}
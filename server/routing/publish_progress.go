@@ -0,0 +1,114 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// publishProgress reports the progress of published records through the publish stages.
+// A nil publishProgress discards the progress, so that only publishes with progress
+// pay for reporting it.
+type publishProgress func(*routingv1.PublishProgress)
+
+// report reports that a record reached a stage.
+func (p publishProgress) report(cid string, stage routingv1.PublishStage) {
+	p.send(&routingv1.PublishProgress{Cid: cid, Stage: stage})
+}
+
+// send reports a progress event, timestamped now.
+func (p publishProgress) send(progress *routingv1.PublishProgress) {
+	if p == nil {
+		return
+	}
+
+	progress.Time = timestamppb.Now()
+	p(progress)
+}
+
+// finish reports whether each record was published or failed.
+func (p publishProgress) finish(records []types.Record, errs []error) {
+	for i, record := range records {
+		if errs[i] != nil {
+			p.send(&routingv1.PublishProgress{
+				Cid:        record.GetCid(),
+				Stage:      routingv1.PublishStage_PUBLISH_STAGE_FAILED,
+				Error:      errs[i].Error(),
+				Violations: recordViolations(errs[i]),
+			})
+
+			continue
+		}
+
+		p.report(record.GetCid(), routingv1.PublishStage_PUBLISH_STAGE_COMPLETED)
+	}
+}
+
+// checkPropagation asks GossipSub topic peers whether they cached the labels of the gossiped
+// records every LabelPropagationCheckInterval, until each record was resolved by a peer or
+// LabelPropagationTimeout elapsed, and reports the records' labels as propagated.
+// It only runs for publishes with progress.
+func (r *routeRemote) checkPropagation(ctx context.Context, cids []string, progress publishProgress) {
+	if progress == nil || r.pubsubManager == nil || len(cids) == 0 {
+		return
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, LabelPropagationTimeout)
+	defer cancel()
+
+	gossiped := make(map[string]bool, len(cids))
+	for _, cidStr := range cids {
+		gossiped[cidStr] = true
+	}
+
+	checks := make(map[string]announcementCheck, len(cids))
+	pending := cids
+
+	ticker := time.NewTicker(LabelPropagationCheckInterval)
+	defer ticker.Stop()
+
+	for len(pending) > 0 {
+		select {
+		case <-checkCtx.Done():
+			for _, cidStr := range pending {
+				reportPropagation(progress, cidStr, checks[cidStr])
+			}
+
+			return
+		case <-ticker.C:
+		}
+
+		attempt := make(map[string]announcementCheck, len(pending))
+		r.verifyGossipAnnouncements(checkCtx, pending, gossiped, attempt)
+
+		var unresolved []string
+
+		for _, cidStr := range pending {
+			checks[cidStr] = attempt[cidStr]
+
+			if attempt[cidStr].GossipPeersResolved > 0 {
+				reportPropagation(progress, cidStr, attempt[cidStr])
+			} else {
+				unresolved = append(unresolved, cidStr)
+			}
+		}
+
+		pending = unresolved
+	}
+}
+
+// reportPropagation reports the last propagation check of a record's labels.
+func reportPropagation(progress publishProgress, cid string, check announcementCheck) {
+	progress.send(&routingv1.PublishProgress{
+		Cid:           cid,
+		Stage:         routingv1.PublishStage_PUBLISH_STAGE_LABELS_PROPAGATED,
+		PeersChecked:  uint32(check.GossipPeersChecked),  //nolint:gosec // bounded by VerificationPeers
+		PeersResolved: uint32(check.GossipPeersResolved), //nolint:gosec // bounded by VerificationPeers
+	})
+}
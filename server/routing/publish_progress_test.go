@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishBatch_ReportsProgress(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := &route{local: newLocal(newMockStore(), dstore, testPeerID, nil, nil)}
+
+	record := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-progress",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	}))
+
+	var events []*routingv1.PublishProgress
+
+	errs := r.PublishBatch(t.Context(), []types.Record{record, adapters.NewRecordAdapter(&corev1.Record{})}, types.PublishOptions{
+		Progress: func(event *routingv1.PublishProgress) { events = append(events, event) },
+	})
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+
+	stages := make([]routingv1.PublishStage, len(events))
+	for i, event := range events {
+		stages[i] = event.GetStage()
+		assert.NotNil(t, event.GetTime())
+	}
+
+	// Without peers, records are queued for announcement, and invalid records fail
+	assert.Equal(t, []routingv1.PublishStage{
+		routingv1.PublishStage_PUBLISH_STAGE_STORED,
+		routingv1.PublishStage_PUBLISH_STAGE_QUEUED,
+		routingv1.PublishStage_PUBLISH_STAGE_COMPLETED,
+		routingv1.PublishStage_PUBLISH_STAGE_FAILED,
+	}, stages)
+	assert.Equal(t, record.GetCid(), events[2].GetCid())
+	assert.NotEmpty(t, events[3].GetError())
+}
+
+func TestPublishProgress_NilDiscards(t *testing.T) {
+	var progress publishProgress
+
+	assert.NotPanics(t, func() {
+		progress.report("cid", routingv1.PublishStage_PUBLISH_STAGE_STORED)
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package publishcheck validates records before they are published.
+//
+// A Pipeline runs a set of rules against a record and collects their violations,
+// so that publishers learn about all problems of a record at once. Rules are
+// independent of each other: the schema rule checks the record against its OASF
+// schema, the taxonomy rule checks its skills against a skills ontology, the
+// locators rule checks that it can be retrieved from somewhere, and the optional
+// locator reachability rule checks that its HTTP locators respond.
+package publishcheck
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// Names of the rules, reported with their violations.
+const (
+	RuleSchema              = "schema"
+	RuleTaxonomy            = "taxonomy"
+	RuleLocators            = "locators"
+	RuleLocatorReachability = "locator_reachability"
+)
+
+// Violation is a violation of a rule by a record.
+type Violation struct {
+	Rule    string
+	Field   string // Path of the violating field, e.g. "skills[0].name"; empty for the whole record
+	Message string
+}
+
+// Rule checks records for violations.
+type Rule interface {
+	// Name returns the name of the rule.
+	Name() string
+
+	// Check returns the violations of the rule by the record with the decoded data.
+	Check(ctx context.Context, record types.Record, data types.RecordData) []Violation
+}
+
+// Pipeline runs rules against records. A Pipeline without rules accepts all records.
+type Pipeline struct {
+	rules []Rule
+}
+
+// New creates a pipeline of the rules, run in order.
+func New(rules ...Rule) *Pipeline {
+	return &Pipeline{rules: rules}
+}
+
+// Enabled reports whether the pipeline has rules.
+func (p *Pipeline) Enabled() bool {
+	return p != nil && len(p.rules) > 0
+}
+
+// Rules returns the names of the rules of the pipeline.
+func (p *Pipeline) Rules() []string {
+	if p == nil {
+		return nil
+	}
+
+	names := make([]string, len(p.rules))
+	for i, rule := range p.rules {
+		names[i] = rule.Name()
+	}
+
+	return names
+}
+
+// Check returns the violations of all rules by the record, nil if it is valid.
+// Records that cannot be decoded violate the schema, whichever rules are enabled.
+func (p *Pipeline) Check(ctx context.Context, record types.Record) []Violation {
+	if !p.Enabled() {
+		return nil
+	}
+
+	data, err := record.GetRecordData()
+	if err != nil {
+		return []Violation{{Rule: RuleSchema, Message: err.Error()}}
+	}
+
+	var violations []Violation
+	for _, rule := range p.rules {
+		violations = append(violations, rule.Check(ctx, record, data)...)
+	}
+
+	return violations
+}
+
+// schemaValidator is implemented by records that can validate themselves against their schema.
+type schemaValidator interface {
+	Validate() (bool, []string, error)
+}
+
+type schemaRule struct{}
+
+// Schema checks records against their OASF schema.
+// Records that cannot validate themselves are not checked.
+func Schema() Rule {
+	return schemaRule{}
+}
+
+func (schemaRule) Name() string {
+	return RuleSchema
+}
+
+func (schemaRule) Check(_ context.Context, record types.Record, _ types.RecordData) []Violation {
+	validator, ok := record.(schemaValidator)
+	if !ok {
+		return nil
+	}
+
+	valid, errs, err := validator.Validate()
+	if err != nil {
+		return []Violation{{Rule: RuleSchema, Message: fmt.Sprintf("failed to validate record: %v", err)}}
+	}
+
+	if valid {
+		return nil
+	}
+
+	violations := make([]Violation, 0, len(errs))
+	for _, msg := range errs {
+		violations = append(violations, Violation{Rule: RuleSchema, Message: msg})
+	}
+
+	if len(violations) == 0 {
+		violations = append(violations, Violation{Rule: RuleSchema, Message: "record does not match its schema"})
+	}
+
+	return violations
+}
+
+// Ontology is the set of skill names records may have.
+type Ontology map[string]struct{}
+
+// LoadOntology loads an ontology from a file with one skill name per line,
+// e.g. "natural_language_processing/text_completion". Blank lines and lines
+// starting with # are ignored.
+func LoadOntology(path string) (Ontology, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open skills ontology: %w", err)
+	}
+	defer file.Close()
+
+	ontology := make(Ontology)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		ontology[line] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read skills ontology: %w", err)
+	}
+
+	if len(ontology) == 0 {
+		return nil, errors.New("skills ontology has no skills")
+	}
+
+	return ontology, nil
+}
+
+type taxonomyRule struct {
+	ontology Ontology
+}
+
+// Taxonomy checks that records have skills, all of which are part of the ontology.
+func Taxonomy(ontology Ontology) Rule {
+	return taxonomyRule{ontology: ontology}
+}
+
+func (taxonomyRule) Name() string {
+	return RuleTaxonomy
+}
+
+func (r taxonomyRule) Check(_ context.Context, _ types.Record, data types.RecordData) []Violation {
+	skills := data.GetSkills()
+	if len(skills) == 0 {
+		return []Violation{{Rule: RuleTaxonomy, Field: "skills", Message: "record has no skills"}}
+	}
+
+	var violations []Violation
+
+	for i, skill := range skills {
+		if _, ok := r.ontology[skill.GetName()]; !ok {
+			violations = append(violations, Violation{
+				Rule:    RuleTaxonomy,
+				Field:   fmt.Sprintf("skills[%d].name", i),
+				Message: fmt.Sprintf("skill %q is not part of the skills ontology", skill.GetName()),
+			})
+		}
+	}
+
+	return violations
+}
+
+type locatorsRule struct{}
+
+// Locators checks that records have locators, each with a type and a URL.
+func Locators() Rule {
+	return locatorsRule{}
+}
+
+func (locatorsRule) Name() string {
+	return RuleLocators
+}
+
+func (locatorsRule) Check(_ context.Context, _ types.Record, data types.RecordData) []Violation {
+	locators := data.GetLocators()
+	if len(locators) == 0 {
+		return []Violation{{Rule: RuleLocators, Field: "locators", Message: "record has no locators"}}
+	}
+
+	var violations []Violation
+
+	for i, locator := range locators {
+		if locator.GetType() == "" {
+			violations = append(violations, Violation{Rule: RuleLocators, Field: fmt.Sprintf("locators[%d].type", i), Message: "locator has no type"})
+		}
+
+		if locator.GetURL() == "" {
+			violations = append(violations, Violation{Rule: RuleLocators, Field: fmt.Sprintf("locators[%d].url", i), Message: "locator has no URL"})
+		} else if _, err := url.Parse(locator.GetURL()); err != nil {
+			violations = append(violations, Violation{Rule: RuleLocators, Field: fmt.Sprintf("locators[%d].url", i), Message: fmt.Sprintf("invalid URL: %v", err)})
+		}
+	}
+
+	return violations
+}
+
+type locatorReachabilityRule struct {
+	client *http.Client
+}
+
+// LocatorReachability checks that the HTTP and HTTPS locators of records respond.
+// Locators respond unless the request fails, or they are not found (404, 410) or fail
+// with a server error. Locators of other schemes, e.g. image references, are not checked.
+// The timeout of the client bounds each request.
+func LocatorReachability(client *http.Client) Rule {
+	return locatorReachabilityRule{client: client}
+}
+
+func (locatorReachabilityRule) Name() string {
+	return RuleLocatorReachability
+}
+
+func (r locatorReachabilityRule) Check(ctx context.Context, _ types.Record, data types.RecordData) []Violation {
+	var violations []Violation
+
+	for i, locator := range data.GetLocators() {
+		u, err := url.Parse(locator.GetURL())
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			continue
+		}
+
+		if err := r.reach(ctx, u.String()); err != nil {
+			violations = append(violations, Violation{
+				Rule:    RuleLocatorReachability,
+				Field:   fmt.Sprintf("locators[%d].url", i),
+				Message: fmt.Sprintf("locator is not reachable: %v", err),
+			})
+		}
+	}
+
+	return violations
+}
+
+func (r locatorReachabilityRule) reach(ctx context.Context, locatorURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, locatorURL, nil)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone || resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	return nil
+}
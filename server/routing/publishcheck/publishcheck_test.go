@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package publishcheck
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func testRecord(skills []*typesv1alpha0.Skill, locators []*typesv1alpha0.Locator) types.Record {
+	return adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+		Skills:        skills,
+		Locators:      locators,
+	}))
+}
+
+func TestPipeline_CollectsViolationsOfAllRules(t *testing.T) {
+	ontology := Ontology{"nlp/text_completion": {}}
+
+	pipeline := New(Taxonomy(ontology), Locators())
+	assert.True(t, pipeline.Enabled())
+	assert.Equal(t, []string{RuleTaxonomy, RuleLocators}, pipeline.Rules())
+
+	valid := testRecord(
+		[]*typesv1alpha0.Skill{{CategoryName: ptr("nlp"), ClassName: ptr("text_completion")}},
+		[]*typesv1alpha0.Locator{{Type: "docker-image", Url: "ghcr.io/agntcy/agent:v1"}},
+	)
+	assert.Empty(t, pipeline.Check(t.Context(), valid))
+
+	invalid := testRecord(
+		[]*typesv1alpha0.Skill{
+			{CategoryName: ptr("nlp"), ClassName: ptr("text_completion")},
+			{CategoryName: ptr("nlp"), ClassName: ptr("unknown")},
+		},
+		[]*typesv1alpha0.Locator{{Type: "", Url: "https://example.com/agent"}},
+	)
+	assert.Equal(t, []Violation{
+		{Rule: RuleTaxonomy, Field: "skills[1].name", Message: `skill "nlp/unknown" is not part of the skills ontology`},
+		{Rule: RuleLocators, Field: "locators[0].type", Message: "locator has no type"},
+	}, pipeline.Check(t.Context(), invalid))
+
+	assert.Equal(t, []Violation{
+		{Rule: RuleTaxonomy, Field: "skills", Message: "record has no skills"},
+		{Rule: RuleLocators, Field: "locators", Message: "record has no locators"},
+	}, pipeline.Check(t.Context(), testRecord(nil, nil)))
+
+	// Records that cannot be decoded violate the schema
+	violations := pipeline.Check(t.Context(), adapters.NewRecordAdapter(&corev1.Record{}))
+	require.Len(t, violations, 1)
+	assert.Equal(t, RuleSchema, violations[0].Rule)
+
+	// Pipelines without rules accept all records
+	assert.False(t, New().Enabled())
+	assert.Empty(t, New().Check(t.Context(), adapters.NewRecordAdapter(&corev1.Record{})))
+}
+
+func TestLoadOntology(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skills.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# Skills\nnlp/text_completion\n\n  nlp/summarization  \n"), 0o600))
+
+	ontology, err := LoadOntology(path)
+	require.NoError(t, err)
+	assert.Equal(t, Ontology{"nlp/text_completion": {}, "nlp/summarization": {}}, ontology)
+
+	require.NoError(t, os.WriteFile(path, []byte("# No skills\n"), 0o600))
+
+	_, err = LoadOntology(path)
+	require.Error(t, err)
+
+	_, err = LoadOntology(filepath.Join(t.TempDir(), "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestLocatorReachability(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/agent":
+			w.WriteHeader(http.StatusOK)
+		case "/private":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	rule := LocatorReachability(server.Client())
+
+	record := testRecord(nil, []*typesv1alpha0.Locator{
+		{Type: "source-code", Url: server.URL + "/agent"},
+		{Type: "source-code", Url: server.URL + "/private"},
+		{Type: "source-code", Url: server.URL + "/missing"},
+		{Type: "docker-image", Url: "ghcr.io/agntcy/agent:v1"},
+	})
+
+	data, err := record.GetRecordData()
+	require.NoError(t, err)
+
+	violations := rule.Check(t.Context(), record, data)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "locators[2].url", violations[0].Field)
+	assert.Equal(t, "locator is not reachable: HTTP 404", violations[0].Message)
+}
@@ -0,0 +1,101 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/internal/didkey"
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// publishers holds the keys of the publishers whose records this peer publishes,
+// by their did:key identifiers. Records published as a publisher are announced
+// with the publisher's signature, so that several publishers can share a peer.
+type publishers struct {
+	keys map[string]crypto.PrivKey
+}
+
+// newPublishers loads the keys of the configured publishers.
+func newPublishers(cfgs []routingconfig.PublisherConfig) (*publishers, error) {
+	p := &publishers{keys: make(map[string]crypto.PrivKey, len(cfgs))}
+
+	for i, cfg := range cfgs {
+		key, err := signing.LoadKey(cfg.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("publisher %d: %w", i, err)
+		}
+
+		did, err := didkey.Encode(key.GetPublic())
+		if err != nil {
+			return nil, fmt.Errorf("publisher %d: %w", i, err)
+		}
+
+		if _, ok := p.keys[did]; ok {
+			return nil, fmt.Errorf("duplicate publisher %s", did)
+		}
+
+		p.keys[did] = key
+	}
+
+	return p, nil
+}
+
+// known reports whether records may be published as the publisher.
+// Records can always be published as this peer. A nil publishers has no publishers configured.
+func (p *publishers) known(publisher string) bool {
+	return publisher == "" || p.key(publisher) != nil
+}
+
+// key returns the key of a configured publisher, nil if it is not configured.
+func (p *publishers) key(publisher string) crypto.PrivKey {
+	if p == nil {
+		return nil
+	}
+
+	return p.keys[publisher]
+}
+
+// ids returns the did:key identifiers of the configured publishers, sorted.
+func (p *publishers) ids() []string {
+	if p == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(p.keys))
+	for did := range p.keys {
+		ids = append(ids, did)
+	}
+
+	slices.Sort(ids)
+
+	return ids
+}
+
+// recordPublisher returns the key of the publisher a local record was published as,
+// nil if it was published as this peer. It signs the record's GossipSub announcements.
+func (r *routeRemote) recordPublisher(cid string) crypto.PrivKey {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return nil
+	}
+
+	return r.publishers.key(decodeLocalRecordMetadata(value).Publisher)
+}
+
+// labelPublisher returns the publisher stored in label metadata, empty if unknown.
+func labelPublisher(value []byte) string {
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return ""
+	}
+
+	return metadata.Publisher
+}
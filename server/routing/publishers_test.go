@@ -0,0 +1,136 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/routing/addressbook"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeTestPublisherKey writes a new PEM encoded Ed25519 key and returns its path.
+func writeTestPublisherKey(t *testing.T) string {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "publisher.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+
+	return path
+}
+
+func TestNewPublishers(t *testing.T) {
+	path := writeTestPublisherKey(t)
+
+	p, err := newPublishers([]routingconfig.PublisherConfig{{KeyPath: path}, {KeyPath: writeTestPublisherKey(t)}})
+	require.NoError(t, err)
+	require.Len(t, p.ids(), 2)
+
+	for _, did := range p.ids() {
+		assert.Regexp(t, `^did:key:z6Mk`, did)
+		assert.True(t, p.known(did))
+	}
+
+	assert.True(t, p.known(""))
+	assert.False(t, p.known("did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"))
+
+	var none *publishers
+	assert.True(t, none.known(""))
+	assert.Nil(t, none.key(p.ids()[0]))
+
+	_, err = newPublishers([]routingconfig.PublisherConfig{{KeyPath: path}, {KeyPath: path}})
+	assert.ErrorContains(t, err, "duplicate publisher")
+
+	_, err = newPublishers([]routingconfig.PublisherConfig{{KeyPath: filepath.Join(t.TempDir(), "missing.key")}})
+	assert.Error(t, err)
+}
+
+func TestPublishWithOptions_Publisher(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	p, err := newPublishers([]routingconfig.PublisherConfig{{KeyPath: writeTestPublisherKey(t)}, {KeyPath: writeTestPublisherKey(t)}})
+	require.NoError(t, err)
+
+	publisher, other := p.ids()[0], p.ids()[1]
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, p)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-publisher",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+	label := types.GetLabelsFromRecord(adapter)[0]
+
+	// Publishers whose key is not configured are rejected
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Publisher: "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The publisher is stored with the record and its labels
+	require.NoError(t, r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Publisher: publisher}))
+
+	value, err := dstore.Get(t.Context(), ipfsdatastore.NewKey("/records/"+record.GetCid()))
+	require.NoError(t, err)
+	assert.Equal(t, publisher, decodeLocalRecordMetadata(value).Publisher)
+
+	value, err = dstore.Get(t.Context(), ipfsdatastore.NewKey(BuildEnhancedLabelKey(label, record.GetCid(), testPeerID)))
+	require.NoError(t, err)
+	assert.Equal(t, publisher, labelPublisher(value))
+
+	// Republishing without a publisher keeps it, publishing as another publisher is refused
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Publisher: other})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestImportSnapshot_DropsPublishers(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{dstore: dstore, addressBook: addressbook.New(dstore, PeerAddressTTL)}
+
+	// Snapshots carry no publisher signatures, so their publishers cannot be trusted
+	value, err := json.Marshal(&types.LabelMetadata{
+		Timestamp: time.Now(),
+		LastSeen:  time.Now(),
+		Publisher: "did:key:z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK",
+	})
+	require.NoError(t, err)
+
+	resp := &rpc.SnapshotResponse{Entries: []rpc.SnapshotEntry{{Key: "/skills/AI/cid1/peer1", Value: value}}}
+	require.Equal(t, 1, r.importSnapshot(t.Context(), resp, "local"))
+
+	stored, err := dstore.Get(t.Context(), ipfsdatastore.NewKey("/skills/AI/cid1/peer1"))
+	require.NoError(t, err)
+	assert.Empty(t, labelPublisher(stored))
+}
@@ -0,0 +1,233 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// propagationAckWindow is how long acks of a published announcement are awaited.
+	propagationAckWindow = time.Minute
+
+	// maxPendingAcks bounds the number of published announcements awaiting acks.
+	maxPendingAcks = 10_000
+
+	// maxPropagationSamples is the number of most recent latencies the statistics are computed from.
+	maxPropagationSamples = 1000
+)
+
+// propagationAck acknowledges the receipt of an announcement to its originator.
+type propagationAck struct {
+	ID         string    `json:"id"`          // AnnouncementID of the announcement
+	Origin     string    `json:"origin"`      // Peer ID of the originator
+	ReceivedAt time.Time `json:"received_at"` // When the acknowledging peer received it
+}
+
+// PropagationStats is the propagation latency of the announcements of this peer,
+// from publishing them until other peers received them.
+type PropagationStats struct {
+	Acks   uint64 // Acks received since startup
+	Median time.Duration
+	P90    time.Duration
+	Max    time.Duration
+}
+
+// pendingAnnouncement is a published announcement awaiting acks.
+type pendingAnnouncement struct {
+	publishedAt time.Time
+	ackers      map[peer.ID]struct{}
+}
+
+// propagationTracker measures the propagation latency of published announcements from the
+// acks of receiving peers. Latencies rely on the clocks of peers being synchronized; acks
+// received before their announcement was published are counted as immediate.
+// A nil tracker records nothing. It is safe for concurrent use.
+type propagationTracker struct {
+	mu      sync.Mutex
+	pending map[string]*pendingAnnouncement
+	samples []time.Duration // Ring of the most recent latencies
+	next    int             // Next sample to overwrite once the ring is full
+	acks    uint64
+}
+
+func newPropagationTracker() *propagationTracker {
+	return &propagationTracker{pending: make(map[string]*pendingAnnouncement)}
+}
+
+// published records that an announcement was published.
+func (t *propagationTracker) published(id string, now time.Time) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Forget announcements whose acks are no longer awaited
+	if len(t.pending) >= maxPendingAcks {
+		for pendingID, announcement := range t.pending {
+			if now.Sub(announcement.publishedAt) > propagationAckWindow {
+				delete(t.pending, pendingID)
+			}
+		}
+	}
+
+	if len(t.pending) >= maxPendingAcks {
+		return
+	}
+
+	// Announcements of a record on several namespace topics share their ID
+	if _, ok := t.pending[id]; !ok {
+		t.pending[id] = &pendingAnnouncement{publishedAt: now, ackers: make(map[peer.ID]struct{})}
+	}
+}
+
+// acked records the ack of a peer, reporting whether it acknowledged an awaited announcement.
+// Each peer's first ack of an announcement is counted.
+func (t *propagationTracker) acked(id string, from peer.ID, receivedAt, now time.Time) bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	announcement, ok := t.pending[id]
+	if !ok || now.Sub(announcement.publishedAt) > propagationAckWindow {
+		return false
+	}
+
+	if _, ok := announcement.ackers[from]; ok {
+		return false
+	}
+
+	announcement.ackers[from] = struct{}{}
+
+	latency := max(receivedAt.Sub(announcement.publishedAt), 0)
+	metrics.GossipSubPropagationLatency.Observe(latency.Seconds())
+
+	if len(t.samples) < maxPropagationSamples {
+		t.samples = append(t.samples, latency)
+	} else {
+		t.samples[t.next] = latency
+		t.next = (t.next + 1) % maxPropagationSamples
+	}
+
+	t.acks++
+
+	return true
+}
+
+// stats returns the latency statistics of the most recent acks, nil for a nil tracker.
+func (t *propagationTracker) stats() *PropagationStats {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	samples := slices.Clone(t.samples)
+	stats := &PropagationStats{Acks: t.acks}
+	t.mu.Unlock()
+
+	if len(samples) == 0 {
+		return stats
+	}
+
+	slices.Sort(samples)
+
+	stats.Median = samples[len(samples)/2]
+	stats.P90 = samples[len(samples)*9/10]
+	stats.Max = samples[len(samples)-1]
+
+	return stats
+}
+
+// joinPropagationAcks joins and subscribes to the propagation ack topic.
+func (m *Manager) joinPropagationAcks() error {
+	topic, err := m.pubsub.Join(TopicPropagationAcks)
+	if err != nil {
+		return fmt.Errorf("failed to join propagation acks topic %q: %w", TopicPropagationAcks, err)
+	}
+
+	m.ackTopic = topic
+
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to propagation acks topic %q: %w", TopicPropagationAcks, err)
+	}
+
+	m.ackSub = sub
+
+	return nil
+}
+
+// publishAck acknowledges a received announcement to its originator, if propagation acks are enabled.
+func (m *Manager) publishAck(msg *pubsub.Message, announcement *RecordPublishEvent, receivedAt time.Time) {
+	if m.ackTopic == nil {
+		return
+	}
+
+	origin := msg.GetFrom().String()
+
+	data, err := json.Marshal(propagationAck{
+		ID:         AnnouncementID(announcement.CID, origin, announcement.Timestamp),
+		Origin:     origin,
+		ReceivedAt: receivedAt,
+	})
+	if err != nil {
+		logger.Debug("Failed to marshal propagation ack", "cid", announcement.CID, "error", err)
+
+		return
+	}
+
+	if err := m.ackTopic.Publish(m.ctx, data); err != nil {
+		logger.Debug("Failed to publish propagation ack", "cid", announcement.CID, "error", err)
+	}
+}
+
+// handlePropagationAcks measures the propagation latency of the announcements of this peer
+// from the acks of receiving peers. Acks of announcements of other peers are ignored.
+func (m *Manager) handlePropagationAcks(sub *pubsub.Subscription) {
+	for {
+		msg, ok := m.nextMessage(sub)
+		if !ok {
+			return
+		}
+
+		// Skip our own acks
+		if msg.ReceivedFrom == m.host.ID() || msg.GetFrom() == m.host.ID() {
+			continue
+		}
+
+		// Acks count towards the same rate limit as announcements
+		if !m.admit(msg) {
+			continue
+		}
+
+		var ack propagationAck
+		if err := json.Unmarshal(msg.Data, &ack); err != nil {
+			logger.Debug("Received invalid propagation ack", "from", msg.ReceivedFrom, "error", err)
+			m.messages.invalid(msg.GetTopic())
+
+			continue
+		}
+
+		if ack.Origin != m.localPeerID {
+			continue
+		}
+
+		if m.propagation.acked(ack.ID, msg.GetFrom(), ack.ReceivedAt, time.Now()) {
+			logger.Debug("Received propagation ack", "from", msg.GetFrom(), "receivedAt", ack.ReceivedAt)
+		}
+	}
+}
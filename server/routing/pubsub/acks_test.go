@@ -0,0 +1,52 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagationTracker(t *testing.T) {
+	tracker := newPropagationTracker()
+	now := time.Now()
+	peerA, peerB, peerC := peer.ID("peer-a"), peer.ID("peer-b"), peer.ID("peer-c")
+
+	stats := tracker.stats()
+	require.NotNil(t, stats)
+	assert.Zero(t, stats.Acks)
+
+	tracker.published("id", now)
+
+	assert.True(t, tracker.acked("id", peerA, now.Add(10*time.Millisecond), now))
+	assert.True(t, tracker.acked("id", peerB, now.Add(30*time.Millisecond), now))
+
+	// Only the first ack of each peer counts
+	assert.False(t, tracker.acked("id", peerA, now.Add(time.Second), now))
+
+	// Acks of announcements that were not published or are no longer awaited are ignored
+	assert.False(t, tracker.acked("other", peerA, now, now))
+	assert.False(t, tracker.acked("id", peerC, now, now.Add(2*propagationAckWindow)))
+
+	// Acks received before publishing due to clock skew count as immediate
+	tracker.published("skewed", now)
+	assert.True(t, tracker.acked("skewed", peerA, now.Add(-time.Second), now))
+
+	stats = tracker.stats()
+	assert.Equal(t, uint64(3), stats.Acks)
+	assert.Equal(t, 10*time.Millisecond, stats.Median)
+	assert.Equal(t, 30*time.Millisecond, stats.P90)
+	assert.Equal(t, 30*time.Millisecond, stats.Max)
+
+	// A nil tracker records nothing
+	var disabled *propagationTracker
+
+	disabled.published("id", now)
+	assert.False(t, disabled.acked("id", peerA, now, now))
+	assert.Nil(t, disabled.stats())
+}
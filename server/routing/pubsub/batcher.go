@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// batcher aggregates the announcements of individually published records
+// within a short window, so that they are published as batch messages
+// instead of one message per record and namespace.
+//
+// The window starts with the first pending announcement. Pending announcements
+// are published when it elapses, when a namespace collects a full compressed
+// batch, or when the batcher is closed.
+type batcher struct {
+	window  time.Duration
+	publish func(context.Context, types.LabelType, []*RecordPublishEvent) error
+
+	mu      sync.Mutex
+	pending map[types.LabelType][]*RecordPublishEvent
+	timer   *time.Timer
+	closed  bool
+}
+
+// newBatcher creates a batcher publishing pending announcements with the given function.
+func newBatcher(window time.Duration, publish func(context.Context, types.LabelType, []*RecordPublishEvent) error) *batcher {
+	return &batcher{
+		window:  window,
+		publish: publish,
+		pending: make(map[types.LabelType][]*RecordPublishEvent),
+	}
+}
+
+// Add queues an announcement for its namespace topic.
+// Returns false if the batcher is closed and the announcement was not queued.
+func (b *batcher) Add(labelType types.LabelType, event *RecordPublishEvent) bool {
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+
+		return false
+	}
+
+	b.pending[labelType] = append(b.pending[labelType], event)
+
+	// Publish full batches right away instead of waiting for the window
+	var full []*RecordPublishEvent
+	if len(b.pending[labelType]) >= MaxEventsPerCompressedBatch {
+		full = b.pending[labelType]
+		delete(b.pending, labelType)
+	}
+
+	if b.timer == nil && len(b.pending) > 0 {
+		b.timer = time.AfterFunc(b.window, b.Flush)
+	}
+
+	b.mu.Unlock()
+
+	if full != nil {
+		b.publishNamespace(labelType, full)
+	}
+
+	return true
+}
+
+// Flush publishes all pending announcements.
+func (b *batcher) Flush() {
+	b.mu.Lock()
+
+	pending := b.pending
+	b.pending = make(map[types.LabelType][]*RecordPublishEvent)
+
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	b.mu.Unlock()
+
+	for _, labelType := range types.AllLabelTypes() {
+		if events, ok := pending[labelType]; ok {
+			b.publishNamespace(labelType, events)
+		}
+	}
+}
+
+// Close publishes all pending announcements and stops queueing new ones.
+func (b *batcher) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	b.Flush()
+}
+
+// publishNamespace publishes the announcements of a namespace, logging failures
+// as there is no caller to return them to. Pending announcements are also
+// published on shutdown, so they are not bound to the manager's context.
+func (b *batcher) publishNamespace(labelType types.LabelType, events []*RecordPublishEvent) {
+	if err := b.publish(context.Background(), labelType, events); err != nil {
+		logger.Warn("Failed to publish batched record announcements",
+			"namespace", labelType,
+			"records", len(events),
+			"error", err)
+	}
+}
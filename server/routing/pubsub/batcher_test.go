@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type publishedBatch struct {
+	labelType types.LabelType
+	events    int
+}
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []publishedBatch
+}
+
+func (r *batchRecorder) publish(_ context.Context, labelType types.LabelType, events []*RecordPublishEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches = append(r.batches, publishedBatch{labelType: labelType, events: len(events)})
+
+	return nil
+}
+
+func (r *batchRecorder) published() []publishedBatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]publishedBatch(nil), r.batches...)
+}
+
+func TestBatcher_PublishesAfterWindow(t *testing.T) {
+	recorder := &batchRecorder{}
+	b := newBatcher(50*time.Millisecond, recorder.publish)
+
+	for _, event := range newTestEvents(t, 3) {
+		require.True(t, b.Add(types.LabelTypeSkill, event))
+	}
+
+	require.True(t, b.Add(types.LabelTypeDomain, newTestEvents(t, 1)[0]))
+	assert.Empty(t, recorder.published())
+
+	assert.Eventually(t, func() bool { return len(recorder.published()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []publishedBatch{
+		{labelType: types.LabelTypeSkill, events: 3},
+		{labelType: types.LabelTypeDomain, events: 1},
+	}, recorder.published())
+}
+
+func TestBatcher_PublishesFullBatches(t *testing.T) {
+	recorder := &batchRecorder{}
+	b := newBatcher(time.Hour, recorder.publish)
+
+	for _, event := range newTestEvents(t, MaxEventsPerCompressedBatch+1) {
+		require.True(t, b.Add(types.LabelTypeSkill, event))
+	}
+
+	assert.Equal(t, []publishedBatch{{labelType: types.LabelTypeSkill, events: MaxEventsPerCompressedBatch}}, recorder.published())
+
+	// Closing publishes pending announcements and stops queueing
+	b.Close()
+	assert.Equal(t, []publishedBatch{
+		{labelType: types.LabelTypeSkill, events: MaxEventsPerCompressedBatch},
+		{labelType: types.LabelTypeSkill, events: 1},
+	}, recorder.published())
+
+	assert.False(t, b.Add(types.LabelTypeSkill, newTestEvents(t, 1)[0]))
+}
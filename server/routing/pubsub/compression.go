@@ -0,0 +1,165 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+)
+
+// errCompressedBatchTooLarge is returned when a compressed batch exceeds MaxMessageSize.
+var errCompressedBatchTooLarge = errors.New("compressed batch exceeds maximum size")
+
+// zstdCodec holds the encoder and decoder shared by all compressed batches.
+// Both are safe for concurrent use with EncodeAll and DecodeAll.
+var zstdCodec = sync.OnceValues(func() (*zstdCoders, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+
+	decoder, err := zstd.NewReader(nil,
+		zstd.WithDecoderConcurrency(0),
+		zstd.WithDecoderMaxMemory(MaxDecompressedMessageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &zstdCoders{encoder: encoder, decoder: decoder}, nil
+})
+
+type zstdCoders struct {
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+}
+
+// marshalCompressedAnnouncements serializes events as a zstd-compressed
+// routingv1.LabelAnnouncements prefixed with WireVersionZstd.
+func marshalCompressedAnnouncements(events []*RecordPublishEvent) ([]byte, error) {
+	if len(events) > MaxEventsPerCompressedBatch {
+		return nil, errors.New("too many events in compressed batch")
+	}
+
+	msg := &routingv1.LabelAnnouncements{
+		Announcements: make([]*routingv1.LabelAnnouncement, 0, len(events)),
+	}
+
+	for _, event := range events {
+		msg.Announcements = append(msg.Announcements, event.ToProto())
+	}
+
+	raw, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal label announcements: %w", err)
+	}
+
+	if len(raw) > MaxDecompressedMessageSize {
+		return nil, errCompressedBatchTooLarge
+	}
+
+	codec, err := zstdCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	data := codec.encoder.EncodeAll(raw, []byte{WireVersionZstd})
+
+	// Validate size to prevent oversized messages
+	if len(data) > MaxMessageSize {
+		return nil, errCompressedBatchTooLarge
+	}
+
+	return data, nil
+}
+
+// unmarshalCompressedAnnouncements decompresses and deserializes the routingv1.LabelAnnouncements
+// following the WireVersionZstd byte, validating each event and its signature (if present).
+// Batches decompressing to more than MaxDecompressedMessageSize are rejected.
+func unmarshalCompressedAnnouncements(data []byte) ([]*RecordPublishEvent, error) {
+	codec, err := zstdCodec()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := codec.decoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress label announcements: %w", err)
+	}
+
+	if len(raw) > MaxDecompressedMessageSize {
+		return nil, errors.New("decompressed batch exceeds maximum size")
+	}
+
+	var msg routingv1.LabelAnnouncements
+	if err := proto.Unmarshal(raw, &msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal label announcements: %w", err)
+	}
+
+	batch := RecordPublishBatch{Events: make([]*RecordPublishEvent, 0, len(msg.GetAnnouncements()))}
+	for _, announcement := range msg.GetAnnouncements() {
+		batch.Events = append(batch.Events, recordPublishEventFromProto(announcement))
+	}
+
+	if err := batch.validate(MaxEventsPerCompressedBatch); err != nil {
+		return nil, err
+	}
+
+	if err := verifyEvents(batch.Events); err != nil {
+		return nil, err
+	}
+
+	return batch.Events, nil
+}
+
+// marshalCompressedBatches serializes events into as few compressed batches as
+// possible. Events are split into batches of MaxEventsPerCompressedBatch, and
+// batches exceeding MaxMessageSize after compression are halved until they fit.
+// Fails if a single event does not fit into a message on its own.
+func marshalCompressedBatches(events []*RecordPublishEvent) ([]batchMessage, error) {
+	var batches []batchMessage
+
+	for start := 0; start < len(events); start += MaxEventsPerCompressedBatch {
+		end := min(start+MaxEventsPerCompressedBatch, len(events))
+
+		split, err := splitCompressedBatch(events[start:end])
+		if err != nil {
+			return nil, err
+		}
+
+		batches = append(batches, split...)
+	}
+
+	return batches, nil
+}
+
+// splitCompressedBatch compresses events into one batch, halving them until each half fits.
+func splitCompressedBatch(events []*RecordPublishEvent) ([]batchMessage, error) {
+	data, err := marshalCompressedAnnouncements(events)
+	if err == nil {
+		return []batchMessage{{data: data, events: len(events)}}, nil
+	}
+
+	if !errors.Is(err, errCompressedBatchTooLarge) || len(events) == 1 {
+		return nil, err
+	}
+
+	half := len(events) / 2 //nolint:mnd
+
+	first, err := splitCompressedBatch(events[:half])
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := splitCompressedBatch(events[half:])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(first, second...), nil
+}
@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalAnnouncements_Zstd(t *testing.T) {
+	events := newTestEvents(t, 3)
+
+	data, err := MarshalAnnouncements(events, WireFormatZstd)
+	require.NoError(t, err)
+	assert.Equal(t, WireVersionZstd, data[0])
+
+	decoded, err := UnmarshalAnnouncements(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, 3)
+
+	for i, event := range decoded {
+		assert.Equal(t, events[i].CID, event.CID)
+		assert.Equal(t, events[i].Labels, event.Labels)
+		assert.True(t, events[i].Timestamp.Equal(event.Timestamp))
+		assert.True(t, event.IsSigned())
+	}
+}
+
+func TestMarshalCompressedBatches(t *testing.T) {
+	events := newTestEvents(t, 2*MaxEventsPerCompressedBatch)
+
+	batches, err := marshalCompressedBatches(events)
+	require.NoError(t, err)
+
+	// Compressed batches carry more events per message than uncompressed ones
+	uncompressed, err := splitIntoBatches(events)
+	require.NoError(t, err)
+	assert.Less(t, len(batches), len(uncompressed))
+
+	var decoded []*RecordPublishEvent
+
+	for _, batch := range batches {
+		assert.LessOrEqual(t, len(batch.data), MaxMessageSize)
+
+		batchEvents, err := UnmarshalAnnouncements(batch.data)
+		require.NoError(t, err)
+		assert.Len(t, batchEvents, batch.events)
+
+		decoded = append(decoded, batchEvents...)
+	}
+
+	require.Len(t, decoded, len(events))
+
+	for i, event := range decoded {
+		assert.Equal(t, events[i].CID, event.CID)
+	}
+}
+
+func TestUnmarshalAnnouncements_InvalidZstdRejected(t *testing.T) {
+	// Not a zstd frame
+	_, err := UnmarshalAnnouncements([]byte{WireVersionZstd, 0xff, 0xff})
+	require.Error(t, err)
+
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+
+	// Highly compressible payloads decompressing beyond the limit are dropped
+	bomb := encoder.EncodeAll(bytes.Repeat([]byte{0}, 2*MaxDecompressedMessageSize), []byte{WireVersionZstd})
+	require.LessOrEqual(t, len(bomb), MaxMessageSize)
+
+	_, err = UnmarshalAnnouncements(bomb)
+	require.Error(t, err)
+
+	// Tampered events are rejected as in uncompressed batches
+	events := newTestEvents(t, 2)
+	events[1].Size = 4096
+
+	data, err := MarshalAnnouncements(events, WireFormatZstd)
+	require.NoError(t, err)
+
+	_, err = UnmarshalAnnouncements(data)
+	assert.Error(t, err)
+}
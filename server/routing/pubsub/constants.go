@@ -3,6 +3,8 @@
 
 package pubsub
 
+import "time"
+
 // Protocol constants for GossipSub label announcements.
 // These values are INTENTIONALLY NOT CONFIGURABLE to ensure network-wide compatibility.
 // All peers must use the same values to communicate properly.
@@ -12,13 +14,34 @@ package pubsub
 //   - Different message sizes → messages may be rejected
 //   - Different label limits → validation inconsistencies
 //
-// If protocol changes are needed, increment the topic version (e.g., "dir/labels/v2")
+// If protocol changes are needed, increment the topic version (e.g., "dir/labels.skills/v2")
 // and coordinate the upgrade across all peers.
 const (
-	// TopicLabels is the GossipSub topic for label announcements.
-	// All peers must subscribe to the same topic to communicate.
-	// Versioned to allow future protocol changes (e.g., "dir/labels/v2").
-	TopicLabels = "dir/labels/v1"
+	// TopicLabelsPrefix and TopicLabelsVersion form the per-namespace GossipSub
+	// topics for label announcements: TopicLabelsPrefix + namespace + TopicLabelsVersion,
+	// e.g. "dir/labels.skills/v1". See NamespaceTopic.
+	// All peers must use the same topic names to communicate.
+	// Versioned to allow future protocol changes (e.g., "dir/labels.skills/v2").
+	TopicLabelsPrefix  = "dir/labels."
+	TopicLabelsVersion = "/v1"
+
+	// TopicRevocations is the GossipSub topic for signed record revocations.
+	// All peers subscribe to it regardless of their namespace subscription policy.
+	TopicRevocations = "dir/revocations/v1"
+
+	// TopicContinuity is the GossipSub topic for signed continuity records of peer identity rotations.
+	// All peers subscribe to it regardless of their namespace subscription policy.
+	TopicContinuity = "dir/continuity/v1"
+
+	// TopicLabelDigests is the GossipSub topic for the label digests of peers,
+	// Bloom filters of their labels used to select the peers to query in live searches.
+	// All peers subscribe to it regardless of their namespace subscription policy.
+	TopicLabelDigests = "dir/digests/v1"
+
+	// TopicPropagationAcks is the GossipSub topic on which peers acknowledge received
+	// announcements to their originator, to measure propagation latency. Only peers
+	// with propagation acks enabled join it, it is meant for debugging.
+	TopicPropagationAcks = "dir/acks/v1"
 
 	// MaxMessageSize is the maximum size of label announcement messages.
 	// This prevents abuse and ensures all peers can process messages.
@@ -29,4 +52,66 @@ const (
 	// This prevents abuse from malicious peers.
 	// 100 labels is generous for typical records.
 	MaxLabelsPerAnnouncement = 100
+
+	// MaxEventsPerBatch is the maximum number of record publish events
+	// coalesced into a single batched GossipSub message.
+	// Batches are additionally limited by MaxMessageSize.
+	MaxEventsPerBatch = 50
+
+	// SignatureDomain is prepended to the signed payload of record publish events.
+	// It prevents signatures from being replayed in other libp2p protocols
+	// that use the same identity key.
+	SignatureDomain = "dir/labels/v1/signature"
+
+	// MaxAnnouncementAge is how old an announcement may be when it is received.
+	// Announcements are published with a fresh timestamp on every republish, so
+	// older announcements can only be replays and are dropped.
+	MaxAnnouncementAge = 15 * time.Minute
+
+	// MaxAnnouncementClockSkew is how far in the future an announcement
+	// timestamp may be, allowing for clock differences between peers.
+	MaxAnnouncementClockSkew = 5 * time.Minute
+
+	// MaxSeenAnnouncements bounds the number of announcement IDs remembered
+	// for duplicate detection. The oldest IDs are forgotten first.
+	MaxSeenAnnouncements = 100_000
+
+	// WireVersionProtobuf is the first byte of protobuf-encoded announcement messages,
+	// followed by a routingv1.LabelAnnouncements message. JSON announcements start
+	// with '{', so receivers tell the encodings apart by the first byte.
+	// New binary encodings must use a new version byte.
+	WireVersionProtobuf byte = 0x01
+
+	// WireVersionZstd is the first byte of compressed announcement batches,
+	// followed by a zstd-compressed routingv1.LabelAnnouncements message.
+	WireVersionZstd byte = 0x02
+
+	// MaxEventsPerCompressedBatch is the maximum number of record publish events
+	// in a compressed batch. Compressed batches are limited by MaxMessageSize
+	// after compression, so they carry more events than uncompressed ones.
+	MaxEventsPerCompressedBatch = 500
+
+	// MaxDecompressedMessageSize is the maximum size of a compressed batch after
+	// decompression. Larger batches are dropped without being fully decompressed.
+	MaxDecompressedMessageSize = 256 * 1024 // 256KB
+)
+
+// Wire formats of published label announcements. Received announcements are
+// accepted in any wire format, so that peers can switch formats independently.
+const (
+	// WireFormatJSON encodes announcements as JSON, readable by all peers.
+	WireFormatJSON = "json"
+
+	// WireFormatProtobuf encodes announcements as protobuf prefixed with WireVersionProtobuf.
+	// Peers that predate it drop such announcements.
+	WireFormatProtobuf = "protobuf"
+
+	// WireFormatZstd encodes announcements as zstd-compressed protobuf prefixed with WireVersionZstd.
+	// Peers that predate it drop such announcements.
+	WireFormatZstd = "zstd"
 )
+
+// MaxBatchWindow is the longest window within which the announcements of individually
+// published records may be aggregated. Batched announcements keep the timestamp of
+// their record's publication, so longer windows would delay them towards MaxAnnouncementAge.
+const MaxBatchWindow = time.Minute
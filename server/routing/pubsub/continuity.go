@@ -0,0 +1,104 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/server/routing/continuity"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// joinContinuity joins and subscribes to the identity continuity topic.
+func (m *Manager) joinContinuity() error {
+	topic, err := m.pubsub.Join(TopicContinuity)
+	if err != nil {
+		return fmt.Errorf("failed to join continuity topic %q: %w", TopicContinuity, err)
+	}
+
+	m.continuityTopic = topic
+
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to continuity topic %q: %w", TopicContinuity, err)
+	}
+
+	m.continuitySub = sub
+
+	return nil
+}
+
+// PublishContinuity publishes a signed continuity record of an identity rotation of this peer.
+func (m *Manager) PublishContinuity(ctx context.Context, record *continuity.Record) error {
+	if record == nil {
+		return errors.New("continuity record is nil")
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := m.continuityTopic.Publish(ctx, data); err != nil {
+		return fmt.Errorf("failed to publish continuity record: %w", err)
+	}
+
+	logger.Info("Published identity continuity record",
+		"oldPeer", record.OldPeerID,
+		"topicPeers", len(m.continuityTopic.ListPeers()))
+
+	return nil
+}
+
+// SetOnContinuity sets the callback for received continuity records.
+// The callback receives records signed by both identities and originated by the new one.
+func (m *Manager) SetOnContinuity(fn func(context.Context, *continuity.Record)) {
+	m.onContinuity = fn
+}
+
+// handleContinuity processes incoming continuity records.
+// Records must be originated by the new peer identity they link to.
+func (m *Manager) handleContinuity(sub *pubsub.Subscription) {
+	for {
+		msg, ok := m.nextMessage(sub)
+		if !ok {
+			return
+		}
+
+		// Skip our own records (already applied locally)
+		if msg.ReceivedFrom == m.host.ID() {
+			continue
+		}
+
+		// Continuity records count towards the same rate limit as announcements
+		if !m.admit(msg) {
+			continue
+		}
+
+		record, err := continuity.Unmarshal(msg.Data)
+		if err == nil && record.NewPeerID != msg.GetFrom().String() {
+			err = fmt.Errorf("continuity record of %s originated from %s", record.NewPeerID, msg.GetFrom())
+		}
+
+		if err != nil {
+			logger.Warn("Rejected identity continuity record",
+				"from", msg.ReceivedFrom,
+				"error", err,
+				"size", len(msg.Data))
+			m.observeAnnouncement(msg, false)
+
+			continue
+		}
+
+		m.observeAnnouncement(msg, true)
+
+		logger.Debug("Received identity continuity record", "from", msg.ReceivedFrom, "oldPeer", record.OldPeerID, "newPeer", record.NewPeerID)
+
+		if m.onContinuity != nil {
+			m.onContinuity(m.ctx, record)
+		}
+	}
+}
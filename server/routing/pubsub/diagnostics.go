@@ -0,0 +1,211 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/metrics"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// PeerScoreInspectPeriod is how often the GossipSub scores of peers are captured for diagnostics.
+const PeerScoreInspectPeriod = 10 * time.Second
+
+// Diagnostics are the GossipSub message statistics, peer scores and propagation latency,
+// for routing diagnostics.
+type Diagnostics struct {
+	Topics      []TopicDiagnostics
+	PeerScoring bool
+	PeerScores  []PeerScore       // Lowest first
+	Propagation *PropagationStats // Nil unless propagation acks are enabled
+}
+
+// TopicDiagnostics are the message statistics of a joined topic since startup.
+type TopicDiagnostics struct {
+	Topic      string
+	MeshDegree int
+	Delivered  uint64 // Messages received for the first time
+	Duplicate  uint64 // Messages received again from another peer
+	Invalid    uint64 // Messages rejected by GossipSub or failing validation
+}
+
+// PeerScore is the GossipSub score of a peer and its components.
+type PeerScore struct {
+	Peer             peer.ID
+	Score            float64
+	AppSpecificScore float64
+	BehaviourPenalty float64
+}
+
+// topicMessages counts the messages received on a topic.
+type topicMessages struct {
+	delivered, duplicate, invalid uint64
+}
+
+// messageTracer counts the delivered, duplicate and invalid messages of each topic from the
+// router's events. Messages failing the validation of announcements, which happens after
+// GossipSub delivered them, are reported by the manager. It is safe for concurrent use.
+type messageTracer struct {
+	self peer.ID
+
+	mu     sync.Mutex
+	topics map[string]*topicMessages
+}
+
+var _ pubsub.RawTracer = (*messageTracer)(nil)
+
+func newMessageTracer(self peer.ID) *messageTracer {
+	return &messageTracer{self: self, topics: make(map[string]*topicMessages)}
+}
+
+// count counts a received message of the topic with the result.
+func (t *messageTracer) count(topic, result string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	messages, ok := t.topics[topic]
+	if !ok {
+		messages = &topicMessages{}
+		t.topics[topic] = messages
+	}
+
+	switch result {
+	case metrics.ResultDelivered:
+		messages.delivered++
+	case metrics.ResultDuplicate:
+		messages.duplicate++
+	default:
+		messages.invalid++
+	}
+
+	metrics.GossipSubMessages.WithLabelValues(topic, result).Inc()
+}
+
+// invalid counts a delivered message of the topic that failed validation.
+func (t *messageTracer) invalid(topic string) {
+	t.count(topic, metrics.RejectInvalid)
+}
+
+// messages returns the message counts of a topic.
+func (t *messageTracer) messages(topic string) topicMessages {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if messages, ok := t.topics[topic]; ok {
+		return *messages
+	}
+
+	return topicMessages{}
+}
+
+func (t *messageTracer) DeliverMessage(msg *pubsub.Message) {
+	if msg.ReceivedFrom != t.self {
+		t.count(msg.GetTopic(), metrics.ResultDelivered)
+	}
+}
+
+func (t *messageTracer) DuplicateMessage(msg *pubsub.Message) {
+	if msg.ReceivedFrom != t.self {
+		t.count(msg.GetTopic(), metrics.ResultDuplicate)
+	}
+}
+
+func (t *messageTracer) RejectMessage(msg *pubsub.Message, reason string) {
+	// Messages dropped under load or ignored by validators are not invalid
+	switch reason {
+	case pubsub.RejectValidationQueueFull, pubsub.RejectValidationThrottled, pubsub.RejectValidationIgnored, pubsub.RejectSelfOrigin:
+		return
+	}
+
+	t.invalid(msg.GetTopic())
+}
+
+// Other router events are not needed to count messages.
+
+func (t *messageTracer) AddPeer(peer.ID, protocol.ID)         {}
+func (t *messageTracer) RemovePeer(peer.ID)                   {}
+func (t *messageTracer) Join(string)                          {}
+func (t *messageTracer) Leave(string)                         {}
+func (t *messageTracer) Graft(peer.ID, string)                {}
+func (t *messageTracer) Prune(peer.ID, string)                {}
+func (t *messageTracer) ValidateMessage(*pubsub.Message)      {}
+func (t *messageTracer) ThrottlePeer(peer.ID)                 {}
+func (t *messageTracer) RecvRPC(*pubsub.RPC)                  {}
+func (t *messageTracer) SendRPC(*pubsub.RPC, peer.ID)         {}
+func (t *messageTracer) DropRPC(*pubsub.RPC, peer.ID)         {}
+func (t *messageTracer) UndeliverableMessage(*pubsub.Message) {}
+
+// scoreInspector keeps the GossipSub scores of peers last captured by the router.
+type scoreInspector struct {
+	mu     sync.Mutex
+	scores []PeerScore
+}
+
+// inspect captures the scores of the connected peers, see pubsub.WithPeerScoreInspect.
+func (s *scoreInspector) inspect(snapshots map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	scores := make([]PeerScore, 0, len(snapshots))
+
+	for p, snapshot := range snapshots {
+		scores = append(scores, PeerScore{
+			Peer:             p,
+			Score:            snapshot.Score,
+			AppSpecificScore: snapshot.AppSpecificScore,
+			BehaviourPenalty: snapshot.BehaviourPenalty,
+		})
+	}
+
+	slices.SortFunc(scores, func(a, b PeerScore) int {
+		return cmp.Or(cmp.Compare(a.Score, b.Score), cmp.Compare(a.Peer, b.Peer))
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scores = scores
+}
+
+// snapshot returns the scores last captured.
+func (s *scoreInspector) snapshot() []PeerScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.scores
+}
+
+// Diagnostics returns the message statistics of the joined topics, the scores of the
+// connected peers and the propagation latency of the announcements of this peer.
+func (m *Manager) Diagnostics() Diagnostics {
+	states := m.TopicStates()
+	topics := make([]TopicDiagnostics, 0, len(states))
+
+	for _, state := range states {
+		messages := m.messages.messages(state.Topic)
+
+		topics = append(topics, TopicDiagnostics{
+			Topic:      state.Topic,
+			MeshDegree: len(state.MeshPeers),
+			Delivered:  messages.delivered,
+			Duplicate:  messages.duplicate,
+			Invalid:    messages.invalid,
+		})
+	}
+
+	diagnostics := Diagnostics{
+		Topics:      topics,
+		PeerScoring: m.scores != nil,
+		Propagation: m.propagation.stats(),
+	}
+
+	if m.scores != nil {
+		diagnostics.PeerScores = m.scores.snapshot()
+	}
+
+	return diagnostics
+}
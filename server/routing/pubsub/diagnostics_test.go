@@ -0,0 +1,52 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func testMessage(topic string, from peer.ID) *pubsub.Message {
+	return &pubsub.Message{Message: &pb.Message{Topic: &topic}, ReceivedFrom: from}
+}
+
+func TestMessageTracer(t *testing.T) {
+	self, other := peer.ID("self"), peer.ID("other")
+	tracer := newMessageTracer(self)
+
+	tracer.DeliverMessage(testMessage("skills", other))
+	tracer.DeliverMessage(testMessage("skills", other))
+	tracer.DuplicateMessage(testMessage("skills", other))
+	tracer.RejectMessage(testMessage("skills", other), pubsub.RejectInvalidSignature)
+	tracer.invalid("skills")
+
+	// Own messages and messages dropped under load are not counted
+	tracer.DeliverMessage(testMessage("skills", self))
+	tracer.RejectMessage(testMessage("skills", other), pubsub.RejectValidationThrottled)
+	tracer.RejectMessage(testMessage("skills", other), pubsub.RejectValidationIgnored)
+
+	assert.Equal(t, topicMessages{delivered: 2, duplicate: 1, invalid: 2}, tracer.messages("skills"))
+	assert.Equal(t, topicMessages{}, tracer.messages("domains"))
+}
+
+func TestScoreInspector(t *testing.T) {
+	inspector := &scoreInspector{}
+	assert.Empty(t, inspector.snapshot())
+
+	inspector.inspect(map[peer.ID]*pubsub.PeerScoreSnapshot{
+		"good": {Score: 10, AppSpecificScore: 10},
+		"bad":  {Score: -5, BehaviourPenalty: 5},
+	})
+
+	// Lowest scores first
+	assert.Equal(t, []PeerScore{
+		{Peer: "bad", Score: -5, BehaviourPenalty: 5},
+		{Peer: "good", Score: 10, AppSpecificScore: 10},
+	}, inspector.snapshot())
+}
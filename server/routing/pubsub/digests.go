@@ -0,0 +1,102 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/server/routing/labeldigest"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// joinLabelDigests joins and subscribes to the label digest topic.
+func (m *Manager) joinLabelDigests() error {
+	topic, err := m.pubsub.Join(TopicLabelDigests)
+	if err != nil {
+		return fmt.Errorf("failed to join label digests topic %q: %w", TopicLabelDigests, err)
+	}
+
+	m.digestTopic = topic
+
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to label digests topic %q: %w", TopicLabelDigests, err)
+	}
+
+	m.digestSub = sub
+
+	return nil
+}
+
+// PublishLabelDigest publishes the label digest of the local peer to the network.
+func (m *Manager) PublishLabelDigest(ctx context.Context, digest *labeldigest.Digest) error {
+	if digest == nil {
+		return errors.New("label digest is nil")
+	}
+
+	data, err := digest.Marshal()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := m.digestTopic.Publish(ctx, data); err != nil {
+		return fmt.Errorf("failed to publish label digest: %w", err)
+	}
+
+	logger.Debug("Published label digest",
+		"labels", digest.Labels,
+		"size", len(digest.Filter),
+		"topicPeers", len(m.digestTopic.ListPeers()))
+
+	return nil
+}
+
+// SetOnLabelDigest sets the callback for received label digests.
+// The callback receives the peer that originated the digest, which GossipSub
+// message signatures authenticate.
+func (m *Manager) SetOnLabelDigest(fn func(context.Context, peer.ID, *labeldigest.Digest)) {
+	m.onLabelDigest = fn
+}
+
+// handleLabelDigests processes incoming label digests.
+func (m *Manager) handleLabelDigests(sub *pubsub.Subscription) {
+	for {
+		msg, ok := m.nextMessage(sub)
+		if !ok {
+			return
+		}
+
+		// Skip our own digests
+		if msg.GetFrom() == m.host.ID() {
+			continue
+		}
+
+		// Digests count towards the same rate limit as announcements
+		if !m.admit(msg) {
+			continue
+		}
+
+		digest, err := labeldigest.Unmarshal(msg.Data)
+		if err != nil {
+			logger.Warn("Rejected label digest",
+				"from", msg.ReceivedFrom,
+				"error", err,
+				"size", len(msg.Data))
+			m.observeAnnouncement(msg, false)
+
+			continue
+		}
+
+		m.observeAnnouncement(msg, true)
+
+		logger.Debug("Received label digest", "from", msg.ReceivedFrom, "publisher", msg.GetFrom(), "labels", digest.Labels)
+
+		if m.onLabelDigest != nil {
+			m.onLabelDigest(m.ctx, msg.GetFrom(), digest)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"time"
 
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/routingerr"
 	"github.com/agntcy/dir/server/types"
 )
 
@@ -19,32 +21,41 @@ import (
 //
 // The handler should:
 //   - Accept a types.Record interface (caller must wrap concrete types with adapters)
+//   - Accept the announcement priority of the record
 //   - Announce the record to DHT
-//   - Publish the record's labels via GossipSub
+//   - Publish the record's labels via GossipSub (unless low priority)
 //   - Handle errors appropriately
 //
 // Example usage:
 //
 //	// In routing_remote.go:
-//	cleanupManager := NewCleanupManager(..., routeAPI.Publish)
+//	cleanupManager := NewCleanupManager(..., routeAPI.PublishWithPriority)
 //
 //	// In cleanup_tasks.go:
 //	type CleanupManager struct {
 //	    publishFunc pubsub.PublishEventHandler
 //	}
-type PublishEventHandler func(context.Context, types.Record) error
+type PublishEventHandler func(context.Context, types.Record, routingv1.AnnouncementPriority) error
 
 // RecordPublishEvent is the wire format for record publication announcements via GossipSub.
 // This is a minimal structure optimized for network efficiency.
+// It is sent as JSON or as protobuf, see MarshalAnnouncements.
 //
-// Protocol parameters: See constants.go for TopicLabels, MaxMessageSize, etc.
+// Protocol parameters: See constants.go for TopicLabelsPrefix, MaxMessageSize, etc.
 // These are intentionally NOT configurable to ensure network-wide compatibility.
 //
 // Security Note:
 //   - PeerID is NOT included in the wire format to prevent spoofing
-//   - Instead, the authenticated sender (msg.ReceivedFrom) is passed separately to handlers
+//   - Instead, the authenticated originator (msg.GetFrom) is passed separately to handlers
 //   - This ensures only cryptographically verified peer IDs are used for storage
 //
+// Signatures:
+//   - PublicKey and Signature are optional and set by the announcing peer
+//   - The signature is an Ed25519 signature of SigningPayload() made with the
+//     peer's libp2p identity key, and is verified on unmarshal
+//   - The Manager additionally checks that the signing key belongs to the
+//     originating peer, so labels cannot be announced on behalf of another PeerID
+//
 // Conversion to storage format:
 //   - Wire: RecordPublishEvent with []string labels
 //   - Handler receives: authenticated PeerID from libp2p transport
@@ -70,19 +81,74 @@ type RecordPublishEvent struct {
 	// Timestamp is when this announcement was created.
 	// This becomes the types.LabelMetadata.Timestamp field.
 	Timestamp time.Time `json:"timestamp"`
+
+	// ExpiresAt is when the record's TTL elapses, after which receivers drop its labels.
+	// Zero if the record does not expire.
+	// This becomes the types.LabelMetadata.ExpiresAt field.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+
+	// Supersedes is the CID of the previous version of the record.
+	// Empty if the record does not supersede another one.
+	// This becomes the types.LabelMetadata.Supersedes field.
+	Supersedes string `json:"supersedes,omitempty"`
+
+	// AccessGated marks the record's content as only served to peers authorized
+	// by the announcing peer, while its labels are announced to everyone.
+	// This becomes the types.LabelMetadata.AccessGated field.
+	AccessGated bool `json:"access_gated,omitempty"`
+
+	// Size is the size of the record's canonical content in bytes, so that receivers
+	// can validate content pulled from the announcing peer against it.
+	// Zero if unknown.
+	// This becomes the types.LabelMetadata.Size field.
+	Size uint64 `json:"size,omitempty"`
+
+	// Tenant is the tenant the record was published for, empty if untenanted.
+	// Receivers store the labels under the tenant's namespace, and drop
+	// announcements of tenants they are not configured for.
+	Tenant string `json:"tenant,omitempty"`
+
+	// Publisher is the did:key identifier of the publisher the record was published as,
+	// empty if it was published as the announcing peer itself. Several publishers can
+	// share a peer, which signs their announcements with their keys.
+	// This becomes the types.LabelMetadata.Publisher field.
+	Publisher string `json:"publisher,omitempty"`
+
+	// PublisherSignature is the signature of SigningPayload() made with the publisher's key.
+	// It is verified on unmarshal, so that publishers cannot be impersonated by peers.
+	PublisherSignature []byte `json:"publisher_signature,omitempty"`
+
+	// RemovedLabels are the labels removed from the record since its previous
+	// announcement by the announcing peer, in the namespace of the topic.
+	// Receivers delete them from their label cache, as announcements only add labels.
+	// An announcement may carry removed labels only, if all labels of its namespace were removed.
+	RemovedLabels []string `json:"removed_labels,omitempty"`
+
+	// RecordSummary is compact metadata of the record, so that search results
+	// can be rendered without pulling it. Empty fields are unknown.
+	// This becomes the types.LabelMetadata.RecordSummary field.
+	types.RecordSummary
+
+	// PublicKey is the marshalled libp2p public key of the announcing peer.
+	// Only Ed25519 keys are accepted.
+	PublicKey []byte `json:"public_key,omitempty"`
+
+	// Signature is the signature of SigningPayload() made with the
+	// announcing peer's identity key.
+	Signature []byte `json:"signature,omitempty"`
 }
 
 // Validate checks if the event is well-formed and safe to process.
 // This prevents malformed or malicious events from being processed.
 //
 // Note: PeerID validation is intentionally omitted as it's provided
-// separately by the authenticated libp2p transport layer (msg.ReceivedFrom).
+// separately by the authenticated originator of the GossipSub message (msg.GetFrom).
 func (e *RecordPublishEvent) Validate() error {
 	if e.CID == "" {
-		return errors.New("missing CID")
+		return routingerr.ErrCIDInvalid.Errorf("missing CID")
 	}
 
-	if len(e.Labels) == 0 {
+	if len(e.Labels) == 0 && len(e.RemovedLabels) == 0 {
 		return errors.New("no labels provided")
 	}
 
@@ -90,11 +156,23 @@ func (e *RecordPublishEvent) Validate() error {
 		return errors.New("too many labels")
 	}
 
+	if len(e.RemovedLabels) > MaxLabelsPerAnnouncement {
+		return errors.New("too many removed labels")
+	}
+
 	if e.Timestamp.IsZero() {
 		return errors.New("missing timestamp")
 	}
 
-	return nil
+	if e.Supersedes == e.CID {
+		return errors.New("record supersedes itself")
+	}
+
+	if e.Publisher == "" && len(e.PublisherSignature) > 0 {
+		return errors.New("publisher signature without publisher")
+	}
+
+	return e.RecordSummary.Validate() //nolint:wrapcheck
 }
 
 // Marshal serializes the event to JSON for network transmission.
@@ -130,5 +208,196 @@ func UnmarshalRecordPublishEvent(data []byte) (*RecordPublishEvent, error) {
 		return nil, err
 	}
 
+	// Reject events carrying an invalid signature.
+	// Unsigned events are accepted here; the Manager decides whether to require them.
+	if event.IsSigned() {
+		if err := event.Verify(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Publishers are always verified, as search results are attributed to them
+	if event.Publisher != "" {
+		if err := event.VerifyPublisher(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &event, nil
 }
+
+// RecordPublishBatch is the wire format for several record publication
+// announcements coalesced into a single GossipSub message.
+// It is used by bulk publishing to reduce per-message overhead.
+// Each event is validated and signed individually.
+//
+// Example wire format:
+//
+//	{
+//	  "events": [
+//	    {"cid": "bafy...1", "labels": ["/skills/AI/ML"], "timestamp": "2025-10-01T10:00:00Z"},
+//	    {"cid": "bafy...2", "labels": ["/skills/AI/NLP"], "timestamp": "2025-10-01T10:00:00Z"}
+//	  ]
+//	}
+type RecordPublishBatch struct {
+	// Events are the coalesced record publish events.
+	Events []*RecordPublishEvent `json:"events"`
+}
+
+// Validate checks if the batch and all of its events are well-formed.
+func (b *RecordPublishBatch) Validate() error {
+	return b.validate(MaxEventsPerBatch)
+}
+
+// validate checks the batch and its events, allowing up to maxEvents events.
+func (b *RecordPublishBatch) validate(maxEvents int) error {
+	if len(b.Events) == 0 {
+		return errors.New("empty batch")
+	}
+
+	if len(b.Events) > maxEvents {
+		return errors.New("too many events in batch")
+	}
+
+	for i, event := range b.Events {
+		if event == nil {
+			return fmt.Errorf("event %d: missing event", i)
+		}
+
+		if err := event.Validate(); err != nil {
+			return fmt.Errorf("event %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Marshal serializes the batch to JSON for network transmission.
+func (b *RecordPublishBatch) Marshal() ([]byte, error) {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record publish batch: %w", err)
+	}
+
+	// Validate size to prevent oversized messages
+	if len(data) > MaxMessageSize {
+		return nil, errors.New("batch exceeds maximum size")
+	}
+
+	return data, nil
+}
+
+// UnmarshalAnnouncements deserializes either a single record publish event
+// or a batch of events, in any wire format, validating each event and its
+// signature (if present). Compressed batches are decompressed transparently.
+// This is the entry point for processing received GossipSub messages.
+func UnmarshalAnnouncements(data []byte) ([]*RecordPublishEvent, error) {
+	// Check size before unmarshaling to prevent resource exhaustion
+	if len(data) > MaxMessageSize {
+		return nil, errors.New("event exceeds maximum size")
+	}
+
+	if len(data) > 0 && data[0] == WireVersionProtobuf {
+		return unmarshalProtobufAnnouncements(data[1:])
+	}
+
+	if len(data) > 0 && data[0] == WireVersionZstd {
+		return unmarshalCompressedAnnouncements(data[1:])
+	}
+
+	var probe struct {
+		Events json.RawMessage `json:"events"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal announcement: %w", err)
+	}
+
+	// Single event
+	if probe.Events == nil {
+		event, err := UnmarshalRecordPublishEvent(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*RecordPublishEvent{event}, nil
+	}
+
+	var batch RecordPublishBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal record publish batch: %w", err)
+	}
+
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := verifyEvents(batch.Events); err != nil {
+		return nil, err
+	}
+
+	return batch.Events, nil
+}
+
+// verifyEvents rejects batched events carrying an invalid signature or publisher signature.
+func verifyEvents(events []*RecordPublishEvent) error {
+	for i, event := range events {
+		if event.IsSigned() {
+			if err := event.Verify(); err != nil {
+				return fmt.Errorf("event %d: %w", i, err)
+			}
+		}
+
+		if event.Publisher != "" {
+			if err := event.VerifyPublisher(); err != nil {
+				return fmt.Errorf("event %d: %w", i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitIntoBatches groups events into batches that respect MaxEventsPerBatch
+// and MaxMessageSize. Events that cannot fit into a batch on their own are
+// returned as single-event batches and rejected when marshalled.
+func splitIntoBatches(events []*RecordPublishEvent) ([][]*RecordPublishEvent, error) {
+	// Size of the batch envelope: {"events":[]}
+	// Protobuf messages are smaller than JSON ones, so batches sized
+	// for JSON fit into a message in either wire format.
+	const envelopeSize = len(`{"events":[]}`)
+
+	var (
+		batches [][]*RecordPublishEvent
+		current []*RecordPublishEvent
+		size    = envelopeSize
+	)
+
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal record publish event: %w", err)
+		}
+
+		// Account for the separating comma between events
+		eventSize := len(data)
+		if len(current) > 0 {
+			eventSize++
+		}
+
+		if len(current) > 0 && (len(current) >= MaxEventsPerBatch || size+eventSize > MaxMessageSize) {
+			batches = append(batches, current)
+			current = nil
+			size = envelopeSize
+			eventSize = len(data)
+		}
+
+		current = append(current, event)
+		size += eventSize
+	}
+
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+
+	return batches, nil
+}
@@ -0,0 +1,104 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvents(tb testing.TB, count int) []*RecordPublishEvent {
+	tb.Helper()
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(tb, err)
+
+	events := make([]*RecordPublishEvent, count)
+	for i := range events {
+		events[i] = &RecordPublishEvent{
+			CID:       fmt.Sprintf("bafy%d", i),
+			Labels:    []string{"/skills/AI/ML"},
+			Timestamp: time.Now(),
+		}
+		require.NoError(tb, events[i].Sign(key))
+	}
+
+	return events
+}
+
+func TestUnmarshalAnnouncements_Batch(t *testing.T) {
+	events := newTestEvents(t, 3)
+
+	data, err := (&RecordPublishBatch{Events: events}).Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalAnnouncements(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, 3)
+	assert.Equal(t, "bafy2", decoded[2].CID)
+}
+
+func TestUnmarshalAnnouncements_SingleEvent(t *testing.T) {
+	data, err := newTestEvents(t, 1)[0].Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalAnnouncements(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, 1)
+}
+
+func TestRecordPublishEvent_ValidateRemovedLabels(t *testing.T) {
+	event := &RecordPublishEvent{
+		CID:           "bafy0",
+		Timestamp:     time.Now(),
+		RemovedLabels: []string{"/skills/AI/NLP"},
+	}
+
+	// Announcements may carry removed labels only
+	require.NoError(t, event.Validate())
+
+	event.RemovedLabels = nil
+	require.Error(t, event.Validate())
+
+	event.RemovedLabels = make([]string, MaxLabelsPerAnnouncement+1)
+	assert.Error(t, event.Validate())
+}
+
+func TestUnmarshalAnnouncements_TamperedBatchRejected(t *testing.T) {
+	events := newTestEvents(t, 2)
+	events[1].Labels = []string{"/skills/spoofed"}
+
+	data, err := (&RecordPublishBatch{Events: events}).Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalAnnouncements(data)
+	assert.Error(t, err)
+}
+
+func TestSplitIntoBatches(t *testing.T) {
+	events := newTestEvents(t, 2*MaxEventsPerBatch+1)
+
+	batches, err := splitIntoBatches(events)
+	require.NoError(t, err)
+
+	total := 0
+
+	for _, batch := range batches {
+		assert.LessOrEqual(t, len(batch), MaxEventsPerBatch)
+
+		data, err := (&RecordPublishBatch{Events: batch}).Marshal()
+		require.NoError(t, err, "batch must fit into a single message")
+		assert.LessOrEqual(t, len(data), MaxMessageSize)
+
+		total += len(batch)
+	}
+
+	assert.Equal(t, len(events), total)
+}
@@ -9,14 +9,28 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/continuity"
 	"github.com/agntcy/dir/server/routing/internal/p2p"
+	"github.com/agntcy/dir/server/routing/labeldigest"
+	"github.com/agntcy/dir/server/routing/ratelimit"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/agntcy/dir/server/routing/routingerr"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var logger = logging.Logger("routing/pubsub")
+var (
+	logger = logging.Logger("routing/pubsub")
+	tracer = otel.Tracer("github.com/agntcy/dir/server/routing/pubsub")
+)
 
 // Manager handles GossipSub operations for label announcements.
 // It provides efficient label propagation across the network without
@@ -35,76 +49,380 @@ type Manager struct {
 	ctx         context.Context //nolint:containedctx // Needed for long-running message handler goroutine
 	host        host.Host
 	pubsub      *pubsub.PubSub
-	topic       *pubsub.Topic
-	sub         *pubsub.Subscription
 	localPeerID string
-	topicName   string // Topic name (protocol constant)
+
+	// Namespace topics. All namespaces are joined so that every record can be
+	// published, but only namespaces allowed by the subscription policy are subscribed.
+	topics map[types.LabelType]*pubsub.Topic
+	subs   map[types.LabelType]*pubsub.Subscription
+
+	// Revocation topic, joined and subscribed by every peer
+	revocationTopic *pubsub.Topic
+	revocationSub   *pubsub.Subscription
+
+	// Identity continuity topic, joined and subscribed by every peer
+	continuityTopic *pubsub.Topic
+	continuitySub   *pubsub.Subscription
+
+	// Label digest topic, joined and subscribed by every peer
+	digestTopic *pubsub.Topic
+	digestSub   *pubsub.Subscription
+
+	// Propagation ack topic, joined and subscribed only with propagation acks enabled
+	ackTopic *pubsub.Topic
+	ackSub   *pubsub.Subscription
+
+	// Identity key used to sign outgoing announcements (nil if unavailable or not Ed25519)
+	signingKey crypto.PrivKey
+
+	// When true, unsigned announcements and announcements signed by
+	// a peer other than the originator are dropped
+	requireSignatures bool
+
+	// IDs of processed announcements per namespace topic, to drop replays.
+	// A record's announcements on different namespace topics share their ID.
+	seen *seenCache
+
+	// Observer of announcement validity per forwarding peer (optional)
+	onAnnouncement func(peer.ID, bool)
+
+	// Provider of the expiration of local records announced by this peer (optional)
+	recordExpiration func(string) time.Time
+
+	// Provider of the superseded CIDs of local records announced by this peer (optional)
+	recordSupersedes func(string) string
+
+	// Provider of the access gates of local records announced by this peer (optional)
+	recordAccessGated func(string) bool
+
+	// Provider of the content sizes of local records announced by this peer (optional)
+	recordSize func(string) uint64
+
+	// Provider of the summaries of local records announced by this peer (optional)
+	recordSummary func(string) types.RecordSummary
+
+	// Provider of the tenants of local records announced by this peer (optional)
+	recordTenant func(string) string
+
+	// Provider of the publisher keys of local records announced by this peer (optional)
+	recordPublisher func(string) crypto.PrivKey
+
+	// Tracker of the labels removed from local records announced by this peer (optional)
+	removedLabels func(context.Context, string, []string) []string
+
+	// Callback invoked with the published announcements of local records (optional)
+	labelsAnnounced func(context.Context, *RecordPublishEvent)
+
+	// Filter of the labels local records announced by this peer are published with (optional)
+	publishedLabels func(context.Context, string, []types.Label) ([]types.Label, error)
+
+	// Encoding of published announcements
+	wireFormat string
+
+	// Aggregator of individually published announcements (nil if disabled)
+	batcher *batcher
+
+	// Per-peer rate limit of received announcement messages (nil if disabled)
+	rateLimiter *ratelimit.Limiter
+
+	// Mesh peers of each topic, tracked for routing introspection
+	mesh *meshTracer
+
+	// Message counts of each topic, tracked for routing diagnostics
+	messages *messageTracer
+
+	// Peer scores last captured by GossipSub (nil if peer scoring is disabled)
+	scores *scoreInspector
+
+	// Propagation latency of published announcements (nil if propagation acks are disabled)
+	propagation *propagationTracker
 
 	// Callback invoked when record publish event is received.
 	// Parameters:
 	//   - context.Context: Operation context
-	//   - string: Authenticated peer ID (from msg.ReceivedFrom, cryptographically verified)
+	//   - string: Originating peer ID (from msg.GetFrom, verified by the message signature)
 	//   - *RecordPublishEvent: The announcement payload
 	onRecordPublishEvent func(context.Context, string, *RecordPublishEvent)
+
+	// Callback invoked when a signed record revocation is received.
+	// The peer ID is the verified signer of the revocation.
+	onRecordRevocation func(context.Context, peer.ID, *revocation.Revocation)
+
+	// Callback invoked when a continuity record of an identity rotation is received.
+	onContinuity func(context.Context, *continuity.Record)
+
+	// Callback invoked when a label digest is received.
+	// The peer ID is the authenticated originator of the digest.
+	onLabelDigest func(context.Context, peer.ID, *labeldigest.Digest)
+
+	// Callback invoked with the announcements of this peer once published.
+	onAnnouncementsPublished func([]*RecordPublishEvent)
+}
+
+// Options configures the local behaviour of the GossipSub manager.
+// None of these options affect the wire protocol, only WireFormat selects
+// one of the wire formats accepted by all current peers.
+type Options struct {
+	// Namespaces lists the label namespaces to subscribe to.
+	// Empty subscribes to all namespaces.
+	Namespaces []types.LabelType
+
+	// RequireSignatures drops announcements that are not signed by their originator.
+	RequireSignatures bool
+
+	// PeerScore returns the application-specific score of a peer.
+	// When set, GossipSub peer scoring is enabled so that peers with a
+	// negative score are excluded from gossip and eventually graylisted.
+	PeerScore func(peer.ID) float64
+
+	// OnAnnouncement is invoked for every announcement received from a peer,
+	// reporting whether it passed validation.
+	OnAnnouncement func(from peer.ID, valid bool)
+
+	// RecordExpiration returns when the TTL of a local record elapses,
+	// or the zero time if it does not expire. When set, the expiration
+	// is included in the record's announcements.
+	RecordExpiration func(cid string) time.Time
+
+	// RecordSupersedes returns the CID of the previous version of a local record,
+	// or an empty string if it does not supersede another one. When set, the
+	// superseded CID is included in the record's announcements.
+	RecordSupersedes func(cid string) string
+
+	// RecordAccessGated reports whether a local record is access-gated.
+	// When set, the gate is included in the record's announcements.
+	RecordAccessGated func(cid string) bool
+
+	// RecordSize returns the size of the canonical content of a local record, zero if unknown.
+	// When set, known sizes are included in the record's announcements.
+	RecordSize func(cid string) uint64
+
+	// RecordSummary returns the summary of a local record, empty if unknown.
+	// When set, known summaries are included in the record's announcements.
+	RecordSummary func(cid string) types.RecordSummary
+
+	// RecordTenant returns the tenant a local record was published for, empty if untenanted.
+	// When set, tenants are included in the record's announcements.
+	RecordTenant func(cid string) string
+
+	// RecordPublisher returns the key of the publisher a local record was published as,
+	// nil if it was published as this peer. When set, publishers are included in the
+	// record's announcements, which are also signed with their keys.
+	RecordPublisher func(cid string) crypto.PrivKey
+
+	// RemovedLabels returns the labels removed from a local record since its last published
+	// announcement. When set, removed labels are included in the record's announcements,
+	// so that receivers delete them from their label cache.
+	RemovedLabels func(ctx context.Context, cid string, labels []string) []string
+
+	// LabelsAnnounced records the labels and removed labels of an announcement of a local
+	// record once it was published, so that removals of announcements that failed to
+	// publish are announced again with the next announcement of the record.
+	LabelsAnnounced func(ctx context.Context, event *RecordPublishEvent)
+
+	// PublishedLabels returns the labels of a local record it may be announced with under
+	// its publish policy. Records whose policy cannot be read are not announced.
+	// Nil announces all labels of records.
+	PublishedLabels func(ctx context.Context, cid string, labels []types.Label) ([]types.Label, error)
+
+	// RateLimiter limits the announcement messages accepted per originating peer.
+	// Nil accepts all messages.
+	RateLimiter *ratelimit.Limiter
+
+	// WireFormat is the encoding of published announcements, WireFormatJSON or
+	// WireFormatProtobuf or WireFormatZstd. Empty publishes JSON.
+	WireFormat string
+
+	// BatchWindow is how long the announcements of individually published
+	// records are aggregated before they are published as batch messages.
+	// Zero publishes every announcement right away.
+	BatchWindow time.Duration
+
+	// PropagationAcks joins the propagation ack topic, acknowledging received announcements
+	// to their originator and measuring the propagation latency of published ones.
+	// It adds a message per received announcement and is meant for debugging.
+	PropagationAcks bool
 }
 
 // New creates a new GossipSub manager for label announcements.
-// This initializes the GossipSub router, joins the labels topic, and
-// starts the message handler goroutine.
+// This initializes the GossipSub router, joins one topic per label namespace,
+// subscribes to the namespaces selected by the subscription policy, and
+// starts a message handler goroutine per subscription.
 //
-// Protocol parameters (TopicLabels, MaxMessageSize) are defined in constants.go
+// Protocol parameters (topic names, MaxMessageSize) are defined in constants.go
 // and are intentionally NOT configurable to ensure network-wide compatibility.
 //
 // Parameters:
 //   - ctx: Context for lifecycle management
 //   - h: libp2p host for network operations
+//   - opts: Subscription policy and signature requirements
 //
 // Returns:
 //   - *Manager: Initialized manager ready for use
 //   - error: If GossipSub setup fails
-func New(ctx context.Context, h host.Host) (*Manager, error) {
+func New(ctx context.Context, h host.Host, opts Options) (*Manager, error) {
+	switch opts.WireFormat {
+	case "", WireFormatJSON, WireFormatProtobuf, WireFormatZstd:
+	default:
+		return nil, fmt.Errorf("unsupported announcement wire format %q", opts.WireFormat)
+	}
+
 	// Create GossipSub with protocol-defined settings
-	ps, err := pubsub.NewGossipSub(
-		ctx,
-		h,
+	psOpts := []pubsub.Option{
 		// Enable peer exchange for better peer discovery
 		pubsub.WithPeerExchange(true),
 		// Limit message size to protocol-defined maximum
 		pubsub.WithMaxMessageSize(MaxMessageSize),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create gossipsub: %w", err)
 	}
 
-	// Join the protocol-defined topic
-	topic, err := ps.Join(TopicLabels)
-	if err != nil {
-		return nil, fmt.Errorf("failed to join labels topic %q: %w", TopicLabels, err)
+	// Track the mesh of each topic, which GossipSub does not expose
+	mesh := newMeshTracer()
+	psOpts = append(psOpts, pubsub.WithRawTracer(mesh))
+
+	// Count the delivered, duplicate and invalid messages of each topic
+	messages := newMessageTracer(h.ID())
+	psOpts = append(psOpts, pubsub.WithRawTracer(messages))
+
+	// Score peers by their application-level reputation, capturing the scores for diagnostics
+	var scores *scoreInspector
+
+	if opts.PeerScore != nil {
+		scores = &scoreInspector{}
+		psOpts = append(psOpts,
+			peerScoreOption(opts.PeerScore),
+			pubsub.WithPeerScoreInspect(pubsub.ExtendedPeerScoreInspectFn(scores.inspect), PeerScoreInspectPeriod))
 	}
 
-	// Subscribe to receive label announcements
-	sub, err := topic.Subscribe()
+	ps, err := pubsub.NewGossipSub(ctx, h, psOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to subscribe to labels topic %q: %w", TopicLabels, err)
+		return nil, fmt.Errorf("failed to create gossipsub: %w", err)
 	}
 
 	manager := &Manager{
 		ctx:         ctx,
 		host:        h,
 		pubsub:      ps,
-		topic:       topic,
-		sub:         sub,
 		localPeerID: h.ID().String(),
-		topicName:   TopicLabels,
+		topics:      make(map[types.LabelType]*pubsub.Topic),
+		subs:        make(map[types.LabelType]*pubsub.Subscription),
+		seen:        newSeenCache(MaxAnnouncementAge+MaxAnnouncementClockSkew, MaxSeenAnnouncements),
+
+		requireSignatures: opts.RequireSignatures,
+		onAnnouncement:    opts.OnAnnouncement,
+		recordExpiration:  opts.RecordExpiration,
+		recordSupersedes:  opts.RecordSupersedes,
+		recordAccessGated: opts.RecordAccessGated,
+		recordSize:        opts.RecordSize,
+		recordSummary:     opts.RecordSummary,
+		recordTenant:      opts.RecordTenant,
+		recordPublisher:   opts.RecordPublisher,
+		removedLabels:     opts.RemovedLabels,
+		labelsAnnounced:   opts.LabelsAnnounced,
+		publishedLabels:   opts.PublishedLabels,
+		wireFormat:        opts.WireFormat,
+		rateLimiter:       opts.RateLimiter,
+		mesh:              mesh,
+		messages:          messages,
+		scores:            scores,
+	}
+
+	// Aggregate individually published announcements into batches
+	if opts.BatchWindow > 0 {
+		manager.batcher = newBatcher(opts.BatchWindow, manager.publishEvents)
+	}
+
+	// Join all namespace topics (required for publishing)
+	for _, labelType := range types.AllLabelTypes() {
+		topicName := NamespaceTopic(labelType)
+
+		topic, err := ps.Join(topicName)
+		if err != nil {
+			_ = manager.Close()
+
+			return nil, fmt.Errorf("failed to join labels topic %q: %w", topicName, err)
+		}
+
+		manager.topics[labelType] = topic
+	}
+
+	// Subscribe only to the namespaces selected by the subscription policy
+	for _, labelType := range subscribedNamespaces(opts.Namespaces) {
+		sub, err := manager.topics[labelType].Subscribe()
+		if err != nil {
+			_ = manager.Close()
+
+			return nil, fmt.Errorf("failed to subscribe to labels topic %q: %w", NamespaceTopic(labelType), err)
+		}
+
+		manager.subs[labelType] = sub
 	}
 
-	// Start message handler goroutine
-	go manager.handleMessages()
+	// Join and subscribe to the revocation topic
+	if err := manager.joinRevocations(); err != nil {
+		_ = manager.Close()
+
+		return nil, err
+	}
+
+	// Join and subscribe to the identity continuity topic
+	if err := manager.joinContinuity(); err != nil {
+		_ = manager.Close()
+
+		return nil, err
+	}
+
+	// Join and subscribe to the label digest topic
+	if err := manager.joinLabelDigests(); err != nil {
+		_ = manager.Close()
+
+		return nil, err
+	}
+
+	// Join and subscribe to the propagation ack topic
+	if opts.PropagationAcks {
+		manager.propagation = newPropagationTracker()
+
+		if err := manager.joinPropagationAcks(); err != nil {
+			_ = manager.Close()
+
+			return nil, err
+		}
+	}
+
+	// Sign outgoing announcements with the host identity key
+	if key := h.Peerstore().PrivKey(h.ID()); key != nil && key.Type() == crypto.Ed25519 {
+		manager.signingKey = key
+	} else {
+		logger.Warn("Host identity key is not Ed25519, announcements will not be signed")
+	}
+
+	// Start one message handler goroutine per subscription
+	subscribed := make([]string, 0, len(manager.subs))
+	for labelType, sub := range manager.subs {
+		go manager.handleMessages(labelType, sub)
+
+		subscribed = append(subscribed, NamespaceTopic(labelType))
+	}
+
+	go manager.handleRevocations(manager.revocationSub)
+
+	go manager.handleContinuity(manager.continuitySub)
+
+	go manager.handleLabelDigests(manager.digestSub)
+
+	if manager.ackSub != nil {
+		go manager.handlePropagationAcks(manager.ackSub)
+	}
 
 	logger.Info("GossipSub manager initialized",
-		"topic", TopicLabels,
+		"subscribedTopics", subscribed,
 		"maxMessageSize", MaxMessageSize,
-		"peerID", manager.localPeerID)
+		"peerID", manager.localPeerID,
+		"requireSignatures", opts.RequireSignatures,
+		"peerScoring", opts.PeerScore != nil,
+		"wireFormat", opts.WireFormat,
+		"batchWindow", opts.BatchWindow,
+		"propagationAcks", opts.PropagationAcks)
 
 	return manager, nil
 }
@@ -115,19 +433,21 @@ func New(ctx context.Context, h host.Host) (*Manager, error) {
 //
 // Flow:
 //  1. Extract CID and labels from record
-//  2. Convert types.Label to wire format ([]string)
-//  3. Create and validate RecordPublishEvent
-//  4. Publish to GossipSub topic
-//  5. GossipSub mesh propagates to all subscribed peers
+//  2. Group labels by namespace and convert to wire format ([]string)
+//  3. Create, validate and sign one RecordPublishEvent per namespace
+//  4. Publish each event to its namespace topic
+//  5. GossipSub mesh propagates to all peers subscribed to that namespace
 //
 // Parameters:
 //   - ctx: Context for operation timeout/cancellation
 //   - record: The record interface (caller must wrap concrete types with adapter)
 //
 // Returns:
-//   - error: If validation or publishing fails
+//   - error: If validation or publishing fails for any namespace
 //
 // Note: This is non-blocking. GossipSub handles propagation asynchronously.
+// With a batch window, announcements are queued and published with the next
+// batch, so publishing failures are logged instead of returned.
 func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error {
 	if record == nil {
 		return errors.New("record is nil")
@@ -136,64 +456,417 @@ func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error
 	// Extract CID from record
 	cid := record.GetCid()
 	if cid == "" {
-		return errors.New("record has no CID")
+		return routingerr.ErrCIDInvalid.Errorf("record has no CID")
 	}
 
 	// Extract labels from record (uses shared label extraction logic)
-	labelList := types.GetLabelsFromRecord(record)
-	if len(labelList) == 0 {
+	labelList, err := m.recordLabels(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	// Group labels by namespace, each namespace is announced on its own topic
+	labelsByNamespace := m.groupLabelsByNamespace(cid, labelList)
+	removedByNamespace := m.groupRemovedLabels(ctx, cid, labelsByNamespace)
+
+	if len(labelList) == 0 && len(removedByNamespace) == 0 {
 		// No labels to publish (not an error, just nothing to do)
 		logger.Debug("Record has no labels, skipping GossipSub announcement", "cid", cid)
 
 		return nil
 	}
 
-	// Convert types.Label to strings for wire format
-	labelStrings := make([]string, len(labelList))
-	for i, label := range labelList {
-		labelStrings[i] = label.String()
+	ctx, span := tracer.Start(ctx, "pubsub.PublishRecord", trace.WithAttributes(
+		attribute.String("cid", cid),
+		attribute.Int("labels", len(labelList))))
+	defer span.End()
+
+	// Use the same timestamp for all namespace announcements of this record
+	timestamp := time.Now()
+
+	var errs []error
+
+	for _, labelType := range types.AllLabelTypes() {
+		labelStrings, removed := labelsByNamespace[labelType], removedByNamespace[labelType]
+		if len(labelStrings) == 0 && len(removed) == 0 {
+			continue
+		}
+
+		var err error
+		if m.batcher != nil {
+			err = m.queueNamespace(ctx, labelType, cid, labelStrings, removed, timestamp)
+		} else {
+			err = m.publishNamespace(ctx, labelType, cid, labelStrings, removed, timestamp)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PublishRecords announces the labels of many records at once.
+// Instead of one message per record and namespace, announcements are
+// coalesced into RecordPublishBatch messages per namespace topic, each
+// bounded by MaxEventsPerBatch and MaxMessageSize.
+//
+// Records without CID or labels are skipped. Returns the joined errors
+// of all batches that could not be built or published.
+func (m *Manager) PublishRecords(ctx context.Context, records []types.Record) error {
+	ctx, span := tracer.Start(ctx, "pubsub.PublishRecords", trace.WithAttributes(attribute.Int("records", len(records))))
+	defer span.End()
+
+	timestamp := time.Now()
+	eventsByNamespace := make(map[types.LabelType][]*RecordPublishEvent)
+	removalsByNamespace := make(map[types.LabelType][]*RecordPublishEvent)
+
+	var errs []error
+
+	for _, record := range records {
+		if record == nil || record.GetCid() == "" {
+			continue
+		}
+
+		cid := record.GetCid()
+
+		labelList, err := m.recordLabels(ctx, record)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		labelsByNamespace := m.groupLabelsByNamespace(cid, labelList)
+		removedByNamespace := m.groupRemovedLabels(ctx, cid, labelsByNamespace)
+
+		for _, labelType := range types.AllLabelTypes() {
+			labelStrings, removed := labelsByNamespace[labelType], removedByNamespace[labelType]
+			if len(labelStrings) == 0 && len(removed) == 0 {
+				continue
+			}
+
+			event, err := m.newEvent(labelType, cid, labelStrings, removed, timestamp)
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+
+			if len(labelStrings) == 0 {
+				removalsByNamespace[labelType] = append(removalsByNamespace[labelType], event)
+
+				continue
+			}
+
+			eventsByNamespace[labelType] = append(eventsByNamespace[labelType], event)
+		}
+	}
+
+	for _, labelType := range types.AllLabelTypes() {
+		if events, ok := eventsByNamespace[labelType]; ok {
+			if err := m.publishEvents(ctx, labelType, events); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		// Removal-only announcements are rejected by peers predating removed labels,
+		// so they are published on their own instead of failing whole batches
+		for _, event := range removalsByNamespace[labelType] {
+			if err := m.publishEvents(ctx, labelType, []*RecordPublishEvent{event}); err != nil {
+				errs = append(errs, err)
+			}
+		}
 	}
 
-	// Create announcement with current timestamp
+	return errors.Join(errs...)
+}
+
+// PublishRemovedLabels announces only the labels removed from local records since their
+// last announcement, for records whose labels are not announced via GossipSub, such as
+// low-priority records, so that receivers still delete the removed labels from their cache.
+// Records without removed labels are skipped. Returns the joined errors of all announcements
+// that could not be published.
+func (m *Manager) PublishRemovedLabels(ctx context.Context, records []types.Record) error {
+	timestamp := time.Now()
+
+	var errs []error
+
+	for _, record := range records {
+		if record == nil || record.GetCid() == "" {
+			continue
+		}
+
+		cid := record.GetCid()
+
+		labelList, err := m.recordLabels(ctx, record)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		removedByNamespace := m.groupRemovedLabels(ctx, cid, m.groupLabelsByNamespace(cid, labelList))
+
+		for _, labelType := range types.AllLabelTypes() {
+			removed := removedByNamespace[labelType]
+			if len(removed) == 0 {
+				continue
+			}
+
+			// Removal-only announcements are never batched, see PublishRecords
+			if err := m.publishNamespace(ctx, labelType, cid, nil, removed, timestamp); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// recordLabels returns the labels a local record is announced with.
+func (m *Manager) recordLabels(ctx context.Context, record types.Record) ([]types.Label, error) {
+	labels := types.GetLabelsFromRecord(record)
+	if m.publishedLabels == nil {
+		return labels, nil
+	}
+
+	return m.publishedLabels(ctx, record.GetCid(), labels)
+}
+
+// groupLabelsByNamespace converts labels to wire format grouped by namespace,
+// skipping labels of namespaces without a topic.
+func (m *Manager) groupLabelsByNamespace(cid string, labelList []types.Label) map[types.LabelType][]string {
+	labelsByNamespace := make(map[types.LabelType][]string)
+
+	for _, label := range labelList {
+		labelType := label.Type()
+		if _, ok := m.topics[labelType]; !ok {
+			logger.Debug("Skipping label with unknown namespace", "cid", cid, "label", label)
+
+			continue
+		}
+
+		labelsByNamespace[labelType] = append(labelsByNamespace[labelType], label.String())
+	}
+
+	return labelsByNamespace
+}
+
+// groupRemovedLabels returns the labels removed from a record since its last published
+// announcement grouped by namespace, skipping namespaces without a topic.
+// Returns nil if removed labels are not tracked.
+func (m *Manager) groupRemovedLabels(ctx context.Context, cid string, labelsByNamespace map[types.LabelType][]string) map[types.LabelType][]string {
+	if m.removedLabels == nil {
+		return nil
+	}
+
+	var labelStrings []string
+	for _, labelType := range types.AllLabelTypes() {
+		labelStrings = append(labelStrings, labelsByNamespace[labelType]...)
+	}
+
+	removedByNamespace := make(map[types.LabelType][]string)
+
+	for _, label := range m.removedLabels(ctx, cid, labelStrings) {
+		labelType := types.Label(label).Type()
+		if _, ok := m.topics[labelType]; !ok {
+			continue
+		}
+
+		removedByNamespace[labelType] = append(removedByNamespace[labelType], label)
+	}
+
+	return removedByNamespace
+}
+
+// newEvent creates, validates and signs the announcement for the labels
+// of a single namespace and the labels removed from it.
+func (m *Manager) newEvent(labelType types.LabelType, cid string, labelStrings, removed []string, timestamp time.Time) (*RecordPublishEvent, error) {
 	// Note: PeerID is not included in the wire format - recipients use
-	// the authenticated msg.ReceivedFrom from libp2p transport layer
+	// the originator of the message, authenticated by its signature
 	announcement := &RecordPublishEvent{
-		CID:       cid,
-		Labels:    labelStrings,
-		Timestamp: time.Now(),
+		CID:           cid,
+		Labels:        labelStrings,
+		Timestamp:     timestamp,
+		RemovedLabels: removed,
+	}
+
+	if m.recordExpiration != nil {
+		announcement.ExpiresAt = m.recordExpiration(cid)
+	}
+
+	if m.recordSupersedes != nil {
+		announcement.Supersedes = m.recordSupersedes(cid)
+	}
+
+	if m.recordAccessGated != nil {
+		announcement.AccessGated = m.recordAccessGated(cid)
+	}
+
+	if m.recordSize != nil {
+		announcement.Size = m.recordSize(cid)
+	}
+
+	if m.recordSummary != nil {
+		announcement.RecordSummary = m.recordSummary(cid)
+	}
+
+	if m.recordTenant != nil {
+		announcement.Tenant = m.recordTenant(cid)
 	}
 
 	// Validate before publishing to catch issues early
 	if err := announcement.Validate(); err != nil {
-		return fmt.Errorf("invalid announcement: %w", err)
+		return nil, fmt.Errorf("invalid %s announcement for %s: %w", labelType, cid, err)
+	}
+
+	// Sign with the publisher's key first, as the publisher is covered by the peer's signature
+	if m.recordPublisher != nil {
+		if key := m.recordPublisher(cid); key != nil {
+			if err := announcement.SignAsPublisher(key); err != nil {
+				return nil, fmt.Errorf("failed to sign %s announcement for %s: %w", labelType, cid, err)
+			}
+		}
 	}
 
-	// Serialize to JSON
-	data, err := announcement.Marshal()
+	// Sign with our identity key so receivers can attribute labels to us
+	if m.signingKey != nil {
+		if err := announcement.Sign(m.signingKey); err != nil {
+			return nil, fmt.Errorf("failed to sign %s announcement for %s: %w", labelType, cid, err)
+		}
+	}
+
+	return announcement, nil
+}
+
+// publishNamespace creates, signs and publishes the announcement for the labels
+// of a single namespace on that namespace's topic.
+func (m *Manager) publishNamespace(ctx context.Context, labelType types.LabelType, cid string, labelStrings, removed []string, timestamp time.Time) error {
+	announcement, err := m.newEvent(labelType, cid, labelStrings, removed, timestamp)
+	if err != nil {
+		return err
+	}
+
+	data, err := MarshalAnnouncements([]*RecordPublishEvent{announcement}, m.wireFormat)
 	if err != nil {
-		return fmt.Errorf("failed to marshal announcement: %w", err)
+		return fmt.Errorf("failed to marshal %s announcement: %w", labelType, err)
 	}
 
-	// Publish to GossipSub topic
-	if err := m.topic.Publish(ctx, data); err != nil {
-		return fmt.Errorf("failed to publish announcement: %w", err)
+	// Publish to the namespace topic
+	topic := m.topics[labelType]
+
+	err = topic.Publish(ctx, data)
+	metrics.AnnouncementsPublished.WithLabelValues(metrics.TransportGossipSub, metrics.Result(err)).Inc()
+
+	if err != nil {
+		return fmt.Errorf("failed to publish %s announcement: %w", labelType, err)
 	}
 
+	m.propagation.published(AnnouncementID(cid, m.localPeerID, timestamp), time.Now())
+	m.recordAnnounced(ctx, []*RecordPublishEvent{announcement})
+
 	logger.Info("Published record announcement",
 		"cid", cid,
-		"labels", len(labelList),
-		"topicPeers", len(m.topic.ListPeers()),
+		"topic", topic.String(),
+		"labels", len(labelStrings),
+		"removedLabels", len(removed),
+		"topicPeers", len(topic.ListPeers()),
 		"size", len(data))
 
 	return nil
 }
 
+// queueNamespace creates and signs the announcement for the labels of a single
+// namespace and queues it for the next batch of that namespace's topic.
+// Once the batcher is closed, the announcement is published right away.
+// Removal-only announcements are never batched, see PublishRecords.
+func (m *Manager) queueNamespace(ctx context.Context, labelType types.LabelType, cid string, labelStrings, removed []string, timestamp time.Time) error {
+	announcement, err := m.newEvent(labelType, cid, labelStrings, removed, timestamp)
+	if err != nil {
+		return err
+	}
+
+	if len(labelStrings) > 0 && m.batcher.Add(labelType, announcement) {
+		return nil
+	}
+
+	return m.publishEvents(ctx, labelType, []*RecordPublishEvent{announcement})
+}
+
+// publishEvents publishes announcements on the namespace topic, coalesced into
+// batch messages of the configured wire format.
+func (m *Manager) publishEvents(ctx context.Context, labelType types.LabelType, events []*RecordPublishEvent) error {
+	batches, err := marshalBatches(events, m.wireFormat)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s announcement batch: %w", labelType, err)
+	}
+
+	var errs []error
+
+	for _, batch := range batches {
+		if err := m.publishBatch(ctx, labelType, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Await acks of the announcements, also if some batches failed to publish
+	now := time.Now()
+	for _, event := range events {
+		m.propagation.published(AnnouncementID(event.CID, m.localPeerID, event.Timestamp), now)
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	m.recordAnnounced(ctx, events)
+
+	if m.onAnnouncementsPublished != nil {
+		m.onAnnouncementsPublished(events)
+	}
+
+	return nil
+}
+
+// recordAnnounced reports the published announcements of local records, see Options.LabelsAnnounced.
+func (m *Manager) recordAnnounced(ctx context.Context, events []*RecordPublishEvent) {
+	if m.labelsAnnounced == nil {
+		return
+	}
+
+	for _, event := range events {
+		m.labelsAnnounced(ctx, event)
+	}
+}
+
+// publishBatch publishes a batch message on the namespace topic.
+func (m *Manager) publishBatch(ctx context.Context, labelType types.LabelType, batch batchMessage) error {
+	topic := m.topics[labelType]
+
+	err := topic.Publish(ctx, batch.data)
+	metrics.AnnouncementsPublished.WithLabelValues(metrics.TransportGossipSub, metrics.Result(err)).Add(float64(batch.events))
+
+	if err != nil {
+		return fmt.Errorf("failed to publish %s announcement batch: %w", labelType, err)
+	}
+
+	logger.Info("Published record announcement batch",
+		"topic", topic.String(),
+		"records", batch.events,
+		"topicPeers", len(topic.ListPeers()),
+		"size", len(batch.data))
+
+	return nil
+}
+
 // SetOnRecordPublishEvent sets the callback for received record publication events.
 // This callback is invoked for each valid announcement received from remote peers.
 //
 // The callback receives:
 //   - ctx: Operation context
-//   - authenticatedPeerID: The peer's ID from msg.ReceivedFrom (cryptographically verified)
+//   - authenticatedPeerID: The originating peer's ID from msg.GetFrom (verified by the message signature)
 //   - event: The announcement payload
 //
 // The callback should:
@@ -202,7 +875,8 @@ func (m *Manager) PublishRecord(ctx context.Context, record types.Record) error
 //   - Store labels.LabelMetadata in datastore
 //
 // Security Note: Always use authenticatedPeerID (not any ID from the event payload)
-// as it's verified by libp2p's cryptographic transport layer.
+// as it's verified by the GossipSub message signature and, for signed announcements,
+// matches their signer. Peers relaying the announcement are never credited with it.
 //
 // Example:
 //
@@ -217,36 +891,37 @@ func (m *Manager) SetOnRecordPublishEvent(fn func(context.Context, string, *Reco
 	m.onRecordPublishEvent = fn
 }
 
+// SetOnAnnouncementsPublished sets the callback for the announcements of this peer,
+// invoked once they were published on their namespace topic. Announcements
+// published together are not reported if any of their batches failed to publish.
+func (m *Manager) SetOnAnnouncementsPublished(fn func([]*RecordPublishEvent)) {
+	m.onAnnouncementsPublished = fn
+}
+
 // handleMessages is the main message processing loop.
 // It runs in a goroutine and processes all incoming label announcements.
 //
 // Flow:
 //  1. Wait for next message from subscription
 //  2. Skip own messages (already cached locally)
-//  3. Unmarshal and validate announcement
-//  4. Invoke callback for processing
+//  3. Drop messages of peers exceeding their rate limit or banned
+//  4. Unmarshal and validate announcement or batch (including signatures, if present)
+//  5. Check that all labels belong to the topic's namespace
+//  6. Check that the signer is the originating peer
+//  7. Drop stale and replayed announcements
+//  8. Invoke callback for processing
 //
 // Error handling:
 //   - Context cancellation: Normal shutdown, exit loop
 //   - Invalid messages: Log warning, continue processing
 //   - Unmarshal errors: Log warning, continue processing
 //
-// This goroutine runs for the lifetime of the Manager.
-func (m *Manager) handleMessages() {
+// One goroutine runs per subscribed namespace for the lifetime of the Manager.
+func (m *Manager) handleMessages(labelType types.LabelType, sub *pubsub.Subscription) {
 	for {
-		msg, err := m.sub.Next(m.ctx)
-		if err != nil {
-			// Check if context was cancelled (normal shutdown)
-			if m.ctx.Err() != nil {
-				logger.Debug("Message handler stopping", "reason", "context_cancelled")
-
-				return
-			}
-
-			// Log error but continue processing
-			logger.Error("Error reading from labels topic", "error", err)
-
-			continue
+		msg, ok := m.nextMessage(sub)
+		if !ok {
+			return
 		}
 
 		// Skip our own messages (we already cached labels locally)
@@ -254,41 +929,242 @@ func (m *Manager) handleMessages() {
 			continue
 		}
 
-		// Parse and validate announcement
-		announcement, err := UnmarshalRecordPublishEvent(msg.Data)
+		// Drop floods before parsing them
+		if !m.admit(msg) {
+			continue
+		}
+
+		// Parse and validate announcement(s), a message may carry a batch
+		announcements, err := UnmarshalAnnouncements(msg.Data)
 		if err != nil {
 			logger.Warn("Received invalid label announcement",
 				"from", msg.ReceivedFrom,
 				"error", err,
 				"size", len(msg.Data))
+			metrics.AnnouncementsReceived.WithLabelValues(metrics.TransportGossipSub).Inc()
+			metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectInvalid).Inc()
+			m.observeAnnouncement(msg, false)
 
 			continue
 		}
 
-		// Extract authenticated peer ID from libp2p transport layer
-		// This is cryptographically verified and cannot be spoofed
-		authenticatedPeerID := msg.ReceivedFrom.String()
+		for _, announcement := range announcements {
+			m.processAnnouncement(msg, labelType, announcement)
+		}
+	}
+}
+
+// admit applies the rate limit of the peer originating the message.
+// Peers are limited by origin rather than by the forwarding peer,
+// so that honest peers relaying a flood are not limited.
+func (m *Manager) admit(msg *pubsub.Message) bool {
+	result := m.rateLimiter.Allow(msg.GetFrom().String(), time.Now())
+
+	switch result {
+	case ratelimit.Allowed:
+		return true
+	case ratelimit.Banned:
+		metrics.RateLimited.WithLabelValues(metrics.TransportGossipSub, metrics.ResultBanned).Inc()
+	case ratelimit.Limited:
+		metrics.RateLimited.WithLabelValues(metrics.TransportGossipSub, metrics.ResultLimited).Inc()
+	}
+
+	logger.Debug("Dropped rate limited GossipSub message",
+		"from", msg.GetFrom(),
+		"forwardedBy", msg.ReceivedFrom,
+		"banned", result == ratelimit.Banned)
+
+	return false
+}
+
+// nextMessage waits for the next message of the subscription.
+// Returns false when the manager is shutting down or the subscription was cancelled.
+func (m *Manager) nextMessage(sub *pubsub.Subscription) (*pubsub.Message, bool) {
+	for {
+		msg, err := sub.Next(m.ctx)
+		if err == nil {
+			return msg, true
+		}
+
+		// Check if context was cancelled (normal shutdown)
+		if m.ctx.Err() != nil {
+			logger.Debug("Message handler stopping", "topic", sub.Topic(), "reason", "context_cancelled")
+
+			return nil, false
+		}
+
+		// Subscription was cancelled by Close
+		if errors.Is(err, pubsub.ErrSubscriptionCancelled) {
+			logger.Debug("Message handler stopping", "topic", sub.Topic(), "reason", "subscription_cancelled")
+
+			return nil, false
+		}
+
+		// Log error but continue processing
+		logger.Error("Error reading from topic", "topic", sub.Topic(), "error", err)
+	}
+}
+
+// processAnnouncement checks a single received announcement and hands it to the callback.
+func (m *Manager) processAnnouncement(msg *pubsub.Message, labelType types.LabelType, announcement *RecordPublishEvent) {
+	metrics.AnnouncementsReceived.WithLabelValues(metrics.TransportGossipSub).Inc()
+
+	// Namespace topics must only carry labels of their own namespace
+	if err := checkNamespace(announcement, labelType); err != nil {
+		logger.Warn("Rejected label announcement",
+			"from", msg.ReceivedFrom,
+			"topic", msg.GetTopic(),
+			"cid", announcement.CID,
+			"error", err)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectNamespace).Inc()
+		m.observeAnnouncement(msg, false)
+
+		return
+	}
 
-		logger.Debug("Received label announcement",
-			"from", authenticatedPeerID,
+	// Make sure the announcement was signed by the peer that originated it
+	if err := m.verifySigner(msg, announcement); err != nil {
+		logger.Warn("Rejected label announcement",
+			"from", msg.ReceivedFrom,
 			"cid", announcement.CID,
-			"labels", len(announcement.Labels))
+			"error", err)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectSignature).Inc()
+		m.observeAnnouncement(msg, false)
+
+		return
+	}
+
+	m.observeAnnouncement(msg, true)
+
+	// Credit the announcement to its originator rather than the peer relaying it. The originator
+	// is authenticated by the message signature and is the signer checked by verifySigner
+	authenticatedPeerID := msg.GetFrom().String()
+
+	// Drop replayed and excessively old announcements before they reach the datastore
+	if !m.checkReplay(msg, labelType, announcement) {
+		return
+	}
+
+	// Acknowledge the announcement to its originator, in debug mode
+	m.publishAck(msg, announcement, time.Now())
+
+	logger.Debug("Received label announcement",
+		"from", authenticatedPeerID,
+		"cid", announcement.CID,
+		"labels", len(announcement.Labels))
+
+	// Invoke callback with authenticated peer ID
+	if m.onRecordPublishEvent != nil {
+		ctx, span := tracer.Start(m.ctx, "pubsub.ReceiveAnnouncement", trace.WithAttributes(
+			attribute.String("cid", announcement.CID),
+			attribute.String("peer", authenticatedPeerID),
+			attribute.String("topic", msg.GetTopic())))
+		defer span.End()
+
+		// Pass authenticated peer ID as separate parameter for security
+		m.onRecordPublishEvent(ctx, authenticatedPeerID, announcement)
+	}
+}
+
+// checkReplay reports whether an announcement is fresh, unexpired and seen for the first time.
+// Announcements are identified by their originator and timestamp, so the same announcement
+// relayed by several peers is only processed once. Replays are not held against the
+// forwarding peer, as honest peers may relay the same announcement more than once.
+func (m *Manager) checkReplay(msg *pubsub.Message, labelType types.LabelType, announcement *RecordPublishEvent) bool {
+	now := time.Now()
+	peerID := msg.ReceivedFrom.String()
+
+	if err := checkFreshness(announcement.Timestamp, now); err != nil {
+		logger.Debug("Dropped stale label announcement",
+			"from", peerID,
+			"cid", announcement.CID,
+			"error", err)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectStale).Inc()
+
+		return false
+	}
+
+	// Records whose TTL has elapsed are no longer announced by their publisher
+	if !announcement.ExpiresAt.IsZero() && !now.Before(announcement.ExpiresAt) {
+		logger.Debug("Dropped expired label announcement",
+			"from", peerID,
+			"cid", announcement.CID,
+			"expiresAt", announcement.ExpiresAt)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectExpired).Inc()
+
+		return false
+	}
+
+	id := string(labelType) + "/" + AnnouncementID(announcement.CID, announcementOrigin(msg, announcement), announcement.Timestamp)
+	if !m.seen.Add(id, now) {
+		logger.Debug("Dropped replayed label announcement",
+			"from", peerID,
+			"cid", announcement.CID)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectReplayed).Inc()
+
+		return false
+	}
 
-		// Invoke callback with authenticated peer ID
-		if m.onRecordPublishEvent != nil {
-			// Pass authenticated peer ID as separate parameter for security
-			m.onRecordPublishEvent(m.ctx, authenticatedPeerID, announcement)
+	return true
+}
+
+// announcementOrigin returns the peer that originated an announcement: its signer if signed,
+// which verifySigner checked to be the message originator, or else the message originator.
+func announcementOrigin(msg *pubsub.Message, announcement *RecordPublishEvent) string {
+	if announcement.IsSigned() {
+		if signerID, err := announcement.SignerID(); err == nil {
+			return signerID.String()
 		}
 	}
+
+	return msg.GetFrom().String()
 }
 
-// GetTopicPeers returns the list of peers subscribed to the labels topic.
+// observeAnnouncement reports the validity of an announcement forwarded by a peer,
+// counting invalid messages towards the diagnostics of their topic.
+func (m *Manager) observeAnnouncement(msg *pubsub.Message, valid bool) {
+	if !valid {
+		m.messages.invalid(msg.GetTopic())
+	}
+
+	if m.onAnnouncement != nil {
+		m.onAnnouncement(msg.ReceivedFrom, valid)
+	}
+}
+
+// verifySigner checks that a signed announcement was signed by its originating peer,
+// and rejects unsigned announcements when signatures are required.
+// The signature itself has already been verified by UnmarshalRecordPublishEvent.
+func (m *Manager) verifySigner(msg *pubsub.Message, announcement *RecordPublishEvent) error {
+	if !announcement.IsSigned() {
+		if m.requireSignatures {
+			return errors.New("announcement is not signed")
+		}
+
+		return nil
+	}
+
+	signerID, err := announcement.SignerID()
+	if err != nil {
+		return err
+	}
+
+	originID := msg.GetFrom()
+
+	if signerID != originID {
+		return fmt.Errorf("announcement signed by %s but originated from %s", signerID, originID)
+	}
+
+	return nil
+}
+
+// GetTopicPeers returns the list of peers subscribed to any of the label topics.
 // This is useful for monitoring network connectivity and debugging.
 //
 // Returns:
-//   - []string: List of peer IDs (as strings)
+//   - []string: List of unique peer IDs (as strings)
 func (m *Manager) GetTopicPeers() []string {
-	peers := m.topic.ListPeers()
+	peers := m.listPeers()
 	peerIDs := make([]string, len(peers))
 
 	for i, p := range peers {
@@ -298,24 +1174,124 @@ func (m *Manager) GetTopicPeers() []string {
 	return peerIDs
 }
 
+// listPeers returns the unique peers across all namespace topics.
+func (m *Manager) listPeers() []peer.ID {
+	seen := make(map[peer.ID]struct{})
+
+	var peers []peer.ID
+
+	for _, topic := range m.topics {
+		for _, p := range topic.ListPeers() {
+			if _, ok := seen[p]; ok {
+				continue
+			}
+
+			seen[p] = struct{}{}
+			peers = append(peers, p)
+		}
+	}
+
+	return peers
+}
+
+// ReportMetrics updates the GossipSub mesh health metrics with the
+// current number of peers subscribed to and in the mesh of each joined topic.
+func (m *Manager) ReportMetrics() {
+	for labelType, topic := range m.topics {
+		metrics.GossipSubTopicPeers.WithLabelValues(NamespaceTopic(labelType)).Set(float64(len(topic.ListPeers())))
+	}
+
+	if m.revocationTopic != nil {
+		metrics.GossipSubTopicPeers.WithLabelValues(TopicRevocations).Set(float64(len(m.revocationTopic.ListPeers())))
+	}
+
+	if m.continuityTopic != nil {
+		metrics.GossipSubTopicPeers.WithLabelValues(TopicContinuity).Set(float64(len(m.continuityTopic.ListPeers())))
+	}
+
+	if m.digestTopic != nil {
+		metrics.GossipSubTopicPeers.WithLabelValues(TopicLabelDigests).Set(float64(len(m.digestTopic.ListPeers())))
+	}
+
+	if m.ackTopic != nil {
+		metrics.GossipSubTopicPeers.WithLabelValues(TopicPropagationAcks).Set(float64(len(m.ackTopic.ListPeers())))
+	}
+
+	for _, state := range m.TopicStates() {
+		metrics.GossipSubMeshPeers.WithLabelValues(state.Topic).Set(float64(len(state.MeshPeers)))
+	}
+}
+
 // Close stops the GossipSub manager and releases resources.
 // This should be called during shutdown to clean up gracefully.
 //
 // Flow:
-//  1. Cancel subscription (stops handleMessages goroutine)
-//  2. Leave topic
-//  3. Release resources
+//  1. Publish pending batched announcements
+//  2. Cancel subscriptions (stops handleMessages goroutines)
+//  3. Leave topics
+//  4. Release resources
 //
 // Returns:
 //   - error: If cleanup fails (rare)
 func (m *Manager) Close() error {
-	m.sub.Cancel()
+	// Publish pending announcements while the topics are still open
+	if m.batcher != nil {
+		m.batcher.Close()
+	}
 
-	if err := m.topic.Close(); err != nil {
-		return fmt.Errorf("failed to close gossipsub topic: %w", err)
+	for _, sub := range m.subs {
+		sub.Cancel()
 	}
 
-	return nil
+	if m.revocationSub != nil {
+		m.revocationSub.Cancel()
+	}
+
+	if m.continuitySub != nil {
+		m.continuitySub.Cancel()
+	}
+
+	if m.digestSub != nil {
+		m.digestSub.Cancel()
+	}
+
+	if m.ackSub != nil {
+		m.ackSub.Cancel()
+	}
+
+	var errs []error
+
+	if m.revocationTopic != nil {
+		if err := m.revocationTopic.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close gossipsub topic %q: %w", TopicRevocations, err))
+		}
+	}
+
+	if m.continuityTopic != nil {
+		if err := m.continuityTopic.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close gossipsub topic %q: %w", TopicContinuity, err))
+		}
+	}
+
+	if m.digestTopic != nil {
+		if err := m.digestTopic.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close gossipsub topic %q: %w", TopicLabelDigests, err))
+		}
+	}
+
+	if m.ackTopic != nil {
+		if err := m.ackTopic.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close gossipsub topic %q: %w", TopicPropagationAcks, err))
+		}
+	}
+
+	for labelType, topic := range m.topics {
+		if err := topic.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close gossipsub topic %q: %w", NamespaceTopic(labelType), err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
 // TagMeshPeers tags all current GossipSub mesh peers with high priority
@@ -341,7 +1317,7 @@ func (m *Manager) TagMeshPeers() {
 		return // No-op if manager or connection manager not available
 	}
 
-	peers := m.topic.ListPeers()
+	peers := m.listPeers()
 
 	if len(peers) == 0 {
 		logger.Debug("No mesh peers to tag")
@@ -356,5 +1332,5 @@ func (m *Manager) TagMeshPeers() {
 	logger.Debug("Tagged GossipSub mesh peers",
 		"count", len(peers),
 		"priority", p2p.PeerPriorityGossipSubMesh,
-		"topic", m.topicName)
+		"topics", len(m.topics))
 }
@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// relayedMessage returns a message originated by a peer and received from another one.
+func relayedMessage(topic string, origin, relay peer.ID) *pubsub.Message {
+	return &pubsub.Message{Message: &pb.Message{Topic: &topic, From: []byte(origin)}, ReceivedFrom: relay}
+}
+
+// newTestManager returns a manager processing announcements without a host,
+// reporting the peers announcements are credited to.
+func newTestManager(t *testing.T) (*Manager, *[]string) {
+	t.Helper()
+
+	var credited []string
+
+	m := &Manager{
+		ctx:      t.Context(),
+		seen:     newSeenCache(MaxAnnouncementAge+MaxAnnouncementClockSkew, MaxSeenAnnouncements),
+		messages: newMessageTracer(peer.ID("self")),
+	}
+	m.SetOnRecordPublishEvent(func(_ context.Context, peerID string, _ *RecordPublishEvent) {
+		credited = append(credited, peerID)
+	})
+
+	return m, &credited
+}
+
+func TestProcessAnnouncement_CreditsOriginator(t *testing.T) {
+	m, credited := newTestManager(t)
+
+	announcement := &RecordPublishEvent{CID: "bafy1", Labels: []string{"/skills/AI"}, Timestamp: time.Now()}
+	m.processAnnouncement(relayedMessage("skills", "origin", "relay"), types.LabelTypeSkill, announcement)
+
+	// Labels are cached for the peer that originated the announcement, not the one relaying it
+	assert.Equal(t, []string{peer.ID("origin").String()}, *credited)
+}
+
+func TestProcessAnnouncement_DropsRelayedReplays(t *testing.T) {
+	m, credited := newTestManager(t)
+
+	announcement := &RecordPublishEvent{CID: "bafy1", Labels: []string{"/skills/AI"}, Timestamp: time.Now()}
+
+	// The same announcement relayed by different peers is only processed once
+	m.processAnnouncement(relayedMessage("skills", "origin", "relay1"), types.LabelTypeSkill, announcement)
+	m.processAnnouncement(relayedMessage("skills", "origin", "relay2"), types.LabelTypeSkill, announcement)
+	assert.Len(t, *credited, 1)
+
+	// Announcements of the same record by another originator are not replays
+	m.processAnnouncement(relayedMessage("skills", "other", "relay1"), types.LabelTypeSkill, announcement)
+	assert.Len(t, *credited, 2)
+}
@@ -0,0 +1,142 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// TopicState is the state of a joined GossipSub topic, for routing introspection.
+type TopicState struct {
+	Topic      string
+	Subscribed bool      // Whether messages of the topic are received
+	Peers      []peer.ID // Peers known to be subscribed to the topic
+	MeshPeers  []peer.ID // Peers in the mesh of the topic
+}
+
+// meshTracer tracks the mesh peers of each topic from the router's graft and prune events,
+// as GossipSub does not expose its mesh. It is safe for concurrent use.
+type meshTracer struct {
+	mu     sync.Mutex
+	meshes map[string]map[peer.ID]struct{}
+}
+
+var _ pubsub.RawTracer = (*meshTracer)(nil)
+
+func newMeshTracer() *meshTracer {
+	return &meshTracer{meshes: make(map[string]map[peer.ID]struct{})}
+}
+
+// meshPeers returns the mesh peers of a topic.
+func (t *meshTracer) meshPeers(topic string) []peer.ID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	peers := make([]peer.ID, 0, len(t.meshes[topic]))
+	for p := range t.meshes[topic] {
+		peers = append(peers, p)
+	}
+
+	slices.Sort(peers)
+
+	return peers
+}
+
+func (t *meshTracer) Graft(p peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.meshes[topic] == nil {
+		t.meshes[topic] = make(map[peer.ID]struct{})
+	}
+
+	t.meshes[topic][p] = struct{}{}
+}
+
+func (t *meshTracer) Prune(p peer.ID, topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.meshes[topic], p)
+}
+
+func (t *meshTracer) RemovePeer(p peer.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, mesh := range t.meshes {
+		delete(mesh, p)
+	}
+}
+
+func (t *meshTracer) Leave(topic string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.meshes, topic)
+}
+
+// Other router events are not needed to track the mesh.
+
+func (t *meshTracer) AddPeer(peer.ID, protocol.ID)          {}
+func (t *meshTracer) Join(string)                           {}
+func (t *meshTracer) ValidateMessage(*pubsub.Message)       {}
+func (t *meshTracer) DeliverMessage(*pubsub.Message)        {}
+func (t *meshTracer) RejectMessage(*pubsub.Message, string) {}
+func (t *meshTracer) DuplicateMessage(*pubsub.Message)      {}
+func (t *meshTracer) ThrottlePeer(peer.ID)                  {}
+func (t *meshTracer) RecvRPC(*pubsub.RPC)                   {}
+func (t *meshTracer) SendRPC(*pubsub.RPC, peer.ID)          {}
+func (t *meshTracer) DropRPC(*pubsub.RPC, peer.ID)          {}
+func (t *meshTracer) UndeliverableMessage(*pubsub.Message)  {}
+
+// TopicStates returns the state of the joined topics, ordered by topic name.
+func (m *Manager) TopicStates() []TopicState {
+	states := make([]TopicState, 0, len(m.topics)+4)
+
+	for labelType, topic := range m.topics {
+		_, subscribed := m.subs[labelType]
+		states = append(states, m.topicState(topic, subscribed))
+	}
+
+	if m.revocationTopic != nil {
+		states = append(states, m.topicState(m.revocationTopic, m.revocationSub != nil))
+	}
+
+	if m.continuityTopic != nil {
+		states = append(states, m.topicState(m.continuityTopic, m.continuitySub != nil))
+	}
+
+	if m.digestTopic != nil {
+		states = append(states, m.topicState(m.digestTopic, m.digestSub != nil))
+	}
+
+	if m.ackTopic != nil {
+		states = append(states, m.topicState(m.ackTopic, m.ackSub != nil))
+	}
+
+	slices.SortFunc(states, func(a, b TopicState) int {
+		return strings.Compare(a.Topic, b.Topic)
+	})
+
+	return states
+}
+
+func (m *Manager) topicState(topic *pubsub.Topic, subscribed bool) TopicState {
+	peers := topic.ListPeers()
+	slices.Sort(peers)
+
+	return TopicState{
+		Topic:      topic.String(),
+		Subscribed: subscribed,
+		Peers:      peers,
+		MeshPeers:  m.mesh.meshPeers(topic.String()),
+	}
+}
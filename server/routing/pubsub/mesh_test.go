@@ -0,0 +1,34 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeshTracer(t *testing.T) {
+	tracer := newMeshTracer()
+
+	peerA, peerB := peer.ID("peer-a"), peer.ID("peer-b")
+
+	tracer.Graft(peerB, "skills")
+	tracer.Graft(peerA, "skills")
+	tracer.Graft(peerA, "domains")
+	assert.Equal(t, []peer.ID{peerA, peerB}, tracer.meshPeers("skills"))
+
+	tracer.Prune(peerB, "skills")
+	assert.Equal(t, []peer.ID{peerA}, tracer.meshPeers("skills"))
+
+	// A removed peer leaves the mesh of every topic
+	tracer.RemovePeer(peerA)
+	assert.Empty(t, tracer.meshPeers("skills"))
+	assert.Empty(t, tracer.meshPeers("domains"))
+
+	tracer.Graft(peerB, "domains")
+	tracer.Leave("domains")
+	assert.Empty(t, tracer.meshPeers("domains"))
+}
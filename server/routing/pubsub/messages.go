@@ -0,0 +1,178 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// batchMessage is a marshalled announcement message and the number of events it carries.
+type batchMessage struct {
+	data   []byte
+	events int
+}
+
+// MarshalAnnouncements serializes events for a single GossipSub message in the given wire format.
+// In JSON, a single event is sent as a plain RecordPublishEvent and several as a RecordPublishBatch.
+// In protobuf, events are sent as routingv1.LabelAnnouncements prefixed with WireVersionProtobuf.
+// In zstd, they are additionally compressed and prefixed with WireVersionZstd instead.
+func MarshalAnnouncements(events []*RecordPublishEvent, wireFormat string) ([]byte, error) {
+	switch wireFormat {
+	case WireFormatJSON, "":
+		if len(events) == 1 {
+			return events[0].Marshal()
+		}
+
+		return (&RecordPublishBatch{Events: events}).Marshal()
+	case WireFormatProtobuf:
+		return marshalProtobufAnnouncements(events)
+	case WireFormatZstd:
+		return marshalCompressedAnnouncements(events)
+	default:
+		return nil, fmt.Errorf("unsupported announcement wire format %q", wireFormat)
+	}
+}
+
+// marshalBatches serializes events into batch messages of the given wire format.
+// Compressed batches are split by their compressed size, other batches as by splitIntoBatches.
+func marshalBatches(events []*RecordPublishEvent, wireFormat string) ([]batchMessage, error) {
+	if wireFormat == WireFormatZstd {
+		return marshalCompressedBatches(events)
+	}
+
+	batches, err := splitIntoBatches(events)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]batchMessage, 0, len(batches))
+
+	for _, batch := range batches {
+		data, err := MarshalAnnouncements(batch, wireFormat)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, batchMessage{data: data, events: len(batch)})
+	}
+
+	return messages, nil
+}
+
+// marshalProtobufAnnouncements serializes events as routingv1.LabelAnnouncements prefixed with WireVersionProtobuf.
+func marshalProtobufAnnouncements(events []*RecordPublishEvent) ([]byte, error) {
+	msg := &routingv1.LabelAnnouncements{
+		Announcements: make([]*routingv1.LabelAnnouncement, 0, len(events)),
+	}
+
+	for _, event := range events {
+		msg.Announcements = append(msg.Announcements, event.ToProto())
+	}
+
+	data := make([]byte, 1, 1+proto.Size(msg))
+	data[0] = WireVersionProtobuf
+
+	data, err := proto.MarshalOptions{}.MarshalAppend(data, msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal label announcements: %w", err)
+	}
+
+	// Validate size to prevent oversized messages
+	if len(data) > MaxMessageSize {
+		return nil, errors.New("announcement exceeds maximum size")
+	}
+
+	return data, nil
+}
+
+// unmarshalProtobufAnnouncements deserializes routingv1.LabelAnnouncements following the
+// WireVersionProtobuf byte, validating each event and its signature (if present).
+func unmarshalProtobufAnnouncements(data []byte) ([]*RecordPublishEvent, error) {
+	var msg routingv1.LabelAnnouncements
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal label announcements: %w", err)
+	}
+
+	batch := RecordPublishBatch{Events: make([]*RecordPublishEvent, 0, len(msg.GetAnnouncements()))}
+	for _, announcement := range msg.GetAnnouncements() {
+		batch.Events = append(batch.Events, recordPublishEventFromProto(announcement))
+	}
+
+	if err := batch.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := verifyEvents(batch.Events); err != nil {
+		return nil, err
+	}
+
+	return batch.Events, nil
+}
+
+// ToProto converts the event to its protobuf wire format.
+func (e *RecordPublishEvent) ToProto() *routingv1.LabelAnnouncement {
+	announcement := &routingv1.LabelAnnouncement{
+		Cid:         e.CID,
+		Labels:      e.Labels,
+		Timestamp:   timestamppb.New(e.Timestamp),
+		Supersedes:  e.Supersedes,
+		AccessGated: e.AccessGated,
+		Size:        e.Size,
+		PublicKey:   e.PublicKey,
+		Signature:   e.Signature,
+		Name:        e.Name,
+		Version:     e.Version,
+		Description: e.Description,
+		Tenant:      e.Tenant,
+
+		Publisher:          e.Publisher,
+		PublisherSignature: e.PublisherSignature,
+		RemovedLabels:      e.RemovedLabels,
+	}
+
+	if !e.ExpiresAt.IsZero() {
+		announcement.ExpiresAt = timestamppb.New(e.ExpiresAt)
+	}
+
+	return announcement
+}
+
+// recordPublishEventFromProto converts an announcement from its protobuf wire format.
+func recordPublishEventFromProto(announcement *routingv1.LabelAnnouncement) *RecordPublishEvent {
+	event := &RecordPublishEvent{
+		CID:         announcement.GetCid(),
+		Labels:      announcement.GetLabels(),
+		Supersedes:  announcement.GetSupersedes(),
+		AccessGated: announcement.GetAccessGated(),
+		Size:        announcement.GetSize(),
+		Tenant:      announcement.GetTenant(),
+		PublicKey:   announcement.GetPublicKey(),
+		Signature:   announcement.GetSignature(),
+
+		Publisher:          announcement.GetPublisher(),
+		PublisherSignature: announcement.GetPublisherSignature(),
+		RemovedLabels:      announcement.GetRemovedLabels(),
+		RecordSummary: types.RecordSummary{
+			Name:        announcement.GetName(),
+			Version:     announcement.GetVersion(),
+			Description: announcement.GetDescription(),
+		},
+	}
+
+	if announcement.GetTimestamp() != nil {
+		event.Timestamp = announcement.GetTimestamp().AsTime()
+	}
+
+	if announcement.GetExpiresAt() != nil {
+		event.ExpiresAt = announcement.GetExpiresAt().AsTime()
+	}
+
+	return event
+}
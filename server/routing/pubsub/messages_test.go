@@ -0,0 +1,157 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalAnnouncements_Protobuf(t *testing.T) {
+	events := newTestEvents(t, 3)
+	events[0].ExpiresAt = time.Now().Add(time.Hour)
+	events[0].Supersedes = "bafyprevious"
+	events[0].AccessGated = true
+	events[0].Size = 1024
+	events[0].RecordSummary = types.RecordSummary{Name: "agent", Version: "v1.0.0", Description: "Summarizes text"}
+	events[0].Tenant = "acme"
+	events[0].RemovedLabels = []string{"/skills/AI/NLP"}
+
+	// Re-sign the event with the optional fields set
+	publisherKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, events[0].SignAsPublisher(publisherKey))
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	require.NoError(t, events[0].Sign(key))
+
+	data, err := MarshalAnnouncements(events, WireFormatProtobuf)
+	require.NoError(t, err)
+	assert.Equal(t, WireVersionProtobuf, data[0])
+
+	decoded, err := UnmarshalAnnouncements(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, 3)
+
+	for i, event := range decoded {
+		assert.Equal(t, events[i].CID, event.CID)
+		assert.Equal(t, events[i].Labels, event.Labels)
+		assert.True(t, events[i].Timestamp.Equal(event.Timestamp))
+		assert.True(t, event.IsSigned())
+	}
+
+	assert.True(t, events[0].ExpiresAt.Equal(decoded[0].ExpiresAt))
+	assert.Equal(t, "bafyprevious", decoded[0].Supersedes)
+	assert.True(t, decoded[0].AccessGated)
+	assert.Equal(t, uint64(1024), decoded[0].Size)
+	assert.Equal(t, events[0].RecordSummary, decoded[0].RecordSummary)
+	assert.Equal(t, "acme", decoded[0].Tenant)
+	assert.Equal(t, []string{"/skills/AI/NLP"}, decoded[0].RemovedLabels)
+	assert.Equal(t, events[0].Publisher, decoded[0].Publisher)
+	assert.True(t, decoded[1].ExpiresAt.IsZero())
+}
+
+func TestMarshalAnnouncements_JSON(t *testing.T) {
+	events := newTestEvents(t, 2)
+
+	// A single event is sent as a plain event, readable by peers that predate batches
+	single, err := MarshalAnnouncements(events[:1], WireFormatJSON)
+	require.NoError(t, err)
+
+	event, err := UnmarshalRecordPublishEvent(single)
+	require.NoError(t, err)
+	assert.Equal(t, events[0].CID, event.CID)
+
+	batch, err := MarshalAnnouncements(events, "")
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalAnnouncements(batch)
+	require.NoError(t, err)
+	assert.Len(t, decoded, 2)
+
+	_, err = MarshalAnnouncements(events, "cbor")
+	assert.Error(t, err)
+}
+
+func TestMarshalAnnouncements_ProtobufSmallerThanJSON(t *testing.T) {
+	events := newTestEvents(t, MaxEventsPerBatch)
+
+	jsonData, err := MarshalAnnouncements(events[:10], WireFormatJSON)
+	require.NoError(t, err)
+
+	protobufData, err := MarshalAnnouncements(events[:10], WireFormatProtobuf)
+	require.NoError(t, err)
+
+	assert.Less(t, len(protobufData), len(jsonData))
+
+	// Batches sized for JSON always fit in protobuf
+	batches, err := splitIntoBatches(events)
+	require.NoError(t, err)
+
+	for _, batch := range batches {
+		_, err := MarshalAnnouncements(batch, WireFormatProtobuf)
+		require.NoError(t, err)
+	}
+}
+
+func TestUnmarshalAnnouncements_TamperedProtobufRejected(t *testing.T) {
+	events := newTestEvents(t, 2)
+	events[1].Size = 4096
+
+	data, err := MarshalAnnouncements(events, WireFormatProtobuf)
+	require.NoError(t, err)
+
+	_, err = UnmarshalAnnouncements(data)
+	require.Error(t, err)
+
+	// Messages carrying no announcements are rejected
+	_, err = UnmarshalAnnouncements([]byte{WireVersionProtobuf})
+	require.Error(t, err)
+
+	_, err = UnmarshalAnnouncements([]byte{WireVersionProtobuf, 0xff})
+	assert.Error(t, err)
+}
+
+func BenchmarkMarshalAnnouncements(b *testing.B) {
+	events := newTestEvents(b, 10)
+
+	for _, wireFormat := range []string{WireFormatJSON, WireFormatProtobuf, WireFormatZstd} {
+		b.Run(wireFormat, func(b *testing.B) {
+			for b.Loop() {
+				data, err := MarshalAnnouncements(events, wireFormat)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				b.SetBytes(int64(len(data)))
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalAnnouncements(b *testing.B) {
+	events := newTestEvents(b, 10)
+
+	for _, wireFormat := range []string{WireFormatJSON, WireFormatProtobuf, WireFormatZstd} {
+		data, err := MarshalAnnouncements(events, wireFormat)
+		require.NoError(b, err)
+
+		b.Run(wireFormat, func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+
+			for b.Loop() {
+				if _, err := UnmarshalAnnouncements(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// AnnouncementID identifies an announcement of a record by a peer.
+// It is the hex-encoded SHA-256 of CID \0 peer ID \0 timestamp(RFC3339Nano, UTC),
+// so a republish, which carries a fresh timestamp, gets a new ID while
+// replays of the same announcement share one.
+func AnnouncementID(cid, peerID string, timestamp time.Time) string {
+	h := sha256.New()
+	h.Write([]byte(cid))
+	h.Write([]byte{0})
+	h.Write([]byte(peerID))
+	h.Write([]byte{0})
+	h.Write([]byte(timestamp.UTC().Format(time.RFC3339Nano)))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkFreshness rejects announcements whose timestamp lies outside the
+// accepted window: older than MaxAnnouncementAge, or further in the future
+// than MaxAnnouncementClockSkew.
+func checkFreshness(timestamp, now time.Time) error {
+	if age := now.Sub(timestamp); age > MaxAnnouncementAge {
+		return fmt.Errorf("announcement is %s old, exceeding %s", age.Round(time.Second), MaxAnnouncementAge)
+	}
+
+	if ahead := timestamp.Sub(now); ahead > MaxAnnouncementClockSkew {
+		return fmt.Errorf("announcement timestamp is %s in the future, exceeding %s", ahead.Round(time.Second), MaxAnnouncementClockSkew)
+	}
+
+	return nil
+}
+
+// seenCache remembers the IDs of processed announcements for as long as they
+// pass checkFreshness, so that every replay within the accepted window is
+// detected. It is bounded by a maximum size, forgetting the oldest IDs first.
+// It is safe for concurrent use.
+type seenCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	max   int
+	seen  map[string]time.Time // ID -> expiry
+	order []seenID             // IDs in insertion order, i.e. by expiry
+}
+
+type seenID struct {
+	id      string
+	expires time.Time
+}
+
+func newSeenCache(ttl time.Duration, maxSize int) *seenCache {
+	return &seenCache{
+		ttl:  ttl,
+		max:  maxSize,
+		seen: make(map[string]time.Time),
+	}
+}
+
+// Add records an announcement ID seen at the given time.
+// Returns false if the ID was already seen and has not expired yet.
+func (c *seenCache) Add(id string, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evict(now)
+
+	if expires, ok := c.seen[id]; ok && now.Before(expires) {
+		return false
+	}
+
+	expires := now.Add(c.ttl)
+	c.seen[id] = expires
+	c.order = append(c.order, seenID{id: id, expires: expires})
+
+	return true
+}
+
+// Len returns the number of remembered IDs.
+func (c *seenCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.seen)
+}
+
+// evict forgets expired IDs and the oldest IDs beyond the maximum size.
+func (c *seenCache) evict(now time.Time) {
+	n := 0
+
+	for ; n < len(c.order); n++ {
+		entry := c.order[n]
+		if now.Before(entry.expires) && len(c.order)-n < c.max {
+			break
+		}
+
+		// The ID may have been re-added with a later expiry after it expired
+		if c.seen[entry.id].Equal(entry.expires) {
+			delete(c.seen, entry.id)
+		}
+	}
+
+	c.order = c.order[n:]
+}
@@ -0,0 +1,65 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnnouncementID(t *testing.T) {
+	ts := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	id := AnnouncementID("bafy1", "peer1", ts)
+
+	assert.Len(t, id, 64)
+	assert.Equal(t, id, AnnouncementID("bafy1", "peer1", ts.In(time.FixedZone("CEST", 2*60*60))))
+	assert.NotEqual(t, id, AnnouncementID("bafy2", "peer1", ts))
+	assert.NotEqual(t, id, AnnouncementID("bafy1", "peer2", ts))
+	assert.NotEqual(t, id, AnnouncementID("bafy1", "peer1", ts.Add(time.Nanosecond)))
+}
+
+func TestCheckFreshness(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, checkFreshness(now, now))
+	assert.NoError(t, checkFreshness(now.Add(-MaxAnnouncementAge), now))
+	assert.NoError(t, checkFreshness(now.Add(MaxAnnouncementClockSkew), now))
+	assert.Error(t, checkFreshness(now.Add(-MaxAnnouncementAge-time.Second), now))
+	assert.Error(t, checkFreshness(now.Add(MaxAnnouncementClockSkew+time.Second), now))
+}
+
+func TestSeenCache_DetectsReplays(t *testing.T) {
+	now := time.Now()
+	cache := newSeenCache(time.Minute, 10)
+
+	assert.True(t, cache.Add("a", now))
+	assert.False(t, cache.Add("a", now.Add(30*time.Second)))
+	assert.True(t, cache.Add("b", now.Add(30*time.Second)))
+
+	// IDs are forgotten once expired
+	assert.True(t, cache.Add("a", now.Add(time.Minute)))
+	assert.Equal(t, 2, cache.Len())
+
+	// The re-added ID is not dropped by the expiry of its first sighting
+	assert.False(t, cache.Add("a", now.Add(90*time.Second)))
+}
+
+func TestSeenCache_Bounded(t *testing.T) {
+	now := time.Now()
+	cache := newSeenCache(time.Hour, 3)
+
+	for i := range 5 {
+		assert.True(t, cache.Add(fmt.Sprintf("id%d", i), now))
+	}
+
+	assert.Equal(t, 3, cache.Len())
+
+	// The oldest IDs were forgotten first
+	assert.True(t, cache.Add("id0", now))
+	assert.False(t, cache.Add("id4", now))
+}
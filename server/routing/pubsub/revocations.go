@@ -0,0 +1,120 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/server/routing/revocation"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// joinRevocations joins and subscribes to the revocation topic.
+func (m *Manager) joinRevocations() error {
+	topic, err := m.pubsub.Join(TopicRevocations)
+	if err != nil {
+		return fmt.Errorf("failed to join revocations topic %q: %w", TopicRevocations, err)
+	}
+
+	m.revocationTopic = topic
+
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to revocations topic %q: %w", TopicRevocations, err)
+	}
+
+	m.revocationSub = sub
+
+	return nil
+}
+
+// PublishRevocation publishes a signed record revocation to the network.
+func (m *Manager) PublishRevocation(ctx context.Context, rev *revocation.Revocation) error {
+	if rev == nil {
+		return errors.New("revocation is nil")
+	}
+
+	data, err := rev.Marshal()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := m.revocationTopic.Publish(ctx, data); err != nil {
+		return fmt.Errorf("failed to publish revocation: %w", err)
+	}
+
+	logger.Info("Published record revocation",
+		"cid", rev.CID,
+		"topicPeers", len(m.revocationTopic.ListPeers()))
+
+	return nil
+}
+
+// SetOnRecordRevocation sets the callback for received record revocations.
+// The callback receives the verified signer of the revocation, which is the
+// only peer whose announcements of the record are revoked.
+func (m *Manager) SetOnRecordRevocation(fn func(context.Context, peer.ID, *revocation.Revocation)) {
+	m.onRecordRevocation = fn
+}
+
+// handleRevocations processes incoming record revocations.
+// Revocations must be validly signed by the peer that originated the message.
+func (m *Manager) handleRevocations(sub *pubsub.Subscription) {
+	for {
+		msg, ok := m.nextMessage(sub)
+		if !ok {
+			return
+		}
+
+		// Skip our own revocations (already applied locally)
+		if msg.ReceivedFrom == m.host.ID() {
+			continue
+		}
+
+		// Revocations count towards the same rate limit as announcements
+		if !m.admit(msg) {
+			continue
+		}
+
+		rev, err := revocation.Unmarshal(msg.Data)
+		if err == nil {
+			err = checkRevocationSigner(msg, rev)
+		}
+
+		if err != nil {
+			logger.Warn("Rejected record revocation",
+				"from", msg.ReceivedFrom,
+				"error", err,
+				"size", len(msg.Data))
+			m.observeAnnouncement(msg, false)
+
+			continue
+		}
+
+		m.observeAnnouncement(msg, true)
+
+		logger.Debug("Received record revocation", "from", msg.ReceivedFrom, "publisher", msg.GetFrom(), "cid", rev.CID)
+
+		if m.onRecordRevocation != nil {
+			m.onRecordRevocation(m.ctx, msg.GetFrom(), rev)
+		}
+	}
+}
+
+// checkRevocationSigner checks that the revocation was signed by the peer that originated it.
+func checkRevocationSigner(msg *pubsub.Message, rev *revocation.Revocation) error {
+	signerID, err := rev.SignerID()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if originID := msg.GetFrom(); signerID != originID {
+		return fmt.Errorf("revocation signed by %s but originated from %s", signerID, originID)
+	}
+
+	return nil
+}
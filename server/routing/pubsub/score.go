@@ -0,0 +1,52 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Peer score thresholds. These are local policy and do not affect the wire protocol.
+// Scores come from the application-specific score function passed in Options.
+const (
+	// GossipThreshold is the score below which no gossip is exchanged with a peer.
+	GossipThreshold = -10
+
+	// PublishThreshold is the score below which our own announcements are not sent to a peer.
+	PublishThreshold = -30
+
+	// GraylistThreshold is the score below which all messages from a peer are ignored.
+	GraylistThreshold = -40
+
+	// AcceptPXThreshold is the score a peer needs for its peer exchange to be accepted.
+	// Peers without observations score 0, so they keep contributing to peer discovery.
+	AcceptPXThreshold = 0
+
+	// OpportunisticGraftThreshold is the median mesh score below which
+	// better-scoring peers are grafted opportunistically.
+	OpportunisticGraftThreshold = 5
+)
+
+// peerScoreOption enables GossipSub peer scoring based solely on the
+// application-specific score, without per-topic delivery scoring.
+func peerScoreOption(score func(peer.ID) float64) pubsub.Option {
+	params := &pubsub.PeerScoreParams{
+		Topics:            map[string]*pubsub.TopicScoreParams{},
+		AppSpecificScore:  score,
+		AppSpecificWeight: 1,
+		DecayInterval:     pubsub.DefaultDecayInterval,
+		DecayToZero:       pubsub.DefaultDecayToZero,
+	}
+
+	thresholds := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             GossipThreshold,
+		PublishThreshold:            PublishThreshold,
+		GraylistThreshold:           GraylistThreshold,
+		AcceptPXThreshold:           AcceptPXThreshold,
+		OpportunisticGraftThreshold: OpportunisticGraftThreshold,
+	}
+
+	return pubsub.WithPeerScore(params, thresholds)
+}
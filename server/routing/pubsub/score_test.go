@@ -0,0 +1,25 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_WithPeerScore(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(1)
+	require.NoError(t, err)
+
+	defer mn.Close()
+
+	manager, err := New(t.Context(), mn.Hosts()[0], Options{
+		PeerScore: func(peer.ID) float64 { return 0 },
+	})
+	require.NoError(t, err)
+	require.NoError(t, manager.Close())
+}
@@ -0,0 +1,142 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/internal/didkey"
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// SigningPayload returns the canonical bytes covered by the event signature.
+// The payload is independent of the JSON encoding so that signatures stay
+// valid regardless of field order or whitespace on the wire.
+//
+// Format: SignatureDomain \0 CID \0 label1 \0 ... labelN \0 timestamp(RFC3339Nano, UTC),
+// followed by the optional fields \0 expiresAt(RFC3339Nano, UTC) \0 supersedes \0 gated \0 size
+// \0 name \0 version \0 description \0 tenant \0 publisher \0 removed1 \0 ... removedN, where gated is "1" for access-gated records and size is decimal. Optional fields are written up to the
+// last one that is set, unset fields before it are empty. Announcements of records
+// without optional fields keep the original format, so their signatures remain
+// verifiable by older peers.
+func (e *RecordPublishEvent) SigningPayload() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(SignatureDomain)
+	buf.WriteByte(0)
+	buf.WriteString(e.CID)
+	buf.WriteByte(0)
+
+	for _, label := range e.Labels {
+		buf.WriteString(label)
+		buf.WriteByte(0)
+	}
+
+	buf.WriteString(e.Timestamp.UTC().Format(time.RFC3339Nano))
+
+	var expiresAt, gated, size string
+	if !e.ExpiresAt.IsZero() {
+		expiresAt = e.ExpiresAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	if e.AccessGated {
+		gated = "1"
+	}
+
+	if e.Size > 0 {
+		size = strconv.FormatUint(e.Size, 10)
+	}
+
+	// Removed labels come last, so that they can be written as separate fields
+	removed := strings.Join(e.RemovedLabels, "\x00")
+
+	optional := []string{expiresAt, e.Supersedes, gated, size, e.Name, e.Version, e.Description, e.Tenant, e.Publisher, removed}
+
+	// Trim unset trailing fields
+	for len(optional) > 0 && optional[len(optional)-1] == "" {
+		optional = optional[:len(optional)-1]
+	}
+
+	for _, field := range optional {
+		buf.WriteByte(0)
+		buf.WriteString(field)
+	}
+
+	return buf.Bytes()
+}
+
+// Sign signs the event with the given Ed25519 identity key and embeds
+// the corresponding public key so that receivers can verify it.
+func (e *RecordPublishEvent) Sign(key crypto.PrivKey) error {
+	publicKey, signature, err := signing.Sign(key, e.SigningPayload())
+	if err != nil {
+		return fmt.Errorf("failed to sign record publish event: %w", err)
+	}
+
+	e.PublicKey = publicKey
+	e.Signature = signature
+
+	return nil
+}
+
+// IsSigned reports whether the event carries signature data.
+func (e *RecordPublishEvent) IsSigned() bool {
+	return len(e.PublicKey) > 0 || len(e.Signature) > 0
+}
+
+// Verify checks the event signature against the embedded public key.
+func (e *RecordPublishEvent) Verify() error {
+	return signing.Verify(e.PublicKey, e.Signature, e.SigningPayload()) //nolint:wrapcheck
+}
+
+// SignAsPublisher sets the publisher of the event to the did:key identifier of the
+// given Ed25519 key and signs the event with it. As the publisher is covered by the
+// signing payload, it must be called before Sign.
+func (e *RecordPublishEvent) SignAsPublisher(key crypto.PrivKey) error {
+	publisher, err := didkey.Encode(key.GetPublic())
+	if err != nil {
+		return fmt.Errorf("failed to encode publisher: %w", err)
+	}
+
+	e.Publisher = publisher
+
+	_, signature, err := signing.Sign(key, e.SigningPayload())
+	if err != nil {
+		return fmt.Errorf("failed to sign record publish event as publisher: %w", err)
+	}
+
+	e.PublisherSignature = signature
+
+	return nil
+}
+
+// VerifyPublisher checks the publisher signature against the key of the publisher's did:key identifier.
+func (e *RecordPublishEvent) VerifyPublisher() error {
+	key, err := didkey.Decode(e.Publisher)
+	if err != nil {
+		return fmt.Errorf("invalid publisher: %w", err)
+	}
+
+	publicKey, err := crypto.MarshalPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("invalid publisher: %w", err)
+	}
+
+	if err := signing.Verify(publicKey, e.PublisherSignature, e.SigningPayload()); err != nil {
+		return fmt.Errorf("invalid publisher signature: %w", err)
+	}
+
+	return nil
+}
+
+// SignerID returns the peer ID derived from the embedded public key.
+func (e *RecordPublishEvent) SignerID() (peer.ID, error) {
+	return signing.SignerID(e.PublicKey) //nolint:wrapcheck
+}
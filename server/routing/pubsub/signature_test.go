@@ -0,0 +1,273 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvent() *RecordPublishEvent {
+	return &RecordPublishEvent{
+		CID:       "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+		Labels:    []string{"/skills/AI/ML", "/domains/research"},
+		Timestamp: time.Now(),
+	}
+}
+
+func TestRecordPublishEvent_SignAndVerify(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	require.NoError(t, event.Sign(key))
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalRecordPublishEvent(data)
+	require.NoError(t, err)
+	assert.True(t, decoded.IsSigned())
+
+	signerID, err := decoded.SignerID()
+	require.NoError(t, err)
+
+	expectedID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, expectedID, signerID)
+}
+
+func TestRecordPublishEvent_TamperedSignatureRejected(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	require.NoError(t, event.Sign(key))
+
+	// Spoof an extra label after signing
+	event.Labels = append(event.Labels, "/modules/spoofed")
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_ExpirationSigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+
+	// Events without expiration keep the original signing payload
+	legacyPayload := event.SigningPayload()
+
+	event.ExpiresAt = event.Timestamp.Add(time.Hour)
+	assert.NotEqual(t, legacyPayload, event.SigningPayload())
+
+	require.NoError(t, event.Sign(key))
+
+	// Extending the expiration after signing is rejected
+	event.ExpiresAt = event.ExpiresAt.Add(time.Hour)
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_SupersedesSigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	event.Supersedes = "bafybeihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"
+
+	// The superseded CID does not collide with an expiration
+	expiring := newTestEvent()
+	expiring.Timestamp = event.Timestamp
+	expiring.ExpiresAt = event.Timestamp.Add(time.Hour)
+	assert.NotEqual(t, expiring.SigningPayload(), event.SigningPayload())
+
+	require.NoError(t, event.Sign(key))
+
+	// Replacing the superseded CID after signing is rejected
+	event.Supersedes = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdj"
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_AccessGateSigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	event.AccessGated = true
+	require.NoError(t, event.Sign(key))
+
+	// Removing the gate after signing is rejected
+	event.AccessGated = false
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_SizeSigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	event.Size = 1024
+	require.NoError(t, event.Sign(key))
+
+	// Substituting the size after signing is rejected
+	event.Size = 2048
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_SummarySigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	event.Name = "agent"
+	event.Description = "Summarizes text"
+	require.NoError(t, event.Sign(key))
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalRecordPublishEvent(data)
+	require.NoError(t, err)
+	assert.Equal(t, event.RecordSummary, decoded.RecordSummary)
+
+	// Substituting the description after signing is rejected
+	event.Description = "Steals credentials"
+
+	data, err = event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_TenantSigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	event.Tenant = "acme"
+	require.NoError(t, event.Sign(key))
+
+	// Moving the record to another tenant after signing is rejected
+	event.Tenant = "other"
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_RemovedLabelsSigned(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	event.Tenant = "acme"
+	event.RemovedLabels = []string{"/skills/AI/NLP", "/domains/finance"}
+	require.NoError(t, event.Sign(key))
+
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalRecordPublishEvent(data)
+	require.NoError(t, err)
+	assert.Equal(t, event.RemovedLabels, decoded.RemovedLabels)
+
+	// Removing other labels of the record after signing is rejected
+	event.RemovedLabels = []string{"/skills/AI/ML"}
+
+	data, err = event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_PublisherSigned(t *testing.T) {
+	publisherKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	event := newTestEvent()
+	require.NoError(t, event.SignAsPublisher(publisherKey))
+	assert.Regexp(t, `^did:key:z`, event.Publisher)
+
+	// Publishers are verified even if the peer does not sign its announcements
+	data, err := event.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalRecordPublishEvent(data)
+	require.NoError(t, err)
+	assert.Equal(t, event.Publisher, decoded.Publisher)
+
+	// Attributing the announcement to another publisher is rejected
+	impersonated := *event
+	require.NoError(t, impersonated.SignAsPublisher(otherKey))
+	impersonated.PublisherSignature = event.PublisherSignature
+
+	data, err = impersonated.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.ErrorContains(t, err, "publisher signature")
+
+	// So are publisher signatures without publisher
+	event.Publisher = ""
+
+	data, err = event.Marshal()
+	require.NoError(t, err)
+
+	_, err = UnmarshalRecordPublishEvent(data)
+	assert.Error(t, err)
+}
+
+func TestRecordPublishEvent_UnsignedAccepted(t *testing.T) {
+	data, err := newTestEvent().Marshal()
+	require.NoError(t, err)
+
+	decoded, err := UnmarshalRecordPublishEvent(data)
+	require.NoError(t, err)
+	assert.False(t, decoded.IsSigned())
+}
+
+func TestRecordPublishEvent_NonEd25519KeyRejected(t *testing.T) {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+
+	assert.Error(t, newTestEvent().Sign(key))
+}
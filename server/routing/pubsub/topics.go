@@ -0,0 +1,68 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// NamespaceTopic returns the GossipSub topic name for a label namespace.
+// Example: NamespaceTopic(types.LabelTypeSkill) returns "dir/labels.skills/v1".
+func NamespaceTopic(labelType types.LabelType) string {
+	return TopicLabelsPrefix + labelType.String() + TopicLabelsVersion
+}
+
+// ParseNamespaces converts the configured subscription policy into label types.
+// Namespaces are given without slashes (e.g. "skills", "domains").
+func ParseNamespaces(namespaces []string) ([]types.LabelType, error) {
+	labelTypes := make([]types.LabelType, 0, len(namespaces))
+
+	for _, namespace := range namespaces {
+		labelType, ok := types.ParseLabelType(namespace)
+		if !ok {
+			return nil, fmt.Errorf("unknown label namespace %q", namespace)
+		}
+
+		labelTypes = append(labelTypes, labelType)
+	}
+
+	return labelTypes, nil
+}
+
+// subscribedNamespaces applies the subscription policy.
+// An empty policy subscribes to all namespaces.
+func subscribedNamespaces(namespaces []types.LabelType) []types.LabelType {
+	if len(namespaces) == 0 {
+		return types.AllLabelTypes()
+	}
+
+	seen := make(map[types.LabelType]bool)
+
+	result := make([]types.LabelType, 0, len(namespaces))
+	for _, labelType := range namespaces {
+		if seen[labelType] {
+			continue
+		}
+
+		seen[labelType] = true
+		result = append(result, labelType)
+	}
+
+	return result
+}
+
+// checkNamespace verifies that all labels and removed labels of an announcement belong to the
+// namespace of the topic it was received on.
+func checkNamespace(event *RecordPublishEvent, labelType types.LabelType) error {
+	for _, label := range slices.Concat(event.Labels, event.RemovedLabels) {
+		if types.Label(label).Type() != labelType {
+			return fmt.Errorf("label %q does not belong to namespace %q", label, labelType)
+		}
+	}
+
+	return nil
+}
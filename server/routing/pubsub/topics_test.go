@@ -0,0 +1,52 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceTopic(t *testing.T) {
+	assert.Equal(t, "dir/labels.skills/v1", NamespaceTopic(types.LabelTypeSkill))
+	assert.Equal(t, "dir/labels.locators/v1", NamespaceTopic(types.LabelTypeLocator))
+}
+
+func TestParseNamespaces(t *testing.T) {
+	labelTypes, err := ParseNamespaces([]string{"skills", "domains"})
+	require.NoError(t, err)
+	assert.Equal(t, []types.LabelType{types.LabelTypeSkill, types.LabelTypeDomain}, labelTypes)
+
+	_, err = ParseNamespaces([]string{"unknown"})
+	assert.Error(t, err)
+}
+
+func TestSubscribedNamespaces(t *testing.T) {
+	// Empty policy subscribes to everything
+	assert.Equal(t, types.AllLabelTypes(), subscribedNamespaces(nil))
+
+	// Duplicates are removed
+	assert.Equal(t,
+		[]types.LabelType{types.LabelTypeModule},
+		subscribedNamespaces([]types.LabelType{types.LabelTypeModule, types.LabelTypeModule}),
+	)
+}
+
+func TestCheckNamespace(t *testing.T) {
+	event := &RecordPublishEvent{Labels: []string{"/skills/AI", "/skills/ML"}}
+	assert.NoError(t, checkNamespace(event, types.LabelTypeSkill))
+
+	event.Labels = append(event.Labels, "/locators/docker")
+	assert.Error(t, checkNamespace(event, types.LabelTypeSkill))
+
+	// Removed labels must belong to the namespace as well
+	event = &RecordPublishEvent{RemovedLabels: []string{"/skills/AI"}}
+	assert.NoError(t, checkNamespace(event, types.LabelTypeSkill))
+
+	event.RemovedLabels = append(event.RemovedLabels, "/domains/research")
+	assert.Error(t, checkNamespace(event, types.LabelTypeSkill))
+}
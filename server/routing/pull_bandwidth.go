@@ -0,0 +1,55 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/bandwidth"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetBandwidthUsage returns the record content served by Pull to each peer within the
+// retained hours, those served the most bytes first.
+func (r *routeRemote) GetBandwidthUsage(_ context.Context, req *routingv1.GetBandwidthUsageRequest) (*routingv1.GetBandwidthUsageResponse, error) {
+	usage := r.bandwidth.Usage(time.Now())
+	if limit := int(req.GetLimit()); limit > 0 && len(usage) > limit {
+		usage = usage[:limit]
+	}
+
+	resp := &routingv1.GetBandwidthUsageResponse{
+		QuotaBytesPerHour: r.bandwidth.Quota(),
+		Peers:             make([]*routingv1.PeerBandwidthUsage, 0, len(usage)),
+	}
+
+	for _, peer := range usage {
+		resp.Peers = append(resp.Peers, peerBandwidthUsageToProto(peer))
+	}
+
+	return resp, nil
+}
+
+func peerBandwidthUsageToProto(peer bandwidth.PeerUsage) *routingv1.PeerBandwidthUsage {
+	out := &routingv1.PeerBandwidthUsage{
+		PeerId:           peer.PeerID,
+		Bytes:            peer.Bytes,
+		Pulls:            peer.Pulls,
+		RefusedPulls:     peer.RefusedPulls,
+		CurrentHourBytes: peer.CurrentHourBytes,
+		Hours:            make([]*routingv1.HourlyBandwidthUsage, 0, len(peer.Hours)),
+	}
+
+	for _, hour := range peer.Hours {
+		out.Hours = append(out.Hours, &routingv1.HourlyBandwidthUsage{
+			Hour:         timestamppb.New(hour.Hour),
+			Bytes:        hour.Bytes,
+			Pulls:        hour.Pulls,
+			RefusedPulls: hour.RefusedPulls,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,127 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// pullPool bounds the concurrent processing of queued DHT provider notifications,
+// so that a slow remote pull does not block label caching of other records.
+//
+// At most concurrency notifications are processed at once, and at most perPeer of
+// them announced by the same peer, so that a single slow or unresponsive peer cannot
+// occupy all workers. Notifications beyond these limits stay in the notification
+// queue until a worker is free (backpressure).
+type pullPool struct {
+	slots   chan struct{}
+	perPeer int
+
+	mu       sync.Mutex
+	peers    map[peer.ID]int
+	inFlight map[string]struct{} // Keys of the notifications being processed
+
+	wg sync.WaitGroup
+}
+
+func newPullPool(concurrency, perPeer int) *pullPool {
+	return &pullPool{
+		slots:    make(chan struct{}, concurrency),
+		perPeer:  perPeer,
+		peers:    make(map[peer.ID]int),
+		inFlight: make(map[string]struct{}),
+	}
+}
+
+// pullConcurrency returns the configured concurrency of the pull pool overall and per peer,
+// using the defaults for unset values.
+func pullConcurrency(cfg routingconfig.Config) (int, int) {
+	concurrency := cfg.PullConcurrency
+	if concurrency <= 0 {
+		concurrency = routingconfig.DefaultPullConcurrency
+	}
+
+	perPeer := cfg.PullConcurrencyPerPeer
+	if perPeer <= 0 {
+		perPeer = routingconfig.DefaultPullConcurrencyPerPeer
+	}
+
+	return concurrency, perPeer
+}
+
+// busy reports whether a notification is being processed or its peer is at its concurrency cap,
+// in which case it is left in the queue.
+func (p *pullPool) busy(entry notifyQueueEntry) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.inFlight[entry.key()]; ok {
+		return true
+	}
+
+	return p.peers[entry.Peer.ID] >= p.perPeer
+}
+
+// submit processes a notification on a worker, waiting for a free worker while all are busy.
+// Returns false without processing it if the notification is busy or the context is done.
+func (p *pullPool) submit(ctx context.Context, entry notifyQueueEntry, process func(notifyQueueEntry), done func()) bool {
+	select {
+	case p.slots <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	p.mu.Lock()
+
+	if _, ok := p.inFlight[entry.key()]; ok || p.peers[entry.Peer.ID] >= p.perPeer {
+		p.mu.Unlock()
+		<-p.slots
+
+		return false
+	}
+
+	p.inFlight[entry.key()] = struct{}{}
+	p.peers[entry.Peer.ID]++
+	p.mu.Unlock()
+
+	p.wg.Add(1)
+
+	go func() {
+		defer p.wg.Done()
+
+		process(entry)
+
+		p.mu.Lock()
+		delete(p.inFlight, entry.key())
+
+		if p.peers[entry.Peer.ID]--; p.peers[entry.Peer.ID] <= 0 {
+			delete(p.peers, entry.Peer.ID)
+		}
+		p.mu.Unlock()
+
+		<-p.slots
+
+		done()
+	}()
+
+	return true
+}
+
+// active returns the number of notifications being processed and the maximum.
+func (p *pullPool) active() (int, int) {
+	if p == nil {
+		return 0, 0
+	}
+
+	return len(p.slots), cap(p.slots)
+}
+
+// wait waits for the notifications being processed.
+func (p *pullPool) wait() {
+	p.wg.Wait()
+}
@@ -0,0 +1,60 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullPool_Limits(t *testing.T) {
+	ctx := t.Context()
+	pool := newPullPool(2, 1)
+
+	slowPeer := notifyQueueEntry{cid: "bafyrecord1", queuedNotification: queuedNotification{Peer: newTestNotification(t, "").Peer}}
+	slowPeerOther := notifyQueueEntry{cid: "bafyrecord2", queuedNotification: slowPeer.queuedNotification}
+	otherPeer := notifyQueueEntry{cid: "bafyrecord3", queuedNotification: queuedNotification{Peer: newTestNotification(t, "").Peer}}
+
+	release := make(chan struct{})
+	processed := make(chan string, 3)
+	process := func(entry notifyQueueEntry) {
+		<-release
+		processed <- entry.cid
+	}
+
+	require.True(t, pool.submit(ctx, slowPeer, process, func() {}))
+
+	// A notification in flight and further notifications of a peer at its cap stay queued
+	assert.True(t, pool.busy(slowPeer))
+	assert.True(t, pool.busy(slowPeerOther))
+	assert.False(t, pool.submit(ctx, slowPeerOther, process, func() {}))
+
+	// Other peers are not blocked by the slow peer
+	assert.False(t, pool.busy(otherPeer))
+	require.True(t, pool.submit(ctx, otherPeer, process, func() {}))
+
+	active, concurrency := pool.active()
+	assert.Equal(t, 2, active)
+	assert.Equal(t, 2, concurrency)
+
+	// Submitting waits while all workers are busy
+	blocked, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+
+	thirdPeer := notifyQueueEntry{cid: "bafyrecord4", queuedNotification: queuedNotification{Peer: newTestNotification(t, "").Peer}}
+	assert.False(t, pool.submit(blocked, thirdPeer, process, func() {}))
+
+	close(release)
+	pool.wait()
+
+	assert.ElementsMatch(t, []string{"bafyrecord1", "bafyrecord3"}, []string{<-processed, <-processed})
+	assert.False(t, pool.busy(slowPeerOther))
+
+	active, _ = pool.active()
+	assert.Zero(t, active)
+}
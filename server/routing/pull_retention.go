@@ -0,0 +1,348 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// RetainedNamespace is the datastore namespace of the pulled records retained in the local store.
+const RetainedNamespace = "retained"
+
+// retainedKey returns the datastore key of a retained record: /retained/<CID>.
+func retainedKey(cid string) datastore.Key {
+	return datastore.NewKey("/" + RetainedNamespace + "/" + cid)
+}
+
+// retainedRecord is a record pulled by the DHT+Pull fallback and kept in the local store.
+// The announcement metadata of its publisher is served to peers pulling it from this peer.
+type retainedRecord struct {
+	Size       uint64    `json:"size"`
+	RetainedAt time.Time `json:"retained_at"`
+	AccessedAt time.Time `json:"accessed_at"`
+	ExpiresAt  time.Time `json:"expires_at,omitzero"`
+	Supersedes string    `json:"supersedes,omitempty"`
+}
+
+// pullRetention keeps pulled records in the local store within a budget.
+// Retaining and evicting are serialized, as pulls run concurrently.
+type pullRetention struct {
+	budget uint64
+
+	mu sync.Mutex
+}
+
+func newPullRetention(cfg routingconfig.RetainPulledConfig) *pullRetention {
+	return &pullRetention{budget: cfg.BudgetBytes}
+}
+
+// retainPulledRecord keeps a record pulled from a remote peer in the local store, making room
+// for it within the budget, and announces this peer as a provider of it. Access-gated records
+// are not retained, as this peer cannot authorize pulls of them. Does nothing if disabled.
+func (r *routeRemote) retainPulledRecord(ctx context.Context, record *corev1.Record, metadata rpc.RecordMetadata) {
+	if r.retention == nil || record == nil || metadata.AccessGated {
+		return
+	}
+
+	result, err := r.retainRecord(ctx, record, metadata)
+	metrics.PulledRecordsRetained.WithLabelValues(result).Inc()
+
+	if err != nil {
+		remoteLogger.Debug("Failed to retain pulled record", "cid", record.GetCid(), "error", err)
+
+		return
+	}
+
+	if result == metrics.ResultSuccess {
+		r.wg.Add(1)
+
+		go func() {
+			defer r.wg.Done()
+
+			r.provideRetained(r.ctx, record.GetCid())
+		}()
+	}
+}
+
+// retainRecord stores a pulled record and its metadata. Returns the metrics result of the retention.
+func (r *routeRemote) retainRecord(ctx context.Context, record *corev1.Record, metadata rpc.RecordMetadata) (string, error) {
+	r.retention.mu.Lock()
+	defer r.retention.mu.Unlock()
+
+	if r.isStored(ctx, record.GetCid()) {
+		return metrics.ResultPresent, nil
+	}
+
+	if metadata.Size > r.retention.budget {
+		return metrics.ResultLimited, fmt.Errorf("record of %d bytes exceeds the retention budget", metadata.Size)
+	}
+
+	if err := r.evictRetained(ctx, r.retention.budget-metadata.Size); err != nil {
+		return metrics.ResultFailure, err
+	}
+
+	if _, err := r.storeAPI.Push(ctx, record); err != nil {
+		return metrics.ResultFailure, fmt.Errorf("failed to store record: %w", err)
+	}
+
+	now := time.Now()
+
+	err := r.putRetained(ctx, record.GetCid(), retainedRecord{
+		Size:       metadata.Size,
+		RetainedAt: now,
+		AccessedAt: now,
+		ExpiresAt:  metadata.ExpiresAt,
+		Supersedes: metadata.Supersedes,
+	})
+	if err != nil {
+		return metrics.ResultFailure, err
+	}
+
+	remoteLogger.Debug("Retained pulled record", "cid", record.GetCid(), "size", metadata.Size)
+
+	return metrics.ResultSuccess, nil
+}
+
+// provideRetained announces this peer as a provider of a retained record in the DHT.
+func (r *routeRemote) provideRetained(ctx context.Context, recordCID string) bool {
+	decodedCID, err := cid.Decode(recordCID)
+	if err != nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, RetainedProvideTimeout)
+	defer cancel()
+
+	if err := r.server.DHT().Provide(ctx, decodedCID, true); err != nil {
+		remoteLogger.Debug("Failed to announce provider of retained record", "cid", recordCID, "error", err)
+
+		return false
+	}
+
+	return true
+}
+
+// getRetained returns the metadata of a retained record.
+func (r *routeRemote) getRetained(ctx context.Context, cid string) (retainedRecord, bool) {
+	data, err := r.dstore.Get(ctx, retainedKey(cid))
+	if err != nil {
+		return retainedRecord{}, false
+	}
+
+	var record retainedRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return retainedRecord{}, false
+	}
+
+	return record, true
+}
+
+func (r *routeRemote) putRetained(ctx context.Context, cid string, record retainedRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal retained record: %w", err)
+	}
+
+	if err := r.dstore.Put(ctx, retainedKey(cid), data); err != nil {
+		return fmt.Errorf("failed to store retained record: %w", err)
+	}
+
+	return nil
+}
+
+// retainedRecordMetadata returns the announcement metadata of a retained record served
+// by Pull, marking it as recently used.
+func (r *routeRemote) retainedRecordMetadata(ctx context.Context, cid string) (rpc.RecordMetadata, bool) {
+	if r.retention == nil {
+		return rpc.RecordMetadata{}, false
+	}
+
+	r.retention.mu.Lock()
+	defer r.retention.mu.Unlock()
+
+	record, ok := r.getRetained(ctx, cid)
+	if !ok {
+		return rpc.RecordMetadata{}, false
+	}
+
+	record.AccessedAt = time.Now()
+	if err := r.putRetained(ctx, cid, record); err != nil {
+		remoteLogger.Debug("Failed to mark retained record as used", "cid", cid, "error", err)
+	}
+
+	return rpc.RecordMetadata{ExpiresAt: record.ExpiresAt, Supersedes: record.Supersedes}, true
+}
+
+// evictRetained deletes the least recently used retained records from the local store until
+// the retained records take at most limit bytes. Evicted records that were since published
+// or pinned are kept in the store, and only no longer count towards the budget.
+func (r *routeRemote) evictRetained(ctx context.Context, limit uint64) error {
+	records, err := r.queryRetained(ctx)
+	if err != nil {
+		return err
+	}
+
+	var total uint64
+	for _, record := range records {
+		total += record.Size
+	}
+
+	slices.SortFunc(records, func(a, b retained) int {
+		return a.AccessedAt.Compare(b.AccessedAt)
+	})
+
+	for _, record := range records {
+		if total <= limit {
+			break
+		}
+
+		if err := r.dropRetained(ctx, record.cid); err != nil {
+			return err
+		}
+
+		total -= record.Size
+	}
+
+	return nil
+}
+
+// retained is a retained record and its CID.
+type retained struct {
+	cid string
+	retainedRecord
+}
+
+// queryRetained returns the retained records.
+func (r *routeRemote) queryRetained(ctx context.Context) ([]retained, error) {
+	results, err := r.dstore.Query(ctx, query.Query{Prefix: "/" + RetainedNamespace + "/"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query retained records: %w", err)
+	}
+	defer results.Close()
+
+	var records []retained
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		var record retainedRecord
+		if err := json.Unmarshal(result.Value, &record); err != nil {
+			remoteLogger.Warn("Failed to parse retained record", "key", result.Key, "error", err)
+
+			continue
+		}
+
+		records = append(records, retained{cid: strings.TrimPrefix(result.Key, "/"+RetainedNamespace+"/"), retainedRecord: record})
+	}
+
+	return records, nil
+}
+
+// dropRetained deletes a retained record from the local store, unless it was since published or pinned.
+func (r *routeRemote) dropRetained(ctx context.Context, cid string) error {
+	owned, err := r.dstore.Has(ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return fmt.Errorf("failed to check local record: %w", err)
+	}
+
+	// Records deleted through the store API only need to stop counting towards the budget
+	if !owned && !r.pins.has(cid) && r.isStored(ctx, cid) {
+		if err := r.storeAPI.Delete(ctx, &corev1.RecordRef{Cid: cid}); err != nil {
+			return fmt.Errorf("failed to delete retained record %s: %w", cid, err)
+		}
+	}
+
+	if err := r.dstore.Delete(ctx, retainedKey(cid)); err != nil {
+		return fmt.Errorf("failed to delete retained record %s: %w", cid, err)
+	}
+
+	remoteLogger.Debug("Evicted retained record", "cid", cid, "kept", owned || r.pins.has(cid))
+
+	return nil
+}
+
+// isStored reports whether a record is in the local store.
+func (r *routeRemote) isStored(ctx context.Context, cid string) bool {
+	_, err := r.storeAPI.Lookup(ctx, &corev1.RecordRef{Cid: cid})
+
+	return err == nil
+}
+
+// startPullRetention periodically drops expired retained records and announces this peer
+// as a provider of the others again before its provider records expire.
+func (r *routeRemote) startPullRetention() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		remoteLogger.Info("Started retaining pulled records", "budgetBytes", r.retention.budget)
+
+		ticker := time.NewTicker(RepublishInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping retention of pulled records")
+
+				return
+			case <-ticker.C:
+				r.reprovideRetained(r.ctx)
+			}
+		}
+	}()
+}
+
+// reprovideRetained drops the retained records whose TTL elapsed and re-announces the others.
+func (r *routeRemote) reprovideRetained(ctx context.Context) {
+	r.retention.mu.Lock()
+	records, err := r.queryRetained(ctx)
+	r.retention.mu.Unlock()
+
+	if err != nil {
+		remoteLogger.Warn("Failed to query retained records", "error", err)
+
+		return
+	}
+
+	now := time.Now()
+	provided := 0
+
+	for _, record := range records {
+		if !record.ExpiresAt.IsZero() && !now.Before(record.ExpiresAt) {
+			r.retention.mu.Lock()
+			err := r.dropRetained(ctx, record.cid)
+			r.retention.mu.Unlock()
+
+			if err != nil {
+				remoteLogger.Warn("Failed to drop expired retained record", "cid", record.cid, "error", err)
+			}
+
+			continue
+		}
+
+		if r.provideRetained(ctx, record.cid) {
+			provided++
+		}
+	}
+
+	remoteLogger.Info("Re-announced retained records", "provided", provided, "retained", len(records))
+}
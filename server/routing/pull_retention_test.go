@@ -0,0 +1,101 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetainRecord_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	store := newMockStore()
+	r := &routeRemote{
+		dstore:    dstore,
+		storeAPI:  store,
+		pins:      newPinSet(),
+		retention: newPullRetention(routingconfig.RetainPulledConfig{Enabled: true, BudgetBytes: 250}),
+	}
+
+	expiresAt := time.Now().Add(time.Hour).UTC()
+
+	retain := func(name string, size uint64) (*corev1.Record, string) {
+		record := corev1.New(&typesv1alpha0.Record{Name: name, SchemaVersion: "v0.3.1"})
+
+		result, err := r.retainRecord(ctx, record, rpc.RecordMetadata{Size: size, ExpiresAt: expiresAt, Supersedes: "cid-previous"})
+		if result != metrics.ResultLimited {
+			require.NoError(t, err)
+		}
+
+		return record, result
+	}
+
+	first, result := retain("agent-1", 100)
+	assert.Equal(t, metrics.ResultSuccess, result)
+
+	second, _ := retain("agent-2", 100)
+
+	_, result = retain("agent-1", 100)
+	assert.Equal(t, metrics.ResultPresent, result)
+
+	_, result = retain("agent-large", 300)
+	assert.Equal(t, metrics.ResultLimited, result)
+
+	// Serving the first record to a peer marks it as used and returns the publisher's metadata
+	metadata, ok := r.retainedRecordMetadata(ctx, first.GetCid())
+	require.True(t, ok)
+	assert.True(t, expiresAt.Equal(metadata.ExpiresAt))
+	assert.Equal(t, "cid-previous", metadata.Supersedes)
+
+	third, _ := retain("agent-3", 100)
+
+	assert.Contains(t, store.data, first.GetCid())
+	assert.NotContains(t, store.data, second.GetCid())
+	assert.Contains(t, store.data, third.GetCid())
+
+	_, ok = r.getRetained(ctx, second.GetCid())
+	assert.False(t, ok)
+}
+
+func TestEvictRetained_KeepsDeletedAndPinnedRecordsOutOfBudget(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	store := newMockStore()
+	r := &routeRemote{dstore: dstore, storeAPI: store, pins: newPinSet()}
+
+	now := time.Now()
+	for cid, accessedAt := range map[string]time.Time{
+		"cid-deleted": now.Add(-2 * time.Hour),
+		"cid-pinned":  now.Add(-time.Hour),
+	} {
+		require.NoError(t, r.putRetained(ctx, cid, retainedRecord{Size: 100, RetainedAt: accessedAt, AccessedAt: accessedAt}))
+	}
+
+	store.data["cid-pinned"] = &corev1.Record{}
+	r.pins.add("cid-pinned")
+
+	require.NoError(t, r.evictRetained(ctx, 0))
+
+	assert.Contains(t, store.data, "cid-pinned")
+
+	records, err := r.queryRetained(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
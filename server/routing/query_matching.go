@@ -5,6 +5,8 @@ package routing
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
@@ -55,114 +57,207 @@ func MatchesAllQueries(
 // QueryMatchesLabels checks if a single query matches against a list of labels.
 // This function contains the unified logic for all query types, resolving the
 // differences between local and remote implementations.
-//
-//nolint:gocognit,cyclop // Complex but necessary logic for handling all query types with exact and prefix matching
 func QueryMatchesLabels(query *routingv1.RecordQuery, labelList []types.Label) bool {
+	return QueryMatchScore(query, labelList) > 0
+}
+
+// QueryMatchScore returns the score of the best match of a single query against a list
+// of labels, zero if the query does not match. Hierarchical queries score ExactMatchScore
+// for the queried label and PartialMatchScore for its descendants and ancestors; other
+// queries, including boolean groups, score one if they match.
+//
+//nolint:cyclop // Complex but necessary logic for handling all query types with exact and prefix matching
+func QueryMatchScore(query *routingv1.RecordQuery, labelList []types.Label) uint32 {
 	if query == nil {
-		return false
+		return 0
 	}
 
-	switch query.GetType() {
-	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
-		// Check if any skill label matches the query
-		targetSkill := types.LabelTypeSkill.Prefix() + query.GetValue()
+	// Boolean groups combine the results of their sub-queries
+	if group := query.GetGroup(); group != nil {
+		if groupMatchesLabels(group, labelList) {
+			return 1
+		}
 
-		for _, label := range labelList {
-			// Type-safe filtering: only check skill labels
-			if label.Type() != types.LabelTypeSkill {
-				continue
-			}
+		return 0
+	}
 
-			labelStr := label.String()
-			// Exact match: /skills/category1/class1 matches "category1/class1"
-			if labelStr == targetSkill {
-				return true
-			}
-			// Prefix match: /skills/category2/class2 matches "category2"
-			if strings.HasPrefix(labelStr, targetSkill+"/") {
-				return true
-			}
-		}
+	var (
+		target    string
+		labelType types.LabelType // Empty matches labels of any namespace
+		exactOnly bool
+	)
 
-		return false
+	switch query.GetType() {
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
+		// Exact match: /skills/category1/class1 matches "category1/class1"
+		// Prefix match: /skills/category2/class2 matches "category2"
+		target, labelType = types.LabelTypeSkill.Prefix()+query.GetValue(), types.LabelTypeSkill
 
 	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
 		// Unified locator handling - use proper namespace prefix (fixing remote implementation)
-		targetLocator := types.LabelTypeLocator.Prefix() + query.GetValue()
+		// Exact match only: /locators/docker-image matches "docker-image"
+		target, labelType, exactOnly = types.LabelTypeLocator.Prefix()+query.GetValue(), types.LabelTypeLocator, true
 
-		for _, label := range labelList {
-			// Type-safe filtering: only check locator labels
-			if label.Type() != types.LabelTypeLocator {
-				continue
-			}
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
+		// Exact match: /domains/research matches "research"
+		// Prefix match: /domains/research/subfield matches "research"
+		target, labelType = types.LabelTypeDomain.Prefix()+query.GetValue(), types.LabelTypeDomain
 
-			// Exact match: /locators/docker-image matches "docker-image"
-			if label.String() == targetLocator {
-				return true
-			}
-		}
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		// Exact match: /modules/runtime/language matches "runtime/language"
+		// Prefix match: /modules/runtime/language/python matches "runtime/language"
+		target, labelType = types.LabelTypeModule.Prefix()+query.GetValue(), types.LabelTypeModule
 
-		return false
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL:
+		// Labels of any namespace (including custom ones) match
+		// Exact match: /teams/platform matches "teams/platform"
+		// Prefix match: /teams/platform/search matches "teams/platform"
+		target = "/" + strings.Trim(query.GetValue(), "/")
 
-	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
-		// Check if any domain label matches the query
-		targetDomain := types.LabelTypeDomain.Prefix() + query.GetValue()
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED:
+		// Unspecified queries match everything
+		return 1
 
-		for _, label := range labelList {
-			// Type-safe filtering: only check domain labels
-			if label.Type() != types.LabelTypeDomain {
-				continue
-			}
+	default:
+		queryLogger.Warn("Unknown query type", "type", query.GetType())
 
-			labelStr := label.String()
-			// Exact match: /domains/research matches "research"
-			if labelStr == targetDomain {
-				return true
-			}
-			// Prefix match: /domains/research/subfield matches "research"
-			if strings.HasPrefix(labelStr, targetDomain+"/") {
-				return true
-			}
+		return 0
+	}
+
+	hierarchical := query.GetMatchMode() == routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL
+
+	var score uint32
+
+	for _, label := range labelList {
+		// Type-safe filtering: only check labels of the queried namespace
+		if labelType != "" && label.Type() != labelType {
+			continue
 		}
 
-		return false
+		labelStr := label.String()
 
-	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
-		// Check if any module label matches the query
-		targetModule := types.LabelTypeModule.Prefix() + query.GetValue()
+		switch {
+		case labelStr == target:
+			if !hierarchical {
+				return 1
+			}
 
-		for _, label := range labelList {
-			// Type-safe filtering: only check module labels
-			if label.Type() != types.LabelTypeModule {
-				continue
+			return ExactMatchScore
+		case exactOnly:
+		case strings.HasPrefix(labelStr, target+"/"):
+			// Descendant of the queried label
+			if !hierarchical {
+				return 1
 			}
 
-			labelStr := label.String()
-			// Exact match: /modules/runtime/language matches "runtime/language"
-			if labelStr == targetModule {
-				return true
+			score = PartialMatchScore
+		case hierarchical && strings.HasPrefix(target, labelStr+"/"):
+			// Ancestor of the queried label, e.g. /skills/AI for "AI/ML"
+			score = PartialMatchScore
+		}
+	}
+
+	return score
+}
+
+// groupMatchesLabels evaluates a boolean query group against a list of labels.
+func groupMatchesLabels(group *routingv1.RecordQueryGroup, labelList []types.Label) bool {
+	switch group.GetOperator() {
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND:
+		// All sub-queries must match
+		for _, query := range group.GetQueries() {
+			if !QueryMatchesLabels(query, labelList) {
+				return false
 			}
-			// Prefix match: /modules/runtime/language/python matches "runtime/language"
-			if strings.HasPrefix(labelStr, targetModule+"/") {
+		}
+
+		return true
+
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR:
+		// At least one sub-query must match
+		for _, query := range group.GetQueries() {
+			if QueryMatchesLabels(query, labelList) {
 				return true
 			}
 		}
 
 		return false
 
-	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED:
-		// Unspecified queries match everything
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT:
+		// None of the sub-queries may match
+		for _, query := range group.GetQueries() {
+			if QueryMatchesLabels(query, labelList) {
+				return false
+			}
+		}
+
 		return true
 
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED:
+		return false
+
 	default:
-		queryLogger.Warn("Unknown query type", "type", query.GetType())
+		queryLogger.Warn("Unknown query operator", "operator", group.GetOperator())
 
 		return false
 	}
 }
 
+// ValidateQueries checks the structure of boolean query groups.
+// Groups must have a known operator, must not be empty, and must not be
+// nested deeper than MaxQueryGroupDepth. Queries must have a known match mode.
+func ValidateQueries(queries []*routingv1.RecordQuery) error {
+	for _, query := range queries {
+		if err := validateQuery(query, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateQuery(query *routingv1.RecordQuery, depth int) error {
+	group := query.GetGroup()
+	if group == nil {
+		if _, ok := routingv1.RecordQueryMatchMode_name[int32(query.GetMatchMode())]; !ok {
+			return fmt.Errorf("invalid query match mode %s", query.GetMatchMode())
+		}
+
+		return nil
+	}
+
+	if depth >= MaxQueryGroupDepth {
+		return fmt.Errorf("query groups are nested deeper than %d levels", MaxQueryGroupDepth)
+	}
+
+	switch group.GetOperator() {
+	case routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+		routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR,
+		routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT:
+	default:
+		return fmt.Errorf("invalid query group operator %s", group.GetOperator())
+	}
+
+	if len(group.GetQueries()) == 0 {
+		return errors.New("query group has no queries")
+	}
+
+	for _, sub := range group.GetQueries() {
+		if sub == nil {
+			return errors.New("query group has a nil query")
+		}
+
+		if err := validateQuery(sub, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetMatchingQueries returns the queries that match against a specific label key.
 // This is used primarily for calculating match scores in Search operations.
+// Query groups are evaluated against the single label of the key.
 func GetMatchingQueries(labelKey string, queries []*routingv1.RecordQuery) []*routingv1.RecordQuery {
 	var matchingQueries []*routingv1.RecordQuery
 
@@ -10,6 +10,8 @@ import (
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestQueryMatchesLabels(t *testing.T) {
@@ -153,6 +155,35 @@ func TestQueryMatchesLabels(t *testing.T) {
 			expected: false,
 		},
 
+		// Generic label queries
+		{
+			name: "label_exact_match_custom_namespace",
+			query: &routingv1.RecordQuery{
+				Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL,
+				Value: "teams/platform",
+			},
+			labels:   []types.Label{types.Label("/teams/platform"), types.Label("/skills/AI")},
+			expected: true,
+		},
+		{
+			name: "label_prefix_match_builtin_namespace",
+			query: &routingv1.RecordQuery{
+				Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL,
+				Value: "/skills/AI/",
+			},
+			labels:   []types.Label{types.Label("/skills/AI/ML")},
+			expected: true,
+		},
+		{
+			name: "label_no_partial_segment_match",
+			query: &routingv1.RecordQuery{
+				Type:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL,
+				Value: "teams/plat",
+			},
+			labels:   []types.Label{types.Label("/teams/platform")},
+			expected: false,
+		},
+
 		// Unspecified queries
 		{
 			name: "unspecified_always_matches",
@@ -534,3 +565,213 @@ func TestQueryMatchingIntegration(t *testing.T) {
 		assert.False(t, MatchesAllQueries(ctx, "mixed-record", queries, complexLabelRetriever)) // Only has /skills/AI, not AI/ML
 	})
 }
+
+func skillQuery(value string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: value}
+}
+
+func groupQuery(operator routingv1.RecordQueryOperator, queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{Operator: operator, Queries: queries}}
+}
+
+func TestQueryMatchesLabels_Groups(t *testing.T) {
+	labels := []types.Label{
+		types.Label("/skills/AI"),
+		types.Label("/domains/research"),
+		types.Label("/modules/runtime/language"),
+	}
+
+	domain := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+	legacy := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, Value: "legacy"}
+	runtime := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE, Value: "runtime"}
+
+	testCases := []struct {
+		name     string
+		query    *routingv1.RecordQuery
+		expected bool
+	}{
+		{
+			name: "skill AND domain AND NOT module",
+			query: groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+				skillQuery("AI"), domain,
+				groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, legacy)),
+			expected: true,
+		},
+		{
+			name: "NOT excludes matching record",
+			query: groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+				skillQuery("AI"),
+				groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, runtime)),
+			expected: false,
+		},
+		{
+			name:     "AND requires all queries",
+			query:    groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, skillQuery("AI"), skillQuery("web")),
+			expected: false,
+		},
+		{
+			name:     "OR requires any query",
+			query:    groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, skillQuery("web"), skillQuery("AI")),
+			expected: true,
+		},
+		{
+			name:     "unspecified operator never matches",
+			query:    groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED, skillQuery("AI")),
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, QueryMatchesLabels(tc.query, labels))
+		})
+	}
+}
+
+func TestQueryMatchScore_Hierarchical(t *testing.T) {
+	hierarchical := func(queryType routingv1.RecordQueryType, value string) *routingv1.RecordQuery {
+		return &routingv1.RecordQuery{
+			Type:      queryType,
+			Value:     value,
+			MatchMode: routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL,
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		query    *routingv1.RecordQuery
+		labels   []types.Label
+		expected uint32
+	}{
+		{
+			name:     "exact match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI/ML"),
+			labels:   []types.Label{"/skills/AI/ML"},
+			expected: ExactMatchScore,
+		},
+		{
+			name:     "descendant match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI"),
+			labels:   []types.Label{"/skills/AI/ML"},
+			expected: PartialMatchScore,
+		},
+		{
+			name:     "ancestor match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI/ML"),
+			labels:   []types.Label{"/skills/AI"},
+			expected: PartialMatchScore,
+		},
+		{
+			name:     "exact match wins over partial matches",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI/ML"),
+			labels:   []types.Label{"/skills/AI", "/skills/AI/ML/NLP", "/skills/AI/ML"},
+			expected: ExactMatchScore,
+		},
+		{
+			name:     "sibling does not match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI/ML"),
+			labels:   []types.Label{"/skills/AI/Vision", "/skills/AIML"},
+			expected: 0,
+		},
+		{
+			name:     "ancestor of other namespace does not match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, "AI/ML"),
+			labels:   []types.Label{"/skills/AI"},
+			expected: 0,
+		},
+		{
+			name:     "custom label ancestor match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "teams/platform/search"),
+			labels:   []types.Label{"/teams/platform"},
+			expected: PartialMatchScore,
+		},
+		{
+			name:     "locator only matches exactly",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, "docker-image/latest"),
+			labels:   []types.Label{"/locators/docker-image"},
+			expected: 0,
+		},
+		{
+			name:     "locator exact match",
+			query:    hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, "docker-image"),
+			labels:   []types.Label{"/locators/docker-image"},
+			expected: ExactMatchScore,
+		},
+		{
+			name:     "default mode scores exact matches one",
+			query:    skillQuery("AI/ML"),
+			labels:   []types.Label{"/skills/AI/ML"},
+			expected: 1,
+		},
+		{
+			name:     "default mode does not match ancestors",
+			query:    skillQuery("AI/ML"),
+			labels:   []types.Label{"/skills/AI"},
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, QueryMatchScore(tc.query, tc.labels))
+		})
+	}
+
+	// Records with the queried label outscore records with partial matches
+	queries := []*routingv1.RecordQuery{
+		hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI"),
+		hierarchical(routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, "research"),
+	}
+
+	matching, exact := matchScoreForLabels(queries, []types.Label{"/skills/AI", "/domains/research"})
+	assert.Len(t, matching, 2)
+	assert.Equal(t, uint32(2*ExactMatchScore), exact)
+
+	matching, partial := matchScoreForLabels(queries, []types.Label{"/skills/AI/ML", "/domains/research/biology"})
+	assert.Len(t, matching, 2)
+	assert.Equal(t, uint32(2*PartialMatchScore), partial)
+}
+
+func TestValidateQueries(t *testing.T) {
+	valid := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+		skillQuery("AI"),
+		groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, skillQuery("legacy")))
+	assert.NoError(t, ValidateQueries([]*routingv1.RecordQuery{skillQuery("AI"), valid}))
+
+	assert.Error(t, ValidateQueries([]*routingv1.RecordQuery{groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND)}))
+	assert.Error(t, ValidateQueries([]*routingv1.RecordQuery{groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_UNSPECIFIED, skillQuery("AI"))}))
+
+	nested := skillQuery("AI")
+	for range MaxQueryGroupDepth + 1 {
+		nested = groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, nested)
+	}
+
+	assert.Error(t, ValidateQueries([]*routingv1.RecordQuery{nested}))
+
+	assert.Error(t, ValidateQueries([]*routingv1.RecordQuery{{
+		Type:      routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+		Value:     "AI",
+		MatchMode: routingv1.RecordQueryMatchMode(42),
+	}}))
+}
+
+func TestDeduplicateQueries_Canonical(t *testing.T) {
+	and := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, skillQuery("AI"), skillQuery("ML"))
+	or := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, skillQuery("AI"), skillQuery("ML"))
+	andReordered := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+		skillQuery("ML"),
+		&routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: "/skill/AI"})
+
+	deduplicated := deduplicateQueries([]*routingv1.RecordQuery{and, or, andReordered, skillQuery(" AI/ ")})
+	require.Len(t, deduplicated, 3)
+
+	assert.True(t, proto.Equal(types.CanonicalQuery(and), deduplicated[0]))
+	assert.True(t, proto.Equal(types.CanonicalQuery(or), deduplicated[1]))
+	assert.True(t, proto.Equal(skillQuery("AI"), deduplicated[2]))
+
+	// Canonical forms of logically identical queries score once
+	_, score := matchScoreForLabels(
+		deduplicateQueries([]*routingv1.RecordQuery{skillQuery("AI"), skillQuery("AI/")}),
+		[]types.Label{"/skills/AI"})
+	assert.Equal(t, uint32(1), score)
+}
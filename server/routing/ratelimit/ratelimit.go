@@ -0,0 +1,212 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit limits the rate of inbound announcements and requests of
+// each remote peer with token buckets, and temporarily bans peers that keep
+// exceeding their limits.
+//
+// Limiters of different transports can share a ban list, so that a peer
+// banned for flooding announcements is refused requests as well.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// ViolationWindow is the window within which rate limit violations of a peer
+	// are counted towards a ban.
+	ViolationWindow = time.Minute
+
+	// maxTrackedPeers is the number of peers above which idle peers are forgotten.
+	maxTrackedPeers = 10000
+)
+
+// Result is the outcome of a rate limit check.
+type Result int
+
+const (
+	// Allowed requests are within the rate limit of the peer.
+	Allowed Result = iota
+	// Limited requests exceed the rate limit of the peer.
+	Limited
+	// Banned requests are refused because the peer is temporarily banned.
+	Banned
+)
+
+// Bans temporarily bans peers with too many rate limit violations within ViolationWindow.
+// It is safe for concurrent use. A nil Bans never bans a peer.
+type Bans struct {
+	mu        sync.Mutex
+	threshold int
+	duration  time.Duration
+	peers     map[string]*violations
+}
+
+type violations struct {
+	count       int
+	windowStart time.Time
+	bannedUntil time.Time
+}
+
+// NewBans creates a ban list banning peers for duration after threshold violations.
+// A zero threshold or duration disables bans.
+func NewBans(threshold int, duration time.Duration) *Bans {
+	return &Bans{
+		threshold: threshold,
+		duration:  duration,
+		peers:     make(map[string]*violations),
+	}
+}
+
+// Banned reports whether the peer is banned at the given time.
+func (b *Bans) Banned(peerID string, now time.Time) bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	v, ok := b.peers[peerID]
+
+	return ok && now.Before(v.bannedUntil)
+}
+
+// violation records a rate limit violation of the peer at the given time.
+// Reports whether the peer was banned by it.
+func (b *Bans) violation(peerID string, now time.Time) bool {
+	if b == nil || b.threshold <= 0 || b.duration <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.peers) >= maxTrackedPeers {
+		b.prune(now)
+	}
+
+	v, ok := b.peers[peerID]
+	if !ok {
+		v = &violations{}
+		b.peers[peerID] = v
+	}
+
+	if now.Sub(v.windowStart) >= ViolationWindow {
+		v.count = 0
+		v.windowStart = now
+	}
+
+	v.count++
+	if v.count < b.threshold {
+		return false
+	}
+
+	v.count = 0
+	v.bannedUntil = now.Add(b.duration)
+
+	return true
+}
+
+// prune forgets peers that are neither banned nor violated their limit recently.
+func (b *Bans) prune(now time.Time) {
+	for peerID, v := range b.peers {
+		if !now.Before(v.bannedUntil) && now.Sub(v.windowStart) >= ViolationWindow {
+			delete(b.peers, peerID)
+		}
+	}
+}
+
+// Limiter is a per-peer token bucket rate limiter. It is safe for concurrent use.
+// A nil Limiter allows all requests.
+type Limiter struct {
+	mu      sync.Mutex
+	rate    float64 // Tokens added per second
+	burst   float64 // Capacity of each bucket
+	bans    *Bans
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// New creates a limiter allowing rate requests per second with bursts of burst requests
+// per peer. Violations are recorded in bans, which may be nil. A rate of zero or less
+// returns nil, i.e. a limiter allowing all requests.
+func New(rate float64, burst int, bans *Bans) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+
+	return &Limiter{
+		rate:    rate,
+		burst:   max(float64(burst), 1),
+		bans:    bans,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the bucket of the peer at the given time.
+// Requests of banned peers are refused without taking a token.
+// A peer is banned once it exceeds its limit too often within ViolationWindow.
+func (l *Limiter) Allow(peerID string, now time.Time) Result {
+	if l == nil {
+		return Allowed
+	}
+
+	if l.bans.Banned(peerID, now) {
+		return Banned
+	}
+
+	if l.take(peerID, now) {
+		return Allowed
+	}
+
+	if l.bans.violation(peerID, now) {
+		return Banned
+	}
+
+	return Limited
+}
+
+// take takes a token from the bucket of the peer, reporting whether one was available.
+func (l *Limiter) take(peerID string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.buckets) >= maxTrackedPeers {
+		l.prune(now)
+	}
+
+	b, ok := l.buckets[peerID]
+	if !ok {
+		b = &bucket{tokens: l.burst, updated: now}
+		l.buckets[peerID] = b
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+		b.updated = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+
+	return true
+}
+
+// prune forgets peers whose buckets are full again, as they behave like new peers.
+func (l *Limiter) prune(now time.Time) {
+	for peerID, b := range l.buckets {
+		if b.tokens+now.Sub(b.updated).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, peerID)
+		}
+	}
+}
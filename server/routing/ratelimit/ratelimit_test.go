@@ -0,0 +1,76 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_TokenBucket(t *testing.T) {
+	limiter := New(2, 3, nil)
+	now := time.Now()
+
+	// The burst is allowed at once
+	for range 3 {
+		assert.Equal(t, Allowed, limiter.Allow("peer1", now))
+	}
+
+	assert.Equal(t, Limited, limiter.Allow("peer1", now))
+
+	// Peers are limited separately
+	assert.Equal(t, Allowed, limiter.Allow("peer2", now))
+
+	// Tokens are refilled at the rate
+	now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, Allowed, limiter.Allow("peer1", now))
+	assert.Equal(t, Limited, limiter.Allow("peer1", now))
+}
+
+func TestLimiter_Disabled(t *testing.T) {
+	limiter := New(0, 10, nil)
+	assert.Nil(t, limiter)
+
+	for range 100 {
+		assert.Equal(t, Allowed, limiter.Allow("peer1", time.Now()))
+	}
+}
+
+func TestLimiter_BansSustainedAbuse(t *testing.T) {
+	bans := NewBans(3, 10*time.Minute)
+	announcements := New(1, 1, bans)
+	requests := New(1, 1, bans)
+	now := time.Now()
+
+	assert.Equal(t, Allowed, announcements.Allow("peer1", now))
+	assert.Equal(t, Limited, announcements.Allow("peer1", now))
+	assert.Equal(t, Limited, announcements.Allow("peer1", now))
+	assert.Equal(t, Banned, announcements.Allow("peer1", now))
+
+	// Banned peers are refused by all limiters sharing the ban list, even with tokens
+	now = now.Add(time.Minute)
+	assert.True(t, bans.Banned("peer1", now))
+	assert.Equal(t, Banned, announcements.Allow("peer1", now))
+	assert.Equal(t, Banned, requests.Allow("peer1", now))
+	assert.Equal(t, Allowed, requests.Allow("peer2", now))
+
+	// Bans are temporary
+	now = now.Add(10 * time.Minute)
+	assert.False(t, bans.Banned("peer1", now))
+	assert.Equal(t, Allowed, announcements.Allow("peer1", now))
+}
+
+func TestBans_ViolationsExpire(t *testing.T) {
+	bans := NewBans(2, time.Minute)
+	now := time.Now()
+
+	assert.False(t, bans.violation("peer1", now))
+
+	// Violations spread over more than the window do not ban the peer
+	now = now.Add(ViolationWindow)
+	assert.False(t, bans.violation("peer1", now))
+	assert.True(t, bans.violation("peer1", now))
+}
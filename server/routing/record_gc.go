@@ -0,0 +1,254 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore/query"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// collectFunc deletes a local record from the store and retracts its announcements.
+type collectFunc func(ctx context.Context, cid string) error
+
+// recordGC garbage collects unpinned local records while the filesystem of the local
+// store is filled beyond the configured threshold. It is safe for concurrent use.
+type recordGC struct {
+	cfg   routingconfig.RecordGCConfig
+	usage func(path string) (float64, error) // Measures the filesystem, see diskUsage
+
+	mu        sync.Mutex
+	diskUsage float64   // Fraction of the filesystem in use at the last check
+	checkedAt time.Time // Zero before the first check
+	collected uint64    // Records collected since startup
+}
+
+func newRecordGC(cfg routingconfig.RecordGCConfig) *recordGC {
+	return &recordGC{cfg: cfg, usage: diskUsage}
+}
+
+// check measures the filesystem, reporting whether it is filled beyond the threshold.
+func (g *recordGC) check() (bool, error) {
+	usage, err := g.usage(g.cfg.Path)
+	if err != nil {
+		return false, err
+	}
+
+	metrics.RecordGCDiskUsage.Set(usage)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.diskUsage = usage
+	g.checkedAt = time.Now()
+
+	return usage >= g.cfg.Threshold, nil
+}
+
+// recordCollected counts a collected record.
+func (g *recordGC) recordCollected() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.collected++
+}
+
+// status returns the state of the garbage collection, disabled for a nil collector.
+func (g *recordGC) status() *routingv1.RecordGCStatus {
+	if g == nil {
+		return &routingv1.RecordGCStatus{}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	status := &routingv1.RecordGCStatus{
+		Enabled:   true,
+		Threshold: g.cfg.Threshold,
+		DiskUsage: g.diskUsage,
+		Collected: g.collected,
+	}
+
+	if !g.checkedAt.IsZero() {
+		status.CheckedAt = timestamppb.New(g.checkedAt)
+	}
+
+	return status
+}
+
+// collectRecord deletes a local record from the store to free disk space and retracts its
+// announcements like a deletion through the store API, then notifies the collect hooks.
+func (r *route) collectRecord(ctx context.Context, cid string) error {
+	if err := r.store.Delete(ctx, &corev1.RecordRef{Cid: cid}); err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	if err := r.Retract(ctx, cid); err != nil {
+		return err
+	}
+
+	r.hooksMu.RLock()
+	hooks := r.collectHooks
+	r.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		if err := hook(ctx, cid); err != nil {
+			remoteLogger.Warn("Failed to run collect hook", "cid", cid, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// OnCollect registers a hook notified with the CID of each local record garbage collected
+// from the store, e.g. to remove it from the search database.
+func (r *route) OnCollect(hook types.DeleteHook) {
+	r.hooksMu.Lock()
+	defer r.hooksMu.Unlock()
+
+	r.collectHooks = append(r.collectHooks, hook)
+}
+
+// startRecordGC starts the background task garbage collecting unpinned local records
+// under disk pressure. It does nothing if garbage collection is disabled.
+func (r *routeRemote) startRecordGC(collect collectFunc) {
+	if r.recordGC == nil {
+		return
+	}
+
+	remoteLogger.Info("Starting record garbage collection task",
+		"path", r.recordGC.cfg.Path, "threshold", r.recordGC.cfg.Threshold, "interval", r.recordGC.cfg.Interval)
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.recordGC.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping record garbage collection task")
+
+				return
+			case <-ticker.C:
+				start := time.Now()
+
+				r.collectRecords(r.ctx, collect)
+
+				metrics.TaskDuration.WithLabelValues(metrics.TaskRecordGC).Observe(time.Since(start).Seconds())
+			}
+		}
+	}()
+}
+
+// collectRecords collects the least recently published unpinned local records, up to
+// RecordGCBatchSize, until the filesystem is no longer filled beyond the threshold.
+func (r *routeRemote) collectRecords(ctx context.Context, collect collectFunc) {
+	// Followers share the local store of their primary, which collects its records
+	if r.follower.following() {
+		return
+	}
+
+	full, err := r.recordGC.check()
+	if err != nil {
+		remoteLogger.Warn("Failed to check disk usage for record garbage collection", "error", err)
+
+		return
+	}
+
+	if !full {
+		return
+	}
+
+	candidates, err := r.collectableRecords(ctx)
+	if err != nil {
+		remoteLogger.Error("Failed to query local records for garbage collection", "error", err)
+
+		return
+	}
+
+	collected := 0
+
+	for _, cid := range candidates {
+		if collected >= RecordGCBatchSize {
+			break
+		}
+
+		if err := collect(ctx, cid); err != nil {
+			remoteLogger.Warn("Failed to garbage collect local record", "cid", cid, "error", err)
+
+			continue
+		}
+
+		collected++
+
+		r.recordGC.recordCollected()
+		metrics.CleanupRemoved.WithLabelValues(metrics.CleanupCollectedRecord).Inc()
+
+		remoteLogger.Debug("Garbage collected local record", "cid", cid)
+
+		if full, err = r.recordGC.check(); err != nil || !full {
+			break
+		}
+	}
+
+	remoteLogger.Info("Garbage collected local records under disk pressure",
+		"collected", collected, "candidates", len(candidates), "diskUsage", r.recordGC.status().GetDiskUsage())
+}
+
+// collectableRecords returns the CIDs of the unpinned local records, least recently published first.
+// Records published before publish times were stored are collected first.
+func (r *routeRemote) collectableRecords(ctx context.Context) ([]string, error) {
+	results, err := r.dstore.Query(ctx, query.Query{Prefix: "/records/"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query local records: %w", err)
+	}
+	defer results.Close()
+
+	type candidate struct {
+		cid         string
+		publishedAt time.Time
+	}
+
+	var candidates []candidate
+
+	for result := range results.Next() {
+		if result.Error != nil {
+			continue
+		}
+
+		cid := path.Base(result.Key)
+		if r.pins.has(cid) {
+			continue
+		}
+
+		candidates = append(candidates, candidate{cid: cid, publishedAt: decodeLocalRecordMetadata(result.Value).PublishedAt})
+	}
+
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Or(a.publishedAt.Compare(b.publishedAt), cmp.Compare(a.cid, b.cid))
+	})
+
+	cids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		cids = append(cids, c.cid)
+	}
+
+	return cids, nil
+}
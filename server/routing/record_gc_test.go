@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectRecords(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	const newestCID = "bafkreibm6jg3ux5qumhcn2b3flc3tyu6dmlb4xa7u5bf44yegnrjhc4yeq"
+
+	now := time.Now()
+	published := map[string]time.Time{
+		pinnedTestCID: now.Add(-3 * time.Hour),
+		otherTestCID:  now.Add(-2 * time.Hour),
+		newestCID:     now.Add(-time.Hour),
+	}
+
+	for cid, publishedAt := range published {
+		value, err := encodeLocalRecordMetadata(localRecordMetadata{PublishedAt: publishedAt})
+		require.NoError(t, err)
+		require.NoError(t, dstore.Put(t.Context(), datastore.NewKey("/records/"+cid), value))
+	}
+
+	usage := 0.95
+
+	r := &routeRemote{
+		dstore:   dstore,
+		pins:     newPinSet(),
+		recordGC: newRecordGC(routingconfig.RecordGCConfig{Path: t.TempDir(), Threshold: 0.9, Interval: time.Minute}),
+	}
+	r.recordGC.usage = func(string) (float64, error) { return usage, nil }
+	r.pins.add(pinnedTestCID)
+
+	var collected []string
+
+	collect := func(_ context.Context, cid string) error {
+		collected = append(collected, cid)
+		usage -= 0.04
+
+		return nil
+	}
+
+	// The least recently published unpinned records are collected until usage drops below the threshold
+	r.collectRecords(t.Context(), collect)
+	assert.Equal(t, []string{otherTestCID, newestCID}, collected)
+
+	status := r.recordGC.status()
+	assert.True(t, status.GetEnabled())
+	assert.Equal(t, uint64(2), status.GetCollected())
+	assert.InDelta(t, 0.87, status.GetDiskUsage(), 0.001)
+	assert.NotNil(t, status.GetCheckedAt())
+
+	// Nothing is collected below the threshold
+	collected = nil
+
+	r.collectRecords(t.Context(), collect)
+	assert.Empty(t, collected)
+
+	// Nothing is collected when disk usage cannot be checked
+	r.recordGC.usage = func(string) (float64, error) { return 0, errors.New("unavailable") }
+
+	r.collectRecords(t.Context(), collect)
+	assert.Empty(t, collected)
+}
+
+func TestDiskUsage(t *testing.T) {
+	usage, err := diskUsage(t.TempDir())
+	require.NoError(t, err)
+	assert.Greater(t, usage, 0.0)
+	assert.LessOrEqual(t, usage, 1.0)
+}
@@ -0,0 +1,147 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// lineageIndex tracks which cached remote records were superseded by a newer version.
+// A record is only superseded for the peer that announced the newer version, so that
+// peers cannot hide the records of other peers by claiming to supersede them.
+type lineageIndex struct {
+	mu         sync.RWMutex
+	superseded map[lineageKey]time.Time // When the newer version was first seen
+}
+
+type lineageKey struct {
+	cid    string
+	peerID string
+}
+
+func newLineageIndex() *lineageIndex {
+	return &lineageIndex{superseded: make(map[lineageKey]time.Time)}
+}
+
+// add records that the peer announced a newer version of the record at the given time.
+func (l *lineageIndex) add(supersededCID, peerID string, at time.Time) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := lineageKey{cid: supersededCID, peerID: peerID}
+	if since, ok := l.superseded[key]; !ok || at.Before(since) {
+		l.superseded[key] = at
+	}
+}
+
+// supersededSince returns when the peer's newer version of the record was first seen,
+// or false if the peer did not announce a newer version.
+func (l *lineageIndex) supersededSince(cid, peerID string) (time.Time, bool) {
+	if l == nil {
+		return time.Time{}, false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	since, ok := l.superseded[lineageKey{cid: cid, peerID: peerID}]
+
+	return since, ok
+}
+
+// isSuperseded reports whether the peer announced a newer version of the record.
+func (l *lineageIndex) isSuperseded(cid, peerID string) bool {
+	_, ok := l.supersededSince(cid, peerID)
+
+	return ok
+}
+
+// replace swaps the contents of the index with a rebuilt one.
+func (l *lineageIndex) replace(rebuilt *lineageIndex) {
+	if l == nil {
+		return
+	}
+
+	rebuilt.mu.RLock()
+	superseded := rebuilt.superseded
+	rebuilt.mu.RUnlock()
+
+	l.mu.Lock()
+	l.superseded = superseded
+	l.mu.Unlock()
+}
+
+// len returns the number of superseded records.
+func (l *lineageIndex) len() int {
+	if l == nil {
+		return 0
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.superseded)
+}
+
+// addToLineage records the previous versions superseded by cached remote label keys.
+func (r *routeRemote) addToLineage(puts []journalPut) {
+	for _, p := range puts {
+		addSupersession(r.lineage, p.Key, p.Value)
+	}
+}
+
+// addSupersession records the previous version superseded by a cached remote label key, if any.
+func addSupersession(index *lineageIndex, key string, value []byte) {
+	_, _, peerID, err := ParseEnhancedLabelKey(key)
+	if err != nil {
+		return
+	}
+
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil || metadata.Supersedes == "" {
+		return
+	}
+
+	index.add(metadata.Supersedes, peerID, metadata.Timestamp)
+}
+
+// recordSupersedes returns the CID of the previous version of a local record,
+// or an empty string if it does not supersede another one or is not published.
+// It is included in the record's GossipSub announcements.
+func (r *routeRemote) recordSupersedes(cid string) string {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return ""
+	}
+
+	return decodeLocalRecordMetadata(value).Supersedes
+}
+
+// recordMetadata returns the announcement metadata of a local or retained record served by Pull.
+func (r *routeRemote) recordMetadata(cid string) rpc.RecordMetadata {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		metadata, _ := r.retainedRecordMetadata(r.ctx, cid)
+
+		return metadata
+	}
+
+	metadata := decodeLocalRecordMetadata(value)
+
+	return rpc.RecordMetadata{
+		ExpiresAt:   metadata.ExpiresAt,
+		Supersedes:  metadata.Supersedes,
+		AccessGated: metadata.AccessGated,
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineageIndex(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	index := newLineageIndex()
+
+	index.add("cid-v1", "peer1", base.Add(time.Minute))
+	index.add("cid-v1", "peer1", base)
+	index.add("cid-v1", "peer1", base.Add(time.Hour))
+
+	// The first time the newer version was seen is kept
+	since, ok := index.supersededSince("cid-v1", "peer1")
+	require.True(t, ok)
+	assert.Equal(t, base, since)
+
+	// Records are only superseded for the peer announcing the newer version
+	assert.True(t, index.isSuperseded("cid-v1", "peer1"))
+	assert.False(t, index.isSuperseded("cid-v1", "peer2"))
+	assert.False(t, index.isSuperseded("cid-v2", "peer1"))
+
+	rebuilt := newLineageIndex()
+	rebuilt.add("cid-v2", "peer1", base)
+	index.replace(rebuilt)
+
+	assert.False(t, index.isSuperseded("cid-v1", "peer1"))
+	assert.True(t, index.isSuperseded("cid-v2", "peer1"))
+	assert.Equal(t, 1, index.len())
+
+	// A nil index supersedes nothing
+	var disabled *lineageIndex
+	disabled.add("cid-v1", "peer1", base)
+	assert.False(t, disabled.isSuperseded("cid-v1", "peer1"))
+}
+
+func TestAddToLineage(t *testing.T) {
+	now := time.Now()
+	r := &routeRemote{lineage: newLineageIndex()}
+
+	value := func(supersedes string) []byte {
+		data, err := json.Marshal(&types.LabelMetadata{Timestamp: now, LastSeen: now, Supersedes: supersedes})
+		require.NoError(t, err)
+
+		return data
+	}
+
+	r.addToLineage([]journalPut{
+		{Key: BuildEnhancedLabelKey("/skills/AI", "cid-v2", "peer1"), Value: value("cid-v1")},
+		{Key: BuildEnhancedLabelKey("/skills/AI", "cid-other", "peer1"), Value: value("")},
+		{Key: "/invalid", Value: value("cid-x")},
+		{Key: BuildEnhancedLabelKey("/skills/AI", "cid-y", "peer2"), Value: []byte("not json")},
+	})
+
+	assert.True(t, r.lineage.isSuperseded("cid-v1", "peer1"))
+	assert.False(t, r.lineage.isSuperseded("cid-v2", "peer1"))
+	assert.Equal(t, 1, r.lineage.len())
+}
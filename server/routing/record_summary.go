@@ -0,0 +1,32 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// recordSummary returns the summary of a local record, empty if unknown.
+// It is included in the record's GossipSub announcements.
+func (r *routeRemote) recordSummary(cid string) types.RecordSummary {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return types.RecordSummary{}
+	}
+
+	return decodeLocalRecordMetadata(value).RecordSummary
+}
+
+// labelRecordSummary returns the summary of the record of a cached label entry, empty if unknown.
+func labelRecordSummary(value []byte) types.RecordSummary {
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return types.RecordSummary{}
+	}
+
+	return metadata.RecordSummary
+}
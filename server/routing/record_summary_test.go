@@ -0,0 +1,28 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelRecordSummary(t *testing.T) {
+	summary := types.RecordSummary{Name: "agent", Version: "v1.0.0", Description: "Summarizes text"}
+
+	value, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now(), RecordSummary: summary})
+	require.NoError(t, err)
+
+	// The summary fields are stored inline with the label metadata
+	assert.Contains(t, string(value), `"name":"agent"`)
+
+	assert.Equal(t, summary, labelRecordSummary(value))
+	assert.Zero(t, labelRecordSummary([]byte("{}")))
+	assert.Zero(t, labelRecordSummary([]byte("invalid")))
+}
@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// recordExpiration returns when the TTL of a local record elapses,
+// or the zero time if the record does not expire, is pinned or is not published.
+// It is included in the record's GossipSub announcements and Pull responses,
+// so that remote peers drop the record's labels once it expires.
+func (r *routeRemote) recordExpiration(cid string) time.Time {
+	if r.pins.has(cid) {
+		return time.Time{}
+	}
+
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return decodeLocalRecordMetadata(value).ExpiresAt
+}
+
+// labelExpired reports whether a cached label entry belongs to a record whose TTL has elapsed.
+// Entries with malformed metadata are left to the cleanup tasks.
+func labelExpired(value []byte, now time.Time) bool {
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return false
+	}
+
+	return metadata.IsExpired(now)
+}
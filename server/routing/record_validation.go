@@ -0,0 +1,156 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/publishcheck"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// newRecordValidation creates the pipeline of the enabled rules records are validated with
+// before they are published.
+func newRecordValidation(cfg routingconfig.RecordValidationConfig) (*publishcheck.Pipeline, error) {
+	var rules []publishcheck.Rule
+
+	if cfg.Schema {
+		rules = append(rules, publishcheck.Schema())
+	}
+
+	if cfg.Taxonomy {
+		ontology, err := publishcheck.LoadOntology(cfg.SkillsOntology)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		rules = append(rules, publishcheck.Taxonomy(ontology))
+	}
+
+	if cfg.Locators {
+		rules = append(rules, publishcheck.Locators())
+	}
+
+	if cfg.LocatorReachability {
+		rules = append(rules, publishcheck.LocatorReachability(&http.Client{Timeout: cfg.LocatorReachabilityTimeout}))
+	}
+
+	return publishcheck.New(rules...), nil
+}
+
+// validateRecord validates a record before it is published. Records violating the enabled
+// rules fail with InvalidArgument and a RecordValidationFailure detail.
+func (r *route) validateRecord(ctx context.Context, record types.Record) error {
+	violations := r.validation.Check(ctx, record)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	failure := recordValidationFailure(record.GetCid(), violations)
+
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("record %s failed validation: %s", record.GetCid(), describeViolations(failure.GetViolations())))
+	if detailed, err := st.WithDetails(failure); err == nil {
+		st = detailed
+	}
+
+	return st.Err() //nolint:wrapcheck
+}
+
+// ValidateRecords validates the records of the local store before they are queued for publishing,
+// so that publishers learn about invalid records instead of the publication failing in the background.
+// Records that cannot be pulled are left to fail when they are published.
+func (r *route) ValidateRecords(ctx context.Context, cids []string) error {
+	if !r.validation.Enabled() {
+		return nil
+	}
+
+	var (
+		failures []*routingv1.RecordValidationFailure
+		messages []string
+	)
+
+	for _, cid := range cids {
+		record, err := r.store.Pull(ctx, &corev1.RecordRef{Cid: cid})
+		if err != nil {
+			continue
+		}
+
+		violations := r.validation.Check(ctx, adapters.NewRecordAdapter(record))
+		if len(violations) == 0 {
+			continue
+		}
+
+		failure := recordValidationFailure(cid, violations)
+		failures = append(failures, failure)
+		messages = append(messages, fmt.Sprintf("record %s: %s", cid, describeViolations(failure.GetViolations())))
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("%d records failed validation: %s", len(failures), strings.Join(messages, "; ")))
+
+	details := make([]protoadapt.MessageV1, len(failures))
+	for i, failure := range failures {
+		details[i] = failure
+	}
+
+	if detailed, err := st.WithDetails(details...); err == nil {
+		st = detailed
+	}
+
+	return st.Err() //nolint:wrapcheck
+}
+
+// recordValidationFailure converts the violations of a record to their API message.
+func recordValidationFailure(cid string, violations []publishcheck.Violation) *routingv1.RecordValidationFailure {
+	failure := &routingv1.RecordValidationFailure{Cid: cid}
+
+	for _, v := range violations {
+		failure.Violations = append(failure.Violations, &routingv1.RecordViolation{
+			Rule:    v.Rule,
+			Field:   v.Field,
+			Message: v.Message,
+		})
+	}
+
+	return failure
+}
+
+// describeViolations joins violations into an error message, e.g. "skills[0].name: ... (taxonomy)".
+func describeViolations(violations []*routingv1.RecordViolation) string {
+	descriptions := make([]string, len(violations))
+
+	for i, v := range violations {
+		if v.GetField() != "" {
+			descriptions[i] = fmt.Sprintf("%s: %s (%s)", v.GetField(), v.GetMessage(), v.GetRule())
+		} else {
+			descriptions[i] = fmt.Sprintf("%s (%s)", v.GetMessage(), v.GetRule())
+		}
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
+// recordViolations returns the violations of a record that failed validation, nil for other errors.
+func recordViolations(err error) []*routingv1.RecordViolation {
+	for _, detail := range status.Convert(err).Details() {
+		if failure, ok := detail.(*routingv1.RecordValidationFailure); ok {
+			return failure.GetViolations()
+		}
+	}
+
+	return nil
+}
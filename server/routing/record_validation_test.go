@@ -0,0 +1,125 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/publishcheck"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPublishBatch_RejectsInvalidRecords(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := &route{
+		local:      newLocal(newMockStore(), dstore, testPeerID, nil, nil),
+		validation: publishcheck.New(publishcheck.Locators()),
+	}
+
+	valid := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-valid",
+		SchemaVersion: "v0.3.1",
+		Skills:        []*typesv1alpha0.Skill{{CategoryName: toPtr("category1"), ClassName: toPtr("class1")}},
+		Locators:      []*typesv1alpha0.Locator{{Type: "docker-image", Url: "ghcr.io/agntcy/agent:v1"}},
+	}))
+	invalid := adapters.NewRecordAdapter(corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-invalid",
+		SchemaVersion: "v0.3.1",
+		Skills:        []*typesv1alpha0.Skill{{CategoryName: toPtr("category1"), ClassName: toPtr("class1")}},
+	}))
+
+	var failed *routingv1.PublishProgress
+
+	errs := r.PublishBatch(t.Context(), []types.Record{valid, invalid}, types.PublishOptions{
+		Progress: func(event *routingv1.PublishProgress) {
+			if event.GetStage() == routingv1.PublishStage_PUBLISH_STAGE_FAILED {
+				failed = event
+			}
+		},
+	})
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+
+	// The violations are reported as structured progress
+	require.NotNil(t, failed)
+	assert.Equal(t, invalid.GetCid(), failed.GetCid())
+	require.Len(t, failed.GetViolations(), 1)
+	assert.Equal(t, publishcheck.RuleLocators, failed.GetViolations()[0].GetRule())
+	assert.Equal(t, "locators", failed.GetViolations()[0].GetField())
+
+	// Invalid records are not published locally
+	_, err := dstore.Get(t.Context(), datastore.NewKey("/records/"+invalid.GetCid()))
+	require.Error(t, err)
+}
+
+func TestValidateRecords(t *testing.T) {
+	store := newMockStore()
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent",
+		SchemaVersion: "v0.3.1",
+		Skills:        []*typesv1alpha0.Skill{{CategoryName: toPtr("category1"), ClassName: toPtr("unknown")}},
+	})
+	_, err := store.Push(t.Context(), record)
+	require.NoError(t, err)
+
+	// Validation is disabled without rules
+	r := &route{store: store}
+	require.NoError(t, r.ValidateRecords(t.Context(), []string{record.GetCid()}))
+
+	r.validation = publishcheck.New(publishcheck.Taxonomy(publishcheck.Ontology{"category1/class1": {}}))
+
+	err = r.ValidateRecords(t.Context(), []string{record.GetCid(), "missing"})
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	require.Len(t, st.Details(), 1)
+
+	failure, ok := st.Details()[0].(*routingv1.RecordValidationFailure)
+	require.True(t, ok)
+	assert.Equal(t, record.GetCid(), failure.GetCid())
+	require.Len(t, failure.GetViolations(), 1)
+	assert.Equal(t, "skills[0].name", failure.GetViolations()[0].GetField())
+	assert.Equal(t, failure.GetViolations(), recordViolations(err))
+}
+
+func TestNewRecordValidation(t *testing.T) {
+	ontology := filepath.Join(t.TempDir(), "skills.txt")
+	require.NoError(t, os.WriteFile(ontology, []byte("category1/class1\n"), 0o600))
+
+	pipeline, err := newRecordValidation(routingconfig.RecordValidationConfig{
+		Schema:              true,
+		Taxonomy:            true,
+		SkillsOntology:      ontology,
+		Locators:            true,
+		LocatorReachability: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		publishcheck.RuleSchema,
+		publishcheck.RuleTaxonomy,
+		publishcheck.RuleLocators,
+		publishcheck.RuleLocatorReachability,
+	}, pipeline.Rules())
+
+	pipeline, err = newRecordValidation(routingconfig.RecordValidationConfig{})
+	require.NoError(t, err)
+	assert.False(t, pipeline.Enabled())
+}
@@ -0,0 +1,365 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// replicateFunc stores a record pulled from another provider and publishes it,
+// so that this peer becomes one of its providers. A zero ttl means the record does not expire.
+type replicateFunc func(ctx context.Context, record *corev1.Record, ttl time.Duration) error
+
+// replicationPolicy keeps at least minProviders providers of records with a label.
+type replicationPolicy struct {
+	label        types.Label
+	minProviders int
+}
+
+// newReplicationPolicies builds the replication policies from config.
+// Labels must be in a built-in or registered namespace, so custom label
+// namespaces must be registered first.
+func newReplicationPolicies(cfgs []routingconfig.ReplicationPolicyConfig) ([]replicationPolicy, error) {
+	policies := make([]replicationPolicy, 0, len(cfgs))
+	seen := make(map[types.Label]bool, len(cfgs))
+
+	for _, cfg := range cfgs {
+		label := types.Label(strings.TrimSuffix(cfg.Label, "/"))
+		if label.Type() == types.LabelTypeUnknown || label.Value() == "" {
+			return nil, fmt.Errorf("replication policy label %q must be a label of a known namespace, e.g. /domains/research", cfg.Label)
+		}
+
+		if seen[label] {
+			return nil, fmt.Errorf("duplicate replication policy for label %q", cfg.Label)
+		}
+
+		if cfg.MinProviders < 2 || cfg.MinProviders > MaxReplicationProviders { //nolint:mnd
+			return nil, fmt.Errorf("minimum providers %d for label %q must be between 2 and %d",
+				cfg.MinProviders, cfg.Label, MaxReplicationProviders)
+		}
+
+		seen[label] = true
+
+		policies = append(policies, replicationPolicy{
+			label:        label,
+			minProviders: cfg.MinProviders,
+		})
+	}
+
+	return policies, nil
+}
+
+// matches reports whether the policy applies to a label: the policy label itself or a label below it.
+func (p replicationPolicy) matches(label types.Label) bool {
+	return label == p.label || strings.HasPrefix(label.String(), p.label.String()+"/")
+}
+
+// replicationCandidate is a remote record matched by a replication policy.
+type replicationCandidate struct {
+	cid          string
+	minProviders int      // Highest minimum of the matching policies
+	providers    []string // Peers the record's labels were cached from
+}
+
+// replicator tracks the records matched by replication policies across runs.
+type replicator struct {
+	policies []replicationPolicy
+
+	mu      sync.Mutex
+	checked map[string]time.Time // Last check of each candidate record
+}
+
+func newReplicator(policies []replicationPolicy) *replicator {
+	return &replicator{
+		policies: policies,
+		checked:  make(map[string]time.Time),
+	}
+}
+
+// candidates returns the remote records in the label cache matched by a policy that this peer
+// does not provide itself, up to limit records, the least recently checked first.
+// Records of expired labels are ignored.
+func (rp *replicator) candidates(entries []NamespaceEntry, localPeerID string, now time.Time, limit int) []replicationCandidate {
+	matched := make(map[string]*replicationCandidate)
+	local := make(map[string]bool)
+
+	for _, entry := range entries {
+		label, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil {
+			continue
+		}
+
+		if keyPeerID == localPeerID {
+			local[keyCID] = true
+
+			continue
+		}
+
+		if labelExpired(entry.Value, now) {
+			continue
+		}
+
+		for _, policy := range rp.policies {
+			if !policy.matches(label) {
+				continue
+			}
+
+			candidate, ok := matched[keyCID]
+			if !ok {
+				candidate = &replicationCandidate{cid: keyCID}
+				matched[keyCID] = candidate
+			}
+
+			candidate.minProviders = max(candidate.minProviders, policy.minProviders)
+
+			if !slices.Contains(candidate.providers, keyPeerID) {
+				candidate.providers = append(candidate.providers, keyPeerID)
+			}
+		}
+	}
+
+	candidates := make([]replicationCandidate, 0, len(matched))
+
+	for recordCID, candidate := range matched {
+		if !local[recordCID] {
+			candidates = append(candidates, *candidate)
+		}
+	}
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	// Forget records that are no longer candidates
+	for recordCID := range rp.checked {
+		if _, ok := matched[recordCID]; !ok || local[recordCID] {
+			delete(rp.checked, recordCID)
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b replicationCandidate) int {
+		return cmp.Or(rp.checked[a.cid].Compare(rp.checked[b.cid]), cmp.Compare(a.cid, b.cid))
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	for _, candidate := range candidates {
+		rp.checked[candidate.cid] = now
+	}
+
+	return candidates
+}
+
+// runReplication checks the provider counts of the records matched by the replication
+// policies and replicates under-replicated records.
+func (r *routeRemote) runReplication(ctx context.Context, replicate replicateFunc) {
+	localPeerID := r.server.Host().ID().String()
+
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		remoteLogger.Error("Failed to get namespace entries for replication", "error", err)
+
+		return
+	}
+
+	candidates := r.replication.candidates(entries, localPeerID, time.Now(), MaxReplicationChecks)
+	if len(candidates) == 0 {
+		return
+	}
+
+	remoteLogger.Debug("Checking replication of records", "records", len(candidates))
+
+	sem := make(chan struct{}, ReplicationConcurrency)
+
+	var wg sync.WaitGroup
+
+	for _, candidate := range candidates {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+
+			return
+		}
+
+		wg.Add(1)
+
+		go func(candidate replicationCandidate) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			checkCtx, cancel := context.WithTimeout(ctx, ReplicationLookupTimeout)
+			defer cancel()
+
+			result := r.replicateIfNeeded(checkCtx, candidate, replicate)
+			metrics.ReplicationChecks.WithLabelValues(result).Inc()
+		}(candidate)
+	}
+
+	wg.Wait()
+}
+
+// replicateIfNeeded counts the DHT providers of a record and replicates it from one of
+// its known providers if it has fewer than required. Returns the metrics result of the check.
+func (r *routeRemote) replicateIfNeeded(ctx context.Context, candidate replicationCandidate, replicate replicateFunc) string {
+	providers, err := r.countProviders(ctx, candidate.cid, candidate.minProviders)
+	if err != nil {
+		remoteLogger.Warn("Failed to count record providers", "cid", candidate.cid, "error", err)
+
+		return metrics.ResultFailure
+	}
+
+	if providers >= candidate.minProviders {
+		return metrics.ResultSatisfied
+	}
+
+	remoteLogger.Info("Replicating under-replicated record",
+		"cid", candidate.cid, "providers", providers, "minProviders", candidate.minProviders)
+
+	for _, peerID := range candidate.providers {
+		if r.reputation.IsExcluded(peerID) {
+			continue
+		}
+
+		err := r.replicateFrom(ctx, candidate.cid, peerID, replicate)
+		if err == nil {
+			remoteLogger.Info("Replicated record", "cid", candidate.cid, "peer", peerID)
+
+			return metrics.ResultSuccess
+		}
+
+		remoteLogger.Warn("Failed to replicate record from peer", "cid", candidate.cid, "peer", peerID, "error", err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return metrics.ResultFailure
+}
+
+// replicateFrom pulls a record from a provider and replicates it with its remaining TTL.
+func (r *routeRemote) replicateFrom(ctx context.Context, cidStr, peerID string, replicate replicateFunc) error {
+	record, metadata, err := r.pullFromProvider(ctx, cidStr, peerID)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := metadata.ExpiresAt
+
+	var ttl time.Duration
+
+	if !expiresAt.IsZero() {
+		ttl = time.Until(expiresAt)
+		if ttl < types.MinRecordTTL {
+			return fmt.Errorf("record expires at %s", expiresAt)
+		}
+	}
+
+	return replicate(ctx, record, ttl)
+}
+
+// pullFromProvider pulls a record from one of its providers and records the outcome
+// in the provider's reputation. Returns the record and its announcement metadata.
+func (r *routeRemote) pullFromProvider(ctx context.Context, cidStr, peerID string) (*corev1.Record, rpc.RecordMetadata, error) {
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return nil, rpc.RecordMetadata{}, fmt.Errorf("invalid peer ID: %w", err)
+	}
+
+	pullStart := time.Now()
+
+	record, metadata, err := r.pull(ctx, pid, &corev1.RecordRef{Cid: cidStr})
+
+	// Refusing to serve an access-gated record is not a failure of the provider
+	if errors.Is(err, rpc.ErrAccessGated) {
+		r.reputation.RecordPull(peerID, time.Since(pullStart), nil)
+	} else {
+		r.reputation.RecordPull(peerID, time.Since(pullStart), err)
+	}
+
+	if errors.Is(err, rpc.ErrContentMismatch) {
+		r.reputation.RecordContentMismatch(peerID)
+	}
+
+	if err != nil {
+		return nil, rpc.RecordMetadata{}, fmt.Errorf("failed to pull record: %w", err)
+	}
+
+	return record, metadata, nil
+}
+
+// countProviders counts the peers other than this one that provide a record in the DHT,
+// stopping once limit providers are found.
+func (r *routeRemote) countProviders(ctx context.Context, cidStr string, limit int) (int, error) {
+	providers, err := r.findProviders(ctx, cidStr, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(providers), nil
+}
+
+// replicate stores a record pulled from another provider and publishes it locally and
+// to the network, so that this peer becomes one of its providers.
+func (r *route) replicate(ctx context.Context, record *corev1.Record, ttl time.Duration) error {
+	if _, err := r.remote.storeAPI.Push(ctx, record); err != nil {
+		return fmt.Errorf("failed to store record: %w", err)
+	}
+
+	errs := r.PublishBatch(ctx, []types.Record{adapters.NewRecordAdapter(record)}, types.PublishOptions{TTL: ttl})
+
+	return errs[0]
+}
+
+// startReplication starts the background task that enforces the replication policies.
+// It does nothing if no replication policies are configured.
+func (r *routeRemote) startReplication(replicate replicateFunc) {
+	if r.replication == nil {
+		return
+	}
+
+	remoteLogger.Info("Starting replication task",
+		"policies", len(r.replication.policies), "interval", ReplicationInterval)
+
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(ReplicationInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping replication task")
+
+				return
+			case <-ticker.C:
+				start := time.Now()
+
+				r.runReplication(r.ctx, replicate)
+
+				metrics.TaskDuration.WithLabelValues(metrics.TaskReplicate).Observe(time.Since(start).Seconds())
+			}
+		}
+	}()
+}
@@ -0,0 +1,83 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReplicationPolicies(t *testing.T) {
+	policies, err := newReplicationPolicies([]routingconfig.ReplicationPolicyConfig{
+		{Label: "/domains/research", MinProviders: 3},
+		{Label: "/skills/AI/", MinProviders: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []replicationPolicy{
+		{label: "/domains/research", minProviders: 3},
+		{label: "/skills/AI", minProviders: 2},
+	}, policies)
+
+	invalid := [][]routingconfig.ReplicationPolicyConfig{
+		{{Label: "/unknown/research", MinProviders: 3}},
+		{{Label: "/domains/", MinProviders: 3}},
+		{{Label: "/domains/research", MinProviders: 1}},
+		{{Label: "/domains/research", MinProviders: MaxReplicationProviders + 1}},
+		{{Label: "/domains/research", MinProviders: 3}, {Label: "/domains/research", MinProviders: 2}},
+	}
+	for _, cfgs := range invalid {
+		_, err := newReplicationPolicies(cfgs)
+		assert.Error(t, err, "config %v", cfgs)
+	}
+}
+
+func TestReplicationPolicy_Matches(t *testing.T) {
+	policy := replicationPolicy{label: "/domains/research", minProviders: 3}
+
+	assert.True(t, policy.matches("/domains/research"))
+	assert.True(t, policy.matches("/domains/research/ml"))
+	assert.False(t, policy.matches("/domains/researchers"))
+	assert.False(t, policy.matches("/skills/research"))
+}
+
+func TestReplicator_Candidates(t *testing.T) {
+	rp := newReplicator([]replicationPolicy{
+		{label: "/domains/research", minProviders: 3},
+		{label: "/skills/AI", minProviders: 5},
+	})
+	now := time.Now()
+	expired := []byte(`{"expires_at":"2000-01-01T00:00:00Z"}`)
+
+	entries := []NamespaceEntry{
+		{Key: "/domains/research/cid1/peer1", Value: []byte(`{}`)},
+		{Key: "/domains/research/ml/cid1/peer2", Value: []byte(`{}`)},
+		{Key: "/skills/AI/cid1/peer1", Value: []byte(`{}`)},
+		{Key: "/domains/research/cid2/peer1", Value: []byte(`{}`)},
+		{Key: "/domains/research/cid3/peer1", Value: []byte(`{}`)},
+		{Key: "/domains/research/cid3/local", Value: []byte(`{}`)}, // already provided locally
+		{Key: "/domains/research/cid4/peer1", Value: expired},
+		{Key: "/domains/finance/cid5/peer1", Value: []byte(`{}`)}, // no matching policy
+	}
+
+	candidates := rp.candidates(entries, "local", now, 10)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, replicationCandidate{cid: "cid1", minProviders: 5, providers: []string{"peer1", "peer2"}}, candidates[0])
+	assert.Equal(t, "cid2", candidates[1].cid)
+
+	// The least recently checked records are checked first
+	entries = append(entries, NamespaceEntry{Key: "/domains/research/cid0/peer1", Value: []byte(`{}`)})
+
+	candidates = rp.candidates(entries, "local", now.Add(time.Hour), 2)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, "cid0", candidates[0].cid)
+	assert.Equal(t, "cid1", candidates[1].cid)
+
+	candidates = rp.candidates(entries, "local", now.Add(2*time.Hour), 1)
+	require.Len(t, candidates, 1)
+	assert.Equal(t, "cid2", candidates[0].cid)
+}
@@ -0,0 +1,127 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// randomize varies a duration uniformly by up to ±fraction of it.
+func randomize(d time.Duration, fraction float64) time.Duration {
+	return time.Duration(float64(d) * (1 + fraction*(2*rand.Float64()-1))) //nolint:gosec,mnd // Scheduling jitter does not need a secure source
+}
+
+// jitterInterval varies a republish interval by up to ±RepublishJitter,
+// so that peers started together do not republish at the same time.
+func jitterInterval(interval time.Duration) time.Duration {
+	return randomize(interval, RepublishJitter)
+}
+
+// republishCandidate is a local record selected for a republishing cycle.
+type republishCandidate struct {
+	cid      string
+	metadata localRecordMetadata
+}
+
+// expiresAt estimates when the DHT provider record of the candidate expires,
+// from when it was last announced. Zero if it was never announced with a stored time.
+func (c republishCandidate) expiresAt() time.Time {
+	announced := c.metadata.PublishedAt
+	if c.metadata.AnnouncedAt.After(announced) {
+		announced = c.metadata.AnnouncedAt
+	}
+
+	if announced.IsZero() {
+		return time.Time{}
+	}
+
+	return announced.Add(RecordTTL)
+}
+
+// sortByExpiry orders candidates by the estimated expiry of their provider records,
+// so that records nearing expiry are republished first. Unknown expiries come first.
+func sortByExpiry(candidates []republishCandidate) {
+	slices.SortStableFunc(candidates, func(a, b republishCandidate) int {
+		return a.expiresAt().Compare(b.expiresAt())
+	})
+}
+
+// republishPacer spreads the records of republishing cycles across their interval
+// and slows republishing down while the DHT is slow to answer.
+type republishPacer struct {
+	mu      sync.Mutex
+	latency time.Duration // Moving average of the latency of republishing a record, zero until observed
+}
+
+func newRepublishPacer() *republishPacer {
+	return &republishPacer{}
+}
+
+// observe records the latency of republishing a record, i.e. of its DHT provide and GossipSub publish.
+func (p *republishPacer) observe(latency time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.latency == 0 {
+		p.latency = latency
+
+		return
+	}
+
+	p.latency = time.Duration(RepublishLatencySmoothing*float64(latency) + (1-RepublishLatencySmoothing)*float64(p.latency))
+}
+
+// throttle returns the factor by which republishing is slowed down: the ratio of the
+// current republish latency to RepublishTargetLatency, between 1 and RepublishMaxThrottle.
+func (p *republishPacer) throttle() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return min(max(float64(p.latency)/float64(RepublishTargetLatency), 1), RepublishMaxThrottle)
+}
+
+// step returns the average time between the records of a cycle republishing n records,
+// spreading them across RepublishSpread of the cycle interval.
+func (p *republishPacer) step(interval time.Duration, n int) time.Duration {
+	if n == 0 {
+		return 0
+	}
+
+	return time.Duration(float64(interval) * RepublishSpread / float64(n))
+}
+
+// delay returns how long to wait before republishing the next record of a cycle:
+// a jittered step, slowed down by the throttle, but never past RepublishExpiryMargin
+// before the record's provider record expires. Records of unknown expiry are not delayed.
+func (p *republishPacer) delay(step time.Duration, expiresAt, now time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return 0
+	}
+
+	paced := time.Duration(float64(randomize(step, RepublishStepJitter)) * p.throttle())
+	deadline := expiresAt.Add(-RepublishExpiryMargin).Sub(now)
+
+	return max(min(paced, deadline), 0)
+}
+
+// wait sleeps for the delay, returning false if the context is cancelled first.
+func (p *republishPacer) wait(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByExpiry(t *testing.T) {
+	now := time.Now()
+
+	candidates := []republishCandidate{
+		{cid: "announced", metadata: localRecordMetadata{PublishedAt: now.Add(-3 * time.Hour), AnnouncedAt: now.Add(-time.Hour)}},
+		{cid: "published", metadata: localRecordMetadata{PublishedAt: now.Add(-2 * time.Hour)}},
+		{cid: "unknown"},
+	}
+
+	sortByExpiry(candidates)
+
+	assert.Equal(t, "unknown", candidates[0].cid)
+	assert.Equal(t, "published", candidates[1].cid)
+	assert.Equal(t, "announced", candidates[2].cid)
+	assert.Equal(t, now.Add(-time.Hour+RecordTTL), candidates[2].expiresAt())
+}
+
+func TestRepublishPacer_Throttle(t *testing.T) {
+	pacer := newRepublishPacer()
+	assert.InDelta(t, 1.0, pacer.throttle(), 0)
+
+	pacer.observe(RepublishTargetLatency / 2)
+	assert.InDelta(t, 1.0, pacer.throttle(), 0)
+
+	for range 50 {
+		pacer.observe(3 * RepublishTargetLatency)
+	}
+
+	assert.InDelta(t, 3.0, pacer.throttle(), 0.01)
+
+	for range 50 {
+		pacer.observe(100 * RepublishTargetLatency)
+	}
+
+	assert.InDelta(t, RepublishMaxThrottle, pacer.throttle(), 0)
+}
+
+func TestRepublishPacer_Delay(t *testing.T) {
+	pacer := newRepublishPacer()
+	now := time.Now()
+	step := pacer.step(RepublishInterval, 100)
+
+	assert.Equal(t, time.Duration(float64(RepublishInterval)*RepublishSpread/100), step)
+	assert.Zero(t, pacer.step(RepublishInterval, 0))
+
+	// Records of unknown expiry are not delayed
+	assert.Zero(t, pacer.delay(step, time.Time{}, now))
+
+	// Records far from expiry wait a jittered step
+	delay := pacer.delay(step, now.Add(RecordTTL), now)
+	assert.GreaterOrEqual(t, delay, time.Duration(float64(step)*(1-RepublishStepJitter)))
+	assert.LessOrEqual(t, delay, time.Duration(float64(step)*(1+RepublishStepJitter)))
+
+	// Records nearing expiry are not delayed past the expiry margin, however slow the DHT is
+	for range 10 {
+		pacer.observe(100 * RepublishTargetLatency)
+	}
+
+	expiresAt := now.Add(RepublishExpiryMargin + time.Minute)
+	assert.LessOrEqual(t, pacer.delay(time.Hour, expiresAt, now), time.Minute)
+	assert.Zero(t, pacer.delay(time.Hour, now.Add(time.Minute), now))
+}
+
+func TestJitterInterval(t *testing.T) {
+	for range 100 {
+		interval := jitterInterval(RepublishInterval)
+		assert.GreaterOrEqual(t, interval, time.Duration(float64(RepublishInterval)*(1-RepublishJitter)))
+		assert.LessOrEqual(t, interval, time.Duration(float64(RepublishInterval)*(1+RepublishJitter)))
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// republishStrategy republishes local records with labels in a namespace
+// at their own interval instead of every RepublishInterval.
+type republishStrategy struct {
+	namespace types.LabelType
+	interval  time.Duration
+}
+
+// newRepublishStrategies builds the republish strategies from config.
+// Namespaces must be built-in or registered, so custom label namespaces
+// must be registered first.
+func newRepublishStrategies(cfgs []routingconfig.RepublishStrategyConfig) ([]republishStrategy, error) {
+	strategies := make([]republishStrategy, 0, len(cfgs))
+	seen := make(map[types.LabelType]bool, len(cfgs))
+
+	for _, cfg := range cfgs {
+		namespace, ok := types.ParseLabelType(cfg.Namespace)
+		if !ok {
+			return nil, fmt.Errorf("unknown label namespace %q in republish strategy", cfg.Namespace)
+		}
+
+		if seen[namespace] {
+			return nil, fmt.Errorf("duplicate republish strategy for label namespace %q", cfg.Namespace)
+		}
+
+		if cfg.Interval < MinRepublishInterval || cfg.Interval > RepublishInterval {
+			return nil, fmt.Errorf("republish interval %s for label namespace %q must be between %s and %s",
+				cfg.Interval, cfg.Namespace, MinRepublishInterval, RepublishInterval)
+		}
+
+		seen[namespace] = true
+
+		strategies = append(strategies, republishStrategy{
+			namespace: namespace,
+			interval:  cfg.Interval,
+		})
+	}
+
+	return strategies, nil
+}
+
+// assignRepublishStrategies maps each local record CID with labels in a strategy's
+// namespace to the namespace of its shortest-interval strategy, so each record is
+// republished by exactly one strategy. Records without an entry use RepublishInterval.
+func assignRepublishStrategies(
+	ctx context.Context,
+	dstore types.Datastore,
+	localPeerID string,
+	strategies []republishStrategy,
+) (map[string]types.LabelType, error) {
+	assigned := make(map[string]types.LabelType)
+	intervals := make(map[string]time.Duration)
+
+	for _, strategy := range strategies {
+		results, err := dstore.Query(ctx, query.Query{
+			Prefix:   strategy.namespace.Prefix(),
+			KeysOnly: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query labels in namespace %q: %w", strategy.namespace, err)
+		}
+
+		for result := range results.Next() {
+			if result.Error != nil {
+				continue
+			}
+
+			_, cid, keyPeerID, err := ParseEnhancedLabelKey(result.Key)
+			if err != nil || keyPeerID != localPeerID {
+				continue
+			}
+
+			if interval, ok := intervals[cid]; ok && interval <= strategy.interval {
+				continue
+			}
+
+			intervals[cid] = strategy.interval
+			assigned[cid] = strategy.namespace
+		}
+
+		results.Close()
+	}
+
+	return assigned, nil
+}
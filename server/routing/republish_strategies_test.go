@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRepublishStrategies(t *testing.T) {
+	strategies, err := newRepublishStrategies([]routingconfig.RepublishStrategyConfig{
+		{Namespace: "locators", Interval: time.Hour},
+		{Namespace: "skills", Interval: 12 * time.Hour},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []republishStrategy{
+		{namespace: types.LabelTypeLocator, interval: time.Hour},
+		{namespace: types.LabelTypeSkill, interval: 12 * time.Hour},
+	}, strategies)
+
+	invalid := [][]routingconfig.RepublishStrategyConfig{
+		{{Namespace: "unknown", Interval: time.Hour}},
+		{{Namespace: "skills", Interval: time.Second}},
+		{{Namespace: "skills", Interval: 48 * time.Hour}},
+		{{Namespace: "skills", Interval: time.Hour}, {Namespace: "skills", Interval: 2 * time.Hour}},
+	}
+	for _, cfgs := range invalid {
+		_, err := newRepublishStrategies(cfgs)
+		assert.Error(t, err, "config %v", cfgs)
+	}
+}
+
+func TestAssignRepublishStrategies(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	labels := []struct {
+		label  string
+		cid    string
+		peerID string
+	}{
+		{"/skills/AI", "cid-skills", testLocalPeerID},
+		{"/skills/AI", "cid-both", testLocalPeerID},
+		{"/locators/docker_image", "cid-both", testLocalPeerID},
+		{"/domains/research", "cid-domains", testLocalPeerID},
+		{"/locators/docker_image", "cid-remote", "remote-peer"},
+	}
+	for _, l := range labels {
+		key := BuildEnhancedLabelKey(types.Label(l.label), l.cid, l.peerID)
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey(key), []byte("metadata")))
+	}
+
+	assigned, err := assignRepublishStrategies(ctx, dstore, testLocalPeerID, []republishStrategy{
+		{namespace: types.LabelTypeSkill, interval: 12 * time.Hour},
+		{namespace: types.LabelTypeLocator, interval: time.Hour},
+	})
+	require.NoError(t, err)
+
+	// Records with labels in several namespaces use the shortest interval;
+	// records without labels in a configured namespace and remote labels are unassigned
+	assert.Equal(t, map[string]types.LabelType{
+		"cid-skills": types.LabelTypeSkill,
+		"cid-both":   types.LabelTypeLocator,
+	}, assigned)
+}
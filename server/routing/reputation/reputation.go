@@ -0,0 +1,314 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reputation tracks per-peer behaviour of remote directory peers
+// and turns it into a score used to deprioritize or exclude misbehaving peers.
+//
+// Scores are computed from three signals:
+//   - Announcement validity: share of GossipSub label announcements that passed validation
+//   - Pull success: share of fallback record pulls that succeeded
+//   - Latency: average latency of successful pulls
+//
+// Serving a record that does not match the announced CID is not an accident,
+// so such peers are excluded right away regardless of their score.
+//
+// Rates use a Laplace prior so that peers with few samples start from a
+// neutral score instead of being judged by a single observation.
+package reputation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/utils/logging"
+)
+
+var logger = logging.Logger("routing/reputation")
+
+// Relative weights of the individual signals in the overall score.
+const (
+	announcementWeight = 0.4
+	pullWeight         = 0.4
+	latencyWeight      = 0.2
+)
+
+const (
+	// NeutralScore is the score of a peer without any observations.
+	NeutralScore = 0.5
+
+	// ExclusionThreshold is the score below which a peer is excluded
+	// from search results, once it has at least MinSamples observations.
+	ExclusionThreshold = 0.2
+
+	// MinSamples is the number of observations required before a peer can be excluded.
+	MinSamples = 10
+
+	// ReferenceLatency is the pull latency at which the latency signal drops to 0.5.
+	ReferenceLatency = time.Second
+
+	// MaxTrackedPeers bounds the number of peers kept in memory.
+	// The least recently seen peer is dropped when the limit is reached.
+	MaxTrackedPeers = 4096
+
+	// GossipSubScoreScale maps a score in [0, 1] to a GossipSub application-specific
+	// score in [-GossipSubScoreScale/2, GossipSubScoreScale/2], centered on NeutralScore.
+	GossipSubScoreScale = 100.0
+)
+
+// PeerStats holds the observations recorded for a single peer.
+type PeerStats struct {
+	// ValidAnnouncements is the number of announcements that passed validation.
+	ValidAnnouncements uint64 `json:"valid_announcements"`
+
+	// InvalidAnnouncements is the number of announcements that were rejected.
+	InvalidAnnouncements uint64 `json:"invalid_announcements"`
+
+	// PullSuccesses is the number of records successfully pulled from the peer.
+	PullSuccesses uint64 `json:"pull_successes"`
+
+	// PullFailures is the number of failed pulls from the peer.
+	PullFailures uint64 `json:"pull_failures"`
+
+	// ContentMismatches is the number of pulls that returned content
+	// not matching the requested CID. They are also counted as pull failures.
+	ContentMismatches uint64 `json:"content_mismatches"`
+
+	// PullLatency is the total latency of successful pulls.
+	PullLatency time.Duration `json:"pull_latency"`
+
+	// LastSeen is the time of the most recent observation.
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Samples returns the total number of observations.
+func (s PeerStats) Samples() uint64 {
+	return s.ValidAnnouncements + s.InvalidAnnouncements + s.PullSuccesses + s.PullFailures
+}
+
+// AverageLatency returns the average latency of successful pulls.
+func (s PeerStats) AverageLatency() time.Duration {
+	if s.PullSuccesses == 0 {
+		return 0
+	}
+
+	return s.PullLatency / time.Duration(s.PullSuccesses) //nolint:gosec // Pull count fits in int64
+}
+
+// ServedMismatchedContent reports whether the peer ever served content
+// not matching the requested CID.
+func (s PeerStats) ServedMismatchedContent() bool {
+	return s.ContentMismatches > 0
+}
+
+// Score returns the reputation score of the peer in the range [0, 1].
+// Only signals with observations contribute, so a peer that only sends
+// announcements is judged by its announcements alone.
+func (s PeerStats) Score() float64 {
+	var score, weight float64
+
+	if s.ValidAnnouncements+s.InvalidAnnouncements > 0 {
+		score += announcementWeight * laplaceRate(s.ValidAnnouncements, s.InvalidAnnouncements)
+		weight += announcementWeight
+	}
+
+	if s.PullSuccesses+s.PullFailures > 0 {
+		score += pullWeight * laplaceRate(s.PullSuccesses, s.PullFailures)
+		weight += pullWeight
+	}
+
+	if avg := s.AverageLatency(); avg > 0 {
+		score += latencyWeight / (1 + float64(avg)/float64(ReferenceLatency))
+		weight += latencyWeight
+	}
+
+	if weight == 0 {
+		return NeutralScore
+	}
+
+	return score / weight
+}
+
+func laplaceRate(good, bad uint64) float64 {
+	return float64(good+1) / float64(good+bad+2) //nolint:mnd
+}
+
+// Tracker records per-peer observations. It is safe for concurrent use.
+// A nil Tracker ignores observations and reports every peer as neutral.
+type Tracker struct {
+	mu    sync.RWMutex
+	peers map[string]*PeerStats
+	now   func() time.Time
+}
+
+// New creates an empty Tracker.
+func New() *Tracker {
+	return &Tracker{
+		peers: make(map[string]*PeerStats),
+		now:   time.Now,
+	}
+}
+
+// RecordAnnouncement records whether an announcement received from the peer was valid.
+func (t *Tracker) RecordAnnouncement(peerID string, valid bool) {
+	t.update(peerID, func(stats *PeerStats) {
+		if valid {
+			stats.ValidAnnouncements++
+		} else {
+			stats.InvalidAnnouncements++
+		}
+	})
+}
+
+// RecordPull records the outcome of a record pull from the peer.
+// Latency is only taken into account for successful pulls.
+func (t *Tracker) RecordPull(peerID string, latency time.Duration, err error) {
+	t.update(peerID, func(stats *PeerStats) {
+		if err != nil {
+			stats.PullFailures++
+
+			return
+		}
+
+		stats.PullSuccesses++
+		stats.PullLatency += latency
+	})
+}
+
+// RecordContentMismatch records a pull from the peer that returned content
+// not matching the requested CID.
+func (t *Tracker) RecordContentMismatch(peerID string) {
+	t.update(peerID, func(stats *PeerStats) {
+		stats.PullFailures++
+		stats.ContentMismatches++
+	})
+}
+
+func (t *Tracker) update(peerID string, fn func(*PeerStats)) {
+	if t == nil || peerID == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, ok := t.peers[peerID]
+	if !ok {
+		if len(t.peers) >= MaxTrackedPeers {
+			t.evictLeastRecentlySeenLocked()
+		}
+
+		stats = &PeerStats{}
+		t.peers[peerID] = stats
+	}
+
+	fn(stats)
+	stats.LastSeen = t.now()
+
+	if !ok || stats.Samples() == MinSamples {
+		logger.Debug("Peer reputation updated", "peer", peerID, "score", stats.Score(), "samples", stats.Samples())
+	}
+}
+
+// evictLeastRecentlySeenLocked drops the peer that was observed least recently.
+// Must be called with the lock held.
+func (t *Tracker) evictLeastRecentlySeenLocked() {
+	var (
+		oldestID   string
+		oldestTime time.Time
+	)
+
+	for id, stats := range t.peers {
+		if oldestID == "" || stats.LastSeen.Before(oldestTime) {
+			oldestID = id
+			oldestTime = stats.LastSeen
+		}
+	}
+
+	delete(t.peers, oldestID)
+}
+
+// Stats returns the observations recorded for the peer, if any.
+func (t *Tracker) Stats(peerID string) (PeerStats, bool) {
+	if t == nil {
+		return PeerStats{}, false
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	stats, ok := t.peers[peerID]
+	if !ok {
+		return PeerStats{}, false
+	}
+
+	return *stats, true
+}
+
+// Snapshot returns the observations recorded for all tracked peers.
+func (t *Tracker) Snapshot() map[string]PeerStats {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	snapshot := make(map[string]PeerStats, len(t.peers))
+	for peerID, stats := range t.peers {
+		snapshot[peerID] = *stats
+	}
+
+	return snapshot
+}
+
+// Score returns the reputation score of the peer in the range [0, 1].
+// Unknown peers get NeutralScore.
+func (t *Tracker) Score(peerID string) float64 {
+	stats, ok := t.Stats(peerID)
+	if !ok {
+		return NeutralScore
+	}
+
+	return stats.Score()
+}
+
+// IsExcluded reports whether the peer served mismatched content,
+// or has enough observations and a score below ExclusionThreshold.
+func (t *Tracker) IsExcluded(peerID string) bool {
+	stats, ok := t.Stats(peerID)
+	if !ok {
+		return false
+	}
+
+	if stats.ServedMismatchedContent() {
+		return true
+	}
+
+	if stats.Samples() < MinSamples {
+		return false
+	}
+
+	return stats.Score() < ExclusionThreshold
+}
+
+// GossipSubScore returns the application-specific GossipSub score of the peer.
+// Peers above NeutralScore get a positive score and peers below get a negative one,
+// so that GossipSub prunes them from the mesh and eventually graylists them.
+// Peers that served mismatched content get the lowest score,
+// and other peers with fewer than MinSamples observations score 0.
+func (t *Tracker) GossipSubScore(peerID string) float64 {
+	stats, ok := t.Stats(peerID)
+	if !ok {
+		return 0
+	}
+
+	if stats.ServedMismatchedContent() {
+		return -NeutralScore * GossipSubScoreScale
+	}
+
+	if stats.Samples() < MinSamples {
+		return 0
+	}
+
+	return (stats.Score() - NeutralScore) * GossipSubScoreScale
+}
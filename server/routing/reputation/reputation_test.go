@@ -0,0 +1,128 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package reputation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker_UnknownPeerIsNeutral(t *testing.T) {
+	tracker := New()
+
+	assert.InDelta(t, NeutralScore, tracker.Score("peer1"), 1e-9)
+	assert.False(t, tracker.IsExcluded("peer1"))
+	assert.Zero(t, tracker.GossipSubScore("peer1"))
+
+	_, ok := tracker.Stats("peer1")
+	assert.False(t, ok)
+}
+
+func TestTracker_NilTrackerIsNoop(t *testing.T) {
+	var tracker *Tracker
+
+	tracker.RecordAnnouncement("peer1", false)
+	tracker.RecordPull("peer1", time.Second, errors.New("failed"))
+
+	assert.InDelta(t, NeutralScore, tracker.Score("peer1"), 1e-9)
+	assert.False(t, tracker.IsExcluded("peer1"))
+}
+
+func TestTracker_InvalidAnnouncementsExcludePeer(t *testing.T) {
+	tracker := New()
+
+	for range MinSamples - 1 {
+		tracker.RecordAnnouncement("spammer", false)
+	}
+
+	// Not enough samples to judge the peer yet
+	assert.Less(t, tracker.Score("spammer"), ExclusionThreshold)
+	assert.False(t, tracker.IsExcluded("spammer"))
+	assert.Zero(t, tracker.GossipSubScore("spammer"))
+
+	tracker.RecordAnnouncement("spammer", false)
+
+	assert.True(t, tracker.IsExcluded("spammer"))
+	assert.Less(t, tracker.GossipSubScore("spammer"), 0.0)
+
+	stats, ok := tracker.Stats("spammer")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(MinSamples), stats.InvalidAnnouncements)
+}
+
+func TestTracker_GoodPeerScoresAboveNeutral(t *testing.T) {
+	tracker := New()
+
+	for range MinSamples {
+		tracker.RecordAnnouncement("good", true)
+		tracker.RecordPull("good", 50*time.Millisecond, nil)
+	}
+
+	assert.Greater(t, tracker.Score("good"), NeutralScore)
+	assert.False(t, tracker.IsExcluded("good"))
+	assert.Greater(t, tracker.GossipSubScore("good"), 0.0)
+
+	stats, _ := tracker.Stats("good")
+	assert.Equal(t, 50*time.Millisecond, stats.AverageLatency())
+}
+
+func TestTracker_PullFailuresAndLatencyLowerScore(t *testing.T) {
+	tracker := New()
+
+	for range MinSamples {
+		tracker.RecordPull("fast", 10*time.Millisecond, nil)
+		tracker.RecordPull("slow", 5*time.Second, nil)
+		tracker.RecordPull("failing", 0, errors.New("connection refused"))
+	}
+
+	assert.Greater(t, tracker.Score("fast"), tracker.Score("slow"))
+	assert.Greater(t, tracker.Score("slow"), tracker.Score("failing"))
+	assert.True(t, tracker.IsExcluded("failing"))
+
+	// Failed pulls do not contribute to latency
+	stats, _ := tracker.Stats("failing")
+	assert.Zero(t, stats.AverageLatency())
+}
+
+func TestTracker_EvictsLeastRecentlySeenPeer(t *testing.T) {
+	tracker := New()
+
+	now := time.Now()
+	tracker.now = func() time.Time { return now }
+
+	for i := range MaxTrackedPeers {
+		now = now.Add(time.Second)
+		tracker.RecordAnnouncement(fmt.Sprintf("peer%d", i), true)
+	}
+
+	now = now.Add(time.Second)
+	tracker.RecordAnnouncement("newcomer", true)
+
+	_, ok := tracker.Stats("peer0")
+	assert.False(t, ok)
+
+	_, ok = tracker.Stats("newcomer")
+	assert.True(t, ok)
+
+	_, ok = tracker.Stats("peer1")
+	assert.True(t, ok)
+}
+
+func TestTracker_ContentMismatchExcludesPeerImmediately(t *testing.T) {
+	tracker := New()
+
+	tracker.RecordAnnouncement("liar", true)
+	tracker.RecordContentMismatch("liar")
+
+	assert.True(t, tracker.IsExcluded("liar"))
+	assert.InDelta(t, -NeutralScore*GossipSubScoreScale, tracker.GossipSubScore("liar"), 1e-9)
+
+	stats, _ := tracker.Stats("liar")
+	assert.Equal(t, uint64(1), stats.ContentMismatches)
+	assert.Equal(t, uint64(1), stats.PullFailures)
+}
@@ -0,0 +1,141 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EstimateResults estimates how many records Search would return for the request
+// from the cardinality sketches of the label cache, without scanning it.
+// Like Search, only remote records are counted, and records match at least
+// minMatchScore of the deduplicated queries, over the labels visible to the request's tenant.
+// If the label index is enabled, matching records are counted by the index instead.
+func (r *routeRemote) EstimateResults(ctx context.Context, req *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error) {
+	if !r.tenants.known(req.GetTenantId()) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown tenant %q", req.GetTenantId())
+	}
+
+	queries := deduplicateQueries(req.GetQueries())
+
+	minMatchScore := req.GetMinMatchScore()
+	if minMatchScore < DefaultMinMatchScore {
+		minMatchScore = DefaultMinMatchScore
+	}
+
+	scope := r.tenants.scope(req.GetTenantId())
+
+	estimated, total := r.cardinality.Estimate(queries, int(minMatchScore), matchLabelOfTenants(scope), labelOfTenants(scope))
+
+	if count, ok := r.countLabelIndexMatches(ctx, queries, minMatchScore, scope); ok {
+		estimated = count
+	}
+
+	return &routingv1.EstimateResultsResponse{
+		EstimatedCount: estimated,
+		TotalCount:     total,
+	}, nil
+}
+
+// matchLabelOfTenants returns a matcher reporting whether a query without boolean group
+// matches a single label of the given tenants. Labels are indexed with their tenant's namespace.
+func matchLabelOfTenants(scope []string) cardinality.Matcher {
+	return func(query *routingv1.RecordQuery, label string) bool {
+		return QueryMatchesLabels(query, labelsOfTenants([]types.Label{types.Label(label)}, scope...))
+	}
+}
+
+// labelOfTenants returns a visibility reporting whether a label belongs to the given tenants,
+// so that records of tenants outside the search scope are not counted.
+func labelOfTenants(scope []string) cardinality.Visibility {
+	return func(label string) bool {
+		tenant, _ := splitTenantLabel(types.Label(label))
+
+		return slices.Contains(scope, tenant)
+	}
+}
+
+// addToCardinalityIndex counts newly cached remote label keys in the cardinality index.
+func (r *routeRemote) addToCardinalityIndex(keys []string) {
+	for _, key := range keys {
+		label, cid, _, err := ParseEnhancedLabelKey(key)
+		if err != nil {
+			continue
+		}
+
+		r.cardinality.Add(label.String(), cid)
+	}
+}
+
+// rebuildLabelIndexes rebuilds the cardinality index and the provider sets from the remote
+// labels of the label cache, dropping labels that were removed since the last rebuild.
+func (r *routeRemote) rebuildLabelIndexes(ctx context.Context) {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		remoteLogger.Warn("Failed to rebuild label indexes", "error", err)
+
+		return
+	}
+
+	localPeerID := r.server.Host().ID().String()
+	now := time.Now()
+	index := cardinality.NewIndex()
+	providerSets := providerset.NewIndex()
+	lineage := newLineageIndex()
+
+	for _, entry := range entries {
+		label, cid, peerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || peerID == localPeerID || (labelExpired(entry.Value, now) && !r.pins.has(cid)) {
+			continue
+		}
+
+		index.Add(label.String(), cid)
+		addProvider(providerSets, entry.Key, entry.Value)
+		addSupersession(lineage, entry.Key, entry.Value)
+	}
+
+	r.cardinality.Replace(index)
+	r.providerSets.Replace(providerSets)
+	r.lineage.replace(lineage)
+
+	// Rebuild the label index if it fell behind the label cache since the last rebuild
+	r.refreshLabelIndex(ctx)
+
+	remoteLogger.Debug("Rebuilt label indexes", "labels", r.cardinality.Labels(), "records", r.providerSets.Records(), "superseded", r.lineage.len())
+}
+
+// startLabelIndexMaintenance builds the cardinality index and the provider sets and
+// periodically rebuilds them. Newly cached labels are added in between (see cacheRemoteLabels).
+func (r *routeRemote) startLabelIndexMaintenance() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		r.rebuildLabelIndexes(r.ctx)
+
+		ticker := time.NewTicker(CardinalityRebuildInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping label index maintenance")
+
+				return
+			case <-ticker.C:
+				r.rebuildLabelIndexes(r.ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateResults(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{
+		dstore:       dstore,
+		peerStats:    peerstats.New(),
+		cardinality:  cardinality.NewIndex(),
+		providerSets: providerset.NewIndex(),
+	}
+
+	// 10 records with /skills/AI/ML, announced by two peers, 5 of them also with /domains/research
+	for i := range 10 {
+		for _, peerID := range []string{"peer1", "peer2"} {
+			labels := &cacheMutation{}
+			labels.put(fmt.Sprintf("/skills/AI/ML/cid%d/%s", i, peerID), []byte(`{}`))
+
+			if i%2 == 0 {
+				labels.put(fmt.Sprintf("/domains/research/cid%d/%s", i, peerID), []byte(`{}`))
+			}
+
+			require.NoError(t, r.cacheRemoteLabels(t.Context(), peerID, labels, nil))
+		}
+	}
+
+	skillQuery := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}
+	domainQuery := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+
+	// Records announced by several peers are counted once, and prefix matches are counted
+	resp, err := r.EstimateResults(t.Context(), &routingv1.SearchRequest{Queries: []*routingv1.RecordQuery{skillQuery}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), resp.GetEstimatedCount())
+	assert.Equal(t, uint64(10), resp.GetTotalCount())
+
+	resp, err = r.EstimateResults(t.Context(), &routingv1.SearchRequest{Queries: []*routingv1.RecordQuery{domainQuery}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), resp.GetEstimatedCount())
+
+	minMatchScore := uint32(2)
+
+	resp, err = r.EstimateResults(t.Context(), &routingv1.SearchRequest{
+		Queries:       []*routingv1.RecordQuery{skillQuery, domainQuery},
+		MinMatchScore: &minMatchScore,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), resp.GetEstimatedCount())
+
+	// Without queries nothing matches, as in Search
+	resp, err = r.EstimateResults(t.Context(), &routingv1.SearchRequest{})
+	require.NoError(t, err)
+	assert.Zero(t, resp.GetEstimatedCount())
+}
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"slices"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// retrievalFilter decides whether the providers returned by a search can serve
+// the retrieval method required by the caller. Decisions are cached per peer
+// for the duration of a search.
+type retrievalFilter struct {
+	method  routingv1.RetrievalMethod
+	serves  func(ctx context.Context, peerID string, method routingv1.RetrievalMethod) bool
+	decided map[string]bool
+}
+
+func (r *routeRemote) newRetrievalFilter(method routingv1.RetrievalMethod) *retrievalFilter {
+	return &retrievalFilter{
+		method:  method,
+		serves:  r.servesRetrievalMethod,
+		decided: make(map[string]bool),
+	}
+}
+
+// allows reports whether the peer can serve the required retrieval method.
+// Any peer is allowed if no retrieval method is required.
+func (f *retrievalFilter) allows(ctx context.Context, peerID string) bool {
+	if f.method == routingv1.RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED {
+		return true
+	}
+
+	allowed, ok := f.decided[peerID]
+	if !ok {
+		allowed = f.serves(ctx, peerID, f.method)
+		f.decided[peerID] = allowed
+
+		if !allowed {
+			remoteLogger.Debug("Skipping peer that cannot serve the required retrieval method",
+				"peer", peerID, "method", f.method)
+		}
+	}
+
+	return allowed
+}
+
+// servesRetrievalMethod reports whether a peer can serve records with the retrieval method,
+// based on the protocols stored for it in the peerstore and its Directory API addresses.
+func (r *routeRemote) servesRetrievalMethod(ctx context.Context, peerID string, method routingv1.RetrievalMethod) bool {
+	var protocols []protocol.ID
+
+	if pid, err := peer.Decode(peerID); err == nil {
+		protocols, _ = r.server.Host().Peerstore().GetProtocols(pid)
+	}
+
+	var dirAddrs []string
+	if method == routingv1.RetrievalMethod_RETRIEVAL_METHOD_STREAMING {
+		dirAddrs = r.getDirectoryAPIAddresses(ctx, peerID)
+	}
+
+	return supportsRetrievalMethod(method, protocols, dirAddrs)
+}
+
+// supportsRetrievalMethod reports whether a peer with the given advertised protocols and
+// Directory API addresses supports the retrieval method:
+//   - RPC pulls require the routing RPC protocol. Peers without known protocols, i.e. peers
+//     that were never identified, are given the benefit of the doubt.
+//   - Streaming pulls require a Directory API address to call StoreService.Pull on.
+func supportsRetrievalMethod(method routingv1.RetrievalMethod, protocols []protocol.ID, dirAddrs []string) bool {
+	switch method {
+	case routingv1.RetrievalMethod_RETRIEVAL_METHOD_RPC_PULL:
+		return len(protocols) == 0 || slices.Contains(protocols, rpc.Protocol)
+	case routingv1.RetrievalMethod_RETRIEVAL_METHOD_STREAMING:
+		return len(dirAddrs) > 0
+	case routingv1.RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED:
+		return true
+	default:
+		return false
+	}
+}
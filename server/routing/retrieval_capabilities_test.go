@@ -0,0 +1,53 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupportsRetrievalMethod(t *testing.T) {
+	rpcPull := routingv1.RetrievalMethod_RETRIEVAL_METHOD_RPC_PULL
+	streaming := routingv1.RetrievalMethod_RETRIEVAL_METHOD_STREAMING
+	dirAddrs := []string{"/ip4/10.0.0.1/tcp/8888/dir/10.0.0.1:8888"}
+
+	// Unidentified peers are assumed to support RPC pulls
+	assert.True(t, supportsRetrievalMethod(rpcPull, nil, nil))
+	assert.True(t, supportsRetrievalMethod(rpcPull, []protocol.ID{"/ipfs/id/1.0.0", rpc.Protocol}, nil))
+	assert.False(t, supportsRetrievalMethod(rpcPull, []protocol.ID{"/ipfs/id/1.0.0"}, dirAddrs))
+
+	assert.True(t, supportsRetrievalMethod(streaming, nil, dirAddrs))
+	assert.False(t, supportsRetrievalMethod(streaming, []protocol.ID{rpc.Protocol}, nil))
+
+	assert.True(t, supportsRetrievalMethod(routingv1.RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED, nil, nil))
+}
+
+func TestRetrievalFilter(t *testing.T) {
+	calls := 0
+
+	filter := &retrievalFilter{
+		method: routingv1.RetrievalMethod_RETRIEVAL_METHOD_STREAMING,
+		serves: func(_ context.Context, peerID string, _ routingv1.RetrievalMethod) bool {
+			calls++
+
+			return peerID == "peer1"
+		},
+		decided: make(map[string]bool),
+	}
+
+	assert.True(t, filter.allows(t.Context(), "peer1"))
+	assert.False(t, filter.allows(t.Context(), "peer2"))
+	assert.False(t, filter.allows(t.Context(), "peer2"))
+	assert.Equal(t, 2, calls, "decisions are cached per peer")
+
+	// Nothing is filtered if no retrieval method is required
+	filter.method = routingv1.RetrievalMethod_RETRIEVAL_METHOD_UNSPECIFIED
+	assert.True(t, filter.allows(t.Context(), "peer2"))
+}
@@ -0,0 +1,182 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package revocation implements publisher-signed record revocations.
+//
+// A revocation withdraws the announcements a peer made for a CID. It is signed
+// with the publishing peer's Ed25519 identity key, so that receivers can verify
+// that only the peer that announced a record can take it down. Revocations are
+// distributed via GossipSub and stored in the DHT under Key(cid, peerID).
+package revocation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/internal/signing"
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// Namespace is the DHT namespace of revocation records.
+	Namespace = "revocations"
+
+	// SignatureDomain is prepended to the signed payload of revocations.
+	// It prevents revocation signatures from being replayed as label announcements
+	// or in other libp2p protocols that use the same identity key.
+	SignatureDomain = "dir/revocations/v1/signature"
+
+	// MaxReasonLength is the maximum length of the revocation reason.
+	MaxReasonLength = 256
+
+	// MaxSize is the maximum size of a marshalled revocation.
+	MaxSize = 4 * 1024 // 4KB
+)
+
+// Revocation withdraws the announcements of a record made by the signing peer.
+//
+// Example wire format:
+//
+//	{
+//	  "cid": "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+//	  "reason": "unpublished",
+//	  "timestamp": "2025-10-01T10:00:00Z",
+//	  "public_key": "CAESIB...",
+//	  "signature": "mE3s..."
+//	}
+type Revocation struct {
+	// CID is the content identifier of the revoked record.
+	CID string `json:"cid"`
+
+	// Reason is an optional human-readable reason for the takedown.
+	Reason string `json:"reason,omitempty"`
+
+	// Timestamp is when the revocation was issued. Announcements of the
+	// same record signed after this time lift the revocation.
+	Timestamp time.Time `json:"timestamp"`
+
+	// PublicKey is the marshalled libp2p public key of the publishing peer.
+	PublicKey []byte `json:"public_key"`
+
+	// Signature is the signature of SigningPayload() made with the
+	// publishing peer's identity key.
+	Signature []byte `json:"signature"`
+}
+
+// New creates an unsigned revocation of the record issued now.
+func New(recordCID, reason string) *Revocation {
+	return &Revocation{
+		CID:       recordCID,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	}
+}
+
+// Key returns the DHT key of the revocation of a record by a peer.
+// Format: /revocations/<cid>/<peer_id>.
+func Key(recordCID, peerID string) string {
+	return "/" + Namespace + "/" + recordCID + "/" + peerID
+}
+
+// Validate checks that the revocation is well-formed.
+// The signature is checked separately by Verify.
+func (r *Revocation) Validate() error {
+	if r.CID == "" {
+		return errors.New("missing CID")
+	}
+
+	if _, err := cid.Decode(r.CID); err != nil {
+		return fmt.Errorf("invalid CID %q: %w", r.CID, err)
+	}
+
+	if len(r.Reason) > MaxReasonLength {
+		return errors.New("reason too long")
+	}
+
+	if r.Timestamp.IsZero() {
+		return errors.New("missing timestamp")
+	}
+
+	return nil
+}
+
+// SigningPayload returns the canonical bytes covered by the revocation signature.
+//
+// Format: SignatureDomain \0 CID \0 reason \0 timestamp(RFC3339Nano, UTC).
+func (r *Revocation) SigningPayload() []byte {
+	var buf bytes.Buffer
+
+	buf.WriteString(SignatureDomain)
+	buf.WriteByte(0)
+	buf.WriteString(r.CID)
+	buf.WriteByte(0)
+	buf.WriteString(r.Reason)
+	buf.WriteByte(0)
+	buf.WriteString(r.Timestamp.UTC().Format(time.RFC3339Nano))
+
+	return buf.Bytes()
+}
+
+// Sign signs the revocation with the publishing peer's Ed25519 identity key.
+func (r *Revocation) Sign(key crypto.PrivKey) error {
+	publicKey, signature, err := signing.Sign(key, r.SigningPayload())
+	if err != nil {
+		return fmt.Errorf("failed to sign revocation: %w", err)
+	}
+
+	r.PublicKey = publicKey
+	r.Signature = signature
+
+	return nil
+}
+
+// Verify checks the revocation signature against the embedded public key.
+func (r *Revocation) Verify() error {
+	return signing.Verify(r.PublicKey, r.Signature, r.SigningPayload()) //nolint:wrapcheck
+}
+
+// SignerID returns the ID of the peer that signed the revocation.
+func (r *Revocation) SignerID() (peer.ID, error) {
+	return signing.SignerID(r.PublicKey) //nolint:wrapcheck
+}
+
+// Marshal serializes the revocation to JSON.
+func (r *Revocation) Marshal() ([]byte, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal revocation: %w", err)
+	}
+
+	if len(data) > MaxSize {
+		return nil, errors.New("revocation exceeds maximum size")
+	}
+
+	return data, nil
+}
+
+// Unmarshal deserializes a revocation and checks that it is well-formed and validly signed.
+func Unmarshal(data []byte) (*Revocation, error) {
+	if len(data) > MaxSize {
+		return nil, errors.New("revocation exceeds maximum size")
+	}
+
+	var revocation Revocation
+	if err := json.Unmarshal(data, &revocation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal revocation: %w", err)
+	}
+
+	if err := revocation.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid revocation: %w", err)
+	}
+
+	if err := revocation.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid revocation signature: %w", err)
+	}
+
+	return &revocation, nil
+}
@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package revocation
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+
+func TestRevocation_SignAndUnmarshal(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	rev := New(testCID, "takedown")
+	require.NoError(t, rev.Sign(key))
+
+	data, err := rev.Marshal()
+	require.NoError(t, err)
+
+	decoded, err := Unmarshal(data)
+	require.NoError(t, err)
+	assert.Equal(t, testCID, decoded.CID)
+	assert.Equal(t, "takedown", decoded.Reason)
+
+	signerID, err := decoded.SignerID()
+	require.NoError(t, err)
+
+	expectedID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, expectedID, signerID)
+}
+
+func TestRevocation_UnmarshalRejectsInvalid(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	// Unsigned
+	data, err := New(testCID, "").Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(data)
+	assert.Error(t, err)
+
+	// Tampered after signing
+	rev := New(testCID, "takedown")
+	require.NoError(t, rev.Sign(key))
+
+	rev.Reason = "something else"
+
+	data, err = rev.Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(data)
+	assert.Error(t, err)
+
+	// Invalid CID
+	rev = New("not-a-cid", "")
+	require.NoError(t, rev.Sign(key))
+
+	data, err = rev.Marshal()
+	require.NoError(t, err)
+
+	_, err = Unmarshal(data)
+	assert.Error(t, err)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, "/revocations/"+testCID+"/peer1", Key(testCID, "peer1"))
+}
@@ -0,0 +1,233 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/events"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Reasons of revocations issued by this peer.
+const (
+	// RevocationReasonUnpublished is the reason of revocations issued on Unpublish.
+	RevocationReasonUnpublished = "unpublished"
+
+	// RevocationReasonDeleted is the reason of revocations issued when a published record
+	// is deleted from the local store.
+	RevocationReasonDeleted = "deleted"
+)
+
+// Revoke withdraws the local peer's announcements of a record from the network.
+// The revocation is signed with the peer identity key, published via GossipSub
+// (if enabled) and stored in the DHT, so that remote peers purge the record's
+// cached labels and stop pulling it.
+func (r *routeRemote) Revoke(ctx context.Context, recordCID string, reason string) error {
+	if _, err := cid.Decode(recordCID); err != nil {
+		return routingerr.ErrCIDInvalid.Errorf("invalid CID %q: %w", recordCID, err)
+	}
+
+	// Publishing the record again must re-announce it, lifting the revocation
+	r.announcements.forget(recordCID)
+	r.publishDedup.forget(recordCID)
+
+	host := r.server.Host()
+
+	rev := revocation.New(recordCID, reason)
+	if err := rev.Sign(host.Peerstore().PrivKey(host.ID())); err != nil {
+		return status.Errorf(codes.FailedPrecondition, "failed to sign revocation: %v", err)
+	}
+
+	data, err := rev.Marshal()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to marshal revocation: %v", err)
+	}
+
+	var errs []error
+
+	if r.pubsubManager != nil {
+		if err := r.pubsubManager.PublishRevocation(ctx, rev); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := r.server.DHT().PutValue(ctx, revocation.Key(rev.CID, host.ID().String()), data); err != nil {
+		errs = append(errs, fmt.Errorf("failed to store revocation in DHT: %w", err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return status.Errorf(codes.Internal, "failed to revoke record %s: %v", rev.CID, err)
+	}
+
+	r.events.Emit(events.RecordRetracted(rev.CID, host.ID().String(), rev.Reason))
+	r.announcementSinks.Retract(host.ID().String(), rev)
+
+	remoteLogger.Info("Revoked record announcements", "cid", rev.CID, "reason", reason)
+
+	return nil
+}
+
+// handleRecordRevocation applies a revocation received via GossipSub.
+// The publisher is the verified signer of the revocation.
+func (r *routeRemote) handleRecordRevocation(ctx context.Context, publisher peer.ID, rev *revocation.Revocation) {
+	r.applyRevocation(ctx, publisher.String(), rev)
+}
+
+// applyRevocation stores a verified revocation of a remote peer and purges
+// the cached labels it revokes. Expired revocations are ignored.
+func (r *routeRemote) applyRevocation(ctx context.Context, peerID string, rev *revocation.Revocation) {
+	if time.Since(rev.Timestamp) > RevocationTTL {
+		return
+	}
+
+	// Keep the most recent revocation only
+	if existing, ok := r.getRevocation(ctx, rev.CID, peerID); ok && !rev.Timestamp.After(existing.Timestamp) {
+		return
+	}
+
+	data, err := rev.Marshal()
+	if err != nil {
+		remoteLogger.Warn("Failed to marshal revocation", "cid", rev.CID, "peer", peerID, "error", err)
+
+		return
+	}
+
+	if err := r.dstore.Put(ctx, datastore.NewKey(revocation.Key(rev.CID, peerID)), data); err != nil {
+		remoteLogger.Warn("Failed to store revocation", "cid", rev.CID, "peer", peerID, "error", err)
+
+		return
+	}
+
+	r.announcementSinks.Retract(peerID, rev)
+
+	purged := r.purgeRemoteRecordLabels(ctx, rev.CID, peerID)
+	if purged > 0 {
+		r.events.Emit(events.RecordRetracted(rev.CID, peerID, rev.Reason))
+	}
+
+	remoteLogger.Info("Applied record revocation",
+		"cid", rev.CID,
+		"peer", peerID,
+		"reason", rev.Reason,
+		"purgedLabels", purged)
+}
+
+// getRevocation returns the revocation of a record by a peer, if one is honoured.
+// Expired revocations are removed.
+func (r *routeRemote) getRevocation(ctx context.Context, cid, peerID string) (*revocation.Revocation, bool) {
+	key := datastore.NewKey(revocation.Key(cid, peerID))
+
+	data, err := r.dstore.Get(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+
+	rev, err := revocation.Unmarshal(data)
+	if err != nil || time.Since(rev.Timestamp) > RevocationTTL {
+		_ = r.dstore.Delete(ctx, key)
+
+		return nil, false
+	}
+
+	return rev, true
+}
+
+// isRevoked reports whether the announcements of a record by a peer are revoked.
+func (r *routeRemote) isRevoked(ctx context.Context, cid, peerID string) bool {
+	_, revoked := r.getRevocation(ctx, cid, peerID)
+
+	return revoked
+}
+
+// fetchRevocation looks up the revocation of a record by a peer in the DHT and applies it.
+// Returns whether the record is revoked.
+func (r *routeRemote) fetchRevocation(ctx context.Context, cid, peerID string) bool {
+	ctx, cancel := context.WithTimeout(ctx, RevocationLookupTimeout)
+	defer cancel()
+
+	// Values are checked by the DHT revocation validator
+	data, err := r.server.DHT().GetValue(ctx, revocation.Key(cid, peerID))
+	if err != nil {
+		return false
+	}
+
+	rev, err := revocation.Unmarshal(data)
+	if err != nil || time.Since(rev.Timestamp) > RevocationTTL {
+		return false
+	}
+
+	r.applyRevocation(ctx, peerID, rev)
+
+	return true
+}
+
+// admitAnnouncement reports whether an announcement of a record by a peer may be cached.
+// Announcements of revoked records are rejected, unless they were signed by the
+// publisher after the revocation was issued, which lifts the revocation.
+func (r *routeRemote) admitAnnouncement(ctx context.Context, peerID string, event *pubsub.RecordPublishEvent) bool {
+	rev, revoked := r.getRevocation(ctx, event.CID, peerID)
+	if !revoked {
+		return true
+	}
+
+	if !event.IsSigned() || !event.Timestamp.After(rev.Timestamp) {
+		return false
+	}
+
+	if signerID, err := event.SignerID(); err != nil || signerID.String() != peerID {
+		return false
+	}
+
+	if err := r.dstore.Delete(ctx, datastore.NewKey(revocation.Key(event.CID, peerID))); err != nil {
+		remoteLogger.Warn("Failed to lift revocation", "cid", event.CID, "peer", peerID, "error", err)
+	}
+
+	remoteLogger.Info("Lifted record revocation after re-signed announcement", "cid", event.CID, "peer", peerID)
+
+	return true
+}
+
+// purgeRemoteRecordLabels deletes all cached labels of a remote peer/CID combination.
+// Returns the number of deleted labels.
+func (r *routeRemote) purgeRemoteRecordLabels(ctx context.Context, cid, peerID string) int {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		remoteLogger.Error("Failed to get namespace entries for revocation", "error", err)
+
+		return 0
+	}
+
+	purge := &cacheMutation{}
+
+	for _, entry := range entries {
+		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil || keyCID != cid || keyPeerID != peerID {
+			continue
+		}
+
+		purge.delete(entry.Key)
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, purge); err != nil {
+		remoteLogger.Warn("Failed to purge revoked labels", "cid", cid, "peer", peerID, "error", err)
+
+		return 0
+	}
+
+	purged := len(purge.Deletes)
+	r.peerStats.AddLabels(peerID, -purged)
+
+	return purged
+}
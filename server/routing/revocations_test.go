@@ -0,0 +1,179 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/routing/sinks"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const revokedTestCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+
+func newTestPublisher(t *testing.T) (crypto.PrivKey, string) {
+	t.Helper()
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	return key, id.String()
+}
+
+func newTestRevocation(t *testing.T, key crypto.PrivKey, timestamp time.Time) *revocation.Revocation {
+	t.Helper()
+
+	rev := revocation.New(revokedTestCID, "takedown")
+	rev.Timestamp = timestamp
+	require.NoError(t, rev.Sign(key))
+
+	return rev
+}
+
+func TestApplyRevocation_PurgesLabels(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	key, publisher := newTestPublisher(t)
+
+	putTestLabel(t, dstore, "/skills/AI", revokedTestCID, publisher, time.Now())
+	putTestLabel(t, dstore, "/domains/research", revokedTestCID, publisher, time.Now())
+	putTestLabel(t, dstore, "/skills/AI", revokedTestCID, "other-peer", time.Now())
+
+	r := &routeRemote{dstore: dstore}
+	r.applyRevocation(t.Context(), publisher, newTestRevocation(t, key, time.Now()))
+
+	assert.True(t, r.isRevoked(t.Context(), revokedTestCID, publisher))
+	assert.False(t, r.isRevoked(t.Context(), revokedTestCID, "other-peer"))
+
+	// Only the labels announced by the publisher are purged
+	assert.False(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, publisher))
+	assert.True(t, r.hasRemoteRecordCached(t.Context(), revokedTestCID, "other-peer"))
+}
+
+// retractionSink records the retractions republished to it.
+type retractionSink struct {
+	revocations []*revocation.Revocation
+}
+
+func (s *retractionSink) Name() string { return "retractions" }
+
+func (s *retractionSink) Announce(context.Context, string, *routingv1.LabelAnnouncement) error {
+	return nil
+}
+
+func (s *retractionSink) Retract(_ context.Context, _ string, rev *revocation.Revocation) error {
+	s.revocations = append(s.revocations, rev)
+
+	return nil
+}
+
+func (s *retractionSink) Close() error { return nil }
+
+func TestApplyRevocation_MirrorsToSinks(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	key, publisher := newTestPublisher(t)
+
+	sink := &retractionSink{}
+	r := &routeRemote{dstore: dstore, announcementSinks: sinks.NewBridge(sink)}
+
+	rev := newTestRevocation(t, key, time.Now())
+	r.applyRevocation(t.Context(), publisher, rev)
+
+	// Revocations already applied are not republished again
+	r.applyRevocation(t.Context(), publisher, rev)
+
+	require.NoError(t, r.announcementSinks.Close())
+	require.Len(t, sink.revocations, 1)
+	assert.Equal(t, rev.Signature, sink.revocations[0].Signature)
+}
+
+func TestApplyRevocation_IgnoresExpired(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	key, publisher := newTestPublisher(t)
+
+	r := &routeRemote{dstore: dstore}
+	r.applyRevocation(t.Context(), publisher, newTestRevocation(t, key, time.Now().Add(-2*RevocationTTL)))
+
+	assert.False(t, r.isRevoked(t.Context(), revokedTestCID, publisher))
+}
+
+func TestAdmitAnnouncement(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	key, publisher := newTestPublisher(t)
+	otherKey, _ := newTestPublisher(t)
+
+	revokedAt := time.Now().Add(-time.Minute)
+
+	r := &routeRemote{dstore: dstore}
+	r.applyRevocation(t.Context(), publisher, newTestRevocation(t, key, revokedAt))
+
+	announcement := func(signingKey crypto.PrivKey, timestamp time.Time) *pubsub.RecordPublishEvent {
+		event := &pubsub.RecordPublishEvent{CID: revokedTestCID, Labels: []string{"/skills/AI"}, Timestamp: timestamp}
+		if signingKey != nil {
+			require.NoError(t, event.Sign(signingKey))
+		}
+
+		return event
+	}
+
+	// Unsigned, older or signed by another peer announcements are rejected
+	assert.False(t, r.admitAnnouncement(t.Context(), publisher, announcement(nil, time.Now())))
+	assert.False(t, r.admitAnnouncement(t.Context(), publisher, announcement(key, revokedAt.Add(-time.Minute))))
+	assert.False(t, r.admitAnnouncement(t.Context(), publisher, announcement(otherKey, time.Now())))
+	assert.True(t, r.isRevoked(t.Context(), revokedTestCID, publisher))
+
+	// Announcements of other peers are not affected
+	assert.True(t, r.admitAnnouncement(t.Context(), "other-peer", announcement(nil, time.Now())))
+
+	// A newer announcement re-signed by the publisher lifts the revocation
+	assert.True(t, r.admitAnnouncement(t.Context(), publisher, announcement(key, time.Now())))
+	assert.False(t, r.isRevoked(t.Context(), revokedTestCID, publisher))
+}
+
+func TestImportSnapshot_SkipsRevoked(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	key, publisher := newTestPublisher(t)
+
+	r := &routeRemote{dstore: dstore}
+	r.applyRevocation(t.Context(), publisher, newTestRevocation(t, key, time.Now()))
+
+	metadata, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+	require.NoError(t, err)
+
+	revokedKey := BuildEnhancedLabelKey("/skills/AI", revokedTestCID, publisher)
+
+	imported := r.importSnapshot(t.Context(), &rpc.SnapshotResponse{
+		Entries: []rpc.SnapshotEntry{{Key: revokedKey, Value: metadata}},
+	}, "local")
+	assert.Zero(t, imported)
+
+	exists, err := dstore.Has(t.Context(), ipfsdatastore.NewKey(revokedKey))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -15,31 +15,66 @@ package routing
 import (
 	"context"
 	"fmt"
+	"io"
+	"sync"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/routing/labelindex"
+	"github.com/agntcy/dir/server/routing/publishcheck"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/routing/taxonomy"
 	"github.com/agntcy/dir/server/types"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type route struct {
-	local  *routeLocal
-	remote *routeRemote
+	local      *routeLocal
+	remote     *routeRemote
+	store      types.StoreAPI
+	validation *publishcheck.Pipeline // Rules records are validated with before publishing
+	taxonomy   *taxonomy.Taxonomy     // Taxonomy labels are normalized to, nil if not configured
+
+	hooksMu      sync.RWMutex
+	collectHooks []types.DeleteHook // Notified of local records garbage collected from the store
 }
 
 // hasPeersInRoutingTable checks if we have any peers in the DHT routing table.
 // This determines whether we can perform network operations or should fall back to local-only mode.
 func (r *route) hasPeersInRoutingTable() bool {
-	if r.remote == nil || r.remote.server == nil {
+	if r.remote == nil {
 		return false
 	}
 
-	return r.remote.server.DHT().RoutingTable().Size() > 0
+	return r.remote.hasPeers()
 }
 
 func New(ctx context.Context, store types.StoreAPI, opts types.APIOptions) (types.RoutingAPI, error) {
+	// Register custom label namespaces before anything iterates over label types
+	if err := registerLabelNamespaces(opts.Config().Routing.LabelNamespaces); err != nil {
+		return nil, fmt.Errorf("failed to register label namespaces: %w", err)
+	}
+
+	// Fail fast on misconfigurations instead of deep inside the p2p host
+	if err := ValidateConfig(opts.Config().Routing); err != nil {
+		return nil, fmt.Errorf("invalid routing configuration: %w", err)
+	}
+
+	validation, err := newRecordValidation(opts.Config().Routing.RecordValidation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record validation: %w", err)
+	}
+
+	// Normalize labels to the label taxonomy before any label is published or cached
+	labelTaxonomy, err := newLabelTaxonomy(opts.Config().Routing.Taxonomy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create label taxonomy: %w", err)
+	}
+
 	// Create main router
-	mainRounter := &route{}
+	mainRounter := &route{store: store, validation: validation, taxonomy: labelTaxonomy}
 
 	// Create routing datastore
 	var dsOpts []datastore.Option
@@ -47,11 +82,53 @@ func New(ctx context.Context, store types.StoreAPI, opts types.APIOptions) (type
 		dsOpts = append(dsOpts, datastore.WithFsProvider(dstoreDir))
 	}
 
-	dstore, err := datastore.New(dsOpts...)
+	baseDstore, err := datastore.New(dsOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create routing datastore: %w", err)
 	}
 
+	// Record per-operation latency, error rate, and key counts for the routing datastore,
+	// and keep accepting writes while it is unavailable
+	var dstore types.Datastore = datastore.WrapWithWriteBuffer(
+		datastore.WrapWithMetrics(baseDstore, datastoreMetricsPrefixes()...),
+		opts.Config().Routing.DatastoreMaxBufferedWrites,
+	)
+
+	// Mirror the label cache into the label index, if configured, including the journal replay
+	var labelIndexDstore *labelIndexDatastore
+
+	if cfg := opts.Config().Routing.LabelIndex; cfg.Driver != "" {
+		index, err := labelindex.Open(cfg.Driver, cfg.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open label index: %w", err)
+		}
+
+		labelIndexDstore = wrapWithLabelIndex(dstore, index)
+		dstore = labelIndexDstore
+	}
+
+	// Invalidate cached search results as the label cache changes, if enabled
+	if cfg := opts.Config().Routing.SearchCache; cfg.Enabled {
+		dstore = wrapWithSearchCache(dstore, newSearchCache(cfg))
+	}
+
+	// Complete cache mutations interrupted by an unclean shutdown before the cache is read
+	replayed, err := replayJournal(ctx, dstore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay routing datastore journal: %w", err)
+	}
+
+	if replayed > 0 {
+		localLogger.Info("Replayed interrupted label cache mutations", "count", replayed)
+	}
+
+	// Rebuild the label index if it does not reflect the label cache, before it is queried
+	if labelIndexDstore != nil {
+		if err := labelIndexDstore.sync(ctx); err != nil {
+			return nil, fmt.Errorf("failed to sync label index: %w", err)
+		}
+	}
+
 	// Create remote router first to get the peer ID
 	mainRounter.remote, err = newRemote(ctx, store, dstore, opts)
 	if err != nil {
@@ -62,58 +139,495 @@ func New(ctx context.Context, store types.StoreAPI, opts types.APIOptions) (type
 	localPeerID := mainRounter.remote.server.Host().ID().String()
 
 	// Create local router with peer ID
-	mainRounter.local = newLocal(store, dstore, localPeerID)
+	mainRounter.local = newLocal(store, dstore, localPeerID, mainRounter.remote.tenants, mainRounter.remote.publishers)
+	mainRounter.local.provenance = mainRounter.remote.provenance
+
+	// Withhold the labels of local records excluded by their publish policy from the local
+	// label store, announcements, IPNI advertisements and the pull metadata of gated records
+	mainRounter.local.publishPolicy, err = newPublishPolicy(opts.Config().Routing.PublishPolicy, opts.Config().Routing.LabelNamespaces)
+	if err != nil {
+		return nil, fmt.Errorf("invalid publish policy: %w", err)
+	}
+
+	mainRounter.remote.setPublishPolicy(mainRounter.local.publishedLabels)
+
+	// Replicate under-replicated records through the local and remote publish paths
+	mainRounter.remote.startReplication(mainRounter.replicate)
+
+	// Collect unpinned local records under disk pressure like deletions through the store API
+	mainRounter.remote.startRecordGC(mainRounter.collectRecord)
 
 	return mainRounter, nil
 }
 
 func (r *route) Publish(ctx context.Context, record types.Record) error {
+	if r.following() {
+		return errFollowing()
+	}
+
+	if err := r.validateRecord(ctx, record); err != nil {
+		return err
+	}
+
 	// Always publish data locally for archival/querying
 	err := r.local.Publish(ctx, record)
 	if err != nil {
-		st := status.Convert(err)
+		return routingerr.Wrap(err, "failed to publish locally")
+	}
+
+	// Only publish to network if peers are available, otherwise announce once they are
+	if !r.hasPeersInRoutingTable() {
+		r.queueAnnouncements([]types.Record{record}, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL)
 
-		return status.Errorf(st.Code(), "failed to publish locally: %s", st.Message())
+		return nil
 	}
 
-	// Only publish to network if peers are available
-	if r.hasPeersInRoutingTable() {
-		err = r.remote.Publish(ctx, record)
-		if err != nil {
-			st := status.Convert(err)
+	err = r.remote.Publish(ctx, record)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to publish to the network")
+	}
+
+	return nil
+}
+
+// PublishBatch publishes many records at once with the given options.
+// Records that fail to publish locally are not announced to the network.
+// Returns one error per record in input order (nil on success), which are
+// also reported as the final stage of each record if progress is requested.
+func (r *route) PublishBatch(ctx context.Context, records []types.Record, opts types.PublishOptions) []error {
+	errs := make([]error, len(records))
+	progress := publishProgress(opts.Progress)
 
-			return status.Errorf(st.Code(), "failed to publish to the network: %s", st.Message())
+	defer func() { progress.finish(records, errs) }()
+
+	if r.following() {
+		for i := range errs {
+			errs[i] = errFollowing()
 		}
+
+		return errs
 	}
 
-	return nil
+	// Always publish data locally for archival/querying
+	var (
+		remoteRecords []types.Record
+		remoteIndexes []int
+	)
+
+	for i, record := range records {
+		// Invalid records are neither published locally nor announced
+		if err := r.validateRecord(ctx, record); err != nil {
+			errs[i] = err
+
+			continue
+		}
+
+		if err := r.local.PublishWithOptions(ctx, record, opts); err != nil {
+			errs[i] = routingerr.Wrap(err, "failed to publish locally")
+
+			continue
+		}
+
+		progress.report(record.GetCid(), routingv1.PublishStage_PUBLISH_STAGE_STORED)
+
+		remoteRecords = append(remoteRecords, record)
+		remoteIndexes = append(remoteIndexes, i)
+	}
+
+	if len(remoteRecords) == 0 {
+		return errs
+	}
+
+	// Only publish to network if peers are available, otherwise announce once they are
+	if !r.hasPeersInRoutingTable() {
+		r.queueAnnouncements(remoteRecords, opts.Priority)
+
+		for _, record := range remoteRecords {
+			progress.report(record.GetCid(), routingv1.PublishStage_PUBLISH_STAGE_QUEUED)
+		}
+
+		return errs
+	}
+
+	for j, err := range r.remote.PublishBatch(ctx, remoteRecords, opts.Priority, progress) {
+		if err != nil {
+			errs[remoteIndexes[j]] = routingerr.Wrap(err, "failed to publish to the network")
+		}
+	}
+
+	return errs
+}
+
+// queueAnnouncements queues network announcements of locally published records
+// until the routing table has peers.
+func (r *route) queueAnnouncements(records []types.Record, priority routingv1.AnnouncementPriority) {
+	if r.remote == nil {
+		return
+	}
+
+	for _, record := range records {
+		if !r.remote.pending.add(record, priority) {
+			remoteLogger.Warn("Too many pending announcements, record is announced by the next republish", "cid", record.GetCid())
+		}
+	}
 }
 
 func (r *route) List(ctx context.Context, req *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error) {
 	// List is always local-only - it returns records that this peer is currently providing
 	// This operation does not interact with the network (per proto comment)
-	return r.local.List(ctx, req)
+	if err := ValidateQueries(req.GetQueries()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid list queries: %v", err)
+	}
+
+	localCh, err := r.local.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Announcements are made by remote routing, which tracks their status
+	outCh := make(chan *routingv1.ListResponse)
+
+	go func() {
+		defer close(outCh)
+
+		for resp := range localCh {
+			lastAnnouncedAt, check := r.remote.announcements.status(resp.GetRecordRef().GetCid())
+			if !lastAnnouncedAt.IsZero() {
+				resp.LastAnnouncedAt = timestamppb.New(lastAnnouncedAt)
+			}
+
+			resp.AnnouncementCheck = check
+
+			outCh <- resp
+		}
+	}()
+
+	return outCh, nil
 }
 
 func (r *route) Search(ctx context.Context, req *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error) {
 	// Search is always remote-only - it returns records from other peers using cached announcements
 	// This operation queries locally cached remote announcements from DHT
+	if err := ValidateQueries(req.GetQueries()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid search queries: %v", err)
+	}
+
 	return r.remote.Search(ctx, req)
 }
 
+func (r *route) EstimateResults(ctx context.Context, req *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error) {
+	// Estimates are computed from the cached remote announcements searched by Search
+	if err := ValidateQueries(req.GetQueries()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid search queries: %v", err)
+	}
+
+	return r.remote.EstimateResults(ctx, req)
+}
+
 func (r *route) Unpublish(ctx context.Context, record types.Record) error {
+	if r.following() {
+		return errFollowing()
+	}
+
 	err := r.local.Unpublish(ctx, record)
 	if err != nil {
-		st := status.Convert(err)
+		return routingerr.Wrap(err, "failed to unpublish locally")
+	}
 
-		return status.Errorf(st.Code(), "failed to unpublish locally: %s", st.Message())
+	r.remote.announcements.forget(record.GetCid())
+	r.remote.publishDedup.forget(record.GetCid())
+	r.remote.pending.remove(record.GetCid())
+	r.remote.forgetAnnouncedLabels(ctx, record.GetCid())
+
+	if err := r.remote.ipni.remove(ctx, record.GetCid()); err != nil {
+		remoteLogger.Warn("Failed to withdraw IPNI advertisement of record", "cid", record.GetCid(), "error", err)
+	}
+
+	// Take the record down from remote caches with a signed revocation.
+	// Best-effort: the record is no longer provided, so remote labels expire anyway.
+	if r.hasPeersInRoutingTable() {
+		if err := r.remote.Revoke(ctx, record.GetCid(), RevocationReasonUnpublished); err != nil {
+			remoteLogger.Warn("Failed to revoke record on the network", "cid", record.GetCid(), "error", err)
+		}
 	}
 
-	// no need to explicitly handle unpublishing from the network
-	// TODO clarify if network sync trigger is needed here
 	return nil
 }
 
+// Retract withdraws the announcements of a record that was deleted from the local store.
+// Its local labels are removed, so that it is no longer listed or republished, and a
+// revocation is published for remote caches to purge it. Records that were not published are ignored.
+func (r *route) Retract(ctx context.Context, cid string) error {
+	// The primary retracts the records deleted from the store it shares with its followers
+	if r.following() {
+		return nil
+	}
+
+	published, err := r.local.Retract(ctx, cid)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to retract locally")
+	}
+
+	if !published {
+		return nil
+	}
+
+	r.remote.announcements.forget(cid)
+	r.remote.publishDedup.forget(cid)
+	r.remote.pending.remove(cid)
+	r.remote.forgetAnnouncedLabels(ctx, cid)
+
+	if err := r.remote.ipni.remove(ctx, cid); err != nil {
+		remoteLogger.Warn("Failed to withdraw IPNI advertisement of deleted record", "cid", cid, "error", err)
+	}
+
+	// Best-effort like on Unpublish: the record is no longer provided, so remote labels expire anyway
+	if r.hasPeersInRoutingTable() {
+		if err := r.remote.Revoke(ctx, cid, RevocationReasonDeleted); err != nil {
+			remoteLogger.Warn("Failed to revoke deleted record on the network", "cid", cid, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *route) GetStats(ctx context.Context, req *routingv1.GetStatsRequest) (*routingv1.GetStatsResponse, error) {
+	// Statistics are kept by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetStatsResponse{}, nil
+	}
+
+	return r.remote.GetStats(ctx, req)
+}
+
+// Readiness reports which routing functionality is available. Local operations are
+// always available; network announcements are queued until the routing table has peers.
+func (r *route) Readiness() types.RoutingReadiness {
+	if r.remote == nil || r.remote.server == nil {
+		return types.RoutingReadiness{}
+	}
+
+	return r.remote.readiness()
+}
+
+func (r *route) Pin(ctx context.Context, req *routingv1.PinRequest) error {
+	// Pins keep cached remote announcements and reprovide local records, which are managed by remote routing
+	return r.remote.Pin(ctx, req)
+}
+
+func (r *route) Unpin(ctx context.Context, req *routingv1.UnpinRequest) error {
+	return r.remote.Unpin(ctx, req)
+}
+
+func (r *route) ListPins(ctx context.Context, req *routingv1.ListPinsRequest) ([]*routingv1.ListPinsResponse, error) {
+	return r.remote.ListPins(ctx, req)
+}
+
+func (r *route) VerifyCache(ctx context.Context, req *routingv1.VerifyCacheRequest) (*routingv1.VerifyCacheResponse, error) {
+	return r.remote.VerifyCache(ctx, req)
+}
+
+func (r *route) SetProfile(ctx context.Context, req *routingv1.SetProfileRequest) (*routingv1.SetProfileResponse, error) {
+	return r.remote.SetProfile(ctx, req)
+}
+
+// GrantAccess issues an access token for the access-gated records of this peer.
+func (r *route) GrantAccess(ctx context.Context, req *routingv1.GrantAccessRequest) (*routingv1.GrantAccessResponse, error) {
+	// Access-gated records are only served to remote peers
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "access tokens are not used without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.GrantAccess(ctx, req)
+}
+
+// Subscribe pushes the remote records matching the queries as their labels are newly cached.
+func (r *route) Subscribe(ctx context.Context, req *routingv1.SubscribeRequest, send func(*routingv1.SearchResponse) error) error {
+	if err := ValidateQueries(req.GetQueries()); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid subscription queries: %v", err) //nolint:wrapcheck
+	}
+
+	// Remote records are only cached by remote routing
+	if r.remote == nil {
+		return status.Error(codes.FailedPrecondition, "subscriptions are not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.Subscribe(ctx, req, send)
+}
+
+// ExportState writes the label cache, and optionally the records it references, as a state archive.
+func (r *route) ExportState(ctx context.Context, req *routingv1.ExportStateRequest, w io.Writer) error {
+	// The label cache is managed by remote routing
+	if r.remote == nil {
+		return status.Error(codes.FailedPrecondition, "state archives are not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.ExportState(ctx, req, w)
+}
+
+// ImportState imports a state archive exported by another peer.
+func (r *route) ImportState(ctx context.Context, rd io.Reader) (*routingv1.ImportStateResponse, error) {
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "state archives are not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.ImportState(ctx, rd)
+}
+
+// GetHistory returns the retained discovery history of this peer.
+func (r *route) GetHistory(ctx context.Context, req *routingv1.GetHistoryRequest) (*routingv1.GetHistoryResponse, error) {
+	// History is retained by remote routing only
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "discovery history is not retained without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.GetHistory(ctx, req)
+}
+
+// GetProvenance returns the provenance of a record as observed by this peer.
+func (r *route) GetProvenance(ctx context.Context, req *routingv1.GetProvenanceRequest) (*routingv1.GetProvenanceResponse, error) {
+	// Announcements are logged by remote routing only
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "provenance is not logged without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.GetProvenance(ctx, req)
+}
+
+// GetRoutingTable dumps the DHT routing table.
+func (r *route) GetRoutingTable(ctx context.Context, req *routingv1.GetRoutingTableRequest) (*routingv1.GetRoutingTableResponse, error) {
+	// The DHT is run by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetRoutingTableResponse{}, nil
+	}
+
+	return r.remote.GetRoutingTable(ctx, req)
+}
+
+// GetGossipSubState returns the peers and the mesh of each joined GossipSub topic.
+func (r *route) GetGossipSubState(ctx context.Context, req *routingv1.GetGossipSubStateRequest) (*routingv1.GetGossipSubStateResponse, error) {
+	// GossipSub is run by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetGossipSubStateResponse{}, nil
+	}
+
+	return r.remote.GetGossipSubState(ctx, req)
+}
+
+// GetGossipSubDiagnostics returns the message rates of each joined GossipSub topic,
+// the scores of connected peers and the propagation latency of announcements.
+func (r *route) GetGossipSubDiagnostics(ctx context.Context, req *routingv1.GetGossipSubDiagnosticsRequest) (*routingv1.GetGossipSubDiagnosticsResponse, error) {
+	// GossipSub is run by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetGossipSubDiagnosticsResponse{}, nil
+	}
+
+	return r.remote.GetGossipSubDiagnostics(ctx, req)
+}
+
+// GetLabelCacheStats returns statistics of the label cache per label namespace.
+func (r *route) GetLabelCacheStats(ctx context.Context, req *routingv1.GetLabelCacheStatsRequest) (*routingv1.GetLabelCacheStatsResponse, error) {
+	// The label cache is kept by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetLabelCacheStatsResponse{}, nil
+	}
+
+	return r.remote.GetLabelCacheStats(ctx, req)
+}
+
+// GetQueueState returns the depth of the internal queues of routing.
+func (r *route) GetQueueState(ctx context.Context, req *routingv1.GetQueueStateRequest) (*routingv1.GetQueueStateResponse, error) {
+	// Queues are kept by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetQueueStateResponse{}, nil
+	}
+
+	return r.remote.GetQueueState(ctx, req)
+}
+
+// ListPinnedRecords lists the pinned records and the state of the garbage collection of local records.
+func (r *route) ListPinnedRecords(ctx context.Context, req *routingv1.ListPinnedRecordsRequest) (*routingv1.ListPinnedRecordsResponse, error) {
+	// Pins are kept by remote routing only
+	if r.remote == nil {
+		return &routingv1.ListPinnedRecordsResponse{}, nil
+	}
+
+	return r.remote.ListPinnedRecords(ctx, req)
+}
+
+// GetTaskStatus returns the schedules and last runs of the background tasks of routing.
+func (r *route) GetTaskStatus(ctx context.Context, req *routingv1.GetTaskStatusRequest) (*routingv1.GetTaskStatusResponse, error) {
+	// Background tasks are run by remote routing only
+	if r.remote == nil {
+		return &routingv1.GetTaskStatusResponse{}, nil
+	}
+
+	return r.remote.GetTaskStatus(ctx, req)
+}
+
+// StartBackfill starts rebuilding the remote label cache from a crawl of the DHT.
+func (r *route) StartBackfill(ctx context.Context, req *routingv1.StartBackfillRequest) (*routingv1.StartBackfillResponse, error) {
+	// The label cache is kept by remote routing only
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "backfills are not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.StartBackfill(ctx, req)
+}
+
+// GetBackfillStatus returns the progress of the running or the last backfill.
+func (r *route) GetBackfillStatus(ctx context.Context, req *routingv1.GetBackfillStatusRequest) (*routingv1.GetBackfillStatusResponse, error) {
+	if r.remote == nil {
+		return &routingv1.GetBackfillStatusResponse{}, nil
+	}
+
+	return r.remote.GetBackfillStatus(ctx, req)
+}
+
+// GetBandwidthUsage returns the record content served by Pull to each peer per hour.
+func (r *route) GetBandwidthUsage(ctx context.Context, req *routingv1.GetBandwidthUsageRequest) (*routingv1.GetBandwidthUsageResponse, error) {
+	// Records are pulled by remote peers only
+	if r.remote == nil {
+		return &routingv1.GetBandwidthUsageResponse{}, nil
+	}
+
+	return r.remote.GetBandwidthUsage(ctx, req)
+}
+
+// SnapshotDatastore writes a point-in-time archive of the routing datastore.
+func (r *route) SnapshotDatastore(ctx context.Context, w io.Writer) error {
+	// The routing datastore is managed by remote routing
+	if r.remote == nil {
+		return status.Error(codes.FailedPrecondition, "datastore snapshots are not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.SnapshotDatastore(ctx, w)
+}
+
+// RestoreDatastore writes the entries of a datastore archive into the routing datastore.
+func (r *route) RestoreDatastore(ctx context.Context, rd io.Reader) (*routingv1.RestoreDatastoreResponse, error) {
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "datastore snapshots are not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.RestoreDatastore(ctx, rd)
+}
+
+// following reports whether this peer is a follower mirroring the datastore of its primary.
+// Records are published and unpublished on the primary until the follower is promoted.
+func (r *route) following() bool {
+	return r.remote != nil && r.remote.follower.following()
+}
+
+// PromoteFollower promotes this follower to announce the records of its primary.
+func (r *route) PromoteFollower(ctx context.Context, req *routingv1.PromoteFollowerRequest) (*routingv1.PromoteFollowerResponse, error) {
+	if r.remote == nil {
+		return nil, status.Error(codes.FailedPrecondition, "follower mode is not supported without remote routing") //nolint:wrapcheck
+	}
+
+	return r.remote.PromoteFollower(ctx, req)
+}
+
 // Stop stops the routing services and releases resources.
 // This should be called during server shutdown to clean up gracefully.
 func (r *route) Stop() error {
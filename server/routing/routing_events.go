@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/events"
+)
+
+// newEventEmitter creates the emitter of routing events for the configured publishers.
+// Returns nil if no publisher is configured.
+func newEventEmitter(cfg routingconfig.EventsConfig) (*events.Emitter, error) {
+	var publishers []events.Publisher
+
+	if cfg.Kafka.RESTProxyURL != "" {
+		publisher, err := events.NewKafkaPublisher(cfg.Kafka.RESTProxyURL, cfg.Kafka.Topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create kafka event publisher: %w", err)
+		}
+
+		publishers = append(publishers, publisher)
+	}
+
+	if cfg.NATS.URL != "" {
+		publisher, err := events.NewNATSPublisher(cfg.NATS.URL, cfg.NATS.SubjectPrefix)
+		if err != nil {
+			for _, p := range publishers {
+				_ = p.Close()
+			}
+
+			return nil, fmt.Errorf("failed to create nats event publisher: %w", err)
+		}
+
+		publishers = append(publishers, publisher)
+	}
+
+	return events.NewEmitter(publishers...), nil
+}
+
+// emitRecordsDiscovered emits a record.discovered event per record with newly cached labels.
+func (r *routeRemote) emitRecordsDiscovered(peerID string, keys []string) {
+	if r.events == nil {
+		return
+	}
+
+	var cids []string
+
+	labelsByCID := make(map[string][]string)
+
+	for _, key := range keys {
+		label, cid, _, err := ParseEnhancedLabelKey(key)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := labelsByCID[cid]; !ok {
+			cids = append(cids, cid)
+		}
+
+		labelsByCID[cid] = append(labelsByCID[cid], label.String())
+	}
+
+	for _, cid := range cids {
+		r.events.Emit(events.RecordDiscovered(cid, peerID, labelsByCID[cid]))
+	}
+}
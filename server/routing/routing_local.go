@@ -6,17 +6,21 @@ package routing
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"slices"
 	"strings"
 	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/routingerr"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var localLogger = logging.Logger("routing/local")
@@ -25,25 +29,71 @@ var localLogger = logging.Logger("routing/local")
 type routeLocal struct {
 	store       types.StoreAPI
 	dstore      types.Datastore
-	localPeerID string // Cached local peer ID for efficient filtering
+	localPeerID string         // Cached local peer ID for efficient filtering
+	tenants     *tenants       // Tenants records may be published for, none if nil
+	publishers  *publishers    // Publishers records may be published as, none if nil
+	provenance  *provenanceLog // Log of record announcements, nil if disabled
+
+	// Configured publish policy, all labels are published if empty
+	publishPolicy []types.LabelPolicy
 }
 
-func newLocal(store types.StoreAPI, dstore types.Datastore, localPeerID string) *routeLocal {
+func newLocal(store types.StoreAPI, dstore types.Datastore, localPeerID string, tenants *tenants, publishers *publishers) *routeLocal {
 	return &routeLocal{
 		store:       store,
 		dstore:      dstore,
 		localPeerID: localPeerID,
+		tenants:     tenants,
+		publishers:  publishers,
 	}
 }
 
 func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
+	return r.PublishWithPriority(ctx, record, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED)
+}
+
+// PublishWithPriority stores the record locally together with its announcement priority.
+// Republishing an existing record with an explicit priority updates the stored priority.
+func (r *routeLocal) PublishWithPriority(ctx context.Context, record types.Record, priority routingv1.AnnouncementPriority) error {
+	return r.PublishWithOptions(ctx, record, types.PublishOptions{Priority: priority})
+}
+
+// PublishWithOptions stores the record locally together with its announcement priority, TTL,
+// the CID of the version it supersedes and whether it is access-gated. Republishing an existing
+// record with an explicit priority or superseded CID updates them, republishing it as access-gated
+// gates it, and republishing it with a TTL renews its expiration.
+// Labels of records published for a tenant are stored under the tenant's namespace.
+// A record can only be published for one tenant and as one publisher at a time.
+//
+//nolint:cyclop
+func (r *routeLocal) PublishWithOptions(ctx context.Context, record types.Record, opts types.PublishOptions) error {
 	if record == nil {
 		return status.Error(codes.InvalidArgument, "record is required") //nolint:wrapcheck // Mock should return exact error without wrapping
 	}
 
 	cid := record.GetCid()
 	if cid == "" {
-		return status.Error(codes.InvalidArgument, "record has no CID") //nolint:wrapcheck
+		return routingerr.ErrCIDInvalid.Errorf("record has no CID")
+	}
+
+	if opts.TTL != 0 && opts.TTL < types.MinRecordTTL {
+		return status.Errorf(codes.InvalidArgument, "record TTL must be at least %s", types.MinRecordTTL)
+	}
+
+	if opts.Supersedes == cid {
+		return status.Error(codes.InvalidArgument, "record cannot supersede itself") //nolint:wrapcheck
+	}
+
+	if !r.tenants.known(opts.Tenant) {
+		return status.Errorf(codes.InvalidArgument, "unknown tenant %q", opts.Tenant)
+	}
+
+	if !r.publishers.known(opts.Publisher) {
+		return status.Errorf(codes.InvalidArgument, "unknown publisher %q, its key is not configured on this peer", opts.Publisher)
+	}
+
+	if err := validateLabelPolicies(opts.LabelPolicies); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid label policies: %v", err)
 	}
 
 	localLogger.Debug("Called local routing's Publish method", "cid", cid)
@@ -53,14 +103,34 @@ func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
 		return status.Errorf(codes.Internal, "failed to load metrics: %v", err)
 	}
 
-	batch, err := r.dstore.Batch(ctx)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to create batch: %v", err)
-	}
+	// the record, its labels and the metrics are stored together as a single journaled mutation
+	mutation := &cacheMutation{}
 
 	// the key where we will save the record
 	recordKey := datastore.NewKey("/records/" + cid)
 
+	now := time.Now()
+
+	recordMetadata := localRecordMetadata{
+		Priority:      opts.Priority,
+		PublishedAt:   now.UTC(),
+		Supersedes:    opts.Supersedes,
+		AccessGated:   opts.AccessGated,
+		Tenant:        opts.Tenant,
+		Publisher:     opts.Publisher,
+		LabelPolicies: opts.LabelPolicies,
+		Size:          recordContentSize(record),
+		RecordSummary: types.NewRecordSummary(record),
+	}
+	if opts.TTL > 0 {
+		recordMetadata.ExpiresAt = now.Add(opts.TTL).UTC()
+	}
+
+	recordValue, err := encodeLocalRecordMetadata(recordMetadata)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to serialize record metadata: %v", err)
+	}
+
 	// check if we have the record already
 	// this is useful to avoid updating metrics and running the same operation multiple times
 	recordExists, err := r.dstore.Has(ctx, recordKey)
@@ -69,25 +139,35 @@ func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
 	}
 
 	if recordExists {
+		if err := r.updateRecordMetadata(ctx, record, recordKey, recordMetadata); err != nil {
+			return err
+		}
+
 		localLogger.Info("Skipping republish as record was already published", "cid", cid)
 
 		return nil
 	}
 
 	// store record for later lookup
-	if err := batch.Put(ctx, recordKey, nil); err != nil {
-		return status.Errorf(codes.Internal, "failed to put record key: %v", err)
-	}
+	mutation.put(recordKey.String(), recordValue)
 
 	// Update metrics for all record labels and store them locally for queries
 	// Note: This handles ALL local storage for both local-only and network scenarios
 	// Network announcements are handled separately by routing_remote when peers are available
-	labelList := types.GetLabelsFromRecord(record)
+	// The record is not stored yet, so its label policies are applied here rather than by the
+	// label publish filter
+	labelList := tenantLabels(opts.Tenant, types.FilterLabels(types.GetLabelsFromRecord(record), types.MergeLabelPolicies(r.publishPolicy, opts.LabelPolicies)))
 	for _, label := range labelList {
 		// Create minimal metadata (PeerID and CID now in key)
 		metadata := &types.LabelMetadata{
-			Timestamp: time.Now(),
-			LastSeen:  time.Now(),
+			Timestamp:     now,
+			LastSeen:      now,
+			ExpiresAt:     recordMetadata.ExpiresAt,
+			Supersedes:    recordMetadata.Supersedes,
+			AccessGated:   recordMetadata.AccessGated,
+			Size:          recordMetadata.Size,
+			Publisher:     recordMetadata.Publisher,
+			RecordSummary: recordMetadata.RecordSummary,
 		}
 
 		// Serialize metadata to JSON
@@ -99,26 +179,130 @@ func (r *routeLocal) Publish(ctx context.Context, record types.Record) error {
 		// Store with enhanced self-descriptive key: /skills/AI/CID123/Peer1
 		enhancedKey := BuildEnhancedLabelKey(label, cid, r.localPeerID)
 
-		labelKey := datastore.NewKey(enhancedKey)
-		if err := batch.Put(ctx, labelKey, metadataBytes); err != nil {
-			return status.Errorf(codes.Internal, "failed to put label key: %v", err)
-		}
+		mutation.put(enhancedKey, metadataBytes)
 
 		metrics.increment(label)
 	}
 
-	err = batch.Commit(ctx)
+	if err := metrics.record(mutation); err != nil {
+		return status.Errorf(codes.Internal, "failed to update metrics: %v", err)
+	}
+
+	r.provenance.append(mutation, *newProvenanceEntry(cid, r.localPeerID, ProvenanceSourceLocal, &types.LabelMetadata{
+		Timestamp:  now,
+		Publisher:  recordMetadata.Publisher,
+		Supersedes: recordMetadata.Supersedes,
+	}, false, now))
+
+	if err := applyCacheMutation(ctx, r.dstore, mutation); err != nil {
+		return status.Errorf(codes.Internal, "failed to store record: %v", err)
+	}
+
+	localLogger.Info("Successfully published record", "cid", cid, "expiresAt", recordMetadata.ExpiresAt, "supersedes", recordMetadata.Supersedes)
+
+	return nil
+}
+
+// updateRecordMetadata stores an explicitly requested priority, renewed expiration, superseded
+// CID or access gate for an already published record. All but the priority are also updated on
+// the record's labels.
+//
+//nolint:cyclop
+func (r *routeLocal) updateRecordMetadata(ctx context.Context, record types.Record, recordKey datastore.Key, requested localRecordMetadata) error {
+	if requested.Priority == routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED && requested.ExpiresAt.IsZero() && requested.Supersedes == "" && !requested.AccessGated && requested.Tenant == "" && requested.Publisher == "" && len(requested.LabelPolicies) == 0 {
+		return nil
+	}
+
+	existing, err := r.dstore.Get(ctx, recordKey)
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to commit batch: %v", err)
+		return status.Errorf(codes.Internal, "failed to get record key: %v", err)
 	}
 
-	// sync metrics
-	err = metrics.update(ctx, r.dstore)
+	current := decodeLocalRecordMetadata(existing)
+	updated := current
+
+	// Republishing without a tenant keeps the record's tenant
+	if requested.Tenant != "" && requested.Tenant != current.Tenant {
+		return status.Errorf(codes.FailedPrecondition, "record is already published for another tenant than %q, unpublish it first", requested.Tenant)
+	}
+
+	// Republishing without a publisher keeps the record's publisher
+	if requested.Publisher != "" && requested.Publisher != current.Publisher {
+		return status.Errorf(codes.FailedPrecondition, "record is already published as another publisher than %q, unpublish it first", requested.Publisher)
+	}
+
+	// Republishing without label policies keeps the record's policies
+	if len(requested.LabelPolicies) > 0 && !slices.EqualFunc(requested.LabelPolicies, current.LabelPolicies, types.LabelPolicy.Equal) {
+		return status.Error(codes.FailedPrecondition, "record is already published with other label policies, unpublish it first") //nolint:wrapcheck
+	}
+
+	if requested.Priority != routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_UNSPECIFIED {
+		updated.Priority = normalizePriority(requested.Priority)
+	}
+
+	if !requested.ExpiresAt.IsZero() {
+		updated.ExpiresAt = requested.ExpiresAt
+	}
+
+	if requested.Supersedes != "" {
+		updated.Supersedes = requested.Supersedes
+	}
+
+	if requested.AccessGated {
+		updated.AccessGated = true
+	}
+
+	labelsChanged := !updated.ExpiresAt.Equal(current.ExpiresAt) ||
+		updated.Supersedes != current.Supersedes ||
+		updated.AccessGated != current.AccessGated
+
+	if updated.Priority == current.Priority && !labelsChanged {
+		return nil
+	}
+
+	recordValue, err := encodeLocalRecordMetadata(updated)
 	if err != nil {
-		return status.Errorf(codes.Internal, "failed to update metrics: %v", err)
+		return status.Errorf(codes.Internal, "failed to serialize record metadata: %v", err)
+	}
+
+	// the record and its renewed labels are updated together as a single journaled mutation
+	mutation := &cacheMutation{}
+	mutation.put(recordKey.String(), recordValue)
+
+	if labelsChanged {
+		now := time.Now()
+
+		labels := types.FilterLabels(types.GetLabelsFromRecord(record), types.MergeLabelPolicies(r.publishPolicy, updated.LabelPolicies))
+
+		for _, label := range tenantLabels(updated.Tenant, labels) {
+			metadataBytes, err := json.Marshal(&types.LabelMetadata{
+				Timestamp:     now,
+				LastSeen:      now,
+				ExpiresAt:     updated.ExpiresAt,
+				Supersedes:    updated.Supersedes,
+				AccessGated:   updated.AccessGated,
+				Size:          updated.Size,
+				Publisher:     updated.Publisher,
+				RecordSummary: updated.RecordSummary,
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to serialize label metadata: %v", err)
+			}
+
+			mutation.put(BuildEnhancedLabelKey(label, record.GetCid(), r.localPeerID), metadataBytes)
+		}
+	}
+
+	if err := applyCacheMutation(ctx, r.dstore, mutation); err != nil {
+		return status.Errorf(codes.Internal, "failed to update record metadata: %v", err)
 	}
 
-	localLogger.Info("Successfully published record", "cid", cid)
+	localLogger.Info("Updated record announcement metadata",
+		"key", recordKey.String(),
+		"priority", updated.Priority,
+		"expiresAt", updated.ExpiresAt,
+		"supersedes", updated.Supersedes,
+		"accessGated", updated.AccessGated)
 
 	return nil
 }
@@ -191,12 +375,21 @@ func (r *routeLocal) listLocalRecords(ctx context.Context, queries []*routingv1.
 				apiLabels[i] = label.String()
 			}
 
-			// Send the response
-			outCh <- &routingv1.ListResponse{
+			metadata := decodeLocalRecordMetadata(result.Value)
+
+			resp := &routingv1.ListResponse{
 				RecordRef: &corev1.RecordRef{Cid: cid},
 				Labels:    apiLabels,
+				TenantId:  metadata.Tenant,
 			}
 
+			if publishedAt := metadata.PublishedAt; !publishedAt.IsZero() {
+				resp.PublishedAt = timestamppb.New(publishedAt)
+			}
+
+			// Send the response
+			outCh <- resp
+
 			processedCount++
 			if limitInt > 0 && processedCount >= limitInt {
 				break
@@ -216,6 +409,7 @@ func (r *routeLocal) matchesAllQueries(ctx context.Context, cid string, queries
 
 // getRecordLabelsEfficiently gets labels for a record by extracting them from datastore keys.
 // This completely avoids expensive Pull operations by using the fact that labels are stored as keys.
+// Labels of records published for a tenant are returned without the tenant's namespace.
 // This function is designed to be resilient - it never returns an error, only logs warnings.
 func (r *routeLocal) getRecordLabelsEfficiently(ctx context.Context, cid string) []types.Label {
 	var labelList []types.Label
@@ -240,7 +434,8 @@ func (r *routeLocal) getRecordLabelsEfficiently(ctx context.Context, cid string)
 
 		// Check if this key matches our CID and is from local peer
 		if keyCID == cid && keyPeerID == r.localPeerID {
-			labelList = append(labelList, label)
+			_, value := splitTenantLabel(label)
+			labelList = append(labelList, value)
 		}
 	}
 
@@ -254,52 +449,75 @@ func (r *routeLocal) Unpublish(ctx context.Context, record types.Record) error {
 
 	cid := record.GetCid()
 	if cid == "" {
-		return status.Error(codes.InvalidArgument, "record has no CID") //nolint:wrapcheck
+		return routingerr.ErrCIDInvalid.Errorf("record has no CID")
 	}
 
 	localLogger.Debug("Called local routing's Unpublish method", "cid", cid)
 
+	return r.unpublish(ctx, cid, types.GetLabelsFromRecord(record))
+}
+
+// Retract removes a record that was deleted from the local store from the label cache.
+// As the record can no longer be read, its labels are taken from the cached label keys.
+// Returns whether the record was published.
+func (r *routeLocal) Retract(ctx context.Context, cid string) (bool, error) {
+	localLogger.Debug("Called local routing's Retract method", "cid", cid)
+
+	_, err := r.dstore.Get(ctx, datastore.NewKey("/records/"+cid))
+	if errors.Is(err, datastore.ErrNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to get record key: %v", err)
+	}
+
+	return true, r.unpublish(ctx, cid, r.getRecordLabelsEfficiently(ctx, cid))
+}
+
+// unpublish removes the record key and the given labels of a local record.
+func (r *routeLocal) unpublish(ctx context.Context, cid string, labels []types.Label) error {
 	// load metrics for the client
 	metrics, err := loadMetrics(ctx, r.dstore)
 	if err != nil {
 		return status.Errorf(codes.Internal, "failed to load metrics: %v", err)
 	}
 
-	batch, err := r.dstore.Batch(ctx)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to create batch: %v", err)
-	}
+	// the record, its labels and the metrics are removed together as a single journaled mutation
+	mutation := &cacheMutation{}
 
 	// get record key and remove record
 	recordKey := datastore.NewKey("/records/" + cid)
-	if err := batch.Delete(ctx, recordKey); err != nil {
-		return status.Errorf(codes.Internal, "failed to delete record key: %v", err)
+
+	// labels of tenant records are stored under the tenant's namespace
+	var tenant string
+
+	if value, err := r.dstore.Get(ctx, recordKey); err == nil {
+		tenant = decodeLocalRecordMetadata(value).Tenant
+	} else if !errors.Is(err, datastore.ErrNotFound) {
+		return status.Errorf(codes.Internal, "failed to get record key: %v", err)
 	}
 
+	mutation.delete(recordKey.String())
+
 	// keep track of all record labels
-	labelList := types.GetLabelsFromRecord(record)
+	labelList := tenantLabels(tenant, labels)
 
 	for _, label := range labelList {
 		// Delete enhanced key with CID and PeerID
 		enhancedKey := BuildEnhancedLabelKey(label, cid, r.localPeerID)
 
-		labelKey := datastore.NewKey(enhancedKey)
-		if err := batch.Delete(ctx, labelKey); err != nil {
-			return status.Errorf(codes.Internal, "failed to delete label key: %v", err)
-		}
+		mutation.delete(enhancedKey)
 
 		metrics.decrement(label)
 	}
 
-	err = batch.Commit(ctx)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to commit batch: %v", err)
+	if err := metrics.record(mutation); err != nil {
+		return status.Errorf(codes.Internal, "failed to update metrics: %v", err)
 	}
 
-	// sync metrics
-	err = metrics.update(ctx, r.dstore)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to update metrics: %v", err)
+	if err := applyCacheMutation(ctx, r.dstore, mutation); err != nil {
+		return status.Errorf(codes.Internal, "failed to remove record: %v", err)
 	}
 
 	localLogger.Info("Successfully unpublished record", "cid", cid)
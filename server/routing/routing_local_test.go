@@ -6,6 +6,7 @@ package routing
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"os"
@@ -17,11 +18,15 @@ import (
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/agntcy/dir/server/datastore"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	ipfsdatastore "github.com/ipfs/go-datastore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const testPeerID = "test-peer-id"
@@ -325,8 +330,8 @@ func Benchmark_RouteLocal(b *testing.B) {
 	inMemoryDatastore := newInMemoryDatastore(b)
 	localLogger = slog.New(slog.DiscardHandler)
 
-	badgerRouter := newLocal(store, badgerDatastore, testPeerID)
-	inMemoryRouter := newLocal(store, inMemoryDatastore, testPeerID)
+	badgerRouter := newLocal(store, badgerDatastore, testPeerID, nil, nil)
+	inMemoryRouter := newLocal(store, inMemoryDatastore, testPeerID, nil, nil)
 
 	record := corev1.New(&typesv1alpha0.Record{
 		Name:          "bench-agent",
@@ -395,3 +400,375 @@ func Benchmark_RouteLocal(b *testing.B) {
 	_ = inMemoryDatastore.Delete(b.Context(), ipfsdatastore.NewKey("/")) // Delete all keys
 	localLogger = logging.Logger("routing/local")
 }
+
+func TestPublishWithPriority_StoresAndUpdatesPriority(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, nil)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-priority",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+	recordKey := ipfsdatastore.NewKey("/records/" + record.GetCid())
+
+	storedPriority := func() routingv1.AnnouncementPriority {
+		value, err := dstore.Get(t.Context(), recordKey)
+		assert.NoError(t, err)
+
+		return decodeLocalRecordMetadata(value).Priority
+	}
+
+	// First publish stores the requested priority
+	err := r.PublishWithPriority(t.Context(), adapter, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW)
+	assert.NoError(t, err)
+	assert.Equal(t, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW, storedPriority())
+
+	// Republishing without a priority keeps the stored one
+	err = r.Publish(t.Context(), adapter)
+	assert.NoError(t, err)
+	assert.Equal(t, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW, storedPriority())
+
+	// Republishing with an explicit priority updates it
+	err = r.PublishWithPriority(t.Context(), adapter, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH)
+	assert.NoError(t, err)
+	assert.Equal(t, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH, storedPriority())
+}
+
+func TestPublishWithOptions_StoresAndRenewsTTL(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, nil)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-ttl",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+	recordKey := ipfsdatastore.NewKey("/records/" + record.GetCid())
+	labelKey := ipfsdatastore.NewKey(BuildEnhancedLabelKey(types.GetLabelsFromRecord(adapter)[0], record.GetCid(), testPeerID))
+
+	stored := func() (localRecordMetadata, types.LabelMetadata) {
+		value, err := dstore.Get(t.Context(), recordKey)
+		require.NoError(t, err)
+
+		labelValue, err := dstore.Get(t.Context(), labelKey)
+		require.NoError(t, err)
+
+		var labelMetadata types.LabelMetadata
+		require.NoError(t, json.Unmarshal(labelValue, &labelMetadata))
+
+		return decodeLocalRecordMetadata(value), labelMetadata
+	}
+
+	// TTLs below the minimum are rejected
+	err := r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{TTL: time.Second})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// First publish stores the expiration on the record and its labels
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{TTL: time.Hour})
+	require.NoError(t, err)
+
+	recordMetadata, labelMetadata := stored()
+	assert.WithinDuration(t, time.Now().Add(time.Hour), recordMetadata.ExpiresAt, time.Minute)
+	assert.True(t, labelMetadata.ExpiresAt.Equal(recordMetadata.ExpiresAt))
+	assert.False(t, recordMetadata.expired(time.Now()))
+	assert.True(t, recordMetadata.expired(recordMetadata.ExpiresAt))
+
+	// Republishing without a TTL keeps the expiration
+	err = r.Publish(t.Context(), adapter)
+	require.NoError(t, err)
+
+	unchanged, _ := stored()
+	assert.True(t, unchanged.ExpiresAt.Equal(recordMetadata.ExpiresAt))
+
+	// Republishing with a TTL renews it
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{TTL: 2 * time.Hour})
+	require.NoError(t, err)
+
+	renewed, renewedLabel := stored()
+	assert.True(t, renewed.ExpiresAt.After(recordMetadata.ExpiresAt))
+	assert.True(t, renewedLabel.ExpiresAt.Equal(renewed.ExpiresAt))
+}
+
+func TestPublishWithOptions_StoresSupersedes(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, nil)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-v2",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+	recordKey := ipfsdatastore.NewKey("/records/" + record.GetCid())
+	labelKey := ipfsdatastore.NewKey(BuildEnhancedLabelKey(types.GetLabelsFromRecord(adapter)[0], record.GetCid(), testPeerID))
+
+	stored := func() (localRecordMetadata, types.LabelMetadata) {
+		value, err := dstore.Get(t.Context(), recordKey)
+		require.NoError(t, err)
+
+		labelValue, err := dstore.Get(t.Context(), labelKey)
+		require.NoError(t, err)
+
+		var labelMetadata types.LabelMetadata
+		require.NoError(t, json.Unmarshal(labelValue, &labelMetadata))
+
+		return decodeLocalRecordMetadata(value), labelMetadata
+	}
+
+	// A record cannot supersede itself
+	err := r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Supersedes: record.GetCid()})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// First publish stores the superseded CID on the record and its labels
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Supersedes: "cid-v1"})
+	require.NoError(t, err)
+
+	recordMetadata, labelMetadata := stored()
+	assert.Equal(t, "cid-v1", recordMetadata.Supersedes)
+	assert.Equal(t, "cid-v1", labelMetadata.Supersedes)
+
+	// Republishing without a superseded CID keeps it
+	err = r.Publish(t.Context(), adapter)
+	require.NoError(t, err)
+
+	unchanged, _ := stored()
+	assert.Equal(t, "cid-v1", unchanged.Supersedes)
+
+	// Republishing with another superseded CID updates the record and its labels
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Supersedes: "cid-v0"})
+	require.NoError(t, err)
+
+	updated, updatedLabel := stored()
+	assert.Equal(t, "cid-v0", updated.Supersedes)
+	assert.Equal(t, "cid-v0", updatedLabel.Supersedes)
+}
+
+func TestPublishWithOptions_AccessGated(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, nil)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-gated",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+	recordKey := ipfsdatastore.NewKey("/records/" + record.GetCid())
+	labelKey := ipfsdatastore.NewKey(BuildEnhancedLabelKey(types.GetLabelsFromRecord(adapter)[0], record.GetCid(), testPeerID))
+
+	gated := func() (bool, bool) {
+		value, err := dstore.Get(t.Context(), recordKey)
+		require.NoError(t, err)
+
+		labelValue, err := dstore.Get(t.Context(), labelKey)
+		require.NoError(t, err)
+
+		return decodeLocalRecordMetadata(value).AccessGated, labelAccessGated(labelValue)
+	}
+
+	// Records are not gated unless requested
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	recordGated, labelGated := gated()
+	assert.False(t, recordGated)
+	assert.False(t, labelGated)
+
+	// Republishing as access-gated gates the record and its labels
+	require.NoError(t, r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{AccessGated: true}))
+
+	recordGated, labelGated = gated()
+	assert.True(t, recordGated)
+	assert.True(t, labelGated)
+
+	// Republishing without the gate keeps it
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	recordGated, _ = gated()
+	assert.True(t, recordGated)
+}
+
+func TestPublishWithOptions_Tenant(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	tenants, err := newTenants([]routingconfig.TenantConfig{{ID: "acme"}, {ID: "other"}})
+	require.NoError(t, err)
+
+	r := newLocal(newMockStore(), dstore, testPeerID, tenants, nil)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-tenant",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+	label := types.GetLabelsFromRecord(adapter)[0]
+	tenantLabelKey := ipfsdatastore.NewKey(BuildEnhancedLabelKey(tenantLabels("acme", []types.Label{label})[0], record.GetCid(), testPeerID))
+
+	// Unknown tenants are rejected
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Tenant: "unknown"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Labels of tenant records are stored under the tenant's namespace
+	require.NoError(t, r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Tenant: "acme"}))
+
+	exists, err := dstore.Has(t.Context(), tenantLabelKey)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = dstore.Has(t.Context(), ipfsdatastore.NewKey(BuildEnhancedLabelKey(label, record.GetCid(), testPeerID)))
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	refsChan, err := r.List(t.Context(), &routingv1.ListRequest{})
+	require.NoError(t, err)
+
+	for ref := range refsChan {
+		assert.Equal(t, "acme", ref.GetTenantId())
+	}
+
+	// Republishing without a tenant keeps it, publishing for another tenant is refused
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{Tenant: "other"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Unpublishing removes the tenant labels
+	require.NoError(t, r.Unpublish(t.Context(), adapter))
+
+	exists, err = dstore.Has(t.Context(), tenantLabelKey)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestList_PublishedAt(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, nil)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-published-at",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	list := func() *routingv1.ListResponse {
+		refsChan, err := r.List(t.Context(), &routingv1.ListRequest{})
+		require.NoError(t, err)
+
+		var refs []*routingv1.ListResponse
+		for ref := range refsChan {
+			refs = append(refs, ref)
+		}
+
+		require.Len(t, refs, 1)
+
+		return refs[0]
+	}
+
+	publishedAt := list().GetPublishedAt().AsTime()
+	assert.WithinDuration(t, time.Now(), publishedAt, time.Minute)
+
+	// Republishing keeps the first publish time
+	require.NoError(t, r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{TTL: time.Hour}))
+	assert.True(t, list().GetPublishedAt().AsTime().Equal(publishedAt))
+}
+
+func TestDecodeLocalRecordMetadata_LegacyValue(t *testing.T) {
+	// Records published before priorities existed have no value
+	assert.Equal(t, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL, decodeLocalRecordMetadata(nil).Priority)
+}
+
+func TestPublishWithOptions_LabelPolicies(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	r := newLocal(newMockStore(), dstore, testPeerID, nil, nil)
+	r.publishPolicy = []types.LabelPolicy{{Namespace: "skills", Exclude: []string{"category2"}}}
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "test-agent-label-policies",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: toPtr("category1"), ClassName: toPtr("class1")},
+			{CategoryName: toPtr("category2"), ClassName: toPtr("class2")},
+			{CategoryName: toPtr("category3"), ClassName: toPtr("class3")},
+		},
+	})
+	adapter := adapters.NewRecordAdapter(record)
+
+	published := func() []types.Label {
+		labels, err := r.publishedLabels(t.Context(), record.GetCid(), types.GetLabelsFromRecord(adapter))
+		require.NoError(t, err)
+
+		return labels
+	}
+
+	stored := func(label types.Label) bool {
+		exists, err := dstore.Has(t.Context(), ipfsdatastore.NewKey(BuildEnhancedLabelKey(label, record.GetCid(), testPeerID)))
+		require.NoError(t, err)
+
+		return exists
+	}
+
+	// Invalid policies are rejected
+	err := r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{LabelPolicies: []types.LabelPolicy{{Namespace: "unknown"}}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Request policies override the configured policy of their namespace
+	policies := []types.LabelPolicy{{Namespace: "skills", Include: []string{"category1", "category2"}}}
+	require.NoError(t, r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{LabelPolicies: policies}))
+
+	assert.True(t, stored("/skills/category1/class1"))
+	assert.True(t, stored("/skills/category2/class2"))
+	assert.False(t, stored("/skills/category3/class3"))
+
+	// Announcements derive the labels of the record from the stored policies
+	assert.Equal(t, []types.Label{"/skills/category1/class1", "/skills/category2/class2"}, published())
+
+	// Republishing without policies keeps them, republishing with other policies is refused
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	err = r.PublishWithOptions(t.Context(), adapter, types.PublishOptions{LabelPolicies: []types.LabelPolicy{{Namespace: "skills"}}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Records published without policies follow the configured policy
+	require.NoError(t, r.Unpublish(t.Context(), adapter))
+	require.NoError(t, r.Publish(t.Context(), adapter))
+
+	assert.True(t, stored("/skills/category1/class1"))
+	assert.False(t, stored("/skills/category2/class2"))
+	assert.True(t, stored("/skills/category3/class3"))
+	assert.Equal(t, []types.Label{"/skills/category1/class1", "/skills/category3/class3"}, published())
+
+	// Labels of records that are not published locally are not filtered
+	require.NoError(t, r.Unpublish(t.Context(), adapter))
+	assert.Len(t, published(), 3)
+}
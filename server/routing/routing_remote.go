@@ -6,29 +6,45 @@ package routing
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingdatastore "github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/routing/bandwidth"
+	"github.com/agntcy/dir/server/routing/cardinality"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/continuity"
+	"github.com/agntcy/dir/server/routing/events"
+	"github.com/agntcy/dir/server/routing/internal/didkey"
 	"github.com/agntcy/dir/server/routing/internal/p2p"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
 	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/ratelimit"
+	"github.com/agntcy/dir/server/routing/reputation"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/agntcy/dir/server/routing/routingerr"
 	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/routing/sinks"
 	validators "github.com/agntcy/dir/server/routing/validators"
 	"github.com/agntcy/dir/server/types"
-	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-cid"
-	"github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
 	"github.com/libp2p/go-libp2p-kad-dht/providers"
 	record "github.com/libp2p/go-libp2p-record"
 	"github.com/libp2p/go-libp2p/core/host"
-	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
-	ma "github.com/multiformats/go-multiaddr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -46,18 +62,13 @@ type NamespaceEntry struct {
 // QueryAllNamespaces queries all supported label namespaces and returns processed entries.
 // This centralizes namespace iteration and datastore querying, eliminating code duplication
 // between local and remote routing operations. All resource management is handled internally.
+// Labels of tenant records are included, with their tenant's namespace (see splitTenantLabel).
 func QueryAllNamespaces(ctx context.Context, dstore types.Datastore) ([]NamespaceEntry, error) {
 	var entries []NamespaceEntry
 
-	// Query all label namespaces
-	namespaces := []string{
-		types.LabelTypeSkill.Prefix(),
-		types.LabelTypeDomain.Prefix(),
-		types.LabelTypeModule.Prefix(),
-		types.LabelTypeLocator.Prefix(),
-	}
+	// Query all label namespaces, including registered custom namespaces and tenants
+	for _, namespace := range labelKeyPrefixes() {
 
-	for _, namespace := range namespaces {
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
@@ -96,13 +107,61 @@ func QueryAllNamespaces(ctx context.Context, dstore types.Datastore) ([]Namespac
 // routeRemote handles routing across the network with hybrid label discovery.
 // It uses both GossipSub (efficient, wide propagation) and DHT+Pull (fallback).
 type routeRemote struct {
-	storeAPI       types.StoreAPI
-	server         *p2p.Server
-	service        *rpc.Service
-	notifyCh       chan *handlerSync
-	dstore         types.Datastore
-	cleanupManager *CleanupManager
-	pubsubManager  *pubsub.Manager // GossipSub manager for label announcements (nil if disabled)
+	storeAPI          types.StoreAPI
+	server            *p2p.Server
+	service           *rpc.Service
+	notifyQueue       *notifyQueue
+	pulls             *pullPool
+	dstore            types.Datastore
+	cleanupManager    *CleanupManager
+	pubsubManager     *pubsub.Manager       // GossipSub manager for label announcements (nil if disabled)
+	publishDedup      *publishDeduplicator  // Coalesces repeated publishes of the same CID
+	announcedLabelsMu sync.Mutex            // Serializes updates of the labels last announced for local records
+	reputation        *reputation.Tracker   // Per-peer announcement and pull behaviour
+	scoring           *scoringStrategies    // Strategies computing the relevance of search results
+	tenants           *tenants              // Tenants whose records are published, cached and searched
+	publishers        *publishers           // Publishers whose records are published by this peer
+	peerStats         *peerstats.Tracker    // Per-peer label counts and announcement rates
+	addressBook       *addressbook.Book     // Multiaddrs of remote directory peers
+	cardinality       *cardinality.Index    // Label cardinality sketches used to estimate search results
+	providerSets      *providerset.Index    // Providers and label union of each cached remote record
+	providerLookups   *providerLookupCache  // Recent DHT provider lookups, including ones without providers
+	labelDigests      *labelDigests         // Label digests of peers, selecting the peers of live searches
+	peerCapabilities  *peerCapabilities     // Capabilities advertised by peers in the capability handshake
+	lineage           *lineageIndex         // Cached remote records superseded by a newer version of the same peer
+	pending           *pendingAnnouncements // Records published before the routing table had peers
+	announcements     *announcementChecks   // Last resolvability check of each local record
+	cacheUsage        *labelCacheUsage      // Search hits and buffered LastSeen refreshes of cached records
+	replication       *replicator           // Replication policy state (nil if no policies are configured)
+	pins              *pinSet               // CIDs of pinned records, exempt from label cleanup, expiry and garbage collection
+	recordGC          *recordGC             // Garbage collection of local records under disk pressure (nil if disabled)
+	state             *runtimeState         // Task runs and announcement counters persisted across restarts
+	maxCachedLabels   int                   // Remote labels kept before records are evicted (0 = unbounded)
+	readinessMinPeers int                   // Routing table peers required to report ready
+	events            *events.Emitter       // Routing events published to message queues (nil if disabled)
+	announcementSinks *sinks.Bridge         // Announcements republished to message buses (nil if disabled)
+	subscriptions     *subscriptions        // Subscriptions pushed newly cached matching records (nil if disabled)
+	history           *historyRecorder      // Downsampled discovery metrics retained in the datastore (nil if disabled)
+	provenance        *provenanceLog        // Announcements of records logged in the datastore (nil if disabled)
+	prefetch          *prefetcher           // Search results prefetched into the local store (nil if disabled)
+	retention         *pullRetention        // Pulled records retained in the local store (nil if disabled)
+	cacheWarmed       chan struct{}         // Closed once seed peer cache warming is done (nil if disabled)
+	backfill          backfillJob           // Progress of the running or the last label cache backfill
+	bandwidth         *bandwidth.Meter      // Record content served by Pull to each peer per hour
+	liveness          *peerLiveness         // Peers with cached labels failing liveness probes (nil if disabled)
+	follower          *follower             // Mirror of the primary's datastore while a warm standby (nil on primaries)
+	ipni              *ipniAdvertiser       // IPNI advertisements of the published records (nil if disabled)
+
+	// Discovery profile
+	profile   atomic.Pointer[discoveryProfile] // Switchable at runtime via SetProfile
+	dhtServer bool                             // Whether the DHT serves records to other peers, fixed at startup
+	dhtMode   string                           // DHT mode configured regardless of the profile, if any
+
+	// Deadlines of remote operations, applied on top of the callers' contexts
+	timeouts routingconfig.TimeoutsConfig
+
+	// Publish policy of local records, set once local routing is created (see publishedLabels)
+	publishPolicy atomic.Pointer[publishPolicyFunc]
 
 	// Lifecycle management
 	//nolint:containedctx // Context needed for managing lifecycle of multiple long-running goroutines (handleNotify, cleanup tasks)
@@ -116,31 +175,155 @@ func newRemote(parentCtx context.Context,
 	dstore types.Datastore,
 	opts types.APIOptions,
 ) (*routeRemote, error) {
+	// Validate republish strategies before starting anything
+	strategies, err := newRepublishStrategies(opts.Config().Routing.RepublishStrategies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid republish strategies: %w", err)
+	}
+
+	replicationPolicies, err := newReplicationPolicies(opts.Config().Routing.Replication)
+	if err != nil {
+		return nil, fmt.Errorf("invalid replication policies: %w", err)
+	}
+
+	profile, err := parseDiscoveryProfile(opts.Config().Routing.Profile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid discovery profile: %w", err)
+	}
+
+	tenants, err := newTenants(opts.Config().Routing.Tenants)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenants: %w", err)
+	}
+
+	publishers, err := newPublishers(opts.Config().Routing.Publishers)
+	if err != nil {
+		return nil, fmt.Errorf("invalid publishers: %w", err)
+	}
+
+	if ids := publishers.ids(); len(ids) > 0 {
+		remoteLogger.Info("Publishing records of configured publishers", "publishers", ids)
+	}
+
+	peerReputation := reputation.New()
+
+	scoring, err := newScoringStrategies(opts.Config().Routing.Scoring, peerReputation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scoring configuration: %w", err)
+	}
+
+	// Publish routing events to the configured message queues (nil if none)
+	eventEmitter, err := newEventEmitter(opts.Config().Routing.Events)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create routing subsystem context for lifecycle management of background tasks
 	routingCtx, cancel := context.WithCancel(parentCtx)
 
 	// Create routing
 	routeAPI := &routeRemote{
-		storeAPI: storeAPI,
-		notifyCh: make(chan *handlerSync, NotificationChannelSize),
-		dstore:   dstore,
-		ctx:      routingCtx,
-		cancel:   cancel,
+		storeAPI:          storeAPI,
+		notifyQueue:       newNotifyQueue(dstore),
+		pulls:             newPullPool(pullConcurrency(opts.Config().Routing)),
+		dstore:            dstore,
+		publishDedup:      newPublishDeduplicator(opts.Config().Routing.PublishDedupWindow),
+		timeouts:          opts.Config().Routing.Timeouts,
+		reputation:        peerReputation,
+		scoring:           scoring,
+		tenants:           tenants,
+		publishers:        publishers,
+		peerStats:         peerstats.New(),
+		addressBook:       addressbook.New(dstore, PeerAddressTTL),
+		cardinality:       cardinality.NewIndex(),
+		providerSets:      providerset.NewIndex(),
+		providerLookups:   newProviderLookupCache(ProviderLookupTTL, NegativeProviderLookupTTL),
+		labelDigests:      newLabelDigests(),
+		peerCapabilities:  newPeerCapabilities(),
+		lineage:           newLineageIndex(),
+		pending:           newPendingAnnouncements(),
+		announcements:     newAnnouncementChecks(),
+		cacheUsage:        newLabelCacheUsage(),
+		pins:              newPinSet(),
+		maxCachedLabels:   opts.Config().Routing.MaxCachedLabels,
+		readinessMinPeers: opts.Config().Routing.ReadinessMinPeers,
+		subscriptions:     newSubscriptions(opts.Config().Routing.MaxSubscriptions),
+		events:            eventEmitter,
+		ctx:               routingCtx,
+		cancel:            cancel,
 	}
 
+	started := false
+
+	// Release everything created so far if the routing subsystem fails to start
+	defer func() {
+		if !started {
+			routeAPI.abort()
+		}
+	}()
+
+	routeAPI.profile.Store(&profile)
+	routeAPI.dhtServer = profile.dhtServer
+	routeAPI.dhtMode = opts.Config().Routing.DHT.Mode
+
+	// Set before notifications are handled, which retain the records they pull
+	if retainCfg := opts.Config().Routing.RetainPulled; retainCfg.Enabled {
+		routeAPI.retention = newPullRetention(retainCfg)
+	}
+
+	// Edge and client nodes query the DHT without serving records to other peers,
+	// unless the DHT mode is configured
+	dhtMode := dht.ModeClient
+	if profile.dhtServer {
+		dhtMode = dht.ModeServer
+	}
+
+	dhtCfg := opts.Config().Routing.DHT
+	limitsCfg := opts.Config().Routing.ResourceLimits
+
 	refreshInterval := RefreshInterval
 	if opts.Config().Routing.RefreshInterval > 0 {
 		refreshInterval = opts.Config().Routing.RefreshInterval
 	}
 
+	// Discover peers on the local network via mDNS, e.g. LAN deployments without bootstrap peers
+	mdnsServiceName := ""
+	if mdnsCfg := opts.Config().Routing.MDNS; mdnsCfg.Enabled {
+		mdnsServiceName = mdnsCfg.ServiceName
+	}
+
+	followerCfg := opts.Config().Routing.Follower
+
 	// Use parent context for p2p server (should live as long as the server)
 	server, err := p2p.New(parentCtx,
 		p2p.WithListenAddress(opts.Config().Routing.ListenAddress),
 		p2p.WithDirectoryAPIAddress(opts.Config().Routing.DirectoryAPIAddress),
+		p2p.WithZone(opts.Config().Routing.Zone),
 		p2p.WithBootstrapAddrs(opts.Config().Routing.BootstrapPeers),
 		p2p.WithRefreshInterval(refreshInterval),
 		p2p.WithRandevous(ProtocolRendezvous), // enable libp2p auto-discovery
+		p2p.WithMDNS(mdnsServiceName),
 		p2p.WithIdentityKeyPath(opts.Config().Routing.KeyPath),
+		p2p.WithAllowedPeers(opts.Config().Routing.AllowedPeers),
+		p2p.WithDeniedPeers(opts.Config().Routing.DeniedPeers),
+		p2p.WithPrivateNetworkKeyPath(opts.Config().Routing.PrivateNetworkKeyPath),
+		p2p.WithNAT(p2p.NATOptions{
+			AutoNATService: opts.Config().Routing.NAT.AutoNATService,
+			PortMapping:    opts.Config().Routing.NAT.PortMapping,
+			HolePunching:   opts.Config().Routing.NAT.HolePunching,
+			RelayClient:    opts.Config().Routing.NAT.RelayClient,
+			StaticRelays:   opts.Config().Routing.NAT.StaticRelays,
+			RelayService:   opts.Config().Routing.NAT.RelayService,
+			Reachability:   opts.Config().Routing.NAT.Reachability,
+		}),
+		p2p.WithDHTMode(dhtCfg.Mode),
+		p2p.WithStandby(followerCfg.Enabled), // followers join the network once promoted
+		p2p.WithResourceLimits(p2p.ResourceLimits{
+			MaxMemory:          limitsCfg.MaxMemory,
+			MaxFileDescriptors: limitsCfg.MaxFileDescriptors,
+			MaxConnections:     limitsCfg.MaxConnections,
+			MaxStreams:         limitsCfg.MaxStreams,
+		}),
 		p2p.WithCustomDHTOpts(
 			func(h host.Host) ([]dht.Option, error) {
 				providerMgr, err := providers.NewProviderManager(h.ID(), h.Peerstore(), dstore)
@@ -148,25 +331,42 @@ func newRemote(parentCtx context.Context,
 					return nil, fmt.Errorf("failed to create provider manager: %w", err)
 				}
 
-				labelValidators := validators.CreateLabelValidators()
-				validator := record.NamespacedValidator{
-					types.LabelTypeSkill.String():  labelValidators[types.LabelTypeSkill.String()],
-					types.LabelTypeDomain.String(): labelValidators[types.LabelTypeDomain.String()],
-					types.LabelTypeModule.String(): labelValidators[types.LabelTypeModule.String()],
+				validator := record.NamespacedValidator{}
+				for namespace, labelValidator := range validators.CreateLabelValidators() {
+					// Locator labels are not validated by the DHT
+					if namespace == types.LabelTypeLocator.String() {
+						continue
+					}
+
+					validator[namespace] = labelValidator
 				}
 
-				return []dht.Option{
+				validator[revocation.Namespace] = &validators.RevocationValidator{}
+				validator[continuity.Namespace] = &validators.ContinuityValidator{}
+
+				dhtOpts := []dht.Option{
 					dht.Datastore(dstore),                           // custom DHT datastore
 					dht.ProtocolPrefix(protocol.ID(ProtocolPrefix)), // custom DHT protocol prefix
 					dht.Validator(validator),                        // custom validators for label namespaces
 					dht.MaxRecordAge(RecordTTL),                     // set consistent TTL for all DHT records
-					dht.Mode(dht.ModeServer),
+					dht.Mode(dhtMode),
 					dht.ProviderStore(&handler{
 						ProviderManager: providerMgr,
 						hostID:          h.ID().String(),
-						notifyCh:        routeAPI.notifyCh,
+						queue:           routeAPI.notifyQueue,
 					}),
-				}, nil
+				}
+
+				// Unset sizes keep the defaults of the DHT
+				if dhtCfg.BucketSize > 0 {
+					dhtOpts = append(dhtOpts, dht.BucketSize(dhtCfg.BucketSize))
+				}
+
+				if dhtCfg.Concurrency > 0 {
+					dhtOpts = append(dhtOpts, dht.Concurrency(dhtCfg.Concurrency))
+				}
+
+				return dhtOpts, nil
 			},
 		),
 	)
@@ -176,24 +376,97 @@ func newRemote(parentCtx context.Context,
 
 	routeAPI.server = server
 
-	rpcService, err := rpc.New(server.Host(), storeAPI)
+	if followerCfg.Enabled {
+		routeAPI.follower, err = newFollower(followerCfg)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(replicationPolicies) > 0 {
+		routeAPI.replication = newReplicator(replicationPolicies)
+	}
+
+	// Count the cached labels of each remote peer before new labels arrive
+	routeAPI.seedPeerStats(routingCtx)
+
+	// Load pins before cleanup tasks start, so that pinned labels are never cleaned up
+	if err := routeAPI.loadPins(routingCtx); err != nil {
+		return nil, fmt.Errorf("failed to load pinned records: %w", err)
+	}
+
+	// Resume processing the notifications queued before a restart
+	if err := routeAPI.notifyQueue.load(routingCtx); err != nil {
+		return nil, fmt.Errorf("failed to load queued notifications: %w", err)
+	}
+
+	// Load runtime state before cleanup tasks start, so that they are scheduled from their last runs
+	state, err := loadRuntimeState(routingCtx, dstore, time.Now())
 	if err != nil {
-		defer server.Close()
+		return nil, fmt.Errorf("failed to load runtime state: %w", err)
+	}
 
+	routeAPI.state = state
+
+	rpcService, err := rpc.New(server.Host(), storeAPI)
+	if err != nil {
 		return nil, fmt.Errorf("failed to create RPC service: %w", err)
 	}
 
 	routeAPI.service = rpcService
 
+	// Serve label cache snapshots to peers warming their cache from us
+	rpcService.SetSnapshotProvider(routeAPI.serveLabelSnapshot)
+	rpcService.SetSearchProvider(routeAPI.serveLiveSearch)
+	rpcService.SetRecordMetadataProvider(routeAPI.recordMetadata)
+	rpcService.SetPublishedLabelsProvider(routeAPI.publishedLabels)
+	rpcService.SetGatedAccessAuthorizer(newGatedAccessAuthorizer(opts.Config().Routing.GatedAccessPeers, server.Host().ID()))
+	rpcService.SetAccessTokenProvider(newAccessTokenProvider(opts.Config().Routing.GatedAccessTokens, server.Host().ID()))
+	rpcService.SetVerifyProvider(routeAPI.serveVerification)
+	rpcService.SetLabelSyncProvider(routeAPI.serveLabelSync)
+	rpcService.SetCapabilitiesProvider(routeAPI.localCapabilities)
+
+	// Rate limit inbound requests and announcements per peer.
+	// Peers banned for abusing either are refused both.
+	rateLimit := opts.Config().Routing.RateLimit
+	bans := ratelimit.NewBans(rateLimit.BanThreshold, rateLimit.BanDuration)
+	rpcService.SetRateLimiter(ratelimit.New(rateLimit.RequestRate, rateLimit.RequestBurst, bans))
+
+	// Meter the record content pulled by each peer, refusing pulls above the hourly quota
+	routeAPI.bandwidth = bandwidth.New(rateLimit.PullBytesPerHour)
+	rpcService.SetBandwidthMeter(routeAPI.bandwidth)
+
 	// Initialize GossipSub manager if enabled
 	// Protocol parameters (topic, message size) are defined in pubsub.constants
 	// and are NOT configurable to ensure network-wide compatibility
 	if opts.Config().Routing.GossipSub.Enabled {
-		// Use parent context for GossipSub (should live as long as the server)
-		pubsubManager, err := pubsub.New(parentCtx, server.Host())
+		namespaces, err := pubsub.ParseNamespaces(opts.Config().Routing.GossipSub.Namespaces)
 		if err != nil {
-			defer server.Close()
+			return nil, fmt.Errorf("invalid gossipsub subscription policy: %w", err)
+		}
 
+		// Use parent context for GossipSub (should live as long as the server)
+		pubsubManager, err := pubsub.New(parentCtx, server.Host(), pubsub.Options{
+			Namespaces:        namespaces,
+			RequireSignatures: opts.Config().Routing.GossipSub.RequireSignatures,
+			PeerScore:         routeAPI.gossipSubPeerScore,
+			OnAnnouncement:    routeAPI.observeAnnouncement,
+			RecordExpiration:  routeAPI.recordExpiration,
+			RecordSupersedes:  routeAPI.recordSupersedes,
+			RecordAccessGated: routeAPI.recordAccessGated,
+			RecordSize:        routeAPI.recordSize,
+			RecordSummary:     routeAPI.recordSummary,
+			RecordTenant:      routeAPI.recordTenant,
+			RecordPublisher:   routeAPI.recordPublisher,
+			RemovedLabels:     routeAPI.removedLabels,
+			LabelsAnnounced:   routeAPI.labelsAnnounced,
+			PublishedLabels:   routeAPI.publishedLabels,
+			RateLimiter:       ratelimit.New(rateLimit.AnnouncementRate, rateLimit.AnnouncementBurst, bans),
+			WireFormat:        opts.Config().Routing.GossipSub.WireFormat,
+			BatchWindow:       opts.Config().Routing.GossipSub.BatchWindow,
+			PropagationAcks:   opts.Config().Routing.GossipSub.PropagationAcks,
+		})
+		if err != nil {
 			return nil, fmt.Errorf("failed to create pubsub manager: %w", err)
 		}
 
@@ -201,80 +474,270 @@ func newRemote(parentCtx context.Context,
 
 		// Set callback for received label announcements
 		pubsubManager.SetOnRecordPublishEvent(routeAPI.handleRecordPublishEvent)
+		pubsubManager.SetOnRecordRevocation(routeAPI.handleRecordRevocation)
+		pubsubManager.SetOnLabelDigest(routeAPI.handleLabelDigest)
+		pubsubManager.SetOnContinuity(routeAPI.handleContinuity)
+
+		// Mirror the announcements to the configured message buses (nil if none)
+		routeAPI.announcementSinks, err = newAnnouncementBridge(opts.Config().Routing.AnnouncementSinks, server.Host().ID().String())
+		if err != nil {
+			return nil, err
+		}
+
+		pubsubManager.SetOnAnnouncementsPublished(routeAPI.mirrorPublishedAnnouncements)
 
 		// Start periodic mesh peer tagging to protect them from Connection Manager pruning
 		routeAPI.startMeshPeerTagging()
 
+		// Publish the label digest of this peer for live searches of other peers
+		routeAPI.startLabelDigests()
+
 		remoteLogger.Info("GossipSub label announcements enabled")
 	} else {
 		remoteLogger.Info("GossipSub disabled, using DHT+Pull fallback only")
 	}
 
-	// Pass Publish as callback to avoid circular dependency
+	// Hand over the labels and addresses of the previous identity after a key rotation
+	if previousKeyPath := opts.Config().Routing.PreviousKeyPath; previousKeyPath != "" {
+		record, err := newContinuityRecord(previousKeyPath, server.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		routeAPI.startIdentityContinuity(record)
+	}
+
+	// Keep peer addresses current and expire stale ones
+	routeAPI.addressBook.OnChange(func(peerID string, dirAddrs []string) {
+		routeAPI.events.Emit(events.PeerChanged(peerID, dirAddrs))
+	})
+	routeAPI.startAddressBookMaintenance()
+
+	// Exchange capabilities with directory peers to describe them in search results
+	routeAPI.startCapabilityHandshakes()
+
+	// Keep the cardinality index used to estimate search results and the provider sets current
+	routeAPI.startLabelIndexMaintenance()
+
+	// Periodically write buffered LastSeen refreshes and bound the label cache size
+	routeAPI.startLabelCacheCompaction()
+
+	// Sync the labels published by connected peers instead of waiting for their announcements
+	routeAPI.startLabelSync()
+
+	// Periodically report warm RPC stream pool usage
+	routeAPI.startStreamPoolReporting()
+
+	// Periodically update the label cache, DHT and GossipSub gauges
+	routeAPI.startMetricsReporting()
+
+	// Periodically report datastore metrics if the datastore is instrumented
+	if metricsDstore, ok := unwrapDatastore[*routingdatastore.MetricsDatastore](dstore); ok {
+		routeAPI.startDatastoreMetricsReporting(metricsDstore)
+	}
+
+	// Advertise the published records to IPNI indexers
+	if ipniCfg := opts.Config().Routing.IPNI; ipniCfg.Enabled {
+		advertiser, err := newIPNIAdvertiser(ipniCfg, dstore, server)
+		if err != nil {
+			return nil, err
+		}
+
+		routeAPI.ipni = advertiser
+		routeAPI.startIPNIAnnouncing()
+	}
+
+	// Pass PublishWithPriority as callback to avoid circular dependency
 	// The method value captures routeAPI's state (server, pubsubManager)
-	routeAPI.cleanupManager = NewCleanupManager(dstore, storeAPI, server, routeAPI.Publish)
+	routeAPI.cleanupManager = NewCleanupManager(dstore, storeAPI, server, routeAPI.PublishWithPriority, routeAPI.peerStats, routeAPI.pins, routeAPI.lineage, strategies, routeAPI.state, routeAPI.republishEnabled)
 
 	// Start all background goroutines with routing context
 	routeAPI.wg.Add(1)
 
 	go routeAPI.handleNotify()
 
-	routeAPI.wg.Add(1)
+	// Followers mirror the datastore of the primary instead, until promoted
+	if routeAPI.follower != nil {
+		routeAPI.startFollowing()
+	} else {
+		routeAPI.startAnnouncing()
+	}
+
+	if historyCfg := opts.Config().Routing.History; historyCfg.Enabled {
+		routeAPI.history = newHistoryRecorder(routingCtx, dstore, historyCfg.Retention, time.Now())
+		routeAPI.startHistoryRecording()
+	}
+
+	if provenanceCfg := opts.Config().Routing.Provenance; provenanceCfg.Enabled {
+		routeAPI.provenance = newProvenanceLog(dstore, provenanceCfg.Retention)
+		routeAPI.startProvenancePruning()
+	}
+
+	if livenessCfg := opts.Config().Routing.Liveness; livenessCfg.Enabled {
+		routeAPI.liveness = newPeerLiveness(livenessCfg)
+		routeAPI.startLivenessProbing(livenessCfg.Interval)
+	}
+
+	if prefetchCfg := opts.Config().Routing.Prefetch; prefetchCfg.Enabled {
+		routeAPI.prefetch = newPrefetcher(prefetchCfg)
+		routeAPI.startPrefetching()
+	}
+
+	if routeAPI.retention != nil {
+		routeAPI.startPullRetention()
+	}
+
+	// Started by routing.New, which collects records through the local and remote unpublish paths
+	if gcCfg := opts.Config().Routing.RecordGC; gcCfg.Threshold > 0 {
+		routeAPI.recordGC = newRecordGC(gcCfg)
+	}
+
+	// Warm the remote label cache from the seed peer on first boot
+	if seedPeer := opts.Config().Routing.SeedPeer; seedPeer != "" {
+		routeAPI.startCacheWarming(seedPeer)
+	}
+
+	started = true
+
+	return routeAPI, nil
+}
+
+// startAnnouncing starts the background tasks announcing the local records and maintaining the
+// datastore. Followers start them once promoted, so that the primary's datastore is only
+// announced and maintained by one peer at a time.
+func (r *routeRemote) startAnnouncing() {
+	// Periodically verify that local records are resolvable by other peers
+	r.startAnnouncementVerification()
+
+	// Announce records published before the routing table had peers
+	r.startPendingAnnouncements()
+
+	r.wg.Add(1)
 	//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
-	go routeAPI.cleanupManager.StartLabelRepublishTask(routeAPI.ctx, &routeAPI.wg)
+	go r.cleanupManager.StartLabelRepublishTask(r.ctx, &r.wg)
 
-	routeAPI.wg.Add(1)
+	r.wg.Add(1)
 	//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
-	go routeAPI.cleanupManager.StartRemoteLabelCleanupTask(routeAPI.ctx, &routeAPI.wg)
+	go r.cleanupManager.StartRemoteLabelCleanupTask(r.ctx, &r.wg)
 
-	return routeAPI, nil
+	r.wg.Add(1)
+	//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
+	go r.cleanupManager.StartExpiredRecordCleanupTask(r.ctx, &r.wg)
+
+	for _, strategy := range r.cleanupManager.strategies {
+		r.wg.Add(1)
+		//nolint:contextcheck // Intentionally passing routing context to child goroutine for lifecycle management
+		go r.cleanupManager.StartNamespaceRepublishTask(r.ctx, &r.wg, strategy)
+	}
 }
 
-// Publish announces a record to the network via DHT and GossipSub.
-// This method is part of the RoutingAPI interface and is also used
-// by CleanupManager for republishing via method value injection.
+// Publish announces a record to the network via DHT and GossipSub with normal priority.
+// This method is part of the RoutingAPI interface.
+func (r *routeRemote) Publish(ctx context.Context, record types.Record) error {
+	return r.PublishWithPriority(ctx, record, routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_NORMAL)
+}
+
+// PublishWithPriority announces a record to the network according to its priority.
+// It is also used by CleanupManager for republishing via method value injection.
 //
 // Flow:
 //  1. Validate and extract CID from record
-//  2. Announce CID to DHT (critical - returns error if fails)
-//  3. Publish record via GossipSub (best-effort - logs warning if fails)
+//  2. Coalesce with in-flight or recent announcements of the same CID
+//     (high-priority records bypass the dedup window and are always announced)
+//  3. Announce CID to DHT (critical - returns error if fails)
+//  4. Publish record via GossipSub (best-effort - logs warning if fails),
+//     skipped for low-priority records
 //
 // Parameters:
 //   - ctx: Operation context
 //   - record: Record interface (caller must wrap corev1.Record with adapter)
+//   - priority: Announcement priority (unspecified is treated as normal)
 //
 // Returns:
 //   - error: If critical operations fail (validation, CID parsing, DHT announcement)
-func (r *routeRemote) Publish(ctx context.Context, record types.Record) error {
-	// Validation
+func (r *routeRemote) PublishWithPriority(ctx context.Context, record types.Record, priority routingv1.AnnouncementPriority) error {
+	return r.publishWithPriority(ctx, record, priority, nil)
+}
+
+// publishWithPriority is PublishWithPriority reporting the network stages of the record to progress, if set.
+func (r *routeRemote) publishWithPriority(ctx context.Context, record types.Record, priority routingv1.AnnouncementPriority, progress publishProgress) error {
+	// Validate and parse CID
+	decodedCID, err := parseRecordCID(record)
+	if err != nil {
+		return err
+	}
+
+	cidStr := record.GetCid()
+	priority = normalizePriority(priority)
+
+	remoteLogger.Debug("Publishing record to network", "cid", cidStr, "priority", priority)
+
+	// Bound the whole announcement, so that a slow DHT cannot stall the caller
+	ctx, cancel := withTimeout(ctx, r.timeouts.Publish)
+	defer cancel()
+
+	announceFn := func() error {
+		return publishBudgetError(ctx, r.announce(ctx, record, decodedCID, priority, progress))
+	}
+
+	// High-priority records are announced immediately
+	if priority == routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_HIGH {
+		return announceFn()
+	}
+
+	variant, err := r.publishVariant(ctx, record, priority)
+	if err != nil {
+		return err
+	}
+
+	// Skip redundant Provide/gossip for clients that retry aggressively
+	deduplicated, err := r.publishDedup.Do(ctx, cidStr, variant, announceFn)
+	if deduplicated {
+		remoteLogger.Debug("Coalesced repeated publish within dedup window", "cid", cidStr, "error", err)
+	}
+
+	return err
+}
+
+// parseRecordCID validates the record and decodes its CID.
+func parseRecordCID(record types.Record) (cid.Cid, error) {
 	if record == nil {
-		return status.Error(codes.InvalidArgument, "record is required") //nolint:wrapcheck
+		return cid.Undef, status.Error(codes.InvalidArgument, "record is required") //nolint:wrapcheck
 	}
 
-	// Extract and validate CID
 	cidStr := record.GetCid()
 	if cidStr == "" {
-		return status.Error(codes.InvalidArgument, "record has no CID") //nolint:wrapcheck
+		return cid.Undef, routingerr.ErrCIDInvalid.Errorf("record has no CID")
 	}
 
-	remoteLogger.Debug("Publishing record to network", "cid", cidStr)
-
-	// Parse CID
 	decodedCID, err := cid.Decode(cidStr)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid CID %q: %v", cidStr, err)
+		return cid.Undef, routingerr.ErrCIDInvalid.Errorf("invalid CID %q: %w", cidStr, err)
 	}
 
+	return decodedCID, nil
+}
+
+// announce performs the actual DHT and GossipSub announcement of a record.
+// Low-priority records are only announced to the DHT.
+func (r *routeRemote) announce(ctx context.Context, record types.Record, decodedCID cid.Cid, priority routingv1.AnnouncementPriority, progress publishProgress) error {
+	cidStr := decodedCID.String()
+
+	ctx, span := tracer.Start(ctx, "routing.Announce", trace.WithAttributes(
+		attribute.String("cid", cidStr),
+		attribute.String("priority", priority.String())))
+	defer span.End()
+
 	// 1. Announce CID to DHT network (content discovery)
-	err = r.server.DHT().Provide(ctx, decodedCID, true)
-	if err != nil {
-		return status.Errorf(codes.Internal, "failed to announce CID to DHT: %v", err)
+	if err := r.provide(ctx, decodedCID, progress); err != nil {
+		return err
 	}
 
-	// 2. Publish record via GossipSub (if enabled)
+	// 2. Publish record via GossipSub (if enabled and not low priority)
 	// This provides efficient label propagation to ALL subscribed peers
-	if r.pubsubManager != nil {
-		if err := r.pubsubManager.PublishRecord(ctx, record); err != nil {
+	gossip := r.pubsubManager != nil && priority != routingv1.AnnouncementPriority_ANNOUNCEMENT_PRIORITY_LOW
+	if gossip {
+		if err := r.gossip(ctx, func(ctx context.Context) error { return r.pubsubManager.PublishRecord(ctx, record) }); err != nil {
 			// Log warning but don't fail - DHT announcement already succeeded
 			// Remote peers can still discover via DHT+Pull fallback
 			remoteLogger.Warn("Failed to publish record via GossipSub",
@@ -285,22 +748,34 @@ func (r *routeRemote) Publish(ctx context.Context, record types.Record) error {
 			remoteLogger.Debug("Successfully published record via GossipSub",
 				"cid", cidStr,
 				"topicPeers", len(r.pubsubManager.GetTopicPeers()))
+
+			r.reportGossiped(ctx, []types.Record{record}, progress)
 		}
+	} else if r.pubsubManager != nil {
+		r.gossipRemovedLabels(ctx, []types.Record{record})
 	}
 
+	// 3. Advertise record to IPNI indexers (if enabled)
+	r.advertiseToIPNI(ctx, record, decodedCID)
+
 	remoteLogger.Debug("Successfully announced record to network",
 		"cid", cidStr,
 		"dhtPeers", r.server.DHT().RoutingTable().Size(),
-		"gossipSubEnabled", r.pubsubManager != nil)
+		"gossipSub", gossip)
 
 	return nil
 }
 
 // Search queries remote records using cached labels with OR logic and minimum threshold.
 // Records are returned if they match at least minMatchScore queries (OR relationship).
+// Each response carries a continuation token that can be used to resume the search after it.
+// Live searches additionally query connected peers on the first page (see searchLivePeers).
+// The search mode trades recall for latency (see routingv1.SearchMode).
 func (r *routeRemote) Search(ctx context.Context, req *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error) {
 	remoteLogger.Debug("Called remote routing's Search method", "req", req)
 
+	r.history.searched()
+
 	// Deduplicate queries to ensure consistent scoring regardless of client behavior
 	originalQueries := req.GetQueries()
 	deduplicatedQueries := deduplicateQueries(originalQueries)
@@ -318,37 +793,206 @@ func (r *routeRemote) Search(ctx context.Context, req *routingv1.SearchRequest)
 		remoteLogger.Debug("Applied minimum match score for production safety", "original", req.GetMinMatchScore(), "applied", minMatchScore)
 	}
 
+	// Decode continuation token (if any) to resume a previous search
+	queryHash := searchQueryHash(deduplicatedQueries, minMatchScore)
+
+	cursor, err := decodeSearchCursor(req.GetPageToken(), queryHash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+
+	if _, ok := routingv1.RetrievalMethod_name[int32(req.GetRequiredRetrievalMethod())]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown retrieval method %d", req.GetRequiredRetrievalMethod())
+	}
+
+	for _, zone := range req.GetZones() {
+		if err := validateZone(zone); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	if req.GetZonesOnly() && len(req.GetZones()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "zones_only requires zones")
+	}
+
+	// Tenants only search the records visible to them
+	if !r.tenants.known(req.GetTenantId()) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown tenant %q", req.GetTenantId())
+	}
+
+	scope := r.tenants.scope(req.GetTenantId())
+
+	if req.GetPublisher() != "" {
+		if _, err := didkey.Decode(req.GetPublisher()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid publisher: %v", err)
+		}
+	}
+
+	// Return the records of peers in the caller's zones first, or only those
+	zones := r.newZoneFilter(req.GetZones(), req.GetZonesOnly())
+
+	startPhase := zonePhasePreferred
+	if cursor != nil {
+		startPhase = cursor.Phase
+	}
+
+	passes, err := searchPasses(zones.phases(startPhase), scope, cursor)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+	}
+
+	scorer, err := r.scoring.forSearch(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid scoring strategy: %v", err)
+	}
+
+	fast := req.GetSearchMode() == routingv1.SearchMode_SEARCH_MODE_FAST
+
+	// The span covers the whole search, until the last result is streamed
+	ctx, span := tracer.Start(ctx, "routing.Search", trace.WithAttributes(
+		attribute.Int("queries", len(deduplicatedQueries)),
+		attribute.Int("minMatchScore", int(minMatchScore)),
+		attribute.String("mode", req.GetSearchMode().String())))
+
+	// Avoid returning an empty result set while the cache is still being warmed,
+	// unless the caller prefers a fast answer from what is cached so far
+	if !fast {
+		if err := r.waitForCacheWarming(ctx); err != nil {
+			err = status.Errorf(codes.Canceled, "search canceled: %v", err)
+			endSpan(span, err)
+
+			return nil, err
+		}
+	}
+
+	// Serve popular searches from the search cache, if enabled
+	searchCache := r.searchCache()
+
+	var fill *searchCacheFill
+
+	if cacheKey, cacheable := searchCacheKey(req, deduplicatedQueries, minMatchScore); searchCache != nil && cacheable {
+		if cached, ok := searchCache.get(cacheKey, time.Now()); ok {
+			span.SetAttributes(attribute.Bool("cached", true))
+			span.End()
+
+			if r.prefetch != nil {
+				return r.prefetchResults(ctx, cachedResults(ctx, cached)), nil
+			}
+
+			return cachedResults(ctx, cached), nil
+		}
+
+		// Collect the results before searching, so that no concurrent label change is missed
+		fill = searchCache.fill(cacheKey, deduplicatedQueries)
+	}
+
 	outCh := make(chan *routingv1.SearchResponse)
 
 	go func() {
+		defer span.End()
 		defer close(outCh)
 
-		r.searchRemoteRecords(ctx, deduplicatedQueries, req.GetLimit(), minMatchScore, outCh)
+		// Fast searches resolve record labels from an in-memory index instead of the datastore
+		resolveLabels := labelResolver(r.getRemoteRecordLabels)
+
+		if fast {
+			index, err := buildLabelIndex(ctx, r.dstore)
+			if err != nil {
+				remoteLogger.Error("Failed to build label index for fast search", "error", err)
+
+				return
+			}
+
+			resolveLabels = index.labels
+		}
+
+		// Skip providers that cannot serve the retrieval method required by the caller
+		retrieval := r.newRetrievalFilter(req.GetRequiredRetrievalMethod())
+
+		processedCIDs := make(map[string]bool)
+
+		for i, pass := range passes {
+			if i > 0 {
+				cursor = nil // Later passes start from the beginning
+			}
+
+			r.searchRemoteRecords(ctx, deduplicatedQueries, req.GetLimit(), minMatchScore, cursor, queryHash, resolveLabels, retrieval, zones, pass, scope, req.GetLatestOnly(), req.GetPublisher(), scorer, processedCIDs, outCh)
+		}
+
+		// Live results cannot be resumed from a cursor, so peers are only queried for the first page.
+		// Peers only serve untenanted records, which private tenants do not see, and live results
+		// carry no publisher signatures, so searches of a publisher do not query peers.
+		if searchQueriesPeers(req) && r.tenants.isShared(req.GetTenantId()) && req.GetPublisher() == "" {
+			r.searchLivePeers(ctx, deduplicatedQueries, req.GetLimit(), minMatchScore, processedCIDs, retrieval, zones, scorer, outCh)
+		}
 	}()
 
-	return outCh, nil
+	var results <-chan *routingv1.SearchResponse = outCh
+
+	// Cache the results once the search completed
+	if fill != nil {
+		results = searchCache.cacheResults(ctx, fill, results)
+	}
+
+	// Pull the records of good results in the background, as callers are likely to pull them next
+	if r.prefetch != nil {
+		return r.prefetchResults(ctx, results), nil
+	}
+
+	return results, nil
 }
 
 // searchRemoteRecords searches for remote records using cached labels with OR logic.
 // Records are returned if they match at least minMatchScore queries.
+// Entries are iterated in a deterministic order so that the search can be resumed from a cursor.
+// Only records of the pass's tenant and of peers allowed by the zone filter in the pass's phase
+// are returned, and only those signed by the publisher if set. Records are matched against
+// their labels visible to the tenants in scope.
+// The CIDs of the returned records are added to processedCIDs, which are not returned again
+// and count towards the limit.
 //
-//nolint:gocognit // Core search algorithm requires complex logic for namespace iteration, filtering, and scoring
-func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routingv1.RecordQuery, limit uint32, minMatchScore uint32, outCh chan<- *routingv1.SearchResponse) {
+//nolint:gocognit,cyclop // Core search algorithm requires complex logic for namespace iteration, filtering, and scoring
+func (r *routeRemote) searchRemoteRecords(
+	ctx context.Context,
+	queries []*routingv1.RecordQuery,
+	limit uint32,
+	minMatchScore uint32,
+	cursor *searchCursor,
+	queryHash string,
+	resolveLabels labelResolver,
+	retrieval *retrievalFilter,
+	zones *zoneFilter,
+	pass searchPass,
+	scope []string,
+	latestOnly bool,
+	publisher string,
+	scorer scoringStrategy,
+	processedCIDs map[string]bool, // Avoid duplicates
+	outCh chan<- *routingv1.SearchResponse,
+) {
 	localPeerID := r.server.Host().ID().String()
-	processedCIDs := make(map[string]bool) // Avoid duplicates
-	processedCount := 0
+	now := time.Now()
+	evaluatedRecords := make(map[string]bool) // CID/PeerID pairs already scored
+	processedCount := len(processedCIDs)
 	limitInt := int(limit)
 
-	remoteLogger.Debug("Starting remote search with OR logic and minimum threshold", "queries", len(queries), "minMatchScore", minMatchScore, "localPeerID", localPeerID)
+	if limitInt > 0 && processedCount >= limitInt {
+		return
+	}
 
-	// Query all namespaces to find remote records
-	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	remoteLogger.Debug("Starting remote search with OR logic and minimum threshold", "queries", len(queries), "minMatchScore", minMatchScore, "localPeerID", localPeerID, "resumed", cursor != nil)
+
+	// Query namespaces to find remote records, starting after the cursor if resuming
+	entries, err := queryNamespacesFrom(ctx, r.dstore, pass.tenant, cursor)
 	if err != nil {
 		remoteLogger.Error("Failed to get namespace entries for search", "error", err)
 
 		return
 	}
 
+	// Only evaluate the records the label index selects as candidates, if enabled
+	candidates := r.labelIndexCandidates(ctx, queries, minMatchScore, scope)
+
 	for _, entry := range entries {
 		if limitInt > 0 && processedCount >= limitInt {
 			break
@@ -366,13 +1010,68 @@ func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routin
 			continue // Skip local records
 		}
 
+		// Exclude records of peers with a low reputation
+		if r.reputation.IsExcluded(keyPeerID) {
+			continue
+		}
+
+		// Exclude records of peers that cannot serve the required retrieval method
+		if !retrieval.allows(ctx, keyPeerID) {
+			continue
+		}
+
+		// Exclude records of peers outside the caller's zones, or only return them in the last phase
+		if !zones.allows(ctx, keyPeerID, pass.phase) {
+			continue
+		}
+
+		// Exclude records whose TTL has elapsed, unless pinned
+		if labelExpired(entry.Value, now) && !r.pins.has(keyCID) {
+			continue
+		}
+
+		// Exclude records of peers that have been unreachable for a while, unless pinned
+		if r.liveness.isStale(keyPeerID, now) && !r.pins.has(keyCID) {
+			continue
+		}
+
+		// Exclude records the peer announced a newer version of, if only latest versions are requested
+		if latestOnly && r.lineage.isSuperseded(keyCID, keyPeerID) {
+			continue
+		}
+
+		// Exclude records not signed by the requested publisher
+		if publisher != "" && labelPublisher(entry.Value) != publisher {
+			continue
+		}
+
 		// Avoid duplicate CIDs (same record might have multiple matching labels)
 		if processedCIDs[keyCID] {
 			continue
 		}
 
-		// Calculate match score using OR logic (how many queries match this record)
-		matchQueries, score := r.calculateMatchScore(ctx, keyCID, queries, keyPeerID)
+		// Skip records whose labels cannot match enough queries
+		if candidates != nil && !candidates[keyCID] {
+			continue
+		}
+
+		recordKey := keyCID + "/" + keyPeerID
+		if evaluatedRecords[recordKey] {
+			continue
+		}
+
+		// Only evaluate a record at its first label key, so that a record
+		// is never returned twice across resumed searches
+		labels := resolveLabels(ctx, keyCID, keyPeerID)
+		if tenantKey(pass.tenant, firstLabelKey(labelsOfTenants(labels, pass.tenant), keyCID, keyPeerID)) != entry.Key {
+			continue
+		}
+
+		evaluatedRecords[recordKey] = true
+
+		// Calculate match score using OR logic (how many queries match this record),
+		// over the labels announced by any provider of the record that are visible to the caller
+		matchQueries, score := matchScoreForLabels(queries, labelsOfTenants(r.withProviderSetLabels(keyCID, labels), scope...))
 
 		remoteLogger.Debug("Calculated match score for remote record", "cid", keyCID, "score", score, "minMatchScore", minMatchScore, "matchingQueries", len(matchQueries))
 
@@ -380,16 +1079,31 @@ func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routin
 		if score >= minMatchScore {
 			peer := r.createPeerInfo(ctx, keyPeerID)
 
-			outCh <- &routingv1.SearchResponse{
-				RecordRef:    &corev1.RecordRef{Cid: keyCID},
-				Peer:         peer,
-				MatchQueries: matchQueries,
-				MatchScore:   score,
-			}
+			nextPageToken := encodeSearchCursor(&searchCursor{
+				Namespace: namespaceIndexOf(entry.Namespace),
+				Key:       entry.Key,
+				QueryHash: queryHash,
+				Phase:     pass.phase,
+				Tenant:    pass.tenant,
+			})
+
+			result := r.remoteSearchResponse(peer, keyCID, entry.Value, matchQueries, score)
+			result.NextPageToken = nextPageToken
+			result.Relevance = scorer.relevance(scoredResult{
+				matchQueries: matchQueries,
+				queries:      len(queries),
+				peerID:       keyPeerID,
+				lastSeen:     labelLastSeen(entry.Value),
+				providers:    r.providerCount(keyCID),
+			})
+
+			outCh <- result
 
 			processedCIDs[keyCID] = true
 			processedCount++
 
+			r.cacheUsage.recordHit(recordKey)
+
 			remoteLogger.Debug("Record meets minimum threshold, including in results", "cid", keyCID, "score", score)
 
 			if limitInt > 0 && processedCount >= limitInt {
@@ -400,10 +1114,31 @@ func (r *routeRemote) searchRemoteRecords(ctx context.Context, queries []*routin
 		}
 	}
 
-	remoteLogger.Debug("Completed Search operation", "processed", processedCount, "queries", len(queries))
+	remoteLogger.Debug("Completed Search operation", "processed", processedCount, "queries", len(queries), "zonePhase", pass.phase, "tenant", pass.tenant)
 }
 
-// calculateMatchScore calculates how many queries match a remote record (OR logic).
+// remoteSearchResponse returns the search result of a remote record provided by the peer,
+// described by the metadata of one of its labels.
+func (r *routeRemote) remoteSearchResponse(peer *routingv1.Peer, cid string, metadata []byte, matchQueries []*routingv1.RecordQuery, score uint32) *routingv1.SearchResponse {
+	summary := labelRecordSummary(metadata)
+
+	return &routingv1.SearchResponse{
+		RecordRef:     &corev1.RecordRef{Cid: cid},
+		Peer:          peer,
+		MatchQueries:  matchQueries,
+		MatchScore:    score,
+		ProviderSet:   r.providerSetOf(cid),
+		AccessGated:   labelAccessGated(metadata),
+		ContentDigest: contentDigest(cid),
+		ContentSize:   labelContentSize(metadata),
+		Name:          summary.Name,
+		Version:       summary.Version,
+		Description:   summary.Description,
+		Publisher:     labelPublisher(metadata),
+	}
+}
+
+// calculateMatchScore calculates how well the queries match a remote record (OR logic).
 // Returns the matching queries and the match score for minimum threshold filtering.
 func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queries []*routingv1.RecordQuery, peerID string) ([]*routingv1.RecordQuery, uint32) {
 	if len(queries) == 0 {
@@ -411,23 +1146,33 @@ func (r *routeRemote) calculateMatchScore(ctx context.Context, cid string, queri
 	}
 
 	labels := r.getRemoteRecordLabels(ctx, cid, peerID)
-	if len(labels) == 0 {
+
+	matchingQueries, score := matchScoreForLabels(queries, labels)
+
+	remoteLogger.Debug("OR logic match score calculated", "cid", cid, "total_queries", len(queries), "matching_queries", len(matchingQueries), "score", score)
+
+	return matchingQueries, score
+}
+
+// matchScoreForLabels returns the queries matching any of the labels and the resulting score,
+// the sum of the scores of the matching queries (see QueryMatchScore).
+func matchScoreForLabels(queries []*routingv1.RecordQuery, labels []types.Label) ([]*routingv1.RecordQuery, uint32) {
+	if len(queries) == 0 || len(labels) == 0 {
 		return nil, 0
 	}
 
 	var matchingQueries []*routingv1.RecordQuery
 
+	var score uint32
+
 	// Check each query against all labels - any match counts toward the score (OR logic)
 	for _, query := range queries {
-		if QueryMatchesLabels(query, labels) {
+		if queryScore := QueryMatchScore(query, labels); queryScore > 0 {
 			matchingQueries = append(matchingQueries, query)
+			score += queryScore
 		}
 	}
 
-	score := safeIntToUint32(len(matchingQueries))
-
-	remoteLogger.Debug("OR logic match score calculated", "cid", cid, "total_queries", len(queries), "matching_queries", len(matchingQueries), "score", score)
-
 	return matchingQueries, score
 }
 
@@ -456,161 +1201,34 @@ func (r *routeRemote) getRemoteRecordLabels(ctx context.Context, cid, peerID str
 	return labelList
 }
 
-// createPeerInfo creates a Peer message from a PeerID string.
-func (r *routeRemote) createPeerInfo(ctx context.Context, peerID string) *routingv1.Peer {
-	dirAPIAddr := r.getDirectoryAPIAddress(ctx, peerID)
-
-	return &routingv1.Peer{
-		Id:    peerID,
-		Addrs: []string{dirAPIAddr},
-	}
-}
-
-func (r *routeRemote) getDirectoryAPIAddress(ctx context.Context, peerID string) string {
-	// Try datastore cache first (fast path)
-	if dirAddr := r.getDirectoryAPIAddressFromDatastore(ctx, peerID); dirAddr != "" {
-		return dirAddr
-	}
-
-	// Fallback: Try live peerstore (handles mDNS and DHT without addresses)
-	pid, err := peer.Decode(peerID)
-	if err != nil {
-		remoteLogger.Error("Failed to decode peer ID", "peerID", peerID, "error", err)
-
-		return ""
-	}
-
-	peerstoreAddrs := r.server.Host().Peerstore().Addrs(pid)
-	if len(peerstoreAddrs) == 0 {
-		remoteLogger.Warn("No Directory API address found for peer",
-			"peerID", peerID,
-			"note", "Peer might be discovered via mDNS or DHT without /dir/ configuration")
-
-		return ""
-	}
-
-	remoteLogger.Debug("Trying peerstore addresses for /dir/ protocol",
-		"peerID", peerID,
-		"addrs", len(peerstoreAddrs))
-
-	if dirAddr := extractDirProtocol(peerstoreAddrs, peerID); dirAddr != "" {
-		return dirAddr
-	}
-
-	remoteLogger.Warn("No /dir/ protocol found in peerstore addresses",
-		"peerID", peerID)
-
-	return ""
-}
-
-// getDirectoryAPIAddressFromDatastore checks datastore cache for peer addresses.
-func (r *routeRemote) getDirectoryAPIAddressFromDatastore(ctx context.Context, peerID string) string {
-	key := datastore.NewKey("peer_addrs/" + peerID)
-
-	addresses, err := r.dstore.Get(ctx, key)
-	if err != nil {
-		remoteLogger.Debug("No cached peer addresses in datastore", "peerID", peerID)
-
-		return ""
-	}
-
-	var multiaddrs []ma.Multiaddr
-	if err := json.Unmarshal(addresses, &multiaddrs); err != nil {
-		remoteLogger.Error("Failed to unmarshal peer addresses", "error", err)
-
-		return ""
-	}
-
-	return extractDirProtocol(multiaddrs, peerID)
-}
-
-// storePeerAddresses stores peer addresses in datastore for later retrieval.
-// Tries DHT notification addresses first, falls back to peerstore if empty.
-func (r *routeRemote) storePeerAddresses(ctx context.Context, peerIDStr string, peerID peer.ID, notifAddrs []ma.Multiaddr, cid string) {
-	// Try DHT notification addresses first
-	peerAddrs := notifAddrs
-	if len(peerAddrs) == 0 {
-		// Fallback: get addresses from libp2p peerstore
-		peerAddrs = r.server.Host().Peerstore().Addrs(peerID)
-		remoteLogger.Debug("DHT notification had no addresses, using peerstore",
-			"peerID", peerIDStr,
-			"peerstoreAddrs", len(peerAddrs))
-	}
-
-	if len(peerAddrs) == 0 {
-		remoteLogger.Warn("No peer addresses available from DHT or peerstore",
-			"peerID", peerIDStr,
-			"cid", cid)
-
-		return
-	}
-
-	// Check if already stored
-	key := datastore.NewKey("peer_addrs/" + peerIDStr)
-	if _, err := r.dstore.Get(ctx, key); err == nil {
-		return // Already have addresses
-	}
-
-	// Marshal and store
-	addresses, err := json.Marshal(peerAddrs)
-	if err != nil {
-		remoteLogger.Error("Failed to marshal peer addresses", "error", err)
-
-		return
-	}
-
-	if err := r.dstore.Put(ctx, key, addresses); err != nil {
-		remoteLogger.Error("Failed to store peer addresses", "error", err)
-
-		return
-	}
-
-	remoteLogger.Debug("Stored peer addresses", "peerID", peerIDStr, "count", len(peerAddrs))
-}
-
-// extractDirProtocol extracts the /dir/ protocol value from a list of multiaddrs.
-// Returns empty string if no /dir/ protocol is found.
-func extractDirProtocol(multiaddrs []ma.Multiaddr, peerID string) string {
-	for _, addr := range multiaddrs {
-		protocols := addr.Protocols()
-		for _, protocol := range protocols {
-			if protocol.Code == p2p.DirProtocolCode {
-				value, err := addr.ValueForProtocol(p2p.DirProtocolCode)
-				if err != nil {
-					remoteLogger.Debug("Failed to extract /dir/ protocol value",
-						"peerID", peerID,
-						"addr", addr.String(),
-						"error", err)
-				} else {
-					remoteLogger.Debug("Found Directory API address",
-						"peerID", peerID,
-						"dirAddress", value)
-
-					return value
-				}
-			}
-		}
-	}
-
-	return ""
-}
-
 func (r *routeRemote) handleNotify() {
 	defer r.wg.Done()
 
 	cleanupLogger.Debug("Started DHT provider notification handler")
 
+	ticker := time.NewTicker(NotifyQueueInterval)
+	defer ticker.Stop()
+
 	// Process DHT provider notifications and handle pull-based label discovery
 	for {
+		// All announcements are now CID provider announcements
+		// Labels are discovered via pull-based mechanism
+		r.processNotifications(r.ctx)
+
 		select {
 		case <-r.ctx.Done():
 			cleanupLogger.Debug("DHT provider notification handler stopped")
 
+			r.pulls.wait()
+
 			return
-		case notif := <-r.notifyCh:
-			// All announcements are now CID provider announcements
-			// Labels are discovered via pull-based mechanism
-			r.handleCIDProviderNotification(r.ctx, notif)
+		case <-r.notifyQueue.wake:
+		case <-ticker.C:
+			if pruned, err := r.notifyQueue.pruneDeadLetters(r.ctx, time.Now()); err != nil {
+				remoteLogger.Warn("Failed to prune dead-lettered notifications", "error", err)
+			} else if pruned > 0 {
+				remoteLogger.Info("Pruned dead-lettered notifications", "count", pruned)
+			}
 		}
 	}
 }
@@ -674,16 +1292,27 @@ func (r *routeRemote) startMeshPeerTagging() {
 //   - 10% case: DHT arrives first (~80ms) → This function pulls (fallback)
 //
 // This ensures labels are always cached regardless of network race conditions.
-func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *handlerSync) {
+// An error is returned if the record could not be pulled or its labels cached,
+// so that the notification is retried.
+func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *handlerSync) error {
 	peerIDStr := notif.Peer.ID.String()
 
 	if peerIDStr == r.server.Host().ID().String() {
 		remoteLogger.Debug("Ignoring self announcement", "cid", notif.Ref.GetCid())
 
-		return
+		return nil
 	}
 
-	// Store peer addresses for later use
+	ctx, span := tracer.Start(ctx, "routing.HandleProviderNotification", trace.WithAttributes(
+		attribute.String("cid", notif.Ref.GetCid()),
+		attribute.String("peer", peerIDStr)))
+	defer span.End()
+
+	r.peerStats.RecordAnnouncement(peerIDStr)
+	metrics.AnnouncementsReceived.WithLabelValues(metrics.TransportDHT).Inc()
+	r.state.announcementReceived()
+
+	// Refresh the announcing peer's addresses in the address book
 	r.storePeerAddresses(ctx, peerIDStr, notif.Peer.ID, notif.Peer.Addrs, notif.Ref.GetCid())
 
 	// Check if we already have labels cached (from GossipSub announcement)
@@ -695,9 +1324,18 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 			"peer", peerIDStr,
 			"source", "gossipsub_or_previous_pull")
 
-		r.updateRemoteRecordLastSeen(ctx, notif.Ref.GetCid(), peerIDStr)
+		r.updateRemoteRecordLastSeen(notif.Ref.GetCid(), peerIDStr)
+		r.logReannouncement(ctx, notif.Ref.GetCid(), peerIDStr)
 
-		return
+		return nil
+	}
+
+	// Do not mirror records revoked by their publisher
+	if r.isRevoked(ctx, notif.Ref.GetCid(), peerIDStr) || r.fetchRevocation(ctx, notif.Ref.GetCid(), peerIDStr) {
+		remoteLogger.Debug("Skipping pull of revoked record", "cid", notif.Ref.GetCid(), "peer", peerIDStr)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportDHT, metrics.RejectRevoked).Inc()
+
+		return nil
 	}
 
 	// FALLBACK: Labels not cached yet, need to pull record
@@ -711,36 +1349,75 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 		"peer", peerIDStr,
 		"reason", "gossipsub_not_received")
 
-	record, err := r.service.Pull(ctx, notif.Peer.ID, notif.Ref)
+	pullStart := time.Now()
+
+	record, recordMetadata, err := r.pull(ctx, notif.Peer.ID, notif.Ref)
+	pullDuration := time.Since(pullStart)
+
+	metrics.PullDuration.Observe(pullDuration.Seconds())
+
+	if errors.Is(err, rpc.ErrContentMismatch) {
+		r.reputation.RecordContentMismatch(peerIDStr)
+		metrics.PullFallbacks.WithLabelValues(metrics.ResultMismatch).Inc()
+
+		remoteLogger.Warn("Rejected remote record not matching the announced CID",
+			"cid", notif.Ref.GetCid(),
+			"peer", peerIDStr,
+			"error", err)
+
+		return nil
+	}
+
+	// Access-gated records are only described by their labels, which is all that is cached
+	if errors.Is(err, rpc.ErrAccessGated) {
+		err = nil
+	}
+
+	r.reputation.RecordPull(peerIDStr, pullDuration, err)
+	metrics.PullFallbacks.WithLabelValues(metrics.Result(err)).Inc()
+
 	if err != nil {
 		remoteLogger.Error("Failed to pull remote content for label caching",
 			"cid", notif.Ref.GetCid(),
 			"peer", peerIDStr,
 			"error", err)
 
-		return
+		return fmt.Errorf("failed to pull remote content: %w", err)
 	}
 
-	adapter := adapters.NewRecordAdapter(record)
+	now := time.Now()
+
+	// Do not cache records whose TTL elapsed while they were announced
+	expiresAt := recordMetadata.ExpiresAt
+	if !expiresAt.IsZero() && !now.Before(expiresAt) {
+		remoteLogger.Debug("Skipping expired remote record", "cid", notif.Ref.GetCid(), "peer", peerIDStr, "expiresAt", expiresAt)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportDHT, metrics.RejectExpired).Inc()
 
-	labelList := types.GetLabelsFromRecord(adapter)
+		return nil
+	}
+
+	labelList := pulledRecordLabels(record, recordMetadata)
 	if len(labelList) == 0 {
 		remoteLogger.Warn("No labels found in remote record",
 			"cid", notif.Ref.GetCid(),
 			"peer", peerIDStr)
 
-		return
+		return nil
 	}
 
-	now := time.Now()
-	cachedCount := 0
+	labels := &cacheMutation{}
 
 	for _, label := range labelList {
 		enhancedKey := BuildEnhancedLabelKey(label, notif.Ref.GetCid(), peerIDStr)
 
 		metadata := &types.LabelMetadata{
-			Timestamp: now,
-			LastSeen:  now,
+			Timestamp:     now,
+			LastSeen:      now,
+			ExpiresAt:     expiresAt,
+			Supersedes:    recordMetadata.Supersedes,
+			AccessGated:   recordMetadata.AccessGated,
+			Size:          recordMetadata.Size,
+			RecordSummary: recordMetadata.Summary,
 		}
 
 		metadataBytes, err := json.Marshal(metadata)
@@ -752,22 +1429,34 @@ func (r *routeRemote) handleCIDProviderNotification(ctx context.Context, notif *
 			continue
 		}
 
-		err = r.dstore.Put(ctx, datastore.NewKey(enhancedKey), metadataBytes)
-		if err != nil {
-			remoteLogger.Warn("Failed to cache remote label",
-				"enhanced_key", enhancedKey,
-				"error", err)
-		} else {
-			cachedCount++
-		}
+		labels.put(enhancedKey, metadataBytes)
+	}
+
+	announcement := newProvenanceEntry(notif.Ref.GetCid(), peerIDStr, metrics.TransportDHT, &types.LabelMetadata{
+		Timestamp:  now,
+		Supersedes: recordMetadata.Supersedes,
+	}, false, now)
+
+	if err := r.cacheRemoteLabels(ctx, peerIDStr, labels, announcement); err != nil {
+		remoteLogger.Warn("Failed to cache remote labels",
+			"cid", notif.Ref.GetCid(),
+			"peer", peerIDStr,
+			"error", err)
+
+		return fmt.Errorf("failed to cache remote labels: %w", err)
 	}
 
 	remoteLogger.Info("Successfully cached labels via DHT+Pull fallback",
 		"cid", notif.Ref.GetCid(),
 		"peer", peerIDStr,
 		"totalLabels", len(labelList),
-		"cached", cachedCount,
+		"cached", len(labels.Puts),
 		"source", "pull_fallback")
+
+	// Keep the content as a secondary provider instead of discarding it
+	r.retainPulledRecord(ctx, record, recordMetadata)
+
+	return nil
 }
 
 // hasRemoteRecordCached checks if we already have cached labels for this remote record.
@@ -801,7 +1490,7 @@ func (r *routeRemote) hasRemoteRecordCached(ctx context.Context, cid, peerID str
 //
 // Parameters:
 //   - ctx: Operation context
-//   - authenticatedPeerID: Cryptographically verified peer ID from msg.ReceivedFrom
+//   - authenticatedPeerID: Peer ID of the originator of the announcement, verified by its message signature
 //   - event: The announcement payload (CID, labels, timestamp)
 //
 // Flow:
@@ -822,112 +1511,144 @@ func (r *routeRemote) handleRecordPublishEvent(ctx context.Context, authenticate
 		return
 	}
 
-	remoteLogger.Info("Caching labels from GossipSub announcement",
-		"cid", event.CID,
-		"peer", authenticatedPeerID,
-		"labels", len(event.Labels))
+	// Nodes running the client profile discover records via the DHT only
+	if !r.currentProfile().gossipSub {
+		return
+	}
 
-	now := time.Now()
-	cachedCount := 0
+	// Tenants only announce to the peers they are configured on
+	if !r.tenants.known(event.Tenant) {
+		remoteLogger.Debug("Dropped announcement of unknown tenant",
+			"cid", event.CID,
+			"peer", authenticatedPeerID,
+			"tenant", event.Tenant)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectTenant).Inc()
 
-	// Convert wire format ([]string) to storage format using existing infrastructure
-	for _, labelStr := range event.Labels {
-		label := types.Label(labelStr)
+		return
+	}
 
-		// Use authenticated peer ID (cryptographically verified by libp2p)
-		enhancedKey := BuildEnhancedLabelKey(label, event.CID, authenticatedPeerID)
+	// Retired identities were rotated, possibly because their key was compromised
+	if r.isRetiredIdentity(ctx, authenticatedPeerID) {
+		remoteLogger.Info("Rejected announcement of retired peer identity",
+			"cid", event.CID,
+			"peer", authenticatedPeerID)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectRetired).Inc()
 
-		// Use existing types.LabelMetadata structure
-		metadata := &types.LabelMetadata{
-			Timestamp: event.Timestamp, // When label was announced
-			LastSeen:  now,             // When we received it
-		}
+		return
+	}
 
-		metadataBytes, err := json.Marshal(metadata)
-		if err != nil {
-			remoteLogger.Warn("Failed to marshal label metadata",
-				"key", enhancedKey,
-				"error", err)
+	// Reject announcements of revoked records unless re-signed by the publisher
+	if !r.admitAnnouncement(ctx, authenticatedPeerID, event) {
+		remoteLogger.Info("Rejected announcement of revoked record",
+			"cid", event.CID,
+			"peer", authenticatedPeerID)
+		metrics.AnnouncementsRejected.WithLabelValues(metrics.TransportGossipSub, metrics.RejectRevoked).Inc()
 
-			continue
-		}
-
-		err = r.dstore.Put(ctx, datastore.NewKey(enhancedKey), metadataBytes)
-		if err != nil {
-			remoteLogger.Warn("Failed to cache label from GossipSub",
-				"key", enhancedKey,
-				"error", err)
-		} else {
-			cachedCount++
-		}
+		return
 	}
 
-	remoteLogger.Info("Successfully cached labels from GossipSub",
+	ctx, span := tracer.Start(ctx, "routing.HandleAnnouncement", trace.WithAttributes(
+		attribute.String("cid", event.CID),
+		attribute.String("peer", authenticatedPeerID),
+		attribute.Int("labels", len(event.Labels))))
+	defer span.End()
+
+	r.peerStats.RecordAnnouncement(authenticatedPeerID)
+	r.state.announcementReceived()
+
+	// A fresh announcement proves that the peer is alive, even if it cannot be dialed
+	r.liveness.markReachable(authenticatedPeerID)
+
+	remoteLogger.Info("Caching labels from GossipSub announcement",
 		"cid", event.CID,
 		"peer", authenticatedPeerID,
-		"total", len(event.Labels),
-		"cached", cachedCount)
-}
-
-// updateLabelMetadataTimestamp updates the lastSeen timestamp for a single cached label entry.
-func (r *routeRemote) updateLabelMetadataTimestamp(ctx context.Context, key string, value []byte, timestamp time.Time) error {
-	var metadata types.LabelMetadata
-	if err := json.Unmarshal(value, &metadata); err != nil {
-		return fmt.Errorf("failed to unmarshal label metadata: %w", err)
-	}
+		"labels", len(event.Labels))
 
-	metadata.LastSeen = timestamp
+	// Store the announcement and expiry times in local clock time
+	metadata, skew := announcementMetadata(event, time.Now())
+	r.peerStats.RecordClockSkew(authenticatedPeerID, skew)
+	metrics.AnnouncementClockSkew.Observe(skew.Abs().Seconds())
 
 	metadataBytes, err := json.Marshal(metadata)
 	if err != nil {
-		return fmt.Errorf("failed to marshal label metadata: %w", err)
+		remoteLogger.Warn("Failed to marshal label metadata",
+			"cid", event.CID,
+			"error", err)
+
+		return
 	}
 
-	err = r.dstore.Put(ctx, datastore.NewKey(key), metadataBytes)
-	if err != nil {
-		return fmt.Errorf("failed to save label metadata: %w", err)
+	// Use authenticated peer ID (cryptographically verified by libp2p)
+	labels := announcementMutation(event, authenticatedPeerID, metadataBytes)
+
+	announcement := newProvenanceEntry(event.CID, authenticatedPeerID, metrics.TransportGossipSub, metadata, event.IsSigned(), metadata.LastSeen)
+
+	if err := r.cacheRemoteLabels(ctx, authenticatedPeerID, labels, announcement); err != nil {
+		remoteLogger.Warn("Failed to cache labels from GossipSub",
+			"cid", event.CID,
+			"peer", authenticatedPeerID,
+			"error", err)
+
+		return
 	}
 
-	return nil
+	r.mirrorReceivedAnnouncement(authenticatedPeerID, event)
+
+	remoteLogger.Info("Successfully cached labels from GossipSub",
+		"cid", event.CID,
+		"peer", authenticatedPeerID,
+		"total", len(event.Labels),
+		"cached", len(labels.Puts),
+		"removed", len(labels.Deletes))
 }
 
-// updateRemoteRecordLastSeen updates the lastSeen timestamp for all cached labels
+// updateRemoteRecordLastSeen refreshes the lastSeen timestamp of all cached labels
 // from a specific remote peer/CID combination (for reannouncement handling).
-func (r *routeRemote) updateRemoteRecordLastSeen(ctx context.Context, cid, peerID string) {
+// The refresh is buffered and written by the next label cache compaction.
+func (r *routeRemote) updateRemoteRecordLastSeen(cid, peerID string) {
 	now := time.Now()
-	updatedCount := 0
 
-	entries, err := QueryAllNamespaces(ctx, r.dstore)
-	if err != nil {
-		remoteLogger.Error("Failed to get namespace entries for lastSeen update", "error", err)
+	r.cacheUsage.touch(cid+"/"+peerID, now)
+	r.providerSets.Touch(cid, peerID, now)
 
-		return
+	remoteLogger.Debug("Buffered lastSeen refresh for reannounced record", "cid", cid, "peer", peerID)
+}
+
+// Stop stops the remote routing services and releases resources.
+// This should be called during server shutdown to clean up gracefully.
+// abort releases the resources of a routing subsystem that failed to start: it stops the
+// background tasks started so far and closes the components created so far, in the order of Stop.
+func (r *routeRemote) abort() {
+	r.cancel()
+	r.wg.Wait()
+
+	if err := r.ipni.stop(context.Background()); err != nil {
+		remoteLogger.Warn("Failed to stop IPNI advertisement server", "error", err)
 	}
 
-	for _, entry := range entries {
-		// Parse enhanced key to check if it matches our CID/PeerID
-		_, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
-		if err != nil {
-			continue
+	if r.pubsubManager != nil {
+		if err := r.pubsubManager.Close(); err != nil {
+			remoteLogger.Warn("Failed to close GossipSub manager", "error", err)
 		}
+	}
 
-		if keyCID == cid && keyPeerID == peerID {
-			if err := r.updateLabelMetadataTimestamp(ctx, entry.Key, entry.Value, now); err != nil {
-				remoteLogger.Warn("Failed to update lastSeen for cached label", "key", entry.Key, "error", err)
-			} else {
-				updatedCount++
+	if err := r.events.Close(); err != nil {
+		remoteLogger.Warn("Failed to close event publishers", "error", err)
+	}
 
-				remoteLogger.Debug("Updated lastSeen for cached label", "key", entry.Key)
-			}
-		}
+	if err := r.announcementSinks.Close(); err != nil {
+		remoteLogger.Warn("Failed to close announcement sinks", "error", err)
 	}
 
-	remoteLogger.Debug("Updated lastSeen timestamps for reannounced record",
-		"cid", cid, "peer", peerID, "updatedLabels", updatedCount)
+	if r.service != nil {
+		r.service.Close()
+	}
+
+	if r.server != nil {
+		r.server.Close()
+	}
 }
 
-// Stop stops the remote routing services and releases resources.
-// This should be called during server shutdown to clean up gracefully.
 func (r *routeRemote) Stop() error {
 	remoteLogger.Info("Stopping routing subsystem")
 
@@ -941,6 +1662,16 @@ func (r *routeRemote) Stop() error {
 	r.wg.Wait()
 	remoteLogger.Debug("All routing background tasks stopped")
 
+	// Record the graceful stop, after the last task runs completed
+	if err := r.state.stopped(context.Background(), time.Now()); err != nil {
+		remoteLogger.Warn("Failed to save runtime state", "error", err)
+	}
+
+	// Stop serving IPNI advertisements if enabled
+	if err := r.ipni.stop(context.Background()); err != nil {
+		remoteLogger.Warn("Failed to stop IPNI advertisement server", "error", err)
+	}
+
 	// Close GossipSub manager if enabled
 	if r.pubsubManager != nil {
 		if err := r.pubsubManager.Close(); err != nil {
@@ -952,10 +1683,32 @@ func (r *routeRemote) Stop() error {
 		remoteLogger.Debug("GossipSub manager closed")
 	}
 
+	// Publish pending routing events
+	if err := r.events.Close(); err != nil {
+		remoteLogger.Warn("Failed to close event publishers", "error", err)
+	}
+
+	// Republish pending announcements
+	if err := r.announcementSinks.Close(); err != nil {
+		remoteLogger.Warn("Failed to close announcement sinks", "error", err)
+	}
+
+	// Release warm RPC streams before closing the host
+	if r.service != nil {
+		r.service.Close()
+	}
+
 	// Close p2p server (host and DHT)
 	r.server.Close()
 	remoteLogger.Debug("P2P server closed")
 
+	// Close the label index database after the last label cache write
+	if labelIndexDstore, ok := unwrapDatastore[*labelIndexDatastore](r.dstore); ok {
+		if err := labelIndexDstore.index.Close(); err != nil {
+			remoteLogger.Warn("Failed to close label index", "error", err)
+		}
+	}
+
 	remoteLogger.Info("Routing subsystem stopped successfully")
 
 	return nil
@@ -0,0 +1,77 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/pubsub"
+	"github.com/agntcy/dir/server/routing/sinks"
+)
+
+// newAnnouncementBridge creates the bridge republishing announcements to the configured sinks.
+// The MQTT client ID defaults to "dir-<peer ID>". Returns nil if no sink is configured.
+func newAnnouncementBridge(cfg routingconfig.AnnouncementSinksConfig, peerID string) (*sinks.Bridge, error) {
+	var configured []sinks.Sink
+
+	if cfg.NATS.URL != "" {
+		sink, err := sinks.NewNATSSink(cfg.NATS.URL, cfg.NATS.SubjectPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create nats announcement sink: %w", err)
+		}
+
+		configured = append(configured, sink)
+	}
+
+	if cfg.MQTT.BrokerURL != "" {
+		clientID := cfg.MQTT.ClientID
+		if clientID == "" {
+			clientID = "dir-" + peerID
+		}
+
+		sink, err := sinks.NewMQTTSink(sinks.MQTTOptions{
+			BrokerURL:   cfg.MQTT.BrokerURL,
+			TopicPrefix: cfg.MQTT.TopicPrefix,
+			ClientID:    clientID,
+			QoS:         byte(cfg.MQTT.QoS), //nolint:gosec // validated to be 0, 1 or 2
+			Username:    cfg.MQTT.Username,
+			Password:    cfg.MQTT.Password,
+		})
+		if err != nil {
+			for _, s := range configured {
+				_ = s.Close()
+			}
+
+			return nil, fmt.Errorf("failed to create mqtt announcement sink: %w", err)
+		}
+
+		configured = append(configured, sink)
+	}
+
+	return sinks.NewBridge(configured...), nil
+}
+
+// mirrorPublishedAnnouncements republishes the announcements of this peer to the announcement sinks.
+// It is registered as the published announcements callback of the GossipSub manager.
+func (r *routeRemote) mirrorPublishedAnnouncements(announcements []*pubsub.RecordPublishEvent) {
+	if r.announcementSinks == nil {
+		return
+	}
+
+	peerID := r.server.Host().ID().String()
+
+	for _, announcement := range announcements {
+		r.announcementSinks.Announce(peerID, announcement.ToProto())
+	}
+}
+
+// mirrorReceivedAnnouncement republishes an announcement of a remote peer to the announcement sinks.
+func (r *routeRemote) mirrorReceivedAnnouncement(peerID string, announcement *pubsub.RecordPublishEvent) {
+	if r.announcementSinks == nil {
+		return
+	}
+
+	r.announcementSinks.Announce(peerID, announcement.ToProto())
+}
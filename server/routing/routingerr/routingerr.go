@@ -0,0 +1,167 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package routingerr defines the typed errors of the routing APIs.
+//
+// Each error kind maps to a gRPC status code, so that clients can tell invalid
+// requests from transient failures without parsing messages. Retryable kinds
+// carry a hint of when to retry, attached to their status as a RetryInfo detail.
+//
+// Errors are derived from the kinds with a message and retry hint of their own,
+// and still match their kind with errors.Is:
+//
+//	err := routingerr.ErrQuotaExceeded.Errorf("pull bandwidth quota exceeded").WithRetryAfter(time.Minute)
+//	errors.Is(err, routingerr.ErrQuotaExceeded) // true
+package routingerr
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// DHTRetryAfter is the default retry hint of ErrDHTUnavailable,
+	// about the time it takes the routing table to refill after bootstrapping.
+	DHTRetryAfter = 30 * time.Second
+
+	// PeerRetryAfter is the default retry hint of ErrPeerUnreachable.
+	PeerRetryAfter = time.Minute
+
+	// QuotaRetryAfter is the default retry hint of ErrQuotaExceeded.
+	QuotaRetryAfter = time.Minute
+)
+
+var (
+	// ErrCIDInvalid is returned for requests with a missing or malformed record CID.
+	ErrCIDInvalid = newKind(codes.InvalidArgument, "invalid CID", 0)
+
+	// ErrDHTUnavailable is returned when the DHT cannot serve a request,
+	// e.g. before the routing table is populated.
+	ErrDHTUnavailable = newKind(codes.Unavailable, "DHT is unavailable", DHTRetryAfter)
+
+	// ErrPeerUnreachable is returned when a remote peer cannot be called.
+	ErrPeerUnreachable = newKind(codes.Unavailable, "peer is unreachable", PeerRetryAfter)
+
+	// ErrDeadlineExceeded is returned when a remote operation exceeds its deadline,
+	// e.g. publishing while the DHT is slow exhausts the publish budget.
+	ErrDeadlineExceeded = newKind(codes.DeadlineExceeded, "deadline exceeded", DHTRetryAfter)
+
+	// ErrQuotaExceeded is returned when a rate limit or quota of the caller is exceeded.
+	ErrQuotaExceeded = newKind(codes.ResourceExhausted, "quota exceeded", QuotaRetryAfter)
+)
+
+// Error is a routing error of a kind. It converts to a gRPC status with the code of
+// its kind, and a RetryInfo detail if it is retryable.
+type Error struct {
+	parent     *Error // The error this one was derived from, nil for kinds
+	code       codes.Code
+	message    string
+	retryAfter time.Duration // Zero if not retryable
+	cause      error
+}
+
+func newKind(code codes.Code, message string, retryAfter time.Duration) *Error {
+	return &Error{code: code, message: message, retryAfter: retryAfter}
+}
+
+// Errorf derives an error with the formatted message. Errors wrapped with %w are unwrapped by it.
+func (e *Error) Errorf(format string, args ...any) *Error {
+	err := fmt.Errorf(format, args...)
+
+	return &Error{
+		parent:     e,
+		code:       e.code,
+		message:    err.Error(),
+		retryAfter: e.retryAfter,
+		cause:      errors.Unwrap(err),
+	}
+}
+
+// WithRetryAfter derives an error hinting to retry after d.
+func (e *Error) WithRetryAfter(d time.Duration) *Error {
+	return &Error{
+		parent:     e,
+		code:       e.code,
+		message:    e.message,
+		retryAfter: d,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Unwrap returns the error this one was derived from and the error it wraps, if any.
+func (e *Error) Unwrap() []error {
+	var errs []error
+
+	if e.parent != nil {
+		errs = append(errs, e.parent)
+	}
+
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+
+	return errs
+}
+
+// Code returns the gRPC status code of the error.
+func (e *Error) Code() codes.Code {
+	return e.code
+}
+
+// RetryAfter returns when the failed request may be retried, zero if it is not retryable.
+func (e *Error) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// GRPCStatus converts the error to a gRPC status. It is used by the status package.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.code, e.message)
+	if e.retryAfter <= 0 {
+		return st
+	}
+
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(e.retryAfter)}); err == nil {
+		return detailed
+	}
+
+	return st
+}
+
+// RetryAfter returns the retry hint of an error, either a routing error or a gRPC status error
+// with a RetryInfo detail, e.g. as received by clients. Reports false if the error is not retryable.
+func RetryAfter(err error) (time.Duration, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.retryAfter, e.retryAfter > 0
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+
+	return 0, false
+}
+
+// Wrap prefixes the message of the gRPC status of err, keeping its code and details.
+func Wrap(err error, prefix string) error {
+	st := status.Convert(err).Proto()
+	st.Message = prefix + ": " + st.GetMessage()
+
+	return status.FromProto(st).Err() //nolint:wrapcheck
+}
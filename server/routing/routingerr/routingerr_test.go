@@ -0,0 +1,69 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routingerr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("selected encoding not supported")
+	err := ErrCIDInvalid.Errorf("invalid CID %q: %w", "bafy", cause)
+
+	assert.Equal(t, `invalid CID "bafy": selected encoding not supported`, err.Error())
+	assert.ErrorIs(t, err, ErrCIDInvalid)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrQuotaExceeded)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, retryable := RetryAfter(err)
+	assert.False(t, retryable)
+
+	// Derived errors match every error they were derived from
+	pullQuota := ErrQuotaExceeded.Errorf("pull bandwidth quota exceeded")
+	refused := pullQuota.WithRetryAfter(10 * time.Minute)
+	assert.ErrorIs(t, refused, pullQuota)
+	assert.ErrorIs(t, refused, ErrQuotaExceeded)
+	assert.Equal(t, "pull bandwidth quota exceeded", refused.Error())
+}
+
+func TestRetryAfter(t *testing.T) {
+	retryAfter, ok := RetryAfter(ErrPeerUnreachable.Errorf("failed to call remote peer"))
+	assert.True(t, ok)
+	assert.Equal(t, PeerRetryAfter, retryAfter)
+
+	// The hint is attached to the gRPC status, also of wrapped errors
+	err := fmt.Errorf("pull failed: %w", ErrQuotaExceeded.WithRetryAfter(5*time.Minute))
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+	received := st.Err()
+	assert.NotErrorIs(t, received, ErrQuotaExceeded)
+
+	retryAfter, ok = RetryAfter(received)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Minute, retryAfter)
+
+	// Wrapping keeps the code and the hint
+	wrapped := Wrap(ErrDHTUnavailable.Errorf("failed to announce CID to DHT"), "failed to publish")
+	assert.Equal(t, codes.Unavailable, status.Code(wrapped))
+	assert.Equal(t, "failed to publish: failed to announce CID to DHT", status.Convert(wrapped).Message())
+
+	retryAfter, ok = RetryAfter(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, DHTRetryAfter, retryAfter)
+
+	_, ok = RetryAfter(errors.New("plain error"))
+	assert.False(t, ok)
+}
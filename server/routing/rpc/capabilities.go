@@ -0,0 +1,116 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DirServiceFuncCapabilities = "Capabilities"
+
+	// Bounds of the advertised capabilities accepted from remote peers.
+	MaxCapabilityFeatures   = 64
+	MaxCapabilityNamespaces = 256
+	MaxCapabilityLength     = 128
+)
+
+type CapabilitiesRequest struct {
+	TraceContext TraceContext
+}
+
+// CapabilitiesResponse describes what the remote peer supports.
+// Peers ignore the features they do not know, so that new ones can be added.
+type CapabilitiesResponse struct {
+	// Version is the directory server version of the peer, empty for development builds.
+	Version string
+	// Features are the API features the peer supports, e.g. "gossipsub".
+	Features []string
+	// Namespaces are the label namespaces the peer indexes, e.g. "skills".
+	Namespaces []string
+}
+
+// CapabilitiesProvider returns the capabilities advertised by this peer.
+type CapabilitiesProvider func() CapabilitiesResponse
+
+func (r *RPCAPI) Capabilities(ctx context.Context, in *CapabilitiesRequest, out *CapabilitiesResponse) error {
+	logger.Debug("P2p RPC: Executing Capabilities request on remote peer", "peer", r.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	ctx, span := startServerSpan(ctx, DirServiceFuncCapabilities, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
+	provider := r.service.getCapabilitiesProvider()
+	if provider == nil {
+		return status.Error(codes.Unimplemented, "capabilities are not served by this peer") //nolint:wrapcheck
+	}
+
+	// set output
+	*out = provider()
+
+	return nil
+}
+
+// SetCapabilitiesProvider sets the function returning the capabilities advertised to remote peers.
+// Until it is set, Capabilities requests are rejected as unimplemented.
+func (s *Service) SetCapabilitiesProvider(fn CapabilitiesProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.capabilitiesProvider = fn
+}
+
+func (s *Service) getCapabilitiesProvider() CapabilitiesProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.capabilitiesProvider
+}
+
+// Capabilities asks the remote peer what it supports.
+// Peers predating the capability handshake return an error.
+func (s *Service) Capabilities(ctx context.Context, peer peer.ID) (*CapabilitiesResponse, error) {
+	logger.Debug("P2p RPC: Executing Capabilities request on remote peer", "peer", peer)
+
+	var resp CapabilitiesResponse
+
+	err := s.call(ctx, peer, DirServiceFuncCapabilities, &CapabilitiesRequest{}, &resp)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	if err := resp.validate(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// validate checks that the capabilities advertised by a remote peer are within bounds.
+func (c *CapabilitiesResponse) validate() error {
+	if len(c.Features) > MaxCapabilityFeatures || len(c.Namespaces) > MaxCapabilityNamespaces {
+		return status.Errorf(codes.InvalidArgument, "too many capabilities: %d features, %d namespaces", len(c.Features), len(c.Namespaces))
+	}
+
+	for _, value := range slices.Concat([]string{c.Version}, c.Features, c.Namespaces) {
+		if len(value) > MaxCapabilityLength {
+			return status.Errorf(codes.InvalidArgument, "capability exceeds %d bytes", MaxCapabilityLength)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/types"
+	rpc "github.com/libp2p/go-libp2p-gorpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Label sync is served on its own protocol, so that peers can tell from the
+// identify protocol whether a peer serves it before syncing from it.
+const (
+	LabelSyncProtocol    = protocol.ID("/dir/labelsync/1.0.0")
+	LabelSyncService     = "LabelSyncAPI"
+	LabelSyncFuncSync    = "Sync"
+	MaxLabelSyncPageSize = 500
+
+	// Pages served to a single peer per window, bounding the cost of syncing peers.
+	LabelSyncRateLimit  = 60
+	LabelSyncRateWindow = time.Minute
+)
+
+// ErrLabelSyncRateLimited is returned when a peer requests label sync pages faster than LabelSyncRateLimit.
+var ErrLabelSyncRateLimited = routingerr.ErrQuotaExceeded.Errorf("label sync rate limit exceeded").WithRetryAfter(LabelSyncRateWindow)
+
+type LabelSyncAPI struct {
+	service *Service
+}
+
+type LabelSyncRequest struct {
+	// Since is the SyncedAt of the previous sync from the peer, 0 for a full sync.
+	// Only records updated since then are returned.
+	Since int64
+	// Cursor is the NextCursor of the previous page, empty for the first page.
+	Cursor       string
+	Limit        int
+	TraceContext TraceContext
+}
+
+// LabelSyncRecord is a record published by the remote peer, with its labels.
+type LabelSyncRecord struct {
+	Cid         string
+	Labels      []string
+	UpdatedAt   int64  // Unix time in nanoseconds when the labels were last published
+	ExpiresAt   int64  // Unix time in seconds when the record's TTL elapses, 0 if it does not expire
+	Supersedes  string // CID of the previous version of the record, empty if none
+	AccessGated bool   // The record is only served to authorized peers
+	Size        uint64 // Size of the record's canonical content, 0 if unknown
+	Summary     types.RecordSummary
+}
+
+type LabelSyncResponse struct {
+	// Records are returned in CID order.
+	Records []LabelSyncRecord
+	// NextCursor is empty when there are no more records.
+	NextCursor string
+	// SyncedAt is the serving peer's Unix time in nanoseconds when the page was served.
+	// The SyncedAt of the first page is passed as Since of the next sync to only receive changes.
+	SyncedAt int64
+}
+
+// LabelSyncProvider serves a page of the records published by this peer that were updated
+// after since, starting after the cursor CID. A zero since serves all records.
+type LabelSyncProvider func(ctx context.Context, since time.Time, cursor string, limit int) (*LabelSyncResponse, error)
+
+func (l *LabelSyncAPI) Sync(ctx context.Context, in *LabelSyncRequest, out *LabelSyncResponse) error {
+	logger.Debug("P2p RPC: Executing label Sync request on remote peer", "peer", l.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	ctx, span := startServerSpan(ctx, LabelSyncFuncSync, in.TraceContext)
+	defer span.End()
+
+	if err := l.service.admit(ctx); err != nil {
+		return err
+	}
+
+	provider := l.service.getLabelSyncProvider()
+	if provider == nil {
+		return status.Error(codes.Unimplemented, "label sync is not served by this peer") //nolint:wrapcheck
+	}
+
+	if sender, err := rpc.GetRequestSender(ctx); err == nil && !l.service.labelSyncLimiter.allow(sender, time.Now()) {
+		return ErrLabelSyncRateLimited
+	}
+
+	limit := in.Limit
+	if limit <= 0 || limit > MaxLabelSyncPageSize {
+		limit = MaxLabelSyncPageSize
+	}
+
+	var since time.Time
+	if in.Since > 0 {
+		since = time.Unix(0, in.Since)
+	}
+
+	resp, err := provider(ctx, since, in.Cursor, limit)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to sync labels")
+	}
+
+	// set output
+	*out = *resp
+
+	return nil
+}
+
+// SetLabelSyncProvider sets the function serving the labels of published records to syncing peers.
+// Until it is set, label sync requests are rejected as unimplemented.
+func (s *Service) SetLabelSyncProvider(fn LabelSyncProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.labelSyncProvider = fn
+}
+
+func (s *Service) getLabelSyncProvider() LabelSyncProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.labelSyncProvider
+}
+
+// SupportsLabelSync reports whether the remote peer advertised the label sync protocol.
+func (s *Service) SupportsLabelSync(peer peer.ID) bool {
+	protocols, err := s.host.Peerstore().SupportsProtocols(peer, LabelSyncProtocol)
+
+	return err == nil && len(protocols) > 0
+}
+
+// SyncLabels fetches a page of the records published by the remote peer, with their labels.
+func (s *Service) SyncLabels(ctx context.Context, peer peer.ID, req *LabelSyncRequest) (*LabelSyncResponse, error) {
+	logger.Debug("P2p RPC: Executing label Sync request on remote peer", "peer", peer, "since", req.Since, "cursor", req.Cursor)
+
+	var resp LabelSyncResponse
+
+	err := callTraced(ctx, s.labelSyncClient, peer, LabelSyncService, LabelSyncFuncSync, req, &resp)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	return &resp, nil
+}
+
+// syncLimiter bounds the label sync pages served to each peer per fixed window.
+type syncLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	windows map[peer.ID]syncWindow
+}
+
+type syncWindow struct {
+	start time.Time
+	count int
+}
+
+func newSyncLimiter(limit int, window time.Duration) *syncLimiter {
+	return &syncLimiter{
+		limit:   limit,
+		window:  window,
+		windows: make(map[peer.ID]syncWindow),
+	}
+}
+
+// allow reports whether the peer may be served another page at now, and counts it if so.
+func (l *syncLimiter) allow(peerID peer.ID, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Forget elapsed windows, only peers syncing within the last window are tracked
+	for id, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, id)
+		}
+	}
+
+	w, ok := l.windows[peerID]
+	if !ok {
+		w = syncWindow{start: now}
+	}
+
+	if w.count >= l.limit {
+		return false
+	}
+
+	w.count++
+	l.windows[peerID] = w
+
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncLabels(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	clientHost, serverHost := mn.Hosts()[0], mn.Hosts()[1]
+
+	server, err := New(serverHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	client, err := New(clientHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	req := &LabelSyncRequest{Since: 42, Cursor: "cid1", Limit: 10000}
+
+	// Peers not serving label sync reject it
+	_, err = client.SyncLabels(t.Context(), serverHost.ID(), req)
+	require.Error(t, err)
+
+	var (
+		gotSince time.Time
+		gotLimit int
+	)
+
+	server.SetLabelSyncProvider(func(_ context.Context, since time.Time, cursor string, limit int) (*LabelSyncResponse, error) {
+		gotSince, gotLimit = since, limit
+
+		return &LabelSyncResponse{
+			Records:    []LabelSyncRecord{{Cid: "cid2", Labels: []string{"/skills/AI"}, UpdatedAt: 7}},
+			NextCursor: "cid2",
+			SyncedAt:   99,
+		}, nil
+	})
+
+	resp, err := client.SyncLabels(t.Context(), serverHost.ID(), req)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Unix(0, 42), gotSince)
+	assert.Equal(t, MaxLabelSyncPageSize, gotLimit)
+	assert.Equal(t, "cid2", resp.NextCursor)
+	assert.Equal(t, int64(99), resp.SyncedAt)
+	require.Len(t, resp.Records, 1)
+	assert.Equal(t, []string{"/skills/AI"}, resp.Records[0].Labels)
+}
+
+func TestSyncLimiter(t *testing.T) {
+	limiter := newSyncLimiter(2, time.Minute)
+	now := time.Now()
+
+	assert.True(t, limiter.allow(peer.ID("peer1"), now))
+	assert.True(t, limiter.allow(peer.ID("peer1"), now.Add(time.Second)))
+	assert.False(t, limiter.allow(peer.ID("peer1"), now.Add(2*time.Second)))
+
+	// Peers are limited separately
+	assert.True(t, limiter.allow(peer.ID("peer2"), now))
+
+	// The limit resets once the window elapsed
+	assert.True(t, limiter.allow(peer.ID("peer1"), now.Add(time.Minute)))
+	assert.Len(t, limiter.windows, 1)
+}
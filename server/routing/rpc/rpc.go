@@ -6,9 +6,19 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/bandwidth"
+	"github.com/agntcy/dir/server/routing/ratelimit"
+	"github.com/agntcy/dir/server/routing/routingerr"
 	"github.com/agntcy/dir/server/types"
+	"github.com/agntcy/dir/server/types/adapters"
 	"github.com/agntcy/dir/utils/logging"
 	rpc "github.com/libp2p/go-libp2p-gorpc"
 	"github.com/libp2p/go-libp2p/core/host"
@@ -16,6 +26,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 var logger = logging.Logger("rpc")
@@ -28,16 +39,92 @@ const (
 	DirServiceFuncLookup = "Lookup"
 	DirServiceFuncPull   = "Pull"
 	MaxPullSize          = 4 * 1024 * 1024 // 4 MB
+
+	DirServiceFuncSnapshot = "Snapshot"
+	MaxSnapshotPageSize    = 1000
+
+	DirServiceFuncSearch = "Search"
+	MaxSearchResults     = 1000
+
+	DirServiceFuncVerify = "Verify"
+	MaxVerifyCids        = 100
+
+	DirServiceFuncHas = "Has"
+	MaxHasCids        = 100
+
+	// Warm stream pool limits for outgoing RPC calls.
+	// Streams are kept for the most recently pulled peers only, once they were
+	// pulled StreamPoolWarmUpPulls times within the idle timeout.
+	StreamPoolMaxPeers       = 32
+	StreamPoolStreamsPerPeer = 2
+	StreamPoolWarmUpPulls    = 2
+	StreamPoolIdleTimeout    = 30 * time.Second
 )
 
+// ErrContentMismatch is returned by Pull when the record served by the remote peer
+// does not hash to the requested CID.
+var ErrContentMismatch = status.Error(codes.DataLoss, "pulled record does not match the requested CID")
+
+// ErrRateLimited is returned when the calling peer exceeds its request rate limit or is temporarily banned.
+var ErrRateLimited = routingerr.ErrQuotaExceeded.Errorf("request rate limit exceeded")
+
+// ErrPullQuotaExceeded is returned by Pull when the calling peer was served its hourly bandwidth quota.
+// Pulls are refused until the next hour, which is the retry hint of the returned errors.
+var ErrPullQuotaExceeded = routingerr.ErrQuotaExceeded.Errorf("pull bandwidth quota exceeded")
+
+// callError converts the error of a call to a remote peer. Only the messages of the errors
+// returned by the peer are sent over the wire, so its refusals due to rate limits and quotas
+// are recognized by their messages.
+func callError(ctx context.Context, err error) error {
+	switch {
+	case ctx.Err() != nil:
+		return status.FromContextError(ctx.Err()).Err() //nolint:wrapcheck
+	case rpc.IsClientError(err):
+		return routingerr.ErrPeerUnreachable.Errorf("failed to call remote peer: %v", err)
+	case rpc.IsAuthorizationError(err):
+		return status.Errorf(codes.PermissionDenied, "failed to call remote peer: %v", err)
+	}
+
+	for _, refusal := range []error{ErrRateLimited, ErrPullQuotaExceeded, ErrLabelSyncRateLimited} {
+		if strings.Contains(err.Error(), refusal.Error()) {
+			return routingerr.ErrQuotaExceeded.Errorf("failed to call remote peer: %v", err)
+		}
+	}
+
+	return status.Errorf(codes.Internal, "failed to call remote peer: %v", err)
+}
+
+// ErrAccessGated is returned by Pull when the remote peer announced the record as
+// access-gated and did not authorize this peer to pull it. Its labels are still returned.
+var ErrAccessGated = status.Error(codes.PermissionDenied, "record is access-gated")
+
 type RPCAPI struct {
 	service *Service
 }
 
+// RecordRequest identifies the record of a Lookup or Pull. It is encoded like
+// corev1.RecordRef, so that peers exchanging either interoperate.
+type RecordRequest struct {
+	Cid          string `codec:"cid,omitempty"`
+	TraceContext TraceContext
+	// AcceptsGated is set by peers handling labels-only responses for access-gated records.
+	// Peers that do not set it are refused access-gated records with an error instead.
+	AcceptsGated bool `codec:"accepts_gated,omitempty"`
+	// AccessToken is an encoded capability token issued by the serving peer,
+	// authorizing the sender to pull its access-gated records.
+	AccessToken string `codec:"access_token,omitempty"`
+}
+
 type PullResponse struct {
 	Cid         string
 	Annotations map[string]string
 	Data        []byte
+	ExpiresAt   int64    // Unix time in seconds when the record's TTL elapses, 0 if it does not expire
+	Supersedes  string   // CID of the previous version of the record, empty if none
+	AccessGated bool     // The record is only served to authorized peers, Data is empty for others
+	Labels      []string // Labels of the record if it is access-gated and Data is empty
+	Size        uint64   // Size of the record's canonical content, also if Data is empty
+	Summary     types.RecordSummary
 }
 
 type LookupResponse struct {
@@ -45,10 +132,126 @@ type LookupResponse struct {
 	Annotations map[string]string
 }
 
+type SnapshotRequest struct {
+	Cursor       string
+	Limit        int
+	TraceContext TraceContext
+}
+
+// SnapshotEntry is a single label cache entry: an enhanced label key and its metadata.
+type SnapshotEntry struct {
+	Key   string
+	Value []byte
+}
+
+type SnapshotResponse struct {
+	Entries []SnapshotEntry
+	// PeerAddrs holds the cached addresses of the peers referenced by Entries.
+	PeerAddrs map[string][]byte
+	// NextCursor is empty when there are no more entries.
+	NextCursor string
+}
+
+type SearchRequest struct {
+	// Queries holds the serialized routingv1.RecordQuery messages.
+	Queries       [][]byte
+	MinMatchScore uint32
+	Limit         int
+	TraceContext  TraceContext
+}
+
+// SearchResult is a local record of the remote peer matching a live search.
+type SearchResult struct {
+	Cid         string
+	Labels      []string
+	AccessGated bool
+	Size        uint64 // Size of the record's canonical content, 0 if unknown
+	Summary     types.RecordSummary
+}
+
+type SearchResponse struct {
+	Results []SearchResult
+}
+
+type VerifyRequest struct {
+	Cids         []string
+	TraceContext TraceContext
+}
+
+// VerifyResult reports how the remote peer sees the announcement of a record by the caller.
+type VerifyResult struct {
+	Cid string
+	// Provider is set if the DHT provider store of the peer holds a provider record of the caller.
+	Provider bool
+	// Labels is the number of labels of the record the peer cached from the caller.
+	Labels int
+}
+
+type VerifyResponse struct {
+	Results []VerifyResult
+}
+
+type HasRequest struct {
+	Cids         []string
+	TraceContext TraceContext
+}
+
+type HasResponse struct {
+	// Has reports for each requested CID, in request order, whether the peer stores the record.
+	Has []bool
+}
+
+// SearchProvider searches the local records of this peer for a remote live search.
+type SearchProvider func(ctx context.Context, queries []*routingv1.RecordQuery, minMatchScore uint32, limit int) ([]SearchResult, error)
+
+// RecordMetadata is the announcement metadata of a record served by Pull.
+type RecordMetadata struct {
+	// ExpiresAt is when the record's TTL elapses, zero if it does not expire.
+	ExpiresAt time.Time
+
+	// Supersedes is the CID of the previous version of the record, empty if none.
+	Supersedes string
+
+	// AccessGated is set if the record's content is only served to authorized peers.
+	AccessGated bool
+
+	// Labels of an access-gated record that was not served.
+	Labels []string
+
+	// Size of the record's canonical content, measured on the pulled content,
+	// or as claimed by the peer for an access-gated record that was not served.
+	Size uint64
+
+	// Summary of the record, read from the pulled content,
+	// or as claimed by the peer for an access-gated record that was not served.
+	Summary types.RecordSummary
+}
+
+// RecordMetadataProvider returns the announcement metadata of a local record.
+type RecordMetadataProvider func(cid string) RecordMetadata
+
+// PublishedLabelsProvider returns the labels of a local record it may be announced with
+// under its publish policy.
+type PublishedLabelsProvider func(ctx context.Context, cid string, labels []types.Label) ([]types.Label, error)
+
+// GatedAccessAuthorizer reports whether a remote peer may pull an access-gated record,
+// given the access token sent with the request, if any.
+type GatedAccessAuthorizer func(reader peer.ID, cid, accessToken string) bool
+
+// AccessTokenProvider returns the access token to send with pulls of a record from a peer,
+// or an empty string if this peer holds no token issued by it.
+type AccessTokenProvider func(issuer peer.ID, cid string) string
+
+// VerifyProvider reports how this peer sees the announcements of records by a remote peer.
+type VerifyProvider func(ctx context.Context, announcer peer.ID, cids []string) ([]VerifyResult, error)
+
+// SnapshotProvider serves a page of the local label cache starting after cursor.
+type SnapshotProvider func(ctx context.Context, cursor string, limit int) (*SnapshotResponse, error)
+
 // NOTE: List-related types removed since List is a local-only operation
 // and should not be part of peer-to-peer RPC communication
 
-func (r *RPCAPI) Lookup(ctx context.Context, in *corev1.RecordRef, out *LookupResponse) error {
+func (r *RPCAPI) Lookup(ctx context.Context, in *RecordRequest, out *LookupResponse) error {
 	logger.Debug("P2p RPC: Executing Lookup request on remote peer", "peer", r.service.host.ID())
 
 	// validate request
@@ -56,12 +259,17 @@ func (r *RPCAPI) Lookup(ctx context.Context, in *corev1.RecordRef, out *LookupRe
 		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
 	}
 
+	ctx, span := startServerSpan(ctx, DirServiceFuncLookup, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
 	// handle lookup
-	meta, err := r.service.store.Lookup(ctx, in)
+	meta, err := r.service.store.Lookup(ctx, &corev1.RecordRef{Cid: in.Cid})
 	if err != nil {
-		st := status.Convert(err)
-
-		return status.Errorf(st.Code(), "failed to lookup: %s", st.Message())
+		return routingerr.Wrap(err, "failed to lookup")
 	}
 
 	// write result
@@ -73,7 +281,7 @@ func (r *RPCAPI) Lookup(ctx context.Context, in *corev1.RecordRef, out *LookupRe
 	return nil
 }
 
-func (r *RPCAPI) Pull(ctx context.Context, in *corev1.RecordRef, out *PullResponse) error {
+func (r *RPCAPI) Pull(ctx context.Context, in *RecordRequest, out *PullResponse) error {
 	logger.Debug("P2p RPC: Executing Pull request on remote peer", "peer", r.service.host.ID())
 
 	// validate request
@@ -81,20 +289,46 @@ func (r *RPCAPI) Pull(ctx context.Context, in *corev1.RecordRef, out *PullRespon
 		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
 	}
 
+	ctx, span := startServerSpan(ctx, DirServiceFuncPull, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
+	if err := r.service.admitPull(ctx); err != nil {
+		return err
+	}
+
+	ref := &corev1.RecordRef{Cid: in.Cid}
+
 	// lookup
-	meta, err := r.service.store.Lookup(ctx, in)
+	meta, err := r.service.store.Lookup(ctx, ref)
 	if err != nil {
-		st := status.Convert(err)
-
-		return status.Errorf(st.Code(), "failed to lookup: %s", st.Message())
+		return routingerr.Wrap(err, "failed to lookup")
 	}
 
 	// pull data
-	record, err := r.service.store.Pull(ctx, in)
+	record, err := r.service.store.Pull(ctx, ref)
 	if err != nil {
-		st := status.Convert(err)
+		return routingerr.Wrap(err, "failed to pull")
+	}
+
+	var metadata RecordMetadata
+	if provider := r.service.getRecordMetadataProvider(); provider != nil {
+		metadata = provider(in.Cid)
+	}
+
+	// set output
+	*out = PullResponse{
+		Cid:         meta.GetCid(),
+		Annotations: meta.GetAnnotations(),
+		Supersedes:  metadata.Supersedes,
+		AccessGated: metadata.AccessGated,
+	}
 
-		return status.Errorf(st.Code(), "failed to pull: %s", st.Message())
+	if !metadata.ExpiresAt.IsZero() {
+		out.ExpiresAt = metadata.ExpiresAt.Unix()
 	}
 
 	canonicalBytes, err := record.Marshal()
@@ -102,23 +336,227 @@ func (r *RPCAPI) Pull(ctx context.Context, in *corev1.RecordRef, out *PullRespon
 		return status.Errorf(codes.Internal, "failed to marshal record: %v", err)
 	}
 
+	out.Size = uint64(len(canonicalBytes))
+	out.Summary = types.NewRecordSummary(adapters.NewRecordAdapter(record))
+
+	// Only announce the labels of access-gated records to unauthorized peers
+	if metadata.AccessGated && !r.service.gatedAccessAllowed(ctx, in) {
+		if !in.AcceptsGated {
+			return ErrAccessGated
+		}
+
+		labels := types.GetLabelsFromRecord(adapters.NewRecordAdapter(record))
+		if provider := r.service.getPublishedLabelsProvider(); provider != nil {
+			labels, err = provider(ctx, in.Cid, labels)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to get published labels: %v", err)
+			}
+		}
+
+		out.Labels = labelStrings(labels)
+
+		return nil
+	}
+
+	out.Data = canonicalBytes
+
+	r.service.recordPull(ctx, len(canonicalBytes))
+
+	return nil
+}
+
+func (r *RPCAPI) Snapshot(ctx context.Context, in *SnapshotRequest, out *SnapshotResponse) error {
+	logger.Debug("P2p RPC: Executing Snapshot request on remote peer", "peer", r.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	ctx, span := startServerSpan(ctx, DirServiceFuncSnapshot, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
+	provider := r.service.getSnapshotProvider()
+	if provider == nil {
+		return status.Error(codes.Unimplemented, "label cache snapshots are not served by this peer") //nolint:wrapcheck
+	}
+
+	limit := in.Limit
+	if limit <= 0 || limit > MaxSnapshotPageSize {
+		limit = MaxSnapshotPageSize
+	}
+
+	resp, err := provider(ctx, in.Cursor, limit)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to snapshot label cache")
+	}
+
 	// set output
-	*out = PullResponse{
-		Cid:         meta.GetCid(),
-		Data:        canonicalBytes,
-		Annotations: meta.GetAnnotations(),
+	*out = *resp
+
+	return nil
+}
+
+func (r *RPCAPI) Search(ctx context.Context, in *SearchRequest, out *SearchResponse) error {
+	logger.Debug("P2p RPC: Executing Search request on remote peer", "peer", r.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	ctx, span := startServerSpan(ctx, DirServiceFuncSearch, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
+	provider := r.service.getSearchProvider()
+	if provider == nil {
+		return status.Error(codes.Unimplemented, "live search is not served by this peer") //nolint:wrapcheck
+	}
+
+	queries := make([]*routingv1.RecordQuery, 0, len(in.Queries))
+
+	for _, data := range in.Queries {
+		query := &routingv1.RecordQuery{}
+		if err := proto.Unmarshal(data, query); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+		}
+
+		queries = append(queries, query)
+	}
+
+	limit := in.Limit
+	if limit <= 0 || limit > MaxSearchResults {
+		limit = MaxSearchResults
 	}
 
+	results, err := provider(ctx, queries, in.MinMatchScore, limit)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to search")
+	}
+
+	// set output
+	*out = SearchResponse{Results: results}
+
+	return nil
+}
+
+func (r *RPCAPI) Verify(ctx context.Context, in *VerifyRequest, out *VerifyResponse) error {
+	logger.Debug("P2p RPC: Executing Verify request on remote peer", "peer", r.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	ctx, span := startServerSpan(ctx, DirServiceFuncVerify, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
+	if len(in.Cids) > MaxVerifyCids {
+		return status.Errorf(codes.InvalidArgument, "too many CIDs: %d (max %d)", len(in.Cids), MaxVerifyCids)
+	}
+
+	provider := r.service.getVerifyProvider()
+	if provider == nil {
+		return status.Error(codes.Unimplemented, "announcement verification is not served by this peer") //nolint:wrapcheck
+	}
+
+	// Announcements are verified for the calling peer only
+	announcer, err := rpc.GetRequestSender(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get request sender: %v", err)
+	}
+
+	results, err := provider(ctx, announcer, in.Cids)
+	if err != nil {
+		return routingerr.Wrap(err, "failed to verify announcements")
+	}
+
+	// set output
+	*out = VerifyResponse{Results: results}
+
+	return nil
+}
+
+func (r *RPCAPI) Has(ctx context.Context, in *HasRequest, out *HasResponse) error {
+	logger.Debug("P2p RPC: Executing Has request on remote peer", "peer", r.service.host.ID())
+
+	// validate request
+	if in == nil || out == nil {
+		return status.Error(codes.InvalidArgument, "invalid request: nil request/response") //nolint:wrapcheck
+	}
+
+	ctx, span := startServerSpan(ctx, DirServiceFuncHas, in.TraceContext)
+	defer span.End()
+
+	if err := r.service.admit(ctx); err != nil {
+		return err
+	}
+
+	if len(in.Cids) > MaxHasCids {
+		return status.Errorf(codes.InvalidArgument, "too many CIDs: %d (max %d)", len(in.Cids), MaxHasCids)
+	}
+
+	has := make([]bool, len(in.Cids))
+
+	for i, cid := range in.Cids {
+		_, err := r.service.store.Lookup(ctx, &corev1.RecordRef{Cid: cid})
+		if err == nil {
+			has[i] = true
+
+			continue
+		}
+
+		// Only a missing record is an answer, other failures must not be mistaken for one
+		if st := status.Convert(err); st.Code() != codes.NotFound {
+			return status.Errorf(st.Code(), "failed to lookup %s: %s", cid, st.Message())
+		}
+	}
+
+	// set output
+	*out = HasResponse{Has: has}
+
 	return nil
 }
 
 // NOTE: List RPC method removed since List is a local-only operation
 
 type Service struct {
-	rpcServer *rpc.Server
-	rpcClient *rpc.Client
-	host      host.Host
-	store     types.StoreAPI
+	rpcServer  *rpc.Server
+	rpcClient  *rpc.Client
+	host       host.Host
+	store      types.StoreAPI
+	streamPool *streamPool
+
+	mu               sync.RWMutex
+	snapshotProvider SnapshotProvider
+	searchProvider   SearchProvider
+	metadataProvider RecordMetadataProvider
+	labelsProvider   PublishedLabelsProvider
+	verifyProvider   VerifyProvider
+	gatedAccess      GatedAccessAuthorizer
+	accessTokens     AccessTokenProvider
+
+	capabilitiesProvider CapabilitiesProvider
+
+	rateLimiter *ratelimit.Limiter
+	bandwidth   *bandwidth.Meter
+
+	labelSyncServer   *rpc.Server
+	labelSyncClient   *rpc.Client
+	labelSyncLimiter  *syncLimiter
+	labelSyncProvider LabelSyncProvider
 }
 
 func New(host host.Host, store types.StoreAPI) (*Service, error) {
@@ -137,19 +575,263 @@ func New(host host.Host, store types.StoreAPI) (*Service, error) {
 	}
 
 	// update client
-	service.rpcClient = rpc.NewClientWithServer(host, Protocol, service.rpcServer)
+	// The client opens streams through the warm stream pool to avoid
+	// per-call stream setup costs for frequently pulled peers.
+	service.streamPool = newStreamPool(host, Protocol, StreamPoolMaxPeers, StreamPoolStreamsPerPeer, StreamPoolWarmUpPulls, StreamPoolIdleTimeout)
+	service.rpcClient = rpc.NewClientWithServer(service.streamPool, Protocol, service.rpcServer)
+
+	// Label sync is served on its own protocol and is rate limited per peer
+	service.labelSyncServer = rpc.NewServer(host, LabelSyncProtocol)
+	service.labelSyncLimiter = newSyncLimiter(LabelSyncRateLimit, LabelSyncRateWindow)
+
+	if err := service.labelSyncServer.Register(&LabelSyncAPI{service: service}); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	service.labelSyncClient = rpc.NewClientWithServer(host, LabelSyncProtocol, service.labelSyncServer)
 
 	return service, nil
 }
 
+// StreamPoolStats returns a snapshot of the warm stream pool used for outgoing calls.
+func (s *Service) StreamPoolStats() StreamPoolStats {
+	return s.streamPool.Stats()
+}
+
+// SetSnapshotProvider sets the function serving label cache snapshots to remote peers.
+// Until it is set, Snapshot requests are rejected as unimplemented.
+func (s *Service) SetSnapshotProvider(fn SnapshotProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshotProvider = fn
+}
+
+func (s *Service) getSnapshotProvider() SnapshotProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.snapshotProvider
+}
+
+// SetSearchProvider sets the function serving live searches to remote peers.
+// Until it is set, Search requests are rejected as unimplemented.
+func (s *Service) SetSearchProvider(fn SearchProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.searchProvider = fn
+}
+
+func (s *Service) getSearchProvider() SearchProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.searchProvider
+}
+
+// SetRecordMetadataProvider sets the function reporting the announcement metadata of local
+// records served by Pull. Until it is set, pulled records are reported as not expiring and
+// not superseding other records.
+func (s *Service) SetRecordMetadataProvider(fn RecordMetadataProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.metadataProvider = fn
+}
+
+func (s *Service) getRecordMetadataProvider() RecordMetadataProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.metadataProvider
+}
+
+// SetPublishedLabelsProvider sets the function filtering the labels of access-gated records
+// reported to unauthorized peers by their publish policies. Until it is set, all labels are reported.
+func (s *Service) SetPublishedLabelsProvider(fn PublishedLabelsProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.labelsProvider = fn
+}
+
+func (s *Service) getPublishedLabelsProvider() PublishedLabelsProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.labelsProvider
+}
+
+// SetGatedAccessAuthorizer sets the function authorizing remote peers to pull access-gated records.
+// Until it is set, access-gated records are not served to any peer.
+func (s *Service) SetGatedAccessAuthorizer(fn GatedAccessAuthorizer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.gatedAccess = fn
+}
+
+// SetAccessTokenProvider sets the function returning the access tokens sent with pulls.
+// Until it is set, no access tokens are sent.
+func (s *Service) SetAccessTokenProvider(fn AccessTokenProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accessTokens = fn
+}
+
+// accessToken returns the access token to send with a pull of a record from a peer.
+func (s *Service) accessToken(issuer peer.ID, cid string) string {
+	s.mu.RLock()
+	provider := s.accessTokens
+	s.mu.RUnlock()
+
+	if provider == nil {
+		return ""
+	}
+
+	return provider(issuer, cid)
+}
+
+// gatedAccessAllowed reports whether the sender of the request may pull the access-gated record.
+func (s *Service) gatedAccessAllowed(ctx context.Context, in *RecordRequest) bool {
+	s.mu.RLock()
+	authorizer := s.gatedAccess
+	s.mu.RUnlock()
+
+	if authorizer == nil {
+		return false
+	}
+
+	sender, err := rpc.GetRequestSender(ctx)
+	if err != nil {
+		return false
+	}
+
+	return authorizer(sender, in.Cid, in.AccessToken)
+}
+
+// labelStrings converts labels to wire format.
+func labelStrings(labels []types.Label) []string {
+	result := make([]string, 0, len(labels))
+	for _, label := range labels {
+		result = append(result, label.String())
+	}
+
+	return result
+}
+
+// SetVerifyProvider sets the function serving announcement verifications to remote peers.
+// Until it is set, Verify requests are rejected as unimplemented.
+func (s *Service) SetVerifyProvider(fn VerifyProvider) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.verifyProvider = fn
+}
+
+func (s *Service) getVerifyProvider() VerifyProvider {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.verifyProvider
+}
+
+// SetRateLimiter sets the per-peer rate limit of incoming requests.
+// Until it is set, or if it is nil, requests are not limited.
+func (s *Service) SetRateLimiter(limiter *ratelimit.Limiter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimiter = limiter
+}
+
+// admit applies the rate limit of the calling peer to an incoming request.
+func (s *Service) admit(ctx context.Context) error {
+	sender, err := rpc.GetRequestSender(ctx)
+	if err != nil {
+		return nil //nolint:nilerr // Local calls are not limited
+	}
+
+	s.mu.RLock()
+	limiter := s.rateLimiter
+	s.mu.RUnlock()
+
+	switch limiter.Allow(sender.String(), time.Now()) {
+	case ratelimit.Allowed:
+		return nil
+	case ratelimit.Banned:
+		metrics.RateLimited.WithLabelValues(metrics.TransportRPC, metrics.ResultBanned).Inc()
+	case ratelimit.Limited:
+		metrics.RateLimited.WithLabelValues(metrics.TransportRPC, metrics.ResultLimited).Inc()
+	}
+
+	logger.Debug("Refused rate limited request", "peer", sender)
+
+	return ErrRateLimited
+}
+
+// SetBandwidthMeter sets the meter of the record content served by Pull to each peer.
+// Until it is set, or if it is nil, pulls are neither metered nor limited.
+func (s *Service) SetBandwidthMeter(meter *bandwidth.Meter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bandwidth = meter
+}
+
+// getBandwidthMeter returns the bandwidth meter, nil if not set.
+func (s *Service) getBandwidthMeter() *bandwidth.Meter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.bandwidth
+}
+
+// admitPull applies the hourly bandwidth quota of the calling peer to an incoming pull.
+func (s *Service) admitPull(ctx context.Context) error {
+	sender, err := rpc.GetRequestSender(ctx)
+	if err != nil {
+		return nil //nolint:nilerr // Local calls are not limited
+	}
+
+	now := time.Now()
+	if s.getBandwidthMeter().Allow(sender.String(), now) {
+		return nil
+	}
+
+	metrics.RateLimited.WithLabelValues(metrics.TransportRPC, metrics.ResultQuota).Inc()
+	logger.Debug("Refused pull exceeding the bandwidth quota", "peer", sender)
+
+	return ErrPullQuotaExceeded.WithRetryAfter(now.Truncate(time.Hour).Add(time.Hour).Sub(now))
+}
+
+// recordPull meters the record content served to the calling peer.
+func (s *Service) recordPull(ctx context.Context, bytes int) {
+	metrics.PullBytesServed.Add(float64(bytes))
+
+	sender, err := rpc.GetRequestSender(ctx)
+	if err != nil {
+		return
+	}
+
+	s.getBandwidthMeter().Record(sender.String(), uint64(bytes), time.Now()) //nolint:gosec // Lengths are not negative
+}
+
+// Close releases the warm streams held by the service.
+func (s *Service) Close() {
+	s.streamPool.Stop()
+}
+
 func (s *Service) Lookup(ctx context.Context, peer peer.ID, req *corev1.RecordRef) (*corev1.RecordRef, error) {
 	logger.Debug("P2p RPC: Executing Lookup request on remote peer", "peer", peer, "req", req)
 
 	var resp LookupResponse
 
-	err := s.rpcClient.CallContext(ctx, peer, DirService, DirServiceFuncLookup, req, &resp)
+	err := s.call(ctx, peer, DirServiceFuncLookup, &RecordRequest{Cid: req.GetCid()}, &resp)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to call remote peer: %v", err)
+		return nil, callError(ctx, err)
 	}
 
 	return &corev1.RecordRef{
@@ -157,23 +839,146 @@ func (s *Service) Lookup(ctx context.Context, peer peer.ID, req *corev1.RecordRe
 	}, nil
 }
 
-func (s *Service) Pull(ctx context.Context, peer peer.ID, req *corev1.RecordRef) (*corev1.Record, error) {
+// Pull fetches a record from the remote peer, together with its announcement metadata.
+// If the peer does not serve the record as it is access-gated, ErrAccessGated is returned
+// together with the metadata, including the record's labels.
+func (s *Service) Pull(ctx context.Context, peer peer.ID, req *corev1.RecordRef) (*corev1.Record, RecordMetadata, error) {
 	logger.Debug("P2p RPC: Executing Pull request on remote peer", "peer", peer, "req", req)
 
 	var resp PullResponse
 
-	err := s.rpcClient.CallContext(ctx, peer, DirService, DirServiceFuncPull, req, &resp)
+	err := s.call(ctx, peer, DirServiceFuncPull, &RecordRequest{
+		Cid:          req.GetCid(),
+		AcceptsGated: true,
+		AccessToken:  s.accessToken(peer, req.GetCid()),
+	}, &resp)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to call remote peer: %v", err)
+		return nil, RecordMetadata{}, callError(ctx, err)
+	}
+
+	metadata := RecordMetadata{Supersedes: resp.Supersedes, AccessGated: resp.AccessGated, Size: resp.Size, Summary: resp.Summary}
+	if resp.ExpiresAt > 0 {
+		metadata.ExpiresAt = time.Unix(resp.ExpiresAt, 0).UTC()
+	}
+
+	// Access-gated records are only described by their labels
+	if resp.AccessGated && len(resp.Data) == 0 {
+		metadata.Labels = resp.Labels
+
+		return nil, metadata, ErrAccessGated
+	}
+
+	// Verify the served bytes before trusting them, the remote peer is not trusted
+	// to serve the content it announced.
+	if err := VerifyContent(req.GetCid(), resp.Data); err != nil {
+		return nil, RecordMetadata{}, err
 	}
 
 	record, err := corev1.UnmarshalRecord(resp.Data)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to unmarshal record: %v", err)
+		return nil, RecordMetadata{}, status.Errorf(codes.Internal, "failed to unmarshal record: %v", err)
 	}
 
-	return record, nil
+	metadata.Size = uint64(len(resp.Data))
+	metadata.Summary = types.NewRecordSummary(adapters.NewRecordAdapter(record))
+
+	return record, metadata, nil
+}
+
+// VerifyContent checks that the canonical record bytes hash to the expected CID.
+func VerifyContent(expectedCID string, data []byte) error {
+	digest, err := corev1.CalculateDigest(data)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to calculate digest of pulled record: %v", err)
+	}
+
+	actualCID, err := corev1.ConvertDigestToCID(digest)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to convert digest of pulled record to CID: %v", err)
+	}
+
+	if actualCID != expectedCID {
+		return fmt.Errorf("%w: requested %s, got %s", ErrContentMismatch, expectedCID, actualCID)
+	}
+
+	return nil
 }
 
 // NOTE: List RPC client method removed since List is a local-only operation
 // Use Search for network-wide record discovery instead
+
+// Snapshot fetches a page of the remote peer's label cache.
+func (s *Service) Snapshot(ctx context.Context, peer peer.ID, req *SnapshotRequest) (*SnapshotResponse, error) {
+	logger.Debug("P2p RPC: Executing Snapshot request on remote peer", "peer", peer, "cursor", req.Cursor)
+
+	var resp SnapshotResponse
+
+	err := s.call(ctx, peer, DirServiceFuncSnapshot, req, &resp)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	return &resp, nil
+}
+
+// Search queries the local records of the remote peer.
+func (s *Service) Search(ctx context.Context, peer peer.ID, queries []*routingv1.RecordQuery, minMatchScore uint32, limit int) ([]SearchResult, error) {
+	logger.Debug("P2p RPC: Executing Search request on remote peer", "peer", peer, "queries", len(queries))
+
+	req := &SearchRequest{
+		Queries:       make([][]byte, 0, len(queries)),
+		MinMatchScore: minMatchScore,
+		Limit:         limit,
+	}
+
+	for _, query := range queries {
+		data, err := proto.Marshal(query)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to marshal query: %v", err)
+		}
+
+		req.Queries = append(req.Queries, data)
+	}
+
+	var resp SearchResponse
+
+	err := s.call(ctx, peer, DirServiceFuncSearch, req, &resp)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	return resp.Results, nil
+}
+
+// Verify asks the remote peer how it sees the announcements of the given records by this peer.
+func (s *Service) Verify(ctx context.Context, peer peer.ID, cids []string) ([]VerifyResult, error) {
+	logger.Debug("P2p RPC: Executing Verify request on remote peer", "peer", peer, "cids", len(cids))
+
+	var resp VerifyResponse
+
+	err := s.call(ctx, peer, DirServiceFuncVerify, &VerifyRequest{Cids: cids}, &resp)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	return resp.Results, nil
+}
+
+// Has asks the remote peer whether it stores the given records.
+// Returns one result per CID in request order.
+func (s *Service) Has(ctx context.Context, peer peer.ID, cids []string) ([]bool, error) {
+	logger.Debug("P2p RPC: Executing Has request on remote peer", "peer", peer, "cids", len(cids))
+
+	var resp HasResponse
+
+	err := s.call(ctx, peer, DirServiceFuncHas, &HasRequest{Cids: cids}, &resp)
+	if err != nil {
+		return nil, callError(ctx, err)
+	}
+
+	if len(resp.Has) != len(cids) {
+		return nil, status.Errorf(codes.Internal, "remote peer returned %d results for %d CIDs", len(resp.Has), len(cids))
+	}
+
+	return resp.Has, nil
+}
@@ -0,0 +1,299 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/routing/bandwidth"
+	"github.com/agntcy/dir/server/routing/ratelimit"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestVerifyContent(t *testing.T) {
+	data := []byte(`{"name":"agent","version":"v1.0.0"}`)
+
+	digest, err := corev1.CalculateDigest(data)
+	require.NoError(t, err)
+
+	cid, err := corev1.ConvertDigestToCID(digest)
+	require.NoError(t, err)
+
+	require.NoError(t, VerifyContent(cid, data))
+
+	err = VerifyContent(cid, []byte(`{"name":"agent","version":"v6.6.6"}`))
+	require.ErrorIs(t, err, ErrContentMismatch)
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+
+	assert.Error(t, VerifyContent(cid, nil))
+}
+
+// Peers sending corev1.RecordRef and peers sending RecordRequest must interoperate.
+func TestRecordRequest_WireCompatibility(t *testing.T) {
+	handle := &codec.MsgpackHandle{}
+
+	var data []byte
+	require.NoError(t, codec.NewEncoderBytes(&data, handle).Encode(&corev1.RecordRef{Cid: "cid1"}))
+
+	var req RecordRequest
+	require.NoError(t, codec.NewDecoderBytes(data, handle).Decode(&req))
+	assert.Equal(t, RecordRequest{Cid: "cid1"}, req)
+
+	data = nil
+	require.NoError(t, codec.NewEncoderBytes(&data, handle).Encode(&RecordRequest{
+		Cid:          "cid2",
+		TraceContext: TraceContext{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}))
+
+	var ref corev1.RecordRef
+	require.NoError(t, codec.NewDecoderBytes(data, handle).Decode(&ref))
+	assert.Equal(t, "cid2", ref.GetCid())
+}
+
+// recordStore serves a single record.
+type recordStore struct {
+	types.StoreAPI
+
+	record *corev1.Record
+}
+
+func (s *recordStore) Lookup(_ context.Context, ref *corev1.RecordRef) (*corev1.RecordMeta, error) {
+	return &corev1.RecordMeta{Cid: ref.GetCid()}, nil
+}
+
+func (s *recordStore) Pull(context.Context, *corev1.RecordRef) (*corev1.Record, error) {
+	return s.record, nil
+}
+
+func TestPull_AccessGated(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	clientHost, serverHost := mn.Hosts()[0], mn.Hosts()[1]
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "gated-agent",
+		SchemaVersion: "v0.3.1",
+		Skills: []*typesv1alpha0.Skill{
+			{CategoryName: proto.String("category1"), ClassName: proto.String("class1")},
+		},
+	})
+
+	server, err := New(serverHost, &recordStore{record: record})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	client, err := New(clientHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	server.SetRecordMetadataProvider(func(string) RecordMetadata {
+		return RecordMetadata{AccessGated: true}
+	})
+
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	canonicalBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	// Unauthorized peers only receive the labels, the content size and the summary
+	pulled, metadata, err := client.Pull(t.Context(), serverHost.ID(), ref)
+	require.ErrorIs(t, err, ErrAccessGated)
+	assert.Nil(t, pulled)
+	assert.True(t, metadata.AccessGated)
+	assert.Equal(t, []string{"/skills/category1/class1"}, metadata.Labels)
+	assert.Equal(t, uint64(len(canonicalBytes)), metadata.Size)
+	assert.Equal(t, "gated-agent", metadata.Summary.Name)
+
+	// Peers not handling labels-only responses are refused
+	var resp PullResponse
+	err = client.call(t.Context(), serverHost.ID(), DirServiceFuncPull, &RecordRequest{Cid: ref.GetCid()}, &resp)
+	require.Error(t, err)
+	assert.Empty(t, resp.Data)
+
+	// Peers sending a valid access token receive the record
+	server.SetGatedAccessAuthorizer(func(reader peer.ID, cid, accessToken string) bool {
+		return reader == clientHost.ID() && cid == ref.GetCid() && accessToken == "token"
+	})
+
+	_, _, err = client.Pull(t.Context(), serverHost.ID(), ref)
+	require.ErrorIs(t, err, ErrAccessGated)
+
+	client.SetAccessTokenProvider(func(issuer peer.ID, _ string) string {
+		if issuer == serverHost.ID() {
+			return "token"
+		}
+
+		return ""
+	})
+
+	pulled, metadata, err = client.Pull(t.Context(), serverHost.ID(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+	assert.True(t, metadata.AccessGated)
+	assert.Empty(t, metadata.Labels)
+	assert.Equal(t, uint64(len(canonicalBytes)), metadata.Size)
+	assert.Equal(t, "gated-agent", metadata.Summary.Name)
+}
+
+func TestRateLimiter(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	clientHost, serverHost := mn.Hosts()[0], mn.Hosts()[1]
+
+	server, err := New(serverHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	client, err := New(clientHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	server.SetRateLimiter(ratelimit.New(0.001, 1, ratelimit.NewBans(2, time.Hour)))
+
+	_, err = client.Lookup(t.Context(), serverHost.ID(), &corev1.RecordRef{Cid: "cid1"})
+	require.NoError(t, err)
+
+	// Requests over the limit are refused until the peer is banned
+	_, err = client.Lookup(t.Context(), serverHost.ID(), &corev1.RecordRef{Cid: "cid1"})
+	require.ErrorContains(t, err, "rate limit exceeded")
+	assert.ErrorIs(t, err, routingerr.ErrQuotaExceeded)
+
+	_, err = client.Lookup(t.Context(), serverHost.ID(), &corev1.RecordRef{Cid: "cid1"})
+	require.Error(t, err)
+
+	// Banned peers are refused label sync as well
+	server.SetLabelSyncProvider(func(context.Context, time.Time, string, int) (*LabelSyncResponse, error) {
+		return &LabelSyncResponse{}, nil
+	})
+
+	_, err = client.SyncLabels(t.Context(), serverHost.ID(), &LabelSyncRequest{})
+	require.ErrorContains(t, err, "rate limit exceeded")
+}
+
+func TestBandwidthQuota(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	clientHost, serverHost := mn.Hosts()[0], mn.Hosts()[1]
+
+	record := corev1.New(&typesv1alpha0.Record{Name: "metered-agent", SchemaVersion: "v0.3.1"})
+
+	server, err := New(serverHost, &recordStore{record: record})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	client, err := New(clientHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	meter := bandwidth.New(1)
+	server.SetBandwidthMeter(meter)
+
+	ref := &corev1.RecordRef{Cid: record.GetCid()}
+
+	canonicalBytes, err := record.Marshal()
+	require.NoError(t, err)
+
+	// The pull crossing the quota is served, later pulls within the hour are refused
+	_, _, err = client.Pull(t.Context(), serverHost.ID(), ref)
+	require.NoError(t, err)
+
+	_, _, err = client.Pull(t.Context(), serverHost.ID(), ref)
+	require.ErrorContains(t, err, "pull bandwidth quota exceeded")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	usage := meter.Usage(time.Now())
+	require.Len(t, usage, 1)
+	assert.Equal(t, clientHost.ID().String(), usage[0].PeerID)
+	assert.Equal(t, uint64(len(canonicalBytes)), usage[0].Bytes)
+	assert.Equal(t, uint64(1), usage[0].Pulls)
+	assert.Equal(t, uint64(1), usage[0].RefusedPulls)
+}
+
+func TestCapabilities(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	clientHost, serverHost := mn.Hosts()[0], mn.Hosts()[1]
+
+	server, err := New(serverHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	client, err := New(clientHost, &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	// Peers not serving capabilities fail the handshake
+	_, err = client.Capabilities(t.Context(), serverHost.ID())
+	require.ErrorContains(t, err, "not served")
+
+	server.SetCapabilitiesProvider(func() CapabilitiesResponse {
+		return CapabilitiesResponse{Version: "v1.2.3", Features: []string{"gossipsub"}, Namespaces: []string{"skills"}}
+	})
+
+	capabilities, err := client.Capabilities(t.Context(), serverHost.ID())
+	require.NoError(t, err)
+	assert.Equal(t, &CapabilitiesResponse{Version: "v1.2.3", Features: []string{"gossipsub"}, Namespaces: []string{"skills"}}, capabilities)
+
+	// Oversized capabilities are rejected
+	server.SetCapabilitiesProvider(func() CapabilitiesResponse {
+		return CapabilitiesResponse{Features: make([]string, MaxCapabilityFeatures+1)}
+	})
+
+	_, err = client.Capabilities(t.Context(), serverHost.ID())
+	require.ErrorContains(t, err, "too many capabilities")
+}
+
+func TestCallError(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(1)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	client, err := New(mn.Hosts()[0], &recordStore{})
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+
+	offline, err := test.RandPeerID()
+	require.NoError(t, err)
+
+	_, err = client.Lookup(t.Context(), offline, &corev1.RecordRef{Cid: "cid1"})
+	require.ErrorIs(t, err, routingerr.ErrPeerUnreachable)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	retryAfter, ok := routingerr.RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, routingerr.PeerRetryAfter, retryAfter)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = client.Lookup(ctx, offline, &corev1.RecordRef{Cid: "cid1"})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+}
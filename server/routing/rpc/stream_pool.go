@@ -0,0 +1,365 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// StreamPoolStats is a point-in-time snapshot of the warm stream pool.
+type StreamPoolStats struct {
+	// Hits is the number of RPC calls served by a warm stream.
+	Hits uint64 `json:"hits"`
+
+	// Misses is the number of RPC calls that had to open a new stream.
+	Misses uint64 `json:"misses"`
+
+	// Opened is the number of warm streams opened in the background.
+	Opened uint64 `json:"opened"`
+
+	// Expired is the number of warm streams closed after the idle timeout.
+	Expired uint64 `json:"expired"`
+
+	// Peers is the number of peers that currently have warm streams.
+	Peers int `json:"peers"`
+
+	// IdleStreams is the number of warm streams currently in the pool.
+	IdleStreams int `json:"idle_streams"`
+}
+
+// HitRate returns the fraction of RPC calls served by a warm stream in the range [0, 1].
+func (s StreamPoolStats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+type warmStream struct {
+	stream    network.Stream
+	createdAt time.Time
+}
+
+type peerStreams struct {
+	idle     []warmStream
+	opening  int
+	lastUsed time.Time
+
+	// Times of the most recent pulls within the idle timeout, at most warmUpPulls
+	pulls []time.Time
+}
+
+// streamPool keeps a few pre-opened RPC streams to recently pulled peers.
+// It wraps the host so that the gorpc client transparently takes a warm stream
+// instead of opening a new one, which avoids dialing and protocol negotiation
+// on the critical path during sync and fallback pull bursts.
+//
+// gorpc uses one stream per call, so pooled streams are single-use: every
+// stream handed out is replaced in the background. Streams are only kept for
+// peers pulled warmUpPulls times within the idle timeout, so that one-off pulls
+// do not open streams that expire unused. Peers that are not used within the
+// idle timeout are dropped together with their streams.
+type streamPool struct {
+	host.Host
+
+	protocol       protocol.ID
+	maxPeers       int
+	streamsPerPeer int
+	warmUpPulls    int
+	idleTimeout    time.Duration
+
+	ctx    context.Context //nolint:containedctx // Needed for background stream opening
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu    sync.Mutex
+	peers map[peer.ID]*peerStreams
+	stats StreamPoolStats
+}
+
+func newStreamPool(h host.Host, proto protocol.ID, maxPeers, streamsPerPeer, warmUpPulls int, idleTimeout time.Duration) *streamPool {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pool := &streamPool{
+		Host:           h,
+		protocol:       proto,
+		maxPeers:       maxPeers,
+		streamsPerPeer: streamsPerPeer,
+		warmUpPulls:    warmUpPulls,
+		idleTimeout:    idleTimeout,
+		ctx:            ctx,
+		cancel:         cancel,
+		peers:          make(map[peer.ID]*peerStreams),
+	}
+
+	pool.wg.Add(1)
+
+	go pool.expireLoop()
+
+	return pool
+}
+
+// NewStream returns a warm stream to the peer if one is available,
+// and opens a new stream otherwise. Either way the peer's pool is refilled
+// if it is pulled frequently enough.
+func (p *streamPool) NewStream(ctx context.Context, id peer.ID, pids ...protocol.ID) (network.Stream, error) {
+	if !slices.Contains(pids, p.protocol) {
+		return p.Host.NewStream(ctx, id, pids...) //nolint:wrapcheck
+	}
+
+	stream := p.take(id)
+	if stream != nil {
+		p.refill(id)
+
+		return stream, nil
+	}
+
+	stream, err := p.Host.NewStream(allowRelayed(ctx), id, p.protocol)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	p.refill(id)
+
+	return stream, nil
+}
+
+// allowRelayed allows opening streams over relayed connections, which are limited in
+// duration and data by the relay. Records of peers behind NATs can then be pulled until
+// hole punching upgrades the connection to a direct one.
+func allowRelayed(ctx context.Context) context.Context {
+	return network.WithAllowLimitedConn(ctx, "record pull")
+}
+
+// take pops a usable warm stream for the peer and marks the peer as used.
+func (p *streamPool) take(id peer.ID) network.Stream {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	peerPool, ok := p.peers[id]
+	if !ok {
+		p.stats.Misses++
+
+		if len(p.peers) >= p.maxPeers {
+			p.evictLeastRecentlyUsedLocked()
+		}
+
+		p.peers[id] = &peerStreams{lastUsed: now, pulls: []time.Time{now}}
+
+		return nil
+	}
+
+	peerPool.lastUsed = now
+	peerPool.pulls = p.recentPulls(peerPool.pulls, now)
+
+	for len(peerPool.idle) > 0 {
+		warm := peerPool.idle[0]
+		peerPool.idle = peerPool.idle[1:]
+
+		// Skip streams whose connection went away while they were idle
+		if warm.stream.Conn().IsClosed() {
+			_ = warm.stream.Reset()
+
+			continue
+		}
+
+		p.stats.Hits++
+
+		return warm.stream
+	}
+
+	p.stats.Misses++
+
+	return nil
+}
+
+// recentPulls appends a pull to the pulls of a peer, dropping those older than the idle timeout
+// and keeping the warmUpPulls most recent ones.
+func (p *streamPool) recentPulls(pulls []time.Time, now time.Time) []time.Time {
+	pulls = append(pulls, now)
+
+	for len(pulls) > 0 && (len(pulls) > p.warmUpPulls || now.Sub(pulls[0]) > p.idleTimeout) {
+		pulls = pulls[1:]
+	}
+
+	return pulls
+}
+
+// refill opens streams in the background until the peer has streamsPerPeer warm streams,
+// once the peer was pulled warmUpPulls times within the idle timeout.
+func (p *streamPool) refill(id peer.ID) {
+	p.mu.Lock()
+
+	peerPool, ok := p.peers[id]
+	if !ok || p.ctx.Err() != nil || len(peerPool.pulls) < p.warmUpPulls {
+		p.mu.Unlock()
+
+		return
+	}
+
+	missing := p.streamsPerPeer - len(peerPool.idle) - peerPool.opening
+	if missing <= 0 {
+		p.mu.Unlock()
+
+		return
+	}
+
+	peerPool.opening += missing
+	p.mu.Unlock()
+
+	for range missing {
+		p.wg.Add(1)
+
+		go func() {
+			defer p.wg.Done()
+
+			p.openWarmStream(id)
+		}()
+	}
+}
+
+func (p *streamPool) openWarmStream(id peer.ID) {
+	ctx, cancel := context.WithTimeout(p.ctx, p.idleTimeout)
+	defer cancel()
+
+	stream, err := p.Host.NewStream(allowRelayed(ctx), id, p.protocol)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	peerPool, ok := p.peers[id]
+	if ok {
+		peerPool.opening--
+	}
+
+	if err != nil {
+		logger.Debug("Failed to open warm RPC stream", "peer", id, "error", err)
+
+		return
+	}
+
+	// Peer was evicted or the pool was closed while the stream was being opened
+	if !ok || p.ctx.Err() != nil {
+		_ = stream.Reset()
+
+		return
+	}
+
+	peerPool.idle = append(peerPool.idle, warmStream{stream: stream, createdAt: time.Now()})
+	p.stats.Opened++
+}
+
+// expireLoop periodically closes idle streams and drops unused peers.
+func (p *streamPool) expireLoop() {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.idleTimeout / 2) //nolint:mnd
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.expire(time.Now())
+		}
+	}
+}
+
+func (p *streamPool) expire(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, peerPool := range p.peers {
+		kept := peerPool.idle[:0]
+
+		for _, warm := range peerPool.idle {
+			if now.Sub(warm.createdAt) > p.idleTimeout {
+				_ = warm.stream.Reset()
+				p.stats.Expired++
+
+				continue
+			}
+
+			kept = append(kept, warm)
+		}
+
+		peerPool.idle = kept
+
+		if len(peerPool.idle) == 0 && peerPool.opening == 0 && now.Sub(peerPool.lastUsed) > p.idleTimeout {
+			delete(p.peers, id)
+		}
+	}
+}
+
+// evictLeastRecentlyUsedLocked drops the peer that was used least recently.
+// Must be called with the lock held.
+func (p *streamPool) evictLeastRecentlyUsedLocked() {
+	var (
+		oldestID   peer.ID
+		oldestTime time.Time
+	)
+
+	for id, peerPool := range p.peers {
+		if oldestID == "" || peerPool.lastUsed.Before(oldestTime) {
+			oldestID = id
+			oldestTime = peerPool.lastUsed
+		}
+	}
+
+	if oldestID == "" {
+		return
+	}
+
+	for _, warm := range p.peers[oldestID].idle {
+		_ = warm.stream.Reset()
+	}
+
+	delete(p.peers, oldestID)
+}
+
+// Stats returns a snapshot of the pool counters.
+func (p *streamPool) Stats() StreamPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := p.stats
+	stats.Peers = len(p.peers)
+
+	for _, peerPool := range p.peers {
+		stats.IdleStreams += len(peerPool.idle)
+	}
+
+	return stats
+}
+
+// Stop stops background work and resets all warm streams.
+// The underlying host is not closed.
+func (p *streamPool) Stop() {
+	p.cancel()
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for id, peerPool := range p.peers {
+		for _, warm := range peerPool.idle {
+			_ = warm.stream.Reset()
+		}
+
+		delete(p.peers, id)
+	}
+}
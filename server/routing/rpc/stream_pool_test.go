@@ -0,0 +1,137 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamPool_WarmStreams(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	client, server := mn.Hosts()[0], mn.Hosts()[1]
+	server.SetStreamHandler(Protocol, func(s network.Stream) {
+		_, _ = io.Copy(io.Discard, s)
+		_ = s.Close()
+	})
+
+	pool := newStreamPool(client, Protocol, 4, 2, 1, time.Minute)
+	t.Cleanup(pool.Stop)
+
+	// First call to a peer is a miss and warms up the pool
+	stream, err := pool.NewStream(t.Context(), server.ID(), Protocol)
+	require.NoError(t, err)
+
+	_ = stream.Close()
+
+	require.Eventually(t, func() bool {
+		return pool.Stats().IdleStreams == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// Second call is served from the pool
+	stream, err = pool.NewStream(t.Context(), server.ID(), Protocol)
+	require.NoError(t, err)
+
+	_ = stream.Close()
+
+	stats := pool.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Peers)
+	assert.InDelta(t, 0.5, stats.HitRate(), 0.001)
+}
+
+func TestStreamPool_ExpiresIdleStreams(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	client, server := mn.Hosts()[0], mn.Hosts()[1]
+	server.SetStreamHandler(Protocol, func(s network.Stream) {
+		_, _ = io.Copy(io.Discard, s)
+		_ = s.Close()
+	})
+
+	pool := newStreamPool(client, Protocol, 4, 1, 1, time.Minute)
+	t.Cleanup(pool.Stop)
+
+	stream, err := pool.NewStream(t.Context(), server.ID(), Protocol)
+	require.NoError(t, err)
+
+	_ = stream.Close()
+
+	require.Eventually(t, func() bool {
+		return pool.Stats().IdleStreams == 1
+	}, time.Second, 10*time.Millisecond)
+
+	// Streams and unused peers are dropped after the idle timeout
+	pool.expire(time.Now().Add(2 * time.Minute))
+
+	stats := pool.Stats()
+	assert.Equal(t, uint64(1), stats.Expired)
+	assert.Equal(t, 0, stats.IdleStreams)
+	assert.Equal(t, 0, stats.Peers)
+}
+
+func TestStreamPool_WarmsUpFrequentlyPulledPeers(t *testing.T) {
+	mn, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = mn.Close() })
+
+	client, server := mn.Hosts()[0], mn.Hosts()[1]
+	server.SetStreamHandler(Protocol, func(s network.Stream) {
+		_, _ = io.Copy(io.Discard, s)
+		_ = s.Close()
+	})
+
+	pool := newStreamPool(client, Protocol, 4, 2, StreamPoolWarmUpPulls, time.Minute)
+	t.Cleanup(pool.Stop)
+
+	// A single pull leaves no warm streams
+	stream, err := pool.NewStream(t.Context(), server.ID(), Protocol)
+	require.NoError(t, err)
+
+	_ = stream.Close()
+
+	assert.Never(t, func() bool {
+		return pool.Stats().IdleStreams > 0
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	// Pulling the peer again within the idle timeout warms up the pool
+	stream, err = pool.NewStream(t.Context(), server.ID(), Protocol)
+	require.NoError(t, err)
+
+	_ = stream.Close()
+
+	require.Eventually(t, func() bool {
+		return pool.Stats().IdleStreams == 2
+	}, time.Second, 10*time.Millisecond)
+
+	stats := pool.Stats()
+	assert.Equal(t, uint64(0), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+}
+
+func TestStreamPool_RecentPulls(t *testing.T) {
+	pool := &streamPool{warmUpPulls: 2, idleTimeout: time.Minute}
+	now := time.Now()
+
+	// Pulls older than the idle timeout do not count
+	assert.Len(t, pool.recentPulls([]time.Time{now.Add(-2 * time.Minute)}, now), 1)
+
+	// At most warmUpPulls pulls are kept
+	assert.Equal(t, []time.Time{now.Add(-time.Second), now}, pool.recentPulls([]time.Time{now.Add(-2 * time.Second), now.Add(-time.Second)}, now))
+}
@@ -0,0 +1,78 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	rpc "github.com/libp2p/go-libp2p-gorpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/agntcy/dir/server/routing/rpc")
+
+// TraceContext carries the W3C trace context of a call in its request, as libp2p RPC
+// has no call metadata. Requests are encoded as maps, so peers that do not trace
+// ignore it and an empty trace context is sent by callers that do not trace.
+type TraceContext map[string]string
+
+// traced is implemented by requests carrying a trace context.
+type traced interface {
+	setTraceContext(tc TraceContext)
+}
+
+func (r *RecordRequest) setTraceContext(tc TraceContext)       { r.TraceContext = tc }
+func (r *SnapshotRequest) setTraceContext(tc TraceContext)     { r.TraceContext = tc }
+func (r *SearchRequest) setTraceContext(tc TraceContext)       { r.TraceContext = tc }
+func (r *VerifyRequest) setTraceContext(tc TraceContext)       { r.TraceContext = tc }
+func (r *HasRequest) setTraceContext(tc TraceContext)          { r.TraceContext = tc }
+func (r *LabelSyncRequest) setTraceContext(tc TraceContext)    { r.TraceContext = tc }
+func (r *CapabilitiesRequest) setTraceContext(tc TraceContext) { r.TraceContext = tc }
+
+// call invokes a method of the remote peer in a client span,
+// propagating the trace context in the request.
+func (s *Service) call(ctx context.Context, peer peer.ID, method string, req traced, resp any) error {
+	return callTraced(ctx, s.rpcClient, peer, DirService, method, req, resp)
+}
+
+// callTraced invokes a method of a service of the remote peer in a client span.
+func callTraced(ctx context.Context, client *rpc.Client, peer peer.ID, service, method string, req traced, resp any) error {
+	ctx, span := tracer.Start(ctx, "rpc."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("peer", peer.String())))
+	defer span.End()
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	if len(carrier) > 0 {
+		req.setTraceContext(TraceContext(carrier))
+	}
+
+	if err := client.CallContext(ctx, peer, service, method, req, resp); err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// startServerSpan starts the span of an incoming call, continuing the trace of the caller.
+func startServerSpan(ctx context.Context, method string, tc TraceContext) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(tc))
+
+	opts := []trace.SpanStartOption{trace.WithSpanKind(trace.SpanKindServer)}
+	if sender, err := rpc.GetRequestSender(ctx); err == nil {
+		opts = append(opts, trace.WithAttributes(attribute.String("peer", sender.String())))
+	}
+
+	return tracer.Start(ctx, "rpc."+method, opts...)
+}
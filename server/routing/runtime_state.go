@@ -0,0 +1,216 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// StateNamespace is the datastore namespace of the persisted runtime state.
+const StateNamespace = "state"
+
+// runtimeStateKey is the datastore key of the persisted runtime state.
+var runtimeStateKey = datastore.NewKey("/" + StateNamespace + "/runtime")
+
+// Names of the background tasks whose last runs are persisted.
+const (
+	stateTaskCleanup              = "cleanup"
+	stateTaskExpiredRecordCleanup = "expired_record_cleanup"
+)
+
+// stateTaskRepublish returns the state task name of a republish cycle.
+func stateTaskRepublish(cycle string) string {
+	return "republish/" + cycle
+}
+
+// persistedState is the runtime state stored in the datastore.
+type persistedState struct {
+	StartedAt              time.Time            `json:"started_at,omitzero"`
+	StoppedAt              time.Time            `json:"stopped_at,omitzero"`
+	LastTaskRuns           map[string]time.Time `json:"last_task_runs,omitempty"` // Completion times by task name
+	AnnouncementsPublished uint64               `json:"announcements_published,omitempty"`
+	AnnouncementsReceived  uint64               `json:"announcements_received,omitempty"`
+}
+
+// runtimeState is lightweight runtime state persisted across restarts, so that
+// background tasks are scheduled from when they last ran instead of from startup.
+// It is saved whenever a task completes and when routing stops. It is safe for concurrent use.
+// A nil state records nothing and schedules tasks a full interval after startup.
+type runtimeState struct {
+	mu              sync.Mutex
+	dstore          types.Datastore
+	state           persistedState
+	previousStopped time.Time // When the previous run stopped, zero if it did not stop gracefully
+}
+
+// loadRuntimeState loads the runtime state of the previous run and marks this run as started.
+// Missing or invalid state starts from zero.
+func loadRuntimeState(ctx context.Context, dstore types.Datastore, now time.Time) (*runtimeState, error) {
+	s := &runtimeState{dstore: dstore}
+
+	value, err := dstore.Get(ctx, runtimeStateKey)
+
+	switch {
+	case errors.Is(err, datastore.ErrNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get runtime state: %w", err)
+	default:
+		if err := json.Unmarshal(value, &s.state); err != nil {
+			remoteLogger.Warn("Failed to parse runtime state, starting from zero", "error", err)
+
+			s.state = persistedState{}
+		}
+	}
+
+	// A run that did not stop after it started crashed
+	if s.state.StoppedAt.After(s.state.StartedAt) {
+		s.previousStopped = s.state.StoppedAt
+	}
+
+	if s.state.LastTaskRuns == nil {
+		s.state.LastTaskRuns = make(map[string]time.Time)
+	}
+
+	s.state.StartedAt = now
+	s.state.StoppedAt = time.Time{}
+
+	return s, s.save(ctx)
+}
+
+// nextRunDelay returns how long to wait before the first run of a task after startup.
+// Tasks that never ran wait a full interval. Tasks that ran before the restart run an interval
+// after their last run, but no sooner than TaskStartupDelay, so that overdue tasks run once
+// the node is connected rather than immediately or a full interval late.
+func (s *runtimeState) nextRunDelay(task string, interval time.Duration, now time.Time) time.Duration {
+	if s == nil {
+		return interval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, ok := s.state.LastTaskRuns[task]
+	if !ok {
+		return interval
+	}
+
+	return min(max(interval-now.Sub(last), TaskStartupDelay), interval)
+}
+
+// taskCompleted records the completion of a task run and saves the state.
+func (s *runtimeState) taskCompleted(ctx context.Context, task string, at time.Time) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.state.LastTaskRuns[task] = at
+	s.mu.Unlock()
+
+	if err := s.save(ctx); err != nil {
+		remoteLogger.Warn("Failed to save runtime state", "task", task, "error", err)
+	}
+}
+
+// announcementPublished counts a successful announcement of a local record.
+func (s *runtimeState) announcementPublished() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.AnnouncementsPublished++
+}
+
+// announcementReceived counts an announcement received from a remote peer.
+func (s *runtimeState) announcementReceived() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.AnnouncementsReceived++
+}
+
+// announcements returns the counts of published and received announcements since the node first started.
+func (s *runtimeState) announcements() (published, received uint64) {
+	if s == nil {
+		return 0, 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.AnnouncementsPublished, s.state.AnnouncementsReceived
+}
+
+// stopped marks this run as stopped gracefully and saves the state.
+func (s *runtimeState) stopped(ctx context.Context, now time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	s.state.StoppedAt = now
+	s.mu.Unlock()
+
+	return s.save(ctx)
+}
+
+func (s *runtimeState) save(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, err := json.Marshal(s.state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal runtime state: %w", err)
+	}
+
+	if err := s.dstore.Put(ctx, runtimeStateKey, value); err != nil {
+		return fmt.Errorf("failed to put runtime state: %w", err)
+	}
+
+	return nil
+}
+
+// toProto returns the runtime state for routing stats.
+func (s *runtimeState) toProto() *routingv1.RuntimeState {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &routingv1.RuntimeState{
+		StartedAt:              timestamppb.New(s.state.StartedAt),
+		LastTaskRuns:           make(map[string]*timestamppb.Timestamp, len(s.state.LastTaskRuns)),
+		AnnouncementsPublished: s.state.AnnouncementsPublished,
+		AnnouncementsReceived:  s.state.AnnouncementsReceived,
+	}
+
+	if !s.previousStopped.IsZero() {
+		result.PreviousStoppedAt = timestamppb.New(s.previousStopped)
+	}
+
+	for task, at := range s.state.LastTaskRuns {
+		result.LastTaskRuns[task] = timestamppb.New(at)
+	}
+
+	return result
+}
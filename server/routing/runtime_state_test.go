@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeState_Persistence(t *testing.T) {
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	ctx := t.Context()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// First run starts from zero
+	state, err := loadRuntimeState(ctx, dstore, start)
+	require.NoError(t, err)
+	assert.Equal(t, RepublishInterval, state.nextRunDelay(stateTaskRepublish("default"), RepublishInterval, start))
+
+	state.taskCompleted(ctx, stateTaskRepublish("default"), start.Add(time.Hour))
+
+	// A crashed run is not reported as stopped, but its task runs are kept
+	state, err = loadRuntimeState(ctx, dstore, start.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Nil(t, state.toProto().GetPreviousStoppedAt())
+	assert.Equal(t, RepublishInterval-time.Hour, state.nextRunDelay(stateTaskRepublish("default"), RepublishInterval, start.Add(2*time.Hour)))
+
+	state.announcementPublished()
+	state.announcementReceived()
+	state.announcementReceived()
+	require.NoError(t, state.stopped(ctx, start.Add(3*time.Hour)))
+
+	// A gracefully stopped run is reported along with its counters
+	state, err = loadRuntimeState(ctx, dstore, start.Add(4*time.Hour))
+	require.NoError(t, err)
+
+	stats := state.toProto()
+	assert.Equal(t, start.Add(4*time.Hour), stats.GetStartedAt().AsTime())
+	assert.Equal(t, start.Add(3*time.Hour), stats.GetPreviousStoppedAt().AsTime())
+	assert.Equal(t, start.Add(time.Hour), stats.GetLastTaskRuns()[stateTaskRepublish("default")].AsTime())
+	assert.Equal(t, uint64(1), stats.GetAnnouncementsPublished())
+	assert.Equal(t, uint64(2), stats.GetAnnouncementsReceived())
+}
+
+func TestRuntimeState_NextRunDelay(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := &runtimeState{state: persistedState{LastTaskRuns: map[string]time.Time{
+		"recent":  now.Add(-time.Hour),
+		"overdue": now.Add(-3 * time.Hour),
+		"future":  now.Add(time.Hour), // clock went backwards
+	}}}
+
+	assert.Equal(t, 2*time.Hour, state.nextRunDelay("missing", 2*time.Hour, now))
+	assert.Equal(t, time.Hour, state.nextRunDelay("recent", 2*time.Hour, now))
+	assert.Equal(t, TaskStartupDelay, state.nextRunDelay("overdue", 2*time.Hour, now))
+	assert.Equal(t, 2*time.Hour, state.nextRunDelay("future", 2*time.Hour, now))
+
+	var nilState *runtimeState
+	assert.Equal(t, 2*time.Hour, nilState.nextRunDelay("recent", 2*time.Hour, now))
+	assert.Nil(t, nilState.toProto())
+}
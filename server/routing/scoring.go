@@ -0,0 +1,336 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/reputation"
+	"github.com/agntcy/dir/server/types"
+)
+
+// scoredResult is a search result whose relevance is computed by a scoring strategy.
+type scoredResult struct {
+	matchQueries []*routingv1.RecordQuery
+	queries      int // Number of queries of the search
+	peerID       string
+	lastSeen     time.Time // When the record was last announced, zero for live results
+	providers    int       // Number of peers known to provide the record, zero if unknown
+}
+
+// scoringStrategy computes the relevance of search results. Higher is more relevant.
+type scoringStrategy interface {
+	relevance(result scoredResult) float64
+}
+
+// countScoring scores results by the number of matched queries.
+type countScoring struct{}
+
+func (countScoring) relevance(result scoredResult) float64 {
+	return float64(len(result.matchQueries))
+}
+
+// weightedNamespaceScoring sums the weights of the label namespaces of the matched queries.
+// Queries without a single namespace, e.g. boolean groups, and unweighted namespaces weigh 1.
+type weightedNamespaceScoring struct {
+	weights map[types.LabelType]float64
+}
+
+func (s weightedNamespaceScoring) relevance(result scoredResult) float64 {
+	var relevance float64
+
+	for _, query := range result.matchQueries {
+		weight, ok := s.weights[queryNamespace(query)]
+		if !ok {
+			weight = 1
+		}
+
+		relevance += weight
+	}
+
+	return relevance
+}
+
+// freshnessScoring decays the number of matched queries with the time since
+// the record was last announced, halving it every halfLife.
+type freshnessScoring struct {
+	halfLife time.Duration
+	now      func() time.Time
+}
+
+func (s freshnessScoring) relevance(result scoredResult) float64 {
+	return float64(len(result.matchQueries)) * s.value(result)
+}
+
+// value is the freshness of the record in [0, 1], halving every halfLife since it was last announced.
+// Live results are fresh.
+func (s freshnessScoring) value(result scoredResult) float64 {
+	if result.lastSeen.IsZero() {
+		return 1
+	}
+
+	age := max(s.now().Sub(result.lastSeen), 0)
+
+	return math.Exp2(-age.Seconds() / s.halfLife.Seconds())
+}
+
+// reputationScoring scales the number of matched queries by the reputation of the providing peer.
+type reputationScoring struct {
+	reputation *reputation.Tracker
+}
+
+func (s reputationScoring) relevance(result scoredResult) float64 {
+	return float64(len(result.matchQueries)) * s.value(result)
+}
+
+// value is the reputation of the providing peer in [0, 1].
+func (s reputationScoring) value(result scoredResult) float64 {
+	return s.reputation.Score(result.peerID)
+}
+
+// Ranking factors combined by the ranked scoring strategy.
+const (
+	rankingFactorMatch      = "match"
+	rankingFactorFreshness  = "freshness"
+	rankingFactorReputation = "reputation"
+	rankingFactorProviders  = "providers"
+)
+
+// rankingFactor is a signal combined by the ranked scoring strategy, normalized to [0, 1].
+type rankingFactor interface {
+	value(result scoredResult) float64
+}
+
+// matchFactor is the fraction of the queries of the search matched by the record.
+type matchFactor struct{}
+
+func (matchFactor) value(result scoredResult) float64 {
+	if result.queries <= 0 {
+		return 1
+	}
+
+	return min(float64(len(result.matchQueries))/float64(result.queries), 1)
+}
+
+// providersFactor grows with the number of peers providing the record,
+// halving the remaining gap to 1 with each provider. Unknown counts as one provider.
+type providersFactor struct{}
+
+func (providersFactor) value(result scoredResult) float64 {
+	return 1 - math.Exp2(-float64(max(result.providers, 1)))
+}
+
+// weightedFactor is a ranking factor with its weight.
+type weightedFactor struct {
+	name   string
+	factor rankingFactor
+	weight float64
+}
+
+// rankedScoring combines weighted ranking factors into a relevance normalized to [0, 1].
+type rankedScoring struct {
+	factors []weightedFactor
+}
+
+// newRankedScoring creates the ranked strategy of the factors.
+// Weights must not be negative and at least one must be positive.
+func newRankedScoring(factors []weightedFactor) (rankedScoring, error) {
+	var total float64
+
+	for _, f := range factors {
+		if f.weight < 0 || math.IsNaN(f.weight) || math.IsInf(f.weight, 0) {
+			return rankedScoring{}, fmt.Errorf("ranking weight %v of factor %q must be a non-negative number", f.weight, f.name)
+		}
+
+		total += f.weight
+	}
+
+	if total == 0 {
+		return rankedScoring{}, errors.New("at least one ranking weight must be positive")
+	}
+
+	return rankedScoring{factors: factors}, nil
+}
+
+func (s rankedScoring) relevance(result scoredResult) float64 {
+	var relevance, total float64
+
+	for _, f := range s.factors {
+		if f.weight == 0 {
+			continue
+		}
+
+		relevance += f.weight * f.factor.value(result)
+		total += f.weight
+	}
+
+	return relevance / total
+}
+
+// withWeights returns the strategy with the weights set by a search. Unset weights are kept.
+func (s rankedScoring) withWeights(weights *routingv1.RankingWeights) (rankedScoring, error) {
+	overrides := map[string]*float64{
+		rankingFactorMatch:      weights.Match,
+		rankingFactorFreshness:  weights.Freshness,
+		rankingFactorReputation: weights.Reputation,
+		rankingFactorProviders:  weights.Providers,
+	}
+
+	factors := slices.Clone(s.factors)
+	for i := range factors {
+		if weight := overrides[factors[i].name]; weight != nil {
+			factors[i].weight = *weight
+		}
+	}
+
+	return newRankedScoring(factors)
+}
+
+// scoringStrategies holds the built-in scoring strategies and the configured default.
+type scoringStrategies struct {
+	strategies map[routingv1.ScoringStrategy]scoringStrategy
+	fallback   routingv1.ScoringStrategy
+}
+
+// newScoringStrategies builds the scoring strategies from config.
+// Namespaces must be built-in or registered, so custom label namespaces
+// must be registered first.
+func newScoringStrategies(cfg routingconfig.ScoringConfig, tracker *reputation.Tracker) (*scoringStrategies, error) {
+	fallback := routingv1.ScoringStrategy_SCORING_STRATEGY_COUNT
+
+	if cfg.Strategy != "" {
+		value, ok := routingv1.ScoringStrategy_value["SCORING_STRATEGY_"+strings.ToUpper(cfg.Strategy)]
+		if !ok || value == int32(routingv1.ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED) {
+			return nil, fmt.Errorf("unknown scoring strategy %q, must be one of: count, weighted_namespaces, freshness, reputation, ranked", cfg.Strategy)
+		}
+
+		fallback = routingv1.ScoringStrategy(value)
+	}
+
+	weights := make(map[types.LabelType]float64, len(cfg.NamespaceWeights))
+
+	for name, weight := range cfg.NamespaceWeights {
+		namespace, ok := types.ParseLabelType(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown label namespace %q in scoring weights", name)
+		}
+
+		if weight < 0 {
+			return nil, fmt.Errorf("scoring weight %v of label namespace %q must not be negative", weight, name)
+		}
+
+		weights[namespace] = weight
+	}
+
+	halfLife := cfg.FreshnessHalfLife
+	if halfLife <= 0 {
+		halfLife = routingconfig.DefaultScoringFreshnessHalfLife
+	}
+
+	rankingWeights := cfg.RankingWeights
+	if rankingWeights == (routingconfig.RankingWeightsConfig{}) {
+		rankingWeights = routingconfig.RankingWeightsConfig{
+			Match:      routingconfig.DefaultRankingWeightMatch,
+			Freshness:  routingconfig.DefaultRankingWeightFreshness,
+			Reputation: routingconfig.DefaultRankingWeightReputation,
+			Providers:  routingconfig.DefaultRankingWeightProviders,
+		}
+	}
+
+	freshness := freshnessScoring{halfLife: halfLife, now: time.Now}
+	reputation := reputationScoring{reputation: tracker}
+
+	ranked, err := newRankedScoring([]weightedFactor{
+		{name: rankingFactorMatch, factor: matchFactor{}, weight: rankingWeights.Match},
+		{name: rankingFactorFreshness, factor: freshness, weight: rankingWeights.Freshness},
+		{name: rankingFactorReputation, factor: reputation, weight: rankingWeights.Reputation},
+		{name: rankingFactorProviders, factor: providersFactor{}, weight: rankingWeights.Providers},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ranking weights: %w", err)
+	}
+
+	return &scoringStrategies{
+		strategies: map[routingv1.ScoringStrategy]scoringStrategy{
+			routingv1.ScoringStrategy_SCORING_STRATEGY_COUNT:               countScoring{},
+			routingv1.ScoringStrategy_SCORING_STRATEGY_WEIGHTED_NAMESPACES: weightedNamespaceScoring{weights: weights},
+			routingv1.ScoringStrategy_SCORING_STRATEGY_FRESHNESS:           freshness,
+			routingv1.ScoringStrategy_SCORING_STRATEGY_REPUTATION:          reputation,
+			routingv1.ScoringStrategy_SCORING_STRATEGY_RANKED:              ranked,
+		},
+		fallback: fallback,
+	}, nil
+}
+
+// get returns the strategy selected by a search, or the configured default if none is selected.
+func (s *scoringStrategies) get(strategy routingv1.ScoringStrategy) (scoringStrategy, error) {
+	if strategy == routingv1.ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED {
+		strategy = s.fallback
+	}
+
+	scorer, ok := s.strategies[strategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown scoring strategy %d", strategy)
+	}
+
+	return scorer, nil
+}
+
+// forSearch returns the strategy selected by a search, with the ranking weights of the search
+// applied to the ranked strategy.
+func (s *scoringStrategies) forSearch(req *routingv1.SearchRequest) (scoringStrategy, error) {
+	scorer, err := s.get(req.GetScoringStrategy())
+	if err != nil {
+		return nil, err
+	}
+
+	if ranked, ok := scorer.(rankedScoring); ok && req.GetRankingWeights() != nil {
+		return ranked.withWeights(req.GetRankingWeights())
+	}
+
+	return scorer, nil
+}
+
+// queryNamespace returns the label namespace a query matches labels of,
+// or LabelTypeUnknown for boolean groups and unspecified queries.
+func queryNamespace(query *routingv1.RecordQuery) types.LabelType {
+	if query.GetGroup() != nil {
+		return types.LabelTypeUnknown
+	}
+
+	switch query.GetType() {
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL:
+		return types.LabelTypeSkill
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR:
+		return types.LabelTypeLocator
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN:
+		return types.LabelTypeDomain
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE:
+		return types.LabelTypeModule
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL:
+		return types.Label("/" + strings.Trim(query.GetValue(), "/")).Type()
+	case routingv1.RecordQueryType_RECORD_QUERY_TYPE_UNSPECIFIED:
+		return types.LabelTypeUnknown
+	default:
+		return types.LabelTypeUnknown
+	}
+}
+
+// labelLastSeen returns when a cached label was last announced, or zero if its metadata is invalid.
+func labelLastSeen(value []byte) time.Time {
+	var metadata types.LabelMetadata
+	if err := json.Unmarshal(value, &metadata); err != nil {
+		return time.Time{}
+	}
+
+	return metadata.LastSeen
+}
@@ -0,0 +1,160 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/reputation"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewScoringStrategies(t *testing.T) {
+	strategies, err := newScoringStrategies(routingconfig.ScoringConfig{}, nil)
+	require.NoError(t, err)
+
+	scorer, err := strategies.get(routingv1.ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED)
+	require.NoError(t, err)
+	assert.IsType(t, countScoring{}, scorer)
+
+	strategies, err = newScoringStrategies(routingconfig.ScoringConfig{
+		Strategy:         "weighted_namespaces",
+		NamespaceWeights: map[string]float64{"skills": 2},
+	}, nil)
+	require.NoError(t, err)
+
+	scorer, err = strategies.get(routingv1.ScoringStrategy_SCORING_STRATEGY_UNSPECIFIED)
+	require.NoError(t, err)
+	assert.Equal(t, weightedNamespaceScoring{weights: map[types.LabelType]float64{types.LabelTypeSkill: 2}}, scorer)
+
+	// Searches can select another strategy
+	scorer, err = strategies.get(routingv1.ScoringStrategy_SCORING_STRATEGY_REPUTATION)
+	require.NoError(t, err)
+	assert.IsType(t, reputationScoring{}, scorer)
+
+	_, err = strategies.get(routingv1.ScoringStrategy(42))
+	require.Error(t, err)
+
+	invalid := []routingconfig.ScoringConfig{
+		{Strategy: "unknown"},
+		{Strategy: "unspecified"},
+		{NamespaceWeights: map[string]float64{"unknown": 2}},
+		{NamespaceWeights: map[string]float64{"skills": -1}},
+		{RankingWeights: routingconfig.RankingWeightsConfig{Match: -1, Freshness: 1}},
+	}
+	for _, cfg := range invalid {
+		_, err := newScoringStrategies(cfg, nil)
+		assert.Error(t, err, "config %v", cfg)
+	}
+}
+
+func TestScoringStrategies(t *testing.T) {
+	now := time.Now()
+
+	result := scoredResult{
+		matchQueries: []*routingv1.RecordQuery{
+			{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"},
+			{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LOCATOR, Value: "docker-image"},
+			{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: "skills/ML"},
+		},
+		peerID:   "peer1",
+		lastSeen: now.Add(-48 * time.Hour),
+	}
+
+	assert.InDelta(t, 3, countScoring{}.relevance(result), 1e-9)
+
+	weighted := weightedNamespaceScoring{weights: map[types.LabelType]float64{
+		types.LabelTypeSkill:   2,
+		types.LabelTypeLocator: 0.5,
+	}}
+	assert.InDelta(t, 4.5, weighted.relevance(result), 1e-9)
+
+	freshness := freshnessScoring{halfLife: 24 * time.Hour, now: func() time.Time { return now }}
+	assert.InDelta(t, 0.75, freshness.relevance(result), 1e-9)
+
+	// Live results are fresh
+	result.lastSeen = time.Time{}
+	assert.InDelta(t, 3, freshness.relevance(result), 1e-9)
+
+	tracker := reputation.New()
+	assert.InDelta(t, 3*reputation.NeutralScore, reputationScoring{reputation: tracker}.relevance(result), 1e-9)
+}
+
+func TestRankedScoring(t *testing.T) {
+	now := time.Now()
+
+	strategies, err := newScoringStrategies(routingconfig.ScoringConfig{
+		Strategy:          "ranked",
+		FreshnessHalfLife: 24 * time.Hour,
+		RankingWeights:    routingconfig.RankingWeightsConfig{Match: 1, Freshness: 1},
+	}, reputation.New())
+	require.NoError(t, err)
+
+	scorer, err := strategies.forSearch(&routingv1.SearchRequest{})
+	require.NoError(t, err)
+
+	result := scoredResult{
+		matchQueries: []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}},
+		queries:      2,
+		peerID:       "peer1",
+		lastSeen:     now,
+		providers:    3,
+	}
+
+	// Half of the queries matched by a fresh record: (1*0.5 + 1*1) / 2
+	assert.InDelta(t, 0.75, scorer.relevance(result), 1e-3)
+
+	// Searches can override single weights, others are kept
+	scorer, err = strategies.forSearch(&routingv1.SearchRequest{
+		RankingWeights: &routingv1.RankingWeights{Freshness: proto.Float64(0), Providers: proto.Float64(1)},
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, (0.5+0.875)/2, scorer.relevance(result), 1e-9)
+
+	// Relevance is normalized
+	scorer, err = strategies.forSearch(&routingv1.SearchRequest{
+		RankingWeights: &routingv1.RankingWeights{Match: proto.Float64(3), Reputation: proto.Float64(2)},
+	})
+	require.NoError(t, err)
+
+	result.matchQueries = append(result.matchQueries, &routingv1.RecordQuery{})
+	relevance := scorer.relevance(result)
+	assert.Greater(t, relevance, 0.0)
+	assert.LessOrEqual(t, relevance, 1.0)
+
+	for _, weights := range []*routingv1.RankingWeights{
+		{Match: proto.Float64(-1)},
+		{Match: proto.Float64(0), Freshness: proto.Float64(0)},
+	} {
+		_, err := strategies.forSearch(&routingv1.SearchRequest{RankingWeights: weights})
+		assert.Error(t, err, "weights %v", weights)
+	}
+
+	// Ranking weights are ignored by other strategies
+	scorer, err = strategies.forSearch(&routingv1.SearchRequest{
+		ScoringStrategy: routingv1.ScoringStrategy_SCORING_STRATEGY_COUNT,
+		RankingWeights:  &routingv1.RankingWeights{Match: proto.Float64(-1)},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, countScoring{}, scorer)
+}
+
+func TestProvidersFactor(t *testing.T) {
+	assert.InDelta(t, 0.5, providersFactor{}.value(scoredResult{}), 1e-9)
+	assert.InDelta(t, 0.5, providersFactor{}.value(scoredResult{providers: 1}), 1e-9)
+	assert.InDelta(t, 0.75, providersFactor{}.value(scoredResult{providers: 2}), 1e-9)
+}
+
+func TestQueryNamespace(t *testing.T) {
+	assert.Equal(t, types.LabelTypeDomain, queryNamespace(&routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN}))
+	assert.Equal(t, types.LabelTypeModule, queryNamespace(&routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: "/modules/runtime/"}))
+	assert.Equal(t, types.LabelTypeUnknown, queryNamespace(&routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, Value: "teams/platform"}))
+	assert.Equal(t, types.LabelTypeUnknown, queryNamespace(&routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{}}))
+}
@@ -0,0 +1,373 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"google.golang.org/protobuf/proto"
+)
+
+// searchCache caches the results of first search pages, so that popular searches do not scan
+// and score the label cache every time. Searches are keyed by their normalized query set and
+// the other parameters of the request.
+//
+// Cached searches are invalidated incrementally as the label cache changes: adding or removing
+// a label drops the searches it may match and those returning its record. Reputation, liveness,
+// zone and pin changes do not invalidate searches, they are reflected once the TTL elapsed.
+// Searches are evicted least recently used first to stay within the memory budget.
+type searchCache struct {
+	ttl      time.Duration
+	maxBytes uint64
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element // Cached searches by key, elements of lru
+	lru      *list.List               // Cached searches, most recently used first
+	bytes    uint64                   // Size of the cached results
+	inflight map[*searchCacheFill]struct{}
+}
+
+// cachedSearch is the complete result set of a search.
+type cachedSearch struct {
+	key       string
+	queries   []*routingv1.RecordQuery
+	results   []*routingv1.SearchResponse
+	cids      map[string]bool
+	size      uint64
+	expiresAt time.Time
+}
+
+// searchCacheFill collects the results of a search missing the cache. It is invalidated like
+// cached searches while the search runs, so that results missing a concurrent label change
+// are not cached.
+type searchCacheFill struct {
+	key         string
+	queries     []*routingv1.RecordQuery
+	results     []*routingv1.SearchResponse
+	invalidated bool
+}
+
+func newSearchCache(cfg routingconfig.SearchCacheConfig) *searchCache {
+	return &searchCache{
+		ttl:      cfg.TTL,
+		maxBytes: cfg.MaxBytes,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+		inflight: make(map[*searchCacheFill]struct{}),
+	}
+}
+
+// searchCacheKey returns the cache key of a search, or false if its results are not cached:
+// only first pages that do not query peers are, as live results depend on the peers online.
+func searchCacheKey(req *routingv1.SearchRequest, queries []*routingv1.RecordQuery, minMatchScore uint32) (string, bool) {
+	if req.GetPageToken() != "" || searchQueriesPeers(req) {
+		return "", false
+	}
+
+	// The queries are normalized by the query hash, the other parameters are compared as is
+	params := proto.CloneOf(req)
+	params.Queries = nil
+	params.MinMatchScore = nil
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+
+	hasher := sha256.New()
+	hasher.Write([]byte(searchQueryHash(queries, minMatchScore)))
+	hasher.Write(data)
+
+	return hex.EncodeToString(hasher.Sum(nil)), true
+}
+
+// get returns the cached results of a search, if they have not expired yet.
+func (c *searchCache) get(key string, now time.Time) ([]*routingv1.SearchResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok || !now.Before(elem.Value.(*cachedSearch).expiresAt) { //nolint:forcetypeassert
+		if ok {
+			c.removeLocked(elem)
+		}
+
+		metrics.SearchCacheLookups.WithLabelValues(metrics.ResultMiss).Inc()
+
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	metrics.SearchCacheLookups.WithLabelValues(metrics.ResultHit).Inc()
+
+	return elem.Value.(*cachedSearch).results, true //nolint:forcetypeassert
+}
+
+// fill starts collecting the results of a search missing the cache.
+func (c *searchCache) fill(key string, queries []*routingv1.RecordQuery) *searchCacheFill {
+	fill := &searchCacheFill{key: key, queries: queries}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.inflight[fill] = struct{}{}
+
+	return fill
+}
+
+// complete caches the results of a search that ran to completion, unless a label it may
+// match changed meanwhile or they exceed the memory budget. Incomplete searches are dropped.
+func (c *searchCache) complete(fill *searchCacheFill, completed bool, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.inflight, fill)
+
+	if !completed || fill.invalidated {
+		return
+	}
+
+	entry := &cachedSearch{
+		key:       fill.key,
+		queries:   fill.queries,
+		results:   fill.results,
+		cids:      make(map[string]bool, len(fill.results)),
+		expiresAt: now.Add(c.ttl),
+	}
+
+	for _, resp := range fill.results {
+		entry.cids[resp.GetRecordRef().GetCid()] = true
+		entry.size += uint64(proto.Size(resp)) //nolint:gosec // Sizes are not negative
+	}
+
+	if entry.size > c.maxBytes {
+		return
+	}
+
+	if elem, ok := c.entries[entry.key]; ok {
+		c.removeLocked(elem)
+	}
+
+	for c.bytes+entry.size > c.maxBytes {
+		c.removeLocked(c.lru.Back())
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.bytes += entry.size
+}
+
+// invalidate drops the cached and running searches that a label of a record may match,
+// or that return the record, as the label was added to or removed from the label cache.
+func (c *searchCache) invalidate(label types.Label, cidStr string) {
+	_, label = splitTenantLabel(label)
+	labels := []types.Label{label}
+
+	matches := func(queries []*routingv1.RecordQuery) bool {
+		_, score := matchScoreForLabels(queries, labels)
+
+		return score > 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+
+		if entry := elem.Value.(*cachedSearch); entry.cids[cidStr] || matches(entry.queries) { //nolint:forcetypeassert
+			c.removeLocked(elem)
+		}
+
+		elem = next
+	}
+
+	for fill := range c.inflight {
+		if !fill.invalidated && matches(fill.queries) {
+			fill.invalidated = true
+		}
+	}
+}
+
+// removeLocked removes a cached search. Must be called with the lock held.
+func (c *searchCache) removeLocked(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cachedSearch) //nolint:forcetypeassert
+	delete(c.entries, entry.key)
+	c.bytes -= entry.size
+}
+
+// cachedResults streams cached search results. Each caller receives copies of the results.
+func cachedResults(ctx context.Context, results []*routingv1.SearchResponse) <-chan *routingv1.SearchResponse {
+	out := make(chan *routingv1.SearchResponse)
+
+	go func() {
+		defer close(out)
+
+		for _, resp := range results {
+			select {
+			case out <- proto.CloneOf(resp):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// cacheResults forwards the results of a search missing the cache and caches them once the
+// search completed. Results of searches cancelled by the caller are not cached.
+func (c *searchCache) cacheResults(ctx context.Context, fill *searchCacheFill, results <-chan *routingv1.SearchResponse) <-chan *routingv1.SearchResponse {
+	out := make(chan *routingv1.SearchResponse)
+
+	go func() {
+		defer close(out)
+
+		for resp := range results {
+			fill.results = append(fill.results, proto.CloneOf(resp))
+
+			select {
+			case out <- resp:
+			case <-ctx.Done():
+				// Drain the search, so that it is not blocked sending results
+				for range results { //nolint:revive // Intentionally empty
+				}
+
+				c.complete(fill, false, time.Now())
+
+				return
+			}
+		}
+
+		c.complete(fill, ctx.Err() == nil, time.Now())
+	}()
+
+	return out
+}
+
+// searchCacheDatastore is a datastore middleware invalidating the search cache as enhanced
+// label keys are written or deleted. Like the label index middleware, it sees all label cache
+// writes, whether the labels were received via GossipSub, pulled after DHT announcements,
+// synced, mirrored or cleaned up.
+type searchCacheDatastore struct {
+	types.Datastore
+
+	cache *searchCache
+}
+
+// wrapWithSearchCache wraps the datastore with the search cache middleware.
+func wrapWithSearchCache(dstore types.Datastore, cache *searchCache) *searchCacheDatastore {
+	return &searchCacheDatastore{Datastore: dstore, cache: cache}
+}
+
+// Unwrap returns the wrapped datastore.
+func (d *searchCacheDatastore) Unwrap() types.Datastore {
+	return d.Datastore
+}
+
+func (d *searchCacheDatastore) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	if err := d.Datastore.Put(ctx, key, value); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	d.invalidate(key.String())
+
+	return nil
+}
+
+func (d *searchCacheDatastore) Delete(ctx context.Context, key datastore.Key) error {
+	if err := d.Datastore.Delete(ctx, key); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	d.invalidate(key.String())
+
+	return nil
+}
+
+func (d *searchCacheDatastore) Batch(ctx context.Context) (datastore.Batch, error) {
+	batch, err := d.Datastore.Batch(ctx)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return &searchCacheBatch{Batch: batch, dstore: d}, nil
+}
+
+// invalidate invalidates the searches affected by a written key, if it is a label key.
+func (d *searchCacheDatastore) invalidate(key string) {
+	if !hasLabelKeyPrefix(key) {
+		return
+	}
+
+	label, cidStr, _, err := ParseEnhancedLabelKey(key)
+	if err != nil {
+		return
+	}
+
+	d.cache.invalidate(label, cidStr)
+}
+
+// searchCacheBatch invalidates the search cache for the label keys of a batch once it is committed.
+type searchCacheBatch struct {
+	datastore.Batch
+
+	dstore *searchCacheDatastore
+	keys   []string // Written label keys
+}
+
+func (b *searchCacheBatch) Put(ctx context.Context, key datastore.Key, value []byte) error {
+	if err := b.Batch.Put(ctx, key, value); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if hasLabelKeyPrefix(key.String()) {
+		b.keys = append(b.keys, key.String())
+	}
+
+	return nil
+}
+
+func (b *searchCacheBatch) Delete(ctx context.Context, key datastore.Key) error {
+	if err := b.Batch.Delete(ctx, key); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if hasLabelKeyPrefix(key.String()) {
+		b.keys = append(b.keys, key.String())
+	}
+
+	return nil
+}
+
+func (b *searchCacheBatch) Commit(ctx context.Context) error {
+	if err := b.Batch.Commit(ctx); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for _, key := range b.keys {
+		b.dstore.invalidate(key)
+	}
+
+	return nil
+}
+
+// searchCache returns the search cache of the routing datastore, nil if it is disabled.
+func (r *routeRemote) searchCache() *searchCache {
+	dstore, ok := unwrapDatastore[*searchCacheDatastore](r.dstore)
+	if !ok {
+		return nil
+	}
+
+	return dstore.cache
+}
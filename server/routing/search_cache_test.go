@@ -0,0 +1,155 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func cacheSearch(c *searchCache, key string, queries []*routingv1.RecordQuery, cids ...string) {
+	fill := c.fill(key, queries)
+
+	for _, cid := range cids {
+		fill.results = append(fill.results, &routingv1.SearchResponse{RecordRef: &corev1.RecordRef{Cid: cid}})
+	}
+
+	c.complete(fill, true, time.Now())
+}
+
+func TestSearchCacheKey(t *testing.T) {
+	skill := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}
+	domain := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+
+	// The order of queries does not matter
+	key, ok := searchCacheKey(&routingv1.SearchRequest{Queries: []*routingv1.RecordQuery{skill, domain}}, []*routingv1.RecordQuery{skill, domain}, 1)
+	require.True(t, ok)
+
+	reordered, _ := searchCacheKey(&routingv1.SearchRequest{Queries: []*routingv1.RecordQuery{domain, skill}}, []*routingv1.RecordQuery{domain, skill}, 1)
+	assert.Equal(t, key, reordered)
+
+	// Other parameters do
+	limited, _ := searchCacheKey(&routingv1.SearchRequest{Limit: proto.Uint32(10)}, []*routingv1.RecordQuery{skill, domain}, 1)
+	assert.NotEqual(t, key, limited)
+
+	scored, _ := searchCacheKey(&routingv1.SearchRequest{}, []*routingv1.RecordQuery{skill, domain}, 2)
+	assert.NotEqual(t, key, scored)
+
+	// Later pages and live searches are not cached
+	_, ok = searchCacheKey(&routingv1.SearchRequest{PageToken: proto.String("token")}, []*routingv1.RecordQuery{skill}, 1)
+	assert.False(t, ok)
+
+	_, ok = searchCacheKey(&routingv1.SearchRequest{SearchMode: routingv1.SearchMode_SEARCH_MODE_THOROUGH}, []*routingv1.RecordQuery{skill}, 1)
+	assert.False(t, ok)
+}
+
+func TestSearchCache_Invalidation(t *testing.T) {
+	c := newSearchCache(routingconfig.SearchCacheConfig{TTL: time.Minute, MaxBytes: 1 << 20})
+	skill := []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}}
+	domain := []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}}
+
+	cacheSearch(c, "skill", skill, "cid1")
+	cacheSearch(c, "domain", domain, "cid2")
+
+	results, ok := c.get("skill", time.Now())
+	require.True(t, ok)
+	assert.Equal(t, "cid1", results[0].GetRecordRef().GetCid())
+
+	// Expired searches are not returned
+	_, ok = c.get("skill", time.Now().Add(time.Hour))
+	assert.False(t, ok)
+
+	// A label matching the queries invalidates the search, also if tenanted
+	cacheSearch(c, "skill", skill, "cid1")
+	c.invalidate("/tenants/acme/skills/AI/ML", "cid3")
+
+	_, ok = c.get("skill", time.Now())
+	assert.False(t, ok)
+
+	_, ok = c.get("domain", time.Now())
+	assert.True(t, ok)
+
+	// A label of a returned record invalidates the search even if it does not match
+	c.invalidate("/skills/Other", "cid2")
+
+	_, ok = c.get("domain", time.Now())
+	assert.False(t, ok)
+
+	// Searches running while a matching label changes are not cached
+	fill := c.fill("skill", skill)
+	c.invalidate("/skills/AI", "cid4")
+	c.complete(fill, true, time.Now())
+
+	_, ok = c.get("skill", time.Now())
+	assert.False(t, ok)
+}
+
+func TestSearchCache_MemoryBudget(t *testing.T) {
+	resp := &routingv1.SearchResponse{RecordRef: &corev1.RecordRef{Cid: "cid1"}}
+	c := newSearchCache(routingconfig.SearchCacheConfig{TTL: time.Minute, MaxBytes: uint64(2 * proto.Size(resp))})
+	queries := []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}}
+
+	cacheSearch(c, "first", queries, "cid1")
+	cacheSearch(c, "second", queries, "cid1")
+
+	// The least recently used search is evicted
+	_, ok := c.get("first", time.Now())
+	require.True(t, ok)
+
+	cacheSearch(c, "third", queries, "cid1")
+
+	_, ok = c.get("second", time.Now())
+	assert.False(t, ok)
+
+	_, ok = c.get("first", time.Now())
+	assert.True(t, ok)
+
+	// Results larger than the budget are not cached
+	cacheSearch(c, "large", queries, "cid1", "cid1", "cid1")
+
+	_, ok = c.get("large", time.Now())
+	assert.False(t, ok)
+	assert.LessOrEqual(t, c.bytes, c.maxBytes)
+}
+
+func TestSearchCacheDatastore_InvalidatesOnLabelWrites(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupTestDatastore(t)
+	t.Cleanup(cleanup)
+
+	c := newSearchCache(routingconfig.SearchCacheConfig{TTL: time.Minute, MaxBytes: 1 << 20})
+	d := wrapWithSearchCache(dstore, c)
+	queries := []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}}
+
+	// Keys outside the label namespaces do not invalidate searches
+	cacheSearch(c, "skill", queries, "cid1")
+	require.NoError(t, d.Put(ctx, datastore.NewKey("/records/cid2"), []byte(`{}`)))
+
+	_, ok := c.get("skill", time.Now())
+	require.True(t, ok)
+
+	// Cache mutations invalidate searches once committed
+	mutation := &cacheMutation{}
+	mutation.put("/skills/AI/cid2/peer1", []byte(`{}`))
+	require.NoError(t, commitCacheMutation(ctx, d, mutation))
+
+	_, ok = c.get("skill", time.Now())
+	assert.False(t, ok)
+
+	// So do direct deletes
+	cacheSearch(c, "skill", queries, "cid2")
+	require.NoError(t, d.Delete(ctx, datastore.NewKey("/skills/AI/cid2/peer1")))
+
+	_, ok = c.get("skill", time.Now())
+	assert.False(t, ok)
+}
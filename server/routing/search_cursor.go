@@ -0,0 +1,286 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+)
+
+// searchCursor is the decoded form of the opaque Search continuation token.
+// It points at the last label key that produced a result, so a resumed search
+// only scans the remaining part of the current namespace and the namespaces after it.
+type searchCursor struct {
+	// Namespace is the index of the label namespace (see labelNamespaces) of the last result.
+	Namespace int `json:"n"`
+
+	// Key is the enhanced label key of the last result.
+	Key string `json:"k"`
+
+	// QueryHash binds the token to the queries and min score it was issued for.
+	QueryHash string `json:"q"`
+
+	// Phase is the zone phase of the last result, for searches preferring zones.
+	Phase zonePhase `json:"p,omitempty"`
+
+	// Tenant is the tenant of the last result, empty for untenanted records.
+	Tenant string `json:"t,omitempty"`
+}
+
+// searchPass is a scan of the cached labels of a tenant in a zone phase.
+// Searches make a pass per tenant in scope in each zone phase.
+type searchPass struct {
+	phase  zonePhase
+	tenant string
+}
+
+// searchPasses returns the passes of a search in order, starting at the pass of the cursor when resuming.
+func searchPasses(phases []zonePhase, scope []string, cursor *searchCursor) ([]searchPass, error) {
+	var passes []searchPass
+
+	for _, phase := range phases {
+		for _, tenant := range scope {
+			passes = append(passes, searchPass{phase: phase, tenant: tenant})
+		}
+	}
+
+	if cursor == nil {
+		return passes, nil
+	}
+
+	for i, pass := range passes {
+		if pass.phase == cursor.Phase && pass.tenant == cursor.Tenant {
+			return passes[i:], nil
+		}
+	}
+
+	return nil, errors.New("page token references a tenant outside the search scope")
+}
+
+// labelNamespaces returns the label namespace prefixes in the fixed order used by Search.
+func labelNamespaces() []string {
+	labelTypes := types.AllLabelTypes()
+
+	namespaces := make([]string, len(labelTypes))
+	for i, labelType := range labelTypes {
+		namespaces[i] = labelType.Prefix()
+	}
+
+	return namespaces
+}
+
+// searchQueryHash computes a stable hash of the search parameters.
+// Query order does not affect the hash.
+func searchQueryHash(queries []*routingv1.RecordQuery, minMatchScore uint32) string {
+	parts := make([]string, 0, len(queries))
+	for _, q := range queries {
+		parts = append(parts, types.QueryHash(q))
+	}
+
+	sort.Strings(parts)
+
+	hasher := sha256.New()
+	for _, part := range parts {
+		hasher.Write([]byte(part))
+		hasher.Write([]byte{0})
+	}
+
+	hasher.Write([]byte(strconv.FormatUint(uint64(minMatchScore), 10)))
+
+	return hex.EncodeToString(hasher.Sum(nil))[:16]
+}
+
+// searchCursorAEAD seals page tokens, so that callers cannot read the label key, and thus
+// the peer ID, of the result a token resumes after, even if the peers of results are redacted.
+// Its key is random per server process, so page tokens do not survive restarts.
+var searchCursorAEAD = newSearchCursorAEAD()
+
+func newSearchCursorAEAD() cipher.AEAD {
+	key := make([]byte, 32) //nolint:mnd // AES-256
+	_, _ = rand.Read(key)   // Never returns an error
+
+	// Creating AES-GCM with a valid key size cannot fail
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+
+	return aead
+}
+
+// encodeSearchCursor serializes and seals the cursor into an opaque page token.
+func encodeSearchCursor(cursor *searchCursor) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		// Marshalling a struct of primitive fields cannot fail
+		return ""
+	}
+
+	nonce := make([]byte, searchCursorAEAD.NonceSize())
+	_, _ = rand.Read(nonce)
+
+	return base64.RawURLEncoding.EncodeToString(searchCursorAEAD.Seal(nonce, nonce, data, nil))
+}
+
+// decodeSearchCursor parses a page token and checks that it was issued for the same search.
+// An empty token yields a nil cursor, which means the search starts from the beginning.
+func decodeSearchCursor(token string, queryHash string) (*searchCursor, error) {
+	if token == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed page token: %w", err)
+	}
+
+	if len(sealed) < searchCursorAEAD.NonceSize() {
+		return nil, errors.New("malformed page token")
+	}
+
+	nonce, ciphertext := sealed[:searchCursorAEAD.NonceSize()], sealed[searchCursorAEAD.NonceSize():]
+
+	data, err := searchCursorAEAD.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("page token was not issued by this server or has expired with a restart")
+	}
+
+	var cursor searchCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("malformed page token: %w", err)
+	}
+
+	if cursor.Namespace < 0 || cursor.Namespace >= len(labelNamespaces()) {
+		return nil, errors.New("page token references unknown namespace")
+	}
+
+	if cursor.Phase != zonePhasePreferred && cursor.Phase != zonePhaseOthers {
+		return nil, errors.New("page token references unknown zone phase")
+	}
+
+	if cursor.QueryHash != queryHash {
+		return nil, errors.New("page token was issued for different search parameters")
+	}
+
+	return &cursor, nil
+}
+
+// queryNamespacesFrom returns label entries of all namespaces of a tenant in a deterministic order
+// (namespace order, then key order), starting right after the cursor position.
+// A nil cursor returns all entries. Entries of tenants keep the namespace without the tenant.
+func queryNamespacesFrom(ctx context.Context, dstore types.Datastore, tenant string, cursor *searchCursor) ([]NamespaceEntry, error) {
+	var entries []NamespaceEntry
+
+	startNamespace := 0
+	if cursor != nil {
+		startNamespace = cursor.Namespace
+	}
+
+	namespaces := labelNamespaces()
+
+	for i := startNamespace; i < len(namespaces); i++ {
+		namespace := namespaces[i]
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("namespace query canceled: %w", ctx.Err())
+		default:
+		}
+
+		q := query.Query{
+			Prefix: tenantKey(tenant, namespace),
+			Orders: []query.Order{query.OrderByKey{}},
+		}
+
+		// Skip everything up to and including the last returned key
+		if cursor != nil && i == cursor.Namespace {
+			q.Filters = []query.Filter{query.FilterKeyCompare{Op: query.GreaterThan, Key: cursor.Key}}
+		}
+
+		results, err := dstore.Query(ctx, q)
+		if err != nil {
+			remoteLogger.Warn("Failed to query namespace", "namespace", namespace, "error", err)
+
+			continue
+		}
+
+		func() {
+			defer results.Close()
+
+			for result := range results.Next() {
+				if result.Error != nil {
+					continue
+				}
+
+				entries = append(entries, NamespaceEntry{
+					Namespace: namespace,
+					Key:       result.Key,
+					Value:     result.Value,
+				})
+			}
+		}()
+	}
+
+	return entries, nil
+}
+
+// firstLabelKey returns the label key at which a record is first encountered
+// when iterating namespaces in Search order. Search only evaluates a record at
+// this position, which guarantees that resumed searches never return it twice.
+func firstLabelKey(labels []types.Label, cid, peerID string) string {
+	namespaceIndex := make(map[string]int)
+	for i, namespace := range labelNamespaces() {
+		namespaceIndex[namespace] = i
+	}
+
+	firstNamespace := -1
+	firstKey := ""
+
+	for _, label := range labels {
+		idx, ok := namespaceIndex[label.Namespace()]
+		if !ok {
+			continue
+		}
+
+		key := datastore.NewKey(BuildEnhancedLabelKey(label, cid, peerID)).String()
+
+		if firstNamespace == -1 || idx < firstNamespace || (idx == firstNamespace && key < firstKey) {
+			firstNamespace = idx
+			firstKey = key
+		}
+	}
+
+	return firstKey
+}
+
+// namespaceIndexOf returns the Search order index of a namespace prefix.
+func namespaceIndexOf(namespace string) int {
+	for i, ns := range labelNamespaces() {
+		if ns == namespace {
+			return i
+		}
+	}
+
+	return -1
+}
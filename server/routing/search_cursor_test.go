@@ -0,0 +1,117 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/base64"
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/datastore"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCursor_RoundTrip(t *testing.T) {
+	queries := []*routingv1.RecordQuery{
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"},
+		{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"},
+	}
+	hash := searchQueryHash(queries, 1)
+
+	// Query order must not change the hash
+	reversed := []*routingv1.RecordQuery{queries[1], queries[0]}
+	assert.Equal(t, hash, searchQueryHash(reversed, 1))
+	assert.NotEqual(t, hash, searchQueryHash(queries, 2))
+
+	token := encodeSearchCursor(&searchCursor{Namespace: 1, Key: "/domains/research/CID1/Peer1", QueryHash: hash})
+
+	cursor, err := decodeSearchCursor(token, hash)
+	require.NoError(t, err)
+	assert.Equal(t, 1, cursor.Namespace)
+	assert.Equal(t, "/domains/research/CID1/Peer1", cursor.Key)
+
+	_, err = decodeSearchCursor(token, searchQueryHash(queries, 2))
+	require.Error(t, err)
+
+	// Searches preferring zones resume in the zone phase of the last result
+	cursor, err = decodeSearchCursor(encodeSearchCursor(&searchCursor{Key: "/skills/AI/CID2/Peer2", QueryHash: hash, Phase: zonePhaseOthers}), hash)
+	require.NoError(t, err)
+	assert.Equal(t, zonePhaseOthers, cursor.Phase)
+
+	_, err = decodeSearchCursor(encodeSearchCursor(&searchCursor{QueryHash: hash, Phase: 7}), hash)
+	require.Error(t, err)
+
+	_, err = decodeSearchCursor("not-a-token!", hash)
+	require.Error(t, err)
+
+	cursor, err = decodeSearchCursor("", hash)
+	require.NoError(t, err)
+	assert.Nil(t, cursor)
+}
+
+func TestSearchCursor_Sealed(t *testing.T) {
+	token := encodeSearchCursor(&searchCursor{Key: "/skills/AI/CID1/Peer1", QueryHash: "0123456789abcdef"})
+
+	// Tokens returned to callers whose results are redacted do not reveal the peer of the last result
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Peer1")
+	assert.NotContains(t, string(data), "CID1")
+
+	// Tokens that were tampered with or not issued by this server are rejected
+	data[len(data)-1] ^= 1
+	_, err = decodeSearchCursor(base64.RawURLEncoding.EncodeToString(data), "0123456789abcdef")
+	require.Error(t, err)
+
+	plain := base64.RawURLEncoding.EncodeToString([]byte(`{"k":"/skills/AI/CID1/Peer1","q":"0123456789abcdef"}`))
+	_, err = decodeSearchCursor(plain, "0123456789abcdef")
+	require.Error(t, err)
+}
+
+func TestQueryNamespacesFrom_ResumesAfterCursor(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, err := datastore.New()
+	require.NoError(t, err)
+
+	keys := []string{
+		"/skills/AI/CID1/Peer1",
+		"/skills/AI/CID2/Peer1",
+		"/domains/research/CID1/Peer1",
+		"/locators/docker-image/CID3/Peer2",
+	}
+	for _, key := range keys {
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey(key), nil))
+	}
+
+	all, err := queryNamespacesFrom(ctx, dstore, "", nil)
+	require.NoError(t, err)
+	require.Len(t, all, len(keys))
+
+	cursor := &searchCursor{Namespace: namespaceIndexOf(types.LabelTypeSkill.Prefix()), Key: "/skills/AI/CID1/Peer1"}
+
+	resumed, err := queryNamespacesFrom(ctx, dstore, "", cursor)
+	require.NoError(t, err)
+
+	resumedKeys := make([]string, len(resumed))
+	for i, entry := range resumed {
+		resumedKeys[i] = entry.Key
+	}
+
+	assert.Equal(t, []string{
+		"/skills/AI/CID2/Peer1",
+		"/domains/research/CID1/Peer1",
+		"/locators/docker-image/CID3/Peer2",
+	}, resumedKeys)
+}
+
+func TestFirstLabelKey(t *testing.T) {
+	labels := []types.Label{"/domains/research", "/skills/ML", "/skills/AI"}
+
+	assert.Equal(t, "/skills/AI/CID1/Peer1", firstLabelKey(labels, "CID1", "Peer1"))
+	assert.Empty(t, firstLabelKey(nil, "CID1", "Peer1"))
+}
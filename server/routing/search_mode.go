@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+)
+
+// labelResolver returns the cached labels of a record announced by a peer.
+type labelResolver func(ctx context.Context, cid, peerID string) []types.Label
+
+// labelIndex is an in-memory index of the label cache by record and peer,
+// built from a single scan of all label namespaces.
+type labelIndex map[string][]types.Label
+
+// buildLabelIndex scans all label namespaces once and indexes the labels by record and peer.
+// Labels of tenant records are indexed with their tenant's namespace.
+func buildLabelIndex(ctx context.Context, dstore types.Datastore) (labelIndex, error) {
+	entries, err := QueryAllNamespaces(ctx, dstore)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(labelIndex)
+
+	for _, entry := range entries {
+		label, keyCID, keyPeerID, err := ParseEnhancedLabelKey(entry.Key)
+		if err != nil {
+			continue
+		}
+
+		recordKey := keyCID + "/" + keyPeerID
+		index[recordKey] = append(index[recordKey], label)
+	}
+
+	return index, nil
+}
+
+// labels returns the indexed labels of a record announced by a peer.
+// It satisfies labelResolver.
+func (idx labelIndex) labels(_ context.Context, cid, peerID string) []types.Label {
+	return idx[cid+"/"+peerID]
+}
+
+// searchQueriesPeers reports whether a search fans out to connected peers.
+// Fast searches never do, thorough searches always do and the default search
+// does if live is set. Peers are only queried for the first page.
+func searchQueriesPeers(req *routingv1.SearchRequest) bool {
+	if req.GetPageToken() != "" {
+		return false
+	}
+
+	switch req.GetSearchMode() {
+	case routingv1.SearchMode_SEARCH_MODE_FAST:
+		return false
+	case routingv1.SearchMode_SEARCH_MODE_THOROUGH:
+		return true
+	case routingv1.SearchMode_SEARCH_MODE_UNSPECIFIED:
+		return req.GetLive()
+	default:
+		return req.GetLive()
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLabelIndex(t *testing.T) {
+	ctx := t.Context()
+
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	for _, l := range []struct{ label, cid, peerID string }{
+		{"/skills/AI", "cid-1", "peer-1"},
+		{"/domains/research", "cid-1", "peer-1"},
+		{"/skills/AI", "cid-1", "peer-2"},
+	} {
+		key := BuildEnhancedLabelKey(types.Label(l.label), l.cid, l.peerID)
+		require.NoError(t, dstore.Put(ctx, ipfsdatastore.NewKey(key), []byte("metadata")))
+	}
+
+	index, err := buildLabelIndex(ctx, dstore)
+	require.NoError(t, err)
+
+	// The index resolves the same labels as the datastore
+	r := &routeRemote{dstore: dstore}
+	for _, peerID := range []string{"peer-1", "peer-2", "peer-3"} {
+		assert.ElementsMatch(t, r.getRemoteRecordLabels(ctx, "cid-1", peerID), index.labels(ctx, "cid-1", peerID), "peer %s", peerID)
+	}
+
+	assert.Len(t, index.labels(ctx, "cid-1", "peer-1"), 2)
+}
+
+func TestSearchQueriesPeers(t *testing.T) {
+	token := "token"
+
+	tests := []struct {
+		name string
+		req  *routingv1.SearchRequest
+		want bool
+	}{
+		{"default", &routingv1.SearchRequest{}, false},
+		{"default live", &routingv1.SearchRequest{Live: true}, true},
+		{"fast live", &routingv1.SearchRequest{Live: true, SearchMode: routingv1.SearchMode_SEARCH_MODE_FAST}, false},
+		{"thorough", &routingv1.SearchRequest{SearchMode: routingv1.SearchMode_SEARCH_MODE_THOROUGH}, true},
+		{"thorough next page", &routingv1.SearchRequest{SearchMode: routingv1.SearchMode_SEARCH_MODE_THOROUGH, PageToken: &token}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, searchQueriesPeers(tt.req))
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/revocation"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// mqttDisconnectQuiesce is how long in milliseconds Close waits for in-flight messages.
+const mqttDisconnectQuiesce = 1000
+
+// MQTTOptions configures the MQTT sink.
+type MQTTOptions struct {
+	// BrokerURL is the URL of the broker, e.g. "tcp://mosquitto:1883" or "ssl://broker:8883".
+	BrokerURL string
+
+	// TopicPrefix of the topics messages are published to.
+	TopicPrefix string
+
+	// ClientID identifies the connection to the broker, unique per peer.
+	ClientID string
+
+	// QoS of the published messages (0, 1 or 2).
+	QoS byte
+
+	// Username and Password authenticate the connection, if set.
+	Username string
+	Password string
+}
+
+// mqttSink republishes to MQTT topics <prefix>/<kind>/<peer ID>,
+// e.g. "dir/announcements/announced/12D3KooW...". Subscribers select the
+// messages of all peers with the "dir/announcements/announced/+" wildcard.
+type mqttSink struct {
+	client mqtt.Client
+	prefix string
+	qos    byte
+}
+
+// NewMQTTSink creates a sink connected to the MQTT broker at the configured URL.
+// The connection is established and re-established in the background; messages
+// published while disconnected are delivered once connected.
+func NewMQTTSink(opts MQTTOptions) (Sink, error) {
+	if opts.BrokerURL == "" {
+		return nil, errors.New("no mqtt broker url configured")
+	}
+
+	if opts.TopicPrefix == "" {
+		return nil, errors.New("no mqtt topic prefix configured")
+	}
+
+	if opts.QoS > 2 { //nolint:mnd
+		return nil, fmt.Errorf("invalid mqtt qos %d", opts.QoS)
+	}
+
+	clientOpts := mqtt.NewClientOptions().
+		AddBroker(opts.BrokerURL).
+		SetClientID(opts.ClientID).
+		SetUsername(opts.Username).
+		SetPassword(opts.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true)
+
+	client := mqtt.NewClient(clientOpts)
+
+	// Connect retries in the background until the broker is reachable
+	client.Connect()
+
+	return &mqttSink{client: client, prefix: opts.TopicPrefix, qos: opts.QoS}, nil
+}
+
+func (s *mqttSink) Name() string {
+	return "mqtt"
+}
+
+func (s *mqttSink) Announce(ctx context.Context, peerID string, announcement *routingv1.LabelAnnouncement) error {
+	data, err := MarshalAnnouncement(announcement)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(ctx, KindAnnounced, peerID, data)
+}
+
+func (s *mqttSink) Retract(ctx context.Context, peerID string, rev *revocation.Revocation) error {
+	data, err := rev.Marshal()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return s.publish(ctx, KindRetracted, peerID, data)
+}
+
+// publish publishes a message, awaiting its acknowledgement by the broker for QoS 1 and 2.
+func (s *mqttSink) publish(ctx context.Context, kind Kind, peerID string, data []byte) error {
+	token := s.client.Publish(Topic(s.prefix, "/", kind, peerID), s.qos, false, data)
+
+	select {
+	case <-token.Done():
+		if err := token.Error(); err != nil {
+			return fmt.Errorf("failed to publish %s message: %w", kind, err)
+		}
+
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish %s message: %w", kind, ctx.Err())
+	}
+}
+
+func (s *mqttSink) Close() error {
+	s.client.Disconnect(mqttDisconnectQuiesce)
+
+	return nil
+}
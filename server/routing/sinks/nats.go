@@ -0,0 +1,88 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/nats-io/nats.go"
+)
+
+// natsSink republishes to NATS subjects <prefix>.<kind>.<peer ID>,
+// e.g. "dir.announcements.announced.12D3KooW...". Subscribers select the
+// messages of all peers with the "dir.announcements.announced.*" wildcard.
+type natsSink struct {
+	conn   *nats.Conn
+	prefix string
+}
+
+// NewNATSSink creates a sink connected to the NATS server at url.
+// The connection is re-established in the background if it is lost.
+func NewNATSSink(url, subjectPrefix string) (Sink, error) {
+	if url == "" {
+		return nil, errors.New("no nats url configured")
+	}
+
+	if subjectPrefix == "" {
+		return nil, errors.New("no nats subject prefix configured")
+	}
+
+	conn, err := nats.Connect(url,
+		nats.Name("dir-routing-announcements"),
+		nats.MaxReconnects(-1),
+		nats.RetryOnFailedConnect(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	return &natsSink{conn: conn, prefix: subjectPrefix}, nil
+}
+
+func (s *natsSink) Name() string {
+	return "nats"
+}
+
+func (s *natsSink) Announce(_ context.Context, peerID string, announcement *routingv1.LabelAnnouncement) error {
+	data, err := MarshalAnnouncement(announcement)
+	if err != nil {
+		return err
+	}
+
+	return s.publish(KindAnnounced, peerID, announcement.GetCid(), data)
+}
+
+func (s *natsSink) Retract(_ context.Context, peerID string, rev *revocation.Revocation) error {
+	data, err := rev.Marshal()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return s.publish(KindRetracted, peerID, rev.CID, data)
+}
+
+func (s *natsSink) publish(kind Kind, peerID, cid string, data []byte) error {
+	msg := nats.NewMsg(Topic(s.prefix, ".", kind, peerID))
+	msg.Data = data
+	msg.Header.Set("Dir-Cid", cid)
+	msg.Header.Set("Dir-Peer-Id", peerID)
+
+	if err := s.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish %s message: %w", kind, err)
+	}
+
+	return nil
+}
+
+func (s *natsSink) Close() error {
+	if err := s.conn.Drain(); err != nil {
+		return fmt.Errorf("failed to drain nats connection: %w", err)
+	}
+
+	return nil
+}
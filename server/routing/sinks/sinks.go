@@ -0,0 +1,235 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sinks mirrors record announcements off the P2P network to external
+// message buses, so that enterprise systems can consume them without joining GossipSub.
+//
+// Announcement sinks republish the LabelAnnouncements published by this peer and those
+// received from other peers via GossipSub, and the signed revocations retracting them.
+// Unlike routing events, messages carry the original signed payloads, so that consumers
+// can verify them with the announcing peer's public key.
+//
+// Announcements are republished as JSON (protojson with proto field names) to
+// "<prefix>.announced.<peer ID>" and revocations in their signed JSON wire format to
+// "<prefix>.retracted.<peer ID>", with "/" separating the levels of MQTT topics.
+// A record announced on several label namespace topics is republished once per namespace;
+// consumers merge the labels.
+//
+// Mirroring never blocks routing: when the queue is full, messages are dropped and
+// counted by the dir_routing_announcement_sink_published_total metric.
+package sinks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/agntcy/dir/utils/logging"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var logger = logging.Logger("routing/sinks")
+
+const (
+	// QueueSize is the buffer size of the queue of messages to republish.
+	QueueSize = 1000
+
+	// PublishTimeout bounds the delivery of a single message to a sink.
+	PublishTimeout = 10 * time.Second
+)
+
+// Kind is the kind of a republished message.
+type Kind string
+
+const (
+	// KindAnnounced is the kind of republished announcements.
+	KindAnnounced Kind = "announced"
+
+	// KindRetracted is the kind of republished revocations.
+	KindRetracted Kind = "retracted"
+)
+
+// Topic returns the subject or topic messages of a kind announced by a peer are published to,
+// with levels joined by the separator of the message bus, e.g. "dir.announcements.announced.12D3KooW...".
+func Topic(prefix, separator string, kind Kind, peerID string) string {
+	return strings.Join([]string{prefix, string(kind), peerID}, separator)
+}
+
+// MarshalAnnouncement serializes an announcement to the JSON it is republished as.
+func MarshalAnnouncement(announcement *routingv1.LabelAnnouncement) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(announcement)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal announcement: %w", err)
+	}
+
+	return data, nil
+}
+
+// Sink republishes announcements and retractions to a message bus.
+// Its methods are called sequentially.
+type Sink interface {
+	// Name identifies the sink in logs and metrics.
+	Name() string
+
+	// Announce republishes an announcement of a record by a peer.
+	Announce(ctx context.Context, peerID string, announcement *routingv1.LabelAnnouncement) error
+
+	// Retract republishes the revocation of the announcements of a record by a peer.
+	Retract(ctx context.Context, peerID string, rev *revocation.Revocation) error
+
+	// Close flushes pending messages and releases the connection.
+	Close() error
+}
+
+// message is a queued announcement or retraction.
+type message struct {
+	kind         Kind
+	peerID       string
+	announcement *routingv1.LabelAnnouncement
+	revocation   *revocation.Revocation
+}
+
+// Bridge republishes announcements and retractions to sinks in the background, in the
+// order they were queued. A nil Bridge discards all messages, so callers need not check
+// whether mirroring is enabled. It is safe for concurrent use.
+type Bridge struct {
+	sinks []Sink
+	queue chan message
+	done  chan struct{}
+
+	mu     sync.RWMutex // Guards closed against concurrent queueing and Close
+	closed bool
+}
+
+// NewBridge starts a bridge republishing to the given sinks.
+// Returns nil if there are no sinks.
+func NewBridge(sinks ...Sink) *Bridge {
+	if len(sinks) == 0 {
+		return nil
+	}
+
+	b := &Bridge{
+		sinks: sinks,
+		queue: make(chan message, QueueSize),
+		done:  make(chan struct{}),
+	}
+
+	go b.run()
+
+	return b
+}
+
+// Announce queues an announcement of a record by a peer for republishing.
+// It never blocks: the announcement is dropped if the queue is full or the bridge is closed.
+func (b *Bridge) Announce(peerID string, announcement *routingv1.LabelAnnouncement) {
+	if announcement == nil {
+		return
+	}
+
+	b.enqueue(message{kind: KindAnnounced, peerID: peerID, announcement: announcement})
+}
+
+// Retract queues the revocation of a record by a peer for republishing.
+// It never blocks: the revocation is dropped if the queue is full or the bridge is closed.
+func (b *Bridge) Retract(peerID string, rev *revocation.Revocation) {
+	if rev == nil {
+		return
+	}
+
+	b.enqueue(message{kind: KindRetracted, peerID: peerID, revocation: rev})
+}
+
+func (b *Bridge) enqueue(msg message) {
+	if b == nil {
+		return
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+
+	select {
+	case b.queue <- msg:
+	default:
+		for _, sink := range b.sinks {
+			metrics.AnnouncementSinkPublished.WithLabelValues(sink.Name(), string(msg.kind), metrics.ResultDropped).Inc()
+		}
+
+		logger.Warn("Announcement sink queue full, dropping message", "kind", msg.kind, "peer", msg.peerID)
+	}
+}
+
+// Close republishes the queued messages and closes the sinks.
+func (b *Bridge) Close() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+
+		return nil
+	}
+
+	b.closed = true
+
+	close(b.queue)
+	b.mu.Unlock()
+
+	<-b.done
+
+	var errs []error
+
+	for _, sink := range b.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s announcement sink: %w", sink.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// run republishes the queued messages in order.
+func (b *Bridge) run() {
+	defer close(b.done)
+
+	for msg := range b.queue {
+		for _, sink := range b.sinks {
+			b.publish(sink, msg)
+		}
+	}
+}
+
+func (b *Bridge) publish(sink Sink, msg message) {
+	ctx, cancel := context.WithTimeout(context.Background(), PublishTimeout)
+	defer cancel()
+
+	var err error
+
+	switch msg.kind {
+	case KindAnnounced:
+		err = sink.Announce(ctx, msg.peerID, msg.announcement)
+	case KindRetracted:
+		err = sink.Retract(ctx, msg.peerID, msg.revocation)
+	}
+
+	metrics.AnnouncementSinkPublished.WithLabelValues(sink.Name(), string(msg.kind), metrics.Result(err)).Inc()
+
+	if err != nil {
+		logger.Warn("Failed to republish to announcement sink",
+			"sink", sink.Name(),
+			"kind", msg.kind,
+			"peer", msg.peerID,
+			"error", err)
+	}
+}
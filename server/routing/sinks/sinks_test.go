@@ -0,0 +1,126 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package sinks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/revocation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type recordingSink struct {
+	mu       sync.Mutex
+	messages []string // "<kind> <peer ID> <cid>"
+	closed   bool
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Announce(_ context.Context, peerID string, announcement *routingv1.LabelAnnouncement) error {
+	return s.record(KindAnnounced, peerID, announcement.GetCid())
+}
+
+func (s *recordingSink) Retract(_ context.Context, peerID string, rev *revocation.Revocation) error {
+	return s.record(KindRetracted, peerID, rev.CID)
+}
+
+func (s *recordingSink) record(kind Kind, peerID, cid string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, fmt.Sprintf("%s %s %s", kind, peerID, cid))
+
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+
+	return nil
+}
+
+func TestBridge_NilIsNoop(t *testing.T) {
+	bridge := NewBridge()
+	assert.Nil(t, bridge)
+
+	bridge.Announce("peer1", &routingv1.LabelAnnouncement{Cid: "cid1"})
+	bridge.Retract("peer1", revocation.New("cid1", "unpublished"))
+	assert.NoError(t, bridge.Close())
+}
+
+func TestBridge_PreservesOrder(t *testing.T) {
+	sink := &recordingSink{}
+	bridge := NewBridge(sink)
+
+	var want []string
+
+	for i := range 100 {
+		cid := fmt.Sprintf("cid%d", i%3)
+
+		if i%2 == 0 {
+			bridge.Announce("peer1", &routingv1.LabelAnnouncement{Cid: cid})
+			want = append(want, "announced peer1 "+cid)
+		} else {
+			bridge.Retract("peer2", revocation.New(cid, "unpublished"))
+			want = append(want, "retracted peer2 "+cid)
+		}
+	}
+
+	require.NoError(t, bridge.Close())
+	assert.True(t, sink.closed)
+	assert.Equal(t, want, sink.messages)
+
+	// Messages queued after closing are dropped
+	bridge.Announce("peer1", &routingv1.LabelAnnouncement{Cid: "cid1"})
+	assert.Len(t, sink.messages, len(want))
+}
+
+func TestTopic(t *testing.T) {
+	assert.Equal(t, "dir.announcements.announced.peer1", Topic("dir.announcements", ".", KindAnnounced, "peer1"))
+	assert.Equal(t, "dir/announcements/retracted/peer1", Topic("dir/announcements", "/", KindRetracted, "peer1"))
+}
+
+func TestMarshalAnnouncement(t *testing.T) {
+	data, err := MarshalAnnouncement(&routingv1.LabelAnnouncement{
+		Cid:       "cid1",
+		Labels:    []string{"/skills/AI/ML"},
+		Timestamp: timestamppb.New(time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)),
+		Signature: []byte("signature"),
+	})
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+
+	assert.Equal(t, "cid1", fields["cid"])
+	assert.Equal(t, []any{"/skills/AI/ML"}, fields["labels"])
+	assert.Equal(t, "2025-10-01T10:00:00Z", fields["timestamp"])
+	assert.Equal(t, "c2lnbmF0dXJl", fields["signature"])
+	assert.NotContains(t, fields, "expires_at")
+}
+
+func TestNewMQTTSink_Validation(t *testing.T) {
+	_, err := NewMQTTSink(MQTTOptions{TopicPrefix: "dir/announcements"})
+	require.Error(t, err)
+
+	_, err = NewMQTTSink(MQTTOptions{BrokerURL: "tcp://localhost:1883"})
+	require.Error(t, err)
+
+	_, err = NewMQTTSink(MQTTOptions{BrokerURL: "tcp://localhost:1883", TopicPrefix: "dir/announcements", QoS: 3})
+	require.Error(t, err)
+}
+
+func TestNewNATSSink_RequiresSubjectPrefix(t *testing.T) {
+	_, err := NewNATSSink("nats://localhost:4222", "")
+	assert.Error(t, err)
+}
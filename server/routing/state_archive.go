@@ -0,0 +1,234 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/agntcy/dir/server/routing/statearchive"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ExportState writes the label cache, and optionally the records of the local store
+// it references, as a state archive. Labels of tenant records are not exported, like
+// in label cache snapshots, nor is the content of access-gated records.
+func (r *routeRemote) ExportState(ctx context.Context, req *routingv1.ExportStateRequest, w io.Writer) error {
+	entries, err := QueryAllNamespaces(ctx, r.dstore)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to query label cache: %v", err)
+	}
+
+	entries = sharedEntries(entries)
+
+	archive, err := statearchive.NewWriter(w, statearchive.Manifest{
+		PeerID:     r.server.Host().ID().String(),
+		ExportedAt: time.Now().UTC(),
+		Records:    req.GetIncludeRecords(),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to start state archive: %v", err)
+	}
+
+	// Records are exported in the order of their first label, gated records not at all
+	var cids []string
+
+	seen := make(map[string]bool)
+	gated := make(map[string]bool)
+
+	for start := 0; start < len(entries); start += StateArchivePageSize {
+		page := &statearchive.LabelPage{PeerAddrs: make(map[string][]string)}
+
+		for _, entry := range entries[start:min(start+StateArchivePageSize, len(entries))] {
+			_, cid, peerID, err := ParseEnhancedLabelKey(entry.Key)
+			if err != nil {
+				continue
+			}
+
+			page.Entries = append(page.Entries, statearchive.LabelEntry{Key: entry.Key, Value: entry.Value})
+
+			if _, ok := page.PeerAddrs[peerID]; !ok {
+				if addrs := r.peerMultiaddrs(ctx, peerID); len(addrs) > 0 {
+					page.PeerAddrs[peerID] = addrs
+				}
+			}
+
+			if !seen[cid] {
+				seen[cid] = true
+				cids = append(cids, cid)
+			}
+
+			var metadata types.LabelMetadata
+			if json.Unmarshal(entry.Value, &metadata) == nil && metadata.AccessGated {
+				gated[cid] = true
+			}
+		}
+
+		if err := archive.WriteLabels(page); err != nil {
+			return status.Errorf(codes.Internal, "failed to export labels: %v", err)
+		}
+	}
+
+	exported := 0
+
+	if req.GetIncludeRecords() {
+		for _, cid := range cids {
+			if gated[cid] {
+				continue
+			}
+
+			ok, err := r.exportRecord(ctx, archive, cid)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				exported++
+			}
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to export state: %v", err)
+	}
+
+	remoteLogger.Info("Exported state archive", "labels", len(entries), "records", exported)
+
+	return nil
+}
+
+// exportRecord writes the content of a record to the archive if the local store holds it.
+func (r *routeRemote) exportRecord(ctx context.Context, archive *statearchive.Writer, cid string) (bool, error) {
+	ref := &corev1.RecordRef{Cid: cid}
+
+	if _, err := r.storeAPI.Lookup(ctx, ref); err != nil {
+		return false, nil //nolint:nilerr // Records not held by the local store are not exported
+	}
+
+	record, err := r.storeAPI.Pull(ctx, ref)
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to pull record %s: %v", cid, err)
+	}
+
+	data, err := record.Marshal()
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "failed to marshal record %s: %v", cid, err)
+	}
+
+	if err := archive.WriteRecord(cid, data); err != nil {
+		return false, status.Errorf(codes.Internal, "failed to export record %s: %v", cid, err)
+	}
+
+	return true, nil
+}
+
+// ImportState imports a state archive exported by another peer. Label pages are imported
+// like label cache snapshots from a seed peer, and records are stored in the local store
+// once their content is verified against their CIDs.
+func (r *routeRemote) ImportState(ctx context.Context, rd io.Reader) (*routingv1.ImportStateResponse, error) {
+	archive, err := statearchive.NewReader(rd)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	manifest := archive.Manifest()
+	resp := &routingv1.ImportStateResponse{
+		ExportedBy: manifest.PeerID,
+		ExportedAt: timestamppb.New(manifest.ExportedAt),
+	}
+
+	localPeerID := r.server.Host().ID().String()
+
+	for {
+		entry, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return resp, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+
+		if entry.Labels != nil {
+			imported := r.importSnapshot(ctx, labelPageSnapshot(entry.Labels), localPeerID)
+			resp.LabelsImported += uint32(imported)                            //nolint:gosec // Bounded by the page size
+			resp.LabelsSkipped += uint32(len(entry.Labels.Entries) - imported) //nolint:gosec // Bounded by the page size
+
+			continue
+		}
+
+		imported, err := r.importRecord(ctx, entry.RecordCID, entry.Record)
+		if err != nil {
+			return resp, err
+		}
+
+		if imported {
+			resp.RecordsImported++
+		} else {
+			resp.RecordsSkipped++
+		}
+	}
+
+	remoteLogger.Info("Imported state archive",
+		"exportedBy", resp.GetExportedBy(),
+		"exportedAt", manifest.ExportedAt,
+		"labelsImported", resp.GetLabelsImported(),
+		"labelsSkipped", resp.GetLabelsSkipped(),
+		"recordsImported", resp.GetRecordsImported(),
+		"recordsSkipped", resp.GetRecordsSkipped())
+
+	return resp, nil
+}
+
+// importRecord stores a record of an archive in the local store, unless it is already stored.
+func (r *routeRemote) importRecord(ctx context.Context, cid string, data []byte) (bool, error) {
+	// Archives travel through untrusted hands, their content is verified before it is stored
+	if err := rpc.VerifyContent(cid, data); err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "invalid record %s: %v", cid, err)
+	}
+
+	if _, err := r.storeAPI.Lookup(ctx, &corev1.RecordRef{Cid: cid}); err == nil {
+		return false, nil
+	}
+
+	record, err := corev1.UnmarshalRecord(data)
+	if err != nil {
+		return false, status.Errorf(codes.InvalidArgument, "failed to unmarshal record %s: %v", cid, err)
+	}
+
+	if _, err := r.storeAPI.Push(ctx, record); err != nil {
+		return false, status.Errorf(codes.Internal, "failed to store record %s: %v", cid, err)
+	}
+
+	return true, nil
+}
+
+// labelPageSnapshot converts a label page of an archive to a label cache snapshot page.
+func labelPageSnapshot(page *statearchive.LabelPage) *rpc.SnapshotResponse {
+	snapshot := &rpc.SnapshotResponse{
+		Entries:   make([]rpc.SnapshotEntry, 0, len(page.Entries)),
+		PeerAddrs: make(map[string][]byte, len(page.PeerAddrs)),
+	}
+
+	for _, entry := range page.Entries {
+		snapshot.Entries = append(snapshot.Entries, rpc.SnapshotEntry{Key: entry.Key, Value: entry.Value})
+	}
+
+	for peerID, addrs := range page.PeerAddrs {
+		if data, err := json.Marshal(addrs); err == nil {
+			snapshot.PeerAddrs[peerID] = data
+		}
+	}
+
+	return snapshot
+}
@@ -0,0 +1,45 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/routing/statearchive"
+	"github.com/agntcy/dir/server/types"
+	ipfsdatastore "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportLabelPage(t *testing.T) {
+	dstore, cleanup := setupTestDatastore(t)
+	defer cleanup()
+
+	r := &routeRemote{dstore: dstore, addressBook: addressbook.New(dstore, PeerAddressTTL)}
+
+	fresh, err := json.Marshal(&types.LabelMetadata{Timestamp: time.Now(), LastSeen: time.Now()})
+	require.NoError(t, err)
+
+	page := &statearchive.LabelPage{
+		Entries: []statearchive.LabelEntry{
+			{Key: "/skills/AI/cid1/peer1", Value: fresh},
+			{Key: "/skills/AI/cid2/local", Value: fresh}, // local peer
+		},
+		PeerAddrs: map[string][]string{"peer1": {"/ip4/10.0.0.1/tcp/8999"}},
+	}
+
+	assert.Equal(t, 1, r.importSnapshot(t.Context(), labelPageSnapshot(page), "local"))
+
+	exists, err := dstore.Has(t.Context(), ipfsdatastore.NewKey("/skills/AI/cid1/peer1"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = dstore.Has(t.Context(), ipfsdatastore.NewKey("peer_addrs/peer1"))
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
@@ -0,0 +1,289 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package statearchive implements portable archives of directory state, used to
+// exchange the label cache and records between air-gapped peers.
+//
+// An archive is a tar file holding, in order:
+//
+//	manifest.json        format version, exporting peer and export time
+//	labels/000001.json   pages of label cache entries and the addresses of their peers
+//	records/<cid>        canonical content of records, if included
+//
+// Label pages are encoded like label cache snapshots served to peers warming their
+// cache, so that importing an archive works like warming the cache from a seed peer.
+// Unknown files are skipped, so that later versions can add content.
+package statearchive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// Version is the format version of archives written by this package.
+	Version = 1
+
+	// ManifestFile is the name of the manifest, the first file of an archive.
+	ManifestFile = "manifest.json"
+
+	// LabelsDir holds the label pages of an archive.
+	LabelsDir = "labels/"
+
+	// RecordsDir holds the record contents of an archive, named by CID.
+	RecordsDir = "records/"
+
+	// MaxManifestSize is the maximum size of a manifest.
+	MaxManifestSize = 64 * 1024 // 64KB
+
+	// MaxPageSize is the maximum size of a label page.
+	MaxPageSize = 64 * 1024 * 1024 // 64MB
+
+	// MaxRecordSize is the maximum size of a record's content. It matches the maximum size of RPC pulls.
+	MaxRecordSize = 4 * 1024 * 1024 // 4MB
+)
+
+// ErrInvalidArchive is returned when reading a malformed archive.
+var ErrInvalidArchive = errors.New("invalid state archive")
+
+// Manifest describes an archive.
+//
+// Example:
+//
+//	{
+//	  "version": 1,
+//	  "peer_id": "12D3KooW...",
+//	  "exported_at": "2025-10-01T10:00:00Z",
+//	  "records": true
+//	}
+type Manifest struct {
+	// Version is the format version of the archive.
+	Version int `json:"version"`
+
+	// PeerID is the ID of the peer that exported the archive.
+	PeerID string `json:"peer_id"`
+
+	// ExportedAt is when the archive was exported.
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Records is set if the archive includes record contents.
+	Records bool `json:"records,omitempty"`
+}
+
+// LabelPage is a page of label cache entries.
+type LabelPage struct {
+	// Entries are enhanced label keys and their metadata.
+	Entries []LabelEntry `json:"entries"`
+
+	// PeerAddrs holds the multiaddrs of the peers referenced by Entries.
+	PeerAddrs map[string][]string `json:"peer_addrs,omitempty"`
+}
+
+// LabelEntry is a single label cache entry.
+type LabelEntry struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Writer writes an archive.
+type Writer struct {
+	tw    *tar.Writer
+	pages int
+	now   time.Time
+}
+
+// NewWriter starts an archive with its manifest, stamping its files with the export time.
+func NewWriter(w io.Writer, manifest Manifest) (*Writer, error) {
+	manifest.Version = Version
+
+	writer := &Writer{tw: tar.NewWriter(w), now: manifest.ExportedAt}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	if err := writer.writeFile(ManifestFile, data); err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// WriteLabels adds a page of label cache entries.
+func (w *Writer) WriteLabels(page *LabelPage) error {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return fmt.Errorf("failed to marshal label page: %w", err)
+	}
+
+	if len(data) > MaxPageSize {
+		return fmt.Errorf("label page exceeds %d bytes", MaxPageSize)
+	}
+
+	w.pages++
+
+	return w.writeFile(fmt.Sprintf("%s%06d.json", LabelsDir, w.pages), data)
+}
+
+// WriteRecord adds the canonical content of a record.
+func (w *Writer) WriteRecord(cid string, data []byte) error {
+	if !validName(cid) {
+		return fmt.Errorf("invalid record CID %q", cid)
+	}
+
+	if len(data) > MaxRecordSize {
+		return fmt.Errorf("record %s exceeds %d bytes", cid, MaxRecordSize)
+	}
+
+	return w.writeFile(RecordsDir+cid, data)
+}
+
+// Close completes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return fmt.Errorf("failed to complete archive: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Writer) writeFile(name string, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644, //nolint:mnd
+		Size:     int64(len(data)),
+		ModTime:  w.now,
+	}
+
+	if err := w.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	if _, err := w.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Entry is a label page or a record read from an archive.
+type Entry struct {
+	// Labels is set for label pages.
+	Labels *LabelPage
+
+	// RecordCID and Record are set for records.
+	RecordCID string
+	Record    []byte
+}
+
+// Reader reads an archive.
+type Reader struct {
+	tr       *tar.Reader
+	manifest Manifest
+}
+
+// NewReader starts reading an archive, reading and checking its manifest.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{tr: tar.NewReader(r)}
+
+	header, err := reader.tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read manifest: %w", ErrInvalidArchive, err)
+	}
+
+	if header.Name != ManifestFile {
+		return nil, fmt.Errorf("%w: first file is %q, not the manifest", ErrInvalidArchive, header.Name)
+	}
+
+	data, err := reader.readFile(header, MaxManifestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &reader.manifest); err != nil {
+		return nil, fmt.Errorf("%w: failed to unmarshal manifest: %w", ErrInvalidArchive, err)
+	}
+
+	if reader.manifest.Version < 1 || reader.manifest.Version > Version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, reader.manifest.Version)
+	}
+
+	return reader, nil
+}
+
+// Manifest returns the manifest of the archive.
+func (r *Reader) Manifest() Manifest {
+	return r.manifest
+}
+
+// Next returns the next label page or record of the archive, or io.EOF at its end.
+func (r *Reader) Next() (*Entry, error) {
+	for {
+		header, err := r.tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(header.Name, LabelsDir):
+			data, err := r.readFile(header, MaxPageSize)
+			if err != nil {
+				return nil, err
+			}
+
+			var page LabelPage
+			if err := json.Unmarshal(data, &page); err != nil {
+				return nil, fmt.Errorf("%w: failed to unmarshal %s: %w", ErrInvalidArchive, header.Name, err)
+			}
+
+			return &Entry{Labels: &page}, nil
+		case strings.HasPrefix(header.Name, RecordsDir):
+			cid := path.Base(header.Name)
+			if !validName(cid) || header.Name != RecordsDir+cid {
+				return nil, fmt.Errorf("%w: invalid record file %q", ErrInvalidArchive, header.Name)
+			}
+
+			data, err := r.readFile(header, MaxRecordSize)
+			if err != nil {
+				return nil, err
+			}
+
+			return &Entry{RecordCID: cid, Record: data}, nil
+		}
+	}
+}
+
+// readFile reads the content of the current file, which must not exceed maxSize.
+func (r *Reader) readFile(header *tar.Header, maxSize int64) ([]byte, error) {
+	if header.Size > maxSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrInvalidArchive, header.Name, maxSize)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.tr, maxSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read %s: %w", ErrInvalidArchive, header.Name, err)
+	}
+
+	return data, nil
+}
+
+// validName reports whether a CID can be used as a file name.
+func validName(cid string) bool {
+	return cid != "" && cid != "." && cid != ".." && !strings.ContainsAny(cid, "/\\")
+}
@@ -0,0 +1,115 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package statearchive
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive_RoundTrip(t *testing.T) {
+	exportedAt := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+	page := &LabelPage{
+		Entries:   []LabelEntry{{Key: "/skills/AI/cid1/peer1", Value: json.RawMessage(`{"timestamp":"2025-10-01T09:00:00Z"}`)}},
+		PeerAddrs: map[string][]string{"peer1": {"/ip4/10.0.0.1/tcp/4001"}},
+	}
+
+	var buf bytes.Buffer
+
+	writer, err := NewWriter(&buf, Manifest{PeerID: "peer1", ExportedAt: exportedAt, Records: true})
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteLabels(page))
+	require.NoError(t, writer.WriteRecord("cid1", []byte("record")))
+	require.NoError(t, writer.Close())
+
+	reader, err := NewReader(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, Manifest{Version: Version, PeerID: "peer1", ExportedAt: exportedAt, Records: true}, reader.Manifest())
+
+	entry, err := reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, page, entry.Labels)
+
+	entry, err = reader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "cid1", entry.RecordCID)
+	assert.Equal(t, []byte("record"), entry.Record)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestWriter_RejectsInvalidRecords(t *testing.T) {
+	writer, err := NewWriter(io.Discard, Manifest{PeerID: "peer1"})
+	require.NoError(t, err)
+
+	assert.Error(t, writer.WriteRecord("../cid1", []byte("record")))
+	assert.Error(t, writer.WriteRecord("cid1", make([]byte, MaxRecordSize+1)))
+}
+
+func TestReader_RejectsInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{name: "missing manifest", files: map[string][]byte{"labels/000001.json": []byte(`{}`)}},
+		{name: "invalid manifest", files: map[string][]byte{ManifestFile: []byte(`{`)}},
+		{name: "unsupported version", files: map[string][]byte{ManifestFile: []byte(`{"version":2}`)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(tarFiles(t, tt.files))
+			assert.ErrorIs(t, err, ErrInvalidArchive)
+		})
+	}
+}
+
+func TestReader_SkipsUnknownFilesAndRejectsOversizedRecords(t *testing.T) {
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	writeTarFile(t, tw, ManifestFile, []byte(`{"version":1}`))
+	writeTarFile(t, tw, "extensions/unknown", []byte("ignored"))
+	writeTarFile(t, tw, RecordsDir+"cid1", make([]byte, MaxRecordSize+1))
+	require.NoError(t, tw.Close())
+
+	reader, err := NewReader(&buf)
+	require.NoError(t, err)
+
+	_, err = reader.Next()
+	assert.ErrorIs(t, err, ErrInvalidArchive)
+	assert.ErrorContains(t, err, "exceeds")
+}
+
+func tarFiles(t *testing.T, files map[string][]byte) io.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	tw := tar.NewWriter(&buf)
+	for name, data := range files {
+		writeTarFile(t, tw, name, data)
+	}
+
+	require.NoError(t, tw.Close())
+
+	return &buf
+}
+
+func writeTarFile(t *testing.T, tw *tar.Writer, name string, data []byte) {
+	t.Helper()
+
+	require.NoError(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: int64(len(data))}))
+
+	_, err := tw.Write(data)
+	require.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import "time"
+
+// startStreamPoolReporting starts a background goroutine that periodically
+// logs the warm RPC stream pool stats. A low hit rate during pull bursts
+// indicates that the pool limits are too small for the workload.
+func (r *routeRemote) startStreamPoolReporting() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(StreamPoolReportInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.ctx.Done():
+				remoteLogger.Debug("Stopping stream pool reporting")
+
+				return
+			case <-ticker.C:
+				stats := r.service.StreamPoolStats()
+
+				remoteLogger.Info("RPC stream pool stats",
+					"hits", stats.Hits,
+					"misses", stats.Misses,
+					"hitRate", stats.HitRate(),
+					"opened", stats.Opened,
+					"expired", stats.Expired,
+					"peers", stats.Peers,
+					"idleStreams", stats.IdleStreams)
+			}
+		}
+	}()
+}
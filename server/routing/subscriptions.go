@@ -0,0 +1,224 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"sync"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/internal/didkey"
+	"github.com/agntcy/dir/server/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errSlowSubscriber ends subscriptions whose subscriber does not keep up with the matching records.
+var errSlowSubscriber = status.Error(codes.ResourceExhausted, "subscriber is too slow, matching records were dropped")
+
+// subscription is a registered set of queries pushed the records newly cached that match them.
+type subscription struct {
+	queries       []*routingv1.RecordQuery
+	minMatchScore uint32
+	scope         []string // Tenants whose records are visible to the subscriber
+	publisher     string   // Only records of this publisher, if set
+
+	results  chan *routingv1.SearchResponse
+	overflow chan struct{} // Closed when a result was dropped because the subscriber is slow
+	once     sync.Once
+}
+
+// deliver queues a result without blocking. Subscribers that do not keep up are ended,
+// so that they search for the records they missed instead of silently losing them.
+func (s *subscription) deliver(result *routingv1.SearchResponse) {
+	select {
+	case s.results <- result:
+	default:
+		s.once.Do(func() { close(s.overflow) })
+	}
+}
+
+// subscriptions are the active subscriptions, bounded by the configured maximum.
+// A nil registry disables subscriptions.
+type subscriptions struct {
+	mu  sync.RWMutex
+	set map[*subscription]struct{}
+	max int
+}
+
+// newSubscriptions creates the registry of subscriptions. Returns nil if the maximum is zero.
+func newSubscriptions(maxSubscriptions int) *subscriptions {
+	if maxSubscriptions <= 0 {
+		return nil
+	}
+
+	return &subscriptions{
+		set: make(map[*subscription]struct{}),
+		max: maxSubscriptions,
+	}
+}
+
+// add registers a subscription. Fails with ResourceExhausted when the maximum is reached.
+func (s *subscriptions) add(sub *subscription) error {
+	if s == nil {
+		return status.Error(codes.Unimplemented, "subscriptions are disabled") //nolint:wrapcheck
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.set) >= s.max {
+		return status.Errorf(codes.ResourceExhausted, "subscription limit of %d reached", s.max) //nolint:wrapcheck
+	}
+
+	s.set[sub] = struct{}{}
+
+	return nil
+}
+
+func (s *subscriptions) remove(sub *subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.set, sub)
+}
+
+// snapshot returns the active subscriptions.
+func (s *subscriptions) snapshot() []*subscription {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subs := make([]*subscription, 0, len(s.set))
+	for sub := range s.set {
+		subs = append(subs, sub)
+	}
+
+	return subs
+}
+
+// Subscribe pushes the remote records matching the queries to send as their labels are
+// newly cached, until the context is done. Returns ResourceExhausted if the subscriber
+// does not keep up with the matching records.
+func (r *routeRemote) Subscribe(ctx context.Context, req *routingv1.SubscribeRequest, send func(*routingv1.SearchResponse) error) error {
+	remoteLogger.Debug("Called remote routing's Subscribe method", "req", req)
+
+	if len(req.GetQueries()) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one query is required") //nolint:wrapcheck
+	}
+
+	if !r.tenants.known(req.GetTenantId()) {
+		return status.Errorf(codes.InvalidArgument, "unknown tenant %q", req.GetTenantId()) //nolint:wrapcheck
+	}
+
+	if req.GetPublisher() != "" {
+		if _, err := didkey.Decode(req.GetPublisher()); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid publisher: %v", err) //nolint:wrapcheck
+		}
+	}
+
+	minMatchScore := req.GetMinMatchScore()
+	if minMatchScore < DefaultMinMatchScore {
+		minMatchScore = DefaultMinMatchScore
+	}
+
+	sub := &subscription{
+		queries:       deduplicateQueries(req.GetQueries()),
+		minMatchScore: minMatchScore,
+		scope:         r.tenants.scope(req.GetTenantId()),
+		publisher:     req.GetPublisher(),
+		results:       make(chan *routingv1.SearchResponse, SubscriptionBufferSize),
+		overflow:      make(chan struct{}),
+	}
+
+	if err := r.subscriptions.add(sub); err != nil {
+		return err
+	}
+	defer r.subscriptions.remove(sub)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-r.ctx.Done():
+			return status.Error(codes.Unavailable, "routing is shutting down") //nolint:wrapcheck
+		case <-sub.overflow:
+			return errSlowSubscriber
+		case result := <-sub.results:
+			// Results queued before an overflow are not sent, the subscriber searches for them instead
+			select {
+			case <-sub.overflow:
+				return errSlowSubscriber
+			default:
+			}
+
+			if err := send(result); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// notifySubscribers pushes the records with newly cached labels of the peer to the
+// subscriptions they match. Records are matched against the labels of the mutation
+// and the labels other providers of the record announced.
+func (r *routeRemote) notifySubscribers(ctx context.Context, peerID string, labels *cacheMutation, added []string) {
+	subs := r.subscriptions.snapshot()
+	if len(subs) == 0 || len(added) == 0 || r.reputation.IsExcluded(peerID) {
+		return
+	}
+
+	newCIDs := make(map[string]bool)
+
+	for _, key := range added {
+		if _, cid, _, err := ParseEnhancedLabelKey(key); err == nil {
+			newCIDs[cid] = true
+		}
+	}
+
+	var cids []string
+
+	recordLabels := make(map[string][]types.Label)
+	metadata := make(map[string][]byte)
+
+	for _, p := range labels.Puts {
+		label, cid, keyPeerID, err := ParseEnhancedLabelKey(p.Key)
+		if err != nil || keyPeerID != peerID || !newCIDs[cid] {
+			continue
+		}
+
+		if _, ok := recordLabels[cid]; !ok {
+			cids = append(cids, cid)
+			metadata[cid] = p.Value
+		}
+
+		recordLabels[cid] = append(recordLabels[cid], label)
+	}
+
+	var peer *routingv1.Peer
+
+	for _, cid := range cids {
+		allLabels := r.withProviderSetLabels(cid, recordLabels[cid])
+
+		for _, sub := range subs {
+			if sub.publisher != "" && labelPublisher(metadata[cid]) != sub.publisher {
+				continue
+			}
+
+			matchQueries, score := matchScoreForLabels(sub.queries, labelsOfTenants(allLabels, sub.scope...))
+			if score < sub.minMatchScore {
+				continue
+			}
+
+			if peer == nil {
+				peer = r.createPeerInfo(ctx, peerID)
+			}
+
+			sub.deliver(r.remoteSearchResponse(peer, cid, metadata[cid], matchQueries, score))
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/agntcy/dir/server/routing/cardinality"
+	"github.com/agntcy/dir/server/routing/peerstats"
+	"github.com/agntcy/dir/server/routing/providerset"
+	"github.com/agntcy/dir/server/routing/reputation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func setupTestSubscriptions(t *testing.T, maxSubscriptions int) *routeRemote {
+	t.Helper()
+
+	dstore, cleanup := setupTestDatastore(t)
+	t.Cleanup(cleanup)
+
+	return &routeRemote{
+		dstore:           dstore,
+		peerStats:        peerstats.New(),
+		reputation:       reputation.New(),
+		cardinality:      cardinality.NewIndex(),
+		providerSets:     providerset.NewIndex(),
+		addressBook:      addressbook.New(dstore, PeerAddressTTL),
+		peerCapabilities: newPeerCapabilities(),
+		announcements:    newAnnouncementChecks(),
+		subscriptions:    newSubscriptions(maxSubscriptions),
+		ctx:              t.Context(),
+	}
+}
+
+// subscribe runs a subscription in the background, returning its results and its error once ended.
+func subscribe(t *testing.T, ctx context.Context, r *routeRemote, req *routingv1.SubscribeRequest) (<-chan *routingv1.SearchResponse, <-chan error) {
+	t.Helper()
+
+	results := make(chan *routingv1.SearchResponse, SubscriptionBufferSize)
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- r.Subscribe(ctx, req, func(result *routingv1.SearchResponse) error {
+			results <- result
+
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool { return len(r.subscriptions.snapshot()) > 0 }, time.Second, time.Millisecond)
+
+	return results, errCh
+}
+
+func TestSubscribe_PushesNewlyCachedMatchingRecords(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	r := setupTestSubscriptions(t, 1)
+
+	skill := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}
+	domain := &routingv1.RecordQuery{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, Value: "research"}
+	minMatchScore := uint32(2)
+
+	results, errCh := subscribe(t, ctx, r, &routingv1.SubscribeRequest{
+		Queries:       []*routingv1.RecordQuery{skill, domain},
+		MinMatchScore: &minMatchScore,
+	})
+
+	cacheLabels := func(peerID string, keys ...string) {
+		labels := &cacheMutation{}
+		for _, key := range keys {
+			labels.put(key, []byte(`{}`))
+		}
+
+		require.NoError(t, r.cacheRemoteLabels(ctx, peerID, labels, nil))
+	}
+
+	// Records matching too few queries are not pushed
+	cacheLabels("peer1", "/skills/AI/ML/cid1/peer1")
+
+	// Records matching enough queries are pushed once their labels are cached
+	cacheLabels("peer1", "/skills/AI/cid2/peer1", "/domains/research/cid2/peer1")
+
+	// Labels cached again are not pushed again
+	cacheLabels("peer1", "/skills/AI/cid2/peer1", "/domains/research/cid2/peer1")
+
+	// Labels of other providers of the record count towards the match score
+	cacheLabels("peer2", "/domains/research/cid1/peer2")
+
+	var cids []string
+
+	for range 2 {
+		select {
+		case result := <-results:
+			cids = append(cids, result.GetRecordRef().GetCid())
+			assert.Equal(t, uint32(2), result.GetMatchScore())
+			assert.Len(t, result.GetMatchQueries(), 2)
+		case <-time.After(time.Second):
+			t.Fatal("subscription result not pushed")
+		}
+	}
+
+	assert.Equal(t, []string{"cid2", "cid1"}, cids)
+	assert.Empty(t, results)
+
+	// Ending the subscription unregisters it
+	cancel()
+	require.NoError(t, <-errCh)
+	assert.Empty(t, r.subscriptions.snapshot())
+}
+
+func TestSubscribe_Limits(t *testing.T) {
+	query := []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}}
+	send := func(*routingv1.SearchResponse) error { return nil }
+
+	// Subscriptions are disabled without a maximum
+	err := setupTestSubscriptions(t, 0).Subscribe(t.Context(), &routingv1.SubscribeRequest{Queries: query}, send)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	r := setupTestSubscriptions(t, 1)
+
+	err = r.Subscribe(t.Context(), &routingv1.SubscribeRequest{}, send)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	err = r.Subscribe(t.Context(), &routingv1.SubscribeRequest{Queries: query, TenantId: "unknown"}, send)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Subscriptions beyond the maximum are rejected
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	_, _ = subscribe(t, ctx, r, &routingv1.SubscribeRequest{Queries: query})
+
+	err = r.Subscribe(t.Context(), &routingv1.SubscribeRequest{Queries: query}, send)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestSubscribe_EndsSlowSubscribers(t *testing.T) {
+	r := setupTestSubscriptions(t, 1)
+
+	// The subscriber blocks on its first result while further records are discovered
+	block := make(chan struct{})
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- r.Subscribe(t.Context(), &routingv1.SubscribeRequest{
+			Queries: []*routingv1.RecordQuery{{Type: routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, Value: "AI"}},
+		}, func(*routingv1.SearchResponse) error {
+			<-block
+
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool { return len(r.subscriptions.snapshot()) > 0 }, time.Second, time.Millisecond)
+
+	labels := &cacheMutation{}
+	for i := range SubscriptionBufferSize + 2 {
+		labels.put(BuildEnhancedLabelKey("/skills/AI", "cid"+strconv.Itoa(i), "peer1"), []byte(`{}`))
+	}
+
+	require.NoError(t, r.cacheRemoteLabels(t.Context(), "peer1", labels, nil))
+	close(block)
+
+	select {
+	case err := <-errCh:
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	case <-time.After(time.Second):
+		t.Fatal("slow subscriber not ended")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// taskStatus is the schedule of a periodic background task since this peer started.
+type taskStatus struct {
+	interval     time.Duration
+	nextRun      time.Time // Zero while running
+	runningSince time.Time // Zero while waiting
+	lastDuration time.Duration
+}
+
+// taskStatuses tracks the schedules of the background tasks for routing introspection.
+// It is safe for concurrent use. A nil tracker tracks nothing.
+type taskStatuses struct {
+	mu    sync.Mutex
+	tasks map[string]*taskStatus
+}
+
+func newTaskStatuses() *taskStatuses {
+	return &taskStatuses{tasks: make(map[string]*taskStatus)}
+}
+
+// scheduled records that the next run of a task is scheduled after delay.
+func (s *taskStatuses) scheduled(task string, interval, delay time.Duration, now time.Time) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tasks[task] = &taskStatus{interval: interval, nextRun: now.Add(delay)}
+}
+
+// started records that a run of a task started.
+func (s *taskStatuses) started(task string, now time.Time) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status, ok := s.tasks[task]; ok {
+		status.nextRun = time.Time{}
+		status.runningSince = now
+	}
+}
+
+// finished records that a run of a task finished and the next run is scheduled an interval later.
+func (s *taskStatuses) finished(task string, now time.Time) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status, ok := s.tasks[task]; ok && !status.runningSince.IsZero() {
+		status.lastDuration = now.Sub(status.runningSince)
+		status.runningSince = time.Time{}
+		status.nextRun = now.Add(status.interval)
+	}
+}
+
+// rescheduled records that the next run of a task is scheduled after delay,
+// for tasks whose runs are not an exact interval apart.
+func (s *taskStatuses) rescheduled(task string, delay time.Duration, now time.Time) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status, ok := s.tasks[task]; ok && status.runningSince.IsZero() {
+		status.nextRun = now.Add(delay)
+	}
+}
+
+// toProto returns the task statuses ordered by name, with their last runs from the runtime state.
+func (s *taskStatuses) toProto(state *runtimeState) []*routingv1.TaskStatus {
+	if s == nil {
+		return nil
+	}
+
+	lastRuns := state.toProto().GetLastTaskRuns()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]*routingv1.TaskStatus, 0, len(s.tasks))
+
+	for task, status := range s.tasks {
+		taskStatus := &routingv1.TaskStatus{
+			Name:     task,
+			Interval: durationpb.New(status.interval),
+			LastRun:  lastRuns[task],
+			Running:  !status.runningSince.IsZero(),
+		}
+
+		if status.lastDuration > 0 {
+			taskStatus.LastDuration = durationpb.New(status.lastDuration)
+		}
+
+		if !status.nextRun.IsZero() {
+			taskStatus.NextRun = timestamppb.New(status.nextRun)
+		}
+
+		result = append(result, taskStatus)
+	}
+
+	slices.SortFunc(result, func(a, b *routingv1.TaskStatus) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+
+	return result
+}
@@ -0,0 +1,67 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStatuses(t *testing.T) {
+	ctx := t.Context()
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	dstore, cleanup := setupCleanupCoreTestDatastore(t)
+	defer cleanup()
+
+	state, err := loadRuntimeState(ctx, dstore, now)
+	require.NoError(t, err)
+
+	tasks := newTaskStatuses()
+	tasks.scheduled("republish", time.Hour, 10*time.Minute, now)
+	tasks.scheduled("cleanup", 5*time.Minute, 0, now)
+
+	// A started task has no next run until it finishes
+	tasks.started("cleanup", now)
+	state.taskCompleted(ctx, "cleanup", now)
+
+	statuses := tasks.toProto(state)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "cleanup", statuses[0].GetName())
+	assert.True(t, statuses[0].GetRunning())
+	assert.Nil(t, statuses[0].GetNextRun())
+	assert.Nil(t, statuses[0].GetLastDuration())
+	assert.Equal(t, now, statuses[0].GetLastRun().AsTime())
+
+	assert.Equal(t, "republish", statuses[1].GetName())
+	assert.False(t, statuses[1].GetRunning())
+	assert.Equal(t, time.Hour, statuses[1].GetInterval().AsDuration())
+	assert.Equal(t, now.Add(10*time.Minute), statuses[1].GetNextRun().AsTime())
+	assert.Nil(t, statuses[1].GetLastRun())
+
+	// A finished task is scheduled an interval later
+	tasks.finished("cleanup", now.Add(30*time.Second))
+
+	statuses = tasks.toProto(state)
+	assert.False(t, statuses[0].GetRunning())
+	assert.Equal(t, 30*time.Second, statuses[0].GetLastDuration().AsDuration())
+	assert.Equal(t, now.Add(30*time.Second+5*time.Minute), statuses[0].GetNextRun().AsTime())
+
+	// Unknown tasks are ignored
+	tasks.started("unknown", now)
+	tasks.finished("unknown", now)
+	assert.Len(t, tasks.toProto(state), 2)
+}
+
+func TestTaskStatuses_Nil(t *testing.T) {
+	var tasks *taskStatuses
+
+	tasks.scheduled("cleanup", time.Minute, 0, time.Now())
+	tasks.started("cleanup", time.Now())
+	tasks.finished("cleanup", time.Now())
+	assert.Nil(t, tasks.toProto(nil))
+}
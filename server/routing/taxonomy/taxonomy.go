@@ -0,0 +1,193 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package taxonomy maps labels to the canonical values of a versioned ontology.
+//
+// A Taxonomy lists the valid values of some label namespaces, e.g. the OASF skills
+// and domains. Labels of those namespaces are normalized before they are compared
+// to the values, so "/skills/Natural Language Processing" and
+// "/skills/natural-language-processing" both map to the canonical
+// "/skills/natural_language_processing". Labels of namespaces the taxonomy does not
+// cover are left unchanged.
+package taxonomy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/agntcy/dir/server/types"
+)
+
+// ErrUnknownLabel is returned for labels of a covered namespace that are not part of the taxonomy.
+var ErrUnknownLabel = errors.New("label is not part of the taxonomy")
+
+// Taxonomy is an immutable versioned set of label values per namespace.
+type Taxonomy struct {
+	version    string
+	namespaces map[types.LabelType]*namespace
+}
+
+type namespace struct {
+	values []string          // Canonical values, sorted
+	index  map[string]string // Normalized value or parent path to canonical value
+}
+
+// file is the JSON representation of a taxonomy.
+type file struct {
+	Version    string              `json:"version"`
+	Namespaces map[string][]string `json:"namespaces"`
+}
+
+// Load loads a taxonomy from a JSON file of the form
+//
+//	{"version": "1.0.0", "namespaces": {"skills": ["nlp/text_generation"], "domains": ["finance"]}}
+func Load(path string) (*Taxonomy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read taxonomy: %w", err)
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse taxonomy: %w", err)
+	}
+
+	namespaces := make(map[types.LabelType][]string, len(f.Namespaces))
+	for name, values := range f.Namespaces {
+		namespaces[types.LabelType(name)] = values
+	}
+
+	return New(f.Version, namespaces)
+}
+
+// New creates a taxonomy of the version with the values per namespace.
+// Values are paths like "nlp/text_generation" and are normalized; parents of values
+// such as "nlp" are valid values themselves.
+func New(version string, namespaces map[types.LabelType][]string) (*Taxonomy, error) {
+	if version == "" {
+		return nil, errors.New("taxonomy has no version")
+	}
+
+	if len(namespaces) == 0 {
+		return nil, errors.New("taxonomy has no namespaces")
+	}
+
+	t := &Taxonomy{
+		version:    version,
+		namespaces: make(map[types.LabelType]*namespace, len(namespaces)),
+	}
+
+	for lt, values := range namespaces {
+		if !lt.IsValid() {
+			return nil, fmt.Errorf("taxonomy namespace %q is not a label namespace", lt)
+		}
+
+		ns := &namespace{index: make(map[string]string)}
+
+		for _, value := range values {
+			canonical := normalize(value)
+			if canonical == "" {
+				return nil, fmt.Errorf("taxonomy namespace %q has an empty value", lt)
+			}
+
+			// Parents are valid values, e.g. "nlp" for "nlp/text_generation"
+			segments := strings.Split(canonical, "/")
+			for i := range segments {
+				path := strings.Join(segments[:i+1], "/")
+				if _, ok := ns.index[path]; !ok {
+					ns.index[path] = path
+					ns.values = append(ns.values, path)
+				}
+			}
+		}
+
+		if len(ns.values) == 0 {
+			return nil, fmt.Errorf("taxonomy namespace %q has no values", lt)
+		}
+
+		slices.Sort(ns.values)
+		t.namespaces[lt] = ns
+	}
+
+	return t, nil
+}
+
+// Version returns the version of the taxonomy.
+func (t *Taxonomy) Version() string {
+	return t.version
+}
+
+// Namespaces returns the namespaces covered by the taxonomy in the order of types.AllLabelTypes.
+func (t *Taxonomy) Namespaces() []types.LabelType {
+	var namespaces []types.LabelType
+
+	for _, lt := range types.AllLabelTypes() {
+		if _, ok := t.namespaces[lt]; ok {
+			namespaces = append(namespaces, lt)
+		}
+	}
+
+	return namespaces
+}
+
+// Values returns the sorted canonical values of the namespace, or nil if it is not covered.
+func (t *Taxonomy) Values(lt types.LabelType) []string {
+	ns, ok := t.namespaces[lt]
+	if !ok {
+		return nil
+	}
+
+	return slices.Clone(ns.values)
+}
+
+// Normalize returns the canonical form of the label. Labels of namespaces the taxonomy
+// does not cover are returned unchanged; labels of covered namespaces that are not part
+// of the taxonomy return ErrUnknownLabel.
+func (t *Taxonomy) Normalize(label types.Label) (types.Label, error) {
+	ns, ok := t.namespaces[label.Type()]
+	if !ok {
+		return label, nil
+	}
+
+	canonical, ok := ns.index[normalize(label.Value())]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownLabel, label)
+	}
+
+	return types.Label(label.Type().Prefix() + canonical), nil
+}
+
+// Normalizer returns a label normalizer for types.SetLabelNormalizer. Labels that are not
+// part of the taxonomy are rejected if strict is set and kept unchanged otherwise.
+func (t *Taxonomy) Normalizer(strict bool) types.LabelNormalizer {
+	return func(label types.Label) (types.Label, bool) {
+		canonical, err := t.Normalize(label)
+		if err != nil {
+			return label, !strict
+		}
+
+		return canonical, true
+	}
+}
+
+// normalize lowercases the path, trims its segments, replaces spaces and dashes with
+// underscores and drops empty segments.
+func normalize(path string) string {
+	var segments []string
+
+	for segment := range strings.SplitSeq(strings.ToLower(path), "/") {
+		segment = strings.Join(strings.FieldsFunc(segment, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '_' || r == '\t'
+		}), "_")
+
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,107 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package taxonomy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTaxonomy(t *testing.T) *Taxonomy {
+	t.Helper()
+
+	taxonomy, err := New("1.0.0", map[types.LabelType][]string{
+		types.LabelTypeSkill:  {"Natural Language Processing/Text Generation", "images"},
+		types.LabelTypeDomain: {"finance"},
+	})
+	require.NoError(t, err)
+
+	return taxonomy
+}
+
+func TestTaxonomy_Normalize(t *testing.T) {
+	taxonomy := testTaxonomy(t)
+
+	tests := []struct {
+		label types.Label
+		want  types.Label
+	}{
+		{"/skills/natural_language_processing/text_generation", "/skills/natural_language_processing/text_generation"},
+		{"/skills/Natural-Language-Processing/ Text Generation ", "/skills/natural_language_processing/text_generation"},
+		{"/skills/NATURAL_LANGUAGE_PROCESSING", "/skills/natural_language_processing"},
+		{"/skills/Images/", "/skills/images"},
+		{"/domains/Finance", "/domains/finance"},
+		{"/modules/Anything", "/modules/Anything"},
+	}
+
+	for _, tt := range tests {
+		got, err := taxonomy.Normalize(tt.label)
+		require.NoError(t, err, tt.label)
+		assert.Equal(t, tt.want, got, tt.label)
+	}
+
+	_, err := taxonomy.Normalize("/skills/audio")
+	require.ErrorIs(t, err, ErrUnknownLabel)
+}
+
+func TestTaxonomy_Values(t *testing.T) {
+	taxonomy := testTaxonomy(t)
+
+	assert.Equal(t, "1.0.0", taxonomy.Version())
+	assert.Equal(t, []types.LabelType{types.LabelTypeSkill, types.LabelTypeDomain}, taxonomy.Namespaces())
+	assert.Equal(t, []string{
+		"images",
+		"natural_language_processing",
+		"natural_language_processing/text_generation",
+	}, taxonomy.Values(types.LabelTypeSkill))
+	assert.Nil(t, taxonomy.Values(types.LabelTypeModule))
+}
+
+func TestTaxonomy_Normalizer(t *testing.T) {
+	taxonomy := testTaxonomy(t)
+
+	label, ok := taxonomy.Normalizer(false)("/skills/audio")
+	assert.True(t, ok)
+	assert.Equal(t, types.Label("/skills/audio"), label)
+
+	_, ok = taxonomy.Normalizer(true)("/skills/audio")
+	assert.False(t, ok)
+
+	label, ok = taxonomy.Normalizer(true)("/skills/IMAGES")
+	assert.True(t, ok)
+	assert.Equal(t, types.Label("/skills/images"), label)
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "taxonomy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": "2.1.0", "namespaces": {"skills": ["nlp"]}}`), 0o600))
+
+	taxonomy, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2.1.0", taxonomy.Version())
+	assert.Equal(t, []string{"nlp"}, taxonomy.Values(types.LabelTypeSkill))
+
+	invalid := map[string]string{
+		"no version":        `{"namespaces": {"skills": ["nlp"]}}`,
+		"no namespaces":     `{"version": "1"}`,
+		"unknown namespace": `{"version": "1", "namespaces": {"teams": ["a"]}}`,
+		"empty value":       `{"version": "1", "namespaces": {"skills": [" / "]}}`,
+		"not json":          `skills`,
+	}
+
+	for name, content := range invalid {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+		_, err := Load(path)
+		assert.Error(t, err, name)
+	}
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
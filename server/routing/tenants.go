@@ -0,0 +1,185 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/ipfs/go-datastore"
+)
+
+// TenantsNamespace is the key namespace of the labels of records published by tenants.
+// Tenant labels are stored as /tenants/<tenant>/<namespace>/<value>/<cid>/<peer_id>,
+// so that searches without the tenant never scan them.
+const TenantsNamespace = "tenants"
+
+// tenantsPrefix is the key prefix of all tenant labels.
+const tenantsPrefix = "/" + TenantsNamespace + "/"
+
+// tenantPattern matches tenant IDs, e.g. acme or team-a.
+var tenantPattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+
+// validateTenant checks that a tenant ID can be used as a key path segment.
+func validateTenant(tenant string) error {
+	if !tenantPattern.MatchString(tenant) {
+		return fmt.Errorf("invalid tenant %q, must be 1-63 letters, digits, dots, dashes or underscores, starting and ending with a letter or digit", tenant)
+	}
+
+	return nil
+}
+
+// labelKeyPrefixes returns the key prefixes of all label namespaces, followed by
+// the prefix of tenant labels.
+func labelKeyPrefixes() []string {
+	return append(labelNamespaces(), tenantsPrefix)
+}
+
+// tenantKey returns a label key under the namespace of a tenant.
+// Keys of untenanted records are returned as is.
+func tenantKey(tenant, key string) string {
+	if tenant == "" {
+		return key
+	}
+
+	return tenantsPrefix + tenant + key
+}
+
+// tenantLabels returns the labels of a record published by a tenant, under the tenant's namespace.
+// Example: ("acme", /skills/AI) → /tenants/acme/skills/AI.
+func tenantLabels(tenant string, labels []types.Label) []types.Label {
+	if tenant == "" {
+		return labels
+	}
+
+	scoped := make([]types.Label, len(labels))
+	for i, label := range labels {
+		scoped[i] = types.Label(tenantKey(tenant, label.String()))
+	}
+
+	return scoped
+}
+
+// splitTenantLabel returns the tenant of a label and the label without the tenant's namespace.
+// Labels of untenanted records have no tenant.
+func splitTenantLabel(label types.Label) (string, types.Label) {
+	rest, ok := strings.CutPrefix(label.String(), tenantsPrefix)
+	if !ok {
+		return "", label
+	}
+
+	tenant, value, _ := strings.Cut(rest, "/")
+
+	return tenant, types.Label("/" + value)
+}
+
+// labelsOfTenants returns the labels of the given tenants, without their tenant's namespace.
+func labelsOfTenants(labels []types.Label, scope ...string) []types.Label {
+	var scoped []types.Label
+
+	for _, label := range labels {
+		if tenant, value := splitTenantLabel(label); slices.Contains(scope, tenant) {
+			scoped = append(scoped, value)
+		}
+	}
+
+	return scoped
+}
+
+// recordTenant returns the tenant a local record was published for, empty if untenanted.
+// It is included in the record's GossipSub announcements.
+func (r *routeRemote) recordTenant(cid string) string {
+	value, err := r.dstore.Get(r.ctx, datastore.NewKey("/records/"+cid))
+	if err != nil {
+		return ""
+	}
+
+	return decodeLocalRecordMetadata(value).Tenant
+}
+
+// sharedEntries returns the label entries of untenanted records.
+// Tenant records are only distributed by announcements, which carry the tenant.
+func sharedEntries(entries []NamespaceEntry) []NamespaceEntry {
+	shared := make([]NamespaceEntry, 0, len(entries))
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Key, tenantsPrefix) {
+			shared = append(shared, entry)
+		}
+	}
+
+	return shared
+}
+
+// tenants holds the tenants configured on this peer and decides which records
+// are visible to the searches of a tenant.
+type tenants struct {
+	public map[string]bool // Whether each configured tenant is public
+	shared []string        // Untenanted records ("") followed by the public tenants, in configuration order
+}
+
+// newTenants validates the configured tenants.
+func newTenants(cfgs []routingconfig.TenantConfig) (*tenants, error) {
+	t := &tenants{
+		public: make(map[string]bool, len(cfgs)),
+		shared: []string{""},
+	}
+
+	for _, cfg := range cfgs {
+		if err := validateTenant(cfg.ID); err != nil {
+			return nil, err
+		}
+
+		if _, ok := t.public[cfg.ID]; ok {
+			return nil, fmt.Errorf("duplicate tenant %q", cfg.ID)
+		}
+
+		t.public[cfg.ID] = cfg.Public
+
+		if cfg.Public {
+			t.shared = append(t.shared, cfg.ID)
+		}
+	}
+
+	return t, nil
+}
+
+// known reports whether records of the tenant may be published and cached by this peer.
+// Untenanted records always are. A nil tenants has no tenants configured.
+func (t *tenants) known(tenant string) bool {
+	if tenant == "" {
+		return true
+	}
+
+	if t == nil {
+		return false
+	}
+
+	_, ok := t.public[tenant]
+
+	return ok
+}
+
+// isShared reports whether the tenant shares records with untenanted publishers.
+func (t *tenants) isShared(tenant string) bool {
+	return tenant == "" || (t != nil && t.public[tenant])
+}
+
+// scope returns the tenants whose records are returned to the searches of a tenant, in search order.
+// Shared tenants see all shared records, other tenants only their own.
+func (t *tenants) scope(tenant string) []string {
+	if t == nil {
+		return []string{tenant}
+	}
+
+	if t.isShared(tenant) {
+		return t.shared
+	}
+
+	return []string{tenant}
+}
@@ -0,0 +1,92 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"testing"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantLabels_RoundTrip(t *testing.T) {
+	labels := []types.Label{"/skills/AI/ML", "/domains/research"}
+
+	// Labels of untenanted records are kept as is
+	assert.Equal(t, labels, tenantLabels("", labels))
+
+	scoped := tenantLabels("acme", labels)
+	assert.Equal(t, []types.Label{"/tenants/acme/skills/AI/ML", "/tenants/acme/domains/research"}, scoped)
+
+	tenant, label := splitTenantLabel(scoped[0])
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, types.Label("/skills/AI/ML"), label)
+
+	tenant, label = splitTenantLabel(labels[1])
+	assert.Empty(t, tenant)
+	assert.Equal(t, labels[1], label)
+
+	mixed := append(tenantLabels("other", labels[:1]), append(scoped[1:], labels[:1]...)...)
+	assert.Equal(t, []types.Label{"/domains/research"}, labelsOfTenants(mixed, "acme"))
+	assert.Equal(t, []types.Label{"/domains/research", "/skills/AI/ML"}, labelsOfTenants(mixed, "", "acme"))
+}
+
+func TestTenants_Scope(t *testing.T) {
+	configured, err := newTenants([]routingconfig.TenantConfig{
+		{ID: "acme"},
+		{ID: "community", Public: true},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, configured.known(""))
+	assert.True(t, configured.known("acme"))
+	assert.False(t, configured.known("unknown"))
+
+	// Public tenants share records with untenanted publishers, private tenants only see their own
+	assert.Equal(t, []string{"", "community"}, configured.scope(""))
+	assert.Equal(t, []string{"", "community"}, configured.scope("community"))
+	assert.Equal(t, []string{"acme"}, configured.scope("acme"))
+
+	assert.True(t, configured.isShared("community"))
+	assert.False(t, configured.isShared("acme"))
+
+	// Peers without tenants only know untenanted records
+	var none *tenants
+
+	assert.True(t, none.known(""))
+	assert.False(t, none.known("acme"))
+	assert.Equal(t, []string{""}, none.scope(""))
+}
+
+func TestNewTenants_Invalid(t *testing.T) {
+	_, err := newTenants([]routingconfig.TenantConfig{{ID: "acme"}, {ID: "acme", Public: true}})
+	require.ErrorContains(t, err, "duplicate tenant")
+
+	_, err = newTenants([]routingconfig.TenantConfig{{ID: "acme/team"}})
+	require.ErrorContains(t, err, "invalid tenant")
+}
+
+func TestSearchPasses_ResumesAtCursor(t *testing.T) {
+	phases := []zonePhase{zonePhasePreferred, zonePhaseOthers}
+	scope := []string{"", "community"}
+
+	passes, err := searchPasses(phases, scope, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []searchPass{
+		{phase: zonePhasePreferred},
+		{phase: zonePhasePreferred, tenant: "community"},
+		{phase: zonePhaseOthers},
+		{phase: zonePhaseOthers, tenant: "community"},
+	}, passes)
+
+	passes, err = searchPasses(phases, scope, &searchCursor{Phase: zonePhaseOthers, Tenant: "community"})
+	require.NoError(t, err)
+	assert.Equal(t, []searchPass{{phase: zonePhaseOthers, tenant: "community"}}, passes)
+
+	// Page tokens of another tenant's search are rejected
+	_, err = searchPasses(phases, scope, &searchCursor{Tenant: "acme"})
+	require.Error(t, err)
+}
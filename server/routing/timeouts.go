@@ -0,0 +1,74 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	"github.com/agntcy/dir/server/metrics"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/agntcy/dir/server/routing/rpc"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// withTimeout bounds a remote operation by its configured deadline.
+// A zero timeout leaves the operation bounded by the caller's context only.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// observeTimeout counts an operation that failed because its deadline passed.
+// Returns whether it did.
+func observeTimeout(ctx context.Context, operation string) bool {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+
+	metrics.OperationTimeouts.WithLabelValues(operation).Inc()
+
+	return true
+}
+
+// pull pulls a record from a remote peer within the pull timeout.
+func (r *routeRemote) pull(ctx context.Context, peerID peer.ID, ref *corev1.RecordRef) (*corev1.Record, rpc.RecordMetadata, error) {
+	ctx, cancel := withTimeout(ctx, r.timeouts.Pull)
+	defer cancel()
+
+	record, metadata, err := r.service.Pull(ctx, peerID, ref)
+	if err != nil {
+		observeTimeout(ctx, metrics.OperationPull)
+	}
+
+	return record, metadata, err //nolint:wrapcheck
+}
+
+// gossip publishes label announcements via GossipSub within the GossipSub publish timeout.
+func (r *routeRemote) gossip(ctx context.Context, publish func(context.Context) error) error {
+	ctx, cancel := withTimeout(ctx, r.timeouts.GossipSubPublish)
+	defer cancel()
+
+	err := publish(ctx)
+	if err != nil {
+		observeTimeout(ctx, metrics.OperationGossipSubPublish)
+	}
+
+	return err
+}
+
+// publishBudgetError reports a publish failing because the publish budget of ctx was
+// exhausted as ErrDeadlineExceeded. Other errors are returned as is.
+func publishBudgetError(ctx context.Context, err error) error {
+	if err == nil || !observeTimeout(ctx, metrics.OperationPublish) {
+		return err
+	}
+
+	return routingerr.ErrDeadlineExceeded.Errorf("publish budget exhausted: %w", err)
+}
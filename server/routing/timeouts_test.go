@@ -0,0 +1,63 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	routingconfig "github.com/agntcy/dir/server/routing/config"
+	"github.com/agntcy/dir/server/routing/routingerr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWithTimeout(t *testing.T) {
+	// Zero timeouts leave the operation bounded by the caller's context only
+	ctx, cancel := withTimeout(t.Context(), 0)
+	defer cancel()
+
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	ctx, cancel = withTimeout(t.Context(), time.Minute)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestGossip_AppliesTimeout(t *testing.T) {
+	r := &routeRemote{timeouts: routingconfig.TimeoutsConfig{GossipSubPublish: 10 * time.Millisecond}}
+
+	err := r.gossip(t.Context(), func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPublishBudgetError(t *testing.T) {
+	failure := errors.New("failed to announce CID to DHT")
+
+	// Failures within the budget are returned as is
+	assert.Equal(t, failure, publishBudgetError(t.Context(), failure))
+	assert.NoError(t, publishBudgetError(t.Context(), nil))
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Nanosecond)
+	defer cancel()
+
+	<-ctx.Done()
+
+	err := publishBudgetError(ctx, failure)
+	assert.ErrorIs(t, err, routingerr.ErrDeadlineExceeded)
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
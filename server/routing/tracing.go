@@ -0,0 +1,24 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of publishes, searches and the handling of remote announcements.
+// Spans are discarded unless tracing is enabled in the server configuration.
+var tracer = otel.Tracer("github.com/agntcy/dir/server/routing")
+
+// endSpan marks the span as failed if err is set, then ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -4,10 +4,10 @@
 package routing
 
 import (
-	"fmt"
 	"math"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/agntcy/dir/server/types"
 )
 
 // toPtr converts a value to a pointer to that value.
@@ -30,11 +30,13 @@ func safeIntToUint32(val int) uint32 {
 	return uint32(val)
 }
 
-// deduplicateQueries removes duplicate queries to ensure consistent scoring.
-// Two queries are considered duplicates if they have the same Type and Value.
+// deduplicateQueries canonicalizes queries and removes duplicates to ensure consistent scoring.
+// Two queries are considered duplicates if their canonical forms have the same hash
+// (see types.CanonicalQuery), so that logically identical queries written differently
+// do not inflate match scores.
 // This provides defensive programming against client bugs and ensures predictable API behavior.
 func deduplicateQueries(queries []*routingv1.RecordQuery) []*routingv1.RecordQuery {
-	if len(queries) <= 1 {
+	if len(queries) == 0 {
 		return queries
 	}
 
@@ -47,13 +49,13 @@ func deduplicateQueries(queries []*routingv1.RecordQuery) []*routingv1.RecordQue
 			continue // Skip nil queries defensively
 		}
 
-		// Create unique key from type and value
-		key := fmt.Sprintf("%s:%s", query.GetType().String(), query.GetValue())
+		canonical := types.CanonicalQuery(query)
 
-		if !seen[key] {
-			seen[key] = true
+		hash := types.QueryHash(canonical)
+		if !seen[hash] {
+			seen[hash] = true
 
-			deduplicated = append(deduplicated, query)
+			deduplicated = append(deduplicated, canonical)
 		}
 	}
 
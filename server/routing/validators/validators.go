@@ -7,7 +7,10 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/agntcy/dir/server/routing/continuity"
+	"github.com/agntcy/dir/server/routing/revocation"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
 	"github.com/ipfs/go-cid"
@@ -362,14 +365,124 @@ func (v *LocatorValidator) Select(key string, values [][]byte) (int, error) {
 	return v.selectFirstValid(key, values, v.Validate)
 }
 
-// CreateLabelValidators creates separate validators for each label namespace.
+// NamespaceValidator validates DHT records of a custom label namespace
+// registered via types.RegisterLabelNamespace.
+type NamespaceValidator struct {
+	BaseValidator
+	namespace types.LabelNamespace
+}
+
+// NewNamespaceValidator creates a validator for a custom label namespace.
+func NewNamespaceValidator(namespace types.LabelNamespace) *NamespaceValidator {
+	return &NamespaceValidator{namespace: namespace}
+}
+
+// Validate validates a custom namespace DHT record.
+// Key format: /<namespace>/<label_path>/<cid>/<peer_id>
+// The label path is checked with the namespace's value validator.
+func (v *NamespaceValidator) Validate(key string, value []byte) error {
+	validatorLogger.Debug("Validating custom namespace DHT record", "namespace", v.namespace.Type, "key", key)
+
+	// Basic format validation
+	parts, err := v.validateKeyFormat(key, v.namespace.Type.String())
+	if err != nil {
+		return err
+	}
+
+	// Namespace-specific validation
+	labelPath := strings.Join(parts[2:len(parts)-2], "/") // Exclude CID and PeerID
+	if err := v.namespace.ValidateLabel(labelPath); err != nil {
+		return errors.New("invalid " + v.namespace.Type.String() + " label: " + err.Error())
+	}
+
+	// Value validation
+	if err := v.validateValue(value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Select chooses between multiple values for custom namespace records.
+func (v *NamespaceValidator) Select(key string, values [][]byte) (int, error) {
+	return v.selectFirstValid(key, values, v.Validate)
+}
+
+// RevocationValidator validates DHT records holding signed record revocations.
+type RevocationValidator struct{}
+
+// Validate validates a revocation DHT record.
+// Key format: /revocations/<cid>/<peer_id>
+// The value must be a revocation of the CID signed by the peer in the key.
+func (v *RevocationValidator) Validate(key string, value []byte) error {
+	validatorLogger.Debug("Validating revocation DHT record", "key", key)
+
+	parts := strings.Split(key, "/")
+	if len(parts) != 4 || parts[1] != revocation.Namespace { //nolint:mnd
+		return errors.New("invalid key format: expected /revocations/<cid>/<peer_id>")
+	}
+
+	rev, err := revocation.Unmarshal(value)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if rev.CID != parts[2] {
+		return errors.New("revocation CID " + rev.CID + " does not match key")
+	}
+
+	signerID, err := rev.SignerID()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if signerID.String() != parts[3] {
+		return errors.New("revocation signed by " + signerID.String() + " does not match key")
+	}
+
+	return nil
+}
+
+// Select chooses the most recent valid revocation.
+func (v *RevocationValidator) Select(key string, values [][]byte) (int, error) {
+	selected := -1
+
+	var latest time.Time
+
+	for i, value := range values {
+		if err := v.Validate(key, value); err != nil {
+			continue
+		}
+
+		rev, _ := revocation.Unmarshal(value)
+		if selected == -1 || rev.Timestamp.After(latest) {
+			selected = i
+			latest = rev.Timestamp
+		}
+	}
+
+	if selected == -1 {
+		return -1, errors.New("no valid values found")
+	}
+
+	return selected, nil
+}
+
+// CreateLabelValidators creates separate validators for each label namespace,
+// including the registered custom namespaces.
 func CreateLabelValidators() map[string]record.Validator {
-	return map[string]record.Validator{
+	labelValidators := map[string]record.Validator{
 		types.LabelTypeSkill.String():   &SkillValidator{},
 		types.LabelTypeDomain.String():  &DomainValidator{},
 		types.LabelTypeModule.String():  &ModuleValidator{},
 		types.LabelTypeLocator.String(): &LocatorValidator{},
 	}
+
+	for _, namespace := range types.CustomLabelNamespaces() {
+		labelValidators[namespace.Type.String()] = NewNamespaceValidator(namespace)
+	}
+
+	return labelValidators
 }
 
 // ValidateLabelKey validates a label key format before storing in DHT.
@@ -438,3 +551,54 @@ func ExtractCIDFromLabelKey(labelKey string) (string, error) {
 
 	return cidStr, nil
 }
+
+// ContinuityValidator validates DHT records holding signed continuity records of identity rotations.
+type ContinuityValidator struct{}
+
+// Validate validates a continuity DHT record.
+// Key format: /continuity/<old_peer_id>
+// The value must be a continuity record of the peer in the key, signed by both identities.
+func (v *ContinuityValidator) Validate(key string, value []byte) error {
+	validatorLogger.Debug("Validating continuity DHT record", "key", key)
+
+	parts := strings.Split(key, "/")
+	if len(parts) != 3 || parts[1] != continuity.Namespace { //nolint:mnd
+		return errors.New("invalid key format: expected /continuity/<old_peer_id>")
+	}
+
+	record, err := continuity.Unmarshal(value)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if record.OldPeerID != parts[2] {
+		return errors.New("continuity record of " + record.OldPeerID + " does not match key")
+	}
+
+	return nil
+}
+
+// Select chooses the most recent valid continuity record.
+func (v *ContinuityValidator) Select(key string, values [][]byte) (int, error) {
+	selected := -1
+
+	var latest time.Time
+
+	for i, value := range values {
+		if err := v.Validate(key, value); err != nil {
+			continue
+		}
+
+		record, _ := continuity.Unmarshal(value)
+		if selected == -1 || record.Timestamp.After(latest) {
+			selected = i
+			latest = record.Timestamp
+		}
+	}
+
+	if selected == -1 {
+		return -1, errors.New("no valid values found")
+	}
+
+	return selected, nil
+}
@@ -4,10 +4,17 @@
 package validators
 
 import (
+	"crypto/rand"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/agntcy/dir/server/routing/continuity"
+	"github.com/agntcy/dir/server/routing/revocation"
 	"github.com/agntcy/dir/server/types"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -565,6 +572,52 @@ func TestCreateLabelValidators(t *testing.T) {
 	assert.IsType(t, &LocatorValidator{}, validators[types.LabelTypeLocator.String()])
 }
 
+func TestNamespaceValidator_Validate(t *testing.T) {
+	validator := NewNamespaceValidator(types.LabelNamespace{
+		Type:       "teams",
+		Annotation: "org.example/teams",
+		ValidateValue: func(value string) error {
+			if !strings.HasPrefix(value, "platform") {
+				return errors.New("unknown team")
+			}
+
+			return nil
+		},
+	})
+
+	tests := []struct {
+		name     string
+		key      string
+		errorMsg string
+	}{
+		{
+			name: "valid custom namespace key",
+			key:  "/teams/platform/search/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+		},
+		{
+			name:     "invalid namespace",
+			key:      "/skills/platform/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			errorMsg: "invalid namespace: expected teams, got skills",
+		},
+		{
+			name:     "value rejected by namespace validator",
+			key:      "/teams/marketing/bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku/Peer1",
+			errorMsg: "invalid teams label: unknown team",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.key, nil)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
 func TestValidateLabelKey(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -903,3 +956,89 @@ func BenchmarkExtractCIDFromLabelKey(b *testing.B) {
 		_, _ = ExtractCIDFromLabelKey(labelKey)
 	}
 }
+
+func TestRevocationValidator(t *testing.T) {
+	const testCID = "bafkreihdwdcefgh4dqkjv67uzcmw7ojee6xedzdetojuzjevtenxquvyku"
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	peerID, err := peer.IDFromPrivateKey(key)
+	require.NoError(t, err)
+
+	signedRevocation := func(timestamp time.Time) []byte {
+		rev := revocation.New(testCID, "")
+		rev.Timestamp = timestamp
+		require.NoError(t, rev.Sign(key))
+
+		data, err := rev.Marshal()
+		require.NoError(t, err)
+
+		return data
+	}
+
+	validator := &RevocationValidator{}
+	key1 := revocation.Key(testCID, peerID.String())
+	older := signedRevocation(time.Now().Add(-time.Hour))
+	newer := signedRevocation(time.Now())
+
+	require.NoError(t, validator.Validate(key1, newer))
+
+	// Signer must match the peer in the key
+	assert.Error(t, validator.Validate(revocation.Key(testCID, "Peer1"), newer))
+
+	// CID must match the key
+	assert.Error(t, validator.Validate(revocation.Key("bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi", peerID.String()), newer))
+
+	assert.Error(t, validator.Validate(key1, []byte("garbage")))
+	assert.Error(t, validator.Validate("/revocations/"+testCID, newer))
+
+	index, err := validator.Select(key1, [][]byte{older, []byte("garbage"), newer})
+	require.NoError(t, err)
+	assert.Equal(t, 2, index)
+
+	_, err = validator.Select(key1, [][]byte{[]byte("garbage")})
+	assert.Error(t, err)
+}
+
+func TestContinuityValidator(t *testing.T) {
+	oldKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	newKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	signedRecord := func(timestamp time.Time) (*continuity.Record, []byte) {
+		record, err := continuity.New(oldKey, newKey)
+		require.NoError(t, err)
+
+		record.Timestamp = timestamp
+		require.NoError(t, record.Sign(oldKey, newKey))
+
+		data, err := record.Marshal()
+		require.NoError(t, err)
+
+		return record, data
+	}
+
+	record, newer := signedRecord(time.Now())
+	_, older := signedRecord(time.Now().Add(-time.Hour))
+
+	validator := &ContinuityValidator{}
+	key := continuity.Key(record.OldPeerID)
+
+	require.NoError(t, validator.Validate(key, newer))
+
+	// The old peer must match the key
+	assert.Error(t, validator.Validate(continuity.Key(record.NewPeerID), newer))
+
+	assert.Error(t, validator.Validate(key, []byte("garbage")))
+	assert.Error(t, validator.Validate(key+"/extra", newer))
+
+	index, err := validator.Select(key, [][]byte{older, []byte("garbage"), newer})
+	require.NoError(t, err)
+	assert.Equal(t, 2, index)
+
+	_, err = validator.Select(key, [][]byte{[]byte("garbage")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/agntcy/dir/server/routing/addressbook"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// PeerAnnotationZone is the annotation of search result peers holding their locality zone.
+const PeerAnnotationZone = "zone"
+
+// zonePattern matches locality zones, e.g. eu-west-1 or us-east-1a.
+var zonePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+
+// validateZone checks that a locality zone can be advertised as a multiaddr component.
+func validateZone(zone string) error {
+	if !zonePattern.MatchString(zone) {
+		return fmt.Errorf("invalid zone %q, must be 1-63 letters, digits, dots, dashes or underscores, starting and ending with a letter or digit", zone)
+	}
+
+	return nil
+}
+
+// zonePhase is a pass of a search over the cached labels.
+// Searches preferring zones first return the records of peers in the zones,
+// then those of other peers; other searches make a single pass.
+type zonePhase int
+
+const (
+	// zonePhasePreferred returns the records of peers in the requested zones,
+	// or of all peers if no zones are requested.
+	zonePhasePreferred zonePhase = iota
+
+	// zonePhaseOthers returns the records of peers outside the preferred zones.
+	zonePhaseOthers
+)
+
+// zoneFilter decides whether the providers returned by a search are in the zones
+// requested by the caller. Decisions are cached per peer for the duration of a search.
+type zoneFilter struct {
+	zones   []string
+	only    bool // Restrict results to the zones instead of preferring them
+	zoneOf  func(ctx context.Context, peerID string) string
+	decided map[string]bool
+}
+
+func (r *routeRemote) newZoneFilter(zones []string, only bool) *zoneFilter {
+	return &zoneFilter{
+		zones:   zones,
+		only:    only,
+		zoneOf:  r.peerZone,
+		decided: make(map[string]bool),
+	}
+}
+
+// phases returns the search passes to make, starting at the given phase when resuming.
+func (f *zoneFilter) phases(start zonePhase) []zonePhase {
+	if len(f.zones) == 0 || f.only || start == zonePhaseOthers {
+		return []zonePhase{start}
+	}
+
+	return []zonePhase{zonePhasePreferred, zonePhaseOthers}
+}
+
+// allows reports whether the records of the peer are returned in the given phase.
+func (f *zoneFilter) allows(ctx context.Context, peerID string, phase zonePhase) bool {
+	if len(f.zones) == 0 {
+		return true
+	}
+
+	if phase == zonePhaseOthers {
+		return !f.inZones(ctx, peerID)
+	}
+
+	return f.inZones(ctx, peerID)
+}
+
+// excludes reports whether the records of the peer must not be returned at all.
+func (f *zoneFilter) excludes(ctx context.Context, peerID string) bool {
+	return f.only && len(f.zones) > 0 && !f.inZones(ctx, peerID)
+}
+
+// inZones reports whether the peer advertised one of the requested zones.
+func (f *zoneFilter) inZones(ctx context.Context, peerID string) bool {
+	in, ok := f.decided[peerID]
+	if !ok {
+		in = slices.Contains(f.zones, f.zoneOf(ctx, peerID))
+		f.decided[peerID] = in
+	}
+
+	return in
+}
+
+// peerZone returns the locality zone a peer advertised with its addresses, empty if unknown.
+func (r *routeRemote) peerZone(ctx context.Context, peerID string) string {
+	if entry, err := r.addressBook.Get(ctx, peerID); err == nil && entry != nil {
+		if zone := entry.Zone(); zone != "" {
+			return zone
+		}
+	}
+
+	pid, err := peer.Decode(peerID)
+	if err != nil {
+		return ""
+	}
+
+	return addressbook.Zone(r.server.Host().Peerstore().Addrs(pid))
+}
@@ -0,0 +1,73 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package routing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestZoneFilter(zones []string, only bool) *zoneFilter {
+	peerZones := map[string]string{"peer-eu": "eu-west-1", "peer-us": "us-east-1"}
+
+	return &zoneFilter{
+		zones:   zones,
+		only:    only,
+		zoneOf:  func(_ context.Context, peerID string) string { return peerZones[peerID] },
+		decided: make(map[string]bool),
+	}
+}
+
+func TestZoneFilter_Prefer(t *testing.T) {
+	ctx := t.Context()
+	filter := newTestZoneFilter([]string{"eu-west-1"}, false)
+
+	// Records of peers in the zones are returned first, then all others
+	assert.Equal(t, []zonePhase{zonePhasePreferred, zonePhaseOthers}, filter.phases(zonePhasePreferred))
+	assert.Equal(t, []zonePhase{zonePhaseOthers}, filter.phases(zonePhaseOthers))
+
+	assert.True(t, filter.allows(ctx, "peer-eu", zonePhasePreferred))
+	assert.False(t, filter.allows(ctx, "peer-us", zonePhasePreferred))
+	assert.False(t, filter.allows(ctx, "peer-unknown", zonePhasePreferred))
+
+	assert.False(t, filter.allows(ctx, "peer-eu", zonePhaseOthers))
+	assert.True(t, filter.allows(ctx, "peer-us", zonePhaseOthers))
+	assert.True(t, filter.allows(ctx, "peer-unknown", zonePhaseOthers))
+
+	assert.False(t, filter.excludes(ctx, "peer-us"))
+}
+
+func TestZoneFilter_Only(t *testing.T) {
+	ctx := t.Context()
+	filter := newTestZoneFilter([]string{"eu-west-1", "eu-central-1"}, true)
+
+	assert.Equal(t, []zonePhase{zonePhasePreferred}, filter.phases(zonePhasePreferred))
+
+	assert.True(t, filter.allows(ctx, "peer-eu", zonePhasePreferred))
+	assert.False(t, filter.allows(ctx, "peer-us", zonePhasePreferred))
+
+	assert.False(t, filter.excludes(ctx, "peer-eu"))
+	assert.True(t, filter.excludes(ctx, "peer-us"))
+	assert.True(t, filter.excludes(ctx, "peer-unknown"), "peers without a zone are excluded")
+}
+
+func TestZoneFilter_NoZones(t *testing.T) {
+	filter := newTestZoneFilter(nil, false)
+
+	assert.Equal(t, []zonePhase{zonePhasePreferred}, filter.phases(zonePhasePreferred))
+	assert.True(t, filter.allows(t.Context(), "peer-us", zonePhasePreferred))
+	assert.False(t, filter.excludes(t.Context(), "peer-unknown"))
+}
+
+func TestValidateZone(t *testing.T) {
+	for _, zone := range []string{"eu-west-1", "us-east-1a", "dc1.rack_2", "z"} {
+		assert.NoError(t, validateZone(zone), zone)
+	}
+
+	for _, zone := range []string{"", "eu/west", "-eu", "eu west"} {
+		assert.Error(t, validateZone(zone), zone)
+	}
+}
@@ -5,8 +5,10 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -22,13 +24,19 @@ import (
 	"github.com/agntcy/dir/server/config"
 	"github.com/agntcy/dir/server/controller"
 	"github.com/agntcy/dir/server/database"
+	"github.com/agntcy/dir/server/gateway"
+	"github.com/agntcy/dir/server/metrics"
 	"github.com/agntcy/dir/server/publication"
 	"github.com/agntcy/dir/server/routing"
 	"github.com/agntcy/dir/server/store"
 	"github.com/agntcy/dir/server/sync"
+	"github.com/agntcy/dir/server/tracing"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -47,6 +55,11 @@ type Server struct {
 	authzService       *authz.Service
 	publicationService *publication.Service
 	healthzServer      *healthz.Server
+	healthServer       *health.Server
+	healthDone         chan struct{}
+	metricsServer      *metrics.Server
+	gatewayServer      *gateway.Server
+	tracingProvider    *tracing.Provider
 	grpcServer         *grpc.Server
 }
 
@@ -85,6 +98,21 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 	options := types.NewOptions(cfg)
 	serverOpts := []grpc.ServerOption{}
 
+	// Export spans if enabled, before any component starts tracing.
+	// Incoming gRPC calls continue the trace context of their callers.
+	var tracingProvider *tracing.Provider
+
+	if cfg.Tracing.Enabled() {
+		var err error
+
+		tracingProvider, err = tracing.New(ctx, cfg.Tracing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create tracing provider: %w", err)
+		}
+
+		serverOpts = append(serverOpts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
+	}
+
 	// Create APIs
 	storeAPI, err := store.New(options) //nolint:staticcheck
 	if err != nil {
@@ -101,6 +129,11 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to create database API: %w", err)
 	}
 
+	// Remove local records garbage collected by routing from the search database
+	routingAPI.OnCollect(func(_ context.Context, cid string) error {
+		return databaseAPI.RemoveRecord(cid) //nolint:wrapcheck
+	})
+
 	// Create services
 	syncService, err := sync.New(databaseAPI, storeAPI, options)
 	if err != nil {
@@ -140,16 +173,63 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 	grpcServer := grpc.NewServer(serverOpts...)
 
 	// Register APIs
-	storev1.RegisterStoreServiceServer(grpcServer, controller.NewStoreController(storeAPI, databaseAPI))
-	routingv1.RegisterRoutingServiceServer(grpcServer, controller.NewRoutingController(routingAPI, storeAPI, publicationService))
+	storev1.RegisterStoreServiceServer(grpcServer, controller.NewStoreController(storeAPI, databaseAPI, routingAPI.Retract))
+	// Filter search results by label namespace policies and derive the tenants of
+	// callers from their identity when authorization is enabled
+	var (
+		labelAuthorizer  controller.LabelNamespaceAuthorizer
+		tenantAuthorizer controller.TenantAuthorizer
+	)
+
+	if authzService != nil {
+		labelAuthorizer = authzService
+		tenantAuthorizer = authzService
+	}
+
+	routingController, err := controller.NewRoutingController(routingAPI, storeAPI, publicationService, labelAuthorizer, tenantAuthorizer, cfg.Routing.PeerRedaction)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create routing controller: %w", err)
+	}
+
+	routingv1.RegisterRoutingServiceServer(grpcServer, routingController)
 	routingv1.RegisterPublicationServiceServer(grpcServer, controller.NewPublicationController(databaseAPI, options))
+	routingv1.RegisterRoutingAdminServiceServer(grpcServer, controller.NewRoutingAdminController(routingAPI))
 	searchv1.RegisterSearchServiceServer(grpcServer, controller.NewSearchController(databaseAPI))
 	storev1.RegisterSyncServiceServer(grpcServer, controller.NewSyncController(databaseAPI, options))
 	signv1.RegisterSignServiceServer(grpcServer, controller.NewSignController(storeAPI))
 
+	// Report the readiness of the server and its subsystems via the gRPC health service
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
 	// Register server
 	reflection.Register(grpcServer)
 
+	// Serve Prometheus metrics if enabled
+	var metricsServer *metrics.Server
+	if cfg.MetricsAddress != "" {
+		metricsServer = metrics.NewServer(cfg.MetricsAddress)
+	}
+
+	// Serve the routing HTTP gateway if enabled, applying the same authn and authz as gRPC
+	var gatewayServer *gateway.Server
+	if cfg.GatewayAddress != "" {
+		var (
+			gatewayAuthn gateway.Authenticator
+			gatewayAuthz gateway.Authorizer
+		)
+
+		if authnService != nil {
+			gatewayAuthn = authnService
+		}
+
+		if authzService != nil {
+			gatewayAuthz = authzService
+		}
+
+		gatewayServer = gateway.NewServer(cfg.GatewayAddress, routingController, gatewayAuthn, gatewayAuthz)
+	}
+
 	return &Server{
 		options:            options,
 		store:              storeAPI,
@@ -160,6 +240,11 @@ func New(ctx context.Context, cfg *config.Config) (*Server, error) {
 		authzService:       authzService,
 		publicationService: publicationService,
 		healthzServer:      healthz.NewHealthServer(cfg.HealthCheckAddress),
+		healthServer:       healthServer,
+		healthDone:         make(chan struct{}),
+		metricsServer:      metricsServer,
+		gatewayServer:      gatewayServer,
+		tracingProvider:    tracingProvider,
 		grpcServer:         grpcServer,
 	}, nil
 }
@@ -208,7 +293,32 @@ func (s Server) Close() {
 		}
 	}
 
+	// Stop metrics server if running
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Stop(context.Background()); err != nil {
+			logger.Error("Failed to stop metrics server", "error", err)
+		}
+	}
+
+	// Stop gateway server if running
+	if s.gatewayServer != nil {
+		if err := s.gatewayServer.Stop(context.Background()); err != nil {
+			logger.Error("Failed to stop gateway server", "error", err)
+		}
+	}
+
+	// Report not serving to health checks while connections drain
+	close(s.healthDone)
+	s.healthServer.Shutdown()
+
 	s.grpcServer.GracefulStop()
+
+	// Flush pending spans last, including those of shutdown
+	if s.tracingProvider != nil {
+		if err := s.tracingProvider.Stop(context.Background()); err != nil {
+			logger.Error("Failed to stop tracing provider", "error", err)
+		}
+	}
 }
 
 func (s Server) start(ctx context.Context) error {
@@ -230,6 +340,23 @@ func (s Server) start(ctx context.Context) error {
 		logger.Info("Publication service started")
 	}
 
+	// Start metrics server
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Start(); err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+	}
+
+	// Start gateway server
+	if s.gatewayServer != nil {
+		if err := s.gatewayServer.Start(); err != nil {
+			return fmt.Errorf("failed to start gateway server: %w", err)
+		}
+	}
+
+	// Report subsystem readiness to the gRPC health service
+	s.startHealthReporting(ctx)
+
 	// Create a listener on TCP port
 	listen, err := net.Listen("tcp", s.Options().Config().ListenAddress) //nolint:noctx
 	if err != nil {
@@ -239,7 +366,12 @@ func (s Server) start(ctx context.Context) error {
 	// Serve gRPC server in the background.
 	// If the server cannot be started, exit with code 1.
 	go func() {
-		// Start health check server
+		// Start health check server, reporting routing functionality next to liveness and readiness.
+		// The server is ready while the DHT bootstraps, as local operations are available.
+		// /healthz and /readyz additionally report the readiness of each subsystem.
+		http.HandleFunc("/healthz/routing", s.serveRoutingReadiness)
+		http.HandleFunc("/healthz", s.serveHealth)
+		http.HandleFunc("/readyz", s.serveReadiness)
 		s.healthzServer.Start()
 
 		s.healthzServer.SetIsReady(true)
@@ -254,3 +386,25 @@ func (s Server) start(ctx context.Context) error {
 
 	return nil
 }
+
+// serveRoutingReadiness reports which routing functionality is available.
+// It responds with 503 while records cannot be announced to the network,
+// e.g. during DHT bootstrap, and with 200 otherwise.
+func (s Server) serveRoutingReadiness(w http.ResponseWriter, _ *http.Request) {
+	readiness := s.routing.Readiness()
+
+	response := struct {
+		Status string `json:"status"`
+		types.RoutingReadiness
+	}{Status: "ready", RoutingReadiness: readiness}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !readiness.NetworkReady() {
+		response.Status = "degraded"
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -24,6 +24,11 @@ type Config struct {
 	// Repository name to connect to
 	RepositoryName string `json:"repository_name,omitempty" mapstructure:"repository_name"`
 
+	// Path to a base64-encoded AES-256 key used to encrypt records at rest.
+	// If set, records are stored encrypted and the cache directory is not used.
+	// Manifest annotations, and thus record labels, are not encrypted.
+	EncryptionKeyPath string `json:"encryption_key_path,omitempty" mapstructure:"encryption_key_path"`
+
 	// Authentication configuration
 	AuthConfig `json:"auth_config,omitempty" mapstructure:"auth_config"`
 }
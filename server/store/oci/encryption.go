@@ -0,0 +1,80 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	// encryptedRecordMediaType is the layer media type of records encrypted at rest.
+	encryptedRecordMediaType = "application/vnd.agntcy.dir.record.v1+json+encrypted"
+
+	// encryptionKeySize is the size of the AES-256 record encryption key.
+	encryptionKeySize = 32
+)
+
+// loadEncryptionKey reads the base64-encoded AES-256 key used to encrypt records at rest.
+func loadEncryptionKey(path string) (cipher.AEAD, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption key: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encryption key: %w", err)
+	}
+
+	if len(key) != encryptionKeySize {
+		return nil, fmt.Errorf("invalid encryption key size %d, expected %d bytes", len(key), encryptionKeySize)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+// encryptRecord encrypts the canonical bytes of a record with AES-GCM.
+// The CID is authenticated, so that the ciphertext of one record cannot be served as another.
+// Format: nonce || ciphertext.
+func encryptRecord(aead cipher.AEAD, cid string, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, []byte(cid)), nil
+}
+
+// decryptRecord decrypts a record encrypted by encryptRecord.
+func decryptRecord(aead cipher.AEAD, cid string, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted record is too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(cid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt record: %w", err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,91 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	typesv1alpha0 "buf.build/gen/go/agntcy/oasf/protocolbuffers/go/agntcy/oasf/types/v1alpha0"
+	corev1 "github.com/agntcy/dir/api/core/v1"
+	ociconfig "github.com/agntcy/dir/server/store/oci/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStoreEncryption(t *testing.T) {
+	dir := t.TempDir()
+
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(dir, "record.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+
+	repoDir := filepath.Join(dir, "repo")
+
+	encrypted, err := New(ociconfig.Config{LocalDir: repoDir, EncryptionKeyPath: keyPath})
+	require.NoError(t, err)
+
+	record := corev1.New(&typesv1alpha0.Record{
+		Name:          "confidential-agent",
+		SchemaVersion: "v0.3.1",
+		Description:   "A confidential description",
+	})
+
+	// The CID is derived from the plaintext record
+	ref, err := encrypted.Push(testCtx, record)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), ref.GetCid())
+
+	pulled, err := encrypted.Pull(testCtx, ref)
+	require.NoError(t, err)
+	assert.Equal(t, record.GetCid(), pulled.GetCid())
+
+	// The stored blob does not contain the plaintext
+	s, ok := encrypted.(*store)
+	require.True(t, ok)
+
+	manifest, _, err := s.fetchAndParseManifest(testCtx, ref.GetCid())
+	require.NoError(t, err)
+	require.Len(t, manifest.Layers, 1)
+	assert.Equal(t, encryptedRecordMediaType, manifest.Layers[0].MediaType)
+
+	reader, err := s.repo.Fetch(testCtx, manifest.Layers[0])
+	require.NoError(t, err)
+
+	blob, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	assert.NotContains(t, string(blob), "A confidential description")
+
+	// Encrypted records cannot be pulled without the key
+	plain, err := New(ociconfig.Config{LocalDir: repoDir})
+	require.NoError(t, err)
+
+	_, err = plain.Pull(testCtx, ref)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Deleting an encrypted record removes its blob
+	require.NoError(t, encrypted.Delete(testCtx, ref))
+
+	exists, err := s.repo.Exists(testCtx, manifest.Layers[0])
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestLoadEncryptionKey_InvalidSize(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "record.key")
+	require.NoError(t, os.WriteFile(keyPath, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0o600))
+
+	_, err := loadEncryptionKey(keyPath)
+	assert.ErrorContains(t, err, "invalid encryption key size")
+}
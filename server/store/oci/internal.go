@@ -105,7 +105,10 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 
 	internalLogger.Debug("Starting OCI store deletion", "cid", cid)
 
-	var errors []string
+	var (
+		errors []string
+		layers []ocispec.Descriptor
+	)
 
 	// Phase 1: Delete manifest (tags will be cleaned up by OCI GC)
 	internalLogger.Debug("Phase 1: Deleting manifest", "cid", cid)
@@ -116,6 +119,11 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 		internalLogger.Debug("Failed to resolve manifest during delete (may already be deleted)", "cid", cid, "error", err)
 		errors = append(errors, fmt.Sprintf("manifest resolve: %v", err))
 	} else {
+		// Remember the record blobs, encrypted blobs are not addressed by the CID
+		if manifest, err := s.fetchAndParseManifestFromDescriptor(ctx, manifestDesc); err == nil {
+			layers = manifest.Layers
+		}
+
 		if err := store.Delete(ctx, manifestDesc); err != nil {
 			internalLogger.Warn("Failed to delete manifest", "cid", cid, "error", err)
 			errors = append(errors, fmt.Sprintf("manifest delete: %v", err))
@@ -127,7 +135,7 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 	// Phase 2: Remove blob data (local store - we have full control)
 	internalLogger.Debug("Phase 2: Deleting blob data", "cid", cid)
 
-	if err := s.deleteBlobForLocalStore(ctx, cid, store); err != nil {
+	if err := s.deleteBlobForLocalStore(ctx, cid, layers, store); err != nil {
 		internalLogger.Warn("Failed to delete blob", "cid", cid, "error", err)
 		errors = append(errors, fmt.Sprintf("blob delete: %v", err))
 	}
@@ -144,23 +152,26 @@ func (s *store) deleteFromOCIStore(ctx context.Context, ref *corev1.RecordRef) e
 	return nil // Best effort - don't fail on partial cleanup
 }
 
-// deleteBlobForLocalStore safely deletes blob data from local OCI store using new CID utility.
-func (s *store) deleteBlobForLocalStore(ctx context.Context, cid string, store *oci.Store) error {
-	// Convert CID to digest using our new utility function
-	ociDigest, err := corev1.ConvertCIDToDigest(cid)
-	if err != nil {
-		return fmt.Errorf("failed to convert CID to digest: %w", err)
-	}
+// deleteBlobForLocalStore safely deletes blob data from local OCI store.
+// It deletes the layers of the record's manifest, or the blob addressed by the CID if they are unknown.
+func (s *store) deleteBlobForLocalStore(ctx context.Context, cid string, layers []ocispec.Descriptor, store *oci.Store) error {
+	if len(layers) == 0 {
+		// Convert CID to digest using our new utility function
+		ociDigest, err := corev1.ConvertCIDToDigest(cid)
+		if err != nil {
+			return fmt.Errorf("failed to convert CID to digest: %w", err)
+		}
 
-	blobDesc := ocispec.Descriptor{
-		Digest: ociDigest,
+		layers = []ocispec.Descriptor{{Digest: ociDigest}}
 	}
 
-	if err := store.Delete(ctx, blobDesc); err != nil {
-		return fmt.Errorf("failed to delete blob: %w", err)
-	}
+	for _, blobDesc := range layers {
+		if err := store.Delete(ctx, blobDesc); err != nil {
+			return fmt.Errorf("failed to delete blob: %w", err)
+		}
 
-	internalLogger.Debug("Blob deleted successfully", "cid", cid, "digest", ociDigest.String())
+		internalLogger.Debug("Blob deleted successfully", "cid", cid, "digest", blobDesc.Digest.String())
+	}
 
 	return nil
 }
@@ -5,6 +5,7 @@ package oci
 
 import (
 	"context"
+	"crypto/cipher"
 	"fmt"
 	"io"
 
@@ -14,6 +15,7 @@ import (
 	ociconfig "github.com/agntcy/dir/server/store/oci/config"
 	"github.com/agntcy/dir/server/types"
 	"github.com/agntcy/dir/utils/logging"
+	ocidigest "github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -27,11 +29,23 @@ var logger = logging.Logger("store/oci")
 type store struct {
 	repo   oras.GraphTarget
 	config ociconfig.Config
+	aead   cipher.AEAD // Encrypts records at rest, nil if disabled
 }
 
 func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 	logger.Debug("Creating OCI store with config", "config", cfg)
 
+	var aead cipher.AEAD
+
+	if cfg.EncryptionKeyPath != "" {
+		var err error
+
+		aead, err = loadEncryptionKey(cfg.EncryptionKeyPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// if local dir used, return client for that local path.
 	// allows mounting of data via volumes
 	// allows S3 usage for backup store
@@ -44,6 +58,7 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 		return &store{
 			repo:   repo,
 			config: cfg,
+			aead:   aead,
 		}, nil
 	}
 
@@ -56,6 +71,7 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 	store := &store{
 		repo:   repo,
 		config: cfg,
+		aead:   aead,
 	}
 
 	// If no cache requested, return.
@@ -64,6 +80,13 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 		return store, nil
 	}
 
+	// Do not cache encrypted records in plaintext.
+	if aead != nil {
+		logger.Warn("Ignoring cache directory, records are encrypted at rest", "cache_dir", cfg.CacheDir)
+
+		return store, nil
+	}
+
 	// Create cache datastore
 	cacheDS, err := datastore.New(datastore.WithFsProvider(cfg.CacheDir))
 	if err != nil {
@@ -81,6 +104,9 @@ func New(cfg ociconfig.Config) (types.StoreAPI, error) {
 // The tag for the blob is needed to link the actual record with its associated metadata.
 // Note that metadata can be stored in a different store and only wrap this store.
 //
+// If an encryption key is configured, the blob holds the encrypted record. The CID is still
+// derived from the canonical bytes, so that it matches the CID announced to the network.
+//
 // Ref: https://github.com/oras-project/oras-go/blob/main/docs/Modeling-Artifacts.md
 func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.RecordRef, error) {
 	logger.Debug("Pushing record to OCI store", "record", record)
@@ -92,14 +118,10 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 		return nil, status.Errorf(codes.Internal, "failed to marshal record: %v", err)
 	}
 
-	// Step 1: Use oras.PushBytes to push the record data and get Layer Descriptor
-	layerDesc, err := oras.PushBytes(ctx, s.repo, "application/json", recordBytes)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to push record bytes: %v", err)
-	}
+	// Step 1: Calculate CID from the digest of the canonical bytes using our new utility function
+	recordDigest := ocidigest.FromBytes(recordBytes)
 
-	// Step 2: Calculate CID from Layer Descriptor's digest using our new utility function
-	recordCID, err := corev1.ConvertDigestToCID(layerDesc.Digest)
+	recordCID, err := corev1.ConvertDigestToCID(recordDigest)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to convert digest to CID: %v", err)
 	}
@@ -114,10 +136,10 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 
 	logger.Debug("CID validation successful",
 		"cid", recordCID,
-		"digest", layerDesc.Digest.String(),
+		"digest", recordDigest.String(),
 		"validation", "ORAS digest CID matches Record CID")
 
-	logger.Debug("Calculated CID from ORAS digest", "cid", recordCID, "digest", layerDesc.Digest.String())
+	logger.Debug("Calculated CID from ORAS digest", "cid", recordCID, "digest", recordDigest.String())
 
 	// Create record reference
 	recordRef := &corev1.RecordRef{Cid: recordCID}
@@ -129,6 +151,22 @@ func (s *store) Push(ctx context.Context, record *corev1.Record) (*corev1.Record
 		return recordRef, nil
 	}
 
+	// Step 2: Use oras.PushBytes to push the record data and get Layer Descriptor
+	layerMediaType, layerBytes := "application/json", recordBytes
+	if s.aead != nil {
+		layerMediaType = encryptedRecordMediaType
+
+		layerBytes, err = encryptRecord(s.aead, recordCID, recordBytes)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encrypt record: %v", err)
+		}
+	}
+
+	layerDesc, err := oras.PushBytes(ctx, s.repo, layerMediaType, layerBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to push record bytes: %v", err)
+	}
+
 	// Step 3: Construct manifest annotations and add CID to annotations
 	manifestAnnotations := extractManifestAnnotations(record)
 	// Add the calculated CID to manifest annotations for discovery
@@ -230,7 +268,7 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 	blobDesc := manifest.Layers[0]
 
 	// Validate layer media type
-	if blobDesc.MediaType != "application/json" {
+	if blobDesc.MediaType != "application/json" && blobDesc.MediaType != encryptedRecordMediaType {
 		logger.Warn("Unexpected blob media type",
 			"cid", ref.GetCid(),
 			"expected", "application/json",
@@ -264,6 +302,14 @@ func (s *store) Pull(ctx context.Context, ref *corev1.RecordRef) (*corev1.Record
 			"actual", len(recordData))
 	}
 
+	// Decrypt records encrypted at rest
+	if blobDesc.MediaType == encryptedRecordMediaType {
+		recordData, err = s.decryptRecord(ref.GetCid(), recordData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Unmarshal canonical JSON data back to Record
 	record, err := corev1.UnmarshalRecord(recordData)
 	if err != nil {
@@ -296,3 +342,22 @@ func (s *store) Delete(ctx context.Context, ref *corev1.RecordRef) error {
 		return status.Errorf(codes.FailedPrecondition, "unsupported repo type: %T", s.repo)
 	}
 }
+
+// decryptRecord decrypts a record encrypted at rest and checks that it matches its CID.
+func (s *store) decryptRecord(cid string, data []byte) ([]byte, error) {
+	if s.aead == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "record %s is encrypted but no encryption key is configured", cid)
+	}
+
+	recordData, err := decryptRecord(s.aead, cid, data)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "failed to decrypt record for CID %s: %v", cid, err)
+	}
+
+	recordCID, err := corev1.ConvertDigestToCID(ocidigest.FromBytes(recordData))
+	if err != nil || recordCID != cid {
+		return nil, status.Errorf(codes.DataLoss, "decrypted record does not match CID %s", cid)
+	}
+
+	return recordData, nil
+}
@@ -0,0 +1,30 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+const (
+	DefaultServiceName = "dir-apiserver"
+	DefaultSampleRatio = 1.0
+)
+
+type Config struct {
+	// OTLP/gRPC endpoint spans are exported to, e.g. otel-collector:4317.
+	// If empty, tracing is disabled.
+	Endpoint string `json:"endpoint,omitempty" mapstructure:"endpoint"`
+
+	// Export spans without TLS.
+	Insecure bool `json:"insecure,omitempty" mapstructure:"insecure"`
+
+	// Service name spans are reported under.
+	ServiceName string `json:"service_name,omitempty" mapstructure:"service_name"`
+
+	// Ratio of traces started by this server that are sampled, between 0 and 1.
+	// Traces started by callers follow the sampling decision of the caller.
+	SampleRatio float64 `json:"sample_ratio,omitempty" mapstructure:"sample_ratio"`
+}
+
+// Enabled reports whether spans are exported.
+func (c Config) Enabled() bool {
+	return c.Endpoint != ""
+}
@@ -0,0 +1,87 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing configures OpenTelemetry tracing of the directory server.
+//
+// Spans are exported over OTLP/gRPC and the W3C trace context is propagated
+// across the gRPC API and the libp2p RPC between peers, so that a publish,
+// pull or search can be followed from the client through remote peers.
+// Packages create spans through otel.Tracer; they are discarded unless
+// tracing is enabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/agntcy/dir/api/version"
+	"github.com/agntcy/dir/server/tracing/config"
+	"github.com/agntcy/dir/utils/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var logger = logging.Logger("tracing")
+
+// Provider exports the spans of the server.
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// New installs a global tracer provider and trace context propagator exporting spans
+// to the configured endpoint. The exporter connects lazily, so an unreachable collector
+// does not prevent startup.
+func New(ctx context.Context, cfg config.Config) (*Provider, error) {
+	if !cfg.Enabled() {
+		return nil, errors.New("tracing endpoint is not set")
+	}
+
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid sample ratio %v: must be between 0 and 1", cfg.SampleRatio)
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = config.DefaultServiceName
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", version.String()),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	logger.Info("Tracing enabled", "endpoint", cfg.Endpoint, "sampleRatio", cfg.SampleRatio)
+
+	return &Provider{provider: provider}, nil
+}
+
+// Stop flushes pending spans and stops exporting.
+func (p *Provider) Stop(ctx context.Context) error {
+	if err := p.provider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+	}
+
+	return nil
+}
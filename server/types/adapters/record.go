@@ -27,6 +27,21 @@ func (r *RecordAdapter) GetCid() string {
 	return r.record.GetCid()
 }
 
+// Marshal returns the canonical bytes of the record, from which its CID is calculated.
+func (r *RecordAdapter) Marshal() ([]byte, error) {
+	data, err := r.record.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	return data, nil
+}
+
+// Validate validates the record against its OASF schema, returning the validation errors if it is invalid.
+func (r *RecordAdapter) Validate() (bool, []string, error) {
+	return r.record.Validate() //nolint:wrapcheck
+}
+
 // GetRecordData implements types.Record interface.
 func (r *RecordAdapter) GetRecordData() (types.RecordData, error) {
 	// Decode record
@@ -9,8 +9,11 @@ package types
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // LabelType represents the category of a label based on its namespace.
@@ -40,21 +43,27 @@ func (lt LabelType) Prefix() string {
 	return "/" + string(lt) + "/"
 }
 
-// IsValid checks if the label type is one of the supported types.
+// IsValid checks if the label type is a built-in or registered custom type.
 func (lt LabelType) IsValid() bool {
-	switch lt {
-	case LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator:
+	if slices.Contains(builtinLabelTypes, lt) {
 		return true
-	case LabelTypeUnknown:
-		return false
-	default:
-		return false
 	}
+
+	_, ok := LookupLabelNamespace(lt)
+
+	return ok
 }
 
-// AllLabelTypes returns all supported label types.
+// AllLabelTypes returns all supported label types: the built-in types
+// followed by the registered custom types in registration order.
 func AllLabelTypes() []LabelType {
-	return []LabelType{LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator}
+	labelTypes := slices.Clone(builtinLabelTypes)
+
+	for _, ns := range CustomLabelNamespaces() {
+		labelTypes = append(labelTypes, ns.Type)
+	}
+
+	return labelTypes
 }
 
 // ParseLabelType converts a string to LabelType if valid.
@@ -88,18 +97,13 @@ func (l Label) Bytes() []byte {
 func (l Label) Type() LabelType {
 	s := string(l)
 
-	switch {
-	case strings.HasPrefix(s, LabelTypeSkill.Prefix()):
-		return LabelTypeSkill
-	case strings.HasPrefix(s, LabelTypeDomain.Prefix()):
-		return LabelTypeDomain
-	case strings.HasPrefix(s, LabelTypeModule.Prefix()):
-		return LabelTypeModule
-	case strings.HasPrefix(s, LabelTypeLocator.Prefix()):
-		return LabelTypeLocator
-	default:
-		return LabelTypeUnknown
+	for _, lt := range AllLabelTypes() {
+		if strings.HasPrefix(s, lt.Prefix()) {
+			return lt
+		}
 	}
+
+	return LabelTypeUnknown
 }
 
 // Namespace returns the namespace prefix of the label.
@@ -123,8 +127,16 @@ func (l Label) Value() string {
 // The label itself is stored in the datastore key structure: /skills/AI/CID123/Peer1
 // where the metadata tracks when the label was first announced and last seen.
 type LabelMetadata struct {
-	Timestamp time.Time `json:"timestamp"` // When label was first announced
-	LastSeen  time.Time `json:"last_seen"` // When label was last seen/refreshed
+	Timestamp   time.Time `json:"timestamp"`              // When label was first announced
+	LastSeen    time.Time `json:"last_seen"`              // When label was last seen/refreshed
+	ExpiresAt   time.Time `json:"expires_at,omitzero"`    // When the record's TTL elapses (zero if it never expires)
+	Supersedes  string    `json:"supersedes,omitempty"`   // CID of the previous version of the record (empty if none)
+	AccessGated bool      `json:"access_gated,omitempty"` // Whether the record's content is only served to authorized peers
+	Size        uint64    `json:"size,omitempty"`         // Size of the record's canonical content in bytes (zero if unknown)
+	Publisher   string    `json:"publisher,omitempty"`    // did:key of the publisher that signed the announcement (empty if the peer itself)
+
+	// Compact metadata of the record, so that search results can be rendered without pulling it
+	RecordSummary
 }
 
 // Validate checks if the metadata is valid and all required fields are properly set.
@@ -149,6 +161,12 @@ func (m *LabelMetadata) IsStale(maxAge time.Duration) bool {
 	return time.Since(m.LastSeen) > maxAge
 }
 
+// IsExpired checks if the record's TTL has elapsed at the given time.
+// Labels without an expiration never expire.
+func (m *LabelMetadata) IsExpired(now time.Time) bool {
+	return !m.ExpiresAt.IsZero() && !now.Before(m.ExpiresAt)
+}
+
 // Age returns how long ago the label was last seen.
 func (m *LabelMetadata) Age() time.Duration {
 	return time.Since(m.LastSeen)
@@ -159,6 +177,61 @@ func (m *LabelMetadata) Update() {
 	m.LastSeen = time.Now()
 }
 
+// MaxRecordSummaryFieldLength is the maximum length in bytes of each record summary field.
+const MaxRecordSummaryFieldLength = 256
+
+// RecordSummary is compact metadata of a record carried by its labels.
+// Fields are empty if unknown.
+type RecordSummary struct {
+	Name        string `json:"name,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Description string `json:"description,omitempty"` // Truncated to MaxRecordSummaryFieldLength
+}
+
+// NewRecordSummary returns the summary of a record, with each field truncated
+// to MaxRecordSummaryFieldLength. The summary is empty if the record data cannot be read.
+func NewRecordSummary(record Record) RecordSummary {
+	data, err := record.GetRecordData()
+	if err != nil || data == nil {
+		return RecordSummary{}
+	}
+
+	return RecordSummary{
+		Name:        truncateSummaryField(data.GetName()),
+		Version:     truncateSummaryField(data.GetVersion()),
+		Description: truncateSummaryField(data.GetDescription()),
+	}
+}
+
+// Validate checks that the summary fields are within MaxRecordSummaryFieldLength.
+func (s RecordSummary) Validate() error {
+	switch {
+	case len(s.Name) > MaxRecordSummaryFieldLength:
+		return fmt.Errorf("record name exceeds %d bytes", MaxRecordSummaryFieldLength)
+	case len(s.Version) > MaxRecordSummaryFieldLength:
+		return fmt.Errorf("record version exceeds %d bytes", MaxRecordSummaryFieldLength)
+	case len(s.Description) > MaxRecordSummaryFieldLength:
+		return fmt.Errorf("record description exceeds %d bytes", MaxRecordSummaryFieldLength)
+	default:
+		return nil
+	}
+}
+
+// truncateSummaryField truncates a value to MaxRecordSummaryFieldLength bytes
+// without splitting a UTF-8 sequence.
+func truncateSummaryField(value string) string {
+	if len(value) <= MaxRecordSummaryFieldLength {
+		return value
+	}
+
+	end := MaxRecordSummaryFieldLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+
+	return value[:end]
+}
+
 // Constants for label validation and processing.
 const (
 	// Enhanced format: /type/label/CID/PeerID splits into ["", "type", "label", "CID", "PeerID"] = 5 parts.
@@ -178,8 +251,9 @@ const (
 //	labels := types.GetLabelsFromRecord(adapter)
 //
 // Returns:
-//   - []Label: List of all labels extracted from the record
-//   - nil: If record is nil, has no data, or doesn't implement LabelProvider
+//   - []Label: List of all labels extracted from the record, including custom namespace labels,
+//     normalized by the label normalizer if one is set
+//   - nil: If record is nil, has no data, or has no labels
 func GetLabelsFromRecord(record Record) []Label {
 	if record == nil {
 		return nil
@@ -190,9 +264,14 @@ func GetLabelsFromRecord(record Record) []Label {
 		return nil
 	}
 
+	var labels []Label
+
 	if provider, ok := recordData.(LabelProvider); ok {
-		return provider.GetAllLabels()
+		labels = provider.GetAllLabels()
 	}
 
-	return nil
+	// Labels of custom namespaces are carried in record annotations
+	labels = append(labels, customLabelsFromAnnotations(recordData.GetAnnotations())...)
+
+	return NormalizeLabels(labels)
 }
@@ -0,0 +1,176 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// LabelPolicy selects the labels of a namespace that are published for local records,
+// so that publishers can withhold labels such as internal module names from announcements.
+//
+// Patterns are matched against label values without the namespace prefix with path.Match,
+// e.g. "internal/*" for "/modules/internal/auth". A pattern matching a label also matches
+// its descendants, so "internal" matches "internal/auth" as well.
+type LabelPolicy struct {
+	// Namespace the policy applies to, e.g. "modules".
+	Namespace string `json:"namespace"`
+
+	// Include lists the patterns of the labels that are published.
+	// Empty publishes all labels that are not excluded.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude lists the patterns of the labels that are withheld, taking precedence over Include.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// LabelPoliciesFromProto converts the label policies of a publish request.
+func LabelPoliciesFromProto(policies []*routingv1.LabelPolicy) []LabelPolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	converted := make([]LabelPolicy, 0, len(policies))
+
+	for _, policy := range policies {
+		converted = append(converted, LabelPolicy{
+			Namespace: policy.GetNamespace(),
+			Include:   policy.GetInclude(),
+			Exclude:   policy.GetExclude(),
+		})
+	}
+
+	return converted
+}
+
+// Validate checks that the policy names a namespace and that its patterns are well-formed.
+// Whether the namespace exists is up to the caller, as custom namespaces may not be registered yet.
+func (p LabelPolicy) Validate() error {
+	if p.Namespace == "" {
+		return errors.New("label policy has no namespace")
+	}
+
+	for _, pattern := range slices.Concat(p.Include, p.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			return fmt.Errorf("invalid label pattern %q in %s label policy", pattern, p.Namespace)
+		}
+	}
+
+	return nil
+}
+
+// Allows reports whether the policy publishes a label value of its namespace.
+func (p LabelPolicy) Allows(value string) bool {
+	for _, pattern := range p.Exclude {
+		if matchesLabelPattern(pattern, value) {
+			return false
+		}
+	}
+
+	if len(p.Include) == 0 {
+		return true
+	}
+
+	for _, pattern := range p.Include {
+		if matchesLabelPattern(pattern, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Equal reports whether two policies are the same.
+func (p LabelPolicy) Equal(other LabelPolicy) bool {
+	return p.Namespace == other.Namespace && slices.Equal(p.Include, other.Include) && slices.Equal(p.Exclude, other.Exclude)
+}
+
+// matchesLabelPattern reports whether a pattern matches a label value or one of its ancestors.
+func matchesLabelPattern(pattern, value string) bool {
+	for {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+
+		i := strings.LastIndex(value, "/")
+		if i < 0 {
+			return false
+		}
+
+		value = value[:i]
+	}
+}
+
+// ValidateLabelPolicies checks the policies and that no namespace has more than one.
+func ValidateLabelPolicies(policies []LabelPolicy) error {
+	seen := make(map[string]struct{}, len(policies))
+
+	for _, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+
+		if _, dup := seen[policy.Namespace]; dup {
+			return fmt.Errorf("duplicate label policy for namespace %q", policy.Namespace)
+		}
+
+		seen[policy.Namespace] = struct{}{}
+	}
+
+	return nil
+}
+
+// MergeLabelPolicies returns the base policies with those of the namespaces of the overrides replaced.
+func MergeLabelPolicies(base, overrides []LabelPolicy) []LabelPolicy {
+	if len(overrides) == 0 {
+		return base
+	}
+
+	merged := make([]LabelPolicy, 0, len(base)+len(overrides))
+
+	for _, policy := range base {
+		if !slices.ContainsFunc(overrides, func(override LabelPolicy) bool { return override.Namespace == policy.Namespace }) {
+			merged = append(merged, policy)
+		}
+	}
+
+	return append(merged, overrides...)
+}
+
+// FilterLabels returns the labels allowed by the policy of their namespace, in order.
+// Labels of namespaces without a policy are kept.
+func FilterLabels(labels []Label, policies []LabelPolicy) []Label {
+	if len(policies) == 0 {
+		return labels
+	}
+
+	filtered := make([]Label, 0, len(labels))
+
+	for _, label := range labels {
+		if allowedByPolicies(label, policies) {
+			filtered = append(filtered, label)
+		}
+	}
+
+	return filtered
+}
+
+// allowedByPolicies reports whether the policy of the label's namespace, if any, allows it.
+func allowedByPolicies(label Label, policies []LabelPolicy) bool {
+	labelType := label.Type()
+
+	for _, policy := range policies {
+		if policy.Namespace == labelType.String() {
+			return policy.Allows(label.Value())
+		}
+	}
+
+	return true
+}
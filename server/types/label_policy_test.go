@@ -0,0 +1,84 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  LabelPolicy
+		wantErr bool
+	}{
+		{name: "valid", policy: LabelPolicy{Namespace: "modules", Include: []string{"runtime/*"}, Exclude: []string{"internal"}}},
+		{name: "no patterns", policy: LabelPolicy{Namespace: "modules"}},
+		{name: "no namespace", policy: LabelPolicy{Exclude: []string{"internal"}}, wantErr: true},
+		{name: "empty pattern", policy: LabelPolicy{Namespace: "modules", Include: []string{""}}, wantErr: true},
+		{name: "malformed pattern", policy: LabelPolicy{Namespace: "modules", Exclude: []string{"internal/["}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLabelPolicy_Allows(t *testing.T) {
+	policy := LabelPolicy{
+		Namespace: "modules",
+		Include:   []string{"runtime", "integration/*"},
+		Exclude:   []string{"runtime/internal*"},
+	}
+
+	assert.True(t, policy.Allows("runtime"))
+	assert.True(t, policy.Allows("runtime/language"))
+	assert.True(t, policy.Allows("integration/mcp/tools"))
+	assert.False(t, policy.Allows("runtime/internal-auth"))
+	assert.False(t, policy.Allows("runtime/internal/auth"))
+	assert.False(t, policy.Allows("integration"))
+	assert.False(t, policy.Allows("security"))
+
+	assert.True(t, LabelPolicy{Namespace: "modules", Exclude: []string{"internal"}}.Allows("security"))
+}
+
+func TestValidateLabelPolicies(t *testing.T) {
+	require.NoError(t, ValidateLabelPolicies([]LabelPolicy{{Namespace: "skills"}, {Namespace: "modules"}}))
+
+	err := ValidateLabelPolicies([]LabelPolicy{{Namespace: "skills"}, {Namespace: "skills", Exclude: []string{"internal"}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate")
+}
+
+func TestMergeLabelPolicies(t *testing.T) {
+	base := []LabelPolicy{
+		{Namespace: "skills", Exclude: []string{"internal"}},
+		{Namespace: "modules", Exclude: []string{"internal"}},
+	}
+	overrides := []LabelPolicy{{Namespace: "modules", Include: []string{"runtime"}}}
+
+	assert.Equal(t, base, MergeLabelPolicies(base, nil))
+	assert.Equal(t, []LabelPolicy{
+		{Namespace: "skills", Exclude: []string{"internal"}},
+		{Namespace: "modules", Include: []string{"runtime"}},
+	}, MergeLabelPolicies(base, overrides))
+}
+
+func TestFilterLabels(t *testing.T) {
+	labels := []Label{"/skills/nlp/summarization", "/modules/internal/auth", "/modules/runtime", "/domains/finance"}
+	policies := []LabelPolicy{{Namespace: "modules", Exclude: []string{"internal"}}}
+
+	assert.Equal(t, labels, FilterLabels(labels, nil))
+	assert.Equal(t, []Label{"/skills/nlp/summarization", "/modules/runtime", "/domains/finance"}, FilterLabels(labels, policies))
+}
@@ -0,0 +1,178 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// labelNamespaceNamePattern restricts custom namespace names so that they are
+// usable as datastore key segments and GossipSub topic names.
+var labelNamespaceNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,62}$`)
+
+// builtinLabelTypes are the label namespaces derived from the OASF record schema.
+var builtinLabelTypes = []LabelType{LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator}
+
+// LabelNamespace describes a custom label namespace, e.g. an organization-specific taxonomy.
+// Labels of custom namespaces are extracted from a record annotation.
+type LabelNamespace struct {
+	// Type is the namespace name. Labels are stored under the /<type>/ prefix.
+	Type LabelType
+
+	// Annotation is the record annotation holding the comma-separated label values,
+	// e.g. "team/platform,team/search" for the annotation "org.example/teams".
+	Annotation string
+
+	// ValidateValue checks a label value without the namespace prefix.
+	// Nil accepts any non-empty value.
+	ValidateValue func(value string) error
+}
+
+// LabelNormalizer maps a label to its canonical form, e.g. "/skills/AI" to "/skills/ai".
+// It reports false for labels that are rejected.
+type LabelNormalizer func(label Label) (Label, bool)
+
+// labelRegistry holds the custom label namespaces and the label normalizer registered at startup.
+var labelRegistry = struct {
+	mu         sync.RWMutex
+	custom     []LabelNamespace
+	normalizer LabelNormalizer
+}{}
+
+// SetLabelNormalizer sets the normalizer applied to the labels of records and to labels
+// received from other peers. Nil disables normalization.
+func SetLabelNormalizer(normalizer LabelNormalizer) {
+	labelRegistry.mu.Lock()
+	defer labelRegistry.mu.Unlock()
+
+	labelRegistry.normalizer = normalizer
+}
+
+// NormalizeLabels returns the canonical forms of the labels in order, without rejected
+// labels and duplicates. Labels are returned unchanged if no normalizer is set.
+func NormalizeLabels(labels []Label) []Label {
+	labelRegistry.mu.RLock()
+	normalizer := labelRegistry.normalizer
+	labelRegistry.mu.RUnlock()
+
+	if normalizer == nil {
+		return labels
+	}
+
+	normalized := make([]Label, 0, len(labels))
+	seen := make(map[Label]struct{}, len(labels))
+
+	for _, label := range labels {
+		canonical, ok := normalizer(label)
+		if !ok {
+			continue
+		}
+
+		if _, dup := seen[canonical]; dup {
+			continue
+		}
+
+		seen[canonical] = struct{}{}
+		normalized = append(normalized, canonical)
+	}
+
+	return normalized
+}
+
+// RegisterLabelNamespace registers a custom label namespace.
+// Registering a namespace again replaces its previous registration.
+// Built-in namespaces cannot be overridden.
+func RegisterLabelNamespace(ns LabelNamespace) error {
+	if !labelNamespaceNamePattern.MatchString(string(ns.Type)) {
+		return fmt.Errorf("invalid label namespace name %q: must match %s", ns.Type, labelNamespaceNamePattern)
+	}
+
+	if slices.Contains(builtinLabelTypes, ns.Type) {
+		return fmt.Errorf("label namespace %q is built-in", ns.Type)
+	}
+
+	if ns.Annotation == "" {
+		return fmt.Errorf("label namespace %q has no annotation", ns.Type)
+	}
+
+	labelRegistry.mu.Lock()
+	defer labelRegistry.mu.Unlock()
+
+	for i, existing := range labelRegistry.custom {
+		if existing.Type == ns.Type {
+			labelRegistry.custom[i] = ns
+
+			return nil
+		}
+	}
+
+	labelRegistry.custom = append(labelRegistry.custom, ns)
+
+	return nil
+}
+
+// CustomLabelNamespaces returns the registered custom label namespaces in registration order.
+func CustomLabelNamespaces() []LabelNamespace {
+	labelRegistry.mu.RLock()
+	defer labelRegistry.mu.RUnlock()
+
+	return slices.Clone(labelRegistry.custom)
+}
+
+// LookupLabelNamespace returns the custom label namespace with the given type.
+func LookupLabelNamespace(lt LabelType) (LabelNamespace, bool) {
+	labelRegistry.mu.RLock()
+	defer labelRegistry.mu.RUnlock()
+
+	for _, ns := range labelRegistry.custom {
+		if ns.Type == lt {
+			return ns, true
+		}
+	}
+
+	return LabelNamespace{}, false
+}
+
+// ValidateLabel checks a label value of the namespace, without the namespace prefix.
+func (ns LabelNamespace) ValidateLabel(value string) error {
+	if value == "" {
+		return errors.New("label value cannot be empty")
+	}
+
+	if ns.ValidateValue != nil {
+		return ns.ValidateValue(value)
+	}
+
+	return nil
+}
+
+// customLabelsFromAnnotations extracts labels of the custom namespaces from record annotations.
+// Invalid values are skipped.
+func customLabelsFromAnnotations(annotations map[string]string) []Label {
+	var labels []Label
+
+	for _, ns := range CustomLabelNamespaces() {
+		raw, ok := annotations[ns.Annotation]
+		if !ok {
+			continue
+		}
+
+		for _, value := range strings.Split(raw, ",") {
+			value = strings.Trim(strings.TrimSpace(value), "/")
+
+			if err := ns.ValidateLabel(value); err != nil {
+				continue
+			}
+
+			labels = append(labels, Label(ns.Type.Prefix()+value))
+		}
+	}
+
+	return labels
+}
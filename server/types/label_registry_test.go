@@ -0,0 +1,137 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetLabelNamespaces removes all custom label namespaces registered by a test.
+func resetLabelNamespaces(t *testing.T) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		labelRegistry.mu.Lock()
+		defer labelRegistry.mu.Unlock()
+
+		labelRegistry.custom = nil
+	})
+}
+
+type testRecordData struct {
+	RecordData
+
+	annotations map[string]string
+}
+
+func (d *testRecordData) GetAnnotations() map[string]string {
+	return d.annotations
+}
+
+type testRecord struct {
+	data *testRecordData
+}
+
+func (r *testRecord) GetCid() string {
+	return "cid"
+}
+
+func (r *testRecord) GetRecordData() (RecordData, error) {
+	return r.data, nil
+}
+
+func TestRegisterLabelNamespace(t *testing.T) {
+	resetLabelNamespaces(t)
+
+	teams := LabelNamespace{Type: "teams", Annotation: "org.example/teams"}
+	require.NoError(t, RegisterLabelNamespace(teams))
+
+	assert.True(t, LabelType("teams").IsValid())
+	assert.Equal(t, []LabelType{LabelTypeSkill, LabelTypeDomain, LabelTypeModule, LabelTypeLocator, "teams"}, AllLabelTypes())
+	assert.Equal(t, LabelType("teams"), Label("/teams/platform/search").Type())
+	assert.Equal(t, "platform/search", Label("/teams/platform/search").Value())
+
+	lt, ok := ParseLabelType("teams")
+	assert.True(t, ok)
+	assert.Equal(t, LabelType("teams"), lt)
+
+	// Registering again replaces the previous registration
+	require.NoError(t, RegisterLabelNamespace(LabelNamespace{Type: "teams", Annotation: "org.example/owners"}))
+
+	registered, ok := LookupLabelNamespace("teams")
+	assert.True(t, ok)
+	assert.Equal(t, "org.example/owners", registered.Annotation)
+	assert.Len(t, CustomLabelNamespaces(), 1)
+}
+
+func TestRegisterLabelNamespace_Invalid(t *testing.T) {
+	resetLabelNamespaces(t)
+
+	tests := []struct {
+		name      string
+		namespace LabelNamespace
+	}{
+		{"built-in namespace", LabelNamespace{Type: LabelTypeSkill, Annotation: "a"}},
+		{"empty name", LabelNamespace{Type: "", Annotation: "a"}},
+		{"uppercase name", LabelNamespace{Type: "Teams", Annotation: "a"}},
+		{"name with slash", LabelNamespace{Type: "teams/x", Annotation: "a"}},
+		{"missing annotation", LabelNamespace{Type: "teams"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, RegisterLabelNamespace(tt.namespace))
+		})
+	}
+
+	assert.Empty(t, CustomLabelNamespaces())
+	assert.False(t, LabelType("teams").IsValid())
+}
+
+func TestGetLabelsFromRecord_CustomNamespaces(t *testing.T) {
+	resetLabelNamespaces(t)
+
+	require.NoError(t, RegisterLabelNamespace(LabelNamespace{
+		Type:       "teams",
+		Annotation: "org.example/teams",
+		ValidateValue: func(value string) error {
+			if !strings.HasPrefix(value, "platform") {
+				return errors.New("unknown team")
+			}
+
+			return nil
+		},
+	}))
+
+	record := &testRecord{data: &testRecordData{annotations: map[string]string{
+		"org.example/teams": "platform/search, /platform/storage/ ,marketing,,",
+		"unrelated":         "value",
+	}}}
+
+	assert.Equal(t, []Label{"/teams/platform/search", "/teams/platform/storage"}, GetLabelsFromRecord(record))
+}
+
+func TestNormalizeLabels(t *testing.T) {
+	t.Cleanup(func() { SetLabelNormalizer(nil) })
+
+	labels := []Label{"/skills/AI", "/skills/ai", "/domains/unknown", "/modules/a"}
+
+	// Labels are unchanged without a normalizer
+	assert.Equal(t, labels, NormalizeLabels(labels))
+
+	SetLabelNormalizer(func(label Label) (Label, bool) {
+		if label.Type() == LabelTypeDomain {
+			return "", false
+		}
+
+		return Label(strings.ToLower(label.String())), true
+	})
+
+	assert.Equal(t, []Label{"/skills/ai", "/modules/a"}, NormalizeLabels(labels))
+}
@@ -4,7 +4,9 @@
 package types_test
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	corev1 "github.com/agntcy/dir/api/core/v1"
 	"github.com/agntcy/dir/server/types"
@@ -151,3 +153,28 @@ func TestGetLabelsFromRecord(t *testing.T) {
 		assert.Nil(t, labels)
 	})
 }
+
+func TestNewRecordSummary(t *testing.T) {
+	// Multibyte runes straddle the truncation limit
+	description := strings.Repeat("é", types.MaxRecordSummaryFieldLength)
+
+	record, err := corev1.UnmarshalRecord([]byte(`{
+		"name": "test-agent",
+		"version": "1.0.0",
+		"description": "` + description + `",
+		"schema_version": "v0.3.1",
+		"authors": ["test"],
+		"created_at": "2023-01-01T00:00:00Z"
+	}`))
+	require.NoError(t, err)
+
+	summary := types.NewRecordSummary(adapters.NewRecordAdapter(record))
+	assert.Equal(t, "test-agent", summary.Name)
+	assert.Equal(t, "1.0.0", summary.Version)
+	assert.Len(t, summary.Description, types.MaxRecordSummaryFieldLength)
+	assert.True(t, utf8.ValidString(summary.Description))
+	require.NoError(t, summary.Validate())
+
+	summary.Name = strings.Repeat("a", types.MaxRecordSummaryFieldLength+1)
+	assert.Error(t, summary.Validate())
+}
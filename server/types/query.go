@@ -0,0 +1,163 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strconv"
+	"strings"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+)
+
+// labelTypeAliases maps alternative spellings of the built-in namespaces
+// in generic label queries to their canonical label type.
+var labelTypeAliases = map[string]LabelType{
+	"skill":   LabelTypeSkill,
+	"domain":  LabelTypeDomain,
+	"module":  LabelTypeModule,
+	"locator": LabelTypeLocator,
+}
+
+// labelTypeQueryTypes maps the namespaces of generic label queries to the typed
+// query with the same matching semantics. Locators are not included, as typed
+// locator queries only match exactly.
+var labelTypeQueryTypes = map[LabelType]routingv1.RecordQueryType{
+	LabelTypeSkill:  routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL,
+	LabelTypeDomain: routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN,
+	LabelTypeModule: routingv1.RecordQueryType_RECORD_QUERY_TYPE_MODULE,
+}
+
+// CanonicalQuery returns the canonical form of a query, so that logically
+// identical queries written differently compare equal:
+//   - Values are trimmed of surrounding whitespace and slashes
+//   - Namespace aliases of generic label queries are expanded (e.g. "skill/AI" to "skills/AI")
+//     and namespaces are lowercased
+//   - Generic label queries of the skills, domains and modules namespaces become typed queries
+//   - Nested AND/OR groups with the same operator are flattened, duplicate sub-queries
+//     are removed, sub-queries are sorted, and single-query AND/OR groups are unwrapped
+//
+// The input query is not modified.
+func CanonicalQuery(query *routingv1.RecordQuery) *routingv1.RecordQuery {
+	if query == nil {
+		return nil
+	}
+
+	if group := query.GetGroup(); group != nil {
+		return canonicalGroup(group)
+	}
+
+	queryType := query.GetType()
+	value := strings.Trim(strings.TrimSpace(query.GetValue()), "/")
+
+	if queryType == routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL {
+		namespace, rest, _ := strings.Cut(value, "/")
+
+		labelType := LabelType(strings.ToLower(strings.TrimSpace(namespace)))
+		if alias, ok := labelTypeAliases[string(labelType)]; ok {
+			labelType = alias
+		}
+
+		switch typed, ok := labelTypeQueryTypes[labelType]; {
+		case ok && rest != "":
+			queryType, value = typed, rest
+		case rest != "":
+			value = labelType.String() + "/" + rest
+		default:
+			value = labelType.String()
+		}
+	}
+
+	return &routingv1.RecordQuery{
+		Type:      queryType,
+		Value:     value,
+		MatchMode: query.GetMatchMode(),
+	}
+}
+
+func canonicalGroup(group *routingv1.RecordQueryGroup) *routingv1.RecordQuery {
+	operator := group.GetOperator()
+	flatten := operator == routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND ||
+		operator == routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR
+
+	seen := make(map[string]bool)
+
+	var (
+		queries []*routingv1.RecordQuery
+		keys    = make(map[*routingv1.RecordQuery]string)
+	)
+
+	var add func(query *routingv1.RecordQuery)
+	add = func(query *routingv1.RecordQuery) {
+		// Sub-groups with the same associative operator are merged into the group
+		if sub := query.GetGroup(); flatten && sub != nil && sub.GetOperator() == operator {
+			for _, q := range sub.GetQueries() {
+				add(q)
+			}
+
+			return
+		}
+
+		key := canonicalKey(query)
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+		keys[query] = key
+		queries = append(queries, query)
+	}
+
+	for _, query := range group.GetQueries() {
+		if query != nil {
+			add(CanonicalQuery(query))
+		}
+	}
+
+	// Sub-queries of all operators are commutative
+	slices.SortFunc(queries, func(a, b *routingv1.RecordQuery) int {
+		return strings.Compare(keys[a], keys[b])
+	})
+
+	if flatten && len(queries) == 1 {
+		return queries[0]
+	}
+
+	return &routingv1.RecordQuery{
+		Group: &routingv1.RecordQueryGroup{
+			Operator: operator,
+			Queries:  queries,
+		},
+	}
+}
+
+// canonicalKey serializes a query that is already in canonical form.
+func canonicalKey(query *routingv1.RecordQuery) string {
+	group := query.GetGroup()
+	if group == nil {
+		key := query.GetType().String() + ":" + strconv.Quote(query.GetValue())
+		if mode := query.GetMatchMode(); mode != routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_UNSPECIFIED {
+			key += ":" + mode.String()
+		}
+
+		return key
+	}
+
+	keys := make([]string, 0, len(group.GetQueries()))
+	for _, sub := range group.GetQueries() {
+		keys = append(keys, canonicalKey(sub))
+	}
+
+	return group.GetOperator().String() + "(" + strings.Join(keys, ",") + ")"
+}
+
+// QueryHash returns a stable hash of the canonical form of a query.
+// Logically identical queries have the same hash.
+func QueryHash(query *routingv1.RecordQuery) string {
+	sum := sha256.Sum256([]byte(canonicalKey(CanonicalQuery(query))))
+
+	return hex.EncodeToString(sum[:16])
+}
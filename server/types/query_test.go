@@ -0,0 +1,117 @@
+// Copyright AGNTCY Contributors (https://github.com/agntcy)
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"testing"
+
+	routingv1 "github.com/agntcy/dir/api/routing/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func leafQuery(queryType routingv1.RecordQueryType, value string) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Type: queryType, Value: value}
+}
+
+func groupQuery(operator routingv1.RecordQueryOperator, queries ...*routingv1.RecordQuery) *routingv1.RecordQuery {
+	return &routingv1.RecordQuery{Group: &routingv1.RecordQueryGroup{Operator: operator, Queries: queries}}
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	skill := leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI/ML")
+	domain := leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, "research")
+
+	testCases := []struct {
+		name     string
+		query    *routingv1.RecordQuery
+		expected *routingv1.RecordQuery
+	}{
+		{
+			name:     "trims value",
+			query:    leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, " /AI/ML/ "),
+			expected: skill,
+		},
+		{
+			name:     "label of built-in namespace becomes typed query",
+			query:    leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "skills/AI/ML"),
+			expected: skill,
+		},
+		{
+			name:     "namespace alias is expanded",
+			query:    leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "/Skill/AI/ML"),
+			expected: skill,
+		},
+		{
+			name:     "locator labels keep prefix matching",
+			query:    leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "locator/docker-image"),
+			expected: leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "locators/docker-image"),
+		},
+		{
+			name:     "whole namespace label is kept",
+			query:    leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "skills"),
+			expected: leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "skills"),
+		},
+		{
+			name:     "custom namespace is lowercased",
+			query:    leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "Teams/Platform"),
+			expected: leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "teams/Platform"),
+		},
+		{
+			name: "nested groups are flattened, deduplicated and sorted",
+			query: groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+				skill,
+				groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, domain, skill)),
+			expected: groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND, domain, skill),
+		},
+		{
+			name:     "single query groups are unwrapped",
+			query:    groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR, skill, skill),
+			expected: skill,
+		},
+		{
+			name: "NOT groups are kept",
+			query: groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT,
+				groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, skill)),
+			expected: groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT,
+				groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_NOT, skill)),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := CanonicalQuery(tc.query)
+			assert.True(t, proto.Equal(tc.expected, actual), "expected %v, got %v", tc.expected, actual)
+		})
+	}
+}
+
+func TestQueryHash(t *testing.T) {
+	a := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR,
+		leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI"),
+		leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, "research"))
+	b := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR,
+		leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "domain/research/"),
+		leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_LABEL, "skills/AI"))
+	c := groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_AND,
+		leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI"),
+		leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_DOMAIN, "research"))
+
+	assert.Equal(t, QueryHash(a), QueryHash(b))
+	assert.NotEqual(t, QueryHash(a), QueryHash(c))
+
+	// Hierarchical queries differ from the same queries matching descendants only
+	hierarchical := leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI")
+	hierarchical.MatchMode = routingv1.RecordQueryMatchMode_RECORD_QUERY_MATCH_MODE_HIERARCHICAL
+
+	assert.NotEqual(t, QueryHash(hierarchical), QueryHash(leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "AI")))
+	assert.Equal(t, hierarchical.GetMatchMode(), CanonicalQuery(hierarchical).GetMatchMode())
+
+	// Values with separators cannot collide
+	assert.NotEqual(t,
+		QueryHash(leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, `a",SKILL:"b`)),
+		QueryHash(groupQuery(routingv1.RecordQueryOperator_RECORD_QUERY_OPERATOR_OR,
+			leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "a"),
+			leafQuery(routingv1.RecordQueryType_RECORD_QUERY_TYPE_SKILL, "b"))))
+}
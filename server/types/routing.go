@@ -5,6 +5,8 @@ package types
 
 import (
 	"context"
+	"io"
+	"time"
 
 	routingv1 "github.com/agntcy/dir/api/routing/v1"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -18,23 +20,217 @@ type RoutingAPI interface {
 	// The caller must wrap concrete record types (e.g. *corev1.Record) with adapters.NewRecordAdapter()
 	Publish(context.Context, Record) error
 
+	// ValidateRecords validates the records of the local store with the enabled record validation rules
+	// before they are published. Returns an InvalidArgument error with a RecordValidationFailure detail
+	// per invalid record.
+	ValidateRecords(ctx context.Context, cids []string) error
+
+	// PublishBatch publishes many records to the network at once,
+	// coalescing network announcements where possible.
+	// Returns one error per record in input order (nil on success).
+	// The caller must wrap concrete record types (e.g. *corev1.Record) with adapters.NewRecordAdapter()
+	PublishBatch(context.Context, []Record, PublishOptions) []error
+
 	// List all records that this peer is currently providing (local-only operation)
 	List(context.Context, *routingv1.ListRequest) (<-chan *routingv1.ListResponse, error)
 
 	// Search for records across the network using cached remote announcements
 	Search(context.Context, *routingv1.SearchRequest) (<-chan *routingv1.SearchResponse, error)
 
+	// EstimateResults estimates how many records Search would return (local-only operation)
+	EstimateResults(context.Context, *routingv1.SearchRequest) (*routingv1.EstimateResultsResponse, error)
+
+	// Retract withdraws the announcements of a record deleted from the local store, if it was published
+	Retract(context.Context, string) error
+
 	// Unpublish record from the network
 	// The caller must wrap concrete record types (e.g. *corev1.Record) with adapters.NewRecordAdapter()
 	Unpublish(context.Context, Record) error
 
+	// GetStats returns statistics about remote peers (local-only operation)
+	GetStats(context.Context, *routingv1.GetStatsRequest) (*routingv1.GetStatsResponse, error)
+
+	// Pin records so that cached labels of remote records are kept, optionally mirroring their content locally,
+	// and local records are always reprovided and never expired or garbage collected
+	Pin(context.Context, *routingv1.PinRequest) error
+
+	// Unpin records, subjecting their cached labels to cleanup and local records to expiry and garbage collection again
+	Unpin(context.Context, *routingv1.UnpinRequest) error
+
+	// OnCollect registers a hook notified of each local record garbage collected from the store under disk pressure
+	OnCollect(DeleteHook)
+
+	// ListPins lists the pinned records (local-only operation)
+	ListPins(context.Context, *routingv1.ListPinsRequest) ([]*routingv1.ListPinsResponse, error)
+
+	// Readiness reports which routing functionality is available (local-only operation)
+	Readiness() RoutingReadiness
+
+	// VerifyCache verifies a sample of the cached remote labels against their providers, optionally evicting unavailable records
+	VerifyCache(context.Context, *routingv1.VerifyCacheRequest) (*routingv1.VerifyCacheResponse, error)
+
+	// SetProfile switches the discovery profile of this node until it is restarted
+	SetProfile(context.Context, *routingv1.SetProfileRequest) (*routingv1.SetProfileResponse, error)
+
+	// GetHistory returns the retained discovery history of this node
+	GetHistory(context.Context, *routingv1.GetHistoryRequest) (*routingv1.GetHistoryResponse, error)
+
+	// GrantAccess issues an access token authorizing a remote peer to pull access-gated records of this node
+	GrantAccess(context.Context, *routingv1.GrantAccessRequest) (*routingv1.GrantAccessResponse, error)
+
+	// Subscribe sends the remote records matching the queries as their labels are newly cached, until the context is done
+	Subscribe(context.Context, *routingv1.SubscribeRequest, func(*routingv1.SearchResponse) error) error
+
+	// GetTaxonomy returns the values of the label taxonomy labels are normalized to (local-only operation)
+	GetTaxonomy(context.Context, *routingv1.GetTaxonomyRequest) (*routingv1.GetTaxonomyResponse, error)
+
+	// GetProvenance returns the logged announcements and current providers of a record (local-only operation)
+	GetProvenance(context.Context, *routingv1.GetProvenanceRequest) (*routingv1.GetProvenanceResponse, error)
+
+	// ExportState writes the label cache, and optionally the records it references, as a portable archive
+	ExportState(context.Context, *routingv1.ExportStateRequest, io.Writer) error
+
+	// ImportState imports a portable archive exported by another node
+	ImportState(context.Context, io.Reader) (*routingv1.ImportStateResponse, error)
+
+	// RoutingAdminAPI exposes the internal state of routing for debugging
+	RoutingAdminAPI
+
 	// Stop stops the routing services and releases resources
 	// Should be called during server shutdown for graceful cleanup
 	Stop() error
 }
 
+// RoutingAdminAPI exposes the internal state of the routing layer (local-only operations,
+// read-only except for backfills).
+type RoutingAdminAPI interface {
+	// GetRoutingTable dumps the DHT routing table
+	GetRoutingTable(context.Context, *routingv1.GetRoutingTableRequest) (*routingv1.GetRoutingTableResponse, error)
+
+	// GetGossipSubState returns the peers and the mesh of each joined GossipSub topic
+	GetGossipSubState(context.Context, *routingv1.GetGossipSubStateRequest) (*routingv1.GetGossipSubStateResponse, error)
+
+	// GetGossipSubDiagnostics returns the message rates of each joined GossipSub topic,
+	// the scores of connected peers and the propagation latency of announcements
+	GetGossipSubDiagnostics(context.Context, *routingv1.GetGossipSubDiagnosticsRequest) (*routingv1.GetGossipSubDiagnosticsResponse, error)
+
+	// GetLabelCacheStats returns statistics of the label cache per label namespace
+	GetLabelCacheStats(context.Context, *routingv1.GetLabelCacheStatsRequest) (*routingv1.GetLabelCacheStatsResponse, error)
+
+	// GetQueueState returns the depth of the internal queues of routing
+	GetQueueState(context.Context, *routingv1.GetQueueStateRequest) (*routingv1.GetQueueStateResponse, error)
+
+	// GetTaskStatus returns the schedules and last runs of the background tasks of routing
+	GetTaskStatus(context.Context, *routingv1.GetTaskStatusRequest) (*routingv1.GetTaskStatusResponse, error)
+
+	// StartBackfill starts rebuilding the remote label cache from a crawl of the DHT
+	StartBackfill(context.Context, *routingv1.StartBackfillRequest) (*routingv1.StartBackfillResponse, error)
+
+	// GetBackfillStatus returns the progress of the running or the last backfill
+	GetBackfillStatus(context.Context, *routingv1.GetBackfillStatusRequest) (*routingv1.GetBackfillStatusResponse, error)
+
+	// GetBandwidthUsage returns the record content served by Pull to each peer per hour
+	GetBandwidthUsage(context.Context, *routingv1.GetBandwidthUsageRequest) (*routingv1.GetBandwidthUsageResponse, error)
+
+	// SnapshotDatastore writes a point-in-time archive of the routing datastore
+	SnapshotDatastore(context.Context, io.Writer) error
+
+	// RestoreDatastore writes the entries of a datastore archive into the routing datastore
+	RestoreDatastore(context.Context, io.Reader) (*routingv1.RestoreDatastoreResponse, error)
+
+	// PromoteFollower promotes this follower to announce the records of its primary
+	PromoteFollower(context.Context, *routingv1.PromoteFollowerRequest) (*routingv1.PromoteFollowerResponse, error)
+
+	// ListPinnedRecords lists the pinned records and the state of the garbage collection of local records
+	ListPinnedRecords(context.Context, *routingv1.ListPinnedRecordsRequest) (*routingv1.ListPinnedRecordsResponse, error)
+}
+
+// PublishOptions controls how records are announced to the network.
+type PublishOptions struct {
+	// Priority of the announcement. Unspecified is treated as normal.
+	Priority routingv1.AnnouncementPriority
+
+	// TTL after which the record is no longer announced and is dropped by remote peers.
+	// Zero means the record does not expire.
+	TTL time.Duration
+
+	// Supersedes is the CID of the previous version of the record, if any.
+	Supersedes string
+
+	// AccessGated announces the record without serving its content to unauthorized peers.
+	AccessGated bool
+
+	// Tenant the record is published for. Its labels are stored and announced under
+	// the tenant's namespace. Empty for untenanted records.
+	Tenant string
+
+	// Publisher is the did:key identifier of the publisher the record is published as,
+	// whose key must be configured on this peer. Empty publishes it as this peer.
+	Publisher string
+
+	// LabelPolicies override the configured publish policy for their namespaces,
+	// withholding labels of the record from its announcements.
+	LabelPolicies []LabelPolicy
+
+	// Progress, if set, receives the progress of each record through the publish stages.
+	// It may be called concurrently.
+	Progress func(*routingv1.PublishProgress)
+}
+
+// RoutingReadiness reports which routing functionality is available.
+// Local operations are available as soon as routing starts, while the DHT bootstraps in the background.
+type RoutingReadiness struct {
+	// Bootstrapped is true once the bootstrap peers were dialled and the DHT routing table was refreshed.
+	Bootstrapped bool `json:"bootstrapped"`
+
+	// Peers is the number of peers in the DHT routing table.
+	Peers int `json:"peers"`
+
+	// PendingAnnouncements is the number of published records waiting for peers to be announced to.
+	PendingAnnouncements int `json:"pending_announcements"`
+
+	// MinPeers is the number of routing table peers required to report ready.
+	MinPeers int `json:"min_peers"`
+
+	// GossipSub is true if label announcements are exchanged over GossipSub.
+	GossipSub bool `json:"gossipsub"`
+
+	// TopicPeers is the number of peers subscribed to the GossipSub label topics.
+	TopicPeers int `json:"topic_peers"`
+
+	// DatastoreWritable is false while writes to the routing datastore fail and are buffered.
+	DatastoreWritable bool `json:"datastore_writable"`
+}
+
+// NetworkReady reports whether records are announced to and discovered from the network.
+func (r RoutingReadiness) NetworkReady() bool {
+	return r.Bootstrapped && r.Peers > 0
+}
+
+// DHTReady reports whether the DHT bootstrapped with at least MinPeers peers in its routing table.
+func (r RoutingReadiness) DHTReady() bool {
+	return r.Bootstrapped && r.Peers >= r.MinPeers
+}
+
+// GossipSubReady reports whether a GossipSub mesh formed, i.e. peers subscribed to the label topics.
+// It is ready if GossipSub is disabled or no peers are required.
+func (r RoutingReadiness) GossipSubReady() bool {
+	return !r.GossipSub || r.MinPeers == 0 || r.TopicPeers > 0
+}
+
+// Ready reports whether all routing subsystems are ready.
+func (r RoutingReadiness) Ready() bool {
+	return r.DHTReady() && r.GossipSubReady() && r.DatastoreWritable
+}
+
+// MinRecordTTL is the shortest TTL a record may be published with.
+const MinRecordTTL = time.Minute
+
 // PublicationAPI handles management of publication tasks.
 type PublicationAPI interface {
 	// CreatePublication creates a new publication task to be processed.
 	CreatePublication(context.Context, *routingv1.PublishRequest) (string, error)
+
+	// Publish runs a publication synchronously, reporting the progress of each record.
+	Publish(context.Context, *routingv1.PublishRequest, func(*routingv1.PublishProgress)) error
 }
@@ -28,6 +28,9 @@ type StoreAPI interface {
 	// List(context.Context, func(*corev1.RecordRef) error) error
 }
 
+// DeleteHook is notified with the CID of each record deleted from the store.
+type DeleteHook func(ctx context.Context, cid string) error
+
 // ReferrerStoreAPI handles management of generic record referrers.
 type ReferrerStoreAPI interface {
 	// Push referrer to content store